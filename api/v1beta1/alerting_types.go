@@ -6,6 +6,10 @@ Licensed under the MIT License.
 
 package v1beta1
 
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
 // RetentionSpec defines retention configuration for logs and traces
 type RetentionSpec struct {
 	// Period defines how long to retain data
@@ -121,6 +125,16 @@ type Route struct {
 	// Matchers is a list of matchers for this route
 	// +optional
 	Matchers []Matcher `json:"matchers,omitempty"`
+
+	// MuteTimeIntervals are the names of the spec.alerting.muteTimeIntervals
+	// during which the route's notifications are muted
+	// +optional
+	MuteTimeIntervals []string `json:"muteTimeIntervals,omitempty"`
+
+	// ActiveTimeIntervals are the names of the spec.alerting.muteTimeIntervals
+	// outside of which the route's notifications are muted
+	// +optional
+	ActiveTimeIntervals []string `json:"activeTimeIntervals,omitempty"`
 }
 
 // Matcher defines a matcher for routes
@@ -384,3 +398,77 @@ type InhibitRule struct {
 	// Equal is the list of labels that must be equal
 	Equal []string `json:"equal"`
 }
+
+// MuteTimeInterval names recurring time intervals, such as maintenance
+// windows or the hours outside of on-call. Routes reference it by name in
+// muteTimeIntervals or activeTimeIntervals.
+type MuteTimeInterval struct {
+	// Name is referenced by routes
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	Name string `json:"name"`
+
+	// TimeIntervals are matched if any of them matches
+	// +kubebuilder:validation:MinItems=1
+	TimeIntervals []TimeInterval `json:"timeIntervals"`
+}
+
+// TimeInterval matches the times within all of its set fields. An empty
+// field matches any time.
+type TimeInterval struct {
+	// Times are ranges of the day
+	// +optional
+	Times []TimeRange `json:"times,omitempty"`
+
+	// Weekdays are days or ranges of days, e.g. monday:friday
+	// +optional
+	Weekdays []string `json:"weekdays,omitempty"`
+
+	// DaysOfMonth are days or ranges of days, e.g. 1:5; negative days count
+	// from the end of the month, e.g. -1 is the last day
+	// +optional
+	DaysOfMonth []string `json:"daysOfMonth,omitempty"`
+
+	// Months are months or ranges of months, by name or number, e.g. january:march
+	// +optional
+	Months []string `json:"months,omitempty"`
+
+	// Years are years or ranges of years, e.g. 2025:2026
+	// +optional
+	Years []string `json:"years,omitempty"`
+
+	// Location is the IANA time zone of the interval, e.g. Europe/Berlin
+	// +kubebuilder:default="UTC"
+	// +optional
+	Location string `json:"location,omitempty"`
+}
+
+// TimeRange is a range of the day
+type TimeRange struct {
+	// StartTime is the inclusive start, e.g. 09:00
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+
+	// EndTime is the exclusive end, e.g. 17:00; 24:00 is the end of the day
+	// +kubebuilder:validation:Pattern=`^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$`
+	EndTime string `json:"endTime"`
+}
+
+// RunbookRegistry maps runbook keys to team documentation
+type RunbookRegistry struct {
+	// BaseURL is prepended to relative runbook entries
+	// +optional
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// Entries maps runbook keys to absolute URLs or paths relative to BaseURL
+	// +optional
+	Entries map[string]string `json:"entries,omitempty"`
+
+	// ConfigMapRef references a ConfigMap in the platform namespace whose
+	// keys are merged into Entries. Entries take precedence.
+	// +optional
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+
+	// DefaultKey is used for alerts without a matching runbook
+	// +optional
+	DefaultKey string `json:"defaultKey,omitempty"`
+}
@@ -20,6 +20,9 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/gunjanjp/gunj-operator/internal/promqlcheck"
+	"github.com/gunjanjp/gunj-operator/internal/querycheck"
 )
 
 // log is for logging in this package.
@@ -340,6 +343,11 @@ func (r *AlertingRule) validateAlertingRule() (admission.Warnings, error) {
 				allErrs = append(allErrs, field.Required(
 					rulePath.Child("expr"),
 					"expression is required"))
+			} else if err := promqlcheck.Check(rule.Expr); err != nil {
+				allErrs = append(allErrs, field.Invalid(
+					rulePath.Child("expr"),
+					rule.Expr,
+					err.Error()))
 			}
 
 			// Validate for duration
@@ -449,6 +457,11 @@ func (r *AlertingRule) validateAlertingRule() (admission.Warnings, error) {
 				allErrs = append(allErrs, field.Required(
 					rulePath.Child("expr"),
 					"expression is required"))
+			} else if err := querycheck.LogQLMetric(rule.Expr); err != nil {
+				allErrs = append(allErrs, field.Invalid(
+					rulePath.Child("expr"),
+					rule.Expr,
+					err.Error()))
 			}
 
 			// Validate for duration
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GrafanaAlloySpec configures Grafana Alloy as the collection layer of the
+// platform. Alloy runs on every node, collects metrics, logs, traces and
+// profiles and sends them to the platform's backends. Its configuration is
+// generated from the enabled pipelines.
+type GrafanaAlloySpec struct {
+	// Enabled determines if Grafana Alloy is deployed
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Version of Grafana Alloy to deploy
+	// +kubebuilder:validation:Pattern=`^v?\d+\.\d+\.\d+(-[a-zA-Z0-9]+)?$`
+	// +kubebuilder:default="v1.5.1"
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Resources of the Alloy container on each node
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Metrics scrapes pods annotated prometheus.io/scrape and remote writes
+	// the samples to the platform's Prometheus
+	// +optional
+	Metrics *AlloyPipelineSpec `json:"metrics,omitempty"`
+
+	// Logs tails container logs and pushes them to the platform's Loki
+	// +optional
+	Logs *AlloyPipelineSpec `json:"logs,omitempty"`
+
+	// Traces receives OTLP traces and exports them to the platform's Tempo
+	// +optional
+	Traces *AlloyPipelineSpec `json:"traces,omitempty"`
+
+	// Profiles scrapes pods annotated profiles.grafana.com/cpu.scrape and
+	// pushes the profiles to the platform's Pyroscope or another Pyroscope
+	// compatible endpoint
+	// +optional
+	Profiles *AlloyPipelineSpec `json:"profiles,omitempty"`
+
+	// ExtraConfig is Alloy configuration appended to the generated
+	// configuration. It may forward to the generated receivers, e.g.
+	// prometheus.remote_write.platform.receiver.
+	// +optional
+	ExtraConfig string `json:"extraConfig,omitempty"`
+
+	// ComponentLogging sets the log level of Alloy and turns on debug mode.
+	// A logging block of ExtraConfig takes precedence.
+	ComponentLogging `json:",inline"`
+}
+
+// AlloyPipelineSpec configures the collection of one signal
+type AlloyPipelineSpec struct {
+	// Enabled determines if the signal is collected. Defaults to true when
+	// the platform runs the backend of the signal or Endpoint is set.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Namespaces restricts the discovery of targets to these namespaces.
+	// Targets in all namespaces are discovered if empty.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Endpoint overrides the URL the signal is sent to
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// IsEnabled returns true if the signal is collected, where backend reports
+// whether the platform runs the backend of the signal
+func (p *AlloyPipelineSpec) IsEnabled(backend bool) bool {
+	if p == nil {
+		return backend
+	}
+	if p.Enabled != nil {
+		return *p.Enabled
+	}
+	return backend || p.Endpoint != ""
+}
+
+// GetVersion returns the Alloy version, v1.5.1 if not set
+func (s *GrafanaAlloySpec) GetVersion() string {
+	if s.Version == "" {
+		return "v1.5.1"
+	}
+	return s.Version
+}
+
+// AlloyEnabled returns true if Grafana Alloy is deployed
+func (c *Components) AlloyEnabled() bool {
+	return c != nil && c.GrafanaAlloy != nil && c.GrafanaAlloy.Enabled
+}
+
+// AlloyCollectsMetrics returns true if Alloy remote writes metrics to the
+// platform's Prometheus, which must then accept remote writes
+func (c *Components) AlloyCollectsMetrics() bool {
+	return c.AlloyEnabled() && c.GrafanaAlloy.Metrics.IsEnabled(c.Prometheus != nil && c.Prometheus.Enabled) &&
+		c.GrafanaAlloy.Metrics.GetEndpoint() == ""
+}
+
+// GetEndpoint returns the endpoint the signal is sent to, empty for the
+// platform's backend
+func (p *AlloyPipelineSpec) GetEndpoint() string {
+	if p == nil {
+		return ""
+	}
+	return p.Endpoint
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Scopes of an ApiKey
+const (
+	// ApiKeyScopeIngestMetrics writes metrics through the ingest gateway
+	ApiKeyScopeIngestMetrics = "ingest-metrics"
+	// ApiKeyScopeIngestLogs writes logs through the ingest gateway
+	ApiKeyScopeIngestLogs = "ingest-logs"
+	// ApiKeyScopeIngestTraces writes traces through the ingest gateway
+	ApiKeyScopeIngestTraces = "ingest-traces"
+	// ApiKeyScopeQuery queries the platform's backends through the API
+	// server, restricted to the key's tenant. Queries are refused where
+	// the backend can't keep the tenant apart.
+	ApiKeyScopeQuery = "query"
+	// ApiKeyScopeTenant reads the platform's state through the API server
+	ApiKeyScopeTenant = "tenant"
+)
+
+// Phases of an ApiKey
+const (
+	ApiKeyPhaseActive  = "Active"
+	ApiKeyPhaseExpired = "Expired"
+	ApiKeyPhaseRevoked = "Revoked"
+)
+
+// DefaultApiKeyRotationGracePeriod is how long the previous key is accepted
+// after a rotation
+const DefaultApiKeyRotationGracePeriod = 24 * time.Hour
+
+// ApiKeySpec defines an API key of a platform
+type ApiKeySpec struct {
+	// Platform is the name of the ObservabilityPlatform in the namespace of
+	// the key
+	// +kubebuilder:validation:MinLength=1
+	Platform string `json:"platform"`
+
+	// Tenant the key writes and queries as, sent to the backends as the
+	// X-Scope-OrgID header
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_.-]+$`
+	Tenant string `json:"tenant"`
+
+	// Scopes granted to the key
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Enum=ingest-metrics;ingest-logs;ingest-traces;query;tenant
+	Scopes []string `json:"scopes"`
+
+	// Description of what the key is used for
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// ExpiresAt is when the key stops being accepted
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// Revoked stops accepting the key immediately
+	// +optional
+	Revoked bool `json:"revoked,omitempty"`
+
+	// Rotation issues a new key whenever it is increased. The previous key
+	// is accepted for the rotation grace period.
+	// +optional
+	Rotation int64 `json:"rotation,omitempty"`
+
+	// RotationGracePeriod is how long the previous key is accepted after a
+	// rotation
+	// +kubebuilder:default="24h"
+	// +optional
+	RotationGracePeriod *metav1.Duration `json:"rotationGracePeriod,omitempty"`
+
+	// SecretName is the Secret the operator writes a new key to. The
+	// operator only keeps the hash of the key: copy the key and delete the
+	// Secret, or mount it in the producer. Defaults to <name>-api-key.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// ApiKeyCredential is a key accepted for an ApiKey, identified by its hash
+type ApiKeyCredential struct {
+	// ID is the public part of the key, to tell keys apart
+	ID string `json:"id"`
+
+	// Hash is the hex SHA-256 of the key
+	Hash string `json:"hash"`
+
+	// CreatedAt is when the key was issued
+	CreatedAt metav1.Time `json:"createdAt"`
+
+	// ExpiresAt is when a rotated key stops being accepted
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// ApiKeyStatus defines the observed state of an ApiKey
+type ApiKeyStatus struct {
+	// Phase of the key: Active, Expired or Revoked
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Keys accepted for the ApiKey, newest first
+	// +optional
+	Keys []ApiKeyCredential `json:"keys,omitempty"`
+
+	// ObservedRotation is the rotation of the newest key
+	// +optional
+	ObservedRotation int64 `json:"observedRotation,omitempty"`
+
+	// LastRotationTime is when the newest key was issued
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// Message explains the phase
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=apikey,categories={observability}
+// +kubebuilder:printcolumn:name="Platform",type=string,JSONPath=`.spec.platform`,description="Platform of the key"
+// +kubebuilder:printcolumn:name="Tenant",type=string,JSONPath=`.spec.tenant`,description="Tenant of the key"
+// +kubebuilder:printcolumn:name="Scopes",type=string,JSONPath=`.spec.scopes`,description="Granted scopes"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,description="Phase of the key"
+// +kubebuilder:printcolumn:name="Key",type=string,JSONPath=`.status.keys[0].id`,description="ID of the newest key"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time since creation"
+
+// ApiKey is an API key of a platform's tenant, accepted by the ingest
+// gateway for its ingest scopes and by the API server for its query and
+// tenant scopes. Only the hashes of the keys are stored.
+type ApiKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApiKeySpec   `json:"spec,omitempty"`
+	Status ApiKeyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ApiKeyList contains a list of ApiKey
+type ApiKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ApiKey `json:"items"`
+}
+
+// HasScope returns true if the key is granted the scope
+func (k *ApiKey) HasScope(scope string) bool {
+	for _, s := range k.Spec.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSecretName returns the Secret a new key is written to
+func (k *ApiKey) GetSecretName() string {
+	if k.Spec.SecretName != "" {
+		return k.Spec.SecretName
+	}
+	return k.Name + "-api-key"
+}
+
+// GetRotationGracePeriod returns how long the previous key is accepted
+// after a rotation
+func (k *ApiKey) GetRotationGracePeriod() time.Duration {
+	if k.Spec.RotationGracePeriod == nil {
+		return DefaultApiKeyRotationGracePeriod
+	}
+	return k.Spec.RotationGracePeriod.Duration
+}
+
+func init() {
+	SchemeBuilder.Register(&ApiKey{}, &ApiKeyList{})
+}
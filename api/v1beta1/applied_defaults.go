@@ -0,0 +1,153 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// AppliedDefaultsAnnotation records the defaults of the defaulting webhooks
+// which change the behavior of a resource: retentions, limits and encodings.
+// The validating webhooks warn about them and the controllers report them in
+// status.appliedDefaults.
+const AppliedDefaultsAnnotation = "observability.io/applied-defaults"
+
+// AppliedDefault is a field the user did not set and which was defaulted
+type AppliedDefault struct {
+	// Field is the path of the field, like spec.components.prometheus.storage.retention
+	Field string `json:"field"`
+
+	// Value is the default value
+	Value string `json:"value"`
+}
+
+// AppliedDefaults returns the defaults applied to a resource, sorted by field
+func AppliedDefaults(obj metav1.Object) []AppliedDefault {
+	var defaults []AppliedDefault
+	if err := json.Unmarshal([]byte(obj.GetAnnotations()[AppliedDefaultsAnnotation]), &defaults); err != nil {
+		return nil
+	}
+	return defaults
+}
+
+// recordDefault records that a field was set to its default value
+func recordDefault(obj metav1.Object, field string, value interface{}) {
+	defaults := AppliedDefaults(obj)
+	for i := range defaults {
+		if defaults[i].Field == field {
+			defaults = append(defaults[:i], defaults[i+1:]...)
+			break
+		}
+	}
+	defaults = append(defaults, AppliedDefault{Field: field, Value: fmt.Sprint(value)})
+	setAppliedDefaults(obj, defaults)
+}
+
+// recordResourceLimitDefaults records the default resource limits of a
+// component
+func recordResourceLimitDefaults(obj metav1.Object, path string, resources *ResourceRequirements) {
+	if resources == nil || resources.Limits == nil {
+		return
+	}
+	if resources.Limits.CPU != "" {
+		recordDefault(obj, path+".limits.cpu", resources.Limits.CPU)
+	}
+	if resources.Limits.Memory != "" {
+		recordDefault(obj, path+".limits.memory", resources.Limits.Memory)
+	}
+}
+
+// pruneAppliedDefaults forgets the defaults the user has since replaced,
+// or which are gone with their section of the spec
+func pruneAppliedDefaults(obj metav1.Object, spec interface{}) {
+	defaults := AppliedDefaults(obj)
+	if len(defaults) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return
+	}
+
+	kept := defaults[:0]
+	for _, d := range defaults {
+		if value, ok := lookupField(fields, strings.TrimPrefix(d.Field, "spec.")); ok && fmt.Sprint(value) == d.Value {
+			kept = append(kept, d)
+		}
+	}
+	setAppliedDefaults(obj, kept)
+}
+
+// lookupField returns the value at a dotted path of a decoded JSON object
+func lookupField(fields map[string]interface{}, path string) (interface{}, bool) {
+	var value interface{} = fields
+	for _, name := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func setAppliedDefaults(obj metav1.Object, defaults []AppliedDefault) {
+	annotations := obj.GetAnnotations()
+	if len(defaults) == 0 {
+		if _, ok := annotations[AppliedDefaultsAnnotation]; ok {
+			delete(annotations, AppliedDefaultsAnnotation)
+			obj.SetAnnotations(annotations)
+		}
+		return
+	}
+
+	sort.Slice(defaults, func(i, j int) bool { return defaults[i].Field < defaults[j].Field })
+	data, err := json.Marshal(defaults)
+	if err != nil {
+		return
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[AppliedDefaultsAnnotation] = string(data)
+	obj.SetAnnotations(annotations)
+}
+
+// appliedDefaultWarnings warns about the defaults applied to a resource
+// which were not applied to its previous version, nil on create
+func appliedDefaultWarnings(obj, old metav1.Object) admission.Warnings {
+	previous := map[AppliedDefault]bool{}
+	if old != nil {
+		for _, d := range AppliedDefaults(old) {
+			previous[d] = true
+		}
+	}
+
+	var warnings admission.Warnings
+	for _, d := range AppliedDefaults(obj) {
+		if !previous[d] {
+			warnings = append(warnings, fmt.Sprintf("%s is not set and defaults to %s", d.Field, d.Value))
+		}
+	}
+	return warnings
+}
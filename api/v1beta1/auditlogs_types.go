@@ -0,0 +1,107 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// AuditLogsSpec configures the ingestion of Kubernetes audit logs into the
+// platform's Loki, for observability of the control plane and security
+// investigations
+type AuditLogsSpec struct {
+	// Enabled determines if audit logs are collected
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Source is where audit events come from. file tails the audit log on
+	// the control plane nodes; webhook receives the events of the API
+	// server's audit webhook backend.
+	// +kubebuilder:validation:Enum=file;webhook
+	// +kubebuilder:default="file"
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Distribution selects the audit log path of the file source: kubeadm
+	// (also kind), k3s or rke2
+	// +kubebuilder:validation:Enum=kubeadm;k3s;rke2
+	// +kubebuilder:default="kubeadm"
+	// +optional
+	Distribution string `json:"distribution,omitempty"`
+
+	// Path overrides the audit log path of the file source on the control
+	// plane nodes
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// ExcludeReadOnly drops get, list and watch requests
+	// +optional
+	ExcludeReadOnly bool `json:"excludeReadOnly,omitempty"`
+
+	// ExcludeUsers drops the requests of these users, e.g. noisy system
+	// components
+	// +optional
+	ExcludeUsers []string `json:"excludeUsers,omitempty"`
+
+	// Dashboard determines if the security dashboard is provisioned
+	// +kubebuilder:default=true
+	// +optional
+	Dashboard *bool `json:"dashboard,omitempty"`
+
+	// Version of Vector collecting the audit logs
+	// +kubebuilder:default="0.39.0"
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Resources of the collector container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+const (
+	// AuditSourceFile tails the audit log files on the control plane nodes
+	AuditSourceFile = "file"
+	// AuditSourceWebhook receives the events of the audit webhook backend
+	AuditSourceWebhook = "webhook"
+)
+
+// GetSource returns the audit event source, file if not set
+func (s *AuditLogsSpec) GetSource() string {
+	if s.Source == "" {
+		return AuditSourceFile
+	}
+	return s.Source
+}
+
+// GetDistribution returns the distribution of the file source, kubeadm if
+// not set
+func (s *AuditLogsSpec) GetDistribution() string {
+	if s.Distribution == "" {
+		return "kubeadm"
+	}
+	return s.Distribution
+}
+
+// GetVersion returns the Vector version, 0.39.0 if not set
+func (s *AuditLogsSpec) GetVersion() string {
+	if s.Version == "" {
+		return "0.39.0"
+	}
+	return s.Version
+}
+
+// DashboardEnabled returns true if the security dashboard is provisioned
+func (s *AuditLogsSpec) DashboardEnabled() bool {
+	return s.Dashboard == nil || *s.Dashboard
+}
+
+// AuditLogsEnabled returns true if audit logs are collected into the
+// platform's Loki
+func (c *Components) AuditLogsEnabled() bool {
+	return c != nil && c.AuditLogs != nil && c.AuditLogs.Enabled &&
+		c.Loki != nil && c.Loki.Enabled
+}
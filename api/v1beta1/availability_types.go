@@ -0,0 +1,59 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AvailabilityStatus is a compact, monthly bucketed availability history
+type AvailabilityStatus struct {
+	// LastSampleTime is when component states were last sampled
+	// +optional
+	LastSampleTime *metav1.Time `json:"lastSampleTime,omitempty"`
+
+	// LastStates are the component readiness states observed at LastSampleTime
+	// +optional
+	LastStates map[string]bool `json:"lastStates,omitempty"`
+
+	// Months holds one entry per calendar month (UTC), oldest first
+	// +optional
+	Months []MonthlyAvailability `json:"months,omitempty"`
+}
+
+// MonthlyAvailability is the availability of all components during one month
+type MonthlyAvailability struct {
+	// Month in YYYY-MM format
+	// +kubebuilder:validation:Pattern=`^\d{4}-\d{2}$`
+	Month string `json:"month"`
+
+	// Components maps component names to their availability
+	Components map[string]ComponentAvailability `json:"components,omitempty"`
+}
+
+// ComponentAvailability is the availability of a component during one month
+type ComponentAvailability struct {
+	// UpSeconds is the observed time the component was ready
+	UpSeconds int64 `json:"upSeconds"`
+
+	// ObservedSeconds is the total observed time
+	ObservedSeconds int64 `json:"observedSeconds"`
+
+	// Downtime lists the windows during which the component was not ready
+	// +optional
+	Downtime []DowntimeWindow `json:"downtime,omitempty"`
+}
+
+// DowntimeWindow is a period during which a component was unavailable
+type DowntimeWindow struct {
+	// Start of the downtime
+	Start metav1.Time `json:"start"`
+
+	// End of the downtime, unset while the component is still unavailable
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
+}
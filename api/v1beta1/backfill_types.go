@@ -0,0 +1,82 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RecordingRuleBackfillSpec configures the backfill of recording rules. When
+// recording rules are added, the operator evaluates them over the lookback
+// window with promtool and writes the resulting blocks into the storage of
+// every Prometheus replica, so dashboards built on them have history
+// immediately.
+type RecordingRuleBackfillSpec struct {
+	// Enabled turns on recording rule backfill
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Lookback is how far back new rules are evaluated. Backfilling beyond
+	// the Prometheus retention only produces blocks that are deleted again.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(m|h)$`
+	// +kubebuilder:default="168h"
+	// +optional
+	Lookback string `json:"lookback,omitempty"`
+
+	// EvalInterval is the evaluation interval of rule groups without an interval
+	// +kubebuilder:validation:Pattern=`^[0-9]+(s|m|h)$`
+	// +kubebuilder:default="1m"
+	// +optional
+	EvalInterval string `json:"evalInterval,omitempty"`
+}
+
+// RecordingRuleBackfillStatus is the state of the recording rule backfill
+type RecordingRuleBackfillStatus struct {
+	// Backfilled are the keys of the recording rules with their history backfilled
+	// +optional
+	Backfilled []string `json:"backfilled,omitempty"`
+
+	// Pending are the keys of the recording rules being backfilled
+	// +optional
+	Pending []string `json:"pending,omitempty"`
+
+	// Jobs are the backfill Jobs of the pending rules, one per Prometheus replica
+	// +optional
+	Jobs []string `json:"jobs,omitempty"`
+
+	// State of the backfill of the pending rules
+	// +kubebuilder:validation:Enum=Running;Succeeded;Failed
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// LastTransitionTime is when the state last changed
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// IsEnabled returns true if recording rule backfill is enabled
+func (b *RecordingRuleBackfillSpec) IsEnabled() bool {
+	return b != nil && b.Enabled
+}
+
+// GetLookback returns how far back new rules are evaluated
+func (b *RecordingRuleBackfillSpec) GetLookback() time.Duration {
+	if d, err := time.ParseDuration(b.Lookback); err == nil && d > 0 {
+		return d
+	}
+	return 7 * 24 * time.Hour
+}
+
+// GetEvalInterval returns the default evaluation interval of backfilled rules
+func (b *RecordingRuleBackfillSpec) GetEvalInterval() time.Duration {
+	if d, err := time.ParseDuration(b.EvalInterval); err == nil && d > 0 {
+		return d
+	}
+	return time.Minute
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+// SharedBaseAnnotation marks an ObservabilityPlatform as a base for
+// platforms in other namespaces. Without it, a base is only usable from its
+// own namespace.
+const SharedBaseAnnotation = "observability.io/shared-base"
+
+// BasePlatformRef references the ObservabilityPlatform whose spec is merged
+// under a platform's own spec. Fields the platform does not set are
+// inherited; objects are merged field by field and lists are replaced. A base
+// used only as a template sets paused, which is not inherited.
+type BasePlatformRef struct {
+	// Name of the base platform
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the base platform, defaulting to the platform's namespace.
+	// Bases in other namespaces must be annotated observability.io/shared-base: "true".
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// InheritedBase is a base platform applied to a platform's spec
+type InheritedBase struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// Generation is the applied generation of the base
+	Generation int64 `json:"generation"`
+}
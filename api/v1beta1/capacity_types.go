@@ -0,0 +1,181 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Capacity resources forecast by capacity planning
+const (
+	// CapacityResourceSeries is the number of active series of Prometheus
+	CapacityResourceSeries = "series"
+	// CapacityResourceLogs is the volume of logs ingested by Loki per day
+	CapacityResourceLogs = "logs"
+	// CapacityResourceSpans is the number of spans ingested by Tempo per day
+	CapacityResourceSpans = "spans"
+	// CapacityResourceStorage is the storage used by a component's volumes
+	CapacityResourceStorage = "storage"
+)
+
+// CapacityPlanningSpec configures capacity planning. The operator
+// periodically reads the daily history of the platform's series, log
+// volume, span volume and storage from its Prometheus, fits a linear trend
+// and records 30 and 90 day forecasts with recommended scaling actions in
+// the platform's status.
+type CapacityPlanningSpec struct {
+	// Enabled turns on the forecasts
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// History is how far back the usage is read to fit the trends
+	// +kubebuilder:validation:Pattern=`^[0-9]+h$`
+	// +kubebuilder:default="720h"
+	// +optional
+	History string `json:"history,omitempty"`
+
+	// AnalysisInterval is how often the forecasts are refreshed
+	// +kubebuilder:validation:Pattern=`^[0-9]+(m|h)$`
+	// +kubebuilder:default="6h"
+	// +optional
+	AnalysisInterval string `json:"analysisInterval,omitempty"`
+
+	// StorageThreshold is the percentage of a volume's capacity the forecast
+	// usage may reach before expanding it is recommended
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=80
+	// +optional
+	StorageThreshold int32 `json:"storageThreshold,omitempty"`
+
+	// GrowthThreshold is the growth in percent of series, logs or spans over
+	// 90 days above which scaling the receiving component is recommended
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=20
+	// +optional
+	GrowthThreshold int32 `json:"growthThreshold,omitempty"`
+}
+
+// IsEnabled returns true if capacity is forecast
+func (c *CapacityPlanningSpec) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+// GetHistory returns how far back the usage is read
+func (c *CapacityPlanningSpec) GetHistory() time.Duration {
+	if d, err := time.ParseDuration(c.History); err == nil && d > 0 {
+		return d
+	}
+	return 30 * 24 * time.Hour
+}
+
+// GetAnalysisInterval returns how often the forecasts are refreshed
+func (c *CapacityPlanningSpec) GetAnalysisInterval() time.Duration {
+	if d, err := time.ParseDuration(c.AnalysisInterval); err == nil && d > 0 {
+		return d
+	}
+	return 6 * time.Hour
+}
+
+// GetStorageThreshold returns the percentage of a volume the forecast usage
+// may reach
+func (c *CapacityPlanningSpec) GetStorageThreshold() int32 {
+	if c.StorageThreshold <= 0 || c.StorageThreshold > 100 {
+		return 80
+	}
+	return c.StorageThreshold
+}
+
+// GetGrowthThreshold returns the 90 day growth in percent above which
+// scaling is recommended
+func (c *CapacityPlanningSpec) GetGrowthThreshold() int32 {
+	if c.GrowthThreshold <= 0 {
+		return 20
+	}
+	return c.GrowthThreshold
+}
+
+// CapacityPlanning returns the capacity planning settings of the platform
+func (p *ObservabilityPlatform) CapacityPlanning() *CapacityPlanningSpec {
+	if p.Spec.Global == nil {
+		return nil
+	}
+	return p.Spec.Global.CapacityPlanning
+}
+
+// CapacityStatus reports the forecasts of the last capacity analysis
+type CapacityStatus struct {
+	// LastAnalysisTime is when the forecasts were computed
+	// +optional
+	LastAnalysisTime *metav1.Time `json:"lastAnalysisTime,omitempty"`
+
+	// HistoryDays is the number of days of history the trends were fit on
+	// +optional
+	HistoryDays int32 `json:"historyDays,omitempty"`
+
+	// Forecasts are the trends of each resource
+	// +optional
+	Forecasts []CapacityForecast `json:"forecasts,omitempty"`
+
+	// Recommendations are the scaling actions the forecasts call for
+	// +optional
+	Recommendations []CapacityRecommendation `json:"recommendations,omitempty"`
+
+	// Message explains resources which couldn't be forecast
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// CapacityForecast is the trend of a resource of a component
+type CapacityForecast struct {
+	// Component is the component the resource belongs to
+	Component string `json:"component"`
+
+	// Resource is series, logs, spans or storage
+	Resource string `json:"resource"`
+
+	// Unit of the values, e.g. series, GB/day or GiB
+	Unit string `json:"unit"`
+
+	// Current is the latest daily value
+	Current string `json:"current"`
+
+	// GrowthPerDay is the slope of the trend
+	GrowthPerDay string `json:"growthPerDay"`
+
+	// In30Days is the value forecast in 30 days
+	In30Days string `json:"in30Days"`
+
+	// In90Days is the value forecast in 90 days
+	In90Days string `json:"in90Days"`
+
+	// Capacity is the size of the volumes, for storage
+	// +optional
+	Capacity string `json:"capacity,omitempty"`
+
+	// FullInDays is the number of days until the forecast storage reaches
+	// the threshold, if it does within 90 days
+	// +optional
+	FullInDays *int32 `json:"fullInDays,omitempty"`
+}
+
+// CapacityRecommendation is a scaling action
+type CapacityRecommendation struct {
+	// Component to scale
+	Component string `json:"component"`
+
+	// Resource whose forecast calls for the action
+	Resource string `json:"resource"`
+
+	// Action is the change to the platform spec
+	Action string `json:"action"`
+
+	// Reason explains the forecast behind the action
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConfigValidationSpec configures the validation of component configurations
+// before they are rolled out. Each changed configuration is checked by the
+// component's own tooling (promtool, loki -verify-config, tempo
+// -config.verify) in a short-lived Job, and configurations failing the check
+// are not applied.
+type ConfigValidationSpec struct {
+	// Enabled determines if configurations are validated before rollout
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// TimeoutSeconds bounds the runtime of a validation Job
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:default=300
+	// +optional
+	TimeoutSeconds int64 `json:"timeoutSeconds,omitempty"`
+}
+
+// ConfigValidationStatus is the validation state of a component configuration
+type ConfigValidationStatus struct {
+	// Hash identifies the validated configuration
+	Hash string `json:"hash"`
+
+	// State of the validation
+	// +kubebuilder:validation:Enum=Pending;Valid;Invalid
+	State string `json:"state"`
+
+	// Job is the name of the validation Job
+	// +optional
+	Job string `json:"job,omitempty"`
+
+	// Message holds the validation errors of an invalid configuration
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastTransitionTime is when the state last changed
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// IsConfigValidationEnabled returns true if configurations are validated before rollout
+func (p *ObservabilityPlatform) IsConfigValidationEnabled() bool {
+	return p.Spec.ConfigValidation != nil && p.Spec.ConfigValidation.Enabled
+}
+
+// GetTimeoutSeconds returns the runtime bound of a validation Job
+func (c *ConfigValidationSpec) GetTimeoutSeconds() int64 {
+	if c == nil || c.TimeoutSeconds <= 0 {
+		return 300
+	}
+	return c.TimeoutSeconds
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 Gunjan Patil.
+
+Licensed under the MIT License.
+*/
+
+package conversion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion/encryption"
+)
+
+// annotationEnvelope encrypts the ConversionDataAnnotation payloads, nil
+// when they are stored in plaintext
+var annotationEnvelope *encryption.Envelope
+
+// SetAnnotationEncryption enables the encryption of the data preserved in
+// ConversionDataAnnotation with envelope, or disables it if nil. Payloads
+// written before are still read.
+func SetAnnotationEncryption(envelope *encryption.Envelope) {
+	annotationEnvelope = envelope
+}
+
+// encodePreservedData returns the ConversionDataAnnotation value of a
+// preserved data payload
+func encodePreservedData(ctx context.Context, payload []byte) (string, error) {
+	if annotationEnvelope == nil {
+		return string(payload), nil
+	}
+	value, err := annotationEnvelope.Seal(ctx, payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt preserved data: %w", err)
+	}
+	return value, nil
+}
+
+// DecodePreservedData returns the payload of a ConversionDataAnnotation
+// value, decrypting it if it is encrypted
+func DecodePreservedData(ctx context.Context, value string) ([]byte, error) {
+	if !encryption.Encrypted(value) {
+		return []byte(value), nil
+	}
+	if annotationEnvelope == nil {
+		return nil, fmt.Errorf("preserved data is encrypted but no encryption key is configured")
+	}
+	payload, err := annotationEnvelope.Open(ctx, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt preserved data: %w", err)
+	}
+	return payload, nil
+}
+
+// LoadPreservedDataEnhanced reads the data preserved in the
+// ConversionDataAnnotation of obj, nil if it has none
+func LoadPreservedDataEnhanced(ctx context.Context, obj runtime.Object) (*PreservedDataEnhanced, error) {
+	accessor, err := metav1.ObjectMetaAccessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := accessor.GetObjectMeta().GetAnnotations()[ConversionDataAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	payload, err := DecodePreservedData(ctx, value)
+	if err != nil {
+		return nil, err
+	}
+	preserved := &PreservedDataEnhanced{}
+	if err := json.Unmarshal(payload, preserved); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal preserved data: %w", err)
+	}
+	return preserved, nil
+}
+
+// RewrapPreservedData wraps the data key of the ConversionDataAnnotation of
+// obj with the current key-encryption key, encrypting it if it is in
+// plaintext, and reports whether the annotation changed
+func RewrapPreservedData(ctx context.Context, obj runtime.Object) (bool, error) {
+	if annotationEnvelope == nil {
+		return false, fmt.Errorf("no encryption key is configured")
+	}
+	accessor, err := metav1.ObjectMetaAccessor(obj)
+	if err != nil {
+		return false, err
+	}
+	meta := accessor.GetObjectMeta()
+	annotations := meta.GetAnnotations()
+	value, ok := annotations[ConversionDataAnnotation]
+	if !ok {
+		return false, nil
+	}
+	rewrapped, changed, err := annotationEnvelope.Rewrap(ctx, value)
+	if err != nil || !changed {
+		return false, err
+	}
+	annotations[ConversionDataAnnotation] = rewrapped
+	meta.SetAnnotations(annotations)
+	return true, nil
+}
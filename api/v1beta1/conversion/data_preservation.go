@@ -178,7 +178,7 @@ func (dp *DataPreserver) PreserveData(ctx context.Context, obj runtime.Object, t
 	}
 	
 	// Store preservation data in annotations
-	if err := dp.storePreservationData(obj, preserved, hash); err != nil {
+	if err := dp.storePreservationData(ctx, obj, preserved, hash); err != nil {
 		return nil, fmt.Errorf("failed to store preservation data: %w", err)
 	}
 	
@@ -386,7 +386,7 @@ func (dp *DataPreserver) generateFieldMappings(obj runtime.Object, targetVersion
 }
 
 // storePreservationData stores preservation data in object annotations
-func (dp *DataPreserver) storePreservationData(obj runtime.Object, preserved *PreservedData, hash string) error {
+func (dp *DataPreserver) storePreservationData(ctx context.Context, obj runtime.Object, preserved *PreservedData, hash string) error {
 	accessor, err := metav1.ObjectMetaAccessor(obj)
 	if err != nil {
 		return err
@@ -404,8 +404,13 @@ func (dp *DataPreserver) storePreservationData(obj runtime.Object, preserved *Pr
 		return fmt.Errorf("failed to marshal preserved data: %w", err)
 	}
 	
-	// Store in annotations
-	annotations[ConversionDataAnnotation] = string(preservedJSON)
+	// Store in annotations, encrypted at rest if an encryption key is
+	// configured
+	value, err := encodePreservedData(ctx, preservedJSON)
+	if err != nil {
+		return err
+	}
+	annotations[ConversionDataAnnotation] = value
 	annotations[DataIntegrityHashAnnotation] = hash
 	annotations[LastConversionVersionAnnotation] = obj.GetObjectKind().GroupVersionKind().Version
 	
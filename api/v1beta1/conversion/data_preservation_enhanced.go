@@ -296,7 +296,7 @@ func (dp *DataPreserverEnhanced) PreserveDataEnhanced(ctx context.Context, obj r
 	}
 	
 	// Store preservation data
-	if err := dp.storeEnhancedPreservationData(obj, enhanced, hash); err != nil {
+	if err := dp.storeEnhancedPreservationData(ctx, obj, enhanced, hash); err != nil {
 		dp.metrics.RecordPreservationError()
 		return nil, fmt.Errorf("failed to store preservation data: %w", err)
 	}
@@ -1038,7 +1038,7 @@ func (dp *DataPreserverEnhanced) calculateEnhancedDataHash(enhanced *PreservedDa
 	return fmt.Sprintf("%x", hash), nil
 }
 
-func (dp *DataPreserverEnhanced) storeEnhancedPreservationData(obj runtime.Object, enhanced *PreservedDataEnhanced, hash string) error {
+func (dp *DataPreserverEnhanced) storeEnhancedPreservationData(ctx context.Context, obj runtime.Object, enhanced *PreservedDataEnhanced, hash string) error {
 	accessor, err := metav1.ObjectMetaAccessor(obj)
 	if err != nil {
 		return err
@@ -1056,7 +1056,12 @@ func (dp *DataPreserverEnhanced) storeEnhancedPreservationData(obj runtime.Objec
 		return fmt.Errorf("failed to marshal enhanced preserved data: %w", err)
 	}
 	
-	annotations[ConversionDataAnnotation] = string(preservedJSON)
+	// Encrypted at rest if an encryption key is configured
+	value, err := encodePreservedData(ctx, preservedJSON)
+	if err != nil {
+		return err
+	}
+	annotations[ConversionDataAnnotation] = value
 	annotations[DataIntegrityHashAnnotation] = hash
 	annotations[LastConversionVersionAnnotation] = obj.GetObjectKind().GroupVersionKind().Version
 	
@@ -17,6 +17,7 @@ import (
 	
 	"github.com/gunjanjp/gunj-operator/api/v1alpha1"
 	"github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion/redaction"
 )
 
 // DryRunConverter provides dry-run conversion capabilities
@@ -95,11 +96,15 @@ func (d *DryRunConverter) DryRunConversion(ctx context.Context, obj runtime.Obje
 		"sourceVersion", sourceVersion,
 		"targetVersion", targetVersion)
 	
-	// Serialize original object
+	// Serialize original object, with its sensitive fields redacted
+	redactor := redaction.Default()
 	originalJSON, err := json.MarshalIndent(obj, "", "  ")
 	if err != nil {
 		return nil, fmt.Errorf("marshaling original object: %w", err)
 	}
+	if originalJSON, err = redactor.JSON(originalJSON); err != nil {
+		return nil, err
+	}
 	result.Original = originalJSON
 	
 	// Perform the conversion
@@ -107,7 +112,7 @@ func (d *DryRunConverter) DryRunConversion(ctx context.Context, obj runtime.Obje
 	if err != nil {
 		result.Success = false
 		result.Recommendations = append(result.Recommendations,
-			fmt.Sprintf("Conversion would fail: %v", err))
+			redactor.Text(fmt.Sprintf("Conversion would fail: %v", err)))
 		return result, nil
 	}
 	
@@ -116,6 +121,9 @@ func (d *DryRunConverter) DryRunConversion(ctx context.Context, obj runtime.Obje
 	if err != nil {
 		return nil, fmt.Errorf("marshaling converted object: %w", err)
 	}
+	if convertedJSON, err = redactor.JSON(convertedJSON); err != nil {
+		return nil, err
+	}
 	result.Converted = convertedJSON
 	
 	// Validate the conversion
@@ -126,6 +134,11 @@ func (d *DryRunConverter) DryRunConversion(ctx context.Context, obj runtime.Obje
 	
 	// Generate diff
 	result.Diff = d.generateDiff(obj, converted, sourceVersion, targetVersion)
+	for i := range result.Diff {
+		entry := &result.Diff[i]
+		entry.OldValue = redactor.Value(entry.Path, entry.OldValue)
+		entry.NewValue = redactor.Value(entry.Path, entry.NewValue)
+	}
 	
 	// Estimate impact
 	result.EstimatedImpact = d.estimateImpact(sourceVersion, targetVersion, result.ValidationResult, result.Diff)
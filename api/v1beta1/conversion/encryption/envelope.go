@@ -0,0 +1,202 @@
+/*
+Copyright 2025 Gunjan Patil.
+
+Licensed under the MIT License.
+*/
+
+// Package encryption encrypts the data the conversion webhook preserves in
+// annotations, which may hold sensitive values. Payloads are encrypted with
+// envelope encryption: each payload with its own AES-256-GCM data key, the
+// data key with a key-encryption key held by a KeyWrapper, either a keyring
+// Secret of the cluster or a key management service. Rotating the
+// key-encryption key only re-wraps the data keys, see Envelope.Rewrap.
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// Prefix marks encrypted annotation values. Values without it are
+	// plaintext, written before encryption was enabled.
+	Prefix = "enc:v1:"
+
+	// dataKeySize is the size of AES-256 keys
+	dataKeySize = 32
+)
+
+// additionalData binds the ciphertexts to their use
+var additionalData = []byte("observability.io/conversion-data")
+
+// KeyWrapper encrypts data keys with a key-encryption key. Implementations
+// backed by a key management service return the ID of the key they used, so
+// data keys wrapped with a rotated key can still be unwrapped.
+type KeyWrapper interface {
+	// Wrap encrypts a data key with the current key-encryption key
+	Wrap(ctx context.Context, dataKey []byte) (keyID string, wrapped []byte, err error)
+	// Unwrap decrypts a data key wrapped with the key keyID
+	Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// sealed is the encoded form of an encrypted payload
+type sealed struct {
+	KeyID   string `json:"kid"`
+	DataKey []byte `json:"dek"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// Envelope encrypts and decrypts annotation values
+type Envelope struct {
+	Wrapper KeyWrapper
+}
+
+// NewEnvelope returns an envelope wrapping its data keys with wrapper
+func NewEnvelope(wrapper KeyWrapper) *Envelope {
+	return &Envelope{Wrapper: wrapper}
+}
+
+// Encrypted returns whether an annotation value is encrypted
+func Encrypted(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// Seal encrypts plaintext with a new data key
+func (e *Envelope) Seal(ctx context.Context, plaintext []byte) (string, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+	nonce, data, err := seal(dataKey, plaintext)
+	if err != nil {
+		return "", err
+	}
+	keyID, wrapped, err := e.Wrapper.Wrap(ctx, dataKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	return encode(&sealed{KeyID: keyID, DataKey: wrapped, Nonce: nonce, Data: data})
+}
+
+// Open decrypts a value sealed by Seal. Plaintext values are returned as
+// they are, so annotations written before encryption was enabled are still
+// read.
+func (e *Envelope) Open(ctx context.Context, value string) ([]byte, error) {
+	if !Encrypted(value) {
+		return []byte(value), nil
+	}
+	s, err := decode(value)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := e.Wrapper.Unwrap(ctx, s.KeyID, s.DataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key of key %q: %w", s.KeyID, err)
+	}
+	return open(dataKey, s.Nonce, s.Data)
+}
+
+// Rewrap wraps the data key of a sealed value with the current
+// key-encryption key, leaving the payload untouched, and reports whether the
+// value changed. Plaintext values are sealed.
+func (e *Envelope) Rewrap(ctx context.Context, value string) (string, bool, error) {
+	if !Encrypted(value) {
+		sealedValue, err := e.Seal(ctx, []byte(value))
+		return sealedValue, err == nil, err
+	}
+	s, err := decode(value)
+	if err != nil {
+		return "", false, err
+	}
+	dataKey, err := e.Wrapper.Unwrap(ctx, s.KeyID, s.DataKey)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to unwrap data key of key %q: %w", s.KeyID, err)
+	}
+	keyID, wrapped, err := e.Wrapper.Wrap(ctx, dataKey)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	if keyID == s.KeyID {
+		return value, false, nil
+	}
+	s.KeyID, s.DataKey = keyID, wrapped
+	rewrapped, err := encode(s)
+	return rewrapped, err == nil, err
+}
+
+// KeyID returns the ID of the key-encryption key of a sealed value
+func KeyID(value string) (string, error) {
+	s, err := decode(value)
+	if err != nil {
+		return "", err
+	}
+	return s.KeyID, nil
+}
+
+func encode(s *sealed) (string, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode sealed value: %w", err)
+	}
+	return Prefix + base64.StdEncoding.EncodeToString(data), nil
+}
+
+func decode(value string) (*sealed, error) {
+	if !Encrypted(value) {
+		return nil, fmt.Errorf("value is not encrypted")
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, Prefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid sealed value: %w", err)
+	}
+	s := &sealed{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid sealed value: %w", err)
+	}
+	return s, nil
+}
+
+// seal encrypts plaintext with AES-GCM under key
+func seal(key, plaintext []byte) (nonce, ciphertext []byte, err error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce = make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return nonce, aead.Seal(nil, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts a ciphertext of seal
+func open(key, nonce, ciphertext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce")
+	}
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025 Gunjan Patil.
+
+Licensed under the MIT License.
+*/
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func key(b byte) []byte {
+	return bytes.Repeat([]byte{b}, dataKeySize)
+}
+
+func TestSealAndOpen(t *testing.T) {
+	ctx := context.Background()
+	envelope := NewEnvelope(&Keyring{Primary: "k1", Keys: map[string][]byte{"k1": key(1)}})
+
+	value, err := envelope.Seal(ctx, []byte(`{"password":"secret"}`))
+	require.NoError(t, err)
+	assert.True(t, Encrypted(value))
+	assert.NotContains(t, value, "secret")
+
+	plaintext, err := envelope.Open(ctx, value)
+	require.NoError(t, err)
+	assert.Equal(t, `{"password":"secret"}`, string(plaintext))
+
+	// Values written before encryption was enabled are read as they are
+	plaintext, err = envelope.Open(ctx, `{"a":1}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, string(plaintext))
+
+	// Another keyring cannot open the value
+	other := NewEnvelope(&Keyring{Primary: "k1", Keys: map[string][]byte{"k1": key(2)}})
+	_, err = other.Open(ctx, value)
+	assert.Error(t, err)
+
+	_, err = envelope.Open(ctx, Prefix+"not base64!")
+	assert.Error(t, err)
+}
+
+func TestRewrap(t *testing.T) {
+	ctx := context.Background()
+	keyring := &Keyring{Primary: "2025-01", Keys: map[string][]byte{"2025-01": key(1)}}
+	envelope := NewEnvelope(keyring)
+
+	value, err := envelope.Seal(ctx, []byte("data"))
+	require.NoError(t, err)
+
+	rewrapped, changed, err := envelope.Rewrap(ctx, value)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, value, rewrapped)
+
+	// Rotate the key-encryption key, keeping the old key to unwrap
+	keyring.Keys["2025-06"] = key(2)
+	keyring.Primary = "2025-06"
+	rewrapped, changed, err = envelope.Rewrap(ctx, value)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	keyID, err := KeyID(rewrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-06", keyID)
+
+	// The old key can be retired once every value is rewrapped
+	delete(keyring.Keys, "2025-01")
+	plaintext, err := envelope.Open(ctx, rewrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(plaintext))
+	_, err = envelope.Open(ctx, value)
+	assert.Error(t, err)
+
+	// Plaintext values are sealed
+	sealedValue, changed, err := envelope.Rewrap(ctx, "plain")
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.True(t, strings.HasPrefix(sealedValue, Prefix))
+}
+
+func TestKeyringFromSecret(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "conversion-keys", Namespace: "gunj-system"},
+		Data:       map[string][]byte{"2025-01": key(1), "2025-06": key(2)},
+	}
+	keyring, err := KeyringFromSecret(secret)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-06", keyring.Primary)
+
+	secret.Annotations = map[string]string{PrimaryKeyAnnotation: "2025-01"}
+	keyring, err = KeyringFromSecret(secret)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01", keyring.Primary)
+
+	secret.Annotations = map[string]string{PrimaryKeyAnnotation: "missing"}
+	_, err = KeyringFromSecret(secret)
+	assert.Error(t, err)
+
+	secret.Annotations = nil
+	secret.Data["short"] = []byte("too short")
+	_, err = KeyringFromSecret(secret)
+	assert.Error(t, err)
+}
+
+func TestSecretKeyring(t *testing.T) {
+	ctx := context.Background()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "conversion-keys", Namespace: "gunj-system"},
+		Data:       map[string][]byte{"k1": key(1)},
+	}
+	reader := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(secret).Build()
+	envelope := NewEnvelope(&SecretKeyring{Reader: reader, Secret: types.NamespacedName{Namespace: "gunj-system", Name: "conversion-keys"}})
+
+	value, err := envelope.Seal(ctx, []byte("data"))
+	require.NoError(t, err)
+	plaintext, err := envelope.Open(ctx, value)
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(plaintext))
+
+	missing := NewEnvelope(&SecretKeyring{Reader: reader, Secret: types.NamespacedName{Namespace: "gunj-system", Name: "missing"}})
+	_, err = missing.Seal(ctx, []byte("data"))
+	assert.Error(t, err)
+}
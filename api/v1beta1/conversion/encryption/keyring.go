@@ -0,0 +1,119 @@
+/*
+Copyright 2025 Gunjan Patil.
+
+Licensed under the MIT License.
+*/
+
+package encryption
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PrimaryKeyAnnotation on a keyring Secret names the key wrapping new data
+// keys. Without it the last key ID in lexical order is used, so keys named
+// by date rotate by adding a key.
+const PrimaryKeyAnnotation = "observability.io/primary-key"
+
+// Keyring holds key-encryption keys by ID
+type Keyring struct {
+	// Primary is the ID of the key wrapping new data keys
+	Primary string
+	// Keys are 32 byte AES-256 keys by ID. Keys no longer primary are kept
+	// to unwrap the data keys they wrapped.
+	Keys map[string][]byte
+}
+
+// Wrap wraps a data key with the primary key
+func (k *Keyring) Wrap(_ context.Context, dataKey []byte) (string, []byte, error) {
+	key, ok := k.Keys[k.Primary]
+	if !ok {
+		return "", nil, fmt.Errorf("primary key %q is not in the keyring", k.Primary)
+	}
+	nonce, wrapped, err := seal(key, dataKey)
+	if err != nil {
+		return "", nil, err
+	}
+	return k.Primary, append(nonce, wrapped...), nil
+}
+
+// Unwrap unwraps a data key wrapped with the key keyID
+func (k *Keyring) Unwrap(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	key, ok := k.Keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("key %q is not in the keyring", keyID)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, fmt.Errorf("invalid wrapped data key")
+	}
+	return open(key, wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():])
+}
+
+// KeyringFromSecret reads a keyring from a Secret holding one 32 byte key
+// per data entry, keyed by key ID
+func KeyringFromSecret(secret *corev1.Secret) (*Keyring, error) {
+	if len(secret.Data) == 0 {
+		return nil, fmt.Errorf("keyring secret %s/%s has no keys", secret.Namespace, secret.Name)
+	}
+	keyring := &Keyring{Keys: make(map[string][]byte, len(secret.Data))}
+	ids := make([]string, 0, len(secret.Data))
+	for id, key := range secret.Data {
+		if len(key) != dataKeySize {
+			return nil, fmt.Errorf("key %q of keyring secret %s/%s is %d bytes, not %d", id, secret.Namespace, secret.Name, len(key), dataKeySize)
+		}
+		keyring.Keys[id] = key
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	keyring.Primary = ids[len(ids)-1]
+	if primary := secret.Annotations[PrimaryKeyAnnotation]; primary != "" {
+		if _, ok := keyring.Keys[primary]; !ok {
+			return nil, fmt.Errorf("primary key %q is not in keyring secret %s/%s", primary, secret.Namespace, secret.Name)
+		}
+		keyring.Primary = primary
+	}
+	return keyring, nil
+}
+
+// SecretKeyring wraps data keys with the keyring of a Secret, read on every
+// use so keys added to the Secret are picked up without a restart
+type SecretKeyring struct {
+	Reader client.Reader
+	Secret types.NamespacedName
+}
+
+// Wrap wraps a data key with the primary key of the Secret
+func (s *SecretKeyring) Wrap(ctx context.Context, dataKey []byte) (string, []byte, error) {
+	keyring, err := s.load(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	return keyring.Wrap(ctx, dataKey)
+}
+
+// Unwrap unwraps a data key with a key of the Secret
+func (s *SecretKeyring) Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	keyring, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return keyring.Unwrap(ctx, keyID, wrapped)
+}
+
+func (s *SecretKeyring) load(ctx context.Context) (*Keyring, error) {
+	secret := &corev1.Secret{}
+	if err := s.Reader.Get(ctx, s.Secret, secret); err != nil {
+		return nil, fmt.Errorf("failed to get keyring secret %s: %w", s.Secret, err)
+	}
+	return KeyringFromSecret(secret)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025 Gunjan Patil.
+
+Licensed under the MIT License.
+*/
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// DefaultVaultTransitMount is the path the transit secrets engine is
+// mounted at by default
+const DefaultVaultTransitMount = "transit"
+
+// VaultTransit wraps data keys with a key of the transit secrets engine of
+// HashiCorp Vault. The key-encryption key never leaves Vault. Its key ID is
+// the transit key and its version, e.g. gunj-conversion:v2, so rotating the
+// key in Vault and rewrapping moves the data keys to the new version, while
+// the old versions still unwrap them.
+type VaultTransit struct {
+	// Address of Vault, e.g. https://vault.vault.svc:8200
+	Address string
+	// Mount is the path of the transit secrets engine, DefaultVaultTransitMount
+	// if empty
+	Mount string
+	// Key is the name of the transit key wrapping new data keys
+	Key string
+	// Token authenticates to Vault. If empty, it is read from TokenFile on
+	// every request, so the tokens renewed by a Vault agent are picked up.
+	Token     string
+	TokenFile string
+	// Client sends the requests to Vault, http.DefaultClient if nil
+	Client *http.Client
+}
+
+// ParseVaultTransitKey parses a transit key reference, mount/key or only the
+// key of the default mount
+func ParseVaultTransitKey(value string) (mount, key string, err error) {
+	mount, key = DefaultVaultTransitMount, value
+	if i := strings.LastIndex(value, "/"); i >= 0 {
+		mount, key = value[:i], value[i+1:]
+	}
+	if mount == "" || key == "" {
+		return "", "", fmt.Errorf("%q is not a transit key, use mount/key", value)
+	}
+	return mount, key, nil
+}
+
+// Wrap encrypts a data key with the latest version of the transit key
+func (v *VaultTransit) Wrap(ctx context.Context, dataKey []byte) (string, []byte, error) {
+	var resp struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	req := map[string]string{"plaintext": base64.StdEncoding.EncodeToString(dataKey)}
+	if err := v.call(ctx, "encrypt", v.Key, req, &resp); err != nil {
+		return "", nil, err
+	}
+	// Ciphertexts are vault:v<version>:<data>
+	parts := strings.SplitN(resp.Ciphertext, ":", 3)
+	if len(parts) != 3 || parts[0] != "vault" {
+		return "", nil, fmt.Errorf("unexpected ciphertext of transit key %s", v.Key)
+	}
+	return v.Key + ":" + parts[1], []byte(resp.Ciphertext), nil
+}
+
+// Unwrap decrypts a data key wrapped with the transit key of keyID, which
+// may be another key than the one wrapping new data keys
+func (v *VaultTransit) Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	key, _, ok := strings.Cut(keyID, ":")
+	if !ok || key == "" {
+		return nil, fmt.Errorf("key %q is not a transit key", keyID)
+	}
+	var resp struct {
+		Plaintext string `json:"plaintext"`
+	}
+	if err := v.call(ctx, "decrypt", key, map[string]string{"ciphertext": string(wrapped)}, &resp); err != nil {
+		return nil, err
+	}
+	dataKey, err := base64.StdEncoding.DecodeString(resp.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid plaintext of transit key %s: %w", key, err)
+	}
+	return dataKey, nil
+}
+
+// call posts a request to an operation of the transit engine and decodes
+// the data of its response
+func (v *VaultTransit) call(ctx context.Context, operation, key string, body, data interface{}) error {
+	token := v.Token
+	if token == "" {
+		raw, err := os.ReadFile(v.TokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read Vault token: %w", err)
+		}
+		token = strings.TrimSpace(string(raw))
+	}
+	mount := v.Mount
+	if mount == "" {
+		mount = DefaultVaultTransitMount
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/v1/%s/%s/%s", strings.TrimSuffix(v.Address, "/"), strings.Trim(mount, "/"), operation, key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", token)
+
+	client := v.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to %s with transit key %s: %w", operation, key, err)
+	}
+	defer resp.Body.Close()
+
+	var decoded struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []string        `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return fmt.Errorf("invalid Vault response to %s with transit key %s: %w", operation, key, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to %s with transit key %s: %s: %s", operation, key, resp.Status, strings.Join(decoded.Errors, "; "))
+	}
+	if err := json.Unmarshal(decoded.Data, data); err != nil {
+		return fmt.Errorf("invalid Vault response to %s with transit key %s: %w", operation, key, err)
+	}
+	return nil
+}
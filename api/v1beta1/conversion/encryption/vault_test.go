@@ -0,0 +1,175 @@
+/*
+Copyright 2025 Gunjan Patil.
+
+Licensed under the MIT License.
+*/
+
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransit emulates the encrypt and decrypt endpoints of a transit
+// secrets engine mounted at transit, with versioned keys
+type fakeTransit struct {
+	token string
+	// keys are the versions of each transit key, the last is the latest
+	keys map[string][][]byte
+}
+
+func (f *fakeTransit) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reply := func(status int, body interface{}) {
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	}
+	fail := func(status int, msg string) {
+		reply(status, map[string]interface{}{"errors": []string{msg}})
+	}
+	if r.Header.Get("X-Vault-Token") != f.token {
+		fail(http.StatusForbidden, "permission denied")
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/transit/"), "/")
+	if r.Method != http.MethodPost || len(parts) != 2 {
+		fail(http.StatusNotFound, "unsupported path")
+		return
+	}
+	versions, ok := f.keys[parts[1]]
+	if !ok {
+		fail(http.StatusBadRequest, "encryption key not found")
+		return
+	}
+	var req map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		fail(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	switch parts[0] {
+	case "encrypt":
+		plaintext, err := base64.StdEncoding.DecodeString(req["plaintext"])
+		if err != nil {
+			fail(http.StatusBadRequest, err.Error())
+			return
+		}
+		nonce, ciphertext, err := seal(versions[len(versions)-1], plaintext)
+		if err != nil {
+			fail(http.StatusInternalServerError, err.Error())
+			return
+		}
+		reply(http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+			"ciphertext":  fmt.Sprintf("vault:v%d:%s", len(versions), base64.StdEncoding.EncodeToString(append(nonce, ciphertext...))),
+			"key_version": len(versions),
+		}})
+	case "decrypt":
+		fields := strings.SplitN(req["ciphertext"], ":", 3)
+		if len(fields) != 3 {
+			fail(http.StatusBadRequest, "invalid ciphertext")
+			return
+		}
+		version, err := strconv.Atoi(strings.TrimPrefix(fields[1], "v"))
+		if err != nil || version < 1 || version > len(versions) {
+			fail(http.StatusBadRequest, "invalid key version")
+			return
+		}
+		raw, err := base64.StdEncoding.DecodeString(fields[2])
+		if err != nil || len(raw) < 12 {
+			fail(http.StatusBadRequest, "invalid ciphertext")
+			return
+		}
+		plaintext, err := open(versions[version-1], raw[:12], raw[12:])
+		if err != nil {
+			fail(http.StatusBadRequest, "cipher: message authentication failed")
+			return
+		}
+		reply(http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+			"plaintext": base64.StdEncoding.EncodeToString(plaintext),
+		}})
+	default:
+		fail(http.StatusNotFound, "unsupported path")
+	}
+}
+
+func TestVaultTransit(t *testing.T) {
+	ctx := context.Background()
+	transit := &fakeTransit{token: "s.token", keys: map[string][][]byte{"gunj-conversion": {key(1)}}}
+	server := httptest.NewServer(transit)
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("s.token\n"), 0o600))
+	wrapper := &VaultTransit{Address: server.URL, Key: "gunj-conversion", TokenFile: tokenFile}
+	envelope := NewEnvelope(wrapper)
+
+	value, err := envelope.Seal(ctx, []byte("data"))
+	require.NoError(t, err)
+	keyID, err := KeyID(value)
+	require.NoError(t, err)
+	assert.Equal(t, "gunj-conversion:v1", keyID)
+
+	plaintext, err := envelope.Open(ctx, value)
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(plaintext))
+
+	_, changed, err := envelope.Rewrap(ctx, value)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// Rotating the key in Vault moves the data keys to the new version
+	transit.keys["gunj-conversion"] = append(transit.keys["gunj-conversion"], key(2))
+	rewrapped, changed, err := envelope.Rewrap(ctx, value)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	keyID, err = KeyID(rewrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "gunj-conversion:v2", keyID)
+	plaintext, err = envelope.Open(ctx, rewrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(plaintext))
+
+	// Values wrapped by a former transit key are still opened with it
+	transit.keys["gunj-conversion-2026"] = [][]byte{key(3)}
+	envelope = NewEnvelope(&VaultTransit{Address: server.URL, Key: "gunj-conversion-2026", Token: "s.token"})
+	plaintext, err = envelope.Open(ctx, value)
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(plaintext))
+
+	// Vault errors are surfaced
+	envelope = NewEnvelope(&VaultTransit{Address: server.URL, Key: "gunj-conversion", Token: "wrong"})
+	_, err = envelope.Seal(ctx, []byte("data"))
+	assert.ErrorContains(t, err, "permission denied")
+
+	_, err = wrapper.Unwrap(ctx, "2025-01", []byte("vault:v1:AAAA"))
+	assert.Error(t, err)
+}
+
+func TestParseVaultTransitKey(t *testing.T) {
+	mount, key, err := ParseVaultTransitKey("gunj-conversion")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultVaultTransitMount, mount)
+	assert.Equal(t, "gunj-conversion", key)
+
+	mount, key, err = ParseVaultTransitKey("secrets/transit/gunj-conversion")
+	require.NoError(t, err)
+	assert.Equal(t, "secrets/transit", mount)
+	assert.Equal(t, "gunj-conversion", key)
+
+	for _, value := range []string{"", "transit/", "/gunj-conversion"} {
+		_, _, err = ParseVaultTransitKey(value)
+		assert.Error(t, err, value)
+	}
+}
@@ -14,6 +14,9 @@ import (
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion/redaction"
 )
 
 // Policy defines a data preservation policy
@@ -794,13 +797,15 @@ type PolicyConfig struct {
 	CustomValidators        map[string]string      `json:"customValidators,omitempty"`
 	CustomExecutors         map[string]string      `json:"customExecutors,omitempty"`
 	Metadata                map[string]interface{} `json:"metadata,omitempty"`
+	// Redaction masks sensitive fields in migration reports, dry-run diffs
+	// and logs. The default redaction policy applies when nil.
+	Redaction *redaction.Policy `json:"redaction,omitempty"`
 }
 
-// LoadPolicyConfig loads policy configuration
+// LoadPolicyConfig loads policy configuration from YAML or JSON, on top of
+// the defaults
 func LoadPolicyConfig(data []byte) (*PolicyConfig, error) {
-	// This would typically load from YAML/JSON
-	// For now, return default config
-	return &PolicyConfig{
+	config := &PolicyConfig{
 		Policies:                DefaultPolicies(),
 		DefaultStrategy:         "deep-copy",
 		EnableMetrics:           true,
@@ -808,7 +813,25 @@ func LoadPolicyConfig(data []byte) (*PolicyConfig, error) {
 		FieldSizeThreshold:      1024,
 		MaxPreservationAttempts: 3,
 		PreservationTimeout:     "30s",
-	}, nil
+	}
+	if len(data) == 0 {
+		return config, nil
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse policy config: %w", err)
+	}
+	if _, err := config.Redactor(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Redactor returns the redactor of the redaction policy
+func (c *PolicyConfig) Redactor() (*redaction.Redactor, error) {
+	if c == nil {
+		return redaction.New(nil)
+	}
+	return redaction.New(c.Redaction)
 }
 
 // PolicyEngine orchestrates policy-based preservation
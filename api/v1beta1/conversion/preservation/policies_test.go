@@ -573,3 +573,45 @@ func TestDefaultPolicies(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadPolicyConfig(t *testing.T) {
+	config, err := preservation.LoadPolicyConfig(nil)
+	if err != nil {
+		t.Fatalf("Failed to load default config: %v", err)
+	}
+	if config.Redaction != nil {
+		t.Errorf("Expected no redaction policy by default")
+	}
+
+	config, err = preservation.LoadPolicyConfig([]byte(`
+defaultStrategy: merge
+redaction:
+  additionalRules:
+  - spec.global.externalLabels.*
+  mask: "***"
+`))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.DefaultStrategy != "merge" {
+		t.Errorf("Expected default strategy merge, got %s", config.DefaultStrategy)
+	}
+	if config.FieldSizeThreshold != 1024 {
+		t.Errorf("Expected the default field size threshold, got %d", config.FieldSizeThreshold)
+	}
+
+	redactor, err := config.Redactor()
+	if err != nil {
+		t.Fatalf("Failed to create redactor: %v", err)
+	}
+	if !redactor.Matches("spec.global.externalLabels.cluster") || !redactor.Matches("spec.components.grafana.adminPassword") {
+		t.Errorf("Expected the additional and the default rules to apply")
+	}
+	if redactor.Mask() != "***" {
+		t.Errorf("Expected mask ***, got %s", redactor.Mask())
+	}
+
+	if _, err := preservation.LoadPolicyConfig([]byte("redaction:\n  rules: [\"spec..password\"]\n")); err == nil {
+		t.Errorf("Expected an invalid redaction rule to be rejected")
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 Gunjan Patil.
+
+Licensed under the MIT License.
+*/
+
+package redaction
+
+import (
+	"errors"
+
+	"github.com/go-logr/logr"
+)
+
+// Logger returns logger with its messages, errors and values redacted
+func Logger(logger logr.Logger, r *Redactor) logr.Logger {
+	if r == nil || r.disabled || logger.GetSink() == nil {
+		return logger
+	}
+	return logger.WithSink(&sink{sink: logger.GetSink(), redactor: r})
+}
+
+// sink redacts what it passes to the sink it wraps
+type sink struct {
+	sink     logr.LogSink
+	redactor *Redactor
+}
+
+var _ logr.CallDepthLogSink = &sink{}
+
+func (s *sink) Init(info logr.RuntimeInfo) {
+	// One frame more for the wrapper
+	info.CallDepth++
+	s.sink.Init(info)
+}
+
+func (s *sink) Enabled(level int) bool {
+	return s.sink.Enabled(level)
+}
+
+func (s *sink) Info(level int, msg string, keysAndValues ...interface{}) {
+	s.sink.Info(level, s.redactor.Text(msg), s.redactor.KeysAndValues(keysAndValues)...)
+}
+
+func (s *sink) Error(err error, msg string, keysAndValues ...interface{}) {
+	if err != nil {
+		if text := s.redactor.Text(err.Error()); text != err.Error() {
+			err = errors.New(text)
+		}
+	}
+	s.sink.Error(err, s.redactor.Text(msg), s.redactor.KeysAndValues(keysAndValues)...)
+}
+
+func (s *sink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &sink{sink: s.sink.WithValues(s.redactor.KeysAndValues(keysAndValues)...), redactor: s.redactor}
+}
+
+func (s *sink) WithName(name string) logr.LogSink {
+	return &sink{sink: s.sink.WithName(name), redactor: s.redactor}
+}
+
+func (s *sink) WithCallDepth(depth int) logr.LogSink {
+	if withCallDepth, ok := s.sink.(logr.CallDepthLogSink); ok {
+		return &sink{sink: withCallDepth.WithCallDepth(depth), redactor: s.redactor}
+	}
+	return s
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2025 Gunjan Patil.
+
+Licensed under the MIT License.
+*/
+
+// Package redaction masks secrets, tokens and passwords in migration
+// reports, dry-run diffs and logs. Structured values are masked by field-path
+// rules, free text by the patterns of credentials embedded in it.
+package redaction
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultMask replaces redacted values
+const DefaultMask = "[REDACTED]"
+
+// DefaultRules are the field paths redacted when a policy has no rules of its
+// own. Segments match case-insensitively, so "**.*password" matches
+// spec.components.grafana.adminPassword.
+var DefaultRules = []string{
+	"**.*password",
+	"**.passwd",
+	"**.*token",
+	"**.*secret",
+	"**.*apikey",
+	"**.*api_key",
+	"**.*accesskey",
+	"**.*secretkey",
+	"**.privatekey",
+	"**.credentials",
+	"**.authorization",
+}
+
+// Policy configures redaction
+type Policy struct {
+	// Disabled turns redaction off
+	Disabled bool `json:"disabled,omitempty"`
+	// Rules are dotted field paths to redact. "*" matches one segment, or
+	// part of one, and "**" any number of segments. DefaultRules apply when
+	// empty.
+	Rules []string `json:"rules,omitempty"`
+	// AdditionalRules are redacted on top of Rules, or of DefaultRules
+	AdditionalRules []string `json:"additionalRules,omitempty"`
+	// Mask replaces redacted values, DefaultMask when empty
+	Mask string `json:"mask,omitempty"`
+}
+
+// textPatterns match credentials embedded in free text. The credential
+// between the first and the optional second group is masked.
+var textPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)((?:password|passwd|secret|token|api[_-]?key|access[_-]?key)["']?\s*[:=]\s*["']?)[^\s"',;&}]+`),
+	regexp.MustCompile(`(?i)((?:bearer|basic)\s+)[a-z0-9._~+/=-]+`),
+	regexp.MustCompile(`(://[^/\s:@]+:)[^/\s@]+(@)`),
+}
+
+// Redactor applies a policy
+type Redactor struct {
+	rules    [][]string
+	mask     string
+	disabled bool
+}
+
+// New returns the redactor of policy, the default one if nil
+func New(policy *Policy) (*Redactor, error) {
+	if policy == nil {
+		policy = &Policy{}
+	}
+	r := &Redactor{mask: policy.Mask, disabled: policy.Disabled}
+	if r.mask == "" {
+		r.mask = DefaultMask
+	}
+	rules := policy.Rules
+	if len(rules) == 0 {
+		rules = DefaultRules
+	}
+	for _, rule := range append(append([]string{}, rules...), policy.AdditionalRules...) {
+		segments := strings.Split(strings.ToLower(rule), ".")
+		for _, segment := range segments {
+			if segment == "" {
+				return nil, fmt.Errorf("invalid redaction rule %q: empty segment", rule)
+			}
+			if _, err := path.Match(segment, ""); err != nil {
+				return nil, fmt.Errorf("invalid redaction rule %q: %w", rule, err)
+			}
+		}
+		r.rules = append(r.rules, segments)
+	}
+	return r, nil
+}
+
+// defaultRedactor is the redactor of the process, see SetDefault
+var defaultRedactor atomic.Pointer[Redactor]
+
+func init() {
+	r, _ := New(nil)
+	defaultRedactor.Store(r)
+}
+
+// Default returns the redactor of the process, the one of the default policy
+// unless SetDefault replaced it
+func Default() *Redactor {
+	return defaultRedactor.Load()
+}
+
+// SetDefault replaces the redactor of the process, applied to reports,
+// dry-run diffs and logs written without a redactor of their own
+func SetDefault(r *Redactor) {
+	if r != nil {
+		defaultRedactor.Store(r)
+	}
+}
+
+// Mask returns the string replacing redacted values
+func (r *Redactor) Mask() string {
+	return r.mask
+}
+
+// Matches returns whether the value at a dotted field path is redacted
+func (r *Redactor) Matches(fieldPath string) bool {
+	if r == nil || r.disabled || fieldPath == "" {
+		return false
+	}
+	segments := strings.Split(strings.ToLower(fieldPath), ".")
+	for _, rule := range r.rules {
+		if matchSegments(rule, segments) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value returns a copy of the value at fieldPath with the values of the
+// fields matching the rules masked and credentials in strings masked. The
+// root of a value is at the empty path. Structs are redacted as their JSON
+// form.
+func (r *Redactor) Value(fieldPath string, value interface{}) interface{} {
+	if r == nil || r.disabled || value == nil {
+		return value
+	}
+	if r.Matches(fieldPath) {
+		if isEmpty(value) {
+			return value
+		}
+		return r.mask
+	}
+	switch v := value.(type) {
+	case string:
+		return r.Text(v)
+	case bool, int, int32, int64, float32, float64, json.Number:
+		return v
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			redacted[key] = r.Value(join(fieldPath, key), item)
+		}
+		return redacted
+	case map[string]string:
+		redacted := make(map[string]string, len(v))
+		for key, item := range v {
+			redacted[key] = r.Value(join(fieldPath, key), item).(string)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = r.Value(fieldPath, item)
+		}
+		return redacted
+	case []string:
+		redacted := make([]string, len(v))
+		for i, item := range v {
+			redacted[i] = r.Value(fieldPath, item).(string)
+		}
+		return redacted
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return value
+	}
+	return r.Value(fieldPath, generic)
+}
+
+// JSON redacts a JSON document, keeping its indentation
+func (r *Redactor) JSON(data []byte) ([]byte, error) {
+	if r == nil || r.disabled || len(data) == 0 {
+		return data, nil
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, fmt.Errorf("failed to redact JSON: %w", err)
+	}
+	redacted := r.Value("", generic)
+	if strings.Contains(string(data), "\n") {
+		return json.MarshalIndent(redacted, "", "  ")
+	}
+	return json.Marshal(redacted)
+}
+
+// Text masks credentials embedded in free text, such as password=...,
+// bearer tokens and passwords of URLs
+func (r *Redactor) Text(text string) string {
+	if r == nil || r.disabled {
+		return text
+	}
+	for _, pattern := range textPatterns {
+		text = pattern.ReplaceAllString(text, "${1}"+r.mask+"${2}")
+	}
+	return text
+}
+
+// KeysAndValues redacts logr key/value pairs, matching keys as field paths
+func (r *Redactor) KeysAndValues(keysAndValues []interface{}) []interface{} {
+	if r == nil || r.disabled || len(keysAndValues) == 0 {
+		return keysAndValues
+	}
+	redacted := make([]interface{}, len(keysAndValues))
+	for i := 0; i < len(keysAndValues); i += 2 {
+		redacted[i] = keysAndValues[i]
+		if i+1 == len(keysAndValues) {
+			break
+		}
+		key, _ := keysAndValues[i].(string)
+		redacted[i+1] = r.logValue(key, keysAndValues[i+1])
+	}
+	return redacted
+}
+
+// logValue redacts a logged value. Errors and Stringers are logged as
+// their text; other values only when redaction changed them, so loggers keep
+// their own encoding.
+func (r *Redactor) logValue(key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case error:
+		if text := r.Text(v.Error()); text != v.Error() || r.Matches(key) {
+			return r.Value(key, text)
+		}
+		return v
+	case fmt.Stringer:
+		if r.Matches(key) {
+			return r.mask
+		}
+		if text := r.Text(v.String()); text != v.String() {
+			return text
+		}
+		return v
+	}
+	redacted := r.Value(key, value)
+	if reflect.DeepEqual(redacted, value) {
+		return value
+	}
+	return redacted
+}
+
+// matchSegments matches field path segments against rule segments
+func matchSegments(rule, segments []string) bool {
+	if len(rule) == 0 {
+		return len(segments) == 0
+	}
+	if rule[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(rule[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(rule[0], segments[0]); !ok {
+		return false
+	}
+	return matchSegments(rule[1:], segments[1:])
+}
+
+func join(fieldPath, key string) string {
+	if fieldPath == "" {
+		return key
+	}
+	return fieldPath + "." + key
+}
+
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return v == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+// CorrelationSpec keeps the labels Grafana correlates signals on, and the
+// tenant they are written as, the same for metrics, logs and traces. Grafana
+// jumps from a metric to its logs and traces by matching labels such as the
+// cluster; a label set on one signal but not the others, or with another
+// value, silently breaks the link.
+type CorrelationSpec struct {
+	// Labels identify the source of all signals, e.g. cluster, environment
+	// and region. They are added to the external labels of Prometheus, to
+	// the metrics generated by Tempo, to the logs collected by Alloy and to
+	// the resource attributes of the traces received by Alloy.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// TenantID is the tenant Alloy writes logs and traces as, sent in the
+	// X-Scope-OrgID header. The query API queries as the same tenant, unless
+	// the API key has its own. Defaults to fake, the tenant of Loki and
+	// Tempo without multi-tenancy.
+	// +kubebuilder:validation:MaxLength=150
+	// +optional
+	TenantID string `json:"tenantId,omitempty"`
+}
+
+// CorrelationLabels returns the correlation labels of the platform
+func (p *ObservabilityPlatform) CorrelationLabels() map[string]string {
+	if p.Spec.Global == nil || p.Spec.Global.Correlation == nil {
+		return nil
+	}
+	return p.Spec.Global.Correlation.Labels
+}
+
+// CorrelationTenantID returns the tenant the platform's signals are written
+// as, empty if it is not set
+func (p *ObservabilityPlatform) CorrelationTenantID() string {
+	if p.Spec.Global == nil || p.Spec.Global.Correlation == nil {
+		return ""
+	}
+	return p.Spec.Global.Correlation.TenantID
+}
@@ -22,6 +22,8 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/gunjanjp/gunj-operator/internal/querycheck"
 )
 
 // log is for logging in this package.
@@ -638,6 +640,9 @@ func (r *Dashboard) validateDashboard() (admission.Warnings, error) {
 				warnings = append(warnings, fmt.Sprintf(
 					"target %s in panel %d has no query defined", target.RefID, panel.ID))
 			}
+
+			// Validate LogQL and TraceQL queries
+			allErrs = append(allErrs, validateTargetQueries(target, targetPath)...)
 		}
 
 		// Validate panel type specific options
@@ -860,6 +865,38 @@ func floatPtr(f float64) *float64 {
 	return &f
 }
 
+// validateTargetQueries checks the syntax of the LogQL and TraceQL queries of
+// a target: the expressions of Loki and Tempo data sources, and the log and
+// trace queries. Queries with dashboard variables, like $namespace, are only
+// valid once Grafana interpolates them and are not checked.
+func validateTargetQueries(target Target, targetPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	check := func(path *field.Path, query string, parse func(string) error) {
+		if query == "" || strings.Contains(query, "$") || strings.Contains(query, "[[") {
+			return
+		}
+		if err := parse(query); err != nil {
+			allErrs = append(allErrs, field.Invalid(path, query, err.Error()))
+		}
+	}
+
+	if target.DataSource != nil {
+		switch target.DataSource.Type {
+		case "loki":
+			check(targetPath.Child("expr"), target.Expr, querycheck.LogQL)
+		case "tempo":
+			check(targetPath.Child("expr"), target.Expr, querycheck.TraceQL)
+		}
+	}
+	if target.LogQuery != nil {
+		check(targetPath.Child("logQuery", "query"), target.LogQuery.Query, querycheck.LogQL)
+	}
+	if target.TraceQuery != nil {
+		check(targetPath.Child("traceQuery", "query"), target.TraceQuery.Query, querycheck.TraceQL)
+	}
+	return allErrs
+}
+
 // isValidFolderName checks if a folder name is valid
 func isValidFolderName(name string) bool {
 	validFolder := regexp.MustCompile(`^[a-zA-Z0-9 _-]+$`)
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DashboardsFromGitSpec imports the JSON and Jsonnet dashboards of a Git
+// repository into Grafana. Dashboards are imported through the Grafana API,
+// so they stay editable in Grafana; edits made in Grafana are reported as
+// drift.
+type DashboardsFromGitSpec struct {
+	// Repo is the URL of the Git repository (https:// or ssh://)
+	// +kubebuilder:validation:MinLength=1
+	Repo string `json:"repo"`
+
+	// Ref is the branch, tag or commit to import from
+	// +kubebuilder:default="main"
+	// +optional
+	Ref string `json:"ref,omitempty"`
+
+	// Path is the directory holding the dashboards. *.json dashboards below
+	// it are imported and *.jsonnet sources are rendered; imports are
+	// resolved against the repository and its vendor directory.
+	// +kubebuilder:default="/"
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// PollInterval is how often the repository is checked for changes
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +kubebuilder:default="5m"
+	// +optional
+	PollInterval string `json:"pollInterval,omitempty"`
+
+	// AuthSecretRef references a Secret with the credentials of the
+	// repository: username and password (or token) for HTTPS, or
+	// ssh-privatekey and known_hosts for SSH
+	// +optional
+	AuthSecretRef *corev1.LocalObjectReference `json:"authSecretRef,omitempty"`
+
+	// ExtVars are the external variables available to Jsonnet sources
+	// through std.extVar
+	// +optional
+	ExtVars map[string]string `json:"extVars,omitempty"`
+
+	// DriftPolicy is how dashboards edited in Grafana are handled: Report
+	// keeps the edits and reports the dashboards as drifted, Overwrite
+	// restores the version in Git
+	// +kubebuilder:validation:Enum=Report;Overwrite
+	// +kubebuilder:default="Report"
+	// +optional
+	DriftPolicy string `json:"driftPolicy,omitempty"`
+}
+
+// DashboardsFromGitStatus is the state of the dashboard import from Git
+type DashboardsFromGitStatus struct {
+	// Revision is the imported commit
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// LastSyncTime is when the repository was last synced
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Dashboards are the imported dashboards
+	// +optional
+	Dashboards []GitDashboardStatus `json:"dashboards,omitempty"`
+
+	// Message holds the error of the last failed sync
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// GitDashboardStatus is the state of a dashboard imported from Git
+type GitDashboardStatus struct {
+	// UID of the dashboard in Grafana
+	UID string `json:"uid"`
+
+	// Title of the dashboard
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// File is the path of the dashboard in the repository
+	File string `json:"file"`
+
+	// Checksum identifies the imported content
+	Checksum string `json:"checksum"`
+
+	// Version is the Grafana version of the imported dashboard
+	Version int64 `json:"version"`
+
+	// Drifted is true if the dashboard was edited in Grafana since it was imported
+	// +optional
+	Drifted bool `json:"drifted,omitempty"`
+}
+
+// GetRef returns the Git ref dashboards are imported from
+func (d *DashboardsFromGitSpec) GetRef() string {
+	if d.Ref == "" {
+		return "main"
+	}
+	return d.Ref
+}
+
+// GetPath returns the repository directory holding the dashboards
+func (d *DashboardsFromGitSpec) GetPath() string {
+	if d.Path == "" {
+		return "/"
+	}
+	return d.Path
+}
+
+// GetPollInterval returns how often the repository is checked for changes
+func (d *DashboardsFromGitSpec) GetPollInterval() time.Duration {
+	if interval, err := time.ParseDuration(d.PollInterval); err == nil && interval > 0 {
+		return interval
+	}
+	return 5 * time.Minute
+}
+
+// OverwritesDrift returns true if dashboards edited in Grafana are restored from Git
+func (d *DashboardsFromGitSpec) OverwritesDrift() bool {
+	return d.DriftPolicy == "Overwrite"
+}
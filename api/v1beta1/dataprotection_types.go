@@ -0,0 +1,102 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeletionPolicy controls what happens to the data of a platform when it is
+// deleted
+// +kubebuilder:validation:Enum=Retain;Delete;Snapshot
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyRetain keeps the PersistentVolumeClaims and the object
+	// storage data
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicyDelete deletes the PersistentVolumeClaims and the object
+	// storage data
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicySnapshot takes a VolumeSnapshot of every
+	// PersistentVolumeClaim before deleting them, and keeps the object
+	// storage data
+	DeletionPolicySnapshot DeletionPolicy = "Snapshot"
+)
+
+// DataProtectionSpec defines how the data of a platform is protected
+type DataProtectionSpec struct {
+	// DeletionPolicy controls what happens to the PersistentVolumeClaims and
+	// the object storage data when the platform is deleted. The platform is
+	// only removed once the policy completed. If unset, the
+	// PersistentVolumeClaims are deleted and the object storage data is kept.
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// VolumeSnapshotClassName is the class of the VolumeSnapshots taken by
+	// the Snapshot policy, the cluster's default class if empty
+	// +optional
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+}
+
+// GetDeletionPolicy returns the deletion policy of the PersistentVolumeClaims,
+// Delete if unset
+func (d *DataProtectionSpec) GetDeletionPolicy() DeletionPolicy {
+	if d == nil || d.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+	return d.DeletionPolicy
+}
+
+// DeletesObjectStorage returns true if the object storage data is deleted
+// with the platform. It must be asked for explicitly.
+func (d *DataProtectionSpec) DeletesObjectStorage() bool {
+	return d != nil && d.DeletionPolicy == DeletionPolicyDelete
+}
+
+// DataProtectionStatus reports the progress of the deletion policy
+type DataProtectionStatus struct {
+	// Policy is the deletion policy being applied
+	Policy DeletionPolicy `json:"policy"`
+
+	// Phase is InProgress, Completed or Failed
+	Phase string `json:"phase"`
+
+	// Message provides additional information about the progress
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Volumes reports the action taken for each PersistentVolumeClaim
+	// +optional
+	Volumes []VolumeProtectionStatus `json:"volumes,omitempty"`
+
+	// ObjectStorage reports the object storage data: Retained, Deleting or
+	// Deleted
+	// +optional
+	ObjectStorage string `json:"objectStorage,omitempty"`
+
+	// StartTime is when the policy started to be applied
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the policy completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// VolumeProtectionStatus reports the action taken for a PersistentVolumeClaim
+type VolumeProtectionStatus struct {
+	// Name of the PersistentVolumeClaim
+	Name string `json:"name"`
+
+	// Action is Retained, Snapshotting, Snapshotted or Deleted
+	Action string `json:"action"`
+
+	// Snapshot is the name of the VolumeSnapshot taken of the claim
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultedFieldsAnnotation records the spec fields the defaulting webhook
+// set on a platform with a base platform. The controller leaves them out when
+// it merges the platform onto its base, so the defaults of the platform don't
+// hide the values of the base; the merged spec is defaulted again instead.
+const DefaultedFieldsAnnotation = "observability.io/defaulted-fields"
+
+// DefaultedField is a spec field the user did not set
+type DefaultedField struct {
+	// Path is the path of the field below spec, one element per key
+	Path []string `json:"path"`
+
+	// Value is the default value
+	Value interface{} `json:"value"`
+}
+
+// DefaultedFields returns the spec fields defaulted on a platform
+func DefaultedFields(obj metav1.Object) []DefaultedField {
+	var fields []DefaultedField
+	if err := json.Unmarshal([]byte(obj.GetAnnotations()[DefaultedFieldsAnnotation]), &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// recordDefaultedFields records the fields of the spec the defaulters added
+// to before, the spec as it was sent. On updates the fields defaulted before
+// are kept while they still hold their default: the client sends them back
+// like fields it set. Platforms without a base record none.
+func recordDefaultedFields(r *ObservabilityPlatform, before map[string]interface{}) {
+	if r.Spec.BasePlatformRef == nil {
+		setDefaultedFields(r, nil)
+		return
+	}
+	after := specFields(r.Spec)
+	if before == nil || after == nil {
+		return
+	}
+
+	var fields []DefaultedField
+	recorded := map[string]bool{}
+	for _, f := range DefaultedFields(r) {
+		if value, ok := lookupPath(after, f.Path); ok && reflect.DeepEqual(value, f.Value) {
+			fields = append(fields, f)
+			recorded[strings.Join(f.Path, "\x00")] = true
+		}
+	}
+	for _, f := range addedFields(before, after, nil) {
+		if !recorded[strings.Join(f.Path, "\x00")] {
+			fields = append(fields, f)
+		}
+	}
+	setDefaultedFields(r, fields)
+}
+
+// addedFields returns the fields of after missing from before. Objects are
+// compared key by key; a missing object is one field.
+func addedFields(before, after map[string]interface{}, path []string) []DefaultedField {
+	var fields []DefaultedField
+	for key, value := range after {
+		fieldPath := append(path[:len(path):len(path)], key)
+		previous, ok := before[key]
+		if !ok {
+			fields = append(fields, DefaultedField{Path: fieldPath, Value: value})
+			continue
+		}
+		previousObject, previousIsObject := previous.(map[string]interface{})
+		object, isObject := value.(map[string]interface{})
+		if previousIsObject && isObject {
+			fields = append(fields, addedFields(previousObject, object, fieldPath)...)
+		}
+	}
+	return fields
+}
+
+// lookupPath returns the value at a path of a decoded JSON object
+func lookupPath(fields map[string]interface{}, path []string) (interface{}, bool) {
+	var value interface{} = fields
+	for _, name := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// specFields returns the spec decoded as JSON, nil if it can't be encoded
+func specFields(spec ObservabilityPlatformSpec) map[string]interface{} {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+func setDefaultedFields(obj metav1.Object, fields []DefaultedField) {
+	annotations := obj.GetAnnotations()
+	if len(fields) == 0 {
+		if _, ok := annotations[DefaultedFieldsAnnotation]; ok {
+			delete(annotations, DefaultedFieldsAnnotation)
+			obj.SetAnnotations(annotations)
+		}
+		return
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		return strings.Join(fields[i].Path, ".") < strings.Join(fields[j].Path, ".")
+	})
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[DefaultedFieldsAnnotation] = string(data)
+	obj.SetAnnotations(annotations)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// EBPFAgentSpec configures the eBPF agent (Grafana Beyla) instrumenting the
+// HTTP and gRPC services on every node without code changes. It generates
+// RED metrics, service graph metrics and traces.
+type EBPFAgentSpec struct {
+	// Enabled determines if the eBPF agent is deployed
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Version of Beyla to deploy
+	// +kubebuilder:validation:Pattern=`^v?\d+\.\d+\.\d+(-[a-zA-Z0-9]+)?$`
+	// +kubebuilder:default="1.8.4"
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Namespaces restricts instrumentation to the services in these
+	// namespaces. Services in all namespaces are instrumented if empty.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Metrics determines if RED metrics are exposed for the platform's
+	// Prometheus to scrape. Defaults to true when Prometheus is enabled.
+	// +optional
+	Metrics *bool `json:"metrics,omitempty"`
+
+	// ServiceGraph determines if service graph metrics are generated, the
+	// calls between services for Grafana's service map
+	// +kubebuilder:default=true
+	// +optional
+	ServiceGraph *bool `json:"serviceGraph,omitempty"`
+
+	// Traces determines if spans are exported to the platform's Tempo.
+	// Defaults to true when Tempo is enabled.
+	// +optional
+	Traces *bool `json:"traces,omitempty"`
+
+	// Resources of the agent container on each node
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// GetVersion returns the Beyla version, 1.8.4 if not set
+func (s *EBPFAgentSpec) GetVersion() string {
+	if s.Version == "" {
+		return "1.8.4"
+	}
+	return s.Version
+}
+
+// EBPFAgentEnabled returns true if the eBPF agent is deployed
+func (c *Components) EBPFAgentEnabled() bool {
+	return c != nil && c.EBPFAgent != nil && c.EBPFAgent.Enabled
+}
+
+// EBPFAgentMetrics returns true if the eBPF agent exposes metrics for the
+// platform's Prometheus
+func (c *Components) EBPFAgentMetrics() bool {
+	prometheus := c.Prometheus != nil && c.Prometheus.Enabled
+	if !c.EBPFAgentEnabled() || !prometheus {
+		return false
+	}
+	return c.EBPFAgent.Metrics == nil || *c.EBPFAgent.Metrics
+}
+
+// EBPFAgentServiceGraph returns true if the eBPF agent generates service
+// graph metrics
+func (c *Components) EBPFAgentServiceGraph() bool {
+	return c.EBPFAgentMetrics() && (c.EBPFAgent.ServiceGraph == nil || *c.EBPFAgent.ServiceGraph)
+}
+
+// EBPFAgentTraces returns true if the eBPF agent exports traces to the
+// platform's Tempo
+func (c *Components) EBPFAgentTraces() bool {
+	tempo := c.Tempo != nil && c.Tempo.Enabled
+	if !c.EBPFAgentEnabled() || !tempo {
+		return false
+	}
+	return c.EBPFAgent.Traces == nil || *c.EBPFAgent.Traces
+}
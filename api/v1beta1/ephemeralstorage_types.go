@@ -0,0 +1,58 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+// EphemeralStorageSpec configures the node-local storage used by a
+// component: the ephemeral-storage requests and limits of its container, the
+// size of its emptyDir volumes and an optional dedicated WAL volume. Without
+// these settings scratch data is unbounded and busy nodes evict the pods.
+type EphemeralStorageSpec struct {
+	// Request is the ephemeral-storage request of the component's container
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?(Ki|Mi|Gi|Ti|K|M|G|T)?$`
+	// +optional
+	Request string `json:"request,omitempty"`
+
+	// Limit is the ephemeral-storage limit of the component's container
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?(Ki|Mi|Gi|Ti|K|M|G|T)?$`
+	// +optional
+	Limit string `json:"limit,omitempty"`
+
+	// EmptyDirSizeLimit caps every emptyDir volume of the component
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?(Ki|Mi|Gi|Ti|K|M|G|T)?$`
+	// +optional
+	EmptyDirSizeLimit string `json:"emptyDirSizeLimit,omitempty"`
+
+	// EmptyDirMedium of the component's emptyDir volumes. Memory-backed
+	// volumes count against the container's memory limit.
+	// +kubebuilder:validation:Enum="";Memory
+	// +optional
+	EmptyDirMedium string `json:"emptyDirMedium,omitempty"`
+
+	// WAL places the write-ahead log on a dedicated volume, e.g. a local SSD.
+	// Only used by components with a WAL (Prometheus, Loki and Tempo).
+	// +optional
+	WAL *WALVolumeSpec `json:"wal,omitempty"`
+}
+
+// WALVolumeSpec configures a dedicated write-ahead log volume. The volume
+// lives as long as the pod, so the WAL is not replayed after the pod is
+// rescheduled; data not yet flushed to long-term storage is lost then.
+type WALVolumeSpec struct {
+	// Size of the WAL volume
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?(Ki|Mi|Gi|Ti|K|M|G|T)?$`
+	Size string `json:"size"`
+
+	// StorageClassName of a generic ephemeral volume backing the WAL, e.g. a
+	// local SSD provisioner. If empty, the WAL uses an emptyDir volume.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// Medium of the emptyDir volume if no storage class is set
+	// +kubebuilder:validation:Enum="";Memory
+	// +optional
+	Medium string `json:"medium,omitempty"`
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// EventExporterSpec configures the export of Kubernetes events into the
+// platform's Loki, where they outlive the API server's event TTL
+type EventExporterSpec struct {
+	// Enabled determines if the event exporter is deployed
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Version of kubernetes-event-exporter to deploy
+	// +kubebuilder:default="v1.7"
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Namespace restricts the export to the events of one namespace. Events
+	// of all namespaces are exported if empty.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// WarningsOnly drops Normal events
+	// +optional
+	WarningsOnly bool `json:"warningsOnly,omitempty"`
+
+	// Dashboard determines if the Kubernetes events dashboard is provisioned
+	// +kubebuilder:default=true
+	// +optional
+	Dashboard *bool `json:"dashboard,omitempty"`
+
+	// Alerts configures the alerts on spikes of Warning events
+	// +optional
+	Alerts *EventAlertsSpec `json:"alerts,omitempty"`
+
+	// Resources of the exporter container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// EventAlertsSpec configures the alerts on Warning event spikes, evaluated by
+// Loki's ruler
+type EventAlertsSpec struct {
+	// Enabled determines if the alerts are provisioned
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// WarningThreshold is the number of Warning events of a namespace in 5
+	// minutes above which an alert fires
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=50
+	// +optional
+	WarningThreshold int32 `json:"warningThreshold,omitempty"`
+}
+
+// GetVersion returns the exporter version, v1.7 if not set
+func (s *EventExporterSpec) GetVersion() string {
+	if s.Version == "" {
+		return "v1.7"
+	}
+	return s.Version
+}
+
+// DashboardEnabled returns true if the events dashboard is provisioned
+func (s *EventExporterSpec) DashboardEnabled() bool {
+	return s.Dashboard == nil || *s.Dashboard
+}
+
+// AlertsEnabled returns true if the Warning spike alerts are provisioned
+func (s *EventExporterSpec) AlertsEnabled() bool {
+	return s.Alerts == nil || s.Alerts.Enabled == nil || *s.Alerts.Enabled
+}
+
+// GetWarningThreshold returns the Warning events in 5 minutes above which an
+// alert fires, 50 if not set
+func (s *EventExporterSpec) GetWarningThreshold() int32 {
+	if s.Alerts == nil || s.Alerts.WarningThreshold == 0 {
+		return 50
+	}
+	return s.Alerts.WarningThreshold
+}
+
+// EventExporterEnabled returns true if Kubernetes events are exported into
+// the platform's Loki
+func (c *Components) EventExporterEnabled() bool {
+	return c != nil && c.EventExporter != nil && c.EventExporter.Enabled &&
+		c.Loki != nil && c.Loki.Enabled
+}
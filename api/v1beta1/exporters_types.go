@@ -0,0 +1,83 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ExporterSpec deploys a well-known Prometheus exporter for a service the
+// platform monitors. The exporter is scraped by the platform's Prometheus and
+// comes with a dashboard and default alerts.
+type ExporterSpec struct {
+	// Type of the exporter
+	// +kubebuilder:validation:Enum=postgres;redis;kafka;nginx;jvm
+	Type string `json:"type"`
+
+	// Name distinguishes several exporters of the same type. Defaults to the
+	// type.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Address of the monitored service: host:port[/database?options] for
+	// postgres, host:port for redis and jvm (the JMX port), comma separated
+	// brokers for kafka and the stub_status URL for nginx
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+
+	// Username the exporter connects as
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecretRef references the password the exporter connects with.
+	// Not supported by the nginx and jvm exporters.
+	// +optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// Args are extra arguments of the exporter
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Version of the exporter image, the catalog's version if not set
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Resources of the exporter container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Dashboard determines if the exporter's dashboard is provisioned
+	// +kubebuilder:default=true
+	// +optional
+	Dashboard *bool `json:"dashboard,omitempty"`
+
+	// Alerts determines if the exporter's default alerts are loaded into
+	// Prometheus
+	// +kubebuilder:default=true
+	// +optional
+	Alerts *bool `json:"alerts,omitempty"`
+}
+
+// GetName returns the name of the exporter, its type if not set
+func (e *ExporterSpec) GetName() string {
+	if e.Name == "" {
+		return e.Type
+	}
+	return e.Name
+}
+
+// DashboardEnabled returns true if the exporter's dashboard is provisioned
+func (e *ExporterSpec) DashboardEnabled() bool {
+	return e.Dashboard == nil || *e.Dashboard
+}
+
+// AlertsEnabled returns true if the exporter's default alerts are loaded
+func (e *ExporterSpec) AlertsEnabled() bool {
+	return e.Alerts == nil || *e.Alerts
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExternalLabelTransitionSpec configures how changes of the Prometheus
+// external labels are rolled out. Remote storage sees new series when an
+// external label changes; during the transition window every remote write
+// target also receives the series with the previous labels, so queries on
+// either label set see no gap while dashboards and rules are moved over.
+type ExternalLabelTransitionSpec struct {
+	// Enabled turns on the dual-write window when the external labels change
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Window is how long the series are written with both label sets
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +kubebuilder:default="24h"
+	// +optional
+	Window string `json:"window,omitempty"`
+
+	// DashboardUIDs are the Grafana dashboards annotated with the window.
+	// When empty, an organization-wide annotation is created.
+	// +optional
+	DashboardUIDs []string `json:"dashboardUIDs,omitempty"`
+}
+
+// ExternalLabelTransitionStatus tracks the dual-write window of an external
+// label change
+type ExternalLabelTransitionStatus struct {
+	// PreviousLabels are the external labels before the change, which the
+	// series are also written with until End
+	PreviousLabels map[string]string `json:"previousLabels,omitempty"`
+
+	// Labels are the external labels after the change
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Start is when the labels changed
+	Start metav1.Time `json:"start"`
+
+	// End is when the series stop being written with the previous labels
+	End metav1.Time `json:"end"`
+
+	// AnnotationIDs are the Grafana annotation IDs marking the window
+	// +optional
+	AnnotationIDs []int64 `json:"annotationIDs,omitempty"`
+}
+
+// IsEnabled returns true if external label changes are rolled out with a
+// dual-write window
+func (s *ExternalLabelTransitionSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// GetWindow returns how long both label sets are written, 24h if not set
+func (s *ExternalLabelTransitionSpec) GetWindow() time.Duration {
+	if d, err := time.ParseDuration(s.Window); err == nil && d > 0 {
+		return d
+	}
+	return 24 * time.Hour
+}
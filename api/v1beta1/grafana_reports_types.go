@@ -0,0 +1,238 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrafanaImageRendererSpec configures grafana-image-renderer, the service
+// Grafana renders panels and dashboards to images with
+type GrafanaImageRendererSpec struct {
+	// Enabled determines if the image renderer is deployed
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Version of grafana-image-renderer to deploy
+	// +kubebuilder:default="3.10.0"
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Replicas is the number of renderer instances
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Resources of the renderer container. Rendering runs a headless
+	// Chromium, so allow for several hundred MiB of memory.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// GrafanaReportSpec is a dashboard rendered on a schedule and delivered to
+// its recipients. Reports require the image renderer.
+type GrafanaReportSpec struct {
+	// Name identifies the report
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// DashboardUID is the UID of the rendered dashboard
+	// +kubebuilder:validation:MinLength=1
+	DashboardUID string `json:"dashboardUID"`
+
+	// Schedule is a cron expression in UTC, e.g. "0 8 * * 1" for Mondays at
+	// 08:00, or one of @hourly, @daily, @weekly and @monthly
+	Schedule string `json:"schedule"`
+
+	// Format of the report
+	// +kubebuilder:validation:Enum=pdf;png
+	// +kubebuilder:default="pdf"
+	// +optional
+	Format string `json:"format,omitempty"`
+
+	// From is the start of the rendered time range
+	// +kubebuilder:default="now-24h"
+	// +optional
+	From string `json:"from,omitempty"`
+
+	// To is the end of the rendered time range
+	// +kubebuilder:default="now"
+	// +optional
+	To string `json:"to,omitempty"`
+
+	// Width of the rendered dashboard in pixels
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:default=1600
+	// +optional
+	Width int32 `json:"width,omitempty"`
+
+	// Height of the rendered dashboard in pixels
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:default=900
+	// +optional
+	Height int32 `json:"height,omitempty"`
+
+	// Variables set the dashboard's template variables
+	// +optional
+	Variables map[string]string `json:"variables,omitempty"`
+
+	// Recipients the report is delivered to
+	Recipients GrafanaReportRecipients `json:"recipients"`
+
+	// Suspend stops the report from running
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// GrafanaReportRecipients are the email addresses and webhooks a report is
+// delivered to
+type GrafanaReportRecipients struct {
+	// Emails are the addresses the report is mailed to through SMTP
+	// +optional
+	Emails []string `json:"emails,omitempty"`
+
+	// SMTP is the mail server; required with emails
+	// +optional
+	SMTP *GrafanaReportSMTPSpec `json:"smtp,omitempty"`
+
+	// Webhooks receive the report as the body of a POST request
+	// +optional
+	Webhooks []GrafanaReportWebhook `json:"webhooks,omitempty"`
+}
+
+// GrafanaReportSMTPSpec is the mail server reports are sent through
+type GrafanaReportSMTPSpec struct {
+	// Host of the mail server
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+
+	// Port of the mail server. STARTTLS is used if the server supports it.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=587
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// From is the sender address
+	// +kubebuilder:validation:MinLength=1
+	From string `json:"from"`
+
+	// CredentialsSecretRef references a Secret with the username and
+	// password keys to authenticate with. Credentials are only sent over
+	// TLS.
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// GrafanaReportWebhook is an HTTP endpoint receiving reports
+type GrafanaReportWebhook struct {
+	// URL of the endpoint
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// BearerTokenSecretRef selects a Secret key holding the token sent in
+	// the Authorization header
+	// +optional
+	BearerTokenSecretRef *corev1.SecretKeySelector `json:"bearerTokenSecretRef,omitempty"`
+}
+
+// GrafanaReportStatus is the state of a scheduled report
+type GrafanaReportStatus struct {
+	// Name of the report
+	Name string `json:"name"`
+
+	// Schedule the next run was computed from
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// NextRunTime is when the report runs next
+	// +optional
+	NextRunTime *metav1.Time `json:"nextRunTime,omitempty"`
+
+	// LastRunTime is when the report last ran
+	// +optional
+	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
+
+	// LastSuccessTime is when the report was last delivered to all recipients
+	// +optional
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+
+	// Message holds the error of the last failed run
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// GetVersion returns the renderer version, 3.10.0 if not set
+func (s *GrafanaImageRendererSpec) GetVersion() string {
+	if s.Version == "" {
+		return "3.10.0"
+	}
+	return s.Version
+}
+
+// GetReplicas returns the number of renderer instances, 1 if not set
+func (s *GrafanaImageRendererSpec) GetReplicas() int32 {
+	if s.Replicas < 1 {
+		return 1
+	}
+	return s.Replicas
+}
+
+// GetFormat returns the report format, pdf if not set
+func (r *GrafanaReportSpec) GetFormat() string {
+	if r.Format == "" {
+		return "pdf"
+	}
+	return r.Format
+}
+
+// GetFrom returns the start of the rendered time range
+func (r *GrafanaReportSpec) GetFrom() string {
+	if r.From == "" {
+		return "now-24h"
+	}
+	return r.From
+}
+
+// GetTo returns the end of the rendered time range
+func (r *GrafanaReportSpec) GetTo() string {
+	if r.To == "" {
+		return "now"
+	}
+	return r.To
+}
+
+// GetWidth returns the rendered width in pixels
+func (r *GrafanaReportSpec) GetWidth() int32 {
+	if r.Width == 0 {
+		return 1600
+	}
+	return r.Width
+}
+
+// GetHeight returns the rendered height in pixels
+func (r *GrafanaReportSpec) GetHeight() int32 {
+	if r.Height == 0 {
+		return 900
+	}
+	return r.Height
+}
+
+// GetPort returns the mail server port, 587 if not set
+func (s *GrafanaReportSMTPSpec) GetPort() int32 {
+	if s.Port == 0 {
+		return 587
+	}
+	return s.Port
+}
+
+// ImageRendererEnabled returns true if grafana-image-renderer is deployed
+func (g *GrafanaSpec) ImageRendererEnabled() bool {
+	return g.ImageRenderer != nil && g.ImageRenderer.Enabled
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IncidentAnnotationsSpec configures the bridge that marks component
+// incidents as Grafana annotations
+type IncidentAnnotationsSpec struct {
+	// Enabled determines if incident annotations are created
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// DashboardUIDs are the dashboards the annotations are added to.
+	// When empty, organization-wide annotations are created, which are
+	// shown on every dashboard with the built-in annotation query enabled.
+	// +optional
+	DashboardUIDs []string `json:"dashboardUIDs,omitempty"`
+
+	// Components restricts which components create incidents. Defaults to all.
+	// +optional
+	Components []string `json:"components,omitempty"`
+
+	// Tags added to every incident annotation in addition to the defaults
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+}
+
+// IncidentAnnotationStatus tracks an open incident and its Grafana annotations
+type IncidentAnnotationStatus struct {
+	// Start is when the component became degraded
+	Start metav1.Time `json:"start"`
+
+	// AnnotationIDs are the Grafana annotation IDs marking the incident
+	// +optional
+	AnnotationIDs []int64 `json:"annotationIDs,omitempty"`
+
+	// PendingDashboards are the dashboards not annotated yet, retried on the
+	// next reconcile. An empty UID is the organization-wide annotation.
+	// +optional
+	PendingDashboards []string `json:"pendingDashboards,omitempty"`
+}
+
+// IsIncidentAnnotationsEnabled returns true if the Grafana incident bridge is enabled
+func (p *ObservabilityPlatform) IsIncidentAnnotationsEnabled() bool {
+	return p.Spec.IncidentAnnotations != nil && p.Spec.IncidentAnnotations.Enabled
+}
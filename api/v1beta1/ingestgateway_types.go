@@ -0,0 +1,230 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Authentication modes of the ingest gateway
+const (
+	// IngestAuthAPIKey authenticates producers by the bearer token they send
+	IngestAuthAPIKey = "APIKey"
+	// IngestAuthMTLS authenticates producers by their client certificate
+	IngestAuthMTLS = "MTLS"
+)
+
+// DefaultTenantID is the tenant Loki and Tempo store signals as without
+// multi-tenancy. With multi-tenancy the platform's own components keep
+// writing as this tenant unless the correlation sets one, so the signals
+// written before stay readable.
+const DefaultTenantID = "fake"
+
+// Signals accepted by the ingest gateway
+const (
+	SignalMetrics = "metrics"
+	SignalLogs    = "logs"
+	SignalTraces  = "traces"
+)
+
+// IngestGatewaySpec configures the ingest gateway, which terminates OTLP,
+// Prometheus remote write and Loki push traffic from producers outside of
+// the cluster. Producers authenticate with an API key or a client
+// certificate, and their writes are routed to the backends with their
+// tenant, so they never talk to the backends directly.
+type IngestGatewaySpec struct {
+	// Enabled determines if the gateway is deployed
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Image of the gateway, an unprivileged nginx
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Replicas of the gateway
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=2
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Resources of the gateway
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Auth is how producers authenticate
+	Auth IngestGatewayAuthSpec `json:"auth"`
+
+	// Tenants that may write through the gateway. In the APIKey mode, the
+	// ApiKeys of the platform with ingest scopes are tenants as well.
+	// +optional
+	Tenants []IngestTenantSpec `json:"tenants,omitempty"`
+
+	// TLS serves the gateway over TLS; required for mTLS
+	// +optional
+	TLS *IngestGatewayTLSSpec `json:"tls,omitempty"`
+
+	// MaxBodySize is the largest accepted request body
+	// +kubebuilder:default="16m"
+	// +optional
+	MaxBodySize string `json:"maxBodySize,omitempty"`
+
+	// Service exposes the gateway, by default through a LoadBalancer
+	// +optional
+	Service *ReceiverServiceSpec `json:"service,omitempty"`
+
+	// Ingress exposes the gateway through an Ingress instead of a
+	// LoadBalancer. Not supported with mTLS, which the gateway must
+	// terminate itself.
+	// +optional
+	Ingress *IngressSpec `json:"ingress,omitempty"`
+}
+
+// IngestGatewayAuthSpec configures the authentication of producers
+type IngestGatewayAuthSpec struct {
+	// Mode of authentication
+	// +kubebuilder:validation:Enum=APIKey;MTLS
+	// +kubebuilder:default=APIKey
+	Mode string `json:"mode"`
+}
+
+// IngestGatewayTLSSpec configures the TLS of the gateway
+type IngestGatewayTLSSpec struct {
+	// SecretName is a kubernetes.io/tls Secret with the serving certificate
+	SecretName string `json:"secretName"`
+
+	// ClientCASecretName is a Secret whose ca.crt verifies client
+	// certificates. Required for mTLS.
+	// +optional
+	ClientCASecretName string `json:"clientCASecretName,omitempty"`
+}
+
+// IngestTenantSpec is a producer writing through the gateway
+type IngestTenantSpec struct {
+	// Name of the tenant, sent to the backends as the X-Scope-OrgID header.
+	// Loki and Tempo run with multi-tenancy while the gateway is enabled, so
+	// each tenant writes its logs and traces to a store of its own.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_.-]+$`
+	Name string `json:"name"`
+
+	// APIKeySecretRef is the key of a Secret holding the API key of the
+	// tenant, for the APIKey mode
+	// +optional
+	APIKeySecretRef *corev1.SecretKeySelector `json:"apiKeySecretRef,omitempty"`
+
+	// ClientCommonNames are the common names of the client certificates of
+	// the tenant, for the MTLS mode
+	// +optional
+	ClientCommonNames []string `json:"clientCommonNames,omitempty"`
+
+	// Signals the tenant may write. Defaults to all signals.
+	// +kubebuilder:validation:items:Enum=metrics;logs;traces
+	// +optional
+	Signals []string `json:"signals,omitempty"`
+}
+
+// IsEnabled returns true if the ingest gateway is deployed
+func (g *IngestGatewaySpec) IsEnabled() bool {
+	return g != nil && g.Enabled
+}
+
+// GetReplicas returns the number of gateway replicas
+func (g *IngestGatewaySpec) GetReplicas() int32 {
+	if g.Replicas <= 0 {
+		return 2
+	}
+	return g.Replicas
+}
+
+// GetAuthMode returns the authentication mode of the gateway
+func (g *IngestGatewaySpec) GetAuthMode() string {
+	if g.Auth.Mode == "" {
+		return IngestAuthAPIKey
+	}
+	return g.Auth.Mode
+}
+
+// Accepts returns true if any tenant may write the signal. In the APIKey
+// mode, an ApiKey may grant any signal.
+func (g *IngestGatewaySpec) Accepts(signal string) bool {
+	if !g.IsEnabled() {
+		return false
+	}
+	if g.GetAuthMode() == IngestAuthAPIKey {
+		return true
+	}
+	for _, tenant := range g.Tenants {
+		if tenant.Allows(signal) {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows returns true if the tenant may write the signal
+func (t IngestTenantSpec) Allows(signal string) bool {
+	if len(t.Signals) == 0 {
+		return true
+	}
+	for _, s := range t.Signals {
+		if s == signal {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMultiTenant returns true if Loki and Tempo run with multi-tenancy. They
+// do while the ingest gateway is enabled, so the writes of its tenants are
+// kept apart, and while query access control restricts teams to tenants;
+// Prometheus has no tenants.
+func (p *ObservabilityPlatform) IsMultiTenant() bool {
+	return p.Spec.IngestGateway.IsEnabled() || p.Spec.QueryACL.HasTenants()
+}
+
+// TenantID returns the tenant the platform's own components write and query
+// logs and traces as: the correlation tenant, or DefaultTenantID with
+// multi-tenancy. Empty if no tenant is sent.
+func (p *ObservabilityPlatform) TenantID() string {
+	if tenant := p.CorrelationTenantID(); tenant != "" {
+		return tenant
+	}
+	if p.IsMultiTenant() {
+		return DefaultTenantID
+	}
+	return ""
+}
+
+// QueryTenantIDs returns the tenants the main Grafana organization queries
+// with multi-tenancy, sorted: the platform's own, the tenants declared by
+// the ingest gateway and those of the query access control teams. Nil
+// without multi-tenancy.
+func (p *ObservabilityPlatform) QueryTenantIDs() []string {
+	if !p.IsMultiTenant() {
+		return nil
+	}
+	seen := map[string]bool{p.TenantID(): true}
+	if p.Spec.IngestGateway.IsEnabled() {
+		for _, tenant := range p.Spec.IngestGateway.Tenants {
+			seen[tenant.Name] = true
+		}
+	}
+	if p.Spec.QueryACL.HasTenants() {
+		for _, team := range p.Spec.QueryACL.Teams {
+			for _, tenant := range team.Tenants {
+				seen[tenant] = true
+			}
+		}
+	}
+	tenants := make([]string, 0, len(seen))
+	for tenant := range seen {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+	return tenants
+}
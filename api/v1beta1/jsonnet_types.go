@@ -0,0 +1,40 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// JsonnetDashboardSource is a ConfigMap of Jsonnet dashboard sources. Each
+// *.jsonnet key is rendered into one dashboard, several dashboards keyed by
+// file name, or the grafanaDashboards of a mixin.
+type JsonnetDashboardSource struct {
+	// ConfigMapRef references the ConfigMap holding the sources
+	ConfigMapRef corev1.LocalObjectReference `json:"configMapRef"`
+
+	// Libraries are ConfigMaps of Jsonnet libraries the sources import,
+	// e.g. Grafonnet or vendored mixins
+	// +optional
+	Libraries []JsonnetLibrary `json:"libraries,omitempty"`
+
+	// ExtVars are the external variables available through std.extVar
+	// +optional
+	ExtVars map[string]string `json:"extVars,omitempty"`
+}
+
+// JsonnetLibrary is a ConfigMap of Jsonnet library files
+type JsonnetLibrary struct {
+	// ConfigMapRef references the ConfigMap holding the library files
+	ConfigMapRef corev1.LocalObjectReference `json:"configMapRef"`
+
+	// Path is the import path of the library's files, e.g.
+	// github.com/grafana/grafonnet-lib/grafonnet for imports of
+	// 'github.com/grafana/grafonnet-lib/grafonnet/grafana.libsonnet'
+	// +optional
+	Path string `json:"path,omitempty"`
+}
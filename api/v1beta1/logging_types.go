@@ -0,0 +1,55 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComponentLogging configures the log level of a component. Alloy applies a
+// new level with a configuration reload; the other components restart.
+type ComponentLogging struct {
+	// LogLevel overrides the global log level for this component
+	// +kubebuilder:validation:Enum=debug;info;warn;error
+	// +optional
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// Debug raises the log level to debug for DebugTTL. Once the TTL runs
+	// out the component returns to its log level; set Debug to false and
+	// back to true to debug again.
+	// +optional
+	Debug bool `json:"debug,omitempty"`
+
+	// DebugTTL is how long debug mode lasts
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +kubebuilder:default="1h"
+	// +optional
+	DebugTTL string `json:"debugTTL,omitempty"`
+}
+
+// GetDebugTTL returns how long debug mode lasts, 1h if not set
+func (l *ComponentLogging) GetDebugTTL() time.Duration {
+	if d, err := time.ParseDuration(l.DebugTTL); err == nil && d > 0 {
+		return d
+	}
+	return time.Hour
+}
+
+// ComponentDebugStatus tracks the debug mode of a component
+type ComponentDebugStatus struct {
+	// Start is when debug mode was turned on
+	Start metav1.Time `json:"start"`
+
+	// End is when debug mode expires
+	End metav1.Time `json:"end"`
+
+	// Expired is set once End has passed, until debug mode is turned off
+	// +optional
+	Expired bool `json:"expired,omitempty"`
+}
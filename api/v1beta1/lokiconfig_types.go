@@ -24,6 +24,10 @@ import (
 
 // LokiConfigSpec defines the desired state of LokiConfig
 type LokiConfigSpec struct {
+	// TargetPlatform references the ObservabilityPlatform this config applies to
+	// +optional
+	TargetPlatform *corev1.LocalObjectReference `json:"targetPlatform,omitempty"`
+
 	// Version of Loki to use
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Pattern=`^(2\.9\.\d+|3\.\d+\.\d+)$`
@@ -1218,6 +1222,11 @@ type LokiConfigStatus struct {
 	// +optional
 	ValidationErrors []string `json:"validationErrors,omitempty"`
 
+	// AppliedDefaults lists the defaults applied by the webhook which change
+	// the behavior of Loki, like retentions and limits
+	// +optional
+	AppliedDefaults []AppliedDefault `json:"appliedDefaults,omitempty"`
+
 	// Applied indicates whether the configuration has been applied
 	// +optional
 	Applied bool `json:"applied,omitempty"`
@@ -1232,6 +1241,7 @@ type LokiConfigStatus struct {
 // +kubebuilder:resource:scope=Namespaced,shortName=lc;lokiconf
 // +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.version`
 // +kubebuilder:printcolumn:name="Storage",type=string,JSONPath=`.spec.storage.type`
+// +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.targetPlatform.name`,description="Target ObservabilityPlatform"
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 var lokiconfiglog = logf.Log.WithName("lokiconfig-resource")
 
 func (r *LokiConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	targetPlatformReader = mgr.GetClient()
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
@@ -112,9 +114,11 @@ func (r *LokiConfig) Default() {
 		}
 		if r.Spec.Ingester.ChunkEncoding == "" {
 			r.Spec.Ingester.ChunkEncoding = "gzip"
+			recordDefault(r, "spec.ingester.chunkEncoding", r.Spec.Ingester.ChunkEncoding)
 		}
 		if r.Spec.Ingester.MaxChunkAge == "" {
 			r.Spec.Ingester.MaxChunkAge = "2h"
+			recordDefault(r, "spec.ingester.maxChunkAge", r.Spec.Ingester.MaxChunkAge)
 		}
 
 		// Default WAL configuration
@@ -164,9 +168,11 @@ func (r *LokiConfig) Default() {
 	if r.Spec.Limits != nil {
 		if r.Spec.Limits.IngestionRateMB == 0 {
 			r.Spec.Limits.IngestionRateMB = 4
+			recordDefault(r, "spec.limits.ingestionRateMB", r.Spec.Limits.IngestionRateMB)
 		}
 		if r.Spec.Limits.IngestionBurstSizeMB == 0 {
 			r.Spec.Limits.IngestionBurstSizeMB = 6
+			recordDefault(r, "spec.limits.ingestionBurstSizeMB", r.Spec.Limits.IngestionBurstSizeMB)
 		}
 		if r.Spec.Limits.MaxLabelNameLength == 0 {
 			r.Spec.Limits.MaxLabelNameLength = 1024
@@ -179,15 +185,18 @@ func (r *LokiConfig) Default() {
 		}
 		if r.Spec.Limits.RejectOldSamplesMaxAge == "" {
 			r.Spec.Limits.RejectOldSamplesMaxAge = "168h"
+			recordDefault(r, "spec.limits.rejectOldSamplesMaxAge", r.Spec.Limits.RejectOldSamplesMaxAge)
 		}
 		if r.Spec.Limits.CreationGracePeriod == "" {
 			r.Spec.Limits.CreationGracePeriod = "10m"
 		}
 		if r.Spec.Limits.MaxStreamsPerUser == 0 {
 			r.Spec.Limits.MaxStreamsPerUser = 5000
+			recordDefault(r, "spec.limits.maxStreamsPerUser", r.Spec.Limits.MaxStreamsPerUser)
 		}
 		if r.Spec.Limits.MaxGlobalStreamsPerUser == 0 {
 			r.Spec.Limits.MaxGlobalStreamsPerUser = 5000
+			recordDefault(r, "spec.limits.maxGlobalStreamsPerUser", r.Spec.Limits.MaxGlobalStreamsPerUser)
 		}
 		if r.Spec.Limits.MaxChunksPerQuery == 0 {
 			r.Spec.Limits.MaxChunksPerQuery = 2000000
@@ -197,12 +206,14 @@ func (r *LokiConfig) Default() {
 		}
 		if r.Spec.Limits.MaxQueryLength == "" {
 			r.Spec.Limits.MaxQueryLength = "721h"
+			recordDefault(r, "spec.limits.maxQueryLength", r.Spec.Limits.MaxQueryLength)
 		}
 		if r.Spec.Limits.MaxQueryParallelism == 0 {
 			r.Spec.Limits.MaxQueryParallelism = 32
 		}
 		if r.Spec.Limits.MaxEntriesLimitPerQuery == 0 {
 			r.Spec.Limits.MaxEntriesLimitPerQuery = 5000
+			recordDefault(r, "spec.limits.maxEntriesLimitPerQuery", r.Spec.Limits.MaxEntriesLimitPerQuery)
 		}
 		if r.Spec.Limits.MaxCacheFreshnessPerQuery == "" {
 			r.Spec.Limits.MaxCacheFreshnessPerQuery = "1m"
@@ -266,6 +277,7 @@ func (r *LokiConfig) Default() {
 		}
 		if r.Spec.Compactor.RetentionDeleteDelay == "" {
 			r.Spec.Compactor.RetentionDeleteDelay = "2h"
+			recordDefault(r, "spec.compactor.retentionDeleteDelay", r.Spec.Compactor.RetentionDeleteDelay)
 		}
 		if r.Spec.Compactor.RetentionDeleteWorkerCount == 0 {
 			r.Spec.Compactor.RetentionDeleteWorkerCount = 150
@@ -356,6 +368,9 @@ func (r *LokiConfig) Default() {
 			r.Spec.Storage.Filesystem.Directory = "/loki/chunks"
 		}
 	}
+
+	// Forget the defaults the user has since replaced
+	pruneAppliedDefaults(r, r.Spec)
 }
 
 // +kubebuilder:webhook:path=/validate-observability-io-v1beta1-lokiconfig,mutating=false,failurePolicy=fail,sideEffects=None,groups=observability.io,resources=lokiconfigs,verbs=create;update,versions=v1beta1,name=vlokiconfig.kb.io,admissionReviewVersions=v1
@@ -366,6 +381,31 @@ var _ webhook.Validator = &LokiConfig{}
 func (r *LokiConfig) ValidateCreate() (admission.Warnings, error) {
 	lokiconfiglog.Info("validate create", "name", r.Name)
 
+	allErrs := r.validateTargetPlatform()
+	allErrs = append(allErrs, r.validateConfig()...)
+
+	// Warn about the defaults which change the behavior of Loki
+	warnings := appliedDefaultWarnings(r, nil)
+
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, allErrs.ToAggregate()
+}
+
+// validateTargetPlatform validates the target platform, if set, exists and
+// enables Loki
+func (r *LokiConfig) validateTargetPlatform() field.ErrorList {
+	if r.Spec.TargetPlatform == nil {
+		return nil
+	}
+	return validateTargetPlatform(context.Background(), targetPlatformReader,
+		field.NewPath("spec").Child("targetPlatform"), r.Namespace, r.Spec.TargetPlatform.Name, "loki")
+}
+
+// validateConfig validates the Loki configuration
+func (r *LokiConfig) validateConfig() field.ErrorList {
 	var allErrs field.ErrorList
 
 	// Validate storage configuration
@@ -412,11 +452,7 @@ func (r *LokiConfig) ValidateCreate() (admission.Warnings, error) {
 		}
 	}
 
-	if len(allErrs) == 0 {
-		return nil, nil
-	}
-
-	return nil, allErrs.ToAggregate()
+	return allErrs
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -442,12 +478,21 @@ func (r *LokiConfig) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 		allErrs = append(allErrs, err...)
 	}
 
+	// The target platform is checked again only when it changes, so a config
+	// whose platform is gone can still be updated
+	if oldConfig.Spec.TargetPlatform == nil || r.Spec.TargetPlatform == nil ||
+		oldConfig.Spec.TargetPlatform.Name != r.Spec.TargetPlatform.Name {
+		allErrs = append(allErrs, r.validateTargetPlatform()...)
+	}
+
 	// Run standard validation
-	warnings, err := r.ValidateCreate()
-	if err != nil {
-		allErrs = append(allErrs, field.Invalid(field.NewPath("spec"), r.Spec, err.Error()))
+	if errs := r.validateConfig(); len(errs) > 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec"), r.Spec, errs.ToAggregate().Error()))
 	}
 
+	// Warn about the defaults applied by this update
+	warnings := appliedDefaultWarnings(r, oldConfig)
+
 	if len(allErrs) == 0 {
 		return warnings, nil
 	}
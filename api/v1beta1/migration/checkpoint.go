@@ -0,0 +1,179 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ErrDraining is returned for migrations started while the migration
+// manager shuts down
+var ErrDraining = errors.New("migration manager is shutting down")
+
+// CheckpointConfigMapName is the name of the ConfigMap holding the
+// migrations unfinished at the last shutdown
+const CheckpointConfigMapName = "gunj-migration-checkpoint"
+
+// taskCheckpoint is an unfinished migration. All of its resources are
+// migrated again on resume: resources already at the target version are
+// skipped.
+type taskCheckpoint struct {
+	ID            string                 `json:"id"`
+	SourceVersion string                 `json:"sourceVersion,omitempty"`
+	TargetVersion string                 `json:"targetVersion"`
+	Resources     []types.NamespacedName `json:"resources"`
+	StartTime     time.Time              `json:"startTime"`
+}
+
+// begin registers a running migration, unless the manager is shutting down
+func (m *MigrationManager) begin() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.draining {
+		return ErrDraining
+	}
+	m.running.Add(1)
+	return nil
+}
+
+// Drain stops accepting migrations and waits for the running ones until the
+// context is done. The migrations still running are then checkpointed, to
+// be resumed by Resume after the restart.
+func (m *MigrationManager) Drain(ctx context.Context) error {
+	m.mu.Lock()
+	m.draining = true
+	m.mu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		m.running.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		m.logger.Info("Drained running migrations")
+		return nil
+	case <-ctx.Done():
+	}
+
+	// Checkpoint with a fresh context, the drain timed out
+	checkpointCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return m.Checkpoint(checkpointCtx)
+}
+
+// Checkpoint writes the pending and in-progress migrations to the
+// checkpoint ConfigMap, replacing the previous checkpoint
+func (m *MigrationManager) Checkpoint(ctx context.Context) error {
+	m.mu.RLock()
+	var checkpoints []taskCheckpoint
+	for _, task := range m.activeMigrations {
+		if task.Status != MigrationStatusPending && task.Status != MigrationStatusInProgress {
+			continue
+		}
+		checkpoints = append(checkpoints, taskCheckpoint{
+			ID:            task.ID,
+			SourceVersion: task.SourceVersion,
+			TargetVersion: task.TargetVersion,
+			Resources:     append([]types.NamespacedName(nil), task.Resources...),
+			StartTime:     task.StartTime,
+		})
+	}
+	m.mu.RUnlock()
+	if len(checkpoints) == 0 {
+		return nil
+	}
+	if m.config.CheckpointNamespace == "" {
+		return fmt.Errorf("%d unfinished migrations not checkpointed: no checkpoint namespace configured", len(checkpoints))
+	}
+	sort.Slice(checkpoints, func(i, j int) bool { return checkpoints[i].ID < checkpoints[j].ID })
+
+	data := map[string]string{}
+	for _, checkpoint := range checkpoints {
+		raw, err := json.Marshal(checkpoint)
+		if err != nil {
+			return fmt.Errorf("failed to encode checkpoint of %s: %w", checkpoint.ID, err)
+		}
+		data[checkpoint.ID] = string(raw)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CheckpointConfigMapName,
+			Namespace: m.config.CheckpointNamespace,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "gunj-operator"},
+		},
+		Data: data,
+	}
+	existing := &corev1.ConfigMap{}
+	err := m.client.Get(ctx, types.NamespacedName{Namespace: cm.Namespace, Name: cm.Name}, existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		err = m.client.Create(ctx, cm)
+	case err == nil:
+		existing.Data = data
+		err = m.client.Update(ctx, existing)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to checkpoint %d unfinished migrations: %w", len(checkpoints), err)
+	}
+	m.logger.Info("Checkpointed unfinished migrations", "count", len(checkpoints))
+	return nil
+}
+
+// Resume restarts the migrations of the checkpoint as batch migrations and
+// deletes the checkpoint
+func (m *MigrationManager) Resume(ctx context.Context) ([]*MigrationTask, error) {
+	if m.config.CheckpointNamespace == "" {
+		return nil, nil
+	}
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: m.config.CheckpointNamespace, Name: CheckpointConfigMapName}
+	if err := m.client.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read the migration checkpoint: %w", err)
+	}
+
+	ids := make([]string, 0, len(cm.Data))
+	for id := range cm.Data {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var tasks []*MigrationTask
+	for _, id := range ids {
+		checkpoint := taskCheckpoint{}
+		if err := json.Unmarshal([]byte(cm.Data[id]), &checkpoint); err != nil {
+			// Don't block the other migrations on a corrupt entry
+			m.logger.Error(err, "Skipping invalid migration checkpoint", "task", id)
+			continue
+		}
+		task, err := m.MigrateBatch(ctx, checkpoint.Resources, checkpoint.TargetVersion)
+		if err != nil {
+			return tasks, fmt.Errorf("failed to resume migration %s: %w", id, err)
+		}
+		m.logger.Info("Resumed checkpointed migration", "task", id, "resumedAs", task.ID)
+		tasks = append(tasks, task)
+	}
+
+	if err := m.client.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+		return tasks, fmt.Errorf("failed to delete the migration checkpoint: %w", err)
+	}
+	return tasks, nil
+}
@@ -8,6 +8,7 @@ package migration
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"sync"
 	"time"
@@ -35,6 +36,7 @@ type MigrationManager struct {
 	lifecycleManager  *LifecycleIntegrationManager
 	batchProcessor    *BatchConversionProcessor
 	statusReporter    *MigrationStatusReporter
+	reports           *ReportStore
 	
 	// Configuration
 	config MigrationConfig
@@ -42,6 +44,11 @@ type MigrationManager struct {
 	// Runtime state
 	mu              sync.RWMutex
 	activeMigrations map[string]*MigrationTask
+	
+	// draining refuses new migrations during the shutdown, running counts
+	// the migrations the shutdown waits for
+	draining bool
+	running  sync.WaitGroup
 }
 
 // MigrationConfig defines configuration for the migration manager
@@ -66,8 +73,30 @@ type MigrationConfig struct {
 	
 	// ProgressReportInterval for status updates
 	ProgressReportInterval time.Duration
+	
+	// CheckpointNamespace is the namespace of the ConfigMap the unfinished
+	// migrations are checkpointed to on shutdown
+	CheckpointNamespace string
+	
+	// ReportNamespace is the namespace the reports of the completed
+	// migrations are persisted to. Reports are kept in memory only when
+	// empty.
+	ReportNamespace string
+	
+	// ReportRetention limits the persisted reports
+	ReportRetention ReportRetention
 }
 
+var (
+	// ErrValidationFailed wraps the failures of the pre-migration checks and
+	// of the post-migration validation
+	ErrValidationFailed = stderrors.New("migration validation failed")
+	
+	// ErrRolledBack wraps the failures of migrations whose changes were
+	// rolled back
+	ErrRolledBack = stderrors.New("migration rolled back")
+)
+
 // MigrationTask represents an active migration
 type MigrationTask struct {
 	ID            string
@@ -79,6 +108,9 @@ type MigrationTask struct {
 	EndTime       *time.Time
 	Error         error
 	Progress      MigrationProgress
+	// Migrated are the resources migrated so far, which a cancellation
+	// rolls back
+	Migrated []types.NamespacedName
 }
 
 // MigrationStatus represents the status of a migration
@@ -105,6 +137,11 @@ type MigrationProgress struct {
 
 // NewMigrationManager creates a new migration manager
 func NewMigrationManager(client client.Client, scheme *runtime.Scheme, logger logr.Logger, config MigrationConfig) *MigrationManager {
+	var reports *ReportStore
+	if config.ReportNamespace != "" {
+		reports = NewReportStore(client, config.ReportNamespace, config.ReportRetention)
+	}
+	
 	return &MigrationManager{
 		client:           client,
 		scheme:           scheme,
@@ -114,6 +151,7 @@ func NewMigrationManager(client client.Client, scheme *runtime.Scheme, logger lo
 		lifecycleManager: NewLifecycleIntegrationManager(client, logger),
 		batchProcessor:   NewBatchConversionProcessor(client, scheme, logger, config.BatchSize),
 		statusReporter:   NewMigrationStatusReporter(logger),
+		reports:          reports,
 		config:           config,
 		activeMigrations: make(map[string]*MigrationTask),
 	}
@@ -125,6 +163,11 @@ func (m *MigrationManager) MigrateResource(ctx context.Context, resource types.N
 		"resource", resource,
 		"targetVersion", targetVersion)
 	
+	if err := m.begin(); err != nil {
+		return err
+	}
+	defer m.running.Done()
+	
 	// Create migration task
 	task := &MigrationTask{
 		ID:            fmt.Sprintf("migrate-%s-%s-%d", resource.Namespace, resource.Name, time.Now().Unix()),
@@ -141,16 +184,21 @@ func (m *MigrationManager) MigrateResource(ctx context.Context, resource types.N
 	m.mu.Lock()
 	m.activeMigrations[task.ID] = task
 	m.mu.Unlock()
+	m.statusReporter.ReportMigrationStart(task)
 	
 	// Execute migration
 	err := m.executeMigration(ctx, task)
 	
 	// Update task status
 	m.mu.Lock()
-	if err != nil {
+	switch {
+	case stderrors.Is(err, ErrRolledBack):
+		task.Status = MigrationStatusRolledBack
+		task.Error = err
+	case err != nil:
 		task.Status = MigrationStatusFailed
 		task.Error = err
-	} else {
+	default:
 		task.Status = MigrationStatusCompleted
 	}
 	endTime := time.Now()
@@ -159,6 +207,7 @@ func (m *MigrationManager) MigrateResource(ctx context.Context, resource types.N
 	
 	// Report status
 	m.statusReporter.ReportMigrationComplete(task)
+	m.persistReport(task)
 	
 	return err
 }
@@ -169,6 +218,10 @@ func (m *MigrationManager) MigrateBatch(ctx context.Context, resources []types.N
 		"resourceCount", len(resources),
 		"targetVersion", targetVersion)
 	
+	if err := m.begin(); err != nil {
+		return nil, err
+	}
+	
 	// Create migration task
 	task := &MigrationTask{
 		ID:            fmt.Sprintf("batch-migrate-%d-%d", len(resources), time.Now().Unix()),
@@ -185,17 +238,21 @@ func (m *MigrationManager) MigrateBatch(ctx context.Context, resources []types.N
 	m.mu.Lock()
 	m.activeMigrations[task.ID] = task
 	m.mu.Unlock()
+	m.statusReporter.ReportMigrationStart(task)
 	
 	// Execute batch migration asynchronously
 	go func() {
+		defer m.running.Done()
 		err := m.executeBatchMigration(ctx, task)
 		
-		// Update task status
+		// Update task status, unless it was cancelled and rolled back
 		m.mu.Lock()
-		if err != nil {
+		switch {
+		case task.Status == MigrationStatusRolledBack:
+		case err != nil:
 			task.Status = MigrationStatusFailed
 			task.Error = err
-		} else {
+		default:
 			task.Status = MigrationStatusCompleted
 		}
 		endTime := time.Now()
@@ -204,6 +261,7 @@ func (m *MigrationManager) MigrateBatch(ctx context.Context, resources []types.N
 		
 		// Report status
 		m.statusReporter.ReportMigrationComplete(task)
+		m.persistReport(task)
 	}()
 	
 	return task, nil
@@ -215,7 +273,7 @@ func (m *MigrationManager) executeMigration(ctx context.Context, task *Migration
 	
 	// Pre-migration checks
 	if err := m.lifecycleManager.PreMigrationCheck(ctx, resource, task.TargetVersion); err != nil {
-		return fmt.Errorf("pre-migration check failed: %w", err)
+		return fmt.Errorf("%w: pre-migration check: %w", ErrValidationFailed, err)
 	}
 	
 	// Get current resource
@@ -271,20 +329,26 @@ func (m *MigrationManager) executeMigration(ctx context.Context, task *Migration
 	})
 	
 	if err != nil {
+		if migrationErr == nil {
+			// The conflicts outlasted the retries
+			migrationErr = err
+		}
+		
 		// Attempt rollback on failure
 		if rollbackErr := m.lifecycleManager.RollbackMigration(ctx, resource); rollbackErr != nil {
 			m.logger.Error(rollbackErr, "Failed to rollback migration")
+			return migrationErr
 		}
-		return migrationErr
+		return fmt.Errorf("%w: %w", ErrRolledBack, migrationErr)
 	}
 	
 	// Post-migration validation
 	if err := m.lifecycleManager.PostMigrationValidation(ctx, resource, task.TargetVersion); err != nil {
-		return fmt.Errorf("post-migration validation failed: %w", err)
+		return fmt.Errorf("%w: %w", ErrValidationFailed, err)
 	}
 	
 	// Update progress
-	m.updateProgress(task, 1, 0, 0)
+	m.updateProgress(task, []types.NamespacedName{resource}, 0, 0)
 	
 	return nil
 }
@@ -298,11 +362,12 @@ func (m *MigrationManager) executeBatchMigration(ctx context.Context, task *Migr
 	}
 	
 	// Update progress based on results
-	var migrated, failed, skipped int
+	var migrated []types.NamespacedName
+	var failed, skipped int
 	for _, result := range results {
 		switch result.Status {
 		case BatchResultStatusSuccess:
-			migrated++
+			migrated = append(migrated, result.Resource)
 		case BatchResultStatusFailed:
 			failed++
 		case BatchResultStatusSkipped:
@@ -358,12 +423,22 @@ func (m *MigrationManager) convertToVersion(u *unstructured.Unstructured, target
 	}
 }
 
-// updateProgress updates migration progress
-func (m *MigrationManager) updateProgress(task *MigrationTask, migrated, failed, skipped int) {
+// updateProgress updates migration progress. Resources migrated after the
+// migration was cancelled are rolled back at once.
+func (m *MigrationManager) updateProgress(task *MigrationTask, migrated []types.NamespacedName, failed, skipped int) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	
-	task.Progress.MigratedResources += migrated
+	if task.Status == MigrationStatusRolledBack {
+		if err := m.rollbackResources(migrated); err != nil {
+			m.logger.Error(err, "Failed to rollback resources migrated after cancellation", "task", task.ID)
+			task.Error = err
+		}
+		return
+	}
+	
+	task.Migrated = append(task.Migrated, migrated...)
+	task.Progress.MigratedResources += len(migrated)
 	task.Progress.FailedResources += failed
 	task.Progress.SkippedResources += skipped
 	
@@ -389,6 +464,7 @@ func (m *MigrationManager) GetMigrationStatus(taskID string) (*MigrationTask, er
 	
 	// Return a copy to avoid race conditions
 	taskCopy := *task
+	taskCopy.Migrated = append([]types.NamespacedName(nil), task.Migrated...)
 	return &taskCopy, nil
 }
 
@@ -400,13 +476,15 @@ func (m *MigrationManager) ListActiveMigrations() []*MigrationTask {
 	tasks := make([]*MigrationTask, 0, len(m.activeMigrations))
 	for _, task := range m.activeMigrations {
 		taskCopy := *task
+		taskCopy.Migrated = append([]types.NamespacedName(nil), task.Migrated...)
 		tasks = append(tasks, &taskCopy)
 	}
 	
 	return tasks
 }
 
-// CancelMigration cancels an active migration
+// CancelMigration cancels an active migration and rolls back the resources
+// it migrated. It returns an error if any of them could not be rolled back.
 func (m *MigrationManager) CancelMigration(taskID string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -424,14 +502,26 @@ func (m *MigrationManager) CancelMigration(taskID string) error {
 	task.Status = MigrationStatusRolledBack
 	
 	// Rollback migrated resources
-	for i := 0; i < task.Progress.MigratedResources; i++ {
-		if i < len(task.Resources) {
-			if err := m.lifecycleManager.RollbackMigration(context.Background(), task.Resources[i]); err != nil {
-				m.logger.Error(err, "Failed to rollback resource during cancellation",
-					"resource", task.Resources[i])
-			}
-		}
+	if err := m.rollbackResources(task.Migrated); err != nil {
+		task.Error = err
+		return fmt.Errorf("failed to cancel migration %s: %w", taskID, err)
 	}
 	
 	return nil
 }
+
+// rollbackResources rolls back migrated resources, returning the errors of
+// those that could not be rolled back
+func (m *MigrationManager) rollbackResources(resources []types.NamespacedName) error {
+	var failed []error
+	for _, resource := range resources {
+		if err := m.lifecycleManager.RollbackMigration(context.Background(), resource); err != nil {
+			m.logger.Error(err, "Failed to rollback resource during cancellation", "resource", resource)
+			failed = append(failed, fmt.Errorf("%s: %w", resource, err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d resources not rolled back: %w", len(failed), len(resources), stderrors.Join(failed...))
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package migration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ReportLabel marks the ConfigMaps holding completed migration reports
+	ReportLabel = "observability.io/migration-report"
+
+	// reportConfigMapPrefix prefixes the names of the report ConfigMaps
+	reportConfigMapPrefix = "gunj-migration-report-"
+
+	// reportDataKey is the key of the report JSON in its ConfigMap
+	reportDataKey = "report.json"
+
+	// reportTaskAnnotation holds the task ID, which may not fit the name
+	reportTaskAnnotation = "observability.io/migration-task"
+
+	// reportCompletedAnnotation holds the end time the retention is based on
+	reportCompletedAnnotation = "observability.io/migration-completed-at"
+
+	// maxReportSize keeps a report below the 1MiB limit of a ConfigMap
+	maxReportSize = 900 * 1024
+)
+
+// ErrReportNotFound is returned for tasks without a persisted report
+var ErrReportNotFound = errors.New("report not found")
+
+// ReportRetention limits the persisted migration reports. Zero values keep
+// the reports indefinitely.
+type ReportRetention struct {
+	// MaxReports is the number of most recent reports kept
+	MaxReports int
+
+	// TTL is how long a report is kept after its migration completed
+	TTL time.Duration
+}
+
+// ReportStore persists completed migration reports to ConfigMaps, one per
+// task, so they outlive the process that ran the migration
+type ReportStore struct {
+	client    client.Client
+	namespace string
+	retention ReportRetention
+}
+
+// NewReportStore creates a report store keeping the reports in a namespace
+func NewReportStore(c client.Client, namespace string, retention ReportRetention) *ReportStore {
+	return &ReportStore{
+		client:    c,
+		namespace: namespace,
+		retention: retention,
+	}
+}
+
+// Save persists the redacted report of a completed migration and prunes the
+// reports beyond the retention
+func (s *ReportStore) Save(ctx context.Context, report *MigrationReport) error {
+	raw, err := encodeReport(redactReport(report))
+	if err != nil {
+		return fmt.Errorf("failed to encode report of %s: %w", report.TaskID, err)
+	}
+
+	completed := report.StartTime
+	if report.EndTime != nil {
+		completed = *report.EndTime
+	}
+	name := reportConfigMapName(report.TaskID)
+	labels := map[string]string{
+		"app.kubernetes.io/managed-by":      "gunj-operator",
+		ReportLabel:                         "true",
+		"observability.io/migration-status": string(report.Status),
+	}
+	annotations := map[string]string{
+		reportTaskAnnotation:      report.TaskID,
+		reportCompletedAnnotation: completed.UTC().Format(time.RFC3339),
+	}
+	data := map[string]string{reportDataKey: string(raw)}
+
+	existing := &corev1.ConfigMap{}
+	err = s.client.Get(ctx, types.NamespacedName{Namespace: s.namespace, Name: name}, existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		err = s.client.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   s.namespace,
+				Labels:      labels,
+				Annotations: annotations,
+			},
+			Data: data,
+		})
+	case err == nil:
+		existing.Labels = labels
+		existing.Annotations = annotations
+		existing.Data = data
+		err = s.client.Update(ctx, existing)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to persist report of %s: %w", report.TaskID, err)
+	}
+
+	_, err = s.Prune(ctx, time.Now())
+	return err
+}
+
+// Load returns the persisted report of a task
+func (s *ReportStore) Load(ctx context.Context, taskID string) (*MigrationReport, error) {
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: s.namespace, Name: reportConfigMapName(taskID)}
+	if err := s.client.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w for task: %s", ErrReportNotFound, taskID)
+		}
+		return nil, fmt.Errorf("failed to read report of %s: %w", taskID, err)
+	}
+	return decodeReport(cm)
+}
+
+// List returns the persisted reports, most recently completed first.
+// Reports that can't be decoded are skipped.
+func (s *ReportStore) List(ctx context.Context) ([]*MigrationReport, error) {
+	items, err := s.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*MigrationReport, 0, len(items))
+	for i := range items {
+		report, err := decodeReport(&items[i])
+		if err != nil {
+			continue
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// Prune deletes the reports beyond the retention count and those completed
+// longer than the TTL before now. It returns the number of deleted reports.
+func (s *ReportStore) Prune(ctx context.Context, now time.Time) (int, error) {
+	items, err := s.list(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for i := range items {
+		expired := s.retention.TTL > 0 && now.Sub(completedAt(&items[i])) > s.retention.TTL
+		excess := s.retention.MaxReports > 0 && i >= s.retention.MaxReports
+		if !expired && !excess {
+			continue
+		}
+		if err := s.client.Delete(ctx, &items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return deleted, fmt.Errorf("failed to prune report %s: %w", items[i].Name, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// list returns the report ConfigMaps, most recently completed first
+func (s *ReportStore) list(ctx context.Context) ([]corev1.ConfigMap, error) {
+	list := &corev1.ConfigMapList{}
+	if err := s.client.List(ctx, list, client.InNamespace(s.namespace), client.MatchingLabels{ReportLabel: "true"}); err != nil {
+		return nil, fmt.Errorf("failed to list migration reports: %w", err)
+	}
+	items := list.Items
+	sort.SliceStable(items, func(i, j int) bool {
+		return completedAt(&items[i]).After(completedAt(&items[j]))
+	})
+	return items, nil
+}
+
+// persistReport saves the report of a finished task, when reports are
+// persisted. Failures are logged, they don't fail the migration.
+func (m *MigrationManager) persistReport(task *MigrationTask) {
+	if m.reports == nil {
+		return
+	}
+	report, err := m.statusReporter.GetReport(task.ID)
+	if err != nil {
+		m.logger.Error(err, "Failed to persist migration report", "task", task.ID)
+		return
+	}
+
+	// Persist with a fresh context, the migration's may be cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := m.reports.Save(ctx, report); err != nil {
+		m.logger.Error(err, "Failed to persist migration report", "task", task.ID)
+	}
+}
+
+// encodeReport marshals a report, dropping the details of the resources
+// migrated or skipped successfully when the report is too large for a
+// ConfigMap
+func encodeReport(report *MigrationReport) ([]byte, error) {
+	raw, err := json.Marshal(report)
+	if err != nil || len(raw) <= maxReportSize {
+		return raw, err
+	}
+
+	trimmed := *report
+	trimmed.ResourceDetails = nil
+	for _, detail := range report.ResourceDetails {
+		if detail.Error != "" {
+			trimmed.ResourceDetails = append(trimmed.ResourceDetails, detail)
+		}
+	}
+	if omitted := len(report.ResourceDetails) - len(trimmed.ResourceDetails); omitted > 0 {
+		trimmed.Recommendations = append(append([]string(nil), report.Recommendations...),
+			fmt.Sprintf("The details of %d resources migrated without errors were omitted from the persisted report", omitted))
+	}
+	raw, err = json.Marshal(&trimmed)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) > maxReportSize {
+		return nil, fmt.Errorf("report is %d bytes, above the %d bytes a ConfigMap holds", len(raw), maxReportSize)
+	}
+	return raw, nil
+}
+
+// decodeReport returns the report held by a ConfigMap
+func decodeReport(cm *corev1.ConfigMap) (*MigrationReport, error) {
+	raw, ok := cm.Data[reportDataKey]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap %s holds no migration report", cm.Name)
+	}
+	report := &MigrationReport{}
+	if err := json.Unmarshal([]byte(raw), report); err != nil {
+		return nil, fmt.Errorf("failed to decode migration report %s: %w", cm.Name, err)
+	}
+	return report, nil
+}
+
+// completedAt returns when the migration of a report ConfigMap completed,
+// its creation time if unknown
+func completedAt(cm *corev1.ConfigMap) time.Time {
+	if t, err := time.Parse(time.RFC3339, cm.Annotations[reportCompletedAnnotation]); err == nil {
+		return t
+	}
+	return cm.CreationTimestamp.Time
+}
+
+// reportConfigMapName returns the name of the ConfigMap of a task's report.
+// Task IDs that don't make a valid name are hashed.
+func reportConfigMapName(taskID string) string {
+	name := reportConfigMapPrefix + strings.ToLower(taskID)
+	if len(validation.IsDNS1123Subdomain(name)) == 0 {
+		return name
+	}
+	sum := sha256.Sum256([]byte(taskID))
+	return reportConfigMapPrefix + hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package migration_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/migration"
+)
+
+var _ = Describe("Report Store", func() {
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		now       time.Time
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+		now = time.Now()
+	})
+
+	// report returns a completed report, ended the given time ago
+	report := func(taskID string, ago time.Duration) *migration.MigrationReport {
+		end := now.Add(-ago)
+		return &migration.MigrationReport{
+			TaskID:         taskID,
+			TargetVersion:  "v1beta1",
+			StartTime:      end.Add(-time.Minute),
+			EndTime:        &end,
+			Duration:       time.Minute,
+			Status:         migration.MigrationStatusCompleted,
+			TotalResources: 2,
+			SuccessCount:   1,
+			FailureCount:   1,
+			ResourceDetails: []migration.ResourceMigrationDetail{
+				{Name: "ok", Namespace: "default", Status: "Success"},
+				{Name: "broken", Namespace: "default", Status: "Failed", Error: "conversion failed: password=hunter2"},
+			},
+		}
+	}
+
+	taskIDs := func(reports []*migration.MigrationReport) []string {
+		ids := make([]string, 0, len(reports))
+		for _, r := range reports {
+			ids = append(ids, r.TaskID)
+		}
+		return ids
+	}
+
+	It("should persist redacted reports that outlive the reporter", func() {
+		store := migration.NewReportStore(k8sClient, "gunj-system", migration.ReportRetention{})
+		Expect(store.Save(ctx, report("batch-migrate-2-1700000000", time.Hour))).To(Succeed())
+
+		loaded, err := store.Load(ctx, "batch-migrate-2-1700000000")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.TargetVersion).To(Equal("v1beta1"))
+		Expect(loaded.Status).To(Equal(migration.MigrationStatusCompleted))
+		Expect(loaded.FailureCount).To(Equal(1))
+		Expect(loaded.ResourceDetails).To(HaveLen(2))
+		Expect(loaded.ResourceDetails[1].Error).NotTo(ContainSubstring("hunter2"))
+
+		cms := &corev1.ConfigMapList{}
+		Expect(k8sClient.List(ctx, cms, client.InNamespace("gunj-system"),
+			client.MatchingLabels{migration.ReportLabel: "true"})).To(Succeed())
+		Expect(cms.Items).To(HaveLen(1))
+
+		_, err = store.Load(ctx, "unknown")
+		Expect(err).To(MatchError(ContainSubstring("report not found")))
+	})
+
+	It("should keep the most recent reports up to the retention count", func() {
+		store := migration.NewReportStore(k8sClient, "gunj-system", migration.ReportRetention{MaxReports: 2})
+		for i := 1; i <= 3; i++ {
+			// migrate-3 completed last
+			Expect(store.Save(ctx, report(fmt.Sprintf("migrate-%d", i), time.Duration(4-i)*time.Hour))).To(Succeed())
+		}
+
+		reports, err := store.List(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(taskIDs(reports)).To(Equal([]string{"migrate-3", "migrate-2"}))
+	})
+
+	It("should prune the reports older than the TTL", func() {
+		keepAll := migration.NewReportStore(k8sClient, "gunj-system", migration.ReportRetention{})
+		Expect(keepAll.Save(ctx, report("migrate-old", 10*24*time.Hour))).To(Succeed())
+		Expect(keepAll.Save(ctx, report("migrate-new", time.Hour))).To(Succeed())
+
+		store := migration.NewReportStore(k8sClient, "gunj-system", migration.ReportRetention{TTL: 7 * 24 * time.Hour})
+		deleted, err := store.Prune(ctx, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(Equal(1))
+
+		reports, err := store.List(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(taskIDs(reports)).To(Equal([]string{"migrate-new"}))
+	})
+
+	It("should persist the reports of task IDs that don't make a ConfigMap name", func() {
+		store := migration.NewReportStore(k8sClient, "gunj-system", migration.ReportRetention{})
+		taskID := "Migrate_" + fmt.Sprintf("%0300d", 1)
+		Expect(store.Save(ctx, report(taskID, time.Hour))).To(Succeed())
+
+		loaded, err := store.Load(ctx, taskID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.TaskID).To(Equal(taskID))
+	})
+})
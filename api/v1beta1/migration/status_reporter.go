@@ -17,6 +17,8 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion/redaction"
 )
 
 // MigrationStatusReporter provides detailed migration status reporting
@@ -104,6 +106,7 @@ const (
 // MigrationReport represents a complete migration report
 type MigrationReport struct {
 	TaskID           string
+	TargetVersion    string
 	StartTime        time.Time
 	EndTime          *time.Time
 	Duration         time.Duration
@@ -238,6 +241,7 @@ func (r *MigrationStatusReporter) ReportMigrationStart(task *MigrationTask) {
 	r.mu.Lock()
 	r.reports[task.ID] = &MigrationReport{
 		TaskID:         task.ID,
+		TargetVersion:  task.TargetVersion,
 		StartTime:      task.StartTime,
 		Status:         task.Status,
 		TotalResources: len(task.Resources),
@@ -375,6 +379,7 @@ func (r *MigrationStatusReporter) ReportBatchResults(taskID string, results []Ba
 	r.mu.Lock()
 	if report, exists := r.reports[taskID]; exists {
 		report.ResourceDetails = append(report.ResourceDetails, resourceDetails...)
+		report.Events = append(report.Events, event)
 	}
 	r.mu.Unlock()
 }
@@ -413,10 +418,8 @@ func (r *MigrationStatusReporter) processEvents() {
 			r.events = r.events[len(r.events)-r.config.MaxEvents:]
 		}
 		
-		// Add to report
-		if report, exists := r.reports[event.TaskID]; exists {
-			report.Events = append(report.Events, event)
-		}
+		// The events of a report are added by the Report methods, so the
+		// report is complete once they return
 		
 		r.mu.Unlock()
 		
@@ -474,6 +477,16 @@ func (r *MigrationStatusReporter) GetReport(taskID string) (*MigrationReport, er
 	return &reportCopy, nil
 }
 
+// RestoreReport adds a report persisted by an earlier process, so it can be
+// exported and rendered like the reports of this process
+func (r *MigrationStatusReporter) RestoreReport(report *MigrationReport) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	reportCopy := *report
+	r.reports[report.TaskID] = &reportCopy
+}
+
 // GetAllReports returns all migration reports
 func (r *MigrationStatusReporter) GetAllReports() []*MigrationReport {
 	r.mu.RLock()
@@ -515,6 +528,7 @@ func (r *MigrationStatusReporter) GenerateHTMLReport(taskID string) (string, err
 	if err != nil {
 		return "", err
 	}
+	report = redactReport(report)
 	
 	tmpl := `
 <!DOCTYPE html>
@@ -606,7 +620,32 @@ func (r *MigrationStatusReporter) ExportReportJSON(taskID string) ([]byte, error
 		return nil, err
 	}
 	
-	return json.MarshalIndent(report, "", "  ")
+	return json.MarshalIndent(redactReport(report), "", "  ")
+}
+
+// redactReport returns a copy of a report with the secrets, tokens and
+// passwords of its messages and event details masked
+func redactReport(report *MigrationReport) *MigrationReport {
+	redactor := redaction.Default()
+	redacted := *report
+	redacted.Events = make([]MigrationEvent, len(report.Events))
+	for i, event := range report.Events {
+		event.Message = redactor.Text(event.Message)
+		if event.Details != nil {
+			event.Details = redactor.Value("", event.Details).(map[string]interface{})
+		}
+		redacted.Events[i] = event
+	}
+	redacted.ResourceDetails = make([]ResourceMigrationDetail, len(report.ResourceDetails))
+	for i, detail := range report.ResourceDetails {
+		detail.Error = redactor.Text(detail.Error)
+		redacted.ResourceDetails[i] = detail
+	}
+	redacted.Recommendations = nil
+	for _, recommendation := range report.Recommendations {
+		redacted.Recommendations = append(redacted.Recommendations, redactor.Text(recommendation))
+	}
+	return &redacted
 }
 
 // sendWebhookNotification sends a webhook notification
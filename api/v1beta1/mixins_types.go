@@ -0,0 +1,133 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ObservabilityMixinsSpec selects the community monitoring mixins installed
+// into the platform: their dashboards are imported into Grafana and their
+// recording rules and alerts are loaded by Prometheus. The loki and tempo
+// mixins follow the versions of the deployed components.
+type ObservabilityMixinsSpec struct {
+	// Node installs the node-exporter mixin
+	// +optional
+	Node *MixinSpec `json:"node,omitempty"`
+
+	// Kubernetes installs the kubernetes-mixin
+	// +optional
+	Kubernetes *MixinSpec `json:"kubernetes,omitempty"`
+
+	// Loki installs the Loki mixin matching the deployed Loki version
+	// +optional
+	Loki *MixinSpec `json:"loki,omitempty"`
+
+	// Tempo installs the Tempo mixin matching the deployed Tempo version
+	// +optional
+	Tempo *MixinSpec `json:"tempo,omitempty"`
+}
+
+// MixinSpec configures a monitoring mixin
+type MixinSpec struct {
+	// Enabled installs the mixin
+	Enabled bool `json:"enabled"`
+
+	// Version is the Git ref the mixin is rendered from, overriding the
+	// component version or the catalog's pinned version
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Config is a Jsonnet object merged into the mixin's _config, such as
+	// { nodeExporterSelector: 'job="node"' }
+	// +optional
+	Config string `json:"config,omitempty"`
+
+	// Dashboards imports the mixin's dashboards into Grafana
+	// +kubebuilder:default=true
+	// +optional
+	Dashboards *bool `json:"dashboards,omitempty"`
+
+	// Rules loads the mixin's recording rules and alerts into Prometheus
+	// +kubebuilder:default=true
+	// +optional
+	Rules *bool `json:"rules,omitempty"`
+}
+
+// MixinStatus is the state of an installed mixin
+type MixinStatus struct {
+	// Version is the Git ref the mixin was rendered from
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Revision is the commit the mixin was rendered from
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// Checksum identifies the settings the mixin was installed with
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+
+	// LastSyncTime is when the mixin was last installed
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Dashboards are the imported dashboards
+	// +optional
+	Dashboards []GitDashboardStatus `json:"dashboards,omitempty"`
+
+	// RuleGroups is the number of installed rule groups
+	// +optional
+	RuleGroups int32 `json:"ruleGroups,omitempty"`
+
+	// Message holds the error of the last failed install
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// Get returns the settings of a mixin by its catalog name, or nil
+func (m *ObservabilityMixinsSpec) Get(name string) *MixinSpec {
+	if m == nil {
+		return nil
+	}
+	switch name {
+	case "node":
+		return m.Node
+	case "kubernetes":
+		return m.Kubernetes
+	case "loki":
+		return m.Loki
+	case "tempo":
+		return m.Tempo
+	}
+	return nil
+}
+
+// HasRules returns true if an enabled mixin loads rules into Prometheus
+func (m *ObservabilityMixinsSpec) HasRules() bool {
+	for _, mixin := range []*MixinSpec{m.Get("node"), m.Get("kubernetes"), m.Get("loki"), m.Get("tempo")} {
+		if mixin.IsEnabled() && mixin.InstallsRules() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEnabled returns true if the mixin is installed
+func (m *MixinSpec) IsEnabled() bool {
+	return m != nil && m.Enabled
+}
+
+// InstallsDashboards returns true if the mixin's dashboards are imported
+func (m *MixinSpec) InstallsDashboards() bool {
+	return m.Dashboards == nil || *m.Dashboards
+}
+
+// InstallsRules returns true if the mixin's rules and alerts are loaded
+func (m *MixinSpec) InstallsRules() bool {
+	return m.Rules == nil || *m.Rules
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+// NodePoolSpec configures a dedicated observability node pool. All components
+// are given a node selector and a toleration matching the pool, and the
+// operator can label and taint a declared set of nodes to form the pool.
+type NodePoolSpec struct {
+	// Enabled determines if components are scheduled on the dedicated node pool
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Name of the pool, used as the value of the node label and the taint
+	// +kubebuilder:default="observability"
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// LabelKey is the key of the node label and taint identifying the pool
+	// +kubebuilder:default="observability.io/node-pool"
+	// +optional
+	LabelKey string `json:"labelKey,omitempty"`
+
+	// Nodes the operator labels and taints to form the pool. If empty, the
+	// pool is expected to exist already, e.g. created by the cloud provider,
+	// and only the tolerations and node selectors are configured.
+	// +optional
+	Nodes []string `json:"nodes,omitempty"`
+
+	// TaintEffect of the pool taint. NoSchedule keeps other workloads off the
+	// pool, PreferNoSchedule only avoids it.
+	// +kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute
+	// +kubebuilder:default="NoSchedule"
+	// +optional
+	TaintEffect string `json:"taintEffect,omitempty"`
+}
+
+// IsEnabled returns true if the dedicated node pool is enabled
+func (n *NodePoolSpec) IsEnabled() bool {
+	return n != nil && n.Enabled
+}
+
+// GetName returns the name of the pool
+func (n *NodePoolSpec) GetName() string {
+	if n.Name == "" {
+		return "observability"
+	}
+	return n.Name
+}
+
+// GetTaintEffect returns the effect of the pool taint
+func (n *NodePoolSpec) GetTaintEffect() string {
+	if n.TaintEffect == "" {
+		return "NoSchedule"
+	}
+	return n.TaintEffect
+}
+
+// GetLabelKey returns the key of the node label and taint
+func (n *NodePoolSpec) GetLabelKey() string {
+	if n.LabelKey == "" {
+		return "observability.io/node-pool"
+	}
+	return n.LabelKey
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import "time"
+
+// NoisyAlertsSpec configures the detection of noisy alerts. The operator
+// periodically analyzes the ALERTS series of the platform's Prometheus for
+// alerts that fire and resolve frequently, and stores a report with
+// suggested adjustments in the prometheus-<platform>-noisy-alerts ConfigMap.
+type NoisyAlertsSpec struct {
+	// Enabled turns on the analysis
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Window is how far back the alerts are analyzed
+	// +kubebuilder:validation:Pattern=`^[0-9]+(m|h)$`
+	// +kubebuilder:default="168h"
+	// +optional
+	Window string `json:"window,omitempty"`
+
+	// AnalysisInterval is how often the report is refreshed
+	// +kubebuilder:validation:Pattern=`^[0-9]+(m|h)$`
+	// +kubebuilder:default="1h"
+	// +optional
+	AnalysisInterval string `json:"analysisInterval,omitempty"`
+
+	// MinFirings is how many times an alert must fire within the window to
+	// be reported
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:default=10
+	// +optional
+	MinFirings int32 `json:"minFirings,omitempty"`
+
+	// ShortFiring is the firing duration below which an alert counts as
+	// resolved on its own, without anyone acting on it
+	// +kubebuilder:validation:Pattern=`^[0-9]+(s|m|h)$`
+	// +kubebuilder:default="5m"
+	// +optional
+	ShortFiring string `json:"shortFiring,omitempty"`
+
+	// TopN is the number of noisiest alerts kept in the report
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=20
+	// +optional
+	TopN int32 `json:"topN,omitempty"`
+}
+
+// IsEnabled returns true if noisy alerts are analyzed
+func (n *NoisyAlertsSpec) IsEnabled() bool {
+	return n != nil && n.Enabled
+}
+
+// GetWindow returns how far back the alerts are analyzed
+func (n *NoisyAlertsSpec) GetWindow() time.Duration {
+	if d, err := time.ParseDuration(n.Window); err == nil && d > 0 {
+		return d
+	}
+	return 7 * 24 * time.Hour
+}
+
+// GetAnalysisInterval returns how often the report is refreshed
+func (n *NoisyAlertsSpec) GetAnalysisInterval() time.Duration {
+	if d, err := time.ParseDuration(n.AnalysisInterval); err == nil && d > 0 {
+		return d
+	}
+	return time.Hour
+}
+
+// GetMinFirings returns how many firings make an alert reported
+func (n *NoisyAlertsSpec) GetMinFirings() int {
+	if n.MinFirings < 2 {
+		return 10
+	}
+	return int(n.MinFirings)
+}
+
+// GetShortFiring returns the duration below which a firing is short
+func (n *NoisyAlertsSpec) GetShortFiring() time.Duration {
+	if d, err := time.ParseDuration(n.ShortFiring); err == nil && d > 0 {
+		return d
+	}
+	return 5 * time.Minute
+}
+
+// GetTopN returns the number of alerts kept in the report
+func (n *NoisyAlertsSpec) GetTopN() int {
+	if n.TopN <= 0 {
+		return 20
+	}
+	return int(n.TopN)
+}
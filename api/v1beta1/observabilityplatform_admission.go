@@ -0,0 +1,314 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/gunjanjp/gunj-operator/internal/correlation"
+	"github.com/gunjanjp/gunj-operator/internal/promqlcheck"
+	"github.com/gunjanjp/gunj-operator/internal/webhook/plugins"
+)
+
+// Feature gates of the built-in admission plugins. The checks reading the
+// cluster can be turned off where their API calls are too costly.
+const (
+	AdmissionGateResourceQuota = "ResourceQuotaValidation"
+	AdmissionGateZones         = "ZoneValidation"
+	AdmissionGatePriority      = "PriorityClassValidation"
+	AdmissionGateFeasibility   = "FeasibilityWarnings"
+)
+
+// AdmissionOrderCallouts is the order of the callouts which set none, after
+// the built-in validators
+const AdmissionOrderCallouts = 1000
+
+// AdmissionPlugin is a defaulter or validator of platforms
+type AdmissionPlugin = plugins.Plugin[*ObservabilityPlatform]
+
+// admissionChain runs the defaulters and validators of the platform webhooks
+var admissionChain = newAdmissionChain()
+
+// RegisterAdmissionPlugins adds plugins to the admission chain of platforms
+func RegisterAdmissionPlugins(p ...AdmissionPlugin) error {
+	return admissionChain.Register(p...)
+}
+
+// ConfigureAdmission adds HTTP callouts to the admission chain of platforms
+// and sets the feature gates of its plugins. Call it before the webhook is
+// served.
+func ConfigureAdmission(gates plugins.Gates, callouts []plugins.CalloutConfig) error {
+	for _, config := range callouts {
+		if config.Order == 0 {
+			config.Order = AdmissionOrderCallouts
+		}
+		callout, err := plugins.NewCallout[*ObservabilityPlatform](config)
+		if err != nil {
+			return err
+		}
+		if err := admissionChain.Register(callout); err != nil {
+			return err
+		}
+	}
+	return admissionChain.SetGates(gates)
+}
+
+// AdmissionPlugins describes the plugins of the admission chain of platforms
+// in the order they run
+func AdmissionPlugins() []plugins.Info {
+	return admissionChain.Plugins()
+}
+
+// newAdmissionChain returns the chain of the built-in plugins
+func newAdmissionChain() *plugins.Chain[*ObservabilityPlatform] {
+	chain := plugins.NewChain[*ObservabilityPlatform]()
+	if err := chain.Register(builtinDefaulters()...); err != nil {
+		panic(err)
+	}
+	if err := chain.Register(builtinValidators()...); err != nil {
+		panic(err)
+	}
+	return chain
+}
+
+// defaulter adapts a defaulting method to a plugin
+func defaulter(name string, order int, set func(r *ObservabilityPlatform)) AdmissionPlugin {
+	return AdmissionPlugin{
+		Name:  name,
+		Order: order,
+		Default: func(_ context.Context, r *ObservabilityPlatform) error {
+			set(r)
+			return nil
+		},
+	}
+}
+
+// builtinDefaulters set the defaults of the operator
+func builtinDefaulters() []AdmissionPlugin {
+	return []AdmissionPlugin{
+		defaulter("metadata", 100, (*ObservabilityPlatform).defaultMetadata),
+		defaulter("components", 200, func(r *ObservabilityPlatform) {
+			if r.Spec.Components == nil {
+				r.Spec.Components = &Components{}
+			}
+		}),
+		defaulter("prometheus", 210, (*ObservabilityPlatform).defaultPrometheus),
+		defaulter("grafana", 220, (*ObservabilityPlatform).defaultGrafana),
+		defaulter("loki", 230, (*ObservabilityPlatform).defaultLoki),
+		defaulter("tempo", 240, (*ObservabilityPlatform).defaultTempo),
+		defaulter("global-settings", 300, (*ObservabilityPlatform).defaultGlobalSettings),
+		defaulter("high-availability", 400, (*ObservabilityPlatform).defaultHighAvailability),
+		defaulter("backup", 500, (*ObservabilityPlatform).defaultBackup),
+		defaulter("alerting", 600, (*ObservabilityPlatform).defaultAlerting),
+		// Forget the defaults the user has since replaced, once all are set
+		defaulter("prune-applied-defaults", 10000, func(r *ObservabilityPlatform) {
+			pruneAppliedDefaults(r, r.Spec)
+		}),
+	}
+}
+
+// builtinValidators validate platforms like the operator expects them
+func builtinValidators() []AdmissionPlugin {
+	return []AdmissionPlugin{
+		{
+			Name:       "immutable-fields",
+			Order:      100,
+			Operations: []plugins.Operation{plugins.Update},
+			Validate: func(ctx context.Context, req plugins.Request[*ObservabilityPlatform]) (admission.Warnings, field.ErrorList) {
+				return nil, req.Object.validateImmutableFields(ctx, req.OldObject)
+			},
+		},
+		{
+			Name:       "read-only",
+			Order:      110,
+			Operations: []plugins.Operation{plugins.Update},
+			Validate: func(_ context.Context, req plugins.Request[*ObservabilityPlatform]) (admission.Warnings, field.ErrorList) {
+				return nil, req.Object.validateReadOnly(req.OldObject)
+			},
+		},
+		{
+			Name:       "version-changes",
+			Order:      120,
+			Operations: []plugins.Operation{plugins.Update},
+			Validate: func(ctx context.Context, req plugins.Request[*ObservabilityPlatform]) (admission.Warnings, field.ErrorList) {
+				return req.Object.validateVersionChanges(ctx, req.OldObject), nil
+			},
+		},
+		{
+			// Warn about the defaults which change the behavior of the platform
+			Name:  "applied-default-warnings",
+			Order: 200,
+			Validate: func(_ context.Context, req plugins.Request[*ObservabilityPlatform]) (admission.Warnings, field.ErrorList) {
+				if req.OldObject == nil {
+					return appliedDefaultWarnings(req.Object, nil), nil
+				}
+				return appliedDefaultWarnings(req.Object, req.OldObject), nil
+			},
+		},
+		{
+			Name:  "spec",
+			Order: 300,
+			Validate: func(ctx context.Context, req plugins.Request[*ObservabilityPlatform]) (admission.Warnings, field.ErrorList) {
+				return nil, req.Object.validateSpec(ctx, globalConfigValidator)
+			},
+		},
+		{
+			// Parse the rule expressions, and warn about rule selectors on
+			// external labels, which never match
+			Name:  "rule-expressions",
+			Order: 310,
+			Validate: func(_ context.Context, req plugins.Request[*ObservabilityPlatform]) (admission.Warnings, field.ErrorList) {
+				return req.Object.checkRuleExpressions()
+			},
+		},
+		{
+			// Warn about the TLS constraints of FIPS mode Prometheus can't
+			// apply to remote write
+			Name:  "fips-remote-write",
+			Order: 320,
+			Validate: func(_ context.Context, req plugins.Request[*ObservabilityPlatform]) (admission.Warnings, field.ErrorList) {
+				return req.Object.fipsRemoteWriteWarnings(), nil
+			},
+		},
+		{
+			Name:  "resource-quota",
+			Order: 400,
+			Gate:  &plugins.Gate{Name: AdmissionGateResourceQuota, Default: true},
+			Validate: func(ctx context.Context, req plugins.Request[*ObservabilityPlatform]) (admission.Warnings, field.ErrorList) {
+				return req.Object.validateResourceQuota(ctx, req.OldObject)
+			},
+		},
+		{
+			// Validate that the zones claimed by zone-aware components exist
+			Name:  "zones",
+			Order: 500,
+			Gate:  &plugins.Gate{Name: AdmissionGateZones, Default: true},
+			Validate: func(ctx context.Context, req plugins.Request[*ObservabilityPlatform]) (admission.Warnings, field.ErrorList) {
+				if globalZoneValidator == nil {
+					return nil, nil
+				}
+				return nil, globalZoneValidator.ValidateZones(ctx, req.Object)
+			},
+		},
+		{
+			// Validate that the referenced priority classes exist
+			Name:  "priority-classes",
+			Order: 600,
+			Gate:  &plugins.Gate{Name: AdmissionGatePriority, Default: true},
+			Validate: func(ctx context.Context, req plugins.Request[*ObservabilityPlatform]) (admission.Warnings, field.ErrorList) {
+				if globalPriorityClassValidator == nil {
+					return nil, nil
+				}
+				return nil, globalPriorityClassValidator.ValidatePriorityClasses(ctx, req.Object)
+			},
+		},
+		{
+			// Warn about replicas that would fit on no node, if asked to
+			Name:         "feasibility",
+			Order:        900,
+			Gate:         &plugins.Gate{Name: AdmissionGateFeasibility, Default: true},
+			SkipOnErrors: true,
+			Validate: func(ctx context.Context, req plugins.Request[*ObservabilityPlatform]) (admission.Warnings, field.ErrorList) {
+				if globalFeasibilityValidator == nil {
+					return nil, nil
+				}
+				return globalFeasibilityValidator.Warnings(ctx, req.Object), nil
+			},
+		},
+	}
+}
+
+// validateResourceQuota validates the platform fits the resource quotas of
+// its namespace. Updates are only checked if they increase the resources.
+func (r *ObservabilityPlatform) validateResourceQuota(ctx context.Context, old *ObservabilityPlatform) (admission.Warnings, field.ErrorList) {
+	if globalQuotaValidator == nil {
+		observabilityplatformlog.V(1).Info("quota validator not initialized, skipping quota validation")
+		return nil, nil
+	}
+	if old != nil && !r.hasIncreasedResources(old) {
+		return nil, nil
+	}
+
+	allErrs := globalQuotaValidator.ValidateResourceQuota(ctx, r)
+	if len(allErrs) == 0 {
+		return nil, nil
+	}
+
+	// Add a warning with quota summary
+	var warnings admission.Warnings
+	if summary, err := globalQuotaValidator.GetQuotaSummary(ctx, r.Namespace); err == nil {
+		warnings = append(warnings, fmt.Sprintf("Resource quota validation failed. Current quota status:\n%s", summary))
+	}
+	return warnings, allErrs
+}
+
+// fipsRemoteWriteWarnings warns that the cipher suites of FIPS mode are not
+// enforced on remote write: Prometheus can only require a minimum TLS version
+// of its clients, and the remote write targets pick the cipher suite.
+func (r *ObservabilityPlatform) fipsRemoteWriteWarnings() admission.Warnings {
+	if !r.IsFIPSEnabled() || r.Spec.Security.FIPS.MinTLSVersion == TLSVersion13 {
+		return nil
+	}
+	if r.Spec.Components == nil || r.Spec.Components.Prometheus == nil || len(r.Spec.Components.Prometheus.RemoteWrite) == 0 {
+		return nil
+	}
+	return admission.Warnings{
+		"spec.components.prometheus.remoteWrite: Prometheus can't restrict the TLS 1.2 cipher suites of remote write; " +
+			"restrict them on the remote write targets or set spec.security.fips.minTLSVersion to 1.3",
+	}
+}
+
+// checkRuleExpressions parses the PromQL expressions of the alerting and
+// recording rules. Invalid expressions are errors, since Prometheus refuses
+// to load a rule file with one; selectors requiring an external label are
+// warnings.
+func (r *ObservabilityPlatform) checkRuleExpressions() (admission.Warnings, field.ErrorList) {
+	alerting := r.Spec.Alerting
+	if alerting == nil {
+		return nil, nil
+	}
+
+	var global, prometheus map[string]string
+	if r.Spec.Global != nil {
+		global = r.Spec.Global.ExternalLabels
+	}
+	if r.Spec.Components != nil && r.Spec.Components.Prometheus != nil {
+		prometheus = r.Spec.Components.Prometheus.ExternalLabels
+	}
+	externalLabels := correlation.Merge(global, r.CorrelationLabels(), prometheus)
+
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	check := func(fldPath *field.Path, expr string) {
+		if expr == "" {
+			return
+		}
+		result := promqlcheck.Validate(expr, externalLabels)
+		if result.Err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, expr, result.Err.Error()))
+		}
+		for _, warning := range result.Warnings {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", fldPath, warning))
+		}
+	}
+
+	rulesPath := field.NewPath("spec", "alerting", "rules")
+	for i, rule := range alerting.Rules {
+		check(rulesPath.Index(i).Child("expression"), rule.Expression)
+	}
+	groupsPath := field.NewPath("spec", "alerting", "recordingRules")
+	for i, group := range alerting.RecordingRules {
+		for j, rule := range group.Rules {
+			check(groupsPath.Index(i).Child("rules").Index(j).Child("expr"), rule.Expr)
+		}
+	}
+	return warnings, allErrs
+}
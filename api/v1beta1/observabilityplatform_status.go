@@ -297,6 +297,11 @@ func (p *ObservabilityPlatform) UpdateEndpoint(component, endpoint string) {
 	p.Status.Endpoints[component] = endpoint
 }
 
+// RemoveEndpoint removes the endpoint for a component
+func (p *ObservabilityPlatform) RemoveEndpoint(component string) {
+	delete(p.Status.Endpoints, component)
+}
+
 // GetEndpoint returns the endpoint for a component
 func (p *ObservabilityPlatform) GetEndpoint(component string) string {
 	if p.Status.Endpoints == nil {
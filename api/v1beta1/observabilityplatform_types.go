@@ -7,6 +7,7 @@ Licensed under the MIT License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -42,6 +43,15 @@ type ObservabilityPlatformSpec struct {
 	// +kubebuilder:default=false
 	Paused bool `json:"paused,omitempty"`
 
+	// Mode is the operating mode of the platform. A readOnly platform
+	// rejects spec changes other than the mode and keeps its components as
+	// they are while still reporting their health, e.g. during an incident
+	// freeze.
+	// +kubebuilder:validation:Enum=normal;readOnly
+	// +kubebuilder:default=normal
+	// +optional
+	Mode PlatformMode `json:"mode,omitempty"`
+
 	// GitOps configuration for declarative management
 	// +optional
 	GitOps *GitOpsConfig `json:"gitOps,omitempty"`
@@ -49,6 +59,72 @@ type ObservabilityPlatformSpec struct {
 	// ServiceMesh configuration for service mesh integration
 	// +optional
 	ServiceMesh *ServiceMeshSpec `json:"serviceMesh,omitempty"`
+
+	// Security configuration such as FIPS/strict-TLS mode
+	// +optional
+	Security *SecuritySettings `json:"security,omitempty"`
+
+	// StatusPage configures the read-only public status page
+	// +optional
+	StatusPage *StatusPageSpec `json:"statusPage,omitempty"`
+
+	// IncidentAnnotations marks component incidents on Grafana dashboards
+	// +optional
+	IncidentAnnotations *IncidentAnnotationsSpec `json:"incidentAnnotations,omitempty"`
+
+	// UpgradeHooks configures the data migration hooks run around component upgrades
+	// +optional
+	UpgradeHooks *UpgradeHooksSpec `json:"upgradeHooks,omitempty"`
+
+	// UpgradeSilences silences the platform's alerts in Alertmanager while
+	// the operator upgrades a component
+	// +optional
+	UpgradeSilences *UpgradeSilencesSpec `json:"upgradeSilences,omitempty"`
+
+	// UpgradeSnapshots takes VolumeSnapshots of a component's volumes before
+	// the operator upgrades it
+	// +optional
+	UpgradeSnapshots *UpgradeSnapshotsSpec `json:"upgradeSnapshots,omitempty"`
+
+	// QueryACL restricts the metrics each team can query by label, and its
+	// logs by Loki tenant
+	// +optional
+	QueryACL *QueryACLSpec `json:"queryACL,omitempty"`
+
+	// ConfigValidation validates component configurations before they are rolled out
+	// +optional
+	ConfigValidation *ConfigValidationSpec `json:"configValidation,omitempty"`
+
+	// ObservabilityMixins installs community monitoring mixins: dashboards,
+	// recording rules and alerts matching the component versions
+	// +optional
+	ObservabilityMixins *ObservabilityMixinsSpec `json:"observabilityMixins,omitempty"`
+
+	// BasePlatformRef references a platform whose spec is merged under this
+	// one, such as an organization-wide golden configuration
+	// +optional
+	BasePlatformRef *BasePlatformRef `json:"basePlatformRef,omitempty"`
+
+	// Exporters deploys well-known Prometheus exporters, such as
+	// [{type: postgres, address: db:5432}], with their scrape configuration,
+	// dashboards and default alerts
+	// +optional
+	Exporters []ExporterSpec `json:"exporters,omitempty"`
+
+	// SelfMonitoring makes the platform scrape the operator's metrics and
+	// provisions the operator dashboard
+	// +optional
+	SelfMonitoring *SelfMonitoringSpec `json:"selfMonitoring,omitempty"`
+
+	// DataProtection controls what happens to the platform's data when it is
+	// deleted
+	// +optional
+	DataProtection *DataProtectionSpec `json:"dataProtection,omitempty"`
+
+	// IngestGateway terminates the telemetry of producers outside of the
+	// cluster with authentication and tenant routing
+	// +optional
+	IngestGateway *IngestGatewaySpec `json:"ingestGateway,omitempty"`
 }
 
 // Components defines the observability components to deploy
@@ -1565,6 +1641,27 @@ type Components struct {
 	// OpenTelemetry Collector configuration
 	// +optional
 	OpenTelemetryCollector *OpenTelemetryCollectorSpec `json:"opentelemetryCollector,omitempty"`
+
+	// GrafanaAlloy configures Grafana Alloy as the collection layer
+	// +optional
+	GrafanaAlloy *GrafanaAlloySpec `json:"grafanaAlloy,omitempty"`
+
+	// Profiling configures the continuous profiling backend
+	// +optional
+	Profiling *ProfilingSpec `json:"profiling,omitempty"`
+
+	// EBPFAgent configures the eBPF agent instrumenting services without
+	// code changes
+	// +optional
+	EBPFAgent *EBPFAgentSpec `json:"ebpfAgent,omitempty"`
+
+	// EventExporter exports Kubernetes events into Loki
+	// +optional
+	EventExporter *EventExporterSpec `json:"eventExporter,omitempty"`
+
+	// AuditLogs collects Kubernetes audit logs into Loki
+	// +optional
+	AuditLogs *AuditLogsSpec `json:"auditLogs,omitempty"`
 }
 
 
@@ -1599,6 +1696,104 @@ type Components struct {
 	// AdminUser for Prometheus (if auth is enabled)
 	// +optional
 	AdminUser string `json:"adminUser,omitempty"`
+
+	// Affinity overrides the global affinity for this component
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints override the global topology spread constraints
+	// for this component. Constraints without a label selector select the
+	// component's pods.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName overrides the global priority class for this component
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// GuaranteedQoS overrides the global guaranteed QoS setting for this component
+	// +optional
+	GuaranteedQoS *bool `json:"guaranteedQoS,omitempty"`
+
+	// EphemeralStorage configures ephemeral-storage requests and limits,
+	// emptyDir sizing and the WAL volume of this component
+	// +optional
+	EphemeralStorage *EphemeralStorageSpec `json:"ephemeralStorage,omitempty"`
+
+	// ExtraContainers are added to the component's pods, e.g. log shippers,
+	// auth proxies or exporters. Names must not collide with the managed
+	// containers.
+	// +optional
+	ExtraContainers []corev1.Container `json:"extraContainers,omitempty"`
+
+	// ExtraVolumes are added to the component's pods for use by the extra
+	// containers. Names must not collide with the managed volumes.
+	// +optional
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// Env adds environment variables to the component's container, e.g.
+	// proxy settings or feature flags. Variables managed by the operator
+	// cannot be overridden.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// EnvFrom adds environment variables from ConfigMaps or Secrets to the
+	// component's container
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// ReloadStrategy is how configuration changes are applied. Reload calls
+	// the component's reload endpoint and is the default for components
+	// supporting it (Prometheus, Grafana); Restart rolls the pods.
+	// +kubebuilder:validation:Enum=Reload;Restart
+	// +optional
+	ReloadStrategy string `json:"reloadStrategy,omitempty"`
+
+	// UpdateStrategy controls how changes roll across the component's
+	// replicas. Defaults to RollingUpdate.
+	// +optional
+	UpdateStrategy *ComponentUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// Probes override the liveness, readiness and startup probes of the
+	// component's container
+	// +optional
+	Probes *ProbesSpec `json:"probes,omitempty"`
+
+	// ComponentLogging sets the log level of the component and turns on
+	// debug mode
+	ComponentLogging `json:",inline"`
+
+	// QueryLog enables the query log and slow-query reporting
+	// +optional
+	QueryLog *QueryLogSpec `json:"queryLog,omitempty"`
+
+	// NoisyAlerts reports the alerts that fire and resolve frequently
+	// +optional
+	NoisyAlerts *NoisyAlertsSpec `json:"noisyAlerts,omitempty"`
+
+	// QueryLimits protects Prometheus from long and expensive queries
+	// +optional
+	QueryLimits *QueryLimitsSpec `json:"queryLimits,omitempty"`
+
+	// Thanos configures the Thanos components storing Prometheus blocks in object storage
+	// +optional
+	Thanos *ThanosSpec `json:"thanos,omitempty"`
+
+	// ExternalLabelsFrom reads external labels from a ConfigMap key holding
+	// a YAML map, e.g. the cluster and region labels shared by the
+	// platforms of a cluster. ExternalLabels override the labels read.
+	// +optional
+	ExternalLabelsFrom *ValueFromSource `json:"externalLabelsFrom,omitempty"`
+
+	// ExternalLabelTransition writes the series with both the previous and
+	// the new external labels for a while after they change
+	// +optional
+	ExternalLabelTransition *ExternalLabelTransitionSpec `json:"externalLabelTransition,omitempty"`
+
+	// AdditionalScrapeConfigsFrom reads additional scrape configurations
+	// from a ConfigMap key, appended to AdditionalScrapeConfigs
+	// +optional
+	AdditionalScrapeConfigsFrom *ValueFromSource `json:"additionalScrapeConfigsFrom,omitempty"`
 }
 
 
@@ -1635,6 +1830,91 @@ type Components struct {
 	// DataSources to configure automatically
 	// +optional
 	DataSources []DataSourceSpec `json:"dataSources,omitempty"`
+
+	// Affinity overrides the global affinity for this component
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints override the global topology spread constraints
+	// for this component. Constraints without a label selector select the
+	// component's pods.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName overrides the global priority class for this component
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// GuaranteedQoS overrides the global guaranteed QoS setting for this component
+	// +optional
+	GuaranteedQoS *bool `json:"guaranteedQoS,omitempty"`
+
+	// EphemeralStorage configures ephemeral-storage requests and limits,
+	// emptyDir sizing and the WAL volume of this component
+	// +optional
+	EphemeralStorage *EphemeralStorageSpec `json:"ephemeralStorage,omitempty"`
+
+	// ExtraContainers are added to the component's pods, e.g. log shippers,
+	// auth proxies or exporters. Names must not collide with the managed
+	// containers.
+	// +optional
+	ExtraContainers []corev1.Container `json:"extraContainers,omitempty"`
+
+	// ExtraVolumes are added to the component's pods for use by the extra
+	// containers. Names must not collide with the managed volumes.
+	// +optional
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// Env adds environment variables to the component's container, e.g.
+	// proxy settings or feature flags. Variables managed by the operator
+	// cannot be overridden.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// EnvFrom adds environment variables from ConfigMaps or Secrets to the
+	// component's container
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// ReloadStrategy is how configuration changes are applied. Reload calls
+	// the component's reload endpoint and is the default for components
+	// supporting it (Prometheus, Grafana); Restart rolls the pods.
+	// +kubebuilder:validation:Enum=Reload;Restart
+	// +optional
+	ReloadStrategy string `json:"reloadStrategy,omitempty"`
+
+	// UpdateStrategy controls how changes roll across the component's
+	// replicas. Defaults to RollingUpdate.
+	// +optional
+	UpdateStrategy *ComponentUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// Probes override the liveness, readiness and startup probes of the
+	// component's container
+	// +optional
+	Probes *ProbesSpec `json:"probes,omitempty"`
+
+	// ComponentLogging sets the log level of the component and turns on
+	// debug mode
+	ComponentLogging `json:",inline"`
+
+	// DashboardsFromGit imports JSON dashboards from a Git repository
+	// +optional
+	DashboardsFromGit *DashboardsFromGitSpec `json:"dashboardsFromGit,omitempty"`
+
+	// JsonnetDashboards renders dashboards defined in Jsonnet, such as
+	// Grafonnet dashboards or monitoring mixins, from ConfigMaps
+	// +optional
+	JsonnetDashboards []JsonnetDashboardSource `json:"jsonnetDashboards,omitempty"`
+
+	// ImageRenderer deploys grafana-image-renderer for rendering panels
+	// and dashboards to images
+	// +optional
+	ImageRenderer *GrafanaImageRendererSpec `json:"imageRenderer,omitempty"`
+
+	// Reports are dashboards rendered on a schedule and delivered by email
+	// or webhook
+	// +optional
+	Reports []GrafanaReportSpec `json:"reports,omitempty"`
 }
 
 
@@ -1657,6 +1937,76 @@ type Components struct {
 	// Retention configuration for logs
 	// +optional
 	Retention *RetentionSpec `json:"retention,omitempty"`
+
+	// Affinity overrides the global affinity for this component
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints override the global topology spread constraints
+	// for this component. Constraints without a label selector select the
+	// component's pods.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName overrides the global priority class for this component
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// GuaranteedQoS overrides the global guaranteed QoS setting for this component
+	// +optional
+	GuaranteedQoS *bool `json:"guaranteedQoS,omitempty"`
+
+	// EphemeralStorage configures ephemeral-storage requests and limits,
+	// emptyDir sizing and the WAL volume of this component
+	// +optional
+	EphemeralStorage *EphemeralStorageSpec `json:"ephemeralStorage,omitempty"`
+
+	// ExtraContainers are added to the component's pods, e.g. log shippers,
+	// auth proxies or exporters. Names must not collide with the managed
+	// containers.
+	// +optional
+	ExtraContainers []corev1.Container `json:"extraContainers,omitempty"`
+
+	// ExtraVolumes are added to the component's pods for use by the extra
+	// containers. Names must not collide with the managed volumes.
+	// +optional
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// Env adds environment variables to the component's container, e.g.
+	// proxy settings or feature flags. Variables managed by the operator
+	// cannot be overridden.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// EnvFrom adds environment variables from ConfigMaps or Secrets to the
+	// component's container
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// ReloadStrategy is how configuration changes are applied. Reload calls
+	// the component's reload endpoint and is the default for components
+	// supporting it (Prometheus, Grafana); Restart rolls the pods.
+	// +kubebuilder:validation:Enum=Reload;Restart
+	// +optional
+	ReloadStrategy string `json:"reloadStrategy,omitempty"`
+
+	// UpdateStrategy controls how changes roll across the component's
+	// replicas. Defaults to RollingUpdate.
+	// +optional
+	UpdateStrategy *ComponentUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// Probes override the liveness, readiness and startup probes of the
+	// component's container
+	// +optional
+	Probes *ProbesSpec `json:"probes,omitempty"`
+
+	// ComponentLogging sets the log level of the component and turns on
+	// debug mode
+	ComponentLogging `json:",inline"`
+
+	// ZoneAwareness configures zone-aware replication of the ingesters
+	// +optional
+	ZoneAwareness *ZoneAwarenessSpec `json:"zoneAwareness,omitempty"`
 }
 
 
@@ -1675,6 +2025,86 @@ type Components struct {
 	// Storage configuration
 	// +optional
 	Storage *StorageSpec `json:"storage,omitempty"`
+
+	// Affinity overrides the global affinity for this component
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints override the global topology spread constraints
+	// for this component. Constraints without a label selector select the
+	// component's pods.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName overrides the global priority class for this component
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// GuaranteedQoS overrides the global guaranteed QoS setting for this component
+	// +optional
+	GuaranteedQoS *bool `json:"guaranteedQoS,omitempty"`
+
+	// EphemeralStorage configures ephemeral-storage requests and limits,
+	// emptyDir sizing and the WAL volume of this component
+	// +optional
+	EphemeralStorage *EphemeralStorageSpec `json:"ephemeralStorage,omitempty"`
+
+	// ExtraContainers are added to the component's pods, e.g. log shippers,
+	// auth proxies or exporters. Names must not collide with the managed
+	// containers.
+	// +optional
+	ExtraContainers []corev1.Container `json:"extraContainers,omitempty"`
+
+	// ExtraVolumes are added to the component's pods for use by the extra
+	// containers. Names must not collide with the managed volumes.
+	// +optional
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// Env adds environment variables to the component's container, e.g.
+	// proxy settings or feature flags. Variables managed by the operator
+	// cannot be overridden.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// EnvFrom adds environment variables from ConfigMaps or Secrets to the
+	// component's container
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// ReloadStrategy is how configuration changes are applied. Reload calls
+	// the component's reload endpoint and is the default for components
+	// supporting it (Prometheus, Grafana); Restart rolls the pods.
+	// +kubebuilder:validation:Enum=Reload;Restart
+	// +optional
+	ReloadStrategy string `json:"reloadStrategy,omitempty"`
+
+	// UpdateStrategy controls how changes roll across the component's
+	// replicas. Defaults to RollingUpdate.
+	// +optional
+	UpdateStrategy *ComponentUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// Probes override the liveness, readiness and startup probes of the
+	// component's container
+	// +optional
+	Probes *ProbesSpec `json:"probes,omitempty"`
+
+	// ComponentLogging sets the log level of the component and turns on
+	// debug mode
+	ComponentLogging `json:",inline"`
+
+	// ZoneAwareness configures zone-aware replication of the ingesters
+	// +optional
+	ZoneAwareness *ZoneAwarenessSpec `json:"zoneAwareness,omitempty"`
+
+	// ReceiverTLS serves the trace receivers over TLS with a certificate
+	// from cert-manager or an existing Secret
+	// +optional
+	ReceiverTLS *ReceiverTLSSpec `json:"receiverTLS,omitempty"`
+
+	// Receivers enables the trace receivers one by one and exposes them
+	// through dedicated Services or Ingresses
+	// +optional
+	Receivers *TraceReceiversSpec `json:"receivers,omitempty"`
 }
 
 // OpenTelemetryCollectorSpec defines OpenTelemetry Collector configuration
@@ -1695,6 +2125,51 @@ type OpenTelemetryCollectorSpec struct {
 	// Resources defines the compute resources
 	// +optional
 	Resources *ResourceRequirements `json:"resources,omitempty"`
+
+	// Affinity overrides the global affinity for this component
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints override the global topology spread constraints
+	// for this component. Constraints without a label selector select the
+	// component's pods.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName overrides the global priority class for this component
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// GuaranteedQoS overrides the global guaranteed QoS setting for this component
+	// +optional
+	GuaranteedQoS *bool `json:"guaranteedQoS,omitempty"`
+
+	// EphemeralStorage configures ephemeral-storage requests and limits,
+	// emptyDir sizing and the WAL volume of this component
+	// +optional
+	EphemeralStorage *EphemeralStorageSpec `json:"ephemeralStorage,omitempty"`
+
+	// ExtraContainers are added to the component's pods, e.g. log shippers,
+	// auth proxies or exporters. Names must not collide with the managed
+	// containers.
+	// +optional
+	ExtraContainers []corev1.Container `json:"extraContainers,omitempty"`
+
+	// ExtraVolumes are added to the component's pods for use by the extra
+	// containers. Names must not collide with the managed volumes.
+	// +optional
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// Env adds environment variables to the component's container, e.g.
+	// proxy settings or feature flags. Variables managed by the operator
+	// cannot be overridden.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// EnvFrom adds environment variables from ConfigMaps or Secrets to the
+	// component's container
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
 }
 
 // ResourceRequirements defines resource requests and limits
@@ -1900,6 +2375,39 @@ type GlobalSettings struct {
 	// +optional
 	Tolerations []Toleration `json:"tolerations,omitempty"`
 
+	// NodePool schedules all components on a dedicated node pool
+	// +optional
+	NodePool *NodePoolSpec `json:"nodePool,omitempty"`
+
+	// Affinity for all components, including node affinity and pod
+	// (anti-)affinity
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints for all components. Constraints without a
+	// label selector select the pods of the component they are applied to.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName for all components, so observability workloads are
+	// preempted and evicted after lower-priority workloads under node pressure.
+	// The PriorityClass must exist in the cluster.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// GuaranteedQoS makes all components run in the Guaranteed QoS class by
+	// setting resource limits equal to requests for CPU and memory
+	// +optional
+	GuaranteedQoS bool `json:"guaranteedQoS,omitempty"`
+
+	// SchedulingCheck simulates on admission whether the replicas of all
+	// components fit on the cluster's nodes, given their allocatable
+	// resources, taints and zones, and warns about the ones that would stay
+	// Pending. The Schedulable condition reports the same check each
+	// reconcile.
+	// +optional
+	SchedulingCheck bool `json:"schedulingCheck,omitempty"`
+
 	// SecurityContext for all components
 	// +optional
 	SecurityContext *SecurityContextSpec `json:"securityContext,omitempty"`
@@ -1907,6 +2415,16 @@ type GlobalSettings struct {
 	// RetentionPolicies defines data retention for each component
 	// +optional
 	RetentionPolicies *RetentionPolicies `json:"retentionPolicies,omitempty"`
+
+	// Correlation keeps the labels and tenant of metrics, logs and traces
+	// consistent, so Grafana can correlate them
+	// +optional
+	Correlation *CorrelationSpec `json:"correlation,omitempty"`
+
+	// CapacityPlanning forecasts the growth of series, logs, spans and
+	// storage from the usage history
+	// +optional
+	CapacityPlanning *CapacityPlanningSpec `json:"capacityPlanning,omitempty"`
 }
 
 // Toleration represents a Kubernetes toleration
@@ -1995,6 +2513,29 @@ type AlertingSettings struct {
 	// Rules defines alerting rules
 	// +optional
 	Rules []AlertingRule `json:"rules,omitempty"`
+
+	// Runbooks is the registry used to add runbook_url annotations to generated alerts
+	// +optional
+	Runbooks *RunbookRegistry `json:"runbooks,omitempty"`
+
+	// RecordingRules defines recording rules evaluated alongside the alerting rules
+	// +optional
+	RecordingRules []RecordingRuleGroup `json:"recordingRules,omitempty"`
+
+	// Backfill configures the backfill of history for newly added recording rules
+	// +optional
+	Backfill *RecordingRuleBackfillSpec `json:"backfill,omitempty"`
+
+	// InhibitRules mute the notifications of target alerts while a source
+	// alert fires. They are added to the inhibit rules of the Alertmanager
+	// configuration.
+	// +optional
+	InhibitRules []InhibitRule `json:"inhibitRules,omitempty"`
+
+	// MuteTimeIntervals define recurring time intervals, such as maintenance
+	// windows, which routes reference to mute their notifications
+	// +optional
+	MuteTimeIntervals []MuteTimeInterval `json:"muteTimeIntervals,omitempty"`
 }
 
 // AlertmanagerSpec defines Alertmanager configuration
@@ -2043,6 +2584,11 @@ type AlertingRule struct {
 	// Annotations to add to the alert
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// RunbookKey references an entry in the runbook registry.
+	// Defaults to the rule name, then to the registry's default key.
+	// +optional
+	RunbookKey string `json:"runbookKey,omitempty"`
 }
 
 // ObservabilityPlatformStatus defines the observed state of ObservabilityPlatform
@@ -2076,6 +2622,126 @@ type ObservabilityPlatformStatus struct {
 	// ServiceMesh contains service mesh status information
 	// +optional
 	ServiceMesh *ServiceMeshStatus `json:"serviceMesh,omitempty"`
+
+	// Availability contains the per-component availability history used for SLA reports
+	// +optional
+	Availability *AvailabilityStatus `json:"availability,omitempty"`
+
+	// OpenIncidents tracks components with an open incident annotation, keyed by component
+	// +optional
+	OpenIncidents map[string]IncidentAnnotationStatus `json:"openIncidents,omitempty"`
+
+	// LastChangeSummary is a human-readable summary of the component settings
+	// changed by the last reconcile that applied changes,
+	// e.g. "prometheus: version v2.48.0→v2.51.0, resources.limits.memory 4Gi→6Gi"
+	// +optional
+	LastChangeSummary string `json:"lastChangeSummary,omitempty"`
+
+	// LastChangeTime is when the changes in LastChangeSummary were applied
+	// +optional
+	LastChangeTime *metav1.Time `json:"lastChangeTime,omitempty"`
+
+	// AppliedSettings is the flattened view of the component settings applied
+	// by the last reconcile, used to compute LastChangeSummary
+	// +optional
+	AppliedSettings map[string]string `json:"appliedSettings,omitempty"`
+
+	// AppliedVersions are the component versions rolled out with all their
+	// upgrade hooks completed, keyed by component
+	// +optional
+	AppliedVersions map[string]string `json:"appliedVersions,omitempty"`
+
+	// UpgradeHooks reports the migration hooks of in-progress component
+	// upgrades, keyed by component
+	// +optional
+	UpgradeHooks map[string]UpgradeHookStatus `json:"upgradeHooks,omitempty"`
+
+	// UpgradeSilences tracks the Alertmanager silences of in-progress
+	// component upgrades, keyed by component
+	// +optional
+	UpgradeSilences map[string]UpgradeSilenceStatus `json:"upgradeSilences,omitempty"`
+
+	// UpgradeSnapshots lists the volume snapshots taken before the latest
+	// upgrades, keyed by component, newest first
+	// +optional
+	UpgradeSnapshots map[string][]UpgradeSnapshotStatus `json:"upgradeSnapshots,omitempty"`
+
+	// RecordingRuleBackfill reports the backfill of recording rules
+	// +optional
+	RecordingRuleBackfill *RecordingRuleBackfillStatus `json:"recordingRuleBackfill,omitempty"`
+
+	// ConfigValidation reports the validation of the component configurations,
+	// keyed by component
+	// +optional
+	ConfigValidation map[string]ConfigValidationStatus `json:"configValidation,omitempty"`
+
+	// Reloads reports the in-place configuration reloads, keyed by component
+	// +optional
+	Reloads map[string]ReloadStatus `json:"reloads,omitempty"`
+
+	// ExternalLabels are the external labels Prometheus was last configured with
+	// +optional
+	ExternalLabels map[string]string `json:"externalLabels,omitempty"`
+
+	// ExternalLabelTransition tracks the dual-write window of the last
+	// external label change while it is open
+	// +optional
+	ExternalLabelTransition *ExternalLabelTransitionStatus `json:"externalLabelTransition,omitempty"`
+
+	// Debug tracks debug mode, keyed by component
+	// +optional
+	Debug map[string]ComponentDebugStatus `json:"debug,omitempty"`
+
+	// DashboardsFromGit reports the import of dashboards from Git
+	// +optional
+	DashboardsFromGit *DashboardsFromGitStatus `json:"dashboardsFromGit,omitempty"`
+
+	// GrafanaReports reports the runs of the scheduled Grafana reports
+	// +optional
+	GrafanaReports []GrafanaReportStatus `json:"grafanaReports,omitempty"`
+
+	// ObservabilityMixins reports the installed mixins, keyed by name
+	// +optional
+	ObservabilityMixins map[string]MixinStatus `json:"observabilityMixins,omitempty"`
+
+	// BasePlatforms are the base platforms applied to the spec, nearest first
+	// +optional
+	BasePlatforms []InheritedBase `json:"basePlatforms,omitempty"`
+
+	// DataProtection reports the progress of the deletion policy while the
+	// platform is being deleted
+	// +optional
+	DataProtection *DataProtectionStatus `json:"dataProtection,omitempty"`
+
+	// TrackedResources is the number of children outside the platform's
+	// namespace, linked to it by tracking labels instead of ownerReferences
+	// +optional
+	TrackedResources int32 `json:"trackedResources,omitempty"`
+
+	// ResourceFootprint is the CPU, memory and storage requested by the
+	// platform's workloads, updated each reconcile
+	// +optional
+	ResourceFootprint *ResourceFootprint `json:"resourceFootprint,omitempty"`
+
+	// CredentialRotation reports when the credentials generated for the
+	// platform were last rotated
+	// +optional
+	CredentialRotation *CredentialRotationStatus `json:"credentialRotation,omitempty"`
+
+	// AppliedDefaults lists the defaults applied by the webhook which change
+	// the behavior of the platform, like retentions and limits
+	// +optional
+	AppliedDefaults []AppliedDefault `json:"appliedDefaults,omitempty"`
+
+	// StorageMigrations tracks the migrations of component volumes to another
+	// StorageClass, keyed by component
+	// +optional
+	StorageMigrations map[string]StorageMigrationStatus `json:"storageMigrations,omitempty"`
+
+	// Capacity reports the 30 and 90 day forecasts of the platform's usage
+	// and the scaling actions they call for
+	// +optional
+	Capacity *CapacityStatus `json:"capacity,omitempty"`
 }
 
 // ComponentStatus represents the status of a single component
@@ -2102,6 +2768,11 @@ type ComponentStatus struct {
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.components.prometheus.version`,priority=1
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=`.spec.mode`,priority=1
+// +kubebuilder:printcolumn:name="Last Change",type=string,JSONPath=`.status.lastChangeSummary`,priority=1
+// +kubebuilder:printcolumn:name="CPU",type=string,JSONPath=`.status.resourceFootprint.cpuRequests`,priority=1
+// +kubebuilder:printcolumn:name="Memory",type=string,JSONPath=`.status.resourceFootprint.memoryRequests`,priority=1
+// +kubebuilder:printcolumn:name="Storage",type=string,JSONPath=`.status.resourceFootprint.storage`,priority=1
 
 // ObservabilityPlatform is the Schema for the observabilityplatforms API
 type ObservabilityPlatform struct {
@@ -13,11 +13,15 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -25,7 +29,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/gunjanjp/gunj-operator/internal/templatevars"
+	"github.com/gunjanjp/gunj-operator/internal/webhook/plugins"
+	"github.com/gunjanjp/gunj-operator/internal/webhook/priority"
 	"github.com/gunjanjp/gunj-operator/internal/webhook/quota"
+	"github.com/gunjanjp/gunj-operator/internal/webhook/scheduling"
+	"github.com/gunjanjp/gunj-operator/internal/webhook/topology"
 	"github.com/gunjanjp/gunj-operator/internal/webhooks"
 )
 
@@ -59,53 +68,61 @@ func (r *ObservabilityPlatform) SetupWebhookWithManager(mgr ctrl.Manager) error
 	// Store the validators in global variables for use in validation
 	globalQuotaValidator = quotaValidator
 	globalConfigValidator = configValidator
+	globalZoneValidator = &topology.ZoneValidator{Client: mgr.GetClient()}
+	globalPriorityClassValidator = &priority.PriorityClassValidator{Client: mgr.GetClient()}
+	globalFeasibilityValidator = &scheduling.FeasibilityValidator{Reader: mgr.GetAPIReader()}
 
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithDefaulter(platformDefaulter{}).
 		Complete()
 }
 
+// platformDefaulter runs the defaulters of the admission chain in the webhook,
+// rejecting the request if one fails instead of admitting it half defaulted
+type platformDefaulter struct{}
+
+// Default implements webhook.CustomDefaulter
+func (platformDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	platform, ok := obj.(*ObservabilityPlatform)
+	if !ok {
+		return fmt.Errorf("expected ObservabilityPlatform object, got %T", obj)
+	}
+	observabilityplatformlog.Info("default", "name", platform.Name)
+	return platform.SetDefaults(ctx)
+}
+
 // Global validator instances
 var (
-	globalQuotaValidator  *quota.ResourceQuotaValidator
-	globalConfigValidator *webhooks.ConfigurationValidator
+	globalQuotaValidator         *quota.ResourceQuotaValidator
+	globalConfigValidator        *webhooks.ConfigurationValidator
+	globalZoneValidator          *topology.ZoneValidator
+	globalPriorityClassValidator *priority.PriorityClassValidator
+	globalFeasibilityValidator   *scheduling.FeasibilityValidator
 )
 
 // +kubebuilder:webhook:path=/mutate-observability-io-v1beta1-observabilityplatform,mutating=true,failurePolicy=fail,sideEffects=None,groups=observability.io,resources=observabilityplatforms,verbs=create;update,versions=v1beta1,name=mobservabilityplatform.kb.io,admissionReviewVersions=v1
 // +kubebuilder:webhook:path=/validate-observability-io-v1beta1-observabilityplatform,mutating=false,failurePolicy=fail,sideEffects=None,groups=observability.io,resources=observabilityplatforms,verbs=create;update,versions=v1beta1,name=vobservabilityplatform.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Defaulter = &ObservabilityPlatform{}
+var _ webhook.CustomDefaulter = platformDefaulter{}
 var _ webhook.Validator = &ObservabilityPlatform{}
 
-// Default implements webhook.Defaulter so a webhook will be registered for the type
+// Default implements webhook.Defaulter. The errors of the defaulters are
+// logged; the webhook and callers that need them use SetDefaults.
 func (r *ObservabilityPlatform) Default() {
-	observabilityplatformlog.Info("default", "name", r.Name)
-	
-	// Set default metadata
-	r.defaultMetadata()
-	
-	// Initialize spec if nil
-	if r.Spec.Components == nil {
-		r.Spec.Components = &Components{}
+	if err := r.SetDefaults(context.Background()); err != nil {
+		observabilityplatformlog.Error(err, "failed to set defaults", "name", r.Name)
 	}
-	
-	// Set defaults for each component
-	r.defaultPrometheus()
-	r.defaultGrafana()
-	r.defaultLoki()
-	r.defaultTempo()
-	
-	// Set global defaults
-	r.defaultGlobalSettings()
-	
-	// Set high availability defaults
-	r.defaultHighAvailability()
-	
-	// Set backup defaults
-	r.defaultBackup()
-	
-	// Set alerting defaults
-	r.defaultAlerting()
+}
+
+// SetDefaults runs the defaulters of the admission chain. The defaulters after
+// a failing one still run; the errors of all are returned.
+func (r *ObservabilityPlatform) SetDefaults(ctx context.Context) error {
+	before := specFields(r.Spec)
+	err := admissionChain.Default(ctx, r)
+	recordDefaultedFields(r, before)
+	return err
 }
 
 // defaultMetadata sets default labels and annotations
@@ -174,6 +191,7 @@ func (r *ObservabilityPlatform) defaultPrometheus() {
 				Memory: "2Gi",
 			},
 		}
+		recordResourceLimitDefaults(r, "spec.components.prometheus.resources", prom.Resources)
 	} else {
 		// Ensure requests and limits are set
 		if prom.Resources.Requests == nil {
@@ -187,6 +205,7 @@ func (r *ObservabilityPlatform) defaultPrometheus() {
 				CPU:    "1",
 				Memory: "2Gi",
 			}
+			recordResourceLimitDefaults(r, "spec.components.prometheus.resources", prom.Resources)
 		}
 	}
 	
@@ -196,12 +215,14 @@ func (r *ObservabilityPlatform) defaultPrometheus() {
 			Size:      "10Gi",
 			Retention: "15d",
 		}
+		recordDefault(r, "spec.components.prometheus.storage.retention", prom.Storage.Retention)
 	} else {
 		if prom.Storage.Size == "" {
 			prom.Storage.Size = "10Gi"
 		}
 		if prom.Storage.Retention == "" {
 			prom.Storage.Retention = "15d"
+			recordDefault(r, "spec.components.prometheus.storage.retention", prom.Storage.Retention)
 		}
 	}
 	
@@ -213,6 +234,26 @@ func (r *ObservabilityPlatform) defaultPrometheus() {
 	if _, ok := prom.ExternalLabels["cluster"]; !ok {
 		prom.ExternalLabels["cluster"] = "default"
 	}
+	
+	// Set default query limits, scaled with the size profile
+	limits := DefaultQueryLimits(r.Labels[SizeProfileLabel])
+	if prom.QueryLimits == nil {
+		prom.QueryLimits = &QueryLimitsSpec{}
+	}
+	if prom.QueryLimits.Timeout == "" {
+		prom.QueryLimits.Timeout = limits.Timeout
+		recordDefault(r, "spec.components.prometheus.queryLimits.timeout", prom.QueryLimits.Timeout)
+	}
+	if prom.QueryLimits.MaxSamples == 0 {
+		prom.QueryLimits.MaxSamples = limits.MaxSamples
+		recordDefault(r, "spec.components.prometheus.queryLimits.maxSamples", prom.QueryLimits.MaxSamples)
+	}
+	if prom.QueryLimits.MaxConcurrency == 0 {
+		prom.QueryLimits.MaxConcurrency = limits.MaxConcurrency
+	}
+	if prom.QueryLimits.LookbackDelta == "" {
+		prom.QueryLimits.LookbackDelta = limits.LookbackDelta
+	}
 }
 
 // defaultGrafana sets defaults for Grafana component
@@ -249,6 +290,7 @@ func (r *ObservabilityPlatform) defaultGrafana() {
 				Memory: "1Gi",
 			},
 		}
+		recordResourceLimitDefaults(r, "spec.components.grafana.resources", grafana.Resources)
 	}
 	
 	// Generate admin password if not provided
@@ -296,6 +338,7 @@ func (r *ObservabilityPlatform) defaultLoki() {
 				Memory: "1Gi",
 			},
 		}
+		recordResourceLimitDefaults(r, "spec.components.loki.resources", loki.Resources)
 	}
 	
 	// Set default storage
@@ -314,6 +357,7 @@ func (r *ObservabilityPlatform) defaultLoki() {
 			DeletesEnabled:         true,
 			CompactionInterval:     "10m",
 		}
+		recordDefault(r, "spec.components.loki.retention.days", loki.Retention.Days)
 	}
 	
 	// Set default replicas
@@ -347,6 +391,7 @@ func (r *ObservabilityPlatform) defaultTempo() {
 				Memory: "1Gi",
 			},
 		}
+		recordResourceLimitDefaults(r, "spec.components.tempo.resources", tempo.Resources)
 	}
 	
 	// Set default storage
@@ -405,6 +450,9 @@ func (r *ObservabilityPlatform) defaultGlobalSettings() {
 			Logs:    "7d",
 			Traces:  "3d",
 		}
+		recordDefault(r, "spec.global.retentionPolicies.metrics", r.Spec.Global.RetentionPolicies.Metrics)
+		recordDefault(r, "spec.global.retentionPolicies.logs", r.Spec.Global.RetentionPolicies.Logs)
+		recordDefault(r, "spec.global.retentionPolicies.traces", r.Spec.Global.RetentionPolicies.Traces)
 	}
 }
 
@@ -457,6 +505,7 @@ func (r *ObservabilityPlatform) defaultBackup() {
 	// Set default retention (7 days)
 	if r.Spec.Backup.Retention == 0 {
 		r.Spec.Backup.Retention = 7
+		recordDefault(r, "spec.backup.retention", r.Spec.Backup.Retention)
 	}
 	
 	// Set default storage type
@@ -557,13 +606,44 @@ func generateSecurePassword(length int) string {
 func (r *ObservabilityPlatform) ValidateCreate() (admission.Warnings, error) {
 	observabilityplatformlog.Info("validate create", "name", r.Name)
 	
+	return r.admit(plugins.Request[*ObservabilityPlatform]{Operation: plugins.Create, Object: r})
+}
+
+// admit runs the validators of the admission chain. Validators that could
+// not complete, such as an unreachable callout, report internal errors; they
+// don't make the platform invalid, so they are returned as an internal error
+// unless another validator rejected the platform.
+func (r *ObservabilityPlatform) admit(req plugins.Request[*ObservabilityPlatform]) (admission.Warnings, error) {
+	warnings, allErrs := admissionChain.Validate(context.Background(), req)
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+	
+	if len(allErrs.Filter(field.NewErrorTypeMatcher(field.ErrorTypeInternal))) == 0 {
+		return warnings, errors.NewInternalError(allErrs.ToAggregate())
+	}
+	return warnings, errors.NewInvalid(
+		schema.GroupKind{Group: GroupVersion.Group, Kind: "ObservabilityPlatform"},
+		r.Name, allErrs)
+}
+
+// ValidateOffline validates the platform like the admission webhook on
+// create, without the checks needing cluster access: resource quotas, zones
+// and priority classes. The mutating webhook's defaults are not applied; call
+// Default first to validate the platform as admitted.
+func (r *ObservabilityPlatform) ValidateOffline(configValidator *webhooks.ConfigurationValidator) field.ErrorList {
+	allErrs := r.validateSpec(context.Background(), configValidator)
+	_, ruleErrs := r.checkRuleExpressions()
+	return append(allErrs, ruleErrs...)
+}
+
+// validateSpec applies the validation which needs no cluster access
+func (r *ObservabilityPlatform) validateSpec(ctx context.Context, configValidator *webhooks.ConfigurationValidator) field.ErrorList {
 	var allErrs field.ErrorList
-	var warnings admission.Warnings
-	ctx := context.Background()
 	
 	// Use configuration validator for comprehensive validation
-	if globalConfigValidator != nil {
-		if err := globalConfigValidator.ValidateConfiguration(ctx, r); err != nil {
+	if configValidator != nil {
+		if err := configValidator.ValidateConfiguration(ctx, r); err != nil {
 			allErrs = append(allErrs, err...)
 		}
 	} else {
@@ -588,83 +668,183 @@ func (r *ObservabilityPlatform) ValidateCreate() (admission.Warnings, error) {
 		allErrs = append(allErrs, err...)
 	}
 	
-	// Validate resource quotas
-	if globalQuotaValidator != nil {
-		if err := globalQuotaValidator.ValidateResourceQuota(ctx, r); err != nil {
-			allErrs = append(allErrs, err...)
-			
-			// Add a warning with quota summary
-			if summary, summaryErr := globalQuotaValidator.GetQuotaSummary(ctx, r.Namespace); summaryErr == nil {
-				warnings = append(warnings, fmt.Sprintf("Resource quota validation failed. Current quota status:\n%s", summary))
-			}
+	// Validate the Alloy pipelines have somewhere to send their signals
+	allErrs = append(allErrs, r.validateAlloy()...)
+	
+	// Validate the profiling backend supports the requested retention
+	allErrs = append(allErrs, r.validateProfiling()...)
+	
+	// Validate the exporters have unique names and supported credentials
+	allErrs = append(allErrs, r.validateExporters()...)
+	
+	// Validate the ingest gateway authenticates its tenants
+	allErrs = append(allErrs, r.validateIngestGateway()...)
+	
+	// Validate the event exporter has a Loki to push to
+	if components := r.Spec.Components; components != nil && components.EventExporter != nil &&
+		components.EventExporter.Enabled && (components.Loki == nil || !components.Loki.Enabled) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "components", "eventExporter", "enabled"), true,
+			"the event exporter requires Loki to be enabled"))
+	}
+	
+	// Validate the audit logs have a Loki to push to
+	if components := r.Spec.Components; components != nil && components.AuditLogs != nil && components.AuditLogs.Enabled {
+		auditPath := field.NewPath("spec", "components", "auditLogs")
+		if components.Loki == nil || !components.Loki.Enabled {
+			allErrs = append(allErrs, field.Invalid(auditPath.Child("enabled"), true,
+				"audit logs require Loki to be enabled"))
+		}
+		if p := components.AuditLogs.Path; p != "" && !strings.HasPrefix(p, "/") {
+			allErrs = append(allErrs, field.Invalid(auditPath.Child("path"), p,
+				"the audit log path must be absolute"))
 		}
-	} else {
-		observabilityplatformlog.V(1).Info("quota validator not initialized, skipping quota validation")
 	}
 	
-	if len(allErrs) == 0 {
-		return warnings, nil
+	// Validate the eBPF agent feeds at least one backend
+	if components := r.Spec.Components; components.EBPFAgentEnabled() &&
+		!components.EBPFAgentMetrics() && !components.EBPFAgentTraces() {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "components", "ebpfAgent", "enabled"), true,
+			"the eBPF agent must export metrics to Prometheus or traces to Tempo, which must be enabled"))
 	}
 	
-	return warnings, errors.NewInvalid(
-		schema.GroupKind{Group: GroupVersion.Group, Kind: "ObservabilityPlatform"},
-		r.Name, allErrs)
+	return allErrs
 }
 
-// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (r *ObservabilityPlatform) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
-	observabilityplatformlog.Info("validate update", "name", r.Name)
-	
-	oldObj, ok := old.(*ObservabilityPlatform)
-	if !ok {
-		return nil, fmt.Errorf("expected ObservabilityPlatform object")
+// validateProfiling checks retention is only set for Pyroscope, as Parca
+// does not expire profiles
+func (r *ObservabilityPlatform) validateProfiling() field.ErrorList {
+	var allErrs field.ErrorList
+	components := r.Spec.Components
+	if !components.ProfilingEnabled() {
+		return allErrs
 	}
-	
+	spec := components.Profiling
+	if spec.Retention != "" && spec.GetBackend() != ProfilingBackendPyroscope {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "components", "profiling", "retention"),
+			fmt.Sprintf("retention is not supported by %s", spec.GetBackend())))
+	}
+	return allErrs
+}
+
+// validateExporters checks the exporters have unique names, as the names
+// identify their objects and Prometheus jobs, and only reference passwords
+// where the exporter accepts one
+func (r *ObservabilityPlatform) validateExporters() field.ErrorList {
 	var allErrs field.ErrorList
-	var warnings admission.Warnings
-	ctx := context.Background()
-	
-	// Validate immutable fields
-	if err := r.validateImmutableFields(ctx, oldObj); err != nil {
-		allErrs = append(allErrs, err...)
+	fldPath := field.NewPath("spec", "exporters")
+	names := map[string]bool{}
+	for i, exporter := range r.Spec.Exporters {
+		name := exporter.GetName()
+		if names[name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), name))
+		}
+		names[name] = true
+		if exporter.PasswordSecretRef != nil && (exporter.Type == "nginx" || exporter.Type == "jvm") {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("passwordSecretRef"),
+				fmt.Sprintf("the %s exporter does not support passwords", exporter.Type)))
+		}
 	}
-	
-	// Validate component version downgrades
-	if warn := r.validateVersionChanges(ctx, oldObj); len(warn) > 0 {
-		warnings = append(warnings, warn...)
+	return allErrs
+}
+
+// validateIngestGateway checks every tenant of the ingest gateway can be
+// authenticated in the configured mode and writes to an enabled backend.
+// mTLS is terminated by the gateway, so it cannot sit behind an Ingress.
+func (r *ObservabilityPlatform) validateIngestGateway() field.ErrorList {
+	var allErrs field.ErrorList
+	spec := r.Spec.IngestGateway
+	if !spec.IsEnabled() {
+		return allErrs
 	}
+	fldPath := field.NewPath("spec", "ingestGateway")
+	mode := spec.GetAuthMode()
 	
-	// Validate resource quotas for scaled resources
-	if globalQuotaValidator != nil {
-		// Check if resource requirements have increased
-		if r.hasIncreasedResources(oldObj) {
-			if err := globalQuotaValidator.ValidateResourceQuota(ctx, r); err != nil {
-				allErrs = append(allErrs, err...)
-				
-				// Add a warning with quota summary
-				if summary, summaryErr := globalQuotaValidator.GetQuotaSummary(ctx, r.Namespace); summaryErr == nil {
-					warnings = append(warnings, fmt.Sprintf("Resource quota validation failed during update. Current quota status:\n%s", summary))
-				}
-			}
+	if mode == IngestAuthMTLS {
+		if spec.TLS == nil || spec.TLS.SecretName == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("tls", "secretName"), "mTLS requires a serving certificate"))
+		}
+		if spec.TLS == nil || spec.TLS.ClientCASecretName == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("tls", "clientCASecretName"), "mTLS requires a client CA"))
 		}
+		if spec.Ingress != nil && spec.Ingress.Enabled {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("ingress"), "mTLS must be terminated by the gateway"))
+		}
+	}
+	if spec.Ingress != nil && spec.Ingress.Enabled && spec.Ingress.Host == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("ingress", "host"), "host is required"))
 	}
 	
-	// Run create validations
-	if createWarnings, err := r.ValidateCreate(); err != nil {
-		if invalidErr, ok := err.(*errors.StatusError); ok {
-			allErrs = append(allErrs, invalidErr.ErrStatus.Details.Causes...)
+	// In the APIKey mode, ApiKeys may be the only tenants
+	if mode == IngestAuthMTLS && len(spec.Tenants) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("tenants"), "at least one tenant is required in the MTLS mode"))
+	}
+	names := map[string]bool{}
+	for i, tenant := range spec.Tenants {
+		tenantPath := fldPath.Child("tenants").Index(i)
+		if names[tenant.Name] {
+			allErrs = append(allErrs, field.Duplicate(tenantPath.Child("name"), tenant.Name))
+		}
+		names[tenant.Name] = true
+		switch {
+		case mode == IngestAuthAPIKey && tenant.APIKeySecretRef == nil:
+			allErrs = append(allErrs, field.Required(tenantPath.Child("apiKeySecretRef"), "required in the APIKey mode"))
+		case mode == IngestAuthMTLS && len(tenant.ClientCommonNames) == 0:
+			allErrs = append(allErrs, field.Required(tenantPath.Child("clientCommonNames"), "required in the MTLS mode"))
 		}
-	} else if len(createWarnings) > 0 {
-		warnings = append(warnings, createWarnings...)
 	}
 	
-	if len(allErrs) == 0 {
-		return warnings, nil
+	components := r.Spec.Components
+	if components == nil || ((components.Prometheus == nil || !components.Prometheus.Enabled) &&
+		(components.Loki == nil || !components.Loki.Enabled) && (components.Tempo == nil || !components.Tempo.Enabled)) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("enabled"), true,
+			"the ingest gateway requires Prometheus, Loki or Tempo to be enabled"))
 	}
 	
-	return warnings, errors.NewInvalid(
-		schema.GroupKind{Group: GroupVersion.Group, Kind: "ObservabilityPlatform"},
-		r.Name, allErrs)
+	return allErrs
+}
+
+// validateAlloy checks each enabled Alloy pipeline has a backend: the
+// platform's component or an explicit endpoint
+func (r *ObservabilityPlatform) validateAlloy() field.ErrorList {
+	var allErrs field.ErrorList
+	components := r.Spec.Components
+	if !components.AlloyEnabled() {
+		return allErrs
+	}
+	spec := components.GrafanaAlloy
+	fldPath := field.NewPath("spec", "components", "grafanaAlloy")
+
+	pipelines := []struct {
+		name     string
+		pipeline *AlloyPipelineSpec
+		backend  string
+		enabled  bool
+	}{
+		{"metrics", spec.Metrics, "prometheus", components.Prometheus != nil && components.Prometheus.Enabled},
+		{"logs", spec.Logs, "loki", components.Loki != nil && components.Loki.Enabled},
+		{"traces", spec.Traces, "tempo", components.Tempo != nil && components.Tempo.Enabled},
+		{"profiles", spec.Profiles, "profiling", components.ProfilingAcceptsPushes()},
+	}
+	for _, p := range pipelines {
+		if !p.pipeline.IsEnabled(p.enabled) || p.enabled || p.pipeline.GetEndpoint() != "" {
+			continue
+		}
+		allErrs = append(allErrs, field.Required(fldPath.Child(p.name, "endpoint"),
+			fmt.Sprintf("endpoint is required when %s is disabled", p.backend)))
+	}
+	return allErrs
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *ObservabilityPlatform) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	observabilityplatformlog.Info("validate update", "name", r.Name)
+	
+	oldObj, ok := old.(*ObservabilityPlatform)
+	if !ok {
+		return nil, fmt.Errorf("expected ObservabilityPlatform object")
+	}
+	
+	return r.admit(plugins.Request[*ObservabilityPlatform]{Operation: plugins.Update, Object: r, OldObject: oldObj})
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -754,12 +934,52 @@ func (r *ObservabilityPlatform) validatePrometheus(fldPath *field.Path) field.Er
 			if !isValidLabelName(k) {
 				allErrs = append(allErrs, field.Invalid(fldPath.Child("externalLabels").Key(k), k, "invalid label name"))
 			}
-			if !isValidLabelValue(v) {
+			// Template variables are rendered by the operator
+			if templatevars.IsTemplate(v) {
+				if err := templatevars.Check(v); err != nil {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("externalLabels").Key(k), v, err.Error()))
+				}
+			} else if !isValidLabelValue(v) {
 				allErrs = append(allErrs, field.Invalid(fldPath.Child("externalLabels").Key(k), v, "invalid label value"))
 			}
 		}
 	}
 	
+	// Validate query limits
+	if prom.QueryLimits != nil {
+		allErrs = append(allErrs, validateQueryLimits(fldPath.Child("queryLimits"), prom.QueryLimits)...)
+	}
+	
+	// Validate values read from ConfigMaps
+	allErrs = append(allErrs, validateValueFrom(fldPath.Child("externalLabelsFrom"), prom.ExternalLabelsFrom)...)
+	allErrs = append(allErrs, validateValueFrom(fldPath.Child("additionalScrapeConfigsFrom"), prom.AdditionalScrapeConfigsFrom)...)
+	
+	// Validate update strategy
+	allErrs = append(allErrs, validateUpdateStrategy(fldPath.Child("updateStrategy"), prom.UpdateStrategy, true)...)
+	allErrs = append(allErrs, validateStagedRollout(fldPath.Child("updateStrategy"), prom.UpdateStrategy, false, nil)...)
+	
+	return allErrs
+}
+
+// validateValueFrom validates a reference to a value kept in a ConfigMap
+func validateValueFrom(fldPath *field.Path, src *ValueFromSource) field.ErrorList {
+	var allErrs field.ErrorList
+	if src == nil {
+		return allErrs
+	}
+	
+	ref := src.ConfigMapKeyRef
+	if ref == nil {
+		return append(allErrs, field.Required(fldPath.Child("configMapKeyRef"), "a value source is required"))
+	}
+	refPath := fldPath.Child("configMapKeyRef")
+	if ref.Name == "" {
+		allErrs = append(allErrs, field.Required(refPath.Child("name"), "ConfigMap name is required"))
+	}
+	if ref.Key == "" {
+		allErrs = append(allErrs, field.Required(refPath.Child("key"), "ConfigMap key is required"))
+	}
+	
 	return allErrs
 }
 
@@ -792,6 +1012,10 @@ func (r *ObservabilityPlatform) validateGrafana(fldPath *field.Path) field.Error
 		}
 	}
 	
+	// Validate update strategy
+	allErrs = append(allErrs, validateUpdateStrategy(fldPath.Child("updateStrategy"), grafana.UpdateStrategy, false)...)
+	allErrs = append(allErrs, validateStagedRollout(fldPath.Child("updateStrategy"), grafana.UpdateStrategy, false, nil)...)
+	
 	return allErrs
 }
 
@@ -826,6 +1050,10 @@ func (r *ObservabilityPlatform) validateLoki(fldPath *field.Path) field.ErrorLis
 		}
 	}
 	
+	// Validate update strategy
+	allErrs = append(allErrs, validateUpdateStrategy(fldPath.Child("updateStrategy"), loki.UpdateStrategy, true)...)
+	allErrs = append(allErrs, validateStagedRollout(fldPath.Child("updateStrategy"), loki.UpdateStrategy, true, loki.ZoneAwareness)...)
+	
 	return allErrs
 }
 
@@ -848,6 +1076,14 @@ func (r *ObservabilityPlatform) validateTempo(fldPath *field.Path) field.ErrorLi
 		}
 	}
 	
+	// Validate update strategy
+	allErrs = append(allErrs, validateUpdateStrategy(fldPath.Child("updateStrategy"), tempo.UpdateStrategy, true)...)
+	allErrs = append(allErrs, validateStagedRollout(fldPath.Child("updateStrategy"), tempo.UpdateStrategy, true, tempo.ZoneAwareness)...)
+	
+	// Validate receiver TLS
+	allErrs = append(allErrs, validateReceiverTLS(fldPath.Child("receiverTLS"), tempo.ReceiverTLS)...)
+	allErrs = append(allErrs, validateTraceReceivers(fldPath.Child("receivers"), tempo.Receivers)...)
+	
 	return allErrs
 }
 
@@ -867,7 +1103,12 @@ func (r *ObservabilityPlatform) validateGlobalSettings(ctx context.Context) fiel
 			if !isValidLabelName(k) {
 				allErrs = append(allErrs, field.Invalid(globalPath.Child("externalLabels").Key(k), k, "invalid label name"))
 			}
-			if !isValidLabelValue(v) {
+			// Template variables are rendered by the operator
+			if templatevars.IsTemplate(v) {
+				if err := templatevars.Check(v); err != nil {
+					allErrs = append(allErrs, field.Invalid(globalPath.Child("externalLabels").Key(k), v, err.Error()))
+				}
+			} else if !isValidLabelValue(v) {
 				allErrs = append(allErrs, field.Invalid(globalPath.Child("externalLabels").Key(k), v, "invalid label value"))
 			}
 		}
@@ -1000,6 +1241,222 @@ func (r *ObservabilityPlatform) validateResourceRequirements(fldPath *field.Path
 	return allErrs
 }
 
+// Bounds of the Prometheus query limits. Beyond them a single query can
+// exhaust the memory of Prometheus or hold its query slots for too long.
+const (
+	minQueryTimeout       = time.Second
+	maxQueryTimeout       = 30 * time.Minute
+	minQueryMaxSamples    = 1000
+	maxQueryMaxSamples    = 1000000000
+	maxQueryConcurrency   = 256
+	minQueryLookbackDelta = 15 * time.Second
+	maxQueryLookbackDelta = time.Hour
+)
+
+// validateQueryLimits rejects query limits that leave Prometheus unprotected
+// or unable to answer ordinary queries
+func validateQueryLimits(fldPath *field.Path, limits *QueryLimitsSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	
+	validateDuration := func(name, value string, min, max time.Duration) {
+		if value == "" {
+			return
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(name), value, "invalid duration"))
+			return
+		}
+		if d < min || d > max {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(name), value, fmt.Sprintf("must be between %s and %s", min, max)))
+		}
+	}
+	validateDuration("timeout", limits.Timeout, minQueryTimeout, maxQueryTimeout)
+	
+	if limits.MaxSamples != 0 && (limits.MaxSamples < minQueryMaxSamples || limits.MaxSamples > maxQueryMaxSamples) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxSamples"), limits.MaxSamples, fmt.Sprintf("must be between %d and %d", minQueryMaxSamples, maxQueryMaxSamples)))
+	}
+	if limits.MaxConcurrency < 0 || limits.MaxConcurrency > maxQueryConcurrency {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxConcurrency"), limits.MaxConcurrency, fmt.Sprintf("must be between 1 and %d", maxQueryConcurrency)))
+	}
+	
+	// Below the scrape interval instant queries miss series between scrapes
+	validateDuration("lookbackDelta", limits.LookbackDelta, minQueryLookbackDelta, maxQueryLookbackDelta)
+	
+	return allErrs
+}
+
+// validateUpdateStrategy validates the update strategy of a component.
+// StatefulSets support RollingUpdate and OnDelete with a partition,
+// Deployments RollingUpdate and Recreate with a surge.
+func validateUpdateStrategy(fldPath *field.Path, strategy *ComponentUpdateStrategy, statefulSet bool) field.ErrorList {
+	var allErrs field.ErrorList
+	if strategy == nil {
+		return allErrs
+	}
+	
+	rolling := strategy.Type == "" || strategy.Type == UpdateStrategyRollingUpdate
+	switch {
+	case statefulSet && strategy.Type == UpdateStrategyRecreate:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), strategy.Type, []string{UpdateStrategyRollingUpdate, UpdateStrategyOnDelete}))
+	case !statefulSet && strategy.Type == UpdateStrategyOnDelete:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), strategy.Type, []string{UpdateStrategyRollingUpdate, UpdateStrategyRecreate}))
+	}
+	
+	if strategy.Partition != nil {
+		if !statefulSet {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("partition"), "only supported by StatefulSet components"))
+		} else if !rolling {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("partition"), "only supported by the RollingUpdate strategy"))
+		} else if *strategy.Partition < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("partition"), *strategy.Partition, "must not be negative"))
+		}
+	}
+	if strategy.MaxSurge != nil && statefulSet {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("maxSurge"), "only supported by Deployment components"))
+	}
+	
+	validateIntOrPercent := func(name string, value *intstr.IntOrString) {
+		if value == nil {
+			return
+		}
+		if !rolling {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(name), "only supported by the RollingUpdate strategy"))
+			return
+		}
+		if n, err := intstr.GetScaledValueFromIntOrPercent(value, 100, true); err != nil || n < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(name), value.String(), "must be a non-negative number or percentage"))
+		}
+	}
+	validateIntOrPercent("maxUnavailable", strategy.MaxUnavailable)
+	validateIntOrPercent("maxSurge", strategy.MaxSurge)
+	
+	return allErrs
+}
+
+// validateStagedRollout validates the staged rollout of a component. Only
+// the unzoned ingester StatefulSets of Loki and Tempo are rolled out in
+// stages, and the operator then owns the partition.
+func validateStagedRollout(fldPath *field.Path, strategy *ComponentUpdateStrategy, supported bool, zoneAwareness *ZoneAwarenessSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if !strategy.IsStaged() {
+		return allErrs
+	}
+	stagedPath := fldPath.Child("stagedRollout")
+	
+	switch {
+	case !supported:
+		allErrs = append(allErrs, field.Forbidden(stagedPath, "only supported by Loki and Tempo"))
+	case zoneAwareness.IsEnabled():
+		allErrs = append(allErrs, field.Forbidden(stagedPath, "not supported with zone awareness"))
+	case strategy.Type != "" && strategy.Type != UpdateStrategyRollingUpdate:
+		allErrs = append(allErrs, field.Forbidden(stagedPath, "only supported by the RollingUpdate strategy"))
+	case strategy.Partition != nil:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("partition"), "managed by the staged rollout"))
+	}
+	
+	validateDuration := func(name, value string) {
+		if value == "" {
+			return
+		}
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			allErrs = append(allErrs, field.Invalid(stagedPath.Child(name), value, "must be a positive duration"))
+		}
+	}
+	validateDuration("stabilizationPeriod", strategy.StagedRollout.StabilizationPeriod)
+	validateDuration("flushTimeout", strategy.StagedRollout.FlushTimeout)
+	
+	return allErrs
+}
+
+// validateReceiverTLS validates the TLS of the trace receivers. The serving
+// certificate comes either from a cert-manager issuer or from a Secret.
+func validateReceiverTLS(fldPath *field.Path, receiverTLS *ReceiverTLSSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if !receiverTLS.IsEnabled() {
+		return allErrs
+	}
+	
+	switch {
+	case receiverTLS.IssuerRef == nil && receiverTLS.SecretName == "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("issuerRef"), "either issuerRef or secretName is required"))
+	case receiverTLS.IssuerRef != nil && receiverTLS.SecretName != "":
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("secretName"), "may not be set together with issuerRef"))
+	}
+	if receiverTLS.IssuerRef == nil {
+		return allErrs
+	}
+	if receiverTLS.IssuerRef.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("issuerRef", "name"), "issuer name is required"))
+	}
+	
+	parse := func(name, value string) time.Duration {
+		if value == "" {
+			return 0
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(name), value, "must be a positive duration"))
+			return 0
+		}
+		return d
+	}
+	duration := parse("duration", receiverTLS.Duration)
+	renewBefore := parse("renewBefore", receiverTLS.RenewBefore)
+	if duration > 0 && renewBefore >= duration {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("renewBefore"), receiverTLS.RenewBefore, "must be shorter than the duration"))
+	}
+	
+	return allErrs
+}
+
+// validateTraceReceivers validates the enablement and exposure of the
+// trace receivers. Tempo needs at least one receiver, and only the HTTP
+// receivers can be exposed through an Ingress.
+func validateTraceReceivers(fldPath *field.Path, receivers *TraceReceiversSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if receivers == nil {
+		return allErrs
+	}
+	
+	enabled := 0
+	validate := func(name, jsonName string, http bool) {
+		receiver := receivers.Get(name)
+		if receivers.IsEnabled(name) {
+			enabled++
+		}
+		if receiver == nil {
+			return
+		}
+		receiverPath := fldPath.Child(jsonName)
+		if ingress := receiver.Ingress; ingress != nil && ingress.Enabled {
+			switch {
+			case !http:
+				allErrs = append(allErrs, field.Forbidden(receiverPath.Child("ingress"), "only supported by the OTLP HTTP, Jaeger Thrift HTTP and Zipkin receivers"))
+			case ingress.Host == "":
+				allErrs = append(allErrs, field.Required(receiverPath.Child("ingress", "host"), "host is required"))
+			}
+		}
+		if service := receiver.Service; service != nil && len(service.LoadBalancerSourceRanges) > 0 &&
+			service.Type != "" && service.Type != corev1.ServiceTypeLoadBalancer {
+			allErrs = append(allErrs, field.Forbidden(receiverPath.Child("service", "loadBalancerSourceRanges"), "only supported by LoadBalancer Services"))
+		}
+	}
+	validate(ReceiverOTLPGRPC, "otlpGrpc", false)
+	validate(ReceiverOTLPHTTP, "otlpHttp", true)
+	validate(ReceiverJaegerGRPC, "jaegerGrpc", false)
+	validate(ReceiverJaegerThriftHTTP, "jaegerThriftHttp", true)
+	validate(ReceiverJaegerThriftCompact, "jaegerThriftCompact", false)
+	validate(ReceiverJaegerThriftBinary, "jaegerThriftBinary", false)
+	validate(ReceiverZipkin, "zipkin", true)
+	
+	if enabled == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one receiver must be enabled"))
+	}
+	
+	return allErrs
+}
+
 // validateImmutableFields checks that immutable fields haven't changed
 func (r *ObservabilityPlatform) validateImmutableFields(ctx context.Context, old *ObservabilityPlatform) field.ErrorList {
 	var allErrs field.ErrorList
@@ -1010,6 +1467,24 @@ func (r *ObservabilityPlatform) validateImmutableFields(ctx context.Context, old
 	return allErrs
 }
 
+// validateReadOnly rejects changes to the spec of a read-only platform,
+// except to its mode so the platform can be switched back to normal
+func (r *ObservabilityPlatform) validateReadOnly(old *ObservabilityPlatform) field.ErrorList {
+	var allErrs field.ErrorList
+	if !old.IsReadOnly() {
+		return allErrs
+	}
+	
+	spec := old.Spec
+	spec.Mode = r.Spec.Mode
+	if !equality.Semantic.DeepEqual(spec, r.Spec) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"),
+			"the platform is read-only; set spec.mode to normal before changing it"))
+	}
+	
+	return allErrs
+}
+
 // validateVersionChanges checks for valid version transitions
 func (r *ObservabilityPlatform) validateVersionChanges(ctx context.Context, old *ObservabilityPlatform) admission.Warnings {
 	var warnings admission.Warnings
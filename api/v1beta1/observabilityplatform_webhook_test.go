@@ -8,14 +8,21 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/gunjanjp/gunj-operator/internal/webhook/plugins"
 )
 
 func TestObservabilityPlatformDefaulting(t *testing.T) {
@@ -470,3 +477,511 @@ func TestDefaultingIdempotency(t *testing.T) {
 	// The result should be identical
 	assert.Equal(t, firstPass, platform, "Defaulting should be idempotent")
 }
+
+func TestValidateAlloyRequiresBackends(t *testing.T) {
+	platform := &ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{Name: "alloy", Namespace: "default"},
+		Spec: ObservabilityPlatformSpec{
+			Components: &Components{
+				Prometheus: &PrometheusSpec{Enabled: true},
+				GrafanaAlloy: &GrafanaAlloySpec{
+					Enabled:  true,
+					Logs:     &AlloyPipelineSpec{Enabled: &[]bool{true}[0]},
+					Profiles: &AlloyPipelineSpec{Enabled: &[]bool{true}[0]},
+				},
+			},
+		},
+	}
+
+	errs := platform.validateAlloy()
+	require.Len(t, errs, 2)
+	assert.Equal(t, "spec.components.grafanaAlloy.logs.endpoint", errs[0].Field)
+	assert.Equal(t, "spec.components.grafanaAlloy.profiles.endpoint", errs[1].Field)
+
+	platform.Spec.Components.GrafanaAlloy.Logs.Endpoint = "http://loki.example.com/loki/api/v1/push"
+	platform.Spec.Components.GrafanaAlloy.Profiles.Endpoint = "http://pyroscope.example.com"
+	assert.Empty(t, platform.validateAlloy())
+}
+
+func TestValidateProfilingRetention(t *testing.T) {
+	platform := &ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{Name: "profiling", Namespace: "default"},
+		Spec: ObservabilityPlatformSpec{
+			Components: &Components{
+				Profiling: &ProfilingSpec{Enabled: true, Retention: "15d"},
+			},
+		},
+	}
+	assert.Empty(t, platform.validateProfiling())
+
+	platform.Spec.Components.Profiling.Backend = ProfilingBackendParca
+	errs := platform.validateProfiling()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.components.profiling.retention", errs[0].Field)
+}
+
+func TestValidateExportersNames(t *testing.T) {
+	platform := &ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{Name: "exporters", Namespace: "default"},
+		Spec: ObservabilityPlatformSpec{
+			Exporters: []ExporterSpec{
+				{Type: "redis", Address: "cache:6379"},
+				{Type: "redis", Name: "sessions", Address: "sessions:6379"},
+			},
+		},
+	}
+	assert.Empty(t, platform.validateExporters())
+
+	platform.Spec.Exporters = append(platform.Spec.Exporters, ExporterSpec{
+		Type:              "redis",
+		Address:           "other:6379",
+		PasswordSecretRef: &corev1.SecretKeySelector{Key: "password"},
+	}, ExporterSpec{
+		Type:              "nginx",
+		Address:           "http://web/stub_status",
+		PasswordSecretRef: &corev1.SecretKeySelector{Key: "password"},
+	})
+	errs := platform.validateExporters()
+	require.Len(t, errs, 2)
+	assert.Equal(t, "spec.exporters[2].name", errs[0].Field)
+	assert.Equal(t, "spec.exporters[3].passwordSecretRef", errs[1].Field)
+}
+
+func TestValidateUpdateStrategy(t *testing.T) {
+	fldPath := field.NewPath("spec", "components", "loki", "updateStrategy")
+	partition := int32(2)
+	surge := intstr.FromString("25%")
+	invalid := intstr.FromString("half")
+
+	assert.Empty(t, validateUpdateStrategy(fldPath, nil, true))
+	assert.Empty(t, validateUpdateStrategy(fldPath, &ComponentUpdateStrategy{Partition: &partition}, true))
+	assert.Empty(t, validateUpdateStrategy(fldPath, &ComponentUpdateStrategy{Type: UpdateStrategyRecreate}, false))
+	assert.Empty(t, validateUpdateStrategy(fldPath, &ComponentUpdateStrategy{MaxSurge: &surge}, false))
+
+	errs := validateUpdateStrategy(fldPath, &ComponentUpdateStrategy{Type: UpdateStrategyRecreate}, true)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.components.loki.updateStrategy.type", errs[0].Field)
+
+	errs = validateUpdateStrategy(fldPath, &ComponentUpdateStrategy{Type: UpdateStrategyOnDelete, Partition: &partition}, true)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.components.loki.updateStrategy.partition", errs[0].Field)
+
+	errs = validateUpdateStrategy(fldPath, &ComponentUpdateStrategy{Partition: &partition, MaxUnavailable: &invalid}, false)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "spec.components.loki.updateStrategy.partition", errs[0].Field)
+	assert.Equal(t, "spec.components.loki.updateStrategy.maxUnavailable", errs[1].Field)
+}
+
+func TestValidateStagedRollout(t *testing.T) {
+	fldPath := field.NewPath("spec", "components", "tempo", "updateStrategy")
+	partition := int32(1)
+	staged := func() *ComponentUpdateStrategy {
+		return &ComponentUpdateStrategy{StagedRollout: &StagedRolloutSpec{Enabled: true, StabilizationPeriod: "2m"}}
+	}
+
+	assert.Empty(t, validateStagedRollout(fldPath, nil, false, nil))
+	assert.Empty(t, validateStagedRollout(fldPath, staged(), true, nil))
+
+	errs := validateStagedRollout(fldPath, staged(), false, nil)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.components.tempo.updateStrategy.stagedRollout", errs[0].Field)
+
+	errs = validateStagedRollout(fldPath, staged(), true, &ZoneAwarenessSpec{Enabled: true, Zones: []string{"a", "b", "c"}})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.components.tempo.updateStrategy.stagedRollout", errs[0].Field)
+
+	strategy := staged()
+	strategy.Partition = &partition
+	strategy.StagedRollout.FlushTimeout = "0s"
+	errs = validateStagedRollout(fldPath, strategy, true, nil)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "spec.components.tempo.updateStrategy.partition", errs[0].Field)
+	assert.Equal(t, "spec.components.tempo.updateStrategy.stagedRollout.flushTimeout", errs[1].Field)
+}
+
+func TestDefaultQueryLimitsScaleWithProfile(t *testing.T) {
+	platform := &ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "large",
+			Namespace: "default",
+			Labels:    map[string]string{SizeProfileLabel: "large"},
+		},
+		Spec: ObservabilityPlatformSpec{
+			Components: &Components{
+				Prometheus: &PrometheusSpec{
+					Enabled:     true,
+					QueryLimits: &QueryLimitsSpec{Timeout: "90s"},
+				},
+			},
+		},
+	}
+	platform.Default()
+
+	assert.Equal(t, &QueryLimitsSpec{Timeout: "90s", MaxSamples: 100000000, MaxConcurrency: 40, LookbackDelta: "5m"},
+		platform.Spec.Components.Prometheus.QueryLimits)
+	assert.Equal(t, DefaultQueryLimits("medium"), DefaultQueryLimits(""))
+}
+
+func TestValidateQueryLimits(t *testing.T) {
+	fldPath := field.NewPath("spec", "components", "prometheus", "queryLimits")
+	for _, profile := range []string{"small", "medium", "large"} {
+		limits := DefaultQueryLimits(profile)
+		assert.Empty(t, validateQueryLimits(fldPath, &limits), profile)
+	}
+
+	errs := validateQueryLimits(fldPath, &QueryLimitsSpec{
+		Timeout:        "2h",
+		MaxSamples:     10,
+		MaxConcurrency: 1000,
+		LookbackDelta:  "1s",
+	})
+	require.Len(t, errs, 4)
+	assert.Equal(t, "spec.components.prometheus.queryLimits.timeout", errs[0].Field)
+	assert.Equal(t, "spec.components.prometheus.queryLimits.maxSamples", errs[1].Field)
+	assert.Equal(t, "spec.components.prometheus.queryLimits.maxConcurrency", errs[2].Field)
+	assert.Equal(t, "spec.components.prometheus.queryLimits.lookbackDelta", errs[3].Field)
+}
+
+func TestValidateReceiverTLS(t *testing.T) {
+	fldPath := field.NewPath("spec", "components", "tempo", "receiverTLS")
+	issuer := &CertManagerIssuerRef{Name: "internal-ca", Kind: "ClusterIssuer"}
+
+	assert.Empty(t, validateReceiverTLS(fldPath, nil))
+	assert.Empty(t, validateReceiverTLS(fldPath, &ReceiverTLSSpec{Enabled: false}))
+	assert.Empty(t, validateReceiverTLS(fldPath, &ReceiverTLSSpec{Enabled: true, SecretName: "tempo-tls"}))
+	assert.Empty(t, validateReceiverTLS(fldPath, &ReceiverTLSSpec{Enabled: true, IssuerRef: issuer, Duration: "2160h", RenewBefore: "360h"}))
+
+	errs := validateReceiverTLS(fldPath, &ReceiverTLSSpec{Enabled: true})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.components.tempo.receiverTLS.issuerRef", errs[0].Field)
+
+	errs = validateReceiverTLS(fldPath, &ReceiverTLSSpec{Enabled: true, IssuerRef: &CertManagerIssuerRef{}, SecretName: "tempo-tls"})
+	require.Len(t, errs, 2)
+	assert.Equal(t, "spec.components.tempo.receiverTLS.secretName", errs[0].Field)
+	assert.Equal(t, "spec.components.tempo.receiverTLS.issuerRef.name", errs[1].Field)
+
+	errs = validateReceiverTLS(fldPath, &ReceiverTLSSpec{Enabled: true, IssuerRef: issuer, Duration: "24h", RenewBefore: "48h"})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.components.tempo.receiverTLS.renewBefore", errs[0].Field)
+
+	errs = validateReceiverTLS(fldPath, &ReceiverTLSSpec{Enabled: true, IssuerRef: issuer, Duration: "90d"})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.components.tempo.receiverTLS.duration", errs[0].Field)
+}
+
+func TestValidateTraceReceivers(t *testing.T) {
+	fldPath := field.NewPath("spec", "components", "tempo", "receivers")
+	disabled := false
+
+	assert.Empty(t, validateTraceReceivers(fldPath, nil))
+	assert.Empty(t, validateTraceReceivers(fldPath, &TraceReceiversSpec{
+		JaegerThriftCompact: &TraceReceiverSpec{Enabled: &disabled},
+		OTLPHTTP: &TraceReceiverSpec{
+			Service: &ReceiverServiceSpec{LoadBalancerSourceRanges: []string{"10.0.0.0/8"}},
+			Ingress: &IngressSpec{Enabled: true, Host: "otlp.example.com"},
+		},
+	}))
+
+	errs := validateTraceReceivers(fldPath, &TraceReceiversSpec{
+		OTLPGRPC: &TraceReceiverSpec{Ingress: &IngressSpec{Enabled: true, Host: "otlp.example.com"}},
+		Zipkin: &TraceReceiverSpec{
+			Service: &ReceiverServiceSpec{Type: corev1.ServiceTypeNodePort, LoadBalancerSourceRanges: []string{"10.0.0.0/8"}},
+			Ingress: &IngressSpec{Enabled: true},
+		},
+	})
+	require.Len(t, errs, 3)
+	assert.Equal(t, "spec.components.tempo.receivers.otlpGrpc.ingress", errs[0].Field)
+	assert.Equal(t, "spec.components.tempo.receivers.zipkin.ingress.host", errs[1].Field)
+	assert.Equal(t, "spec.components.tempo.receivers.zipkin.service.loadBalancerSourceRanges", errs[2].Field)
+
+	off := &TraceReceiverSpec{Enabled: &disabled}
+	errs = validateTraceReceivers(fldPath, &TraceReceiversSpec{
+		OTLPGRPC: off, OTLPHTTP: off, JaegerGRPC: off, JaegerThriftHTTP: off,
+		JaegerThriftCompact: off, JaegerThriftBinary: off, Zipkin: off,
+	})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.components.tempo.receivers", errs[0].Field)
+}
+
+func TestValidateIngestGateway(t *testing.T) {
+	platform := func(gateway *IngestGatewaySpec) *ObservabilityPlatform {
+		return &ObservabilityPlatform{
+			Spec: ObservabilityPlatformSpec{
+				Components:    &Components{Loki: &LokiSpec{Enabled: true}},
+				IngestGateway: gateway,
+			},
+		}
+	}
+	keyRef := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "team-a"}, Key: "api-key"}
+
+	assert.Empty(t, platform(nil).validateIngestGateway())
+	assert.Empty(t, platform(&IngestGatewaySpec{
+		Enabled: true,
+		Tenants: []IngestTenantSpec{{Name: "team-a", APIKeySecretRef: keyRef}},
+	}).validateIngestGateway())
+	// ApiKeys may be the only tenants
+	assert.Empty(t, platform(&IngestGatewaySpec{Enabled: true}).validateIngestGateway())
+
+	errs := platform(&IngestGatewaySpec{
+		Enabled: true,
+		Tenants: []IngestTenantSpec{{Name: "team-a", APIKeySecretRef: keyRef}, {Name: "team-a"}},
+	}).validateIngestGateway()
+	require.Len(t, errs, 2)
+	assert.Equal(t, "spec.ingestGateway.tenants[1].name", errs[0].Field)
+	assert.Equal(t, "spec.ingestGateway.tenants[1].apiKeySecretRef", errs[1].Field)
+
+	errs = platform(&IngestGatewaySpec{
+		Enabled: true,
+		Auth:    IngestGatewayAuthSpec{Mode: IngestAuthMTLS},
+		TLS:     &IngestGatewayTLSSpec{SecretName: "gateway-tls"},
+		Ingress: &IngressSpec{Enabled: true, Host: "ingest.example.com"},
+		Tenants: []IngestTenantSpec{{Name: "edge"}},
+	}).validateIngestGateway()
+	require.Len(t, errs, 3)
+	assert.Equal(t, "spec.ingestGateway.tls.clientCASecretName", errs[0].Field)
+	assert.Equal(t, "spec.ingestGateway.ingress", errs[1].Field)
+	assert.Equal(t, "spec.ingestGateway.tenants[0].clientCommonNames", errs[2].Field)
+
+	errs = platform(&IngestGatewaySpec{
+		Enabled: true,
+		Auth:    IngestGatewayAuthSpec{Mode: IngestAuthMTLS},
+		TLS:     &IngestGatewayTLSSpec{SecretName: "gateway-tls", ClientCASecretName: "gateway-client-ca"},
+	}).validateIngestGateway()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.ingestGateway.tenants", errs[0].Field)
+
+	noBackend := platform(&IngestGatewaySpec{Enabled: true, Tenants: []IngestTenantSpec{{Name: "team-a", APIKeySecretRef: keyRef}}})
+	noBackend.Spec.Components = nil
+	errs = noBackend.validateIngestGateway()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.ingestGateway.enabled", errs[0].Field)
+}
+
+func TestValidateValueFrom(t *testing.T) {
+	fldPath := field.NewPath("spec", "components", "prometheus", "externalLabelsFrom")
+	assert.Empty(t, validateValueFrom(fldPath, nil))
+	assert.Empty(t, validateValueFrom(fldPath, &ValueFromSource{
+		ConfigMapKeyRef: &ConfigMapKeyReference{Name: "cluster-labels", Namespace: "observability", Key: "labels.yaml"},
+	}))
+
+	errs := validateValueFrom(fldPath, &ValueFromSource{})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.components.prometheus.externalLabelsFrom.configMapKeyRef", errs[0].Field)
+
+	errs = validateValueFrom(fldPath, &ValueFromSource{ConfigMapKeyRef: &ConfigMapKeyReference{}})
+	require.Len(t, errs, 2)
+	assert.Equal(t, "spec.components.prometheus.externalLabelsFrom.configMapKeyRef.name", errs[0].Field)
+	assert.Equal(t, "spec.components.prometheus.externalLabelsFrom.configMapKeyRef.key", errs[1].Field)
+}
+
+func TestValidateTemplatedExternalLabels(t *testing.T) {
+	platform := &ObservabilityPlatform{
+		Spec: ObservabilityPlatformSpec{
+			Global: &GlobalSettings{
+				ExternalLabels: map[string]string{
+					"cluster": "{{ .ClusterName }}",
+					"region":  "{{ .Region }}-a",
+					"team":    "sre",
+				},
+			},
+		},
+	}
+	assert.Empty(t, platform.validateGlobalSettings(context.Background()))
+
+	platform.Spec.Global.ExternalLabels["cluster"] = "{{ .ClusterName "
+	errs := platform.validateGlobalSettings(context.Background())
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.global.externalLabels[cluster]", errs[0].Field)
+}
+
+func TestCheckRuleExpressions(t *testing.T) {
+	platform := &ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{Name: "rules", Namespace: "default"},
+		Spec: ObservabilityPlatformSpec{
+			Global: &GlobalSettings{ExternalLabels: map[string]string{"cluster": "prod-eu"}},
+			Components: &Components{
+				Prometheus: &PrometheusSpec{Enabled: true},
+			},
+			Alerting: &AlertingSettings{
+				Rules: []AlertingRule{
+					{Name: "InstanceDown", Expression: `up == 0`},
+					{Name: "HighErrorRate", Expression: `sum(rate(errors_total[5m]) by (job)`},
+					{Name: "ClusterDown", Expression: `up{cluster="prod-eu"} == 0`},
+				},
+				RecordingRules: []RecordingRuleGroup{{
+					Name:  "requests",
+					Rules: []RecordingRule{{Record: "job:requests:rate5m", Expr: `sum by (job) (rate(requests_total[5m])`}},
+				}},
+			},
+		},
+	}
+
+	warnings, errs := platform.checkRuleExpressions()
+	require.Len(t, errs, 2)
+	assert.Equal(t, "spec.alerting.rules[1].expression", errs[0].Field)
+	assert.Contains(t, errs[0].Detail, "parse error")
+	assert.Equal(t, "spec.alerting.recordingRules[0].rules[0].expr", errs[1].Field)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "spec.alerting.rules[2].expression: selector up{cluster=\"prod-eu\"} never matches")
+
+	platform.Spec.Alerting.Rules = platform.Spec.Alerting.Rules[:1]
+	platform.Spec.Alerting.RecordingRules = nil
+	warnings, errs = platform.checkRuleExpressions()
+	assert.Empty(t, warnings)
+	assert.Empty(t, errs)
+}
+
+func TestFIPSRemoteWriteWarnings(t *testing.T) {
+	platform := &ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{Name: "fips", Namespace: "default"},
+		Spec: ObservabilityPlatformSpec{
+			Security: &SecuritySettings{FIPS: &FIPSSpec{Enabled: true, MinTLSVersion: TLSVersion12}},
+			Components: &Components{
+				Prometheus: &PrometheusSpec{
+					Enabled:     true,
+					RemoteWrite: []RemoteWriteSpec{{URL: "https://metrics.example.com/api/v1/write"}},
+				},
+			},
+		},
+	}
+
+	warnings := platform.fipsRemoteWriteWarnings()
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "spec.components.prometheus.remoteWrite: Prometheus can't restrict the TLS 1.2 cipher suites")
+
+	platform.Spec.Security.FIPS.MinTLSVersion = TLSVersion13
+	assert.Empty(t, platform.fipsRemoteWriteWarnings())
+
+	platform.Spec.Security.FIPS.MinTLSVersion = TLSVersion12
+	platform.Spec.Components.Prometheus.RemoteWrite = nil
+	assert.Empty(t, platform.fipsRemoteWriteWarnings())
+}
+
+func TestAdmitIncompleteValidation(t *testing.T) {
+	defer func(chain *plugins.Chain[*ObservabilityPlatform]) { admissionChain = chain }(admissionChain)
+
+	unreachable := AdmissionPlugin{
+		Name: "unreachable",
+		Validate: func(context.Context, plugins.Request[*ObservabilityPlatform]) (admission.Warnings, field.ErrorList) {
+			return nil, field.ErrorList{field.InternalError(field.NewPath("spec"), fmt.Errorf("connection refused"))}
+		},
+	}
+	rejecting := AdmissionPlugin{
+		Name: "rejecting",
+		Validate: func(context.Context, plugins.Request[*ObservabilityPlatform]) (admission.Warnings, field.ErrorList) {
+			return nil, field.ErrorList{field.Forbidden(field.NewPath("spec", "components"), "not allowed")}
+		},
+	}
+	platform := &ObservabilityPlatform{ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "monitoring"}}
+
+	// A validator that could not complete doesn't make the platform invalid
+	admissionChain = plugins.NewChain[*ObservabilityPlatform]()
+	require.NoError(t, admissionChain.Register(unreachable))
+	_, err := platform.ValidateCreate()
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInternalError(err))
+	assert.False(t, apierrors.IsInvalid(err))
+
+	// A platform rejected by another validator is invalid
+	require.NoError(t, admissionChain.Register(rejecting))
+	_, err = platform.ValidateCreate()
+	assert.True(t, apierrors.IsInvalid(err))
+}
+
+func TestAppliedDefaults(t *testing.T) {
+	platform := &ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "default"},
+		Spec: ObservabilityPlatformSpec{
+			Components: &Components{
+				Prometheus: &PrometheusSpec{
+					Enabled: true,
+					Storage: &StorageSpec{Size: "50Gi", Retention: "30d"},
+				},
+			},
+		},
+	}
+	platform.Default()
+
+	defaults := map[string]string{}
+	for _, d := range AppliedDefaults(platform) {
+		defaults[d.Field] = d.Value
+	}
+	assert.Equal(t, "2Gi", defaults["spec.components.prometheus.resources.limits.memory"])
+	assert.Equal(t, "100000000", defaults["spec.components.prometheus.queryLimits.maxSamples"])
+	assert.Equal(t, "7d", defaults["spec.global.retentionPolicies.logs"])
+	// Values set by the user are not defaults
+	assert.NotContains(t, defaults, "spec.components.prometheus.storage.retention")
+
+	warnings := appliedDefaultWarnings(platform, nil)
+	assert.Len(t, warnings, len(defaults))
+	assert.Contains(t, warnings, "spec.components.prometheus.resources.limits.memory is not set and defaults to 2Gi")
+
+	// Replacing a default forgets it, and an update only warns about new defaults
+	old := &ObservabilityPlatform{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		AppliedDefaultsAnnotation: platform.Annotations[AppliedDefaultsAnnotation],
+	}}}
+	platform.Spec.Components.Prometheus.Resources.Limits.Memory = "4Gi"
+	platform.Spec.Components.Loki = &LokiSpec{Enabled: true}
+	platform.Default()
+
+	fields := map[string]bool{}
+	for _, d := range AppliedDefaults(platform) {
+		fields[d.Field] = true
+	}
+	assert.False(t, fields["spec.components.prometheus.resources.limits.memory"])
+	assert.True(t, fields["spec.components.prometheus.resources.limits.cpu"])
+	assert.ElementsMatch(t, admission.Warnings{
+		"spec.components.loki.resources.limits.cpu is not set and defaults to 500m",
+		"spec.components.loki.resources.limits.memory is not set and defaults to 1Gi",
+		"spec.components.loki.retention.days is not set and defaults to 7",
+	}, appliedDefaultWarnings(platform, old))
+}
+
+func TestDefaultedFields(t *testing.T) {
+	platform := &ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "default"},
+		Spec: ObservabilityPlatformSpec{
+			BasePlatformRef: &BasePlatformRef{Name: "golden"},
+			Components: &Components{
+				Prometheus: &PrometheusSpec{Enabled: true, Replicas: 2},
+			},
+		},
+	}
+	require.NoError(t, platform.SetDefaults(context.Background()))
+
+	paths := map[string]interface{}{}
+	for _, f := range DefaultedFields(platform) {
+		paths[strings.Join(f.Path, ".")] = f.Value
+	}
+	assert.Equal(t, "v2.48.0", paths["components.prometheus.version"])
+	assert.Contains(t, paths, "components.prometheus.resources")
+	// Values set by the user are not defaulted
+	assert.NotContains(t, paths, "components.prometheus.replicas")
+	assert.NotContains(t, paths, "components.prometheus.enabled")
+
+	// An update sends the defaults back; they stay recorded until replaced
+	platform.Spec.Components.Prometheus.Version = "v2.50.0"
+	require.NoError(t, platform.SetDefaults(context.Background()))
+	paths = map[string]interface{}{}
+	for _, f := range DefaultedFields(platform) {
+		paths[strings.Join(f.Path, ".")] = f.Value
+	}
+	assert.NotContains(t, paths, "components.prometheus.version")
+	assert.Contains(t, paths, "components.prometheus.resources")
+
+	// Platforms without a base record none
+	platform.Spec.BasePlatformRef = nil
+	require.NoError(t, platform.SetDefaults(context.Background()))
+	assert.NotContains(t, platform.Annotations, DefaultedFieldsAnnotation)
+}
+
+func TestAdmissionChain(t *testing.T) {
+	infos := AdmissionPlugins()
+	require.NotEmpty(t, infos)
+	for i := 1; i < len(infos); i++ {
+		assert.LessOrEqual(t, infos[i-1].Order, infos[i].Order)
+	}
+	assert.Equal(t, "prune-applied-defaults", infos[len(infos)-1].Name, "pruning runs after all defaulters")
+
+	assert.Error(t, ConfigureAdmission(map[string]bool{"ZoneValidaton": false}, nil), "unknown gate")
+}
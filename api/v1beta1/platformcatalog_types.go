@@ -0,0 +1,72 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// PlatformCatalogSpec defines the examples of a PlatformCatalog
+type PlatformCatalogSpec struct {
+	// Entries are the example platforms of the catalog
+	// +kubebuilder:validation:MinItems=1
+	Entries []PlatformCatalogEntry `json:"entries"`
+}
+
+// PlatformCatalogEntry is an example platform which can be listed and
+// instantiated by the API, the UI and gunj init
+type PlatformCatalogEntry struct {
+	// Name identifies the entry in its catalog
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Title is the display name of the entry
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// Description explains what the example is for
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Tags categorize the entry, e.g. production or logging
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Spec is the ObservabilityPlatform spec of the example
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Spec runtime.RawExtension `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=catalog,categories={observability}
+// +kubebuilder:printcolumn:name="Entries",type=string,JSONPath=`.spec.entries[*].name`,description="Example platforms"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time since creation"
+
+// PlatformCatalog is a set of curated example ObservabilityPlatform specs.
+// The operator keeps the examples it ships in the catalog named builtin;
+// edits to that catalog are overwritten.
+type PlatformCatalog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PlatformCatalogSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PlatformCatalogList contains a list of PlatformCatalog
+type PlatformCatalogList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PlatformCatalog `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PlatformCatalog{}, &PlatformCatalogList{})
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Phases of a PlatformLoadTest
+const (
+	// LoadTestPhasePending waits for the platform to be ready
+	LoadTestPhasePending = "Pending"
+	// LoadTestPhaseRunning generates the load
+	LoadTestPhaseRunning = "Running"
+	// LoadTestPhasePassed met the success threshold for every signal
+	LoadTestPhasePassed = "Passed"
+	// LoadTestPhaseFailed missed the success threshold, or could not run
+	LoadTestPhaseFailed = "Failed"
+)
+
+const (
+	// DefaultLoadTestDuration is how long the load is generated by default
+	DefaultLoadTestDuration = 10 * time.Minute
+	// DefaultLoadTestSuccessThreshold is the default percentage of the
+	// generated signals that must be ingested
+	DefaultLoadTestSuccessThreshold = 99
+	// DefaultLoadTestLineSize is the default size of a generated log line
+	DefaultLoadTestLineSize = 256
+)
+
+// PlatformLoadTestSpec defines the load generated against a platform
+type PlatformLoadTestSpec struct {
+	// Platform is the ObservabilityPlatform under test, in the namespace of
+	// the load test
+	Platform string `json:"platform"`
+
+	// Duration of the load, 10m by default
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Metrics are generated by avalanche and scraped by Prometheus
+	// +optional
+	Metrics *LoadTestMetricsSpec `json:"metrics,omitempty"`
+
+	// Logs are pushed to Loki by loki-canary, which reads them back to
+	// measure their latency
+	// +optional
+	Logs *LoadTestLogsSpec `json:"logs,omitempty"`
+
+	// Traces are sent to Tempo over OTLP by telemetrygen
+	// +optional
+	Traces *LoadTestTracesSpec `json:"traces,omitempty"`
+
+	// SuccessThreshold is the percentage of the generated signals which
+	// must be ingested for the test to pass, 99 by default
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	SuccessThreshold int32 `json:"successThreshold,omitempty"`
+}
+
+// LoadTestMetricsSpec defines the synthetic metrics
+type LoadTestMetricsSpec struct {
+	// Series is the number of active series exposed
+	// +kubebuilder:validation:Minimum=1
+	Series int32 `json:"series"`
+
+	// ChurnInterval replaces all series at this interval, none if unset
+	// +optional
+	ChurnInterval *metav1.Duration `json:"churnInterval,omitempty"`
+
+	// Image of avalanche
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// LoadTestLogsSpec defines the synthetic logs
+type LoadTestLogsSpec struct {
+	// LinesPerSecond is the rate of log lines pushed
+	// +kubebuilder:validation:Minimum=1
+	LinesPerSecond int32 `json:"linesPerSecond"`
+
+	// LineSize is the size of a line in bytes, 256 by default
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	LineSize int32 `json:"lineSize,omitempty"`
+
+	// Image of loki-canary
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// LoadTestTracesSpec defines the synthetic traces
+type LoadTestTracesSpec struct {
+	// SpansPerSecond is the rate of spans sent
+	// +kubebuilder:validation:Minimum=1
+	SpansPerSecond int32 `json:"spansPerSecond"`
+
+	// ChildSpans is the number of child spans of each trace
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ChildSpans int32 `json:"childSpans,omitempty"`
+
+	// Image of telemetrygen
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// PlatformLoadTestStatus reports the progress and outcome of a load test
+type PlatformLoadTestStatus struct {
+	// Phase of the test: Pending, Running, Passed or Failed
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// StartTime is when the load generators were deployed
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the results were collected
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Signals are the ingestion results of each signal
+	// +optional
+	Signals []LoadTestSignalResult `json:"signals,omitempty"`
+
+	// Headroom is the peak resource usage of the components under load
+	// +optional
+	Headroom []LoadTestHeadroom `json:"headroom,omitempty"`
+
+	// Message explains the phase
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// LoadTestSignalResult is the ingestion result of a signal. Values which
+// could not be measured are empty.
+type LoadTestSignalResult struct {
+	// Signal is metrics, logs or traces
+	Signal string `json:"signal"`
+
+	// Target is the generated load, e.g. 10000 series
+	Target string `json:"target"`
+
+	// Ingested is the percentage of the generated signals ingested
+	// +optional
+	Ingested string `json:"ingested,omitempty"`
+
+	// LatencyP99 is the 99th percentile of the write latency: the scrape
+	// duration for metrics, the time until a line can be read for logs and
+	// the OTLP export duration for traces
+	// +optional
+	LatencyP99 string `json:"latencyP99,omitempty"`
+
+	// Passed is true if the ingested percentage met the success threshold
+	Passed bool `json:"passed"`
+}
+
+// LoadTestHeadroom is the peak resource usage of a component's pods
+// relative to their limits. Values which could not be measured are empty.
+type LoadTestHeadroom struct {
+	// Component is prometheus, loki or tempo
+	Component string `json:"component"`
+
+	// PeakCPU is the highest CPU usage of a pod, in cores
+	// +optional
+	PeakCPU string `json:"peakCPU,omitempty"`
+
+	// CPUHeadroom is the percentage of the CPU limit left at the peak
+	// +optional
+	CPUHeadroom string `json:"cpuHeadroom,omitempty"`
+
+	// PeakMemory is the highest working set of a pod
+	// +optional
+	PeakMemory string `json:"peakMemory,omitempty"`
+
+	// MemoryHeadroom is the percentage of the memory limit left at the peak
+	// +optional
+	MemoryHeadroom string `json:"memoryHeadroom,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=loadtest,categories={observability}
+// +kubebuilder:printcolumn:name="Platform",type=string,JSONPath=`.spec.platform`,description="Platform under test"
+// +kubebuilder:printcolumn:name="Duration",type=string,JSONPath=`.spec.duration`,description="Duration of the load"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,description="Phase of the test"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time since creation"
+
+// PlatformLoadTest generates synthetic metrics, logs and traces against a
+// platform at declared rates, and reports how much was ingested, the write
+// latency and the resource headroom of the components. Run one before a
+// production launch; the generators are removed once the test completes.
+type PlatformLoadTest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PlatformLoadTestSpec   `json:"spec,omitempty"`
+	Status PlatformLoadTestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PlatformLoadTestList contains a list of PlatformLoadTest
+type PlatformLoadTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PlatformLoadTest `json:"items"`
+}
+
+// GetDuration returns how long the load is generated
+func (t *PlatformLoadTest) GetDuration() time.Duration {
+	if t.Spec.Duration == nil || t.Spec.Duration.Duration <= 0 {
+		return DefaultLoadTestDuration
+	}
+	return t.Spec.Duration.Duration
+}
+
+// GetSuccessThreshold returns the percentage of the generated signals which
+// must be ingested
+func (t *PlatformLoadTest) GetSuccessThreshold() int32 {
+	if t.Spec.SuccessThreshold == 0 {
+		return DefaultLoadTestSuccessThreshold
+	}
+	return t.Spec.SuccessThreshold
+}
+
+// IsFinished returns true once the test passed or failed
+func (t *PlatformLoadTest) IsFinished() bool {
+	return t.Status.Phase == LoadTestPhasePassed || t.Status.Phase == LoadTestPhaseFailed
+}
+
+func init() {
+	SchemeBuilder.Register(&PlatformLoadTest{}, &PlatformLoadTestList{})
+}
@@ -0,0 +1,25 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+// PlatformMode is the operating mode of a platform
+type PlatformMode string
+
+const (
+	// PlatformModeNormal applies spec changes as usual
+	PlatformModeNormal PlatformMode = "normal"
+
+	// PlatformModeReadOnly freezes the platform: the webhook rejects spec
+	// changes other than the mode, and the operator only reports the health
+	// of the components without changing them
+	PlatformModeReadOnly PlatformMode = "readOnly"
+)
+
+// IsReadOnly returns true if the platform is frozen in read-only mode
+func (p *ObservabilityPlatform) IsReadOnly() bool {
+	return p.Spec.Mode == PlatformModeReadOnly
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+// ProbesSpec overrides the health probes of a component's container. Unset
+// fields keep the operator's defaults.
+type ProbesSpec struct {
+	// Liveness overrides the liveness probe, which restarts the container
+	// when it fails
+	// +optional
+	Liveness *ProbeSpec `json:"liveness,omitempty"`
+
+	// Readiness overrides the readiness probe, which removes the pod from
+	// its Services when it fails
+	// +optional
+	Readiness *ProbeSpec `json:"readiness,omitempty"`
+
+	// Startup adds a startup probe on the readiness endpoint. Liveness and
+	// readiness are only probed once it succeeded, so a slow start such as
+	// the WAL replay of a large Prometheus TSDB is not restarted.
+	// +optional
+	Startup *ProbeSpec `json:"startup,omitempty"`
+}
+
+// ProbeSpec overrides the settings of an HTTP probe
+type ProbeSpec struct {
+	// Path of the HTTP endpoint probed
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// InitialDelaySeconds is how long to wait after the container started
+	// before probing
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// PeriodSeconds is how often to probe
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=300
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// TimeoutSeconds is how long a probe may take
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=300
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// SuccessThreshold is the number of consecutive successes after a
+	// failure for the probe to pass. Must be 1 for liveness and startup
+	// probes.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	SuccessThreshold *int32 `json:"successThreshold,omitempty"`
+
+	// FailureThreshold is the number of consecutive failures for the probe
+	// to fail
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ProfilingSpec configures the continuous profiling backend of the platform,
+// the fourth signal next to metrics, logs and traces
+type ProfilingSpec struct {
+	// Enabled determines if the profiling backend is deployed
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Backend is the profiling database, pyroscope or parca
+	// +kubebuilder:validation:Enum=pyroscope;parca
+	// +kubebuilder:default="pyroscope"
+	// +optional
+	Backend string `json:"backend,omitempty"`
+
+	// Version of the backend to deploy. Defaults to 1.7.1 for Pyroscope and
+	// v0.22.0 for Parca.
+	// +kubebuilder:validation:Pattern=`^v?\d+\.\d+\.\d+(-[a-zA-Z0-9]+)?$`
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Resources of the backend container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Storage is the size of the volume profiles are stored on. An emptyDir
+	// is used if not set.
+	// +optional
+	Storage *resource.Quantity `json:"storage,omitempty"`
+
+	// StorageClassName of the profiles volume
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// Retention is how long profiles are kept, in the duration format of
+	// Prometheus (e.g. "15d"). Only supported by Pyroscope; profiles are kept
+	// until the volume is full if not set.
+	// +kubebuilder:validation:Pattern=`^\d+[smhdwy]$`
+	// +optional
+	Retention string `json:"retention,omitempty"`
+
+	// Datasource determines if a Grafana datasource for the profiles is
+	// provisioned
+	// +kubebuilder:default=true
+	// +optional
+	Datasource *bool `json:"datasource,omitempty"`
+}
+
+const (
+	// ProfilingBackendPyroscope is Grafana Pyroscope
+	ProfilingBackendPyroscope = "pyroscope"
+	// ProfilingBackendParca is Parca
+	ProfilingBackendParca = "parca"
+)
+
+// GetBackend returns the profiling backend, pyroscope if not set
+func (s *ProfilingSpec) GetBackend() string {
+	if s.Backend == "" {
+		return ProfilingBackendPyroscope
+	}
+	return s.Backend
+}
+
+// GetVersion returns the version of the backend
+func (s *ProfilingSpec) GetVersion() string {
+	switch {
+	case s.Version != "":
+		return s.Version
+	case s.GetBackend() == ProfilingBackendParca:
+		return "v0.22.0"
+	default:
+		return "1.7.1"
+	}
+}
+
+// DatasourceEnabled returns true if a Grafana datasource is provisioned
+func (s *ProfilingSpec) DatasourceEnabled() bool {
+	return s.Datasource == nil || *s.Datasource
+}
+
+// ProfilingEnabled returns true if the profiling backend is deployed
+func (c *Components) ProfilingEnabled() bool {
+	return c != nil && c.Profiling != nil && c.Profiling.Enabled
+}
+
+// ProfilingAcceptsPushes returns true if the profiling backend accepts the
+// profiles Alloy pushes. Parca pulls profiles itself.
+func (c *Components) ProfilingAcceptsPushes() bool {
+	return c.ProfilingEnabled() && c.Profiling.GetBackend() == ProfilingBackendPyroscope
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import "sort"
+
+// QueryACLSpec configures label-based query access control per team. Each
+// team gets a Prometheus datasource in its Grafana organization that queries
+// through prom-label-proxy, which restricts every query to the team's label
+// values. Teams with tenants also get a Loki datasource querying only those
+// tenants. The organizations must exist in Grafana. Team organizations get
+// no trace datasources.
+type QueryACLSpec struct {
+	// Enabled determines if query access control is enforced
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Label is the label enforced on every query, e.g. namespace or tenant
+	// +kubebuilder:default="namespace"
+	// +optional
+	Label string `json:"label,omitempty"`
+
+	// Teams and the label values they may query
+	// +optional
+	Teams []QueryACLTeam `json:"teams,omitempty"`
+
+	// Image of prom-label-proxy
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Replicas of the proxy
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=2
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Upstream is the Prometheus URL the proxy forwards to. Defaults to the
+	// Prometheus service the operator creates; set it when Prometheus is
+	// deployed with Helm.
+	// +optional
+	Upstream string `json:"upstream,omitempty"`
+}
+
+// QueryACLTeam is a Grafana organization restricted to a set of label values
+type QueryACLTeam struct {
+	// Name of the team, used in the datasource name
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// OrgID is the Grafana organization of the team. The main organization 1
+	// holds the unrestricted datasources, so it can't be a team's.
+	// +kubebuilder:validation:Minimum=2
+	OrgID int64 `json:"orgId"`
+
+	// Values of the enforced label the team may query
+	// +kubebuilder:validation:MinItems=1
+	Values []string `json:"values"`
+
+	// Tenants are the Loki tenants whose logs the team may query. Loki runs
+	// with multi-tenancy while a team has tenants. Without tenants, the team
+	// gets no Loki datasource.
+	// +optional
+	Tenants []string `json:"tenants,omitempty"`
+}
+
+// IsEnabled returns true if query access control is enabled
+func (q *QueryACLSpec) IsEnabled() bool {
+	return q != nil && q.Enabled
+}
+
+// HasTenants returns true if query access control is enabled and a team
+// queries Loki tenants
+func (q *QueryACLSpec) HasTenants() bool {
+	if !q.IsEnabled() {
+		return false
+	}
+	for _, team := range q.Teams {
+		if len(team.Tenants) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// TenantValues returns the label values of the teams querying a tenant,
+// sorted. The metrics of a tenant are those with these values.
+func (q *QueryACLSpec) TenantValues(tenant string) []string {
+	if !q.IsEnabled() {
+		return nil
+	}
+	seen := map[string]bool{}
+	for _, team := range q.Teams {
+		for _, t := range team.Tenants {
+			if t != tenant {
+				continue
+			}
+			for _, value := range team.Values {
+				seen[value] = true
+			}
+		}
+	}
+	values := make([]string, 0, len(seen))
+	for value := range seen {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// GetLabel returns the label enforced on every query
+func (q *QueryACLSpec) GetLabel() string {
+	if q.Label == "" {
+		return "namespace"
+	}
+	return q.Label
+}
+
+// GetReplicas returns the number of proxy replicas
+func (q *QueryACLSpec) GetReplicas() int32 {
+	if q.Replicas <= 0 {
+		return 2
+	}
+	return q.Replicas
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+// SizeProfileLabel holds the size profile a platform was generated with,
+// such as small, medium or large. Defaults scale with the profile.
+const SizeProfileLabel = "observability.io/size-profile"
+
+// QueryLimitsSpec protects Prometheus from long and expensive queries.
+// Unset limits default to the limits of the platform's size profile.
+type QueryLimitsSpec struct {
+	// Timeout is the maximum time a query may take before it is aborted
+	// (--query.timeout)
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h))+$`
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+
+	// MaxSamples is the maximum number of samples a single query can load
+	// into memory (--query.max-samples)
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSamples int64 `json:"maxSamples,omitempty"`
+
+	// MaxConcurrency is the maximum number of queries executed concurrently
+	// (--query.max-concurrency)
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrency int32 `json:"maxConcurrency,omitempty"`
+
+	// LookbackDelta is how far back samples are looked for in instant
+	// queries and range query steps (--query.lookback-delta)
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h))+$`
+	// +optional
+	LookbackDelta string `json:"lookbackDelta,omitempty"`
+}
+
+// queryLimitsByProfile are the default query limits of the size profiles.
+// Platforms without a known profile get the medium limits, which match the
+// Prometheus defaults.
+var queryLimitsByProfile = map[string]QueryLimitsSpec{
+	"small":  {Timeout: "1m", MaxSamples: 20000000, MaxConcurrency: 10, LookbackDelta: "5m"},
+	"medium": {Timeout: "2m", MaxSamples: 50000000, MaxConcurrency: 20, LookbackDelta: "5m"},
+	"large":  {Timeout: "2m", MaxSamples: 100000000, MaxConcurrency: 40, LookbackDelta: "5m"},
+}
+
+// DefaultQueryLimits returns the default query limits of a size profile
+func DefaultQueryLimits(profile string) QueryLimitsSpec {
+	if limits, ok := queryLimitsByProfile[profile]; ok {
+		return limits
+	}
+	return queryLimitsByProfile["medium"]
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import "time"
+
+// QueryLogSpec configures Prometheus query logging and slow-query reporting.
+// The operator periodically summarizes the query log into a top-N report
+// stored in the prometheus-<platform>-query-report ConfigMap and exposed as
+// operator metrics.
+type QueryLogSpec struct {
+	// Enabled turns on the Prometheus query log
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// SlowQueryThreshold is the execution time above which a query counts as slow
+	// +kubebuilder:validation:Pattern=`^[0-9]+(ms|s|m)$`
+	// +kubebuilder:default="1s"
+	// +optional
+	SlowQueryThreshold string `json:"slowQueryThreshold,omitempty"`
+
+	// TopN is the number of most expensive queries kept in the report
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=10
+	// +optional
+	TopN int32 `json:"topN,omitempty"`
+
+	// AnalysisInterval is how often the query log is analyzed; each report
+	// covers the queries since the previous one
+	// +kubebuilder:validation:Pattern=`^[0-9]+(s|m|h)$`
+	// +kubebuilder:default="15m"
+	// +optional
+	AnalysisInterval string `json:"analysisInterval,omitempty"`
+}
+
+// IsEnabled returns true if the query log is enabled
+func (q *QueryLogSpec) IsEnabled() bool {
+	return q != nil && q.Enabled
+}
+
+// GetSlowQueryThreshold returns the slow query threshold
+func (q *QueryLogSpec) GetSlowQueryThreshold() time.Duration {
+	if d, err := time.ParseDuration(q.SlowQueryThreshold); err == nil && d > 0 {
+		return d
+	}
+	return time.Second
+}
+
+// GetTopN returns the number of queries kept in the report
+func (q *QueryLogSpec) GetTopN() int {
+	if q.TopN <= 0 {
+		return 10
+	}
+	return int(q.TopN)
+}
+
+// GetAnalysisInterval returns how often the query log is analyzed
+func (q *QueryLogSpec) GetAnalysisInterval() time.Duration {
+	if d, err := time.ParseDuration(q.AnalysisInterval); err == nil && d > 0 {
+		return d
+	}
+	return 15 * time.Minute
+}
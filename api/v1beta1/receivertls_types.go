@@ -0,0 +1,69 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+// ReceiverTLSSpec serves the trace receivers of Tempo over TLS. The serving
+// certificate is either requested from a cert-manager issuer, which renews
+// it before it expires, or read from an existing Secret.
+type ReceiverTLSSpec struct {
+	// Enabled turns on TLS for the OTLP, Jaeger gRPC, Jaeger Thrift HTTP and
+	// Zipkin receivers. The Jaeger Thrift UDP receivers stay plain text.
+	Enabled bool `json:"enabled"`
+
+	// IssuerRef is the cert-manager issuer of the serving certificate
+	// +optional
+	IssuerRef *CertManagerIssuerRef `json:"issuerRef,omitempty"`
+
+	// SecretName is an existing kubernetes.io/tls Secret holding the serving
+	// certificate, used instead of an issuer
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// DNSNames are added to the certificate next to the names of the Tempo
+	// Service, e.g. the host of an ingress or a load balancer
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// Duration of the requested certificate
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(s|m|h))+$`
+	// +kubebuilder:default="2160h"
+	// +optional
+	Duration string `json:"duration,omitempty"`
+
+	// RenewBefore is how long before expiry the certificate is renewed
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(s|m|h))+$`
+	// +kubebuilder:default="360h"
+	// +optional
+	RenewBefore string `json:"renewBefore,omitempty"`
+
+	// ClientCASecretName is a Secret whose ca.crt verifies client
+	// certificates; clients without a valid certificate are rejected
+	// +optional
+	ClientCASecretName string `json:"clientCASecretName,omitempty"`
+}
+
+// CertManagerIssuerRef references a cert-manager Issuer or ClusterIssuer
+type CertManagerIssuerRef struct {
+	// Name of the issuer
+	Name string `json:"name"`
+
+	// Kind of the issuer
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +kubebuilder:default=Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Group of the issuer, for external issuers
+	// +kubebuilder:default="cert-manager.io"
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
+// IsEnabled returns true if the receivers are served over TLS
+func (r *ReceiverTLSSpec) IsEnabled() bool {
+	return r != nil && r.Enabled
+}
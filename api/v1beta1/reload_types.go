@@ -0,0 +1,29 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReloadStatus is the state of the in-place configuration reloads of a component
+type ReloadStatus struct {
+	// Checksum identifies the reloaded configuration
+	Checksum string `json:"checksum"`
+
+	// ObservedTime is when the configuration change was observed
+	// +optional
+	ObservedTime *metav1.Time `json:"observedTime,omitempty"`
+
+	// LastReloadTime is when all pods last reloaded their configuration
+	// +optional
+	LastReloadTime *metav1.Time `json:"lastReloadTime,omitempty"`
+
+	// Message holds the error of the last failed reload
+	// +optional
+	Message string `json:"message,omitempty"`
+}
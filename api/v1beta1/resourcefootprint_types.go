@@ -0,0 +1,58 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ResourceFootprint is the compute and storage a platform's workloads
+// request, summed over their replicas
+type ResourceFootprint struct {
+	ResourceTotals `json:",inline"`
+
+	// Components breaks the totals down by component
+	// +optional
+	Components []ComponentFootprint `json:"components,omitempty"`
+}
+
+// ComponentFootprint is the footprint of a component
+type ComponentFootprint struct {
+	// Name of the component
+	Name string `json:"name"`
+
+	ResourceTotals `json:",inline"`
+}
+
+// ResourceTotals sums the requests and limits of workloads. A workload
+// container without a limit does not count toward the limits, which are then
+// a lower bound.
+type ResourceTotals struct {
+	// CPURequests is the CPU requested
+	// +optional
+	CPURequests *resource.Quantity `json:"cpuRequests,omitempty"`
+
+	// CPULimits is the CPU limit
+	// +optional
+	CPULimits *resource.Quantity `json:"cpuLimits,omitempty"`
+
+	// MemoryRequests is the memory requested
+	// +optional
+	MemoryRequests *resource.Quantity `json:"memoryRequests,omitempty"`
+
+	// MemoryLimits is the memory limit
+	// +optional
+	MemoryLimits *resource.Quantity `json:"memoryLimits,omitempty"`
+
+	// Storage is the storage requested by the PersistentVolumeClaims
+	// +optional
+	Storage *resource.Quantity `json:"storage,omitempty"`
+
+	// Pods is the number of pods the workloads run
+	// +optional
+	Pods int32 `json:"pods,omitempty"`
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TLS version identifiers accepted by FIPSSpec.MinTLSVersion
+const (
+	// TLSVersion12 requires TLS 1.2 or newer
+	TLSVersion12 = "1.2"
+	// TLSVersion13 requires TLS 1.3
+	TLSVersion13 = "1.3"
+)
+
+// SecuritySettings defines platform-wide security configuration
+type SecuritySettings struct {
+	// FIPS enables FIPS/strict-TLS mode across all components
+	// +optional
+	FIPS *FIPSSpec `json:"fips,omitempty"`
+
+	// CredentialRotation regenerates the credentials the operator generated
+	// for the platform on a schedule
+	// +optional
+	CredentialRotation *CredentialRotationSpec `json:"credentialRotation,omitempty"`
+}
+
+// FIPSSpec defines FIPS/strict-TLS configuration
+type FIPSSpec struct {
+	// Enabled determines if strict TLS settings are enforced
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// MinTLSVersion is the minimum TLS version accepted by components
+	// +kubebuilder:validation:Enum="1.2";"1.3"
+	// +kubebuilder:default="1.2"
+	// +optional
+	MinTLSVersion string `json:"minTLSVersion,omitempty"`
+
+	// CipherSuites restricts the TLS 1.2 cipher suites offered by components.
+	// Every entry must be a FIPS-approved suite. When empty, all approved suites are used.
+	// +optional
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+}
+
+// IsFIPSEnabled returns true if FIPS/strict-TLS mode is enabled for the platform
+func (p *ObservabilityPlatform) IsFIPSEnabled() bool {
+	return p.Spec.Security != nil && p.Spec.Security.FIPS != nil && p.Spec.Security.FIPS.Enabled
+}
+
+// CredentialRotationSpec defines the scheduled rotation of the Grafana admin
+// password, the shared admin credentials and the Secrets labeled
+// observability.io/rotate=true. Credentials are also rotated on demand by
+// setting the observability.io/rotate-credentials annotation of the platform
+// to a new value, with or without a schedule.
+type CredentialRotationSpec struct {
+	// Enabled turns on scheduled rotation
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Interval between two rotations of a credential
+	// +kubebuilder:default="720h"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// CredentialRotationStatus reports the rotations of the platform's
+// credentials
+type CredentialRotationStatus struct {
+	// LastRequest is the last value of the observability.io/rotate-credentials
+	// annotation handled
+	// +optional
+	LastRequest string `json:"lastRequest,omitempty"`
+
+	// Credentials lists when each credential was last rotated
+	// +optional
+	Credentials []RotatedCredential `json:"credentials,omitempty"`
+}
+
+// RotatedCredential is a rotated credential of the platform
+type RotatedCredential struct {
+	// Name of the credential, e.g. grafana-admin
+	Name string `json:"name"`
+
+	// Secret holding the credential
+	Secret string `json:"secret"`
+
+	// LastRotated is when the credential was last rotated
+	LastRotated metav1.Time `json:"lastRotated"`
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+// SelfMonitoringSpec makes the platform monitor the operator managing it
+type SelfMonitoringSpec struct {
+	// Enabled adds a scrape job for the operator's metrics Service to the
+	// platform's Prometheus
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Dashboard determines if the operator dashboard is provisioned
+	// +kubebuilder:default=true
+	// +optional
+	Dashboard *bool `json:"dashboard,omitempty"`
+
+	// ServiceMonitor also creates a ServiceMonitor for the operator, for
+	// clusters whose Prometheus Operator should scrape it
+	// +optional
+	ServiceMonitor bool `json:"serviceMonitor,omitempty"`
+}
+
+// IsEnabled returns true if the platform monitors the operator
+func (s *SelfMonitoringSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// DashboardEnabled returns true if the operator dashboard is provisioned
+func (s *SelfMonitoringSpec) DashboardEnabled() bool {
+	return s.IsEnabled() && (s.Dashboard == nil || *s.Dashboard)
+}
+
+// ServiceMonitorEnabled returns true if a ServiceMonitor is created
+func (s *SelfMonitoringSpec) ServiceMonitorEnabled() bool {
+	return s.IsEnabled() && s.ServiceMonitor
+}
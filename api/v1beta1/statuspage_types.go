@@ -0,0 +1,63 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+// StatusPageSpec defines the read-only public status page
+type StatusPageSpec struct {
+	// Enabled determines if the status page is deployed
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Title shown at the top of the status page
+	// +kubebuilder:default="Observability Platform Status"
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// HistoryDays is the number of days of uptime history kept and displayed
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=90
+	// +kubebuilder:default=30
+	// +optional
+	HistoryDays int32 `json:"historyDays,omitempty"`
+
+	// Image serving the rendered page
+	// +kubebuilder:default="nginx:1.25-alpine"
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Ingress exposes the status page through the platform's ingress controller
+	// +optional
+	Ingress *StatusPageIngress `json:"ingress,omitempty"`
+}
+
+// StatusPageIngress defines how the status page is exposed
+type StatusPageIngress struct {
+	// Host name of the status page
+	Host string `json:"host"`
+
+	// Path under which the page is served
+	// +kubebuilder:default="/"
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// ClassName of the ingress controller
+	// +optional
+	ClassName *string `json:"className,omitempty"`
+
+	// TLSSecretName references the certificate for the host
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// Annotations added to the Ingress
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// IsStatusPageEnabled returns true if the public status page is enabled
+func (p *ObservabilityPlatform) IsStatusPageEnabled() bool {
+	return p.Spec.StatusPage != nil && p.Spec.StatusPage.Enabled
+}
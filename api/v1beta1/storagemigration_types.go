@@ -0,0 +1,70 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StorageMigrationStatus is the progress of the migration of a component's
+// persistent volumes to another StorageClass
+type StorageMigrationStatus struct {
+	// From is the StorageClass the data is migrated from
+	// +optional
+	From string `json:"from,omitempty"`
+
+	// To is the StorageClass the data is migrated to
+	To string `json:"to"`
+
+	// Phase of the migration
+	// +kubebuilder:validation:Enum=ScalingDown;Copying;Swapping;Completed;Failed
+	Phase string `json:"phase"`
+
+	// Progress summarizes the migrated volumes, e.g. "2/3 volumes copied"
+	// +optional
+	Progress string `json:"progress,omitempty"`
+
+	// Volumes are the migrated PersistentVolumeClaims
+	// +optional
+	Volumes []VolumeMigrationStatus `json:"volumes,omitempty"`
+
+	// Message describes the current phase or the failure
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is when the migration started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the migration completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// VolumeMigrationStatus is the progress of the migration of a
+// PersistentVolumeClaim
+type VolumeMigrationStatus struct {
+	// Claim is the name of the migrated PersistentVolumeClaim
+	Claim string `json:"claim"`
+
+	// Phase of the volume migration
+	// +kubebuilder:validation:Enum=Pending;Copying;Copied;Swapped;Failed
+	Phase string `json:"phase"`
+
+	// Job copying the data of the claim
+	// +optional
+	Job string `json:"job,omitempty"`
+
+	// Volume is the PersistentVolume the data was copied into
+	// +optional
+	Volume string `json:"volume,omitempty"`
+
+	// RetainedVolume is the PersistentVolume with the data in the previous
+	// StorageClass. It is kept as a fallback and must be deleted manually.
+	// +optional
+	RetainedVolume string `json:"retainedVolume,omitempty"`
+}
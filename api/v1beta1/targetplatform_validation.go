@@ -0,0 +1,102 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reasons a component configuration cannot apply to its target platform
+const (
+	ReasonTargetNotFound    = "TargetNotFound"
+	ReasonComponentDisabled = "ComponentDisabled"
+	ReasonTargetReady       = "TargetReady"
+
+	// ConditionTargetPlatform is true when the target platform of a
+	// component configuration exists and enables the component
+	ConditionTargetPlatform = "TargetPlatform"
+)
+
+// targetPlatformReader reads the target platforms of the component
+// configurations at admission. It is set up with their webhooks; the check
+// is skipped without it.
+var targetPlatformReader client.Reader
+
+// TargetPlatformError tells why a component configuration cannot apply to
+// its target platform
+type TargetPlatformError struct {
+	// Reason is ReasonTargetNotFound or ReasonComponentDisabled
+	Reason    string
+	Platform  string
+	Component string
+}
+
+func (e *TargetPlatformError) Error() string {
+	if e.Reason == ReasonTargetNotFound {
+		return fmt.Sprintf("target platform %s not found", e.Platform)
+	}
+	return fmt.Sprintf("target platform %s does not enable %s", e.Platform, e.Component)
+}
+
+// ComponentEnabled returns true if the platform enables the component
+func (p *ObservabilityPlatform) ComponentEnabled(component string) bool {
+	if p.Spec.Components == nil {
+		return false
+	}
+	for _, enabled := range p.GetEnabledComponents() {
+		if enabled == component {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckTargetPlatform returns a *TargetPlatformError if the platform does not
+// exist in the namespace or does not enable the component, and other errors
+// if the platform cannot be read
+func CheckTargetPlatform(ctx context.Context, reader client.Reader, namespace, name, component string) error {
+	platform := &ObservabilityPlatform{}
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, platform); err != nil {
+		if errors.IsNotFound(err) {
+			return &TargetPlatformError{Reason: ReasonTargetNotFound, Platform: name, Component: component}
+		}
+		return fmt.Errorf("failed to get target platform %s: %w", name, err)
+	}
+	if !platform.ComponentEnabled(component) {
+		return &TargetPlatformError{Reason: ReasonComponentDisabled, Platform: name, Component: component}
+	}
+	return nil
+}
+
+// validateTargetPlatform validates at admission that the target platform of
+// a component configuration exists and enables the component
+func validateTargetPlatform(ctx context.Context, reader client.Reader, fldPath *field.Path, namespace, name, component string) field.ErrorList {
+	var allErrs field.ErrorList
+	if name == "" {
+		return append(allErrs, field.Required(fldPath.Child("name"), "target platform name is required"))
+	}
+	if reader == nil {
+		return allErrs
+	}
+
+	err := CheckTargetPlatform(ctx, reader, namespace, name, component)
+	if targetErr, ok := err.(*TargetPlatformError); ok {
+		if targetErr.Reason == ReasonTargetNotFound {
+			return append(allErrs, field.NotFound(fldPath.Child("name"), name))
+		}
+		return append(allErrs, field.Invalid(fldPath.Child("name"), name, targetErr.Error()))
+	}
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+	return allErrs
+}
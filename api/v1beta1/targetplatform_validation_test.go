@@ -0,0 +1,103 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// platformReader serves ObservabilityPlatforms by name
+type platformReader struct {
+	platforms map[string]*ObservabilityPlatform
+	err       error
+}
+
+func (r *platformReader) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	if r.err != nil {
+		return r.err
+	}
+	platform, ok := r.platforms[key.Name]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{Group: "observability.io", Resource: "observabilityplatforms"}, key.Name)
+	}
+	*obj.(*ObservabilityPlatform) = *platform
+	return nil
+}
+
+func (r *platformReader) List(context.Context, client.ObjectList, ...client.ListOption) error {
+	return nil
+}
+
+func TestCheckTargetPlatform(t *testing.T) {
+	ctx := context.Background()
+	reader := &platformReader{platforms: map[string]*ObservabilityPlatform{
+		"prod": {Spec: ObservabilityPlatformSpec{Components: &Components{
+			Tempo: &TempoSpec{Enabled: true},
+		}}},
+		"empty": {},
+	}}
+
+	assert.NoError(t, CheckTargetPlatform(ctx, reader, "monitoring", "prod", "tempo"))
+
+	err := CheckTargetPlatform(ctx, reader, "monitoring", "prod", "loki")
+	var targetErr *TargetPlatformError
+	require.True(t, errors.As(err, &targetErr))
+	assert.Equal(t, ReasonComponentDisabled, targetErr.Reason)
+	assert.Equal(t, "target platform prod does not enable loki", err.Error())
+
+	err = CheckTargetPlatform(ctx, reader, "monitoring", "empty", "tempo")
+	require.True(t, errors.As(err, &targetErr))
+	assert.Equal(t, ReasonComponentDisabled, targetErr.Reason)
+
+	err = CheckTargetPlatform(ctx, reader, "monitoring", "staging", "tempo")
+	require.True(t, errors.As(err, &targetErr))
+	assert.Equal(t, ReasonTargetNotFound, targetErr.Reason)
+
+	err = CheckTargetPlatform(ctx, &platformReader{err: errors.New("connection refused")}, "monitoring", "prod", "tempo")
+	require.Error(t, err)
+	assert.False(t, errors.As(err, &targetErr))
+}
+
+func TestValidateTargetPlatform(t *testing.T) {
+	ctx := context.Background()
+	fldPath := field.NewPath("spec").Child("targetPlatform")
+	reader := &platformReader{platforms: map[string]*ObservabilityPlatform{
+		"prod": {Spec: ObservabilityPlatformSpec{Components: &Components{
+			Loki: &LokiSpec{Enabled: true},
+		}}},
+	}}
+
+	assert.Empty(t, validateTargetPlatform(ctx, reader, fldPath, "monitoring", "prod", "loki"))
+	// Without a reader only the name is checked
+	assert.Empty(t, validateTargetPlatform(ctx, nil, fldPath, "monitoring", "staging", "loki"))
+
+	errs := validateTargetPlatform(ctx, nil, fldPath, "monitoring", "", "loki")
+	require.Len(t, errs, 1)
+	assert.Equal(t, field.ErrorTypeRequired, errs[0].Type)
+
+	errs = validateTargetPlatform(ctx, reader, fldPath, "monitoring", "staging", "loki")
+	require.Len(t, errs, 1)
+	assert.Equal(t, field.ErrorTypeNotFound, errs[0].Type)
+	assert.Equal(t, "spec.targetPlatform.name", errs[0].Field)
+
+	errs = validateTargetPlatform(ctx, reader, fldPath, "monitoring", "prod", "tempo")
+	require.Len(t, errs, 1)
+	assert.Equal(t, field.ErrorTypeInvalid, errs[0].Type)
+
+	errs = validateTargetPlatform(ctx, &platformReader{err: errors.New("connection refused")}, fldPath, "monitoring", "prod", "loki")
+	require.Len(t, errs, 1)
+	assert.Equal(t, field.ErrorTypeInternal, errs[0].Type)
+}
@@ -1867,7 +1867,7 @@ type TempoConfigStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
-	// +kubebuilder:validation:Enum=Pending;Applied;Failed;Invalid
+	// +kubebuilder:validation:Enum=Pending;Applying;Applied;Failed;Invalid
 	// Phase represents the current phase of the configuration
 	Phase string `json:"phase,omitempty"`
 
@@ -1890,12 +1890,86 @@ type TempoConfigStatus struct {
 	ValidationErrors []string `json:"validationErrors,omitempty"`
 
 	// SyncStatus shows the synchronization status with target platform
-	SyncStatus *SyncStatus `json:"syncStatus,omitempty"`
+	SyncStatus *TempoConfigSyncStatus `json:"syncStatus,omitempty"`
+
+	// Conflicts lists the sections of the overrides which other TempoConfigs
+	// targeting the same platform set differently
+	// +optional
+	Conflicts []TempoConfigConflict `json:"conflicts,omitempty"`
+
+	// AppliedDefaults lists the defaults applied by the webhook which change
+	// the behavior of Tempo, like retentions and limits
+	// +optional
+	AppliedDefaults []AppliedDefault `json:"appliedDefaults,omitempty"`
 
 	// ComponentStatus shows individual component status
 	ComponentStatus map[string]ComponentHealthStatus `json:"componentStatus,omitempty"`
 }
 
+// TempoConfigConflict is a section of the overrides set differently by
+// another TempoConfig targeting the same platform. The section is applied
+// from the oldest of the two.
+type TempoConfigConflict struct {
+	// Section is "*" for the limits of every tenant, or the name of a tenant
+	Section string `json:"section"`
+
+	// TempoConfig is the other TempoConfig setting the section
+	TempoConfig string `json:"tempoConfig"`
+
+	// Applied is true if the section is applied from this TempoConfig, and
+	// false if it is applied from the other one
+	Applied bool `json:"applied"`
+}
+
+// Sync states of a TempoConfig
+const (
+	// TempoConfigSyncPending waits for the target platform to accept the configuration
+	TempoConfigSyncPending = "Pending"
+	// TempoConfigSyncApplying has written the configuration and waits for Tempo to load it
+	TempoConfigSyncApplying = "Applying"
+	// TempoConfigSyncApplied is loaded by Tempo
+	TempoConfigSyncApplied = "Applied"
+	// TempoConfigSyncFailed could not be applied; see the reason and last error
+	TempoConfigSyncFailed = "Failed"
+)
+
+// TempoConfigSyncStatus tracks the application of a TempoConfig to the Tempo
+// of its target platform: Pending, then Applying and Applied, or Failed with
+// the reason and the time of the next retry
+type TempoConfigSyncStatus struct {
+	// +kubebuilder:validation:Enum=Pending;Applying;Applied;Failed
+	// State of the synchronization
+	State string `json:"state"`
+
+	// Reason is a CamelCase reason for the state
+	Reason string `json:"reason,omitempty"`
+
+	// Message explains the state
+	Message string `json:"message,omitempty"`
+
+	// DesiredHash is the hash of the configuration rendered from the spec
+	DesiredHash string `json:"desiredHash,omitempty"`
+
+	// Retries counts the failed attempts at applying the desired
+	// configuration; reset when the spec changes
+	Retries int32 `json:"retries,omitempty"`
+
+	// LastError is the error of the last failed attempt
+	LastError string `json:"lastError,omitempty"`
+
+	// LastAttemptTime is when the configuration was last written
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+
+	// NextRetryTime is when a failed attempt is retried
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+
+	// LastSyncTime is when the configuration was last found applied
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastTransitionTime is when the state last changed
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
 // ComponentHealthStatus defines component health status
 type ComponentHealthStatus struct {
 	// +kubebuilder:validation:Enum=Healthy;Unhealthy;Unknown
@@ -1915,6 +1989,9 @@ type ComponentHealthStatus struct {
 // +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.targetPlatform.name`,description="Target ObservabilityPlatform"
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,description="Current phase"
 // +kubebuilder:printcolumn:name="Sync",type=string,JSONPath=`.status.syncStatus.state`,description="Sync status"
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.syncStatus.reason`,description="Sync reason",priority=1
+// +kubebuilder:printcolumn:name="Retries",type=integer,JSONPath=`.status.syncStatus.retries`,description="Failed attempts",priority=1
+// +kubebuilder:printcolumn:name="Conflicts",type=string,JSONPath=`.status.conditions[?(@.type=="Conflict")].status`,description="Sections set differently by other TempoConfigs",priority=1
 // +kubebuilder:printcolumn:name="Backend",type=string,JSONPath=`.spec.storage.trace.backend`,description="Storage backend"
 // +kubebuilder:printcolumn:name="Last Applied",type=date,JSONPath=`.status.lastAppliedTime`,description="Last applied time"
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time since creation"
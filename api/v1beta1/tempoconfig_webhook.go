@@ -7,6 +7,7 @@ Licensed under the MIT License.
 package v1beta1
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
@@ -24,6 +25,7 @@ import (
 var tempoconfiglog = logf.Log.WithName("tempoconfig-resource")
 
 func (r *TempoConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	targetPlatformReader = mgr.GetClient()
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
@@ -226,6 +228,7 @@ func (r *TempoConfig) Default() {
 	}
 	if r.Spec.Compactor.BlockRetention == "" {
 		r.Spec.Compactor.BlockRetention = "336h" // 14 days
+		recordDefault(r, "spec.compactor.blockRetention", r.Spec.Compactor.BlockRetention)
 	}
 	if r.Spec.Compactor.CompactedBlockRetention == "" {
 		r.Spec.Compactor.CompactedBlockRetention = "1h"
@@ -440,6 +443,9 @@ func (r *TempoConfig) Default() {
 	if r.Spec.Metrics != nil && r.Spec.Metrics.Enabled {
 		r.defaultMetricsGenerator()
 	}
+
+	// Forget the defaults the user has since replaced
+	pruneAppliedDefaults(r, r.Spec)
 }
 
 // defaultStorageBackend sets defaults for the configured storage backend
@@ -621,6 +627,27 @@ var _ webhook.Validator = &TempoConfig{}
 func (r *TempoConfig) ValidateCreate() (admission.Warnings, error) {
 	tempoconfiglog.Info("validate create", "name", r.Name)
 
+	allErrs := r.validateTargetPlatform()
+	allErrs = append(allErrs, r.validateConfig()...)
+
+	// Warn about the defaults which change the behavior of Tempo
+	warnings := appliedDefaultWarnings(r, nil)
+
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, allErrs.ToAggregate()
+}
+
+// validateTargetPlatform validates the target platform exists and enables Tempo
+func (r *TempoConfig) validateTargetPlatform() field.ErrorList {
+	return validateTargetPlatform(context.Background(), targetPlatformReader,
+		field.NewPath("spec").Child("targetPlatform"), r.Namespace, r.Spec.TargetPlatform.Name, "tempo")
+}
+
+// validateConfig validates the Tempo configuration
+func (r *TempoConfig) validateConfig() field.ErrorList {
 	var allErrs field.ErrorList
 
 	// Validate storage configuration
@@ -684,11 +711,7 @@ func (r *TempoConfig) ValidateCreate() (admission.Warnings, error) {
 		}
 	}
 
-	if len(allErrs) == 0 {
-		return nil, nil
-	}
-
-	return nil, allErrs.ToAggregate()
+	return allErrs
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -719,12 +742,20 @@ func (r *TempoConfig) ValidateUpdate(old runtime.Object) (admission.Warnings, er
 		}
 	}
 
+	// The target platform is checked again only when it changes, so a config
+	// whose platform is gone can still be paused or finalized
+	if oldConfig.Spec.TargetPlatform.Name != r.Spec.TargetPlatform.Name {
+		allErrs = append(allErrs, r.validateTargetPlatform()...)
+	}
+
 	// Run standard validation
-	warnings, err := r.ValidateCreate()
-	if err != nil {
-		allErrs = append(allErrs, field.Invalid(field.NewPath("spec"), r.Spec, err.Error()))
+	if errs := r.validateConfig(); len(errs) > 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec"), r.Spec, errs.ToAggregate().Error()))
 	}
 
+	// Warn about the defaults applied by this update
+	warnings := appliedDefaultWarnings(r, oldConfig)
+
 	if len(allErrs) == 0 {
 		return warnings, nil
 	}
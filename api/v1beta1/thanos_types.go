@@ -0,0 +1,149 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ThanosSpec configures the Thanos components of a Prometheus. A sidecar in
+// the Prometheus pods uploads the TSDB blocks to object storage and serves
+// the Store API to queriers. Blocks in the object storage bucket are
+// compacted, downsampled and expired by a singleton compactor.
+type ThanosSpec struct {
+	// Enabled determines if the Thanos components are deployed
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Version of Thanos to deploy
+	// +kubebuilder:validation:Pattern=`^v?\d+\.\d+\.\d+(-[a-zA-Z0-9]+)?$`
+	// +kubebuilder:default="v0.34.1"
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Image is the Thanos image repository, e.g. a mirror of
+	// quay.io/thanos/thanos. The tag is the version.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// ObjectStorageConfig references the Secret key holding the Thanos
+	// object storage configuration (objstore.yml)
+	ObjectStorageConfig corev1.SecretKeySelector `json:"objectStorageConfig"`
+
+	// Sidecar configures the Thanos sidecar of the Prometheus pods
+	// +optional
+	Sidecar *ThanosSidecarSpec `json:"sidecar,omitempty"`
+
+	// Compactor configures the Thanos compactor
+	// +optional
+	Compactor *ThanosCompactorSpec `json:"compactor,omitempty"`
+}
+
+// ThanosSidecarSpec configures the sidecar uploading the Prometheus blocks
+type ThanosSidecarSpec struct {
+	// UploadInterval is how often Prometheus cuts a block, which the sidecar
+	// then uploads. It sets both the minimum and maximum block duration of
+	// Prometheus, so local compaction is disabled as Thanos requires.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(m|h)$`
+	// +kubebuilder:default="2h"
+	// +optional
+	UploadInterval string `json:"uploadInterval,omitempty"`
+
+	// Resources defines the compute resources of the sidecar
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// ThanosCompactorSpec configures the compaction, downsampling and retention
+// of the blocks in object storage
+type ThanosCompactorSpec struct {
+	// Downsampling determines if 5m and 1h resolution blocks are created
+	// +kubebuilder:default=true
+	// +optional
+	Downsampling *bool `json:"downsampling,omitempty"`
+
+	// Retention is how long blocks of each resolution are kept
+	// +optional
+	Retention *DownsamplingRetention `json:"retention,omitempty"`
+
+	// Storage is the size of the compactor's working volume. The compactor
+	// uses an emptyDir if not set.
+	// +optional
+	Storage *resource.Quantity `json:"storage,omitempty"`
+
+	// StorageClassName of the compactor's working volume
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+}
+
+// DownsamplingRetention is the retention of each block resolution. A
+// retention of 0d keeps the blocks forever.
+type DownsamplingRetention struct {
+	// Raw is the retention of raw blocks
+	// +kubebuilder:validation:Pattern=`^[0-9]+(h|d|w|y)$`
+	// +kubebuilder:default="30d"
+	// +optional
+	Raw string `json:"raw,omitempty"`
+
+	// FiveMinutes is the retention of 5m resolution blocks
+	// +kubebuilder:validation:Pattern=`^[0-9]+(h|d|w|y)$`
+	// +kubebuilder:default="90d"
+	// +optional
+	FiveMinutes string `json:"fiveMinutes,omitempty"`
+
+	// OneHour is the retention of 1h resolution blocks
+	// +kubebuilder:validation:Pattern=`^[0-9]+(h|d|w|y)$`
+	// +kubebuilder:default="1y"
+	// +optional
+	OneHour string `json:"oneHour,omitempty"`
+}
+
+// IsEnabled returns true if the Thanos components are enabled
+func (t *ThanosSpec) IsEnabled() bool {
+	return t != nil && t.Enabled
+}
+
+// GetVersion returns the Thanos version
+func (t *ThanosSpec) GetVersion() string {
+	if t.Version == "" {
+		return "v0.34.1"
+	}
+	return t.Version
+}
+
+// GetUploadInterval returns how often Prometheus cuts a block, 2h if not set
+func (s *ThanosSidecarSpec) GetUploadInterval() string {
+	if s == nil || s.UploadInterval == "" {
+		return "2h"
+	}
+	return s.UploadInterval
+}
+
+// IsDownsamplingEnabled returns true unless downsampling is disabled
+func (c *ThanosCompactorSpec) IsDownsamplingEnabled() bool {
+	return c == nil || c.Downsampling == nil || *c.Downsampling
+}
+
+// GetRetention returns the retention of each resolution, with defaults for
+// the resolutions not set
+func (c *ThanosCompactorSpec) GetRetention() DownsamplingRetention {
+	retention := DownsamplingRetention{Raw: "30d", FiveMinutes: "90d", OneHour: "1y"}
+	if c == nil || c.Retention == nil {
+		return retention
+	}
+	if c.Retention.Raw != "" {
+		retention.Raw = c.Retention.Raw
+	}
+	if c.Retention.FiveMinutes != "" {
+		retention.FiveMinutes = c.Retention.FiveMinutes
+	}
+	if c.Retention.OneHour != "" {
+		retention.OneHour = c.Retention.OneHour
+	}
+	return retention
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Trace receivers of Tempo
+const (
+	ReceiverOTLPGRPC            = "otlp-grpc"
+	ReceiverOTLPHTTP            = "otlp-http"
+	ReceiverJaegerGRPC          = "jaeger-grpc"
+	ReceiverJaegerThriftHTTP    = "jaeger-thrift-http"
+	ReceiverJaegerThriftCompact = "jaeger-thrift-compact"
+	ReceiverJaegerThriftBinary  = "jaeger-thrift-binary"
+	ReceiverZipkin              = "zipkin"
+)
+
+// TraceReceiversSpec enables the trace receivers of Tempo one by one and
+// exposes them outside of the platform. Receivers not listed are enabled.
+type TraceReceiversSpec struct {
+	// OTLPGRPC is the OTLP receiver over gRPC, port 4317
+	// +optional
+	OTLPGRPC *TraceReceiverSpec `json:"otlpGrpc,omitempty"`
+
+	// OTLPHTTP is the OTLP receiver over HTTP, port 4318
+	// +optional
+	OTLPHTTP *TraceReceiverSpec `json:"otlpHttp,omitempty"`
+
+	// JaegerGRPC is the Jaeger receiver over gRPC, port 14250
+	// +optional
+	JaegerGRPC *TraceReceiverSpec `json:"jaegerGrpc,omitempty"`
+
+	// JaegerThriftHTTP is the Jaeger Thrift receiver over HTTP, port 14268
+	// +optional
+	JaegerThriftHTTP *TraceReceiverSpec `json:"jaegerThriftHttp,omitempty"`
+
+	// JaegerThriftCompact is the Jaeger Thrift compact receiver over UDP,
+	// port 6831
+	// +optional
+	JaegerThriftCompact *TraceReceiverSpec `json:"jaegerThriftCompact,omitempty"`
+
+	// JaegerThriftBinary is the Jaeger Thrift binary receiver over UDP, port
+	// 6832
+	// +optional
+	JaegerThriftBinary *TraceReceiverSpec `json:"jaegerThriftBinary,omitempty"`
+
+	// Zipkin is the Zipkin receiver, port 9411
+	// +optional
+	Zipkin *TraceReceiverSpec `json:"zipkin,omitempty"`
+}
+
+// TraceReceiverSpec configures a trace receiver
+type TraceReceiverSpec struct {
+	// Enabled determines if the receiver listens. Disabled receivers are
+	// removed from the Tempo Service.
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Service exposes the receiver through a dedicated Service, e.g. a
+	// LoadBalancer for clients outside of the cluster
+	// +optional
+	Service *ReceiverServiceSpec `json:"service,omitempty"`
+
+	// Ingress exposes the receiver through an Ingress. Only supported by the
+	// OTLP HTTP, Jaeger Thrift HTTP and Zipkin receivers.
+	// +optional
+	Ingress *IngressSpec `json:"ingress,omitempty"`
+}
+
+// ReceiverServiceSpec configures the dedicated Service of a receiver
+type ReceiverServiceSpec struct {
+	// Type of the Service
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +kubebuilder:default=LoadBalancer
+	// +optional
+	Type corev1.ServiceType `json:"type,omitempty"`
+
+	// Annotations of the Service, e.g. to configure the load balancer
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// LoadBalancerSourceRanges restricts the clients of a LoadBalancer
+	// +optional
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+}
+
+// Get returns the configuration of a receiver, nil if not configured
+func (r *TraceReceiversSpec) Get(name string) *TraceReceiverSpec {
+	if r == nil {
+		return nil
+	}
+	switch name {
+	case ReceiverOTLPGRPC:
+		return r.OTLPGRPC
+	case ReceiverOTLPHTTP:
+		return r.OTLPHTTP
+	case ReceiverJaegerGRPC:
+		return r.JaegerGRPC
+	case ReceiverJaegerThriftHTTP:
+		return r.JaegerThriftHTTP
+	case ReceiverJaegerThriftCompact:
+		return r.JaegerThriftCompact
+	case ReceiverJaegerThriftBinary:
+		return r.JaegerThriftBinary
+	case ReceiverZipkin:
+		return r.Zipkin
+	default:
+		return nil
+	}
+}
+
+// IsEnabled returns true if a receiver listens; receivers are enabled unless
+// disabled explicitly
+func (r *TraceReceiversSpec) IsEnabled(name string) bool {
+	receiver := r.Get(name)
+	return receiver == nil || receiver.Enabled == nil || *receiver.Enabled
+}
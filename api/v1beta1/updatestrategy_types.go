@@ -0,0 +1,80 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Update strategy types of a component
+const (
+	// UpdateStrategyRollingUpdate replaces the pods one batch at a time
+	UpdateStrategyRollingUpdate = "RollingUpdate"
+	// UpdateStrategyRecreate deletes all pods before creating the new ones.
+	// Only supported by Deployments (Grafana).
+	UpdateStrategyRecreate = "Recreate"
+	// UpdateStrategyOnDelete only replaces pods once they are deleted.
+	// Only supported by StatefulSets (Prometheus, Loki and Tempo).
+	UpdateStrategyOnDelete = "OnDelete"
+)
+
+// ComponentUpdateStrategy controls how changes to a component's pod
+// template roll across its replicas
+type ComponentUpdateStrategy struct {
+	// Type of the update strategy
+	// +kubebuilder:validation:Enum=RollingUpdate;Recreate;OnDelete
+	// +kubebuilder:default=RollingUpdate
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// MaxUnavailable is the number or percentage of pods that can be
+	// unavailable during a rolling update
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// MaxSurge is the number or percentage of pods created above the desired
+	// replicas during a rolling update. Only supported by Deployments.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// Partition stages a rolling update of a StatefulSet: only the pods with
+	// an ordinal greater than or equal to the partition are updated. Lower it
+	// step by step to roll an ingester change one replica at a time.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Partition *int32 `json:"partition,omitempty"`
+
+	// StagedRollout rolls the ingesters of Loki and Tempo out one at a time,
+	// waiting for the ring to be stable and flushing each ingester before it
+	// is replaced. The operator then manages the partition.
+	// +optional
+	StagedRollout *StagedRolloutSpec `json:"stagedRollout,omitempty"`
+}
+
+// StagedRolloutSpec configures the staged rollout of a ring-based component
+type StagedRolloutSpec struct {
+	// Enabled turns on staged rollouts
+	Enabled bool `json:"enabled"`
+
+	// StabilizationPeriod is how long the ring must be stable after an
+	// ingester was replaced before the next one is released
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +kubebuilder:default="1m"
+	// +optional
+	StabilizationPeriod string `json:"stabilizationPeriod,omitempty"`
+
+	// FlushTimeout bounds the flush of an ingester before it is replaced
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +kubebuilder:default="10m"
+	// +optional
+	FlushTimeout string `json:"flushTimeout,omitempty"`
+}
+
+// IsStaged returns true if staged rollouts are enabled
+func (s *ComponentUpdateStrategy) IsStaged() bool {
+	return s != nil && s.StagedRollout != nil && s.StagedRollout.Enabled
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpgradeHooksSpec configures the data migration Jobs run when a component
+// is upgraded. Hooks are defined per version transition in the operator's
+// built-in catalog and, optionally, a catalog in a ConfigMap. Pre-upgrade
+// hooks must succeed before the new version is rolled out.
+type UpgradeHooksSpec struct {
+	// Enabled determines if upgrade hooks are run
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// CatalogRef references a ConfigMap key holding additional hooks in the
+	// catalog format. Hooks with the name of a built-in hook replace it.
+	// +optional
+	CatalogRef *corev1.ConfigMapKeySelector `json:"catalogRef,omitempty"`
+
+	// ServiceAccountName runs the hook Jobs. Defaults to the platform's
+	// service account.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// UpgradeHookStatus is the state of the migration hooks of a component upgrade
+type UpgradeHookStatus struct {
+	// From is the version being upgraded from
+	From string `json:"from"`
+
+	// To is the version being upgraded to
+	To string `json:"to"`
+
+	// Phase is the hook phase in progress
+	// +kubebuilder:validation:Enum=pre-upgrade;post-upgrade
+	Phase string `json:"phase"`
+
+	// Hook is the name of the hook running or failed
+	// +optional
+	Hook string `json:"hook,omitempty"`
+
+	// Job is the name of the hook's Job
+	// +optional
+	Job string `json:"job,omitempty"`
+
+	// State of the hook Job
+	// +kubebuilder:validation:Enum=Running;Succeeded;Failed
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// LastTransitionTime is when the state last changed
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// IsUpgradeHooksEnabled returns true unless upgrade hooks are disabled
+func (p *ObservabilityPlatform) IsUpgradeHooksEnabled() bool {
+	return p.Spec.UpgradeHooks == nil || p.Spec.UpgradeHooks.Enabled == nil || *p.Spec.UpgradeHooks.Enabled
+}
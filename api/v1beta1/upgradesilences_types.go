@@ -0,0 +1,113 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpgradeSilencesSpec configures the Alertmanager silences created while the
+// operator upgrades a component. A silence is created when a new version
+// starts rolling out and expired once the component is healthy again, so the
+// planned restart does not page anyone.
+type UpgradeSilencesSpec struct {
+	// Enabled determines if upgrades are silenced
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// AlertmanagerURL is the Alertmanager the silences are created in.
+	// Defaults to the platform's Alertmanager.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	AlertmanagerURL string `json:"alertmanagerURL,omitempty"`
+
+	// Duration is how long a silence lasts at most. It bounds the silence of
+	// an upgrade that never becomes healthy, or that the operator stopped
+	// tracking.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +kubebuilder:default="2h"
+	// +optional
+	Duration string `json:"duration,omitempty"`
+
+	// Matchers select the silenced alerts. Defaults to the alerts of the
+	// platform's namespace.
+	// +optional
+	Matchers []SilenceMatcher `json:"matchers,omitempty"`
+
+	// Components restricts which component upgrades are silenced. Defaults
+	// to all.
+	// +optional
+	Components []string `json:"components,omitempty"`
+}
+
+// SilenceMatcher matches an alert label
+type SilenceMatcher struct {
+	// Name of the label
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Value the label is matched against. Occurrences of {{component}} are
+	// replaced with the upgraded component.
+	Value string `json:"value"`
+
+	// Regex matches the value as a regular expression
+	// +optional
+	Regex bool `json:"regex,omitempty"`
+}
+
+// UpgradeSilenceStatus tracks the silence of an in-progress component upgrade
+type UpgradeSilenceStatus struct {
+	// ID of the silence in Alertmanager
+	ID string `json:"id"`
+
+	// From is the version being upgraded from
+	From string `json:"from"`
+
+	// To is the version being upgraded to
+	To string `json:"to"`
+
+	// EndsAt is when the silence expires unless the upgrade completes before
+	EndsAt metav1.Time `json:"endsAt"`
+}
+
+// IsUpgradeSilencesEnabled returns true if upgrades are silenced
+func (p *ObservabilityPlatform) IsUpgradeSilencesEnabled() bool {
+	return p.Spec.UpgradeSilences != nil && p.Spec.UpgradeSilences.Enabled
+}
+
+// GetAlertmanagerURL returns the Alertmanager silences are created in. It
+// may be called on a nil spec.
+func (s *UpgradeSilencesSpec) GetAlertmanagerURL(platform *ObservabilityPlatform) string {
+	if s != nil && s.AlertmanagerURL != "" {
+		return s.AlertmanagerURL
+	}
+	return fmt.Sprintf("http://%s-alertmanager.%s.svc:9093", platform.Name, platform.Namespace)
+}
+
+// GetDuration returns how long a silence lasts at most, 2h if not set
+func (s *UpgradeSilencesSpec) GetDuration() time.Duration {
+	if duration, err := time.ParseDuration(s.Duration); err == nil && duration > 0 {
+		return duration
+	}
+	return 2 * time.Hour
+}
+
+// Silences returns true if the upgrades of a component are silenced
+func (s *UpgradeSilencesSpec) Silences(component string) bool {
+	if len(s.Components) == 0 {
+		return true
+	}
+	for _, c := range s.Components {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
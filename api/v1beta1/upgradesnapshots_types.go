@@ -0,0 +1,115 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpgradeSnapshotsSpec configures the VolumeSnapshots taken of a component's
+// PersistentVolumeClaims before a new version rolls out. The rollout waits
+// for the snapshots to be ready, so a failed upgrade can be rolled back to
+// the data it started from.
+type UpgradeSnapshotsSpec struct {
+	// Enabled determines if upgrades are preceded by volume snapshots
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// VolumeSnapshotClassName is the class of the snapshots. Defaults to the
+	// class of spec.dataProtection, or the cluster's default class.
+	// +optional
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+
+	// Components restricts which component upgrades are snapshotted.
+	// Defaults to all components with persistent storage.
+	// +optional
+	Components []string `json:"components,omitempty"`
+
+	// Keep is the number of upgrades whose snapshots are kept per component.
+	// The snapshots of older upgrades are deleted.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3
+	// +optional
+	Keep *int32 `json:"keep,omitempty"`
+}
+
+// UpgradeSnapshotStatus reports the snapshots taken before an upgrade
+type UpgradeSnapshotStatus struct {
+	// From is the version being upgraded from
+	From string `json:"from"`
+
+	// To is the version being upgraded to
+	To string `json:"to"`
+
+	// Phase of the snapshots
+	// +kubebuilder:validation:Enum=Snapshotting;Ready;Failed
+	Phase string `json:"phase"`
+
+	// Message describes a failure
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Snapshots are the VolumeSnapshots of the component's claims
+	// +optional
+	Snapshots []UpgradeVolumeSnapshot `json:"snapshots,omitempty"`
+
+	// CreationTime is when the snapshots were requested
+	CreationTime metav1.Time `json:"creationTime"`
+}
+
+// UpgradeVolumeSnapshot is the VolumeSnapshot of a PersistentVolumeClaim
+type UpgradeVolumeSnapshot struct {
+	// Claim is the name of the snapshotted PersistentVolumeClaim
+	Claim string `json:"claim"`
+
+	// Snapshot is the name of the VolumeSnapshot
+	Snapshot string `json:"snapshot"`
+
+	// ReadyToUse is true once the snapshot can be restored
+	// +optional
+	ReadyToUse bool `json:"readyToUse,omitempty"`
+}
+
+// IsUpgradeSnapshotsEnabled returns true if upgrades are preceded by volume
+// snapshots
+func (p *ObservabilityPlatform) IsUpgradeSnapshotsEnabled() bool {
+	return p.Spec.UpgradeSnapshots != nil && p.Spec.UpgradeSnapshots.Enabled
+}
+
+// GetUpgradeVolumeSnapshotClassName returns the class of the upgrade
+// snapshots, or an empty string for the cluster's default class
+func (p *ObservabilityPlatform) GetUpgradeVolumeSnapshotClassName() string {
+	if p.Spec.UpgradeSnapshots != nil && p.Spec.UpgradeSnapshots.VolumeSnapshotClassName != "" {
+		return p.Spec.UpgradeSnapshots.VolumeSnapshotClassName
+	}
+	if p.Spec.DataProtection != nil {
+		return p.Spec.DataProtection.VolumeSnapshotClassName
+	}
+	return ""
+}
+
+// GetKeep returns the number of upgrades whose snapshots are kept, 3 if not
+// set
+func (s *UpgradeSnapshotsSpec) GetKeep() int {
+	if s.Keep == nil || *s.Keep < 1 {
+		return 3
+	}
+	return int(*s.Keep)
+}
+
+// Snapshots returns true if the upgrades of a component are snapshotted
+func (s *UpgradeSnapshotsSpec) Snapshots(component string) bool {
+	if len(s.Components) == 0 {
+		return true
+	}
+	for _, c := range s.Components {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
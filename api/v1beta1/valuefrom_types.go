@@ -0,0 +1,37 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+// ValueFromSource selects a configuration value kept outside of the
+// platform, so shared settings are maintained once and reused across many
+// platforms. The value is read each time the configuration is rendered.
+type ValueFromSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap
+	ConfigMapKeyRef *ConfigMapKeyReference `json:"configMapKeyRef"`
+}
+
+// ConfigMapKeyReference selects a key of a ConfigMap. A ConfigMap in another
+// namespace than the platform's must be labeled
+// observability.io/shared=true.
+type ConfigMapKeyReference struct {
+	// Name of the ConfigMap
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap, the platform's namespace if empty
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Key of the value in the ConfigMap
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Optional renders the configuration without the value when the
+	// ConfigMap or its key does not exist, instead of failing
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
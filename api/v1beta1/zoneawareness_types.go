@@ -0,0 +1,72 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package v1beta1
+
+// ZoneAwarenessSpec configures zone-aware replication of ring members such as
+// the Loki and Tempo ingesters. One StatefulSet is created per zone and each
+// write is replicated to ReplicationFactor different zones.
+type ZoneAwarenessSpec struct {
+	// Enabled determines if ingesters are deployed zone-aware
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// TopologyKey is the node label holding the zone
+	// +kubebuilder:default="topology.kubernetes.io/zone"
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// Zones the ingesters are spread across. Every zone must have
+	// schedulable nodes carrying the topology key.
+	// +kubebuilder:validation:MinItems=2
+	Zones []string `json:"zones"`
+
+	// ReplicationFactor is the number of zones each write is replicated to.
+	// It must not exceed the number of zones.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3
+	// +optional
+	ReplicationFactor int32 `json:"replicationFactor,omitempty"`
+
+	// ReplicasPerZone is the number of ingesters in each zone
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	ReplicasPerZone int32 `json:"replicasPerZone,omitempty"`
+}
+
+// IsEnabled returns true if zone-aware replication is enabled
+func (z *ZoneAwarenessSpec) IsEnabled() bool {
+	return z != nil && z.Enabled && len(z.Zones) > 0
+}
+
+// GetTopologyKey returns the node label holding the zone
+func (z *ZoneAwarenessSpec) GetTopologyKey() string {
+	if z.TopologyKey == "" {
+		return "topology.kubernetes.io/zone"
+	}
+	return z.TopologyKey
+}
+
+// GetReplicationFactor returns the replication factor, capped at the number of zones
+func (z *ZoneAwarenessSpec) GetReplicationFactor() int32 {
+	rf := z.ReplicationFactor
+	if rf <= 0 {
+		rf = 3
+	}
+	if n := int32(len(z.Zones)); rf > n {
+		rf = n
+	}
+	return rf
+}
+
+// GetReplicasPerZone returns the number of ingesters per zone
+func (z *ZoneAwarenessSpec) GetReplicasPerZone() int32 {
+	if z.ReplicasPerZone <= 0 {
+		return 1
+	}
+	return z.ReplicasPerZone
+}
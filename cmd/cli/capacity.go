@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// newCapacityCmd creates the capacity command
+func newCapacityCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "capacity PLATFORM",
+		Short: "Show the capacity forecasts of a platform and the scaling actions they call for",
+		Long: `capacity prints the 30 and 90 day forecasts of a platform's active series,
+log volume, span volume and storage, and the recommended scaling actions.
+The operator computes them from the usage history in the platform's
+Prometheus when spec.global.capacityPlanning is enabled.`,
+		Example: `  # Forecasts and recommendations of a platform
+  gunj capacity production -n monitoring
+
+  # As JSON
+  gunj capacity production -n monitoring -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCapacity(cmd.Context(), cmd.OutOrStdout(), args[0])
+		},
+	}
+}
+
+func runCapacity(ctx context.Context, out io.Writer, platformName string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	c, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	platform := &observabilityv1beta1.ObservabilityPlatform{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: platformName}, platform); err != nil {
+		return fmt.Errorf("failed to get platform %s/%s: %w", namespace, platformName, err)
+	}
+
+	status := platform.Status.Capacity
+	if status == nil {
+		if !platform.CapacityPlanning().IsEnabled() {
+			return fmt.Errorf("capacity planning is not enabled for platform %s/%s; set spec.global.capacityPlanning.enabled", namespace, platformName)
+		}
+		return fmt.Errorf("the capacity of platform %s/%s has not been forecast yet", namespace, platformName)
+	}
+
+	switch output {
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(status)
+	case "table", "":
+		return printCapacity(out, platformName, status)
+	default:
+		return fmt.Errorf("unsupported output format %q", output)
+	}
+}
+
+// printCapacity prints the forecasts, then the recommendations
+func printCapacity(out io.Writer, platformName string, status *observabilityv1beta1.CapacityStatus) error {
+	analyzed := "never"
+	if status.LastAnalysisTime != nil {
+		analyzed = status.LastAnalysisTime.Local().Format(time.RFC3339)
+	}
+	fmt.Fprintf(out, "Capacity forecast of %s, from %d days of history, computed %s\n\n", platformName, status.HistoryDays, analyzed)
+
+	if len(status.Forecasts) > 0 {
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "COMPONENT\tRESOURCE\tUNIT\tCURRENT\tPER DAY\tIN 30 DAYS\tIN 90 DAYS\tCAPACITY\tFULL IN")
+		for _, f := range status.Forecasts {
+			capacity, fullIn := "-", "-"
+			if f.Capacity != "" {
+				capacity = f.Capacity
+			}
+			if f.FullInDays != nil {
+				fullIn = strconv.Itoa(int(*f.FullInDays)) + "d"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				f.Component, f.Resource, f.Unit, f.Current, f.GrowthPerDay, f.In30Days, f.In90Days, capacity, fullIn)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	if status.Message != "" {
+		fmt.Fprintf(out, "\n%s\n", status.Message)
+	}
+
+	if len(status.Recommendations) == 0 {
+		fmt.Fprintln(out, "\nNo scaling needed within 90 days")
+		return nil
+	}
+	fmt.Fprintln(out, "\nRecommendations:")
+	for _, r := range status.Recommendations {
+		fmt.Fprintf(out, "  - %s\n    %s\n", r.Action, r.Reason)
+	}
+	return nil
+}
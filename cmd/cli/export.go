@@ -0,0 +1,107 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/vendorexport"
+)
+
+// newExportCmd creates the export command
+func newExportCmd() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "export VENDOR PLATFORM",
+		Short: "Render a platform's telemetry collection as a vendor agent configuration",
+		Long: `export renders the scrape jobs, log collection and trace ingestion of a
+platform as the configuration of a vendor agent (` + strings.Join(vendorexport.Vendors(), ", ") + `),
+so the same telemetry can be sent to both during a vendor migration.
+Credentials are left as environment variable references. What cannot be
+rendered is reported on stderr.`,
+		Example: `  # Print the Datadog Agent configuration
+  gunj export datadog production -n monitoring
+
+  # Write the Elastic Agent configuration to a directory
+  gunj export elastic production -n monitoring --dir ./elastic`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(cmd.Context(), cmd.OutOrStdout(), args[0], args[1], dir)
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", "Write the configuration files to this directory instead of stdout")
+
+	return cmd
+}
+
+func runExport(ctx context.Context, out io.Writer, vendor, platformName, dir string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	c, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	platform := &observabilityv1beta1.ObservabilityPlatform{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: platformName}, platform); err != nil {
+		return fmt.Errorf("failed to get platform %s/%s: %w", namespace, platformName, err)
+	}
+
+	intents, notes, err := vendorexport.FromPlatform(platform)
+	if err != nil {
+		return err
+	}
+	export, err := vendorexport.Render(vendor, intents)
+	if err != nil {
+		return err
+	}
+	export.Notes = append(notes, export.Notes...)
+
+	switch {
+	case output == "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(export)
+	case dir != "":
+		for _, path := range export.Paths() {
+			target := filepath.Join(dir, filepath.FromSlash(path))
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(target, []byte(export.Files[path]), 0644); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Wrote %s\n", target)
+		}
+	default:
+		for i, path := range export.Paths() {
+			if i > 0 {
+				fmt.Fprintln(out, "---")
+			}
+			fmt.Fprintf(out, "# %s\n%s", path, export.Files[path])
+		}
+	}
+
+	for _, note := range export.Notes {
+		fmt.Fprintf(os.Stderr, "note: %s\n", note)
+	}
+	return nil
+}
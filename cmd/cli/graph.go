@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/graph"
+)
+
+// newGraphCmd creates the graph command
+func newGraphCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "graph PLATFORM",
+		Short: "Export the object graph of a platform as DOT, Mermaid or JSON",
+		Long: `graph lists the resources the operator manages for a platform (Deployments,
+StatefulSets, Services, ConfigMaps, Secrets, PVCs, ...) and prints them with
+an edge from every owner to the objects it owns.`,
+		Example: `  # Render with Graphviz
+  gunj graph production -n monitoring | dot -Tsvg > production.svg
+
+  # Mermaid flowchart for a README or wiki page
+  gunj graph production -n monitoring --format mermaid`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("format") && output == "json" {
+				format = string(graph.FormatJSON)
+			}
+			return runGraph(cmd.Context(), cmd.OutOrStdout(), args[0], format)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", string(graph.FormatDOT), "Graph format (dot, mermaid, json)")
+
+	return cmd
+}
+
+func runGraph(ctx context.Context, out io.Writer, platformName, formatName string) error {
+	format, err := graph.ParseFormat(formatName)
+	if err != nil {
+		return err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	c, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	platform := &observabilityv1beta1.ObservabilityPlatform{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: platformName}, platform); err != nil {
+		return fmt.Errorf("failed to get platform %s/%s: %w", namespace, platformName, err)
+	}
+
+	g, err := graph.Collect(ctx, c, platform)
+	if err != nil {
+		return err
+	}
+	return g.Render(out, format)
+}
@@ -0,0 +1,346 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/pkg/catalog"
+	"github.com/gunjanjp/gunj-operator/pkg/onboarding"
+)
+
+// initOptions are the flags of the init command
+type initOptions struct {
+	answers        onboarding.Answers
+	example        string
+	listExamples   bool
+	file           string
+	nonInteractive bool
+	skipChecks     bool
+	force          bool
+}
+
+// newInitCmd creates the init command
+func newInitCmd() *cobra.Command {
+	opts := &initOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Generate a ready-to-apply ObservabilityPlatform for this cluster",
+		Long: `init asks about the cluster's size, storage class, ingress and single
+sign-on, and generates an ObservabilityPlatform sized by the matching profile
+(` + profileList() + `). Questions answered by flags are not asked.
+
+Before the manifest is written, the answers are checked against the cluster:
+the storage and ingress classes and the Secrets must exist, and the nodes
+must fit the profile's requests. Failed checks prevent the manifest from
+being written unless --force; warnings are only reported.
+
+With --example, the platform is instead instantiated from an entry of the
+cluster's PlatformCatalogs, or of the builtin examples without a cluster;
+--list-examples lists them.`,
+		Example: `  # Answer the questions interactively
+  gunj init -n monitoring > platform.yaml
+
+  # Without questions, e.g. in a pipeline
+  gunj init --non-interactive --name production -n monitoring --size medium \
+    --storage-class gp3 --ingress-host grafana.example.com --ingress-class nginx \
+    --sso-provider okta --sso-client-id grafana --sso-client-secret grafana-oauth \
+    --file platform.yaml
+
+  # Start from a catalog example
+  gunj init --list-examples
+  gunj init --example ha-production --name production -n monitoring`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInit(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.answers.Name, "name", "production", "Name of the platform")
+	cmd.Flags().StringVar(&opts.answers.Size, "size", "", "Size profile ("+profileList()+"), by default matched to the node count")
+	cmd.Flags().StringSliceVar(&opts.answers.Components, "components", []string{"prometheus", "grafana", "loki"}, "Components to deploy ("+strings.Join(onboarding.Components, ", ")+")")
+	cmd.Flags().StringVar(&opts.answers.StorageClass, "storage-class", "", "StorageClass of the volumes, by default the cluster's default class")
+	cmd.Flags().StringVar(&opts.answers.IngressHost, "ingress-host", "", "Expose Grafana on this host")
+	cmd.Flags().StringVar(&opts.answers.IngressClass, "ingress-class", "", "IngressClass of the Grafana ingress")
+	cmd.Flags().StringVar(&opts.answers.IngressTLSSecret, "ingress-tls-secret", "", "Secret of the ingress host's certificate")
+	cmd.Flags().StringVar(&opts.answers.SSOProvider, "sso-provider", "", "Grafana single sign-on provider ("+strings.Join(onboarding.SSOProviders, ", ")+")")
+	cmd.Flags().StringVar(&opts.answers.SSOClientID, "sso-client-id", "", "OAuth client ID of Grafana")
+	cmd.Flags().StringVar(&opts.answers.SSOClientSecret, "sso-client-secret", "", "Secret holding the OAuth client secret under the key "+onboarding.SSOClientSecretKey)
+	cmd.Flags().StringVar(&opts.example, "example", "", "Instantiate this catalog entry, <catalog>/<entry> or a unique entry name")
+	cmd.Flags().BoolVar(&opts.listExamples, "list-examples", false, "List the entries of the platform catalogs")
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "Write the manifest to this file instead of stdout")
+	cmd.Flags().BoolVar(&opts.nonInteractive, "non-interactive", false, "Do not ask questions; use the flags and their defaults")
+	cmd.Flags().BoolVar(&opts.skipChecks, "skip-checks", false, "Do not check the answers against the cluster")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Write the manifest even if checks fail")
+
+	return cmd
+}
+
+func runInit(cmd *cobra.Command, opts *initOptions) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	answers := &opts.answers
+	answers.Namespace = namespace
+	stderr := cmd.ErrOrStderr()
+
+	var c client.Client
+	if !opts.skipChecks {
+		var err error
+		if c, err = createClient(); err != nil {
+			return fmt.Errorf("cannot run the checks, use --skip-checks to generate the manifest offline: %w", err)
+		}
+	}
+
+	if opts.listExamples || opts.example != "" {
+		entries, err := catalogEntries(ctx, c)
+		if err != nil {
+			return err
+		}
+		if opts.listExamples {
+			return printCatalog(cmd.OutOrStdout(), entries)
+		}
+		return runInitExample(ctx, cmd, opts, c, entries)
+	}
+
+	// Match the size to the cluster unless asked for
+	if answers.Size == "" {
+		answers.Size = "small"
+		if c != nil {
+			nodes := &corev1.NodeList{}
+			if err := c.List(ctx, nodes); err == nil {
+				answers.Size = onboarding.ProfileForNodes(len(nodes.Items)).Name
+			}
+		}
+	}
+
+	if !opts.nonInteractive {
+		p := &prompter{in: bufio.NewReader(cmd.InOrStdin()), out: stderr}
+		if err := askInit(cmd, p, answers); err != nil {
+			return err
+		}
+	}
+
+	manifest, err := onboarding.Generate(*answers)
+	if err != nil {
+		return err
+	}
+	if err := validatePlatform(manifest.Platform); err != nil {
+		return err
+	}
+
+	if c != nil {
+		findings := onboarding.Check(ctx, c, *answers)
+		for _, finding := range findings {
+			fmt.Fprintln(stderr, finding)
+		}
+		if onboarding.HasErrors(findings) && !opts.force {
+			return fmt.Errorf("pre-checks failed, fix the findings or use --force")
+		}
+	}
+
+	data, err := manifest.YAML()
+	if err != nil {
+		return err
+	}
+	return writeManifest(cmd, opts.file, data)
+}
+
+// runInitExample generates the platform of a catalog entry
+func runInitExample(ctx context.Context, cmd *cobra.Command, opts *initOptions, c client.Client, entries []catalog.Entry) error {
+	entry, err := catalog.Find(entries, opts.example)
+	if err != nil {
+		return err
+	}
+	answers := &opts.answers
+	if !opts.nonInteractive && !cmd.Flags().Changed("name") {
+		p := &prompter{in: bufio.NewReader(cmd.InOrStdin()), out: cmd.ErrOrStderr()}
+		answers.Name = p.ask("Platform name", answers.Name)
+		if p.err != nil {
+			return p.err
+		}
+	}
+
+	platform, err := catalog.Instantiate(entry, answers.Name, answers.Namespace)
+	if err != nil {
+		return err
+	}
+	if err := validatePlatform(platform); err != nil {
+		return err
+	}
+
+	if c != nil {
+		findings := onboarding.CheckTarget(ctx, c, answers.Name, answers.Namespace)
+		for _, finding := range findings {
+			fmt.Fprintln(cmd.ErrOrStderr(), finding)
+		}
+		if onboarding.HasErrors(findings) && !opts.force {
+			return fmt.Errorf("pre-checks failed, fix the findings or use --force")
+		}
+	}
+
+	data, err := yaml.Marshal(platform.Object)
+	if err != nil {
+		return err
+	}
+	return writeManifest(cmd, opts.file, data)
+}
+
+// catalogEntries returns the entries of the cluster's catalogs, or the
+// builtin examples without a cluster
+func catalogEntries(ctx context.Context, c client.Client) ([]catalog.Entry, error) {
+	if c == nil {
+		return catalog.Builtin()
+	}
+	return catalog.List(ctx, c)
+}
+
+// printCatalog lists catalog entries
+func printCatalog(out io.Writer, entries []catalog.Entry) error {
+	if output == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ENTRY\tTAGS\tDESCRIPTION")
+	for _, entry := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Ref(), strings.Join(entry.Tags, ","), entry.Description)
+	}
+	return w.Flush()
+}
+
+// writeManifest writes a manifest to a file, or to stdout without one
+func writeManifest(cmd *cobra.Command, file string, data []byte) error {
+	if file != "" {
+		if err := os.WriteFile(file, data, 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Platform written to %s, apply it with: kubectl apply -f %s\n", file, file)
+		return nil
+	}
+	_, err := cmd.OutOrStdout().Write(data)
+	return err
+}
+
+// askInit asks the questions whose flags were not set
+func askInit(cmd *cobra.Command, p *prompter, answers *onboarding.Answers) error {
+	ask := func(flag string, question string, value *string) {
+		if !cmd.Flags().Changed(flag) && p.err == nil {
+			*value = p.ask(question, *value)
+		}
+	}
+	confirm := func(flag, question string) bool {
+		return !cmd.Flags().Changed(flag) && p.err == nil && p.confirm(question)
+	}
+
+	ask("name", "Platform name", &answers.Name)
+	for _, profile := range onboarding.Profiles() {
+		fmt.Fprintf(p.out, "  %-8s %s\n", profile.Name, profile.Description)
+	}
+	ask("size", "Cluster size", &answers.Size)
+	if !cmd.Flags().Changed("components") && p.err == nil {
+		components := p.ask("Components ("+strings.Join(onboarding.Components, ", ")+")", strings.Join(answers.Components, ","))
+		answers.Components = splitList(components)
+	}
+	ask("storage-class", "Storage class (empty for the cluster's default)", &answers.StorageClass)
+
+	if answers.IngressHost != "" || confirm("ingress-host", "Expose Grafana through an ingress?") {
+		ask("ingress-host", "Grafana host", &answers.IngressHost)
+		ask("ingress-class", "Ingress class (empty for the cluster's default)", &answers.IngressClass)
+		ask("ingress-tls-secret", "TLS certificate Secret (empty for plain HTTP)", &answers.IngressTLSSecret)
+	}
+
+	if answers.SSOProvider != "" || confirm("sso-provider", "Sign in to Grafana with SSO?") {
+		ask("sso-provider", "SSO provider ("+strings.Join(onboarding.SSOProviders, ", ")+")", &answers.SSOProvider)
+		ask("sso-client-id", "OAuth client ID", &answers.SSOClientID)
+		ask("sso-client-secret", "Secret holding the OAuth client secret", &answers.SSOClientSecret)
+	}
+	return p.err
+}
+
+// validatePlatform runs the validation of the platform webhook on a
+// generated platform
+func validatePlatform(obj *unstructured.Unstructured) error {
+	platform := &observabilityv1beta1.ObservabilityPlatform{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, platform); err != nil {
+		return err
+	}
+	platform.Default()
+	if _, err := platform.ValidateCreate(); err != nil {
+		return fmt.Errorf("the generated platform is invalid: %w", err)
+	}
+	return nil
+}
+
+// prompter asks questions on a terminal. The first read error is kept and
+// stops further questions.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+	err error
+}
+
+// ask asks a question, returning the default on an empty answer
+func (p *prompter) ask(question, defaultValue string) string {
+	if defaultValue != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, defaultValue)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+	line, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		p.err = fmt.Errorf("no answer to %q, use --non-interactive to answer with flags: %w", question, err)
+		return defaultValue
+	}
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer
+	}
+	return defaultValue
+}
+
+// confirm asks a yes/no question, answered no by default
+func (p *prompter) confirm(question string) bool {
+	answer := strings.ToLower(p.ask(question+" (y/N)", ""))
+	return answer == "y" || answer == "yes"
+}
+
+func profileList() string {
+	var names []string
+	for _, profile := range onboarding.Profiles() {
+		names = append(names, profile.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/queryproxy"
+)
+
+var (
+	kubeconfig    string
+	namespace     string
+	allNamespaces bool
+	output        string
+
+	scheme = runtime.NewScheme()
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(observabilityv1beta1.AddToScheme(scheme))
+}
+
+func main() {
+	rootCmd := &cobra.Command{
+		Use:   "gunj",
+		Short: "Command-line interface for the Gunj Operator",
+		Long: `gunj inspects and operates ObservabilityPlatform resources managed by the
+Gunj Operator. It can also be installed as a kubectl plugin (kubectl gunj).`,
+		SilenceUsage: true,
+	}
+
+	// Global flags
+	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: $KUBECONFIG or ~/.kube/config)")
+	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "default", "Kubernetes namespace")
+	rootCmd.PersistentFlags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Operate on platforms in all namespaces")
+	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "table", "Output format (table, json)")
+
+	// Add subcommands
+	rootCmd.AddCommand(
+		newInitCmd(),
+		newReportCmd(),
+		newGraphCmd(),
+		newTimelineCmd(),
+		newCapacityCmd(),
+		newExportCmd(),
+		newRenderCmd(),
+		newQueryCmd(queryproxy.PromQL),
+		newQueryCmd(queryproxy.LogQL),
+		newQueryCmd(queryproxy.TraceQL),
+	)
+
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// loadConfig loads the REST config of the current kubeconfig
+func loadConfig() (*rest.Config, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig != "" {
+		loadingRules.ExplicitPath = kubeconfig
+	}
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	return config, nil
+}
+
+// createClient creates a controller-runtime client for the current kubeconfig
+func createClient() (client.Client, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.New(config, client.Options{Scheme: scheme})
+}
+
+// listNamespace returns the namespace to list in, honouring --all-namespaces
+func listNamespace() string {
+	if allNamespaces {
+		return ""
+	}
+	return namespace
+}
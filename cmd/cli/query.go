@@ -0,0 +1,330 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/queryproxy"
+	"github.com/gunjanjp/gunj-operator/internal/queryresult"
+)
+
+// queryExamples are the examples of the query commands by language
+var queryExamples = map[queryproxy.Language]string{
+	queryproxy.PromQL: `  # Instant query
+  gunj promql production 'up{job="prometheus"}' -n monitoring
+
+  # Range query over the last hour, as JSON
+  gunj promql production 'rate(http_requests_total[5m])' -n monitoring --since 1h --step 1m -o json`,
+	queryproxy.LogQL: `  # Last 50 error lines of the past 15 minutes
+  gunj logql production '{app="api"} |= "error"' -n monitoring --since 15m --limit 50`,
+	queryproxy.TraceQL: `  # Slow traces of the past hour
+  gunj traceql production '{ duration > 2s }' -n monitoring --since 1h`,
+}
+
+// queryOptions are the flags of the query commands
+type queryOptions struct {
+	since    time.Duration
+	step     time.Duration
+	limit    int
+	endpoint string
+	timeout  time.Duration
+}
+
+// newQueryCmd creates the query command of a language
+func newQueryCmd(language queryproxy.Language) *cobra.Command {
+	opts := &queryOptions{}
+
+	cmd := &cobra.Command{
+		Use:   string(language) + " PLATFORM QUERY",
+		Short: fmt.Sprintf("Run a %s query against a platform", strings.ToUpper(string(language))),
+		Long: fmt.Sprintf(`%s runs a query against the backend of a platform and prints the result.
+The backend's service is resolved from the platform. When its cluster DNS name
+does not resolve, as outside the cluster, a port-forward to one of its ready
+pods is opened for the duration of the query.`, language),
+		Example: queryExamples[language],
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQuery(cmd.Context(), cmd.OutOrStdout(), language, args[0], args[1], opts)
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.since, "since", 0, "Query the range from this long ago to now instead of the current instant")
+	cmd.Flags().DurationVar(&opts.step, "step", 0, "Resolution of range queries (default: the backend's)")
+	cmd.Flags().IntVar(&opts.limit, "limit", 0, "Maximum number of log lines or traces (default: the backend's)")
+	cmd.Flags().StringVar(&opts.endpoint, "endpoint", "", "Base URL of the backend, skipping service resolution and port-forwarding")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 30*time.Second, "Timeout of the query")
+
+	return cmd
+}
+
+func runQuery(ctx context.Context, out io.Writer, language queryproxy.Language, platformName, query string, opts *queryOptions) error {
+	if output != "table" && output != "" && output != "json" {
+		return fmt.Errorf("unsupported output format %q", output)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	platform := &observabilityv1beta1.ObservabilityPlatform{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: platformName}, platform); err != nil {
+		return fmt.Errorf("failed to get platform %s/%s: %w", namespace, platformName, err)
+	}
+	target, err := queryproxy.TargetFor(platform, language, opts.since > 0)
+	if err != nil {
+		return err
+	}
+
+	baseURL := opts.endpoint
+	if baseURL == "" {
+		var stop func()
+		baseURL, stop, err = resolveBackend(ctx, config, c, target.URL.Host)
+		if err != nil {
+			return err
+		}
+		defer stop()
+	}
+	reqURL, err := url.Parse(strings.TrimSuffix(baseURL, "/") + target.URL.Path)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", baseURL, err)
+	}
+	reqURL.RawQuery = queryParams(target.QueryParam, query, opts, time.Now()).Encode()
+
+	ns := &corev1.Namespace{}
+	_ = c.Get(ctx, client.ObjectKey{Name: namespace}, ns)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(queryproxy.TenantHeader, queryproxy.Tenant(ns.Labels))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("query failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read the query response: %w", err)
+	}
+	// Prometheus and Loki report query errors in the body, with a 4xx code
+	if resp.StatusCode >= 300 && !json.Valid(body) {
+		return fmt.Errorf("query failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	if output == "json" {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, body, "", "  "); err != nil {
+			return fmt.Errorf("invalid query response: %w", err)
+		}
+		_, err := fmt.Fprintln(out, indented.String())
+		return err
+	}
+
+	table, err := queryresult.Parse(language, body)
+	if err != nil {
+		return err
+	}
+	return printQueryTable(out, table)
+}
+
+// queryParams returns the backend parameters of a query
+func queryParams(queryParam, query string, opts *queryOptions, now time.Time) url.Values {
+	params := url.Values{queryParam: {query}}
+	if opts.since > 0 {
+		params.Set("start", strconv.FormatInt(now.Add(-opts.since).Unix(), 10))
+		params.Set("end", strconv.FormatInt(now.Unix(), 10))
+		if opts.step > 0 {
+			params.Set("step", strconv.FormatFloat(opts.step.Seconds(), 'f', -1, 64))
+		}
+	}
+	if opts.limit > 0 {
+		params.Set("limit", strconv.Itoa(opts.limit))
+	}
+	return params
+}
+
+// resolveBackend returns the base URL of the backend service at host
+// (service.namespace.svc.cluster.local:port). The service is queried directly
+// if its name resolves, through a port-forward to one of its pods otherwise.
+func resolveBackend(ctx context.Context, config *rest.Config, c client.Client, host string) (string, func(), error) {
+	noop := func() {}
+	hostname, port, err := net.SplitHostPort(host)
+	if err != nil {
+		return "", noop, err
+	}
+	lookupCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	if _, err := net.DefaultResolver.LookupHost(lookupCtx, hostname); err == nil {
+		return "http://" + host, noop, nil
+	}
+
+	parts := strings.SplitN(hostname, ".", 3)
+	if len(parts) < 2 {
+		return "", noop, fmt.Errorf("unexpected backend host %q", host)
+	}
+	serviceName, serviceNamespace := parts[0], parts[1]
+	svc := &corev1.Service{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: serviceNamespace, Name: serviceName}, svc); err != nil {
+		return "", noop, fmt.Errorf("failed to get service %s/%s: %w", serviceNamespace, serviceName, err)
+	}
+	pod, targetPort, err := readyPod(ctx, c, svc, port)
+	if err != nil {
+		return "", noop, err
+	}
+
+	localPort, stop, err := forwardPort(config, pod, targetPort)
+	if err != nil {
+		return "", noop, err
+	}
+	fmt.Fprintf(os.Stderr, "Forwarding from 127.0.0.1:%d to pod %s/%s:%d\n", localPort, pod.Namespace, pod.Name, targetPort)
+	return fmt.Sprintf("http://127.0.0.1:%d", localPort), stop, nil
+}
+
+// readyPod returns a ready pod of the service and the container port its
+// port forwards to
+func readyPod(ctx context.Context, c client.Client, svc *corev1.Service, port string) (*corev1.Pod, int, error) {
+	var servicePort *corev1.ServicePort
+	for i := range svc.Spec.Ports {
+		if strconv.Itoa(int(svc.Spec.Ports[i].Port)) == port {
+			servicePort = &svc.Spec.Ports[i]
+		}
+	}
+	if servicePort == nil {
+		return nil, 0, fmt.Errorf("service %s/%s has no port %s", svc.Namespace, svc.Name, port)
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return nil, 0, fmt.Errorf("service %s/%s has no selector", svc.Namespace, svc.Name)
+	}
+
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(svc.Namespace), client.MatchingLabels(svc.Spec.Selector)); err != nil {
+		return nil, 0, fmt.Errorf("failed to list pods of service %s/%s: %w", svc.Namespace, svc.Name, err)
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || !podReady(pod) {
+			continue
+		}
+		if targetPort := containerPort(pod, servicePort); targetPort > 0 {
+			return pod, targetPort, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("no ready pod for service %s/%s", svc.Namespace, svc.Name)
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// containerPort resolves the target port of a service port on a pod
+func containerPort(pod *corev1.Pod, servicePort *corev1.ServicePort) int {
+	target := servicePort.TargetPort
+	switch {
+	case target.Type == intstr.Int && target.IntVal > 0:
+		return int(target.IntVal)
+	case target.Type == intstr.String && target.StrVal != "":
+		for _, container := range pod.Spec.Containers {
+			for _, p := range container.Ports {
+				if p.Name == target.StrVal {
+					return int(p.ContainerPort)
+				}
+			}
+		}
+		return 0
+	default:
+		return int(servicePort.Port)
+	}
+}
+
+// forwardPort forwards a local port to the port of a pod until stop is called
+func forwardPort(config *rest.Config, pod *corev1.Pod, port int) (int, func(), error) {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return 0, nil, err
+	}
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return 0, nil, err
+	}
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(pod.Namespace).Name(pod.Name).SubResource("portforward")
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	stopCh, readyCh := make(chan struct{}), make(chan struct{})
+	var errOut bytes.Buffer
+	fw, err := portforward.New(dialer, []string{fmt.Sprintf("0:%d", port)}, stopCh, readyCh, io.Discard, &errOut)
+	if err != nil {
+		return 0, nil, err
+	}
+	errCh := make(chan error, 1)
+	go func() { errCh <- fw.ForwardPorts() }()
+
+	select {
+	case <-readyCh:
+	case err := <-errCh:
+		return 0, nil, fmt.Errorf("port-forward to pod %s/%s failed: %v %s", pod.Namespace, pod.Name, err, errOut.String())
+	}
+	ports, err := fw.GetPorts()
+	if err != nil || len(ports) == 0 {
+		close(stopCh)
+		return 0, nil, fmt.Errorf("port-forward to pod %s/%s has no local port: %v", pod.Namespace, pod.Name, err)
+	}
+	return int(ports[0].Local), func() { close(stopCh) }, nil
+}
+
+// printQueryTable prints a query result as a table
+func printQueryTable(out io.Writer, table *queryresult.Table) error {
+	if len(table.Rows) == 0 {
+		fmt.Fprintln(out, "No results")
+		return nil
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(table.Header, "\t"))
+	for _, row := range table.Rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
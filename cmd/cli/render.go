@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/render"
+)
+
+// renderOptions are the flags of the render command
+type renderOptions struct {
+	file      string
+	kustomize string
+	overlays  []string
+}
+
+// newRenderCmd creates the render command
+func newRenderCmd() *cobra.Command {
+	opts := &renderOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "render [PLATFORM]",
+		Short: "Render the manifests the operator creates for a platform",
+		Long: `render prints the objects the operator creates for a platform, rendered by
+the component managers without applying them, for review workflows and GitOps
+pipelines that apply pre-rendered manifests. The platform is read from the
+cluster, or from a file with --file, without a cluster.
+
+With --kustomize, the manifests are written to a directory as a kustomize
+base, a file per object under base/, with example overlays under overlays/:
+staging scales the workloads to one replica, production pins the images to
+their tags. Overlays named otherwise only label the objects with their
+environment.
+
+Secrets are left out, so no secret material is committed; they are reported
+on stderr and must exist in the cluster. Platforms with valueFrom references
+are rendered from the cluster only if the operator can resolve them.`,
+		Example: `  # Print the manifests of a platform
+  gunj render production -n monitoring
+
+  # Write a kustomize base and the staging and production overlays
+  gunj render -f platform.yaml --kustomize ./deploy/observability
+  kubectl kustomize ./deploy/observability/overlays/staging`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (len(args) == 0) == (opts.file == "") {
+				return fmt.Errorf("specify either a platform or --file")
+			}
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return runRender(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), name, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "Render the platform of this manifest instead of one of the cluster")
+	cmd.Flags().StringVar(&opts.kustomize, "kustomize", "", "Write a kustomize base and overlays to this directory")
+	cmd.Flags().StringSliceVar(&opts.overlays, "overlays", []string{"staging", "production"}, "Overlays written with --kustomize")
+
+	return cmd
+}
+
+func runRender(ctx context.Context, out, stderr io.Writer, platformName string, opts *renderOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	platform, err := loadPlatform(ctx, platformName, opts.file)
+	if err != nil {
+		return err
+	}
+
+	result, err := render.Platform(ctx, scheme, platform)
+	if err != nil {
+		return err
+	}
+	for _, note := range result.Notes {
+		fmt.Fprintf(stderr, "note: %s\n", note)
+	}
+
+	if opts.kustomize != "" {
+		layout, err := render.Kustomize(result.Objects, overlays(opts.overlays))
+		if err != nil {
+			return err
+		}
+		for _, path := range layout.Paths() {
+			target := filepath.Join(opts.kustomize, filepath.FromSlash(path))
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(target, []byte(layout.Files[path]), 0644); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Wrote %s\n", target)
+		}
+		return nil
+	}
+
+	if output == "json" {
+		items := make([]interface{}, 0, len(result.Objects))
+		for _, obj := range result.Objects {
+			items = append(items, obj.Object)
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(map[string]interface{}{"apiVersion": "v1", "kind": "List", "items": items})
+	}
+	for i, obj := range result.Objects {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return fmt.Errorf("failed to render %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		if i > 0 {
+			fmt.Fprintln(out, "---")
+		}
+		fmt.Fprint(out, string(data))
+	}
+	return nil
+}
+
+// loadPlatform reads a platform from a manifest, or from the cluster
+func loadPlatform(ctx context.Context, name, file string) (*observabilityv1beta1.ObservabilityPlatform, error) {
+	platform := &observabilityv1beta1.ObservabilityPlatform{}
+	if file == "" {
+		c, err := createClient()
+		if err != nil {
+			return nil, err
+		}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, platform); err != nil {
+			return nil, fmt.Errorf("failed to get platform %s/%s: %w", namespace, name, err)
+		}
+		return platform, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, platform); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	if platform.Kind != "ObservabilityPlatform" {
+		return nil, fmt.Errorf("%s is not an ObservabilityPlatform", file)
+	}
+	if platform.Namespace == "" {
+		platform.Namespace = namespace
+	}
+	return platform, nil
+}
+
+// overlays returns the overlays of the given names, the example overlays for
+// their names
+func overlays(names []string) []render.Overlay {
+	examples := map[string]render.Overlay{}
+	for _, overlay := range render.ExampleOverlays() {
+		examples[overlay.Name] = overlay
+	}
+
+	result := make([]render.Overlay, 0, len(names))
+	for _, name := range names {
+		overlay, ok := examples[name]
+		if !ok {
+			overlay = render.Overlay{Name: name}
+		}
+		result = append(result, overlay)
+	}
+	return result
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/sla"
+)
+
+// newReportCmd creates the report command
+func newReportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Generate reports about ObservabilityPlatform resources",
+	}
+
+	cmd.AddCommand(newReportSLACmd())
+
+	return cmd
+}
+
+// newReportSLACmd creates the report sla command
+func newReportSLACmd() *cobra.Command {
+	var (
+		month    string
+		platform string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sla",
+		Short: "Report monthly availability and downtime windows per platform",
+		Example: `  # Availability of all platforms in the monitoring namespace for July 2025
+  gunj report sla --month 2025-07 -n monitoring
+
+  # A single platform, as JSON
+  gunj report sla --month 2025-07 -n monitoring --platform production -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReportSLA(cmd.Context(), cmd.OutOrStdout(), month, platform)
+		},
+	}
+
+	cmd.Flags().StringVar(&month, "month", time.Now().UTC().Format(sla.MonthLayout), "Month to report on (YYYY-MM)")
+	cmd.Flags().StringVar(&platform, "platform", "", "Only report on the named platform")
+
+	return cmd
+}
+
+func runReportSLA(ctx context.Context, out io.Writer, month, platformName string) error {
+	if _, _, err := sla.ParseMonth(month); err != nil {
+		return err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	c, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	var platforms []observabilityv1beta1.ObservabilityPlatform
+	if platformName != "" {
+		platform := observabilityv1beta1.ObservabilityPlatform{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: platformName}, &platform); err != nil {
+			return fmt.Errorf("failed to get platform %s/%s: %w", namespace, platformName, err)
+		}
+		platforms = append(platforms, platform)
+	} else {
+		list := &observabilityv1beta1.ObservabilityPlatformList{}
+		if err := c.List(ctx, list, client.InNamespace(listNamespace())); err != nil {
+			return fmt.Errorf("failed to list platforms: %w", err)
+		}
+		platforms = list.Items
+	}
+
+	now := time.Now().UTC()
+	reports := make([]*sla.Report, 0, len(platforms))
+	for i := range platforms {
+		p := &platforms[i]
+		report, err := sla.BuildReport(p.Name, p.Namespace, sla.FromStatus(p.Status.Availability), month, now)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, report)
+	}
+
+	switch output {
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(reports)
+	case "table", "":
+		return printSLATable(out, reports, now)
+	default:
+		return fmt.Errorf("unsupported output format %q", output)
+	}
+}
+
+// printSLATable prints one row per component followed by its downtime windows
+func printSLATable(out io.Writer, reports []*sla.Report, now time.Time) error {
+	if len(reports) == 0 {
+		fmt.Fprintln(out, "No platforms found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tPLATFORM\tCOMPONENT\tAVAILABILITY\tCOVERAGE\tDOWNTIME\tINCIDENTS")
+	for _, report := range reports {
+		if len(report.Components) == 0 {
+			fmt.Fprintf(w, "%s\t%s\t-\t-\t-\t-\t-\n", report.Namespace, report.Platform)
+			continue
+		}
+		for _, component := range report.Components {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%.3f%%\t%.1f%%\t%s\t%d\n",
+				report.Namespace,
+				report.Platform,
+				component.Component,
+				component.Availability,
+				component.Coverage,
+				component.Downtime.Round(time.Second),
+				len(component.Windows),
+			)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// Downtime windows
+	printedHeader := false
+	for _, report := range reports {
+		for _, component := range report.Components {
+			for _, window := range component.Windows {
+				if !printedHeader {
+					fmt.Fprintln(out, "\nDowntime windows:")
+					printedHeader = true
+				}
+				end := "ongoing"
+				if !window.Open() {
+					end = window.End.Format(time.RFC3339)
+				}
+				fmt.Fprintf(out, "  %s/%s %s: %s - %s (%s)\n",
+					report.Namespace, report.Platform, component.Component,
+					window.Start.Format(time.RFC3339), end,
+					window.Duration(now).Round(time.Second))
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/timeline"
+)
+
+// newTimelineCmd creates the timeline command
+func newTimelineCmd() *cobra.Command {
+	var (
+		since     time.Duration
+		kinds     string
+		component string
+		limit     int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "timeline PLATFORM",
+		Short: "Show the events, condition changes, upgrades, migrations and backups of a platform",
+		Long: `timeline merges the Kubernetes events reported on a platform and on the
+objects managed for it with the condition transitions, upgrades, storage
+migrations and snapshots recorded in its status, oldest first. Status entries
+outlive the events, which the API server expires after an hour by default.`,
+		Example: `  # Everything that happened to a platform
+  gunj timeline production -n monitoring
+
+  # Upgrades and migrations of Loki in the last day
+  gunj timeline production -n monitoring --since 24h --kind upgrade,migration --component loki`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := timeline.Options{Component: component, Limit: limit}
+			if since > 0 {
+				opts.Since = time.Now().Add(-since)
+			}
+			var err error
+			if opts.Kinds, err = timeline.ParseKinds(kinds); err != nil {
+				return err
+			}
+			return runTimeline(cmd.Context(), cmd.OutOrStdout(), args[0], opts)
+		},
+	}
+
+	cmd.Flags().DurationVar(&since, "since", 0, "Only show entries newer than this duration, e.g. 1h")
+	cmd.Flags().StringVar(&kinds, "kind", "", "Only show these kinds (event, condition, upgrade, migration, backup), comma-separated")
+	cmd.Flags().StringVar(&component, "component", "", "Only show the entries of a component")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Only show the newest entries")
+
+	return cmd
+}
+
+func runTimeline(ctx context.Context, out io.Writer, platformName string, opts timeline.Options) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	c, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	platform := &observabilityv1beta1.ObservabilityPlatform{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: platformName}, platform); err != nil {
+		return fmt.Errorf("failed to get platform %s/%s: %w", namespace, platformName, err)
+	}
+
+	t, err := timeline.Collect(ctx, c, platform, opts)
+	if err != nil {
+		return err
+	}
+
+	switch output {
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(t)
+	case "table", "":
+		return printTimelineTable(out, t)
+	default:
+		return fmt.Errorf("unsupported output format %q", output)
+	}
+}
+
+// printTimelineTable prints one row per entry, marking warnings
+func printTimelineTable(out io.Writer, t *timeline.Timeline) error {
+	if len(t.Entries) == 0 {
+		fmt.Fprintln(out, "No entries found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tKIND\tCOMPONENT\tREASON\tMESSAGE")
+	for _, entry := range t.Entries {
+		reason := entry.Reason
+		if entry.Warning {
+			reason = "! " + reason
+		}
+		component := entry.Component
+		if component == "" {
+			component = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			entry.Time.Local().Format(time.RFC3339),
+			entry.Kind,
+			component,
+			reason,
+			entry.Message,
+		)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025 Gunjan Patil.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion/redaction"
+	"github.com/gunjanjp/gunj-operator/pkg/envdiff"
+)
+
+// newDiffCmd creates the diff command
+func newDiffCmd() *cobra.Command {
+	var (
+		sourceContext   string
+		targetContext   string
+		targetNamespace string
+		targetName      string
+		ignore          []string
+		summary         bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff [platform]",
+		Short: "Compare an ObservabilityPlatform between two clusters or environments",
+		Long: `Compare two live ObservabilityPlatforms and report their drift: the settings
+of their specs and the configuration rendered for them by the operator. The
+source platform is read with --source-context, the target with
+--target-context; both default to the current context, so two platforms of
+one cluster can be compared with --target-namespace or --target-name.
+
+Settings expected to differ between environments are left out with --ignore,
+e.g. --ignore global.externalLabels. The command fails when the platforms
+differ, so it can gate promotions in scripts.`,
+		Example: `  gunj-migrate diff --source-context prod --target-context staging my-platform -n monitoring`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, err := createClientForContext(sourceContext)
+			if err != nil {
+				return fmt.Errorf("failed to create client for the source: %w", err)
+			}
+			target, err := createClientForContext(targetContext)
+			if err != nil {
+				return fmt.Errorf("failed to create client for the target: %w", err)
+			}
+			if targetNamespace == "" {
+				targetNamespace = namespace
+			}
+			if targetName == "" {
+				targetName = args[0]
+			}
+			return runDiff(cmd.Context(), cmd.OutOrStdout(), source, target, args[0], targetNamespace, targetName, ignore, summary)
+		},
+	}
+
+	cmd.Flags().StringVar(&sourceContext, "source-context", "", "Kubeconfig context of the source platform (default: current context)")
+	cmd.Flags().StringVar(&targetContext, "target-context", "", "Kubeconfig context of the target platform (default: current context)")
+	cmd.Flags().StringVar(&targetNamespace, "target-namespace", "", "Namespace of the target platform (default: --namespace)")
+	cmd.Flags().StringVar(&targetName, "target-name", "", "Name of the target platform (default: the source's name)")
+	cmd.Flags().StringSliceVar(&ignore, "ignore", nil, "Spec paths expected to differ, e.g. global.externalLabels")
+	cmd.Flags().BoolVar(&summary, "summary", false, "List the differing configuration files without their lines")
+
+	return cmd
+}
+
+// runDiff compares a platform of the source with a platform of the target
+func runDiff(ctx context.Context, out io.Writer, source, target client.Client, sourceName, targetNamespace, targetName string, ignore []string, summary bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	from, err := envdiff.Capture(ctx, source, namespace, sourceName)
+	if err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+	to, err := envdiff.Capture(ctx, target, targetNamespace, targetName)
+	if err != nil {
+		return fmt.Errorf("target: %w", err)
+	}
+
+	report := envdiff.Compare(from, to, ignore)
+	printDiffReport(out, report, summary)
+	if !report.InSync() {
+		return fmt.Errorf("%s and %s differ in %d settings and files", report.Source, report.Target, report.Differences())
+	}
+	return nil
+}
+
+// printDiffReport prints a report with its sensitive values redacted
+func printDiffReport(out io.Writer, report *envdiff.Report, summary bool) {
+	redactor := redaction.Default()
+	fmt.Fprintf(out, "--- source %s\n+++ target %s\n", report.Source, report.Target)
+	if report.InSync() {
+		fmt.Fprintln(out, "\nNo drift")
+		return
+	}
+
+	if len(report.Spec) > 0 {
+		fmt.Fprintln(out, "\nSpec:")
+		for _, change := range report.Spec {
+			fmt.Fprintf(out, "  %s: %s → %s\n", change.Path, redactor.Value(change.Path, change.Old), redactor.Value(change.Path, change.New))
+		}
+	}
+
+	if len(report.Config) > 0 {
+		fmt.Fprintln(out, "\nRendered configuration:")
+		for _, drift := range report.Config {
+			if drift.OnlyIn != "" {
+				fmt.Fprintf(out, "  %s: only in %s\n", drift.Path, drift.OnlyIn)
+				continue
+			}
+			fmt.Fprintf(out, "  %s: differs\n", drift.Path)
+			if summary {
+				continue
+			}
+			for _, line := range drift.Removed {
+				fmt.Fprintf(out, "    - %s\n", redactor.Text(line))
+			}
+			for _, line := range drift.Added {
+				fmt.Fprintf(out, "    + %s\n", redactor.Text(line))
+			}
+		}
+	}
+}
+
+// createClientForContext creates a client for a context of the kubeconfig,
+// the current context if empty
+func createClientForContext(kubeContext string) (client.Client, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig != "" {
+		loadingRules.ExplicitPath = kubeconfig
+	}
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: kubeContext}
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.New(config, client.Options{
+		Scheme: scheme.Scheme,
+	})
+}
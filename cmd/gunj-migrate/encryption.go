@@ -0,0 +1,169 @@
+/*
+Copyright 2025 Gunjan Patil.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion"
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion/encryption"
+	"github.com/gunjanjp/gunj-operator/pkg/envdiff"
+)
+
+// encryptionConfigured reports whether the keys of encrypted conversion data
+// are given, by --keyring-secret or --vault-transit-key
+func encryptionConfigured() bool {
+	return keyringSecret != "" || vaultTransitKey != ""
+}
+
+// configureEncryption reads encrypted conversion data with the keyring
+// Secret of --keyring-secret or the Vault transit key of
+// --vault-transit-key, if set
+func configureEncryption(c client.Reader) error {
+	switch {
+	case keyringSecret != "" && vaultTransitKey != "":
+		return fmt.Errorf("--keyring-secret and --vault-transit-key are mutually exclusive")
+	case keyringSecret != "":
+		secret, err := parseNamespacedName(keyringSecret)
+		if err != nil {
+			return fmt.Errorf("invalid --keyring-secret: %w", err)
+		}
+		conversion.SetAnnotationEncryption(encryption.NewEnvelope(&encryption.SecretKeyring{Reader: c, Secret: secret}))
+	case vaultTransitKey != "":
+		mount, key, err := encryption.ParseVaultTransitKey(vaultTransitKey)
+		if err != nil {
+			return fmt.Errorf("invalid --vault-transit-key: %w", err)
+		}
+		if vaultAddress == "" {
+			return fmt.Errorf("--vault-address or $VAULT_ADDR is required with --vault-transit-key")
+		}
+		wrapper := &encryption.VaultTransit{Address: vaultAddress, Mount: mount, Key: key, TokenFile: vaultTokenFile}
+		if vaultTokenFile == "" {
+			if wrapper.Token = os.Getenv("VAULT_TOKEN"); wrapper.Token == "" {
+				return fmt.Errorf("--vault-token-file or $VAULT_TOKEN is required with --vault-transit-key")
+			}
+		}
+		conversion.SetAnnotationEncryption(encryption.NewEnvelope(wrapper))
+	}
+	return nil
+}
+
+// parseNamespacedName parses a namespace/name reference
+func parseNamespacedName(value string) (types.NamespacedName, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return types.NamespacedName{}, fmt.Errorf("%q is not namespace/name", value)
+	}
+	return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, nil
+}
+
+// newRewrapCmd creates the rewrap command
+func newRewrapCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "rewrap [resource-name]",
+		Short: "Re-encrypt preserved conversion data with the current key",
+		Long: `Wrap the data keys of the conversion data preserved in ObservabilityPlatform
+annotations with the primary key of the keyring Secret of --keyring-secret,
+or the latest version of the Vault transit key of --vault-transit-key. Data
+preserved in plaintext is encrypted. Without a resource name, every platform
+of the namespace is rewrapped.
+
+Run it after adding a key to the keyring Secret, or rotating the transit key
+in Vault; the old key can be removed, or its versions made undecryptable,
+once no platform uses it.`,
+		Example: `  gunj-migrate rewrap --keyring-secret gunj-system/conversion-keys -n monitoring
+  VAULT_ADDR=https://vault.example.com:8200 gunj-migrate rewrap --vault-transit-key transit/gunj-conversion -n monitoring`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !encryptionConfigured() {
+				return fmt.Errorf("--keyring-secret or --vault-transit-key is required")
+			}
+			c, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			if err := configureEncryption(c); err != nil {
+				return err
+			}
+			name := ""
+			if len(args) == 1 {
+				name = args[0]
+			}
+			return runRewrap(cmd.Context(), cmd.OutOrStdout(), c, name, dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report the platforms to rewrap without updating them")
+
+	return cmd
+}
+
+// runRewrap rewraps the preserved data of one or every platform of the
+// namespace
+func runRewrap(ctx context.Context, out io.Writer, c client.Client, name string, dryRun bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var platforms []*unstructured.Unstructured
+	if name != "" {
+		platform := &unstructured.Unstructured{}
+		platform.SetGroupVersionKind(envdiff.PlatformGVK)
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, platform); err != nil {
+			return fmt.Errorf("failed to get %s/%s: %w", namespace, name, err)
+		}
+		platforms = append(platforms, platform)
+	} else {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(envdiff.PlatformGVK.GroupVersion().WithKind(envdiff.PlatformGVK.Kind + "List"))
+		if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			return fmt.Errorf("failed to list platforms: %w", err)
+		}
+		for i := range list.Items {
+			platforms = append(platforms, &list.Items[i])
+		}
+	}
+
+	rewrapped, failed := 0, 0
+	for _, platform := range platforms {
+		changed, err := conversion.RewrapPreservedData(ctx, platform)
+		if err != nil {
+			fmt.Fprintf(out, "%s/%s: %v\n", platform.GetNamespace(), platform.GetName(), err)
+			failed++
+			continue
+		}
+		if !changed {
+			continue
+		}
+		if !dryRun {
+			if err := c.Update(ctx, platform); err != nil {
+				fmt.Fprintf(out, "%s/%s: failed to update: %v\n", platform.GetNamespace(), platform.GetName(), err)
+				failed++
+				continue
+			}
+		}
+		fmt.Fprintf(out, "%s/%s: rewrapped\n", platform.GetNamespace(), platform.GetName())
+		rewrapped++
+	}
+
+	fmt.Fprintf(out, "\n%d of %d platforms rewrapped\n", rewrapped, len(platforms))
+	if failed > 0 {
+		return fmt.Errorf("%d platforms could not be rewrapped", failed)
+	}
+	return nil
+}
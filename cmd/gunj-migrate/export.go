@@ -0,0 +1,151 @@
+/*
+Copyright 2025 Gunjan Patil.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion"
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion/encryption"
+	"github.com/gunjanjp/gunj-operator/pkg/bundle"
+	"github.com/gunjanjp/gunj-operator/pkg/envdiff"
+)
+
+// newExportCmd creates the export command
+func newExportCmd() *cobra.Command {
+	var (
+		allNamespaces bool
+		source        string
+		outputFile    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export [resource-name...]",
+		Short: "Export ObservabilityPlatforms to a bundle for another cluster",
+		Long: `Export ObservabilityPlatforms to a bundle that gunj-migrate import re-creates
+in another cluster. Without resource names, every platform of the namespace
+is exported.
+
+Platforms are exported without their status and cluster-specific metadata.
+The conversion data preserved in their annotations is kept; encrypted data
+is decrypted with the keyring Secret of --keyring-secret or the Vault
+transit key of --vault-transit-key, so the target cluster does not need the
+same keys. The Secrets and ConfigMaps the platforms reference are listed in
+the bundle but not exported: create them in the target cluster before or
+after the import.`,
+		Example: `  gunj-migrate export -n monitoring --source prod-eu -o platforms.yaml
+  gunj-migrate export --all-namespaces --keyring-secret gunj-system/conversion-keys -o platforms.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			if err := configureEncryption(c); err != nil {
+				return err
+			}
+			return runExport(cmd.Context(), cmd.OutOrStdout(), c, args, allNamespaces, source, outputFile)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Export the platforms of every namespace")
+	cmd.Flags().StringVar(&source, "source", "", "Name of the source cluster, recorded in the bundle")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write the bundle to this file instead of stdout")
+
+	return cmd
+}
+
+// runExport exports platforms to a bundle
+func runExport(ctx context.Context, out io.Writer, c client.Client, names []string, allNamespaces bool, source, outputFile string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if allNamespaces && len(names) > 0 {
+		return fmt.Errorf("resource names cannot be used with --all-namespaces")
+	}
+
+	var platforms []unstructured.Unstructured
+	if len(names) > 0 {
+		for _, name := range names {
+			platform := unstructured.Unstructured{}
+			platform.SetGroupVersionKind(envdiff.PlatformGVK)
+			if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &platform); err != nil {
+				return fmt.Errorf("failed to get %s/%s: %w", namespace, name, err)
+			}
+			platforms = append(platforms, platform)
+		}
+	} else {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(envdiff.PlatformGVK.GroupVersion().WithKind(envdiff.PlatformGVK.Kind + "List"))
+		var opts []client.ListOption
+		if !allNamespaces {
+			opts = append(opts, client.InNamespace(namespace))
+		}
+		if err := c.List(ctx, list, opts...); err != nil {
+			return fmt.Errorf("failed to list platforms: %w", err)
+		}
+		platforms = list.Items
+	}
+	if len(platforms) == 0 {
+		return fmt.Errorf("no platforms to export")
+	}
+
+	for i := range platforms {
+		if err := decryptPreservedData(ctx, &platforms[i]); err != nil {
+			return err
+		}
+	}
+
+	b := bundle.New(source, platforms, time.Now())
+	if outputFile == "" {
+		return b.Write(out)
+	}
+
+	f, err := os.Create(outputFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := b.Write(f); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%d platforms written to %s\n", len(b.Platforms), outputFile)
+	for _, ref := range b.References {
+		fmt.Fprintf(os.Stderr, "  needs %s\n", ref)
+	}
+	return nil
+}
+
+// decryptPreservedData replaces the encrypted conversion data of a platform
+// with its plaintext when a keyring or transit key is configured. Without
+// one, the data stays encrypted and the target cluster needs the same keys.
+func decryptPreservedData(ctx context.Context, platform *unstructured.Unstructured) error {
+	annotations := platform.GetAnnotations()
+	value, ok := annotations[conversion.ConversionDataAnnotation]
+	if !ok || !encryption.Encrypted(value) {
+		return nil
+	}
+	if !encryptionConfigured() {
+		fmt.Fprintf(os.Stderr, "Warning: %s/%s keeps encrypted conversion data; the target cluster needs the same keys, or use --keyring-secret or --vault-transit-key\n",
+			platform.GetNamespace(), platform.GetName())
+		return nil
+	}
+	payload, err := conversion.DecodePreservedData(ctx, value)
+	if err != nil {
+		return fmt.Errorf("%s/%s: %w", platform.GetNamespace(), platform.GetName(), err)
+	}
+	annotations[conversion.ConversionDataAnnotation] = string(payload)
+	platform.SetAnnotations(annotations)
+	return nil
+}
@@ -0,0 +1,465 @@
+/*
+Copyright 2025 Gunjan Patil.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion"
+	"github.com/gunjanjp/gunj-operator/pkg/bundle"
+	"github.com/gunjanjp/gunj-operator/pkg/cliout"
+	"github.com/gunjanjp/gunj-operator/pkg/importer"
+)
+
+// newImportCmd creates the import command
+func newImportCmd() *cobra.Command {
+	var (
+		namespaceMap     []string
+		createNamespaces bool
+		dryRun           bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import [bundle]",
+		Short: "Re-create exported platforms, or generate them from other monitoring stacks",
+		Long: `Re-create the ObservabilityPlatforms of a bundle written by gunj-migrate export,
+- for stdin. Platforms move to the namespaces of --namespace-map, and so do
+the namespaces their specs refer to. Existing platforms are left alone. The
+Secrets and ConfigMaps the platforms reference are checked, and the missing
+ones are reported. With --keyring-secret or --vault-transit-key, the
+preserved conversion data is encrypted with the keys of the target cluster.
+
+The subcommands generate an ObservabilityPlatform from the configuration of an
+existing monitoring stack instead. Settings without an equivalent are
+reported on stderr.`,
+		Example: `  gunj-migrate import platforms.yaml --namespace-map monitoring=observability --dry-run
+  gunj-migrate import platforms.yaml --namespace-map monitoring=observability --create-namespaces`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+			c, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			if err := configureEncryption(c); err != nil {
+				return err
+			}
+			return runImportBundle(cmd.Context(), cmd.OutOrStdout(), c, args[0], namespaceMap, createNamespaces, dryRun)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&namespaceMap, "namespace-map", nil, "Move the platforms of a namespace to another, as source=target; repeatable")
+	cmd.Flags().BoolVar(&createNamespaces, "create-namespaces", false, "Create the target namespaces that don't exist")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would be imported without creating anything")
+
+	cmd.AddCommand(
+		newImportPrometheusOperatorCmd(),
+		newImportGrafanaCloudCmd(),
+	)
+
+	return cmd
+}
+
+// newImportPrometheusOperatorCmd creates the import prometheus-operator command
+func newImportPrometheusOperatorCmd() *cobra.Command {
+	var (
+		files       []string
+		valuesFile  string
+		fromCluster bool
+		name        string
+		outputFile  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "prometheus-operator",
+		Short: "Import a prometheus-operator or kube-prometheus-stack installation",
+		Long: `Import the Prometheus and Alertmanager resources of a prometheus-operator
+installation and the Helm values of a kube-prometheus-stack release.
+Resources are read from files with --filename or from the namespace with
+--from-cluster; Grafana is imported from the values.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImportPrometheusOperator(cmd.OutOrStdout(), files, valuesFile, fromCluster, name, outputFile)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&files, "filename", "f", nil, "Files or directories with Prometheus and Alertmanager resources, - for stdin")
+	cmd.Flags().StringVar(&valuesFile, "values", "", "Helm values of a kube-prometheus-stack release")
+	cmd.Flags().BoolVar(&fromCluster, "from-cluster", false, "Read Prometheus and Alertmanager resources from the namespace")
+	cmd.Flags().StringVar(&name, "name", "", "Name of the generated platform (required)")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write the platform to this file instead of stdout")
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+// runImportPrometheusOperator imports a prometheus-operator installation
+func runImportPrometheusOperator(out io.Writer, files []string, valuesFile string, fromCluster bool, name, outputFile string) error {
+	sources := importer.PrometheusOperatorSources{}
+
+	paths, err := manifestFiles(files)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		objects, err := readImportObjects(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		sources.Objects = append(sources.Objects, objects...)
+	}
+
+	if fromCluster {
+		objects, err := listPrometheusOperatorResources()
+		if err != nil {
+			return err
+		}
+		sources.Objects = append(sources.Objects, objects...)
+	}
+
+	if valuesFile != "" {
+		if sources.Values, err = readImportValues(valuesFile); err != nil {
+			return err
+		}
+	}
+
+	if len(sources.Objects) == 0 && sources.Values == nil {
+		return fmt.Errorf("nothing to import: use --filename, --values or --from-cluster")
+	}
+
+	result := importer.ImportPrometheusOperator(name, namespace, sources)
+	return writeImport(out, result, outputFile)
+}
+
+// newImportGrafanaCloudCmd creates the import grafana-cloud command
+func newImportGrafanaCloudCmd() *cobra.Command {
+	var (
+		agentFile          string
+		mimirFile          string
+		mimirOverridesFile string
+		lokiFile           string
+		lokiOverridesFile  string
+		ruleFiles          []string
+		name               string
+		outputFile         string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "grafana-cloud",
+		Short: "Import Grafana Agent, Mimir and Loki configurations",
+		Long: `Import the configurations of a Grafana Cloud or self-managed Mimir and Loki
+setup: remote writes and scrape configs of Grafana Agent or Prometheus,
+retention, Loki tenancy and limits, and rule files as exported with
+mimirtool rules print. Loki tenancy is written as a LokiConfig fragment
+after the platform.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sources := importer.GrafanaCloudSources{}
+			for _, config := range []struct {
+				file   string
+				values *map[string]interface{}
+			}{
+				{agentFile, &sources.Agent},
+				{mimirFile, &sources.Mimir},
+				{mimirOverridesFile, &sources.MimirOverrides},
+				{lokiFile, &sources.Loki},
+				{lokiOverridesFile, &sources.LokiOverrides},
+			} {
+				if config.file == "" {
+					continue
+				}
+				var err error
+				if *config.values, err = readImportValues(config.file); err != nil {
+					return err
+				}
+			}
+
+			paths, err := manifestFiles(ruleFiles)
+			if err != nil {
+				return err
+			}
+			if len(paths) > 0 {
+				sources.Rules = make(map[string]map[string]interface{}, len(paths))
+			}
+			for _, path := range paths {
+				if sources.Rules[path], err = readImportValues(path); err != nil {
+					return err
+				}
+			}
+
+			if sources.Agent == nil && sources.Mimir == nil && sources.MimirOverrides == nil &&
+				sources.Loki == nil && sources.LokiOverrides == nil && len(sources.Rules) == 0 {
+				return fmt.Errorf("nothing to import: use --agent, --mimir, --loki or --rules")
+			}
+
+			result := importer.ImportGrafanaCloud(name, namespace, sources)
+			return writeImport(cmd.OutOrStdout(), result, outputFile)
+		},
+	}
+
+	cmd.Flags().StringVar(&agentFile, "agent", "", "Grafana Agent (static mode) or Prometheus configuration")
+	cmd.Flags().StringVar(&mimirFile, "mimir", "", "Mimir configuration")
+	cmd.Flags().StringVar(&mimirOverridesFile, "mimir-overrides", "", "Mimir runtime configuration with tenant overrides")
+	cmd.Flags().StringVar(&lokiFile, "loki", "", "Loki configuration")
+	cmd.Flags().StringVar(&lokiOverridesFile, "loki-overrides", "", "Loki runtime configuration with tenant overrides")
+	cmd.Flags().StringSliceVar(&ruleFiles, "rules", nil, "Rule files or directories")
+	cmd.Flags().StringVar(&name, "name", "", "Name of the generated platform (required)")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write the platform to this file instead of stdout")
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+// readImportValues reads a configuration file, - for stdin
+func readImportValues(path string) (map[string]interface{}, error) {
+	r := io.Reader(os.Stdin)
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	values, err := importer.ReadValues(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// readImportObjects reads the objects of a file, - for stdin
+func readImportObjects(path string) ([]*unstructured.Unstructured, error) {
+	if path == "-" {
+		return importer.ReadObjects(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return importer.ReadObjects(f)
+}
+
+// listPrometheusOperatorResources lists the Prometheus and Alertmanager
+// resources of the namespace
+func listPrometheusOperatorResources() ([]*unstructured.Unstructured, error) {
+	c, err := createClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var objects []*unstructured.Unstructured
+	for _, kind := range []string{"PrometheusList", "AlertmanagerList"} {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: kind})
+		if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", kind, err)
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	}
+	return objects, nil
+}
+
+// writeImport writes an imported platform and reports its unsupported settings
+func writeImport(out io.Writer, result *importer.Result, outputFile string) error {
+	data, err := result.YAML()
+	if err != nil {
+		return fmt.Errorf("failed to marshal platform: %w", err)
+	}
+
+	if outputFile != "" {
+		if err := os.WriteFile(outputFile, data, 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Platform written to %s\n", outputFile)
+	} else {
+		out.Write(data)
+	}
+
+	if len(result.Unsupported) > 0 {
+		fmt.Fprintf(os.Stderr, "\n%d setting(s) were not imported:\n", len(result.Unsupported))
+		for _, unsupported := range result.Unsupported {
+			fmt.Fprintf(os.Stderr, "  - %s\n", unsupported)
+		}
+	}
+	return nil
+}
+
+// importResult is the outcome of the import of a platform
+type importResult struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Action is created, exists, or failed; with --dry-run, create
+	Action string `json:"action"`
+	Error  string `json:"error,omitempty"`
+}
+
+// importReport is the outcome of the import of a bundle
+type importReport struct {
+	Platforms         []importResult     `json:"platforms"`
+	MissingReferences []bundle.Reference `json:"missingReferences,omitempty"`
+}
+
+// runImportBundle re-creates the platforms of a bundle in the cluster,
+// moved to the namespaces of the mapping
+func runImportBundle(ctx context.Context, out io.Writer, c client.Client, path string, mappings []string, createNamespaces, dryRun bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	mapping, err := bundle.ParseNamespaceMap(mappings)
+	if err != nil {
+		return fmt.Errorf("invalid --namespace-map: %w", err)
+	}
+
+	r := io.Reader(os.Stdin)
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	b, err := bundle.Read(r)
+	if err != nil {
+		return err
+	}
+	b.Remap(mapping)
+
+	report := importReport{}
+	for _, ref := range b.References {
+		missing, err := referenceMissing(ctx, c, ref)
+		if err != nil {
+			return err
+		}
+		if missing && !ref.Optional {
+			report.MissingReferences = append(report.MissingReferences, ref)
+		}
+	}
+
+	failed := 0
+	for i := range b.Platforms {
+		result := importPlatform(ctx, c, &b.Platforms[i], createNamespaces, dryRun)
+		if result.Action == "failed" {
+			failed++
+		}
+		report.Platforms = append(report.Platforms, result)
+	}
+
+	if err := cliout.Write(out, outputFormat, report, func(w io.Writer) error {
+		for _, result := range report.Platforms {
+			if result.Error != "" {
+				fmt.Fprintf(w, "%s/%s: %s: %s\n", result.Namespace, result.Name, result.Action, result.Error)
+				continue
+			}
+			fmt.Fprintf(w, "%s/%s: %s\n", result.Namespace, result.Name, result.Action)
+		}
+		if len(report.MissingReferences) > 0 {
+			fmt.Fprintf(w, "\n%d referenced objects are missing; create them for the platforms to reconcile:\n", len(report.MissingReferences))
+			for _, ref := range report.MissingReferences {
+				fmt.Fprintf(w, "  %s, used by %v\n", ref, ref.UsedBy)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d platforms could not be imported", failed)
+	}
+	return nil
+}
+
+// importPlatform creates a platform of a bundle, and its namespace if
+// createNamespaces. Existing platforms are left alone.
+func importPlatform(ctx context.Context, c client.Client, platform *unstructured.Unstructured, createNamespaces, dryRun bool) importResult {
+	result := importResult{Namespace: platform.GetNamespace(), Name: platform.GetName()}
+	fail := func(err error) importResult {
+		result.Action = "failed"
+		result.Error = err.Error()
+		return result
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(platform.GroupVersionKind())
+	err := c.Get(ctx, client.ObjectKeyFromObject(platform), existing)
+	switch {
+	case err == nil:
+		result.Action = "exists"
+		return result
+	case !errors.IsNotFound(err):
+		return fail(err)
+	}
+
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: platform.GetNamespace()}, ns); err != nil {
+		if !errors.IsNotFound(err) {
+			return fail(err)
+		}
+		if !createNamespaces {
+			return fail(fmt.Errorf("namespace %s not found, use --create-namespaces or --namespace-map", platform.GetNamespace()))
+		}
+		if !dryRun {
+			ns = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: platform.GetNamespace()}}
+			if err := c.Create(ctx, ns); err != nil && !errors.IsAlreadyExists(err) {
+				return fail(fmt.Errorf("failed to create namespace: %w", err))
+			}
+		}
+	}
+
+	if encryptionConfigured() {
+		// Encrypt the conversion data with the keys of this cluster
+		if _, err := conversion.RewrapPreservedData(ctx, platform); err != nil {
+			return fail(err)
+		}
+	}
+
+	if dryRun {
+		result.Action = "create"
+		return result
+	}
+	if err := c.Create(ctx, platform); err != nil {
+		return fail(err)
+	}
+	result.Action = "created"
+	return result
+}
+
+// referenceMissing reports whether a referenced Secret or ConfigMap is
+// missing from the cluster
+func referenceMissing(ctx context.Context, c client.Client, ref bundle.Reference) (bool, error) {
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(ref.Kind))
+	err := c.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, obj)
+	if errors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get %s: %w", ref, err)
+	}
+	return false, nil
+}
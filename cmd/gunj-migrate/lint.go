@@ -0,0 +1,155 @@
+/*
+Copyright 2025 Gunjan Patil.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gunjanjp/gunj-operator/pkg/lint"
+)
+
+// newLintCmd creates the lint command
+func newLintCmd() *cobra.Command {
+	var (
+		format   string
+		failOn   string
+		minLevel string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "lint [file|dir|-]...",
+		Short: "Lint ObservabilityPlatform manifests offline",
+		Long: `Lint ObservabilityPlatform manifests with the validation of the admission
+webhook, without a cluster. Directories are searched for *.yaml and *.yml
+files; "-" reads standard input. The command fails if a finding is at least
+as severe as --fail-on, so it can run in pre-commit hooks and CI.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLint(cmd.OutOrStdout(), args, format, failOn, minLevel)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "output", "o", "text", "Output format (text, json)")
+	cmd.Flags().StringVar(&failOn, "fail-on", "error", "Fail on findings of this severity or higher (error, warning, info)")
+	cmd.Flags().StringVar(&minLevel, "min-severity", "info", "Report findings of this severity or higher (error, warning, info)")
+
+	return cmd
+}
+
+// runLint lints the manifests of paths
+func runLint(out io.Writer, paths []string, format, failOn, minLevel string) error {
+	failSeverity, err := lint.ParseSeverity(failOn)
+	if err != nil {
+		return err
+	}
+	minSeverity, err := lint.ParseSeverity(minLevel)
+	if err != nil {
+		return err
+	}
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+
+	files, err := manifestFiles(paths)
+	if err != nil {
+		return err
+	}
+
+	linter := lint.New()
+	findings := []lint.Finding{}
+	for _, file := range files {
+		var fileFindings []lint.Finding
+		if file == "-" {
+			fileFindings, err = linter.Lint("<stdin>", os.Stdin)
+		} else {
+			fileFindings, err = lintFile(linter, file)
+		}
+		if err != nil {
+			return err
+		}
+		for _, finding := range fileFindings {
+			if finding.Severity.AtLeast(minSeverity) {
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	failed := 0
+	for _, finding := range findings {
+		if finding.Severity.AtLeast(failSeverity) {
+			failed++
+		}
+	}
+
+	if format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(findings); err != nil {
+			return err
+		}
+	} else {
+		for _, finding := range findings {
+			fmt.Fprintln(out, finding)
+		}
+		fmt.Fprintf(out, "%d file(s) linted, %d finding(s)\n", len(files), len(findings))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d finding(s) at or above severity %s", failed, failSeverity)
+	}
+	return nil
+}
+
+// lintFile lints a manifest file
+func lintFile(linter *lint.Linter, file string) ([]lint.Finding, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return linter.Lint(file, f)
+}
+
+// manifestFiles expands directories into the YAML files below them
+func manifestFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		if path == "-" {
+			files = append(files, path)
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(name string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.IsDir() && (strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")) {
+				files = append(files, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
@@ -10,13 +10,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion"
 	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion/preservation"
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion/redaction"
 	"github.com/gunjanjp/gunj-operator/api/v1beta1/migration"
+	"github.com/gunjanjp/gunj-operator/pkg/cliout"
 	"github.com/gunjanjp/gunj-operator/pkg/conversion"
+	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -29,6 +36,12 @@ var (
 	kubeconfig string
 	namespace  string
 	verbose    bool
+
+	keyringSecret   string
+	vaultAddress    string
+	vaultTransitKey string
+	vaultTokenFile  string
+	outputFormat    string
 )
 
 func main() {
@@ -36,13 +49,31 @@ func main() {
 		Use:   "gunj-migrate",
 		Short: "Gunj Operator migration and data preservation tool",
 		Long: `A command-line tool for managing ObservabilityPlatform migrations,
-data preservation, and conversion operations.`,
+data preservation, and conversion operations.
+
+Exit codes:
+  0  success
+  1  error, or migration failed for all resources
+  2  migration failed for some of the resources
+  3  resources failed the migration checks or validation
+  4  migration rolled back
+
+Completion scripts for bash, zsh, fish and powershell are printed by
+gunj-migrate completion, see gunj-migrate completion --help.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return cliout.Validate(outputFormat)
+		},
 	}
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: $KUBECONFIG or ~/.kube/config)")
 	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "default", "Kubernetes namespace")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging")
+	rootCmd.PersistentFlags().StringVar(&keyringSecret, "keyring-secret", "", "namespace/name of the Secret with the keys of encrypted conversion data")
+	rootCmd.PersistentFlags().StringVar(&vaultTransitKey, "vault-transit-key", "", "Vault transit key wrapping the keys of encrypted conversion data, as mount/key")
+	rootCmd.PersistentFlags().StringVar(&vaultAddress, "vault-address", os.Getenv("VAULT_ADDR"), "Address of Vault for --vault-transit-key")
+	rootCmd.PersistentFlags().StringVar(&vaultTokenFile, "vault-token-file", "", "File with the Vault token for --vault-transit-key (default: $VAULT_TOKEN)")
+	cliout.AddFlag(rootCmd, &outputFormat)
 
 	// Add subcommands
 	rootCmd.AddCommand(
@@ -53,11 +84,18 @@ data preservation, and conversion operations.`,
 		newSchemaCmd(),
 		newStatusCmd(),
 		newOptimizeCmd(),
+		newLintCmd(),
+		newImportCmd(),
+		newExportCmd(),
+		newUnstickCmd(),
+		newDiffCmd(),
+		newRewrapCmd(),
+		newStorageMigrateCmd(),
 	)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(cliout.ExitCode(err))
 	}
 }
 
@@ -69,14 +107,20 @@ func newMigrateCmd() *cobra.Command {
 		dryRun        bool
 		force         bool
 		backup        bool
+		checkpoint    checkpointOptions
 	)
 
 	cmd := &cobra.Command{
 		Use:   "migrate [resource-name]",
 		Short: "Migrate ObservabilityPlatform resources between API versions",
-		Args:  cobra.ExactArgs(1),
+		Long: `Migrate an ObservabilityPlatform resource between API versions.
+
+Migrations unfinished when the tool is interrupted are checkpointed to the
+--checkpoint-namespace, and resumed by the next run before the resource is
+migrated.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runMigrate(args[0], sourceVersion, targetVersion, dryRun, force, backup)
+			return runMigrate(args[0], sourceVersion, targetVersion, dryRun, force, backup, checkpoint)
 		},
 	}
 
@@ -85,10 +129,18 @@ func newMigrateCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Perform a dry-run migration")
 	cmd.Flags().BoolVar(&force, "force", false, "Force migration even with warnings")
 	cmd.Flags().BoolVar(&backup, "backup", true, "Create backup before migration")
+	cmd.Flags().StringVar(&checkpoint.namespace, "checkpoint-namespace", "gunj-system", "Namespace the migrations unfinished when interrupted are checkpointed to, and resumed from by the next run (empty to not checkpoint them)")
+	cmd.Flags().DurationVar(&checkpoint.drainTimeout, "drain-timeout", 30*time.Second, "How long the running migrations may take to finish when interrupted before they are checkpointed")
 
 	return cmd
 }
 
+// checkpointOptions configure the checkpoint of interrupted migrations
+type checkpointOptions struct {
+	namespace    string
+	drainTimeout time.Duration
+}
+
 // newPreserveCmd creates the preserve command
 func newPreserveCmd() *cobra.Command {
 	var (
@@ -129,11 +181,10 @@ func newRestoreCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&inputFile, "input", "i", "", "Input file with preserved data (required)")
+	cmd.Flags().StringVarP(&inputFile, "input", "i", "", "Input file with preserved data (default: the target's conversion data annotation)")
 	cmd.Flags().StringVar(&targetResource, "target", "", "Target resource name (required)")
 	cmd.Flags().BoolVar(&verify, "verify", true, "Verify data integrity after restoration")
 
-	cmd.MarkFlagRequired("input")
 	cmd.MarkFlagRequired("target")
 
 	return cmd
@@ -173,6 +224,7 @@ func newSchemaCmd() *cobra.Command {
 		newSchemaListCmd(),
 		newSchemaPathCmd(),
 		newSchemaHistoryCmd(),
+		newSchemaDiffCmd(),
 	)
 
 	return cmd
@@ -184,7 +236,7 @@ func newSchemaListCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List available schema versions",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runSchemaList()
+			return runSchemaList(cmd.OutOrStdout())
 		},
 	}
 }
@@ -200,7 +252,7 @@ func newSchemaPathCmd() *cobra.Command {
 		Use:   "path",
 		Short: "Show migration path between versions",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runSchemaPath(from, to)
+			return runSchemaPath(cmd.OutOrStdout(), from, to)
 		},
 	}
 
@@ -234,7 +286,7 @@ func newStatusCmd() *cobra.Command {
 		Short: "Show migration status of ObservabilityPlatform resource",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runStatus(args[0])
+			return runStatus(cmd.OutOrStdout(), args[0])
 		},
 	}
 }
@@ -263,9 +315,9 @@ func newOptimizeCmd() *cobra.Command {
 
 // Implementation functions
 
-func runMigrate(resourceName, sourceVersion, targetVersion string, dryRun, force, backup bool) error {
+func runMigrate(resourceName, sourceVersion, targetVersion string, dryRun, force, backup bool, checkpoint checkpointOptions) error {
 	ctx := context.Background()
-	logger := zap.New(zap.UseDevMode(verbose))
+	logger := newLogger()
 
 	// Create client
 	client, err := createClient()
@@ -273,8 +325,20 @@ func runMigrate(resourceName, sourceVersion, targetVersion string, dryRun, force
 		return fmt.Errorf("failed to create client: %w", err)
 	}
 
-	// Create migration manager
-	manager := migration.NewMigrationManager(client, logger)
+	// Create migration manager, checkpointing its migrations when interrupted
+	manager := migration.NewMigrationManager(client, scheme.Scheme, logger, migration.MigrationConfig{
+		DryRun:              dryRun,
+		CheckpointNamespace: checkpoint.namespace,
+	})
+	stopDrain := cliout.DrainOnSignal(manager, checkpoint.drainTimeout)
+	defer stopDrain()
+
+	// Finish the migrations an interrupted run checkpointed first
+	if !dryRun {
+		if err := cliout.ResumeCheckpointed(ctx, os.Stdout, manager, time.Second); err != nil {
+			return fmt.Errorf("failed to resume interrupted migrations: %w", err)
+		}
+	}
 
 	// Configure manager
 	config := &migration.MigrationConfig{
@@ -305,12 +369,14 @@ func runMigrate(resourceName, sourceVersion, targetVersion string, dryRun, force
 	if len(result.Warnings) > 0 {
 		fmt.Println("\nWarnings:")
 		for _, warning := range result.Warnings {
-			fmt.Printf("  - %s\n", warning)
+			fmt.Printf("  - %s\n", redaction.Default().Text(warning))
 		}
 	}
 
 	if result.Error != nil {
-		fmt.Printf("\nError: %v\n", result.Error)
+		// The error of the migration may quote the resource; report it
+		// redacted, with the exit code of its cause
+		return cliout.WithExitCode(cliout.ExitCode(result.Error), fmt.Errorf("migration %s: %s", result.Status, redaction.Default().Text(result.Error.Error())))
 	}
 
 	return nil
@@ -318,7 +384,7 @@ func runMigrate(resourceName, sourceVersion, targetVersion string, dryRun, force
 
 func runPreserve(resourceName, outputFile, policy, format string) error {
 	ctx := context.Background()
-	logger := zap.New(zap.UseDevMode(verbose))
+	logger := newLogger()
 
 	// Create client
 	client, err := createClient()
@@ -391,24 +457,16 @@ func runPreserve(resourceName, outputFile, policy, format string) error {
 
 func runRestore(inputFile, targetResource string, verify bool) error {
 	ctx := context.Background()
-	logger := zap.New(zap.UseDevMode(verbose))
-
-	// Read preserved data
-	data, err := os.ReadFile(inputFile)
-	if err != nil {
-		return fmt.Errorf("failed to read input file: %w", err)
-	}
-
-	var preserved conversion.PreservedDataEnhanced
-	if err := json.Unmarshal(data, &preserved); err != nil {
-		return fmt.Errorf("failed to unmarshal preserved data: %w", err)
-	}
+	logger := newLogger()
 
 	// Create client
 	client, err := createClient()
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
+	if err := configureEncryption(client); err != nil {
+		return err
+	}
 
 	// Get target resource
 	obj, err := getResource(ctx, client, namespace, targetResource)
@@ -416,6 +474,31 @@ func runRestore(inputFile, targetResource string, verify bool) error {
 		return fmt.Errorf("failed to get target resource: %w", err)
 	}
 
+	// Read preserved data from the input file, or from the resource's
+	// annotation, decrypted if it is encrypted
+	var preserved conversion.PreservedDataEnhanced
+	if inputFile != "" {
+		data, err := os.ReadFile(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to read input file: %w", err)
+		}
+		if data, err = conversion.DecodePreservedData(ctx, string(data)); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &preserved); err != nil {
+			return fmt.Errorf("failed to unmarshal preserved data: %w", err)
+		}
+	} else {
+		annotated, err := conversion.LoadPreservedDataEnhanced(ctx, obj)
+		if err != nil {
+			return err
+		}
+		if annotated == nil {
+			return fmt.Errorf("%s/%s has no preserved data, use --input", namespace, targetResource)
+		}
+		preserved = *annotated
+	}
+
 	// Create data preserver
 	policyConfig := &preservation.PolicyConfig{
 		Policies:        preservation.DefaultPolicies(),
@@ -454,7 +537,7 @@ func runRestore(inputFile, targetResource string, verify bool) error {
 
 func runValidate(resourceName, targetVersion string, detailed bool) error {
 	ctx := context.Background()
-	logger := zap.New(zap.UseDevMode(verbose))
+	logger := newLogger()
 
 	// Create client
 	client, err := createClient()
@@ -510,33 +593,75 @@ func runValidate(resourceName, targetVersion string, detailed bool) error {
 		}
 	}
 
+	if !result.Valid {
+		return cliout.WithExitCode(cliout.ExitValidationFailed, fmt.Errorf("%s/%s can't be converted to %s", namespace, resourceName, targetVersion))
+	}
 	return nil
 }
 
-func runSchemaList() error {
-	logger := zap.New(zap.UseDevMode(verbose))
+// schemaVersion is an API version in the output of schema list
+type schemaVersion struct {
+	Version        string     `json:"version"`
+	ReleaseDate    time.Time  `json:"releaseDate"`
+	DeprecatedDate *time.Time `json:"deprecatedDate,omitempty"`
+	RemovalDate    *time.Time `json:"removalDate,omitempty"`
+	Features       []string   `json:"features,omitempty"`
+}
+
+func runSchemaList(out io.Writer) error {
+	logger := newLogger()
 	tracker := migration.NewSchemaEvolutionTracker(logger)
 
-	versions := tracker.GetAvailableVersions()
-	
-	fmt.Println("Available API Versions:")
-	for _, version := range versions {
-		info := tracker.GetVersionInfo(version)
-		fmt.Printf("  - %s", version)
-		if info != nil {
-			fmt.Printf(" (released: %s)", info.ReleaseDate.Format("2006-01-02"))
-			if info.Deprecated {
-				fmt.Printf(" [DEPRECATED]")
-			}
+	names := tracker.GetSupportedVersions()
+	sort.Strings(names)
+
+	versions := []schemaVersion{}
+	for _, name := range names {
+		info, err := tracker.GetVersionInfo(name)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, schemaVersion{
+			Version:        info.Version,
+			ReleaseDate:    info.ReleaseDate,
+			DeprecatedDate: info.DeprecatedDate,
+			RemovalDate:    info.RemovalDate,
+			Features:       info.Features,
+		})
+	}
+
+	return cliout.Write(out, outputFormat, versions, func(w io.Writer) error {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "VERSION\tRELEASED\tDEPRECATED\tREMOVED")
+		for _, v := range versions {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", v.Version, v.ReleaseDate.Format("2006-01-02"), formatDate(v.DeprecatedDate), formatDate(v.RemovalDate))
 		}
-		fmt.Println()
+		return tw.Flush()
+	})
+}
+
+// formatDate formats an optional date, "-" if unset
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return "-"
 	}
+	return t.Format("2006-01-02")
+}
 
-	return nil
+// schemaPath is a migration path in the output of schema path
+type schemaPath struct {
+	From            string   `json:"from"`
+	To              string   `json:"to"`
+	Direct          bool     `json:"direct"`
+	Steps           []string `json:"steps,omitempty"`
+	Complexity      string   `json:"complexity"`
+	DataLossRisk    bool     `json:"dataLossRisk"`
+	RequiresManual  bool     `json:"requiresManual"`
+	Transformations []string `json:"transformations,omitempty"`
 }
 
-func runSchemaPath(from, to string) error {
-	logger := zap.New(zap.UseDevMode(verbose))
+func runSchemaPath(out io.Writer, from, to string) error {
+	logger := newLogger()
 	tracker := migration.NewSchemaEvolutionTracker(logger)
 
 	path, err := tracker.GetMigrationPath(from, to)
@@ -544,30 +669,47 @@ func runSchemaPath(from, to string) error {
 		return fmt.Errorf("no migration path found: %w", err)
 	}
 
-	fmt.Printf("Migration Path from %s to %s:\n", from, to)
-	fmt.Printf("  Direct Migration: %v\n", path.Direct)
-	fmt.Printf("  Data Loss Risk: %v\n", path.DataLossRisk)
-	fmt.Printf("  Complexity: %s\n", path.Complexity)
-
-	if len(path.IntermediateVersions) > 0 {
-		fmt.Println("  Intermediate Versions:")
-		for _, v := range path.IntermediateVersions {
-			fmt.Printf("    - %s\n", v)
-		}
+	result := schemaPath{
+		From:           from,
+		To:             to,
+		Direct:         path.Direct,
+		Steps:          path.Steps,
+		Complexity:     string(path.Complexity),
+		DataLossRisk:   path.DataLossRisk,
+		RequiresManual: path.RequiresManual,
 	}
+	for _, t := range path.Transformations {
+		result.Transformations = append(result.Transformations, fmt.Sprintf("%s (%s): %s", t.Field, t.Type, t.Description))
+	}
+
+	return cliout.Write(out, outputFormat, result, func(w io.Writer) error {
+		var b strings.Builder
+		fmt.Fprintf(&b, "Migration Path from %s to %s:\n", result.From, result.To)
+		fmt.Fprintf(&b, "  Direct Migration: %v\n", result.Direct)
+		fmt.Fprintf(&b, "  Data Loss Risk: %v\n", result.DataLossRisk)
+		fmt.Fprintf(&b, "  Requires Manual Steps: %v\n", result.RequiresManual)
+		fmt.Fprintf(&b, "  Complexity: %s\n", result.Complexity)
 
-	if len(path.RequiredTransformations) > 0 {
-		fmt.Println("  Required Transformations:")
-		for _, t := range path.RequiredTransformations {
-			fmt.Printf("    - %s\n", t)
+		if len(result.Steps) > 0 {
+			b.WriteString("  Intermediate Versions:\n")
+			for _, v := range result.Steps {
+				fmt.Fprintf(&b, "    - %s\n", v)
+			}
 		}
-	}
 
-	return nil
+		if len(result.Transformations) > 0 {
+			b.WriteString("  Required Transformations:\n")
+			for _, t := range result.Transformations {
+				fmt.Fprintf(&b, "    - %s\n", t)
+			}
+		}
+		_, err := io.WriteString(w, b.String())
+		return err
+	})
 }
 
 func runSchemaHistory(limit int) error {
-	logger := zap.New(zap.UseDevMode(verbose))
+	logger := newLogger()
 	tracker := migration.NewSchemaEvolutionTracker(logger)
 
 	history := tracker.GetMigrationHistory(limit)
@@ -596,9 +738,21 @@ func runSchemaHistory(limit int) error {
 	return nil
 }
 
-func runStatus(resourceName string) error {
+// resourceStatus is the migration status of a platform in the output of
+// status
+type resourceStatus struct {
+	Namespace          string    `json:"namespace"`
+	Name               string    `json:"name"`
+	APIVersion         string    `json:"apiVersion"`
+	Created            time.Time `json:"created"`
+	Generation         int64     `json:"generation"`
+	LastConversionFrom string    `json:"lastConversionFrom,omitempty"`
+	PreservedFields    string    `json:"preservedFields,omitempty"`
+	UnknownFields      string    `json:"unknownFields,omitempty"`
+}
+
+func runStatus(out io.Writer, resourceName string) error {
 	ctx := context.Background()
-	logger := zap.New(zap.UseDevMode(verbose))
 
 	// Create client
 	client, err := createClient()
@@ -618,35 +772,44 @@ func runStatus(resourceName string) error {
 		return fmt.Errorf("failed to get metadata: %w", err)
 	}
 
-	// Display status
-	fmt.Printf("Resource: %s/%s\n", namespace, resourceName)
-	fmt.Printf("API Version: %s\n", obj.GetObjectKind().GroupVersionKind().Version)
-	fmt.Printf("Created: %s\n", meta.GetCreationTimestamp().Format(time.RFC3339))
-	fmt.Printf("Generation: %d\n", meta.GetGeneration())
-
 	// Check annotations for migration info
 	annotations := meta.GetAnnotations()
-	if annotations != nil {
-		if lastVersion := annotations[conversion.LastConversionVersionAnnotation]; lastVersion != "" {
-			fmt.Printf("\nLast Conversion:\n")
-			fmt.Printf("  From Version: %s\n", lastVersion)
+	status := resourceStatus{
+		Namespace:          namespace,
+		Name:               resourceName,
+		APIVersion:         obj.GetObjectKind().GroupVersionKind().Version,
+		Created:            meta.GetCreationTimestamp().Time,
+		Generation:         meta.GetGeneration(),
+		LastConversionFrom: annotations[conversion.LastConversionVersionAnnotation],
+		PreservedFields:    annotations[conversion.PreservedFieldsAnnotation],
+		UnknownFields:      annotations[conversion.UnknownFieldsAnnotation],
+	}
+
+	return cliout.Write(out, outputFormat, status, func(w io.Writer) error {
+		var b strings.Builder
+		fmt.Fprintf(&b, "Resource: %s/%s\n", status.Namespace, status.Name)
+		fmt.Fprintf(&b, "API Version: %s\n", status.APIVersion)
+		fmt.Fprintf(&b, "Created: %s\n", status.Created.Format(time.RFC3339))
+		fmt.Fprintf(&b, "Generation: %d\n", status.Generation)
+
+		if status.LastConversionFrom != "" {
+			b.WriteString("\nLast Conversion:\n")
+			fmt.Fprintf(&b, "  From Version: %s\n", status.LastConversionFrom)
 		}
-		
-		if preservedFields := annotations[conversion.PreservedFieldsAnnotation]; preservedFields != "" {
-			fmt.Printf("\nPreserved Fields: %s\n", preservedFields)
+		if status.PreservedFields != "" {
+			fmt.Fprintf(&b, "\nPreserved Fields: %s\n", status.PreservedFields)
 		}
-		
-		if unknownFields := annotations[conversion.UnknownFieldsAnnotation]; unknownFields != "" {
-			fmt.Printf("\nUnknown Fields: %s\n", unknownFields)
+		if status.UnknownFields != "" {
+			fmt.Fprintf(&b, "\nUnknown Fields: %s\n", status.UnknownFields)
 		}
-	}
-
-	return nil
+		_, err := io.WriteString(w, b.String())
+		return err
+	})
 }
 
 func runOptimize(resourceName, strategy string, apply bool) error {
 	ctx := context.Background()
-	logger := zap.New(zap.UseDevMode(verbose))
+	logger := newLogger()
 
 	// Create client
 	client, err := createClient()
@@ -696,6 +859,11 @@ func runOptimize(resourceName, strategy string, apply bool) error {
 
 // Helper functions
 
+// newLogger creates a logger redacting secrets, tokens and passwords
+func newLogger() logr.Logger {
+	return redaction.Logger(zap.New(zap.UseDevMode(verbose)), redaction.Default())
+}
+
 func createClient() (client.Client, error) {
 	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
 	if err != nil {
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 Gunjan Patil.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gunjanjp/gunj-operator/pkg/crdschema"
+)
+
+// newSchemaDiffCmd creates the schema diff command
+func newSchemaDiffCmd() *cobra.Command {
+	var (
+		from         string
+		to           string
+		format       string
+		breakingOnly bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare the CRD schemas of two operator releases",
+		Long: `Compare the CRD schemas of two operator releases, listing the API versions and
+fields added, removed or changed between them, with their defaults and
+validations. Changes that may reject objects or clients valid with the older
+release are marked breaking.
+
+--from and --to take a release embedded in gunj-migrate, or a CRD file or
+directory of CRD files such as config/crd/bases of a checkout.`,
+		Example: `  gunj-migrate schema diff --from v1.2.0 --to v1.3.0
+  gunj-migrate schema diff --from v2.0.0 --to config/crd/bases -o markdown`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSchemaDiff(cmd.OutOrStdout(), from, to, format, breakingOnly)
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Release, CRD file or directory to compare from")
+	cmd.Flags().StringVar(&to, "to", "", "Release, CRD file or directory to compare to")
+	cmd.Flags().StringVarP(&format, "output", "o", "text", "Output format (text, markdown, json)")
+	cmd.Flags().BoolVar(&breakingOnly, "breaking-only", false, "Only list breaking changes")
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func runSchemaDiff(out io.Writer, from, to, format string, breakingOnly bool) error {
+	fromCRDs, err := crdschema.Load(from)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", from, err)
+	}
+	toCRDs, err := crdschema.Load(to)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", to, err)
+	}
+
+	changes := crdschema.Diff(fromCRDs, toCRDs)
+	if breakingOnly {
+		var breaking []crdschema.Change
+		for _, change := range changes {
+			if change.Breaking {
+				breaking = append(breaking, change)
+			}
+		}
+		changes = breaking
+	}
+
+	switch format {
+	case "json":
+		if changes == nil {
+			changes = []crdschema.Change{}
+		}
+		data, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+	case "markdown":
+		fmt.Fprintf(out, "# CRD schema changes from %s to %s\n\n", from, to)
+		if len(changes) == 0 {
+			fmt.Fprintln(out, "No changes.")
+			return nil
+		}
+		fmt.Fprintln(out, "| CRD | Version | Field | Change | From | To | Breaking |")
+		fmt.Fprintln(out, "|-----|---------|-------|--------|------|----|----------|")
+		for _, c := range changes {
+			breaking := ""
+			if c.Breaking {
+				breaking = "yes"
+			}
+			fmt.Fprintf(out, "| %s | %s | %s | %s %s | %s | %s | %s |\n",
+				c.CRD, c.Version, markdownCell(c.Path), c.Aspect, strings.ToLower(string(c.Kind)),
+				markdownCell(c.From), markdownCell(c.To), breaking)
+		}
+	case "text":
+		if len(changes) == 0 {
+			fmt.Fprintf(out, "No CRD schema changes from %s to %s\n", from, to)
+			return nil
+		}
+		fmt.Fprintf(out, "CRD schema changes from %s to %s:\n", from, to)
+		breaking := 0
+		for _, c := range changes {
+			fmt.Fprintf(out, "  %s\n", c)
+			if c.Breaking {
+				breaking++
+			}
+		}
+		fmt.Fprintf(out, "\n%d changes, %d breaking\n", len(changes), breaking)
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+	return nil
+}
+
+// markdownCell escapes a value for a markdown table cell
+func markdownCell(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "`" + strings.ReplaceAll(value, "|", `\|`) + "`"
+}
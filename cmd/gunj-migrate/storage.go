@@ -0,0 +1,132 @@
+/*
+Copyright 2025 Gunjan Patil.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gunjanjp/gunj-operator/api/v1alpha1"
+	"github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/pkg/storagemigration"
+)
+
+// platformCRD is the CRD of the ObservabilityPlatforms
+const platformCRD = "observabilityplatforms.observability.io"
+
+// newStorageMigrateCmd creates the storage-migrate command
+func newStorageMigrateCmd() *cobra.Command {
+	var (
+		targetVersion string
+		crdName       string
+		dryRun        bool
+		keepServed    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "storage-migrate",
+		Short: "Rewrite all stored ObservabilityPlatforms to a new version without a conversion webhook",
+		Long: `Rewrite every stored ObservabilityPlatform of the cluster to a new API version
+with the operator's converters, for clusters where conversion webhooks are
+forbidden. Each platform is read in the version it is stored in, converted,
+and written back once the new version is the storage version. The CRD's
+status.storedVersions is then set to the new version alone and the previous
+versions are no longer served, unless --keep-served.
+
+Stop the operator first: platforms written in the new version during the
+migration could be converted twice. An interrupted migration resumes where it
+stopped when run again; rewritten platforms are annotated
+observability.io/stored-version. A platform that cannot be converted aborts
+the migration before anything is changed.`,
+		Example: `  gunj-migrate storage-migrate --to v1beta1 --dry-run
+  gunj-migrate storage-migrate --to v1beta1`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := createStorageClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			return runStorageMigrate(cmd.Context(), cmd.OutOrStdout(), c, crdName, targetVersion, dryRun, keepServed)
+		},
+	}
+
+	cmd.Flags().StringVar(&targetVersion, "to", "v1beta1", "Version to store the platforms in")
+	cmd.Flags().StringVar(&crdName, "crd", platformCRD, "CRD to migrate")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Convert the platforms without writing anything")
+	cmd.Flags().BoolVar(&keepServed, "keep-served", false, "Keep serving the previous versions after the migration")
+
+	return cmd
+}
+
+// runStorageMigrate migrates the stored objects of a CRD
+func runStorageMigrate(ctx context.Context, out io.Writer, c client.Client, crdName, targetVersion string, dryRun, keepServed bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	converters := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(converters); err != nil {
+		return err
+	}
+	if err := v1beta1.AddToScheme(converters); err != nil {
+		return err
+	}
+
+	migrator := &storagemigration.Migrator{
+		Client:     c,
+		Converter:  &storagemigration.SchemeConverter{Scheme: converters},
+		Out:        out,
+		DryRun:     dryRun,
+		KeepServed: keepServed,
+	}
+	result, err := migrator.Run(ctx, crdName, targetVersion)
+	if result != nil && len(result.Failed) > 0 {
+		fmt.Fprintln(out, "\nFailed:")
+		keys := make([]string, 0, len(result.Failed))
+		for key, failure := range result.Failed {
+			keys = append(keys, fmt.Sprintf("  %s: %v", key, failure))
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintln(out, key)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if !dryRun {
+		fmt.Fprintf(out, "\n%d migrated, %d already stored in %s\n", len(result.Migrated), len(result.Skipped), targetVersion)
+	}
+	return nil
+}
+
+// createStorageClient creates a client reading CRDs
+func createStorageClient() (client.Client, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	s := runtime.NewScheme()
+	if err := scheme.AddToScheme(s); err != nil {
+		return nil, err
+	}
+	if err := apiextensionsv1.AddToScheme(s); err != nil {
+		return nil, err
+	}
+	return client.New(config, client.Options{Scheme: s})
+}
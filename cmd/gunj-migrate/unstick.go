@@ -0,0 +1,129 @@
+/*
+Copyright 2025 Gunjan Patil.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gunjanjp/gunj-operator/pkg/unstick"
+)
+
+// newUnstickCmd creates the unstick command
+func newUnstickCmd() *cobra.Command {
+	var (
+		force     bool
+		dryRun    bool
+		reason    string
+		threshold time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "unstick [platform]",
+		Short: "Diagnose and release ObservabilityPlatforms stuck terminating",
+		Long: `Diagnose ObservabilityPlatforms stuck terminating: operator finalizers
+that are not removed, webhooks that reject updates, remaining children and
+finalizers of other controllers. Without a platform, list the platforms of
+the namespace terminating for longer than --threshold.
+
+With a platform, remove the operator's finalizers once its children are
+gone, keeping the finalizers of other controllers. Every removal is recorded
+in a ConfigMap labeled observability.io/unstuck-platform=<platform>.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			inspector := &unstick.Inspector{Client: c}
+			if len(args) == 0 {
+				return runListStuck(cmd.Context(), cmd.OutOrStdout(), inspector, threshold)
+			}
+			options := unstick.Options{Force: force, DryRun: dryRun, Reason: reason, Actor: os.Getenv("USER")}
+			return runUnstick(cmd.Context(), cmd.OutOrStdout(), inspector, args[0], options)
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Remove the finalizers even if children of the platform remain")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be removed without changing anything")
+	cmd.Flags().StringVar(&reason, "reason", "", "Reason recorded in the audit record")
+	cmd.Flags().DurationVar(&threshold, "threshold", unstick.DefaultThreshold, "How long a platform terminates before it is listed as stuck")
+
+	return cmd
+}
+
+// runListStuck lists the platforms of the namespace stuck terminating
+func runListStuck(ctx context.Context, out io.Writer, inspector *unstick.Inspector, threshold time.Duration) error {
+	platforms := &unstructured.UnstructuredList{}
+	platforms.SetGroupVersionKind(unstick.PlatformGVK.GroupVersion().WithKind(unstick.PlatformGVK.Kind + "List"))
+	if err := inspector.Client.List(ctx, platforms, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list platforms: %w", err)
+	}
+
+	stuck := 0
+	for _, platform := range platforms.Items {
+		if platform.GetDeletionTimestamp() == nil {
+			continue
+		}
+		d, err := inspector.Diagnose(ctx, namespace, platform.GetName())
+		if err != nil {
+			return err
+		}
+		if !d.Stuck(threshold) {
+			continue
+		}
+		stuck++
+		printDiagnosis(out, d)
+	}
+	if stuck == 0 {
+		fmt.Fprintf(out, "No platforms stuck terminating in %s\n", namespace)
+	}
+	return nil
+}
+
+// runUnstick releases a platform stuck terminating
+func runUnstick(ctx context.Context, out io.Writer, inspector *unstick.Inspector, name string, options unstick.Options) error {
+	d, err := inspector.Diagnose(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+	printDiagnosis(out, d)
+
+	record, err := inspector.Unstick(ctx, d, options)
+	if err != nil {
+		return err
+	}
+	if options.DryRun {
+		fmt.Fprintf(out, "\nWould remove finalizers: %v\n", record.RemovedFinalizers)
+		return nil
+	}
+	fmt.Fprintf(out, "\nRemoved finalizers: %v\n", record.RemovedFinalizers)
+	if len(record.KeptFinalizers) > 0 {
+		fmt.Fprintf(out, "Kept finalizers of other controllers: %v\n", record.KeptFinalizers)
+	}
+	if record.Forced {
+		fmt.Fprintf(out, "Left %d children behind, delete them manually\n", len(record.RemainingChildren))
+	}
+	return nil
+}
+
+func printDiagnosis(out io.Writer, d *unstick.Diagnosis) {
+	fmt.Fprintf(out, "%s/%s terminating for %s\n", d.Namespace, d.Platform, d.Terminating.Round(time.Second))
+	for _, reason := range d.Reasons() {
+		fmt.Fprintf(out, "  - %s\n", reason)
+	}
+	for _, child := range d.Children {
+		fmt.Fprintf(out, "    %s %s\n", child.Kind, child.Name)
+	}
+}
@@ -8,7 +8,9 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -26,6 +28,7 @@ import (
 	observabilityv1alpha1 "github.com/gunjanjp/gunj-operator/api/v1alpha1"
 	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
 	"github.com/gunjanjp/gunj-operator/api/v1beta1/migration"
+	"github.com/gunjanjp/gunj-operator/pkg/cliout"
 )
 
 var (
@@ -39,8 +42,21 @@ var (
 	outputFile      string
 	enableOptimization bool
 	progressInterval time.Duration
+	reportNamespace string
+	reportRetention int
+	reportTTL       time.Duration
+	noInteractive   bool
+	waitForCompletion bool
+	timeout         time.Duration
+	outputFormat    string
+	checkpointNamespace string
+	drainTimeout    time.Duration
 )
 
+// reportPollInterval is how often the persisted report of a migration is
+// checked while waiting for it to complete
+const reportPollInterval = 5 * time.Second
+
 func main() {
 	rootCmd := &cobra.Command{
 		Use:   "gunj-migrate",
@@ -48,9 +64,28 @@ func main() {
 		Long: `A tool for migrating ObservabilityPlatform resources between API versions.
 		
 This tool helps you migrate your ObservabilityPlatform resources from one API version
-to another, with support for batch processing, dry-run mode, and detailed reporting.`,
+to another, with support for batch processing, dry-run mode, and detailed reporting.
+
+Exit codes:
+  0  success
+  1  error, or migration failed for all resources
+  2  migration failed for some of the resources
+  3  resources failed the migration checks or aren't ready to be migrated
+  4  migration rolled back
+
+Completion scripts for bash, zsh, fish and powershell are printed by
+gunj-migrate completion, see gunj-migrate completion --help.`,
+		// Errors are printed once, by main, without the usage
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return cliout.Validate(outputFormat)
+		},
 	}
 	
+	cliout.AddFlag(rootCmd, &outputFormat)
+	rootCmd.PersistentFlags().BoolVar(&noInteractive, "no-interactive", false, "Never prompt for confirmation, proceed as if confirmed")
+	
 	// Add subcommands
 	rootCmd.AddCommand(
 		newMigrateCmd(),
@@ -62,7 +97,7 @@ to another, with support for batch processing, dry-run mode, and detailed report
 	
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(cliout.ExitCode(err))
 	}
 }
 
@@ -84,7 +119,14 @@ Examples:
   gunj-migrate migrate --target-version v1beta1 --all-namespaces
   
   # Dry-run mode to preview changes
-  gunj-migrate migrate --target-version v1beta1 --namespace default --dry-run`,
+  gunj-migrate migrate --target-version v1beta1 --namespace default --dry-run
+  
+  # In a pipeline: no prompt, roll back what was migrated after 10 minutes
+  gunj-migrate migrate --target-version v1beta1 --namespace monitoring --no-interactive --timeout 10m
+
+Migrations unfinished when the tool is interrupted are checkpointed to the
+--checkpoint-namespace, and resumed by the next run before anything else is
+migrated.`,
 		RunE: runMigrate,
 	}
 	
@@ -97,6 +139,12 @@ Examples:
 	cmd.Flags().IntVar(&maxConcurrent, "max-concurrent", 5, "Maximum concurrent migrations")
 	cmd.Flags().BoolVar(&enableOptimization, "enable-optimization", true, "Enable conversion optimizations")
 	cmd.Flags().DurationVar(&progressInterval, "progress-interval", 5*time.Second, "Progress report interval")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "How long the migration may take before it is cancelled and rolled back (0 for no limit)")
+	cmd.Flags().StringVar(&reportNamespace, "report-namespace", "gunj-system", "Namespace the migration reports are persisted to (empty to not persist them)")
+	cmd.Flags().IntVar(&reportRetention, "report-retention", 50, "Number of most recent migration reports kept (0 keeps all)")
+	cmd.Flags().DurationVar(&reportTTL, "report-ttl", 30*24*time.Hour, "How long migration reports are kept (0 keeps them indefinitely)")
+	cmd.Flags().StringVar(&checkpointNamespace, "checkpoint-namespace", "gunj-system", "Namespace the migrations unfinished when interrupted are checkpointed to, and resumed from by the next run (empty to not checkpoint them)")
+	cmd.Flags().DurationVar(&drainTimeout, "drain-timeout", 30*time.Second, "How long the running migrations may take to finish when interrupted before they are checkpointed")
 	
 	return cmd
 }
@@ -113,10 +161,19 @@ Examples:
   gunj-migrate status
   
   # Check specific migration status
-  gunj-migrate status migrate-12345`,
-		RunE: runStatus,
+  gunj-migrate status migrate-12345
+  
+  # Wait up to 30 minutes for a migration to complete, the exit code is its
+  # result
+  gunj-migrate status migrate-12345 --wait --timeout 30m`,
+		RunE:              runStatus,
+		ValidArgsFunction: completeTaskIDs,
 	}
 	
+	cmd.Flags().BoolVar(&waitForCompletion, "wait", false, "Wait until the migration completed and its report is persisted")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "How long to wait for the migration (0 for no limit)")
+	cmd.Flags().StringVar(&reportNamespace, "report-namespace", "gunj-system", "Namespace the migration reports are persisted to")
+	
 	return cmd
 }
 
@@ -125,20 +182,34 @@ func newReportCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "report [task-id]",
 		Short: "Generate migration report",
-		Long: `Generate detailed migration reports in various formats.
+		Long: `Generate detailed migration reports in various formats, from the reports
+persisted by the migrations.
 		
 Examples:
+  # List the persisted reports
+  gunj-migrate report list
+  
   # Generate JSON report
   gunj-migrate report migrate-12345 --format json --output report.json
   
   # Generate HTML report
   gunj-migrate report migrate-12345 --format html --output report.html`,
-		RunE: runReport,
+		RunE:              runReport,
+		ValidArgsFunction: completeTaskIDs,
 	}
 	
+	cmd.PersistentFlags().StringVar(&reportNamespace, "report-namespace", "gunj-system", "Namespace the migration reports are persisted to")
 	cmd.Flags().StringVar(&reportFormat, "format", "json", "Report format (json, html, text)")
 	cmd.Flags().StringVar(&outputFile, "output", "", "Output file (default: stdout)")
 	
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List the persisted migration reports",
+		Long: `List the persisted migration reports, most recently completed first.`,
+		Args:  cobra.NoArgs,
+		RunE:  runReportList,
+	})
+	
 	return cmd
 }
 
@@ -174,7 +245,8 @@ func newRollbackCmd() *cobra.Command {
 Examples:
   # Rollback a specific migration
   gunj-migrate rollback migrate-12345`,
-		RunE: runRollback,
+		RunE:              runRollback,
+		ValidArgsFunction: completeTaskIDs,
 	}
 	
 	return cmd
@@ -183,6 +255,11 @@ Examples:
 // runMigrate executes the migrate command
 func runMigrate(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 	
 	// Setup logging
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
@@ -212,9 +289,24 @@ func runMigrate(cmd *cobra.Command, args []string) error {
 		EnableOptimizations:     enableOptimization,
 		DryRun:                  dryRun,
 		ProgressReportInterval:  progressInterval,
+		CheckpointNamespace:     checkpointNamespace,
+		ReportNamespace:         reportNamespace,
+		ReportRetention: migration.ReportRetention{
+			MaxReports: reportRetention,
+			TTL:        reportTTL,
+		},
 	}
 	
 	migrationManager := migration.NewMigrationManager(k8sClient, scheme.Scheme, logger, migrationConfig)
+	stopDrain := cliout.DrainOnSignal(migrationManager, drainTimeout)
+	defer stopDrain()
+	
+	// Finish the migrations an interrupted run checkpointed first
+	if !dryRun {
+		if err := cliout.ResumeCheckpointed(ctx, os.Stdout, migrationManager, progressInterval); err != nil {
+			return fmt.Errorf("failed to resume interrupted migrations: %w", err)
+		}
+	}
 	
 	// Determine resources to migrate
 	resources, err := getResourcesToMigrate(ctx, k8sClient, args)
@@ -236,7 +328,7 @@ func runMigrate(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	
 	// Confirm if not dry-run
-	if !dryRun {
+	if !dryRun && !noInteractive {
 		fmt.Print("Proceed with migration? [y/N]: ")
 		var response string
 		fmt.Scanln(&response)
@@ -263,9 +355,19 @@ func runMigrate(cmd *cobra.Command, args []string) error {
 		}
 		
 		// Monitor progress
-		if err := monitorMigration(migrationManager, task.ID); err != nil {
+		finished, err := monitorMigration(ctx, migrationManager, task.ID)
+		if err != nil {
 			return fmt.Errorf("error monitoring migration: %w", err)
 		}
+		if finished == nil {
+			// Timed out: stop the migration and roll back what it migrated
+			if err := migrationManager.CancelMigration(task.ID); err != nil {
+				return cliout.WithExitCode(cliout.ExitFailure, fmt.Errorf("migration %s timed out after %s, and could not be rolled back: %w", task.ID, timeout, err))
+			}
+			return cliout.WithExitCode(cliout.ExitRolledBack, fmt.Errorf("migration %s timed out after %s and was rolled back", task.ID, timeout))
+		}
+		return cliout.ResultError(finished.ID, finished.Status, finished.Progress.TotalResources,
+			finished.Progress.MigratedResources, finished.Progress.FailedResources)
 	}
 	
 	return nil
@@ -300,41 +402,73 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	
 	if len(args) == 0 {
 		// List all active migrations
-		tasks := migrationManager.ListActiveMigrations()
-		if len(tasks) == 0 {
-			fmt.Println("No active migrations found")
-			return nil
-		}
-		
-		fmt.Printf("Active Migrations:\n\n")
-		for _, task := range tasks {
-			fmt.Printf("Task ID: %s\n", task.ID)
-			fmt.Printf("  Status: %s\n", task.Status)
-			fmt.Printf("  Target Version: %s\n", task.TargetVersion)
-			fmt.Printf("  Progress: %d/%d\n", 
-				task.Progress.MigratedResources+task.Progress.FailedResources+task.Progress.SkippedResources,
-				task.Progress.TotalResources)
-			fmt.Printf("  Started: %s\n", task.StartTime.Format(time.RFC3339))
-			fmt.Println()
+		summaries := []taskSummary{}
+		for _, task := range migrationManager.ListActiveMigrations() {
+			summaries = append(summaries, summarizeTask(task))
 		}
+		return cliout.Write(os.Stdout, outputFormat, summaries, func(w io.Writer) error {
+			if len(summaries) == 0 {
+				_, err := fmt.Fprintln(w, "No active migrations found")
+				return err
+			}
+			return writeTaskTable(w, summaries)
+		})
 	} else {
-		// Get specific migration status
+		// Get specific migration status, from its persisted report once it
+		// completed
 		taskID := args[0]
-		task, err := migrationManager.GetMigrationStatus(taskID)
+		if task, err := migrationManager.GetMigrationStatus(taskID); err == nil && !waitForCompletion {
+			return cliout.Write(os.Stdout, outputFormat, summarizeTask(task), func(io.Writer) error {
+				displayMigrationStatus(task)
+				return nil
+			})
+		}
+		
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		store := migration.NewReportStore(k8sClient, reportNamespace, migration.ReportRetention{})
+		report, err := waitForReport(ctx, store, taskID)
 		if err != nil {
 			return fmt.Errorf("failed to get migration status: %w", err)
 		}
 		
-		displayMigrationStatus(task)
+		err = cliout.Write(os.Stdout, outputFormat, summarizeReport(report), func(w io.Writer) error {
+			_, err := io.WriteString(w, formatTextReport(report))
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		return cliout.ResultError(report.TaskID, report.Status, report.TotalResources, report.SuccessCount, report.FailureCount)
 	}
 	
 	return nil
 }
 
+// waitForReport returns the persisted report of a migration. With --wait,
+// it waits until the migration completed and its report is persisted.
+func waitForReport(ctx context.Context, store *migration.ReportStore, taskID string) (*migration.MigrationReport, error) {
+	for {
+		report, err := store.Load(ctx, taskID)
+		if err == nil || !waitForCompletion || !errors.Is(err, migration.ErrReportNotFound) {
+			return report, err
+		}
+		
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out after %s waiting for migration %s", timeout, taskID)
+		case <-time.After(reportPollInterval):
+		}
+	}
+}
+
 // runReport executes the report command
 func runReport(cmd *cobra.Command, args []string) error {
 	if len(args) == 0 {
-		return fmt.Errorf("task ID is required")
+		return fmt.Errorf("task ID is required, see gunj-migrate report list")
 	}
 	
 	ctx := context.Background()
@@ -344,12 +478,26 @@ func runReport(cmd *cobra.Command, args []string) error {
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
 	logger := ctrl.Log.WithName("report")
 	
+	// Create Kubernetes client
+	config := ctrl.GetConfigOrDie()
+	k8sClient, err := client.New(config, client.Options{})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	
+	// Load the persisted report
+	store := migration.NewReportStore(k8sClient, reportNamespace, migration.ReportRetention{})
+	persisted, err := store.Load(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	
 	// Create status reporter
 	reporter := migration.NewMigrationStatusReporter(logger)
+	reporter.RestoreReport(persisted)
 	
 	// Generate report
 	var output string
-	var err error
 	
 	switch reportFormat {
 	case "json":
@@ -389,6 +537,35 @@ func runReport(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runReportList lists the persisted migration reports
+func runReportList(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	
+	// Create Kubernetes client
+	config := ctrl.GetConfigOrDie()
+	k8sClient, err := client.New(config, client.Options{})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	
+	store := migration.NewReportStore(k8sClient, reportNamespace, migration.ReportRetention{})
+	reports, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+	summaries := make([]taskSummary, 0, len(reports))
+	for _, report := range reports {
+		summaries = append(summaries, summarizeReport(report))
+	}
+	return cliout.Write(os.Stdout, outputFormat, summaries, func(w io.Writer) error {
+		if len(summaries) == 0 {
+			_, err := fmt.Fprintf(w, "No migration reports found in namespace %s\n", reportNamespace)
+			return err
+		}
+		return writeTaskTable(w, summaries)
+	})
+}
+
 // runAnalyze executes the analyze command
 func runAnalyze(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
@@ -410,22 +587,14 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get resources: %w", err)
 	}
 	
-	if len(resources) == 0 {
-		fmt.Println("No resources found to analyze")
-		return nil
-	}
-	
 	// Create schema evolution tracker
 	tracker := migration.NewSchemaEvolutionTracker(logger)
 	
-	fmt.Printf("Migration Analysis Report\n")
-	fmt.Printf("========================\n\n")
-	fmt.Printf("Target Version: %s\n", targetVersion)
-	fmt.Printf("Resources Found: %d\n\n", len(resources))
-	
 	// Analyze each resource
-	var needsMigration int
-	var warnings []string
+	result := analysis{
+		TargetVersion: targetVersion,
+		Resources:     len(resources),
+	}
 	
 	for _, resource := range resources {
 		// Get current resource
@@ -436,65 +605,43 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		})
 		
 		if err := k8sClient.Get(ctx, resource, u); err != nil {
-			warnings = append(warnings, fmt.Sprintf("Failed to get %s/%s: %v", 
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Failed to get %s/%s: %v", 
 				resource.Namespace, resource.Name, err))
 			continue
 		}
 		
 		currentVersion := strings.TrimPrefix(u.GetAPIVersion(), "observability.io/")
 		if currentVersion != targetVersion {
-			needsMigration++
+			result.NeedsMigration++
 			
 			// Get migration path
 			path, err := tracker.GetMigrationPath(currentVersion, targetVersion)
+			if err != nil || path.DataLossRisk || path.RequiresManual {
+				result.NotReady++
+			}
 			if err != nil {
-				warnings = append(warnings, fmt.Sprintf("%s/%s: No migration path from %s to %s",
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s/%s: No migration path from %s to %s",
 					resource.Namespace, resource.Name, currentVersion, targetVersion))
 			} else {
 				if path.DataLossRisk {
-					warnings = append(warnings, fmt.Sprintf("%s/%s: Migration may result in data loss",
+					result.Warnings = append(result.Warnings, fmt.Sprintf("%s/%s: Migration may result in data loss",
 						resource.Namespace, resource.Name))
 				}
 				if path.RequiresManual {
-					warnings = append(warnings, fmt.Sprintf("%s/%s: Manual intervention required",
+					result.Warnings = append(result.Warnings, fmt.Sprintf("%s/%s: Manual intervention required",
 						resource.Namespace, resource.Name))
 				}
 			}
 		}
 	}
 	
-	// Display results
-	fmt.Printf("Resources Requiring Migration: %d\n", needsMigration)
-	fmt.Printf("Resources Already at Target Version: %d\n\n", len(resources)-needsMigration)
-	
-	if len(warnings) > 0 {
-		fmt.Printf("Warnings:\n")
-		for _, warning := range warnings {
-			fmt.Printf("  - %s\n", warning)
-		}
-		fmt.Println()
-	}
-	
-	// Migration complexity assessment
-	if needsMigration > 0 {
-		fmt.Printf("Migration Complexity Assessment:\n")
-		if needsMigration < 10 {
-			fmt.Printf("  - Low: Small number of resources\n")
-		} else if needsMigration < 50 {
-			fmt.Printf("  - Medium: Moderate number of resources\n")
-		} else {
-			fmt.Printf("  - High: Large number of resources\n")
-		}
-		
-		fmt.Printf("\nRecommendations:\n")
-		fmt.Printf("  - Run migration in dry-run mode first\n")
-		fmt.Printf("  - Backup resources before migration\n")
-		fmt.Printf("  - Monitor migration progress closely\n")
-		if needsMigration > 50 {
-			fmt.Printf("  - Consider migrating in smaller batches\n")
-		}
+	if err := cliout.Write(os.Stdout, outputFormat, result, result.writeText); err != nil {
+		return err
 	}
 	
+	if result.NotReady > 0 {
+		return cliout.WithExitCode(cliout.ExitValidationFailed, fmt.Errorf("%d resources are not ready to be migrated to %s", result.NotReady, targetVersion))
+	}
 	return nil
 }
 
@@ -534,12 +681,14 @@ func runRollback(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	
 	// Confirm rollback
-	fmt.Print("Are you sure you want to rollback this migration? [y/N]: ")
-	var response string
-	fmt.Scanln(&response)
-	if strings.ToLower(response) != "y" {
-		fmt.Println("Rollback cancelled")
-		return nil
+	if !noInteractive {
+		fmt.Print("Are you sure you want to rollback this migration? [y/N]: ")
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" {
+			fmt.Println("Rollback cancelled")
+			return nil
+		}
 	}
 	
 	// Perform rollback
@@ -590,24 +739,28 @@ func getResourcesToMigrate(ctx context.Context, k8sClient client.Client, args []
 	return resources, nil
 }
 
-// monitorMigration monitors the progress of a migration
-func monitorMigration(manager *migration.MigrationManager, taskID string) error {
+// monitorMigration monitors the progress of a migration until it finished,
+// and returns it. It returns nil if the context is done first.
+func monitorMigration(ctx context.Context, manager *migration.MigrationManager, taskID string) (*migration.MigrationTask, error) {
 	ticker := time.NewTicker(progressInterval)
 	defer ticker.Stop()
 	
 	for {
 		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return nil, nil
 		case <-ticker.C:
 			task, err := manager.GetMigrationStatus(taskID)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			
 			displayMigrationProgress(task)
 			
 			if task.Status != migration.MigrationStatusInProgress {
 				displayMigrationStatus(task)
-				return nil
+				return task, nil
 			}
 		}
 	}
@@ -663,6 +816,9 @@ func formatTextReport(report *migration.MigrationReport) string {
 	
 	b.WriteString(fmt.Sprintf("Task ID: %s\n", report.TaskID))
 	b.WriteString(fmt.Sprintf("Status: %s\n", report.Status))
+	if report.TargetVersion != "" {
+		b.WriteString(fmt.Sprintf("Target Version: %s\n", report.TargetVersion))
+	}
 	b.WriteString(fmt.Sprintf("Start Time: %s\n", report.StartTime.Format(time.RFC3339)))
 	
 	if report.EndTime != nil {
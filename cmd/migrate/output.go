@@ -0,0 +1,176 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion/redaction"
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/migration"
+)
+
+// taskSummary is a migration in the output of status and report list
+type taskSummary struct {
+	TaskID         string     `json:"taskID"`
+	Status         string     `json:"status"`
+	TargetVersion  string     `json:"targetVersion,omitempty"`
+	TotalResources int        `json:"totalResources"`
+	Migrated       int        `json:"migrated"`
+	Failed         int        `json:"failed"`
+	Skipped        int        `json:"skipped"`
+	StartTime      time.Time  `json:"startTime"`
+	EndTime        *time.Time `json:"endTime,omitempty"`
+	Error          string     `json:"error,omitempty"`
+}
+
+// summarizeTask returns the summary of a migration of this process
+func summarizeTask(task *migration.MigrationTask) taskSummary {
+	summary := taskSummary{
+		TaskID:         task.ID,
+		Status:         string(task.Status),
+		TargetVersion:  task.TargetVersion,
+		TotalResources: task.Progress.TotalResources,
+		Migrated:       task.Progress.MigratedResources,
+		Failed:         task.Progress.FailedResources,
+		Skipped:        task.Progress.SkippedResources,
+		StartTime:      task.StartTime,
+		EndTime:        task.EndTime,
+	}
+	if task.Error != nil {
+		summary.Error = redaction.Default().Text(task.Error.Error())
+	}
+	return summary
+}
+
+// summarizeReport returns the summary of a persisted migration report
+func summarizeReport(report *migration.MigrationReport) taskSummary {
+	return taskSummary{
+		TaskID:         report.TaskID,
+		Status:         string(report.Status),
+		TargetVersion:  report.TargetVersion,
+		TotalResources: report.TotalResources,
+		Migrated:       report.SuccessCount,
+		Failed:         report.FailureCount,
+		Skipped:        report.SkippedCount,
+		StartTime:      report.StartTime,
+		EndTime:        report.EndTime,
+	}
+}
+
+// writeTaskTable writes migrations as a table
+func writeTaskTable(w io.Writer, tasks []taskSummary) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TASK ID\tSTATUS\tTARGET\tRESOURCES\tMIGRATED\tFAILED\tSKIPPED\tSTARTED\tDURATION")
+	for _, task := range tasks {
+		duration := "-"
+		if task.EndTime != nil {
+			duration = task.EndTime.Sub(task.StartTime).Round(time.Second).String()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%d\t%d\t%s\t%s\n",
+			task.TaskID, task.Status, task.TargetVersion, task.TotalResources,
+			task.Migrated, task.Failed, task.Skipped,
+			task.StartTime.Format(time.RFC3339), duration)
+	}
+	return tw.Flush()
+}
+
+// analysis is the result of analyze
+type analysis struct {
+	TargetVersion  string   `json:"targetVersion"`
+	Resources      int      `json:"resources"`
+	NeedsMigration int      `json:"needsMigration"`
+	NotReady       int      `json:"notReady"`
+	Warnings       []string `json:"warnings,omitempty"`
+}
+
+// writeText writes the analysis with its assessment and recommendations
+func (a analysis) writeText(w io.Writer) error {
+	if a.Resources == 0 {
+		_, err := fmt.Fprintln(w, "No resources found to analyze")
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString("Migration Analysis Report\n")
+	b.WriteString("========================\n\n")
+	fmt.Fprintf(&b, "Target Version: %s\n", a.TargetVersion)
+	fmt.Fprintf(&b, "Resources Found: %d\n\n", a.Resources)
+	fmt.Fprintf(&b, "Resources Requiring Migration: %d\n", a.NeedsMigration)
+	fmt.Fprintf(&b, "Resources Already at Target Version: %d\n\n", a.Resources-a.NeedsMigration)
+
+	if len(a.Warnings) > 0 {
+		b.WriteString("Warnings:\n")
+		for _, warning := range a.Warnings {
+			fmt.Fprintf(&b, "  - %s\n", warning)
+		}
+		b.WriteString("\n")
+	}
+
+	// Migration complexity assessment
+	if a.NeedsMigration > 0 {
+		b.WriteString("Migration Complexity Assessment:\n")
+		switch {
+		case a.NeedsMigration < 10:
+			b.WriteString("  - Low: Small number of resources\n")
+		case a.NeedsMigration < 50:
+			b.WriteString("  - Medium: Moderate number of resources\n")
+		default:
+			b.WriteString("  - High: Large number of resources\n")
+		}
+
+		b.WriteString("\nRecommendations:\n")
+		b.WriteString("  - Run migration in dry-run mode first\n")
+		b.WriteString("  - Backup resources before migration\n")
+		b.WriteString("  - Monitor migration progress closely\n")
+		if a.NeedsMigration > 50 {
+			b.WriteString("  - Consider migrating in smaller batches\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// completeTaskIDs completes the IDs of the migrations with a persisted
+// report. Nothing is completed without access to the cluster.
+func completeTaskIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	k8sClient, err := client.New(config, client.Options{})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	reports, err := migration.NewReportStore(k8sClient, reportNamespace, migration.ReportRetention{}).List(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var ids []string
+	for _, report := range reports {
+		if strings.HasPrefix(report.TaskID, toComplete) {
+			ids = append(ids, report.TaskID)
+		}
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
@@ -8,10 +8,13 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -19,12 +22,15 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2"
 	"k8s.io/klog/v2/klogr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -33,9 +39,23 @@ import (
 
 	observabilityv1alpha1 "github.com/gunjanjp/gunj-operator/api/v1alpha1"
 	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion"
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion/encryption"
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion/preservation"
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion/redaction"
 	"github.com/gunjanjp/gunj-operator/controllers"
+	"github.com/gunjanjp/gunj-operator/internal/api"
+	"github.com/gunjanjp/gunj-operator/internal/cacheconfig"
+	"github.com/gunjanjp/gunj-operator/internal/conversioncleanup"
+	"github.com/gunjanjp/gunj-operator/internal/drain"
 	"github.com/gunjanjp/gunj-operator/internal/managers"
 	"github.com/gunjanjp/gunj-operator/internal/metrics"
+	"github.com/gunjanjp/gunj-operator/internal/metricsauth"
+	"github.com/gunjanjp/gunj-operator/internal/readiness"
+	"github.com/gunjanjp/gunj-operator/internal/siem"
+	"github.com/gunjanjp/gunj-operator/internal/templatevars"
+	"github.com/gunjanjp/gunj-operator/internal/upgradehooks"
+	"github.com/gunjanjp/gunj-operator/internal/webhook/plugins"
 	"github.com/gunjanjp/gunj-operator/internal/webhooks"
 	//+kubebuilder:scaffold:imports
 )
@@ -68,19 +88,111 @@ func main() {
 	var printVersion bool
 	var namespace string
 	var watchNamespace string
+	var siemType string
+	var siemEndpoint string
+	var siemFormat string
+	var siemIndex string
+	var siemBatchSize int
+	var siemFlushInterval time.Duration
+	var siemMaxRetries int
+	var cacheLabelSelector string
+	var cacheFieldSelector string
+	var cacheSelectorKinds string
+	var cacheExcludeKinds string
+	var cacheStripManagedFields bool
+	var cacheStripAnnotations string
+	var cacheMaxAnnotationBytes int
+	var cacheSizeReportInterval time.Duration
+	var conversionKeyringSecret string
+	var conversionVaultAddress string
+	var conversionVaultTransitKey string
+	var conversionVaultTokenFile string
+	var preservationPolicyFile string
+	var templateValuesFile string
+	var admissionFeatureGates string
+	var admissionCalloutsFile string
+	var webhookFailOpen bool
+	var metricsSecure bool
+	var metricsCertDir string
+	var metricsClientCA string
+	var metricsTokenReview bool
+	var drainTimeout time.Duration
+	var conversionCleanupCRDs string
+	var conversionCleanupInterval time.Duration
+	var conversionCleanupQPS float64
+	var conversionCleanupBatchSize int64
+	var apiPort int
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	flag.BoolVar(&metricsSecure, "metrics-secure", false,
+		"Serve the metrics endpoint over HTTPS, with the certificate of --metrics-cert-dir or a self-signed one.")
+	flag.StringVar(&metricsCertDir, "metrics-cert-dir", "",
+		"The directory that contains the tls.crt and tls.key of the metrics endpoint.")
+	flag.StringVar(&metricsClientCA, "metrics-client-ca", "",
+		"PEM bundle verifying the client certificates of metrics scrapers. Requires --metrics-secure.")
+	flag.BoolVar(&metricsTokenReview, "metrics-token-review", false,
+		"Authenticate metrics scrapers by their bearer token with TokenReviews. Requires --metrics-secure.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook server binds to.")
+	flag.IntVar(&apiPort, "api-port", 0, "The port the REST API server binds to. Disabled if 0.")
 	flag.StringVar(&certDir, "cert-dir", "/tmp/k8s-webhook-server/serving-certs", "The directory that contains the server key and certificate.")
 	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 3, "Maximum number of concurrent reconciles.")
 	flag.DurationVar(&requeueDuration, "requeue-duration", 5*time.Minute, "Duration after which to requeue successful reconciliations.")
+	flag.DurationVar(&drainTimeout, "drain-timeout", 30*time.Second,
+		"On shutdown, how long in-flight reconciles and migrations are waited for before the remaining work is checkpointed.")
 	flag.BoolVar(&printVersion, "version", false, "Print version information and exit.")
 	flag.StringVar(&namespace, "namespace", "", "Namespace to watch for resources. If empty, all namespaces are watched.")
 	flag.StringVar(&watchNamespace, "watch-namespace", "", "Namespace to watch for resources. If empty, all namespaces are watched.")
+	flag.StringVar(&siemType, "siem-type", "", "SIEM backend for audit and platform event export (splunk, elastic, syslog). Disabled if empty.")
+	flag.StringVar(&siemEndpoint, "siem-endpoint", "", "SIEM endpoint: Splunk HEC or Elasticsearch URL, or syslog host:port.")
+	flag.StringVar(&siemFormat, "siem-format", "json", "Format of exported SIEM records (json, cef).")
+	flag.StringVar(&siemIndex, "siem-index", "gunj-operator", "Splunk or Elasticsearch index for exported records.")
+	flag.IntVar(&siemBatchSize, "siem-batch-size", 100, "Maximum number of records sent to the SIEM per request.")
+	flag.DurationVar(&siemFlushInterval, "siem-flush-interval", 5*time.Second, "Maximum time records are buffered before being sent to the SIEM.")
+	flag.IntVar(&siemMaxRetries, "siem-max-retries", 3, "Number of retries for a failed SIEM batch.")
+	flag.StringVar(&cacheLabelSelector, "cache-label-selector", "",
+		"Label selector restricting the cached objects of the --cache-selector-kinds. "+
+			"Objects outside the selector are invisible to the operator. Everything is cached if empty.")
+	flag.StringVar(&cacheFieldSelector, "cache-field-selector", "",
+		"Field selector restricting the cached objects of the --cache-selector-kinds.")
+	flag.StringVar(&cacheSelectorKinds, "cache-selector-kinds", strings.Join(cacheconfig.DefaultSelectorKinds, ","),
+		"Comma separated kinds the cache selectors apply to ("+strings.Join(cacheconfig.Kinds(), ", ")+").")
+	flag.StringVar(&cacheExcludeKinds, "cache-exclude-kinds", "",
+		"Comma separated kinds never cached: they are read from the API server and not watched.")
+	flag.BoolVar(&cacheStripManagedFields, "cache-strip-managed-fields", true, "Drop the managed fields of the cached objects.")
+	flag.StringVar(&cacheStripAnnotations, "cache-strip-annotations", strings.Join(cacheconfig.DefaultStripAnnotations, ","),
+		"Comma separated annotations dropped from the cached objects. They are restored from the API server before an update.")
+	flag.IntVar(&cacheMaxAnnotationBytes, "cache-max-annotation-bytes", 0,
+		"Drop the annotations of the cached objects larger than this many bytes. No limit if 0.")
+	flag.DurationVar(&cacheSizeReportInterval, "cache-size-report-interval", 5*time.Minute,
+		"Interval between estimates of the cache size by kind, exported as gunj_operator_cache_bytes. Disabled if 0.")
+	flag.StringVar(&conversionKeyringSecret, "conversion-keyring-secret", "",
+		"namespace/name of the Secret holding the keys encrypting the data preserved by the conversion webhook. Stored in plaintext if empty.")
+	flag.StringVar(&conversionVaultTransitKey, "conversion-vault-transit-key", "",
+		"Vault transit key, as mount/key, wrapping the keys encrypting the data preserved by the conversion webhook, in place of --conversion-keyring-secret.")
+	flag.StringVar(&conversionVaultAddress, "conversion-vault-address", os.Getenv("VAULT_ADDR"),
+		"Address of Vault for --conversion-vault-transit-key.")
+	flag.StringVar(&conversionVaultTokenFile, "conversion-vault-token-file", "/var/run/secrets/vault/token",
+		"File with the Vault token for --conversion-vault-transit-key, re-read on every request.")
+	flag.BoolVar(&webhookFailOpen, "webhook-fail-open", false,
+		"The validation webhooks are deployed with failurePolicy Ignore: re-validate platforms before reconciling them and hold back invalid ones.")
+	flag.StringVar(&conversionCleanupCRDs, "conversion-cleanup-crds", "observabilityplatforms.observability.io",
+		"Comma separated CRDs whose legacy conversion annotations and labels are removed once all objects are in the storage version. Disabled if empty.")
+	flag.DurationVar(&conversionCleanupInterval, "conversion-cleanup-interval", time.Hour, "Interval between cleanups of the conversion metadata.")
+	flag.Float64Var(&conversionCleanupQPS, "conversion-cleanup-qps", conversioncleanup.DefaultQPS, "Maximum number of objects patched per second by the conversion metadata cleanup.")
+	flag.Int64Var(&conversionCleanupBatchSize, "conversion-cleanup-batch-size", conversioncleanup.DefaultBatchSize,
+		"Number of objects listed at once by the conversion metadata cleanup.")
+	flag.StringVar(&preservationPolicyFile, "preservation-policy-file", "",
+		"YAML file with the data preservation PolicyConfig, including the redaction rules of reports, dry-run diffs and logs. Defaults apply if empty.")
+	flag.StringVar(&templateValuesFile, "template-values-file", "",
+		"YAML file with the values of the template variables of platform specs, like clusterName and region. Templates fail to render if empty.")
+	flag.StringVar(&admissionFeatureGates, "admission-feature-gates", "",
+		"Feature gates of the admission plugins of platforms, e.g. ZoneValidation=false,OrgPolicy=true.")
+	flag.StringVar(&admissionCalloutsFile, "admission-callouts-file", "",
+		"YAML file with the HTTP callouts validating platforms after the built-in admission plugins. None if empty.")
 
 	opts := zap.Options{
 		Development: true,
@@ -89,8 +201,34 @@ func main() {
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	// Set up logging
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	// Set up logging, with sensitive values redacted
+	policyConfig, err := loadPolicyConfig(preservationPolicyFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid --preservation-policy-file: %v\n", err)
+		os.Exit(1)
+	}
+	redactor, err := policyConfig.Redactor()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid --preservation-policy-file: %v\n", err)
+		os.Exit(1)
+	}
+	redaction.SetDefault(redactor)
+	templateValues, err := loadTemplateValues(templateValuesFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid --template-values-file: %v\n", err)
+		os.Exit(1)
+	}
+	admissionGates, err := plugins.ParseGates(admissionFeatureGates)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid --admission-feature-gates: %v\n", err)
+		os.Exit(1)
+	}
+	admissionCallouts, err := loadAdmissionCallouts(admissionCalloutsFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid --admission-callouts-file: %v\n", err)
+		os.Exit(1)
+	}
+	ctrl.SetLogger(redaction.Logger(zap.New(zap.UseFlagOptions(&opts)), redactor))
 	klog.SetLogger(klogr.New())
 
 	// Print version information
@@ -114,28 +252,156 @@ func main() {
 	// Get REST config
 	restConfig := ctrl.GetConfigOrDie()
 
+	// Initialize metrics collector
+	metricsCollector := metrics.NewCollector()
+
+	// Configure the cache
+	ns := namespace
+	if watchNamespace != "" {
+		ns = watchNamespace
+	}
+	cacheConfig := cacheconfig.Config{
+		Namespace:     ns,
+		LabelSelector: cacheLabelSelector,
+		FieldSelector: cacheFieldSelector,
+		SelectorKinds: cacheconfig.ParseKinds(cacheSelectorKinds),
+		ExcludeKinds:  cacheconfig.ParseKinds(cacheExcludeKinds),
+
+		StripManagedFields: cacheStripManagedFields,
+		StripAnnotations:   splitList(cacheStripAnnotations),
+		MaxAnnotationBytes: cacheMaxAnnotationBytes,
+		RecordStripped:     metricsCollector.RecordCacheStripped,
+	}
+	cacheOptions, err := getCacheOptions(cacheConfig)
+	if err != nil {
+		setupLog.Error(err, "invalid cache configuration")
+		os.Exit(1)
+	}
+	clientOptions, err := cacheConfig.ClientOptions()
+	if err != nil {
+		setupLog.Error(err, "invalid cache configuration")
+		os.Exit(1)
+	}
+
+	// Configure the metrics endpoint: scrapers authenticated by client
+	// certificate or token must be allowed to get /metrics by RBAC
+	metricsOptions := metricsserver.Options{
+		BindAddress:   metricsAddr,
+		SecureServing: metricsSecure,
+		CertDir:       metricsCertDir,
+	}
+	metricsAuth := metricsauth.Options{
+		ClientCAFile: metricsClientCA,
+		TokenReview:  metricsTokenReview,
+	}
+	if metricsAuth.Enabled() && !metricsSecure {
+		setupLog.Error(nil, "--metrics-client-ca and --metrics-token-review require --metrics-secure")
+		os.Exit(1)
+	}
+	if metricsSecure {
+		metricsOptions.TLSOpts, err = metricsAuth.TLSOpts()
+		if err != nil {
+			setupLog.Error(err, "invalid metrics TLS configuration")
+			os.Exit(1)
+		}
+	}
+	if metricsAuth.Enabled() {
+		metricsOptions.FilterProvider = metricsAuth.FilterProvider()
+	}
+
+	// Track the in-flight work drained on shutdown
+	drainCoordinator := drain.NewCoordinator(ctrl.Log.WithName("drain"))
+
+	// Track the readiness of the subsystems, reported by /readyz?verbose
+	readinessTracker := readiness.NewTracker()
+	readinessTracker.Register("cache-sync")
+	readinessTracker.Register("managers")
+	readinessTracker.Register("version-catalog")
+
 	// Set up manager options
 	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
-		Scheme: scheme,
-		Metrics: metricsserver.Options{
-			BindAddress: metricsAddr,
-		},
+		Scheme:  scheme,
+		Metrics: metricsOptions,
 		WebhookServer: webhook.NewServer(webhook.Options{
 			Port:    webhookPort,
 			CertDir: certDir,
 		}),
-		HealthProbeBindAddress: probeAddr,
+		// The probes are served by the readiness server below
+		HealthProbeBindAddress: "0",
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "gunj-operator.observability.io",
-		Cache:                  getCacheOptions(namespace, watchNamespace),
+		Cache:                  cacheOptions,
+		Client:                 clientOptions,
+		NewClient:              cacheConfig.NewClient,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
-	// Initialize metrics collector
-	metricsCollector := metrics.NewCollector()
+	// Report the size of the cached kinds the controllers watch
+	if cacheSizeReportInterval > 0 {
+		lists := cacheConfig.Lists("configmaps", "secrets", "services", "persistentvolumeclaims", "jobs")
+		lists["observabilityplatforms"] = func() client.ObjectList { return &observabilityv1beta1.ObservabilityPlatformList{} }
+		if err := mgr.Add(&cacheconfig.SizeReporter{
+			Reader:   mgr.GetCache(),
+			Lists:    lists,
+			Interval: cacheSizeReportInterval,
+			Record:   metricsCollector.RecordCacheSize,
+			Log:      ctrl.Log.WithName("cache-size"),
+		}); err != nil {
+			setupLog.Error(err, "unable to add cache size reporter")
+			os.Exit(1)
+		}
+	}
+
+	// Set up SIEM export of platform events. The token is read from the
+	// environment so it does not show up in the pod spec arguments.
+	var siemExporter *siem.Exporter
+	if siemType != "" {
+		siemExporter, err = siem.NewExporter(siem.Config{
+			Type:          siem.SinkType(siemType),
+			Endpoint:      siemEndpoint,
+			Token:         os.Getenv("SIEM_TOKEN"),
+			Index:         siemIndex,
+			Network:       os.Getenv("SIEM_SYSLOG_NETWORK"),
+			Format:        siem.Format(siemFormat),
+			BatchSize:     siemBatchSize,
+			FlushInterval: siemFlushInterval,
+			MaxRetries:    siemMaxRetries,
+		}, ctrl.Log.WithName("siem"))
+		if err != nil {
+			setupLog.Error(err, "unable to create SIEM exporter")
+			os.Exit(1)
+		}
+		if err := mgr.Add(siemExporter); err != nil {
+			setupLog.Error(err, "unable to add SIEM exporter")
+			os.Exit(1)
+		}
+		setupLog.Info("SIEM export enabled", "type", siemType, "format", siemFormat)
+	}
+
+	// Serve the REST API, auditing its requests to the SIEM
+	if apiPort != 0 {
+		apiServer := api.NewServer(mgr.GetClient(), ctrl.Log, &api.Config{
+			Port:            apiPort,
+			RateLimitRPS:    100,
+			ReadTimeout:     30 * time.Second,
+			WriteTimeout:    30 * time.Second,
+			IdleTimeout:     2 * time.Minute,
+			ShutdownTimeout: drainTimeout,
+			SIEMExporter:    siemExporter,
+		})
+		// Stream the status changes of the platforms seen by the manager's cache
+		if err := apiServer.WatchPlatforms(context.Background(), mgr.GetCache()); err != nil {
+			setupLog.Error(err, "unable to watch platforms for the API server")
+			os.Exit(1)
+		}
+		if err := mgr.Add(apiServer); err != nil {
+			setupLog.Error(err, "unable to add API server")
+			os.Exit(1)
+		}
+	}
 
 	// Create manager factory with REST config for Helm support
 	managerFactory := managers.NewDefaultManagerFactoryWithConfig(mgr.GetClient(), mgr.GetScheme(), restConfig)
@@ -157,72 +423,320 @@ func main() {
 		LokiManager:             lokiManager,
 		TempoManager:            tempoManager,
 		Metrics:                 metricsCollector,
+		SIEMExporter:            siemExporter,
 		MaxConcurrentReconciles: maxConcurrentReconciles,
 		RequeueDuration:         requeueDuration,
+		CacheConfig:             cacheConfig,
+		APIReader:               mgr.GetAPIReader(),
+		RevalidateAdmission:     webhookFailOpen,
+		Drain:                   drainCoordinator,
+		TemplateValues:          templateValues,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ObservabilityPlatform")
 		os.Exit(1)
 	}
+	readinessTracker.SetReady("managers")
+
+	// Issue and rotate the keys of the ApiKeys
+	if err = (&controllers.ApiKeyReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Log:    ctrl.Log.WithName("controllers").WithName("ApiKey"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ApiKey")
+		os.Exit(1)
+	}
+
+	// Apply TempoConfigs to the Tempo of their target platform
+	if err = (&controllers.TempoConfigReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Log:    ctrl.Log.WithName("controllers").WithName("TempoConfig"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TempoConfig")
+		os.Exit(1)
+	}
+	if err = (&controllers.LokiConfigReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Log:    ctrl.Log.WithName("controllers").WithName("LokiConfig"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "LokiConfig")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.PlatformLoadTestReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Log:    ctrl.Log.WithName("controllers").WithName("PlatformLoadTest"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PlatformLoadTest")
+		os.Exit(1)
+	}
+
+	// Load the catalog of the upgrade hooks of each version transition
+	if _, err := upgradehooks.Default(); err != nil {
+		// Don't fail the manager: only upgrades need the catalog
+		setupLog.Error(err, "unable to load the upgrade hook catalog")
+		readinessTracker.SetNotReady("version-catalog", err)
+	} else {
+		readinessTracker.SetReady("version-catalog")
+	}
+
+	// Set up slow-query reporting for platforms with the Prometheus query log enabled
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to create clientset")
+		os.Exit(1)
+	}
+	if err := mgr.Add(&controllers.QueryLogAnalyzer{
+		Client:    mgr.GetClient(),
+		Clientset: clientset,
+		Scheme:    mgr.GetScheme(),
+		Metrics:   metricsCollector,
+		Log:       ctrl.Log.WithName("query-log-analyzer"),
+	}); err != nil {
+		setupLog.Error(err, "unable to add query log analyzer")
+		os.Exit(1)
+	}
+	if err := mgr.Add(&controllers.NoisyAlertAnalyzer{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Metrics: metricsCollector,
+		Log:     ctrl.Log.WithName("noisy-alert-analyzer"),
+	}); err != nil {
+		setupLog.Error(err, "unable to add noisy alert analyzer")
+		os.Exit(1)
+	}
+	if err := mgr.Add(&controllers.CapacityPlanner{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("capacity-planner"),
+	}); err != nil {
+		setupLog.Error(err, "unable to add capacity planner")
+		os.Exit(1)
+	}
+
+	// Set up dashboard imports for platforms with Grafana dashboards in Git
+	if err := mgr.Add(&controllers.DashboardGitSync{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("dashboard-git-sync"),
+	}); err != nil {
+		setupLog.Error(err, "unable to add dashboard Git sync")
+		os.Exit(1)
+	}
+
+	// Set up the scheduled Grafana reports
+	if err := mgr.Add(&controllers.GrafanaReports{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("grafana-reports"),
+	}); err != nil {
+		setupLog.Error(err, "unable to add Grafana reports")
+		os.Exit(1)
+	}
+
+	// Set up the installation of monitoring mixins
+	if err := mgr.Add(&controllers.MixinSync{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Log:    ctrl.Log.WithName("mixin-sync"),
+	}); err != nil {
+		setupLog.Error(err, "unable to add mixin sync")
+		os.Exit(1)
+	}
+
+	// Set up the installation of the builtin platform catalog
+	if err := mgr.Add(&controllers.CatalogInstaller{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("catalog-installer"),
+	}); err != nil {
+		setupLog.Error(err, "unable to add catalog installer")
+		os.Exit(1)
+	}
+
+	// Set up the removal of the conversion metadata after migrations
+	if crds := splitList(conversionCleanupCRDs); len(crds) > 0 {
+		if err := mgr.Add(&controllers.ConversionCleanup{
+			APIReader: mgr.GetAPIReader(),
+			Client:    mgr.GetClient(),
+			CRDs:      crds,
+			Config: conversioncleanup.Config{
+				BatchSize: conversionCleanupBatchSize,
+				QPS:       conversionCleanupQPS,
+			},
+			Interval: conversionCleanupInterval,
+			Log:      ctrl.Log.WithName("conversion-cleanup"),
+		}); err != nil {
+			setupLog.Error(err, "unable to add conversion cleanup")
+			os.Exit(1)
+		}
+	}
+
+	// Set up the staged rollouts of the ingesters
+	if err := mgr.Add(&controllers.StagedRollouts{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("staged-rollouts"),
+	}); err != nil {
+		setupLog.Error(err, "unable to add staged rollouts")
+		os.Exit(1)
+	}
 
 	// Set up webhooks
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		// Add the callouts and feature gates to the admission plugins
+		if err = observabilityv1beta1.ConfigureAdmission(admissionGates, admissionCallouts); err != nil {
+			setupLog.Error(err, "unable to configure the admission plugins")
+			os.Exit(1)
+		}
+		for _, plugin := range observabilityv1beta1.AdmissionPlugins() {
+			setupLog.V(1).Info("Admission plugin", "name", plugin.Name, "order", plugin.Order,
+				"gate", plugin.Gate, "enabled", plugin.Enabled)
+		}
 		if err = (&observabilityv1beta1.ObservabilityPlatform{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "ObservabilityPlatform")
 			os.Exit(1)
 		}
+		if err = (&observabilityv1beta1.TempoConfig{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "TempoConfig")
+			os.Exit(1)
+		}
+		if err = (&observabilityv1beta1.LokiConfig{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "LokiConfig")
+			os.Exit(1)
+		}
 		
+		// Encrypt the data preserved in conversion annotations
+		if conversionKeyringSecret != "" && conversionVaultTransitKey != "" {
+			setupLog.Error(nil, "--conversion-keyring-secret and --conversion-vault-transit-key are mutually exclusive")
+			os.Exit(1)
+		}
+		if conversionKeyringSecret != "" {
+			keyringNamespace, keyringName, ok := strings.Cut(conversionKeyringSecret, "/")
+			if !ok {
+				setupLog.Error(nil, "--conversion-keyring-secret must be namespace/name", "value", conversionKeyringSecret)
+				os.Exit(1)
+			}
+			conversion.SetAnnotationEncryption(encryption.NewEnvelope(&encryption.SecretKeyring{
+				Reader: mgr.GetAPIReader(),
+				Secret: types.NamespacedName{Namespace: keyringNamespace, Name: keyringName},
+			}))
+			setupLog.Info("Encrypting preserved conversion data", "keyring", conversionKeyringSecret)
+		}
+		if conversionVaultTransitKey != "" {
+			mount, key, err := encryption.ParseVaultTransitKey(conversionVaultTransitKey)
+			if err != nil {
+				setupLog.Error(err, "invalid --conversion-vault-transit-key")
+				os.Exit(1)
+			}
+			if conversionVaultAddress == "" {
+				setupLog.Error(nil, "--conversion-vault-address is required with --conversion-vault-transit-key")
+				os.Exit(1)
+			}
+			conversion.SetAnnotationEncryption(encryption.NewEnvelope(&encryption.VaultTransit{
+				Address:   conversionVaultAddress,
+				Mount:     mount,
+				Key:       key,
+				TokenFile: conversionVaultTokenFile,
+			}))
+			setupLog.Info("Encrypting preserved conversion data", "vaultTransitKey", conversionVaultTransitKey)
+		}
+
 		// Set up conversion webhook
 		if err = webhooks.SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create conversion webhook")
 			os.Exit(1)
 		}
 		setupLog.Info("Conversion webhook enabled")
+
+		readinessTracker.AddCheck("webhook-cert", readiness.All(
+			readiness.CertificateChecker(filepath.Join(certDir, "tls.crt"), filepath.Join(certDir, "tls.key")),
+			mgr.GetWebhookServer().StartedChecker(),
+		))
 	}
 
 	//+kubebuilder:scaffold:builder
 
-	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up health check")
-		os.Exit(1)
-	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up ready check")
-		os.Exit(1)
+	// On SIGTERM, drain the in-flight work before stopping the manager. A
+	// second signal exits immediately.
+	signalCtx := ctrl.SetupSignalHandler()
+	ctx, stopManager := context.WithCancel(context.Background())
+	go func() {
+		<-signalCtx.Done()
+		setupLog.Info("Shutting down, draining in-flight work", "timeout", drainTimeout)
+		readinessTracker.SetNotReady("shutdown", errors.New("shutting down"))
+		drainCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+		if err := drainCoordinator.Shutdown(drainCtx); err != nil {
+			setupLog.Error(err, "graceful shutdown incomplete")
+		}
+		cancel()
+		stopManager()
+	}()
+
+	// Serve the health probes before the manager starts, so they answer
+	// while the caches sync
+	probeServer := &readiness.Server{
+		Addr:     probeAddr,
+		Tracker:  readinessTracker,
+		Liveness: map[string]healthz.Checker{"healthz": healthz.Ping},
+		Log:      ctrl.Log.WithName("health-probes"),
 	}
+	go func() {
+		if err := probeServer.Start(ctx); err != nil {
+			setupLog.Error(err, "problem running health probe server")
+			os.Exit(1)
+		}
+	}()
+	go func() {
+		if mgr.GetCache().WaitForCacheSync(ctx) {
+			readinessTracker.SetReady("cache-sync")
+		}
+	}()
 
 	// Start a goroutine to log memory stats periodically
 	go logMemoryStats()
 
 	setupLog.Info("starting manager")
-	ctx := ctrl.SetupSignalHandler()
 	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
 
-// getCacheOptions returns cache options based on namespace configuration
-func getCacheOptions(namespace, watchNamespace string) cache.Options {
-	opts := cache.Options{}
-
-	// Determine which namespace to watch
-	ns := namespace
-	if watchNamespace != "" {
-		ns = watchNamespace
+// getCacheOptions returns cache options based on the cache configuration
+func getCacheOptions(config cacheconfig.Config) (cache.Options, error) {
+	opts, err := config.CacheOptions()
+	if err != nil {
+		return opts, err
 	}
 
-	if ns != "" {
+	if config.Namespace != "" {
 		// Watch a specific namespace
-		opts.DefaultNamespaces = map[string]cache.Config{
-			ns: {},
-		}
-		setupLog.Info("Watching namespace", "namespace", ns)
+		setupLog.Info("Watching namespace", "namespace", config.Namespace)
 	} else {
 		// Watch all namespaces
 		setupLog.Info("Watching all namespaces")
 	}
+	if config.LabelSelector != "" || config.FieldSelector != "" {
+		setupLog.Info("Restricting the cache",
+			"labelSelector", config.LabelSelector,
+			"fieldSelector", config.FieldSelector,
+			"kinds", config.SelectorKinds)
+	}
+	if len(config.ExcludeKinds) > 0 {
+		setupLog.Info("Excluding kinds from the cache", "kinds", config.ExcludeKinds)
+	}
 
-	return opts
+	return opts, nil
+}
+
+// splitList splits a comma separated list, dropping empty elements
+func splitList(list string) []string {
+	var elements []string
+	for _, element := range strings.Split(list, ",") {
+		if element = strings.TrimSpace(element); element != "" {
+			elements = append(elements, element)
+		}
+	}
+	return elements
 }
 
 // logMemoryStats logs memory statistics periodically for debugging
@@ -242,3 +756,42 @@ func logMemoryStats() {
 		)
 	}
 }
+
+// loadPolicyConfig reads the data preservation PolicyConfig of a file, the
+// default one if path is empty
+func loadPolicyConfig(path string) (*preservation.PolicyConfig, error) {
+	if path == "" {
+		return preservation.LoadPolicyConfig(nil)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return preservation.LoadPolicyConfig(data)
+}
+
+// loadAdmissionCallouts reads the HTTP callouts of the admission plugins of
+// a file, none if path is empty
+func loadAdmissionCallouts(path string) ([]plugins.CalloutConfig, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return plugins.LoadCallouts(data)
+}
+
+// loadTemplateValues reads the values of the template variables of a file,
+// none if path is empty
+func loadTemplateValues(path string) (templatevars.Values, error) {
+	if path == "" {
+		return templatevars.Values{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return templatevars.Values{}, err
+	}
+	return templatevars.Load(data)
+}
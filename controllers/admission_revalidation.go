@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// revalidateAdmission validates the platform like the validation webhook
+// does on create, since a webhook failing open admits platforms while the
+// operator is down. It reports the result in the Validated condition and
+// returns whether the platform may be reconciled. Once a generation is
+// validated it is not validated again.
+func (r *ObservabilityPlatformReconciler) revalidateAdmission(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) (bool, error) {
+	if !r.RevalidateAdmission {
+		return true, nil
+	}
+	condition := meta.FindStatusCondition(platform.Status.Conditions, ConditionValidated)
+	if condition != nil && condition.ObservedGeneration == platform.Generation && condition.Status == metav1.ConditionTrue {
+		return true, nil
+	}
+
+	// Validate a defaulted copy, as the mutating webhook fails open too and
+	// the platform's validators may default its fields. The defaults are not
+	// reconciled, so those of base platforms aren't overridden.
+	candidate := platform.DeepCopy()
+	err := candidate.SetDefaults(ctx)
+	if err == nil {
+		_, err = candidate.ValidateCreate()
+	}
+	switch {
+	case err == nil:
+		if err := r.StatusManager.SetCondition(ctx, platform, ConditionValidated,
+			metav1.ConditionTrue, ReasonValidated, "The platform passed admission validation"); err != nil {
+			return false, err
+		}
+		return true, nil
+
+	case errors.IsInvalid(err):
+		log.FromContext(ctx).Info("Platform admitted while the validation webhook failed open is invalid", "error", err.Error())
+		if condition == nil || condition.ObservedGeneration != platform.Generation || condition.Reason != ReasonValidationFailed {
+			r.EventRecorder.RecordPlatformEvent(platform, EventReasonConfigValidationFailed,
+				fmt.Sprintf("Platform admitted without validation is invalid, the last valid configuration stays applied: %v", err))
+		}
+		return false, r.StatusManager.SetCondition(ctx, platform, ConditionValidated,
+			metav1.ConditionFalse, ReasonValidationFailed, err.Error())
+
+	default:
+		// Validation could not complete, e.g. a defaulter or a quota lookup
+		// failed or a callout was unreachable
+		if setErr := r.StatusManager.SetCondition(ctx, platform, ConditionValidated,
+			metav1.ConditionUnknown, ReasonValidationPending, fmt.Sprintf("Validation could not complete: %v", err)); setErr != nil {
+			return false, setErr
+		}
+		return false, fmt.Errorf("failed to validate platform: %w", err)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/alertmanager"
+)
+
+// reconcileAlertmanagerConfig renders the receivers, routes, inhibit rules
+// and mute time intervals of the platform into the configuration Secret of
+// its Alertmanager, and removes the Secret when Alertmanager is disabled.
+// The configuration holds receiver credentials, so it is kept in a Secret.
+func (r *ObservabilityPlatformReconciler) reconcileAlertmanagerConfig(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx).WithValues("alertmanager", "config")
+
+	name := alertmanager.ConfigSecretName(platform.Name)
+	alerting := platform.Spec.Alerting
+	if alerting == nil || alerting.Alertmanager == nil || !alerting.Alertmanager.Enabled {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: platform.Namespace}}
+		if err := r.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete Alertmanager configuration: %w", err)
+		}
+		return nil
+	}
+
+	config, err := alertmanager.Render(alerting)
+	if err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: platform.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels["app.kubernetes.io/name"] = "alertmanager"
+		secret.Labels["app.kubernetes.io/instance"] = platform.Name
+		secret.Labels["app.kubernetes.io/managed-by"] = "gunj-operator"
+		secret.Labels["observability.io/platform"] = platform.Name
+		secret.Data = map[string][]byte{alertmanager.ConfigKey: []byte(config)}
+		return controllerutil.SetControllerReference(platform, secret, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile Alertmanager configuration: %w", err)
+	}
+
+	log.V(1).Info("Alertmanager configuration reconciled", "secret", name,
+		"inhibitRules", len(alerting.InhibitRules), "muteTimeIntervals", len(alerting.MuteTimeIntervals))
+	return nil
+}
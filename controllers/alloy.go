@@ -0,0 +1,206 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/alloy"
+	"github.com/gunjanjp/gunj-operator/internal/correlation"
+	"github.com/gunjanjp/gunj-operator/internal/managers"
+	"github.com/gunjanjp/gunj-operator/internal/profiling"
+)
+
+// reconcileAlloy deploys Grafana Alloy on every node with a configuration
+// wiring the enabled pipelines to the platform's backends, and removes it
+// when Alloy is disabled
+func (r *ObservabilityPlatformReconciler) reconcileAlloy(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx).WithValues("alloy", "reconcile")
+
+	components := platform.Spec.Components
+	if !components.AlloyEnabled() {
+		return r.deleteAlloy(ctx, platform)
+	}
+	spec := components.GrafanaAlloy
+
+	collector := alloy.Collector{
+		Name:      alloy.CollectorName(platform.Name),
+		Namespace: platform.Namespace,
+		Version:   spec.GetVersion(),
+		Resources: spec.Resources,
+		Config:    alloyConfig(platform),
+		Labels: map[string]string{
+			"app.kubernetes.io/name":       "alloy",
+			"app.kubernetes.io/instance":   platform.Name,
+			"app.kubernetes.io/managed-by": "gunj-operator",
+			"app.kubernetes.io/part-of":    "observability-platform",
+			"observability.io/platform":    platform.Name,
+		},
+	}
+
+	desiredConfig := alloy.BuildConfigMap(collector)
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: desiredConfig.Name, Namespace: desiredConfig.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		cm.Labels = desiredConfig.Labels
+		cm.Data = desiredConfig.Data
+		return controllerutil.SetControllerReference(platform, cm, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile Alloy config: %w", err)
+	}
+
+	desiredSA := alloy.BuildServiceAccount(collector)
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: desiredSA.Name, Namespace: desiredSA.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, sa, func() error {
+		sa.Labels = desiredSA.Labels
+		return controllerutil.SetControllerReference(platform, sa, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile Alloy service account: %w", err)
+	}
+
+	// The ClusterRole and its binding are cluster scoped and cannot be owned
+	// by the platform; they carry tracking labels and are removed by
+	// deleteAlloy
+	name := alloy.ClusterRoleName(platform.Name, platform.Namespace)
+	desiredRole := alloy.BuildClusterRole(name, collector.Labels)
+	role := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, role, func() error {
+		role.Labels = desiredRole.Labels
+		role.Rules = desiredRole.Rules
+		return r.setOwner(platform, role)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile Alloy cluster role: %w", err)
+	}
+	desiredBinding := alloy.BuildClusterRoleBinding(name, collector)
+	binding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, binding, func() error {
+		binding.Labels = desiredBinding.Labels
+		// The role reference is immutable
+		if binding.CreationTimestamp.IsZero() {
+			binding.RoleRef = desiredBinding.RoleRef
+		}
+		binding.Subjects = desiredBinding.Subjects
+		return r.setOwner(platform, binding)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile Alloy cluster role binding: %w", err)
+	}
+
+	desired := alloy.BuildDaemonSet(collector)
+	// Restart the collectors when the configuration changes. The log level
+	// is left out: a new level is applied by reloading the collectors, see
+	// reconcileReloads.
+	hashed := collector.Config
+	hashed.LogLevel = ""
+	desired.Spec.Template.Annotations = map[string]string{
+		"observability.io/config-hash": fmt.Sprintf("%x", sha256.Sum256([]byte(alloy.Render(hashed)))),
+	}
+	ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, ds, func() error {
+		ds.Labels = desired.Labels
+		// The selector is immutable, so only set it on creation
+		if ds.CreationTimestamp.IsZero() {
+			ds.Spec.Selector = desired.Spec.Selector
+		}
+		ds.Spec.Template = desired.Spec.Template
+		return controllerutil.SetControllerReference(platform, ds, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile Alloy daemon set: %w", err)
+	}
+
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: collector.Name, Namespace: collector.Namespace}}
+	if collector.Config.Traces == nil {
+		if err := r.Delete(ctx, svc); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete Alloy service: %w", err)
+		}
+	} else {
+		desiredSvc := alloy.BuildService(collector)
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, svc, func() error {
+			svc.Labels = desiredSvc.Labels
+			svc.Spec.Selector = desiredSvc.Spec.Selector
+			svc.Spec.Ports = desiredSvc.Spec.Ports
+			svc.Spec.InternalTrafficPolicy = desiredSvc.Spec.InternalTrafficPolicy
+			return controllerutil.SetControllerReference(platform, svc, r.Scheme)
+		}); err != nil {
+			return fmt.Errorf("failed to reconcile Alloy service: %w", err)
+		}
+	}
+
+	log.V(1).Info("Alloy reconciled", "metrics", collector.Config.Metrics != nil, "logs", collector.Config.Logs != nil,
+		"traces", collector.Config.Traces != nil, "profiles", collector.Config.Profiles != nil)
+	return nil
+}
+
+// alloyConfig returns the pipelines of a platform's Alloy, sending each
+// signal to the platform's backend unless its endpoint is overridden
+func alloyConfig(platform *observabilityv1beta1.ObservabilityPlatform) alloy.Config {
+	components := platform.Spec.Components
+	spec := components.GrafanaAlloy
+
+	pipeline := func(p *observabilityv1beta1.AlloyPipelineSpec, backend bool, endpoint string) *alloy.Pipeline {
+		if !p.IsEnabled(backend) {
+			return nil
+		}
+		result := &alloy.Pipeline{Endpoint: endpoint}
+		if p != nil {
+			result.Namespaces = p.Namespaces
+			if p.Endpoint != "" {
+				result.Endpoint = p.Endpoint
+			}
+		}
+		return result
+	}
+
+	return alloy.Config{
+		Metrics: pipeline(spec.Metrics, components.Prometheus != nil && components.Prometheus.Enabled,
+			alloy.PrometheusEndpoint(platform.Name, platform.Namespace)),
+		Logs: pipeline(spec.Logs, components.Loki != nil && components.Loki.Enabled,
+			alloy.LokiEndpoint(platform.Name, platform.Namespace)),
+		Traces: pipeline(spec.Traces, components.Tempo != nil && components.Tempo.Enabled,
+			alloy.TempoEndpoint(platform.Name, platform.Namespace)),
+		Profiles: pipeline(spec.Profiles, components.ProfilingAcceptsPushes(),
+			profiling.URL(platform.Name, platform.Namespace, profiling.Pyroscope)),
+		ExtraConfig: spec.ExtraConfig,
+		// The correlation labels match the signals with the metrics of the
+		// same source in Prometheus
+		ExternalLabels: correlation.Merge(platform.CorrelationLabels(), map[string]string{
+			correlation.PlatformLabel: platform.Name,
+		}),
+		ResourceAttributes: platform.CorrelationLabels(),
+		TenantID:           platform.TenantID(),
+		LogLevel:           managers.LogLevel(platform, "alloy", spec.ComponentLogging),
+	}
+}
+
+// deleteAlloy removes the Alloy collector of a platform, including its
+// cluster scoped RBAC which is not garbage collected with the platform
+func (r *ObservabilityPlatformReconciler) deleteAlloy(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	roleName := alloy.ClusterRoleName(platform.Name, platform.Namespace)
+	name, namespace := alloy.CollectorName(platform.Name), platform.Namespace
+	for _, obj := range []client.Object{
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: roleName}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: roleName}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+	} {
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete Alloy %T: %w", obj, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/apikeys"
+)
+
+// ApiKeyReconciler issues, rotates, expires and revokes the keys of ApiKeys.
+// A new key is written to the ApiKey's Secret once, and only its hash is
+// kept in the status; the platform controller renders the hashes into the
+// ingest gateway and the API server verifies keys against them.
+type ApiKeyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+}
+
+// +kubebuilder:rbac:groups=observability.io,resources=apikeys,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observability.io,resources=apikeys/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile brings the keys of an ApiKey in line with its spec
+func (r *ApiKeyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("apikey", req.NamespacedName)
+
+	apiKey := &observabilityv1beta1.ApiKey{}
+	if err := r.Get(ctx, req.NamespacedName, apiKey); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !apiKey.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	previous := apiKey.Status
+	var requeueAfter time.Duration
+
+	switch {
+	case apiKey.Spec.Revoked:
+		apiKey.Status.Phase = observabilityv1beta1.ApiKeyPhaseRevoked
+		apiKey.Status.Keys = nil
+		apiKey.Status.Message = "The key was revoked"
+		if err := r.deleteKeySecret(ctx, apiKey); err != nil {
+			return ctrl.Result{}, err
+		}
+
+	case apiKey.Spec.ExpiresAt != nil && !now.Before(apiKey.Spec.ExpiresAt.Time):
+		apiKey.Status.Phase = observabilityv1beta1.ApiKeyPhaseExpired
+		apiKey.Status.Keys = nil
+		apiKey.Status.Message = fmt.Sprintf("The key expired at %s", apiKey.Spec.ExpiresAt.UTC().Format(time.RFC3339))
+		if err := r.deleteKeySecret(ctx, apiKey); err != nil {
+			return ctrl.Result{}, err
+		}
+
+	default:
+		// Issue the first key, or a new one when a rotation is requested
+		if len(apiKey.Status.Keys) == 0 || apiKey.Spec.Rotation != apiKey.Status.ObservedRotation {
+			if err := r.issueKey(ctx, apiKey, now); err != nil {
+				return ctrl.Result{}, err
+			}
+			log.Info("Issued API key", "id", apiKey.Status.Keys[0].ID, "rotation", apiKey.Spec.Rotation)
+		}
+
+		keys, next := apikeys.Prune(apiKey.Status.Keys, now)
+		apiKey.Status.Keys = keys
+		apiKey.Status.Phase = observabilityv1beta1.ApiKeyPhaseActive
+		apiKey.Status.Message = ""
+		if apiKey.Spec.ExpiresAt != nil && (next.IsZero() || apiKey.Spec.ExpiresAt.Time.Before(next)) {
+			next = apiKey.Spec.ExpiresAt.Time
+		}
+		if !next.IsZero() {
+			requeueAfter = next.Sub(now)
+		}
+	}
+
+	if !equality.Semantic.DeepEqual(previous, apiKey.Status) {
+		if err := r.Status().Update(ctx, apiKey); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update API key status: %w", err)
+		}
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// issueKey generates a key, writes it to the ApiKey's Secret and records its
+// hash. The previous keys are accepted for the rotation grace period.
+func (r *ApiKeyReconciler) issueKey(ctx context.Context, apiKey *observabilityv1beta1.ApiKey, now time.Time) error {
+	key, id, err := apikeys.Generate()
+	if err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: apiKey.GetSecretName(), Namespace: apiKey.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		secret.Labels = map[string]string{
+			"app.kubernetes.io/managed-by": "gunj-operator",
+			"observability.io/platform":    apiKey.Spec.Platform,
+			"observability.io/api-key":     apiKey.Name,
+		}
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = map[string][]byte{apikeys.SecretKey: []byte(key)}
+		return controllerutil.SetControllerReference(apiKey, secret, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to write API key secret: %w", err)
+	}
+
+	apiKey.Status.Keys = apikeys.Rotate(apiKey.Status.Keys, apikeys.NewCredential(key, id, now), apiKey.GetRotationGracePeriod(), now)
+	apiKey.Status.ObservedRotation = apiKey.Spec.Rotation
+	apiKey.Status.LastRotationTime = &metav1.Time{Time: now}
+	return nil
+}
+
+// deleteKeySecret removes the Secret of an ApiKey which no longer has keys,
+// if the operator created it
+func (r *ApiKeyReconciler) deleteKeySecret(ctx context.Context, apiKey *observabilityv1beta1.ApiKey) error {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: apiKey.Namespace, Name: apiKey.GetSecretName()}, secret); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(secret, apiKey) {
+		return nil
+	}
+	if err := r.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete API key secret: %w", err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *ApiKeyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&observabilityv1beta1.ApiKey{}).
+		Complete(r)
+}
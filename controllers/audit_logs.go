@@ -0,0 +1,182 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/auditlogs"
+)
+
+// reconcileAuditLogs deploys the collector pushing Kubernetes audit events
+// into the platform's Loki, as a DaemonSet on the control plane nodes for the
+// file source or as a Deployment behind a Service for the webhook source, and
+// removes it when audit logs are disabled
+func (r *ObservabilityPlatformReconciler) reconcileAuditLogs(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx).WithValues("auditLogs", "reconcile")
+
+	components := platform.Spec.Components
+	if !components.AuditLogsEnabled() {
+		return r.deleteAuditLogs(ctx, platform)
+	}
+	spec := components.AuditLogs
+
+	path := spec.Path
+	if path == "" {
+		path = auditlogs.DistributionPath(spec.GetDistribution())
+	}
+	pipeline := auditlogs.Pipeline{
+		Name:            auditlogs.PipelineName(platform.Name),
+		Namespace:       platform.Namespace,
+		Version:         spec.GetVersion(),
+		Platform:        platform.Name,
+		LokiURL:         fmt.Sprintf("http://loki-%s.%s.svc.cluster.local:3100", platform.Name, platform.Namespace),
+		TenantID:        platform.TenantID(),
+		Source:          spec.GetSource(),
+		Path:            path,
+		ExcludeReadOnly: spec.ExcludeReadOnly,
+		ExcludeUsers:    spec.ExcludeUsers,
+		Resources:       spec.Resources,
+		Labels: map[string]string{
+			"app.kubernetes.io/name":       "audit-logs",
+			"app.kubernetes.io/instance":   platform.Name,
+			"app.kubernetes.io/managed-by": "gunj-operator",
+			"app.kubernetes.io/part-of":    "observability-platform",
+			"observability.io/platform":    platform.Name,
+		},
+	}
+
+	desiredConfig, err := auditlogs.BuildConfigMap(pipeline)
+	if err != nil {
+		return err
+	}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: desiredConfig.Name, Namespace: desiredConfig.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		cm.Labels = desiredConfig.Labels
+		cm.Data = desiredConfig.Data
+		return controllerutil.SetControllerReference(platform, cm, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile audit log config: %w", err)
+	}
+	// Restart the collector when the configuration changes
+	configHash := fmt.Sprintf("%x", sha256.Sum256([]byte(desiredConfig.Data[auditlogs.ConfigKey])))
+
+	if pipeline.Source == auditlogs.File {
+		// Remove the webhook collector when switching sources
+		if err := r.deleteAuditObjects(ctx, r.auditWebhookObjects(platform)); err != nil {
+			return err
+		}
+
+		desired := auditlogs.BuildDaemonSet(pipeline)
+		desired.Spec.Template.Annotations = map[string]string{"observability.io/config-hash": configHash}
+		ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, ds, func() error {
+			ds.Labels = desired.Labels
+			// The selector is immutable, so only set it on creation
+			if ds.CreationTimestamp.IsZero() {
+				ds.Spec.Selector = desired.Spec.Selector
+			}
+			ds.Spec.Template = desired.Spec.Template
+			return controllerutil.SetControllerReference(platform, ds, r.Scheme)
+		}); err != nil {
+			return fmt.Errorf("failed to reconcile audit log collector: %w", err)
+		}
+
+		log.V(1).Info("Audit logs reconciled", "source", pipeline.Source, "path", path)
+		return nil
+	}
+
+	// Remove the file collector when switching sources
+	if err := r.deleteAuditObjects(ctx, []client.Object{
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: pipeline.Name, Namespace: pipeline.Namespace}},
+	}); err != nil {
+		return err
+	}
+
+	desired := auditlogs.BuildDeployment(pipeline)
+	desired.Spec.Template.Annotations = map[string]string{"observability.io/config-hash": configHash}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		deployment.Labels = desired.Labels
+		// The selector is immutable, so only set it on creation
+		if deployment.CreationTimestamp.IsZero() {
+			deployment.Spec.Selector = desired.Spec.Selector
+		}
+		deployment.Spec.Replicas = desired.Spec.Replicas
+		deployment.Spec.Template = desired.Spec.Template
+		return controllerutil.SetControllerReference(platform, deployment, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile audit log collector: %w", err)
+	}
+
+	desiredSvc := auditlogs.BuildService(pipeline)
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: desiredSvc.Name, Namespace: desiredSvc.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, svc, func() error {
+		svc.Labels = desiredSvc.Labels
+		svc.Spec.Selector = desiredSvc.Spec.Selector
+		svc.Spec.Ports = desiredSvc.Spec.Ports
+		return controllerutil.SetControllerReference(platform, svc, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile audit log service: %w", err)
+	}
+
+	// The API server does not resolve cluster DNS names, so the kubeconfig of
+	// its audit webhook backend points to the ClusterIP
+	kubeconfig := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: auditlogs.KubeconfigName(platform.Name), Namespace: platform.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, kubeconfig, func() error {
+		kubeconfig.Labels = pipeline.Labels
+		kubeconfig.Data = map[string]string{
+			auditlogs.KubeconfigKey: auditlogs.Kubeconfig(fmt.Sprintf("http://%s:%d", svc.Spec.ClusterIP, auditlogs.WebhookPort)),
+		}
+		return controllerutil.SetControllerReference(platform, kubeconfig, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile audit webhook kubeconfig: %w", err)
+	}
+
+	log.V(1).Info("Audit logs reconciled", "source", pipeline.Source, "clusterIP", svc.Spec.ClusterIP)
+	return nil
+}
+
+// auditWebhookObjects returns the objects of the webhook source collector
+func (r *ObservabilityPlatformReconciler) auditWebhookObjects(platform *observabilityv1beta1.ObservabilityPlatform) []client.Object {
+	name, namespace := auditlogs.PipelineName(platform.Name), platform.Namespace
+	return []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: auditlogs.KubeconfigName(platform.Name), Namespace: namespace}},
+	}
+}
+
+// deleteAuditLogs removes the audit log collector of a platform
+func (r *ObservabilityPlatformReconciler) deleteAuditLogs(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	name, namespace := auditlogs.PipelineName(platform.Name), platform.Namespace
+	return r.deleteAuditObjects(ctx, append(r.auditWebhookObjects(platform),
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+	))
+}
+
+// deleteAuditObjects deletes objects of the audit log collector, ignoring the ones
+// which do not exist
+func (r *ObservabilityPlatformReconciler) deleteAuditObjects(ctx context.Context, objects []client.Object) error {
+	for _, obj := range objects {
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete audit log collector %T: %w", obj, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/sla"
+)
+
+// recordAvailability samples component readiness into the platform's
+// availability history, which backs the monthly SLA reports.
+func (r *ObservabilityPlatformReconciler) recordAvailability(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) {
+	states := make(map[string]bool, len(platform.Status.ComponentStatuses))
+	for component, status := range platform.Status.ComponentStatuses {
+		states[component] = status.Ready
+	}
+
+	history := sla.FromStatus(platform.Status.Availability)
+	history.Record(time.Now(), states, sla.DefaultRetentionMonths)
+	if err := r.StatusManager.ApplyStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
+		status.Availability = sla.ToStatus(history)
+	}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update availability history")
+	}
+}
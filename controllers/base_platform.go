@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/overlay"
+)
+
+// applyBasePlatforms replaces the spec of a platform with its effective spec:
+// its own spec merged onto its chain of base platforms. Specs are read as
+// stored, without the fields the webhook defaulted, so fields the platform
+// does not set are inherited; the effective spec is then defaulted. The
+// applied bases are recorded in status.
+func (r *ObservabilityPlatformReconciler) applyBasePlatforms(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	if platform.Spec.BasePlatformRef == nil {
+		r.setBasePlatforms(ctx, platform, nil)
+		return nil
+	}
+
+	current := overlay.Ref{Namespace: platform.Namespace, Name: platform.Name}
+	own, err := r.rawPlatform(ctx, current)
+	if err != nil {
+		return err
+	}
+	spec, _, _ := unstructured.NestedMap(own.Object, "spec")
+
+	chain := []map[string]interface{}{withoutDefaultedFields(own, spec)}
+	visited := []overlay.Ref{current}
+	var bases []observabilityv1beta1.InheritedBase
+	for {
+		ref, ok := overlay.BaseRef(spec, current.Namespace)
+		if !ok {
+			break
+		}
+		if err := overlay.CheckChain(visited, ref); err != nil {
+			return err
+		}
+		base, err := r.rawPlatform(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("failed to get base platform %s: %w", ref, err)
+		}
+		if ref.Namespace != current.Namespace && base.GetAnnotations()[observabilityv1beta1.SharedBaseAnnotation] != "true" {
+			return fmt.Errorf("base platform %s is in another namespace than %s and not annotated %s: \"true\"",
+				ref, current, observabilityv1beta1.SharedBaseAnnotation)
+		}
+
+		spec, _, _ = unstructured.NestedMap(base.Object, "spec")
+		chain = append(chain, withoutDefaultedFields(base, spec))
+		visited = append(visited, ref)
+		bases = append(bases, observabilityv1beta1.InheritedBase{
+			Name:       ref.Name,
+			Namespace:  ref.Namespace,
+			Generation: base.GetGeneration(),
+		})
+		current = ref
+	}
+
+	data, err := json.Marshal(overlay.Compose(chain))
+	if err != nil {
+		return fmt.Errorf("failed to compose platform spec: %w", err)
+	}
+	merged := platform.DeepCopy()
+	merged.Spec = observabilityv1beta1.ObservabilityPlatformSpec{}
+	if err := json.Unmarshal(data, &merged.Spec); err != nil {
+		return fmt.Errorf("effective spec merged from base platforms is invalid: %w", err)
+	}
+	// Default the fields neither the platform nor its bases set
+	if err := merged.SetDefaults(ctx); err != nil {
+		return fmt.Errorf("failed to default the effective spec: %w", err)
+	}
+	platform.Spec = merged.Spec
+	r.setBasePlatforms(ctx, platform, bases)
+	return nil
+}
+
+// withoutDefaultedFields returns the stored spec of a platform without the
+// fields its defaulting webhook set. A base without a base of its own records
+// none and keeps its defaults, which its overlays inherit.
+func withoutDefaultedFields(obj *unstructured.Unstructured, spec map[string]interface{}) map[string]interface{} {
+	fields := observabilityv1beta1.DefaultedFields(obj)
+	paths := make([][]string, 0, len(fields))
+	for _, f := range fields {
+		paths = append(paths, f.Path)
+	}
+	return overlay.Without(spec, paths)
+}
+
+// setBasePlatforms records the applied base platforms in status
+func (r *ObservabilityPlatformReconciler) setBasePlatforms(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, bases []observabilityv1beta1.InheritedBase) {
+	if err := r.StatusManager.ApplyStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
+		status.BasePlatforms = append([]observabilityv1beta1.InheritedBase(nil), bases...)
+	}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update base platforms")
+	}
+}
+
+// rawPlatform reads a platform as stored
+func (r *ObservabilityPlatformReconciler) rawPlatform(ctx context.Context, ref overlay.Ref) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(observabilityv1beta1.GroupVersion.WithKind("ObservabilityPlatform"))
+	if err := r.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// findPlatformsForBase returns requests for the platforms inheriting from a
+// changed platform, directly or through other bases
+func (r *ObservabilityPlatformReconciler) findPlatformsForBase(obj client.Object) []reconcile.Request {
+	platforms := &observabilityv1beta1.ObservabilityPlatformList{}
+	if err := r.List(context.Background(), platforms); err != nil {
+		r.Log.Error(err, "Failed to list platforms inheriting from base", "base", obj.GetNamespace()+"/"+obj.GetName())
+		return nil
+	}
+
+	inheriting := make(map[overlay.Ref][]overlay.Ref)
+	for _, platform := range platforms.Items {
+		if ref := platform.Spec.BasePlatformRef; ref != nil {
+			base := overlay.Ref{Namespace: platform.Namespace, Name: ref.Name}
+			if ref.Namespace != "" {
+				base.Namespace = ref.Namespace
+			}
+			inheriting[base] = append(inheriting[base], overlay.Ref{Namespace: platform.Namespace, Name: platform.Name})
+		}
+	}
+
+	var requests []reconcile.Request
+	seen := map[overlay.Ref]bool{}
+	pending := []overlay.Ref{{Namespace: obj.GetNamespace(), Name: obj.GetName()}}
+	for len(pending) > 0 {
+		base := pending[0]
+		pending = pending[1:]
+		for _, ref := range inheriting[base] {
+			if seen[ref] {
+				continue
+			}
+			seen[ref] = true
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}})
+			pending = append(pending, ref)
+		}
+	}
+	return requests
+}
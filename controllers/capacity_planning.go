@@ -0,0 +1,213 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/capacity"
+)
+
+// capacityPlanningTick is how often platforms are checked for a due
+// forecast
+const capacityPlanningTick = time.Minute
+
+// CapacityPlanner periodically forecasts the usage of every platform with
+// capacity planning enabled. It reads the daily history of the series, log
+// volume, span volume and storage of the platform from its Prometheus, and
+// records the 30 and 90 day forecasts with the scaling actions they call for
+// in the platform's status. It implements manager.Runnable.
+type CapacityPlanner struct {
+	Client client.Client
+	HTTP   *http.Client
+	Log    logr.Logger
+
+	// lastRun is when each platform was last forecast
+	lastRun map[types.NamespacedName]time.Time
+}
+
+// Start runs the forecast loop until the context is cancelled
+func (p *CapacityPlanner) Start(ctx context.Context) error {
+	p.lastRun = make(map[types.NamespacedName]time.Time)
+	if p.HTTP == nil {
+		p.HTTP = &http.Client{Timeout: time.Minute}
+	}
+
+	ticker := time.NewTicker(capacityPlanningTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			p.forecastAll(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection returns true so only the leader writes forecasts
+func (p *CapacityPlanner) NeedLeaderElection() bool {
+	return true
+}
+
+// forecastAll forecasts the platforms whose analysis interval has elapsed
+func (p *CapacityPlanner) forecastAll(ctx context.Context) {
+	platforms := &observabilityv1beta1.ObservabilityPlatformList{}
+	if err := p.Client.List(ctx, platforms); err != nil {
+		p.Log.Error(err, "Failed to list platforms for capacity planning")
+		return
+	}
+
+	now := time.Now()
+	active := make(map[types.NamespacedName]bool)
+	for i := range platforms.Items {
+		platform := &platforms.Items[i]
+		spec := platform.CapacityPlanning()
+		if !spec.IsEnabled() || !platform.DeletionTimestamp.IsZero() || !componentEnabled(platform, "prometheus") {
+			continue
+		}
+		key := client.ObjectKeyFromObject(platform)
+		active[key] = true
+
+		if last, ok := p.lastRun[key]; ok && now.Sub(last) < spec.GetAnalysisInterval() {
+			continue
+		}
+		if err := p.forecast(ctx, platform, spec, now); err != nil {
+			// The platform is retried on the next tick
+			p.Log.Error(err, "Failed to forecast capacity", "platform", platform.Name, "namespace", platform.Namespace)
+			continue
+		}
+		p.lastRun[key] = now
+	}
+
+	for key := range p.lastRun {
+		if !active[key] {
+			delete(p.lastRun, key)
+		}
+	}
+}
+
+// forecast fits the trends of a platform's usage and records them
+func (p *CapacityPlanner) forecast(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, spec *observabilityv1beta1.CapacityPlanningSpec, now time.Time) error {
+	prometheusURL := fmt.Sprintf("http://prometheus-%s.%s.svc.cluster.local:9090", platform.Name, platform.Namespace)
+	start := now.Add(-spec.GetHistory())
+
+	var usages []capacity.Usage
+	var unforecast []string
+	add := func(usage capacity.Usage, query string) error {
+		points, err := capacity.Fetch(ctx, p.HTTP, prometheusURL, query, start, now)
+		if err != nil {
+			return fmt.Errorf("failed to read %s %s history: %w", usage.Component, usage.Resource, err)
+		}
+		if usage.Trend, err = capacity.Fit(points); err != nil {
+			unforecast = append(unforecast, fmt.Sprintf("%s %s (%v)", usage.Component, usage.Resource, err))
+			return nil
+		}
+		usages = append(usages, usage)
+		return nil
+	}
+
+	if err := add(capacity.Usage{Component: "prometheus", Resource: observabilityv1beta1.CapacityResourceSeries}, capacity.SeriesQuery()); err != nil {
+		return err
+	}
+	if componentEnabled(platform, "loki") {
+		if err := add(capacity.Usage{Component: "loki", Resource: observabilityv1beta1.CapacityResourceLogs}, capacity.LogsQuery(platform.Namespace)); err != nil {
+			return err
+		}
+	}
+	if componentEnabled(platform, "tempo") {
+		if err := add(capacity.Usage{Component: "tempo", Resource: observabilityv1beta1.CapacityResourceSpans}, capacity.SpansQuery(platform.Namespace)); err != nil {
+			return err
+		}
+	}
+
+	for _, component := range []string{"prometheus", "loki", "tempo"} {
+		if !componentEnabled(platform, component) {
+			continue
+		}
+		claims, err := p.componentClaims(ctx, platform, component)
+		if err != nil {
+			return err
+		}
+		if len(claims) == 0 {
+			continue
+		}
+		usage := capacity.Usage{Component: component, Resource: observabilityv1beta1.CapacityResourceStorage, Volumes: len(claims)}
+		names := make([]string, 0, len(claims))
+		for i := range claims {
+			names = append(names, claims[i].Name)
+			size, ok := claims[i].Status.Capacity[corev1.ResourceStorage]
+			if !ok {
+				size = claims[i].Spec.Resources.Requests[corev1.ResourceStorage]
+			}
+			usage.Capacity += size.AsApproximateFloat64()
+		}
+		if err := add(usage, capacity.StorageQuery(platform.Namespace, names)); err != nil {
+			return err
+		}
+	}
+
+	opts := capacity.OptionsFor(spec)
+	status := &observabilityv1beta1.CapacityStatus{
+		LastAnalysisTime: &metav1.Time{Time: now},
+		Recommendations:  capacity.Recommend(platform, usages, opts),
+	}
+	for _, usage := range usages {
+		status.Forecasts = append(status.Forecasts, capacity.Forecast(usage, opts))
+		if days := int32(usage.Trend.Days); days > status.HistoryDays {
+			status.HistoryDays = days
+		}
+	}
+	if len(unforecast) > 0 {
+		status.Message = "Not enough history to forecast " + strings.Join(unforecast, ", ")
+	}
+
+	if err := p.writeStatus(ctx, platform, status); err != nil {
+		return fmt.Errorf("failed to record capacity forecasts: %w", err)
+	}
+	p.Log.V(1).Info("Forecast capacity", "platform", platform.Name, "namespace", platform.Namespace,
+		"forecasts", len(status.Forecasts), "recommendations", len(status.Recommendations))
+	return nil
+}
+
+// componentClaims returns the PersistentVolumeClaims of a component
+func (p *CapacityPlanner) componentClaims(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, component string) ([]corev1.PersistentVolumeClaim, error) {
+	list := &appsv1.StatefulSetList{}
+	if err := p.Client.List(ctx, list, client.InNamespace(platform.Namespace), client.MatchingLabels{
+		"app.kubernetes.io/name":     component,
+		"app.kubernetes.io/instance": platform.Name,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list %s StatefulSets: %w", component, err)
+	}
+	return statefulSetClaims(ctx, p.Client, platform.Namespace, list.Items)
+}
+
+// writeStatus records the forecasts of a platform
+func (p *CapacityPlanner) writeStatus(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, status *observabilityv1beta1.CapacityStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &observabilityv1beta1.ObservabilityPlatform{}
+		if err := p.Client.Get(ctx, client.ObjectKeyFromObject(platform), latest); err != nil {
+			return err
+		}
+		latest.Status.Capacity = status
+		return p.Client.Status().Update(ctx, latest)
+	})
+}
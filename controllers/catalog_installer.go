@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gunjanjp/gunj-operator/pkg/catalog"
+)
+
+// catalogRetryInterval is how long a failed install of the builtin catalog
+// waits for a retry
+const catalogRetryInterval = time.Minute
+
+// +kubebuilder:rbac:groups=observability.io,resources=platformcatalogs,verbs=get;list;watch;create;update
+
+// CatalogInstaller installs the builtin PlatformCatalog of the examples
+// shipped with the operator, replacing the examples of a previous version.
+// It implements manager.Runnable.
+type CatalogInstaller struct {
+	Client client.Client
+	Log    logr.Logger
+}
+
+// Start installs the catalog, retrying until it succeeds or the context is
+// cancelled
+func (i *CatalogInstaller) Start(ctx context.Context) error {
+	for {
+		err := catalog.EnsureBuiltin(ctx, i.Client)
+		if err == nil {
+			i.Log.Info("Installed the builtin platform catalog")
+			return nil
+		}
+		// Don't fail the manager: the catalog only serves examples
+		i.Log.Error(err, "Failed to install the builtin platform catalog", "retryIn", catalogRetryInterval)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(catalogRetryInterval):
+		}
+	}
+}
+
+// NeedLeaderElection returns true so only the leader installs the catalog
+func (i *CatalogInstaller) NeedLeaderElection() bool {
+	return true
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion/redaction"
+	"github.com/gunjanjp/gunj-operator/internal/changesummary"
+)
+
+// recordChangeSummary compares the applied component settings with those of
+// the previous reconcile and records a summary of what changed in the status,
+// so changes can be reviewed from the platform resource alone. The first
+// reconcile only records the baseline. Secrets are redacted before the
+// settings leave the reconciler, in status, events and logs alike.
+func (r *ObservabilityPlatformReconciler) recordChangeSummary(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) {
+	log := log.FromContext(ctx)
+
+	current, err := changesummary.Flatten(platform.Spec.Components)
+	if err != nil {
+		log.Error(err, "Failed to snapshot applied settings")
+		return
+	}
+	redactor := redaction.Default()
+	current = current.Redact(redactor)
+
+	previous := platform.Status.AppliedSettings
+	summary := ""
+	if previous != nil {
+		summary = changesummary.Summarize(changesummary.Redact(changesummary.Diff(previous, current), redactor))
+	}
+
+	now := metav1.Now()
+	if err := r.StatusManager.ApplyStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
+		status.AppliedSettings = make(map[string]string, len(current))
+		for path, value := range current {
+			status.AppliedSettings[path] = value
+		}
+		if summary != "" {
+			status.LastChangeSummary = summary
+			status.LastChangeTime = now.DeepCopy()
+		}
+	}); err != nil {
+		log.Error(err, "Failed to update applied settings")
+	}
+	if summary == "" {
+		return
+	}
+
+	log.Info("Applied configuration changes", "summary", summary)
+	r.EventRecorder.RecordPlatformEvent(platform, EventReasonConfigApplied, summary)
+}
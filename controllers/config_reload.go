@@ -0,0 +1,193 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/managers"
+	"github.com/gunjanjp/gunj-operator/internal/reload"
+)
+
+// reloadClient calls the reload endpoints of the component pods
+var reloadClient = &http.Client{Timeout: 10 * time.Second}
+
+// reconcileReloads reloads the configuration of components using the Reload
+// strategy. Their pods do not roll when the reloaded configuration changes,
+// so the change is tracked by checksum in status and every ready pod is
+// reloaded until a reload happens after the change has reached the mounted
+// volumes.
+func (r *ObservabilityPlatformReconciler) reconcileReloads(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	if platform.Spec.Components == nil {
+		return nil
+	}
+	// Persist the checksums, so a change is reloaded once and not again after
+	// a requeue or restart
+	defer r.persistReloads(ctx, platform)
+
+	strategies := make(map[string]string)
+	if p := platform.Spec.Components.Prometheus; p != nil && p.Enabled {
+		strategies["prometheus"] = p.ReloadStrategy
+	}
+	if g := platform.Spec.Components.Grafana; g != nil && g.Enabled {
+		strategies["grafana"] = g.ReloadStrategy
+	}
+	if platform.Spec.Components.AlloyEnabled() {
+		// Alloy is always reloaded; its pods still roll when the rest of its
+		// configuration changes
+		strategies["alloy"] = ""
+	}
+
+	var errs []error
+	for component, configured := range strategies {
+		if reload.StrategyFor(component, configured) != reload.StrategyReload {
+			delete(platform.Status.Reloads, component)
+			continue
+		}
+		if err := r.reloadComponent(ctx, platform, component); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for component := range platform.Status.Reloads {
+		if _, ok := strategies[component]; !ok {
+			delete(platform.Status.Reloads, component)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to reload %d component(s): %v", len(errs), errs)
+	}
+	return nil
+}
+
+// persistReloads writes the reload status of the components
+func (r *ObservabilityPlatformReconciler) persistReloads(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) {
+	var reloads map[string]observabilityv1beta1.ReloadStatus
+	for component, status := range platform.Status.Reloads {
+		if reloads == nil {
+			reloads = make(map[string]observabilityv1beta1.ReloadStatus, len(platform.Status.Reloads))
+		}
+		reloads[component] = *status.DeepCopy()
+	}
+	if err := r.StatusManager.UpdatePlatformStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
+		status.Reloads = reloads
+	}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update reload status")
+	}
+}
+
+// reloadComponent reloads the configuration of a component's pods if it changed
+func (r *ObservabilityPlatformReconciler) reloadComponent(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, component string) error {
+	log := log.FromContext(ctx).WithValues("reload", component)
+	endpoint := reload.Endpoints[component]
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(platform.Namespace), client.MatchingLabels{
+		"app.kubernetes.io/name":     component,
+		"app.kubernetes.io/instance": platform.Name,
+	}); err != nil {
+		return fmt.Errorf("failed to list %s pods: %w", component, err)
+	}
+	var ready []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp == nil && pod.Status.PodIP != "" && podReady(&pod) {
+			ready = append(ready, pod)
+		}
+	}
+	if len(ready) == 0 {
+		return nil
+	}
+
+	objects, err := managers.FetchReferences(ctx, r.Client, platform.Namespace, reload.VolumeReferences(&ready[0].Spec, endpoint.Volumes))
+	if err != nil {
+		return err
+	}
+	checksum := reload.Checksum(objects)
+
+	if platform.Status.Reloads == nil {
+		platform.Status.Reloads = make(map[string]observabilityv1beta1.ReloadStatus)
+	}
+	status, ok := platform.Status.Reloads[component]
+	if !ok {
+		// The pods started with the current configuration
+		now := metav1.Now()
+		platform.Status.Reloads[component] = observabilityv1beta1.ReloadStatus{Checksum: checksum, ObservedTime: &now, LastReloadTime: &now}
+		return nil
+	}
+	if status.Checksum != checksum {
+		now := metav1.Now()
+		status.Checksum = checksum
+		status.ObservedTime = &now
+	}
+
+	var lastReload time.Time
+	if status.LastReloadTime != nil {
+		lastReload = status.LastReloadTime.Time
+	}
+	if status.ObservedTime == nil || !reload.Due(status.ObservedTime.Time, lastReload) {
+		platform.Status.Reloads[component] = status
+		return nil
+	}
+
+	var credentials *reload.Credentials
+	if endpoint.BasicAuth {
+		if credentials, err = r.grafanaCredentials(ctx, platform); err != nil {
+			return err
+		}
+	}
+
+	for _, pod := range ready {
+		baseURL := fmt.Sprintf("http://%s:%d", pod.Status.PodIP, endpoint.Port)
+		if err := reload.Reload(ctx, reloadClient, baseURL, endpoint, credentials); err != nil {
+			status.Message = fmt.Sprintf("pod %s: %v", pod.Name, err)
+			platform.Status.Reloads[component] = status
+			return fmt.Errorf("failed to reload %s pod %s: %w", component, pod.Name, err)
+		}
+	}
+
+	now := metav1.Now()
+	status.LastReloadTime = &now
+	status.Message = ""
+	platform.Status.Reloads[component] = status
+	log.Info("Reloaded configuration", "pods", len(ready), "checksum", checksum)
+	return nil
+}
+
+// grafanaCredentials returns the Grafana admin credentials of a platform
+func (r *ObservabilityPlatformReconciler) grafanaCredentials(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) (*reload.Credentials, error) {
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: platform.Namespace, Name: fmt.Sprintf("grafana-%s-admin", platform.Name)}
+	if err := r.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("failed to get Grafana admin secret: %w", err)
+	}
+	return &reload.Credentials{
+		Username: string(secret.Data["admin-user"]),
+		Password: string(secret.Data["admin-password"]),
+	}, nil
+}
+
+// podReady returns true if a pod is running and ready
+func podReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gunjanjp/gunj-operator/internal/conversioncleanup"
+)
+
+// defaultConversionCleanupInterval is the default interval between cleanups
+const defaultConversionCleanupInterval = time.Hour
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observability.io,resources=observabilityplatforms,verbs=list;patch
+
+// ConversionCleanup periodically removes the legacy conversion annotations
+// and labels of the objects of CRDs whose migration to the storage version
+// is complete. It implements manager.Runnable.
+type ConversionCleanup struct {
+	// APIReader lists the objects without caching them
+	APIReader client.Reader
+	Client    client.Client
+	// CRDs are the names of the CRDs cleaned up
+	CRDs     []string
+	Config   conversioncleanup.Config
+	Interval time.Duration
+	Log      logr.Logger
+}
+
+// Start runs the cleanup every interval until the context is cancelled
+func (c *ConversionCleanup) Start(ctx context.Context) error {
+	interval := c.Interval
+	if interval == 0 {
+		interval = defaultConversionCleanupInterval
+	}
+	cleaner := &conversioncleanup.Cleaner{
+		Reader: c.APIReader,
+		Writer: c.Client,
+		Config: c.Config,
+		Log:    c.Log,
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, crd := range c.CRDs {
+			result, err := cleaner.Run(ctx, crd)
+			switch {
+			case err != nil:
+				// Don't fail the manager: the metadata is only left behind
+				c.Log.Error(err, "Failed to clean up conversion metadata", "crd", crd)
+			case result.Skipped != "":
+				c.Log.V(1).Info("Skipped conversion metadata cleanup", "crd", crd, "reason", result.Skipped)
+			case result.Cleaned > 0 || result.Failed > 0:
+				c.Log.Info("Cleaned up conversion metadata", "crd", crd,
+					"scanned", result.Scanned, "cleaned", result.Cleaned, "failed", result.Failed)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection returns true so only the leader patches the objects
+func (c *ConversionCleanup) NeedLeaderElection() bool {
+	return true
+}
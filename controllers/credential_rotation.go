@@ -0,0 +1,223 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/rotation"
+)
+
+// rotatedCredential is a credential of a platform the operator rotates.
+// Only credentials whose dependents the operator can update are rotated: a
+// regenerated value nothing else learns would lock the dependents out.
+type rotatedCredential struct {
+	// name of the credential in status
+	name   string
+	secret string
+	keys   []string
+	// apply applies the staged value of a key to the dependents keeping a
+	// copy of the credential
+	apply func(ctx context.Context, secret *corev1.Secret, key, current, next string) error
+}
+
+// rotateCredentials rotates the credentials of the platform that are due, or
+// all of them when a rotation is requested through the annotation, and
+// records the rotations in status.credentialRotation
+func (r *ObservabilityPlatformReconciler) rotateCredentials(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx)
+
+	var spec *observabilityv1beta1.CredentialRotationSpec
+	if platform.Spec.Security != nil {
+		spec = platform.Spec.Security.CredentialRotation
+	}
+	status := &observabilityv1beta1.CredentialRotationStatus{}
+	if platform.Status.CredentialRotation != nil {
+		status = platform.Status.CredentialRotation.DeepCopy()
+	}
+	scheduled := spec != nil && spec.Enabled
+	request, requested := rotation.Requested(platform.Annotations, status.LastRequest)
+	if !scheduled && !requested {
+		return nil
+	}
+	interval := rotation.DefaultInterval
+	if spec != nil && spec.Interval != nil {
+		interval = spec.Interval.Duration
+	}
+
+	credentials := rotatedCredentials(platform)
+
+	now := time.Now()
+	var rotated []string
+	var errs []error
+	for _, credential := range credentials {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: platform.Namespace, Name: credential.secret}, secret); err != nil {
+			if errors.IsNotFound(err) {
+				// Not generated yet
+				continue
+			}
+			errs = append(errs, fmt.Errorf("failed to get secret %s: %w", credential.secret, err))
+			continue
+		}
+
+		last := lastRotated(status, credential.name)
+		if last.IsZero() {
+			last = secret.CreationTimestamp.Time
+		}
+		if !requested && !rotation.Due(last, interval, now) {
+			continue
+		}
+
+		if err := r.rotateSecret(ctx, secret, credential); err != nil {
+			errs = append(errs, fmt.Errorf("failed to rotate %s: %w", credential.name, err))
+			continue
+		}
+		setLastRotated(status, credential, metav1.NewTime(now))
+		rotated = append(rotated, credential.name)
+		log.Info("Rotated credential", "credential", credential.name, "secret", credential.secret)
+	}
+
+	// A request is handled once every credential is rotated, so failed
+	// rotations are retried
+	handled := requested && len(errs) == 0
+	if handled {
+		status.LastRequest = request
+	}
+	if len(errs) > 0 {
+		r.EventRecorder.RecordPlatformEvent(platform, EventReasonRotationFailed, utilerrors.NewAggregate(errs).Error())
+	}
+	if len(rotated) > 0 {
+		r.EventRecorder.RecordPlatformEvent(platform, EventReasonCredentialsRotated,
+			fmt.Sprintf("Rotated credentials: %s", strings.Join(rotated, ", ")))
+	}
+	if len(rotated) > 0 || handled {
+		r.StatusManager.UpdatePlatformStatus(ctx, platform, func(s *observabilityv1beta1.ObservabilityPlatformStatus) {
+			s.CredentialRotation = status
+		})
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// rotatedCredentials lists the credentials the operator rotates for the
+// platform: the Grafana admin password. The shared admin credentials have no
+// dependent the operator could update and are not rotated.
+func rotatedCredentials(platform *observabilityv1beta1.ObservabilityPlatform) []rotatedCredential {
+	var credentials []rotatedCredential
+
+	if platform.Spec.Components != nil && platform.Spec.Components.Grafana != nil && platform.Spec.Components.Grafana.Enabled {
+		url := fmt.Sprintf("http://grafana-%s.%s.svc:3000", platform.Name, platform.Namespace)
+		credentials = append(credentials, rotatedCredential{
+			name:   "grafana-admin",
+			secret: fmt.Sprintf("grafana-%s-admin", platform.Name),
+			keys:   []string{"admin-password"},
+			apply: func(ctx context.Context, secret *corev1.Secret, key, current, next string) error {
+				user := string(secret.Data["admin-user"])
+				if user == "" {
+					user = "admin"
+				}
+				// Grafana keeps the password in its database: the one in
+				// the environment only seeds a new database
+				return rotation.NewGrafana(url, user).ChangePassword(ctx, current, next)
+			},
+		})
+	}
+
+	return credentials
+}
+
+// rotateSecret stages new values of the keys of a credential in its Secret,
+// applies them to the dependents and commits them. An interrupted rotation
+// resumes with the staged values.
+func (r *ObservabilityPlatformReconciler) rotateSecret(ctx context.Context, secret *corev1.Secret, credential rotatedCredential) error {
+	type staged struct{ key, current, next string }
+	var keys []staged
+	changed := false
+	for _, key := range credential.keys {
+		current, ok := secret.Data[key]
+		if !ok {
+			continue
+		}
+		next, stagedNow, err := rotation.Stage(secret.Data, key)
+		if err != nil {
+			return err
+		}
+		changed = changed || stagedNow
+		keys = append(keys, staged{key: key, current: string(current), next: next})
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("secret %s has none of the keys %s", secret.Name, strings.Join(credential.keys, ", "))
+	}
+	if changed {
+		if err := r.Update(ctx, secret); err != nil {
+			return fmt.Errorf("failed to stage new values: %w", err)
+		}
+	}
+
+	for _, k := range keys {
+		if err := credential.apply(ctx, secret, k.key, k.current, k.next); err != nil {
+			return fmt.Errorf("failed to apply the new %s: %w", k.key, err)
+		}
+	}
+
+	for _, k := range keys {
+		rotation.Commit(secret.Data, k.key)
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[rotation.RotatedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	if err := r.Update(ctx, secret); err != nil {
+		return fmt.Errorf("failed to commit new values: %w", err)
+	}
+	return nil
+}
+
+func lastRotated(status *observabilityv1beta1.CredentialRotationStatus, name string) time.Time {
+	for _, c := range status.Credentials {
+		if c.Name == name {
+			return c.LastRotated.Time
+		}
+	}
+	return time.Time{}
+}
+
+func setLastRotated(status *observabilityv1beta1.CredentialRotationStatus, credential rotatedCredential, at metav1.Time) {
+	for i := range status.Credentials {
+		if status.Credentials[i].Name == credential.name {
+			status.Credentials[i].Secret = credential.secret
+			status.Credentials[i].LastRotated = at
+			return
+		}
+	}
+	status.Credentials = append(status.Credentials, observabilityv1beta1.RotatedCredential{
+		Name:        credential.name,
+		Secret:      credential.secret,
+		LastRotated: at,
+	})
+}
+
+// rotationRequested passes updates of a platform changing its
+// rotate-credentials annotation, which leave its generation unchanged
+var rotationRequested = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.GetAnnotations()[rotation.RequestAnnotation] != e.ObjectNew.GetAnnotations()[rotation.RequestAnnotation]
+	},
+}
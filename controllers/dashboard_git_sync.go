@@ -0,0 +1,272 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/dashboardsync"
+)
+
+// dashboardGitSyncTick is how often platforms are checked for a due sync
+const dashboardGitSyncTick = 30 * time.Second
+
+// DashboardGitSync periodically imports the JSON and Jsonnet dashboards of the Git
+// repositories configured in spec.components.grafana.dashboardsFromGit into
+// the platform's Grafana, and reports dashboards edited in Grafana since they
+// were imported as drifted. It implements manager.Runnable.
+type DashboardGitSync struct {
+	Client client.Client
+	HTTP   *http.Client
+	Log    logr.Logger
+}
+
+// Start runs the sync loop until the context is cancelled
+func (s *DashboardGitSync) Start(ctx context.Context) error {
+	if s.HTTP == nil {
+		s.HTTP = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	ticker := time.NewTicker(dashboardGitSyncTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.syncAll(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection returns true so only the leader imports dashboards
+func (s *DashboardGitSync) NeedLeaderElection() bool {
+	return true
+}
+
+// syncAll syncs the platforms whose poll interval has elapsed
+func (s *DashboardGitSync) syncAll(ctx context.Context) {
+	platforms := &observabilityv1beta1.ObservabilityPlatformList{}
+	if err := s.Client.List(ctx, platforms); err != nil {
+		s.Log.Error(err, "Failed to list platforms for dashboard sync")
+		return
+	}
+
+	now := time.Now()
+	for i := range platforms.Items {
+		platform := &platforms.Items[i]
+		spec := dashboardsFromGit(platform)
+		if spec == nil || !platform.DeletionTimestamp.IsZero() {
+			continue
+		}
+		status := platform.Status.DashboardsFromGit
+		if status != nil && status.LastSyncTime != nil && now.Sub(status.LastSyncTime.Time) < spec.GetPollInterval() {
+			continue
+		}
+
+		next, err := s.sync(ctx, platform, spec)
+		if err != nil {
+			s.Log.Error(err, "Failed to sync dashboards from Git", "platform", platform.Name, "namespace", platform.Namespace)
+			next.Message = err.Error()
+		}
+		syncTime := metav1.NewTime(now)
+		next.LastSyncTime = &syncTime
+		if err := s.writeStatus(ctx, platform, next); err != nil {
+			s.Log.Error(err, "Failed to write dashboard sync status", "platform", platform.Name, "namespace", platform.Namespace)
+		}
+	}
+}
+
+// sync imports the dashboards of a platform's repository and returns the new
+// sync status. On failure the returned status keeps the records of the
+// dashboards handled so far.
+func (s *DashboardGitSync) sync(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, spec *observabilityv1beta1.DashboardsFromGitSpec) (*observabilityv1beta1.DashboardsFromGitStatus, error) {
+	previous := &observabilityv1beta1.DashboardsFromGitStatus{}
+	if platform.Status.DashboardsFromGit != nil {
+		previous = platform.Status.DashboardsFromGit
+	}
+	next := &observabilityv1beta1.DashboardsFromGitStatus{
+		Revision:   previous.Revision,
+		Dashboards: previous.Dashboards,
+	}
+
+	var secretData map[string][]byte
+	if spec.AuthSecretRef != nil {
+		secret := &corev1.Secret{}
+		if err := s.Client.Get(ctx, client.ObjectKey{Namespace: platform.Namespace, Name: spec.AuthSecretRef.Name}, secret); err != nil {
+			return next, fmt.Errorf("failed to get auth secret %s: %w", spec.AuthSecretRef.Name, err)
+		}
+		secretData = secret.Data
+	}
+	auth, err := dashboardsync.AuthFor(spec.Repo, secretData)
+	if err != nil {
+		return next, err
+	}
+
+	revision, files, err := dashboardsync.Fetch(ctx, dashboardsync.Source{URL: spec.Repo, Ref: spec.GetRef(), Path: spec.GetPath()}, auth)
+	if err != nil {
+		return next, err
+	}
+	dashboards, err := dashboardsync.Load(files, spec.GetPath(), spec.ExtVars)
+	if err != nil {
+		return next, err
+	}
+
+	grafana, err := grafanaClient(ctx, s.Client, s.HTTP, platform)
+	if err != nil {
+		return next, err
+	}
+
+	previousRecords := dashboardRecords(previous.Dashboards)
+	records := make(map[string]dashboardsync.Record, len(previousRecords))
+	for _, record := range previousRecords {
+		records[record.UID] = record
+	}
+
+	// On failure, dashboards not handled yet keep their previous records, so
+	// they are not mistaken for dashboards edited in Grafana on the next sync
+	handled := make(map[string]dashboardsync.Record, len(dashboards))
+	fail := func(err error) (*observabilityv1beta1.DashboardsFromGitStatus, error) {
+		merged := make([]dashboardsync.Record, 0, len(previousRecords))
+		for _, record := range previousRecords {
+			if updated, ok := handled[record.UID]; ok {
+				record = updated
+				delete(handled, record.UID)
+			}
+			merged = append(merged, record)
+		}
+		for _, record := range handled {
+			merged = append(merged, record)
+		}
+		next.Dashboards = dashboardStatuses(merged)
+		return next, err
+	}
+
+	message := fmt.Sprintf("Synced from %s@%s", spec.Repo, revision)
+	synced := make([]dashboardsync.Record, 0, len(dashboards))
+	for _, dashboard := range dashboards {
+		version, err := grafana.Version(ctx, dashboard.UID)
+		if err != nil {
+			return fail(err)
+		}
+
+		var prev *dashboardsync.Record
+		if record, ok := records[dashboard.UID]; ok {
+			prev = &record
+		}
+		record := dashboardsync.Record{UID: dashboard.UID, Title: dashboard.Title, File: dashboard.File}
+		switch dashboardsync.Decide(dashboard, prev, version, spec.OverwritesDrift()) {
+		case dashboardsync.ActionImport:
+			if record.Version, err = grafana.Import(ctx, dashboard, message); err != nil {
+				return fail(fmt.Errorf("failed to import dashboard %s: %w", dashboard.File, err))
+			}
+			record.Checksum = dashboard.Checksum
+		case dashboardsync.ActionKeep:
+			// The recorded import is kept, so the dashboard stays drifted
+			// until it is overwritten or the edits are reverted
+			record.Drifted = true
+			if prev != nil {
+				record.Checksum, record.Version = prev.Checksum, prev.Version
+			}
+		default:
+			record.Checksum, record.Version = prev.Checksum, prev.Version
+		}
+		handled[record.UID] = record
+		synced = append(synced, record)
+	}
+
+	// Dashboards removed from Git are deleted from Grafana unless edited there
+	for _, removed := range dashboardsync.Removed(dashboards, previousRecords) {
+		version, err := grafana.Version(ctx, removed.UID)
+		if err != nil {
+			return fail(err)
+		}
+		if version != 0 && version != removed.Version {
+			s.Log.Info("Keeping dashboard removed from Git but edited in Grafana", "platform", platform.Name, "uid", removed.UID)
+			continue
+		}
+		if err := grafana.Delete(ctx, removed.UID); err != nil {
+			return fail(fmt.Errorf("failed to delete dashboard %s: %w", removed.UID, err))
+		}
+	}
+
+	next.Revision = revision
+	next.Dashboards = dashboardStatuses(synced)
+	s.Log.V(1).Info("Synced dashboards from Git", "platform", platform.Name, "revision", revision, "dashboards", len(synced))
+	return next, nil
+}
+
+// writeStatus records the sync status of a platform
+func (s *DashboardGitSync) writeStatus(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, status *observabilityv1beta1.DashboardsFromGitStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &observabilityv1beta1.ObservabilityPlatform{}
+		if err := s.Client.Get(ctx, client.ObjectKeyFromObject(platform), latest); err != nil {
+			return err
+		}
+		latest.Status.DashboardsFromGit = status
+		return s.Client.Status().Update(ctx, latest)
+	})
+}
+
+// grafanaClient returns a client of a platform's Grafana API, authenticated
+// as its admin
+func grafanaClient(ctx context.Context, c client.Client, httpClient *http.Client, platform *observabilityv1beta1.ObservabilityPlatform) (*dashboardsync.Grafana, error) {
+	admin := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: platform.Namespace, Name: fmt.Sprintf("grafana-%s-admin", platform.Name)}, admin); err != nil {
+		return nil, fmt.Errorf("failed to get Grafana admin secret: %w", err)
+	}
+	return &dashboardsync.Grafana{
+		BaseURL:  fmt.Sprintf("http://grafana-%s.%s.svc:3000", platform.Name, platform.Namespace),
+		HTTP:     httpClient,
+		Username: string(admin.Data["admin-user"]),
+		Password: string(admin.Data["admin-password"]),
+	}, nil
+}
+
+// dashboardRecords converts dashboard status to sync records
+func dashboardRecords(statuses []observabilityv1beta1.GitDashboardStatus) []dashboardsync.Record {
+	records := make([]dashboardsync.Record, 0, len(statuses))
+	for _, d := range statuses {
+		records = append(records, dashboardsync.Record{UID: d.UID, Title: d.Title, File: d.File, Checksum: d.Checksum, Version: d.Version, Drifted: d.Drifted})
+	}
+	return records
+}
+
+// dashboardStatuses converts sync records to status
+func dashboardStatuses(records []dashboardsync.Record) []observabilityv1beta1.GitDashboardStatus {
+	statuses := make([]observabilityv1beta1.GitDashboardStatus, 0, len(records))
+	for _, record := range records {
+		statuses = append(statuses, observabilityv1beta1.GitDashboardStatus{
+			UID:      record.UID,
+			Title:    record.Title,
+			File:     record.File,
+			Checksum: record.Checksum,
+			Version:  record.Version,
+			Drifted:  record.Drifted,
+		})
+	}
+	return statuses
+}
+
+// dashboardsFromGit returns the Git dashboard settings of a platform's Grafana
+func dashboardsFromGit(platform *observabilityv1beta1.ObservabilityPlatform) *observabilityv1beta1.DashboardsFromGitSpec {
+	if platform.Spec.Components == nil || platform.Spec.Components.Grafana == nil || !platform.Spec.Components.Grafana.Enabled {
+		return nil
+	}
+	return platform.Spec.Components.Grafana.DashboardsFromGit
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/dataprotection"
+)
+
+const (
+	// DataProtectionFinalizer blocks the removal of the platform until its
+	// deletion policy completed
+	DataProtectionFinalizer = "observabilityplatform.observability.io/data-protection"
+
+	// DataProtectionRequeue is how often the deletion policy is checked while
+	// it is in progress
+	DataProtectionRequeue = 10 * time.Second
+
+	// retainedVolumeAnnotation marks the claims kept by the Retain policy
+	retainedVolumeAnnotation = "observability.io/retained"
+
+	dataProtectionInProgress = "InProgress"
+	dataProtectionCompleted  = "Completed"
+	dataProtectionFailed     = "Failed"
+)
+
+// errDeletionPending is returned while the deletion policy waits for
+// snapshots or the object storage cleanup; the deletion is retried later
+var errDeletionPending = goerrors.New("deletion policy in progress")
+
+// handleDataProtectionFinalizer applies the deletion policy of the platform.
+// It runs before the other finalizers, which delete the claims and the
+// object storage credentials:
+//   - Retain releases the claims from the platform so they are kept
+//   - Snapshot takes a VolumeSnapshot of every claim and waits for them to be
+//     ready before the claims are deleted
+//   - Delete lets the claims be deleted and empties the object storage
+//     buckets with a Job
+//
+// A failed snapshot or cleanup blocks the deletion; changing the policy on
+// the platform unblocks it.
+func (fm *FinalizerManager) handleDataProtectionFinalizer(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, r *ObservabilityPlatformReconciler) error {
+	log := log.FromContext(ctx).WithValues("finalizer", DataProtectionFinalizer)
+
+	policy := platform.Spec.DataProtection.GetDeletionPolicy()
+	status := platform.Status.DataProtection
+	if status == nil || status.Policy != policy {
+		now := metav1.Now()
+		status = &observabilityv1beta1.DataProtectionStatus{Policy: policy, StartTime: &now}
+		platform.Status.DataProtection = status
+		log.Info("Applying deletion policy", "policy", policy)
+		r.EventRecorder.RecordPlatformEvent(platform, "DataProtection", fmt.Sprintf("Applying deletion policy %s", policy))
+	}
+	if status.Phase == dataProtectionCompleted {
+		return nil
+	}
+
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := r.List(ctx, pvcList, client.InNamespace(platform.Namespace),
+		client.MatchingLabelsSelector{Selector: labels.SelectorFromSet(labels.Set{
+			"observability.io/platform": platform.Name,
+		})}); err != nil {
+		return fmt.Errorf("failed to list PVCs: %w", err)
+	}
+
+	var err error
+	switch policy {
+	case observabilityv1beta1.DeletionPolicyRetain:
+		err = fm.retainVolumes(ctx, platform, pvcList.Items, r)
+	case observabilityv1beta1.DeletionPolicySnapshot:
+		err = fm.snapshotVolumes(ctx, platform, pvcList.Items, r)
+	default:
+		status.Volumes = nil
+		for _, pvc := range pvcList.Items {
+			status.Volumes = append(status.Volumes, observabilityv1beta1.VolumeProtectionStatus{Name: pvc.Name, Action: "Deleted"})
+		}
+	}
+	if err == nil {
+		err = fm.protectObjectStorage(ctx, platform, r)
+	}
+
+	switch {
+	case err == nil:
+		now := metav1.Now()
+		status.Phase = dataProtectionCompleted
+		status.Message = fmt.Sprintf("Deletion policy %s completed", policy)
+		status.CompletionTime = &now
+	case goerrors.Is(err, errDeletionPending):
+		status.Phase = dataProtectionInProgress
+	default:
+		status.Phase = dataProtectionFailed
+		status.Message = err.Error()
+	}
+	if updateErr := r.Status().Update(ctx, platform); updateErr != nil {
+		log.Error(updateErr, "Failed to update data protection status")
+	}
+	return err
+}
+
+// retainVolumes removes the platform's owner references from the claims, so
+// they are not garbage collected, and marks them as retained for cleanupPVCs
+func (fm *FinalizerManager) retainVolumes(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, pvcs []corev1.PersistentVolumeClaim, r *ObservabilityPlatformReconciler) error {
+	status := platform.Status.DataProtection
+	status.Volumes = nil
+	for i := range pvcs {
+		pvc := &pvcs[i]
+		patch := client.MergeFrom(pvc.DeepCopy())
+
+		var owners []metav1.OwnerReference
+		for _, ref := range pvc.OwnerReferences {
+			if ref.UID != platform.UID {
+				owners = append(owners, ref)
+			}
+		}
+		pvc.OwnerReferences = owners
+		if pvc.Annotations == nil {
+			pvc.Annotations = make(map[string]string)
+		}
+		pvc.Annotations[retainedVolumeAnnotation] = "true"
+
+		if err := r.Patch(ctx, pvc, patch); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to retain PVC %s: %w", pvc.Name, err)
+		}
+		status.Volumes = append(status.Volumes, observabilityv1beta1.VolumeProtectionStatus{Name: pvc.Name, Action: "Retained"})
+	}
+	status.Message = fmt.Sprintf("Retained %d volumes", len(pvcs))
+	return nil
+}
+
+// snapshotVolumes takes a VolumeSnapshot of every claim and returns
+// errDeletionPending until they are all ready to use
+func (fm *FinalizerManager) snapshotVolumes(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, pvcs []corev1.PersistentVolumeClaim, r *ObservabilityPlatformReconciler) error {
+	status := platform.Status.DataProtection
+	status.Volumes = nil
+	pending := 0
+	for _, pvc := range pvcs {
+		desired := dataprotection.BuildVolumeSnapshot(platform.Name, platform.Namespace, pvc.Name, platform.Spec.DataProtection.VolumeSnapshotClassName)
+
+		snapshot := &unstructured.Unstructured{}
+		snapshot.SetGroupVersionKind(dataprotection.VolumeSnapshotGVK)
+		err := r.Get(ctx, types.NamespacedName{Name: desired.GetName(), Namespace: desired.GetNamespace()}, snapshot)
+		switch {
+		case meta.IsNoMatchError(err):
+			return fmt.Errorf("the Snapshot deletion policy requires the VolumeSnapshot CRD: %w", err)
+		case errors.IsNotFound(err):
+			if err := r.Create(ctx, desired); err != nil && !errors.IsAlreadyExists(err) {
+				return fmt.Errorf("failed to snapshot PVC %s: %w", pvc.Name, err)
+			}
+			snapshot = desired
+		case err != nil:
+			return fmt.Errorf("failed to get snapshot of PVC %s: %w", pvc.Name, err)
+		}
+
+		volume := observabilityv1beta1.VolumeProtectionStatus{Name: pvc.Name, Action: "Snapshotted", Snapshot: desired.GetName()}
+		ready, message := dataprotection.SnapshotReady(snapshot)
+		if message != "" {
+			return fmt.Errorf("snapshot %s of PVC %s failed: %s", desired.GetName(), pvc.Name, message)
+		}
+		if !ready {
+			volume.Action = "Snapshotting"
+			pending++
+		}
+		status.Volumes = append(status.Volumes, volume)
+	}
+
+	if pending > 0 {
+		status.Message = fmt.Sprintf("Waiting for %d of %d volume snapshots", pending, len(pvcs))
+		return errDeletionPending
+	}
+	status.Message = fmt.Sprintf("Snapshotted %d volumes", len(pvcs))
+	return nil
+}
+
+// protectObjectStorage empties the object storage buckets of the platform
+// with a Job when the Delete policy is set explicitly, and keeps them
+// otherwise
+func (fm *FinalizerManager) protectObjectStorage(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, r *ObservabilityPlatformReconciler) error {
+	status := platform.Status.DataProtection
+	buckets := platformBuckets(platform)
+	if len(buckets) == 0 {
+		status.ObjectStorage = ""
+		return nil
+	}
+	if !platform.Spec.DataProtection.DeletesObjectStorage() {
+		status.ObjectStorage = "Retained"
+		return nil
+	}
+	if status.ObjectStorage == "Deleted" {
+		return nil
+	}
+
+	secret := dataprotection.BuildCleanupSecret(platform.Name, platform.Namespace, buckets)
+	if err := r.Create(ctx, secret); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create object storage cleanup secret: %w", err)
+	}
+
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Name: dataprotection.CleanupName(platform.Name), Namespace: platform.Namespace}, job)
+	if errors.IsNotFound(err) {
+		job = dataprotection.BuildCleanupJob(platform.Name, platform.Namespace, buckets)
+		if err := r.Create(ctx, job); err != nil && !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create object storage cleanup job: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to get object storage cleanup job: %w", err)
+	}
+
+	finished, failed := dataprotection.JobFinished(job)
+	switch {
+	case failed:
+		status.ObjectStorage = "Failed"
+		return fmt.Errorf("object storage cleanup job %s failed, delete it to retry", job.Name)
+	case !finished:
+		status.ObjectStorage = "Deleting"
+		status.Message = fmt.Sprintf("Deleting %d object storage buckets", len(buckets))
+		return errDeletionPending
+	}
+	status.ObjectStorage = "Deleted"
+	return nil
+}
+
+// platformBuckets returns the object storage buckets of the platform
+func platformBuckets(platform *observabilityv1beta1.ObservabilityPlatform) []dataprotection.Bucket {
+	var buckets []dataprotection.Bucket
+	if loki := platform.Spec.Components.Loki; loki != nil && loki.S3 != nil && loki.S3.Enabled && loki.S3.BucketName != "" {
+		buckets = append(buckets, dataprotection.Bucket{
+			Component:       "loki",
+			Name:            loki.S3.BucketName,
+			Region:          loki.S3.Region,
+			Endpoint:        loki.S3.Endpoint,
+			AccessKeyID:     loki.S3.AccessKeyID,
+			SecretAccessKey: loki.S3.SecretAccessKey,
+		})
+	}
+	if tempo := platform.Spec.Components.Tempo; tempo != nil && tempo.S3 != nil && tempo.S3.BucketName != "" {
+		buckets = append(buckets, dataprotection.Bucket{
+			Component:       "tempo",
+			Name:            tempo.S3.BucketName,
+			Region:          tempo.S3.Region,
+			Endpoint:        tempo.S3.Endpoint,
+			AccessKeyID:     tempo.S3.AccessKeyID,
+			SecretAccessKey: tempo.S3.SecretAccessKey,
+		})
+	}
+	return buckets
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/alloy"
+	"github.com/gunjanjp/gunj-operator/internal/beyla"
+)
+
+// reconcileEBPFAgent deploys the eBPF agent on every node, feeding RED
+// metrics to the platform's Prometheus and traces to its Tempo, and removes
+// it when the agent is disabled
+func (r *ObservabilityPlatformReconciler) reconcileEBPFAgent(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx).WithValues("ebpfAgent", "reconcile")
+
+	components := platform.Spec.Components
+	if !components.EBPFAgentEnabled() {
+		return r.deleteEBPFAgent(ctx, platform)
+	}
+	spec := components.EBPFAgent
+
+	agent := beyla.Agent{
+		Name:         beyla.AgentName(platform.Name),
+		Namespace:    platform.Namespace,
+		Version:      spec.GetVersion(),
+		Namespaces:   spec.Namespaces,
+		Metrics:      components.EBPFAgentMetrics(),
+		ServiceGraph: components.EBPFAgentServiceGraph(),
+		Resources:    spec.Resources,
+		Labels: map[string]string{
+			"app.kubernetes.io/name":       "beyla",
+			"app.kubernetes.io/instance":   platform.Name,
+			"app.kubernetes.io/managed-by": "gunj-operator",
+			"app.kubernetes.io/part-of":    "observability-platform",
+			"observability.io/platform":    platform.Name,
+		},
+	}
+	if components.EBPFAgentTraces() {
+		agent.TracesEndpoint = "http://" + alloy.TempoEndpoint(platform.Name, platform.Namespace)
+		agent.TenantID = platform.TenantID()
+	}
+
+	desiredConfig, err := beyla.BuildConfigMap(agent)
+	if err != nil {
+		return err
+	}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: desiredConfig.Name, Namespace: desiredConfig.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		cm.Labels = desiredConfig.Labels
+		cm.Data = desiredConfig.Data
+		return controllerutil.SetControllerReference(platform, cm, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile eBPF agent config: %w", err)
+	}
+
+	desiredSA := beyla.BuildServiceAccount(agent)
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: desiredSA.Name, Namespace: desiredSA.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, sa, func() error {
+		sa.Labels = desiredSA.Labels
+		return controllerutil.SetControllerReference(platform, sa, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile eBPF agent service account: %w", err)
+	}
+
+	// The ClusterRole and its binding are cluster scoped and cannot be owned
+	// by the platform; they carry tracking labels and are removed by
+	// deleteEBPFAgent
+	name := beyla.ClusterRoleName(platform.Name, platform.Namespace)
+	desiredRole := beyla.BuildClusterRole(name, agent.Labels)
+	role := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, role, func() error {
+		role.Labels = desiredRole.Labels
+		role.Rules = desiredRole.Rules
+		return r.setOwner(platform, role)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile eBPF agent cluster role: %w", err)
+	}
+	desiredBinding := beyla.BuildClusterRoleBinding(name, agent)
+	binding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, binding, func() error {
+		binding.Labels = desiredBinding.Labels
+		// The role reference is immutable
+		if binding.CreationTimestamp.IsZero() {
+			binding.RoleRef = desiredBinding.RoleRef
+		}
+		binding.Subjects = desiredBinding.Subjects
+		return r.setOwner(platform, binding)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile eBPF agent cluster role binding: %w", err)
+	}
+
+	desired := beyla.BuildDaemonSet(agent)
+	// Restart the agents when the configuration changes
+	if desired.Spec.Template.Annotations == nil {
+		desired.Spec.Template.Annotations = map[string]string{}
+	}
+	desired.Spec.Template.Annotations["observability.io/config-hash"] =
+		fmt.Sprintf("%x", sha256.Sum256([]byte(desiredConfig.Data[beyla.ConfigKey])))
+	ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, ds, func() error {
+		ds.Labels = desired.Labels
+		// The selector is immutable, so only set it on creation
+		if ds.CreationTimestamp.IsZero() {
+			ds.Spec.Selector = desired.Spec.Selector
+		}
+		ds.Spec.Template = desired.Spec.Template
+		return controllerutil.SetControllerReference(platform, ds, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile eBPF agent daemon set: %w", err)
+	}
+
+	log.V(1).Info("eBPF agent reconciled", "metrics", agent.Metrics, "serviceGraph", agent.ServiceGraph,
+		"traces", agent.TracesEndpoint != "")
+	return nil
+}
+
+// deleteEBPFAgent removes the eBPF agent of a platform, including its
+// cluster scoped RBAC which is not garbage collected with the platform
+func (r *ObservabilityPlatformReconciler) deleteEBPFAgent(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	roleName := beyla.ClusterRoleName(platform.Name, platform.Namespace)
+	name, namespace := beyla.AgentName(platform.Name), platform.Namespace
+	for _, obj := range []client.Object{
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: roleName}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: roleName}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+	} {
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete eBPF agent %T: %w", obj, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/alloy"
+	"github.com/gunjanjp/gunj-operator/internal/eventexporter"
+	"github.com/gunjanjp/gunj-operator/internal/managers/loki"
+)
+
+// reconcileEventExporter deploys the exporter pushing Kubernetes events into
+// the platform's Loki with the Warning spike alerts, and removes both when
+// the exporter is disabled
+func (r *ObservabilityPlatformReconciler) reconcileEventExporter(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx).WithValues("eventExporter", "reconcile")
+
+	components := platform.Spec.Components
+	if !components.EventExporterEnabled() {
+		return r.deleteEventExporter(ctx, platform)
+	}
+	spec := components.EventExporter
+
+	exporter := eventexporter.Exporter{
+		Name:           eventexporter.ExporterName(platform.Name),
+		Namespace:      platform.Namespace,
+		Version:        spec.GetVersion(),
+		Platform:       platform.Name,
+		LokiURL:        alloy.LokiEndpoint(platform.Name, platform.Namespace),
+		TenantID:       platform.TenantID(),
+		WatchNamespace: spec.Namespace,
+		WarningsOnly:   spec.WarningsOnly,
+		Resources:      spec.Resources,
+		Labels: map[string]string{
+			"app.kubernetes.io/name":       "event-exporter",
+			"app.kubernetes.io/instance":   platform.Name,
+			"app.kubernetes.io/managed-by": "gunj-operator",
+			"app.kubernetes.io/part-of":    "observability-platform",
+			"observability.io/platform":    platform.Name,
+		},
+	}
+
+	desiredConfig, err := eventexporter.BuildConfigMap(exporter)
+	if err != nil {
+		return err
+	}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: desiredConfig.Name, Namespace: desiredConfig.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		cm.Labels = desiredConfig.Labels
+		cm.Data = desiredConfig.Data
+		return controllerutil.SetControllerReference(platform, cm, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile event exporter config: %w", err)
+	}
+
+	desiredSA := eventexporter.BuildServiceAccount(exporter)
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: desiredSA.Name, Namespace: desiredSA.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, sa, func() error {
+		sa.Labels = desiredSA.Labels
+		return controllerutil.SetControllerReference(platform, sa, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile event exporter service account: %w", err)
+	}
+
+	// The ClusterRole and its binding are cluster scoped and cannot be owned
+	// by the platform; they carry tracking labels and are removed by
+	// deleteEventExporter
+	name := eventexporter.ClusterRoleName(platform.Name, platform.Namespace)
+	desiredRole := eventexporter.BuildClusterRole(name, exporter.Labels)
+	role := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, role, func() error {
+		role.Labels = desiredRole.Labels
+		role.Rules = desiredRole.Rules
+		return r.setOwner(platform, role)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile event exporter cluster role: %w", err)
+	}
+	desiredBinding := eventexporter.BuildClusterRoleBinding(name, exporter)
+	binding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, binding, func() error {
+		binding.Labels = desiredBinding.Labels
+		// The role reference is immutable
+		if binding.CreationTimestamp.IsZero() {
+			binding.RoleRef = desiredBinding.RoleRef
+		}
+		binding.Subjects = desiredBinding.Subjects
+		return r.setOwner(platform, binding)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile event exporter cluster role binding: %w", err)
+	}
+
+	desired := eventexporter.BuildDeployment(exporter)
+	// Restart the exporter when the configuration changes
+	desired.Spec.Template.Annotations = map[string]string{
+		"observability.io/config-hash": fmt.Sprintf("%x", sha256.Sum256([]byte(desiredConfig.Data[eventexporter.ConfigKey]))),
+	}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		deployment.Labels = desired.Labels
+		// The selector is immutable, so only set it on creation
+		if deployment.CreationTimestamp.IsZero() {
+			deployment.Spec.Selector = desired.Spec.Selector
+		}
+		deployment.Spec.Replicas = desired.Spec.Replicas
+		deployment.Spec.Strategy = desired.Spec.Strategy
+		deployment.Spec.Template = desired.Spec.Template
+		return controllerutil.SetControllerReference(platform, deployment, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile event exporter: %w", err)
+	}
+
+	if err := r.reconcileEventRules(ctx, platform, spec); err != nil {
+		return err
+	}
+
+	log.V(1).Info("Event exporter reconciled", "namespace", spec.Namespace, "warningsOnly", spec.WarningsOnly,
+		"alerts", spec.AlertsEnabled())
+	return nil
+}
+
+// reconcileEventRules adds the Warning spike alerts to the rule files Loki's
+// ruler evaluates, or removes them when alerts are disabled. The rules
+// ConfigMap is shared, so only the events key is managed.
+func (r *ObservabilityPlatformReconciler) reconcileEventRules(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, spec *observabilityv1beta1.EventExporterSpec) error {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: loki.RulesConfigMapName(platform.Name), Namespace: platform.Namespace}}
+	if spec == nil || !spec.AlertsEnabled() {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(cm), cm); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if _, ok := cm.Data[eventexporter.RulesKey]; !ok {
+			return nil
+		}
+		delete(cm.Data, eventexporter.RulesKey)
+		if err := r.Update(ctx, cm); err != nil {
+			return fmt.Errorf("failed to remove event alerts: %w", err)
+		}
+		return nil
+	}
+
+	rules, err := eventexporter.Rules(platform.Name, spec.GetWarningThreshold())
+	if err != nil {
+		return err
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		if cm.Labels == nil {
+			cm.Labels = map[string]string{}
+		}
+		cm.Labels["app.kubernetes.io/managed-by"] = "gunj-operator"
+		cm.Labels["observability.io/platform"] = platform.Name
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[eventexporter.RulesKey] = rules
+		return controllerutil.SetControllerReference(platform, cm, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile event alerts: %w", err)
+	}
+	return nil
+}
+
+// deleteEventExporter removes the event exporter of a platform and its
+// alerts, including its cluster scoped RBAC which is not garbage collected
+// with the platform
+func (r *ObservabilityPlatformReconciler) deleteEventExporter(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	roleName := eventexporter.ClusterRoleName(platform.Name, platform.Namespace)
+	name, namespace := eventexporter.ExporterName(platform.Name), platform.Namespace
+	for _, obj := range []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: roleName}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: roleName}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+	} {
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete event exporter %T: %w", obj, err)
+		}
+	}
+	return r.reconcileEventRules(ctx, platform, nil)
+}
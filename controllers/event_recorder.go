@@ -21,8 +21,11 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
+
+	"github.com/gunjanjp/gunj-operator/internal/siem"
 )
 
 // EventReason defines reasons for events
@@ -37,6 +40,8 @@ const (
 	EventReasonPlatformReady    EventReason = "PlatformReady"
 	EventReasonPlatformFailed   EventReason = "PlatformFailed"
 	EventReasonPlatformDegraded EventReason = "PlatformDegraded"
+	EventReasonDeletionStuck    EventReason = "DeletionStuck"
+	EventReasonUnschedulable    EventReason = "Unschedulable"
 
 	// Component events
 	EventReasonComponentDeploying    EventReason = "ComponentDeploying"
@@ -68,6 +73,8 @@ const (
 	EventReasonConfigApplied          EventReason = "ConfigApplied"
 	EventReasonSecretMissing          EventReason = "SecretMissing"
 	EventReasonConfigMapMissing       EventReason = "ConfigMapMissing"
+	EventReasonCredentialsRotated     EventReason = "CredentialsRotated"
+	EventReasonRotationFailed         EventReason = "CredentialRotationFailed"
 
 	// Network events
 	EventReasonNetworkPolicyApplied EventReason = "NetworkPolicyApplied"
@@ -90,6 +97,7 @@ type EnhancedEventRecorder struct {
 	eventHistory    []EventRecord
 	maxHistorySize  int
 	componentPrefix string
+	exporter        *siem.Exporter
 }
 
 // EventRecord represents a recorded event with metadata
@@ -135,6 +143,50 @@ func (er *EnhancedEventRecorder) RecordEvent(object runtime.Object, eventType Ev
 	}
 
 	er.addToHistory(record)
+	er.export(record)
+}
+
+// SetExporter forwards all recorded events to the given SIEM exporter
+func (er *EnhancedEventRecorder) SetExporter(exporter *siem.Exporter) {
+	er.exporter = exporter
+}
+
+// export ships an event record to the SIEM exporter if one is configured
+func (er *EnhancedEventRecorder) export(record EventRecord) {
+	if er.exporter == nil {
+		return
+	}
+
+	severity := siem.SeverityLow
+	outcome := "success"
+	if record.Type == EventTypeWarning {
+		severity = siem.SeverityMedium
+		outcome = "failure"
+	}
+	if er.isErrorReason(record.Reason) {
+		severity = siem.SeverityHigh
+		outcome = "failure"
+	}
+
+	siemRecord := siem.Record{
+		Kind:      siem.KindPlatform,
+		Timestamp: record.Timestamp,
+		Action:    string(record.Reason),
+		Severity:  severity,
+		User:      record.Component,
+		Outcome:   outcome,
+		Message:   record.Message,
+		Details:   record.Details,
+	}
+	if obj, err := meta.Accessor(record.Object); err == nil {
+		siemRecord.Namespace = obj.GetNamespace()
+		siemRecord.Name = obj.GetName()
+	}
+	if record.Object != nil {
+		siemRecord.Resource = record.Object.GetObjectKind().GroupVersionKind().Kind
+	}
+
+	er.exporter.Publish(siemRecord)
 }
 
 // RecordPlatformEvent records platform-level events
@@ -291,6 +343,8 @@ func (er *EnhancedEventRecorder) addToHistory(record EventRecord) {
 func (er *EnhancedEventRecorder) isErrorReason(reason EventReason) bool {
 	errorReasons := []EventReason{
 		EventReasonPlatformFailed,
+		EventReasonDeletionStuck,
+		EventReasonUnschedulable,
 		EventReasonComponentFailed,
 		EventReasonResourceFailed,
 		EventReasonBackupFailed,
@@ -298,6 +352,7 @@ func (er *EnhancedEventRecorder) isErrorReason(reason EventReason) bool {
 		EventReasonConfigValidationFailed,
 		EventReasonSecretMissing,
 		EventReasonConfigMapMissing,
+		EventReasonRotationFailed,
 		EventReasonInsufficientQuota,
 		EventReasonStorageError,
 		EventReasonDNSError,
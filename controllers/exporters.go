@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/exporters"
+	"github.com/gunjanjp/gunj-operator/internal/managers"
+)
+
+// reconcileExporters deploys the exporters of spec.exporters and removes the
+// ones which were dropped from the spec. Prometheus scrapes them and Grafana
+// provisions their dashboards from the same spec.
+func (r *ObservabilityPlatformReconciler) reconcileExporters(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx).WithValues("exporters", "reconcile")
+
+	desiredNames := map[string]bool{}
+	for _, exporter := range managers.Exporters(platform) {
+		if err := r.reconcileExporter(ctx, platform, exporter); err != nil {
+			return err
+		}
+		desiredNames[exporters.ObjectName(exporter)] = true
+	}
+
+	// Remove the exporters no longer in the spec
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(platform.Namespace), client.MatchingLabels{
+		"app.kubernetes.io/component": exporters.ComponentLabel,
+		"observability.io/platform":   platform.Name,
+	}); err != nil {
+		return fmt.Errorf("failed to list exporters: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		if desiredNames[deployment.Name] {
+			continue
+		}
+		for _, obj := range []client.Object{
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: deployment.Name, Namespace: platform.Namespace}},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: deployment.Name, Namespace: platform.Namespace}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: deployment.Name, Namespace: platform.Namespace}},
+		} {
+			if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete exporter %T: %w", obj, err)
+			}
+		}
+		log.V(1).Info("Exporter removed", "name", deployment.Name)
+	}
+
+	log.V(1).Info("Exporters reconciled", "count", len(desiredNames))
+	return nil
+}
+
+// reconcileExporter deploys an exporter with the Service Prometheus scrapes
+func (r *ObservabilityPlatformReconciler) reconcileExporter(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, exporter exporters.Exporter) error {
+	var annotations map[string]string
+	desiredConfig, err := exporters.BuildConfigMap(exporter)
+	if err != nil {
+		return err
+	}
+	if desiredConfig != nil {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: desiredConfig.Name, Namespace: desiredConfig.Namespace}}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+			cm.Labels = desiredConfig.Labels
+			cm.Data = desiredConfig.Data
+			return controllerutil.SetControllerReference(platform, cm, r.Scheme)
+		}); err != nil {
+			return fmt.Errorf("failed to reconcile %s exporter config: %w", exporter.Name, err)
+		}
+		// Restart the exporter when the configuration changes
+		annotations = map[string]string{
+			"observability.io/config-hash": fmt.Sprintf("%x", sha256.Sum256([]byte(desiredConfig.Data[exporters.ConfigKey]))),
+		}
+	}
+
+	desired := exporters.BuildDeployment(exporter)
+	desired.Spec.Template.Annotations = annotations
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		deployment.Labels = desired.Labels
+		// The selector is immutable, so only set it on creation
+		if deployment.CreationTimestamp.IsZero() {
+			deployment.Spec.Selector = desired.Spec.Selector
+		}
+		deployment.Spec.Replicas = desired.Spec.Replicas
+		deployment.Spec.Template = desired.Spec.Template
+		return controllerutil.SetControllerReference(platform, deployment, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile %s exporter: %w", exporter.Name, err)
+	}
+
+	desiredSvc := exporters.BuildService(exporter)
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: desiredSvc.Name, Namespace: desiredSvc.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, svc, func() error {
+		svc.Labels = desiredSvc.Labels
+		svc.Spec.Selector = desiredSvc.Spec.Selector
+		svc.Spec.Ports = desiredSvc.Spec.Ports
+		return controllerutil.SetControllerReference(platform, svc, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile %s exporter service: %w", exporter.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/incidents"
+	"github.com/gunjanjp/gunj-operator/internal/labeltransition"
+	"github.com/gunjanjp/gunj-operator/internal/managers/prometheus"
+)
+
+// externalLabelsAnnotationTag tags the Grafana annotations of external label
+// transitions
+const externalLabelsAnnotationTag = "external-labels"
+
+// reconcileExternalLabelTransition opens a dual-write window when the
+// external labels of Prometheus change and closes it when it ends. It runs
+// before the components are reconciled, so the configuration with the new
+// labels is rendered together with the remote writes restoring the previous
+// ones, and is applied by the same reload. The labels last rendered are kept
+// in status to detect the change.
+func (r *ObservabilityPlatformReconciler) reconcileExternalLabelTransition(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx).WithValues("externalLabelTransition", "reconcile")
+	defer r.persistExternalLabelStatus(ctx, platform)
+
+	prometheusSpec := platform.Spec.Components.Prometheus
+	if prometheusSpec == nil || !prometheusSpec.Enabled {
+		r.endExternalLabelTransition(ctx, platform, "Prometheus is disabled")
+		platform.Status.ExternalLabels = nil
+		return nil
+	}
+
+	labels, err := prometheus.ExternalLabels(ctx, r.Client, platform)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	previous := platform.Status.ExternalLabels
+	changed := previous != nil && labeltransition.Changed(previous, labels)
+	platform.Status.ExternalLabels = labels
+
+	spec := prometheusSpec.ExternalLabelTransition
+	if transition := platform.Status.ExternalLabelTransition; transition != nil {
+		switch {
+		case !spec.IsEnabled():
+			r.endExternalLabelTransition(ctx, platform, "transition disabled")
+		case changed:
+			// The labels changed again; the series move on from the labels
+			// written until now
+			r.endExternalLabelTransition(ctx, platform, "labels changed again")
+		case !now.Before(transition.End.Time):
+			r.endExternalLabelTransition(ctx, platform, "")
+		}
+	}
+
+	if !changed || !spec.IsEnabled() {
+		return nil
+	}
+	if len(prometheusSpec.RemoteWrite) == 0 {
+		// Local queries don't see external labels; only remote storage
+		// needs the previous labels
+		log.V(1).Info("External labels changed without remote write targets, no transition needed")
+		return nil
+	}
+
+	transition := &observabilityv1beta1.ExternalLabelTransitionStatus{
+		PreviousLabels: previous,
+		Labels:         labels,
+		Start:          metav1.NewTime(now),
+		End:            metav1.NewTime(now.Add(spec.GetWindow())),
+	}
+	platform.Status.ExternalLabelTransition = transition
+
+	changes := labeltransition.Describe(previous, labels)
+	log.Info("Writing both external label sets", "changes", changes, "end", transition.End.Time)
+	r.EventRecorder.RecordComponentEvent(platform, "prometheus", "ExternalLabelTransitionStarted",
+		fmt.Sprintf("External labels changed (%s), writing the previous labels too until %s",
+			changes, transition.End.UTC().Format(time.RFC3339)))
+
+	transition.AnnotationIDs = r.annotateExternalLabelTransition(ctx, platform, spec,
+		fmt.Sprintf("Prometheus external labels of platform %s/%s changed: %s. The previous labels are written until %s.",
+			platform.Namespace, platform.Name, changes, transition.End.UTC().Format(time.RFC3339)))
+	return nil
+}
+
+// persistExternalLabelStatus writes the labels last rendered and the
+// dual-write window, so a change is detected against the labels of the
+// previous reconcile and the window survives restarts
+func (r *ObservabilityPlatformReconciler) persistExternalLabelStatus(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) {
+	var labels map[string]string
+	if platform.Status.ExternalLabels != nil {
+		labels = make(map[string]string, len(platform.Status.ExternalLabels))
+		for name, value := range platform.Status.ExternalLabels {
+			labels[name] = value
+		}
+	}
+	transition := platform.Status.ExternalLabelTransition.DeepCopy()
+	if err := r.StatusManager.UpdatePlatformStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
+		status.ExternalLabels = labels
+		status.ExternalLabelTransition = transition
+	}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update external label status")
+	}
+}
+
+// endExternalLabelTransition closes the dual-write window, if one is open,
+// and its Grafana annotations. Without a reason, the window ran its course.
+func (r *ObservabilityPlatformReconciler) endExternalLabelTransition(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, reason string) {
+	transition := platform.Status.ExternalLabelTransition
+	if transition == nil {
+		return
+	}
+	log := log.FromContext(ctx).WithValues("externalLabelTransition", "end")
+	platform.Status.ExternalLabelTransition = nil
+
+	message := "Stopped writing the previous external labels"
+	if reason != "" {
+		message += " early: " + reason
+	}
+	log.Info(message)
+	r.EventRecorder.RecordComponentEvent(platform, "prometheus", "ExternalLabelTransitionEnded", message)
+
+	if len(transition.AnnotationIDs) == 0 {
+		return
+	}
+	grafanaSpec := platform.Spec.Components.Grafana
+	if grafanaSpec == nil || !grafanaSpec.Enabled {
+		return
+	}
+	annotationClient, err := r.grafanaAnnotationClient(ctx, platform, grafanaSpec)
+	if err != nil {
+		log.Error(err, "Failed to close external label annotations")
+		return
+	}
+	now := time.Now()
+	for _, id := range transition.AnnotationIDs {
+		// Best effort; an annotation left open only misses its end
+		if err := annotationClient.End(ctx, id, now, message+"."); err != nil {
+			log.Error(err, "Failed to close external label annotation", "annotation", id)
+		}
+	}
+}
+
+// annotateExternalLabelTransition marks the start of a dual-write window on
+// the Grafana dashboards and returns the annotation IDs. Annotating is best
+// effort and never holds the transition.
+func (r *ObservabilityPlatformReconciler) annotateExternalLabelTransition(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, spec *observabilityv1beta1.ExternalLabelTransitionSpec, text string) []int64 {
+	grafanaSpec := platform.Spec.Components.Grafana
+	if grafanaSpec == nil || !grafanaSpec.Enabled {
+		return nil
+	}
+	log := log.FromContext(ctx).WithValues("externalLabelTransition", "annotate")
+
+	annotationClient, err := r.grafanaAnnotationClient(ctx, platform, grafanaSpec)
+	if err != nil {
+		log.Error(err, "Failed to annotate external label change")
+		r.EventRecorder.RecordComponentEvent(platform, "prometheus", "ExternalLabelAnnotationFailed", err.Error())
+		return nil
+	}
+
+	dashboards := spec.DashboardUIDs
+	if len(dashboards) == 0 {
+		// Organization-wide annotation
+		dashboards = []string{""}
+	}
+
+	var ids []int64
+	now := time.Now()
+	for _, uid := range dashboards {
+		id, err := annotationClient.Create(ctx, incidents.Annotation{
+			DashboardUID: uid,
+			Time:         now,
+			Text:         text,
+			Tags:         []string{"gunj-operator", "platform:" + platform.Name, "component:prometheus", externalLabelsAnnotationTag},
+		})
+		if err != nil {
+			log.Error(err, "Failed to annotate external label change", "dashboard", uid)
+			r.EventRecorder.RecordComponentEvent(platform, "prometheus", "ExternalLabelAnnotationFailed",
+				fmt.Sprintf("Failed to annotate dashboard %q: %v", uid, err))
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
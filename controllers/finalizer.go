@@ -61,6 +61,7 @@ func (fm *FinalizerManager) AddFinalizers(ctx context.Context, platform *observa
 		FinalizerName,
 		ComponentFinalizer,
 		ExternalResourceFinalizer,
+		DataProtectionFinalizer,
 	}
 	
 	// Add backup finalizer if backup is enabled
@@ -114,7 +115,15 @@ func (fm *FinalizerManager) HandleDeletion(ctx context.Context, platform *observ
 	deleteCtx, cancel := context.WithTimeout(ctx, FinalizerDeletionGracePeriod)
 	defer cancel()
 	
-	// Process finalizers in order
+	// Process finalizers in order, applying the deletion policy first as the
+	// other finalizers delete the volumes and the storage credentials
+	if controllerutil.ContainsFinalizer(platform, DataProtectionFinalizer) {
+		if err := fm.handleDataProtectionFinalizer(deleteCtx, platform, r); err != nil {
+			return fmt.Errorf("data protection finalizer failed: %w", err)
+		}
+		fm.removeFinalizer(ctx, platform, DataProtectionFinalizer, r)
+	}
+
 	if controllerutil.ContainsFinalizer(platform, BackupFinalizer) {
 		if err := fm.handleBackupFinalizer(deleteCtx, platform, r); err != nil {
 			return fmt.Errorf("backup finalizer failed: %w", err)
@@ -330,6 +339,31 @@ func (fm *FinalizerManager) handleMainFinalizer(ctx context.Context, platform *o
 		log.Error(err, "Failed to cleanup namespace labels")
 	}
 	
+	// Release the node pool nodes this platform labeled and tainted
+	if err := r.releaseNodePool(ctx, platform); err != nil {
+		log.Error(err, "Failed to release node pool")
+	}
+
+	// Remove the Alloy collector's cluster scoped RBAC
+	if err := r.deleteAlloy(ctx, platform); err != nil {
+		log.Error(err, "Failed to remove Alloy")
+	}
+
+	// Remove the eBPF agent's cluster scoped RBAC
+	if err := r.deleteEBPFAgent(ctx, platform); err != nil {
+		log.Error(err, "Failed to remove eBPF agent")
+	}
+
+	// Remove the event exporter's cluster scoped RBAC
+	if err := r.deleteEventExporter(ctx, platform); err != nil {
+		log.Error(err, "Failed to remove event exporter")
+	}
+
+	// Remove any other child linked to the platform by tracking labels
+	if err := r.deleteTrackedChildren(ctx, platform); err != nil {
+		log.Error(err, "Failed to remove tracked children")
+	}
+	
 	// Record final event
 	r.EventRecorder.RecordPlatformEvent(platform, EventReasonPlatformDeleted, "Platform cleanup completed")
 	
@@ -355,6 +389,12 @@ func (fm *FinalizerManager) cleanupPVCs(ctx context.Context, platform *observabi
 	}
 	
 	for _, pvc := range pvcList.Items {
+		// Skip the volumes kept by the Retain deletion policy
+		if pvc.Annotations[retainedVolumeAnnotation] == "true" {
+			log.V(1).Info("Skipping retained PVC", "name", pvc.Name)
+			continue
+		}
+		
 		log.V(1).Info("Deleting PVC", "name", pvc.Name)
 		if err := r.Delete(ctx, &pvc); err != nil && !errors.IsNotFound(err) {
 			log.Error(err, "Failed to delete PVC", "name", pvc.Name)
@@ -539,8 +579,11 @@ func (fm *FinalizerManager) removeFinalizer(ctx context.Context, platform *obser
 	
 	if controllerutil.ContainsFinalizer(platform, finalizer) {
 		log.V(1).Info("Removing finalizer", "finalizer", finalizer)
+		// Patch only the finalizers: the spec may be merged onto base
+		// platforms and must not be written back
+		patch := client.MergeFromWithOptions(platform.DeepCopy(), client.MergeFromWithOptimisticLock{})
 		controllerutil.RemoveFinalizer(platform, finalizer)
-		if err := r.Update(ctx, platform); err != nil {
+		if err := r.Patch(ctx, platform, patch); err != nil {
 			log.Error(err, "Failed to remove finalizer", "finalizer", finalizer)
 		}
 	}
@@ -0,0 +1,229 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/grafanareports"
+)
+
+// grafanaReportsTick is how often reports are checked for a due run
+const grafanaReportsTick = 30 * time.Second
+
+// GrafanaReports renders the reports configured in
+// spec.components.grafana.reports on their schedules through the platform's
+// image renderer, and delivers them by email and webhook. Runs are recorded in
+// status.grafanaReports. It implements manager.Runnable.
+type GrafanaReports struct {
+	Client client.Client
+	HTTP   *http.Client
+	Log    logr.Logger
+}
+
+// Start runs the report loop until the context is cancelled
+func (r *GrafanaReports) Start(ctx context.Context) error {
+	if r.HTTP == nil {
+		// Rendering a large dashboard takes a while
+		r.HTTP = &http.Client{Timeout: 2 * time.Minute}
+	}
+
+	ticker := time.NewTicker(grafanaReportsTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			r.runAll(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection returns true so only the leader sends reports
+func (r *GrafanaReports) NeedLeaderElection() bool {
+	return true
+}
+
+// runAll advances the schedules of all reports and runs the due ones
+func (r *GrafanaReports) runAll(ctx context.Context) {
+	platforms := &observabilityv1beta1.ObservabilityPlatformList{}
+	if err := r.Client.List(ctx, platforms); err != nil {
+		r.Log.Error(err, "Failed to list platforms for Grafana reports")
+		return
+	}
+
+	for i := range platforms.Items {
+		platform := &platforms.Items[i]
+		if !platform.DeletionTimestamp.IsZero() || platform.Spec.Paused {
+			continue
+		}
+		reports := grafanaReports(platform)
+		if len(reports) == 0 && len(platform.Status.GrafanaReports) == 0 {
+			continue
+		}
+
+		previous := make(map[string]observabilityv1beta1.GrafanaReportStatus, len(platform.Status.GrafanaReports))
+		for _, status := range platform.Status.GrafanaReports {
+			previous[status.Name] = status
+		}
+
+		statuses := make([]observabilityv1beta1.GrafanaReportStatus, 0, len(reports))
+		for j := range reports {
+			statuses = append(statuses, r.advance(ctx, platform, &reports[j], previous[reports[j].Name]))
+		}
+		if len(statuses) == 0 {
+			statuses = nil
+		}
+
+		if equality.Semantic.DeepEqual(statuses, platform.Status.GrafanaReports) {
+			continue
+		}
+		if err := r.writeStatus(ctx, platform, statuses); err != nil {
+			r.Log.Error(err, "Failed to write Grafana report status", "platform", platform.Name, "namespace", platform.Namespace)
+		}
+	}
+}
+
+// advance moves a report's schedule forward and runs the report if it is due
+func (r *GrafanaReports) advance(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, report *observabilityv1beta1.GrafanaReportSpec, previous observabilityv1beta1.GrafanaReportStatus) observabilityv1beta1.GrafanaReportStatus {
+	now := time.Now()
+	status, due, err := grafanareports.Advance(report, previous, now)
+	if err != nil {
+		status.Message = err.Error()
+		return status
+	}
+	if !due {
+		return status
+	}
+
+	runTime := metav1.NewTime(now)
+	status.LastRunTime = &runTime
+	if err := r.run(ctx, platform, report, now); err != nil {
+		r.Log.Error(err, "Grafana report failed", "platform", platform.Name, "namespace", platform.Namespace, "report", report.Name)
+		status.Message = err.Error()
+		return status
+	}
+	status.LastSuccessTime = &runTime
+	status.Message = ""
+	r.Log.Info("Delivered Grafana report", "platform", platform.Name, "namespace", platform.Namespace, "report", report.Name)
+	return status
+}
+
+// run renders a report and delivers it to all recipients. Delivery continues
+// after a failed recipient; the failures are returned together.
+func (r *GrafanaReports) run(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, report *observabilityv1beta1.GrafanaReportSpec, now time.Time) error {
+	grafana, err := grafanaClient(ctx, r.Client, r.HTTP, platform)
+	if err != nil {
+		return err
+	}
+	renderer := &grafanareports.Renderer{
+		BaseURL:  grafana.BaseURL,
+		HTTP:     r.HTTP,
+		Username: grafana.Username,
+		Password: grafana.Password,
+	}
+	rendered, err := renderer.Render(ctx, report, now)
+	if err != nil {
+		return err
+	}
+
+	var failures []string
+	if len(report.Recipients.Emails) > 0 {
+		if err := r.mail(ctx, platform.Namespace, report, rendered); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	for _, webhook := range report.Recipients.Webhooks {
+		token, err := r.bearerToken(ctx, platform.Namespace, webhook.BearerTokenSecretRef)
+		if err == nil {
+			err = grafanareports.Post(ctx, r.HTTP, webhook.URL, token, rendered)
+		}
+		if err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// mail sends a report to its email recipients
+func (r *GrafanaReports) mail(ctx context.Context, namespace string, report *observabilityv1beta1.GrafanaReportSpec, rendered *grafanareports.Report) error {
+	settings := report.Recipients.SMTP
+	if settings == nil {
+		return fmt.Errorf("report %s has email recipients but no SMTP server", report.Name)
+	}
+	mailer := &grafanareports.SMTP{
+		Host: settings.Host,
+		Port: int(settings.GetPort()),
+		From: settings.From,
+	}
+	if settings.CredentialsSecretRef != nil {
+		secret := &corev1.Secret{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: settings.CredentialsSecretRef.Name}, secret); err != nil {
+			return fmt.Errorf("failed to get SMTP credentials secret %s: %w", settings.CredentialsSecretRef.Name, err)
+		}
+		mailer.Username = string(secret.Data["username"])
+		mailer.Password = string(secret.Data["password"])
+	}
+	return mailer.Mail(report.Recipients.Emails, rendered)
+}
+
+// bearerToken reads the token of a webhook from its Secret
+func (r *GrafanaReports) bearerToken(ctx context.Context, namespace string, ref *corev1.SecretKeySelector) (string, error) {
+	if ref == nil {
+		return "", nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+		return "", fmt.Errorf("failed to get webhook token secret %s: %w", ref.Name, err)
+	}
+	token, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("webhook token secret %s has no key %s", ref.Name, ref.Key)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
+// writeStatus records the report runs of a platform
+func (r *GrafanaReports) writeStatus(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, statuses []observabilityv1beta1.GrafanaReportStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &observabilityv1beta1.ObservabilityPlatform{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(platform), latest); err != nil {
+			return err
+		}
+		latest.Status.GrafanaReports = statuses
+		return r.Client.Status().Update(ctx, latest)
+	})
+}
+
+// grafanaReports returns the reports of a platform's Grafana; reports need
+// the image renderer
+func grafanaReports(platform *observabilityv1beta1.ObservabilityPlatform) []observabilityv1beta1.GrafanaReportSpec {
+	if platform.Spec.Components == nil || platform.Spec.Components.Grafana == nil || !platform.Spec.Components.Grafana.Enabled {
+		return nil
+	}
+	if !platform.Spec.Components.Grafana.ImageRendererEnabled() {
+		return nil
+	}
+	return platform.Spec.Components.Grafana.Reports
+}
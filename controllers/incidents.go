@@ -0,0 +1,180 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/incidents"
+)
+
+// reconcileIncidentAnnotations creates a Grafana annotation when a component
+// becomes degraded and closes it into a region when the component recovers.
+// Open incidents are tracked in the platform status so the window can be
+// closed after an operator restart.
+func (r *ObservabilityPlatformReconciler) reconcileIncidentAnnotations(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx).WithValues("incidentAnnotations", "reconcile")
+	defer r.persistOpenIncidents(ctx, platform)
+
+	grafanaSpec := platform.Spec.Components.Grafana
+	if grafanaSpec == nil || !grafanaSpec.Enabled {
+		return nil
+	}
+
+	spec := platform.Spec.IncidentAnnotations
+	states := make(map[string]bool, len(platform.Status.ComponentStatuses))
+	for component, status := range platform.Status.ComponentStatuses {
+		states[component] = status.Ready
+	}
+	open := make(map[string]bool, len(platform.Status.OpenIncidents))
+	for component := range platform.Status.OpenIncidents {
+		open[component] = true
+	}
+
+	started, recovered := incidents.Transitions(open, states, spec.Components)
+	pending := false
+	for _, incident := range platform.Status.OpenIncidents {
+		pending = pending || len(incident.PendingDashboards) > 0
+	}
+	if len(started) == 0 && len(recovered) == 0 && !pending {
+		return nil
+	}
+
+	// Grafana itself may be the degraded component; it cannot be annotated then
+	if ready, ok := states["grafana"]; ok && !ready {
+		log.V(1).Info("Grafana is not ready, deferring incident annotations")
+		return nil
+	}
+
+	annotationClient, err := r.grafanaAnnotationClient(ctx, platform, grafanaSpec)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if platform.Status.OpenIncidents == nil {
+		platform.Status.OpenIncidents = make(map[string]observabilityv1beta1.IncidentAnnotationStatus)
+	}
+
+	for _, component := range recovered {
+		incident := platform.Status.OpenIncidents[component]
+		text := fmt.Sprintf("%s incident on platform %s/%s resolved after %s",
+			component, platform.Namespace, platform.Name, now.Sub(incident.Start.Time).Round(time.Second))
+		for len(incident.AnnotationIDs) > 0 {
+			if err := annotationClient.End(ctx, incident.AnnotationIDs[0], now, text); err != nil {
+				return fmt.Errorf("failed to close incident annotation for %s: %w", component, err)
+			}
+			// Closed annotations are dropped at once, so a failure only
+			// retries the others
+			incident.AnnotationIDs = incident.AnnotationIDs[1:]
+			platform.Status.OpenIncidents[component] = incident
+		}
+		delete(platform.Status.OpenIncidents, component)
+		log.Info("Closed incident annotation", "component", component)
+	}
+
+	for _, component := range started {
+		dashboards := spec.DashboardUIDs
+		if len(dashboards) == 0 {
+			// Organization-wide annotation
+			dashboards = []string{""}
+		}
+		platform.Status.OpenIncidents[component] = observabilityv1beta1.IncidentAnnotationStatus{
+			Start:             metav1.NewTime(now),
+			PendingDashboards: append([]string(nil), dashboards...),
+		}
+		log.Info("Opened incident annotation", "component", component)
+	}
+
+	components := make([]string, 0, len(platform.Status.OpenIncidents))
+	for component := range platform.Status.OpenIncidents {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+	for _, component := range components {
+		if err := r.annotateIncident(ctx, annotationClient, platform, component); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// annotateIncident creates the pending annotations of an open incident. Each
+// annotation is recorded as soon as it's created, so a failure doesn't
+// create it again on the next reconcile.
+func (r *ObservabilityPlatformReconciler) annotateIncident(ctx context.Context, annotationClient *incidents.AnnotationClient, platform *observabilityv1beta1.ObservabilityPlatform, component string) error {
+	incident := platform.Status.OpenIncidents[component]
+	if len(incident.PendingDashboards) == 0 {
+		return nil
+	}
+
+	spec := platform.Spec.IncidentAnnotations
+	text := fmt.Sprintf("%s degraded on platform %s/%s", component, platform.Namespace, platform.Name)
+	if message := platform.Status.ComponentStatuses[component].Message; message != "" {
+		text += ": " + message
+	}
+
+	for len(incident.PendingDashboards) > 0 {
+		id, err := annotationClient.Create(ctx, incidents.Annotation{
+			DashboardUID: incident.PendingDashboards[0],
+			Time:         incident.Start.Time,
+			Text:         text,
+			Tags:         incidents.Tags(platform.Name, component, spec.Tags),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create incident annotation for %s: %w", component, err)
+		}
+		incident.AnnotationIDs = append(incident.AnnotationIDs, id)
+		incident.PendingDashboards = incident.PendingDashboards[1:]
+		platform.Status.OpenIncidents[component] = incident
+	}
+	return nil
+}
+
+// persistOpenIncidents writes the open incidents, so their annotations are
+// closed, and not created again, after a requeue or restart
+func (r *ObservabilityPlatformReconciler) persistOpenIncidents(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) {
+	var open map[string]observabilityv1beta1.IncidentAnnotationStatus
+	for component, incident := range platform.Status.OpenIncidents {
+		if open == nil {
+			open = make(map[string]observabilityv1beta1.IncidentAnnotationStatus, len(platform.Status.OpenIncidents))
+		}
+		open[component] = *incident.DeepCopy()
+	}
+	if err := r.StatusManager.UpdatePlatformStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
+		status.OpenIncidents = open
+	}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update open incidents")
+	}
+}
+
+// grafanaAnnotationClient creates an annotation client for the platform's Grafana using the admin credentials
+func (r *ObservabilityPlatformReconciler) grafanaAnnotationClient(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, grafanaSpec *observabilityv1beta1.GrafanaSpec) (*incidents.AnnotationClient, error) {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Name: fmt.Sprintf("grafana-%s-admin", platform.Name), Namespace: platform.Namespace}
+	if err := r.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("failed to get Grafana admin secret: %w", err)
+	}
+
+	user := grafanaSpec.AdminUser
+	if user == "" {
+		user = "admin"
+	}
+
+	url := fmt.Sprintf("http://grafana-%s.%s.svc:3000", platform.Name, platform.Namespace)
+	return incidents.NewAnnotationClient(url, user, string(secret.Data["admin-password"])), nil
+}
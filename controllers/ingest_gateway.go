@@ -0,0 +1,306 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/apikeys"
+	"github.com/gunjanjp/gunj-operator/internal/ingestgateway"
+)
+
+// ingestGatewayEndpointKey is the key of the gateway in the platform's
+// status endpoints
+const ingestGatewayEndpointKey = "ingest-gateway"
+
+// reconcileIngestGateway deploys the ingest gateway terminating the writes of
+// producers outside of the cluster, and removes it when it is disabled
+func (r *ObservabilityPlatformReconciler) reconcileIngestGateway(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx).WithValues("ingestGateway", "reconcile")
+
+	spec := platform.Spec.IngestGateway
+	if !spec.IsEnabled() {
+		platform.RemoveEndpoint(ingestGatewayEndpointKey)
+		return r.deleteIngestGateway(ctx, platform)
+	}
+
+	tenants, err := r.ingestGatewayTenants(ctx, platform)
+	if err != nil {
+		return err
+	}
+	if err := ingestgateway.CheckAPIKeyHashes(tenants); err != nil {
+		return fmt.Errorf("invalid ingest gateway tenants: %w", err)
+	}
+	gateway := ingestgateway.Gateway{
+		Name:        ingestgateway.Name(platform.Name),
+		Namespace:   platform.Namespace,
+		Image:       spec.Image,
+		Replicas:    spec.GetReplicas(),
+		Resources:   spec.Resources,
+		Mode:        spec.GetAuthMode(),
+		MaxBodySize: spec.MaxBodySize,
+		Tenants:     tenants,
+		Upstreams:   ingestGatewayUpstreams(platform),
+		Labels: map[string]string{
+			"app.kubernetes.io/name":       "ingest-gateway",
+			"app.kubernetes.io/instance":   platform.Name,
+			"app.kubernetes.io/managed-by": "gunj-operator",
+			"app.kubernetes.io/part-of":    "observability-platform",
+			"observability.io/platform":    platform.Name,
+		},
+	}
+	if spec.TLS != nil {
+		gateway.TLSSecretName = spec.TLS.SecretName
+		gateway.ClientCASecretName = spec.TLS.ClientCASecretName
+	}
+
+	desiredSecret := ingestgateway.BuildSecret(gateway)
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: desiredSecret.Name, Namespace: desiredSecret.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		secret.Labels = desiredSecret.Labels
+		secret.Type = desiredSecret.Type
+		secret.Data = desiredSecret.Data
+		return controllerutil.SetControllerReference(platform, secret, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile ingest gateway config: %w", err)
+	}
+
+	desired := ingestgateway.BuildDeployment(gateway)
+	// Restart the gateway when the configuration changes, e.g. a rotated API key
+	desired.Spec.Template.Annotations = map[string]string{
+		"observability.io/config-hash": fmt.Sprintf("%x", sha256.Sum256(desiredSecret.Data[ingestgateway.ConfigKey])),
+	}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		deployment.Labels = desired.Labels
+		// The selector is immutable, so only set it on creation
+		if deployment.CreationTimestamp.IsZero() {
+			deployment.Spec.Selector = desired.Spec.Selector
+		}
+		deployment.Spec.Replicas = desired.Spec.Replicas
+		deployment.Spec.Template = desired.Spec.Template
+		return controllerutil.SetControllerReference(platform, deployment, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile ingest gateway deployment: %w", err)
+	}
+
+	desiredService := ingestgateway.BuildService(gateway)
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: desiredService.Name, Namespace: desiredService.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		service.Labels = desiredService.Labels
+		service.Spec.Selector = desiredService.Spec.Selector
+		service.Spec.Ports = desiredService.Spec.Ports
+		// Behind an Ingress the gateway is only reached in the cluster
+		service.Spec.Type = corev1.ServiceTypeClusterIP
+		service.Spec.LoadBalancerSourceRanges = nil
+		service.Annotations = nil
+		if spec.Ingress == nil || !spec.Ingress.Enabled {
+			service.Spec.Type = corev1.ServiceTypeLoadBalancer
+			if s := spec.Service; s != nil {
+				if s.Type != "" {
+					service.Spec.Type = s.Type
+				}
+				service.Annotations = s.Annotations
+				if service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+					service.Spec.LoadBalancerSourceRanges = s.LoadBalancerSourceRanges
+				}
+			}
+		}
+		return controllerutil.SetControllerReference(platform, service, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile ingest gateway service: %w", err)
+	}
+
+	scheme := "http"
+	if gateway.TLSSecretName != "" {
+		scheme = "https"
+	}
+	endpoint := fmt.Sprintf("%s://%s.%s.svc.cluster.local:%d", scheme, service.Name, service.Namespace, ingestgateway.Port)
+	if lb := service.Status.LoadBalancer.Ingress; len(lb) > 0 {
+		address := lb[0].IP
+		if lb[0].Hostname != "" {
+			address = lb[0].Hostname
+		}
+		endpoint = fmt.Sprintf("%s://%s:%d", scheme, address, ingestgateway.Port)
+	}
+
+	ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: gateway.Name, Namespace: gateway.Namespace}}
+	if spec.Ingress != nil && spec.Ingress.Enabled {
+		if err := r.reconcileIngestGatewayIngress(ctx, platform, ingress, gateway, spec.Ingress); err != nil {
+			return err
+		}
+		endpoint = fmt.Sprintf("http://%s", spec.Ingress.Host)
+		if spec.Ingress.TLS != nil && spec.Ingress.TLS.Enabled {
+			endpoint = fmt.Sprintf("https://%s", spec.Ingress.Host)
+		}
+	} else if err := r.Delete(ctx, ingress); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete ingest gateway ingress: %w", err)
+	}
+	platform.UpdateEndpoint(ingestGatewayEndpointKey, endpoint)
+
+	log.V(1).Info("Ingest gateway reconciled", "mode", gateway.Mode, "tenants", len(tenants))
+	return nil
+}
+
+// reconcileIngestGatewayIngress creates or updates the Ingress of the gateway
+func (r *ObservabilityPlatformReconciler) reconcileIngestGatewayIngress(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, ingress *networkingv1.Ingress, gateway ingestgateway.Gateway, spec *observabilityv1beta1.IngressSpec) error {
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, ingress, func() error {
+		ingress.Labels = gateway.Labels
+		ingress.Annotations = map[string]string{}
+		for k, v := range spec.Annotations {
+			ingress.Annotations[k] = v
+		}
+		// Keep large remote write batches
+		if gateway.MaxBodySize != "" {
+			ingress.Annotations["nginx.ingress.kubernetes.io/proxy-body-size"] = gateway.MaxBodySize
+		}
+		if gateway.TLSSecretName != "" {
+			ingress.Annotations["nginx.ingress.kubernetes.io/backend-protocol"] = "HTTPS"
+		}
+
+		pathType := networkingv1.PathTypePrefix
+		ingress.Spec = networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{{
+				Host: spec.Host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     "/",
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: gateway.Name,
+									Port: networkingv1.ServiceBackendPort{Number: ingestgateway.Port},
+								},
+							},
+						}},
+					},
+				},
+			}},
+		}
+		if spec.ClassName != "" {
+			ingress.Spec.IngressClassName = &spec.ClassName
+		}
+		if spec.TLS != nil && spec.TLS.Enabled {
+			ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{spec.Host}, SecretName: spec.TLS.SecretName}}
+		}
+		return controllerutil.SetControllerReference(platform, ingress, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile ingest gateway ingress: %w", err)
+	}
+	return nil
+}
+
+// ingestGatewayTenants returns the tenants of the gateway with the hashes of
+// their API keys: the keys read from the Secrets of the spec's tenants, and
+// the keys of the platform's ApiKeys with ingest scopes
+func (r *ObservabilityPlatformReconciler) ingestGatewayTenants(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) ([]ingestgateway.Tenant, error) {
+	spec := platform.Spec.IngestGateway
+	tenants := make([]ingestgateway.Tenant, 0, len(spec.Tenants))
+	for _, t := range spec.Tenants {
+		tenant := ingestgateway.Tenant{Name: t.Name, CommonNames: t.ClientCommonNames, Signals: t.Signals}
+		if ref := t.APIKeySecretRef; ref != nil && spec.GetAuthMode() == observabilityv1beta1.IngestAuthAPIKey {
+			secret := &corev1.Secret{}
+			if err := r.Get(ctx, client.ObjectKey{Namespace: platform.Namespace, Name: ref.Name}, secret); err != nil {
+				return nil, fmt.Errorf("failed to get the API key of tenant %s: %w", t.Name, err)
+			}
+			key := strings.TrimSpace(string(secret.Data[ref.Key]))
+			if !ingestgateway.ValidAPIKey(key) {
+				return nil, fmt.Errorf("the API key of tenant %s in secret %s must be at least 16 token characters", t.Name, ref.Name)
+			}
+			tenant.APIKeyHashes = append(tenant.APIKeyHashes, apikeys.Hash(key))
+		}
+		tenants = append(tenants, tenant)
+	}
+	if spec.GetAuthMode() != observabilityv1beta1.IngestAuthAPIKey {
+		return tenants, nil
+	}
+
+	apiKeys := &observabilityv1beta1.ApiKeyList{}
+	if err := r.List(ctx, apiKeys, client.InNamespace(platform.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	now := time.Now()
+	for i := range apiKeys.Items {
+		apiKey := &apiKeys.Items[i]
+		if apiKey.Spec.Platform != platform.Name {
+			continue
+		}
+		signals := apikeys.Signals(apiKey.Spec.Scopes)
+		hashes := apikeys.Hashes(apiKey, now)
+		if len(signals) == 0 || len(hashes) == 0 {
+			continue
+		}
+		tenants = append(tenants, ingestgateway.Tenant{Name: apiKey.Spec.Tenant, APIKeyHashes: hashes, Signals: signals})
+	}
+	return tenants, nil
+}
+
+// findPlatformForApiKey returns the platform of an ApiKey, whose gateway
+// accepts its keys
+func (r *ObservabilityPlatformReconciler) findPlatformForApiKey(obj client.Object) []reconcile.Request {
+	apiKey, ok := obj.(*observabilityv1beta1.ApiKey)
+	if !ok || apiKey.Spec.Platform == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: apiKey.Namespace, Name: apiKey.Spec.Platform}}}
+}
+
+// ingestGatewayUpstreams returns the backends of the platform the gateway
+// forwards to
+func ingestGatewayUpstreams(platform *observabilityv1beta1.ObservabilityPlatform) ingestgateway.Upstreams {
+	upstreams := ingestgateway.Upstreams{}
+	components := platform.Spec.Components
+	if components == nil {
+		return upstreams
+	}
+	if components.Prometheus != nil && components.Prometheus.Enabled {
+		upstreams.Prometheus = fmt.Sprintf("http://prometheus-%s.%s.svc.cluster.local:9090", platform.Name, platform.Namespace)
+	}
+	if components.Loki != nil && components.Loki.Enabled {
+		upstreams.Loki = fmt.Sprintf("http://loki-%s.%s.svc.cluster.local:3100", platform.Name, platform.Namespace)
+	}
+	if tempo := components.Tempo; tempo != nil && tempo.Enabled && tempo.Receivers.IsEnabled(observabilityv1beta1.ReceiverOTLPHTTP) {
+		scheme := "http"
+		if tempo.ReceiverTLS.IsEnabled() {
+			scheme = "https"
+		}
+		upstreams.Tempo = fmt.Sprintf("%s://%s-tempo.%s.svc.cluster.local:4318", scheme, platform.Name, platform.Namespace)
+	}
+	return upstreams
+}
+
+// deleteIngestGateway removes the ingest gateway of a platform
+func (r *ObservabilityPlatformReconciler) deleteIngestGateway(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	name, namespace := ingestgateway.Name(platform.Name), platform.Namespace
+	for _, obj := range []client.Object{
+		&networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+	} {
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete ingest gateway %T: %w", obj, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/loglevel"
+	"github.com/gunjanjp/gunj-operator/internal/managers"
+)
+
+// componentLogging returns the logging settings of the enabled components,
+// keyed by component
+func componentLogging(platform *observabilityv1beta1.ObservabilityPlatform) map[string]observabilityv1beta1.ComponentLogging {
+	logging := make(map[string]observabilityv1beta1.ComponentLogging)
+	components := platform.Spec.Components
+	if components == nil {
+		return logging
+	}
+	if p := components.Prometheus; p != nil && p.Enabled {
+		logging["prometheus"] = p.ComponentLogging
+	}
+	if g := components.Grafana; g != nil && g.Enabled {
+		logging["grafana"] = g.ComponentLogging
+	}
+	if l := components.Loki; l != nil && l.Enabled {
+		logging["loki"] = l.ComponentLogging
+	}
+	if t := components.Tempo; t != nil && t.Enabled {
+		logging["tempo"] = t.ComponentLogging
+	}
+	if components.AlloyEnabled() {
+		logging["alloy"] = components.GrafanaAlloy.ComponentLogging
+	}
+	return logging
+}
+
+// reconcileDebugModes opens and expires the debug windows of the components.
+// It runs before the components are reconciled, so they render the level of
+// the window. A window expires on the first reconcile after its TTL, within
+// the periodic resync.
+func (r *ObservabilityPlatformReconciler) reconcileDebugModes(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) {
+	log := log.FromContext(ctx).WithValues("debugMode", "reconcile")
+	now := time.Now()
+
+	logging := componentLogging(platform)
+	components := make([]string, 0, len(logging))
+	for component := range logging {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+
+	// Windows of disabled components are dropped, so they start at their
+	// configured level
+	debug := make(map[string]observabilityv1beta1.ComponentDebugStatus)
+	for _, component := range components {
+		settings := logging[component]
+		window, change := loglevel.Next(settings.Debug, settings.GetDebugTTL(), managers.DebugWindow(platform, component), now)
+		if window != nil {
+			debug[component] = observabilityv1beta1.ComponentDebugStatus{
+				Start:   metav1.NewTime(window.Start),
+				End:     metav1.NewTime(window.End),
+				Expired: window.Expired,
+			}
+		}
+
+		level := managers.ConfiguredLogLevel(platform, settings)
+		apply := "restarting it"
+		if loglevel.Reloadable(component) {
+			apply = "reloading its configuration"
+		}
+		switch change {
+		case loglevel.Opened:
+			log.Info("Debug mode turned on", "component", component, "until", window.End)
+			r.EventRecorder.RecordComponentEvent(platform, component, "DebugModeEnabled",
+				fmt.Sprintf("Logging at debug level until %s, %s", window.End.UTC().Format(time.RFC3339), apply))
+		case loglevel.Expired:
+			log.Info("Debug mode expired", "component", component, "level", level)
+			r.EventRecorder.RecordComponentEvent(platform, component, "DebugModeExpired",
+				fmt.Sprintf("Debug mode expired, logging at %s level again, %s. Set debug to false to clear it.", level, apply))
+		case loglevel.Closed:
+			log.Info("Debug mode turned off", "component", component, "level", level)
+			r.EventRecorder.RecordComponentEvent(platform, component, "DebugModeDisabled",
+				fmt.Sprintf("Debug mode turned off, logging at %s level again, %s", level, apply))
+		}
+	}
+
+	// Persist the windows, so they expire across requeues and restarts
+	if equality.Semantic.DeepEqual(debug, platform.Status.Debug) {
+		return
+	}
+	if err := r.StatusManager.ApplyStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
+		status.Debug = nil
+		for component, window := range debug {
+			if status.Debug == nil {
+				status.Debug = make(map[string]observabilityv1beta1.ComponentDebugStatus, len(debug))
+			}
+			status.Debug[component] = window
+		}
+	}); err != nil {
+		log.Error(err, "Failed to update debug mode status")
+	}
+}
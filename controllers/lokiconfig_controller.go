@@ -0,0 +1,107 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// LokiConfigReconciler checks that LokiConfigs target an existing platform
+// which enables Loki, and records the result in their TargetPlatform
+// condition. It also reports the defaults the webhook applied.
+type LokiConfigReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+}
+
+// +kubebuilder:rbac:groups=observability.io,resources=lokiconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observability.io,resources=lokiconfigs/status,verbs=get;update;patch
+
+// Reconcile checks the target platform of a LokiConfig and reports its
+// applied defaults
+func (r *LokiConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("lokiconfig", req.NamespacedName)
+
+	config := &observabilityv1beta1.LokiConfig{}
+	if err := r.Get(ctx, req.NamespacedName, config); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !config.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	previous := config.Status
+	previous.Conditions = append([]metav1.Condition(nil), config.Status.Conditions...)
+	config.Status.AppliedDefaults = observabilityv1beta1.AppliedDefaults(config)
+
+	var platform string
+	if config.Spec.TargetPlatform != nil {
+		platform = config.Spec.TargetPlatform.Name
+		err := checkTargetPlatform(ctx, r.Client, &config.Status.Conditions, config.Generation, config.Namespace, platform, "loki")
+		if targetErr, ok := err.(*observabilityv1beta1.TargetPlatformError); ok {
+			config.Status.Phase = "Failed"
+			config.Status.Message = targetErr.Error()
+		} else if err != nil {
+			return ctrl.Result{}, err
+		} else if config.Status.Phase == "" || config.Status.Phase == "Failed" {
+			config.Status.Phase = "Pending"
+			config.Status.Message = ""
+		}
+		config.Status.ObservedGeneration = config.Generation
+	}
+
+	if !equality.Semantic.DeepEqual(previous, config.Status) {
+		if err := r.Status().Update(ctx, config); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update LokiConfig status: %w", err)
+		}
+		if config.Status.Phase != previous.Phase {
+			log.Info("LokiConfig phase changed", "phase", config.Status.Phase, "platform", platform)
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// findLokiConfigsForPlatform maps a platform to the LokiConfigs targeting it,
+// so their condition follows the platform
+func (r *LokiConfigReconciler) findLokiConfigsForPlatform(obj client.Object) []reconcile.Request {
+	configs := &observabilityv1beta1.LokiConfigList{}
+	if err := r.List(context.Background(), configs, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, config := range configs.Items {
+		if config.Spec.TargetPlatform != nil && config.Spec.TargetPlatform.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&config)})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *LokiConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&observabilityv1beta1.LokiConfig{}).
+		Watches(
+			&source.Kind{Type: &observabilityv1beta1.ObservabilityPlatform{}},
+			handler.EnqueueRequestsFromMapFunc(r.findLokiConfigsForPlatform),
+		).
+		Complete(r)
+}
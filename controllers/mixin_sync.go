@@ -0,0 +1,388 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/dashboardsync"
+	"github.com/gunjanjp/gunj-operator/internal/mixins"
+)
+
+const (
+	// mixinSyncTick is how often platforms are checked for mixins to install
+	mixinSyncTick = time.Minute
+	// mixinResyncInterval is how often installed mixins are installed again,
+	// restoring dashboards deleted or edited in Grafana
+	mixinResyncInterval = time.Hour
+	// mixinRetryInterval is how long a failed install waits for a retry
+	mixinRetryInterval = 5 * time.Minute
+	// maxCachedMixinBundles bounds the rendered mixins kept in memory
+	maxCachedMixinBundles = 32
+)
+
+// MixinSync installs the monitoring mixins selected in
+// spec.observabilityMixins: it renders them from their upstream repositories
+// at the versions matching the deployed components, imports their dashboards
+// into Grafana, and writes their rules into a ConfigMap the Prometheus
+// manager adds to the Prometheus configuration. Mixin dashboards are owned by
+// the operator, so edits made in Grafana are overwritten. It implements
+// manager.Runnable.
+type MixinSync struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	HTTP   *http.Client
+	Log    logr.Logger
+
+	// Fetch fetches mixin repositories, dashboardsync.Fetch by default
+	Fetch mixins.FetchFunc
+
+	// bundles caches rendered mixins by mixin, ref and config
+	bundles map[string]*mixins.Bundle
+}
+
+// Start runs the sync loop until the context is cancelled
+func (s *MixinSync) Start(ctx context.Context) error {
+	if s.HTTP == nil {
+		s.HTTP = &http.Client{Timeout: 30 * time.Second}
+	}
+	if s.Fetch == nil {
+		s.Fetch = func(ctx context.Context, source dashboardsync.Source) (string, map[string][]byte, error) {
+			return dashboardsync.Fetch(ctx, source, nil)
+		}
+	}
+	s.bundles = make(map[string]*mixins.Bundle)
+
+	ticker := time.NewTicker(mixinSyncTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.syncAll(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection returns true so only the leader installs mixins
+func (s *MixinSync) NeedLeaderElection() bool {
+	return true
+}
+
+// syncAll installs and removes the mixins of all platforms
+func (s *MixinSync) syncAll(ctx context.Context) {
+	platforms := &observabilityv1beta1.ObservabilityPlatformList{}
+	if err := s.Client.List(ctx, platforms); err != nil {
+		s.Log.Error(err, "Failed to list platforms for mixin sync")
+		return
+	}
+
+	for i := range platforms.Items {
+		platform := &platforms.Items[i]
+		if !platform.DeletionTimestamp.IsZero() {
+			continue
+		}
+		statuses, changed := s.syncPlatform(ctx, platform)
+		if !changed {
+			continue
+		}
+		if err := s.writeStatus(ctx, platform, statuses); err != nil {
+			s.Log.Error(err, "Failed to write mixin status", "platform", platform.Name, "namespace", platform.Namespace)
+		}
+	}
+}
+
+// syncPlatform installs the due mixins of a platform and removes the
+// disabled ones. It returns the new mixin statuses and whether they changed.
+func (s *MixinSync) syncPlatform(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) (map[string]observabilityv1beta1.MixinStatus, bool) {
+	statuses := make(map[string]observabilityv1beta1.MixinStatus, len(platform.Status.ObservabilityMixins))
+	for name, status := range platform.Status.ObservabilityMixins {
+		statuses[name] = status
+	}
+
+	now := time.Now()
+	changed := false
+	for _, name := range mixins.Names() {
+		mixin := mixins.Catalog[name]
+		spec := platform.Spec.ObservabilityMixins.Get(name)
+		previous, installed := statuses[name]
+		log := s.Log.WithValues("platform", platform.Name, "namespace", platform.Namespace, "mixin", name)
+
+		if !spec.IsEnabled() || !componentEnabled(platform, mixin.Component) {
+			if !installed {
+				continue
+			}
+			changed = true
+			if err := s.uninstall(ctx, platform, name, previous); err != nil {
+				log.Error(err, "Failed to remove mixin")
+				previous.Message = err.Error()
+				statuses[name] = previous
+				continue
+			}
+			delete(statuses, name)
+			log.Info("Removed mixin")
+			continue
+		}
+
+		ref := mixins.Ref(mixin, componentVersion(platform, mixin.Component), spec.Version)
+		dashboards := spec.InstallsDashboards() && componentEnabled(platform, "grafana")
+		rules := spec.InstallsRules() && componentEnabled(platform, "prometheus")
+		checksum := mixins.Checksum(ref, spec.Config, dashboards, rules)
+		if installed && previous.Checksum == checksum && previous.LastSyncTime != nil {
+			interval := mixinResyncInterval
+			if previous.Message != "" {
+				interval = mixinRetryInterval
+			}
+			if now.Sub(previous.LastSyncTime.Time) < interval {
+				continue
+			}
+		}
+
+		next, err := s.install(ctx, platform, mixin, ref, spec.Config, dashboards, rules, previous)
+		if err != nil {
+			log.Error(err, "Failed to install mixin", "ref", ref)
+			next.Message = err.Error()
+		} else {
+			log.V(1).Info("Installed mixin", "ref", ref, "revision", next.Revision,
+				"dashboards", len(next.Dashboards), "ruleGroups", next.RuleGroups)
+		}
+		next.Checksum = checksum
+		syncTime := metav1.NewTime(now)
+		next.LastSyncTime = &syncTime
+		statuses[name] = next
+		changed = true
+	}
+	return statuses, changed
+}
+
+// install renders a mixin and installs its rules and dashboards. On failure
+// the returned status keeps the records of the dashboards handled so far.
+func (s *MixinSync) install(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, mixin mixins.Mixin, ref, config string, dashboards, rules bool, previous observabilityv1beta1.MixinStatus) (observabilityv1beta1.MixinStatus, error) {
+	next := observabilityv1beta1.MixinStatus{
+		Version:    ref,
+		Revision:   previous.Revision,
+		Dashboards: previous.Dashboards,
+		RuleGroups: previous.RuleGroups,
+	}
+
+	bundle, err := s.bundle(ctx, mixin, ref, config)
+	if err != nil {
+		return next, err
+	}
+
+	ruleFile, ruleGroups := "", 0
+	if rules {
+		ruleFile, ruleGroups = bundle.Rules, bundle.RuleGroups
+	}
+	if err := s.writeRules(ctx, platform, mixin.Name, ruleFile); err != nil {
+		return next, err
+	}
+	next.RuleGroups = int32(ruleGroups)
+
+	var wanted []dashboardsync.Dashboard
+	if dashboards {
+		wanted = bundle.Dashboards
+	}
+	switch {
+	case dashboards || len(previous.Dashboards) > 0 && componentEnabled(platform, "grafana"):
+		message := fmt.Sprintf("Installed from the %s mixin at %s", mixin.Name, ref)
+		records, err := s.importDashboards(ctx, platform, wanted, dashboardRecords(previous.Dashboards), message)
+		next.Dashboards = dashboardStatuses(records)
+		if err != nil {
+			return next, err
+		}
+	default:
+		next.Dashboards = nil
+	}
+
+	next.Revision = bundle.Revision
+	return next, nil
+}
+
+// bundle returns a rendered mixin, rendering it on first use
+func (s *MixinSync) bundle(ctx context.Context, mixin mixins.Mixin, ref, config string) (*mixins.Bundle, error) {
+	key := mixin.Name + "@" + ref + "\x00" + config
+	if bundle, ok := s.bundles[key]; ok {
+		return bundle, nil
+	}
+	bundle, err := mixins.Build(ctx, s.Fetch, mixin, ref, config)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.bundles) >= maxCachedMixinBundles {
+		s.bundles = make(map[string]*mixins.Bundle)
+	}
+	s.bundles[key] = bundle
+	return bundle, nil
+}
+
+// importDashboards imports dashboards into a platform's Grafana, overwriting
+// edits, and deletes the previously imported dashboards no longer wanted. It
+// returns the records of the imported dashboards; on failure, those of the
+// dashboards not handled yet are kept from previous.
+func (s *MixinSync) importDashboards(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, dashboards []dashboardsync.Dashboard, previous []dashboardsync.Record, message string) ([]dashboardsync.Record, error) {
+	grafana, err := grafanaClient(ctx, s.Client, s.HTTP, platform)
+	if err != nil {
+		return previous, err
+	}
+
+	records := make(map[string]dashboardsync.Record, len(previous))
+	for _, record := range previous {
+		records[record.UID] = record
+	}
+	handled := make(map[string]bool, len(dashboards))
+	imported := make([]dashboardsync.Record, 0, len(dashboards))
+	fail := func(err error) ([]dashboardsync.Record, error) {
+		for _, record := range previous {
+			if !handled[record.UID] {
+				imported = append(imported, record)
+			}
+		}
+		return imported, err
+	}
+
+	for _, dashboard := range dashboards {
+		version, err := grafana.Version(ctx, dashboard.UID)
+		if err != nil {
+			return fail(err)
+		}
+
+		var prev *dashboardsync.Record
+		if record, ok := records[dashboard.UID]; ok {
+			prev = &record
+		}
+		record := dashboardsync.Record{UID: dashboard.UID, Title: dashboard.Title, File: dashboard.File}
+		if dashboardsync.Decide(dashboard, prev, version, true) == dashboardsync.ActionImport {
+			if record.Version, err = grafana.Import(ctx, dashboard, message); err != nil {
+				return fail(fmt.Errorf("failed to import dashboard %s: %w", dashboard.File, err))
+			}
+			record.Checksum = dashboard.Checksum
+		} else {
+			record.Checksum, record.Version = prev.Checksum, prev.Version
+		}
+		handled[record.UID] = true
+		imported = append(imported, record)
+	}
+
+	for _, removed := range dashboardsync.Removed(dashboards, previous) {
+		if err := grafana.Delete(ctx, removed.UID); err != nil {
+			return fail(fmt.Errorf("failed to delete dashboard %s: %w", removed.UID, err))
+		}
+		handled[removed.UID] = true
+	}
+	return imported, nil
+}
+
+// uninstall removes the rules and dashboards of a mixin
+func (s *MixinSync) uninstall(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, name string, previous observabilityv1beta1.MixinStatus) error {
+	if err := s.writeRules(ctx, platform, name, ""); err != nil {
+		return err
+	}
+	if len(previous.Dashboards) == 0 || !componentEnabled(platform, "grafana") {
+		return nil
+	}
+	_, err := s.importDashboards(ctx, platform, nil, dashboardRecords(previous.Dashboards), "")
+	return err
+}
+
+// writeRules sets the rule file of a mixin in the platform's mixin rules
+// ConfigMap, removing it if rules is empty
+func (s *MixinSync) writeRules(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, name, rules string) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mixins.RulesConfigMapName(platform.Name),
+			Namespace: platform.Namespace,
+		},
+	}
+	if rules == "" {
+		if err := s.Client.Get(ctx, client.ObjectKeyFromObject(configMap), configMap); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to get mixin rules: %w", err)
+		}
+		if _, ok := configMap.Data[mixins.RulesFile(name)]; !ok {
+			return nil
+		}
+	}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, s.Client, configMap, func() error {
+		configMap.Labels = map[string]string{
+			"app.kubernetes.io/name":       "prometheus",
+			"app.kubernetes.io/instance":   platform.Name,
+			"app.kubernetes.io/component":  "mixin-rules",
+			"app.kubernetes.io/managed-by": "gunj-operator",
+			"observability.io/platform":    platform.Name,
+		}
+		if configMap.Data == nil {
+			configMap.Data = make(map[string]string)
+		}
+		if rules == "" {
+			delete(configMap.Data, mixins.RulesFile(name))
+		} else {
+			configMap.Data[mixins.RulesFile(name)] = rules
+		}
+		// Owned by the platform, so changes trigger a reconcile of the
+		// Prometheus configuration
+		return controllerutil.SetControllerReference(platform, configMap, s.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to write mixin rules: %w", err)
+	}
+	return nil
+}
+
+// writeStatus records the mixin statuses of a platform
+func (s *MixinSync) writeStatus(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, statuses map[string]observabilityv1beta1.MixinStatus) error {
+	if len(statuses) == 0 {
+		statuses = nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &observabilityv1beta1.ObservabilityPlatform{}
+		if err := s.Client.Get(ctx, client.ObjectKeyFromObject(platform), latest); err != nil {
+			return err
+		}
+		latest.Status.ObservabilityMixins = statuses
+		return s.Client.Status().Update(ctx, latest)
+	})
+}
+
+// componentEnabled returns true if a component of the platform is enabled;
+// the empty component, of mixins following no component, always is
+func componentEnabled(platform *observabilityv1beta1.ObservabilityPlatform, component string) bool {
+	components := platform.Spec.Components
+	if components == nil {
+		return component == ""
+	}
+	switch component {
+	case "":
+		return true
+	case "prometheus":
+		return components.Prometheus != nil && components.Prometheus.Enabled
+	case "grafana":
+		return components.Grafana != nil && components.Grafana.Enabled
+	case "loki":
+		return components.Loki != nil && components.Loki.Enabled
+	case "tempo":
+		return components.Tempo != nil && components.Tempo.Enabled
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/managers"
+	"github.com/gunjanjp/gunj-operator/internal/nodepool"
+)
+
+// reconcileNodePool labels and taints the nodes declared for the platform's
+// dedicated node pool and releases the nodes it bootstrapped earlier that are
+// no longer declared. Without declared nodes the pool is expected to exist
+// and nothing is changed on the nodes.
+func (r *ObservabilityPlatformReconciler) reconcileNodePool(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx).WithValues("nodePool", "reconcile")
+
+	owner := platform.Namespace + "/" + platform.Name
+	pool := managers.NodePoolFor(platform)
+	if pool == nil {
+		return r.releaseNodePool(ctx, platform)
+	}
+
+	declared := make(map[string]bool, len(platform.Spec.Global.NodePool.Nodes))
+	for _, name := range platform.Spec.Global.NodePool.Nodes {
+		declared[name] = true
+
+		node := &corev1.Node{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name}, node); err != nil {
+			if errors.IsNotFound(err) {
+				log.Info("Declared node pool node not found", "node", name)
+				r.EventRecorder.RecordPlatformEvent(platform, "NodePoolNodeNotFound", fmt.Sprintf("Node %s declared for the node pool does not exist", name))
+				continue
+			}
+			return fmt.Errorf("failed to get node %s: %w", name, err)
+		}
+
+		// A node bootstrapped into the pool under another name or label is
+		// released from it first
+		changed := false
+		if recorded, ok := nodepool.RecordedPool(node); ok && (recorded.LabelKey != pool.LabelKey || recorded.Name != pool.Name) {
+			changed = nodepool.Release(node, owner)
+		}
+		if !nodepool.Bootstrap(node, *pool, owner) && !changed {
+			continue
+		}
+		if err := r.Update(ctx, node); err != nil {
+			return fmt.Errorf("failed to add node %s to node pool: %w", name, err)
+		}
+		log.Info("Added node to node pool", "node", name, "pool", pool.Name)
+	}
+
+	return r.releaseNodes(ctx, owner, declared)
+}
+
+// releaseNodePool removes the label and taint from all nodes the platform
+// bootstrapped into its node pool, including after the pool was removed from
+// the spec
+func (r *ObservabilityPlatformReconciler) releaseNodePool(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	return r.releaseNodes(ctx, platform.Namespace+"/"+platform.Name, nil)
+}
+
+// releaseNodes releases the nodes bootstrapped by owner that are not kept. The
+// nodes are found by their managed-by annotation and released from the pool
+// recorded on them, so nodes of a renamed pool are released too.
+func (r *ObservabilityPlatformReconciler) releaseNodes(ctx context.Context, owner string, keep map[string]bool) error {
+	log := log.FromContext(ctx)
+
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes); err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if keep[node.Name] || node.Annotations[nodepool.ManagedByAnnotation] != owner {
+			continue
+		}
+		pool, _ := nodepool.RecordedPool(node)
+		if !nodepool.Release(node, owner) {
+			continue
+		}
+		if err := r.Update(ctx, node); err != nil {
+			return fmt.Errorf("failed to remove node %s from node pool: %w", node.Name, err)
+		}
+		log.Info("Removed node from node pool", "node", node.Name, "pool", pool.Name)
+	}
+
+	return nil
+}
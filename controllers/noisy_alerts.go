@@ -0,0 +1,175 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/metrics"
+	"github.com/gunjanjp/gunj-operator/internal/noisyalerts"
+)
+
+const (
+	// noisyAlertsTick is how often platforms are checked for a due analysis
+	noisyAlertsTick = time.Minute
+	// noisyAlertsReportKey is the ConfigMap key holding the JSON report
+	noisyAlertsReportKey = "report.json"
+)
+
+// NoisyAlertAnalyzer periodically analyzes the ALERTS series of every
+// managed Prometheus with noisy alert detection enabled, and reports the
+// alerts firing and resolving most often with suggested adjustments. Reports
+// are written to the prometheus-<platform>-noisy-alerts ConfigMap and exposed
+// as operator metrics. It implements manager.Runnable.
+type NoisyAlertAnalyzer struct {
+	Client  client.Client
+	HTTP    *http.Client
+	Scheme  *runtime.Scheme
+	Metrics *metrics.Collector
+	Log     logr.Logger
+
+	// lastRun is when each platform was last analyzed
+	lastRun map[types.NamespacedName]time.Time
+}
+
+// Start runs the analysis loop until the context is cancelled
+func (a *NoisyAlertAnalyzer) Start(ctx context.Context) error {
+	a.lastRun = make(map[types.NamespacedName]time.Time)
+	if a.HTTP == nil {
+		// A week of ALERTS is a large range query
+		a.HTTP = &http.Client{Timeout: 2 * time.Minute}
+	}
+
+	ticker := time.NewTicker(noisyAlertsTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			a.analyzeAll(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection returns true so only the leader writes reports
+func (a *NoisyAlertAnalyzer) NeedLeaderElection() bool {
+	return true
+}
+
+// analyzeAll analyzes the platforms whose analysis interval has elapsed
+func (a *NoisyAlertAnalyzer) analyzeAll(ctx context.Context) {
+	platforms := &observabilityv1beta1.ObservabilityPlatformList{}
+	if err := a.Client.List(ctx, platforms); err != nil {
+		a.Log.Error(err, "Failed to list platforms for noisy alert analysis")
+		return
+	}
+
+	now := time.Now()
+	active := make(map[types.NamespacedName]bool)
+	for i := range platforms.Items {
+		platform := &platforms.Items[i]
+		spec := prometheusNoisyAlerts(platform)
+		if !spec.IsEnabled() || !platform.DeletionTimestamp.IsZero() {
+			continue
+		}
+		key := client.ObjectKeyFromObject(platform)
+		active[key] = true
+
+		if last, ok := a.lastRun[key]; ok && now.Sub(last) < spec.GetAnalysisInterval() {
+			continue
+		}
+		if err := a.analyze(ctx, platform, spec, now); err != nil {
+			// The platform is retried on the next tick
+			a.Log.Error(err, "Failed to analyze noisy alerts", "platform", platform.Name, "namespace", platform.Namespace)
+			continue
+		}
+		a.lastRun[key] = now
+	}
+
+	for key := range a.lastRun {
+		if !active[key] {
+			delete(a.lastRun, key)
+			a.Metrics.DeleteNoisyAlerts(key.Name, key.Namespace)
+		}
+	}
+}
+
+// analyze reads the ALERTS series of a platform's Prometheus over the
+// window and writes the report
+func (a *NoisyAlertAnalyzer) analyze(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, spec *observabilityv1beta1.NoisyAlertsSpec, now time.Time) error {
+	window := spec.GetWindow()
+	step := noisyalerts.Step(window)
+	start := now.Add(-window)
+
+	prometheusURL := fmt.Sprintf("http://prometheus-%s.%s.svc.cluster.local:9090", platform.Name, platform.Namespace)
+	series, err := noisyalerts.Fetch(ctx, a.HTTP, prometheusURL, start, now, step)
+	if err != nil {
+		return err
+	}
+
+	report := noisyalerts.Analyze(series, step, noisyalerts.Options{
+		MinFirings:  spec.GetMinFirings(),
+		ShortFiring: spec.GetShortFiring(),
+		TopN:        spec.GetTopN(),
+	}, start, now)
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal noisy alerts report: %w", err)
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("prometheus-%s-noisy-alerts", platform.Name),
+			Namespace: platform.Namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, a.Client, configMap, func() error {
+		if configMap.Labels == nil {
+			configMap.Labels = map[string]string{}
+		}
+		configMap.Labels["app.kubernetes.io/managed-by"] = "gunj-operator"
+		configMap.Labels["observability.io/platform"] = platform.Name
+		configMap.Data = map[string]string{noisyAlertsReportKey: string(data)}
+		return controllerutil.SetControllerReference(platform, configMap, a.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to write noisy alerts report: %w", err)
+	}
+
+	firings := make(map[string]int, len(report.Noisy))
+	for _, alert := range report.Noisy {
+		firings[alert.Name] += alert.Firings
+	}
+	a.Metrics.RecordNoisyAlerts(platform.Name, platform.Namespace, firings)
+
+	a.Log.V(1).Info("Wrote noisy alerts report", "platform", platform.Name, "namespace", platform.Namespace,
+		"alertsFiring", report.AlertsFiring, "noisyAlerts", len(report.Noisy))
+	return nil
+}
+
+// prometheusNoisyAlerts returns the noisy alert settings of a platform's
+// Prometheus
+func prometheusNoisyAlerts(platform *observabilityv1beta1.ObservabilityPlatform) *observabilityv1beta1.NoisyAlertsSpec {
+	if platform.Spec.Components == nil || platform.Spec.Components.Prometheus == nil || !platform.Spec.Components.Prometheus.Enabled {
+		return nil
+	}
+	return platform.Spec.Components.Prometheus.NoisyAlerts
+}
@@ -8,10 +8,12 @@ package controllers
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,6 +24,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -31,9 +34,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/cacheconfig"
+	"github.com/gunjanjp/gunj-operator/internal/drain"
 	"github.com/gunjanjp/gunj-operator/internal/managers"
 	"github.com/gunjanjp/gunj-operator/internal/gitops"
 	"github.com/gunjanjp/gunj-operator/internal/metrics"
+	"github.com/gunjanjp/gunj-operator/internal/siem"
+	"github.com/gunjanjp/gunj-operator/internal/templatevars"
+	"github.com/gunjanjp/gunj-operator/internal/tracking"
+	"github.com/gunjanjp/gunj-operator/pkg/unstick"
 )
 
 const (
@@ -71,6 +80,9 @@ type ObservabilityPlatformReconciler struct {
 	StatusManager *StatusManager
 	EventRecorder *EnhancedEventRecorder
 
+	// SIEM export of platform events (optional)
+	SIEMExporter *siem.Exporter
+
 	// Finalizer management
 	FinalizerManager *FinalizerManager
 
@@ -84,6 +96,29 @@ type ObservabilityPlatformReconciler struct {
 	// Configuration
 	MaxConcurrentReconciles int
 	RequeueDuration         time.Duration
+
+	// CacheConfig is the configuration of the manager's cache. Kinds
+	// excluded from it are not watched.
+	CacheConfig cacheconfig.Config
+
+	// Tracking indexes the children linked to their platform by tracking
+	// labels, as they are cluster scoped or in another namespace
+	Tracking *tracking.Index
+
+	// APIReader reads nodes and pods for the scheduling check without
+	// caching every pod of the cluster. The client is used if nil.
+	APIReader client.Reader
+
+	// RevalidateAdmission validates platforms like the validation webhook
+	// before reconciling them, for webhooks failing open
+	RevalidateAdmission bool
+
+	// Drain tracks the in-flight reconciles for the graceful shutdown and
+	// flushes the status updates on shutdown, optional
+	Drain *drain.Coordinator
+	// TemplateValues are the operator's values of the template variables
+	// of platform specs, like {{ .ClusterName }}
+	TemplateValues templatevars.Values
 }
 
 // +kubebuilder:rbac:groups=observability.io,resources=observabilityplatforms,verbs=get;list;watch;create;update;patch;delete
@@ -91,13 +126,22 @@ type ObservabilityPlatformReconciler struct {
 // +kubebuilder:rbac:groups=observability.io,resources=observabilityplatforms/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
 // +kubebuilder:rbac:groups="",resources=services;serviceaccounts;configmaps;secrets;persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;networkpolicies,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings;clusterroles;clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheuses;alertmanagers;servicemonitors;podmonitors;prometheusrules,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=scheduling.k8s.io,resources=priorityclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=observability.io,resources=apikeys,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -105,6 +149,17 @@ func (r *ObservabilityPlatformReconciler) Reconcile(ctx context.Context, req ctr
 	log := log.FromContext(ctx).WithValues("observabilityplatform", req.NamespacedName)
 	startTime := time.Now()
 
+	// Don't start reconciles while shutting down, the next leader picks
+	// them up
+	if r.Drain != nil {
+		done, ok := r.Drain.Begin("reconcile")
+		if !ok {
+			log.V(1).Info("Shutting down, skipping reconciliation")
+			return ctrl.Result{RequeueAfter: RequeueAfterError}, nil
+		}
+		defer done()
+	}
+
 	// Record reconciliation metrics
 	defer func() {
 		duration := time.Since(startTime)
@@ -132,6 +187,11 @@ func (r *ObservabilityPlatformReconciler) Reconcile(ctx context.Context, req ctr
 
 	// Check if the platform is being deleted
 	if !platform.ObjectMeta.DeletionTimestamp.IsZero() {
+		// The finalizers clean up what the effective spec deployed; a base
+		// platform deleted first leaves them the platform's own spec
+		if err := r.applyBasePlatforms(ctx, platform); err != nil {
+			log.Error(err, "Failed to apply base platform, cleaning up with the platform's own spec")
+		}
 		return r.handleDeletion(ctx, platform)
 	}
 
@@ -155,6 +215,36 @@ func (r *ObservabilityPlatformReconciler) Reconcile(ctx context.Context, req ctr
 		return ctrl.Result{RequeueAfter: time.Hour}, nil
 	}
 
+	// Only report the health of a read-only platform
+	if platform.IsReadOnly() {
+		return r.reconcileReadOnly(ctx, platform)
+	}
+
+	// Validate platforms the validation webhook may have admitted while the
+	// operator was down; invalid ones keep their last applied configuration
+	valid, err := r.revalidateAdmission(ctx, platform)
+	if err != nil {
+		log.Error(err, "Failed to re-validate platform")
+		return ctrl.Result{RequeueAfter: RequeueAfterError}, nil
+	}
+	if !valid {
+		// The validators look up quotas, priority classes and nodes, so an
+		// invalid generation may become valid without a spec change
+		return ctrl.Result{RequeueAfter: r.RequeueDuration}, nil
+	}
+
+	// Merge the spec onto its base platforms; the merged spec is never written back
+	if err := r.applyBasePlatforms(ctx, platform); err != nil {
+		r.EventRecorder.RecordPlatformEvent(platform, "BasePlatformError", err.Error())
+		return r.handleError(ctx, platform, err, "Failed to apply base platform")
+	}
+
+	// Render the template variables of the spec; the rendered spec is never written back
+	if err := r.applyTemplateValues(platform); err != nil {
+		r.EventRecorder.RecordPlatformEvent(platform, "TemplateValuesError", err.Error())
+		return r.handleError(ctx, platform, err, "Failed to render template variables")
+	}
+
 	// Main reconciliation logic
 	return r.reconcilePlatform(ctx, platform)
 }
@@ -177,6 +267,9 @@ func (r *ObservabilityPlatformReconciler) SetupWithManager(mgr ctrl.Manager) err
 
 	// Initialize enhanced event recorder
 	r.EventRecorder = NewEnhancedEventRecorder(r.Recorder, "observabilityplatform-controller")
+	if r.SIEMExporter != nil {
+		r.EventRecorder.SetExporter(r.SIEMExporter)
+	}
 
 	// Initialize status manager
 	r.StatusManager = NewStatusManager(r.Client, r.Log, r.EventRecorder)
@@ -188,6 +281,10 @@ func (r *ObservabilityPlatformReconciler) SetupWithManager(mgr ctrl.Manager) err
 	if r.Metrics == nil {
 		r.Metrics = metrics.NewCollector()
 	}
+	r.StatusManager.metrics = r.Metrics
+	if r.Drain != nil {
+		r.Drain.OnShutdown("status-updates", r.StatusManager.Flush)
+	}
 
 	// Initialize component managers with Helm support if not already set
 	if r.PrometheusManager == nil || r.GrafanaManager == nil || r.LokiManager == nil || r.TempoManager == nil {
@@ -236,15 +333,39 @@ func (r *ObservabilityPlatformReconciler) SetupWithManager(mgr ctrl.Manager) err
 	}
 
 	// Build the controller
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&observabilityv1beta1.ObservabilityPlatform{}, builder.WithPredicates(
-			predicate.GenerationChangedPredicate{},
-		)).
-		// Watch owned resources
-		Owns(&corev1.ConfigMap{}).
-		Owns(&corev1.Secret{}).
-		Owns(&corev1.Service{}).
-		Owns(&corev1.PersistentVolumeClaim{}).
+			predicate.Or(predicate.GenerationChangedPredicate{}, rotationRequested),
+		))
+	// Watch owned resources, except the kinds excluded from the cache: a
+	// watch would start the informer the exclusion avoids
+	for _, owned := range []client.Object{
+		&corev1.ConfigMap{},
+		&corev1.Secret{},
+		&corev1.Service{},
+		&corev1.PersistentVolumeClaim{},
+		&batchv1.Job{},
+	} {
+		if r.CacheConfig.Excludes(owned) {
+			continue
+		}
+		b = b.Owns(owned)
+	}
+	// Watch the children ownerReferences cannot link to their platform
+	if r.Tracking == nil {
+		r.Tracking = tracking.NewIndex()
+	}
+	for _, tracked := range trackedKinds {
+		gvk, err := apiutil.GVKForObject(tracked, r.Scheme)
+		if err != nil {
+			return err
+		}
+		b = b.Watches(tracked, r.Tracking.EventHandler(gvk), builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			_, ok := tracking.OwnerOf(obj)
+			return ok
+		})))
+	}
+	return b.
 		// Set controller options
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
@@ -258,6 +379,17 @@ func (r *ObservabilityPlatformReconciler) SetupWithManager(mgr ctrl.Manager) err
 			handler.EnqueueRequestsFromMapFunc(r.findPlatformsForNamespace),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
+		// Reconcile platforms when a base they inherit from changes
+		Watches(
+			&source.Kind{Type: &observabilityv1beta1.ObservabilityPlatform{}},
+			handler.EnqueueRequestsFromMapFunc(r.findPlatformsForBase),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		// Reconcile the ingest gateway when the keys of an ApiKey change
+		Watches(
+			&source.Kind{Type: &observabilityv1beta1.ApiKey{}},
+			handler.EnqueueRequestsFromMapFunc(r.findPlatformForApiKey),
+		).
 		Complete(r)
 }
 
@@ -269,6 +401,9 @@ func (r *ObservabilityPlatformReconciler) reconcilePlatform(ctx context.Context,
 	// Clear any previous errors
 	r.StatusManager.ClearError(ctx, platform)
 
+	// Record the tracked children applied by this reconcile
+	r.Tracking.BeginReconcile(client.ObjectKeyFromObject(platform))
+
 	// Set progressing condition
 	r.StatusManager.SetCondition(ctx, platform, ConditionProgressing, metav1.ConditionTrue, ReasonReconciling, "Reconciling platform components")
 
@@ -285,11 +420,156 @@ func (r *ObservabilityPlatformReconciler) reconcilePlatform(ctx context.Context,
 		return r.handleError(ctx, platform, err, "Failed to reconcile common resources")
 	}
 
-	// Reconcile components with dependency management
-	if err := r.ReconcileWithDependencies(ctx, platform); err != nil {
+	// Bootstrap the dedicated node pool before the components are scheduled on it
+	if err := r.reconcileNodePool(ctx, platform); err != nil {
+		// Don't fail reconciliation; components still schedule on existing pool nodes
+		log.Error(err, "Failed to reconcile node pool")
+		r.EventRecorder.RecordPlatformEvent(platform, "NodePoolError", err.Error())
+	}
+
+	// Rotate the generated credentials that are due or requested
+	if err := r.rotateCredentials(ctx, platform); err != nil {
+		// Don't fail reconciliation; staged rotations resume on the next
+		// reconcile and the current credentials stay valid until then
+		log.Error(err, "Failed to rotate credentials")
+	}
+
+	// Check the replicas fit on the nodes before waiting on Pending pods
+	if err := r.checkSchedulingFeasibility(ctx, platform); err != nil {
+		// Don't fail reconciliation; the check is advisory
+		log.Error(err, "Failed to check scheduling feasibility")
+	}
+
+	// Deploy the query access control proxy the team datasources point at
+	if err := r.reconcileQueryACL(ctx, platform); err != nil {
+		// Don't fail reconciliation; team datasources fail closed without the proxy
+		log.Error(err, "Failed to reconcile query ACL proxy")
+		r.EventRecorder.RecordPlatformEvent(platform, "QueryACLError", err.Error())
+	}
+
+	// Apply the downsampling and retention policies of the Thanos bucket
+	if err := r.reconcileThanos(ctx, platform); err != nil {
+		// Don't fail reconciliation; blocks are compacted once the compactor is up
+		log.Error(err, "Failed to reconcile Thanos compactor")
+		r.EventRecorder.RecordPlatformEvent(platform, "ThanosError", err.Error())
+	}
+
+	// Deploy the continuous profiling backend
+	if err := r.reconcileProfiling(ctx, platform); err != nil {
+		// Don't fail reconciliation; profiles are only one of the signals
+		log.Error(err, "Failed to reconcile profiling backend")
+		r.EventRecorder.RecordPlatformEvent(platform, "ProfilingError", err.Error())
+	}
+
+	// Deploy the eBPF agent instrumenting services without code changes
+	if err := r.reconcileEBPFAgent(ctx, platform); err != nil {
+		// Don't fail reconciliation; instrumented services keep working
+		log.Error(err, "Failed to reconcile eBPF agent")
+		r.Tracking.Incomplete(client.ObjectKeyFromObject(platform))
+		r.EventRecorder.RecordPlatformEvent(platform, "EBPFAgentError", err.Error())
+	}
+
+	// Export Kubernetes events into Loki
+	if err := r.reconcileEventExporter(ctx, platform); err != nil {
+		// Don't fail reconciliation; events remain in the API server until
+		// they expire
+		log.Error(err, "Failed to reconcile event exporter")
+		r.Tracking.Incomplete(client.ObjectKeyFromObject(platform))
+		r.EventRecorder.RecordPlatformEvent(platform, "EventExporterError", err.Error())
+	}
+
+	// Let Prometheus Operator setups monitor the operator
+	if err := r.reconcileSelfMonitoring(ctx, platform); err != nil {
+		// Don't fail reconciliation; the platform's Prometheus still scrapes
+		// the operator
+		log.Error(err, "Failed to reconcile self-monitoring")
+		r.EventRecorder.RecordPlatformEvent(platform, "SelfMonitoringError", err.Error())
+	}
+
+	// Deploy the exporters of spec.exporters
+	if err := r.reconcileExporters(ctx, platform); err != nil {
+		// Don't fail reconciliation; the exporters are scraped once deployed
+		log.Error(err, "Failed to reconcile exporters")
+		r.EventRecorder.RecordPlatformEvent(platform, "ExportersError", err.Error())
+	}
+
+	// Collect Kubernetes audit logs into Loki
+	if err := r.reconcileAuditLogs(ctx, platform); err != nil {
+		// Don't fail reconciliation; the API server keeps writing its audit
+		// log
+		log.Error(err, "Failed to reconcile audit logs")
+		r.EventRecorder.RecordPlatformEvent(platform, "AuditLogsError", err.Error())
+	}
+
+	// Deploy the Alloy collection layer feeding the platform's backends
+	if err := r.reconcileAlloy(ctx, platform); err != nil {
+		// Don't fail reconciliation; the backends still accept direct writes
+		log.Error(err, "Failed to reconcile Alloy")
+		r.Tracking.Incomplete(client.ObjectKeyFromObject(platform))
+		r.EventRecorder.RecordPlatformEvent(platform, "AlloyError", err.Error())
+	}
+
+	// Terminate the writes of producers outside of the cluster
+	if err := r.reconcileIngestGateway(ctx, platform); err != nil {
+		// Don't fail reconciliation; producers in the cluster still write to
+		// the backends directly
+		log.Error(err, "Failed to reconcile ingest gateway")
+		r.Tracking.Incomplete(client.ObjectKeyFromObject(platform))
+		r.EventRecorder.RecordPlatformEvent(platform, "IngestGatewayError", err.Error())
+	}
+
+	// Render the routes, inhibit rules and mute time intervals of Alertmanager
+	if err := r.reconcileAlertmanagerConfig(ctx, platform); err != nil {
+		// Don't fail reconciliation; Alertmanager keeps its current configuration
+		log.Error(err, "Failed to reconcile Alertmanager configuration")
+		r.EventRecorder.RecordPlatformEvent(platform, "AlertmanagerConfigError", err.Error())
+	}
+
+	// Open or close the dual-write window of external label changes before
+	// Prometheus renders its configuration
+	if err := r.reconcileExternalLabelTransition(ctx, platform); err != nil {
+		// Don't fail reconciliation; Prometheus reports the same error
+		log.Error(err, "Failed to reconcile external label transition")
+	}
+
+	// Open and expire debug modes before the components render their log
+	// levels
+	r.reconcileDebugModes(ctx, platform)
+
+	// Reconcile components with dependency management. The managers record
+	// the validation of the component configurations in memory; it's
+	// persisted whether or not the components reconciled.
+	err := r.ReconcileWithDependencies(ctx, platform)
+	r.persistConfigValidation(ctx, platform)
+	if err != nil {
 		return r.handleError(ctx, platform, err, "Failed to reconcile components")
 	}
 
+	// Summarize the settings changed since the last successful reconcile
+	r.recordChangeSummary(ctx, platform)
+
+	// Report the defaults the webhook applied which change behavior
+	appliedDefaults := observabilityv1beta1.AppliedDefaults(platform)
+	if err := r.StatusManager.ApplyStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
+		status.AppliedDefaults = append([]observabilityv1beta1.AppliedDefault(nil), appliedDefaults...)
+	}); err != nil {
+		log.Error(err, "Failed to update applied defaults")
+	}
+
+	// Backfill the history of new recording rules once Prometheus runs them
+	if err := r.reconcileRecordingRuleBackfill(ctx, platform); err != nil {
+		// Don't fail reconciliation; the rules are evaluated from now on regardless
+		log.Error(err, "Failed to backfill recording rules")
+		r.EventRecorder.RecordPlatformEvent(platform, "RecordingRuleBackfillError", err.Error())
+	}
+
+	// Reload changed configuration in place for components using the Reload strategy
+	if err := r.reconcileReloads(ctx, platform); err != nil {
+		// Don't fail reconciliation; the reload is retried on the next reconcile
+		log.Error(err, "Failed to reload configuration")
+		r.EventRecorder.RecordPlatformEvent(platform, "ConfigReloadError", err.Error())
+	}
+
 	// Reconcile GitOps if configured
 	if platform.Spec.GitOps != nil {
 		if err := r.reconcileGitOps(ctx, platform); err != nil {
@@ -307,6 +587,64 @@ func (r *ObservabilityPlatformReconciler) reconcilePlatform(ctx context.Context,
 	}
 
 	// Perform health checks on all components
+	r.checkHealth(ctx, platform)
+
+	// Mark component incidents on Grafana dashboards if configured
+	if platform.IsIncidentAnnotationsEnabled() {
+		if err := r.reconcileIncidentAnnotations(ctx, platform); err != nil {
+			// Don't fail reconciliation on annotation errors; retried next reconcile
+			log.Error(err, "Failed to reconcile incident annotations")
+		}
+	}
+
+	// Render the public status page if configured
+	if platform.IsStatusPageEnabled() {
+		if err := r.reconcileStatusPage(ctx, platform); err != nil {
+			// Don't fail reconciliation on status page errors
+			log.Error(err, "Failed to reconcile status page")
+		}
+	} else if err := r.cleanupStatusPage(ctx, platform); err != nil {
+		log.Error(err, "Failed to clean up status page")
+	}
+
+	// Prune the tracked children this reconcile no longer applied
+	if err := r.pruneTrackedChildren(ctx, platform); err != nil {
+		// Don't fail reconciliation; pruning is retried next reconcile
+		log.Error(err, "Failed to prune tracked children")
+	}
+
+	// Report the resources requested by the platform's workloads
+	if err := r.recordResourceFootprint(ctx, platform); err != nil {
+		// Don't fail reconciliation; the footprint is refreshed next reconcile
+		log.Error(err, "Failed to record resource footprint")
+	}
+
+	// All components reconciled successfully
+	log.Info("All components reconciled successfully with health status", "healthy", platform.Status.Health.Healthy)
+
+	// Complete the operation
+	duration := time.Since(startTime)
+	r.StatusManager.CompleteOperation(ctx, platform, "reconciliation", true, "All components reconciled successfully", duration)
+
+	// Update conditions
+	r.StatusManager.SetCondition(ctx, platform, ConditionProgressing, metav1.ConditionFalse, ReasonReady, "Reconciliation complete")
+	r.StatusManager.AggregateComponentStatuses(ctx, platform)
+	r.StatusManager.CalculateAndSetPhase(ctx, platform)
+	r.StatusManager.UpdateMetrics(ctx, platform)
+
+	// Record successful reconciliation
+	r.EventRecorder.RecordPlatformEvent(platform, EventReasonPlatformReady, "Platform is ready")
+	r.Metrics.RecordPlatformStatus(platform.Name, platform.Namespace, string(platform.Status.Phase))
+
+	// Requeue after success duration for continuous reconciliation
+	return ctrl.Result{RequeueAfter: r.RequeueDuration}, nil
+}
+
+// checkHealth checks the health of all components, records it in the
+// platform status and metrics, and tracks the components' availability
+func (r *ObservabilityPlatformReconciler) checkHealth(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) {
+	log := log.FromContext(ctx)
+
 	healthCheckStart := time.Now()
 	healthStatus, err := r.HealthCheckManager.CheckComponentHealth(ctx, platform)
 	if err != nil {
@@ -327,50 +665,88 @@ func (r *ObservabilityPlatformReconciler) reconcilePlatform(ctx context.Context,
 			}
 		}
 		UpdateHealthMetrics(platform.Name, platform.Namespace, componentHealthMap)
-		
-		// Update platform status with health information
-		platform.Status.Health = *healthStatus
+
+		// Persist the health: read-only platforms report nothing else
+		if err := r.StatusManager.ApplyStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
+			health := *healthStatus
+			health.Components = make(map[string]observabilityv1beta1.ComponentHealthStatus, len(healthStatus.Components))
+			for name, component := range healthStatus.Components {
+				health.Components[name] = component
+			}
+			status.Health = health
+		}); err != nil {
+			log.Error(err, "Failed to update health status")
+		}
 	}
 
 	// Update health server timestamp
 	r.HealthServer.UpdateLastHealthCheck()
 
-	// All components reconciled successfully
-	log.Info("All components reconciled successfully with health status", "healthy", healthStatus.Healthy)
-
-	// Complete the operation
-	duration := time.Since(startTime)
-	r.StatusManager.CompleteOperation(ctx, platform, "reconciliation", true, "All components reconciled successfully", duration)
-
-	// Update conditions
-	r.StatusManager.SetCondition(ctx, platform, ConditionProgressing, metav1.ConditionFalse, ReasonReady, "Reconciliation complete")
-	r.StatusManager.AggregateComponentStatuses(ctx, platform)
-	r.StatusManager.CalculateAndSetPhase(ctx, platform)
-	r.StatusManager.UpdateMetrics(ctx, platform)
-
-	// Record successful reconciliation
-	r.EventRecorder.RecordPlatformEvent(platform, EventReasonPlatformReady, "Platform is ready")
-	r.Metrics.RecordPlatformStatus(platform.Name, platform.Namespace, string(platform.Status.Phase))
+	// Track component availability for SLA reports
+	r.recordAvailability(ctx, platform)
+}
 
-	// Requeue after success duration for continuous reconciliation
-	return ctrl.Result{RequeueAfter: r.RequeueDuration}, nil
+// persistConfigValidation writes the validation state of the component
+// configurations, so a validated configuration isn't checked again after a
+// requeue or restart
+func (r *ObservabilityPlatformReconciler) persistConfigValidation(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) {
+	var validation map[string]observabilityv1beta1.ConfigValidationStatus
+	for component, check := range platform.Status.ConfigValidation {
+		if validation == nil {
+			validation = make(map[string]observabilityv1beta1.ConfigValidationStatus, len(platform.Status.ConfigValidation))
+		}
+		validation[component] = *check.DeepCopy()
+	}
+	if err := r.StatusManager.UpdatePlatformStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
+		status.ConfigValidation = validation
+	}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update config validation status")
+	}
 }
 
 // handleDeletion handles the deletion of the ObservabilityPlatform
 func (r *ObservabilityPlatformReconciler) handleDeletion(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 	log.Info("Handling platform deletion")
+	r.detectStuckDeletion(ctx, platform)
 
 	// Use FinalizerManager to handle deletion
 	if err := r.FinalizerManager.HandleDeletion(ctx, platform, r); err != nil {
+		if goerrors.Is(err, errDeletionPending) {
+			log.Info("Waiting for the deletion policy to complete")
+			return ctrl.Result{RequeueAfter: DataProtectionRequeue}, nil
+		}
 		log.Error(err, "Failed to handle deletion")
 		r.Recorder.Event(platform, corev1.EventTypeWarning, "DeletionFailed", err.Error())
 		return ctrl.Result{RequeueAfter: RequeueAfterError}, err
 	}
 
+	// Finalizers of other controllers keep the platform terminating; check
+	// again later to report them if they are never removed
+	if _, foreign := unstick.SplitFinalizers(platform.Finalizers); len(foreign) > 0 {
+		return ctrl.Result{RequeueAfter: unstick.DefaultThreshold}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// detectStuckDeletion reports a platform terminating for longer than
+// unstick.DefaultThreshold with a DeletionStuck event naming the finalizers
+// that remain, so it can be released with `gunj-migrate unstick`
+func (r *ObservabilityPlatformReconciler) detectStuckDeletion(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) {
+	terminating := unstick.Terminating(platform.DeletionTimestamp, time.Now())
+	if terminating < unstick.DefaultThreshold {
+		return
+	}
+
+	operator, foreign := unstick.SplitFinalizers(platform.Finalizers)
+	message := fmt.Sprintf("Platform terminating for %s; operator finalizers %v, other finalizers %v. "+
+		"Check the operator logs, then run `gunj-migrate unstick %s -n %s`",
+		terminating.Round(time.Minute), operator, foreign, platform.Name, platform.Namespace)
+	log.FromContext(ctx).Info("Platform deletion is stuck", "terminating", terminating, "finalizers", platform.Finalizers)
+	r.EventRecorder.RecordPlatformEvent(platform, EventReasonDeletionStuck, message)
+}
+
 // cleanup performs cleanup operations (deprecated - use FinalizerManager)
 func (r *ObservabilityPlatformReconciler) cleanup(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
 	// This method is deprecated in favor of FinalizerManager.HandleDeletion
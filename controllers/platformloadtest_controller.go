@@ -0,0 +1,277 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/alloy"
+	"github.com/gunjanjp/gunj-operator/internal/loadtest"
+)
+
+const (
+	// loadTestPendingRequeue is how often a pending load test checks
+	// whether its platform is ready
+	loadTestPendingRequeue = 30 * time.Second
+	// loadTestCollectTimeout bounds how long the results of a finished test
+	// are retried before it fails
+	loadTestCollectTimeout = 10 * time.Minute
+)
+
+// PlatformLoadTestReconciler runs PlatformLoadTests: it deploys the load
+// generators once the platform is ready, and when the duration has
+// elapsed reads the ingestion results and resource headroom from the
+// platform's Prometheus, records them in the status and removes the
+// generators.
+type PlatformLoadTestReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	HTTP   *http.Client
+	Log    logr.Logger
+}
+
+// +kubebuilder:rbac:groups=observability.io,resources=platformloadtests,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observability.io,resources=platformloadtests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+
+// Reconcile moves a load test through its phases
+func (r *PlatformLoadTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("loadtest", req.NamespacedName)
+
+	test := &observabilityv1beta1.PlatformLoadTest{}
+	if err := r.Get(ctx, req.NamespacedName, test); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	// The generators are owned by the test and garbage collected with it
+	if !test.DeletionTimestamp.IsZero() || test.IsFinished() {
+		return ctrl.Result{}, nil
+	}
+
+	previous := test.Status
+	result, err := r.run(ctx, log, test)
+	if !equality.Semantic.DeepEqual(previous, test.Status) {
+		if updateErr := r.Status().Update(ctx, test); updateErr != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update load test status: %w", updateErr)
+		}
+	}
+	return result, err
+}
+
+// run advances the test and sets its status
+func (r *PlatformLoadTestReconciler) run(ctx context.Context, log logr.Logger, test *observabilityv1beta1.PlatformLoadTest) (ctrl.Result, error) {
+	platform := &observabilityv1beta1.ObservabilityPlatform{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: test.Namespace, Name: test.Spec.Platform}, platform); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		if test.Status.Phase == observabilityv1beta1.LoadTestPhaseRunning {
+			return ctrl.Result{}, r.fail(ctx, test, fmt.Sprintf("The platform %s was deleted", test.Spec.Platform))
+		}
+		test.Status.Phase = observabilityv1beta1.LoadTestPhasePending
+		test.Status.Message = fmt.Sprintf("Waiting for the platform %s to be created", test.Spec.Platform)
+		return ctrl.Result{RequeueAfter: loadTestPendingRequeue}, nil
+	}
+
+	if test.Status.Phase != observabilityv1beta1.LoadTestPhaseRunning {
+		if msg := loadTestSpecError(test, platform); msg != "" {
+			return ctrl.Result{}, r.fail(ctx, test, msg)
+		}
+		if platform.Status.Phase != observabilityv1beta1.PhaseReady {
+			test.Status.Phase = observabilityv1beta1.LoadTestPhasePending
+			test.Status.Message = fmt.Sprintf("Waiting for the platform %s to be ready", platform.Name)
+			return ctrl.Result{RequeueAfter: loadTestPendingRequeue}, nil
+		}
+		test.Status.StartTime = &metav1.Time{Time: time.Now()}
+		log.Info("Starting load test", "platform", platform.Name, "duration", test.GetDuration())
+	}
+
+	// Deploy the generators, and restore them if removed while running
+	if err := r.applyGenerators(ctx, test, platform); err != nil {
+		return ctrl.Result{}, err
+	}
+	test.Status.Phase = observabilityv1beta1.LoadTestPhaseRunning
+	end := test.Status.StartTime.Add(test.GetDuration())
+	if remaining := time.Until(end); remaining > 0 {
+		test.Status.Message = fmt.Sprintf("Generating load until %s", end.UTC().Format(time.RFC3339))
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	if err := r.collectResults(ctx, test, platform, end); err != nil {
+		if time.Since(end) < loadTestCollectTimeout {
+			return ctrl.Result{}, fmt.Errorf("failed to collect load test results: %w", err)
+		}
+		return ctrl.Result{}, r.fail(ctx, test, fmt.Sprintf("Failed to collect the results: %v", err))
+	}
+	log.Info("Load test finished", "platform", platform.Name, "phase", test.Status.Phase)
+	return ctrl.Result{}, r.deleteGenerators(ctx, test)
+}
+
+// loadTestSpecError returns why the load can't be generated against the
+// platform, empty if it can
+func loadTestSpecError(test *observabilityv1beta1.PlatformLoadTest, platform *observabilityv1beta1.ObservabilityPlatform) string {
+	if test.Spec.Metrics == nil && test.Spec.Logs == nil && test.Spec.Traces == nil {
+		return "No metrics, logs or traces to generate"
+	}
+
+	components := platform.Spec.Components
+	if components == nil || components.Prometheus == nil || !components.Prometheus.Enabled {
+		return "The results are read from Prometheus, which is not enabled"
+	}
+	if test.Spec.Logs != nil && (components.Loki == nil || !components.Loki.Enabled) {
+		return "Logs are generated, but Loki is not enabled"
+	}
+	if test.Spec.Traces != nil && (components.Tempo == nil || !components.Tempo.Enabled) {
+		return "Traces are generated, but Tempo is not enabled"
+	}
+	return ""
+}
+
+// applyGenerators creates or updates the Deployments generating the load
+func (r *PlatformLoadTestReconciler) applyGenerators(ctx context.Context, test *observabilityv1beta1.PlatformLoadTest, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	targets := loadtest.Targets{
+		TempoAddress: alloy.TempoEndpoint(platform.Name, platform.Namespace),
+		TenantID:     platform.TenantID(),
+	}
+	if u, err := url.Parse(alloy.LokiEndpoint(platform.Name, platform.Namespace)); err == nil {
+		targets.LokiAddress = u.Host
+	}
+
+	for _, desired := range loadtest.Generators(test, targets) {
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+			deployment.Labels = desired.Labels
+			deployment.Spec.Replicas = desired.Spec.Replicas
+			if deployment.CreationTimestamp.IsZero() {
+				deployment.Spec.Selector = desired.Spec.Selector
+			}
+			deployment.Spec.Template = desired.Spec.Template
+			return controllerutil.SetControllerReference(test, deployment, r.Scheme)
+		}); err != nil {
+			return fmt.Errorf("failed to apply load generator %s: %w", desired.Name, err)
+		}
+	}
+	return nil
+}
+
+// collectResults reads the results of the test from the platform's
+// Prometheus and sets its final phase
+func (r *PlatformLoadTestReconciler) collectResults(ctx context.Context, test *observabilityv1beta1.PlatformLoadTest, platform *observabilityv1beta1.ObservabilityPlatform, end time.Time) error {
+	httpClient := r.HTTP
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: time.Minute}
+	}
+	querier := &loadtest.Querier{
+		HTTP: httpClient,
+		URL:  fmt.Sprintf("http://prometheus-%s.%s.svc.cluster.local:9090", platform.Name, platform.Namespace),
+	}
+
+	signals, err := loadtest.Signals(ctx, querier, test, end)
+	if err != nil {
+		return err
+	}
+
+	var headroom []observabilityv1beta1.LoadTestHeadroom
+	for _, component := range loadTestComponents(test) {
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods, client.InNamespace(platform.Namespace), client.MatchingLabels{
+			"app.kubernetes.io/name":    component,
+			"observability.io/platform": platform.Name,
+		}); err != nil {
+			return fmt.Errorf("failed to list %s pods: %w", component, err)
+		}
+		h, err := loadtest.Headroom(ctx, querier, test, component, pods.Items, end)
+		if err != nil {
+			return err
+		}
+		headroom = append(headroom, h)
+	}
+
+	var failed []string
+	for _, signal := range signals {
+		if !signal.Passed {
+			failed = append(failed, signal.Signal)
+		}
+	}
+
+	test.Status.Signals = signals
+	test.Status.Headroom = headroom
+	test.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	if len(failed) == 0 {
+		test.Status.Phase = observabilityv1beta1.LoadTestPhasePassed
+		test.Status.Message = fmt.Sprintf("All signals met the success threshold of %d%%", test.GetSuccessThreshold())
+	} else {
+		test.Status.Phase = observabilityv1beta1.LoadTestPhaseFailed
+		test.Status.Message = fmt.Sprintf("Below the success threshold of %d%% or not measured: %s",
+			test.GetSuccessThreshold(), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// loadTestComponents returns the components receiving the load of a test
+func loadTestComponents(test *observabilityv1beta1.PlatformLoadTest) []string {
+	components := []string{"prometheus"}
+	if test.Spec.Logs != nil {
+		components = append(components, "loki")
+	}
+	if test.Spec.Traces != nil {
+		components = append(components, "tempo")
+	}
+	return components
+}
+
+// fail finishes the test as failed and removes its generators
+func (r *PlatformLoadTestReconciler) fail(ctx context.Context, test *observabilityv1beta1.PlatformLoadTest, message string) error {
+	test.Status.Phase = observabilityv1beta1.LoadTestPhaseFailed
+	test.Status.Message = message
+	test.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	return r.deleteGenerators(ctx, test)
+}
+
+// deleteGenerators removes the generators of a finished test
+func (r *PlatformLoadTestReconciler) deleteGenerators(ctx context.Context, test *observabilityv1beta1.PlatformLoadTest) error {
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(test.Namespace),
+		client.MatchingLabels{loadtest.LoadTestLabel: test.Name}); err != nil {
+		return fmt.Errorf("failed to list load generators: %w", err)
+	}
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if !metav1.IsControlledBy(deployment, test) {
+			continue
+		}
+		if err := r.Delete(ctx, deployment); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete load generator %s: %w", deployment.Name, err)
+		}
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *PlatformLoadTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&observabilityv1beta1.PlatformLoadTest{}).
+		Owns(&appsv1.Deployment{}).
+		Complete(r)
+}
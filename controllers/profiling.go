@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/profiling"
+)
+
+// reconcileProfiling deploys the continuous profiling backend, and removes
+// it when profiling is disabled. The profiles volume is kept.
+func (r *ObservabilityPlatformReconciler) reconcileProfiling(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx).WithValues("profiling", "reconcile")
+
+	if !platform.Spec.Components.ProfilingEnabled() {
+		return r.deleteProfiling(ctx, platform)
+	}
+	spec := platform.Spec.Components.Profiling
+
+	server := profiling.Server{
+		Name:             profiling.ServerName(platform.Name),
+		Namespace:        platform.Namespace,
+		Backend:          spec.GetBackend(),
+		Version:          spec.GetVersion(),
+		Retention:        spec.Retention,
+		Storage:          spec.Storage,
+		StorageClassName: spec.StorageClassName,
+		Resources:        spec.Resources,
+		Labels: map[string]string{
+			"app.kubernetes.io/name":       "profiling",
+			"app.kubernetes.io/component":  spec.GetBackend(),
+			"app.kubernetes.io/instance":   platform.Name,
+			"app.kubernetes.io/managed-by": "gunj-operator",
+			"app.kubernetes.io/part-of":    "observability-platform",
+			"observability.io/platform":    platform.Name,
+		},
+	}
+
+	desiredConfig, err := profiling.BuildConfigMap(server)
+	if err != nil {
+		return err
+	}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: desiredConfig.Name, Namespace: desiredConfig.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		cm.Labels = desiredConfig.Labels
+		cm.Data = desiredConfig.Data
+		return controllerutil.SetControllerReference(platform, cm, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile profiling config: %w", err)
+	}
+
+	desired := profiling.BuildStatefulSet(server)
+	// Restart the backend when the configuration changes
+	desired.Spec.Template.Annotations = map[string]string{
+		"observability.io/config-hash": fmt.Sprintf("%x", sha256.Sum256([]byte(desiredConfig.Data[profiling.ConfigKey]))),
+	}
+	sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, sts, func() error {
+		sts.Labels = desired.Labels
+		// The selector, service name and volume claims are immutable, so only
+		// set them on creation
+		if sts.CreationTimestamp.IsZero() {
+			sts.Spec.Selector = desired.Spec.Selector
+			sts.Spec.ServiceName = desired.Spec.ServiceName
+			sts.Spec.VolumeClaimTemplates = desired.Spec.VolumeClaimTemplates
+		}
+		sts.Spec.Replicas = desired.Spec.Replicas
+		sts.Spec.Template = desired.Spec.Template
+		return controllerutil.SetControllerReference(platform, sts, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile profiling backend: %w", err)
+	}
+
+	desiredSvc := profiling.BuildService(server)
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: desiredSvc.Name, Namespace: desiredSvc.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, svc, func() error {
+		svc.Labels = desiredSvc.Labels
+		svc.Spec.Selector = desiredSvc.Spec.Selector
+		svc.Spec.Ports = desiredSvc.Spec.Ports
+		return controllerutil.SetControllerReference(platform, svc, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile profiling service: %w", err)
+	}
+
+	log.V(1).Info("Profiling backend reconciled", "backend", server.Backend, "retention", server.Retention,
+		"url", profiling.URL(platform.Name, platform.Namespace, server.Backend))
+	return nil
+}
+
+// deleteProfiling removes the profiling backend of a platform
+func (r *ObservabilityPlatformReconciler) deleteProfiling(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	name, namespace := profiling.ServerName(platform.Name), platform.Namespace
+	for _, obj := range []client.Object{
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+	} {
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete profiling %T: %w", obj, err)
+		}
+	}
+	return nil
+}
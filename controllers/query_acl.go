@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/queryacl"
+)
+
+// reconcileQueryACL deploys the prom-label-proxy enforcing the label-based
+// query access of the platform's teams, admitting only Grafana to it, and
+// removes it when query access control is disabled
+func (r *ObservabilityPlatformReconciler) reconcileQueryACL(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx).WithValues("queryACL", "reconcile")
+
+	acl := platform.Spec.QueryACL
+	prometheusEnabled := platform.Spec.Components != nil &&
+		platform.Spec.Components.Prometheus != nil && platform.Spec.Components.Prometheus.Enabled
+	if !acl.IsEnabled() || !prometheusEnabled {
+		return r.deleteQueryACLProxy(ctx, platform)
+	}
+
+	proxy := queryacl.Proxy{
+		Name:      queryacl.ProxyName(platform.Name),
+		Namespace: platform.Namespace,
+		Image:     acl.Image,
+		Label:     acl.GetLabel(),
+		Upstream:  acl.Upstream,
+		Replicas:  acl.GetReplicas(),
+		Labels: map[string]string{
+			"app.kubernetes.io/name":       "prom-label-proxy",
+			"app.kubernetes.io/instance":   platform.Name,
+			"app.kubernetes.io/managed-by": "gunj-operator",
+			"app.kubernetes.io/part-of":    "observability-platform",
+			"observability.io/platform":    platform.Name,
+		},
+		// Grafana deployed natively and with Helm, whose release is named
+		// after the platform
+		Clients: []map[string]string{
+			{"app.kubernetes.io/name": "grafana", "app.kubernetes.io/instance": platform.Name},
+			{"app.kubernetes.io/name": "grafana", "app.kubernetes.io/instance": platform.Name + "-grafana"},
+		},
+	}
+	if proxy.Image == "" {
+		proxy.Image = queryacl.DefaultImage
+	}
+	if proxy.Upstream == "" {
+		proxy.Upstream = fmt.Sprintf("http://prometheus-%s.%s.svc.cluster.local:9090", platform.Name, platform.Namespace)
+	}
+
+	desired := queryacl.BuildDeployment(proxy)
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		deployment.Labels = desired.Labels
+		// The selector is immutable, so only set it on creation
+		if deployment.CreationTimestamp.IsZero() {
+			deployment.Spec.Selector = desired.Spec.Selector
+		}
+		deployment.Spec.Replicas = desired.Spec.Replicas
+		deployment.Spec.Template = desired.Spec.Template
+		return controllerutil.SetControllerReference(platform, deployment, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile query ACL proxy deployment: %w", err)
+	}
+
+	desiredService := queryacl.BuildService(proxy)
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: desiredService.Name, Namespace: desiredService.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		service.Labels = desiredService.Labels
+		service.Spec.Selector = desiredService.Spec.Selector
+		service.Spec.Ports = desiredService.Spec.Ports
+		return controllerutil.SetControllerReference(platform, service, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile query ACL proxy service: %w", err)
+	}
+
+	desiredPolicy := queryacl.BuildNetworkPolicy(proxy)
+	policy := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: desiredPolicy.Name, Namespace: desiredPolicy.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		policy.Labels = desiredPolicy.Labels
+		policy.Spec = desiredPolicy.Spec
+		return controllerutil.SetControllerReference(platform, policy, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile query ACL proxy network policy: %w", err)
+	}
+
+	log.V(1).Info("Query ACL proxy reconciled", "teams", len(acl.Teams), "label", proxy.Label)
+	return nil
+}
+
+// deleteQueryACLProxy removes the query access control proxy of a platform
+func (r *ObservabilityPlatformReconciler) deleteQueryACLProxy(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	name := queryacl.ProxyName(platform.Name)
+	objects := []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: platform.Namespace}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: platform.Namespace}},
+		&networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: platform.Namespace}},
+	}
+	for _, obj := range objects {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get query ACL proxy %s: %w", name, err)
+		}
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete query ACL proxy %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/metrics"
+	"github.com/gunjanjp/gunj-operator/internal/querylog"
+)
+
+const (
+	// queryLogAnalyzerTick is how often platforms are checked for a due analysis
+	queryLogAnalyzerTick = time.Minute
+	// queryLogLimitBytes bounds the log read from a single pod per analysis
+	queryLogLimitBytes int64 = 64 * 1024 * 1024
+	// queryReportKey is the ConfigMap key holding the JSON report
+	queryReportKey = "report.json"
+)
+
+// QueryLogAnalyzer periodically summarizes the query log of every managed
+// Prometheus with query logging enabled into a top-N report of slow and
+// expensive queries. Reports are written to the prometheus-<platform>-query-report
+// ConfigMap and exposed as operator metrics. It implements manager.Runnable.
+type QueryLogAnalyzer struct {
+	Client    client.Client
+	Clientset kubernetes.Interface
+	Scheme    *runtime.Scheme
+	Metrics   *metrics.Collector
+	Log       logr.Logger
+
+	// lastRun is the end of the previous analysis window per platform
+	lastRun map[types.NamespacedName]time.Time
+}
+
+// Start runs the analysis loop until the context is cancelled
+func (a *QueryLogAnalyzer) Start(ctx context.Context) error {
+	a.lastRun = make(map[types.NamespacedName]time.Time)
+
+	ticker := time.NewTicker(queryLogAnalyzerTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			a.analyzeAll(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection returns true so only the leader writes reports
+func (a *QueryLogAnalyzer) NeedLeaderElection() bool {
+	return true
+}
+
+// analyzeAll analyzes the platforms whose analysis interval has elapsed
+func (a *QueryLogAnalyzer) analyzeAll(ctx context.Context) {
+	platforms := &observabilityv1beta1.ObservabilityPlatformList{}
+	if err := a.Client.List(ctx, platforms); err != nil {
+		a.Log.Error(err, "Failed to list platforms for query log analysis")
+		return
+	}
+
+	now := time.Now()
+	active := make(map[types.NamespacedName]bool)
+	for i := range platforms.Items {
+		platform := &platforms.Items[i]
+		queryLog := prometheusQueryLog(platform)
+		if !queryLog.IsEnabled() || !platform.DeletionTimestamp.IsZero() {
+			continue
+		}
+		key := client.ObjectKeyFromObject(platform)
+		active[key] = true
+
+		interval := queryLog.GetAnalysisInterval()
+		since, ok := a.lastRun[key]
+		if !ok {
+			since = now.Add(-interval)
+		} else if now.Sub(since) < interval {
+			continue
+		}
+
+		if err := a.analyze(ctx, platform, queryLog, since, now); err != nil {
+			// The window is kept, so the next tick retries it
+			a.Log.Error(err, "Failed to analyze Prometheus query log", "platform", platform.Name, "namespace", platform.Namespace)
+			continue
+		}
+		a.lastRun[key] = now
+	}
+
+	for key := range a.lastRun {
+		if !active[key] {
+			delete(a.lastRun, key)
+			a.Metrics.DeleteQueryReport(key.Name, key.Namespace)
+		}
+	}
+}
+
+// analyze reads the query log of a platform's Prometheus pods since the given
+// time and writes the report
+func (a *QueryLogAnalyzer) analyze(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, queryLog *observabilityv1beta1.QueryLogSpec, since, now time.Time) error {
+	pods := &corev1.PodList{}
+	if err := a.Client.List(ctx, pods, client.InNamespace(platform.Namespace), client.MatchingLabels{
+		"app.kubernetes.io/name":     "prometheus",
+		"app.kubernetes.io/instance": platform.Name,
+	}); err != nil {
+		return fmt.Errorf("failed to list Prometheus pods: %w", err)
+	}
+
+	var entries []querylog.Entry
+	sinceTime := metav1.NewTime(since)
+	limitBytes := queryLogLimitBytes
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		stream, err := a.Clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+			Container:  "prometheus",
+			SinceTime:  &sinceTime,
+			LimitBytes: &limitBytes,
+		}).Stream(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to read logs of pod %s: %w", pod.Name, err)
+		}
+		podEntries, err := querylog.Parse(stream)
+		stream.Close()
+		if err != nil {
+			return fmt.Errorf("failed to parse query log of pod %s: %w", pod.Name, err)
+		}
+		// SinceTime has second precision, so drop entries of the previous window
+		for _, entry := range podEntries {
+			if entry.Timestamp.IsZero() || entry.Timestamp.After(since) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	report := querylog.Summarize(entries, queryLog.GetSlowQueryThreshold(), queryLog.GetTopN(), since, now)
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal query report: %w", err)
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("prometheus-%s-query-report", platform.Name),
+			Namespace: platform.Namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, a.Client, configMap, func() error {
+		if configMap.Labels == nil {
+			configMap.Labels = map[string]string{}
+		}
+		configMap.Labels["app.kubernetes.io/managed-by"] = "gunj-operator"
+		configMap.Labels["observability.io/platform"] = platform.Name
+		configMap.Data = map[string]string{queryReportKey: string(data)}
+		return controllerutil.SetControllerReference(platform, configMap, a.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to write query report: %w", err)
+	}
+
+	topSeconds := make([]float64, 0, len(report.Top))
+	for _, stats := range report.Top {
+		topSeconds = append(topSeconds, stats.TotalSeconds)
+	}
+	a.Metrics.RecordQueryReport(platform.Name, platform.Namespace, report.TotalQueries, report.SlowQueries, topSeconds)
+
+	a.Log.V(1).Info("Wrote Prometheus query report", "platform", platform.Name, "namespace", platform.Namespace,
+		"queries", report.TotalQueries, "slowQueries", report.SlowQueries)
+	return nil
+}
+
+// prometheusQueryLog returns the query log settings of a platform's Prometheus
+func prometheusQueryLog(platform *observabilityv1beta1.ObservabilityPlatform) *observabilityv1beta1.QueryLogSpec {
+	if platform.Spec.Components == nil || platform.Spec.Components.Prometheus == nil || !platform.Spec.Components.Prometheus.Enabled {
+		return nil
+	}
+	return platform.Spec.Components.Prometheus.QueryLog
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// reconcileReadOnly reconciles a platform in read-only mode: nothing is
+// created, updated or deleted, neither the components nor the upgrades,
+// migrations and rotations in progress, which resume once the mode is set
+// back to normal. The health of the components is still checked and
+// reported, so the platform can be watched while it is frozen.
+func (r *ObservabilityPlatformReconciler) reconcileReadOnly(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.V(1).Info("Platform is read-only, only checking health")
+
+	r.checkHealth(ctx, platform)
+
+	r.StatusManager.SetCondition(ctx, platform, ConditionProgressing, metav1.ConditionFalse, "ReadOnly", "Platform is read-only; spec changes are not applied")
+	r.StatusManager.AggregateComponentStatuses(ctx, platform)
+	r.StatusManager.CalculateAndSetPhase(ctx, platform)
+	r.StatusManager.UpdateMetrics(ctx, platform)
+
+	return ctrl.Result{RequeueAfter: r.RequeueDuration}, nil
+}
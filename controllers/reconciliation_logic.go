@@ -24,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/upgradehooks"
 )
 
 // ReconciliationState tracks the state of reconciliation
@@ -235,6 +236,13 @@ func (r *ObservabilityPlatformReconciler) reconcileNetworkPolicies(ctx context.C
 					"app.kubernetes.io/managed-by": "gunj-operator",
 					"app.kubernetes.io/instance":   platform.Name,
 				},
+				// The query ACL proxy has its own policy admitting only
+				// Grafana; policies add up, so it must not be selected here
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      "app.kubernetes.io/name",
+					Operator: metav1.LabelSelectorOpNotIn,
+					Values:   []string{"prom-label-proxy"},
+				}},
 			},
 			PolicyTypes: []networkingv1.PolicyType{
 				networkingv1.PolicyTypeIngress,
@@ -681,28 +689,66 @@ func (r *ObservabilityPlatformReconciler) ReconcileWithDependencies(ctx context.
 		// Track deployment time
 		startTime := time.Now()
 		
-		// Reconcile based on component type
-		var err error
-		
-		switch component {
-		case "prometheus":
-			if r.PrometheusManager != nil {
-				err = r.PrometheusManager.ReconcileWithConfig(ctx, platform, config)
-			}
-		case "grafana":
-			if r.GrafanaManager != nil {
-				err = r.GrafanaManager.ReconcileWithConfig(ctx, platform, config)
+		// Hold the component while its volumes move to another StorageClass
+		if migrating, err := r.migrateStorage(ctx, platform, component); err != nil || migrating {
+			message := "Migrating storage"
+			if err != nil {
+				log.Error(err, "Failed to migrate storage", "component", component)
+				message = fmt.Sprintf("Storage migration failed: %v", err)
 			}
-		case "loki":
-			if r.LokiManager != nil {
-				err = r.LokiManager.ReconcileWithConfig(ctx, platform, config)
-			}
-		case "tempo":
-			if r.TempoManager != nil {
-				err = r.TempoManager.ReconcileWithConfig(ctx, platform, config)
+			r.StatusManager.SetComponentStatus(ctx, platform, component, false, message)
+			continue
+		}
+
+		// Silence the platform's alerts while a new version rolls out
+		r.silenceUpgrade(ctx, platform, component)
+
+		// Hold the rollout of a new version until its volumes are snapshotted
+		snapshotted, err := r.snapshotBeforeUpgrade(ctx, platform, component)
+		if err == nil && !snapshotted {
+			log.Info("Waiting for volume snapshots before rolling out new version", "component", component)
+			r.StatusManager.SetComponentStatus(ctx, platform, component, false, "Waiting for pre-upgrade volume snapshots")
+			continue
+		}
+
+		// Hold the rollout of a new version until its pre-upgrade hooks succeed
+		preUpgradeDone := true
+		if err == nil {
+			preUpgradeDone, err = r.runUpgradeHooks(ctx, platform, component, upgradehooks.PreUpgrade)
+		}
+		if err == nil && !preUpgradeDone {
+			log.Info("Waiting for pre-upgrade hooks before rolling out new version", "component", component)
+			r.StatusManager.SetComponentStatus(ctx, platform, component, false, "Waiting for pre-upgrade hooks")
+			continue
+		}
+
+		// Reconcile based on component type
+		if err == nil {
+			switch component {
+			case "prometheus":
+				if r.PrometheusManager != nil {
+					err = r.PrometheusManager.ReconcileWithConfig(ctx, platform, config)
+				}
+			case "grafana":
+				if r.GrafanaManager != nil {
+					err = r.GrafanaManager.ReconcileWithConfig(ctx, platform, config)
+				}
+			case "loki":
+				if r.LokiManager != nil {
+					err = r.LokiManager.ReconcileWithConfig(ctx, platform, config)
+				}
+			case "tempo":
+				if r.TempoManager != nil {
+					err = r.TempoManager.ReconcileWithConfig(ctx, platform, config)
+				}
 			}
 		}
 
+		// Run post-upgrade hooks and record the rolled out version
+		if err == nil {
+			err = r.completeUpgrade(ctx, platform, component)
+		}
+
 		// Record deployment duration
 		duration := time.Since(startTime)
 
@@ -0,0 +1,235 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/backfill"
+	"github.com/gunjanjp/gunj-operator/internal/upgradehooks"
+)
+
+const (
+	// prometheusDataPath is where the data volume is mounted in Prometheus
+	prometheusDataPath = "/prometheus"
+	// prometheusDataVolume is the name of the Prometheus data volume
+	prometheusDataVolume = "data"
+)
+
+// reconcileRecordingRuleBackfill backfills the history of recording rules
+// added since the last backfill. Once Prometheus is ready, a Job per replica
+// evaluates the new rules over the lookback window and writes the blocks into
+// the replica's storage. The rules are recorded as backfilled once all Jobs
+// have succeeded; a failed Job is kept for inspection and deleting it retries
+// the backfill.
+func (r *ObservabilityPlatformReconciler) reconcileRecordingRuleBackfill(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx).WithValues("recordingRuleBackfill", "reconcile")
+
+	alerting := platform.Spec.Alerting
+	prometheusEnabled := platform.Spec.Components != nil &&
+		platform.Spec.Components.Prometheus != nil && platform.Spec.Components.Prometheus.Enabled
+	if alerting == nil || !alerting.Backfill.IsEnabled() || !prometheusEnabled {
+		return nil
+	}
+
+	// Persist the backfilled rules, so they're not backfilled again after a
+	// requeue or restart
+	defer func() {
+		backfillStatus := platform.Status.RecordingRuleBackfill.DeepCopy()
+		if err := r.StatusManager.UpdatePlatformStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
+			status.RecordingRuleBackfill = backfillStatus
+		}); err != nil {
+			log.Error(err, "Failed to update recording rule backfill status")
+		}
+	}()
+
+	rules := backfillRules(alerting.RecordingRules)
+	status := platform.Status.RecordingRuleBackfill
+	if status == nil {
+		status = &observabilityv1beta1.RecordingRuleBackfillStatus{}
+		platform.Status.RecordingRuleBackfill = status
+	}
+	status.Backfilled = backfill.Retain(rules, status.Backfilled)
+
+	pending := backfill.Pending(rules, status.Backfilled)
+	if len(pending) == 0 {
+		setBackfillState(status, nil, nil, "")
+		return nil
+	}
+	keys := backfill.Keys(pending)
+
+	// The rules must be loaded and the data volumes bound before backfilling
+	if ready, err := r.componentReady(ctx, platform, "prometheus"); err != nil || !ready {
+		return err
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(platform.Namespace), client.MatchingLabels{
+		"app.kubernetes.io/name":     "prometheus",
+		"app.kubernetes.io/instance": platform.Name,
+	}); err != nil {
+		return fmt.Errorf("failed to list Prometheus pods: %w", err)
+	}
+
+	// Blocks written next to an emptyDir would be lost with the pod, so only
+	// replicas with persistent storage are backfilled
+	claims := make(map[string]string)
+	for _, pod := range pods.Items {
+		if claimName := dataClaimName(&pod); claimName != "" {
+			claims[pod.Name] = claimName
+		}
+	}
+	if len(claims) == 0 {
+		log.V(1).Info("No Prometheus replica with persistent storage to backfill", "rules", len(keys))
+		return nil
+	}
+
+	ruleFile, err := backfill.RuleFile(pending)
+	if err != nil {
+		return err
+	}
+	labels := r.commonLabels(platform)
+	labels["app.kubernetes.io/component"] = "recording-rule-backfill"
+
+	configMapName := backfill.ConfigMapName(platform.Name, keys)
+	desired := backfill.BuildConfigMap(configMapName, platform.Namespace, labels, ruleFile)
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Labels = desired.Labels
+		configMap.Data = desired.Data
+		return controllerutil.SetControllerReference(platform, configMap, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile backfill rules: %w", err)
+	}
+
+	end := time.Now()
+	var jobs []string
+	state := upgradehooks.StateSucceeded
+	for podName, claimName := range claims {
+		target := backfill.Target{
+			Platform:           platform.Name,
+			Namespace:          platform.Namespace,
+			Image:              fmt.Sprintf("%s:%s", componentImages["prometheus"], componentVersion(platform, "prometheus")),
+			URL:                fmt.Sprintf("http://prometheus-%s.%s.svc.cluster.local:9090", platform.Name, platform.Namespace),
+			Pod:                podName,
+			ClaimName:          claimName,
+			DataPath:           prometheusDataPath,
+			Start:              end.Add(-alerting.Backfill.GetLookback()),
+			End:                end,
+			EvalInterval:       alerting.Backfill.GetEvalInterval(),
+			ServiceAccountName: fmt.Sprintf("%s-observability", platform.Name),
+			Labels:             labels,
+		}
+		job := backfill.BuildJob(target, backfill.JobName(podName, keys), configMapName)
+		jobs = append(jobs, job.Name)
+
+		existing := &batchv1.Job{}
+		err := r.Get(ctx, client.ObjectKeyFromObject(job), existing)
+		if errors.IsNotFound(err) {
+			if err := controllerutil.SetControllerReference(platform, job, r.Scheme); err != nil {
+				return fmt.Errorf("failed to set owner reference on backfill job: %w", err)
+			}
+			if err := r.Create(ctx, job); err != nil {
+				return fmt.Errorf("failed to create backfill job %s: %w", job.Name, err)
+			}
+			log.Info("Started recording rule backfill", "job", job.Name, "rules", len(keys))
+			state = upgradehooks.StateRunning
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get backfill job %s: %w", job.Name, err)
+		}
+
+		switch upgradehooks.JobState(existing) {
+		case upgradehooks.StateFailed:
+			state = upgradehooks.StateFailed
+		case upgradehooks.StateRunning:
+			if state != upgradehooks.StateFailed {
+				state = upgradehooks.StateRunning
+			}
+		}
+	}
+
+	sort.Strings(jobs)
+	previous := status.State
+	setBackfillState(status, keys, jobs, state)
+	switch state {
+	case upgradehooks.StateRunning:
+		if previous != string(state) {
+			r.EventRecorder.RecordComponentEvent(platform, "prometheus", "RecordingRuleBackfillStarted",
+				fmt.Sprintf("Backfilling %d recording rules over the last %s", len(keys), alerting.Backfill.GetLookback()))
+		}
+		return nil
+	case upgradehooks.StateFailed:
+		if previous != string(state) {
+			r.EventRecorder.RecordComponentEvent(platform, "prometheus", "RecordingRuleBackfillFailed",
+				fmt.Sprintf("Backfill of %d recording rules failed", len(keys)))
+		}
+		return fmt.Errorf("recording rule backfill failed, see jobs %v; delete the failed jobs to retry", jobs)
+	}
+
+	status.Backfilled = backfill.Retain(rules, append(status.Backfilled, keys...))
+	setBackfillState(status, nil, nil, "")
+	r.EventRecorder.RecordComponentEvent(platform, "prometheus", "RecordingRuleBackfillCompleted",
+		fmt.Sprintf("Backfilled %d recording rules into %d replicas", len(keys), len(jobs)))
+
+	if err := r.Delete(ctx, configMap); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete backfill rules %s: %w", configMapName, err)
+	}
+	return nil
+}
+
+// backfillRules flattens recording rule groups
+func backfillRules(groups []observabilityv1beta1.RecordingRuleGroup) []backfill.Rule {
+	var rules []backfill.Rule
+	for _, g := range groups {
+		for _, r := range g.Rules {
+			rules = append(rules, backfill.Rule{
+				Group:    g.Name,
+				Interval: g.Interval,
+				Record:   r.Record,
+				Expr:     r.Expr,
+				Labels:   r.Labels,
+			})
+		}
+	}
+	return rules
+}
+
+// dataClaimName returns the PersistentVolumeClaim of a Prometheus pod's data
+// volume, or an empty string if it has no persistent storage
+func dataClaimName(pod *corev1.Pod) string {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == prometheusDataVolume && volume.PersistentVolumeClaim != nil {
+			return volume.PersistentVolumeClaim.ClaimName
+		}
+	}
+	return ""
+}
+
+// setBackfillState records the state of the pending backfill
+func setBackfillState(status *observabilityv1beta1.RecordingRuleBackfillStatus, pending, jobs []string, state upgradehooks.State) {
+	if status.State != string(state) {
+		now := metav1.Now()
+		status.LastTransitionTime = &now
+	}
+	status.Pending = pending
+	status.Jobs = jobs
+	status.State = string(state)
+}
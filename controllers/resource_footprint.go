@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/footprint"
+)
+
+// recordResourceFootprint sums the resources requested by the workloads of
+// the platform into status.resourceFootprint
+func (r *ObservabilityPlatformReconciler) recordResourceFootprint(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	opts := []client.ListOption{
+		client.InNamespace(platform.Namespace),
+		client.MatchingLabels{"observability.io/platform": platform.Name},
+	}
+	acc := footprint.New()
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, opts...); err != nil {
+		return fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for i := range deployments.Items {
+		acc.AddDeployment(&deployments.Items[i])
+	}
+
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := r.List(ctx, daemonSets, opts...); err != nil {
+		return fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for i := range daemonSets.Items {
+		acc.AddDaemonSet(&daemonSets.Items[i])
+	}
+
+	// StatefulSets go before the claims, so the claims of their templates
+	// are not counted twice
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := r.List(ctx, statefulSets, opts...); err != nil {
+		return fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for i := range statefulSets.Items {
+		acc.AddStatefulSet(&statefulSets.Items[i])
+	}
+
+	claims := &corev1.PersistentVolumeClaimList{}
+	if err := r.List(ctx, claims, opts...); err != nil {
+		return fmt.Errorf("failed to list persistent volume claims: %w", err)
+	}
+	for i := range claims.Items {
+		acc.AddClaim(&claims.Items[i])
+	}
+
+	result := acc.Footprint()
+	r.StatusManager.UpdatePlatformStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
+		status.ResourceFootprint = result
+	})
+	return nil
+}
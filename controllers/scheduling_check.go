@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/feasibility"
+)
+
+// checkSchedulingFeasibility simulates whether the replicas of the platform's
+// components fit on the cluster's nodes and reports it in the Schedulable
+// condition, with a warning event when they stop fitting
+func (r *ObservabilityPlatformReconciler) checkSchedulingFeasibility(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	if !feasibility.Enabled(platform) {
+		return nil
+	}
+
+	var reader client.Reader = r.Client
+	if r.APIReader != nil {
+		reader = r.APIReader
+	}
+	results, err := feasibility.Check(ctx, reader, platform)
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		return r.StatusManager.SetCondition(ctx, platform, ConditionSchedulable,
+			metav1.ConditionTrue, ReasonSchedulable, "All replicas fit on the cluster's nodes")
+	}
+
+	messages := make([]string, 0, len(results))
+	for _, result := range results {
+		messages = append(messages, result.String())
+	}
+	message := strings.Join(messages, "; ")
+	log.FromContext(ctx).Info("Replicas would fit on no node", "unschedulable", messages)
+
+	if !meta.IsStatusConditionFalse(platform.Status.Conditions, ConditionSchedulable) {
+		r.EventRecorder.RecordPlatformEvent(platform, EventReasonUnschedulable, message)
+	}
+	return r.StatusManager.SetCondition(ctx, platform, ConditionSchedulable,
+		metav1.ConditionFalse, ReasonUnschedulable, message)
+}
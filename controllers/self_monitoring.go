@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/selfmonitoring"
+)
+
+// reconcileSelfMonitoring creates the ServiceMonitor of the operator when the
+// platform asks for one, and removes it otherwise. The scrape job and the
+// dashboard are part of the Prometheus and Grafana configurations. Clusters
+// without the Prometheus Operator have no ServiceMonitor kind, which is
+// logged and otherwise ignored.
+func (r *ObservabilityPlatformReconciler) reconcileSelfMonitoring(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx).WithValues("selfMonitoring", "reconcile")
+
+	desired := selfmonitoring.ServiceMonitor(selfmonitoring.ServiceMonitorName(platform.Name), platform.Namespace, map[string]string{
+		"app.kubernetes.io/name":       "gunj-operator",
+		"app.kubernetes.io/instance":   platform.Name,
+		"app.kubernetes.io/managed-by": "gunj-operator",
+		"app.kubernetes.io/part-of":    "observability-platform",
+		"observability.io/platform":    platform.Name,
+	})
+	sm := &unstructured.Unstructured{}
+	sm.SetGroupVersionKind(desired.GroupVersionKind())
+	sm.SetName(desired.GetName())
+	sm.SetNamespace(desired.GetNamespace())
+
+	if !platform.Spec.SelfMonitoring.ServiceMonitorEnabled() {
+		if err := r.Delete(ctx, sm); client.IgnoreNotFound(err) != nil && !meta.IsNoMatchError(err) {
+			return fmt.Errorf("failed to delete operator service monitor: %w", err)
+		}
+		return nil
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, sm, func() error {
+		sm.SetLabels(desired.GetLabels())
+		sm.Object["spec"] = desired.Object["spec"]
+		return controllerutil.SetControllerReference(platform, sm, r.Scheme)
+	})
+	if meta.IsNoMatchError(err) {
+		log.Info("ServiceMonitor kind not installed, the operator is only scraped by the platform's Prometheus")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reconcile operator service monitor: %w", err)
+	}
+
+	log.V(1).Info("Operator service monitor reconciled", "name", sm.GetName())
+	return nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gunjanjp/gunj-operator/internal/rollout"
+)
+
+// defaultStagedRolloutInterval is the default interval between rollout steps
+const defaultStagedRolloutInterval = 15 * time.Second
+
+// StagedRollouts advances the staged rollouts of the StatefulSets prepared
+// by the component managers: it releases their pods one at a time once the
+// ring is stable, flushing each ingester before it is replaced. It
+// implements manager.Runnable.
+type StagedRollouts struct {
+	Client   client.Client
+	Prober   *rollout.Prober
+	Interval time.Duration
+	Log      logr.Logger
+}
+
+// Start advances the rollouts every interval until the context is cancelled
+func (s *StagedRollouts) Start(ctx context.Context) error {
+	interval := s.Interval
+	if interval == 0 {
+		interval = defaultStagedRolloutInterval
+	}
+	if s.Prober == nil {
+		s.Prober = &rollout.Prober{}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		list := &appsv1.StatefulSetList{}
+		if err := s.Client.List(ctx, list, client.MatchingLabels{rollout.Label: "true"}); err != nil {
+			// Don't fail the manager: the rollouts are held until the next tick
+			s.Log.Error(err, "Failed to list StatefulSets with staged rollouts")
+		}
+		for i := range list.Items {
+			sts := &list.Items[i]
+			if err := s.advance(ctx, sts); err != nil {
+				s.Log.Error(err, "Failed to advance staged rollout", "namespace", sts.Namespace, "statefulset", sts.Name)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// advance takes the next step of the rollout of a StatefulSet
+func (s *StagedRollouts) advance(ctx context.Context, sts *appsv1.StatefulSet) error {
+	if rollout.Partition(sts) <= 0 || sts.Spec.Selector == nil {
+		return nil
+	}
+	config, err := rollout.ConfigFor(sts)
+	if err != nil {
+		return err
+	}
+	log := s.Log.WithValues("namespace", sts.Namespace, "statefulset", sts.Name)
+
+	pods := &corev1.PodList{}
+	if err := s.Client.List(ctx, pods, client.InNamespace(sts.Namespace), client.MatchingLabels(sts.Spec.Selector.MatchLabels)); err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+	state := rollout.StateFor(sts, pods.Items)
+	state.Now = time.Now()
+	state.StabilizationPeriod = config.StabilizationPeriod.Duration
+
+	ringURL := fmt.Sprintf("http://%s.%s.svc:%d%s", sts.Spec.ServiceName, sts.Namespace, config.Port, config.RingPath)
+	if instances, err := s.Prober.Ring(ctx, ringURL); err != nil {
+		state.RingError = err
+	} else {
+		state.RingError = rollout.CheckRing(instances, state.Replicas, state.Now, rollout.DefaultHeartbeatTimeout)
+	}
+
+	decision := rollout.Step(state)
+	switch decision.Action {
+	case rollout.ActionWait:
+		log.V(1).Info("Staged rollout waiting", "partition", state.Partition, "reason", decision.Reason)
+		return nil
+	case rollout.ActionFlush:
+		if err := s.flush(ctx, sts, decision.Ordinal, config); err != nil {
+			return err
+		}
+	case rollout.ActionRelease:
+	default:
+		return nil
+	}
+	return s.release(ctx, sts, decision.Ordinal, log)
+}
+
+// flush flushes the ingester of a pod. The flush is recorded first, so that
+// a flush interrupted by a restart of the operator is completed even though
+// the ingester has already left the ring.
+func (s *StagedRollouts) flush(ctx context.Context, sts *appsv1.StatefulSet, ordinal int32, config rollout.Config) error {
+	if sts.Annotations[rollout.FlushingAnnotation] != strconv.Itoa(int(ordinal)) {
+		patch := client.MergeFrom(sts.DeepCopy())
+		sts.Annotations[rollout.FlushingAnnotation] = strconv.Itoa(int(ordinal))
+		if err := s.Client.Patch(ctx, sts, patch); err != nil {
+			return fmt.Errorf("failed to record the flush of pod %d: %w", ordinal, err)
+		}
+	}
+
+	pod := &corev1.Pod{}
+	if err := s.Client.Get(ctx, client.ObjectKey{Namespace: sts.Namespace, Name: fmt.Sprintf("%s-%d", sts.Name, ordinal)}, pod); err != nil {
+		return fmt.Errorf("failed to get pod %d: %w", ordinal, err)
+	}
+	if pod.Status.PodIP == "" {
+		return fmt.Errorf("pod %s has no IP", pod.Name)
+	}
+
+	flushCtx, cancel := context.WithTimeout(ctx, config.FlushTimeout.Duration)
+	defer cancel()
+	s.Log.Info("Flushing ingester before its update", "namespace", sts.Namespace, "pod", pod.Name)
+	return s.Prober.Flush(flushCtx, fmt.Sprintf("http://%s:%d%s", pod.Status.PodIP, config.Port, config.FlushPath))
+}
+
+// release lowers the partition to the ordinal, letting the StatefulSet
+// controller replace the pod
+func (s *StagedRollouts) release(ctx context.Context, sts *appsv1.StatefulSet, ordinal int32, log logr.Logger) error {
+	patch := client.MergeFrom(sts.DeepCopy())
+	sts.Spec.UpdateStrategy.RollingUpdate.Partition = &ordinal
+	sts.Annotations[rollout.StepTimeAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	delete(sts.Annotations, rollout.FlushingAnnotation)
+	if err := s.Client.Patch(ctx, sts, patch); err != nil {
+		return fmt.Errorf("failed to lower the partition to %d: %w", ordinal, err)
+	}
+	log.Info("Staged rollout released pod", "ordinal", ordinal, "partition", ordinal)
+	return nil
+}
+
+// NeedLeaderElection returns true so only the leader advances the rollouts
+func (s *StagedRollouts) NeedLeaderElection() bool {
+	return true
+}
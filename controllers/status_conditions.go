@@ -47,6 +47,15 @@ const (
 	ConditionResourcesAvailable = "ResourcesAvailable"
 	ConditionStorageReady       = "StorageReady"
 	ConditionNetworkReady       = "NetworkReady"
+
+	// ConditionSchedulable reports whether the replicas of all components
+	// fit on the cluster's nodes, when the scheduling check is enabled
+	ConditionSchedulable = "Schedulable"
+
+	// ConditionValidated reports whether the controller re-validated the
+	// platform's generation like the validation webhook, when the webhook
+	// fails open
+	ConditionValidated = "Validated"
 )
 
 // Condition Reasons
@@ -57,6 +66,8 @@ const (
 	ReasonFailed            = "Failed"
 	ReasonInProgress        = "InProgress"
 	ReasonValidationFailed  = "ValidationFailed"
+	ReasonValidationPending = "ValidationPending"
+	ReasonValidated         = "Validated"
 	ReasonDependencyMissing = "DependencyMissing"
 
 	// Component reasons
@@ -72,6 +83,8 @@ const (
 	ReasonStorageUnavailable    = "StorageUnavailable"
 	ReasonNetworkPolicyFailed   = "NetworkPolicyFailed"
 	ReasonQuotaExceeded         = "QuotaExceeded"
+	ReasonSchedulable           = "Schedulable"
+	ReasonUnschedulable         = "Unschedulable"
 )
 
 // Phase transitions and their meanings
@@ -19,15 +19,18 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/metrics"
 )
 
 // StatusManager handles all status updates for ObservabilityPlatform
@@ -38,8 +41,21 @@ type StatusManager struct {
 	eventRecorder    *EnhancedEventRecorder
 	updateQueue      chan statusUpdate
 	metricsCollector *MetricsCollector
+	// batchWindow is how long queued updates are collected before being
+	// written, so the updates of a reconcile result in a single write
+	batchWindow time.Duration
+	// metrics counts the status writes and the suppressed updates, optional
+	metrics *metrics.Collector
+	// pending counts the queued updates not written yet, guarded by
+	// pendingMu. flushed is broadcast when it drops to zero.
+	pendingMu sync.Mutex
+	pending   int
+	flushed   sync.Cond
 }
 
+// defaultStatusBatchWindow is the default batch window of status updates
+const defaultStatusBatchWindow = 200 * time.Millisecond
+
 // statusUpdate represents a queued status update
 type statusUpdate struct {
 	platform *observabilityv1beta1.ObservabilityPlatform
@@ -108,6 +124,7 @@ func NewStatusManager(client client.Client, log logr.Logger, eventRecorder *Enha
 		conditionUtils: NewConditionUtils(),
 		eventRecorder:  eventRecorder,
 		updateQueue:    make(chan statusUpdate, 100),
+		batchWindow:    defaultStatusBatchWindow,
 		metricsCollector: &MetricsCollector{
 			componentStats: make(map[string]ComponentMetrics),
 		},
@@ -122,6 +139,7 @@ func NewStatusManager(client client.Client, log logr.Logger, eventRecorder *Enha
 // UpdatePlatformStatus updates the platform status with retries
 func (sm *StatusManager) UpdatePlatformStatus(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, updateFn func(*observabilityv1beta1.ObservabilityPlatformStatus)) error {
 	// Queue the update
+	sm.addPending(1)
 	select {
 	case sm.updateQueue <- statusUpdate{
 		platform: platform,
@@ -130,10 +148,69 @@ func (sm *StatusManager) UpdatePlatformStatus(ctx context.Context, platform *obs
 	}:
 		return nil
 	case <-ctx.Done():
+		sm.addPending(-1)
 		return ctx.Err()
 	}
 }
 
+// ApplyStatus applies updateFn to the status of platform, so the rest of the
+// reconcile sees it, and queues it to be written. Status set on platform
+// directly is never written: the queued updates are applied to the latest
+// platform from the API server. updateFn runs twice and must not share maps
+// or slices between the two statuses.
+func (sm *StatusManager) ApplyStatus(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, updateFn func(*observabilityv1beta1.ObservabilityPlatformStatus)) error {
+	updateFn(&platform.Status)
+	return sm.UpdatePlatformStatus(ctx, platform, updateFn)
+}
+
+// Flush waits until the queued status updates are written or the context
+// is done
+func (sm *StatusManager) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			// Wake the wait below, it checks the context
+			sm.pendingMu.Lock()
+			sm.pendingCond().Broadcast()
+			sm.pendingMu.Unlock()
+		case <-done:
+		}
+	}()
+
+	sm.pendingMu.Lock()
+	defer sm.pendingMu.Unlock()
+	for sm.pending > 0 {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("status updates not flushed: %w", err)
+		}
+		sm.pendingCond().Wait()
+	}
+	return nil
+}
+
+// addPending adds delta to the queued updates and wakes Flush when none are
+// left
+func (sm *StatusManager) addPending(delta int) {
+	sm.pendingMu.Lock()
+	defer sm.pendingMu.Unlock()
+	sm.pending += delta
+	if sm.pending <= 0 {
+		sm.pending = 0
+		sm.pendingCond().Broadcast()
+	}
+}
+
+// pendingCond returns the condition of the pending counter. pendingMu must be
+// held.
+func (sm *StatusManager) pendingCond() *sync.Cond {
+	if sm.flushed.L == nil {
+		sm.flushed.L = &sm.pendingMu
+	}
+	return &sm.flushed
+}
+
 // SetCondition sets a condition on the platform
 func (sm *StatusManager) SetCondition(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, condType string, status metav1.ConditionStatus, reason, message string) error {
 	return sm.UpdatePlatformStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
@@ -233,6 +310,18 @@ func (sm *StatusManager) CompleteOperation(ctx context.Context, platform *observ
 			FinishedAt: metav1.Now(),
 		}
 		
+		// Repeated outcomes of an operation, such as the periodic
+		// reconciliations of an unchanged platform, update the latest entry
+		// rather than filling the history
+		if len(status.OperationHistory) > 0 {
+			latest := &status.OperationHistory[0]
+			if latest.Operation == operation && latest.Success == success && latest.Message == message {
+				latest.Duration = result.Duration
+				latest.FinishedAt = result.FinishedAt
+				return
+			}
+		}
+
 		// Keep last 10 operations
 		status.OperationHistory = append([]observabilityv1beta1.OperationResult{result}, status.OperationHistory...)
 		if len(status.OperationHistory) > 10 {
@@ -365,40 +454,92 @@ func (sm *StatusManager) UpdateMetrics(ctx context.Context, platform *observabil
 	})
 }
 
-// processUpdates processes queued status updates
+// processUpdates processes queued status updates. The updates queued within
+// the batch window are grouped by platform and written at once.
 func (sm *StatusManager) processUpdates() {
 	for update := range sm.updateQueue {
-		sm.applyStatusUpdate(update)
+		for _, updates := range sm.collectBatch(update) {
+			sm.applyStatusUpdates(updates)
+			sm.addPending(-len(updates))
+		}
 	}
 }
 
-// applyStatusUpdate applies a single status update with retries
-func (sm *StatusManager) applyStatusUpdate(update statusUpdate) {
+// collectBatch collects the updates queued within the batch window after
+// first, grouped by platform in queue order
+func (sm *StatusManager) collectBatch(first statusUpdate) [][]statusUpdate {
+	var batches [][]statusUpdate
+	index := map[types.NamespacedName]int{}
+	add := func(update statusUpdate) {
+		key := types.NamespacedName{Name: update.platform.Name, Namespace: update.platform.Namespace}
+		i, ok := index[key]
+		if !ok {
+			i = len(batches)
+			index[key] = i
+			batches = append(batches, nil)
+		}
+		batches[i] = append(batches[i], update)
+	}
+	add(first)
+
+	if sm.batchWindow <= 0 {
+		return batches
+	}
+	timer := time.NewTimer(sm.batchWindow)
+	defer timer.Stop()
+	for {
+		select {
+		case update, ok := <-sm.updateQueue:
+			if !ok {
+				return batches
+			}
+			add(update)
+		case <-timer.C:
+			return batches
+		}
+	}
+}
+
+// applyStatusUpdates applies the updates of a platform with retries. The
+// status is only written if the updates change it.
+func (sm *StatusManager) applyStatusUpdates(updates []statusUpdate) {
+	last := updates[len(updates)-1]
 	maxRetries := 3
 	for i := 0; i < maxRetries; i++ {
 		// Get latest version
 		latest := &observabilityv1beta1.ObservabilityPlatform{}
 		key := types.NamespacedName{
-			Name:      update.platform.Name,
-			Namespace: update.platform.Namespace,
+			Name:      last.platform.Name,
+			Namespace: last.platform.Namespace,
 		}
 		
-		if err := sm.client.Get(update.ctx, key, latest); err != nil {
+		if err := sm.client.Get(last.ctx, key, latest); err != nil {
 			if !apierrors.IsNotFound(err) {
 				sm.log.Error(err, "Failed to get latest platform", "attempt", i+1)
 			}
 			return
 		}
 		
-		// Apply the update function
-		update.updateFn(&latest.Status)
+		// Apply the update functions
+		current := latest.Status.DeepCopy()
+		for _, update := range updates {
+			update.updateFn(&latest.Status)
+		}
 		
 		// Update observed generation
 		latest.Status.ObservedGeneration = latest.Generation
+		
+		// Skip the write if only timestamps changed
+		if statusEqual(current, &latest.Status) {
+			if sm.metrics != nil {
+				sm.metrics.RecordStatusUpdatesSuppressed(len(updates))
+			}
+			return
+		}
 		latest.Status.LastUpdated = metav1.Now()
 		
 		// Try to update
-		if err := sm.client.Status().Update(update.ctx, latest); err != nil {
+		if err := sm.client.Status().Update(last.ctx, latest); err != nil {
 			if apierrors.IsConflict(err) && i < maxRetries-1 {
 				// Retry on conflict
 				time.Sleep(time.Millisecond * 100 * time.Duration(i+1))
@@ -409,10 +550,37 @@ func (sm *StatusManager) applyStatusUpdate(update statusUpdate) {
 		}
 		
 		// Success
+		if sm.metrics != nil {
+			sm.metrics.RecordStatusWrite(len(updates))
+		}
 		return
 	}
 }
 
+// statusEqual reports whether two statuses only differ by the timestamps the
+// status updates refresh on every reconcile
+func statusEqual(a, b *observabilityv1beta1.ObservabilityPlatformStatus) bool {
+	return equality.Semantic.DeepEqual(withoutTimestamps(a), withoutTimestamps(b))
+}
+
+// withoutTimestamps returns a copy of status without the refreshed timestamps
+func withoutTimestamps(status *observabilityv1beta1.ObservabilityPlatformStatus) *observabilityv1beta1.ObservabilityPlatformStatus {
+	status = status.DeepCopy()
+	status.LastUpdated = metav1.Time{}
+	if status.Metrics != nil {
+		status.Metrics.LastReconcileTime = metav1.Time{}
+		status.Metrics.ReconciliationDuration = ""
+	}
+	if status.Progress != nil {
+		status.Progress.LastUpdated = metav1.Time{}
+	}
+	for i := range status.OperationHistory {
+		status.OperationHistory[i].Duration = ""
+		status.OperationHistory[i].FinishedAt = metav1.Time{}
+	}
+	return status
+}
+
 // GetEventSummary returns a summary of recent events
 func (sm *StatusManager) GetEventSummary() map[string]interface{} {
 	return sm.eventRecorder.GenerateEventSummary()
@@ -463,3 +463,80 @@ func TestFormatDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusEqualIgnoresTimestamps(t *testing.T) {
+	status := &observabilityv1beta1.ObservabilityPlatformStatus{
+		Phase:       PhaseReady,
+		LastUpdated: metav1.NewTime(time.Now().Add(-time.Hour)),
+		Metrics: &observabilityv1beta1.PlatformMetrics{
+			LastReconcileTime:      metav1.NewTime(time.Now().Add(-time.Hour)),
+			ReconciliationDuration: "1s",
+			HealthScore:            100,
+		},
+	}
+
+	refreshed := status.DeepCopy()
+	refreshed.LastUpdated = metav1.Now()
+	refreshed.Metrics.LastReconcileTime = metav1.Now()
+	refreshed.Metrics.ReconciliationDuration = "2s"
+	assert.True(t, statusEqual(status, refreshed))
+
+	refreshed.Phase = PhaseDegraded
+	assert.False(t, statusEqual(status, refreshed))
+}
+
+func TestCollectBatchGroupsUpdatesByPlatform(t *testing.T) {
+	sm := &StatusManager{updateQueue: make(chan statusUpdate, 10), batchWindow: 50 * time.Millisecond}
+	platform := func(name string) *observabilityv1beta1.ObservabilityPlatform {
+		return &observabilityv1beta1.ObservabilityPlatform{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "monitoring"}}
+	}
+	noop := func(*observabilityv1beta1.ObservabilityPlatformStatus) {}
+
+	sm.updateQueue <- statusUpdate{platform: platform("b"), updateFn: noop}
+	sm.updateQueue <- statusUpdate{platform: platform("a"), updateFn: noop}
+	sm.updateQueue <- statusUpdate{platform: platform("b"), updateFn: noop}
+
+	batches := sm.collectBatch(statusUpdate{platform: platform("a"), updateFn: noop})
+	require.Len(t, batches, 2)
+	assert.Equal(t, "a", batches[0][0].platform.Name)
+	assert.Len(t, batches[0], 2)
+	assert.Equal(t, "b", batches[1][0].platform.Name)
+	assert.Len(t, batches[1], 2)
+}
+
+func TestStatusWritesGoThroughUpdates(t *testing.T) {
+	s := scheme.Scheme
+	require.NoError(t, observabilityv1beta1.AddToScheme(s))
+
+	platform := &observabilityv1beta1.ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{Name: "direct-write", Namespace: "test-namespace", Generation: 1},
+	}
+	client := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(platform).
+		WithStatusSubresource(&observabilityv1beta1.ObservabilityPlatform{}).
+		Build()
+	sm := NewStatusManager(client, log.FromContext(context.Background()), NewEnhancedEventRecorder(record.NewFakeRecorder(100), "test"))
+	ctx := context.Background()
+	key := types.NamespacedName{Name: platform.Name, Namespace: platform.Namespace}
+
+	// A status field set directly is lost, even when other updates are written
+	platform.Status.ExternalLabels = map[string]string{"cluster": "prod"}
+	require.NoError(t, sm.SetCondition(ctx, platform, ConditionProgressing, metav1.ConditionTrue, ReasonReconciling, "Reconciling"))
+	require.NoError(t, sm.Flush(ctx))
+
+	written := &observabilityv1beta1.ObservabilityPlatform{}
+	require.NoError(t, client.Get(ctx, key, written))
+	assert.Len(t, written.Status.Conditions, 1)
+	assert.Empty(t, written.Status.ExternalLabels)
+
+	// ApplyStatus sets the in-memory status and writes it
+	require.NoError(t, sm.ApplyStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
+		status.ExternalLabels = map[string]string{"cluster": "prod-1"}
+	}))
+	assert.Equal(t, "prod-1", platform.Status.ExternalLabels["cluster"])
+	require.NoError(t, sm.Flush(ctx))
+
+	require.NoError(t, client.Get(ctx, key, written))
+	assert.Equal(t, map[string]string{"cluster": "prod-1"}, written.Status.ExternalLabels)
+}
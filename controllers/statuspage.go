@@ -0,0 +1,386 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/statuspage"
+)
+
+const (
+	statusPageComponent    = "status-page"
+	statusPageHistoryKey   = "history.json"
+	statusPageHTMLKey      = "index.html"
+	statusPageJSONKey      = "status.json"
+	statusPagePort         = 8080
+	defaultStatusPageTitle = "Observability Platform Status"
+	defaultStatusPageImage = "nginx:1.25-alpine"
+	defaultStatusPageDays  = 30
+	statusPageNginxConfig  = "default.conf"
+	statusPageHTMLRoot     = "/usr/share/nginx/html"
+)
+
+// reconcileStatusPage records component health into the uptime history and
+// renders the public status page. The history is persisted in the page's
+// ConfigMap so it survives operator restarts.
+func (r *ObservabilityPlatformReconciler) reconcileStatusPage(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx).WithValues("statusPage", "reconcile")
+
+	spec := platform.Spec.StatusPage
+	title := spec.Title
+	if title == "" {
+		title = defaultStatusPageTitle
+	}
+	historyDays := int(spec.HistoryDays)
+	if historyDays <= 0 {
+		historyDays = defaultStatusPageDays
+	}
+
+	name := statusPageName(platform)
+	path := statusPagePath(spec.Ingress)
+
+	// Load the existing history
+	existing := &corev1.ConfigMap{}
+	var historyData []byte
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: platform.Namespace}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get status page ConfigMap: %w", err)
+	}
+	if err == nil {
+		historyData = []byte(existing.Data[statusPageHistoryKey])
+	}
+
+	history, err := statuspage.ParseHistory(historyData)
+	if err != nil {
+		// A corrupt history should not block the page; start over
+		log.Error(err, "Failed to parse status page history, resetting")
+		history = &statuspage.History{}
+	}
+
+	current := make(map[string]bool, len(platform.Status.ComponentStatuses))
+	for component, status := range platform.Status.ComponentStatuses {
+		current[component] = status.Ready
+	}
+
+	now := time.Now()
+	history.Record(now, current, historyDays)
+
+	page := statuspage.BuildPage(title, platform.Name, history, current, historyDays, now)
+	html, err := page.RenderHTML()
+	if err != nil {
+		return err
+	}
+	pageJSON, err := page.RenderJSON()
+	if err != nil {
+		return fmt.Errorf("failed to render status page JSON: %w", err)
+	}
+	historyData, err = history.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to encode status page history: %w", err)
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: platform.Namespace},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Labels = statusPageLabels(platform)
+		configMap.Data = map[string]string{
+			statusPageHistoryKey:  string(historyData),
+			statusPageHTMLKey:     string(html),
+			statusPageJSONKey:     string(pageJSON),
+			statusPageNginxConfig: statusPageNginxServer(path),
+		}
+		return controllerutil.SetControllerReference(platform, configMap, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to create/update status page ConfigMap: %w", err)
+	}
+
+	if err := r.reconcileStatusPageDeployment(ctx, platform, spec, path); err != nil {
+		return err
+	}
+	if err := r.reconcileStatusPageService(ctx, platform); err != nil {
+		return err
+	}
+	ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: platform.Namespace}}
+	if spec.Ingress != nil {
+		if err := r.reconcileStatusPageIngress(ctx, platform, ingress, spec.Ingress, path); err != nil {
+			return err
+		}
+	} else if err := r.Delete(ctx, ingress); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete status page Ingress: %w", err)
+	}
+
+	log.V(1).Info("Status page reconciled", "status", page.Status)
+	return nil
+}
+
+// reconcileStatusPageDeployment serves the rendered page with a static web
+// server, under the path of the Ingress
+func (r *ObservabilityPlatformReconciler) reconcileStatusPageDeployment(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, spec *observabilityv1beta1.StatusPageSpec, path string) error {
+	image := spec.Image
+	if image == "" {
+		image = defaultStatusPageImage
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: statusPageName(platform), Namespace: platform.Namespace},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		replicas := int32(1)
+		readOnly := true
+		runAsNonRoot := true
+		runAsUser := int64(101)
+
+		deployment.Labels = statusPageLabels(platform)
+		deployment.Spec.Replicas = &replicas
+		deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: statusPageSelectorLabels(platform)}
+		deployment.Spec.Template.ObjectMeta.Labels = statusPageLabels(platform)
+		deployment.Spec.Template.Spec = corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot: &runAsNonRoot,
+				RunAsUser:    &runAsUser,
+			},
+			Containers: []corev1.Container{
+				{
+					Name:  "nginx",
+					Image: image,
+					Ports: []corev1.ContainerPort{
+						{Name: "http", ContainerPort: statusPagePort, Protocol: corev1.ProtocolTCP},
+					},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("10m"),
+							corev1.ResourceMemory: resource.MustParse("16Mi"),
+						},
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("100m"),
+							corev1.ResourceMemory: resource.MustParse("64Mi"),
+						},
+					},
+					SecurityContext: &corev1.SecurityContext{
+						ReadOnlyRootFilesystem: &readOnly,
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "page", MountPath: statusPageHTMLRoot, ReadOnly: true},
+						{Name: "nginx-config", MountPath: "/etc/nginx/conf.d", ReadOnly: true},
+						{Name: "cache", MountPath: "/var/cache/nginx"},
+						{Name: "run", MountPath: "/var/run"},
+					},
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{Path: statusPageLocation(path) + statusPageJSONKey, Port: intstr.FromInt(statusPagePort)},
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "page",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: statusPageName(platform)},
+							Items: []corev1.KeyToPath{
+								{Key: statusPageHTMLKey, Path: statusPageHTMLKey},
+								{Key: statusPageJSONKey, Path: statusPageJSONKey},
+							},
+						},
+					},
+				},
+				{
+					Name: "nginx-config",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: statusPageName(platform)},
+							Items: []corev1.KeyToPath{
+								{Key: statusPageNginxConfig, Path: statusPageNginxConfig},
+							},
+						},
+					},
+				},
+				{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+				{Name: "run", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			},
+		}
+		return controllerutil.SetControllerReference(platform, deployment, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create/update status page Deployment: %w", err)
+	}
+	return nil
+}
+
+// reconcileStatusPageService exposes the status page inside the cluster
+func (r *ObservabilityPlatformReconciler) reconcileStatusPageService(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: statusPageName(platform), Namespace: platform.Namespace},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		service.Labels = statusPageLabels(platform)
+		service.Spec.Type = corev1.ServiceTypeClusterIP
+		service.Spec.Selector = statusPageSelectorLabels(platform)
+		service.Spec.Ports = []corev1.ServicePort{
+			{
+				Name:       "http",
+				Port:       80,
+				TargetPort: intstr.FromInt(statusPagePort),
+				Protocol:   corev1.ProtocolTCP,
+			},
+		}
+		return controllerutil.SetControllerReference(platform, service, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create/update status page Service: %w", err)
+	}
+	return nil
+}
+
+// reconcileStatusPageIngress exposes the status page through the ingress controller
+func (r *ObservabilityPlatformReconciler) reconcileStatusPageIngress(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, ingress *networkingv1.Ingress, spec *observabilityv1beta1.StatusPageIngress, path string) error {
+	pathType := networkingv1.PathTypePrefix
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, ingress, func() error {
+		ingress.Labels = statusPageLabels(platform)
+		ingress.Annotations = make(map[string]string, len(spec.Annotations))
+		for k, v := range spec.Annotations {
+			ingress.Annotations[k] = v
+		}
+
+		ingress.Spec = networkingv1.IngressSpec{
+			IngressClassName: spec.ClassName,
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: spec.Host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     path,
+									PathType: &pathType,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: statusPageName(platform),
+											Port: networkingv1.ServiceBackendPort{Name: "http"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		if spec.TLSSecretName != "" {
+			ingress.Spec.TLS = []networkingv1.IngressTLS{
+				{Hosts: []string{spec.Host}, SecretName: spec.TLSSecretName},
+			}
+		}
+		return controllerutil.SetControllerReference(platform, ingress, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create/update status page Ingress: %w", err)
+	}
+	return nil
+}
+
+// cleanupStatusPage removes status page resources when the page is disabled
+func (r *ObservabilityPlatformReconciler) cleanupStatusPage(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	name := statusPageName(platform)
+	objects := []client.Object{
+		&networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: platform.Namespace}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: platform.Namespace}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: platform.Namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: platform.Namespace}},
+	}
+	for _, obj := range objects {
+		if err := r.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete status page %T: %w", obj, err)
+		}
+	}
+	return nil
+}
+
+// statusPagePath returns the path the status page is served under: the path
+// of the Ingress without a trailing slash, or "/"
+func statusPagePath(ingress *observabilityv1beta1.StatusPageIngress) string {
+	if ingress == nil {
+		return "/"
+	}
+	return "/" + strings.Trim(ingress.Path, "/")
+}
+
+// statusPageLocation returns the nginx location of the page, which ends with
+// a slash so the relative links of the page resolve below it
+func statusPageLocation(path string) string {
+	if path == "/" {
+		return path
+	}
+	return path + "/"
+}
+
+// statusPageNginxServer returns the nginx server block serving the page under
+// path. The Ingress passes the path on unchanged, so nginx serves the page
+// there rather than at its root; the path without the trailing slash
+// redirects to the page.
+func statusPageNginxServer(path string) string {
+	var sb strings.Builder
+	sb.WriteString("server {\n")
+	sb.WriteString(fmt.Sprintf("    listen %d;\n", statusPagePort))
+	sb.WriteString("    absolute_redirect off;\n")
+	if path != "/" {
+		sb.WriteString(fmt.Sprintf("    location = %s {\n", path))
+		sb.WriteString(fmt.Sprintf("        return 301 %s;\n", statusPageLocation(path)))
+		sb.WriteString("    }\n")
+	}
+	sb.WriteString(fmt.Sprintf("    location %s {\n", statusPageLocation(path)))
+	sb.WriteString(fmt.Sprintf("        alias %s/;\n", statusPageHTMLRoot))
+	sb.WriteString("        index index.html;\n")
+	sb.WriteString("        add_header Cache-Control \"no-cache\";\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// statusPageName returns the name of all status page resources
+func statusPageName(platform *observabilityv1beta1.ObservabilityPlatform) string {
+	return platform.Name + "-" + statusPageComponent
+}
+
+func statusPageLabels(platform *observabilityv1beta1.ObservabilityPlatform) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       statusPageComponent,
+		"app.kubernetes.io/instance":   platform.Name,
+		"app.kubernetes.io/managed-by": "gunj-operator",
+		"app.kubernetes.io/part-of":    "observability-platform",
+		"app.kubernetes.io/component":  statusPageComponent,
+		"observability.io/platform":    platform.Name,
+	}
+}
+
+func statusPageSelectorLabels(platform *observabilityv1beta1.ObservabilityPlatform) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      statusPageComponent,
+		"app.kubernetes.io/instance":  platform.Name,
+		"app.kubernetes.io/component": statusPageComponent,
+	}
+}
@@ -0,0 +1,441 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/storagemigration"
+	"github.com/gunjanjp/gunj-operator/internal/upgradehooks"
+)
+
+// migrateStorage migrates the volumes of a component to the StorageClass of
+// its storage spec when requested through the migrate-storage annotation. It
+// returns true while the migration holds the component:
+//  1. The component's StatefulSets are scaled down to zero
+//  2. A Job per claim copies its data into a claim in the new class
+//  3. The volumes in the new class are bound under the original claim names;
+//     the volumes in the previous class are retained
+//  4. The StatefulSets are deleted, keeping their claims, so the component is
+//     recreated with the new volume claim templates
+//
+// A failed copy holds the component; deleting the failed Job retries it. A
+// started migration runs to completion even if the annotation is removed.
+func (r *ObservabilityPlatformReconciler) migrateStorage(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, component string) (bool, error) {
+	log := log.FromContext(ctx).WithValues("storageMigration", component)
+
+	status, tracked := platform.Status.StorageMigrations[component]
+	if !tracked || status.Phase == storagemigration.PhaseCompleted {
+		started, err := r.startStorageMigration(ctx, platform, component)
+		if err != nil || started == nil {
+			return false, err
+		}
+		status = *started
+		log.Info("Started storage migration", "from", status.From, "to", status.To, "volumes", len(status.Volumes))
+		r.EventRecorder.RecordComponentEvent(platform, component, "StorageMigrationStarted",
+			fmt.Sprintf("Migrating %d volumes from StorageClass %q to %q", len(status.Volumes), status.From, status.To))
+	}
+
+	statefulSets, err := r.componentStatefulSets(ctx, platform, component)
+	if err != nil {
+		return true, err
+	}
+
+	switch status.Phase {
+	case storagemigration.PhaseScalingDown:
+		if r.scaleDownForMigration(ctx, statefulSets) {
+			status.Phase = storagemigration.PhaseCopying
+			status.Message = "Copying data to the new StorageClass"
+		} else {
+			status.Message = "Waiting for the pods to stop"
+		}
+	case storagemigration.PhaseCopying, storagemigration.PhaseFailed:
+		err = r.copyVolumes(ctx, platform, component, &status)
+	case storagemigration.PhaseSwapping:
+		err = r.swapVolumes(ctx, platform, statefulSets, &status)
+	}
+
+	if err == nil && status.Phase == storagemigration.PhaseSwapping && allVolumes(status.Volumes, storagemigration.VolumeSwapped) {
+		// Deleting the StatefulSets with orphaned dependents keeps the claims;
+		// the manager recreates them with the new volume claim templates
+		for i := range statefulSets {
+			if err = r.Delete(ctx, &statefulSets[i], client.PropagationPolicy(metav1.DeletePropagationOrphan)); client.IgnoreNotFound(err) != nil {
+				err = fmt.Errorf("failed to delete StatefulSet %s: %w", statefulSets[i].Name, err)
+				break
+			}
+			err = nil
+		}
+		if err == nil {
+			now := metav1.Now()
+			status.Phase = storagemigration.PhaseCompleted
+			status.CompletionTime = &now
+			status.Message = fmt.Sprintf("Volumes migrated to StorageClass %q; the volumes in %q are retained", status.To, status.From)
+			log.Info("Completed storage migration", "to", status.To)
+			r.EventRecorder.RecordComponentEvent(platform, component, "StorageMigrationCompleted", status.Message)
+		}
+	}
+
+	status.Progress = storagemigration.Progress(status.Phase, status.Volumes)
+	// Persisted, so the migration resumes from its phase after a requeue or
+	// restart
+	if statusErr := r.StatusManager.ApplyStatus(ctx, platform, func(platformStatus *observabilityv1beta1.ObservabilityPlatformStatus) {
+		if platformStatus.StorageMigrations == nil {
+			platformStatus.StorageMigrations = make(map[string]observabilityv1beta1.StorageMigrationStatus)
+		}
+		platformStatus.StorageMigrations[component] = *status.DeepCopy()
+	}); statusErr != nil && err == nil {
+		err = fmt.Errorf("failed to record storage migration: %w", statusErr)
+	}
+
+	return status.Phase != storagemigration.PhaseCompleted, err
+}
+
+// startStorageMigration returns a new migration of a component if one is
+// requested and some of its claims are not in the requested StorageClass
+func (r *ObservabilityPlatformReconciler) startStorageMigration(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, component string) (*observabilityv1beta1.StorageMigrationStatus, error) {
+	requested := false
+	for _, c := range storagemigration.Requested(platform.Annotations) {
+		requested = requested || c == component
+	}
+	target := componentStorageClass(platform, component)
+	if !requested || target == "" {
+		return nil, nil
+	}
+
+	claims, err := r.componentClaims(ctx, platform, component)
+	if err != nil {
+		return nil, err
+	}
+
+	var from string
+	var volumes []observabilityv1beta1.VolumeMigrationStatus
+	for i := range claims {
+		if class := storagemigration.StorageClass(&claims[i]); class != target {
+			from = class
+			volumes = append(volumes, observabilityv1beta1.VolumeMigrationStatus{Claim: claims[i].Name, Phase: storagemigration.VolumePending})
+		}
+	}
+	if len(volumes) == 0 {
+		return nil, nil
+	}
+
+	now := metav1.Now()
+	return &observabilityv1beta1.StorageMigrationStatus{
+		From:      from,
+		To:        target,
+		Phase:     storagemigration.PhaseScalingDown,
+		Volumes:   volumes,
+		Message:   "Scaling down to migrate the volumes",
+		StartTime: &now,
+	}, nil
+}
+
+// scaleDownForMigration scales the StatefulSets to zero and returns true once
+// all their pods are gone
+func (r *ObservabilityPlatformReconciler) scaleDownForMigration(ctx context.Context, statefulSets []appsv1.StatefulSet) bool {
+	stopped := true
+	for i := range statefulSets {
+		sts := &statefulSets[i]
+		if sts.Spec.Replicas == nil || *sts.Spec.Replicas != 0 {
+			patch := client.MergeFrom(sts.DeepCopy())
+			sts.Spec.Replicas = &[]int32{0}[0]
+			if err := r.Patch(ctx, sts, patch); err != nil {
+				log.FromContext(ctx).Error(err, "Failed to scale down StatefulSet for storage migration", "statefulSet", sts.Name)
+			}
+		}
+		if sts.Status.Replicas > 0 {
+			stopped = false
+		}
+	}
+	return stopped
+}
+
+// copyVolumes creates the target claim and the copy Job of every volume and
+// records the state of the Jobs
+func (r *ObservabilityPlatformReconciler) copyVolumes(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, component string, status *observabilityv1beta1.StorageMigrationStatus) error {
+	labels := r.commonLabels(platform)
+	labels["app.kubernetes.io/component"] = "storage-migration"
+
+	var failed []string
+	for i := range status.Volumes {
+		volume := &status.Volumes[i]
+		if volume.Phase == storagemigration.VolumeCopied {
+			continue
+		}
+
+		source := &corev1.PersistentVolumeClaim{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: platform.Namespace, Name: volume.Claim}, source); err != nil {
+			return fmt.Errorf("failed to get claim %s: %w", volume.Claim, err)
+		}
+		target := storagemigration.BuildTargetClaim(source, status.To, labels)
+		if err := r.Create(ctx, target); err != nil && !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create claim %s: %w", target.Name, err)
+		}
+
+		job := storagemigration.BuildCopyJob(volume.Claim, platform.Namespace, storagemigration.DefaultImage, labels)
+		volume.Job = job.Name
+		existing := &batchv1.Job{}
+		err := r.Get(ctx, client.ObjectKeyFromObject(job), existing)
+		if errors.IsNotFound(err) {
+			if err := controllerutil.SetControllerReference(platform, job, r.Scheme); err != nil {
+				return fmt.Errorf("failed to set owner reference on copy job: %w", err)
+			}
+			if err := r.Create(ctx, job); err != nil {
+				return fmt.Errorf("failed to create copy job %s: %w", job.Name, err)
+			}
+			volume.Phase = storagemigration.VolumeCopying
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get copy job %s: %w", job.Name, err)
+		}
+
+		switch upgradehooks.JobState(existing) {
+		case upgradehooks.StateSucceeded:
+			volume.Phase = storagemigration.VolumeCopied
+		case upgradehooks.StateFailed:
+			volume.Phase = storagemigration.VolumeFailed
+			failed = append(failed, job.Name)
+		default:
+			volume.Phase = storagemigration.VolumeCopying
+		}
+	}
+
+	switch {
+	case len(failed) > 0:
+		if status.Phase != storagemigration.PhaseFailed {
+			r.EventRecorder.RecordComponentEvent(platform, component, "StorageMigrationFailed",
+				fmt.Sprintf("Copying volumes failed, see jobs %s", strings.Join(failed, ", ")))
+		}
+		status.Phase = storagemigration.PhaseFailed
+		status.Message = fmt.Sprintf("Copy jobs %s failed; delete them to retry", strings.Join(failed, ", "))
+	case allVolumes(status.Volumes, storagemigration.VolumeCopied):
+		status.Phase = storagemigration.PhaseSwapping
+		status.Message = "Binding the copied volumes to the claims"
+	default:
+		status.Phase = storagemigration.PhaseCopying
+		status.Message = "Copying data to the new StorageClass"
+	}
+	return nil
+}
+
+// swapVolumes binds the volumes the data was copied into to the original
+// claim names. Each step is checked against the cluster, so an interrupted
+// swap resumes where it stopped.
+func (r *ObservabilityPlatformReconciler) swapVolumes(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, statefulSets []appsv1.StatefulSet, status *observabilityv1beta1.StorageMigrationStatus) error {
+	for i := range status.Volumes {
+		volume := &status.Volumes[i]
+		if volume.Phase == storagemigration.VolumeSwapped {
+			continue
+		}
+		if err := r.swapVolume(ctx, platform.Namespace, status.To, statefulSets, volume); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ObservabilityPlatformReconciler) swapVolume(ctx context.Context, namespace, storageClass string, statefulSets []appsv1.StatefulSet, volume *observabilityv1beta1.VolumeMigrationStatus) error {
+	source := &corev1.PersistentVolumeClaim{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: volume.Claim}, source)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get claim %s: %w", volume.Claim, err)
+	}
+	targetKey := client.ObjectKey{Namespace: namespace, Name: storagemigration.TargetClaimName(volume.Claim)}
+
+	switch {
+	case err == nil && storagemigration.StorageClass(source) != storageClass:
+		// Retain both volumes before their claims are deleted
+		target := &corev1.PersistentVolumeClaim{}
+		if err := r.Get(ctx, targetKey, target); err != nil {
+			return fmt.Errorf("failed to get claim %s: %w", targetKey.Name, err)
+		}
+		if target.Spec.VolumeName == "" {
+			return fmt.Errorf("claim %s is not bound", target.Name)
+		}
+		volume.Volume = target.Spec.VolumeName
+		if err := r.retainVolume(ctx, target.Spec.VolumeName); err != nil {
+			return err
+		}
+		if source.Spec.VolumeName != "" {
+			volume.RetainedVolume = source.Spec.VolumeName
+			if err := r.retainVolume(ctx, source.Spec.VolumeName); err != nil {
+				return err
+			}
+		}
+		for _, claim := range []*corev1.PersistentVolumeClaim{source, target} {
+			if err := r.Delete(ctx, claim); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete claim %s: %w", claim.Name, err)
+			}
+		}
+		return nil
+
+	case errors.IsNotFound(err):
+		// Wait for the claim of the copied volume to be gone before binding
+		// the volume to the original claim name
+		if err := r.Get(ctx, targetKey, &corev1.PersistentVolumeClaim{}); !errors.IsNotFound(err) {
+			return client.IgnoreNotFound(err)
+		}
+		pv := &corev1.PersistentVolume{}
+		if err := r.Get(ctx, client.ObjectKey{Name: volume.Volume}, pv); err != nil {
+			return fmt.Errorf("failed to get volume %s: %w", volume.Volume, err)
+		}
+		if pv.Spec.ClaimRef == nil || pv.Spec.ClaimRef.Name != volume.Claim {
+			pv.Spec.ClaimRef = &corev1.ObjectReference{Kind: "PersistentVolumeClaim", APIVersion: "v1", Namespace: namespace, Name: volume.Claim}
+			if err := r.Update(ctx, pv); err != nil {
+				return fmt.Errorf("failed to reserve volume %s: %w", pv.Name, err)
+			}
+		}
+		claim := storagemigration.BuildSwappedClaim(volume.Claim, namespace, storagemigration.TemplateLabels(statefulSets, volume.Claim), pv, storageClass)
+		if err := r.Create(ctx, claim); err != nil && !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create claim %s: %w", claim.Name, err)
+		}
+		return nil
+
+	default:
+		// The claim is in the new class; once bound, the volume gets back its
+		// reclaim policy
+		if source.Status.Phase != corev1.ClaimBound {
+			return nil
+		}
+		if err := r.restoreReclaimPolicy(ctx, source.Spec.VolumeName); err != nil {
+			return err
+		}
+		volume.Phase = storagemigration.VolumeSwapped
+		return nil
+	}
+}
+
+// retainVolume sets the reclaim policy of a volume to Retain and records the
+// previous policy
+func (r *ObservabilityPlatformReconciler) retainVolume(ctx context.Context, name string) error {
+	pv := &corev1.PersistentVolume{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, pv); err != nil {
+		return fmt.Errorf("failed to get volume %s: %w", name, err)
+	}
+	if pv.Spec.PersistentVolumeReclaimPolicy == corev1.PersistentVolumeReclaimRetain {
+		return nil
+	}
+	if pv.Annotations == nil {
+		pv.Annotations = make(map[string]string)
+	}
+	pv.Annotations[storagemigration.ReclaimPolicyAnnotation] = string(pv.Spec.PersistentVolumeReclaimPolicy)
+	pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimRetain
+	if err := r.Update(ctx, pv); err != nil {
+		return fmt.Errorf("failed to retain volume %s: %w", name, err)
+	}
+	return nil
+}
+
+// restoreReclaimPolicy restores the reclaim policy retainVolume recorded
+func (r *ObservabilityPlatformReconciler) restoreReclaimPolicy(ctx context.Context, name string) error {
+	pv := &corev1.PersistentVolume{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, pv); err != nil {
+		return fmt.Errorf("failed to get volume %s: %w", name, err)
+	}
+	policy, ok := pv.Annotations[storagemigration.ReclaimPolicyAnnotation]
+	if !ok {
+		return nil
+	}
+	delete(pv.Annotations, storagemigration.ReclaimPolicyAnnotation)
+	pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimPolicy(policy)
+	if err := r.Update(ctx, pv); err != nil {
+		return fmt.Errorf("failed to restore reclaim policy of volume %s: %w", name, err)
+	}
+	return nil
+}
+
+// componentStatefulSets returns the StatefulSets of a component
+func (r *ObservabilityPlatformReconciler) componentStatefulSets(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, component string) ([]appsv1.StatefulSet, error) {
+	list := &appsv1.StatefulSetList{}
+	if err := r.List(ctx, list, client.InNamespace(platform.Namespace), client.MatchingLabels{
+		"app.kubernetes.io/name":     component,
+		"app.kubernetes.io/instance": platform.Name,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list %s StatefulSets: %w", component, err)
+	}
+	return list.Items, nil
+}
+
+// componentClaims returns the PersistentVolumeClaims of the replicas of a
+// component's StatefulSets
+func (r *ObservabilityPlatformReconciler) componentClaims(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, component string) ([]corev1.PersistentVolumeClaim, error) {
+	statefulSets, err := r.componentStatefulSets(ctx, platform, component)
+	if err != nil {
+		return nil, err
+	}
+	return statefulSetClaims(ctx, r.Client, platform.Namespace, statefulSets)
+}
+
+// statefulSetClaims returns the PersistentVolumeClaims of the replicas of
+// StatefulSets which exist
+func statefulSetClaims(ctx context.Context, c client.Client, namespace string, statefulSets []appsv1.StatefulSet) ([]corev1.PersistentVolumeClaim, error) {
+	var claims []corev1.PersistentVolumeClaim
+	for _, sts := range statefulSets {
+		replicas := int32(1)
+		if sts.Spec.Replicas != nil {
+			replicas = *sts.Spec.Replicas
+		}
+		for _, name := range storagemigration.ClaimNames(sts.Spec.VolumeClaimTemplates, sts.Name, replicas) {
+			pvc := &corev1.PersistentVolumeClaim{}
+			if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, pvc); err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				return nil, fmt.Errorf("failed to get claim %s: %w", name, err)
+			}
+			claims = append(claims, *pvc)
+		}
+	}
+	return claims, nil
+}
+
+// componentStorageClass returns the StorageClass in the storage spec of a
+// component
+func componentStorageClass(platform *observabilityv1beta1.ObservabilityPlatform, component string) string {
+	components := platform.Spec.Components
+	if components == nil {
+		return ""
+	}
+	switch component {
+	case "prometheus":
+		if components.Prometheus != nil && components.Prometheus.Storage != nil {
+			return components.Prometheus.Storage.StorageClassName
+		}
+	case "loki":
+		if components.Loki != nil && components.Loki.Storage != nil {
+			return components.Loki.Storage.StorageClassName
+		}
+	case "tempo":
+		if components.Tempo != nil && components.Tempo.Storage != nil {
+			return components.Tempo.Storage.StorageClassName
+		}
+	}
+	return ""
+}
+
+// allVolumes returns true if all volumes are in a phase
+func allVolumes(volumes []observabilityv1beta1.VolumeMigrationStatus, phase string) bool {
+	for _, v := range volumes {
+		if v.Phase != phase {
+			return false
+		}
+	}
+	return true
+}
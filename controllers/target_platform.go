@@ -0,0 +1,43 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// checkTargetPlatform checks the target platform of a component
+// configuration and records the result in its TargetPlatform condition. It
+// returns a *TargetPlatformError if the platform does not exist or does not
+// enable the component, and other errors if the platform cannot be read.
+func checkTargetPlatform(ctx context.Context, c client.Reader, conditions *[]metav1.Condition, generation int64, namespace, name, component string) error {
+	err := observabilityv1beta1.CheckTargetPlatform(ctx, c, namespace, name, component)
+	condition := metav1.Condition{
+		Type:               observabilityv1beta1.ConditionTargetPlatform,
+		Status:             metav1.ConditionTrue,
+		Reason:             observabilityv1beta1.ReasonTargetReady,
+		Message:            fmt.Sprintf("Target platform %s enables %s", name, component),
+		ObservedGeneration: generation,
+	}
+
+	if targetErr, ok := err.(*observabilityv1beta1.TargetPlatformError); ok {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = targetErr.Reason
+		condition.Message = targetErr.Error()
+	} else if err != nil {
+		return err
+	}
+	meta.SetStatusCondition(conditions, condition)
+	return err
+}
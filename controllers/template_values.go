@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/templatevars"
+)
+
+// applyTemplateValues renders the template variables of the external
+// labels, ingress hosts and remote-write URLs of a platform with the
+// operator's values. Like the merge onto base platforms, the rendered spec
+// is never written back, so the stored spec keeps its templates.
+func (r *ObservabilityPlatformReconciler) applyTemplateValues(platform *observabilityv1beta1.ObservabilityPlatform) error {
+	values := r.TemplateValues
+	spec := &platform.Spec
+
+	if spec.Global != nil {
+		labels, err := values.RenderMap(spec.Global.ExternalLabels)
+		if err != nil {
+			return fmt.Errorf("spec.global.externalLabels: %w", err)
+		}
+		spec.Global.ExternalLabels = labels
+	}
+
+	if spec.IngestGateway != nil {
+		if err := renderIngressHost(values, spec.IngestGateway.Ingress, "spec.ingestGateway.ingress.host"); err != nil {
+			return err
+		}
+	}
+
+	if spec.Components == nil {
+		return nil
+	}
+
+	if prom := spec.Components.Prometheus; prom != nil {
+		labels, err := values.RenderMap(prom.ExternalLabels)
+		if err != nil {
+			return fmt.Errorf("spec.components.prometheus.externalLabels: %w", err)
+		}
+		prom.ExternalLabels = labels
+
+		for i := range prom.RemoteWrite {
+			url, err := values.Render(prom.RemoteWrite[i].URL)
+			if err != nil {
+				return fmt.Errorf("spec.components.prometheus.remoteWrite[%d].url: %w", i, err)
+			}
+			prom.RemoteWrite[i].URL = url
+		}
+	}
+
+	if grafana := spec.Components.Grafana; grafana != nil {
+		if err := renderIngressHost(values, grafana.Ingress, "spec.components.grafana.ingress.host"); err != nil {
+			return err
+		}
+	}
+
+	if tempo := spec.Components.Tempo; tempo != nil && tempo.Receivers != nil {
+		receivers := tempo.Receivers
+		for field, receiver := range map[string]*observabilityv1beta1.TraceReceiverSpec{
+			"otlpGrpc":            receivers.OTLPGRPC,
+			"otlpHttp":            receivers.OTLPHTTP,
+			"jaegerGrpc":          receivers.JaegerGRPC,
+			"jaegerThriftHttp":    receivers.JaegerThriftHTTP,
+			"jaegerThriftCompact": receivers.JaegerThriftCompact,
+			"jaegerThriftBinary":  receivers.JaegerThriftBinary,
+			"zipkin":              receivers.Zipkin,
+		} {
+			if receiver == nil {
+				continue
+			}
+			if err := renderIngressHost(values, receiver.Ingress, "spec.components.tempo.receivers."+field+".ingress.host"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// renderIngressHost renders the template variables of an ingress host
+func renderIngressHost(values templatevars.Values, ingress *observabilityv1beta1.IngressSpec, path string) error {
+	if ingress == nil {
+		return nil
+	}
+	host, err := values.Render(ingress.Host)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	ingress.Host = host
+	return nil
+}
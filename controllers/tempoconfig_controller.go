@@ -0,0 +1,415 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/temposync"
+)
+
+// tempoConfigFinalizer resets the overrides of a deleted TempoConfig
+const tempoConfigFinalizer = "tempoconfig.observability.io/finalizer"
+
+// TempoConfigReconciler applies the per-tenant overrides of TempoConfigs to
+// the runtime overrides file of their target platform's Tempo, and records
+// every step of the sync in status.syncStatus so users can see why a
+// configuration is not applied yet.
+type TempoConfigReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+}
+
+// +kubebuilder:rbac:groups=observability.io,resources=tempoconfigs,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=observability.io,resources=tempoconfigs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=observability.io,resources=tempoconfigs/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;update;patch
+
+// Reconcile moves the sync of a TempoConfig one step further
+func (r *TempoConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("tempoconfig", req.NamespacedName)
+
+	config := &observabilityv1beta1.TempoConfig{}
+	if err := r.Get(ctx, req.NamespacedName, config); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !config.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.finalize(ctx, config)
+	}
+	if !controllerutil.ContainsFinalizer(config, tempoConfigFinalizer) {
+		controllerutil.AddFinalizer(config, tempoConfigFinalizer)
+		if err := r.Update(ctx, config); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
+		}
+	}
+
+	now := time.Now()
+	previous := config.Status
+	previous.Conditions = append([]metav1.Condition(nil), config.Status.Conditions...)
+	var previousSync observabilityv1beta1.TempoConfigSyncStatus
+	if config.Status.SyncStatus != nil {
+		previousSync = *config.Status.SyncStatus
+	}
+	syncStatus := previousSync
+	result, err := r.sync(ctx, config, &syncStatus, now)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	config.Status.SyncStatus = &syncStatus
+	config.Status.Phase = temposync.Phase(&syncStatus)
+	config.Status.Message = syncStatus.Message
+	config.Status.ObservedGeneration = config.Generation
+	config.Status.AppliedDefaults = observabilityv1beta1.AppliedDefaults(config)
+	condition := metav1.Condition{
+		Type:               ConditionSynced,
+		Status:             metav1.ConditionFalse,
+		Reason:             syncStatus.Reason,
+		Message:            syncStatus.Message,
+		ObservedGeneration: config.Generation,
+	}
+	if syncStatus.State == observabilityv1beta1.TempoConfigSyncApplied {
+		condition.Status = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(&config.Status.Conditions, condition)
+	meta.SetStatusCondition(&config.Status.Conditions, conflictCondition(config))
+
+	if !equality.Semantic.DeepEqual(previous, config.Status) {
+		if err := r.Status().Update(ctx, config); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update TempoConfig status: %w", err)
+		}
+		if syncStatus.State != previousSync.State {
+			log.Info("TempoConfig sync state changed", "state", syncStatus.State, "reason", syncStatus.Reason, "retries", syncStatus.Retries)
+		}
+	}
+	return result, nil
+}
+
+// sync advances the state of the sync and returns when to look again
+func (r *TempoConfigReconciler) sync(ctx context.Context, config *observabilityv1beta1.TempoConfig, sync *observabilityv1beta1.TempoConfigSyncStatus, now time.Time) (ctrl.Result, error) {
+	// Only the configurations merged into the overrides file have conflicts
+	config.Status.Conflicts = nil
+	if config.Spec.Paused {
+		temposync.Pending(sync, temposync.ReasonPaused, "The configuration is paused", now)
+		return ctrl.Result{}, nil
+	}
+
+	// Configurations which cannot be rendered are not retried until they change
+	own, err := temposync.RenderSections(config.Spec.Overrides)
+	var data string
+	if err == nil {
+		data, err = temposync.Render(own)
+	}
+	if err != nil {
+		temposync.Observe(sync, "", now)
+		config.Status.ValidationErrors = []string{err.Error()}
+		temposync.Fail(sync, temposync.ReasonInvalid, err, false, now)
+		return ctrl.Result{}, nil
+	}
+	config.Status.ValidationErrors = nil
+	hash := temposync.Hash(data)
+	temposync.Observe(sync, hash, now)
+
+	platform := config.Spec.TargetPlatform.Name
+	if err := checkTargetPlatform(ctx, r.Client, &config.Status.Conditions, config.Generation, config.Namespace, platform, "tempo"); err != nil {
+		targetErr, ok := err.(*observabilityv1beta1.TargetPlatformError)
+		if !ok {
+			return ctrl.Result{}, err
+		}
+		return retryAfter(temposync.Fail(sync, targetErr.Reason, targetErr, true, now)), nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	cmKey := client.ObjectKey{Namespace: config.Namespace, Name: temposync.OverridesConfigMapName(platform)}
+	if err := r.Get(ctx, cmKey, cm); err != nil {
+		if !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		// The platform controller creates it with the rest of Tempo
+		temposync.Pending(sync, temposync.ReasonWaitingForTempo,
+			fmt.Sprintf("Waiting for the Tempo of platform %s to be deployed", platform), now)
+		return ctrl.Result{RequeueAfter: temposync.BaseBackoff}, nil
+	}
+
+	// The overrides of every TempoConfig targeting the platform are merged,
+	// so they all render the same file instead of overwriting each other
+	sources, err := r.overrideSources(ctx, config.Namespace, platform, config.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	sources = append(sources, temposync.Source{Name: config.Name, Created: config.CreationTimestamp.Time, Sections: own})
+	sections, conflicts := temposync.Merge(sources)
+	config.Status.Conflicts = conflicts[config.Name]
+	if data, err = temposync.Render(sections); err != nil {
+		return retryAfter(temposync.Fail(sync, temposync.ReasonApplyFailed, err, true, now)), nil
+	}
+	fileHash := temposync.Hash(data)
+	owners := sourceNames(sources)
+
+	// Hash comparison: the overrides are written once, then only if they
+	// drifted from the specs
+	if cm.Annotations[temposync.OwnerAnnotation] == owners && cm.Annotations[temposync.HashAnnotation] == fileHash && cm.Data[temposync.OverridesKey] == data {
+		switch sync.State {
+		case observabilityv1beta1.TempoConfigSyncApplied:
+			return ctrl.Result{}, nil
+		case observabilityv1beta1.TempoConfigSyncApplying:
+			if reloaded, wait := temposync.Reloaded(sync, now); !reloaded {
+				return ctrl.Result{RequeueAfter: wait}, nil
+			}
+			temposync.Applied(sync, now)
+			applied := metav1.NewTime(now)
+			config.Status.LastAppliedTime = &applied
+			config.Status.LastAppliedHash = fileHash
+			return ctrl.Result{}, nil
+		default:
+			// Written by an earlier attempt whose status was not recorded, or
+			// from another TempoConfig of the platform
+			temposync.Applying(sync, now)
+			return ctrl.Result{RequeueAfter: temposync.ReloadPeriod}, nil
+		}
+	}
+
+	if cm.Annotations == nil {
+		cm.Annotations = map[string]string{}
+	}
+	cm.Annotations[temposync.OwnerAnnotation] = owners
+	cm.Annotations[temposync.HashAnnotation] = fileHash
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[temposync.OverridesKey] = data
+	if err := r.Update(ctx, cm); err != nil {
+		if errors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return retryAfter(temposync.Fail(sync, temposync.ReasonApplyFailed,
+			fmt.Errorf("failed to write the overrides of platform %s: %w", platform, err), true, now)), nil
+	}
+	temposync.Applying(sync, now)
+	return ctrl.Result{RequeueAfter: temposync.ReloadPeriod}, nil
+}
+
+// finalize removes the overrides of a deleted TempoConfig from the overrides
+// file, which keeps those of the other TempoConfigs of the platform
+func (r *TempoConfigReconciler) finalize(ctx context.Context, config *observabilityv1beta1.TempoConfig) error {
+	if !controllerutil.ContainsFinalizer(config, tempoConfigFinalizer) {
+		return nil
+	}
+
+	platform := config.Spec.TargetPlatform.Name
+	cm := &corev1.ConfigMap{}
+	cmKey := client.ObjectKey{Namespace: config.Namespace, Name: temposync.OverridesConfigMapName(platform)}
+	if err := r.Get(ctx, cmKey, cm); client.IgnoreNotFound(err) != nil {
+		return err
+	} else if err == nil && containsName(cm.Annotations[temposync.OwnerAnnotation], config.Name) {
+		sources, err := r.overrideSources(ctx, config.Namespace, platform, config.Name)
+		if err != nil {
+			return err
+		}
+		sections, _ := temposync.Merge(sources)
+		data, err := temposync.Render(sections)
+		if err != nil {
+			return err
+		}
+		if len(sources) == 0 {
+			delete(cm.Annotations, temposync.OwnerAnnotation)
+			delete(cm.Annotations, temposync.HashAnnotation)
+		} else {
+			cm.Annotations[temposync.OwnerAnnotation] = sourceNames(sources)
+			cm.Annotations[temposync.HashAnnotation] = temposync.Hash(data)
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[temposync.OverridesKey] = data
+		if err := r.Update(ctx, cm); err != nil {
+			return fmt.Errorf("failed to remove the overrides of TempoConfig %s from platform %s: %w", config.Name, platform, err)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(config, tempoConfigFinalizer)
+	return r.Update(ctx, config)
+}
+
+// overrideSources returns the TempoConfigs merged into the overrides file of
+// a platform: those targeting it which are not paused, deleted or invalid,
+// except the excluded one
+func (r *TempoConfigReconciler) overrideSources(ctx context.Context, namespace, platform, exclude string) ([]temposync.Source, error) {
+	configs := &observabilityv1beta1.TempoConfigList{}
+	if err := r.List(ctx, configs, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list the TempoConfigs of platform %s: %w", platform, err)
+	}
+	var sources []temposync.Source
+	for _, other := range configs.Items {
+		if other.Name == exclude || other.Spec.TargetPlatform.Name != platform || other.Spec.Paused || !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+		sections, err := temposync.RenderSections(other.Spec.Overrides)
+		if err != nil {
+			continue
+		}
+		sources = append(sources, temposync.Source{Name: other.Name, Created: other.CreationTimestamp.Time, Sections: sections})
+	}
+	return sources, nil
+}
+
+// sourceNames returns the names of the TempoConfigs merged into an overrides
+// file, for its OwnerAnnotation
+func sourceNames(sources []temposync.Source) string {
+	names := make([]string, 0, len(sources))
+	for _, source := range sources {
+		names = append(names, source.Name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// containsName returns true if a list of names separated by commas contains
+// the name
+func containsName(names, name string) bool {
+	for _, n := range strings.Split(names, ",") {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// conflictCondition reports the sections of the overrides which other
+// TempoConfigs of the platform set differently
+func conflictCondition(config *observabilityv1beta1.TempoConfig) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               temposync.ConditionConflict,
+		Status:             metav1.ConditionFalse,
+		Reason:             temposync.ReasonNoConflicts,
+		ObservedGeneration: config.Generation,
+	}
+	if len(config.Status.Conflicts) == 0 {
+		return condition
+	}
+
+	var applied, overridden []string
+	for _, conflict := range config.Status.Conflicts {
+		description := fmt.Sprintf("%q with TempoConfig %s", conflict.Section, conflict.TempoConfig)
+		if conflict.Applied {
+			applied = append(applied, description)
+		} else {
+			overridden = append(overridden, description)
+		}
+	}
+	var messages []string
+	if len(overridden) > 0 {
+		messages = append(messages, "Not applied, set first by an older TempoConfig: "+strings.Join(overridden, ", "))
+	}
+	if len(applied) > 0 {
+		messages = append(messages, "Applied from this TempoConfig, set differently by: "+strings.Join(applied, ", "))
+	}
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = temposync.ReasonOverlappingSections
+	condition.Message = strings.Join(messages, ". ")
+	return condition
+}
+
+// retryAfter returns the result retrying a failed attempt after the delay
+func retryAfter(delay time.Duration) ctrl.Result {
+	return ctrl.Result{RequeueAfter: delay}
+}
+
+// findTempoConfigsForPlatform maps a platform to the TempoConfigs targeting
+// it, so a sync waiting for the platform resumes without its backoff
+func (r *TempoConfigReconciler) findTempoConfigsForPlatform(obj client.Object) []reconcile.Request {
+	configs := &observabilityv1beta1.TempoConfigList{}
+	if err := r.List(context.Background(), configs, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, config := range configs.Items {
+		if config.Spec.TargetPlatform.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&config)})
+		}
+	}
+	return requests
+}
+
+// findTempoConfigsForSibling maps a TempoConfig to the other TempoConfigs
+// targeting its platform, as their merged overrides and conflicts change
+func (r *TempoConfigReconciler) findTempoConfigsForSibling(obj client.Object) []reconcile.Request {
+	sibling, ok := obj.(*observabilityv1beta1.TempoConfig)
+	if !ok {
+		return nil
+	}
+	configs := &observabilityv1beta1.TempoConfigList{}
+	if err := r.List(context.Background(), configs, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, config := range configs.Items {
+		if config.Name != sibling.Name && config.Spec.TargetPlatform.Name == sibling.Spec.TargetPlatform.Name {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&config)})
+		}
+	}
+	return requests
+}
+
+// findTempoConfigForOverrides maps an overrides ConfigMap to the TempoConfigs
+// applied to it, so edits of the file are reverted
+func (r *TempoConfigReconciler) findTempoConfigForOverrides(obj client.Object) []reconcile.Request {
+	owners := obj.GetAnnotations()[temposync.OwnerAnnotation]
+	if owners == "" {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, owner := range strings.Split(owners, ",") {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Namespace: obj.GetNamespace(), Name: owner}})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager. Status updates
+// don't trigger reconciles, so a failed attempt is only retried after its
+// backoff.
+func (r *TempoConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&observabilityv1beta1.TempoConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&source.Kind{Type: &observabilityv1beta1.TempoConfig{}},
+			handler.EnqueueRequestsFromMapFunc(r.findTempoConfigsForSibling),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&source.Kind{Type: &observabilityv1beta1.ObservabilityPlatform{}},
+			handler.EnqueueRequestsFromMapFunc(r.findTempoConfigsForPlatform),
+		).
+		Watches(
+			&source.Kind{Type: &corev1.ConfigMap{}},
+			handler.EnqueueRequestsFromMapFunc(r.findTempoConfigForOverrides),
+		).
+		Complete(r)
+}
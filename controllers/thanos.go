@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/thanos"
+)
+
+// reconcileThanos deploys the Thanos compactor applying the downsampling and
+// retention policies of the platform's Prometheus, and removes it when Thanos
+// is disabled
+func (r *ObservabilityPlatformReconciler) reconcileThanos(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx).WithValues("thanos", "reconcile")
+
+	if platform.Spec.Components == nil || platform.Spec.Components.Prometheus == nil ||
+		!platform.Spec.Components.Prometheus.Enabled || !platform.Spec.Components.Prometheus.Thanos.IsEnabled() {
+		return r.deleteThanosCompactor(ctx, platform)
+	}
+	spec := platform.Spec.Components.Prometheus.Thanos
+	retention := spec.Compactor.GetRetention()
+
+	compactor := thanos.Compactor{
+		Name:                thanos.CompactorName(platform.Name),
+		Namespace:           platform.Namespace,
+		Image:               spec.Image,
+		Version:             spec.GetVersion(),
+		ObjectStorageConfig: spec.ObjectStorageConfig,
+		Downsampling:        spec.Compactor.IsDownsamplingEnabled(),
+		Retention: thanos.Retention{
+			Raw:         retention.Raw,
+			FiveMinutes: retention.FiveMinutes,
+			OneHour:     retention.OneHour,
+		},
+		Labels: map[string]string{
+			"app.kubernetes.io/name":       "thanos-compactor",
+			"app.kubernetes.io/instance":   platform.Name,
+			"app.kubernetes.io/managed-by": "gunj-operator",
+			"app.kubernetes.io/part-of":    "observability-platform",
+			"observability.io/platform":    platform.Name,
+		},
+	}
+	if spec.Compactor != nil {
+		compactor.Storage = spec.Compactor.Storage
+		compactor.StorageClassName = spec.Compactor.StorageClassName
+	}
+
+	desired := thanos.BuildCompactor(compactor)
+	sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, sts, func() error {
+		sts.Labels = desired.Labels
+		// The selector, service name and volume claims are immutable, so only
+		// set them on creation
+		if sts.CreationTimestamp.IsZero() {
+			sts.Spec.Selector = desired.Spec.Selector
+			sts.Spec.ServiceName = desired.Spec.ServiceName
+			sts.Spec.VolumeClaimTemplates = desired.Spec.VolumeClaimTemplates
+		}
+		sts.Spec.Replicas = desired.Spec.Replicas
+		sts.Spec.Template = desired.Spec.Template
+		return controllerutil.SetControllerReference(platform, sts, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile Thanos compactor: %w", err)
+	}
+
+	log.V(1).Info("Thanos compactor reconciled", "downsampling", compactor.Downsampling,
+		"raw", retention.Raw, "5m", retention.FiveMinutes, "1h", retention.OneHour)
+	return nil
+}
+
+// deleteThanosCompactor removes the Thanos compactor of a platform
+func (r *ObservabilityPlatformReconciler) deleteThanosCompactor(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: thanos.CompactorName(platform.Name), Namespace: platform.Namespace}}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(sts), sts); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get Thanos compactor: %w", err)
+	}
+	if err := r.Delete(ctx, sts); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete Thanos compactor: %w", err)
+	}
+	return nil
+}
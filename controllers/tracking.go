@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/tracking"
+)
+
+// trackedKinds are the kinds of the children ownerReferences cannot link to
+// their platform, watched to keep the tracking index up to date
+var trackedKinds = []client.Object{
+	&rbacv1.ClusterRole{},
+	&rbacv1.ClusterRoleBinding{},
+}
+
+// setOwner makes platform the owner of obj: through a controller reference
+// when obj is in the platform's namespace, through tracking labels otherwise.
+// Tracked children are recorded as applied by the current reconcile, so the
+// ones it no longer applies are pruned.
+func (r *ObservabilityPlatformReconciler) setOwner(platform *observabilityv1beta1.ObservabilityPlatform, obj client.Object) error {
+	if !tracking.NeedsTracking(platform, obj) {
+		return controllerutil.SetControllerReference(platform, obj, r.Scheme)
+	}
+	tracking.SetOwner(platform, obj)
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return err
+	}
+	r.Tracking.Applied(client.ObjectKeyFromObject(platform), tracking.Ref{Kind: gvk, Namespace: obj.GetNamespace(), Name: obj.GetName()})
+	return nil
+}
+
+// pruneTrackedChildren deletes the tracked children of the platform not
+// applied by the current reconcile and records their count in the status
+func (r *ObservabilityPlatformReconciler) pruneTrackedChildren(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	log := log.FromContext(ctx)
+	key := client.ObjectKeyFromObject(platform)
+
+	var errs []error
+	for _, ref := range r.Tracking.Stale(key) {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(ref.Kind)
+		if err := r.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, obj); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		// The index only knows the owner's name; never prune the children
+		// of a previous platform with the same name
+		if !tracking.OwnedBy(platform, obj) {
+			continue
+		}
+		log.Info("Pruning tracked child", "kind", ref.Kind.Kind, "namespace", ref.Namespace, "name", ref.Name)
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	count := int32(r.Tracking.Count(key))
+	r.StatusManager.UpdatePlatformStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
+		status.TrackedResources = count
+	})
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to prune %d tracked children: %v", len(errs), errs[0])
+	}
+	return nil
+}
+
+// deleteTrackedChildren deletes every tracked child of a deleted platform,
+// which the garbage collector does not do without ownerReferences
+func (r *ObservabilityPlatformReconciler) deleteTrackedChildren(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	for _, kind := range trackedKinds {
+		gvk, err := apiutil.GVKForObject(kind, r.Scheme)
+		if err != nil {
+			return err
+		}
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(schema.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind + "List"})
+		if err := r.List(ctx, list, tracking.MatchingOwner(platform)); err != nil {
+			return fmt.Errorf("failed to list tracked %s: %w", gvk.Kind, err)
+		}
+		for i := range list.Items {
+			if err := r.Delete(ctx, &list.Items[i]); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete tracked %s %s: %w", gvk.Kind, list.Items[i].GetName(), err)
+			}
+		}
+	}
+	r.Tracking.Forget(client.ObjectKeyFromObject(platform))
+	return nil
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/upgradehooks"
+)
+
+// componentImages are the image repositories of the managed components; hook
+// Jobs default to the component image at the target version
+var componentImages = map[string]string{
+	"prometheus": "prom/prometheus",
+	"grafana":    "grafana/grafana",
+	"loki":       "grafana/loki",
+	"tempo":      "grafana/tempo",
+}
+
+// runUpgradeHooks runs the hooks of a phase for the pending upgrade of a
+// component one at a time, creating each hook's Job when it is reached. It
+// returns true once all hooks of the phase have succeeded, and an error if a
+// hook failed; the failed Job is kept for inspection and deleting it retries
+// the hook.
+func (r *ObservabilityPlatformReconciler) runUpgradeHooks(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, component string, phase upgradehooks.Phase) (bool, error) {
+	log := log.FromContext(ctx).WithValues("component", component, "phase", phase)
+
+	from := platform.Status.AppliedVersions[component]
+	to := componentVersion(platform, component)
+	if !platform.IsUpgradeHooksEnabled() || from == "" || from == to {
+		return true, nil
+	}
+
+	catalog, err := r.upgradeHookCatalog(ctx, platform)
+	if err != nil {
+		return false, err
+	}
+
+	target := upgradehooks.Target{
+		Platform:           platform.Name,
+		Namespace:          platform.Namespace,
+		Component:          component,
+		From:               from,
+		To:                 to,
+		Image:              fmt.Sprintf("%s:%s", componentImages[component], to),
+		ConfigMap:          fmt.Sprintf("%s-%s-config", component, platform.Name),
+		ServiceAccountName: fmt.Sprintf("%s-observability", platform.Name),
+		Labels:             r.commonLabels(platform),
+	}
+	if component == "tempo" {
+		// Tempo images are tagged without the "v" prefix
+		target.Image = fmt.Sprintf("%s:%s", componentImages[component], strings.TrimPrefix(to, "v"))
+	}
+	if platform.Spec.UpgradeHooks != nil && platform.Spec.UpgradeHooks.ServiceAccountName != "" {
+		target.ServiceAccountName = platform.Spec.UpgradeHooks.ServiceAccountName
+	}
+
+	for _, hook := range catalog.Match(component, phase, from, to) {
+		job := upgradehooks.BuildJob(hook, target)
+
+		existing := &batchv1.Job{}
+		err := r.Get(ctx, client.ObjectKeyFromObject(job), existing)
+		if errors.IsNotFound(err) {
+			if err := controllerutil.SetControllerReference(platform, job, r.Scheme); err != nil {
+				return false, fmt.Errorf("failed to set owner reference on hook job: %w", err)
+			}
+			if err := r.Create(ctx, job); err != nil {
+				return false, fmt.Errorf("failed to create %s hook %s: %w", phase, hook.Name, err)
+			}
+			log.Info("Started upgrade hook", "hook", hook.Name, "job", job.Name, "from", from, "to", to)
+			r.EventRecorder.RecordComponentEvent(platform, component, "UpgradeHookStarted",
+				fmt.Sprintf("Started %s hook %s for upgrade %s -> %s", phase, hook.Name, from, to))
+			return false, r.setUpgradeHookStatus(ctx, platform, component, from, to, phase, hook.Name, job.Name, upgradehooks.StateRunning)
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to get hook job %s: %w", job.Name, err)
+		}
+
+		state := upgradehooks.JobState(existing)
+		if err := r.setUpgradeHookStatus(ctx, platform, component, from, to, phase, hook.Name, job.Name, state); err != nil {
+			return false, err
+		}
+		switch state {
+		case upgradehooks.StateRunning:
+			return false, nil
+		case upgradehooks.StateFailed:
+			r.EventRecorder.RecordComponentEvent(platform, component, "UpgradeHookFailed",
+				fmt.Sprintf("%s hook %s failed for upgrade %s -> %s", phase, hook.Name, from, to))
+			return false, fmt.Errorf("%s hook %s failed, see job %s; delete the job to retry", phase, hook.Name, job.Name)
+		}
+	}
+
+	return true, nil
+}
+
+// completeUpgrade runs the post-upgrade hooks of a rolled out component and
+// records its version as applied once they have succeeded, expiring the
+// upgrade's alert silence
+func (r *ObservabilityPlatformReconciler) completeUpgrade(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, component string) error {
+	from := platform.Status.AppliedVersions[component]
+	if from != "" && from != componentVersion(platform, component) {
+		// Post-upgrade hooks run against the new version once it is rolled out
+		if ready, err := r.componentReady(ctx, platform, component); err != nil || !ready {
+			return err
+		}
+	}
+
+	done, err := r.runUpgradeHooks(ctx, platform, component, upgradehooks.PostUpgrade)
+	if err != nil || !done {
+		return err
+	}
+
+	// Persisted, so the hooks don't run again after a requeue or restart
+	version := componentVersion(platform, component)
+	if err := r.StatusManager.ApplyStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
+		if status.AppliedVersions == nil {
+			status.AppliedVersions = make(map[string]string)
+		}
+		status.AppliedVersions[component] = version
+		delete(status.UpgradeHooks, component)
+	}); err != nil {
+		return fmt.Errorf("failed to record applied version of %s: %w", component, err)
+	}
+
+	// The component is healthy on its new version; end the upgrade's silence
+	r.expireUpgradeSilence(ctx, platform, component)
+	return nil
+}
+
+// componentReady reports whether all replicas of a component run the latest
+// revision of its workloads and are ready. Ready replicas of the previous
+// revision, before the rollout started or after a rollback, don't count.
+func (r *ObservabilityPlatformReconciler) componentReady(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, component string) (bool, error) {
+	if _, ok := componentImages[component]; !ok {
+		return true, nil
+	}
+	selector := client.MatchingLabels{
+		"app.kubernetes.io/name":     component,
+		"app.kubernetes.io/instance": platform.Name,
+	}
+
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := r.List(ctx, statefulSets, client.InNamespace(platform.Namespace), selector); err != nil {
+		return false, fmt.Errorf("failed to list %s StatefulSets: %w", component, err)
+	}
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(platform.Namespace), selector); err != nil {
+		return false, fmt.Errorf("failed to list %s Deployments: %w", component, err)
+	}
+	if len(statefulSets.Items) == 0 && len(deployments.Items) == 0 {
+		return false, nil
+	}
+
+	for i := range statefulSets.Items {
+		if !upgradehooks.StatefulSetRolledOut(&statefulSets.Items[i]) {
+			return false, nil
+		}
+	}
+	for i := range deployments.Items {
+		if !upgradehooks.DeploymentRolledOut(&deployments.Items[i]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// upgradeHookCatalog returns the built-in hook catalog merged with the
+// platform's catalog ConfigMap, if any
+func (r *ObservabilityPlatformReconciler) upgradeHookCatalog(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) (*upgradehooks.Catalog, error) {
+	catalog, err := upgradehooks.Default()
+	if err != nil {
+		return nil, err
+	}
+	if platform.Spec.UpgradeHooks == nil || platform.Spec.UpgradeHooks.CatalogRef == nil {
+		return catalog, nil
+	}
+
+	ref := platform.Spec.UpgradeHooks.CatalogRef
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: platform.Namespace}, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get upgrade hook catalog %s: %w", ref.Name, err)
+	}
+	data, ok := configMap.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("upgrade hook catalog %s has no key %s", ref.Name, ref.Key)
+	}
+	custom, err := upgradehooks.Parse([]byte(data))
+	if err != nil {
+		return nil, err
+	}
+	return catalog.Merge(custom), nil
+}
+
+// setUpgradeHookStatus records the state of a component's upgrade hook. The
+// state is persisted, so a succeeded hook isn't run again after a requeue or
+// restart.
+func (r *ObservabilityPlatformReconciler) setUpgradeHookStatus(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, component, from, to string, phase upgradehooks.Phase, hook, job string, state upgradehooks.State) error {
+	status := observabilityv1beta1.UpgradeHookStatus{
+		From:  from,
+		To:    to,
+		Phase: string(phase),
+		Hook:  hook,
+		Job:   job,
+		State: string(state),
+	}
+	previous, ok := platform.Status.UpgradeHooks[component]
+	if ok && previous.Job == job && previous.State == string(state) {
+		status.LastTransitionTime = previous.LastTransitionTime
+	} else {
+		now := metav1.Now()
+		status.LastTransitionTime = &now
+	}
+
+	if err := r.StatusManager.ApplyStatus(ctx, platform, func(platformStatus *observabilityv1beta1.ObservabilityPlatformStatus) {
+		if platformStatus.UpgradeHooks == nil {
+			platformStatus.UpgradeHooks = make(map[string]observabilityv1beta1.UpgradeHookStatus)
+		}
+		platformStatus.UpgradeHooks[component] = *status.DeepCopy()
+	}); err != nil {
+		return fmt.Errorf("failed to record %s hook %s: %w", phase, hook, err)
+	}
+	return nil
+}
+
+// componentVersion returns the target version of a component
+func componentVersion(platform *observabilityv1beta1.ObservabilityPlatform, component string) string {
+	components := platform.Spec.Components
+	if components == nil {
+		return ""
+	}
+	switch component {
+	case "prometheus":
+		if components.Prometheus != nil {
+			return components.Prometheus.Version
+		}
+	case "grafana":
+		if components.Grafana != nil {
+			return components.Grafana.Version
+		}
+	case "loki":
+		if components.Loki != nil {
+			return components.Loki.Version
+		}
+	case "tempo":
+		if components.Tempo != nil {
+			return components.Tempo.Version
+		}
+	}
+	return ""
+}
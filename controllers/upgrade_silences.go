@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/silences"
+)
+
+// silenceUpgrade creates an Alertmanager silence for the pending upgrade of a
+// component before it rolls out. The silence is tracked in the platform status
+// until completeUpgrade expires it. Silencing is best effort: a failure is
+// reported, but does not hold the upgrade.
+func (r *ObservabilityPlatformReconciler) silenceUpgrade(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, component string) {
+	from := platform.Status.AppliedVersions[component]
+	to := componentVersion(platform, component)
+	if !platform.IsUpgradeSilencesEnabled() || from == "" || from == to {
+		return
+	}
+	spec := platform.Spec.UpgradeSilences
+	if !spec.Silences(component) {
+		return
+	}
+
+	existing, ok := platform.Status.UpgradeSilences[component]
+	if ok && existing.From == from && existing.To == to {
+		return
+	}
+
+	log := log.FromContext(ctx).WithValues("component", component, "from", from, "to", to)
+	client := silences.NewClient(spec.GetAlertmanagerURL(platform))
+
+	// The target changed during the upgrade; replace the silence
+	if ok {
+		if err := client.Expire(ctx, existing.ID); err != nil {
+			log.Error(err, "Failed to expire the silence of the previous upgrade target", "silence", existing.ID)
+		}
+		r.setUpgradeSilence(ctx, platform, component, nil)
+	}
+
+	now := time.Now()
+	silence := silences.ForUpgrade(silences.Upgrade{
+		Platform:  platform.Name,
+		Namespace: platform.Namespace,
+		Component: component,
+		From:      from,
+		To:        to,
+	}, spec.Matchers, now, spec.GetDuration())
+	id, err := client.Create(ctx, silence)
+	if err != nil {
+		// Don't fail reconciliation; the upgrade proceeds unsilenced
+		log.Error(err, "Failed to silence alerts for upgrade")
+		r.EventRecorder.RecordComponentEvent(platform, component, "UpgradeSilenceFailed",
+			fmt.Sprintf("Failed to silence alerts for upgrade %s -> %s: %v", from, to, err))
+		return
+	}
+
+	r.setUpgradeSilence(ctx, platform, component, &observabilityv1beta1.UpgradeSilenceStatus{
+		ID:     id,
+		From:   from,
+		To:     to,
+		EndsAt: metav1.NewTime(silence.EndsAt),
+	})
+	log.Info("Silenced alerts for upgrade", "silence", id, "endsAt", silence.EndsAt)
+	r.EventRecorder.RecordComponentEvent(platform, component, "UpgradeSilenced",
+		fmt.Sprintf("Silenced alerts until %s for upgrade %s -> %s", silence.EndsAt.UTC().Format(time.RFC3339), from, to))
+}
+
+// expireUpgradeSilence expires the silence of a component whose upgrade
+// completed and whose health checks passed. A silence that cannot be expired
+// is kept in the status and retried on the next reconcile; it ends on its own
+// at its EndsAt.
+func (r *ObservabilityPlatformReconciler) expireUpgradeSilence(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, component string) {
+	existing, ok := platform.Status.UpgradeSilences[component]
+	if !ok {
+		return
+	}
+	log := log.FromContext(ctx).WithValues("component", component, "silence", existing.ID)
+
+	if time.Now().Before(existing.EndsAt.Time) {
+		// The spec may be gone already; the silence is still expired
+		url := platform.Spec.UpgradeSilences.GetAlertmanagerURL(platform)
+		if err := silences.NewClient(url).Expire(ctx, existing.ID); err != nil {
+			// Don't fail reconciliation; the silence ends on its own
+			log.Error(err, "Failed to expire upgrade silence")
+			return
+		}
+	}
+
+	r.setUpgradeSilence(ctx, platform, component, nil)
+	log.Info("Expired upgrade silence", "from", existing.From, "to", existing.To)
+	r.EventRecorder.RecordComponentEvent(platform, component, "UpgradeSilenceExpired",
+		fmt.Sprintf("Upgrade %s -> %s is healthy, expired its alert silence", existing.From, existing.To))
+}
+
+// setUpgradeSilence records the silence of a component's upgrade, or its
+// removal when silence is nil. The silence is persisted, so it's expired,
+// and not created again, after a requeue or restart.
+func (r *ObservabilityPlatformReconciler) setUpgradeSilence(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, component string, silence *observabilityv1beta1.UpgradeSilenceStatus) {
+	if err := r.StatusManager.ApplyStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
+		if silence == nil {
+			delete(status.UpgradeSilences, component)
+			return
+		}
+		if status.UpgradeSilences == nil {
+			status.UpgradeSilences = make(map[string]observabilityv1beta1.UpgradeSilenceStatus)
+		}
+		status.UpgradeSilences[component] = *silence
+	}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update upgrade silence", "component", component)
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/dataprotection"
+)
+
+const (
+	upgradeSnapshotsSnapshotting = "Snapshotting"
+	upgradeSnapshotsReady        = "Ready"
+	upgradeSnapshotsFailed       = "Failed"
+)
+
+// snapshotBeforeUpgrade takes a VolumeSnapshot of every claim of a component
+// before a new version rolls out, and returns true once they are all ready to
+// use or when no snapshot is needed. The snapshots are listed in the platform
+// status so the backup/restore subsystem can restore them if the upgrade goes
+// wrong. A failed snapshot holds the upgrade; deleting it takes it again.
+func (r *ObservabilityPlatformReconciler) snapshotBeforeUpgrade(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, component string) (bool, error) {
+	from := platform.Status.AppliedVersions[component]
+	to := componentVersion(platform, component)
+	if !platform.IsUpgradeSnapshotsEnabled() || from == "" || from == to {
+		return true, nil
+	}
+	spec := platform.Spec.UpgradeSnapshots
+	if !spec.Snapshots(component) {
+		return true, nil
+	}
+	log := log.FromContext(ctx).WithValues("component", component, "from", from, "to", to)
+
+	history := platform.Status.UpgradeSnapshots[component]
+	if len(history) == 0 || history[0].From != from || history[0].To != to {
+		claims, err := r.componentClaims(ctx, platform, component)
+		if err != nil {
+			return false, err
+		}
+		if len(claims) == 0 {
+			return true, nil
+		}
+
+		current := observabilityv1beta1.UpgradeSnapshotStatus{
+			From:         from,
+			To:           to,
+			Phase:        upgradeSnapshotsSnapshotting,
+			CreationTime: metav1.Now(),
+		}
+		for _, claim := range claims {
+			current.Snapshots = append(current.Snapshots, observabilityv1beta1.UpgradeVolumeSnapshot{
+				Claim:    claim.Name,
+				Snapshot: dataprotection.UpgradeSnapshotName(claim.Name, to, current.CreationTime.Time),
+			})
+		}
+		history = append([]observabilityv1beta1.UpgradeSnapshotStatus{current}, history...)
+
+		// Delete the snapshots of the upgrades no longer kept
+		if keep := spec.GetKeep(); len(history) > keep {
+			for _, expired := range history[keep:] {
+				r.deleteUpgradeSnapshots(ctx, platform, expired)
+			}
+			history = history[:keep]
+		}
+		log.Info("Taking volume snapshots before upgrade", "claims", len(claims))
+	}
+
+	current := &history[0]
+	if current.Phase != upgradeSnapshotsReady {
+		r.takeUpgradeSnapshots(ctx, platform, component, current)
+	}
+	// Persisted, so the snapshots aren't taken again after a requeue or
+	// restart and stay listed for restores
+	if err := r.StatusManager.ApplyStatus(ctx, platform, func(status *observabilityv1beta1.ObservabilityPlatformStatus) {
+		if status.UpgradeSnapshots == nil {
+			status.UpgradeSnapshots = make(map[string][]observabilityv1beta1.UpgradeSnapshotStatus)
+		}
+		snapshots := make([]observabilityv1beta1.UpgradeSnapshotStatus, len(history))
+		for i := range history {
+			history[i].DeepCopyInto(&snapshots[i])
+		}
+		status.UpgradeSnapshots[component] = snapshots
+	}); err != nil {
+		return false, fmt.Errorf("failed to record upgrade snapshots: %w", err)
+	}
+
+	switch current.Phase {
+	case upgradeSnapshotsReady:
+		return true, nil
+	case upgradeSnapshotsFailed:
+		return false, fmt.Errorf("pre-upgrade volume snapshots failed: %s", current.Message)
+	}
+	return false, nil
+}
+
+// takeUpgradeSnapshots creates the missing snapshots of an upgrade and
+// records their state
+func (r *ObservabilityPlatformReconciler) takeUpgradeSnapshots(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, component string, current *observabilityv1beta1.UpgradeSnapshotStatus) {
+	previous := current.Phase
+	current.Phase = upgradeSnapshotsReady
+	current.Message = ""
+
+	for i := range current.Snapshots {
+		volume := &current.Snapshots[i]
+		desired := dataprotection.BuildUpgradeSnapshot(dataprotection.UpgradeSnapshot{
+			Name:      volume.Snapshot,
+			Platform:  platform.Name,
+			Namespace: platform.Namespace,
+			Component: component,
+			Claim:     volume.Claim,
+			From:      current.From,
+			To:        current.To,
+		}, platform.GetUpgradeVolumeSnapshotClassName())
+
+		snapshot := &unstructured.Unstructured{}
+		snapshot.SetGroupVersionKind(dataprotection.VolumeSnapshotGVK)
+		err := r.Get(ctx, client.ObjectKeyFromObject(desired), snapshot)
+		switch {
+		case meta.IsNoMatchError(err):
+			current.Phase = upgradeSnapshotsFailed
+			current.Message = "upgrade snapshots require the VolumeSnapshot CRD"
+			return
+		case errors.IsNotFound(err):
+			if err := r.Create(ctx, desired); err != nil && !errors.IsAlreadyExists(err) {
+				current.Phase = upgradeSnapshotsFailed
+				current.Message = fmt.Sprintf("failed to snapshot claim %s: %v", volume.Claim, err)
+				return
+			}
+			snapshot = desired
+		case err != nil:
+			current.Phase = upgradeSnapshotsFailed
+			current.Message = fmt.Sprintf("failed to get snapshot %s: %v", volume.Snapshot, err)
+			return
+		}
+
+		ready, message := dataprotection.SnapshotReady(snapshot)
+		volume.ReadyToUse = ready
+		switch {
+		case message != "":
+			current.Phase = upgradeSnapshotsFailed
+			current.Message = fmt.Sprintf("snapshot %s of claim %s failed: %s; delete it to retry", volume.Snapshot, volume.Claim, message)
+		case !ready && current.Phase != upgradeSnapshotsFailed:
+			current.Phase = upgradeSnapshotsSnapshotting
+		}
+	}
+
+	if current.Phase == previous {
+		return
+	}
+	switch current.Phase {
+	case upgradeSnapshotsReady:
+		r.EventRecorder.RecordComponentEvent(platform, component, "UpgradeSnapshotsReady",
+			fmt.Sprintf("Snapshotted %d volumes before upgrade %s -> %s", len(current.Snapshots), current.From, current.To))
+	case upgradeSnapshotsFailed:
+		r.EventRecorder.RecordComponentEvent(platform, component, "UpgradeSnapshotsFailed", current.Message)
+	}
+}
+
+// deleteUpgradeSnapshots deletes the snapshots of an upgrade no longer kept
+func (r *ObservabilityPlatformReconciler) deleteUpgradeSnapshots(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, expired observabilityv1beta1.UpgradeSnapshotStatus) {
+	for _, volume := range expired.Snapshots {
+		snapshot := &unstructured.Unstructured{}
+		snapshot.SetGroupVersionKind(dataprotection.VolumeSnapshotGVK)
+		snapshot.SetName(volume.Snapshot)
+		snapshot.SetNamespace(platform.Namespace)
+		if err := r.Delete(ctx, snapshot); client.IgnoreNotFound(err) != nil && !meta.IsNoMatchError(err) {
+			// Don't fail reconciliation; the snapshot is left behind
+			log.FromContext(ctx).Error(err, "Failed to delete expired upgrade snapshot", "snapshot", volume.Snapshot)
+		}
+	}
+}
@@ -4,6 +4,8 @@ go 1.21
 
 require (
 	github.com/go-logr/logr v1.2.4
+	github.com/google/go-jsonnet v0.20.0
+	github.com/prometheus/prometheus v0.48.0
 	github.com/stretchr/testify v1.8.4
 	google.golang.org/protobuf v1.31.0
 	istio.io/api v0.0.0-20231113182140-d4b7e3fc2b44
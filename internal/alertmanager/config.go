@@ -0,0 +1,395 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package alertmanager renders the alerting settings of a platform into an
+// Alertmanager configuration file. The receivers and routes come from
+// spec.alerting.alertmanager.config; the inhibit rules and mute time
+// intervals declared in spec.alerting are added to them.
+package alertmanager
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+const (
+	// ConfigKey is the key of the configuration file in the config Secret
+	ConfigKey = "alertmanager.yml"
+
+	// DefaultReceiver receives the alerts when no configuration is set. It
+	// has no integrations, so the alerts are only visible in Alertmanager.
+	DefaultReceiver = "default"
+)
+
+// ConfigSecretName returns the name of the Secret holding the configuration
+// of a platform's Alertmanager
+func ConfigSecretName(platform string) string {
+	return fmt.Sprintf("%s-alertmanager-config", platform)
+}
+
+type config struct {
+	Global        *global        `yaml:"global,omitempty"`
+	Route         *route         `yaml:"route"`
+	Receivers     []receiver     `yaml:"receivers"`
+	InhibitRules  []inhibitRule  `yaml:"inhibit_rules,omitempty"`
+	TimeIntervals []timeInterval `yaml:"time_intervals,omitempty"`
+	Templates     []string       `yaml:"templates,omitempty"`
+}
+
+type global struct {
+	ResolveTimeout   string `yaml:"resolve_timeout,omitempty"`
+	SMTPFrom         string `yaml:"smtp_from,omitempty"`
+	SMTPSmarthost    string `yaml:"smtp_smarthost,omitempty"`
+	SMTPAuthUsername string `yaml:"smtp_auth_username,omitempty"`
+	SMTPAuthPassword string `yaml:"smtp_auth_password,omitempty"`
+	SMTPRequireTLS   *bool  `yaml:"smtp_require_tls,omitempty"`
+	SlackAPIURL      string `yaml:"slack_api_url,omitempty"`
+	PagerdutyURL     string `yaml:"pagerduty_url,omitempty"`
+}
+
+type route struct {
+	Receiver            string   `yaml:"receiver,omitempty"`
+	GroupBy             []string `yaml:"group_by,omitempty"`
+	GroupWait           string   `yaml:"group_wait,omitempty"`
+	GroupInterval       string   `yaml:"group_interval,omitempty"`
+	RepeatInterval      string   `yaml:"repeat_interval,omitempty"`
+	Matchers            []string `yaml:"matchers,omitempty"`
+	Continue            bool     `yaml:"continue,omitempty"`
+	MuteTimeIntervals   []string `yaml:"mute_time_intervals,omitempty"`
+	ActiveTimeIntervals []string `yaml:"active_time_intervals,omitempty"`
+	Routes              []route  `yaml:"routes,omitempty"`
+}
+
+type receiver struct {
+	Name             string            `yaml:"name"`
+	EmailConfigs     []emailConfig     `yaml:"email_configs,omitempty"`
+	PagerdutyConfigs []pagerdutyConfig `yaml:"pagerduty_configs,omitempty"`
+	SlackConfigs     []slackConfig     `yaml:"slack_configs,omitempty"`
+	WebhookConfigs   []webhookConfig   `yaml:"webhook_configs,omitempty"`
+	OpsgenieConfigs  []opsgenieConfig  `yaml:"opsgenie_configs,omitempty"`
+}
+
+type emailConfig struct {
+	To           string            `yaml:"to"`
+	From         string            `yaml:"from,omitempty"`
+	Smarthost    string            `yaml:"smarthost,omitempty"`
+	AuthUsername string            `yaml:"auth_username,omitempty"`
+	AuthPassword string            `yaml:"auth_password,omitempty"`
+	Headers      map[string]string `yaml:"headers,omitempty"`
+	HTML         string            `yaml:"html,omitempty"`
+	Text         string            `yaml:"text,omitempty"`
+	RequireTLS   *bool             `yaml:"require_tls,omitempty"`
+}
+
+type pagerdutyConfig struct {
+	ServiceKey  string            `yaml:"service_key"`
+	URL         string            `yaml:"url,omitempty"`
+	Client      string            `yaml:"client,omitempty"`
+	ClientURL   string            `yaml:"client_url,omitempty"`
+	Description string            `yaml:"description,omitempty"`
+	Details     map[string]string `yaml:"details,omitempty"`
+}
+
+type slackConfig struct {
+	APIURL      string       `yaml:"api_url"`
+	Channel     string       `yaml:"channel,omitempty"`
+	Username    string       `yaml:"username,omitempty"`
+	Color       string       `yaml:"color,omitempty"`
+	Title       string       `yaml:"title,omitempty"`
+	TitleLink   string       `yaml:"title_link,omitempty"`
+	Pretext     string       `yaml:"pretext,omitempty"`
+	Text        string       `yaml:"text,omitempty"`
+	Fields      []slackField `yaml:"fields,omitempty"`
+	ShortFields bool         `yaml:"short_fields,omitempty"`
+	Footer      string       `yaml:"footer,omitempty"`
+	Fallback    string       `yaml:"fallback,omitempty"`
+	IconEmoji   string       `yaml:"icon_emoji,omitempty"`
+	IconURL     string       `yaml:"icon_url,omitempty"`
+	LinkNames   bool         `yaml:"link_names,omitempty"`
+}
+
+type slackField struct {
+	Title string `yaml:"title"`
+	Value string `yaml:"value"`
+	Short bool   `yaml:"short,omitempty"`
+}
+
+type webhookConfig struct {
+	URL        string      `yaml:"url"`
+	HTTPConfig *httpConfig `yaml:"http_config,omitempty"`
+	MaxAlerts  int32       `yaml:"max_alerts,omitempty"`
+}
+
+type opsgenieConfig struct {
+	APIKey      string `yaml:"api_key"`
+	APIURL      string `yaml:"api_url,omitempty"`
+	Message     string `yaml:"message,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	Source      string `yaml:"source,omitempty"`
+	Tags        string `yaml:"tags,omitempty"`
+	Note        string `yaml:"note,omitempty"`
+	Priority    string `yaml:"priority,omitempty"`
+}
+
+type httpConfig struct {
+	BasicAuth   *basicAuth `yaml:"basic_auth,omitempty"`
+	BearerToken string     `yaml:"bearer_token,omitempty"`
+	ProxyURL    string     `yaml:"proxy_url,omitempty"`
+	TLSConfig   *tlsConfig `yaml:"tls_config,omitempty"`
+}
+
+type basicAuth struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+type tlsConfig struct {
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty"`
+	CAFile             string `yaml:"ca_file,omitempty"`
+	CertFile           string `yaml:"cert_file,omitempty"`
+	KeyFile            string `yaml:"key_file,omitempty"`
+}
+
+type inhibitRule struct {
+	SourceMatchers []string `yaml:"source_matchers"`
+	TargetMatchers []string `yaml:"target_matchers"`
+	Equal          []string `yaml:"equal,omitempty"`
+}
+
+type timeInterval struct {
+	Name          string           `yaml:"name"`
+	TimeIntervals []timeIntervalOf `yaml:"time_intervals"`
+}
+
+type timeIntervalOf struct {
+	Times       []timeRange `yaml:"times,omitempty"`
+	Weekdays    []string    `yaml:"weekdays,omitempty"`
+	DaysOfMonth []string    `yaml:"days_of_month,omitempty"`
+	Months      []string    `yaml:"months,omitempty"`
+	Years       []string    `yaml:"years,omitempty"`
+	Location    string      `yaml:"location,omitempty"`
+}
+
+type timeRange struct {
+	StartTime string `yaml:"start_time"`
+	EndTime   string `yaml:"end_time"`
+}
+
+// Render renders the Alertmanager configuration file of the alerting
+// settings. Without spec.alerting.alertmanager.config, all alerts go to a
+// receiver without integrations, and only the inhibit rules and time
+// intervals are configured.
+func Render(alerting *observabilityv1beta1.AlertingSettings) (string, error) {
+	cfg := config{
+		Route:     &route{Receiver: DefaultReceiver},
+		Receivers: []receiver{{Name: DefaultReceiver}},
+	}
+
+	if alerting.Alertmanager != nil && alerting.Alertmanager.Config != nil {
+		am := alerting.Alertmanager.Config
+		if am.Global != nil {
+			cfg.Global = convertGlobal(am.Global)
+		}
+		if am.Route != nil {
+			r := convertRoute(*am.Route)
+			cfg.Route = &r
+		}
+		if len(am.Receivers) > 0 {
+			cfg.Receivers = make([]receiver, 0, len(am.Receivers))
+			for _, r := range am.Receivers {
+				cfg.Receivers = append(cfg.Receivers, convertReceiver(r))
+			}
+		}
+		for _, rule := range am.InhibitRules {
+			cfg.InhibitRules = append(cfg.InhibitRules, convertInhibitRule(rule))
+		}
+		cfg.Templates = am.Templates
+	}
+
+	for _, rule := range alerting.InhibitRules {
+		cfg.InhibitRules = append(cfg.InhibitRules, convertInhibitRule(rule))
+	}
+	for _, interval := range alerting.MuteTimeIntervals {
+		cfg.TimeIntervals = append(cfg.TimeIntervals, convertTimeInterval(interval))
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal Alertmanager configuration: %w", err)
+	}
+	return string(data), nil
+}
+
+// FormatMatcher formats a matcher in the Alertmanager matcher syntax, e.g.
+// severity=~"warning|info"
+func FormatMatcher(m observabilityv1beta1.Matcher) string {
+	op := m.MatchType
+	if op == "" {
+		op = "="
+	}
+	value := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(m.Value)
+	return fmt.Sprintf(`%s%s"%s"`, m.Name, op, value)
+}
+
+func formatMatchers(matchers []observabilityv1beta1.Matcher) []string {
+	if len(matchers) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(matchers))
+	for _, m := range matchers {
+		out = append(out, FormatMatcher(m))
+	}
+	return out
+}
+
+func convertGlobal(g *observabilityv1beta1.AlertmanagerGlobalConfig) *global {
+	return &global{
+		ResolveTimeout:   g.ResolveTimeout,
+		SMTPFrom:         g.SMTPFrom,
+		SMTPSmarthost:    g.SMTPSmarthost,
+		SMTPAuthUsername: g.SMTPAuthUsername,
+		SMTPAuthPassword: g.SMTPAuthPassword,
+		SMTPRequireTLS:   g.SMTPRequireTLS,
+		SlackAPIURL:      g.SlackAPIURL,
+		PagerdutyURL:     g.PagerdutyURL,
+	}
+}
+
+func convertRoute(r observabilityv1beta1.Route) route {
+	out := route{
+		Receiver:            r.Receiver,
+		GroupBy:             r.GroupBy,
+		GroupWait:           r.GroupWait,
+		GroupInterval:       r.GroupInterval,
+		RepeatInterval:      r.RepeatInterval,
+		Matchers:            formatMatchers(r.Matchers),
+		Continue:            r.Continue,
+		MuteTimeIntervals:   r.MuteTimeIntervals,
+		ActiveTimeIntervals: r.ActiveTimeIntervals,
+	}
+	for _, child := range r.Routes {
+		out.Routes = append(out.Routes, convertRoute(child))
+	}
+	return out
+}
+
+func convertReceiver(r observabilityv1beta1.Receiver) receiver {
+	out := receiver{Name: r.Name}
+	for _, c := range r.EmailConfigs {
+		out.EmailConfigs = append(out.EmailConfigs, emailConfig{
+			To:           c.To,
+			From:         c.From,
+			Smarthost:    c.Smarthost,
+			AuthUsername: c.AuthUsername,
+			AuthPassword: c.AuthPassword,
+			Headers:      c.Headers,
+			HTML:         c.HTML,
+			Text:         c.Text,
+			RequireTLS:   c.RequireTLS,
+		})
+	}
+	for _, c := range r.PagerdutyConfigs {
+		out.PagerdutyConfigs = append(out.PagerdutyConfigs, pagerdutyConfig{
+			ServiceKey:  c.ServiceKey,
+			URL:         c.URL,
+			Client:      c.Client,
+			ClientURL:   c.ClientURL,
+			Description: c.Description,
+			Details:     c.Details,
+		})
+	}
+	for _, c := range r.SlackConfigs {
+		slack := slackConfig{
+			APIURL:      c.APIURL,
+			Channel:     c.Channel,
+			Username:    c.Username,
+			Color:       c.Color,
+			Title:       c.Title,
+			TitleLink:   c.TitleLink,
+			Pretext:     c.Pretext,
+			Text:        c.Text,
+			ShortFields: c.ShortFields,
+			Footer:      c.Footer,
+			Fallback:    c.Fallback,
+			IconEmoji:   c.IconEmoji,
+			IconURL:     c.IconURL,
+			LinkNames:   c.LinkNames,
+		}
+		for _, f := range c.Fields {
+			slack.Fields = append(slack.Fields, slackField{Title: f.Title, Value: f.Value, Short: f.Short})
+		}
+		out.SlackConfigs = append(out.SlackConfigs, slack)
+	}
+	for _, c := range r.WebhookConfigs {
+		webhook := webhookConfig{URL: c.URL, MaxAlerts: c.MaxAlerts}
+		if c.HTTPConfig != nil {
+			webhook.HTTPConfig = &httpConfig{
+				BearerToken: c.HTTPConfig.BearerToken,
+				ProxyURL:    c.HTTPConfig.ProxyURL,
+				TLSConfig:   convertTLSConfig(c.HTTPConfig.TLSConfig),
+			}
+			if auth := c.HTTPConfig.BasicAuth; auth != nil {
+				webhook.HTTPConfig.BasicAuth = &basicAuth{Username: auth.Username, Password: auth.Password}
+			}
+		}
+		out.WebhookConfigs = append(out.WebhookConfigs, webhook)
+	}
+	for _, c := range r.OpsgenieConfigs {
+		out.OpsgenieConfigs = append(out.OpsgenieConfigs, opsgenieConfig{
+			APIKey:      c.APIKey,
+			APIURL:      c.APIURL,
+			Message:     c.Message,
+			Description: c.Description,
+			Source:      c.Source,
+			Tags:        c.Tags,
+			Note:        c.Note,
+			Priority:    c.Priority,
+		})
+	}
+	return out
+}
+
+func convertTLSConfig(c *observabilityv1beta1.TLSConfig) *tlsConfig {
+	if c == nil {
+		return nil
+	}
+	return &tlsConfig{
+		InsecureSkipVerify: c.InsecureSkipVerify,
+		CAFile:             c.CAFile,
+		CertFile:           c.CertFile,
+		KeyFile:            c.KeyFile,
+	}
+}
+
+func convertInhibitRule(r observabilityv1beta1.InhibitRule) inhibitRule {
+	return inhibitRule{
+		SourceMatchers: formatMatchers(r.SourceMatch),
+		TargetMatchers: formatMatchers(r.TargetMatch),
+		Equal:          r.Equal,
+	}
+}
+
+func convertTimeInterval(m observabilityv1beta1.MuteTimeInterval) timeInterval {
+	out := timeInterval{Name: m.Name}
+	for _, ti := range m.TimeIntervals {
+		interval := timeIntervalOf{
+			Weekdays:    ti.Weekdays,
+			DaysOfMonth: ti.DaysOfMonth,
+			Months:      ti.Months,
+			Years:       ti.Years,
+			Location:    ti.Location,
+		}
+		for _, t := range ti.Times {
+			interval.Times = append(interval.Times, timeRange{StartTime: t.StartTime, EndTime: t.EndTime})
+		}
+		out.TimeIntervals = append(out.TimeIntervals, interval)
+	}
+	return out
+}
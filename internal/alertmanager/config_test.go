@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package alertmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+func TestRenderDefault(t *testing.T) {
+	out, err := Render(&observabilityv1beta1.AlertingSettings{
+		InhibitRules: []observabilityv1beta1.InhibitRule{{
+			SourceMatch: []observabilityv1beta1.Matcher{{Name: "severity", Value: "critical"}},
+			TargetMatch: []observabilityv1beta1.Matcher{{Name: "severity", Value: "warning|info", MatchType: "=~"}},
+			Equal:       []string{"alertname", "namespace"},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `route:
+    receiver: default
+receivers:
+    - name: default
+inhibit_rules:
+    - source_matchers:
+        - severity="critical"
+      target_matchers:
+        - severity=~"warning|info"
+      equal:
+        - alertname
+        - namespace
+`, out)
+}
+
+func TestRenderMuteTimeIntervals(t *testing.T) {
+	alerting := &observabilityv1beta1.AlertingSettings{
+		Alertmanager: &observabilityv1beta1.AlertmanagerSpec{
+			Config: &observabilityv1beta1.AlertmanagerConfig{
+				Route: &observabilityv1beta1.Route{
+					Receiver: "oncall",
+					GroupBy:  []string{"alertname"},
+					Routes: []observabilityv1beta1.Route{{
+						Receiver:          "team",
+						Matchers:          []observabilityv1beta1.Matcher{{Name: "team", Value: `db"ops`}},
+						MuteTimeIntervals: []string{"maintenance"},
+					}},
+				},
+				Receivers: []observabilityv1beta1.Receiver{
+					{Name: "oncall", PagerdutyConfigs: []observabilityv1beta1.PagerdutyConfig{{ServiceKey: "key"}}},
+					{Name: "team", SlackConfigs: []observabilityv1beta1.SlackConfig{{APIURL: "https://hooks.slack.com/x", Channel: "#db"}}},
+				},
+				InhibitRules: []observabilityv1beta1.InhibitRule{{
+					SourceMatch: []observabilityv1beta1.Matcher{{Name: "alertname", Value: "ClusterDown"}},
+					TargetMatch: []observabilityv1beta1.Matcher{{Name: "alertname", Value: "ClusterDown", MatchType: "!="}},
+				}},
+			},
+		},
+		InhibitRules: []observabilityv1beta1.InhibitRule{{
+			SourceMatch: []observabilityv1beta1.Matcher{{Name: "severity", Value: "critical"}},
+			TargetMatch: []observabilityv1beta1.Matcher{{Name: "severity", Value: "warning"}},
+		}},
+		MuteTimeIntervals: []observabilityv1beta1.MuteTimeInterval{{
+			Name: "maintenance",
+			TimeIntervals: []observabilityv1beta1.TimeInterval{{
+				Times:    []observabilityv1beta1.TimeRange{{StartTime: "02:00", EndTime: "04:00"}},
+				Weekdays: []string{"saturday:sunday"},
+				Location: "Europe/Berlin",
+			}},
+		}},
+	}
+
+	out, err := Render(alerting)
+	require.NoError(t, err)
+
+	var cfg config
+	require.NoError(t, yaml.Unmarshal([]byte(out), &cfg))
+	assert.Equal(t, "oncall", cfg.Route.Receiver)
+	require.Len(t, cfg.Route.Routes, 1)
+	assert.Equal(t, []string{`team="db\"ops"`}, cfg.Route.Routes[0].Matchers)
+	assert.Equal(t, []string{"maintenance"}, cfg.Route.Routes[0].MuteTimeIntervals)
+	require.Len(t, cfg.Receivers, 2)
+	assert.Equal(t, "key", cfg.Receivers[0].PagerdutyConfigs[0].ServiceKey)
+	assert.Equal(t, "#db", cfg.Receivers[1].SlackConfigs[0].Channel)
+
+	// The inhibit rules of the raw configuration come first
+	require.Len(t, cfg.InhibitRules, 2)
+	assert.Equal(t, []string{`alertname!="ClusterDown"`}, cfg.InhibitRules[0].TargetMatchers)
+	assert.Equal(t, []string{`severity="critical"`}, cfg.InhibitRules[1].SourceMatchers)
+
+	require.Len(t, cfg.TimeIntervals, 1)
+	assert.Equal(t, "maintenance", cfg.TimeIntervals[0].Name)
+	assert.Equal(t, timeIntervalOf{
+		Times:    []timeRange{{StartTime: "02:00", EndTime: "04:00"}},
+		Weekdays: []string{"saturday:sunday"},
+		Location: "Europe/Berlin",
+	}, cfg.TimeIntervals[0].TimeIntervals[0])
+}
+
+func TestValidateMatcher(t *testing.T) {
+	path := field.NewPath("m")
+	assert.Empty(t, ValidateMatcher(observabilityv1beta1.Matcher{Name: "severity", Value: "critical"}, path))
+	assert.Empty(t, ValidateMatcher(observabilityv1beta1.Matcher{Name: "job", Value: "node.*", MatchType: "=~"}, path))
+	assert.Len(t, ValidateMatcher(observabilityv1beta1.Matcher{Name: "1bad", Value: "x"}, path), 1)
+	assert.Len(t, ValidateMatcher(observabilityv1beta1.Matcher{Name: "job", Value: "(", MatchType: "!~"}, path), 1)
+	assert.Len(t, ValidateMatcher(observabilityv1beta1.Matcher{Name: "job", Value: "x", MatchType: "=="}, path), 1)
+}
+
+func TestValidateInhibitRule(t *testing.T) {
+	errs := ValidateInhibitRule(observabilityv1beta1.InhibitRule{Equal: []string{"alert-name"}}, field.NewPath("rule"))
+	require.Len(t, errs, 3)
+	assert.Equal(t, "rule.sourceMatch", errs[0].Field)
+	assert.Equal(t, "rule.targetMatch", errs[1].Field)
+	assert.Equal(t, "rule.equal[0]", errs[2].Field)
+}
+
+func TestValidateTimeInterval(t *testing.T) {
+	path := field.NewPath("ti")
+
+	valid := observabilityv1beta1.TimeInterval{
+		Times:       []observabilityv1beta1.TimeRange{{StartTime: "17:00", EndTime: "24:00"}},
+		Weekdays:    []string{"monday:friday", "Sunday"},
+		DaysOfMonth: []string{"1:5", "-3:-1", "15:-1"},
+		Months:      []string{"january:march", "12"},
+		Years:       []string{"2025:2026"},
+		Location:    "UTC",
+	}
+	assert.Empty(t, ValidateTimeInterval(valid, path))
+
+	invalid := observabilityv1beta1.TimeInterval{
+		Times: []observabilityv1beta1.TimeRange{
+			{StartTime: "10:00", EndTime: "09:00"},
+			{StartTime: "24:00", EndTime: "25:00"},
+		},
+		Weekdays:    []string{"friday:monday", "funday"},
+		DaysOfMonth: []string{"0", "-1:5", "10:3"},
+		Months:      []string{"13"},
+		Years:       []string{"2026:2025"},
+		Location:    "Mars/Olympus",
+	}
+	var fields []string
+	for _, err := range ValidateTimeInterval(invalid, path) {
+		fields = append(fields, err.Field)
+	}
+	assert.Equal(t, []string{
+		"ti.times[0].endTime",
+		"ti.times[1].startTime",
+		"ti.times[1].endTime",
+		"ti.weekdays[0]",
+		"ti.weekdays[1]",
+		"ti.daysOfMonth[0]",
+		"ti.daysOfMonth[1]",
+		"ti.daysOfMonth[2]",
+		"ti.months[0]",
+		"ti.years[0]",
+		"ti.location",
+	}, fields)
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package alertmanager
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+var (
+	labelNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	clockRegex     = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$|^24:00$`)
+
+	weekdays = map[string]int{
+		"sunday": 0, "monday": 1, "tuesday": 2, "wednesday": 3, "thursday": 4, "friday": 5, "saturday": 6,
+	}
+	months = map[string]int{
+		"january": 1, "february": 2, "march": 3, "april": 4, "may": 5, "june": 6,
+		"july": 7, "august": 8, "september": 9, "october": 10, "november": 11, "december": 12,
+	}
+)
+
+// ValidateMatcher validates a matcher of a route or inhibit rule
+func ValidateMatcher(m observabilityv1beta1.Matcher, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !labelNameRegex.MatchString(m.Name) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), m.Name, "must be a valid label name"))
+	}
+	switch m.MatchType {
+	case "", "=", "!=":
+	case "=~", "!~":
+		// Alertmanager anchors matcher regexes
+		if _, err := regexp.Compile("^(?:" + m.Value + ")$"); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("value"), m.Value, fmt.Sprintf("invalid regex: %v", err)))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("matchType"), m.MatchType, []string{"=", "!=", "=~", "!~"}))
+	}
+
+	return allErrs
+}
+
+// ValidateInhibitRule validates an inhibit rule. A rule without source or
+// target matchers would inhibit every alert.
+func ValidateInhibitRule(rule observabilityv1beta1.InhibitRule, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(rule.SourceMatch) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("sourceMatch"), "at least one source matcher is required"))
+	}
+	if len(rule.TargetMatch) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("targetMatch"), "at least one target matcher is required"))
+	}
+	for i, m := range rule.SourceMatch {
+		allErrs = append(allErrs, ValidateMatcher(m, fldPath.Child("sourceMatch").Index(i))...)
+	}
+	for i, m := range rule.TargetMatch {
+		allErrs = append(allErrs, ValidateMatcher(m, fldPath.Child("targetMatch").Index(i))...)
+	}
+	for i, label := range rule.Equal {
+		if !labelNameRegex.MatchString(label) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("equal").Index(i), label, "must be a valid label name"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateTimeInterval validates the ranges of a time interval the way
+// Alertmanager parses them
+func ValidateTimeInterval(ti observabilityv1beta1.TimeInterval, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, t := range ti.Times {
+		timePath := fldPath.Child("times").Index(i)
+		start, startOK := minuteOfDay(t.StartTime)
+		end, endOK := minuteOfDay(t.EndTime)
+		if !startOK || start == 24*60 {
+			allErrs = append(allErrs, field.Invalid(timePath.Child("startTime"), t.StartTime, "must be a time of day, e.g. 09:00"))
+		}
+		if !endOK {
+			allErrs = append(allErrs, field.Invalid(timePath.Child("endTime"), t.EndTime, "must be a time of day, e.g. 17:00, or 24:00"))
+		}
+		if startOK && endOK && start >= end {
+			allErrs = append(allErrs, field.Invalid(timePath.Child("endTime"), t.EndTime, "must be after startTime"))
+		}
+	}
+	for i, r := range ti.Weekdays {
+		if err := validateRange(r, func(s string) (int, bool) {
+			v, ok := weekdays[strings.ToLower(s)]
+			return v, ok
+		}); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("weekdays").Index(i), r, err.Error()))
+		}
+	}
+	for i, r := range ti.DaysOfMonth {
+		if err := validateDaysOfMonth(r); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("daysOfMonth").Index(i), r, err.Error()))
+		}
+	}
+	for i, r := range ti.Months {
+		if err := validateRange(r, func(s string) (int, bool) {
+			if v, ok := months[strings.ToLower(s)]; ok {
+				return v, true
+			}
+			v, err := strconv.Atoi(s)
+			return v, err == nil && v >= 1 && v <= 12
+		}); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("months").Index(i), r, err.Error()))
+		}
+	}
+	for i, r := range ti.Years {
+		if err := validateRange(r, func(s string) (int, bool) {
+			v, err := strconv.Atoi(s)
+			return v, err == nil && v > 0
+		}); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("years").Index(i), r, err.Error()))
+		}
+	}
+	if ti.Location != "" {
+		if _, err := time.LoadLocation(ti.Location); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("location"), ti.Location, "must be an IANA time zone, e.g. Europe/Berlin"))
+		}
+	}
+
+	return allErrs
+}
+
+// minuteOfDay parses a HH:MM time of day
+func minuteOfDay(s string) (int, bool) {
+	if !clockRegex.MatchString(s) {
+		return 0, false
+	}
+	h, _ := strconv.Atoi(s[:2])
+	m, _ := strconv.Atoi(s[3:])
+	return h*60 + m, true
+}
+
+// validateRange validates a value or an inclusive start:end range
+func validateRange(r string, parse func(string) (int, bool)) error {
+	start, end, isRange := strings.Cut(r, ":")
+	from, ok := parse(start)
+	if !ok {
+		return fmt.Errorf("invalid value %q", start)
+	}
+	if !isRange {
+		return nil
+	}
+	to, ok := parse(end)
+	if !ok {
+		return fmt.Errorf("invalid value %q", end)
+	}
+	if from > to {
+		return fmt.Errorf("start of the range must not be after its end")
+	}
+	return nil
+}
+
+// validateDaysOfMonth validates a day of the month or a range of days.
+// Negative days count from the end of the month; a range from a positive to
+// a negative day, e.g. 15:-1, spans to the end of any month.
+func validateDaysOfMonth(r string) error {
+	parse := func(s string) (int, bool) {
+		v, err := strconv.Atoi(s)
+		return v, err == nil && v != 0 && v >= -31 && v <= 31
+	}
+	start, end, isRange := strings.Cut(r, ":")
+	from, ok := parse(start)
+	if !ok {
+		return fmt.Errorf("invalid day %q, must be 1 to 31 or -31 to -1", start)
+	}
+	if !isRange {
+		return nil
+	}
+	to, ok := parse(end)
+	if !ok {
+		return fmt.Errorf("invalid day %q, must be 1 to 31 or -31 to -1", end)
+	}
+	if (from > 0) == (to > 0) && from > to {
+		return fmt.Errorf("start of the range must not be after its end")
+	}
+	if from < 0 && to > 0 {
+		return fmt.Errorf("a range cannot start from the end of the month and end at a day from its start")
+	}
+	return nil
+}
@@ -0,0 +1,448 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package alloy builds Grafana Alloy as the collection layer of a platform.
+// Alloy runs as a DaemonSet; its configuration is generated from the enabled
+// pipelines and wires each signal to the platform's backend: metrics are
+// remote written to Prometheus, logs pushed to Loki, traces exported to Tempo
+// over OTLP and profiles pushed to a Pyroscope compatible endpoint.
+package alloy
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// Image is the Grafana Alloy image repository
+	Image = "grafana/alloy"
+	// HTTPPort serves Alloy's UI and metrics
+	HTTPPort int32 = 12345
+	// OTLPGRPCPort receives OTLP traces over gRPC
+	OTLPGRPCPort int32 = 4317
+	// OTLPHTTPPort receives OTLP traces over HTTP
+	OTLPHTTPPort int32 = 4318
+	// ConfigKey is the key of the configuration in the ConfigMap
+	ConfigKey = "config.alloy"
+
+	configPath = "/etc/alloy"
+)
+
+// Pipeline is the collection of one signal
+type Pipeline struct {
+	// Namespaces restricts discovery, all namespaces if empty
+	Namespaces []string
+	// Endpoint is the URL the signal is sent to
+	Endpoint string
+}
+
+// Config is the generated Alloy configuration. A nil pipeline is not
+// collected.
+type Config struct {
+	Metrics  *Pipeline
+	Logs     *Pipeline
+	Traces   *Pipeline
+	Profiles *Pipeline
+	// ExternalLabels are attached to all metrics, logs and profiles
+	ExternalLabels map[string]string
+	// ResourceAttributes are set on the resource of all traces
+	ResourceAttributes map[string]string
+	// TenantID is sent with the logs and traces, empty for the backend's
+	// default tenant
+	TenantID string
+	// ExtraConfig is appended verbatim
+	ExtraConfig string
+	// LogLevel is set in the logging block, unless ExtraConfig has one.
+	// Alloy applies it on a configuration reload.
+	LogLevel string
+}
+
+// Collector is an Alloy DaemonSet
+type Collector struct {
+	Name      string
+	Namespace string
+	Version   string
+	Config    Config
+	Resources corev1.ResourceRequirements
+	Labels    map[string]string
+}
+
+// CollectorName returns the name of the Alloy DaemonSet of a platform
+func CollectorName(platform string) string {
+	return fmt.Sprintf("alloy-%s", platform)
+}
+
+// ClusterRoleName returns the name of the ClusterRole and ClusterRoleBinding
+// of a platform's collector. They are cluster scoped, so the name includes
+// the namespace.
+func ClusterRoleName(platform, namespace string) string {
+	return fmt.Sprintf("gunj-alloy-%s-%s", namespace, platform)
+}
+
+// PrometheusEndpoint returns the remote write URL of a platform's Prometheus
+func PrometheusEndpoint(platform, namespace string) string {
+	return fmt.Sprintf("http://prometheus-%s.%s.svc.cluster.local:9090/api/v1/write", platform, namespace)
+}
+
+// LokiEndpoint returns the push URL of a platform's Loki
+func LokiEndpoint(platform, namespace string) string {
+	return fmt.Sprintf("http://loki-%s.%s.svc.cluster.local:3100/loki/api/v1/push", platform, namespace)
+}
+
+// TempoEndpoint returns the OTLP gRPC endpoint of a platform's Tempo
+func TempoEndpoint(platform, namespace string) string {
+	return fmt.Sprintf("%s-tempo.%s.svc.cluster.local:%d", platform, namespace, OTLPGRPCPort)
+}
+
+// Render returns the Alloy configuration
+func Render(c Config) string {
+	var b strings.Builder
+	block := func(name, label string, body ...string) {
+		fmt.Fprintf(&b, "%s %s {\n", name, strconv.Quote(label))
+		for _, line := range body {
+			if line == "" {
+				continue
+			}
+			fmt.Fprintf(&b, "  %s\n", strings.ReplaceAll(line, "\n", "\n  "))
+		}
+		b.WriteString("}\n\n")
+	}
+	if c.LogLevel != "" && !loggingBlock.MatchString(c.ExtraConfig) {
+		fmt.Fprintf(&b, "logging {\n  level = %s\n}\n\n", strconv.Quote(c.LogLevel))
+	}
+	labels := ""
+	if len(c.ExternalLabels) > 0 {
+		labels = "external_labels = " + object(c.ExternalLabels)
+	}
+
+	if c.Metrics != nil {
+		block("discovery.kubernetes", "metrics", `role = "pod"`, localPods, namespaces(c.Metrics.Namespaces))
+		block("discovery.relabel", "metrics",
+			"targets = discovery.kubernetes.metrics.targets",
+			rule(`source_labels = ["__meta_kubernetes_pod_annotation_prometheus_io_scrape"]`, `regex = "true"`, `action = "keep"`),
+			rule(`source_labels = ["__meta_kubernetes_pod_annotation_prometheus_io_path"]`, `regex = "(.+)"`, `target_label = "__metrics_path__"`),
+			rule(`source_labels = ["__address__", "__meta_kubernetes_pod_annotation_prometheus_io_port"]`,
+				`regex = "([^:]+)(?::\\d+)?;(\\d+)"`, `replacement = "$1:$2"`, `target_label = "__address__"`),
+			rule(`source_labels = ["__meta_kubernetes_namespace"]`, `target_label = "namespace"`),
+			rule(`source_labels = ["__meta_kubernetes_pod_name"]`, `target_label = "pod"`),
+		)
+		block("prometheus.scrape", "pods",
+			"targets    = discovery.relabel.metrics.output",
+			"forward_to = [prometheus.remote_write.platform.receiver]",
+		)
+		block("prometheus.remote_write", "platform", endpoint(c.Metrics.Endpoint), labels)
+	}
+
+	if c.Logs != nil {
+		block("discovery.kubernetes", "logs", `role = "pod"`, localPods, namespaces(c.Logs.Namespaces))
+		block("discovery.relabel", "logs",
+			"targets = discovery.kubernetes.logs.targets",
+			rule(`source_labels = ["__meta_kubernetes_namespace"]`, `target_label = "namespace"`),
+			rule(`source_labels = ["__meta_kubernetes_pod_name"]`, `target_label = "pod"`),
+			rule(`source_labels = ["__meta_kubernetes_pod_container_name"]`, `target_label = "container"`),
+		)
+		block("loki.source.kubernetes", "pods",
+			"targets    = discovery.relabel.logs.output",
+			"forward_to = [loki.write.platform.receiver]",
+		)
+		tenant := ""
+		if c.TenantID != "" {
+			tenant = "tenant_id = " + strconv.Quote(c.TenantID)
+		}
+		block("loki.write", "platform", endpoint(c.Logs.Endpoint, tenant), labels)
+	}
+
+	if c.Traces != nil {
+		next := "otelcol.processor.batch.default.input"
+		if len(c.ResourceAttributes) > 0 {
+			next = "otelcol.processor.transform.resource.input"
+		}
+		block("otelcol.receiver.otlp", "default",
+			fmt.Sprintf("grpc {\n  endpoint = \"0.0.0.0:%d\"\n}", OTLPGRPCPort),
+			fmt.Sprintf("http {\n  endpoint = \"0.0.0.0:%d\"\n}", OTLPHTTPPort),
+			fmt.Sprintf("output {\n  traces = [%s]\n}", next),
+		)
+		if len(c.ResourceAttributes) > 0 {
+			block("otelcol.processor.transform", "resource",
+				fmt.Sprintf("trace_statements {\n  context    = \"resource\"\n  statements = %s\n}", list(setAttributes(c.ResourceAttributes))),
+				"output {\n  traces = [otelcol.processor.batch.default.input]\n}",
+			)
+		}
+		block("otelcol.processor.batch", "default",
+			"output {\n  traces = [otelcol.exporter.otlp.platform.input]\n}",
+		)
+		headers := ""
+		if c.TenantID != "" {
+			headers = fmt.Sprintf("\n  headers = {\n    %s = %s,\n  }", strconv.Quote("X-Scope-OrgID"), strconv.Quote(c.TenantID))
+		}
+		block("otelcol.exporter.otlp", "platform",
+			fmt.Sprintf("client {\n  endpoint = %s%s\n  tls {\n    insecure = true\n  }\n}", strconv.Quote(c.Traces.Endpoint), headers),
+		)
+	}
+
+	if c.Profiles != nil {
+		block("discovery.kubernetes", "profiles", `role = "pod"`, localPods, namespaces(c.Profiles.Namespaces))
+		block("discovery.relabel", "profiles",
+			"targets = discovery.kubernetes.profiles.targets",
+			rule(`source_labels = ["__meta_kubernetes_pod_annotation_profiles_grafana_com_cpu_scrape"]`, `regex = "true"`, `action = "keep"`),
+			rule(`source_labels = ["__address__", "__meta_kubernetes_pod_annotation_profiles_grafana_com_cpu_port"]`,
+				`regex = "([^:]+)(?::\\d+)?;(\\d+)"`, `replacement = "$1:$2"`, `target_label = "__address__"`),
+			rule(`source_labels = ["__meta_kubernetes_namespace"]`, `target_label = "namespace"`),
+			rule(`source_labels = ["__meta_kubernetes_pod_name"]`, `target_label = "pod"`),
+			rule(`source_labels = ["__meta_kubernetes_pod_container_name"]`, `target_label = "service_name"`),
+		)
+		block("pyroscope.scrape", "pods",
+			"targets    = discovery.relabel.profiles.output",
+			"forward_to = [pyroscope.write.platform.receiver]",
+		)
+		block("pyroscope.write", "platform", endpoint(c.Profiles.Endpoint), labels)
+	}
+
+	if c.ExtraConfig != "" {
+		b.WriteString(strings.TrimSpace(c.ExtraConfig))
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// loggingBlock matches the logging block of a configuration; Alloy accepts
+// only one
+var loggingBlock = regexp.MustCompile(`(?m)^\s*logging\s*\{`)
+
+// localPods restricts discovery to the pods on the collector's node, so each
+// target is collected once
+const localPods = "selectors {\n  role  = \"pod\"\n  field = \"spec.nodeName=\" + sys.env(\"NODE_NAME\")\n}"
+
+// namespaces returns the namespaces block of a discovery component, empty
+// for all namespaces
+func namespaces(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("namespaces {\n  names = %s\n}", list(names))
+}
+
+// rule returns a relabelling rule block
+func rule(attributes ...string) string {
+	return "rule {\n  " + strings.Join(attributes, "\n  ") + "\n}"
+}
+
+// endpoint returns the endpoint block of a write component with optional
+// further attributes
+func endpoint(url string, attributes ...string) string {
+	body := "url = " + strconv.Quote(url)
+	for _, attribute := range attributes {
+		if attribute != "" {
+			body += "\n  " + attribute
+		}
+	}
+	return fmt.Sprintf("endpoint {\n  %s\n}", body)
+}
+
+// setAttributes returns the OTTL statements setting attributes, sorted by key
+func setAttributes(attributes map[string]string) []string {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	statements := make([]string, 0, len(keys))
+	for _, key := range keys {
+		statements = append(statements, fmt.Sprintf("set(attributes[%s], %s)", strconv.Quote(key), strconv.Quote(attributes[key])))
+	}
+	return statements
+}
+
+// list returns a list of strings in Alloy syntax
+func list(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, strconv.Quote(value))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// object returns a map of strings as an Alloy object with sorted keys
+func object(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s = %s", strconv.Quote(key), strconv.Quote(values[key])))
+	}
+	return "{\n  " + strings.Join(pairs, ",\n  ") + ",\n}"
+}
+
+// BuildConfigMap returns the ConfigMap holding the configuration
+func BuildConfigMap(c Collector) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.Name,
+			Namespace: c.Namespace,
+			Labels:    c.Labels,
+		},
+		Data: map[string]string{ConfigKey: Render(c.Config)},
+	}
+}
+
+// BuildServiceAccount returns the ServiceAccount the collector runs as
+func BuildServiceAccount(c Collector) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.Name,
+			Namespace: c.Namespace,
+			Labels:    c.Labels,
+		},
+	}
+}
+
+// BuildClusterRole returns the ClusterRole allowing the collector to discover
+// pods and read their logs in all namespaces
+func BuildClusterRole(name string, labels map[string]string) *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"pods", "nodes", "namespaces"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"pods/log"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+}
+
+// BuildClusterRoleBinding binds the ClusterRole to the collector's
+// ServiceAccount
+func BuildClusterRoleBinding(name string, c Collector) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: c.Labels},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     name,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      c.Name,
+			Namespace: c.Namespace,
+		}},
+	}
+}
+
+// BuildDaemonSet returns the collector DaemonSet. The pods restart when the
+// configuration changes, as the template is annotated with its checksum by
+// the caller.
+func BuildDaemonSet(c Collector) *appsv1.DaemonSet {
+	ports := []corev1.ContainerPort{{Name: "http", ContainerPort: HTTPPort, Protocol: corev1.ProtocolTCP}}
+	if c.Config.Traces != nil {
+		ports = append(ports,
+			corev1.ContainerPort{Name: "otlp-grpc", ContainerPort: OTLPGRPCPort, Protocol: corev1.ProtocolTCP},
+			corev1.ContainerPort{Name: "otlp-http", ContainerPort: OTLPHTTPPort, Protocol: corev1.ProtocolTCP},
+		)
+	}
+
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.Name,
+			Namespace: c.Namespace,
+			Labels:    c.Labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: c.Labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: c.Labels},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: c.Name,
+					Tolerations:        []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+					Containers: []corev1.Container{{
+						Name:  "alloy",
+						Image: fmt.Sprintf("%s:%s", Image, c.Version),
+						Args: []string{
+							"run",
+							fmt.Sprintf("%s/%s", configPath, ConfigKey),
+							fmt.Sprintf("--server.http.listen-addr=0.0.0.0:%d", HTTPPort),
+							"--storage.path=/tmp/alloy",
+							"--stability.level=generally-available",
+						},
+						Env: []corev1.EnvVar{{
+							Name: "NODE_NAME",
+							ValueFrom: &corev1.EnvVarSource{
+								FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+							},
+						}},
+						Ports:     ports,
+						Resources: c.Resources,
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{Path: "/-/ready", Port: intstr.FromString("http")},
+							},
+							PeriodSeconds: 10,
+						},
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: &[]bool{false}[0],
+							ReadOnlyRootFilesystem:   &[]bool{true}[0],
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "config", MountPath: configPath},
+							{Name: "storage", MountPath: "/tmp/alloy"},
+						},
+					}},
+					Volumes: []corev1.Volume{
+						{
+							Name: "config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: c.Name},
+								},
+							},
+						},
+						{
+							Name:         "storage",
+							VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// BuildService returns the Service applications send OTLP traces to
+func BuildService(c Collector) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.Name,
+			Namespace: c.Namespace,
+			Labels:    c.Labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: c.Labels,
+			// Send traces to the collector on the same node
+			InternalTrafficPolicy: &[]corev1.ServiceInternalTrafficPolicy{corev1.ServiceInternalTrafficPolicyLocal}[0],
+			Ports: []corev1.ServicePort{
+				{Name: "otlp-grpc", Port: OTLPGRPCPort, TargetPort: intstr.FromString("otlp-grpc"), Protocol: corev1.ProtocolTCP},
+				{Name: "otlp-http", Port: OTLPHTTPPort, TargetPort: intstr.FromString("otlp-http"), Protocol: corev1.ProtocolTCP},
+			},
+		},
+	}
+}
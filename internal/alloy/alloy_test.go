@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package alloy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderWiresPipelinesToBackends(t *testing.T) {
+	config := Render(Config{
+		Metrics:        &Pipeline{Endpoint: PrometheusEndpoint("prod", "monitoring")},
+		Logs:           &Pipeline{Endpoint: LokiEndpoint("prod", "monitoring"), Namespaces: []string{"shop", "payments"}},
+		Traces:         &Pipeline{Endpoint: TempoEndpoint("prod", "monitoring")},
+		ExternalLabels: map[string]string{"platform": "prod", "cluster": "eu-1"},
+	})
+
+	assert.Contains(t, config, `prometheus.remote_write "platform" {
+  endpoint {
+    url = "http://prometheus-prod.monitoring.svc.cluster.local:9090/api/v1/write"
+  }
+  external_labels = {
+    "cluster" = "eu-1",
+    "platform" = "prod",
+  }
+}`)
+	assert.Contains(t, config, "forward_to = [prometheus.remote_write.platform.receiver]")
+	assert.Contains(t, config, `url = "http://loki-prod.monitoring.svc.cluster.local:3100/loki/api/v1/push"`)
+	assert.Contains(t, config, `names = ["shop", "payments"]`)
+	assert.Contains(t, config, `endpoint = "prod-tempo.monitoring.svc.cluster.local:4317"`)
+	assert.Contains(t, config, `field = "spec.nodeName=" + sys.env("NODE_NAME")`)
+	assert.NotContains(t, config, "pyroscope")
+}
+
+func TestRenderSetsCorrelationOnLogsAndTraces(t *testing.T) {
+	config := Render(Config{
+		Logs:               &Pipeline{Endpoint: LokiEndpoint("prod", "monitoring")},
+		Traces:             &Pipeline{Endpoint: TempoEndpoint("prod", "monitoring")},
+		ResourceAttributes: map[string]string{"region": "eu", "cluster": "eu-1"},
+		TenantID:           "team-a",
+	})
+
+	assert.Contains(t, config, `loki.write "platform" {
+  endpoint {
+    url = "http://loki-prod.monitoring.svc.cluster.local:3100/loki/api/v1/push"
+    tenant_id = "team-a"
+  }
+}`)
+	assert.Contains(t, config, "traces = [otelcol.processor.transform.resource.input]")
+	assert.Contains(t, config, `otelcol.processor.transform "resource" {
+  trace_statements {
+    context    = "resource"
+    statements = ["set(attributes[\"cluster\"], \"eu-1\")", "set(attributes[\"region\"], \"eu\")"]
+  }
+  output {
+    traces = [otelcol.processor.batch.default.input]
+  }
+}`)
+	assert.Contains(t, config, `"X-Scope-OrgID" = "team-a",`)
+
+	config = Render(Config{Traces: &Pipeline{Endpoint: TempoEndpoint("prod", "monitoring")}})
+	assert.Contains(t, config, "traces = [otelcol.processor.batch.default.input]")
+	assert.NotContains(t, config, "transform")
+	assert.NotContains(t, config, "headers")
+}
+
+func TestRenderSkipsDisabledPipelines(t *testing.T) {
+	config := Render(Config{
+		Profiles:    &Pipeline{Endpoint: "http://pyroscope:4040"},
+		ExtraConfig: "\nlogging {\n  level = \"debug\"\n}\n",
+	})
+
+	assert.Contains(t, config, `pyroscope.write "platform"`)
+	assert.Contains(t, config, "__meta_kubernetes_pod_annotation_profiles_grafana_com_cpu_scrape")
+	assert.NotContains(t, config, "prometheus.remote_write")
+	assert.NotContains(t, config, "loki.write")
+	assert.NotContains(t, config, "otelcol")
+	assert.NotContains(t, config, "namespaces {")
+	assert.True(t, len(config) > 0 && config[len(config)-1] == '}', "extra config is appended last")
+}
+
+func TestRenderLogLevel(t *testing.T) {
+	config := Render(Config{LogLevel: "debug", Metrics: &Pipeline{Endpoint: "http://prometheus:9090/api/v1/write"}})
+	assert.True(t, strings.HasPrefix(config, "logging {\n  level = \"debug\"\n}\n\n"), config)
+
+	// The logging block of the extra configuration takes precedence
+	config = Render(Config{LogLevel: "warn", ExtraConfig: "logging {\n  level = \"error\"\n}"})
+	assert.Equal(t, 1, strings.Count(config, "logging {"))
+	assert.Contains(t, config, `level = "error"`)
+
+	assert.NotContains(t, Render(Config{Metrics: &Pipeline{Endpoint: "http://prometheus:9090/api/v1/write"}}), "logging")
+}
+
+func TestBuildDaemonSetExposesOTLPWithTraces(t *testing.T) {
+	c := Collector{
+		Name:      CollectorName("prod"),
+		Namespace: "monitoring",
+		Version:   "v1.5.1",
+		Labels:    map[string]string{"app.kubernetes.io/name": "alloy"},
+	}
+
+	ds := BuildDaemonSet(c)
+	require.Len(t, ds.Spec.Template.Spec.Containers, 1)
+	container := ds.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "alloy-prod", ds.Name)
+	assert.Equal(t, "grafana/alloy:v1.5.1", container.Image)
+	assert.Equal(t, "alloy-prod", ds.Spec.Template.Spec.ServiceAccountName)
+	assert.Len(t, container.Ports, 1)
+
+	c.Config.Traces = &Pipeline{Endpoint: TempoEndpoint("prod", "monitoring")}
+	container = BuildDaemonSet(c).Spec.Template.Spec.Containers[0]
+	assert.Len(t, container.Ports, 3)
+
+	binding := BuildClusterRoleBinding(ClusterRoleName("prod", "monitoring"), c)
+	assert.Equal(t, "gunj-alloy-monitoring-prod", binding.RoleRef.Name)
+	assert.Equal(t, "monitoring", binding.Subjects[0].Namespace)
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/apikeys"
+)
+
+// lookupAPIKey returns the ApiKey accepting a key, nil if none does. Keys
+// are found by their id and verified against the hashes of the ApiKeys.
+func (s *Server) lookupAPIKey(ctx context.Context, key string) (*observabilityv1beta1.ApiKey, error) {
+	id := apikeys.ID(key)
+	if id == "" {
+		return nil, nil
+	}
+
+	list := &observabilityv1beta1.ApiKeyList{}
+	if err := s.client.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	now := time.Now()
+	for i := range list.Items {
+		apiKey := &list.Items[i]
+		for _, cred := range apiKey.Status.Keys {
+			if cred.ID == id && apikeys.Verify(apiKey, key, now) {
+				return apiKey, nil
+			}
+		}
+	}
+	return nil, nil
+}
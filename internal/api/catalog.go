@@ -0,0 +1,115 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gunjanjp/gunj-operator/pkg/catalog"
+)
+
+// instantiateRequest is the body of a catalog entry instantiation
+type instantiateRequest struct {
+	Name      string `json:"name" binding:"required"`
+	Namespace string `json:"namespace"`
+	// DryRun returns the platform without creating it
+	DryRun bool `json:"dryRun"`
+}
+
+// handleListCatalog returns the entries of the platform catalogs, filtered
+// by the tag query parameter
+func (s *Server) handleListCatalog(c *gin.Context) {
+	entries, err := catalog.List(c.Request.Context(), s.client)
+	if err != nil {
+		s.log.Error(err, "Failed to list platform catalogs")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if tag := c.Query("tag"); tag != "" {
+		filtered := make([]catalog.Entry, 0, len(entries))
+		for _, entry := range entries {
+			for _, entryTag := range entry.Tags {
+				if entryTag == tag {
+					filtered = append(filtered, entry)
+					break
+				}
+			}
+		}
+		entries = filtered
+	}
+	c.JSON(http.StatusOK, gin.H{"items": entries})
+}
+
+// handleGetCatalogEntry returns a catalog entry
+func (s *Server) handleGetCatalogEntry(c *gin.Context) {
+	entry, ok := s.findCatalogEntry(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, entry)
+}
+
+// handleInstantiateCatalogEntry creates a platform from a catalog entry
+func (s *Server) handleInstantiateCatalogEntry(c *gin.Context) {
+	request := instantiateRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if request.Namespace == "" {
+		request.Namespace = "default"
+	}
+
+	entry, ok := s.findCatalogEntry(c)
+	if !ok {
+		return
+	}
+	platform, err := catalog.Instantiate(entry, request.Name, request.Namespace)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var opts []client.CreateOption
+	if request.DryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := s.client.Create(c.Request.Context(), platform, opts...); err != nil {
+		switch {
+		case errors.IsAlreadyExists(err):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.IsInvalid(err) || errors.IsForbidden(err):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			s.log.Error(err, "Failed to instantiate catalog entry", "entry", entry.Ref())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	status := http.StatusCreated
+	if request.DryRun {
+		status = http.StatusOK
+	}
+	c.JSON(status, platform.Object)
+}
+
+// findCatalogEntry returns the entry of the catalog and entry parameters,
+// writing the error response when it is not found
+func (s *Server) findCatalogEntry(c *gin.Context) (*catalog.Entry, bool) {
+	entries, err := catalog.List(c.Request.Context(), s.client)
+	if err != nil {
+		s.log.Error(err, "Failed to list platform catalogs")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	entry, err := catalog.Find(entries, c.Param("catalog")+"/"+c.Param("entry"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return entry, true
+}
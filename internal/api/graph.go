@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/graph"
+)
+
+// graphContentTypes maps graph formats to response content types
+var graphContentTypes = map[graph.Format]string{
+	graph.FormatDOT:     "text/vnd.graphviz; charset=utf-8",
+	graph.FormatMermaid: "text/plain; charset=utf-8",
+	graph.FormatJSON:    "application/json; charset=utf-8",
+}
+
+// handlePlatformGraph returns the object graph of a platform.
+// The namespace and format (dot, mermaid, json) are query parameters.
+func (s *Server) handlePlatformGraph(c *gin.Context) {
+	format, err := graph.ParseFormat(c.DefaultQuery("format", string(graph.FormatJSON)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	platform := &observabilityv1beta1.ObservabilityPlatform{}
+	key := client.ObjectKey{Namespace: c.DefaultQuery("namespace", "default"), Name: c.Param("name")}
+	if err := s.client.Get(c.Request.Context(), key, platform); err != nil {
+		if errors.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "platform not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	g, err := graph.Collect(c.Request.Context(), s.client, platform)
+	if err != nil {
+		s.log.Error(err, "Failed to collect platform graph", "platform", key)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", graphContentTypes[format])
+	c.Status(http.StatusOK)
+	if err := g.Render(c.Writer, format); err != nil {
+		s.log.Error(err, "Failed to render platform graph", "platform", key)
+	}
+}
@@ -1,15 +1,28 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-logr/logr"
 	"golang.org/x/time/rate"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/apikeys"
+	"github.com/gunjanjp/gunj-operator/internal/siem"
 )
 
+// ContextAPIKey is the context key of the ApiKey of a request authenticated
+// by an API key
+const ContextAPIKey = "apikey"
+
+// APIKeyLookup returns the ApiKey accepting a key, nil if none does
+type APIKeyLookup func(ctx context.Context, key string) (*observabilityv1beta1.ApiKey, error)
+
 // Logger returns a middleware that logs HTTP requests
 func Logger(log logr.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -45,9 +58,67 @@ func Logger(log logr.Logger) gin.HandlerFunc {
 	}
 }
 
+// PlatformKey returns the namespace and name of the platform of a request.
+// On /platforms/{namespace}/{platform}/... routes, the namespace is the name
+// parameter, as gin only allows one wildcard name per path segment; on the
+// other routes it is the namespace query parameter.
+func PlatformKey(c *gin.Context) (namespace, name string) {
+	if platform := c.Param("platform"); platform != "" {
+		return c.Param("name"), platform
+	}
+	return c.DefaultQuery("namespace", "default"), c.Param("name")
+}
+
+// AuthenticateAPIKey authenticates the requests bearing the key of an ApiKey
+// and authorizes them by its scopes, for the platform of the key only. Other
+// requests are left to Authenticate and Authorize.
+func AuthenticateAPIKey(lookup APIKeyLookup) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !apikeys.IsKey(key) {
+			c.Next()
+			return
+		}
+
+		apiKey, err := lookup(c.Request.Context(), key)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to verify API key",
+			})
+			return
+		}
+		if apiKey == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "invalid API key",
+			})
+			return
+		}
+
+		namespace, platform := PlatformKey(c)
+		if !apikeys.Permits(apiKey, c.Request.Method, c.FullPath(), namespace, platform) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "API key not permitted",
+			})
+			return
+		}
+
+		c.Set(ContextAPIKey, apiKey)
+		c.Set("user", "apikey:"+apiKey.Namespace+"/"+apiKey.Name)
+		c.Set("tenant", apiKey.Spec.Tenant)
+
+		c.Next()
+	}
+}
+
 // Authenticate validates JWT tokens and extracts user information
 func Authenticate(config *Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Requests authenticated by an API key are authorized by its scopes
+		if _, ok := c.Get(ContextAPIKey); ok {
+			c.Next()
+			return
+		}
+
 		// Get token from Authorization header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -87,6 +158,11 @@ func Authenticate(config *Config) gin.HandlerFunc {
 // Authorize checks if the authenticated user has permission for the requested resource
 func Authorize() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if _, ok := c.Get(ContextAPIKey); ok {
+			c.Next()
+			return
+		}
+
 		// Get user information from context
 		user, exists := c.Get("user")
 		if !exists {
@@ -121,4 +197,51 @@ func RateLimit(limiter *rate.Limiter) gin.HandlerFunc {
 		}
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+
+// Audit returns a middleware that exports an audit record for every mutating
+// request and every rejected request. It must run ahead of authentication, which
+// aborts the requests it rejects.
+func Audit(exporter *siem.Exporter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		method := c.Request.Method
+		statusCode := c.Writer.Status()
+		readOnly := method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+		if readOnly && statusCode != http.StatusUnauthorized && statusCode != http.StatusForbidden {
+			return
+		}
+
+		outcome := "success"
+		severity := siem.SeverityLow
+		if statusCode >= 400 {
+			outcome = "failure"
+			severity = siem.SeverityMedium
+		}
+		if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+			severity = siem.SeverityHigh
+		}
+
+		namespace, name := c.Query("namespace"), c.Param("name")
+		if c.Param("platform") != "" {
+			namespace, name = PlatformKey(c)
+		}
+		exporter.Publish(siem.Record{
+			Kind:      siem.KindAudit,
+			Timestamp: time.Now(),
+			Action:    method + " " + c.FullPath(),
+			Severity:  severity,
+			User:      c.GetString("user"),
+			SourceIP:  c.ClientIP(),
+			Namespace: namespace,
+			Name:      name,
+			Resource:  c.Request.URL.Path,
+			Outcome:   outcome,
+			Details: map[string]string{
+				"status":    strconv.Itoa(statusCode),
+				"requestID": c.GetString("request-id"),
+			},
+		})
+	}
+}
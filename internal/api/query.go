@@ -0,0 +1,113 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/api/middleware"
+	"github.com/gunjanjp/gunj-operator/internal/queryproxy"
+)
+
+// handlePlatformQuery forwards a PromQL, LogQL or TraceQL query to the
+// backend of a platform. The language is a query parameter (promql by
+// default) and the query is in the query parameter or form field; the other
+// parameters, such as start, end and step, are passed to the backend. The
+// namespace is a path segment of /platforms/{namespace}/{name}/query, or the
+// query parameter of /platforms/{name}/query like on the other platform
+// routes.
+func (s *Server) handlePlatformQuery(c *gin.Context) {
+	language, err := queryproxy.ParseLanguage(c.Query("language"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	query := c.Query("query")
+	if query == "" {
+		query = c.PostForm("query")
+	}
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing query"})
+		return
+	}
+
+	platform := &observabilityv1beta1.ObservabilityPlatform{}
+	key := client.ObjectKey{}
+	key.Namespace, key.Name = middleware.PlatformKey(c)
+	if err := s.client.Get(c.Request.Context(), key, platform); err != nil {
+		if errors.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "platform not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Parse the form before the ranged check, so start and end may be
+	// posted as well
+	_ = c.Request.ParseForm()
+	target, err := queryproxy.TargetFor(platform, language, queryproxy.Ranged(c.Request.Form))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The platform writes its logs and traces as its tenant with
+	// multi-tenancy; without, the tenant of the namespace is sent
+	tenant := platform.TenantID()
+	if tenant == "" {
+		namespace := &corev1.Namespace{}
+		if err := s.client.Get(c.Request.Context(), client.ObjectKey{Name: key.Namespace}, namespace); err != nil {
+			s.log.Error(err, "Failed to get platform namespace, using the default tenant", "platform", key)
+		}
+		tenant = queryproxy.Tenant(namespace.Labels)
+	}
+	// A key with the query scope queries as its tenant, which must be
+	// enforced by the backend
+	if apiKeyTenant := c.GetString("tenant"); apiKeyTenant != "" {
+		tenant = apiKeyTenant
+		if status, err := enforceTenant(platform, language, tenant, query, target); err != nil {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	s.log.V(1).Info("Forwarding query", "platform", key, "language", language, "backend", target.URL.Host, "tenant", tenant)
+	proxy := queryproxy.NewProxy(target, tenant)
+	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		s.log.Error(err, "Failed to forward query", "platform", key, "backend", target.URL.Host)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "backend unavailable"})
+	}
+	proxy.ServeHTTP(c.Writer, c.Request)
+}
+
+// enforceTenant restricts the query of an API key to its tenant. Loki and
+// Tempo only keep tenants apart with multi-tenancy. Prometheus has no
+// tenants, so the query is restricted to the label values of the query
+// access control teams querying the tenant. The status is the one of the
+// response if the query is refused.
+func enforceTenant(platform *observabilityv1beta1.ObservabilityPlatform, language queryproxy.Language, tenant, query string, target *queryproxy.Target) (int, error) {
+	if language != queryproxy.PromQL {
+		if !platform.IsMultiTenant() {
+			return http.StatusForbidden, fmt.Errorf("%s queries of tenant %s can't be restricted: Loki and Tempo run without multi-tenancy", language, tenant)
+		}
+		return http.StatusOK, nil
+	}
+
+	acl := platform.Spec.QueryACL
+	values := acl.TenantValues(tenant)
+	if len(values) == 0 {
+		return http.StatusForbidden, fmt.Errorf("promql queries of tenant %s can't be restricted: no query access control team has the tenant", tenant)
+	}
+	enforced, err := queryproxy.EnforceLabel(query, acl.GetLabel(), values)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid query: %w", err)
+	}
+	target.Query = enforced
+	return http.StatusOK, nil
+}
@@ -3,6 +3,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -16,6 +17,8 @@ import (
 
 	"github.com/gunjanjp/gunj-operator/internal/api/handlers"
 	"github.com/gunjanjp/gunj-operator/internal/api/middleware"
+	"github.com/gunjanjp/gunj-operator/internal/api/stream"
+	"github.com/gunjanjp/gunj-operator/internal/siem"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -26,6 +29,7 @@ type Server struct {
 	log        logr.Logger
 	config     *Config
 	httpServer *http.Server
+	broker     *stream.Broker
 }
 
 // Config holds API server configuration
@@ -43,6 +47,10 @@ type Config struct {
 	WriteTimeout      time.Duration
 	IdleTimeout       time.Duration
 	ShutdownTimeout   time.Duration
+
+	// SIEMExporter receives audit records for mutating and rejected API
+	// requests (optional)
+	SIEMExporter *siem.Exporter
 }
 
 // NewServer creates a new API server instance
@@ -61,6 +69,7 @@ func NewServer(client client.Client, log logr.Logger, config *Config) *Server {
 		client: client,
 		log:    log.WithName("api-server"),
 		config: config,
+		broker: stream.NewBroker(0),
 	}
 }
 
@@ -106,7 +115,15 @@ func (s *Server) setupRoutes() {
 	// API v1 routes
 	v1 := s.router.Group("/api/v1")
 	{
-		// Apply authentication middleware to API routes
+		// Audit ahead of authentication, so requests rejected by it are
+		// audited too
+		if s.config.SIEMExporter != nil {
+			v1.Use(middleware.Audit(s.config.SIEMExporter))
+		}
+
+		// Apply authentication middleware to API routes; the keys of
+		// ApiKeys are authorized by their scopes
+		v1.Use(middleware.AuthenticateAPIKey(s.lookupAPIKey))
 		v1.Use(middleware.Authenticate(s.config))
 		v1.Use(middleware.Authorize())
 
@@ -128,12 +145,30 @@ func (s *Server) setupRoutes() {
 			// Platform metrics and health
 			platforms.GET("/:name/metrics", handlers.GetPlatformMetrics(s.client))
 			platforms.GET("/:name/health", handlers.GetPlatformHealth(s.client))
+			platforms.GET("/:name/graph", s.handlePlatformGraph)
+			platforms.GET("/:name/timeline", s.handlePlatformTimeline)
+
+			// Query passthrough to the platform's backends, by namespace and name.
+			// The namespace is the first segment of the namespaced route, which
+			// gin requires to share the wildcard name of the routes above.
+			platforms.GET("/:name/query", s.handlePlatformQuery)
+			platforms.POST("/:name/query", s.handlePlatformQuery)
+			platforms.GET("/:name/:platform/query", s.handlePlatformQuery)
+			platforms.POST("/:name/:platform/query", s.handlePlatformQuery)
 
 			// Component management
 			platforms.GET("/:name/components", handlers.ListComponents(s.client))
 			platforms.PUT("/:name/components/:component", handlers.UpdateComponent(s.client))
 		}
 
+		// Example platforms of the platform catalogs
+		catalogs := v1.Group("/catalog")
+		{
+			catalogs.GET("", s.handleListCatalog)
+			catalogs.GET("/:catalog/:entry", s.handleGetCatalogEntry)
+			catalogs.POST("/:catalog/:entry/instantiate", s.handleInstantiateCatalogEntry)
+		}
+
 		// Alerting rules
 		alerts := v1.Group("/alerts")
 		{
@@ -185,14 +220,33 @@ func (s *Server) Start(ctx context.Context) error {
 		IdleTimeout:  s.config.IdleTimeout,
 	}
 
+	// Shut down once the context is done, so the server can run as a
+	// runnable of the controller manager
+	go func() {
+		<-ctx.Done()
+		if err := s.Shutdown(context.Background()); err != nil {
+			s.log.Error(err, "Failed to shut down API server")
+		}
+	}()
+
 	// Start server
+	var err error
 	if s.config.TLSEnabled {
 		s.log.Info("Starting HTTPS server", "port", s.config.Port)
-		return s.httpServer.ListenAndServeTLS(s.config.TLSCertPath, s.config.TLSKeyPath)
+		err = s.httpServer.ListenAndServeTLS(s.config.TLSCertPath, s.config.TLSKeyPath)
+	} else {
+		s.log.Info("Starting HTTP server", "port", s.config.Port)
+		err = s.httpServer.ListenAndServe()
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
 	}
+	return err
+}
 
-	s.log.Info("Starting HTTP server", "port", s.config.Port)
-	return s.httpServer.ListenAndServe()
+// NeedLeaderElection returns false so every replica serves the API
+func (s *Server) NeedLeaderElection() bool {
+	return false
 }
 
 // Shutdown gracefully shuts down the API server
@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package stream fans platform status updates out to streaming API clients.
+package stream
+
+import (
+	"sync"
+	"time"
+)
+
+// UpdateType describes what happened to a platform
+type UpdateType string
+
+const (
+	UpdateTypeAdded    UpdateType = "ADDED"
+	UpdateTypeModified UpdateType = "MODIFIED"
+	UpdateTypeDeleted  UpdateType = "DELETED"
+)
+
+// ComponentUpdate is the health of a single component
+type ComponentUpdate struct {
+	Ready    bool   `json:"ready"`
+	Version  string `json:"version,omitempty"`
+	Replicas int32  `json:"replicas"`
+	Message  string `json:"message,omitempty"`
+}
+
+// Update is a platform status change pushed to subscribers
+type Update struct {
+	Type               UpdateType                 `json:"type"`
+	Namespace          string                     `json:"namespace"`
+	Name               string                     `json:"name"`
+	Phase              string                     `json:"phase,omitempty"`
+	Message            string                     `json:"message,omitempty"`
+	ObservedGeneration int64                      `json:"observedGeneration,omitempty"`
+	Components         map[string]ComponentUpdate `json:"components,omitempty"`
+	Timestamp          time.Time                  `json:"timestamp"`
+}
+
+// Filter restricts the updates delivered to a subscriber. Empty fields match everything.
+type Filter struct {
+	Namespace string
+	Name      string
+}
+
+// Matches reports whether the update passes the filter
+func (f Filter) Matches(update Update) bool {
+	if f.Namespace != "" && f.Namespace != update.Namespace {
+		return false
+	}
+	if f.Name != "" && f.Name != update.Name {
+		return false
+	}
+	return true
+}
+
+// subscriber is a single streaming client
+type subscriber struct {
+	filter Filter
+	ch     chan Update
+}
+
+// Broker distributes updates to subscribers. Slow subscribers never block
+// publishers; when a subscriber's buffer is full its oldest update is dropped.
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[*subscriber]struct{}
+	bufferSize  int
+}
+
+// NewBroker creates a broker with the given per-subscriber buffer size
+func NewBroker(bufferSize int) *Broker {
+	if bufferSize <= 0 {
+		bufferSize = 64
+	}
+	return &Broker{
+		subscribers: make(map[*subscriber]struct{}),
+		bufferSize:  bufferSize,
+	}
+}
+
+// Subscribe registers a subscriber and returns its update channel together
+// with a function that unsubscribes and closes the channel.
+func (b *Broker) Subscribe(filter Filter) (<-chan Update, func()) {
+	sub := &subscriber{
+		filter: filter,
+		ch:     make(chan Update, b.bufferSize),
+	}
+
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, sub)
+			close(sub.ch)
+			b.mu.Unlock()
+		})
+	}
+	return sub.ch, cancel
+}
+
+// Publish delivers an update to all matching subscribers
+func (b *Broker) Publish(update Update) {
+	if update.Timestamp.IsZero() {
+		update.Timestamp = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subscribers {
+		if !sub.filter.Matches(update) {
+			continue
+		}
+		for {
+			select {
+			case sub.ch <- update:
+			default:
+				// Drop the oldest update to make room
+				select {
+				case <-sub.ch:
+				default:
+				}
+				continue
+			}
+			break
+		}
+	}
+}
+
+// SubscriberCount returns the number of active subscribers
+func (b *Broker) SubscriberCount() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers)
+}
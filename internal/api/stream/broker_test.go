@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBrokerFiltersUpdates(t *testing.T) {
+	broker := NewBroker(4)
+
+	all, cancelAll := broker.Subscribe(Filter{})
+	defer cancelAll()
+	prod, cancelProd := broker.Subscribe(Filter{Namespace: "monitoring", Name: "prod"})
+	defer cancelProd()
+
+	broker.Publish(Update{Type: UpdateTypeModified, Namespace: "monitoring", Name: "prod", Phase: "Ready"})
+	broker.Publish(Update{Type: UpdateTypeModified, Namespace: "monitoring", Name: "staging", Phase: "Failed"})
+
+	assert.Len(t, all, 2)
+	assert.Len(t, prod, 1)
+
+	update := <-prod
+	assert.Equal(t, "prod", update.Name)
+	assert.False(t, update.Timestamp.IsZero())
+}
+
+func TestBrokerDropsOldestForSlowSubscribers(t *testing.T) {
+	broker := NewBroker(2)
+	ch, cancel := broker.Subscribe(Filter{})
+	defer cancel()
+
+	for _, phase := range []string{"Pending", "Installing", "Ready"} {
+		broker.Publish(Update{Name: "prod", Phase: phase})
+	}
+
+	assert.Equal(t, "Installing", (<-ch).Phase)
+	assert.Equal(t, "Ready", (<-ch).Phase)
+}
+
+func TestBrokerUnsubscribe(t *testing.T) {
+	broker := NewBroker(1)
+	ch, cancel := broker.Subscribe(Filter{})
+	assert.Equal(t, 1, broker.SubscriberCount())
+
+	cancel()
+	cancel()
+
+	_, ok := <-ch
+	assert.False(t, ok)
+	assert.Equal(t, 0, broker.SubscriberCount())
+
+	// Publishing after unsubscribe must not panic
+	broker.Publish(Update{Name: "prod"})
+}
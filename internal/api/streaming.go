@@ -0,0 +1,248 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/api/middleware"
+	"github.com/gunjanjp/gunj-operator/internal/api/stream"
+)
+
+const (
+	// streamKeepAlive is how often idle streams are pinged to keep proxies from closing them
+	streamKeepAlive = 30 * time.Second
+)
+
+// WatchPlatforms feeds platform status changes from the shared informer into
+// the stream broker. It must be called before Start for streaming endpoints
+// to receive updates.
+func (s *Server) WatchPlatforms(ctx context.Context, informers cache.Informers) error {
+	informer, err := informers.GetInformer(ctx, &observabilityv1beta1.ObservabilityPlatform{})
+	if err != nil {
+		return fmt.Errorf("failed to get platform informer: %w", err)
+	}
+
+	_, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if platform, ok := obj.(*observabilityv1beta1.ObservabilityPlatform); ok {
+				s.broker.Publish(platformUpdate(stream.UpdateTypeAdded, platform))
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldPlatform, ok := oldObj.(*observabilityv1beta1.ObservabilityPlatform)
+			if !ok {
+				return
+			}
+			newPlatform, ok := newObj.(*observabilityv1beta1.ObservabilityPlatform)
+			if !ok {
+				return
+			}
+			// Only status and component health changes are interesting to clients
+			if statusEqual(oldPlatform, newPlatform) {
+				return
+			}
+			s.broker.Publish(platformUpdate(stream.UpdateTypeModified, newPlatform))
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if platform, ok := obj.(*observabilityv1beta1.ObservabilityPlatform); ok {
+				s.broker.Publish(platformUpdate(stream.UpdateTypeDeleted, platform))
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register platform event handler: %w", err)
+	}
+	return nil
+}
+
+// setupSSE configures the Server-Sent Events stream of platform status updates
+func (s *Server) setupSSE() {
+	group := s.router.Group("/api/v1/stream")
+	group.Use(middleware.Authenticate(s.config))
+	group.Use(middleware.Authorize())
+
+	group.GET("/platforms", s.handleSSE)
+}
+
+// setupWebSocket configures the WebSocket stream of platform status updates
+func (s *Server) setupWebSocket() {
+	group := s.router.Group("/api/v1/ws")
+	group.Use(middleware.Authenticate(s.config))
+	group.Use(middleware.Authorize())
+
+	group.GET("/platforms", s.handleWebSocket)
+}
+
+// handleSSE streams platform updates as Server-Sent Events
+func (s *Server) handleSSE(c *gin.Context) {
+	updates, cancel := s.broker.Subscribe(streamFilter(c))
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return false
+			}
+			c.SSEvent("platform", update)
+			return true
+		case <-keepAlive.C:
+			_, _ = io.WriteString(w, ": keep-alive\n\n")
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+// wsUpgrader upgrades streaming requests to WebSocket connections
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// handleWebSocket streams platform updates over a WebSocket connection
+func (s *Server) handleWebSocket(c *gin.Context) {
+	upgrader := wsUpgrader
+	upgrader.CheckOrigin = s.checkOrigin
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		s.log.V(1).Info("WebSocket upgrade failed", "error", err.Error())
+		return
+	}
+	defer conn.Close()
+
+	updates, cancel := s.broker.Subscribe(streamFilter(c))
+	defer cancel()
+
+	// Read pump: we do not expect messages from the client, but reading is
+	// required to process control frames and detect disconnects.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(streamKeepAlive)
+	defer ping.Stop()
+
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(update)
+			if err != nil {
+				continue
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+// checkOrigin allows WebSocket connections from the configured CORS origins
+func (s *Server) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range s.config.CORSAllowOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// streamFilter builds a subscription filter from the request query parameters
+func streamFilter(c *gin.Context) stream.Filter {
+	return stream.Filter{
+		Namespace: c.Query("namespace"),
+		Name:      c.Query("name"),
+	}
+}
+
+// platformUpdate converts a platform into a stream update
+func platformUpdate(updateType stream.UpdateType, platform *observabilityv1beta1.ObservabilityPlatform) stream.Update {
+	update := stream.Update{
+		Type:               updateType,
+		Namespace:          platform.Namespace,
+		Name:               platform.Name,
+		Phase:              platform.Status.Phase,
+		Message:            platform.Status.Message,
+		ObservedGeneration: platform.Status.ObservedGeneration,
+		Timestamp:          time.Now(),
+	}
+
+	if len(platform.Status.ComponentStatuses) > 0 {
+		update.Components = make(map[string]stream.ComponentUpdate, len(platform.Status.ComponentStatuses))
+		for name, status := range platform.Status.ComponentStatuses {
+			update.Components[name] = stream.ComponentUpdate{
+				Ready:    status.Ready,
+				Version:  status.Version,
+				Replicas: status.Replicas,
+				Message:  status.Message,
+			}
+		}
+	}
+	return update
+}
+
+// statusEqual reports whether two platforms have the same streamed status
+func statusEqual(a, b *observabilityv1beta1.ObservabilityPlatform) bool {
+	if a.Status.Phase != b.Status.Phase ||
+		a.Status.Message != b.Status.Message ||
+		a.Status.ObservedGeneration != b.Status.ObservedGeneration ||
+		len(a.Status.ComponentStatuses) != len(b.Status.ComponentStatuses) {
+		return false
+	}
+	for name, statusA := range a.Status.ComponentStatuses {
+		statusB, ok := b.Status.ComponentStatuses[name]
+		if !ok ||
+			statusA.Ready != statusB.Ready ||
+			statusA.Version != statusB.Version ||
+			statusA.Replicas != statusB.Replicas ||
+			statusA.Message != statusB.Message {
+			return false
+		}
+	}
+	return true
+}
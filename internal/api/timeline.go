@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/timeline"
+)
+
+// handlePlatformTimeline returns the chronological feed of a platform's
+// events, condition transitions, upgrades, migrations and backups.
+// The namespace, since (a duration such as 1h), kind (comma-separated),
+// component and limit are query parameters.
+func (s *Server) handlePlatformTimeline(c *gin.Context) {
+	opts := timeline.Options{Component: c.Query("component")}
+	var err error
+	if opts.Kinds, err = timeline.ParseKinds(c.Query("kind")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if since := c.Query("since"); since != "" {
+		d, err := time.ParseDuration(since)
+		if err != nil || d <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be a positive duration"})
+			return
+		}
+		opts.Since = time.Now().Add(-d)
+	}
+	if limit := c.Query("limit"); limit != "" {
+		if opts.Limit, err = strconv.Atoi(limit); err != nil || opts.Limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a non-negative integer"})
+			return
+		}
+	}
+
+	platform := &observabilityv1beta1.ObservabilityPlatform{}
+	key := client.ObjectKey{Namespace: c.DefaultQuery("namespace", "default"), Name: c.Param("name")}
+	if err := s.client.Get(c.Request.Context(), key, platform); err != nil {
+		if errors.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "platform not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	t, err := timeline.Collect(c.Request.Context(), s.client, platform, opts)
+	if err != nil {
+		s.log.Error(err, "Failed to collect platform timeline", "platform", key)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
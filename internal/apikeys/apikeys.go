@@ -0,0 +1,193 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package apikeys issues and verifies the keys of ApiKey resources. A key is
+// gunj_<id>_<secret>: the id tells keys apart in listings and the secret is
+// 256 random bits. Only the SHA-256 of a key is stored; keys are random, so
+// an unsalted hash is enough to keep them from being recovered.
+package apikeys
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+const (
+	// Prefix starts every key, so API keys are told apart from other tokens
+	Prefix = "gunj_"
+	// SecretKey is the key of a new API key in its Secret
+	SecretKey = "api-key"
+
+	idBytes     = 4
+	secretBytes = 32
+)
+
+// Generate returns a new key and its id
+func Generate() (key, id string, err error) {
+	buf := make([]byte, idBytes+secretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	id = hex.EncodeToString(buf[:idBytes])
+	return Prefix + id + "_" + hex.EncodeToString(buf[idBytes:]), id, nil
+}
+
+// IsKey returns true if the token looks like an API key
+func IsKey(token string) bool {
+	return strings.HasPrefix(token, Prefix)
+}
+
+// ID returns the id of a key, empty if the token is not a key
+func ID(key string) string {
+	if !IsKey(key) {
+		return ""
+	}
+	id, _, ok := strings.Cut(strings.TrimPrefix(key, Prefix), "_")
+	if !ok || len(id) != 2*idBytes {
+		return ""
+	}
+	return id
+}
+
+// Hash returns the hex SHA-256 of a key
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewCredential returns the credential of a new key
+func NewCredential(key, id string, now time.Time) observabilityv1beta1.ApiKeyCredential {
+	return observabilityv1beta1.ApiKeyCredential{
+		ID:        id,
+		Hash:      Hash(key),
+		CreatedAt: metav1.NewTime(now),
+	}
+}
+
+// Rotate returns the credentials with the new one first. The previous keys
+// expire after the grace period, or earlier if they were already due to.
+func Rotate(creds []observabilityv1beta1.ApiKeyCredential, newCred observabilityv1beta1.ApiKeyCredential, grace time.Duration, now time.Time) []observabilityv1beta1.ApiKeyCredential {
+	expiry := metav1.NewTime(now.Add(grace))
+	rotated := []observabilityv1beta1.ApiKeyCredential{newCred}
+	for _, c := range creds {
+		if c.ExpiresAt == nil || c.ExpiresAt.After(expiry.Time) {
+			c.ExpiresAt = &expiry
+		}
+		rotated = append(rotated, c)
+	}
+	return rotated
+}
+
+// Prune returns the credentials which have not expired, and when the next
+// of them expires, zero if none does
+func Prune(creds []observabilityv1beta1.ApiKeyCredential, now time.Time) ([]observabilityv1beta1.ApiKeyCredential, time.Time) {
+	var kept []observabilityv1beta1.ApiKeyCredential
+	var next time.Time
+	for _, c := range creds {
+		if c.ExpiresAt == nil {
+			kept = append(kept, c)
+			continue
+		}
+		if !now.Before(c.ExpiresAt.Time) {
+			continue
+		}
+		kept = append(kept, c)
+		if next.IsZero() || c.ExpiresAt.Time.Before(next) {
+			next = c.ExpiresAt.Time
+		}
+	}
+	return kept, next
+}
+
+// Active returns true if the ApiKey accepts keys at the time
+func Active(apiKey *observabilityv1beta1.ApiKey, now time.Time) bool {
+	if apiKey.Spec.Revoked || apiKey.Status.Phase != observabilityv1beta1.ApiKeyPhaseActive {
+		return false
+	}
+	return apiKey.Spec.ExpiresAt == nil || now.Before(apiKey.Spec.ExpiresAt.Time)
+}
+
+// Hashes returns the hashes of the keys the ApiKey accepts at the time
+func Hashes(apiKey *observabilityv1beta1.ApiKey, now time.Time) []string {
+	if !Active(apiKey, now) {
+		return nil
+	}
+	var hashes []string
+	for _, c := range apiKey.Status.Keys {
+		if c.ExpiresAt == nil || now.Before(c.ExpiresAt.Time) {
+			hashes = append(hashes, c.Hash)
+		}
+	}
+	return hashes
+}
+
+// Verify returns true if the ApiKey accepts the key at the time
+func Verify(apiKey *observabilityv1beta1.ApiKey, key string, now time.Time) bool {
+	hash := []byte(Hash(key))
+	for _, h := range Hashes(apiKey, now) {
+		if subtle.ConstantTimeCompare(hash, []byte(h)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// Signals returns the signals the ingest scopes allow to write through the
+// ingest gateway
+func Signals(scopes []string) []string {
+	var signals []string
+	for _, scope := range scopes {
+		switch scope {
+		case observabilityv1beta1.ApiKeyScopeIngestMetrics:
+			signals = append(signals, observabilityv1beta1.SignalMetrics)
+		case observabilityv1beta1.ApiKeyScopeIngestLogs:
+			signals = append(signals, observabilityv1beta1.SignalLogs)
+		case observabilityv1beta1.ApiKeyScopeIngestTraces:
+			signals = append(signals, observabilityv1beta1.SignalTraces)
+		}
+	}
+	return signals
+}
+
+// queryRoutes are the API routes of the query passthrough, by namespace
+// parameter and by namespace path segment
+var queryRoutes = map[string]bool{
+	"/api/v1/platforms/:name/query":           true,
+	"/api/v1/platforms/:name/:platform/query": true,
+}
+
+// tenantRoutes are the API routes reading a platform's state
+var tenantRoutes = map[string]bool{
+	"/api/v1/platforms/:name":            true,
+	"/api/v1/platforms/:name/health":     true,
+	"/api/v1/platforms/:name/metrics":    true,
+	"/api/v1/platforms/:name/graph":      true,
+	"/api/v1/platforms/:name/components": true,
+}
+
+// Permits returns true if the scopes of the ApiKey allow the request on the
+// API route, for the platform by namespace and name
+func Permits(apiKey *observabilityv1beta1.ApiKey, method, route, namespace, platform string) bool {
+	if namespace != apiKey.Namespace || platform != apiKey.Spec.Platform {
+		return false
+	}
+	switch {
+	case queryRoutes[route]:
+		return apiKey.HasScope(observabilityv1beta1.ApiKeyScopeQuery)
+	case tenantRoutes[route]:
+		return method == "GET" && apiKey.HasScope(observabilityv1beta1.ApiKeyScopeTenant)
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package apikeys
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+func TestGenerate(t *testing.T) {
+	key, id, err := Generate()
+	require.NoError(t, err)
+	assert.True(t, IsKey(key))
+	assert.Len(t, key, len(Prefix)+8+1+64)
+	assert.Equal(t, id, ID(key))
+	assert.Len(t, Hash(key), 64)
+
+	other, _, err := Generate()
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+
+	assert.Empty(t, ID("Bearer token"))
+	assert.Empty(t, ID("gunj_short_secret"))
+}
+
+func TestRotateAndPrune(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	soon := metav1.NewTime(now.Add(time.Hour))
+	creds := []observabilityv1beta1.ApiKeyCredential{
+		{ID: "b", Hash: "hb"},
+		{ID: "a", Hash: "ha", ExpiresAt: &soon},
+	}
+
+	rotated := Rotate(creds, observabilityv1beta1.ApiKeyCredential{ID: "c", Hash: "hc"}, 24*time.Hour, now)
+	require.Len(t, rotated, 3)
+	assert.Equal(t, "c", rotated[0].ID)
+	assert.Nil(t, rotated[0].ExpiresAt)
+	assert.Equal(t, now.Add(24*time.Hour), rotated[1].ExpiresAt.Time)
+	// A key already due to expire keeps its expiry
+	assert.Equal(t, soon.Time, rotated[2].ExpiresAt.Time)
+	assert.Nil(t, creds[0].ExpiresAt)
+
+	kept, next := Prune(rotated, now)
+	assert.Len(t, kept, 3)
+	assert.Equal(t, soon.Time, next)
+
+	kept, next = Prune(rotated, now.Add(2*time.Hour))
+	require.Len(t, kept, 2)
+	assert.Equal(t, "b", kept[1].ID)
+	assert.Equal(t, now.Add(24*time.Hour), next)
+
+	kept, next = Prune(rotated, now.Add(48*time.Hour))
+	require.Len(t, kept, 1)
+	assert.True(t, next.IsZero())
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	key, id, err := Generate()
+	require.NoError(t, err)
+	previous, previousID, err := Generate()
+	require.NoError(t, err)
+
+	apiKey := &observabilityv1beta1.ApiKey{
+		Status: observabilityv1beta1.ApiKeyStatus{Phase: observabilityv1beta1.ApiKeyPhaseActive},
+	}
+	apiKey.Status.Keys = Rotate(
+		[]observabilityv1beta1.ApiKeyCredential{NewCredential(previous, previousID, now.Add(-time.Hour))},
+		NewCredential(key, id, now), time.Hour, now)
+
+	assert.True(t, Verify(apiKey, key, now))
+	assert.True(t, Verify(apiKey, previous, now))
+	assert.False(t, Verify(apiKey, previous, now.Add(time.Hour)))
+	assert.False(t, Verify(apiKey, key+"x", now))
+	assert.Len(t, Hashes(apiKey, now), 2)
+
+	expiry := metav1.NewTime(now.Add(time.Minute))
+	apiKey.Spec.ExpiresAt = &expiry
+	assert.True(t, Verify(apiKey, key, now))
+	assert.False(t, Verify(apiKey, key, now.Add(time.Minute)))
+
+	apiKey.Spec.ExpiresAt = nil
+	apiKey.Spec.Revoked = true
+	assert.False(t, Verify(apiKey, key, now))
+	assert.Empty(t, Hashes(apiKey, now))
+}
+
+func TestSignals(t *testing.T) {
+	assert.Equal(t, []string{"logs", "traces"}, Signals([]string{"ingest-logs", "query", "ingest-traces"}))
+	assert.Empty(t, Signals([]string{"query", "tenant"}))
+}
+
+func TestPermits(t *testing.T) {
+	apiKey := &observabilityv1beta1.ApiKey{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "monitoring"},
+		Spec: observabilityv1beta1.ApiKeySpec{
+			Platform: "prod",
+			Tenant:   "team-a",
+			Scopes:   []string{observabilityv1beta1.ApiKeyScopeQuery},
+		},
+	}
+
+	assert.True(t, Permits(apiKey, "POST", "/api/v1/platforms/:name/query", "monitoring", "prod"))
+	assert.False(t, Permits(apiKey, "GET", "/api/v1/platforms/:name/query", "monitoring", "staging"))
+	assert.True(t, Permits(apiKey, "GET", "/api/v1/platforms/:name/:platform/query", "monitoring", "prod"))
+	assert.False(t, Permits(apiKey, "GET", "/api/v1/platforms/:name/:platform/query", "default", "prod"))
+	assert.False(t, Permits(apiKey, "GET", "/api/v1/platforms/:name", "monitoring", "prod"))
+
+	apiKey.Spec.Scopes = []string{observabilityv1beta1.ApiKeyScopeTenant}
+	assert.True(t, Permits(apiKey, "GET", "/api/v1/platforms/:name/health", "monitoring", "prod"))
+	assert.False(t, Permits(apiKey, "DELETE", "/api/v1/platforms/:name", "monitoring", "prod"))
+	assert.False(t, Permits(apiKey, "GET", "/api/v1/platforms", "monitoring", "prod"))
+	assert.False(t, Permits(apiKey, "GET", "/api/v1/platforms/:name/query", "monitoring", "prod"))
+}
@@ -0,0 +1,409 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package auditlogs builds the Kubernetes audit log pipeline of a platform.
+// Vector collects the audit events, either tailing the audit log on the
+// control plane nodes or receiving the API server's audit webhook, keeps the
+// completed requests and pushes them as JSON lines into Loki under the stream
+// {job="kubernetes-audit", platform="<platform>", verb="<verb>"}.
+package auditlogs
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Image is the Vector image repository
+	Image = "timberio/vector"
+	// Job is the job label of the audit stream
+	Job = "kubernetes-audit"
+	// WebhookPort receives the audit webhook
+	WebhookPort int32 = 8080
+	// ConfigKey is the key of the configuration in the ConfigMap
+	ConfigKey = "vector.yaml"
+	// KubeconfigKey is the key of the audit webhook kubeconfig
+	KubeconfigKey = "audit-webhook.kubeconfig"
+	// DashboardKey is the key of the dashboard in the dashboards ConfigMap
+	DashboardKey = "kubernetes-audit.json"
+
+	// File tails the audit log files
+	File = "file"
+	// Webhook receives the audit webhook
+	Webhook = "webhook"
+
+	configPath = "/etc/vector"
+	dataPath   = "/vector-data"
+	logsPath   = "/var/log/kubernetes-audit"
+)
+
+// distributionPaths are the audit log paths of the distributions which write
+// the audit log on the control plane nodes
+var distributionPaths = map[string]string{
+	"kubeadm": "/var/log/kubernetes/audit/audit.log",
+	"k3s":     "/var/lib/rancher/k3s/server/logs/audit.log",
+	"rke2":    "/var/lib/rancher/rke2/server/logs/audit.log",
+}
+
+// DistributionPath returns the audit log path of a distribution, empty if
+// it is not supported
+func DistributionPath(distribution string) string {
+	return distributionPaths[distribution]
+}
+
+// Pipeline is an audit log collector
+type Pipeline struct {
+	Name      string
+	Namespace string
+	Version   string
+	// Platform labels the audit stream
+	Platform string
+	// LokiURL is the base URL of Loki
+	LokiURL string
+	// TenantID is sent with the audit events, empty for Loki's default
+	// tenant
+	TenantID string
+	// Source is File or Webhook
+	Source string
+	// Path is the audit log path on the control plane nodes of File
+	Path string
+	// ExcludeReadOnly drops get, list and watch requests
+	ExcludeReadOnly bool
+	// ExcludeUsers drops the requests of these users
+	ExcludeUsers []string
+	Resources    corev1.ResourceRequirements
+	Labels       map[string]string
+}
+
+// PipelineName returns the name of the audit log collector of a platform
+func PipelineName(platform string) string {
+	return fmt.Sprintf("audit-logs-%s", platform)
+}
+
+// KubeconfigName returns the name of the ConfigMap holding the audit webhook
+// kubeconfig of a platform
+func KubeconfigName(platform string) string {
+	return fmt.Sprintf("audit-webhook-%s", platform)
+}
+
+// Config returns the Vector configuration. The events transform turns the
+// input into one event per audit event; the filter transform applies the
+// parsing rules.
+func Config(p Pipeline) (string, error) {
+	var source map[string]interface{}
+	var events string
+	switch p.Source {
+	case File:
+		source = map[string]interface{}{
+			"type":      "file",
+			"include":   []string{path.Join(logsPath, path.Base(p.Path))},
+			"read_from": "end",
+		}
+		events = `. = parse_json!(string!(.message))`
+	case Webhook:
+		source = map[string]interface{}{
+			"type":     "http_server",
+			"address":  fmt.Sprintf("0.0.0.0:%d", WebhookPort),
+			"decoding": map[string]string{"codec": "json"},
+		}
+		// The webhook posts an EventList; an array result emits one event
+		// per element
+		events = `. = array!(.items)`
+	default:
+		return "", fmt.Errorf("unsupported audit source %q", p.Source)
+	}
+
+	sink := map[string]interface{}{
+		"type":     "loki",
+		"inputs":   []string{"filter"},
+		"endpoint": p.LokiURL,
+		"encoding": map[string]string{"codec": "json"},
+		"labels": map[string]string{
+			"job":      Job,
+			"platform": p.Platform,
+			"verb":     "{{ verb }}",
+		},
+	}
+	if p.TenantID != "" {
+		sink["tenant_id"] = p.TenantID
+	}
+
+	config := map[string]interface{}{
+		"data_dir": dataPath,
+		"sources":  map[string]interface{}{"audit": source},
+		"transforms": map[string]interface{}{
+			"events": map[string]interface{}{
+				"type":   "remap",
+				"inputs": []string{"audit"},
+				"source": events,
+			},
+			"filter": map[string]interface{}{
+				"type":   "remap",
+				"inputs": []string{"events"},
+				"source": FilterProgram(p.ExcludeReadOnly, p.ExcludeUsers),
+			},
+		},
+		"sinks": map[string]interface{}{"loki": sink},
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to render audit log config: %w", err)
+	}
+	return string(data), nil
+}
+
+// FilterProgram returns the VRL program applying the parsing rules: only the
+// completed requests are kept, the read only requests and the requests of
+// the excluded users dropped, and the event is timestamped with the time the
+// request was received
+func FilterProgram(excludeReadOnly bool, excludeUsers []string) string {
+	lines := []string{
+		`if .stage != "ResponseComplete" && .stage != "Panic" { abort }`,
+	}
+	if excludeReadOnly {
+		lines = append(lines, `if includes(["get", "list", "watch"], .verb) { abort }`)
+	}
+	if len(excludeUsers) > 0 {
+		users := append([]string(nil), excludeUsers...)
+		sort.Strings(users)
+		quoted := make([]string, 0, len(users))
+		for _, user := range users {
+			quoted = append(quoted, strconv.Quote(user))
+		}
+		lines = append(lines, fmt.Sprintf(`if includes([%s], .user.username) { abort }`, strings.Join(quoted, ", ")))
+	}
+	lines = append(lines, `.timestamp = parse_timestamp(.requestReceivedTimestamp, "%+") ?? now()`)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Dashboard returns the Grafana security dashboard of a platform's audit
+// events
+func Dashboard(platform string) string {
+	datasource := map[string]string{"type": "loki", "uid": "${datasource}"}
+	stream := func(matchers string) string {
+		return fmt.Sprintf(`{job=%q, platform=%q%s}`, Job, platform, matchers)
+	}
+	fields := ` | json user="user.username", code="responseStatus.code", resource="objectRef.resource", subresource="objectRef.subresource", namespace="objectRef.namespace", object="objectRef.name"`
+	panel := func(id int, title, panelType, expr string, x, y, w, h int) map[string]interface{} {
+		return map[string]interface{}{
+			"id":         id,
+			"title":      title,
+			"type":       panelType,
+			"datasource": datasource,
+			"gridPos":    map[string]int{"x": x, "y": y, "w": w, "h": h},
+			"targets":    []interface{}{map[string]interface{}{"refId": "A", "expr": expr, "datasource": datasource}},
+		}
+	}
+
+	secrets := panel(3, "Secret reads by user", "bargauge",
+		fmt.Sprintf(`topk(10, sum by (user, namespace) (count_over_time(%s%s | resource="secrets" [$__range])))`,
+			stream(`, verb=~"get|list|watch"`), fields), 0, 8, 12, 8)
+	secrets["targets"].([]interface{})[0].(map[string]interface{})["queryType"] = "instant"
+
+	dashboard := map[string]interface{}{
+		"uid":           "kubernetes-audit",
+		"title":         "Kubernetes Audit",
+		"tags":          []string{"kubernetes", "audit", "security", "gunj-operator"},
+		"schemaVersion": 39,
+		"time":          map[string]string{"from": "now-6h", "to": "now"},
+		"templating": map[string]interface{}{"list": []interface{}{
+			map[string]interface{}{"name": "datasource", "type": "datasource", "query": "loki"},
+		}},
+		"panels": []interface{}{
+			panel(1, "Requests by verb", "timeseries",
+				fmt.Sprintf(`sum by (verb) (count_over_time(%s [$__interval]))`, stream("")), 0, 0, 12, 8),
+			panel(2, "Denied requests by user", "timeseries",
+				fmt.Sprintf(`sum by (user) (count_over_time(%s%s | code=~"401|403" [$__interval]))`, stream(""), fields), 12, 0, 12, 8),
+			secrets,
+			panel(4, "Anonymous requests", "timeseries",
+				fmt.Sprintf(`sum by (resource) (count_over_time(%s%s | user="system:anonymous" [$__interval]))`, stream(""), fields), 12, 8, 12, 8),
+			panel(5, "Exec, attach and port-forward", "logs",
+				stream("")+fields+` | subresource=~"exec|attach|portforward"`, 0, 16, 12, 8),
+			panel(6, "RBAC changes", "logs",
+				stream(`, verb=~"create|update|patch|delete"`)+fields+` | resource=~"roles|rolebindings|clusterroles|clusterrolebindings"`, 12, 16, 12, 8),
+		},
+	}
+	data, _ := json.MarshalIndent(dashboard, "", "  ")
+	return string(data)
+}
+
+// Kubeconfig returns the kubeconfig the API server's audit webhook backend
+// is configured with (--audit-webhook-config-file) to post to the collector
+func Kubeconfig(server string) string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: gunj-audit
+  cluster:
+    server: %s
+contexts:
+- name: default
+  context:
+    cluster: gunj-audit
+    user: ""
+current-context: default
+users: []
+`, server)
+}
+
+// BuildConfigMap returns the ConfigMap holding the Vector configuration
+func BuildConfigMap(p Pipeline) (*corev1.ConfigMap, error) {
+	config, err := Config(p)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.Name,
+			Namespace: p.Namespace,
+			Labels:    p.Labels,
+		},
+		Data: map[string]string{ConfigKey: config},
+	}, nil
+}
+
+// container returns the Vector container
+func container(p Pipeline) corev1.Container {
+	return corev1.Container{
+		Name:      "vector",
+		Image:     fmt.Sprintf("%s:%s-distroless-libc", Image, p.Version),
+		Args:      []string{"--config", fmt.Sprintf("%s/%s", configPath, ConfigKey)},
+		Resources: p.Resources,
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "config", MountPath: configPath},
+			{Name: "data", MountPath: dataPath},
+		},
+	}
+}
+
+// configVolume returns the volume of the Vector configuration
+func configVolume(p Pipeline) corev1.Volume {
+	return corev1.Volume{
+		Name: "config",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: p.Name},
+			},
+		},
+	}
+}
+
+// BuildDaemonSet returns the collector DaemonSet of the file source. It runs
+// on the control plane nodes as root, as the audit log is only readable by
+// root, and keeps its checkpoints on the node so restarts resume where they
+// stopped.
+func BuildDaemonSet(p Pipeline) *appsv1.DaemonSet {
+	directoryOrCreate := corev1.HostPathDirectoryOrCreate
+	c := container(p)
+	c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{Name: "audit", MountPath: logsPath, ReadOnly: true})
+	c.SecurityContext = &corev1.SecurityContext{
+		RunAsUser:                &[]int64{0}[0],
+		AllowPrivilegeEscalation: &[]bool{false}[0],
+		ReadOnlyRootFilesystem:   &[]bool{true}[0],
+	}
+
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.Name,
+			Namespace: p.Namespace,
+			Labels:    p.Labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: p.Labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: p.Labels},
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"node-role.kubernetes.io/control-plane": ""},
+					Tolerations: []corev1.Toleration{
+						{Key: "node-role.kubernetes.io/control-plane", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+						{Key: "node-role.kubernetes.io/master", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+					},
+					Containers: []corev1.Container{c},
+					Volumes: []corev1.Volume{
+						configVolume(p),
+						{
+							Name: "data",
+							VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{Path: "/var/lib/gunj-audit/" + p.Name, Type: &directoryOrCreate},
+							},
+						},
+						{
+							Name: "audit",
+							VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{Path: path.Dir(p.Path)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// BuildDeployment returns the collector Deployment of the webhook source
+func BuildDeployment(p Pipeline) *appsv1.Deployment {
+	replicas := int32(1)
+	c := container(p)
+	c.Ports = []corev1.ContainerPort{{Name: "http", ContainerPort: WebhookPort, Protocol: corev1.ProtocolTCP}}
+	c.SecurityContext = &corev1.SecurityContext{
+		RunAsNonRoot:             &[]bool{true}[0],
+		RunAsUser:                &[]int64{65534}[0],
+		AllowPrivilegeEscalation: &[]bool{false}[0],
+		ReadOnlyRootFilesystem:   &[]bool{true}[0],
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.Name,
+			Namespace: p.Namespace,
+			Labels:    p.Labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: p.Labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: p.Labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{c},
+					Volumes: []corev1.Volume{
+						configVolume(p),
+						{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// BuildService returns the Service the audit webhook posts to
+func BuildService(p Pipeline) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.Name,
+			Namespace: p.Namespace,
+			Labels:    p.Labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: p.Labels,
+			Ports: []corev1.ServicePort{{
+				Name:       "http",
+				Port:       WebhookPort,
+				TargetPort: intstr.FromString("http"),
+				Protocol:   corev1.ProtocolTCP,
+			}},
+		},
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package auditlogs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+type vectorConfig struct {
+	Sources    map[string]map[string]interface{} `json:"sources"`
+	Transforms map[string]struct {
+		Inputs []string `json:"inputs"`
+		Source string   `json:"source"`
+	} `json:"transforms"`
+	Sinks map[string]struct {
+		Endpoint string            `json:"endpoint"`
+		Labels   map[string]string `json:"labels"`
+		TenantID string            `json:"tenant_id"`
+	} `json:"sinks"`
+}
+
+func TestConfigTailsAuditLog(t *testing.T) {
+	config, err := Config(Pipeline{
+		Platform: "prod",
+		LokiURL:  "http://loki-prod.monitoring.svc.cluster.local:3100",
+		Source:   File,
+		Path:     DistributionPath("k3s"),
+	})
+	require.NoError(t, err)
+
+	var parsed vectorConfig
+	require.NoError(t, yaml.Unmarshal([]byte(config), &parsed))
+	assert.Equal(t, "file", parsed.Sources["audit"]["type"])
+	assert.Equal(t, []interface{}{"/var/log/kubernetes-audit/audit.log"}, parsed.Sources["audit"]["include"])
+	assert.Contains(t, parsed.Transforms["events"].Source, "parse_json!")
+	assert.Equal(t, []string{"events"}, parsed.Transforms["filter"].Inputs)
+	assert.Equal(t, "http://loki-prod.monitoring.svc.cluster.local:3100", parsed.Sinks["loki"].Endpoint)
+	assert.Equal(t, map[string]string{"job": "kubernetes-audit", "platform": "prod", "verb": "{{ verb }}"}, parsed.Sinks["loki"].Labels)
+	assert.Empty(t, parsed.Sinks["loki"].TenantID)
+}
+
+func TestConfigReceivesWebhook(t *testing.T) {
+	config, err := Config(Pipeline{Platform: "prod", Source: Webhook, TenantID: "fake"})
+	require.NoError(t, err)
+
+	var parsed vectorConfig
+	require.NoError(t, yaml.Unmarshal([]byte(config), &parsed))
+	assert.Equal(t, "http_server", parsed.Sources["audit"]["type"])
+	assert.Equal(t, "0.0.0.0:8080", parsed.Sources["audit"]["address"])
+	assert.Equal(t, ". = array!(.items)", parsed.Transforms["events"].Source)
+	assert.Equal(t, "fake", parsed.Sinks["loki"].TenantID)
+
+	_, err = Config(Pipeline{Source: "syslog"})
+	assert.Error(t, err)
+}
+
+func TestFilterProgram(t *testing.T) {
+	program := FilterProgram(false, nil)
+	assert.Contains(t, program, `.stage != "ResponseComplete"`)
+	assert.NotContains(t, program, `"watch"`)
+
+	program = FilterProgram(true, []string{"system:kube-scheduler", "system:apiserver"})
+	assert.Contains(t, program, `if includes(["get", "list", "watch"], .verb) { abort }`)
+	assert.Contains(t, program, `if includes(["system:apiserver", "system:kube-scheduler"], .user.username) { abort }`)
+}
+
+func TestBuildDaemonSetMountsLogDirectory(t *testing.T) {
+	ds := BuildDaemonSet(Pipeline{Name: PipelineName("prod"), Namespace: "monitoring", Version: "0.39.0", Path: DistributionPath("kubeadm")})
+	assert.Equal(t, "audit-logs-prod", ds.Name)
+	spec := ds.Spec.Template.Spec
+	assert.Contains(t, spec.NodeSelector, "node-role.kubernetes.io/control-plane")
+	assert.Equal(t, "timberio/vector:0.39.0-distroless-libc", spec.Containers[0].Image)
+	require.Len(t, spec.Volumes, 3)
+	assert.Equal(t, "/var/log/kubernetes/audit", spec.Volumes[2].HostPath.Path)
+}
+
+func TestKubeconfigPointsToCollector(t *testing.T) {
+	var parsed struct {
+		Clusters []struct {
+			Cluster struct {
+				Server string `json:"server"`
+			} `json:"cluster"`
+		} `json:"clusters"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(Kubeconfig("http://10.96.0.20:8080")), &parsed))
+	require.Len(t, parsed.Clusters, 1)
+	assert.Equal(t, "http://10.96.0.20:8080", parsed.Clusters[0].Cluster.Server)
+}
+
+func TestDashboardIsValidJSON(t *testing.T) {
+	var dashboard map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(Dashboard("prod")), &dashboard))
+	assert.Equal(t, "kubernetes-audit", dashboard["uid"])
+	assert.Len(t, dashboard["panels"], 6)
+}
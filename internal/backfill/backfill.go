@@ -0,0 +1,291 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package backfill backfills the history of recording rules. New rules are
+// evaluated over a lookback window with `promtool tsdb create-blocks-from
+// rules` against the running Prometheus, and the generated blocks are moved
+// into its storage, where they are picked up at the next block reload.
+package backfill
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// RuleFileKey is the ConfigMap key of the rule file evaluated by the Jobs
+	RuleFileKey = "rules.yml"
+
+	rulesPath   = "/etc/backfill"
+	stagingName = ".backfill"
+
+	defaultBackoffLimit          int32 = 2
+	defaultActiveDeadlineSeconds int64 = 6 * 3600
+	defaultTTLSecondsAfterFinish int32 = 24 * 3600
+)
+
+// Rule is a recording rule
+type Rule struct {
+	// Group is the name of the rule group
+	Group string
+	// Interval is the evaluation interval of the group
+	Interval string
+	Record   string
+	Expr     string
+	Labels   map[string]string
+}
+
+// Key identifies a rule by its record name and a hash of its definition, so
+// a rule whose expression or labels change is backfilled again
+func Key(r Rule) string {
+	labels := make([]string, 0, len(r.Labels))
+	for k, v := range r.Labels {
+		labels = append(labels, k+"="+v)
+	}
+	sort.Strings(labels)
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{r.Group, r.Interval, r.Expr, strings.Join(labels, ",")}, "\n")))
+	return r.Record + "@" + hex.EncodeToString(sum[:])[:8]
+}
+
+// Keys returns the sorted keys of rules
+func Keys(rules []Rule) []string {
+	keys := make([]string, 0, len(rules))
+	for _, r := range rules {
+		keys = append(keys, Key(r))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Pending returns the rules whose key is not in backfilled
+func Pending(rules []Rule, backfilled []string) []Rule {
+	done := make(map[string]bool, len(backfilled))
+	for _, key := range backfilled {
+		done[key] = true
+	}
+
+	var pending []Rule
+	for _, r := range rules {
+		if !done[Key(r)] {
+			pending = append(pending, r)
+		}
+	}
+	return pending
+}
+
+// Retain returns the sorted keys of rules that are in keys, dropping the
+// keys of removed rules
+func Retain(rules []Rule, keys []string) []string {
+	wanted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		wanted[key] = true
+	}
+
+	var retained []string
+	for _, key := range Keys(rules) {
+		if wanted[key] {
+			retained = append(retained, key)
+		}
+	}
+	return retained
+}
+
+type ruleFile struct {
+	Groups []ruleGroup `yaml:"groups"`
+}
+
+type ruleGroup struct {
+	Name     string       `yaml:"name"`
+	Interval string       `yaml:"interval,omitempty"`
+	Rules    []recordRule `yaml:"rules"`
+}
+
+type recordRule struct {
+	Record string            `yaml:"record"`
+	Expr   string            `yaml:"expr"`
+	Labels map[string]string `yaml:"labels,omitempty"`
+}
+
+// RuleFile renders rules as a Prometheus rule file, keeping the order of
+// their groups
+func RuleFile(rules []Rule) ([]byte, error) {
+	var file ruleFile
+	index := make(map[string]int)
+	for _, r := range rules {
+		i, ok := index[r.Group]
+		if !ok {
+			i = len(file.Groups)
+			index[r.Group] = i
+			file.Groups = append(file.Groups, ruleGroup{Name: r.Group, Interval: r.Interval})
+		}
+		file.Groups[i].Rules = append(file.Groups[i].Rules, recordRule{Record: r.Record, Expr: r.Expr, Labels: r.Labels})
+	}
+
+	data, err := yaml.Marshal(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal backfill rules: %w", err)
+	}
+	return data, nil
+}
+
+// Target is the Prometheus replica a backfill Job writes to
+type Target struct {
+	Platform  string
+	Namespace string
+	// Image is the Prometheus image, which ships promtool
+	Image string
+	// URL is the Prometheus the rule expressions are evaluated against
+	URL string
+	// Pod is the Prometheus replica; the Job runs on its node to mount its
+	// ReadWriteOnce volume
+	Pod string
+	// ClaimName is the data volume of the replica
+	ClaimName string
+	// DataPath is where the data volume is mounted in Prometheus
+	DataPath string
+	// Start and End bound the evaluated window
+	Start time.Time
+	End   time.Time
+	// EvalInterval is the evaluation interval of groups without an interval
+	EvalInterval time.Duration
+	// ServiceAccountName runs the Job
+	ServiceAccountName string
+	// Labels are added to the Job and its pod
+	Labels map[string]string
+}
+
+// ConfigMapName returns the name of the ConfigMap holding a set of rules to
+// backfill. The rule keys are hashed into the name, so each set of new rules
+// gets its own ConfigMap and Jobs.
+func ConfigMapName(platform string, keys []string) string {
+	return hashedName(fmt.Sprintf("backfill-prometheus-%s", platform), keys)
+}
+
+// JobName returns the name of the Job backfilling a set of rules into a replica
+func JobName(pod string, keys []string) string {
+	return hashedName("backfill-"+pod, keys)
+}
+
+func hashedName(prefix string, keys []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(keys, "\n")))
+	suffix := hex.EncodeToString(sum[:])[:8]
+	// Job names become pod labels, which are limited to 63 characters
+	if max := 63 - len(suffix) - 1; len(prefix) > max {
+		prefix = strings.TrimRight(prefix[:max], "-.")
+	}
+	return prefix + "-" + suffix
+}
+
+// BuildConfigMap returns the ConfigMap holding the rule file evaluated by the
+// backfill Jobs of a set of rules
+func BuildConfigMap(name, namespace string, labels map[string]string, ruleFile []byte) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Data: map[string]string{RuleFileKey: string(ruleFile)},
+	}
+}
+
+// Script returns the shell script run by a backfill Job. Blocks are created
+// in a staging directory on the data volume, which Prometheus ignores, and
+// only moved into place once promtool has succeeded, so a failed run leaves
+// no partial blocks behind.
+func Script(t Target) string {
+	staging := t.DataPath + "/" + stagingName
+	return strings.Join([]string{
+		"set -e",
+		fmt.Sprintf("rm -rf %s", staging),
+		fmt.Sprintf("promtool tsdb create-blocks-from rules --start=%d --end=%d --url=%s --eval-interval=%s --output-dir=%s %s/%s",
+			t.Start.Unix(), t.End.Unix(), t.URL, t.EvalInterval, staging, rulesPath, RuleFileKey),
+		fmt.Sprintf("for block in %s/*/; do mv \"$block\" %s/; done", staging, t.DataPath),
+		fmt.Sprintf("rm -rf %s", staging),
+	}, "\n")
+}
+
+// BuildJob returns the Job backfilling the rules of a ConfigMap into a replica
+func BuildJob(t Target, name, configMap string) *batchv1.Job {
+	backoffLimit := defaultBackoffLimit
+	activeDeadlineSeconds := defaultActiveDeadlineSeconds
+	ttl := defaultTTLSecondsAfterFinish
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: t.Namespace,
+			Labels:    t.Labels,
+			Annotations: map[string]string{
+				"observability.io/backfill-start": t.Start.UTC().Format(time.RFC3339),
+				"observability.io/backfill-end":   t.End.UTC().Format(time.RFC3339),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			ActiveDeadlineSeconds:   &activeDeadlineSeconds,
+			TTLSecondsAfterFinished: &ttl,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: t.Labels},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: t.ServiceAccountName,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					// Write blocks with the user and group of Prometheus
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsUser:    &[]int64{65534}[0],
+						RunAsNonRoot: &[]bool{true}[0],
+						FSGroup:      &[]int64{65534}[0],
+					},
+					Affinity: &corev1.Affinity{
+						PodAffinity: &corev1.PodAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+								LabelSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{"statefulset.kubernetes.io/pod-name": t.Pod},
+								},
+								TopologyKey: "kubernetes.io/hostname",
+							}},
+						},
+					},
+					Containers: []corev1.Container{{
+						Name:    "backfill",
+						Image:   t.Image,
+						Command: []string{"/bin/sh", "-c", Script(t)},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "rules", MountPath: rulesPath, ReadOnly: true},
+							{Name: "data", MountPath: t.DataPath},
+						},
+					}},
+					Volumes: []corev1.Volume{
+						{
+							Name: "rules",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: configMap},
+								},
+							},
+						},
+						{
+							Name: "data",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: t.ClaimName},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package backfill
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var rules = []Rule{
+	{Group: "slo", Interval: "30s", Record: "job:http_requests:rate5m", Expr: "sum by (job) (rate(http_requests_total[5m]))"},
+	{Group: "slo", Interval: "30s", Record: "job:http_errors:rate5m", Expr: `sum by (job) (rate(http_requests_total{code=~"5.."}[5m]))`},
+	{Group: "capacity", Record: "node:cpu:ratio", Expr: "avg by (node) (rate(node_cpu_seconds_total[5m]))", Labels: map[string]string{"team": "infra"}},
+}
+
+func TestKey(t *testing.T) {
+	key := Key(rules[0])
+	assert.True(t, strings.HasPrefix(key, "job:http_requests:rate5m@"))
+	assert.Equal(t, key, Key(rules[0]))
+
+	changed := rules[0]
+	changed.Expr = "sum by (job) (rate(http_requests_total[1m]))"
+	assert.NotEqual(t, key, Key(changed))
+
+	relabeled := rules[2]
+	relabeled.Labels = map[string]string{"team": "platform"}
+	assert.NotEqual(t, Key(rules[2]), Key(relabeled))
+}
+
+func TestPendingAndRetain(t *testing.T) {
+	backfilled := []string{Key(rules[0]), "removed:rule@deadbeef"}
+
+	pending := Pending(rules, backfilled)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "job:http_errors:rate5m", pending[0].Record)
+	assert.Equal(t, "node:cpu:ratio", pending[1].Record)
+
+	assert.Equal(t, []string{Key(rules[0])}, Retain(rules, backfilled))
+	assert.Empty(t, Pending(rules, Keys(rules)))
+}
+
+func TestRuleFile(t *testing.T) {
+	data, err := RuleFile(rules)
+	require.NoError(t, err)
+
+	expected := `groups:
+    - name: slo
+      interval: 30s
+      rules:
+        - record: job:http_requests:rate5m
+          expr: sum by (job) (rate(http_requests_total[5m]))
+        - record: job:http_errors:rate5m
+          expr: sum by (job) (rate(http_requests_total{code=~"5.."}[5m]))
+    - name: capacity
+      rules:
+        - record: node:cpu:ratio
+          expr: avg by (node) (rate(node_cpu_seconds_total[5m]))
+          labels:
+            team: infra
+`
+	assert.Equal(t, expected, string(data))
+}
+
+func TestNames(t *testing.T) {
+	keys := Keys(rules)
+
+	assert.Regexp(t, `^backfill-prometheus-prod-[0-9a-f]{8}$`, ConfigMapName("prod", keys))
+	assert.Regexp(t, `^backfill-prometheus-prod-0-[0-9a-f]{8}$`, JobName("prometheus-prod-0", keys))
+	assert.NotEqual(t, JobName("prometheus-prod-0", keys), JobName("prometheus-prod-0", keys[:1]))
+	assert.LessOrEqual(t, len(JobName(strings.Repeat("p", 80), keys)), 63)
+}
+
+func TestBuildJob(t *testing.T) {
+	end := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	target := Target{
+		Platform:     "prod",
+		Namespace:    "monitoring",
+		Image:        "prom/prometheus:v2.48.0",
+		URL:          "http://prometheus-prod.monitoring.svc.cluster.local:9090",
+		Pod:          "prometheus-prod-1",
+		ClaimName:    "data-prometheus-prod-1",
+		DataPath:     "/prometheus",
+		Start:        end.Add(-24 * time.Hour),
+		End:          end,
+		EvalInterval: time.Minute,
+		Labels:       map[string]string{"app.kubernetes.io/name": "prometheus-backfill"},
+	}
+
+	job := BuildJob(target, "backfill-prometheus-prod-1-abcdef12", "backfill-prometheus-prod-abcdef12")
+
+	assert.Equal(t, "backfill-prometheus-prod-1-abcdef12", job.Name)
+	assert.Equal(t, "2025-03-01T12:00:00Z", job.Annotations["observability.io/backfill-end"])
+
+	pod := job.Spec.Template.Spec
+	require.Len(t, pod.Containers, 1)
+	script := pod.Containers[0].Command[2]
+	assert.Contains(t, script, "promtool tsdb create-blocks-from rules --start=1740744000 --end=1740830400 "+
+		"--url=http://prometheus-prod.monitoring.svc.cluster.local:9090 --eval-interval=1m0s "+
+		"--output-dir=/prometheus/.backfill /etc/backfill/rules.yml")
+	assert.Contains(t, script, `mv "$block" /prometheus/`)
+
+	require.Len(t, pod.Volumes, 2)
+	assert.Equal(t, "backfill-prometheus-prod-abcdef12", pod.Volumes[0].ConfigMap.Name)
+	assert.Equal(t, "data-prometheus-prod-1", pod.Volumes[1].PersistentVolumeClaim.ClaimName)
+
+	terms := pod.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	require.Len(t, terms, 1)
+	assert.Equal(t, "prometheus-prod-1", terms[0].LabelSelector.MatchLabels["statefulset.kubernetes.io/pod-name"])
+}
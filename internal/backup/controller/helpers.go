@@ -11,9 +11,13 @@ import (
 
 	"github.com/gunjanjp/gunj-operator/internal/backup"
 	"github.com/gunjanjp/gunj-operator/internal/backup/storage"
+	"github.com/gunjanjp/gunj-operator/internal/dataprotection"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
@@ -401,6 +405,23 @@ func (rc *RestoreController) restoreVolumeSnapshots(ctx context.Context, state *
 	
 	rc.log.V(1).Info("Restoring volume snapshots")
 	
+	// Restore the claims from the CSI VolumeSnapshots requested, e.g. the
+	// snapshots a platform took before an upgrade
+	for _, volume := range state.spec.VolumeSnapshots {
+		rc.log.V(1).Info("Restoring PVC from volume snapshot", "namespace", volume.Namespace, "pvc", volume.ClaimName, "snapshot", volume.SnapshotName)
+		if rc.config.DryRun {
+			continue
+		}
+		if err := rc.restoreClaimFromSnapshot(ctx, volume); err != nil {
+			return fmt.Errorf("restoring pvc %s/%s: %w", volume.Namespace, volume.ClaimName, err)
+		}
+		state.status.RestoredItemsCount++
+	}
+	
+	if state.spec.BackupName == "" {
+		return nil
+	}
+	
 	// Find snapshot ConfigMaps
 	snapshots := &corev1.ConfigMapList{}
 	if err := rc.client.List(ctx, snapshots); err != nil {
@@ -425,3 +446,36 @@ func (rc *RestoreController) restoreVolumeSnapshots(ctx context.Context, state *
 	
 	return nil
 }
+
+// restoreClaimFromSnapshot replaces a PVC with one provisioned from a CSI
+// VolumeSnapshot. The workloads using the claim must be scaled down first,
+// otherwise the old claim is never released.
+func (rc *RestoreController) restoreClaimFromSnapshot(ctx context.Context, volume backup.VolumeSnapshotRestore) error {
+	key := types.NamespacedName{Namespace: volume.Namespace, Name: volume.ClaimName}
+	existing := &corev1.PersistentVolumeClaim{}
+	if err := rc.client.Get(ctx, key, existing); err != nil {
+		return fmt.Errorf("getting pvc: %w", err)
+	}
+	restored := dataprotection.RestoredClaim(existing, volume.SnapshotName)
+	
+	if err := rc.client.Delete(ctx, existing); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("deleting pvc: %w", err)
+	}
+	
+	// Wait for the claim to be gone before recreating it
+	err := wait.PollUntilContextTimeout(ctx, 2*time.Second, 5*time.Minute, true, func(ctx context.Context) (bool, error) {
+		err := rc.client.Get(ctx, key, &corev1.PersistentVolumeClaim{})
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for pvc deletion: %w", err)
+	}
+	
+	if err := rc.client.Create(ctx, restored); err != nil {
+		return fmt.Errorf("creating pvc from snapshot: %w", err)
+	}
+	return nil
+}
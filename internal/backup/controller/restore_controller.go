@@ -193,15 +193,21 @@ func (rc *RestoreController) CreateRestore(ctx context.Context, spec *backup.Res
 	}
 	rc.restoresMu.RUnlock()
 	
-	// Get backup to restore from
-	backupStatus, err := rc.backupCtrl.GetBackup(ctx, spec.BackupName)
-	if err != nil {
-		return nil, fmt.Errorf("getting backup %s: %w", spec.BackupName, err)
-	}
-	
-	// Validate backup is restorable
-	if backupStatus.Phase != backup.BackupPhaseCompleted {
-		return nil, fmt.Errorf("backup %s is not completed (phase: %s)", spec.BackupName, backupStatus.Phase)
+	// Get backup to restore from; a restore of volume snapshots only has none
+	var backupStatus *backup.BackupStatus
+	if spec.BackupName != "" {
+		var err error
+		backupStatus, err = rc.backupCtrl.GetBackup(ctx, spec.BackupName)
+		if err != nil {
+			return nil, fmt.Errorf("getting backup %s: %w", spec.BackupName, err)
+		}
+		
+		// Validate backup is restorable
+		if backupStatus.Phase != backup.BackupPhaseCompleted {
+			return nil, fmt.Errorf("backup %s is not completed (phase: %s)", spec.BackupName, backupStatus.Phase)
+		}
+	} else if len(spec.VolumeSnapshots) == 0 {
+		return nil, fmt.Errorf("a backup name or volume snapshots are required")
 	}
 	
 	// Initialize restore state
@@ -254,6 +260,18 @@ func (rc *RestoreController) runRestore(ctx context.Context, restoreName string,
 		}
 	}
 	
+	// Restore only the volume snapshots when no backup is given, e.g. to roll
+	// back to the snapshots a platform took before an upgrade
+	if state.spec.BackupName == "" {
+		rc.log.V(1).Info("Restoring volume snapshots", "restore", restoreName, "count", len(state.spec.VolumeSnapshots))
+		if err := rc.restoreVolumeSnapshots(ctx, state); err != nil {
+			rc.handleRestoreError(state, fmt.Errorf("restoring volume snapshots: %w", err))
+			return
+		}
+		rc.completeRestore(ctx, restoreName, state)
+		return
+	}
+	
 	// Download backup data
 	rc.log.V(1).Info("Downloading backup data", "restore", restoreName, "backup", state.spec.BackupName)
 	backupData, err := rc.downloadBackup(ctx, state)
@@ -331,13 +349,18 @@ func (rc *RestoreController) runRestore(ctx context.Context, restoreName string,
 	}
 	
 	// Restore volume snapshots if requested
-	if state.spec.RestorePVs {
+	if state.spec.RestorePVs || len(state.spec.VolumeSnapshots) > 0 {
 		rc.log.V(1).Info("Restoring volume snapshots", "restore", restoreName)
 		if err := rc.restoreVolumeSnapshots(ctx, state); err != nil {
 			state.status.Warnings = append(state.status.Warnings, fmt.Sprintf("Volume restore failed: %v", err))
 		}
 	}
 	
+	rc.completeRestore(ctx, restoreName, state)
+}
+
+// completeRestore runs the post-restore hooks and marks the restore as completed
+func (rc *RestoreController) completeRestore(ctx context.Context, restoreName string, state *restoreState) {
 	// Run post-restore hooks
 	if state.spec.Hooks != nil && len(state.spec.Hooks.PostRestore) > 0 {
 		rc.log.V(1).Info("Running post-restore hooks", "restore", restoreName)
@@ -589,6 +612,9 @@ func (rc *RestoreController) handleRestoreError(state *restoreState, err error)
 
 // generateRestoreName generates a unique restore name
 func (rc *RestoreController) generateRestoreName(spec *backup.RestoreSpec) string {
+	if spec.BackupName == "" {
+		return fmt.Sprintf("restore-snapshots-%d", time.Now().Unix())
+	}
 	return fmt.Sprintf("restore-%s-%d", spec.BackupName, time.Now().Unix())
 }
 
@@ -178,6 +178,23 @@ type RestoreSpec struct {
 	
 	// ValidationPolicy specifies validation requirements
 	ValidationPolicy *ValidationPolicy `json:"validationPolicy,omitempty"`
+	
+	// VolumeSnapshots are claims to restore from CSI VolumeSnapshots, such
+	// as the snapshots a platform takes before upgrades. Without BackupName,
+	// only these claims are restored.
+	VolumeSnapshots []VolumeSnapshotRestore `json:"volumeSnapshots,omitempty"`
+}
+
+// VolumeSnapshotRestore restores a PersistentVolumeClaim from a VolumeSnapshot
+type VolumeSnapshotRestore struct {
+	// Namespace of the claim and the snapshot
+	Namespace string `json:"namespace"`
+	
+	// ClaimName is the claim replaced with one provisioned from the snapshot
+	ClaimName string `json:"claimName"`
+	
+	// SnapshotName is the VolumeSnapshot to restore
+	SnapshotName string `json:"snapshotName"`
 }
 
 // RestoreStatus defines the status of a restore
@@ -0,0 +1,242 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package beyla builds the eBPF agent of a platform. Grafana Beyla runs on
+// every node, attaches to the HTTP and gRPC servers and clients of the
+// instrumented namespaces and generates RED metrics, service graph metrics
+// and traces without code changes. Its metrics endpoint is annotated for the
+// platform's Prometheus to scrape; traces are exported to Tempo over OTLP.
+package beyla
+
+import (
+	"fmt"
+	"regexp"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Image is the Beyla image repository
+	Image = "grafana/beyla"
+	// MetricsPort serves the RED metrics
+	MetricsPort int32 = 9400
+	// ConfigKey is the key of the configuration in the ConfigMap
+	ConfigKey = "beyla-config.yml"
+
+	configPath = "/etc/beyla"
+)
+
+// Agent is a Beyla DaemonSet
+type Agent struct {
+	Name      string
+	Namespace string
+	Version   string
+	// Namespaces restricts instrumentation, all namespaces if empty
+	Namespaces []string
+	// Metrics exposes RED metrics on MetricsPort
+	Metrics bool
+	// ServiceGraph adds the service graph metrics
+	ServiceGraph bool
+	// TracesEndpoint is the OTLP gRPC endpoint traces are exported to, no
+	// traces are exported if empty
+	TracesEndpoint string
+	// TenantID is sent with the traces, empty for Tempo's default tenant
+	TenantID  string
+	Resources corev1.ResourceRequirements
+	Labels    map[string]string
+}
+
+// AgentName returns the name of the Beyla DaemonSet of a platform
+func AgentName(platform string) string {
+	return fmt.Sprintf("beyla-%s", platform)
+}
+
+// ClusterRoleName returns the name of the ClusterRole and ClusterRoleBinding
+// of a platform's agent. They are cluster scoped, so the name includes the
+// namespace.
+func ClusterRoleName(platform, namespace string) string {
+	return fmt.Sprintf("gunj-beyla-%s-%s", namespace, platform)
+}
+
+// Config returns the Beyla configuration file
+func Config(a Agent) (string, error) {
+	var services []interface{}
+	if len(a.Namespaces) == 0 {
+		services = append(services, map[string]interface{}{"k8s_namespace": "."})
+	}
+	for _, namespace := range a.Namespaces {
+		services = append(services, map[string]interface{}{
+			"k8s_namespace": "^" + regexp.QuoteMeta(namespace) + "$",
+		})
+	}
+
+	config := map[string]interface{}{
+		"attributes": map[string]interface{}{
+			"kubernetes": map[string]interface{}{"enable": true},
+		},
+		"discovery": map[string]interface{}{"services": services},
+		// Group unmatched paths into routes to bound the cardinality
+		"routes": map[string]interface{}{"unmatched": "heuristic"},
+	}
+	if a.Metrics {
+		features := []string{"application"}
+		if a.ServiceGraph {
+			features = append(features, "application_service_graph")
+		}
+		config["prometheus_export"] = map[string]interface{}{
+			"port":     MetricsPort,
+			"path":     "/metrics",
+			"features": features,
+		}
+	}
+	if a.TracesEndpoint != "" {
+		config["otel_traces_export"] = map[string]interface{}{
+			"endpoint": a.TracesEndpoint,
+			"protocol": "grpc",
+		}
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to render Beyla config: %w", err)
+	}
+	return string(data), nil
+}
+
+// BuildConfigMap returns the ConfigMap holding the configuration
+func BuildConfigMap(a Agent) (*corev1.ConfigMap, error) {
+	config, err := Config(a)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.Name,
+			Namespace: a.Namespace,
+			Labels:    a.Labels,
+		},
+		Data: map[string]string{ConfigKey: config},
+	}, nil
+}
+
+// BuildServiceAccount returns the ServiceAccount the agent runs as
+func BuildServiceAccount(a Agent) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.Name,
+			Namespace: a.Namespace,
+			Labels:    a.Labels,
+		},
+	}
+}
+
+// BuildClusterRole returns the ClusterRole allowing the agent to decorate
+// metrics and spans with Kubernetes metadata
+func BuildClusterRole(name string, labels map[string]string) *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"pods", "services", "nodes"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"apps"},
+				Resources: []string{"replicasets"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+}
+
+// BuildClusterRoleBinding binds the ClusterRole to the agent's
+// ServiceAccount
+func BuildClusterRoleBinding(name string, a Agent) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: a.Labels},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     name,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      a.Name,
+			Namespace: a.Namespace,
+		}},
+	}
+}
+
+// BuildDaemonSet returns the agent DaemonSet. Beyla shares the host's PID
+// namespace to find the processes of the instrumented pods and needs
+// privileges to load its eBPF programs.
+func BuildDaemonSet(a Agent) *appsv1.DaemonSet {
+	var annotations map[string]string
+	var ports []corev1.ContainerPort
+	if a.Metrics {
+		annotations = map[string]string{
+			"prometheus.io/scrape": "true",
+			"prometheus.io/port":   fmt.Sprintf("%d", MetricsPort),
+			"prometheus.io/path":   "/metrics",
+		}
+		ports = append(ports, corev1.ContainerPort{Name: "metrics", ContainerPort: MetricsPort, Protocol: corev1.ProtocolTCP})
+	}
+	env := []corev1.EnvVar{{
+		Name:  "BEYLA_CONFIG_PATH",
+		Value: fmt.Sprintf("%s/%s", configPath, ConfigKey),
+	}}
+	if a.TracesEndpoint != "" && a.TenantID != "" {
+		// The configuration file has no headers; the exporter reads them
+		// from the standard OpenTelemetry variable
+		env = append(env, corev1.EnvVar{
+			Name:  "OTEL_EXPORTER_OTLP_TRACES_HEADERS",
+			Value: "X-Scope-OrgID=" + a.TenantID,
+		})
+	}
+
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.Name,
+			Namespace: a.Namespace,
+			Labels:    a.Labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: a.Labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: a.Labels, Annotations: annotations},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: a.Name,
+					HostPID:            true,
+					Tolerations:        []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+					Containers: []corev1.Container{{
+						Name:      "beyla",
+						Image:     fmt.Sprintf("%s:%s", Image, a.Version),
+						Env:       env,
+						Ports:     ports,
+						Resources: a.Resources,
+						SecurityContext: &corev1.SecurityContext{
+							Privileged: &[]bool{true}[0],
+						},
+						VolumeMounts: []corev1.VolumeMount{{Name: "config", MountPath: configPath}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "config",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: a.Name},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
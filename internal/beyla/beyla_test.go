@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package beyla
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func parse(t *testing.T, a Agent) map[string]interface{} {
+	t.Helper()
+	config, err := Config(a)
+	require.NoError(t, err)
+	var parsed map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(config), &parsed))
+	return parsed
+}
+
+func TestConfigFeedsPrometheusAndTempo(t *testing.T) {
+	config := parse(t, Agent{
+		Namespaces:     []string{"shop", "pay.ments"},
+		Metrics:        true,
+		ServiceGraph:   true,
+		TracesEndpoint: "http://prod-tempo.monitoring.svc.cluster.local:4317",
+	})
+
+	assert.Equal(t, map[string]interface{}{"services": []interface{}{
+		map[string]interface{}{"k8s_namespace": "^shop$"},
+		map[string]interface{}{"k8s_namespace": `^pay\.ments$`},
+	}}, config["discovery"])
+	assert.Equal(t, map[string]interface{}{
+		"port":     float64(MetricsPort),
+		"path":     "/metrics",
+		"features": []interface{}{"application", "application_service_graph"},
+	}, config["prometheus_export"])
+	assert.Equal(t, map[string]interface{}{
+		"endpoint": "http://prod-tempo.monitoring.svc.cluster.local:4317",
+		"protocol": "grpc",
+	}, config["otel_traces_export"])
+}
+
+func TestConfigWithoutBackends(t *testing.T) {
+	config := parse(t, Agent{})
+
+	assert.Equal(t, map[string]interface{}{"services": []interface{}{
+		map[string]interface{}{"k8s_namespace": "."},
+	}}, config["discovery"])
+	assert.NotContains(t, config, "prometheus_export")
+	assert.NotContains(t, config, "otel_traces_export")
+}
+
+func TestBuildDaemonSetAnnotatesMetrics(t *testing.T) {
+	a := Agent{Name: AgentName("prod"), Namespace: "monitoring", Version: "1.8.4", Metrics: true}
+
+	ds := BuildDaemonSet(a)
+	assert.Equal(t, "beyla-prod", ds.Name)
+	assert.True(t, ds.Spec.Template.Spec.HostPID)
+	assert.Equal(t, "true", ds.Spec.Template.Annotations["prometheus.io/scrape"])
+	assert.Equal(t, "9400", ds.Spec.Template.Annotations["prometheus.io/port"])
+	assert.Equal(t, "grafana/beyla:1.8.4", ds.Spec.Template.Spec.Containers[0].Image)
+
+	a.Metrics = false
+	ds = BuildDaemonSet(a)
+	assert.Empty(t, ds.Spec.Template.Annotations)
+	assert.Empty(t, ds.Spec.Template.Spec.Containers[0].Ports)
+}
+
+func TestBuildDaemonSetSendsTenant(t *testing.T) {
+	a := Agent{Name: AgentName("prod"), Version: "1.8.4", TenantID: "fake"}
+	assert.Len(t, BuildDaemonSet(a).Spec.Template.Spec.Containers[0].Env, 1)
+
+	a.TracesEndpoint = "http://prod-tempo.monitoring.svc.cluster.local:4317"
+	env := BuildDaemonSet(a).Spec.Template.Spec.Containers[0].Env
+	require.Len(t, env, 2)
+	assert.Equal(t, "OTEL_EXPORTER_OTLP_TRACES_HEADERS", env[1].Name)
+	assert.Equal(t, "X-Scope-OrgID=fake", env[1].Value)
+}
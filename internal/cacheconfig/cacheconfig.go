@@ -0,0 +1,241 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package cacheconfig configures the informer cache of the operator. On
+// clusters with many Secrets and ConfigMaps, caching every object of a kind
+// delays startup until the informers have synced and holds all of them in
+// memory. The cache can be restricted to the objects matching label and field
+// selectors, and kinds can be excluded from it entirely: reads of excluded
+// kinds go to the API server and no informer is started for them.
+package cacheconfig
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// kind is a cached type
+type kind struct {
+	object func() client.Object
+	list   func() client.ObjectList
+}
+
+// kinds maps the names accepted on the command line to the cached types
+var kinds = map[string]kind{
+	"configmaps": {
+		func() client.Object { return &corev1.ConfigMap{} },
+		func() client.ObjectList { return &corev1.ConfigMapList{} },
+	},
+	"secrets": {
+		func() client.Object { return &corev1.Secret{} },
+		func() client.ObjectList { return &corev1.SecretList{} },
+	},
+	"services": {
+		func() client.Object { return &corev1.Service{} },
+		func() client.ObjectList { return &corev1.ServiceList{} },
+	},
+	"pods": {
+		func() client.Object { return &corev1.Pod{} },
+		func() client.ObjectList { return &corev1.PodList{} },
+	},
+	"events": {
+		func() client.Object { return &corev1.Event{} },
+		func() client.ObjectList { return &corev1.EventList{} },
+	},
+	"persistentvolumeclaims": {
+		func() client.Object { return &corev1.PersistentVolumeClaim{} },
+		func() client.ObjectList { return &corev1.PersistentVolumeClaimList{} },
+	},
+	"deployments": {
+		func() client.Object { return &appsv1.Deployment{} },
+		func() client.ObjectList { return &appsv1.DeploymentList{} },
+	},
+	"statefulsets": {
+		func() client.Object { return &appsv1.StatefulSet{} },
+		func() client.ObjectList { return &appsv1.StatefulSetList{} },
+	},
+	"daemonsets": {
+		func() client.Object { return &appsv1.DaemonSet{} },
+		func() client.ObjectList { return &appsv1.DaemonSetList{} },
+	},
+	"jobs": {
+		func() client.Object { return &batchv1.Job{} },
+		func() client.ObjectList { return &batchv1.JobList{} },
+	},
+}
+
+// DefaultSelectorKinds are the kinds the selectors apply to when none are
+// given, the kinds that are the most numerous on large clusters
+var DefaultSelectorKinds = []string{"configmaps", "secrets"}
+
+// Config is the cache configuration
+type Config struct {
+	// Namespace restricts the cache, all namespaces if empty
+	Namespace string
+	// LabelSelector restricts the cached objects of the selector kinds
+	LabelSelector string
+	// FieldSelector restricts the cached objects of the selector kinds
+	FieldSelector string
+	// SelectorKinds are the kinds the selectors apply to,
+	// DefaultSelectorKinds if empty
+	SelectorKinds []string
+	// ExcludeKinds are never cached
+	ExcludeKinds []string
+	// StripManagedFields drops the managed fields of the cached objects
+	StripManagedFields bool
+	// StripAnnotations are dropped from the cached objects
+	StripAnnotations []string
+	// MaxAnnotationBytes drops the annotations of the cached objects larger
+	// than it, no limit if zero
+	MaxAnnotationBytes int
+	// RecordStripped is called with the kind and the number of bytes stripped
+	// from each cached object, optional
+	RecordStripped func(kind string, bytes int)
+}
+
+// Kinds returns the kind names accepted by the configuration
+func Kinds() []string {
+	names := make([]string, 0, len(kinds))
+	for name := range kinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseKinds splits a comma separated list of kind names
+func ParseKinds(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// objects returns an object of each named kind
+func objects(names []string) ([]client.Object, error) {
+	objs := make([]client.Object, 0, len(names))
+	for _, name := range names {
+		k, ok := kinds[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown kind %q, expected one of %s", name, strings.Join(Kinds(), ", "))
+		}
+		objs = append(objs, k.object())
+	}
+	return objs, nil
+}
+
+// Validate checks the kinds and selectors of the configuration
+func (c Config) Validate() error {
+	_, err := c.CacheOptions()
+	return err
+}
+
+// CacheOptions returns the options of the manager's cache
+func (c Config) CacheOptions() (cache.Options, error) {
+	opts := cache.Options{DefaultTransform: c.Transform()}
+	if c.Namespace != "" {
+		opts.DefaultNamespaces = map[string]cache.Config{c.Namespace: {}}
+	}
+
+	excluded, err := objects(c.ExcludeKinds)
+	if err != nil {
+		return opts, fmt.Errorf("invalid excluded kinds: %w", err)
+	}
+	if c.LabelSelector == "" && c.FieldSelector == "" {
+		return opts, nil
+	}
+
+	byObject := cache.ByObject{}
+	if c.LabelSelector != "" {
+		if byObject.Label, err = labels.Parse(c.LabelSelector); err != nil {
+			return opts, fmt.Errorf("invalid label selector %q: %w", c.LabelSelector, err)
+		}
+	}
+	if c.FieldSelector != "" {
+		if byObject.Field, err = fields.ParseSelector(c.FieldSelector); err != nil {
+			return opts, fmt.Errorf("invalid field selector %q: %w", c.FieldSelector, err)
+		}
+	}
+
+	selectorKinds := c.SelectorKinds
+	if len(selectorKinds) == 0 {
+		selectorKinds = DefaultSelectorKinds
+	}
+	selected, err := objects(selectorKinds)
+	if err != nil {
+		return opts, fmt.Errorf("invalid selector kinds: %w", err)
+	}
+	opts.ByObject = map[client.Object]cache.ByObject{}
+	for _, obj := range selected {
+		// Excluded kinds have no informer to restrict
+		if contains(excluded, obj) {
+			continue
+		}
+		opts.ByObject[obj] = byObject
+	}
+	return opts, nil
+}
+
+// ClientOptions returns the options of the manager's client. Reads of the
+// excluded kinds bypass the cache, so their informers are never started.
+func (c Config) ClientOptions() (client.Options, error) {
+	excluded, err := objects(c.ExcludeKinds)
+	if err != nil {
+		return client.Options{}, fmt.Errorf("invalid excluded kinds: %w", err)
+	}
+	if len(excluded) == 0 {
+		return client.Options{}, nil
+	}
+	return client.Options{Cache: &client.CacheOptions{DisableFor: excluded}}, nil
+}
+
+// Lists returns a list constructor for each of the named kinds that is not
+// excluded from the cache. Unknown names are ignored.
+func (c Config) Lists(names ...string) map[string]func() client.ObjectList {
+	lists := map[string]func() client.ObjectList{}
+	for _, name := range names {
+		k, ok := kinds[name]
+		if !ok || c.Excludes(k.object()) {
+			continue
+		}
+		lists[name] = k.list
+	}
+	return lists
+}
+
+// Excludes reports whether the kind of obj is excluded from the cache.
+// Controllers must not watch excluded kinds, as the watch would start the
+// informer the exclusion avoids.
+func (c Config) Excludes(obj client.Object) bool {
+	excluded, err := objects(c.ExcludeKinds)
+	if err != nil {
+		return false
+	}
+	return contains(excluded, obj)
+}
+
+// contains reports whether objs holds an object of the type of obj
+func contains(objs []client.Object, obj client.Object) bool {
+	for _, o := range objs {
+		if reflect.TypeOf(o) == reflect.TypeOf(obj) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package cacheconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestParseKinds(t *testing.T) {
+	assert.Equal(t, []string{"secrets", "configmaps"}, ParseKinds(" Secrets, configmaps,,"))
+	assert.Empty(t, ParseKinds(""))
+}
+
+func TestCacheOptionsWithoutSelectorCachesEverything(t *testing.T) {
+	opts, err := Config{Namespace: "monitoring"}.CacheOptions()
+	require.NoError(t, err)
+	assert.Contains(t, opts.DefaultNamespaces, "monitoring")
+	assert.Empty(t, opts.ByObject)
+}
+
+func TestCacheOptionsRestrictsSelectorKinds(t *testing.T) {
+	opts, err := Config{
+		LabelSelector: "app.kubernetes.io/managed-by=gunj-operator",
+		ExcludeKinds:  []string{"secrets"},
+	}.CacheOptions()
+	require.NoError(t, err)
+	require.Len(t, opts.ByObject, 1)
+	for obj, byObject := range opts.ByObject {
+		assert.IsType(t, &corev1.ConfigMap{}, obj)
+		assert.True(t, byObject.Label.Matches(labels.Set{"app.kubernetes.io/managed-by": "gunj-operator"}))
+		assert.False(t, byObject.Label.Matches(labels.Set{"app": "other"}))
+		assert.Nil(t, byObject.Field)
+	}
+}
+
+func TestCacheOptionsRejectsInvalidInput(t *testing.T) {
+	_, err := Config{LabelSelector: "a in (", SelectorKinds: []string{"pods"}}.CacheOptions()
+	assert.Error(t, err)
+	_, err = Config{LabelSelector: "a=b", SelectorKinds: []string{"widgets"}}.CacheOptions()
+	assert.Error(t, err)
+	assert.Error(t, Config{ExcludeKinds: []string{"widgets"}}.Validate())
+}
+
+func TestClientOptionsDisableCacheForExcludedKinds(t *testing.T) {
+	config := Config{ExcludeKinds: []string{"secrets", "statefulsets"}}
+	opts, err := config.ClientOptions()
+	require.NoError(t, err)
+	require.NotNil(t, opts.Cache)
+	assert.Len(t, opts.Cache.DisableFor, 2)
+	assert.True(t, config.Excludes(&corev1.Secret{}))
+	assert.True(t, config.Excludes(&appsv1.StatefulSet{}))
+	assert.False(t, config.Excludes(&corev1.ConfigMap{}))
+
+	opts, err = Config{}.ClientOptions()
+	require.NoError(t, err)
+	assert.Nil(t, opts.Cache)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package cacheconfig
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SizeReporter periodically estimates the memory held by the cache for each
+// kind, so large fleets can tell which kinds to restrict, strip or exclude.
+// Only list kinds that are watched anyway: listing a kind from the cache
+// starts its informer.
+type SizeReporter struct {
+	// Reader is the manager's cache
+	Reader client.Reader
+	// Lists maps the reported kinds to a list constructor
+	Lists map[string]func() client.ObjectList
+	// Interval between reports, 5 minutes if zero
+	Interval time.Duration
+	// Record is called with the kind, the number of objects and their
+	// estimated size in bytes
+	Record func(kind string, objects, bytes int)
+	Log    logr.Logger
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, every
+// replica has its own cache
+func (s *SizeReporter) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable
+func (s *SizeReporter) Start(ctx context.Context) error {
+	interval := s.Interval
+	if interval == 0 {
+		interval = 5 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.Report(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Report records the size of each kind
+func (s *SizeReporter) Report(ctx context.Context) {
+	for kind, newList := range s.Lists {
+		list := newList()
+		if err := s.Reader.List(ctx, list); err != nil {
+			s.Log.Error(err, "Failed to list cached objects", "kind", kind)
+			continue
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			s.Log.Error(err, "Failed to extract cached objects", "kind", kind)
+			continue
+		}
+		bytes := 0
+		for _, item := range items {
+			bytes += objectSize(item)
+		}
+		s.Record(kind, len(items), bytes)
+	}
+}
+
+// objectSize estimates the size of an object by its protobuf encoding, or
+// its JSON encoding for types without one such as custom resources
+func objectSize(obj runtime.Object) int {
+	if sized, ok := obj.(interface{ Size() int }); ok {
+		return sized.Size()
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
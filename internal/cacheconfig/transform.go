@@ -0,0 +1,210 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package cacheconfig
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// StrippedAnnotation lists the annotations stripped from a cached object. It
+// only exists on cached copies, the client restores the listed annotations
+// from the API server before updating the object.
+const StrippedAnnotation = "observability.io/cache-stripped-annotations"
+
+// DefaultStripAnnotations are the large annotations the operator never reads
+// from the cache: the last applied configuration of kubectl and the fields
+// preserved by the conversion webhook
+var DefaultStripAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"observability.io/conversion-data",
+}
+
+// strips reports whether the transform changes the cached objects
+func (c Config) strips() bool {
+	return c.StripManagedFields || len(c.StripAnnotations) > 0 || c.MaxAnnotationBytes > 0
+}
+
+// Transform returns the transform applied to the objects before they are
+// cached, nil if it would not change them. Managed fields are set to nil
+// rather than emptied, as an update with nil managed fields leaves them
+// unchanged on the server.
+func (c Config) Transform() toolscache.TransformFunc {
+	if !c.strips() {
+		return nil
+	}
+	strip := make(map[string]bool, len(c.StripAnnotations))
+	for _, key := range c.StripAnnotations {
+		strip[key] = true
+	}
+
+	return func(in interface{}) (interface{}, error) {
+		// Deletion tombstones are passed through
+		obj, ok := in.(client.Object)
+		if !ok {
+			return in, nil
+		}
+
+		stripped := 0
+		if c.StripManagedFields {
+			for _, field := range obj.GetManagedFields() {
+				if field.FieldsV1 != nil {
+					stripped += len(field.FieldsV1.Raw)
+				}
+			}
+			obj.SetManagedFields(nil)
+		}
+
+		annotations := obj.GetAnnotations()
+		var keys []string
+		for key, value := range annotations {
+			if key == StrippedAnnotation {
+				continue
+			}
+			if strip[key] || (c.MaxAnnotationBytes > 0 && len(value) > c.MaxAnnotationBytes) {
+				keys = append(keys, key)
+				stripped += len(value)
+				delete(annotations, key)
+			}
+		}
+		if len(keys) > 0 {
+			sort.Strings(keys)
+			annotations[StrippedAnnotation] = strings.Join(keys, ",")
+			obj.SetAnnotations(annotations)
+		}
+
+		if stripped > 0 && c.RecordStripped != nil {
+			c.RecordStripped(kindOf(obj), stripped)
+		}
+		return obj, nil
+	}
+}
+
+// kindOf returns the kind of a cached object. Typed objects have no TypeMeta
+// in the cache, their kind is the name of their type.
+func kindOf(obj client.Object) string {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	t := reflect.TypeOf(obj)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// NewClient creates the manager's client. Objects read from the cache miss
+// the stripped annotations, so before an update the client restores them from
+// the API server; otherwise the update would delete them.
+func (c Config) NewClient(config *rest.Config, options client.Options) (client.Client, error) {
+	cached, err := client.New(config, options)
+	if err != nil {
+		return nil, err
+	}
+	if !c.strips() {
+		return cached, nil
+	}
+
+	options.Cache = nil
+	live, err := client.New(config, options)
+	if err != nil {
+		return nil, err
+	}
+	return &restoringClient{Client: cached, live: live}, nil
+}
+
+// restoringClient restores the stripped annotations of the objects it
+// updates or patches, including through the status subresource
+type restoringClient struct {
+	client.Client
+	live client.Reader
+}
+
+// Update restores the stripped annotations of obj and updates it
+func (r *restoringClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := restore(ctx, r.live, obj); err != nil {
+		return err
+	}
+	return r.Client.Update(ctx, obj, opts...)
+}
+
+// Patch restores the stripped annotations of obj and patches it. Patches
+// sending the annotations of obj whole, like apply patches, would otherwise
+// delete the stripped ones and store the list of them.
+func (r *restoringClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := restore(ctx, r.live, obj); err != nil {
+		return err
+	}
+	return r.Client.Patch(ctx, obj, patch, opts...)
+}
+
+// Status returns a writer of the status subresource restoring the stripped
+// annotations
+func (r *restoringClient) Status() client.SubResourceWriter {
+	return &restoringStatusWriter{SubResourceWriter: r.Client.Status(), live: r.live}
+}
+
+// restoringStatusWriter restores the stripped annotations of the objects
+// whose status it writes. The API server ignores the metadata of status
+// writes, but resources without a status subresource take the whole object.
+type restoringStatusWriter struct {
+	client.SubResourceWriter
+	live client.Reader
+}
+
+// Update restores the stripped annotations of obj and updates its status
+func (w *restoringStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if err := restore(ctx, w.live, obj); err != nil {
+		return err
+	}
+	return w.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+// Patch restores the stripped annotations of obj and patches its status
+func (w *restoringStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if err := restore(ctx, w.live, obj); err != nil {
+		return err
+	}
+	return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
+
+// restore copies the stripped annotations of obj back from the API server.
+// Annotations set on obj since it was read are kept.
+func restore(ctx context.Context, live client.Reader, obj client.Object) error {
+	annotations := obj.GetAnnotations()
+	keys, ok := annotations[StrippedAnnotation]
+	if !ok {
+		return nil
+	}
+	delete(annotations, StrippedAnnotation)
+
+	current, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("failed to copy %T", obj)
+	}
+	current.SetAnnotations(nil)
+	if err := live.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		return fmt.Errorf("failed to read the stripped annotations of %s: %w", obj.GetName(), err)
+	}
+	for _, key := range strings.Split(keys, ",") {
+		if _, set := annotations[key]; set {
+			continue
+		}
+		if value, ok := current.GetAnnotations()[key]; ok {
+			annotations[key] = value
+		}
+	}
+	obj.SetAnnotations(annotations)
+	return nil
+}
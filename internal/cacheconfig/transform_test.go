@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package cacheconfig
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func platformConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "prometheus-prod",
+			Namespace: "monitoring",
+			Annotations: map[string]string{
+				"observability.io/conversion-data": strings.Repeat("x", 100),
+				"observability.io/config-hash":     "abc",
+				"example.com/large":                strings.Repeat("y", 50),
+			},
+			ManagedFields: []metav1.ManagedFieldsEntry{{
+				Manager:  "gunj-operator",
+				FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:data":{}}`)},
+			}},
+		},
+	}
+}
+
+func TestTransformIsNilWithoutStripping(t *testing.T) {
+	assert.Nil(t, Config{}.Transform())
+}
+
+func TestTransformStripsManagedFieldsAndAnnotations(t *testing.T) {
+	recorded := map[string]int{}
+	transform := Config{
+		StripManagedFields: true,
+		StripAnnotations:   DefaultStripAnnotations,
+		MaxAnnotationBytes: 40,
+		RecordStripped:     func(kind string, bytes int) { recorded[kind] += bytes },
+	}.Transform()
+	require.NotNil(t, transform)
+
+	out, err := transform(platformConfigMap())
+	require.NoError(t, err)
+	cm := out.(*corev1.ConfigMap)
+	assert.Nil(t, cm.ManagedFields)
+	assert.Equal(t, map[string]string{
+		"observability.io/config-hash": "abc",
+		StrippedAnnotation:             "example.com/large,observability.io/conversion-data",
+	}, cm.Annotations)
+	assert.Equal(t, map[string]int{"ConfigMap": len(`{"f:data":{}}`) + 150}, recorded)
+}
+
+func TestClientRestoresStrippedAnnotationsOnUpdate(t *testing.T) {
+	live := fake.NewClientBuilder().WithObjects(platformConfigMap()).Build()
+	c := &restoringClient{Client: live, live: live}
+
+	cached := platformConfigMap()
+	out, err := Config{StripAnnotations: DefaultStripAnnotations}.Transform()(cached)
+	require.NoError(t, err)
+	cm := out.(*corev1.ConfigMap)
+	current := &corev1.ConfigMap{}
+	require.NoError(t, live.Get(context.Background(), client.ObjectKeyFromObject(cm), current))
+	cm.ResourceVersion = current.ResourceVersion
+	cm.Annotations["observability.io/config-hash"] = "def"
+
+	require.NoError(t, c.Update(context.Background(), cm))
+
+	updated := &corev1.ConfigMap{}
+	require.NoError(t, live.Get(context.Background(), client.ObjectKeyFromObject(cm), updated))
+	assert.Equal(t, strings.Repeat("x", 100), updated.Annotations["observability.io/conversion-data"])
+	assert.Equal(t, "def", updated.Annotations["observability.io/config-hash"])
+	assert.NotContains(t, updated.Annotations, StrippedAnnotation)
+}
+
+func TestClientRestoresStrippedAnnotationsOnPatch(t *testing.T) {
+	live := fake.NewClientBuilder().WithObjects(platformConfigMap()).Build()
+	c := &restoringClient{Client: live, live: live}
+
+	out, err := Config{StripAnnotations: DefaultStripAnnotations}.Transform()(platformConfigMap())
+	require.NoError(t, err)
+	cm := out.(*corev1.ConfigMap)
+	cm.Annotations["observability.io/config-hash"] = "def"
+
+	// A patch from an object without annotations sends them whole
+	base := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: cm.Name, Namespace: cm.Namespace}}
+	require.NoError(t, c.Patch(context.Background(), cm, client.MergeFrom(base)))
+
+	updated := &corev1.ConfigMap{}
+	require.NoError(t, live.Get(context.Background(), client.ObjectKeyFromObject(cm), updated))
+	assert.Equal(t, strings.Repeat("x", 100), updated.Annotations["observability.io/conversion-data"])
+	assert.Equal(t, "def", updated.Annotations["observability.io/config-hash"])
+	assert.NotContains(t, updated.Annotations, StrippedAnnotation)
+}
+
+func TestClientRestoresStrippedAnnotationsOnStatusUpdate(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: platformConfigMap().ObjectMeta}
+	live := fake.NewClientBuilder().WithObjects(pod).WithStatusSubresource(&corev1.Pod{}).Build()
+	c := &restoringClient{Client: live, live: live}
+
+	out, err := Config{StripAnnotations: DefaultStripAnnotations}.Transform()(pod.DeepCopy())
+	require.NoError(t, err)
+	cached := out.(*corev1.Pod)
+	current := &corev1.Pod{}
+	require.NoError(t, live.Get(context.Background(), client.ObjectKeyFromObject(cached), current))
+	cached.ResourceVersion = current.ResourceVersion
+	cached.Status.Phase = corev1.PodRunning
+
+	require.NoError(t, c.Status().Update(context.Background(), cached))
+	assert.Equal(t, strings.Repeat("x", 100), cached.Annotations["observability.io/conversion-data"])
+	assert.NotContains(t, cached.Annotations, StrippedAnnotation)
+
+	updated := &corev1.Pod{}
+	require.NoError(t, live.Get(context.Background(), client.ObjectKeyFromObject(cached), updated))
+	assert.Equal(t, corev1.PodRunning, updated.Status.Phase)
+	assert.Equal(t, strings.Repeat("x", 100), updated.Annotations["observability.io/conversion-data"])
+	assert.NotContains(t, updated.Annotations, StrippedAnnotation)
+}
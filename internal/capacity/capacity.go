@@ -0,0 +1,206 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package capacity forecasts the growth of a platform. It reads the daily
+// history of the series of Prometheus, the log volume of Loki, the span
+// volume of Tempo and the storage used by the component volumes from the
+// platform's Prometheus, fits a linear trend to each, and recommends the
+// scaling actions the 30 and 90 day forecasts call for.
+package capacity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Step is the resolution of the history, one point per day
+	Step = 24 * time.Hour
+
+	// MinPoints is the number of days of history a trend needs
+	MinPoints = 7
+)
+
+// Point is a daily value
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// SeriesQuery returns the daily peak of the active series of Prometheus.
+// Replicas hold the same series, so the busiest one counts.
+func SeriesQuery() string {
+	return `max(max_over_time(prometheus_tsdb_head_series{job="prometheus"}[1d]))`
+}
+
+// LogsQuery returns the bytes of logs received by the Loki of a namespace
+// per day
+func LogsQuery(namespace string) string {
+	return fmt.Sprintf(`sum(increase(loki_distributor_bytes_received_total{kubernetes_namespace=%q}[1d]))`, namespace)
+}
+
+// SpansQuery returns the spans received by the Tempo of a namespace per day
+func SpansQuery(namespace string) string {
+	return fmt.Sprintf(`sum(increase(tempo_distributor_spans_received_total{kubernetes_namespace=%q}[1d]))`, namespace)
+}
+
+// StorageQuery returns the daily peak of the bytes used by the volumes of
+// claims, summed over the claims
+func StorageQuery(namespace string, claims []string) string {
+	names := make([]string, 0, len(claims))
+	for _, claim := range claims {
+		names = append(names, regexp.QuoteMeta(claim))
+	}
+	return fmt.Sprintf(`sum(max_over_time(kubelet_volume_stats_used_bytes{namespace=%q,persistentvolumeclaim=~%q}[1d]))`,
+		namespace, strings.Join(names, "|"))
+}
+
+// Fetch runs a range query with a daily step against the Prometheus at
+// baseURL and returns the points of its first series. The queries are
+// aggregated to a single series.
+func Fetch(ctx context.Context, httpClient *http.Client, baseURL, query string, start, end time.Time) ([]Point, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", strconv.FormatInt(start.Unix(), 10))
+	params.Set("end", strconv.FormatInt(end.Unix(), 10))
+	params.Set("step", strconv.FormatFloat(Step.Seconds(), 'f', -1, 64))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/api/v1/query_range?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Prometheus: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Prometheus response: %w", err)
+	}
+	return Parse(body)
+}
+
+// Parse parses the first series of the matrix returned by a range query.
+// Samples which aren't numbers are skipped.
+func Parse(body []byte) ([]Point, error) {
+	var response struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Data   struct {
+			ResultType string `json:"resultType"`
+			Result     []struct {
+				Values [][2]interface{} `json:"values"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode Prometheus response: %w", err)
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed: %s", response.Error)
+	}
+	if response.Data.ResultType != "matrix" {
+		return nil, fmt.Errorf("unexpected result type %q", response.Data.ResultType)
+	}
+	if len(response.Data.Result) == 0 {
+		return nil, nil
+	}
+
+	values := response.Data.Result[0].Values
+	points := make([]Point, 0, len(values))
+	for _, value := range values {
+		ts, ok := value[0].(float64)
+		if !ok {
+			return nil, fmt.Errorf("invalid sample timestamp %v", value[0])
+		}
+		s, ok := value[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid sample value %v", value[1])
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		points = append(points, Point{Time: time.Unix(int64(ts), 0).UTC(), Value: v})
+	}
+	return points, nil
+}
+
+// Trend is the linear trend of a resource
+type Trend struct {
+	// Current is the latest value
+	Current float64
+	// Slope is the growth per day
+	Slope float64
+	// Days is the number of daily points the trend was fit on
+	Days int
+
+	// fitted is the value of the trend line at the latest point
+	fitted float64
+}
+
+// Fit fits a least squares line to the points. It returns an error if
+// there are fewer than MinPoints.
+func Fit(points []Point) (Trend, error) {
+	if len(points) < MinPoints {
+		return Trend{}, fmt.Errorf("%d days of history, %d needed", len(points), MinPoints)
+	}
+
+	first := points[0].Time
+	var sumX, sumY, sumXY, sumXX float64
+	for _, p := range points {
+		x := p.Time.Sub(first).Hours() / 24
+		sumX += x
+		sumY += p.Value
+		sumXY += x * p.Value
+		sumXX += x * x
+	}
+	n := float64(len(points))
+	meanX, meanY := sumX/n, sumY/n
+
+	var slope float64
+	if variance := sumXX - n*meanX*meanX; variance > 0 {
+		slope = (sumXY - n*meanX*meanY) / variance
+	}
+	last := points[len(points)-1]
+	lastX := last.Time.Sub(first).Hours() / 24
+	return Trend{
+		Current: last.Value,
+		Slope:   slope,
+		Days:    len(points),
+		fitted:  meanY + slope*(lastX-meanX),
+	}, nil
+}
+
+// In returns the value forecast in days. The forecast starts from the
+// trend line, not from the latest value, so a single unusual day doesn't
+// shift it.
+func (t Trend) In(days float64) float64 {
+	return math.Max(0, t.fitted+t.Slope*days)
+}
+
+// DaysUntil returns the number of days until the trend reaches a value, 0
+// if it already has, and false if it never does
+func (t Trend) DaysUntil(value float64) (int32, bool) {
+	if t.Current >= value || t.fitted >= value {
+		return 0, true
+	}
+	if t.Slope <= 0 {
+		return 0, false
+	}
+	return int32(math.Ceil((value - t.fitted) / t.Slope)), true
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package capacity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+var start = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// linear returns daily points growing from base by slope a day
+func linear(days int, base, slope float64) []Point {
+	points := make([]Point, 0, days)
+	for i := 0; i < days; i++ {
+		points = append(points, Point{Time: start.Add(time.Duration(i) * Step), Value: base + slope*float64(i)})
+	}
+	return points
+}
+
+func TestFit(t *testing.T) {
+	trend, err := Fit(linear(30, 1000, 10))
+	require.NoError(t, err)
+	assert.Equal(t, 30, trend.Days)
+	assert.InDelta(t, 1290, trend.Current, 1e-9)
+	assert.InDelta(t, 10, trend.Slope, 1e-9)
+	assert.InDelta(t, 1590, trend.In(30), 1e-9)
+	assert.InDelta(t, 2190, trend.In(90), 1e-9)
+
+	days, ok := trend.DaysUntil(1500)
+	assert.True(t, ok)
+	assert.Equal(t, int32(21), days)
+	days, ok = trend.DaysUntil(1000)
+	assert.True(t, ok)
+	assert.Zero(t, days, "already reached")
+
+	// Shrinking usage never reaches more, and is not forecast below zero
+	shrinking, err := Fit(linear(10, 100, -20))
+	require.NoError(t, err)
+	_, ok = shrinking.DaysUntil(1000)
+	assert.False(t, ok)
+	assert.Zero(t, shrinking.In(90))
+
+	_, err = Fit(linear(MinPoints-1, 1000, 10))
+	assert.Error(t, err)
+}
+
+func TestFitSpike(t *testing.T) {
+	points := linear(30, 1000, 0)
+	points[len(points)-1].Value = 5000
+
+	trend, err := Fit(points)
+	require.NoError(t, err)
+	assert.Equal(t, 5000.0, trend.Current)
+	assert.Less(t, trend.In(0), 1600.0, "a single day barely moves the trend")
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/query_range", r.URL.Path)
+		assert.Equal(t, "86400", r.URL.Query().Get("step"))
+		assert.Equal(t, SeriesQuery(), r.URL.Query().Get("query"))
+		fmt.Fprint(w, `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[1748736000,"100"],[1748822400,"NaN"],[1748908800,"120"]]}]}}`)
+	}))
+	defer server.Close()
+
+	points, err := Fetch(context.Background(), server.Client(), server.URL, SeriesQuery(), start, start.Add(2*Step))
+	require.NoError(t, err)
+	assert.Equal(t, []Point{
+		{Time: start, Value: 100},
+		{Time: start.Add(2 * Step), Value: 120},
+	}, points)
+
+	points, err = Parse([]byte(`{"status":"success","data":{"resultType":"matrix","result":[]}}`))
+	require.NoError(t, err)
+	assert.Empty(t, points)
+
+	_, err = Parse([]byte(`{"status":"error","error":"bad_data"}`))
+	assert.Error(t, err)
+}
+
+func TestQueries(t *testing.T) {
+	assert.Contains(t, LogsQuery("monitoring"), `kubernetes_namespace="monitoring"`)
+	assert.Contains(t, SpansQuery("monitoring"), "tempo_distributor_spans_received_total")
+	query := StorageQuery("monitoring", []string{"data-loki-production-0", "data-loki-production-1"})
+	assert.True(t, strings.Contains(query, `persistentvolumeclaim=~"data-loki-production-0|data-loki-production-1"`), query)
+}
+
+func TestRecommend(t *testing.T) {
+	platform := &observabilityv1beta1.ObservabilityPlatform{
+		Spec: observabilityv1beta1.ObservabilityPlatformSpec{
+			Components: &observabilityv1beta1.Components{
+				Prometheus: &observabilityv1beta1.PrometheusSpec{
+					Enabled: true,
+					Resources: &observabilityv1beta1.ResourceRequirements{
+						Limits: &observabilityv1beta1.ResourceList{Memory: "4Gi"},
+					},
+				},
+				Loki:  &observabilityv1beta1.LokiSpec{Enabled: true, Replicas: 2},
+				Tempo: &observabilityv1beta1.TempoSpec{Enabled: true, Replicas: 1},
+			},
+		},
+	}
+	fit := func(points []Point) Trend {
+		trend, err := Fit(points)
+		require.NoError(t, err)
+		return trend
+	}
+	usages := []Usage{
+		// 1M series growing 10k a day reach 2.19M in 90 days
+		{Component: "prometheus", Resource: observabilityv1beta1.CapacityResourceSeries, Trend: fit(linear(30, 1e6, 1e4))},
+		// Flat logs need nothing
+		{Component: "loki", Resource: observabilityv1beta1.CapacityResourceLogs, Trend: fit(linear(30, 50e9, 0))},
+		{Component: "tempo", Resource: observabilityv1beta1.CapacityResourceSpans, Trend: fit(linear(30, 1e8, 1e6))},
+		// 2 volumes of 20Gi, 11.7GiB used growing 0.3GiB a day
+		{Component: "loki", Resource: observabilityv1beta1.CapacityResourceStorage, Trend: fit(linear(30, 3*gib, 0.3*gib)),
+			Capacity: 40 * gib, Volumes: 2},
+	}
+	opts := OptionsFor(&observabilityv1beta1.CapacityPlanningSpec{Enabled: true})
+
+	recommendations := Recommend(platform, usages, opts)
+	require.Len(t, recommendations, 3)
+	assert.Equal(t, "Raise spec.components.prometheus.resources.limits.memory from 4Gi to 7Gi", recommendations[0].Action)
+	assert.Equal(t, "The active series count is forecast to grow from 1.29M to 2.19M series (+70%) in 90 days", recommendations[0].Reason)
+	assert.Equal(t, "Scale spec.components.tempo.replicas from 1 to 2", recommendations[1].Action)
+	assert.Equal(t, "Expand spec.components.loki.storage.size from 20Gi to 25Gi", recommendations[2].Action)
+	assert.Equal(t, "Storage is forecast to reach 80% of 40.0 GiB in 68 days, and 38.7 GiB in 90 days", recommendations[2].Reason)
+
+	forecast := Forecast(usages[3], opts)
+	assert.Equal(t, observabilityv1beta1.CapacityForecast{
+		Component:    "loki",
+		Resource:     observabilityv1beta1.CapacityResourceStorage,
+		Unit:         "GiB",
+		Current:      "11.7",
+		GrowthPerDay: "+0.30",
+		In30Days:     "20.7",
+		In90Days:     "38.7",
+		Capacity:     "40.0",
+		FullInDays:   &[]int32{68}[0],
+	}, forecast)
+
+	logs := Forecast(usages[1], opts)
+	assert.Equal(t, "GB/day", logs.Unit)
+	assert.Equal(t, "50.0", logs.Current)
+	assert.Equal(t, "+0.00", logs.GrowthPerDay)
+	assert.Nil(t, logs.FullInDays)
+
+	// Without a memory limit, the growth is recommended as a percentage
+	platform.Spec.Components.Prometheus.Resources = nil
+	recommendations = Recommend(platform, usages[:1], opts)
+	require.Len(t, recommendations, 1)
+	assert.Equal(t, "Plan for 70% more Prometheus memory", recommendations[0].Action)
+}
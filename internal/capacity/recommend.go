@@ -0,0 +1,247 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package capacity
+
+import (
+	"fmt"
+	"math"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+const (
+	gib = 1 << 30
+
+	// horizon is the forecast the recommendations are based on
+	horizon = 90
+)
+
+// subjects name the resources in the reasons of the recommendations
+var subjects = map[string]string{
+	observabilityv1beta1.CapacityResourceSeries: "The active series count",
+	observabilityv1beta1.CapacityResourceLogs:   "The log volume",
+	observabilityv1beta1.CapacityResourceSpans:  "The span volume",
+}
+
+// Usage is the trend of a resource of a component
+type Usage struct {
+	Component string
+	Resource  string
+	Trend     Trend
+	// Capacity is the total size of the volumes in bytes, for storage
+	Capacity float64
+	// Volumes is the number of volumes, for storage
+	Volumes int
+}
+
+// Options tune the recommendations
+type Options struct {
+	// StorageThreshold is the ratio of the capacity the forecast storage
+	// may reach
+	StorageThreshold float64
+	// GrowthThreshold is the 90 day growth ratio above which scaling is
+	// recommended
+	GrowthThreshold float64
+}
+
+// OptionsFor returns the options of capacity planning settings
+func OptionsFor(spec *observabilityv1beta1.CapacityPlanningSpec) Options {
+	return Options{
+		StorageThreshold: float64(spec.GetStorageThreshold()) / 100,
+		GrowthThreshold:  float64(spec.GetGrowthThreshold()) / 100,
+	}
+}
+
+// Forecast returns the status of the trend of a usage
+func Forecast(u Usage, opts Options) observabilityv1beta1.CapacityForecast {
+	unit, format := units(u.Resource)
+	forecast := observabilityv1beta1.CapacityForecast{
+		Component:    u.Component,
+		Resource:     u.Resource,
+		Unit:         unit,
+		Current:      format(u.Trend.Current),
+		GrowthPerDay: formatGrowth(u.Trend.Slope, format),
+		In30Days:     format(u.Trend.In(30)),
+		In90Days:     format(u.Trend.In(horizon)),
+	}
+	if u.Resource == observabilityv1beta1.CapacityResourceStorage && u.Capacity > 0 {
+		forecast.Capacity = format(u.Capacity)
+		if days, ok := u.Trend.DaysUntil(opts.StorageThreshold * u.Capacity); ok && days <= horizon {
+			forecast.FullInDays = &days
+		}
+	}
+	return forecast
+}
+
+// Recommend returns the scaling actions the 90 day forecasts of the usages
+// call for: more Prometheus memory for more series, more Loki and Tempo
+// replicas for more logs and spans, and larger volumes for storage forecast
+// above the threshold
+func Recommend(platform *observabilityv1beta1.ObservabilityPlatform, usages []Usage, opts Options) []observabilityv1beta1.CapacityRecommendation {
+	var recommendations []observabilityv1beta1.CapacityRecommendation
+	for _, u := range usages {
+		var action, reason string
+		if u.Resource == observabilityv1beta1.CapacityResourceStorage {
+			action, reason = recommendStorage(u, opts)
+		} else {
+			action, reason = recommendScaling(platform, u, opts)
+		}
+		if action == "" {
+			continue
+		}
+		recommendations = append(recommendations, observabilityv1beta1.CapacityRecommendation{
+			Component: u.Component,
+			Resource:  u.Resource,
+			Action:    action,
+			Reason:    reason,
+		})
+	}
+	return recommendations
+}
+
+// recommendScaling scales the component receiving series, logs or spans
+// growing above the threshold, in proportion to the growth
+func recommendScaling(platform *observabilityv1beta1.ObservabilityPlatform, u Usage, opts Options) (string, string) {
+	current, in90 := u.Trend.Current, u.Trend.In(horizon)
+	if current <= 0 || in90 < current*(1+opts.GrowthThreshold) {
+		return "", ""
+	}
+	ratio := in90 / current
+	unit, format := units(u.Resource)
+	reason := fmt.Sprintf("%s is forecast to grow from %s to %s %s (+%.0f%%) in %d days",
+		subjects[u.Resource], format(current), format(in90), unit, (ratio-1)*100, horizon)
+
+	if u.Resource == observabilityv1beta1.CapacityResourceSeries {
+		// The memory of Prometheus grows with its active series
+		if limit := prometheusMemoryLimit(platform); limit > 0 {
+			target := math.Ceil(limit*ratio/gib) * gib
+			return fmt.Sprintf("Raise spec.components.prometheus.resources.limits.memory from %s to %s",
+				formatGi(limit), formatGi(target)), reason
+		}
+		return fmt.Sprintf("Plan for %.0f%% more Prometheus memory", (ratio-1)*100), reason
+	}
+
+	replicas := componentReplicas(platform, u.Component)
+	target := int32(math.Ceil(float64(replicas) * ratio))
+	return fmt.Sprintf("Scale spec.components.%s.replicas from %d to %d", u.Component, replicas, target), reason
+}
+
+// recommendStorage expands the volumes of a component whose storage is
+// forecast above the threshold within 90 days, so the forecast fits below it
+func recommendStorage(u Usage, opts Options) (string, string) {
+	if u.Capacity <= 0 || u.Volumes == 0 {
+		return "", ""
+	}
+	threshold := opts.StorageThreshold * u.Capacity
+	in90 := u.Trend.In(horizon)
+	days, ok := u.Trend.DaysUntil(threshold)
+	if !ok || days > horizon {
+		return "", ""
+	}
+
+	current := u.Capacity / float64(u.Volumes)
+	target := math.Max(math.Ceil(in90/opts.StorageThreshold/float64(u.Volumes)/gib)*gib, current+gib)
+	percent := opts.StorageThreshold * 100
+	reason := fmt.Sprintf("Storage is forecast to reach %.0f%% of %s GiB in %d days, and %s GiB in %d days",
+		percent, formatNumber(u.Capacity/gib), days, formatNumber(in90/gib), horizon)
+	if days == 0 {
+		reason = fmt.Sprintf("Storage is above %.0f%% of %s GiB, and forecast to reach %s GiB in %d days",
+			percent, formatNumber(u.Capacity/gib), formatNumber(in90/gib), horizon)
+	}
+	return fmt.Sprintf("Expand spec.components.%s.storage.size from %s to %s", u.Component, formatGi(current), formatGi(target)), reason
+}
+
+// prometheusMemoryLimit returns the memory limit of Prometheus in bytes, 0
+// if unlimited
+func prometheusMemoryLimit(platform *observabilityv1beta1.ObservabilityPlatform) float64 {
+	components := platform.Spec.Components
+	if components == nil || components.Prometheus == nil || components.Prometheus.Resources == nil ||
+		components.Prometheus.Resources.Limits == nil || components.Prometheus.Resources.Limits.Memory == "" {
+		return 0
+	}
+	limit, err := resource.ParseQuantity(components.Prometheus.Resources.Limits.Memory)
+	if err != nil {
+		return 0
+	}
+	return limit.AsApproximateFloat64()
+}
+
+// componentReplicas returns the replicas of Loki or Tempo, at least one
+func componentReplicas(platform *observabilityv1beta1.ObservabilityPlatform, component string) int32 {
+	var replicas int32
+	if components := platform.Spec.Components; components != nil {
+		switch component {
+		case "loki":
+			if components.Loki != nil {
+				replicas = components.Loki.Replicas
+			}
+		case "tempo":
+			if components.Tempo != nil {
+				replicas = components.Tempo.Replicas
+			}
+		}
+	}
+	if replicas < 1 {
+		return 1
+	}
+	return replicas
+}
+
+// units returns the unit of a resource and the formatter of its values
+func units(res string) (string, func(float64) string) {
+	switch res {
+	case observabilityv1beta1.CapacityResourceSeries:
+		return "series", formatCount
+	case observabilityv1beta1.CapacityResourceLogs:
+		return "GB/day", func(v float64) string { return formatNumber(v / 1e9) }
+	case observabilityv1beta1.CapacityResourceSpans:
+		return "spans/day", formatCount
+	default:
+		return "GiB", func(v float64) string { return formatNumber(v / gib) }
+	}
+}
+
+// formatCount formats a count with a k, M or B suffix
+func formatCount(v float64) string {
+	switch abs := math.Abs(v); {
+	case abs >= 1e9:
+		return formatNumber(v/1e9) + "B"
+	case abs >= 1e6:
+		return formatNumber(v/1e6) + "M"
+	case abs >= 1e3:
+		return formatNumber(v/1e3) + "k"
+	default:
+		return fmt.Sprintf("%.0f", v)
+	}
+}
+
+// formatNumber formats a value with three significant digits at most
+func formatNumber(v float64) string {
+	switch abs := math.Abs(v); {
+	case abs >= 100:
+		return fmt.Sprintf("%.0f", v)
+	case abs >= 10:
+		return fmt.Sprintf("%.1f", v)
+	default:
+		return fmt.Sprintf("%.2f", v)
+	}
+}
+
+// formatGrowth formats a daily growth with its sign
+func formatGrowth(slope float64, format func(float64) string) string {
+	if slope < 0 {
+		return "-" + format(-slope)
+	}
+	return "+" + format(slope)
+}
+
+// formatGi formats bytes as a whole number of Gi, rounded up
+func formatGi(bytes float64) string {
+	return fmt.Sprintf("%.0fGi", math.Ceil(bytes/gib))
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package certmanager builds cert-manager Certificates as unstructured
+// objects, so the operator requests serving certificates without depending
+// on the cert-manager API packages.
+package certmanager
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// CertificateGVK is the GroupVersionKind of cert-manager Certificates
+var CertificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+// Defaults of an IssuerRef
+const (
+	DefaultIssuerKind  = "Issuer"
+	DefaultIssuerGroup = "cert-manager.io"
+)
+
+// IssuerRef references the issuer of a certificate
+type IssuerRef struct {
+	Name  string
+	Kind  string
+	Group string
+}
+
+// Certificate is a serving certificate requested from cert-manager
+type Certificate struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	// SecretName is the Secret cert-manager writes the certificate to
+	SecretName string
+	DNSNames   []string
+	Issuer     IssuerRef
+	// Duration and RenewBefore are Go durations, the cert-manager defaults
+	// if empty
+	Duration    string
+	RenewBefore string
+}
+
+// Object returns the Certificate as an unstructured object. The private
+// key is rotated on every renewal.
+func (c Certificate) Object() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(CertificateGVK)
+	obj.SetName(c.Name)
+	obj.SetNamespace(c.Namespace)
+	obj.SetLabels(c.Labels)
+	obj.Object["spec"] = c.Spec()
+	return obj
+}
+
+// Spec returns the spec of the Certificate
+func (c Certificate) Spec() map[string]interface{} {
+	kind, group := c.Issuer.Kind, c.Issuer.Group
+	if kind == "" {
+		kind = DefaultIssuerKind
+	}
+	if group == "" {
+		group = DefaultIssuerGroup
+	}
+
+	dnsNames := make([]interface{}, 0, len(c.DNSNames))
+	seen := map[string]bool{}
+	for _, name := range c.DNSNames {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		dnsNames = append(dnsNames, name)
+	}
+
+	spec := map[string]interface{}{
+		"secretName": c.SecretName,
+		"dnsNames":   dnsNames,
+		"issuerRef": map[string]interface{}{
+			"name":  c.Issuer.Name,
+			"kind":  kind,
+			"group": group,
+		},
+		"usages":     []interface{}{"server auth", "digital signature", "key encipherment"},
+		"privateKey": map[string]interface{}{"rotationPolicy": "Always"},
+	}
+	if len(dnsNames) > 0 {
+		spec["commonName"] = dnsNames[0]
+	}
+	if c.Duration != "" {
+		spec["duration"] = c.Duration
+	}
+	if c.RenewBefore != "" {
+		spec["renewBefore"] = c.RenewBefore
+	}
+	return spec
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package certmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertificateObject(t *testing.T) {
+	obj := Certificate{
+		Name:        "traces-tempo-receiver-tls",
+		Namespace:   "monitoring",
+		Labels:      map[string]string{"app.kubernetes.io/component": "tempo"},
+		SecretName:  "traces-tempo-receiver-tls",
+		DNSNames:    []string{"traces-tempo.monitoring.svc", "", "traces-tempo.monitoring.svc", "tempo.example.com"},
+		Issuer:      IssuerRef{Name: "internal-ca"},
+		RenewBefore: "360h",
+	}.Object()
+
+	assert.Equal(t, CertificateGVK, obj.GroupVersionKind())
+	assert.Equal(t, "monitoring", obj.GetNamespace())
+	assert.Equal(t, map[string]interface{}{
+		"secretName": "traces-tempo-receiver-tls",
+		"commonName": "traces-tempo.monitoring.svc",
+		"dnsNames":   []interface{}{"traces-tempo.monitoring.svc", "tempo.example.com"},
+		"issuerRef": map[string]interface{}{
+			"name":  "internal-ca",
+			"kind":  "Issuer",
+			"group": "cert-manager.io",
+		},
+		"usages":      []interface{}{"server auth", "digital signature", "key encipherment"},
+		"privateKey":  map[string]interface{}{"rotationPolicy": "Always"},
+		"renewBefore": "360h",
+	}, obj.Object["spec"])
+}
+
+func TestCertificateClusterIssuer(t *testing.T) {
+	spec := Certificate{Issuer: IssuerRef{Name: "letsencrypt", Kind: "ClusterIssuer"}, Duration: "2160h"}.Spec()
+	assert.Equal(t, "ClusterIssuer", spec["issuerRef"].(map[string]interface{})["kind"])
+	assert.Equal(t, "2160h", spec["duration"])
+	assert.NotContains(t, spec, "commonName")
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package changesummary computes compact, human-readable summaries of the
+// settings changed between two reconciles of a platform.
+package changesummary
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion/redaction"
+)
+
+const (
+	// MaxValueLength is the longest value stored verbatim in a snapshot.
+	// Longer values, such as inline configuration, are stored as a digest.
+	MaxValueLength = 128
+
+	// MaxSummaryLength bounds the length of a summary
+	MaxSummaryLength = 1024
+
+	// unset is shown for settings that were added or removed
+	unset = "(unset)"
+)
+
+// Snapshot is a flattened view of settings keyed by dotted path,
+// e.g. "prometheus.resources.limits.memory"
+type Snapshot map[string]string
+
+// Change is a single changed setting
+type Change struct {
+	Path string
+	Old  string
+	New  string
+}
+
+// Flatten converts a value into a snapshot using its JSON representation.
+// Array elements are addressed by index and long values are replaced by a
+// digest so that snapshots stay small enough to keep in status.
+func Flatten(v interface{}) (Snapshot, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode settings: %w", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, fmt.Errorf("failed to decode settings: %w", err)
+	}
+
+	snapshot := Snapshot{}
+	flatten(snapshot, "", generic)
+	return snapshot, nil
+}
+
+func flatten(snapshot Snapshot, prefix string, v interface{}) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, child := range value {
+			flatten(snapshot, join(prefix, k), child)
+		}
+	case []interface{}:
+		for i, child := range value {
+			flatten(snapshot, prefix+"["+strconv.Itoa(i)+"]", child)
+		}
+	case nil:
+		// Null values are treated as unset
+	default:
+		s := fmt.Sprint(value)
+		if str, ok := value.(string); ok {
+			s = str
+		}
+		if len(s) > MaxValueLength {
+			sum := sha256.Sum256([]byte(s))
+			s = "sha256:" + hex.EncodeToString(sum[:])[:12]
+		}
+		snapshot[prefix] = s
+	}
+}
+
+func join(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// Diff returns the changed settings sorted by path
+func Diff(old, current Snapshot) []Change {
+	var changes []Change
+	for path, value := range current {
+		if prev, ok := old[path]; !ok {
+			changes = append(changes, Change{Path: path, Old: unset, New: value})
+		} else if prev != value {
+			changes = append(changes, Change{Path: path, Old: prev, New: value})
+		}
+	}
+	for path, prev := range old {
+		if _, ok := current[path]; !ok {
+			changes = append(changes, Change{Path: path, Old: prev, New: unset})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// Redact returns a copy of the snapshot with the values of the paths
+// matching the rules of r masked, and credentials in the other values masked.
+// A change of a masked value is not detected; the snapshot kept in status
+// holds nothing derived from it.
+func (s Snapshot) Redact(r *redaction.Redactor) Snapshot {
+	redacted := make(Snapshot, len(s))
+	for path, value := range s {
+		redacted[path] = redactValue(r, path, value)
+	}
+	return redacted
+}
+
+// Redact returns a copy of changes with the values redacted as by
+// Snapshot.Redact, so changes diffed against a snapshot recorded before
+// redaction don't reveal its values
+func Redact(changes []Change, r *redaction.Redactor) []Change {
+	redacted := make([]Change, 0, len(changes))
+	for _, c := range changes {
+		redacted = append(redacted, Change{
+			Path: c.Path,
+			Old:  redactValue(r, c.Path, c.Old),
+			New:  redactValue(r, c.Path, c.New),
+		})
+	}
+	return redacted
+}
+
+// redactValue redacts the value at a path; unset values stay visible
+func redactValue(r *redaction.Redactor, path, value string) string {
+	if value == unset {
+		return value
+	}
+	if redacted, ok := r.Value(path, value).(string); ok {
+		return redacted
+	}
+	return value
+}
+
+// Summarize renders changes grouped by their first path segment, e.g.
+// "prometheus: version v2.48.0→v2.51.0, resources.limits.memory 4Gi→6Gi".
+// The summary is truncated to MaxSummaryLength with a count of the
+// changes left out.
+func Summarize(changes []Change) string {
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var groups []string
+	byGroup := make(map[string][]string)
+	for _, c := range changes {
+		group, rest := c.Path, ""
+		if i := strings.IndexAny(c.Path, ".["); i > 0 {
+			group, rest = c.Path[:i], strings.TrimPrefix(c.Path[i:], ".")
+		}
+		if _, ok := byGroup[group]; !ok {
+			groups = append(groups, group)
+		}
+		entry := c.Old + "→" + c.New
+		if rest != "" {
+			entry = rest + " " + entry
+		}
+		byGroup[group] = append(byGroup[group], entry)
+	}
+
+	var b strings.Builder
+	written := 0
+	for gi, group := range groups {
+		for i, entry := range byGroup[group] {
+			var part string
+			switch {
+			case i == 0 && gi == 0:
+				part = group + ": " + entry
+			case i == 0:
+				part = "; " + group + ": " + entry
+			default:
+				part = ", " + entry
+			}
+			remaining := len(changes) - written
+			suffix := fmt.Sprintf(" (+%d more)", remaining)
+			if b.Len()+len(part)+len(suffix) > MaxSummaryLength && written > 0 {
+				b.WriteString(suffix)
+				return b.String()
+			}
+			b.WriteString(part)
+			written++
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package configcheck validates component configurations before they are
+// rolled out. A configuration is mounted into a short-lived Job running the
+// component's own validation tooling at the deployed version, so it is
+// checked by exactly the parser that would load it.
+package configcheck
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// State is the validation state of a configuration
+type State string
+
+const (
+	// StatePending means the validation Job has not finished yet
+	StatePending State = "Pending"
+	// StateValid means the configuration passed validation
+	StateValid State = "Valid"
+	// StateInvalid means the configuration failed validation
+	StateInvalid State = "Invalid"
+
+	// maxMessageLength bounds the validation output kept in status
+	maxMessageLength = 1024
+
+	defaultTTLSecondsAfterFinish int32 = 3600
+)
+
+// Tool is the validation command of a component
+type Tool struct {
+	// MountPath is where the component loads its configuration from
+	MountPath string
+	// Command validates the configuration files mounted at MountPath
+	Command []string
+}
+
+// Tools are the validation commands per component. Prometheus configurations
+// are checked with promtool, which also checks the referenced rule files.
+var Tools = map[string]Tool{
+	"prometheus": {
+		MountPath: "/etc/prometheus",
+		Command:   []string{"promtool", "check", "config", "/etc/prometheus/prometheus.yml"},
+	},
+	"loki": {
+		MountPath: "/etc/loki",
+		Command:   []string{"/usr/bin/loki", "-config.file=/etc/loki/loki.yaml", "-verify-config"},
+	},
+	"tempo": {
+		MountPath: "/etc/tempo",
+		Command:   []string{"/tempo", "-config.file=/etc/tempo/tempo.yaml", "-config.verify"},
+	},
+}
+
+// Check is the validation of a component configuration
+type Check struct {
+	Component string
+	Platform  string
+	Namespace string
+	// Image is the component image at the deployed version
+	Image string
+	// Files are the configuration files, keyed by file name
+	Files map[string]string
+	// TimeoutSeconds bounds the runtime of the Job
+	TimeoutSeconds int64
+	// Labels are added to the Job, its pod and the ConfigMap
+	Labels map[string]string
+}
+
+// Hash identifies the configuration files of a check
+func Hash(files map[string]string) string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s\x00%s\x00", name, files[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Name returns the name of the ConfigMap and Job validating a configuration.
+// The configuration hash is part of the name, so each configuration is
+// validated once.
+func Name(component, platform, hash string) string {
+	suffix := hash[:8]
+	name := fmt.Sprintf("%s-%s-config-check", component, platform)
+	// Job names become pod labels, which are limited to 63 characters
+	if max := 63 - len(suffix) - 1; len(name) > max {
+		name = strings.TrimRight(name[:max], "-.")
+	}
+	return name + "-" + suffix
+}
+
+// BuildConfigMap returns the ConfigMap holding the configuration under validation
+func BuildConfigMap(c Check, name string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: c.Namespace,
+			Labels:    c.Labels,
+		},
+		Data: c.Files,
+	}
+}
+
+// BuildJob returns the Job validating a configuration, or nil if the
+// component has no validation tool. The output of a failed validation is kept
+// as the container's termination message.
+func BuildJob(c Check, name string) *batchv1.Job {
+	tool, ok := Tools[c.Component]
+	if !ok {
+		return nil
+	}
+
+	backoffLimit := int32(0)
+	activeDeadlineSeconds := c.TimeoutSeconds
+	ttl := defaultTTLSecondsAfterFinish
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: c.Namespace,
+			Labels:    c.Labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			ActiveDeadlineSeconds:   &activeDeadlineSeconds,
+			TTLSecondsAfterFinished: &ttl,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: c.Labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy:                corev1.RestartPolicyNever,
+					AutomountServiceAccountToken: &[]bool{false}[0],
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: &[]bool{true}[0],
+						RunAsUser:    &[]int64{65534}[0],
+					},
+					Containers: []corev1.Container{{
+						Name:                     "config-check",
+						Image:                    c.Image,
+						Command:                  tool.Command,
+						TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      "config",
+							MountPath: tool.MountPath,
+							ReadOnly:  true,
+						}},
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: &[]bool{false}[0],
+							ReadOnlyRootFilesystem:   &[]bool{true}[0],
+						},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "config",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: name},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// JobState returns the validation state of a finished or running Job
+func JobState(job *batchv1.Job) State {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return StateValid
+		case batchv1.JobFailed:
+			return StateInvalid
+		}
+	}
+	return StatePending
+}
+
+// FailureMessage returns the validation output of the failed pods of a Job,
+// falling back to the Job's failure reason
+func FailureMessage(job *batchv1.Job, pods []corev1.Pod) string {
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode != 0 {
+				if message := strings.TrimSpace(terminated.Message); message != "" {
+					return truncate(message)
+				}
+			}
+		}
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Message != "" {
+			return truncate(condition.Message)
+		}
+	}
+	return "configuration validation failed"
+}
+
+// truncate keeps the end of a message, where validation tools report errors
+func truncate(message string) string {
+	if len(message) <= maxMessageLength {
+		return message
+	}
+	return "..." + message[len(message)-maxMessageLength+3:]
+}
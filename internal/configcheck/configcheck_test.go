@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package configcheck
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestHash(t *testing.T) {
+	files := map[string]string{"prometheus.yml": "global: {}", "alerting-rules.yml": "groups: []"}
+
+	hash := Hash(files)
+	assert.Len(t, hash, 16)
+	assert.Equal(t, hash, Hash(map[string]string{"alerting-rules.yml": "groups: []", "prometheus.yml": "global: {}"}))
+	assert.NotEqual(t, hash, Hash(map[string]string{"prometheus.yml": "global: {}"}))
+	// File boundaries are part of the hash
+	assert.NotEqual(t, Hash(map[string]string{"a": "bc"}), Hash(map[string]string{"ab": "c"}))
+}
+
+func TestName(t *testing.T) {
+	hash := Hash(map[string]string{"loki.yaml": "auth_enabled: false"})
+
+	assert.Equal(t, "loki-prod-config-check-"+hash[:8], Name("loki", "prod", hash))
+	assert.LessOrEqual(t, len(Name("prometheus", strings.Repeat("p", 80), hash)), 63)
+}
+
+func TestBuildJob(t *testing.T) {
+	check := Check{
+		Component:      "prometheus",
+		Platform:       "prod",
+		Namespace:      "monitoring",
+		Image:          "prom/prometheus:v2.48.0",
+		Files:          map[string]string{"prometheus.yml": "global: {}"},
+		TimeoutSeconds: 300,
+	}
+
+	job := BuildJob(check, "prometheus-prod-config-check-abcdef12")
+	require.NotNil(t, job)
+	assert.Equal(t, int32(0), *job.Spec.BackoffLimit)
+	assert.Equal(t, int64(300), *job.Spec.ActiveDeadlineSeconds)
+
+	pod := job.Spec.Template.Spec
+	require.Len(t, pod.Containers, 1)
+	container := pod.Containers[0]
+	assert.Equal(t, "prom/prometheus:v2.48.0", container.Image)
+	assert.Equal(t, []string{"promtool", "check", "config", "/etc/prometheus/prometheus.yml"}, container.Command)
+	assert.Equal(t, corev1.TerminationMessageFallbackToLogsOnError, container.TerminationMessagePolicy)
+	assert.Equal(t, "/etc/prometheus", container.VolumeMounts[0].MountPath)
+	assert.Equal(t, "prometheus-prod-config-check-abcdef12", pod.Volumes[0].ConfigMap.Name)
+
+	check.Component = "grafana"
+	assert.Nil(t, BuildJob(check, "grafana-prod-config-check-abcdef12"))
+}
+
+func TestJobStateAndFailureMessage(t *testing.T) {
+	job := &batchv1.Job{}
+	assert.Equal(t, StatePending, JobState(job))
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	assert.Equal(t, StateValid, JobState(job))
+
+	job.Status.Conditions = []batchv1.JobCondition{{
+		Type:    batchv1.JobFailed,
+		Status:  corev1.ConditionTrue,
+		Message: "Job has reached the specified backoff limit",
+	}}
+	assert.Equal(t, StateInvalid, JobState(job))
+	assert.Equal(t, "Job has reached the specified backoff limit", FailureMessage(job, nil))
+
+	pods := []corev1.Pod{{
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 1,
+					Message:  "Checking /etc/prometheus/prometheus.yml\n  FAILED: parsing YAML file: unknown field \"scrape_interva\"\n",
+				}},
+			}},
+		},
+	}}
+	assert.Equal(t, "Checking /etc/prometheus/prometheus.yml\n  FAILED: parsing YAML file: unknown field \"scrape_interva\"",
+		FailureMessage(job, pods))
+
+	long := strings.Repeat("x", 2000) + "error at the end"
+	pods[0].Status.ContainerStatuses[0].State.Terminated.Message = long
+	message := FailureMessage(job, pods)
+	assert.Len(t, message, maxMessageLength)
+	assert.True(t, strings.HasSuffix(message, "error at the end"))
+}
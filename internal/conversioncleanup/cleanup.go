@@ -0,0 +1,241 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package conversioncleanup removes the annotations and labels the
+// conversion webhook and the migration tools leave on objects, once every
+// object of a custom resource is stored in the storage version. The objects
+// are patched in batches, at a limited rate, so that the cleanup of many
+// objects does not load the API server.
+package conversioncleanup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/time/rate"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConversionDataAnnotation holds the fields of other versions the storage
+// version cannot represent. It is only removed once the CRD serves a single
+// version, as reads in another version restore the fields from it.
+const ConversionDataAnnotation = "observability.io/conversion-data"
+
+// DefaultAnnotations are the legacy conversion annotations removed by default
+var DefaultAnnotations = []string{
+	"observability.io/converted-from",
+	"observability.io/conversion-timestamp",
+	"observability.io/conversion-history",
+	"observability.io/conversion-lost-fields",
+	"observability.io/conversion-validated",
+	"observability.io/last-conversion-version",
+	"observability.io/preserved-fields",
+	"observability.io/data-integrity-hash",
+	"observability.io/stored-version",
+}
+
+// DefaultLabels are the legacy conversion labels removed by default
+var DefaultLabels = []string{
+	"observability.io/source-version",
+	"observability.io/target-version",
+	"observability.io/conversion-timestamp",
+	"observability.io/data-preserved",
+}
+
+// Defaults of the Config
+const (
+	DefaultBatchSize = 100
+	DefaultQPS       = 5
+)
+
+// Config configures the cleanup
+type Config struct {
+	// Annotations and Labels are the keys removed, the defaults if nil
+	Annotations []string
+	Labels      []string
+
+	// BatchSize is the number of objects listed at once
+	BatchSize int64
+
+	// QPS and Burst limit the rate of the patches
+	QPS   float64
+	Burst int
+}
+
+func (c Config) withDefaults() Config {
+	if c.Annotations == nil {
+		c.Annotations = DefaultAnnotations
+	}
+	if c.Labels == nil {
+		c.Labels = DefaultLabels
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = DefaultBatchSize
+	}
+	if c.QPS <= 0 {
+		c.QPS = DefaultQPS
+	}
+	if c.Burst <= 0 {
+		c.Burst = 1
+	}
+	return c
+}
+
+// Result is the outcome of the cleanup of a CRD
+type Result struct {
+	CRD string
+	// Skipped explains why the CRD was not cleaned up, such as objects
+	// still stored in another version
+	Skipped string
+	Scanned int
+	Cleaned int
+	Failed  int
+}
+
+// Cleaner removes the legacy conversion metadata of the objects of CRDs
+type Cleaner struct {
+	// Reader lists the objects, uncached so that listing a kind does not
+	// start an informer for it
+	Reader client.Reader
+	// Writer patches the objects
+	Writer client.Writer
+	Config Config
+	Log    logr.Logger
+
+	limiter *rate.Limiter
+}
+
+// Run cleans up the objects of a CRD, if the migration to its storage
+// version is complete
+func (c *Cleaner) Run(ctx context.Context, crdName string) (*Result, error) {
+	config := c.Config.withDefaults()
+	if c.limiter == nil {
+		c.limiter = rate.NewLimiter(rate.Limit(config.QPS), config.Burst)
+	}
+	result := &Result{CRD: crdName}
+
+	crd, err := getCRD(ctx, c.Reader, crdName)
+	if err != nil {
+		return nil, err
+	}
+	storage, complete := MigrationComplete(crd)
+	if !complete {
+		result.Skipped = fmt.Sprintf("objects are still stored in %v, the storage version is %s", crd.Status.StoredVersions, storage)
+		return result, nil
+	}
+
+	annotations := config.Annotations
+	if servesOnly(crd, storage) {
+		annotations = append(append([]string(nil), annotations...), ConversionDataAnnotation)
+	}
+
+	gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: storage, Kind: crd.Spec.Names.ListKind}
+	continueToken := ""
+	for {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk)
+		if err := c.Reader.List(ctx, list, client.Limit(config.BatchSize), client.Continue(continueToken)); err != nil {
+			return result, fmt.Errorf("failed to list %s: %w", crdName, err)
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			result.Scanned++
+			patch := Patch(obj, annotations, config.Labels)
+			if patch == nil {
+				continue
+			}
+			if err := c.limiter.Wait(ctx); err != nil {
+				return result, err
+			}
+			if err := c.Writer.Patch(ctx, obj, client.RawPatch(types.MergePatchType, patch)); err != nil {
+				// Don't stop the batch, the object is cleaned up on the next run
+				c.Log.Error(err, "Failed to remove the conversion metadata", "crd", crdName,
+					"namespace", obj.GetNamespace(), "name", obj.GetName())
+				result.Failed++
+				continue
+			}
+			result.Cleaned++
+		}
+
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			return result, nil
+		}
+	}
+}
+
+// Patch returns the merge patch removing the annotations and labels of
+// keys from obj, nil if obj has none of them
+func Patch(obj client.Object, annotations, labels []string) []byte {
+	remove := func(current map[string]string, keys []string) map[string]interface{} {
+		removed := map[string]interface{}{}
+		for _, key := range keys {
+			if _, ok := current[key]; ok {
+				removed[key] = nil
+			}
+		}
+		return removed
+	}
+
+	metadata := map[string]interface{}{}
+	if removed := remove(obj.GetAnnotations(), annotations); len(removed) > 0 {
+		metadata["annotations"] = removed
+	}
+	if removed := remove(obj.GetLabels(), labels); len(removed) > 0 {
+		metadata["labels"] = removed
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+	patch, _ := json.Marshal(map[string]interface{}{"metadata": metadata})
+	return patch
+}
+
+// MigrationComplete returns the storage version of the CRD and whether
+// every object is stored in it
+func MigrationComplete(crd *apiextensionsv1.CustomResourceDefinition) (string, bool) {
+	storage := ""
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			storage = v.Name
+		}
+	}
+	stored := crd.Status.StoredVersions
+	return storage, storage != "" && len(stored) == 1 && stored[0] == storage
+}
+
+// servesOnly reports whether version is the only version the CRD serves
+func servesOnly(crd *apiextensionsv1.CustomResourceDefinition, version string) bool {
+	for _, v := range crd.Spec.Versions {
+		if v.Served && v.Name != version {
+			return false
+		}
+	}
+	return true
+}
+
+// getCRD reads a CRD as unstructured, so the client needs no scheme for the
+// apiextensions types
+func getCRD(ctx context.Context, reader client.Reader, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"))
+	if err := reader.Get(ctx, client.ObjectKey{Name: name}, u); err != nil {
+		return nil, fmt.Errorf("failed to get CRD %s: %w", name, err)
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, crd); err != nil {
+		return nil, fmt.Errorf("failed to decode CRD %s: %w", name, err)
+	}
+	return crd, nil
+}
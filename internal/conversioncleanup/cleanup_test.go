@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package conversioncleanup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var platformGVK = schema.GroupVersionKind{Group: "observability.io", Version: "v1beta1", Kind: "ObservabilityPlatform"}
+
+func newCRD(storedVersions []string, served ...string) *unstructured.Unstructured {
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "observabilityplatforms.observability.io"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "observability.io",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "ObservabilityPlatform", ListKind: "ObservabilityPlatformList"},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: contains(served, "v1alpha1")},
+				{Name: "v1beta1", Served: true, Storage: true},
+			},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: storedVersions},
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(crd)
+	if err != nil {
+		panic(err)
+	}
+	u := &unstructured.Unstructured{Object: obj}
+	u.SetGroupVersionKind(apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"))
+	return u
+}
+
+func newPlatform(name string, annotations, labels map[string]string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(platformGVK)
+	u.SetNamespace("monitoring")
+	u.SetName(name)
+	u.SetAnnotations(annotations)
+	u.SetLabels(labels)
+	return u
+}
+
+func newClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	for _, gvk := range []schema.GroupVersionKind{platformGVK, apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition")} {
+		scheme.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+		scheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &unstructured.UnstructuredList{})
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func get(t *testing.T, c client.Client, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(platformGVK)
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "monitoring", Name: name}, u))
+	return u
+}
+
+func TestRun(t *testing.T) {
+	legacy := map[string]string{
+		"observability.io/converted-from":       "v1alpha1",
+		"observability.io/conversion-timestamp": "2025-01-01T00:00:00Z",
+		ConversionDataAnnotation:                "{}",
+		"team":                                  "sre",
+	}
+	c := newClient(
+		newCRD([]string{"v1beta1"}, "v1alpha1"),
+		newPlatform("converted", legacy, map[string]string{"observability.io/source-version": "v1alpha1", "app": "prometheus"}),
+		newPlatform("clean", map[string]string{"team": "sre"}, nil),
+	)
+
+	cleaner := &Cleaner{Reader: c, Writer: c, Config: Config{QPS: 1000}, Log: logr.Discard()}
+	result, err := cleaner.Run(context.Background(), "observabilityplatforms.observability.io")
+	require.NoError(t, err)
+	assert.Equal(t, &Result{CRD: "observabilityplatforms.observability.io", Scanned: 2, Cleaned: 1}, result)
+
+	converted := get(t, c, "converted")
+	// The preserved data is kept while v1alpha1 is served
+	assert.Equal(t, map[string]string{ConversionDataAnnotation: "{}", "team": "sre"}, converted.GetAnnotations())
+	assert.Equal(t, map[string]string{"app": "prometheus"}, converted.GetLabels())
+}
+
+func TestRunRemovesConversionDataOnceSingleVersion(t *testing.T) {
+	c := newClient(
+		newCRD([]string{"v1beta1"}),
+		newPlatform("converted", map[string]string{ConversionDataAnnotation: "{}"}, nil),
+	)
+	cleaner := &Cleaner{Reader: c, Writer: c, Config: Config{QPS: 1000}, Log: logr.Discard()}
+	result, err := cleaner.Run(context.Background(), "observabilityplatforms.observability.io")
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Cleaned)
+	assert.Empty(t, get(t, c, "converted").GetAnnotations())
+}
+
+func TestRunSkipsIncompleteMigration(t *testing.T) {
+	c := newClient(
+		newCRD([]string{"v1alpha1", "v1beta1"}, "v1alpha1"),
+		newPlatform("converted", map[string]string{"observability.io/converted-from": "v1alpha1"}, nil),
+	)
+	cleaner := &Cleaner{Reader: c, Writer: c, Log: logr.Discard()}
+	result, err := cleaner.Run(context.Background(), "observabilityplatforms.observability.io")
+	require.NoError(t, err)
+	assert.Equal(t, "objects are still stored in [v1alpha1 v1beta1], the storage version is v1beta1", result.Skipped)
+	assert.Contains(t, get(t, c, "converted").GetAnnotations(), "observability.io/converted-from")
+}
+
+func TestPatch(t *testing.T) {
+	obj := newPlatform("p", map[string]string{"a": "1", "b": "2"}, map[string]string{"c": "3"})
+	assert.JSONEq(t, `{"metadata":{"annotations":{"a":null},"labels":{"c":null}}}`, string(Patch(obj, []string{"a", "x"}, []string{"c"})))
+	assert.Nil(t, Patch(obj, []string{"x"}, []string{"y"}))
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
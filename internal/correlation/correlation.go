@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package correlation keeps the labels Grafana correlates signals on
+// consistent across the components of a platform. The correlation labels are
+// added to every signal; a component setting one of them to another value
+// would leave its signals unmatched, so such conflicts are reported instead
+// of resolved.
+package correlation
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PlatformLabel is set by the operator on the signals collected by Alloy
+const PlatformLabel = "platform"
+
+// maxTenantIDLength is the longest tenant ID Loki and Tempo accept
+const maxTenantIDLength = 150
+
+// Conflict is a correlation label a component sets to another value
+type Conflict struct {
+	Label string
+	Value string
+	Want  string
+}
+
+// Error describes the conflict
+func (c Conflict) Error() string {
+	return fmt.Sprintf("label %s is %q but the correlation label is %q", c.Label, c.Value, c.Want)
+}
+
+// Merge returns the union of label sets; later sets override earlier ones
+func Merge(sets ...map[string]string) map[string]string {
+	var merged map[string]string
+	for _, set := range sets {
+		for k, v := range set {
+			if merged == nil {
+				merged = make(map[string]string)
+			}
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// Conflicts returns the correlation labels set to another value in labels,
+// sorted by label
+func Conflicts(correlation, labels map[string]string) []Conflict {
+	var conflicts []Conflict
+	for label, want := range correlation {
+		if value, ok := labels[label]; ok && value != want {
+			conflicts = append(conflicts, Conflict{Label: label, Value: value, Want: want})
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Label < conflicts[j].Label })
+	return conflicts
+}
+
+// ValidateTenantID validates a tenant ID the way Loki and Tempo do
+func ValidateTenantID(id string) error {
+	if id == "." || id == ".." {
+		return fmt.Errorf("tenant ID cannot be %q", id)
+	}
+	if len(id) > maxTenantIDLength {
+		return fmt.Errorf("tenant ID must be at most %d characters", maxTenantIDLength)
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '!', r == '-', r == '_', r == '.', r == '*', r == '\'', r == '(', r == ')':
+		default:
+			return fmt.Errorf("tenant ID contains the unsupported character %q", r)
+		}
+	}
+	return nil
+}
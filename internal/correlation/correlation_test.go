@@ -0,0 +1,44 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package correlation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	assert.Nil(t, Merge(nil, map[string]string{}))
+	assert.Equal(t, map[string]string{"cluster": "eu-1", "region": "eu", "env": "prod"}, Merge(
+		map[string]string{"cluster": "old", "region": "eu"},
+		nil,
+		map[string]string{"cluster": "eu-1", "env": "prod"},
+	))
+}
+
+func TestConflicts(t *testing.T) {
+	correlation := map[string]string{"cluster": "eu-1", "env": "prod", "region": "eu"}
+
+	assert.Empty(t, Conflicts(correlation, map[string]string{"cluster": "eu-1", "team": "db"}))
+	assert.Empty(t, Conflicts(nil, map[string]string{"cluster": "eu-1"}))
+
+	conflicts := Conflicts(correlation, map[string]string{"region": "us", "cluster": "us-1", "env": "prod"})
+	assert.Equal(t, []Conflict{
+		{Label: "cluster", Value: "us-1", Want: "eu-1"},
+		{Label: "region", Value: "us", Want: "eu"},
+	}, conflicts)
+	assert.EqualError(t, conflicts[0], `label cluster is "us-1" but the correlation label is "eu-1"`)
+}
+
+func TestValidateTenantID(t *testing.T) {
+	assert.NoError(t, ValidateTenantID("team-a_prod.(eu)"))
+	assert.Error(t, ValidateTenantID(".."))
+	assert.Error(t, ValidateTenantID("a|b"))
+	assert.Error(t, ValidateTenantID("a/b"))
+	assert.Error(t, ValidateTenantID(string(make([]byte, 151))))
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package dashboardsync imports dashboards from a Git repository into
+// Grafana: JSON dashboards, and dashboards rendered from Jsonnet sources such
+// as Grafonnet dashboards and monitoring mixins. Dashboards are imported through the Grafana API rather than
+// provisioned from files, so they stay editable in Grafana. The Grafana
+// version of each imported dashboard is recorded: a different version means
+// the dashboard was edited in Grafana since, which is reported as drift.
+package dashboardsync
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gunjanjp/gunj-operator/internal/jsonnet"
+)
+
+const (
+	// maxUIDLength is the longest dashboard UID Grafana accepts
+	maxUIDLength = 40
+
+	// vendorDir is where jsonnet-bundler installs libraries
+	vendorDir = "vendor"
+)
+
+// Dashboard is a dashboard read from Git
+type Dashboard struct {
+	UID   string
+	Title string
+	// File is the path of the dashboard in the repository
+	File string
+	// Model is the dashboard JSON
+	Model map[string]interface{}
+	// Checksum identifies the content of the dashboard
+	Checksum string
+}
+
+// Record is the state of an imported dashboard
+type Record struct {
+	UID      string
+	Title    string
+	File     string
+	Checksum string
+	// Version is the Grafana version of the imported dashboard
+	Version int64
+	Drifted bool
+}
+
+// Load returns the dashboards of a repository below dir: its JSON dashboards
+// and the dashboards rendered from its Jsonnet sources. Imports are resolved
+// against the files read by Fetch and the vendor directory, where
+// jsonnet-bundler installs libraries; vendored files are not dashboards.
+func Load(files map[string][]byte, dir string, extVars map[string]string) ([]Dashboard, error) {
+	dir = strings.Trim(dir, "/")
+	dashboards := make(map[string][]byte)
+	for name, data := range files {
+		if strings.HasSuffix(name, ".json") && below(name, dir) {
+			dashboards[name] = data
+		}
+	}
+
+	renderer := &jsonnet.Renderer{Files: files, JPath: []string{vendorDir}, ExtVars: extVars}
+	rendered, err := renderer.RenderAll(dir)
+	if err != nil {
+		return nil, err
+	}
+	for name, data := range rendered {
+		if _, ok := dashboards[name]; ok {
+			return nil, fmt.Errorf("%s is both a file and rendered from Jsonnet", name)
+		}
+		dashboards[name] = data
+	}
+	return Parse(dashboards)
+}
+
+// below returns true if a repository file is below dir and not vendored
+func below(name, dir string) bool {
+	if name == vendorDir || strings.HasPrefix(name, vendorDir+"/") {
+		return false
+	}
+	return dir == "" || strings.HasPrefix(name, dir+"/")
+}
+
+// Parse reads the dashboards of files keyed by repository path. Files other
+// than *.json and JSON files which are not dashboards, such as
+// jsonnetfile.json, are ignored. Dashboards without a uid get one derived
+// from their path, so they keep their identity across syncs.
+func Parse(files map[string][]byte) ([]Dashboard, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		if strings.HasSuffix(name, ".json") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	dashboards := make([]Dashboard, 0, len(names))
+	seen := make(map[string]string, len(names))
+	for _, name := range names {
+		model := map[string]interface{}{}
+		if err := json.Unmarshal(files[name], &model); err != nil {
+			return nil, fmt.Errorf("%s is not a valid dashboard: %w", name, err)
+		}
+		_, panels := model["panels"]
+		_, rows := model["rows"]
+		if !panels && !rows {
+			continue
+		}
+
+		uid, _ := model["uid"].(string)
+		if uid == "" {
+			uid = UIDFor(name)
+		}
+		if len(uid) > maxUIDLength {
+			return nil, fmt.Errorf("%s: uid %q is longer than %d characters", name, uid, maxUIDLength)
+		}
+		if other, ok := seen[uid]; ok {
+			return nil, fmt.Errorf("%s and %s have the same uid %q", other, name, uid)
+		}
+		seen[uid] = name
+
+		// Grafana assigns ids and versions; imported ones would conflict
+		delete(model, "id")
+		delete(model, "version")
+		model["uid"] = uid
+		title, _ := model["title"].(string)
+		if title == "" {
+			title = strings.TrimSuffix(path.Base(name), ".json")
+			model["title"] = title
+		}
+
+		sum := sha256.Sum256(files[name])
+		dashboards = append(dashboards, Dashboard{
+			UID:      uid,
+			Title:    title,
+			File:     name,
+			Model:    model,
+			Checksum: hex.EncodeToString(sum[:])[:16],
+		})
+	}
+	return dashboards, nil
+}
+
+// UIDFor derives the uid of a dashboard from its repository path
+func UIDFor(file string) string {
+	sum := sha256.Sum256([]byte(file))
+	return "git-" + hex.EncodeToString(sum[:])[:16]
+}
+
+// Action is what a sync does with a dashboard
+type Action string
+
+const (
+	// ActionNone leaves the dashboard as is
+	ActionNone Action = "None"
+	// ActionImport imports the dashboard from Git
+	ActionImport Action = "Import"
+	// ActionKeep keeps the dashboard edited in Grafana and reports it as drifted
+	ActionKeep Action = "Keep"
+)
+
+// Decide returns the action for a dashboard, given its record from the
+// previous sync (nil if it was never imported) and its version in Grafana
+// (0 if it does not exist there). A dashboard whose Grafana version differs
+// from the imported one was edited in Grafana; the edits are overwritten only
+// if overwrite is set.
+func Decide(dashboard Dashboard, previous *Record, version int64, overwrite bool) Action {
+	if version == 0 {
+		return ActionImport
+	}
+	drifted := previous == nil || previous.Version != version
+	switch {
+	case drifted && !overwrite:
+		return ActionKeep
+	case drifted || previous.Checksum != dashboard.Checksum:
+		return ActionImport
+	default:
+		return ActionNone
+	}
+}
+
+// Removed returns the records of dashboards no longer in Git
+func Removed(dashboards []Dashboard, previous []Record) []Record {
+	current := make(map[string]bool, len(dashboards))
+	for _, dashboard := range dashboards {
+		current[dashboard.UID] = true
+	}
+	var removed []Record
+	for _, record := range previous {
+		if !current[record.UID] {
+			removed = append(removed, record)
+		}
+	}
+	return removed
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package dashboardsync
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	dashboards, err := Parse(map[string][]byte{
+		"dashboards/api.json":   []byte(`{"id": 12, "uid": "api", "title": "API", "version": 7, "panels": []}`),
+		"dashboards/nodes.json": []byte(`{"panels": []}`),
+		"dashboards/README.md":  []byte(`# Dashboards`),
+	})
+	require.NoError(t, err)
+	require.Len(t, dashboards, 2)
+
+	assert.Equal(t, "api", dashboards[0].UID)
+	assert.Equal(t, "API", dashboards[0].Title)
+	assert.NotContains(t, dashboards[0].Model, "id")
+	assert.NotContains(t, dashboards[0].Model, "version")
+	assert.Len(t, dashboards[0].Checksum, 16)
+
+	assert.Equal(t, UIDFor("dashboards/nodes.json"), dashboards[1].UID)
+	assert.LessOrEqual(t, len(dashboards[1].UID), maxUIDLength)
+	assert.Equal(t, "nodes", dashboards[1].Title)
+
+	_, err = Parse(map[string][]byte{"a.json": []byte(`{"uid": "x", "panels": []}`), "b.json": []byte(`{"uid": "x", "panels": []}`)})
+	assert.ErrorContains(t, err, "same uid")
+
+	// JSON files which are not dashboards are ignored
+	dashboards, err = Parse(map[string][]byte{"jsonnetfile.json": []byte(`{"version": 1, "dependencies": []}`)})
+	require.NoError(t, err)
+	assert.Empty(t, dashboards)
+
+	_, err = Parse(map[string][]byte{"a.json": []byte(`{`)})
+	assert.ErrorContains(t, err, "not a valid dashboard")
+}
+
+func TestLoad(t *testing.T) {
+	files := map[string][]byte{
+		"dashboards/api.json":                 []byte(`{"uid": "api", "panels": []}`),
+		"dashboards/nodes.jsonnet":            []byte(`(import 'node-mixin/mixin.libsonnet').grafanaDashboards`),
+		"dashboards/team.jsonnet":             []byte(`local lib = import '../lib/team.libsonnet'; lib.dashboard(std.extVar('team'))`),
+		"lib/team.libsonnet":                  []byte(`{ dashboard(team):: { uid: 'team-' + team, title: team, panels: [] } }`),
+		"vendor/node-mixin/mixin.libsonnet":   []byte(`{ grafanaDashboards:: { 'nodes.json': { title: 'Nodes', panels: [] } } }`),
+		"vendor/node-mixin/dashboard.jsonnet": []byte(`{ panels: [] }`),
+		"other/skip.json":                     []byte(`{"panels": []}`),
+	}
+
+	dashboards, err := Load(files, "/dashboards", map[string]string{"team": "payments"})
+	require.NoError(t, err)
+	require.Len(t, dashboards, 3)
+	assert.Equal(t, "dashboards/api.json", dashboards[0].File)
+	assert.Equal(t, "dashboards/nodes.json", dashboards[1].File)
+	assert.Equal(t, UIDFor("dashboards/nodes.json"), dashboards[1].UID)
+	assert.Equal(t, "team-payments", dashboards[2].UID)
+
+	files["dashboards/api.jsonnet"] = []byte(`{ panels: [] }`)
+	_, err = Load(files, "dashboards", map[string]string{"team": "payments"})
+	assert.ErrorContains(t, err, "both a file and rendered")
+}
+
+func TestDecide(t *testing.T) {
+	dashboard := Dashboard{UID: "api", Checksum: "new"}
+	synced := &Record{UID: "api", Checksum: "old", Version: 3}
+
+	assert.Equal(t, ActionImport, Decide(dashboard, nil, 0, false))
+	assert.Equal(t, ActionImport, Decide(dashboard, synced, 3, false))
+	assert.Equal(t, ActionNone, Decide(Dashboard{UID: "api", Checksum: "old"}, synced, 3, false))
+
+	// Edited in Grafana
+	assert.Equal(t, ActionKeep, Decide(dashboard, synced, 4, false))
+	assert.Equal(t, ActionImport, Decide(dashboard, synced, 4, true))
+	// Created in Grafana before it was imported
+	assert.Equal(t, ActionKeep, Decide(dashboard, nil, 1, false))
+}
+
+func TestRemoved(t *testing.T) {
+	removed := Removed([]Dashboard{{UID: "a"}}, []Record{{UID: "a"}, {UID: "b"}})
+	assert.Equal(t, []Record{{UID: "b"}}, removed)
+}
+
+func TestGrafana(t *testing.T) {
+	versions := map[string]int64{"api": 3}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, _ := r.BasicAuth(); user != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/dashboards/db":
+			var request struct {
+				Dashboard map[string]interface{} `json:"dashboard"`
+				Overwrite bool                   `json:"overwrite"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.True(t, request.Overwrite)
+			uid := request.Dashboard["uid"].(string)
+			versions[uid]++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"uid": uid, "version": versions[uid]})
+		case r.Method == http.MethodGet:
+			version, ok := versions[filepath.Base(r.URL.Path)]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"dashboard": map[string]interface{}{"version": version}})
+		case r.Method == http.MethodDelete:
+			delete(versions, filepath.Base(r.URL.Path))
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	grafana := &Grafana{BaseURL: server.URL, HTTP: server.Client(), Username: "admin", Password: "secret"}
+
+	version, err := grafana.Version(ctx, "api")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), version)
+
+	version, err = grafana.Version(ctx, "missing")
+	require.NoError(t, err)
+	assert.Zero(t, version)
+
+	version, err = grafana.Import(ctx, Dashboard{UID: "api", Model: map[string]interface{}{"uid": "api"}}, "sync")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), version)
+
+	require.NoError(t, grafana.Delete(ctx, "api"))
+	require.NoError(t, grafana.Delete(ctx, "api"))
+
+	grafana.Password = "wrong"
+	_, err = grafana.Version(ctx, "api")
+	assert.ErrorContains(t, err, "status 401")
+}
+
+func TestFetch(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	commit := func(files map[string]string) string {
+		for name, content := range files {
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+			_, err := worktree.Add(name)
+			require.NoError(t, err)
+		}
+		hash, err := worktree.Commit("update", &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+		return hash.String()
+	}
+	first := commit(map[string]string{
+		"dashboards/api.json":    `{"uid": "api", "panels": []}`,
+		"dashboards/notes.md":    "notes",
+		"other/skip.json":        `{}`,
+		"vendor/lib/g.libsonnet": `{}`,
+	})
+	_, err = repo.CreateTag("v1", mustHash(t, repo, first), nil)
+	require.NoError(t, err)
+	second := commit(map[string]string{"dashboards/team/nodes.json": `{"panels": []}`})
+
+	head, err := repo.Head()
+	require.NoError(t, err)
+	branch := head.Name().Short()
+
+	ctx := context.Background()
+	revision, files, err := Fetch(ctx, Source{URL: dir, Ref: branch, Path: "/dashboards/"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, second, revision)
+	assert.Equal(t, []string{"dashboards/api.json", "dashboards/team/nodes.json", "vendor/lib/g.libsonnet"}, keys(files))
+
+	revision, files, err = Fetch(ctx, Source{URL: dir, Ref: "v1", Path: "dashboards"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, first, revision)
+	assert.Equal(t, []string{"dashboards/api.json", "vendor/lib/g.libsonnet"}, keys(files))
+
+	// Without a path the whole tree is read
+	revision, files, err = Fetch(ctx, Source{URL: dir, Ref: first, Path: "/"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, first, revision)
+	assert.Equal(t, []string{"dashboards/api.json", "other/skip.json", "vendor/lib/g.libsonnet"}, keys(files))
+
+	_, _, err = Fetch(ctx, Source{URL: dir, Ref: branch, Path: "missing"}, nil)
+	assert.ErrorContains(t, err, "path missing not found")
+}
+
+func TestAuthFor(t *testing.T) {
+	auth, err := AuthFor("https://github.com/org/dashboards.git", map[string][]byte{"password": []byte("token")})
+	require.NoError(t, err)
+	assert.Equal(t, "http-basic-auth", auth.Name())
+
+	_, err = AuthFor("ssh://git@github.com/org/dashboards.git", map[string][]byte{"ssh-privatekey": []byte("key")})
+	assert.ErrorContains(t, err, "known_hosts")
+
+	auth, err = AuthFor("https://github.com/org/dashboards.git", nil)
+	require.NoError(t, err)
+	assert.Nil(t, auth)
+}
+
+func mustHash(t *testing.T, repo *git.Repository, revision string) plumbing.Hash {
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	require.NoError(t, err)
+	return *hash
+}
+
+func keys(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package dashboardsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/gunjanjp/gunj-operator/internal/jsonnet"
+)
+
+// maxDashboardSize bounds the size of a dashboard file read from Git
+const maxDashboardSize = 10 << 20
+
+// Source is the location of dashboards in a Git repository
+type Source struct {
+	URL string
+	// Ref is a branch, tag or commit
+	Ref string
+	// Path is the directory holding the dashboards
+	Path string
+}
+
+// AuthFor returns the credentials of a repository from the data of its auth
+// Secret: username and password for HTTPS, ssh-privatekey and known_hosts for
+// SSH. Host keys are always verified, so SSH requires known_hosts.
+func AuthFor(repoURL string, data map[string][]byte) (transport.AuthMethod, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(repoURL, "http://") || strings.HasPrefix(repoURL, "https://") {
+		username := string(data["username"])
+		if username == "" {
+			// Token authentication ignores the user name, but requires one
+			username = "git"
+		}
+		return &http.BasicAuth{Username: username, Password: string(data["password"])}, nil
+	}
+
+	privateKey := data["ssh-privatekey"]
+	if len(privateKey) == 0 {
+		return nil, fmt.Errorf("auth secret has no ssh-privatekey")
+	}
+	if len(data["known_hosts"]) == 0 {
+		return nil, fmt.Errorf("auth secret has no known_hosts to verify the host key of %s", repoURL)
+	}
+	auth, err := gitssh.NewPublicKeys("git", privateKey, "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid ssh-privatekey: %w", err)
+	}
+
+	// knownhosts only reads files, and reads them once
+	file, err := os.CreateTemp("", "known_hosts-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to write known_hosts: %w", err)
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write(data["known_hosts"])
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write known_hosts: %w", err)
+	}
+	auth.HostKeyCallback, err = knownhosts.New(file.Name())
+	if err != nil {
+		return nil, fmt.Errorf("invalid known_hosts: %w", err)
+	}
+	return auth, nil
+}
+
+// Fetch returns the commit a source's ref resolves to and its dashboard files
+// keyed by repository path: the JSON and Jsonnet files below the source's
+// path, and the libraries of the vendor directory Jsonnet sources import. A
+// source without a path reads the whole tree. The repository is cloned into
+// memory; branches and tags are fetched shallowly, commits require the full
+// history.
+func Fetch(ctx context.Context, source Source, auth transport.AuthMethod) (string, map[string][]byte, error) {
+	repo, hash, err := clone(ctx, source, auth)
+	if err != nil {
+		return "", nil, err
+	}
+
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read commit %s: %w", hash, err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read tree of %s: %w", hash, err)
+	}
+	dir := strings.Trim(source.Path, "/")
+	files := make(map[string][]byte)
+	if err := readFiles(tree, dir, files); err != nil {
+		if errors.Is(err, object.ErrDirectoryNotFound) {
+			return "", nil, fmt.Errorf("path %s not found at %s: %w", source.Path, hash, err)
+		}
+		return "", nil, err
+	}
+	if dir != "" && dir != vendorDir && !strings.HasPrefix(dir, vendorDir+"/") {
+		// The vendor directory is optional
+		if err := readFiles(tree, vendorDir, files); err != nil && !errors.Is(err, object.ErrDirectoryNotFound) {
+			return "", nil, err
+		}
+	}
+	return hash.String(), files, nil
+}
+
+// readFiles adds the JSON and Jsonnet files below dir of a tree to files,
+// keyed by repository path
+func readFiles(tree *object.Tree, dir string, files map[string][]byte) error {
+	if dir != "" {
+		subtree, err := tree.Tree(dir)
+		if err != nil {
+			return err
+		}
+		tree = subtree
+	}
+	return tree.Files().ForEach(func(f *object.File) error {
+		name := path.Join(dir, f.Name)
+		if !jsonnet.IsFile(name) {
+			return nil
+		}
+		if f.Size > maxDashboardSize {
+			return fmt.Errorf("%s is larger than %d bytes", name, maxDashboardSize)
+		}
+		contents, err := f.Contents()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		files[name] = []byte(contents)
+		return nil
+	})
+}
+
+// clone clones a repository into memory and resolves the source's ref
+func clone(ctx context.Context, source Source, auth transport.AuthMethod) (*git.Repository, plumbing.Hash, error) {
+	for _, name := range []plumbing.ReferenceName{
+		plumbing.NewBranchReferenceName(source.Ref),
+		plumbing.NewTagReferenceName(source.Ref),
+	} {
+		repo, err := git.CloneContext(ctx, memory.NewStorage(), nil, &git.CloneOptions{
+			URL:           source.URL,
+			Auth:          auth,
+			ReferenceName: name,
+			SingleBranch:  true,
+			Depth:         1,
+			Tags:          git.NoTags,
+		})
+		if err == nil {
+			head, err := repo.Head()
+			if err != nil {
+				return nil, plumbing.ZeroHash, fmt.Errorf("failed to resolve %s: %w", source.Ref, err)
+			}
+			return repo, head.Hash(), nil
+		}
+		if !isRefNotFound(err) {
+			return nil, plumbing.ZeroHash, fmt.Errorf("failed to clone %s: %w", source.URL, err)
+		}
+	}
+
+	// Neither a branch nor a tag, so a commit
+	repo, err := git.CloneContext(ctx, memory.NewStorage(), nil, &git.CloneOptions{
+		URL:  source.URL,
+		Auth: auth,
+		Tags: git.NoTags,
+	})
+	if err != nil {
+		return nil, plumbing.ZeroHash, fmt.Errorf("failed to clone %s: %w", source.URL, err)
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(source.Ref))
+	if err != nil {
+		return nil, plumbing.ZeroHash, fmt.Errorf("ref %s not found in %s: %w", source.Ref, source.URL, err)
+	}
+	return repo, *hash, nil
+}
+
+// isRefNotFound returns true if a clone failed because the ref does not exist
+func isRefNotFound(err error) bool {
+	var noMatch git.NoMatchingRefSpecError
+	return errors.Is(err, plumbing.ErrReferenceNotFound) || errors.As(err, &noMatch)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package dashboardsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Grafana is a client of the Grafana dashboard API
+type Grafana struct {
+	BaseURL  string
+	HTTP     *http.Client
+	Username string
+	Password string
+}
+
+// Version returns the version of a dashboard in Grafana, or 0 if it does not exist
+func (g *Grafana) Version(ctx context.Context, uid string) (int64, error) {
+	var result struct {
+		Dashboard struct {
+			Version int64 `json:"version"`
+		} `json:"dashboard"`
+	}
+	status, err := g.do(ctx, http.MethodGet, "/api/dashboards/uid/"+url.PathEscape(uid), nil, &result)
+	if status == http.StatusNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return result.Dashboard.Version, nil
+}
+
+// Import creates or overwrites a dashboard and returns its new version
+func (g *Grafana) Import(ctx context.Context, dashboard Dashboard, message string) (int64, error) {
+	request := map[string]interface{}{
+		"dashboard": dashboard.Model,
+		"overwrite": true,
+		"message":   message,
+	}
+	var result struct {
+		Version int64 `json:"version"`
+	}
+	if _, err := g.do(ctx, http.MethodPost, "/api/dashboards/db", request, &result); err != nil {
+		return 0, err
+	}
+	return result.Version, nil
+}
+
+// Delete removes a dashboard; missing dashboards are ignored
+func (g *Grafana) Delete(ctx context.Context, uid string) error {
+	status, err := g.do(ctx, http.MethodDelete, "/api/dashboards/uid/"+url.PathEscape(uid), nil, nil)
+	if status == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// do calls the Grafana API and decodes the response into result
+func (g *Grafana) do(ctx context.Context, method, path string, body, result interface{}) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(g.BaseURL, "/")+path, reader)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(g.Username, g.Password)
+
+	resp, err := g.HTTP.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to read response of %s %s: %w", method, path, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message := strings.TrimSpace(string(data))
+		if len(message) > 256 {
+			message = message[:256]
+		}
+		return resp.StatusCode, fmt.Errorf("%s %s failed with status %d: %s", method, path, resp.StatusCode, message)
+	}
+	if result != nil {
+		if err := json.Unmarshal(data, result); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode response of %s %s: %w", method, path, err)
+		}
+	}
+	return resp.StatusCode, nil
+}
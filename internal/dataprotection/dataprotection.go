@@ -0,0 +1,296 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package dataprotection builds the objects applying the deletion policy of a
+// platform: the VolumeSnapshots taken of its PersistentVolumeClaims by the
+// Snapshot policy, and the Job emptying its object storage buckets by the
+// Delete policy. None of them is owned by the platform, so they outlive it.
+// It also builds the VolumeSnapshots taken before upgrades, and the claims
+// restoring them.
+package dataprotection
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// PlatformLabel is set on the objects built for a platform
+	PlatformLabel = "observability.io/platform"
+
+	// CleanupImage runs the object storage cleanup
+	CleanupImage = "amazon/aws-cli:2.15.0"
+
+	defaultBackoffLimit          int32 = 3
+	defaultActiveDeadlineSeconds int64 = 3600
+)
+
+// VolumeSnapshotGVK is the kind of the snapshots taken by the Snapshot policy
+var VolumeSnapshotGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1",
+	Kind:    "VolumeSnapshot",
+}
+
+// SnapshotName returns the name of the VolumeSnapshot of a claim
+func SnapshotName(platform, claim string) string {
+	name := fmt.Sprintf("%s-%s-final", platform, claim)
+	if len(name) > 253 {
+		name = strings.TrimRight(name[:253], "-.")
+	}
+	return name
+}
+
+// BuildVolumeSnapshot returns the VolumeSnapshot of a claim. className may be
+// empty to use the cluster's default VolumeSnapshotClass.
+func BuildVolumeSnapshot(platform, namespace, claim, className string) *unstructured.Unstructured {
+	return buildVolumeSnapshot(SnapshotName(platform, claim), namespace, claim, className, map[string]string{
+		PlatformLabel:                  platform,
+		"observability.io/pvc":         claim,
+		"app.kubernetes.io/managed-by": "gunj-operator",
+	})
+}
+
+// UpgradeSnapshotName returns the name of the VolumeSnapshot of a claim taken
+// at a time before the upgrade to a version. The time tells apart repeated
+// upgrades to the same version.
+func UpgradeSnapshotName(claim, version string, at time.Time) string {
+	version = strings.ToLower(strings.NewReplacer("+", "-", "_", "-").Replace(version))
+	suffix := fmt.Sprintf("-pre-%s-%s", version, at.UTC().Format("20060102150405"))
+	if len(claim)+len(suffix) > 253 {
+		claim = strings.TrimRight(claim[:253-len(suffix)], "-.")
+	}
+	return claim + suffix
+}
+
+// UpgradeSnapshot is the snapshot of a claim of a component taken before its
+// upgrade from one version to another
+type UpgradeSnapshot struct {
+	Name      string
+	Platform  string
+	Namespace string
+	Component string
+	Claim     string
+	From      string
+	To        string
+}
+
+// BuildUpgradeSnapshot returns the VolumeSnapshot taken before an upgrade.
+// className may be empty to use the cluster's default VolumeSnapshotClass.
+func BuildUpgradeSnapshot(s UpgradeSnapshot, className string) *unstructured.Unstructured {
+	snapshot := buildVolumeSnapshot(s.Name, s.Namespace, s.Claim, className, map[string]string{
+		PlatformLabel:                  s.Platform,
+		"observability.io/pvc":         s.Claim,
+		"observability.io/component":   s.Component,
+		"app.kubernetes.io/managed-by": "gunj-operator",
+	})
+	snapshot.SetAnnotations(map[string]string{
+		"observability.io/upgrade-from": s.From,
+		"observability.io/upgrade-to":   s.To,
+	})
+	return snapshot
+}
+
+func buildVolumeSnapshot(name, namespace, claim, className string, labels map[string]string) *unstructured.Unstructured {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+	snapshot.SetName(name)
+	snapshot.SetNamespace(namespace)
+	snapshot.SetLabels(labels)
+
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": claim,
+		},
+	}
+	if className != "" {
+		spec["volumeSnapshotClassName"] = className
+	}
+	snapshot.Object["spec"] = spec
+	return snapshot
+}
+
+// RestoredClaim returns a claim replacing claim, provisioned from a
+// VolumeSnapshot of it. It keeps the name, labels, class and access modes of
+// claim, so the workload using it finds it again.
+func RestoredClaim(claim *corev1.PersistentVolumeClaim, snapshot string) *corev1.PersistentVolumeClaim {
+	apiGroup := VolumeSnapshotGVK.Group
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claim.Name,
+			Namespace: claim.Namespace,
+			Labels:    claim.Labels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      claim.Spec.AccessModes,
+			StorageClassName: claim.Spec.StorageClassName,
+			VolumeMode:       claim.Spec.VolumeMode,
+			Resources:        claim.Spec.Resources,
+			DataSource: &corev1.TypedLocalObjectReference{
+				APIGroup: &apiGroup,
+				Kind:     VolumeSnapshotGVK.Kind,
+				Name:     snapshot,
+			},
+		},
+	}
+}
+
+// SnapshotReady returns whether a VolumeSnapshot is ready to use, and the
+// error reported by the snapshot controller if it failed
+func SnapshotReady(snapshot *unstructured.Unstructured) (bool, string) {
+	ready, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+	message, _, _ := unstructured.NestedString(snapshot.Object, "status", "error", "message")
+	return ready, message
+}
+
+// Bucket is an object storage bucket holding platform data
+type Bucket struct {
+	// Component is the component writing to the bucket
+	Component string
+	Name      string
+	Region    string
+	// Endpoint is set for S3 compatible storage
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// CleanupName returns the name of the Job and Secret emptying the buckets of
+// a platform
+func CleanupName(platform string) string {
+	name := platform + "-data-cleanup"
+	// Job names become pod labels, which are limited to 63 characters
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-.")
+	}
+	return name
+}
+
+// BuildCleanupSecret returns the Secret holding the credentials of the
+// buckets, keyed by component. It is copied from the component Secrets,
+// which are deleted with the platform before the Job completes.
+func BuildCleanupSecret(platform, namespace string, buckets []Bucket) *corev1.Secret {
+	data := make(map[string][]byte)
+	for _, b := range buckets {
+		if b.AccessKeyID == "" && b.SecretAccessKey == "" {
+			continue
+		}
+		data[b.Component+"-access-key-id"] = []byte(b.AccessKeyID)
+		data[b.Component+"-secret-access-key"] = []byte(b.SecretAccessKey)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CleanupName(platform),
+			Namespace: namespace,
+			Labels:    cleanupLabels(platform),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+}
+
+// BuildCleanupJob returns the Job emptying the buckets, with one container
+// per bucket
+func BuildCleanupJob(platform, namespace string, buckets []Bucket) *batchv1.Job {
+	buckets = append([]Bucket(nil), buckets...)
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Component < buckets[j].Component })
+
+	secret := CleanupName(platform)
+	containers := make([]corev1.Container, 0, len(buckets))
+	for _, b := range buckets {
+		args := []string{"s3", "rm", "s3://" + b.Name, "--recursive"}
+		if b.Endpoint != "" {
+			args = append(args, "--endpoint-url", b.Endpoint)
+		}
+
+		var env []corev1.EnvVar
+		if b.Region != "" {
+			env = append(env, corev1.EnvVar{Name: "AWS_DEFAULT_REGION", Value: b.Region})
+		}
+		if b.AccessKeyID != "" || b.SecretAccessKey != "" {
+			env = append(env,
+				secretEnv("AWS_ACCESS_KEY_ID", secret, b.Component+"-access-key-id"),
+				secretEnv("AWS_SECRET_ACCESS_KEY", secret, b.Component+"-secret-access-key"),
+			)
+		}
+
+		containers = append(containers, corev1.Container{
+			Name:    "cleanup-" + b.Component,
+			Image:   CleanupImage,
+			Command: []string{"aws"},
+			Args:    args,
+			Env:     env,
+		})
+	}
+
+	backoffLimit := defaultBackoffLimit
+	activeDeadlineSeconds := defaultActiveDeadlineSeconds
+	labels := cleanupLabels(platform)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CleanupName(platform),
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: &activeDeadlineSeconds,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+					Containers:    containers,
+				},
+			},
+		},
+	}
+}
+
+// JobFinished returns whether a Job completed or failed
+func JobFinished(job *batchv1.Job) (finished bool, failed bool) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return true, false
+		case batchv1.JobFailed:
+			return true, true
+		}
+	}
+	return false, false
+}
+
+func secretEnv(name, secret, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+func cleanupLabels(platform string) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       "data-cleanup",
+		"app.kubernetes.io/instance":   platform,
+		"app.kubernetes.io/managed-by": "gunj-operator",
+		PlatformLabel:                  platform,
+	}
+}
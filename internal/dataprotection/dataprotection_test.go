@@ -0,0 +1,174 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package dataprotection
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestBuildVolumeSnapshot(t *testing.T) {
+	snapshot := BuildVolumeSnapshot("prod", "monitoring", "data-prometheus-0", "csi-snapclass")
+
+	assert.Equal(t, VolumeSnapshotGVK, snapshot.GroupVersionKind())
+	assert.Equal(t, "prod-data-prometheus-0-final", snapshot.GetName())
+	assert.Equal(t, "monitoring", snapshot.GetNamespace())
+	assert.Empty(t, snapshot.GetOwnerReferences())
+	assert.Equal(t, "prod", snapshot.GetLabels()[PlatformLabel])
+
+	claim, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName")
+	assert.Equal(t, "data-prometheus-0", claim)
+	class, _, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName")
+	assert.Equal(t, "csi-snapclass", class)
+
+	snapshot = BuildVolumeSnapshot("prod", "monitoring", "data-prometheus-0", "")
+	_, found, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName")
+	assert.False(t, found)
+}
+
+func TestBuildUpgradeSnapshot(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC)
+	name := UpgradeSnapshotName("data-prometheus-0", "v2.48.0+build_1", at)
+	assert.Equal(t, "data-prometheus-0-pre-v2.48.0-build-1-20250601123000", name)
+	assert.NotEqual(t, name, UpgradeSnapshotName("data-prometheus-0", "v2.48.0+build_1", at.Add(time.Hour)))
+
+	long := UpgradeSnapshotName(strings.Repeat("c", 260), "v1", at)
+	assert.Len(t, long, 253)
+	assert.True(t, strings.HasSuffix(long, "-pre-v1-20250601123000"))
+
+	snapshot := BuildUpgradeSnapshot(UpgradeSnapshot{
+		Name:      name,
+		Platform:  "prod",
+		Namespace: "monitoring",
+		Component: "prometheus",
+		Claim:     "data-prometheus-0",
+		From:      "v2.47.0",
+		To:        "v2.48.0+build_1",
+	}, "csi-snapclass")
+
+	assert.Equal(t, VolumeSnapshotGVK, snapshot.GroupVersionKind())
+	assert.Equal(t, name, snapshot.GetName())
+	assert.Equal(t, "monitoring", snapshot.GetNamespace())
+	assert.Empty(t, snapshot.GetOwnerReferences())
+	assert.Equal(t, "prometheus", snapshot.GetLabels()["observability.io/component"])
+	assert.Equal(t, "v2.47.0", snapshot.GetAnnotations()["observability.io/upgrade-from"])
+
+	claim, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName")
+	assert.Equal(t, "data-prometheus-0", claim)
+	class, _, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName")
+	assert.Equal(t, "csi-snapclass", class)
+}
+
+func TestRestoredClaim(t *testing.T) {
+	class := "fast-ssd"
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "data-loki-prod-0",
+			Namespace: "monitoring",
+			Labels:    map[string]string{"app.kubernetes.io/name": "loki"},
+			UID:       "uid",
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: &class,
+			VolumeName:       "pv-old",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		},
+	}
+
+	restored := RestoredClaim(claim, "data-loki-prod-0-pre-2.9.0")
+	assert.Equal(t, claim.Name, restored.Name)
+	assert.Equal(t, claim.Labels, restored.Labels)
+	assert.Empty(t, restored.UID)
+	assert.Empty(t, restored.Spec.VolumeName)
+	assert.Equal(t, claim.Spec.StorageClassName, restored.Spec.StorageClassName)
+	assert.Equal(t, claim.Spec.Resources, restored.Spec.Resources)
+	require.NotNil(t, restored.Spec.DataSource)
+	assert.Equal(t, "snapshot.storage.k8s.io", *restored.Spec.DataSource.APIGroup)
+	assert.Equal(t, "VolumeSnapshot", restored.Spec.DataSource.Kind)
+	assert.Equal(t, "data-loki-prod-0-pre-2.9.0", restored.Spec.DataSource.Name)
+}
+
+func TestSnapshotReady(t *testing.T) {
+	snapshot := BuildVolumeSnapshot("prod", "monitoring", "data", "")
+	ready, message := SnapshotReady(snapshot)
+	assert.False(t, ready)
+	assert.Empty(t, message)
+
+	require.NoError(t, unstructured.SetNestedField(snapshot.Object, true, "status", "readyToUse"))
+	ready, _ = SnapshotReady(snapshot)
+	assert.True(t, ready)
+
+	require.NoError(t, unstructured.SetNestedField(snapshot.Object, false, "status", "readyToUse"))
+	require.NoError(t, unstructured.SetNestedField(snapshot.Object, "no class", "status", "error", "message"))
+	ready, message = SnapshotReady(snapshot)
+	assert.False(t, ready)
+	assert.Equal(t, "no class", message)
+}
+
+func TestCleanupName(t *testing.T) {
+	assert.Equal(t, "prod-data-cleanup", CleanupName("prod"))
+	assert.LessOrEqual(t, len(CleanupName(strings.Repeat("a", 70))), 63)
+}
+
+func TestBuildCleanupJob(t *testing.T) {
+	buckets := []Bucket{
+		{Component: "tempo", Name: "traces", Endpoint: "http://minio:9000", AccessKeyID: "id", SecretAccessKey: "key"},
+		{Component: "loki", Name: "logs", Region: "eu-west-1"},
+	}
+	job := BuildCleanupJob("prod", "monitoring", buckets)
+
+	assert.Equal(t, "prod-data-cleanup", job.Name)
+	assert.Empty(t, job.OwnerReferences)
+	require.Len(t, job.Spec.Template.Spec.Containers, 2)
+
+	loki := job.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "cleanup-loki", loki.Name)
+	assert.Equal(t, []string{"s3", "rm", "s3://logs", "--recursive"}, loki.Args)
+	assert.Equal(t, []corev1.EnvVar{{Name: "AWS_DEFAULT_REGION", Value: "eu-west-1"}}, loki.Env)
+
+	tempo := job.Spec.Template.Spec.Containers[1]
+	assert.Equal(t, []string{"s3", "rm", "s3://traces", "--recursive", "--endpoint-url", "http://minio:9000"}, tempo.Args)
+	require.Len(t, tempo.Env, 2)
+	assert.Equal(t, "prod-data-cleanup", tempo.Env[0].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "tempo-access-key-id", tempo.Env[0].ValueFrom.SecretKeyRef.Key)
+
+	secret := BuildCleanupSecret("prod", "monitoring", buckets)
+	assert.Equal(t, job.Name, secret.Name)
+	assert.Equal(t, map[string][]byte{
+		"tempo-access-key-id":     []byte("id"),
+		"tempo-secret-access-key": []byte("key"),
+	}, secret.Data)
+}
+
+func TestJobFinished(t *testing.T) {
+	job := &batchv1.Job{}
+	finished, failed := JobFinished(job)
+	assert.False(t, finished)
+	assert.False(t, failed)
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+	finished, failed = JobFinished(job)
+	assert.True(t, finished)
+	assert.True(t, failed)
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	finished, failed = JobFinished(job)
+	assert.True(t, finished)
+	assert.False(t, failed)
+}
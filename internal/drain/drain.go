@@ -0,0 +1,171 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package drain coordinates the graceful shutdown of the operator: once the
+// shutdown starts no new work is accepted, the in-flight work is waited for
+// up to the drain timeout and the shutdown hooks then persist what is left,
+// such as queued status updates and unfinished migration tasks, so that a
+// restart does not lose it.
+package drain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// DefaultHookTimeout bounds the shutdown hooks when the drain timed out
+const DefaultHookTimeout = 10 * time.Second
+
+// Hook persists state on shutdown, after the in-flight work is drained
+type Hook func(ctx context.Context) error
+
+type hook struct {
+	name string
+	fn   Hook
+}
+
+// Coordinator tracks the in-flight work of the operator and drains it on
+// shutdown
+type Coordinator struct {
+	// HookTimeout bounds the shutdown hooks when the drain timed out,
+	// DefaultHookTimeout if zero
+	HookTimeout time.Duration
+	Log         logr.Logger
+
+	mu       sync.Mutex
+	draining bool
+	inFlight map[string]int
+	idle     chan struct{}
+	hooks    []hook
+}
+
+// NewCoordinator returns a Coordinator accepting work
+func NewCoordinator(log logr.Logger) *Coordinator {
+	return &Coordinator{Log: log, inFlight: map[string]int{}}
+}
+
+// Begin registers in-flight work of a kind, such as a reconcile. It returns
+// false once the shutdown started, and the work must not start. Otherwise
+// done must be called when the work finishes.
+func (c *Coordinator) Begin(kind string) (done func(), ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.draining {
+		return nil, false
+	}
+	c.inFlight[kind]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			c.inFlight[kind]--
+			if c.inFlight[kind] == 0 {
+				delete(c.inFlight, kind)
+			}
+			if len(c.inFlight) == 0 && c.idle != nil {
+				close(c.idle)
+				c.idle = nil
+			}
+		})
+	}, true
+}
+
+// Draining reports whether the shutdown started
+func (c *Coordinator) Draining() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.draining
+}
+
+// InFlight returns the number of in-flight works by kind
+func (c *Coordinator) InFlight() map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	inFlight := make(map[string]int, len(c.inFlight))
+	for kind, n := range c.inFlight {
+		inFlight[kind] = n
+	}
+	return inFlight
+}
+
+// OnShutdown registers a hook run after the drain, in registration order
+func (c *Coordinator) OnShutdown(name string, fn Hook) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hooks = append(c.hooks, hook{name: name, fn: fn})
+}
+
+// Shutdown stops accepting work, waits for the in-flight work until the
+// context is done and runs the shutdown hooks. The hooks run even if the
+// drain timed out, bounded by the HookTimeout. The error lists the work
+// still in flight and the failed hooks.
+func (c *Coordinator) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	c.draining = true
+	var idle chan struct{}
+	if len(c.inFlight) > 0 {
+		if c.idle == nil {
+			c.idle = make(chan struct{})
+		}
+		idle = c.idle
+	}
+	hooks := append([]hook(nil), c.hooks...)
+	c.mu.Unlock()
+
+	var errs []error
+	if idle != nil {
+		c.Log.Info("Draining in-flight work", "inFlight", c.InFlight())
+		select {
+		case <-idle:
+			c.Log.Info("Drained in-flight work")
+		case <-ctx.Done():
+			errs = append(errs, fmt.Errorf("drain timed out with work in flight: %s", formatInFlight(c.InFlight())))
+		}
+	}
+
+	hookCtx := ctx
+	if ctx.Err() != nil {
+		timeout := c.HookTimeout
+		if timeout == 0 {
+			timeout = DefaultHookTimeout
+		}
+		var cancel context.CancelFunc
+		hookCtx, cancel = context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+	}
+	for _, h := range hooks {
+		if err := h.fn(hookCtx); err != nil {
+			errs = append(errs, fmt.Errorf("shutdown hook %s failed: %w", h.name, err))
+			continue
+		}
+		c.Log.V(1).Info("Ran shutdown hook", "hook", h.name)
+	}
+	return errors.Join(errs...)
+}
+
+func formatInFlight(inFlight map[string]int) string {
+	kinds := make([]string, 0, len(inFlight))
+	for kind := range inFlight {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	s := ""
+	for i, kind := range kinds {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("%d %s", inFlight[kind], kind)
+	}
+	return s
+}
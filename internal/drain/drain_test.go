@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package drain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShutdownWaitsForInFlightWork(t *testing.T) {
+	c := NewCoordinator(logr.Discard())
+	done, ok := c.Begin("reconcile")
+	require.True(t, ok)
+
+	var ran []string
+	c.OnShutdown("status-updates", func(ctx context.Context) error {
+		ran = append(ran, "status-updates")
+		return nil
+	})
+	c.OnShutdown("migrations", func(ctx context.Context) error {
+		ran = append(ran, "migrations")
+		return nil
+	})
+
+	shutdown := make(chan error)
+	go func() {
+		shutdown <- c.Shutdown(context.Background())
+	}()
+
+	// New work is refused while draining
+	require.Eventually(t, c.Draining, time.Second, time.Millisecond)
+	_, ok = c.Begin("reconcile")
+	assert.False(t, ok)
+
+	select {
+	case <-shutdown:
+		t.Fatal("shutdown did not wait for the in-flight reconcile")
+	case <-time.After(20 * time.Millisecond):
+	}
+	done()
+	done()
+	require.NoError(t, <-shutdown)
+	assert.Equal(t, []string{"status-updates", "migrations"}, ran)
+	assert.Empty(t, c.InFlight())
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	c := NewCoordinator(logr.Discard())
+	_, ok := c.Begin("reconcile")
+	require.True(t, ok)
+	_, ok = c.Begin("migration")
+	require.True(t, ok)
+
+	hookRan := false
+	c.OnShutdown("migrations", func(ctx context.Context) error {
+		// The hooks get a fresh context once the drain timed out
+		hookRan = ctx.Err() == nil
+		return errors.New("checkpoint failed")
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := c.Shutdown(ctx)
+	assert.ErrorContains(t, err, "drain timed out with work in flight: 1 migration, 1 reconcile")
+	assert.ErrorContains(t, err, "shutdown hook migrations failed: checkpoint failed")
+	assert.True(t, hookRan)
+}
+
+func TestShutdownWithoutWork(t *testing.T) {
+	c := NewCoordinator(logr.Discard())
+	require.NoError(t, c.Shutdown(context.Background()))
+	_, ok := c.Begin("reconcile")
+	assert.False(t, ok)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package envvars injects user-defined environment variables, such as proxy
+// settings, feature flags or vendor agent configuration, into the containers
+// of managed components.
+package envvars
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ComponentReserved are the environment variables the operator sets on the
+// container of each component. They carry credentials and paths the
+// generated configuration relies on, so users cannot override them.
+var ComponentReserved = map[string][]string{
+	"grafana": {
+		"GF_SECURITY_ADMIN_USER",
+		"GF_SECURITY_ADMIN_PASSWORD",
+		"GF_PATHS_DATA",
+		"GF_PATHS_LOGS",
+		"GF_PATHS_PLUGINS",
+		"GF_PATHS_PROVISIONING",
+	},
+	"loki": {
+		"AWS_ACCESS_KEY_ID",
+		"AWS_SECRET_ACCESS_KEY",
+		"AWS_REGION",
+	},
+}
+
+// Apply adds copies of the environment variables and sources to a container.
+// Variables are appended after the managed ones so they can reference them;
+// variables already set on the container are skipped and their names
+// returned. Sources are appended too: Kubernetes gives env precedence over
+// envFrom, so they can never override a managed variable.
+func Apply(container *corev1.Container, env []corev1.EnvVar, envFrom []corev1.EnvFromSource) []string {
+	var skipped []string
+
+	set := make(map[string]bool, len(container.Env))
+	for _, e := range container.Env {
+		set[e.Name] = true
+	}
+	for _, e := range env {
+		if set[e.Name] {
+			skipped = append(skipped, e.Name)
+			continue
+		}
+		set[e.Name] = true
+		container.Env = append(container.Env, *e.DeepCopy())
+	}
+
+	for _, source := range envFrom {
+		container.EnvFrom = append(container.EnvFrom, *source.DeepCopy())
+	}
+
+	return skipped
+}
+
+// Validate checks the environment variables and sources of a component:
+// names must be valid, unique and not reserved, a variable takes either a
+// value or exactly one source, and each envFrom entry references exactly one
+// ConfigMap or Secret.
+func Validate(reserved []string, env []corev1.EnvVar, envFrom []corev1.EnvFromSource, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	isReserved := make(map[string]bool, len(reserved))
+	for _, name := range reserved {
+		isReserved[name] = true
+	}
+
+	seen := map[string]bool{}
+	envPath := fldPath.Child("env")
+	for i, e := range env {
+		varPath := envPath.Index(i)
+		namePath := varPath.Child("name")
+		switch {
+		case e.Name == "":
+			allErrs = append(allErrs, field.Required(namePath, "environment variable name is required"))
+		case isReserved[e.Name]:
+			allErrs = append(allErrs, field.Invalid(namePath, e.Name, "environment variable is managed by the operator"))
+		case seen[e.Name]:
+			allErrs = append(allErrs, field.Duplicate(namePath, e.Name))
+		default:
+			seen[e.Name] = true
+			for _, msg := range validation.IsEnvVarName(e.Name) {
+				allErrs = append(allErrs, field.Invalid(namePath, e.Name, msg))
+			}
+		}
+
+		if e.ValueFrom == nil {
+			continue
+		}
+		if e.Value != "" {
+			allErrs = append(allErrs, field.Invalid(varPath.Child("valueFrom"), "", "may not be specified when value is not empty"))
+		}
+		if countSources(e.ValueFrom) != 1 {
+			allErrs = append(allErrs, field.Invalid(varPath.Child("valueFrom"), "", "must specify exactly one source"))
+		}
+	}
+
+	envFromPath := fldPath.Child("envFrom")
+	for i, source := range envFrom {
+		sourcePath := envFromPath.Index(i)
+		switch {
+		case source.ConfigMapRef != nil && source.SecretRef != nil:
+			allErrs = append(allErrs, field.Invalid(sourcePath, "", "may not specify both configMapRef and secretRef"))
+		case source.ConfigMapRef != nil:
+			if source.ConfigMapRef.Name == "" {
+				allErrs = append(allErrs, field.Required(sourcePath.Child("configMapRef", "name"), "ConfigMap name is required"))
+			}
+		case source.SecretRef != nil:
+			if source.SecretRef.Name == "" {
+				allErrs = append(allErrs, field.Required(sourcePath.Child("secretRef", "name"), "Secret name is required"))
+			}
+		default:
+			allErrs = append(allErrs, field.Required(sourcePath, "configMapRef or secretRef is required"))
+		}
+		if source.Prefix != "" {
+			for _, msg := range validation.IsEnvVarName(source.Prefix) {
+				allErrs = append(allErrs, field.Invalid(sourcePath.Child("prefix"), source.Prefix, msg))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// countSources returns the number of sources set on an environment variable
+func countSources(source *corev1.EnvVarSource) int {
+	n := 0
+	if source.FieldRef != nil {
+		n++
+	}
+	if source.ResourceFieldRef != nil {
+		n++
+	}
+	if source.ConfigMapKeyRef != nil {
+		n++
+	}
+	if source.SecretKeyRef != nil {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package envvars
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestApply(t *testing.T) {
+	container := &corev1.Container{
+		Name: "grafana",
+		Env:  []corev1.EnvVar{{Name: "GF_PATHS_DATA", Value: "/var/lib/grafana"}},
+	}
+	env := []corev1.EnvVar{
+		{Name: "HTTPS_PROXY", Value: "http://proxy:3128"},
+		{Name: "GF_PATHS_DATA", Value: "/tmp"},
+	}
+	envFrom := []corev1.EnvFromSource{{
+		SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "vendor-agent"}},
+	}}
+
+	skipped := Apply(container, env, envFrom)
+
+	assert.Equal(t, []string{"GF_PATHS_DATA"}, skipped)
+	require.Len(t, container.Env, 2)
+	assert.Equal(t, "/var/lib/grafana", container.Env[0].Value)
+	assert.Equal(t, "HTTPS_PROXY", container.Env[1].Name)
+	require.Len(t, container.EnvFrom, 1)
+
+	// The container holds copies, not the user's objects
+	container.EnvFrom[0].SecretRef.Name = "changed"
+	assert.Equal(t, "vendor-agent", envFrom[0].SecretRef.Name)
+}
+
+func TestValidate(t *testing.T) {
+	fldPath := field.NewPath("spec", "components", "loki")
+	secretKey := &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "flags"},
+		Key:                  "token",
+	}}
+
+	tests := []struct {
+		name       string
+		env        []corev1.EnvVar
+		envFrom    []corev1.EnvFromSource
+		wantFields []string
+	}{
+		{
+			name: "valid",
+			env: []corev1.EnvVar{
+				{Name: "NO_PROXY", Value: ".svc,.cluster.local"},
+				{Name: "FEATURE_TOKEN", ValueFrom: secretKey},
+			},
+			envFrom: []corev1.EnvFromSource{{
+				Prefix:       "AGENT_",
+				ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "agent"}},
+			}},
+		},
+		{
+			name:       "reserved and duplicate names",
+			env:        []corev1.EnvVar{{Name: "AWS_REGION"}, {Name: "A"}, {Name: "A"}},
+			wantFields: []string{"spec.components.loki.env[0].name", "spec.components.loki.env[2].name"},
+		},
+		{
+			name:       "missing and invalid names",
+			env:        []corev1.EnvVar{{}, {Name: "1=BAD"}},
+			wantFields: []string{"spec.components.loki.env[0].name", "spec.components.loki.env[1].name"},
+		},
+		{
+			name: "value and valueFrom",
+			env:  []corev1.EnvVar{{Name: "A", Value: "x", ValueFrom: secretKey}},
+			wantFields: []string{
+				"spec.components.loki.env[0].valueFrom",
+			},
+		},
+		{
+			name:       "valueFrom without source",
+			env:        []corev1.EnvVar{{Name: "A", ValueFrom: &corev1.EnvVarSource{}}},
+			wantFields: []string{"spec.components.loki.env[0].valueFrom"},
+		},
+		{
+			name: "invalid envFrom",
+			envFrom: []corev1.EnvFromSource{
+				{},
+				{
+					ConfigMapRef: &corev1.ConfigMapEnvSource{},
+					Prefix:       "1-",
+				},
+			},
+			wantFields: []string{
+				"spec.components.loki.envFrom[0]",
+				"spec.components.loki.envFrom[1].configMapRef.name",
+				"spec.components.loki.envFrom[1].prefix",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(ComponentReserved["loki"], tt.env, tt.envFrom, fldPath)
+
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package eventexporter builds the Kubernetes events pipeline of a platform.
+// kubernetes-event-exporter watches the events of the cluster and pushes them
+// as JSON lines into Loki, under the stream {job="kubernetes-events",
+// platform="<platform>"}. A dashboard charts them and Loki's ruler alerts on
+// spikes of Warning events.
+package eventexporter
+
+import (
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Image is the kubernetes-event-exporter image repository
+	Image = "ghcr.io/resmoio/kubernetes-event-exporter"
+	// Job is the job label of the events stream
+	Job = "kubernetes-events"
+	// ConfigKey is the key of the configuration in the ConfigMap
+	ConfigKey = "config.yaml"
+	// RulesKey is the key of the alerting rules in the Loki rules ConfigMap
+	RulesKey = "kubernetes-events.yaml"
+	// DashboardKey is the key of the dashboard in the dashboards ConfigMap
+	DashboardKey = "kubernetes-events.json"
+
+	configPath = "/etc/event-exporter"
+)
+
+// Exporter is an event exporter deployment
+type Exporter struct {
+	Name      string
+	Namespace string
+	Version   string
+	// Platform labels the events stream
+	Platform string
+	// LokiURL is the push URL of Loki
+	LokiURL string
+	// TenantID is sent with the events, empty for Loki's default tenant
+	TenantID string
+	// WatchNamespace restricts the export, all namespaces if empty
+	WatchNamespace string
+	// WarningsOnly drops Normal events
+	WarningsOnly bool
+	Resources    corev1.ResourceRequirements
+	Labels       map[string]string
+}
+
+// ExporterName returns the name of the event exporter of a platform
+func ExporterName(platform string) string {
+	return fmt.Sprintf("event-exporter-%s", platform)
+}
+
+// ClusterRoleName returns the name of the ClusterRole and ClusterRoleBinding
+// of a platform's exporter. They are cluster scoped, so the name includes the
+// namespace.
+func ClusterRoleName(platform, namespace string) string {
+	return fmt.Sprintf("gunj-event-exporter-%s-%s", namespace, platform)
+}
+
+// selector returns the LogQL stream selector of a platform's events
+func selector(platform string) string {
+	return fmt.Sprintf(`{job=%q, platform=%q}`, Job, platform)
+}
+
+// Config returns the exporter configuration
+func Config(e Exporter) (string, error) {
+	route := map[string]interface{}{
+		"match": []interface{}{map[string]interface{}{"receiver": "loki"}},
+	}
+	if e.WarningsOnly {
+		route["drop"] = []interface{}{map[string]interface{}{"type": "Normal"}}
+	}
+	loki := map[string]interface{}{
+		"url":          e.LokiURL,
+		"streamLabels": map[string]string{"job": Job, "platform": e.Platform},
+	}
+	if e.TenantID != "" {
+		loki["headers"] = map[string]string{"X-Scope-OrgID": e.TenantID}
+	}
+	config := map[string]interface{}{
+		"logLevel":  "error",
+		"logFormat": "json",
+		"namespace": e.WatchNamespace,
+		// Events older than the exporter are already in Loki or expired
+		"maxEventAgeSeconds": 60,
+		"route":              map[string]interface{}{"routes": []interface{}{route}},
+		"receivers": []interface{}{map[string]interface{}{
+			"name": "loki",
+			"loki": loki,
+		}},
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to render event exporter config: %w", err)
+	}
+	return string(data), nil
+}
+
+// Rules returns the Loki rule file alerting when a namespace records more
+// Warning events in 5 minutes than the threshold
+func Rules(platform string, threshold int32) (string, error) {
+	rules := map[string]interface{}{
+		"groups": []interface{}{map[string]interface{}{
+			"name": "kubernetes-events",
+			"rules": []interface{}{map[string]interface{}{
+				"alert": "KubernetesWarningEventSpike",
+				"expr": fmt.Sprintf(`sum by (namespace, reason) (count_over_time(%s | json type="type", reason="reason", namespace="involvedObject.namespace" | type="Warning" [5m])) > %d`,
+					selector(platform), threshold),
+				"for":    "5m",
+				"labels": map[string]string{"severity": "warning", "platform": platform},
+				"annotations": map[string]string{
+					"summary":     "Spike of {{ $labels.reason }} Warning events in {{ $labels.namespace }}",
+					"description": fmt.Sprintf("Namespace {{ $labels.namespace }} recorded {{ $value }} {{ $labels.reason }} Warning events in 5 minutes, more than %d.", threshold),
+				},
+			}},
+		}},
+	}
+	data, err := yaml.Marshal(rules)
+	if err != nil {
+		return "", fmt.Errorf("failed to render event rules: %w", err)
+	}
+	return string(data), nil
+}
+
+// Dashboard returns the Grafana dashboard of a platform's events
+func Dashboard(platform string) string {
+	datasource := map[string]string{"type": "loki", "uid": "${datasource}"}
+	events := selector(platform) + ` | json type="type", reason="reason", namespace="involvedObject.namespace", kind="involvedObject.kind", object="involvedObject.name" | namespace=~"$namespace"`
+	panel := func(id int, title, panelType, expr string, x, y, w, h int) map[string]interface{} {
+		return map[string]interface{}{
+			"id":         id,
+			"title":      title,
+			"type":       panelType,
+			"datasource": datasource,
+			"gridPos":    map[string]int{"x": x, "y": y, "w": w, "h": h},
+			"targets":    []interface{}{map[string]interface{}{"refId": "A", "expr": expr, "datasource": datasource}},
+		}
+	}
+
+	topReasons := panel(3, "Top Warning reasons", "bargauge",
+		fmt.Sprintf(`topk(10, sum by (reason) (count_over_time(%s | type="Warning" [$__range])))`, events), 0, 8, 8, 8)
+	topReasons["targets"].([]interface{})[0].(map[string]interface{})["queryType"] = "instant"
+
+	dashboard := map[string]interface{}{
+		"uid":           "kubernetes-events",
+		"title":         "Kubernetes Events",
+		"tags":          []string{"kubernetes", "events", "gunj-operator"},
+		"schemaVersion": 39,
+		"time":          map[string]string{"from": "now-6h", "to": "now"},
+		"templating": map[string]interface{}{"list": []interface{}{
+			map[string]interface{}{"name": "datasource", "type": "datasource", "query": "loki"},
+			map[string]interface{}{"name": "namespace", "type": "textbox", "query": ".*", "label": "Namespace regex"},
+		}},
+		"panels": []interface{}{
+			panel(1, "Events by type", "timeseries",
+				fmt.Sprintf(`sum by (type) (count_over_time(%s [$__interval]))`, events), 0, 0, 12, 8),
+			panel(2, "Warning events by reason", "timeseries",
+				fmt.Sprintf(`sum by (reason) (count_over_time(%s | type="Warning" [$__interval]))`, events), 12, 0, 12, 8),
+			topReasons,
+			panel(4, "Warning events", "logs", events+` | type="Warning"`, 8, 8, 16, 8),
+		},
+	}
+	data, _ := json.MarshalIndent(dashboard, "", "  ")
+	return string(data)
+}
+
+// BuildConfigMap returns the ConfigMap holding the exporter configuration
+func BuildConfigMap(e Exporter) (*corev1.ConfigMap, error) {
+	config, err := Config(e)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      e.Name,
+			Namespace: e.Namespace,
+			Labels:    e.Labels,
+		},
+		Data: map[string]string{ConfigKey: config},
+	}, nil
+}
+
+// BuildServiceAccount returns the ServiceAccount the exporter runs as
+func BuildServiceAccount(e Exporter) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      e.Name,
+			Namespace: e.Namespace,
+			Labels:    e.Labels,
+		},
+	}
+}
+
+// BuildClusterRole returns the ClusterRole allowing the exporter to watch
+// events. The involved objects are not read, so their labels are not added.
+func BuildClusterRole(name string, labels map[string]string) *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{"", "events.k8s.io"},
+			Resources: []string{"events"},
+			Verbs:     []string{"get", "list", "watch"},
+		}},
+	}
+}
+
+// BuildClusterRoleBinding binds the ClusterRole to the exporter's
+// ServiceAccount
+func BuildClusterRoleBinding(name string, e Exporter) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: e.Labels},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     name,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      e.Name,
+			Namespace: e.Namespace,
+		}},
+	}
+}
+
+// BuildDeployment returns the exporter Deployment. It runs as a single
+// replica, as replicas would each push every event.
+func BuildDeployment(e Exporter) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      e.Name,
+			Namespace: e.Namespace,
+			Labels:    e.Labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: e.Labels},
+			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: e.Labels},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: e.Name,
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: &[]bool{true}[0],
+						RunAsUser:    &[]int64{65534}[0],
+					},
+					Containers: []corev1.Container{{
+						Name:      "event-exporter",
+						Image:     fmt.Sprintf("%s:%s", Image, e.Version),
+						Args:      []string{fmt.Sprintf("-conf=%s/%s", configPath, ConfigKey)},
+						Resources: e.Resources,
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: &[]bool{false}[0],
+							ReadOnlyRootFilesystem:   &[]bool{true}[0],
+						},
+						VolumeMounts: []corev1.VolumeMount{{Name: "config", MountPath: configPath}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "config",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: e.Name},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package eventexporter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestConfigPushesToLoki(t *testing.T) {
+	config, err := Config(Exporter{
+		Platform:     "prod",
+		LokiURL:      "http://loki-prod.monitoring.svc.cluster.local:3100/loki/api/v1/push",
+		WarningsOnly: true,
+	})
+	require.NoError(t, err)
+
+	var parsed struct {
+		Namespace string `json:"namespace"`
+		Route     struct {
+			Routes []struct {
+				Drop  []map[string]string `json:"drop"`
+				Match []map[string]string `json:"match"`
+			} `json:"routes"`
+		} `json:"route"`
+		Receivers []struct {
+			Name string `json:"name"`
+			Loki struct {
+				URL          string            `json:"url"`
+				StreamLabels map[string]string `json:"streamLabels"`
+				Headers      map[string]string `json:"headers"`
+			} `json:"loki"`
+		} `json:"receivers"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(config), &parsed))
+	assert.Empty(t, parsed.Namespace)
+	require.Len(t, parsed.Route.Routes, 1)
+	assert.Equal(t, []map[string]string{{"type": "Normal"}}, parsed.Route.Routes[0].Drop)
+	assert.Equal(t, []map[string]string{{"receiver": "loki"}}, parsed.Route.Routes[0].Match)
+	require.Len(t, parsed.Receivers, 1)
+	assert.Equal(t, "http://loki-prod.monitoring.svc.cluster.local:3100/loki/api/v1/push", parsed.Receivers[0].Loki.URL)
+	assert.Equal(t, map[string]string{"job": "kubernetes-events", "platform": "prod"}, parsed.Receivers[0].Loki.StreamLabels)
+	assert.Empty(t, parsed.Receivers[0].Loki.Headers)
+}
+
+func TestConfigSendsTenant(t *testing.T) {
+	config, err := Config(Exporter{Platform: "prod", TenantID: "fake"})
+	require.NoError(t, err)
+	assert.Contains(t, config, "headers:\n      X-Scope-OrgID: fake\n")
+}
+
+func TestRulesAlertOnWarningSpikes(t *testing.T) {
+	rules, err := Rules("prod", 20)
+	require.NoError(t, err)
+
+	var parsed struct {
+		Groups []struct {
+			Rules []struct {
+				Alert string `json:"alert"`
+				Expr  string `json:"expr"`
+			} `json:"rules"`
+		} `json:"groups"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(rules), &parsed))
+	require.Len(t, parsed.Groups, 1)
+	require.Len(t, parsed.Groups[0].Rules, 1)
+	rule := parsed.Groups[0].Rules[0]
+	assert.Equal(t, "KubernetesWarningEventSpike", rule.Alert)
+	assert.Contains(t, rule.Expr, `{job="kubernetes-events", platform="prod"}`)
+	assert.Contains(t, rule.Expr, `type="Warning" [5m])) > 20`)
+}
+
+func TestDashboardIsValidJSON(t *testing.T) {
+	var dashboard map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(Dashboard("prod")), &dashboard))
+	assert.Equal(t, "kubernetes-events", dashboard["uid"])
+	assert.Len(t, dashboard["panels"], 4)
+}
+
+func TestBuildDeploymentRunsSingleReplica(t *testing.T) {
+	d := BuildDeployment(Exporter{Name: ExporterName("prod"), Namespace: "monitoring", Version: "v1.7"})
+	assert.Equal(t, "event-exporter-prod", d.Name)
+	assert.Equal(t, int32(1), *d.Spec.Replicas)
+	assert.Equal(t, "ghcr.io/resmoio/kubernetes-event-exporter:v1.7", d.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, []string{"-conf=/etc/event-exporter/config.yaml"}, d.Spec.Template.Spec.Containers[0].Args)
+}
@@ -0,0 +1,443 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package exporters is the catalog of well-known Prometheus exporters a
+// platform deploys with one line of its spec. Each exporter runs as a
+// Deployment next to the platform, is scraped by the platform's Prometheus
+// under the job <name>-exporter and comes with a dashboard and default
+// alerts.
+package exporters
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Postgres is the PostgreSQL exporter
+	Postgres = "postgres"
+	// Redis is the Redis exporter
+	Redis = "redis"
+	// Kafka is the Kafka exporter
+	Kafka = "kafka"
+	// Nginx is the NGINX exporter
+	Nginx = "nginx"
+	// JVM is the JMX exporter
+	JVM = "jvm"
+
+	// ComponentLabel marks the objects of the exporters
+	ComponentLabel = "exporter"
+	// RulesFile is the Prometheus rule file of the default alerts
+	RulesFile = "exporter-rules.yml"
+	// ConfigKey is the key of the JMX exporter configuration
+	ConfigKey = "config.yaml"
+
+	configPath = "/etc/jmx-exporter"
+	// passwordEnv holds the password of the secret reference
+	passwordEnv = "EXPORTER_PASSWORD"
+)
+
+// Definition describes how an exporter of the catalog is run
+type Definition struct {
+	Image   string
+	Version string
+	Port    int32
+	// Password is true if the exporter accepts a password
+	Password bool
+}
+
+// Catalog are the exporters which can be deployed, keyed by type
+var Catalog = map[string]Definition{
+	Postgres: {Image: "quay.io/prometheuscommunity/postgres-exporter", Version: "v0.15.0", Port: 9187, Password: true},
+	Redis:    {Image: "oliver006/redis_exporter", Version: "v1.62.0", Port: 9121, Password: true},
+	Kafka:    {Image: "danielqsj/kafka-exporter", Version: "v1.7.0", Port: 9308, Password: true},
+	Nginx:    {Image: "nginx/nginx-prometheus-exporter", Version: "1.3.0", Port: 9113},
+	JVM:      {Image: "bitnami/jmx-exporter", Version: "1.0.1", Port: 5556},
+}
+
+// Types returns the exporter types of the catalog, sorted
+func Types() []string {
+	types := make([]string, 0, len(Catalog))
+	for t := range Catalog {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Exporter is an exporter deployment of a platform
+type Exporter struct {
+	// Name is the name of the exporter in the platform spec
+	Name      string
+	Namespace string
+	// Platform is the name of the platform
+	Platform string
+	// Type is the catalog entry
+	Type    string
+	Version string
+	Address string
+	// Username and PasswordSecret are the credentials the exporter connects
+	// with
+	Username       string
+	PasswordSecret *corev1.SecretKeySelector
+	Args           []string
+	Resources      corev1.ResourceRequirements
+	Labels         map[string]string
+}
+
+// ObjectName returns the name of the Deployment, Service and ConfigMap of an
+// exporter
+func ObjectName(e Exporter) string {
+	return fmt.Sprintf("%s-exporter-%s", e.Name, e.Platform)
+}
+
+// Job returns the Prometheus job of an exporter
+func Job(e Exporter) string {
+	return e.Name + "-exporter"
+}
+
+// image returns the image of an exporter
+func image(e Exporter) string {
+	version := e.Version
+	if version == "" {
+		version = Catalog[e.Type].Version
+	}
+	return fmt.Sprintf("%s:%s", Catalog[e.Type].Image, version)
+}
+
+// Config returns the JMX exporter configuration, empty for the other
+// exporters which are configured by arguments
+func Config(e Exporter) (string, error) {
+	if e.Type != JVM {
+		return "", nil
+	}
+	config := map[string]interface{}{
+		"hostPort":            e.Address,
+		"lowercaseOutputName": true,
+		"rules":               []interface{}{map[string]string{"pattern": ".*"}},
+	}
+	if e.Username != "" {
+		config["username"] = e.Username
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to render JMX exporter config: %w", err)
+	}
+	return string(data), nil
+}
+
+// Container returns the exporter container. Passwords are read from the
+// secret into an environment variable and referenced as $(EXPORTER_PASSWORD)
+// by the arguments which need them.
+func Container(e Exporter) corev1.Container {
+	def := Catalog[e.Type]
+	var args []string
+	var env []corev1.EnvVar
+	if e.PasswordSecret != nil && def.Password {
+		env = append(env, corev1.EnvVar{
+			Name:      passwordEnv,
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: e.PasswordSecret},
+		})
+	}
+
+	switch e.Type {
+	case Postgres:
+		env = append(env, corev1.EnvVar{Name: "DATA_SOURCE_URI", Value: e.Address})
+		if e.Username != "" {
+			env = append(env, corev1.EnvVar{Name: "DATA_SOURCE_USER", Value: e.Username})
+		}
+		if e.PasswordSecret != nil {
+			env = append(env, corev1.EnvVar{Name: "DATA_SOURCE_PASS", Value: "$(" + passwordEnv + ")"})
+		}
+	case Redis:
+		address := e.Address
+		if !strings.Contains(address, "://") {
+			address = "redis://" + address
+		}
+		env = append(env, corev1.EnvVar{Name: "REDIS_ADDR", Value: address})
+		if e.Username != "" {
+			env = append(env, corev1.EnvVar{Name: "REDIS_USER", Value: e.Username})
+		}
+		if e.PasswordSecret != nil {
+			env = append(env, corev1.EnvVar{Name: "REDIS_PASSWORD", Value: "$(" + passwordEnv + ")"})
+		}
+	case Kafka:
+		for _, broker := range strings.Split(e.Address, ",") {
+			args = append(args, "--kafka.server="+strings.TrimSpace(broker))
+		}
+		if e.Username != "" {
+			args = append(args, "--sasl.enabled", "--sasl.username="+e.Username)
+			if e.PasswordSecret != nil {
+				args = append(args, "--sasl.password=$("+passwordEnv+")")
+			}
+		}
+	case Nginx:
+		args = append(args, "--nginx.scrape-uri="+e.Address)
+	case JVM:
+		args = append(args, fmt.Sprintf("%d", def.Port), fmt.Sprintf("%s/%s", configPath, ConfigKey))
+	}
+
+	c := corev1.Container{
+		Name:      "exporter",
+		Image:     image(e),
+		Args:      append(args, e.Args...),
+		Env:       env,
+		Resources: e.Resources,
+		Ports: []corev1.ContainerPort{{
+			Name:          "metrics",
+			ContainerPort: def.Port,
+			Protocol:      corev1.ProtocolTCP,
+		}},
+		SecurityContext: &corev1.SecurityContext{
+			RunAsNonRoot:             &[]bool{true}[0],
+			RunAsUser:                &[]int64{65534}[0],
+			AllowPrivilegeEscalation: &[]bool{false}[0],
+			ReadOnlyRootFilesystem:   &[]bool{true}[0],
+		},
+	}
+	if e.Type == JVM {
+		c.VolumeMounts = []corev1.VolumeMount{{Name: "config", MountPath: configPath}}
+	}
+	return c
+}
+
+// BuildConfigMap returns the ConfigMap of the JMX exporter configuration, nil
+// for the other exporters
+func BuildConfigMap(e Exporter) (*corev1.ConfigMap, error) {
+	if e.Type != JVM {
+		return nil, nil
+	}
+	config, err := Config(e)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ObjectName(e),
+			Namespace: e.Namespace,
+			Labels:    e.Labels,
+		},
+		Data: map[string]string{ConfigKey: config},
+	}, nil
+}
+
+// BuildDeployment returns the exporter Deployment
+func BuildDeployment(e Exporter) *appsv1.Deployment {
+	replicas := int32(1)
+	var volumes []corev1.Volume
+	if e.Type == JVM {
+		volumes = append(volumes, corev1.Volume{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: ObjectName(e)},
+				},
+			},
+		})
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ObjectName(e),
+			Namespace: e.Namespace,
+			Labels:    e.Labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: e.Labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: e.Labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{Container(e)},
+					Volumes:    volumes,
+				},
+			},
+		},
+	}
+}
+
+// BuildService returns the Service Prometheus scrapes the exporter through
+func BuildService(e Exporter) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ObjectName(e),
+			Namespace: e.Namespace,
+			Labels:    e.Labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: e.Labels,
+			Ports: []corev1.ServicePort{{
+				Name:       "metrics",
+				Port:       Catalog[e.Type].Port,
+				TargetPort: intstr.FromString("metrics"),
+				Protocol:   corev1.ProtocolTCP,
+			}},
+		},
+	}
+}
+
+// ScrapeConfigs returns the scrape_configs entries of the exporters,
+// indented to be appended to the scrape_configs of prometheus.yml
+func ScrapeConfigs(exporters []Exporter) string {
+	var b strings.Builder
+	for _, e := range exporters {
+		fmt.Fprintf(&b, `
+
+  # %s exporter %s
+  - job_name: '%s'
+    static_configs:
+      - targets: ['%s.%s.svc.cluster.local:%d']
+        labels:
+          exporter: '%s'`, e.Type, e.Name, Job(e), ObjectName(e), e.Namespace, Catalog[e.Type].Port, e.Type)
+	}
+	return b.String()
+}
+
+// alert is a default alert of an exporter type; %s in the expression is the
+// job selector
+type alert struct {
+	name        string
+	expr        string
+	duration    string
+	severity    string
+	description string
+}
+
+var alerts = map[string][]alert{
+	Postgres: {
+		{"PostgresDown", `pg_up{%s} == 0`, "1m", "critical", "PostgreSQL {{ $labels.job }} is unreachable."},
+		{"PostgresTooManyConnections", `sum by (job) (pg_stat_activity_count{%[1]s}) / max by (job) (pg_settings_max_connections{%[1]s}) > 0.8`, "5m", "warning", "PostgreSQL {{ $labels.job }} uses more than 80% of its connections."},
+	},
+	Redis: {
+		{"RedisDown", `redis_up{%s} == 0`, "1m", "critical", "Redis {{ $labels.job }} is unreachable."},
+		{"RedisMemoryHigh", `redis_memory_used_bytes{%[1]s} / redis_memory_max_bytes{%[1]s} > 0.9 and redis_memory_max_bytes{%[1]s} > 0`, "5m", "warning", "Redis {{ $labels.job }} uses more than 90% of its maxmemory."},
+	},
+	Kafka: {
+		{"KafkaBrokersDown", `kafka_brokers{%s} < 1`, "1m", "critical", "Kafka {{ $labels.job }} has no reachable broker."},
+		{"KafkaConsumerGroupLag", `sum by (job, consumergroup, topic) (kafka_consumergroup_lag{%s}) > 1000`, "15m", "warning", "Consumer group {{ $labels.consumergroup }} lags {{ $value }} messages behind on {{ $labels.topic }}."},
+	},
+	Nginx: {
+		{"NginxDown", `nginx_up{%s} == 0`, "1m", "critical", "NGINX {{ $labels.job }} is unreachable."},
+		{"NginxDroppedConnections", `rate(nginx_connections_accepted{%[1]s}[5m]) - rate(nginx_connections_handled{%[1]s}[5m]) > 0`, "5m", "warning", "NGINX {{ $labels.job }} drops connections."},
+	},
+	JVM: {
+		{"JVMDown", `up{%s} == 0`, "1m", "critical", "The JMX exporter {{ $labels.job }} cannot be scraped."},
+		{"JVMHeapHigh", `java_lang_memory_heapmemoryusage_used{%[1]s} / java_lang_memory_heapmemoryusage_max{%[1]s} > 0.9`, "10m", "warning", "JVM {{ $labels.job }} uses more than 90% of its heap."},
+	},
+}
+
+// Rules returns the Prometheus rule file of the exporters' default alerts,
+// one group per exporter. It returns an empty string if there are no
+// exporters.
+func Rules(exporters []Exporter) (string, error) {
+	if len(exporters) == 0 {
+		return "", nil
+	}
+	var groups []interface{}
+	for _, e := range exporters {
+		selector := fmt.Sprintf(`job=%q`, Job(e))
+		var rules []interface{}
+		for _, a := range alerts[e.Type] {
+			rules = append(rules, map[string]interface{}{
+				"alert":       a.name,
+				"expr":        fmt.Sprintf(a.expr, selector),
+				"for":         a.duration,
+				"labels":      map[string]string{"severity": a.severity, "exporter": e.Type},
+				"annotations": map[string]string{"description": a.description},
+			})
+		}
+		groups = append(groups, map[string]interface{}{"name": Job(e), "rules": rules})
+	}
+	data, err := yaml.Marshal(map[string]interface{}{"groups": groups})
+	if err != nil {
+		return "", fmt.Errorf("failed to render exporter rules: %w", err)
+	}
+	return string(data), nil
+}
+
+// panels are the dashboard panels of an exporter type: title and query,
+// where %s is the job selector
+var panels = map[string][][2]string{
+	Postgres: {
+		{"Up", `pg_up{%s}`},
+		{"Connections", `sum by (state) (pg_stat_activity_count{%s})`},
+		{"Transactions/s", `sum(rate(pg_stat_database_xact_commit{%[1]s}[5m])) + sum(rate(pg_stat_database_xact_rollback{%[1]s}[5m]))`},
+		{"Database size", `sum by (datname) (pg_database_size_bytes{%s})`},
+	},
+	Redis: {
+		{"Up", `redis_up{%s}`},
+		{"Commands/s", `sum(rate(redis_commands_processed_total{%s}[5m]))`},
+		{"Memory used", `redis_memory_used_bytes{%s}`},
+		{"Connected clients", `redis_connected_clients{%s}`},
+	},
+	Kafka: {
+		{"Brokers", `kafka_brokers{%s}`},
+		{"Messages in/s by topic", `sum by (topic) (rate(kafka_topic_partition_current_offset{%s}[5m]))`},
+		{"Consumer group lag", `sum by (consumergroup, topic) (kafka_consumergroup_lag{%s})`},
+		{"Under-replicated partitions", `sum by (topic) (kafka_topic_partition_under_replicated_partition{%s})`},
+	},
+	Nginx: {
+		{"Up", `nginx_up{%s}`},
+		{"Requests/s", `rate(nginx_http_requests_total{%s}[5m])`},
+		{"Active connections", `nginx_connections_active{%s}`},
+		{"Dropped connections/s", `rate(nginx_connections_accepted{%[1]s}[5m]) - rate(nginx_connections_handled{%[1]s}[5m])`},
+	},
+	JVM: {
+		{"Up", `up{%s}`},
+		{"Heap used", `java_lang_memory_heapmemoryusage_used{%s}`},
+		{"Threads", `java_lang_threading_threadcount{%s}`},
+		{"GC time/s", `sum by (name) (rate(java_lang_garbagecollector_collectiontime{%s}[5m])) / 1000`},
+	},
+}
+
+// DashboardKey returns the key of an exporter's dashboard in the dashboards
+// ConfigMap
+func DashboardKey(e Exporter) string {
+	return fmt.Sprintf("exporter-%s.json", e.Name)
+}
+
+// Dashboard returns the Grafana dashboard of an exporter
+func Dashboard(e Exporter) string {
+	datasource := map[string]string{"type": "prometheus", "uid": "${datasource}"}
+	selector := fmt.Sprintf(`job=%q`, Job(e))
+	var list []interface{}
+	for i, p := range panels[e.Type] {
+		list = append(list, map[string]interface{}{
+			"id":         i + 1,
+			"title":      p[0],
+			"type":       "timeseries",
+			"datasource": datasource,
+			"gridPos":    map[string]int{"x": (i % 2) * 12, "y": (i / 2) * 8, "w": 12, "h": 8},
+			"targets": []interface{}{map[string]interface{}{
+				"refId":      "A",
+				"expr":       fmt.Sprintf(p[1], selector),
+				"datasource": datasource,
+			}},
+		})
+	}
+
+	dashboard := map[string]interface{}{
+		"uid":           fmt.Sprintf("exporter-%s-%s", e.Platform, e.Name),
+		"title":         fmt.Sprintf("%s exporter: %s", strings.ToUpper(e.Type[:1])+e.Type[1:], e.Name),
+		"tags":          []string{"exporter", e.Type, "gunj-operator"},
+		"schemaVersion": 39,
+		"time":          map[string]string{"from": "now-6h", "to": "now"},
+		"templating": map[string]interface{}{"list": []interface{}{
+			map[string]interface{}{"name": "datasource", "type": "datasource", "query": "prometheus"},
+		}},
+		"panels": list,
+	}
+	data, _ := json.MarshalIndent(dashboard, "", "  ")
+	return string(data)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package exporters
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestContainerReadsPasswordFromSecret(t *testing.T) {
+	secret := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "db"},
+		Key:                  "password",
+	}
+	c := Container(Exporter{Name: "orders", Type: Postgres, Address: "db:5432/orders?sslmode=disable", Username: "monitor", PasswordSecret: secret})
+	assert.Equal(t, "quay.io/prometheuscommunity/postgres-exporter:v0.15.0", c.Image)
+	require.Len(t, c.Env, 4)
+	assert.Equal(t, "EXPORTER_PASSWORD", c.Env[0].Name)
+	assert.Equal(t, secret, c.Env[0].ValueFrom.SecretKeyRef)
+	assert.Equal(t, corev1.EnvVar{Name: "DATA_SOURCE_PASS", Value: "$(EXPORTER_PASSWORD)"}, c.Env[3])
+
+	c = Container(Exporter{Name: "events", Type: Kafka, Address: "kafka-0:9092, kafka-1:9092", Username: "monitor", PasswordSecret: secret})
+	assert.Equal(t, []string{
+		"--kafka.server=kafka-0:9092",
+		"--kafka.server=kafka-1:9092",
+		"--sasl.enabled",
+		"--sasl.username=monitor",
+		"--sasl.password=$(EXPORTER_PASSWORD)",
+	}, c.Args)
+
+	c = Container(Exporter{Name: "cache", Type: Redis, Address: "redis:6379", Version: "v1.60.0"})
+	assert.Equal(t, "oliver006/redis_exporter:v1.60.0", c.Image)
+	assert.Equal(t, []corev1.EnvVar{{Name: "REDIS_ADDR", Value: "redis://redis:6379"}}, c.Env)
+}
+
+func TestBuildDeploymentMountsJMXConfig(t *testing.T) {
+	e := Exporter{Name: "app", Platform: "prod", Namespace: "monitoring", Type: JVM, Address: "app:9010"}
+	cm, err := BuildConfigMap(e)
+	require.NoError(t, err)
+	assert.Equal(t, "app-exporter-prod", cm.Name)
+	assert.Contains(t, cm.Data[ConfigKey], "hostPort: app:9010")
+
+	d := BuildDeployment(e)
+	require.Len(t, d.Spec.Template.Spec.Volumes, 1)
+	assert.Equal(t, []string{"5556", "/etc/jmx-exporter/config.yaml"}, d.Spec.Template.Spec.Containers[0].Args)
+
+	cm, err = BuildConfigMap(Exporter{Name: "web", Type: Nginx})
+	require.NoError(t, err)
+	assert.Nil(t, cm)
+}
+
+func TestScrapeConfigs(t *testing.T) {
+	config := ScrapeConfigs([]Exporter{{Name: "web", Platform: "prod", Namespace: "monitoring", Type: Nginx}})
+	assert.Contains(t, config, "- job_name: 'web-exporter'")
+	assert.Contains(t, config, "- targets: ['web-exporter-prod.monitoring.svc.cluster.local:9113']")
+}
+
+func TestRulesSelectJob(t *testing.T) {
+	rules, err := Rules(nil)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	rules, err = Rules([]Exporter{{Name: "cache", Type: Redis}})
+	require.NoError(t, err)
+	var parsed struct {
+		Groups []struct {
+			Name  string `json:"name"`
+			Rules []struct {
+				Alert string `json:"alert"`
+				Expr  string `json:"expr"`
+			} `json:"rules"`
+		} `json:"groups"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(rules), &parsed))
+	require.Len(t, parsed.Groups, 1)
+	assert.Equal(t, "cache-exporter", parsed.Groups[0].Name)
+	require.Len(t, parsed.Groups[0].Rules, 2)
+	assert.Equal(t, `redis_up{job="cache-exporter"} == 0`, parsed.Groups[0].Rules[0].Expr)
+	assert.NotContains(t, parsed.Groups[0].Rules[1].Expr, "%")
+}
+
+func TestCatalogHasAlertsAndPanels(t *testing.T) {
+	for _, exporterType := range Types() {
+		assert.NotEmpty(t, alerts[exporterType], exporterType)
+		assert.Len(t, panels[exporterType], 4, exporterType)
+
+		var dashboard map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(Dashboard(Exporter{Name: exporterType, Platform: "prod", Type: exporterType})), &dashboard))
+		assert.NotContains(t, Dashboard(Exporter{Name: exporterType, Type: exporterType}), "%!")
+	}
+}
@@ -0,0 +1,429 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package feasibility simulates whether the replicas of a platform's
+// components can be scheduled on the nodes of the cluster, so users are
+// warned before they wait on Pending pods. Replicas are placed one by one on
+// the schedulable node with the most free CPU that matches their node
+// selector and affinity, tolerates their taints and has room for their
+// requests after the pods already running there. The simulation is an
+// approximation of the scheduler: pod affinity and topology spread
+// constraints are not considered.
+package feasibility
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/footprint"
+	"github.com/gunjanjp/gunj-operator/internal/nodepool"
+	"github.com/gunjanjp/gunj-operator/internal/zones"
+)
+
+// PlatformLabel selects the pods of a platform, which are replaced by the
+// simulated ones and so do not take room on their nodes
+const PlatformLabel = "observability.io/platform"
+
+// simulated are the resources checked against the allocatable of nodes
+var simulated = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourcePods}
+
+// Workload is the replicas of a component to place
+type Workload struct {
+	// Name of the component
+	Name     string
+	Replicas int32
+	// Requests of each replica
+	Requests     corev1.ResourceList
+	NodeSelector map[string]string
+	// NodeAffinity, of which only the required terms are considered
+	NodeAffinity *corev1.NodeAffinity
+	Tolerations  []corev1.Toleration
+}
+
+// Result reports a workload some replicas of which fit on no node
+type Result struct {
+	Workload      string
+	Replicas      int32
+	Unschedulable int32
+	// Reason the first unschedulable replica fits on no node
+	Reason string
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%s: %d of %d replicas cannot be scheduled: %s", r.Workload, r.Unschedulable, r.Replicas, r.Reason)
+}
+
+// node is the room left on a node
+type node struct {
+	name   string
+	labels map[string]string
+	taints []corev1.Taint
+	free   corev1.ResourceList
+}
+
+// Simulate places the workloads on nodes, after the pods already bound to
+// them, and returns the workloads with replicas left unplaced
+func Simulate(nodes []corev1.Node, pods []corev1.Pod, workloads []Workload) []Result {
+	byName := make(map[string]*node, len(nodes))
+	candidates := make([]*node, 0, len(nodes))
+	for i := range nodes {
+		if nodes[i].Spec.Unschedulable {
+			continue
+		}
+		n := &node{
+			name:   nodes[i].Name,
+			labels: nodes[i].Labels,
+			taints: nodes[i].Spec.Taints,
+			free:   corev1.ResourceList{},
+		}
+		for _, name := range simulated {
+			n.free[name] = nodes[i].Status.Allocatable[name].DeepCopy()
+		}
+		byName[n.name] = n
+		candidates = append(candidates, n)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].name < candidates[j].name })
+
+	for i := range pods {
+		n, ok := byName[pods[i].Spec.NodeName]
+		if !ok || finished(&pods[i]) {
+			continue
+		}
+		requests, _ := footprint.PodResources(pods[i].Spec)
+		n.take(requests)
+	}
+
+	var results []Result
+	for _, w := range workloads {
+		result := Result{Workload: w.Name, Replicas: w.Replicas}
+		for replica := int32(0); replica < w.Replicas; replica++ {
+			n, reason := place(candidates, w)
+			if n == nil {
+				if result.Unschedulable == 0 {
+					result.Reason = reason
+				}
+				result.Unschedulable++
+				continue
+			}
+			n.take(w.Requests)
+		}
+		if result.Unschedulable > 0 {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// place returns the node a replica of w goes to, or why it fits on none
+func place(candidates []*node, w Workload) (*node, string) {
+	var best *node
+	matching, tolerated := 0, 0
+	var untolerated []string
+	insufficient := map[corev1.ResourceName]bool{}
+	for _, n := range candidates {
+		if !matches(n.labels, w.NodeSelector, w.NodeAffinity) {
+			continue
+		}
+		matching++
+		if taint, ok := untoleratedTaint(n.taints, w.Tolerations); ok {
+			untolerated = append(untolerated, taint)
+			continue
+		}
+		tolerated++
+		if missing := n.lacks(w.Requests); len(missing) > 0 {
+			for _, name := range missing {
+				insufficient[name] = true
+			}
+			continue
+		}
+		if best == nil || n.free.Cpu().Cmp(*best.free.Cpu()) > 0 {
+			best = n
+		}
+	}
+
+	switch {
+	case best != nil:
+		return best, ""
+	case matching == 0:
+		return nil, "no schedulable node matches the node selector and affinity"
+	case tolerated == 0:
+		return nil, fmt.Sprintf("the %d matching nodes have untolerated taints (%s)", matching, strings.Join(unique(untolerated), ", "))
+	default:
+		names := make([]string, 0, len(insufficient))
+		for name := range insufficient {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		return nil, fmt.Sprintf("insufficient %s on the %d matching nodes", strings.Join(names, ", "), tolerated)
+	}
+}
+
+// take reserves room for a pod with requests
+func (n *node) take(requests corev1.ResourceList) {
+	for name, q := range requests {
+		if free, ok := n.free[name]; ok {
+			free.Sub(q)
+			n.free[name] = free
+		}
+	}
+	pods := n.free[corev1.ResourcePods]
+	pods.Sub(*resource.NewQuantity(1, resource.DecimalSI))
+	n.free[corev1.ResourcePods] = pods
+}
+
+// lacks returns the resources the node has no room for a pod with requests
+func (n *node) lacks(requests corev1.ResourceList) []corev1.ResourceName {
+	var missing []corev1.ResourceName
+	for _, name := range simulated {
+		free := n.free[name]
+		need := requests[name]
+		if name == corev1.ResourcePods {
+			need = *resource.NewQuantity(1, resource.DecimalSI)
+		}
+		if need.Cmp(free) > 0 {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// matches returns whether node labels satisfy the node selector and the
+// required terms of the affinity, of which one must match
+func matches(nodeLabels, nodeSelector map[string]string, affinity *corev1.NodeAffinity) bool {
+	set := labels.Set(nodeLabels)
+	if !labels.SelectorFromSet(nodeSelector).Matches(set) {
+		return false
+	}
+	if affinity == nil || affinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+	for _, term := range affinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		if termMatches(set, term) {
+			return true
+		}
+	}
+	return false
+}
+
+var operators = map[corev1.NodeSelectorOperator]selection.Operator{
+	corev1.NodeSelectorOpIn:           selection.In,
+	corev1.NodeSelectorOpNotIn:        selection.NotIn,
+	corev1.NodeSelectorOpExists:       selection.Exists,
+	corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	corev1.NodeSelectorOpGt:           selection.GreaterThan,
+	corev1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+func termMatches(set labels.Set, term corev1.NodeSelectorTerm) bool {
+	// A term without label expressions only selects by fields, which are
+	// not simulated
+	if len(term.MatchExpressions) == 0 {
+		return len(term.MatchFields) > 0
+	}
+	selector := labels.NewSelector()
+	for _, expr := range term.MatchExpressions {
+		r, err := labels.NewRequirement(expr.Key, operators[expr.Operator], expr.Values)
+		if err != nil {
+			return false
+		}
+		selector = selector.Add(*r)
+	}
+	return selector.Matches(set)
+}
+
+// untoleratedTaint returns the first NoSchedule or NoExecute taint not
+// tolerated
+func untoleratedTaint(taints []corev1.Taint, tolerations []corev1.Toleration) (string, bool) {
+	for i := range taints {
+		if taints[i].Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for j := range tolerations {
+			if tolerations[j].ToleratesTaint(&taints[i]) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return taints[i].ToString(), true
+		}
+	}
+	return "", false
+}
+
+func finished(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+func unique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var out []string
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Enabled returns whether the platform asks for the scheduling check
+func Enabled(platform *observabilityv1beta1.ObservabilityPlatform) bool {
+	return platform.Spec.Global != nil && platform.Spec.Global.SchedulingCheck
+}
+
+// WorkloadsFor returns the workloads of the enabled components of a
+// platform, with one workload per zone for zone-aware ingesters
+func WorkloadsFor(platform *observabilityv1beta1.ObservabilityPlatform) ([]Workload, error) {
+	base := Workload{NodeSelector: map[string]string{}}
+	if global := platform.Spec.Global; global != nil {
+		for k, v := range global.NodeSelector {
+			base.NodeSelector[k] = v
+		}
+		for _, t := range global.Tolerations {
+			base.Tolerations = append(base.Tolerations, corev1.Toleration{
+				Key:      t.Key,
+				Operator: corev1.TolerationOperator(t.Operator),
+				Value:    t.Value,
+				Effect:   corev1.TaintEffect(t.Effect),
+			})
+		}
+		if global.Affinity != nil {
+			base.NodeAffinity = global.Affinity.NodeAffinity
+		}
+		if pool := global.NodePool; pool.IsEnabled() {
+			p := nodepool.Pool{LabelKey: pool.GetLabelKey(), Name: pool.GetName(), TaintEffect: corev1.TaintEffect(pool.GetTaintEffect())}
+			base.NodeSelector[p.LabelKey] = p.Name
+			base.Tolerations = append(base.Tolerations, p.Toleration())
+		}
+	}
+
+	var workloads []Workload
+	add := func(name string, replicas int32, resources *observabilityv1beta1.ResourceRequirements, zoneAwareness *observabilityv1beta1.ZoneAwarenessSpec) error {
+		requests, err := requestsOf(resources)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if !zoneAwareness.IsEnabled() {
+			w := base
+			w.Name, w.Replicas, w.Requests = name, replicasOrOne(replicas), requests
+			workloads = append(workloads, w)
+			return nil
+		}
+		for _, zone := range zoneAwareness.Zones {
+			w := base
+			w.Name, w.Replicas, w.Requests = zones.Name(name, zone), zoneAwareness.GetReplicasPerZone(), requests
+			w.NodeSelector = map[string]string{zoneAwareness.GetTopologyKey(): zone}
+			for k, v := range base.NodeSelector {
+				w.NodeSelector[k] = v
+			}
+			workloads = append(workloads, w)
+		}
+		return nil
+	}
+
+	components := platform.Spec.Components
+	if components == nil {
+		return nil, nil
+	}
+	if p := components.Prometheus; p != nil && p.Enabled {
+		if err := add("prometheus", p.Replicas, p.Resources, nil); err != nil {
+			return nil, err
+		}
+	}
+	if g := components.Grafana; g != nil && g.Enabled {
+		if err := add("grafana", g.Replicas, g.Resources, nil); err != nil {
+			return nil, err
+		}
+	}
+	if l := components.Loki; l != nil && l.Enabled {
+		if err := add("loki", l.Replicas, l.Resources, l.ZoneAwareness); err != nil {
+			return nil, err
+		}
+	}
+	if t := components.Tempo; t != nil && t.Enabled {
+		if err := add("tempo", t.Replicas, t.Resources, t.ZoneAwareness); err != nil {
+			return nil, err
+		}
+	}
+	if alerting := platform.Spec.Alerting; alerting != nil && alerting.Alertmanager != nil && alerting.Alertmanager.Enabled {
+		am := alerting.Alertmanager
+		if err := add("alertmanager", am.Replicas, am.Resources, nil); err != nil {
+			return nil, err
+		}
+	}
+	return workloads, nil
+}
+
+// requestsOf parses the CPU and memory requests of a component
+func requestsOf(resources *observabilityv1beta1.ResourceRequirements) (corev1.ResourceList, error) {
+	requests := corev1.ResourceList{}
+	if resources == nil || resources.Requests == nil {
+		return requests, nil
+	}
+	for name, value := range map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    resources.Requests.CPU,
+		corev1.ResourceMemory: resources.Requests.Memory,
+	} {
+		if value == "" {
+			continue
+		}
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s request %q: %w", name, value, err)
+		}
+		requests[name] = q
+	}
+	return requests, nil
+}
+
+func replicasOrOne(replicas int32) int32 {
+	if replicas <= 0 {
+		return 1
+	}
+	return replicas
+}
+
+// Check simulates the workloads of a platform on the cluster read through
+// reader. The platform's own pods are left out, as the simulated replicas
+// stand for them.
+func Check(ctx context.Context, reader client.Reader, platform *observabilityv1beta1.ObservabilityPlatform) ([]Result, error) {
+	workloads, err := WorkloadsFor(platform)
+	if err != nil {
+		return nil, err
+	}
+	if len(workloads) == 0 {
+		return nil, nil
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := reader.List(ctx, nodes); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	pods := &corev1.PodList{}
+	if err := reader.List(ctx, pods); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	others := pods.Items[:0]
+	for _, pod := range pods.Items {
+		if pod.Namespace == platform.Namespace && pod.Labels[PlatformLabel] == platform.Name {
+			continue
+		}
+		others = append(others, pod)
+	}
+	return Simulate(nodes.Items, others, workloads), nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package feasibility
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+func testNode(name, zone, cpu, memory string, taints ...corev1.Taint) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"topology.kubernetes.io/zone": zone}},
+		Spec:       corev1.NodeSpec{Taints: taints},
+		Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+			corev1.ResourcePods:   resource.MustParse("110"),
+		}},
+	}
+}
+
+func testPod(name, nodeName, cpu string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PodSpec{NodeName: nodeName, Containers: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}},
+		}}},
+	}
+}
+
+func requests(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	gpu := corev1.Taint{Key: "gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule}
+	nodes := []corev1.Node{
+		testNode("node-a", "zone-a", "4", "8Gi"),
+		testNode("node-b", "zone-b", "4", "8Gi"),
+		testNode("node-gpu", "zone-b", "16", "64Gi", gpu),
+	}
+	pods := []corev1.Pod{testPod("busy", "node-a", "3")}
+
+	t.Run("fits", func(t *testing.T) {
+		results := Simulate(nodes, pods, []Workload{{Name: "grafana", Replicas: 2, Requests: requests("500m", "1Gi")}})
+		assert.Empty(t, results)
+	})
+
+	t.Run("insufficient resources", func(t *testing.T) {
+		// One replica fits on node-b, node-a only has 1 CPU left
+		results := Simulate(nodes, pods, []Workload{{Name: "prometheus", Replicas: 3, Requests: requests("3", "2Gi")}})
+		require.Len(t, results, 1)
+		assert.Equal(t, int32(2), results[0].Unschedulable)
+		assert.Equal(t, "insufficient cpu on the 2 matching nodes", results[0].Reason)
+		assert.Equal(t, "prometheus: 2 of 3 replicas cannot be scheduled: insufficient cpu on the 2 matching nodes", results[0].String())
+	})
+
+	t.Run("taints", func(t *testing.T) {
+		results := Simulate(nodes, pods, []Workload{{
+			Name: "loki", Replicas: 1, Requests: requests("8", "1Gi"),
+			NodeSelector: map[string]string{"topology.kubernetes.io/zone": "zone-b"},
+		}})
+		require.Len(t, results, 1)
+		assert.Equal(t, "insufficient cpu on the 1 matching nodes", results[0].Reason)
+
+		results = Simulate(nodes, pods, []Workload{{
+			Name: "loki", Replicas: 1, Requests: requests("8", "1Gi"),
+			Tolerations: []corev1.Toleration{{Key: "gpu", Operator: corev1.TolerationOpExists}},
+		}})
+		assert.Empty(t, results)
+
+		results = Simulate(nodes, pods, []Workload{{
+			Name: "loki", Replicas: 1,
+			NodeAffinity: &corev1.NodeAffinity{RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key: "kubernetes.io/hostname", Operator: corev1.NodeSelectorOpExists,
+				}}}},
+			}},
+		}})
+		require.Len(t, results, 1)
+		assert.Equal(t, "no schedulable node matches the node selector and affinity", results[0].Reason)
+	})
+
+	t.Run("cordoned and untolerated", func(t *testing.T) {
+		cordoned := testNode("node-c", "zone-c", "4", "8Gi")
+		cordoned.Spec.Unschedulable = true
+		all := append([]corev1.Node{cordoned}, nodes...)
+		results := Simulate(all, nil, []Workload{
+			{Name: "tempo-zone-c", Replicas: 1, NodeSelector: map[string]string{"topology.kubernetes.io/zone": "zone-c"}},
+			{Name: "tempo-gpu", Replicas: 1, NodeSelector: map[string]string{"topology.kubernetes.io/zone": "zone-b"}, Requests: requests("10", "1Gi")},
+		})
+		require.Len(t, results, 2)
+		assert.Equal(t, "no schedulable node matches the node selector and affinity", results[0].Reason)
+		assert.Equal(t, "insufficient cpu on the 1 matching nodes", results[1].Reason)
+
+		results = Simulate([]corev1.Node{nodes[2]}, nil, []Workload{{Name: "tempo", Replicas: 1}})
+		require.Len(t, results, 1)
+		assert.Equal(t, "the 1 matching nodes have untolerated taints (gpu=true:NoSchedule)", results[0].Reason)
+	})
+}
+
+func TestWorkloadsFor(t *testing.T) {
+	platform := &observabilityv1beta1.ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "monitoring"},
+		Spec: observabilityv1beta1.ObservabilityPlatformSpec{
+			Global: &observabilityv1beta1.GlobalSettings{
+				SchedulingCheck: true,
+				NodeSelector:    map[string]string{"role": "observability"},
+				NodePool:        &observabilityv1beta1.NodePoolSpec{Enabled: true},
+			},
+			Components: &observabilityv1beta1.Components{
+				Prometheus: &observabilityv1beta1.PrometheusSpec{
+					Enabled:   true,
+					Replicas:  2,
+					Resources: &observabilityv1beta1.ResourceRequirements{Requests: &observabilityv1beta1.ResourceList{CPU: "1", Memory: "2Gi"}},
+				},
+				Loki: &observabilityv1beta1.LokiSpec{
+					Enabled:       true,
+					ZoneAwareness: &observabilityv1beta1.ZoneAwarenessSpec{Enabled: true, Zones: []string{"zone-a", "zone-b"}, ReplicasPerZone: 2},
+				},
+			},
+		},
+	}
+	assert.True(t, Enabled(platform))
+
+	workloads, err := WorkloadsFor(platform)
+	require.NoError(t, err)
+	require.Len(t, workloads, 3)
+	assert.Equal(t, "prometheus", workloads[0].Name)
+	assert.Equal(t, int32(2), workloads[0].Replicas)
+	assert.Equal(t, map[string]string{"role": "observability", "observability.io/node-pool": "observability"}, workloads[0].NodeSelector)
+	require.Len(t, workloads[0].Tolerations, 1)
+	assert.Equal(t, "observability.io/node-pool", workloads[0].Tolerations[0].Key)
+	cpu := workloads[0].Requests[corev1.ResourceCPU]
+	assert.Equal(t, "1", cpu.String())
+
+	assert.Equal(t, "loki-zone-b", workloads[2].Name)
+	assert.Equal(t, int32(2), workloads[2].Replicas)
+	assert.Equal(t, "zone-b", workloads[2].NodeSelector["topology.kubernetes.io/zone"])
+	assert.Equal(t, "observability", workloads[2].NodeSelector["role"])
+
+	platform.Spec.Components.Prometheus.Resources.Requests.CPU = "lots"
+	_, err = WorkloadsFor(platform)
+	assert.ErrorContains(t, err, "prometheus")
+}
+
+func TestCheck(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	node := testNode("node-a", "zone-a", "2", "8Gi")
+	own := testPod("prod-prometheus-0", "node-a", "1500m")
+	own.Namespace = "monitoring"
+	own.Labels = map[string]string{PlatformLabel: "prod"}
+	other := testPod("app", "node-a", "500m")
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&node, &own, &other).Build()
+
+	platform := &observabilityv1beta1.ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "monitoring"},
+		Spec: observabilityv1beta1.ObservabilityPlatformSpec{
+			Components: &observabilityv1beta1.Components{
+				Prometheus: &observabilityv1beta1.PrometheusSpec{
+					Enabled:   true,
+					Resources: &observabilityv1beta1.ResourceRequirements{Requests: &observabilityv1beta1.ResourceList{CPU: "1500m"}},
+				},
+			},
+		},
+	}
+	// The running Prometheus pod is replaced by the simulated one
+	results, err := Check(context.Background(), c, platform)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	platform.Spec.Components.Prometheus.Replicas = 2
+	results, err = Check(context.Background(), c, platform)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, int32(1), results[0].Unschedulable)
+}
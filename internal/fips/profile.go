@@ -0,0 +1,176 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package fips translates the platform FIPS/strict-TLS settings into the
+// configuration dialects understood by each managed component.
+package fips
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ApprovedCipherSuites lists the FIPS 140-2 approved TLS 1.2 cipher suites
+// (IANA names) that components may be restricted to.
+var ApprovedCipherSuites = []string{
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+}
+
+// opensslCipherNames maps IANA cipher suite names to their OpenSSL equivalents,
+// which is the format expected by ingress-nginx.
+var opensslCipherNames = map[string]string{
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256": "ECDHE-ECDSA-AES128-GCM-SHA256",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384": "ECDHE-ECDSA-AES256-GCM-SHA384",
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":   "ECDHE-RSA-AES128-GCM-SHA256",
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":   "ECDHE-RSA-AES256-GCM-SHA384",
+}
+
+// MinimumComponentVersions is the oldest release of each component that
+// supports configuring a minimum TLS version and cipher suites.
+var MinimumComponentVersions = map[string]string{
+	"prometheus":              "2.35.0",
+	"grafana":                 "8.3.0",
+	"loki":                    "2.7.0",
+	"tempo":                   "2.0.0",
+	"opentelemetry-collector": "0.80.0",
+}
+
+// Profile is a resolved strict-TLS profile
+type Profile struct {
+	// MinVersion is the minimum TLS version ("1.2" or "1.3")
+	MinVersion string
+	// CipherSuites are the IANA names of the allowed TLS 1.2 cipher suites
+	CipherSuites []string
+}
+
+// NewProfile resolves a profile from the user supplied settings, applying
+// defaults and rejecting anything that is not FIPS approved.
+func NewProfile(minVersion string, cipherSuites []string) (*Profile, error) {
+	if minVersion == "" {
+		minVersion = "1.2"
+	}
+	if minVersion != "1.2" && minVersion != "1.3" {
+		return nil, fmt.Errorf("unsupported minimum TLS version %q: must be 1.2 or 1.3", minVersion)
+	}
+
+	if len(cipherSuites) == 0 {
+		cipherSuites = ApprovedCipherSuites
+	}
+	if invalid := UnapprovedCipherSuites(cipherSuites); len(invalid) > 0 {
+		return nil, fmt.Errorf("cipher suites are not FIPS approved: %s", strings.Join(invalid, ", "))
+	}
+
+	suites := make([]string, len(cipherSuites))
+	copy(suites, cipherSuites)
+
+	return &Profile{
+		MinVersion:   minVersion,
+		CipherSuites: suites,
+	}, nil
+}
+
+// Resolve returns the profile for the given settings, falling back to the
+// default profile (TLS 1.2 with all approved suites) if they are invalid.
+// Admission rejects invalid settings, so the fallback only guards against
+// objects that bypassed the webhook; it never weakens the TLS constraints.
+func Resolve(minVersion string, cipherSuites []string) *Profile {
+	profile, err := NewProfile(minVersion, cipherSuites)
+	if err != nil {
+		profile, _ = NewProfile("", nil)
+	}
+	return profile
+}
+
+// UnapprovedCipherSuites returns the entries of suites that are not FIPS approved
+func UnapprovedCipherSuites(suites []string) []string {
+	var invalid []string
+	for _, suite := range suites {
+		if _, ok := opensslCipherNames[suite]; !ok {
+			invalid = append(invalid, suite)
+		}
+	}
+	return invalid
+}
+
+// PrometheusMinVersion returns the min_version value used in Prometheus tls_config blocks
+func (p *Profile) PrometheusMinVersion() string {
+	return "TLS" + strings.ReplaceAll(p.MinVersion, ".", "")
+}
+
+// ServerMinVersion returns the tls_min_version value used by Loki and Tempo servers
+func (p *Profile) ServerMinVersion() string {
+	return "VersionTLS" + strings.ReplaceAll(p.MinVersion, ".", "")
+}
+
+// ReceiverMinVersion returns the min_version value used in the tls blocks of
+// the OpenTelemetry receivers, which Tempo embeds
+func (p *Profile) ReceiverMinVersion() string {
+	return p.MinVersion
+}
+
+// GrafanaMinVersion returns the min_tls_version value used in grafana.ini
+func (p *Profile) GrafanaMinVersion() string {
+	return "TLS" + p.MinVersion
+}
+
+// ServerCipherSuites returns the comma separated cipher suite list used by Loki and Tempo servers
+func (p *Profile) ServerCipherSuites() string {
+	return strings.Join(p.CipherSuites, ",")
+}
+
+// NginxProtocols returns the value for the ingress-nginx ssl-protocols annotation
+func (p *Profile) NginxProtocols() string {
+	if p.MinVersion == "1.3" {
+		return "TLSv1.3"
+	}
+	return "TLSv1.2 TLSv1.3"
+}
+
+// NginxCiphers returns the value for the ingress-nginx ssl-ciphers annotation
+func (p *Profile) NginxCiphers() string {
+	names := make([]string, 0, len(p.CipherSuites))
+	for _, suite := range p.CipherSuites {
+		names = append(names, opensslCipherNames[suite])
+	}
+	return strings.Join(names, ":")
+}
+
+// SupportsComponentVersion reports whether the given component version can
+// honour strict TLS settings. Unknown components are assumed to be supported.
+func SupportsComponentVersion(component, version string) bool {
+	minimum, ok := MinimumComponentVersions[component]
+	if !ok {
+		return true
+	}
+	return compareVersions(version, minimum) >= 0
+}
+
+// compareVersions compares two dotted versions, ignoring a leading "v"
+func compareVersions(a, b string) int {
+	aParts := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(b, "v"), ".")
+
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aNum, bNum int
+		if i < len(aParts) {
+			aNum, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bNum, _ = strconv.Atoi(bParts[i])
+		}
+		if aNum != bNum {
+			if aNum < bNum {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
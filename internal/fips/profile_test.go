@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package fips
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProfile(t *testing.T) {
+	tests := []struct {
+		name         string
+		minVersion   string
+		cipherSuites []string
+		wantErr      bool
+		wantMin      string
+		wantSuites   int
+	}{
+		{
+			name:       "defaults",
+			wantMin:    "1.2",
+			wantSuites: len(ApprovedCipherSuites),
+		},
+		{
+			name:         "tls 1.3 with restricted suites",
+			minVersion:   "1.3",
+			cipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+			wantMin:      "1.3",
+			wantSuites:   1,
+		},
+		{
+			name:       "unsupported version",
+			minVersion: "1.1",
+			wantErr:    true,
+		},
+		{
+			name:         "unapproved cipher suite",
+			cipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := NewProfile(tt.minVersion, tt.cipherSuites)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMin, profile.MinVersion)
+			assert.Len(t, profile.CipherSuites, tt.wantSuites)
+		})
+	}
+}
+
+func TestProfileDialects(t *testing.T) {
+	profile, err := NewProfile("1.2", []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "TLS12", profile.PrometheusMinVersion())
+	assert.Equal(t, "VersionTLS12", profile.ServerMinVersion())
+	assert.Equal(t, "1.2", profile.ReceiverMinVersion())
+	assert.Equal(t, "TLS1.2", profile.GrafanaMinVersion())
+	assert.Equal(t, "TLSv1.2 TLSv1.3", profile.NginxProtocols())
+	assert.Equal(t, "ECDHE-RSA-AES128-GCM-SHA256", profile.NginxCiphers())
+}
+
+func TestSupportsComponentVersion(t *testing.T) {
+	assert.True(t, SupportsComponentVersion("prometheus", "v2.48.0"))
+	assert.False(t, SupportsComponentVersion("prometheus", "v2.30.1"))
+	assert.True(t, SupportsComponentVersion("tempo", "2.3.0"))
+	assert.False(t, SupportsComponentVersion("grafana", "7.5.0"))
+	assert.True(t, SupportsComponentVersion("unknown", "0.0.1"))
+}
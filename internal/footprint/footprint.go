@@ -0,0 +1,215 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package footprint sums the CPU, memory and storage the workloads of a
+// platform request, for status.resourceFootprint. Pods count with the
+// effective requests the scheduler uses: the larger of the sum of their
+// containers and their largest init container, plus the pod overhead.
+// Storage counts the volume claim templates of StatefulSets for every
+// replica and the other claims of the platform.
+package footprint
+
+import (
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+const (
+	// ComponentLabel names the component of a workload
+	ComponentLabel = "app.kubernetes.io/name"
+
+	// OtherComponent groups the workloads without a component label
+	OtherComponent = "other"
+)
+
+type totals struct {
+	cpuRequests    resource.Quantity
+	cpuLimits      resource.Quantity
+	memoryRequests resource.Quantity
+	memoryLimits   resource.Quantity
+	storage        resource.Quantity
+	pods           int32
+}
+
+// Accumulator sums the footprint of workloads by component
+type Accumulator struct {
+	total      totals
+	components map[string]*totals
+	// claims are the claims created from StatefulSet templates, already
+	// counted
+	claims map[string]bool
+}
+
+// New returns an empty accumulator
+func New() *Accumulator {
+	return &Accumulator{
+		components: make(map[string]*totals),
+		claims:     make(map[string]bool),
+	}
+}
+
+// component returns the totals of the component labeled in labels
+func (a *Accumulator) component(labels map[string]string) *totals {
+	name := labels[ComponentLabel]
+	if name == "" {
+		name = OtherComponent
+	}
+	t, ok := a.components[name]
+	if !ok {
+		t = &totals{}
+		a.components[name] = t
+	}
+	return t
+}
+
+// AddPods counts replicas pods of spec toward the component in labels
+func (a *Accumulator) AddPods(labels map[string]string, spec corev1.PodSpec, replicas int32) {
+	if replicas <= 0 {
+		return
+	}
+	requests, limits := PodResources(spec)
+	for _, t := range []*totals{&a.total, a.component(labels)} {
+		addScaled(&t.cpuRequests, requests[corev1.ResourceCPU], replicas)
+		addScaled(&t.cpuLimits, limits[corev1.ResourceCPU], replicas)
+		addScaled(&t.memoryRequests, requests[corev1.ResourceMemory], replicas)
+		addScaled(&t.memoryLimits, limits[corev1.ResourceMemory], replicas)
+		t.pods += replicas
+	}
+}
+
+// AddDeployment counts the desired pods of a Deployment
+func (a *Accumulator) AddDeployment(d *appsv1.Deployment) {
+	a.AddPods(d.Labels, d.Spec.Template.Spec, replicasOf(d.Spec.Replicas))
+}
+
+// AddDaemonSet counts the pods a DaemonSet is scheduled to run
+func (a *Accumulator) AddDaemonSet(d *appsv1.DaemonSet) {
+	a.AddPods(d.Labels, d.Spec.Template.Spec, d.Status.DesiredNumberScheduled)
+}
+
+// AddStatefulSet counts the desired pods of a StatefulSet and the claims of
+// its volume claim templates
+func (a *Accumulator) AddStatefulSet(s *appsv1.StatefulSet) {
+	replicas := replicasOf(s.Spec.Replicas)
+	a.AddPods(s.Labels, s.Spec.Template.Spec, replicas)
+	for _, template := range s.Spec.VolumeClaimTemplates {
+		size := template.Spec.Resources.Requests[corev1.ResourceStorage]
+		for _, t := range []*totals{&a.total, a.component(s.Labels)} {
+			addScaled(&t.storage, size, replicas)
+		}
+		for i := int32(0); i < replicas; i++ {
+			a.claims[fmt.Sprintf("%s/%s-%s-%d", s.Namespace, template.Name, s.Name, i)] = true
+		}
+	}
+}
+
+// AddClaim counts a claim, unless it was created from a StatefulSet template
+// already counted. Add the StatefulSets first.
+func (a *Accumulator) AddClaim(c *corev1.PersistentVolumeClaim) {
+	if a.claims[c.Namespace+"/"+c.Name] {
+		return
+	}
+	size := c.Spec.Resources.Requests[corev1.ResourceStorage]
+	for _, t := range []*totals{&a.total, a.component(c.Labels)} {
+		addScaled(&t.storage, size, 1)
+	}
+}
+
+// Footprint returns the footprint summed so far, with the components sorted
+// by name
+func (a *Accumulator) Footprint() *observabilityv1beta1.ResourceFootprint {
+	footprint := &observabilityv1beta1.ResourceFootprint{ResourceTotals: a.total.status()}
+	names := make([]string, 0, len(a.components))
+	for name := range a.components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		footprint.Components = append(footprint.Components, observabilityv1beta1.ComponentFootprint{
+			Name:           name,
+			ResourceTotals: a.components[name].status(),
+		})
+	}
+	return footprint
+}
+
+func (t *totals) status() observabilityv1beta1.ResourceTotals {
+	return observabilityv1beta1.ResourceTotals{
+		CPURequests:    nonZero(t.cpuRequests),
+		CPULimits:      nonZero(t.cpuLimits),
+		MemoryRequests: nonZero(t.memoryRequests),
+		MemoryLimits:   nonZero(t.memoryLimits),
+		Storage:        nonZero(t.storage),
+		Pods:           t.pods,
+	}
+}
+
+// PodResources returns the effective requests and limits of a pod
+func PodResources(spec corev1.PodSpec) (requests, limits corev1.ResourceList) {
+	requests, limits = corev1.ResourceList{}, corev1.ResourceList{}
+	for _, c := range spec.Containers {
+		addList(requests, c.Resources.Requests)
+		addList(limits, c.Resources.Limits)
+	}
+	for _, c := range spec.InitContainers {
+		maxList(requests, c.Resources.Requests)
+		maxList(limits, c.Resources.Limits)
+	}
+	addList(requests, spec.Overhead)
+	if len(limits) > 0 {
+		addList(limits, spec.Overhead)
+	}
+	return requests, limits
+}
+
+func addList(dst, src corev1.ResourceList) {
+	for name, q := range src {
+		sum := dst[name]
+		sum.Add(q)
+		dst[name] = sum
+	}
+}
+
+func maxList(dst, src corev1.ResourceList) {
+	for name, q := range src {
+		if current, ok := dst[name]; !ok || q.Cmp(current) > 0 {
+			dst[name] = q.DeepCopy()
+		}
+	}
+}
+
+func addScaled(dst *resource.Quantity, q resource.Quantity, n int32) {
+	if q.IsZero() {
+		return
+	}
+	scaled := resource.NewMilliQuantity(q.MilliValue()*int64(n), q.Format)
+	if q.MilliValue()%1000 == 0 {
+		// Keep whole quantities such as memory in their binary units
+		scaled = resource.NewQuantity(q.Value()*int64(n), q.Format)
+	}
+	dst.Add(*scaled)
+}
+
+func nonZero(q resource.Quantity) *resource.Quantity {
+	if q.IsZero() {
+		return nil
+	}
+	q = q.DeepCopy()
+	return &q
+}
+
+func replicasOf(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
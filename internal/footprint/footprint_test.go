@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package footprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func container(cpu, memory, cpuLimit string) corev1.Container {
+	c := corev1.Container{Resources: corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		},
+	}}
+	if cpuLimit != "" {
+		c.Resources.Limits = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpuLimit)}
+	}
+	return c
+}
+
+func TestPodResources(t *testing.T) {
+	spec := corev1.PodSpec{
+		Containers:     []corev1.Container{container("100m", "128Mi", "200m"), container("50m", "64Mi", "")},
+		InitContainers: []corev1.Container{container("500m", "32Mi", "")},
+		Overhead:       corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")},
+	}
+	requests, limits := PodResources(spec)
+
+	cpu := requests[corev1.ResourceCPU]
+	assert.Equal(t, "510m", cpu.String())
+	memory := requests[corev1.ResourceMemory]
+	assert.Equal(t, "192Mi", memory.String())
+	cpuLimit := limits[corev1.ResourceCPU]
+	assert.Equal(t, "210m", cpuLimit.String())
+}
+
+func TestFootprint(t *testing.T) {
+	replicas := int32(2)
+	prometheus := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-prometheus", Namespace: "monitoring", Labels: map[string]string{ComponentLabel: "prometheus"}},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{container("1", "2Gi", "2")}}},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+				ObjectMeta: metav1.ObjectMeta{Name: "data"},
+				Spec: corev1.PersistentVolumeClaimSpec{Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("50Gi")},
+				}},
+			}},
+		},
+	}
+	grafana := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-grafana", Labels: map[string]string{ComponentLabel: "grafana"}},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{container("250m", "512Mi", "")}}},
+		},
+	}
+	alloy := &appsv1.DaemonSet{
+		Spec:   appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{container("100m", "256Mi", "")}}}},
+		Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3},
+	}
+	claim := func(name, size string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "monitoring", Labels: map[string]string{ComponentLabel: "prometheus"}},
+			Spec: corev1.PersistentVolumeClaimSpec{Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+			}},
+		}
+	}
+
+	a := New()
+	a.AddStatefulSet(prometheus)
+	a.AddDeployment(grafana)
+	a.AddDaemonSet(alloy)
+	// Created from the template, already counted
+	a.AddClaim(claim("data-prod-prometheus-0", "50Gi"))
+	a.AddClaim(claim("backup", "10Gi"))
+
+	f := a.Footprint()
+	assert.Equal(t, "2550m", f.CPURequests.String())
+	assert.Equal(t, "4", f.CPULimits.String())
+	assert.Equal(t, "5376Mi", f.MemoryRequests.String())
+	assert.Nil(t, f.MemoryLimits)
+	assert.Equal(t, "110Gi", f.Storage.String())
+	assert.Equal(t, int32(6), f.Pods)
+
+	require.Len(t, f.Components, 3)
+	assert.Equal(t, "grafana", f.Components[0].Name)
+	assert.Equal(t, OtherComponent, f.Components[1].Name)
+	assert.Equal(t, int32(3), f.Components[1].Pods)
+	assert.Equal(t, "prometheus", f.Components[2].Name)
+	assert.Equal(t, "110Gi", f.Components[2].Storage.String())
+	assert.Equal(t, "2", f.Components[2].CPURequests.String())
+}
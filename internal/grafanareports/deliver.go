@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package grafanareports
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTP is a mail server reports are sent through
+type SMTP struct {
+	Host     string
+	Port     int
+	From     string
+	Username string
+	Password string
+}
+
+// Mail sends a report to the recipients. net/smtp upgrades the connection
+// with STARTTLS if offered and refuses to send credentials without TLS.
+func (s *SMTP) Mail(to []string, report *Report) error {
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+	message, err := Message(s.From, to, report)
+	if err != nil {
+		return err
+	}
+	if err := smtp.SendMail(net.JoinHostPort(s.Host, strconv.Itoa(s.Port)), auth, s.From, to, message); err != nil {
+		return fmt.Errorf("failed to mail report %s: %w", report.Name, err)
+	}
+	return nil
+}
+
+// Message returns the MIME message mailing a report as an attachment
+func Message(from string, to []string, report *Report) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	text, err := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"7bit"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(text, "Grafana report %s of dashboard %s, generated at %s.\r\n",
+		report.Name, report.DashboardUID, report.Generated.UTC().Format(time.RFC1123))
+
+	attachment, err := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {mime.FormatMediaType(report.ContentType(), map[string]string{"name": report.FileName()})},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": report.FileName()})},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := writeBase64Lines(attachment, report.Data); err != nil {
+		return nil, err
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var message bytes.Buffer
+	headers := [][2]string{
+		{"From", from},
+		{"To", strings.Join(to, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", "Grafana report "+report.Name)},
+		{"Date", report.Generated.UTC().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": parts.Boundary()})},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&message, "%s: %s\r\n", header[0], header[1])
+	}
+	message.WriteString("\r\n")
+	message.Write(body.Bytes())
+	return message.Bytes(), nil
+}
+
+// writeBase64Lines writes data base64-encoded in lines of 76 characters
+func writeBase64Lines(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 0 {
+		n := 76
+		if len(encoded) < n {
+			n = len(encoded)
+		}
+		if _, err := io.WriteString(w, encoded[:n]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[n:]
+	}
+	return nil
+}
+
+// Post sends a report as the body of a POST request. The report is
+// described by the Content-Disposition and X-Grafana-Report headers.
+func Post(ctx context.Context, httpClient *http.Client, url, token string, report *Report) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(report.Data))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", report.ContentType())
+	request.Header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": report.FileName()}))
+	request.Header.Set("X-Grafana-Report", report.Name)
+	request.Header.Set("X-Grafana-Dashboard-UID", report.DashboardUID)
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to post report %s: %w", report.Name, err)
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 1<<20))
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("posting report %s returned %s", report.Name, response.Status)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package grafanareports
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+func TestSchedule(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2025, 6, 4, 10, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2025, 6, 4, 10, 45, 0, 0, time.UTC)},
+		{"0 8 * * 1", time.Date(2025, 6, 9, 8, 0, 0, 0, time.UTC)},
+		{"0 8 * * 7", time.Date(2025, 6, 8, 8, 0, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2025, 6, 5, 10, 30, 0, 0, time.UTC)},
+		{"0 9-17/4 * * 1-5", time.Date(2025, 6, 4, 13, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)},
+		// Days of month and week both restricted run on either
+		{"0 0 20 * 5", time.Date(2025, 6, 6, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		schedule, err := ParseSchedule(tt.spec)
+		require.NoError(t, err, tt.spec)
+		assert.Equal(t, tt.want, schedule.Next(now), tt.spec)
+	}
+
+	never, err := ParseSchedule("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, never.Next(now).IsZero())
+
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "@often"} {
+		_, err := ParseSchedule(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	now := time.Date(2025, 6, 4, 10, 30, 0, 0, time.UTC)
+	spec := &observabilityv1beta1.GrafanaReportSpec{Name: "weekly", Schedule: "0 * * * *"}
+
+	// A new report is scheduled, not run
+	status, due, err := Advance(spec, seenStatus(), now)
+	require.NoError(t, err)
+	assert.False(t, due)
+	assert.Equal(t, "weekly", status.Name)
+	assert.Equal(t, time.Date(2025, 6, 4, 11, 0, 0, 0, time.UTC), status.NextRunTime.Time)
+
+	status, due, err = Advance(spec, status, now.Add(20*time.Minute))
+	require.NoError(t, err)
+	assert.False(t, due)
+
+	// Missed runs are not caught up
+	status, due, err = Advance(spec, status, now.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.True(t, due)
+	assert.Equal(t, time.Date(2025, 6, 4, 14, 0, 0, 0, time.UTC), status.NextRunTime.Time)
+
+	// A changed schedule is scheduled anew
+	spec.Schedule = "@daily"
+	status, due, err = Advance(spec, status, now.Add(4*time.Hour))
+	require.NoError(t, err)
+	assert.False(t, due)
+	assert.Equal(t, time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC), status.NextRunTime.Time)
+
+	spec.Suspend = true
+	status, due, err = Advance(spec, status, now.Add(48*time.Hour))
+	require.NoError(t, err)
+	assert.False(t, due)
+	assert.Nil(t, status.NextRunTime)
+
+	spec.Suspend = false
+	spec.Schedule = "daily"
+	_, due, err = Advance(spec, status, now)
+	assert.Error(t, err)
+	assert.False(t, due)
+}
+
+// seenStatus is the status of a report which ran before
+func seenStatus() observabilityv1beta1.GrafanaReportStatus {
+	last := metav1.NewTime(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+	return observabilityv1beta1.GrafanaReportStatus{LastRunTime: &last}
+}
+
+func TestRenderPath(t *testing.T) {
+	spec := &observabilityv1beta1.GrafanaReportSpec{
+		DashboardUID: "k8s cluster",
+		Variables:    map[string]string{"namespace": "prod", "cluster": "eu-1"},
+	}
+	path := RenderPath(spec)
+	require.True(t, strings.HasPrefix(path, "/render/d/k8s%20cluster?"))
+
+	query, err := url.ParseQuery(strings.SplitN(path, "?", 2)[1])
+	require.NoError(t, err)
+	assert.Equal(t, "now-24h", query.Get("from"))
+	assert.Equal(t, "now", query.Get("to"))
+	assert.Equal(t, "1600", query.Get("width"))
+	assert.Equal(t, "900", query.Get("height"))
+	assert.Equal(t, "prod", query.Get("var-namespace"))
+	assert.Equal(t, "eu-1", query.Get("var-cluster"))
+}
+
+func TestPDF(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	img.Set(1, 0, color.NRGBA{A: 0})
+	var data bytes.Buffer
+	require.NoError(t, png.Encode(&data, img))
+
+	pdf, err := PDF(data.Bytes())
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	assert.Contains(t, string(pdf), "/MediaBox [0 0 3 2]")
+	assert.Contains(t, string(pdf), "/Width 3 /Height 2")
+
+	// The cross-reference table points at the objects
+	xref := bytes.Index(pdf, []byte("\nxref\n")) + 1
+	require.Positive(t, xref)
+	assert.Contains(t, string(pdf), fmt.Sprintf("startxref\n%d\n", xref))
+	offset := bytes.Index(pdf, []byte("3 0 obj"))
+	assert.Contains(t, string(pdf[xref:]), fmt.Sprintf("%010d 00000 n", offset))
+
+	_, err = PDF([]byte("not a png"))
+	assert.Error(t, err)
+}
+
+func TestMessage(t *testing.T) {
+	report := &Report{
+		Name:         "weekly",
+		DashboardUID: "k8s",
+		Format:       FormatPDF,
+		Data:         bytes.Repeat([]byte{0xff}, 100),
+		Generated:    time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC),
+	}
+	assert.Equal(t, "weekly-20250602-0800.pdf", report.FileName())
+	assert.Equal(t, "application/pdf", report.ContentType())
+
+	message, err := Message("reports@example.com", []string{"a@example.com", "b@example.com"}, report)
+	require.NoError(t, err)
+	text := string(message)
+	assert.Contains(t, text, "To: a@example.com, b@example.com\r\n")
+	assert.Contains(t, text, "Subject: Grafana report weekly\r\n")
+	assert.Contains(t, text, `filename=weekly-20250602-0800.pdf`)
+	for _, line := range strings.Split(text, "\r\n") {
+		assert.LessOrEqual(t, len(line), 998)
+	}
+}
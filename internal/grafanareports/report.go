@@ -0,0 +1,192 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package grafanareports renders Grafana dashboards on a schedule and
+// delivers them to their recipients. Dashboards are rendered to PNG by
+// Grafana's image renderer; PDF reports wrap the image into a single page.
+// Reports are mailed through SMTP or posted to webhooks.
+package grafanareports
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+const (
+	// FormatPDF reports are a PDF page holding the rendered dashboard
+	FormatPDF = "pdf"
+	// FormatPNG reports are the rendered dashboard
+	FormatPNG = "png"
+
+	// maxImageSize bounds the rendered images read from Grafana
+	maxImageSize = 64 << 20
+)
+
+// Report is a rendered report
+type Report struct {
+	// Name of the report
+	Name string
+	// DashboardUID of the rendered dashboard
+	DashboardUID string
+	// Format is pdf or png
+	Format string
+	// Data is the report file
+	Data []byte
+	// Generated is when the report was rendered
+	Generated time.Time
+}
+
+// FileName returns the name the report is delivered as
+func (r *Report) FileName() string {
+	return fmt.Sprintf("%s-%s.%s", r.Name, r.Generated.UTC().Format("20060102-1504"), r.Format)
+}
+
+// ContentType returns the media type of the report
+func (r *Report) ContentType() string {
+	if r.Format == FormatPDF {
+		return "application/pdf"
+	}
+	return "image/png"
+}
+
+// RenderPath returns the path of Grafana's render endpoint for a report's
+// dashboard, time range, size and variables
+func RenderPath(report *observabilityv1beta1.GrafanaReportSpec) string {
+	query := url.Values{}
+	query.Set("from", report.GetFrom())
+	query.Set("to", report.GetTo())
+	query.Set("width", strconv.Itoa(int(report.GetWidth())))
+	query.Set("height", strconv.Itoa(int(report.GetHeight())))
+	query.Set("tz", "UTC")
+	query.Set("kiosk", "")
+
+	names := make([]string, 0, len(report.Variables))
+	for name := range report.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		query.Set("var-"+name, report.Variables[name])
+	}
+	return "/render/d/" + url.PathEscape(report.DashboardUID) + "?" + query.Encode()
+}
+
+// Renderer renders dashboards through the Grafana API
+type Renderer struct {
+	BaseURL  string
+	HTTP     *http.Client
+	Username string
+	Password string
+}
+
+// Render renders a report's dashboard into the report's format
+func (r *Renderer) Render(ctx context.Context, spec *observabilityv1beta1.GrafanaReportSpec, now time.Time) (*Report, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, r.BaseURL+RenderPath(spec), nil)
+	if err != nil {
+		return nil, err
+	}
+	request.SetBasicAuth(r.Username, r.Password)
+
+	response, err := r.HTTP.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render dashboard %s: %w", spec.DashboardUID, err)
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxImageSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rendered dashboard %s: %w", spec.DashboardUID, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rendering dashboard %s returned %s: %s", spec.DashboardUID, response.Status, bytes.TrimSpace(data))
+	}
+
+	report := &Report{
+		Name:         spec.Name,
+		DashboardUID: spec.DashboardUID,
+		Format:       spec.GetFormat(),
+		Data:         data,
+		Generated:    now,
+	}
+	if report.Format == FormatPDF {
+		if report.Data, err = PDF(data); err != nil {
+			return nil, fmt.Errorf("failed to convert dashboard %s to PDF: %w", spec.DashboardUID, err)
+		}
+	}
+	return report, nil
+}
+
+// PDF wraps a PNG image into a single-page PDF sized to the image, one point
+// per pixel
+func PDF(pngData []byte) ([]byte, error) {
+	img, err := png.Decode(bytes.NewReader(pngData))
+	if err != nil {
+		return nil, err
+	}
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	// PDF images are uncompressed RGB samples, deflated
+	var pixels bytes.Buffer
+	deflate := zlib.NewWriter(&pixels)
+	row := make([]byte, 0, width*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row = row[:0]
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			row = appendRGB(row, img, x, y)
+		}
+		if _, err := deflate.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := deflate.Close(); err != nil {
+		return nil, err
+	}
+
+	content := fmt.Sprintf("q %d 0 0 %d 0 0 cm /Im0 Do Q", width, height)
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /XObject << /Im0 4 0 R >> >> /Contents 5 0 R >>", width, height),
+		fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream", width, height, pixels.Len(), pixels.Bytes()),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return out.Bytes(), nil
+}
+
+// appendRGB appends the 8-bit RGB samples of a pixel, composited on white
+func appendRGB(row []byte, img image.Image, x, y int) []byte {
+	r, g, b, a := img.At(x, y).RGBA()
+	// The samples are alpha-premultiplied, so add white for the transparency
+	white := 0xffff - a
+	return append(row, byte((r+white)>>8), byte((g+white)>>8), byte((b+white)>>8))
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package grafanareports
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// macros are the schedule shorthands
+var macros = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+}
+
+// field is the range of a cron field
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Schedule is a parsed five-field cron expression, evaluated in UTC
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny are true for unrestricted days; restricting both
+	// runs on days matching either, as cron does
+	domAny, dowAny bool
+}
+
+// ParseSchedule parses a cron expression of minute, hour, day of month,
+// month and day of week, or one of the @hourly, @daily, @weekly, @monthly
+// and @yearly shorthands. Fields take *, values, ranges, lists and steps.
+func ParseSchedule(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if expanded, ok := macros[spec]; ok {
+		spec = expanded
+	}
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("schedule %q must have %d fields: minute, hour, day of month, month and day of week", spec, len(fields))
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, part := range parts {
+		b, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, err
+		}
+		bits[i] = b
+	}
+
+	// Sunday is 0 or 7
+	dow := bits[4]
+	if dow&(1<<7) != 0 {
+		dow = (dow | 1) &^ (1 << 7)
+	}
+	return &Schedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    dow,
+		domAny: strings.HasPrefix(parts[2], "*"),
+		dowAny: strings.HasPrefix(parts[4], "*"),
+	}, nil
+}
+
+// parseField parses the comma-separated list of a field into a bit set
+func parseField(value string, f field) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(value, ",") {
+		rangePart, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step in %s field %q", f.name, item)
+			}
+			rangePart, step = item[:i], n
+		}
+
+		low, high := f.min, f.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = parseValue(bounds[0], f); err != nil {
+				return 0, err
+			}
+			if high, err = parseValue(bounds[1], f); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range in %s field %q", f.name, item)
+			}
+		default:
+			n, err := parseValue(rangePart, f)
+			if err != nil {
+				return 0, err
+			}
+			low, high = n, n
+			// A step on a single value runs from it to the end of the range
+			if step > 1 {
+				high = f.max
+			}
+		}
+
+		for n := low; n <= high; n += step {
+			bits |= 1 << uint(n)
+		}
+	}
+	return bits, nil
+}
+
+// parseValue parses a number within the range of a field
+func parseValue(value string, f field) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("%s must be between %d and %d, got %q", f.name, f.min, f.max, value)
+	}
+	return n, nil
+}
+
+// Next returns the first time after t the schedule runs at, or the zero time
+// if it never runs, e.g. on February 30
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	// Leap days recur within eight years
+	limit := t.AddDate(8, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// matchesDay returns true if the schedule runs on the day of t
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// Advance returns the status of a report at now, and whether the report is
+// due to run. A report first runs at the schedule's first time after it was
+// seen; missed runs are not caught up, and a suspended report is scheduled
+// anew when resumed.
+func Advance(spec *observabilityv1beta1.GrafanaReportSpec, status observabilityv1beta1.GrafanaReportStatus, now time.Time) (observabilityv1beta1.GrafanaReportStatus, bool, error) {
+	status.Name = spec.Name
+	if spec.Suspend {
+		status.NextRunTime = nil
+		return status, false, nil
+	}
+
+	schedule, err := ParseSchedule(spec.Schedule)
+	if err != nil {
+		status.NextRunTime = nil
+		return status, false, err
+	}
+	due := status.Schedule == spec.Schedule && status.NextRunTime != nil && !now.Before(status.NextRunTime.Time)
+	if due || status.Schedule != spec.Schedule || status.NextRunTime == nil {
+		status.Schedule = spec.Schedule
+		if next := schedule.Next(now); next.IsZero() {
+			status.NextRunTime = nil
+		} else {
+			nextRun := metav1.NewTime(next)
+			status.NextRunTime = &nextRun
+		}
+	}
+	return status, due, nil
+}
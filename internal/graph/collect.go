@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PlatformKind is the kind of the root node of a platform graph
+const PlatformKind = "ObservabilityPlatform"
+
+// collectedKinds are the object kinds listed for a platform. Workloads come
+// first so objects they own (such as StatefulSet PVCs) are matched through
+// their owner references.
+var collectedKinds = []struct {
+	kind string
+	list func() client.ObjectList
+}{
+	{"Deployment", func() client.ObjectList { return &appsv1.DeploymentList{} }},
+	{"StatefulSet", func() client.ObjectList { return &appsv1.StatefulSetList{} }},
+	{"DaemonSet", func() client.ObjectList { return &appsv1.DaemonSetList{} }},
+	{"Service", func() client.ObjectList { return &corev1.ServiceList{} }},
+	{"ConfigMap", func() client.ObjectList { return &corev1.ConfigMapList{} }},
+	{"Secret", func() client.ObjectList { return &corev1.SecretList{} }},
+	{"PersistentVolumeClaim", func() client.ObjectList { return &corev1.PersistentVolumeClaimList{} }},
+	{"ServiceAccount", func() client.ObjectList { return &corev1.ServiceAccountList{} }},
+	{"Ingress", func() client.ObjectList { return &networkingv1.IngressList{} }},
+}
+
+// Collect lists the objects managed for a platform and builds their graph.
+// Objects are matched by the app.kubernetes.io/instance label the component
+// managers set; objects owned by the platform are included regardless.
+func Collect(ctx context.Context, c client.Reader, platform metav1.Object) (*Graph, error) {
+	objects := []Object{toObject(PlatformKind, platform)}
+	collected := map[string]bool{string(platform.GetUID()): true}
+
+	for _, k := range collectedKinds {
+		list := k.list()
+		if err := c.List(ctx, list, client.InNamespace(platform.GetNamespace())); err != nil {
+			return nil, fmt.Errorf("failed to list %s objects: %w", k.kind, err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract %s objects: %w", k.kind, err)
+		}
+
+		for _, item := range items {
+			obj, ok := item.(metav1.Object)
+			if !ok || !managedBy(obj, platform.GetName(), collected) {
+				continue
+			}
+			collected[string(obj.GetUID())] = true
+			objects = append(objects, toObject(k.kind, obj))
+		}
+	}
+
+	return Build(objects), nil
+}
+
+// managedBy reports whether an object belongs to the platform
+func managedBy(obj metav1.Object, platformName string, collected map[string]bool) bool {
+	if obj.GetLabels()["app.kubernetes.io/instance"] == platformName &&
+		obj.GetLabels()["app.kubernetes.io/managed-by"] == "gunj-operator" {
+		return true
+	}
+	if obj.GetLabels()["observability.io/platform"] == platformName {
+		return true
+	}
+	for _, ref := range obj.GetOwnerReferences() {
+		if collected[string(ref.UID)] {
+			return true
+		}
+	}
+	return false
+}
+
+// toObject converts a Kubernetes object into a graph object
+func toObject(kind string, obj metav1.Object) Object {
+	o := Object{
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		UID:       string(obj.GetUID()),
+	}
+	for _, ref := range obj.GetOwnerReferences() {
+		o.Owners = append(o.Owners, OwnerRef{Kind: ref.Kind, Name: ref.Name, UID: string(ref.UID)})
+	}
+	return o
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package graph builds the object graph of a platform from owner references
+// and renders it as DOT, Mermaid or JSON for visualization.
+package graph
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Format is an output format for a graph
+type Format string
+
+const (
+	// FormatDOT renders the graph in Graphviz DOT
+	FormatDOT Format = "dot"
+	// FormatMermaid renders the graph as a Mermaid flowchart
+	FormatMermaid Format = "mermaid"
+	// FormatJSON renders the graph as JSON
+	FormatJSON Format = "json"
+)
+
+// Formats lists the supported output formats
+var Formats = []Format{FormatDOT, FormatMermaid, FormatJSON}
+
+// ParseFormat parses an output format name
+func ParseFormat(name string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == name {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported graph format %q (supported: dot, mermaid, json)", name)
+}
+
+// OwnerRef references the owner of an object
+type OwnerRef struct {
+	Kind string
+	Name string
+	UID  string
+}
+
+// Object is a Kubernetes object with its owner references
+type Object struct {
+	Kind      string
+	Namespace string
+	Name      string
+	UID       string
+	Owners    []OwnerRef
+}
+
+// Node is an object in the graph
+type Node struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// External is set for owners that were referenced but not collected
+	External bool `json:"external,omitempty"`
+}
+
+// Edge points from an owner to an object it owns
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Graph is the object graph of a platform
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// Build creates a graph from objects, with an edge for every owner reference.
+// Owners that are not part of objects are added as external nodes. Nodes and
+// edges are sorted so the output is stable.
+func Build(objects []Object) *Graph {
+	g := &Graph{Nodes: []Node{}, Edges: []Edge{}}
+	byUID := make(map[string]string, len(objects))
+	seen := make(map[string]bool, len(objects))
+
+	for _, obj := range objects {
+		id := nodeID(obj.Kind, obj.Namespace, obj.Name)
+		if obj.UID != "" {
+			byUID[obj.UID] = id
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		g.Nodes = append(g.Nodes, Node{ID: id, Kind: obj.Kind, Namespace: obj.Namespace, Name: obj.Name})
+	}
+
+	edges := make(map[Edge]bool)
+	for _, obj := range objects {
+		to := nodeID(obj.Kind, obj.Namespace, obj.Name)
+		for _, owner := range obj.Owners {
+			from, ok := byUID[owner.UID]
+			if !ok {
+				// Owner references are namespace-local
+				from = nodeID(owner.Kind, obj.Namespace, owner.Name)
+				if !seen[from] {
+					seen[from] = true
+					g.Nodes = append(g.Nodes, Node{ID: from, Kind: owner.Kind, Namespace: obj.Namespace, Name: owner.Name, External: true})
+				}
+			}
+			edge := Edge{From: from, To: to}
+			if !edges[edge] {
+				edges[edge] = true
+				g.Edges = append(g.Edges, edge)
+			}
+		}
+	}
+
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID < g.Nodes[j].ID })
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+	return g
+}
+
+// nodeID returns the identifier of an object, unique within a graph
+func nodeID(kind, namespace, name string) string {
+	if namespace == "" {
+		return kind + "/" + name
+	}
+	return kind + "/" + namespace + "/" + name
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testObjects() []Object {
+	return []Object{
+		{Kind: "ObservabilityPlatform", Namespace: "monitoring", Name: "prod", UID: "p"},
+		{Kind: "StatefulSet", Namespace: "monitoring", Name: "prometheus-prod", UID: "s",
+			Owners: []OwnerRef{{Kind: "ObservabilityPlatform", Name: "prod", UID: "p"}}},
+		{Kind: "PersistentVolumeClaim", Namespace: "monitoring", Name: "data-prometheus-prod-0", UID: "v",
+			Owners: []OwnerRef{{Kind: "StatefulSet", Name: "prometheus-prod", UID: "s"}}},
+		{Kind: "Secret", Namespace: "monitoring", Name: "tls", UID: "t",
+			Owners: []OwnerRef{{Kind: "Certificate", Name: "tls", UID: "c"}}},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	g := Build(testObjects())
+
+	require.Len(t, g.Nodes, 5)
+	assert.Equal(t, "Certificate/monitoring/tls", g.Nodes[0].ID)
+	assert.True(t, g.Nodes[0].External)
+
+	assert.Equal(t, []Edge{
+		{From: "Certificate/monitoring/tls", To: "Secret/monitoring/tls"},
+		{From: "ObservabilityPlatform/monitoring/prod", To: "StatefulSet/monitoring/prometheus-prod"},
+		{From: "StatefulSet/monitoring/prometheus-prod", To: "PersistentVolumeClaim/monitoring/data-prometheus-prod-0"},
+	}, g.Edges)
+}
+
+func TestRender(t *testing.T) {
+	g := Build(testObjects())
+
+	var dot bytes.Buffer
+	require.NoError(t, g.Render(&dot, FormatDOT))
+	assert.Contains(t, dot.String(), `"ObservabilityPlatform/monitoring/prod" -> "StatefulSet/monitoring/prometheus-prod";`)
+	assert.Contains(t, dot.String(), "style=dashed")
+
+	var mermaid bytes.Buffer
+	require.NoError(t, g.Render(&mermaid, FormatMermaid))
+	assert.Contains(t, mermaid.String(), "flowchart LR\n")
+	assert.Contains(t, mermaid.String(), `n0(["Certificate<br/>tls"])`)
+	assert.Contains(t, mermaid.String(), "n0 --> n3")
+
+	var out bytes.Buffer
+	require.NoError(t, g.Render(&out, FormatJSON))
+	var decoded Graph
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, g, &decoded)
+
+	_, err := ParseFormat("svg")
+	assert.Error(t, err)
+}
+
+func TestCollect(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	platform := &metav1.ObjectMeta{Name: "prod", Namespace: "monitoring", UID: "p"}
+	owner := []metav1.OwnerReference{{Kind: "ObservabilityPlatform", Name: "prod", UID: "p"}}
+	labels := map[string]string{
+		"app.kubernetes.io/instance":   "prod",
+		"app.kubernetes.io/managed-by": "gunj-operator",
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "grafana-prod", Namespace: "monitoring", UID: "d", Labels: labels, OwnerReferences: owner}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "grafana-prod-config", Namespace: "monitoring", UID: "cm", OwnerReferences: owner}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "monitoring", UID: "u"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "grafana-prod", Namespace: "other", UID: "o", Labels: labels}},
+	).Build()
+
+	g, err := Collect(context.Background(), c, platform)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, n := range g.Nodes {
+		ids = append(ids, n.ID)
+	}
+	assert.Equal(t, []string{
+		"ConfigMap/monitoring/grafana-prod-config",
+		"Deployment/monitoring/grafana-prod",
+		"ObservabilityPlatform/monitoring/prod",
+	}, ids)
+	assert.Len(t, g.Edges, 2)
+}
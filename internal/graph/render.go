@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Render writes the graph in the given format
+func (g *Graph) Render(w io.Writer, format Format) error {
+	switch format {
+	case FormatDOT:
+		return g.RenderDOT(w)
+	case FormatMermaid:
+		return g.RenderMermaid(w)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(g)
+	default:
+		return fmt.Errorf("unsupported graph format %q", format)
+	}
+}
+
+// RenderDOT writes the graph in Graphviz DOT
+func (g *Graph) RenderDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph platform {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, fontname=\"Helvetica\"];\n")
+	for _, n := range g.Nodes {
+		style := ""
+		if n.External {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  %s [label=%s%s];\n", strconv.Quote(n.ID), strconv.Quote(n.Kind+"\n"+n.Name), style)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s -> %s;\n", strconv.Quote(e.From), strconv.Quote(e.To))
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// RenderMermaid writes the graph as a Mermaid flowchart
+func (g *Graph) RenderMermaid(w io.Writer) error {
+	ids := make(map[string]string, len(g.Nodes))
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for i, n := range g.Nodes {
+		// Mermaid identifiers cannot contain slashes or dots
+		id := fmt.Sprintf("n%d", i)
+		ids[n.ID] = id
+		label := mermaidEscape(n.Kind) + "<br/>" + mermaidEscape(n.Name)
+		if n.External {
+			fmt.Fprintf(&b, "  %s([\"%s\"])\n", id, label)
+		} else {
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", id, label)
+		}
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s --> %s\n", ids[e.From], ids[e.To])
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mermaidEscape escapes characters that terminate a quoted Mermaid label
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(s)
+}
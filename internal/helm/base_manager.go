@@ -340,6 +340,11 @@ func (bhm *BaseHelmManager) getReleaseName(
 	platform *observabilityv1beta1.ObservabilityPlatform,
 	componentType string,
 ) string {
+	return ReleaseName(platform, componentType)
+}
+
+// ReleaseName returns the release name of a component of a platform
+func ReleaseName(platform *observabilityv1beta1.ObservabilityPlatform, componentType string) string {
 	return fmt.Sprintf("%s-%s", platform.Name, componentType)
 }
 
@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package incidents
+
+import (
+	"sort"
+)
+
+// DefaultTags are added to every incident annotation
+var DefaultTags = []string{"gunj-operator", "incident"}
+
+// Transitions compares the open incidents with the current component states
+// and returns the components whose incident started and the components that
+// recovered. Only components in scope are considered; an empty scope means
+// all components. Open incidents for components that disappeared are
+// treated as recovered.
+func Transitions(open map[string]bool, states map[string]bool, scope []string) (started, recovered []string) {
+	inScope := func(component string) bool {
+		if len(scope) == 0 {
+			return true
+		}
+		for _, s := range scope {
+			if s == component {
+				return true
+			}
+		}
+		return false
+	}
+
+	for component, ready := range states {
+		if !inScope(component) {
+			continue
+		}
+		switch {
+		case !ready && !open[component]:
+			started = append(started, component)
+		case ready && open[component]:
+			recovered = append(recovered, component)
+		}
+	}
+
+	for component := range open {
+		if _, ok := states[component]; !ok || !inScope(component) {
+			recovered = append(recovered, component)
+		}
+	}
+
+	sort.Strings(started)
+	sort.Strings(recovered)
+	return started, recovered
+}
+
+// Tags returns the tags for an incident annotation
+func Tags(platform, component string, extra []string) []string {
+	tags := make([]string, 0, len(DefaultTags)+len(extra)+2)
+	tags = append(tags, DefaultTags...)
+	tags = append(tags, "platform:"+platform, "component:"+component)
+	return append(tags, extra...)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package incidents bridges component health transitions to Grafana
+// annotations so incident windows are visible on dashboards.
+package incidents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Annotation is a Grafana region annotation
+type Annotation struct {
+	DashboardUID string
+	Time         time.Time
+	Text         string
+	Tags         []string
+}
+
+// AnnotationClient creates and updates Grafana annotations through the HTTP API
+type AnnotationClient struct {
+	BaseURL    string
+	Username   string
+	Password   string
+	HTTPClient *http.Client
+}
+
+// NewAnnotationClient creates a client authenticating with basic auth
+func NewAnnotationClient(baseURL, username, password string) *AnnotationClient {
+	return &AnnotationClient{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		Username:   username,
+		Password:   password,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// createAnnotationRequest is the body of POST /api/annotations
+type createAnnotationRequest struct {
+	DashboardUID string   `json:"dashboardUID,omitempty"`
+	Time         int64    `json:"time"`
+	Text         string   `json:"text"`
+	Tags         []string `json:"tags,omitempty"`
+}
+
+// createAnnotationResponse is the response of POST /api/annotations
+type createAnnotationResponse struct {
+	ID int64 `json:"id"`
+}
+
+// Create creates an annotation and returns its ID
+func (c *AnnotationClient) Create(ctx context.Context, annotation Annotation) (int64, error) {
+	body := createAnnotationRequest{
+		DashboardUID: annotation.DashboardUID,
+		Time:         annotation.Time.UnixMilli(),
+		Text:         annotation.Text,
+		Tags:         annotation.Tags,
+	}
+
+	var resp createAnnotationResponse
+	if err := c.do(ctx, http.MethodPost, "/api/annotations", body, &resp); err != nil {
+		return 0, err
+	}
+	return resp.ID, nil
+}
+
+// End turns an annotation into a region ending at end and appends text
+func (c *AnnotationClient) End(ctx context.Context, id int64, end time.Time, text string) error {
+	body := map[string]interface{}{
+		"timeEnd": end.UnixMilli(),
+	}
+	if text != "" {
+		body["text"] = text
+	}
+	return c.do(ctx, http.MethodPatch, fmt.Sprintf("/api/annotations/%d", id), body, nil)
+}
+
+// do sends a JSON request and decodes the response into out if non-nil
+func (c *AnnotationClient) do(ctx context.Context, method, path string, in, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.Username, c.Password)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("grafana request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("grafana returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode grafana response: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package incidents
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransitions(t *testing.T) {
+	open := map[string]bool{"loki": true, "tempo": true}
+	states := map[string]bool{"prometheus": false, "grafana": true, "loki": true}
+
+	started, recovered := Transitions(open, states, nil)
+	assert.Equal(t, []string{"prometheus"}, started)
+	assert.Equal(t, []string{"loki", "tempo"}, recovered)
+
+	started, _ = Transitions(nil, states, []string{"grafana"})
+	assert.Empty(t, started)
+}
+
+func TestAnnotationClient(t *testing.T) {
+	var created map[string]interface{}
+	var patched map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		require.True(t, ok)
+		assert.Equal(t, "admin", user)
+		assert.Equal(t, "secret", pass)
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/annotations":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_, _ = w.Write([]byte(`{"id":42,"message":"Annotation added"}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/annotations/42":
+			_ = json.NewDecoder(r.Body).Decode(&patched)
+			_, _ = w.Write([]byte(`{"message":"Annotation patched"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewAnnotationClient(server.URL+"/", "admin", "secret")
+	start := time.UnixMilli(1700000000000)
+
+	id, err := client.Create(context.Background(), Annotation{
+		DashboardUID: "platform-overview",
+		Time:         start,
+		Text:         "prometheus degraded",
+		Tags:         Tags("prod", "prometheus", nil),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), id)
+	assert.Equal(t, "platform-overview", created["dashboardUID"])
+	assert.Equal(t, float64(1700000000000), created["time"])
+
+	require.NoError(t, client.End(context.Background(), 42, start.Add(time.Minute), ""))
+	assert.Equal(t, float64(1700000060000), patched["timeEnd"])
+
+	assert.Error(t, client.End(context.Background(), 7, start, ""))
+}
@@ -0,0 +1,492 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package ingestgateway builds the ingest gateway of a platform. The gateway
+// is an nginx terminating the OTLP, Prometheus remote write and Loki push
+// traffic of producers outside of the cluster: it authenticates them by API
+// key or client certificate, checks the signals their tenant may write and
+// forwards the writes to the platform's backends with the tenant in the
+// X-Scope-OrgID header. Loki and Tempo run with multi-tenancy while the
+// gateway is enabled, so each tenant writes its logs and traces to a store of
+// its own; Prometheus has no tenants. API keys are matched by their SHA-256,
+// computed by an njs script, so the configuration holds no key.
+package ingestgateway
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// DefaultImage is the unprivileged nginx image of the gateway
+	DefaultImage = "nginxinc/nginx-unprivileged:1.25-alpine"
+	// Port receives the writes of the producers
+	Port int32 = 8080
+	// HealthPort serves the unauthenticated health endpoint
+	HealthPort int32 = 8081
+	// ConfigKey is the key of the configuration in the Secret
+	ConfigKey = "nginx.conf"
+	// ScriptKey is the key of the njs script hashing the API keys in the
+	// Secret
+	ScriptKey = "apikey.js"
+	// TenantHeader carries the tenant to the backends
+	TenantHeader = "X-Scope-OrgID"
+	// DefaultMaxBodySize is the default largest accepted request body
+	DefaultMaxBodySize = "16m"
+
+	configPath   = "/etc/nginx"
+	njsModule    = "/usr/lib/nginx/modules/ngx_http_js_module.so"
+	tlsPath      = "/etc/ingest-gateway/tls"
+	clientCAPath = "/etc/ingest-gateway/client-ca"
+)
+
+// Authentication modes
+const (
+	ModeAPIKey = "APIKey"
+	ModeMTLS   = "MTLS"
+)
+
+// Signals
+const (
+	SignalMetrics = "metrics"
+	SignalLogs    = "logs"
+	SignalTraces  = "traces"
+)
+
+var (
+	// apiKeyPattern are the characters of a bearer token (RFC 6750)
+	apiKeyPattern = regexp.MustCompile(`^[A-Za-z0-9._~+/=-]{16,}$`)
+	// commonNamePattern are the characters accepted in a client common name
+	commonNamePattern = regexp.MustCompile(`^[A-Za-z0-9 ._@*:-]+$`)
+	// hashPattern is a hex SHA-256
+	hashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+)
+
+// script returns the hex SHA-256 of the bearer token of a request
+const script = `function hash(r) {
+    var authorization = r.headersIn.Authorization || '';
+    if (authorization.indexOf('Bearer ') !== 0) {
+        return '';
+    }
+    return require('crypto').createHash('sha256').update(authorization.slice(7)).digest('hex');
+}
+
+export default {hash: hash};
+`
+
+// Tenant is a producer writing through the gateway
+type Tenant struct {
+	Name string
+	// APIKeyHashes are the hex SHA-256 of the keys authenticating the
+	// tenant in the APIKey mode
+	APIKeyHashes []string
+	// CommonNames of the client certificates of the tenant in the MTLS mode
+	CommonNames []string
+	// Signals the tenant may write, all if empty
+	Signals []string
+}
+
+// Allows returns true if the tenant may write the signal
+func (t Tenant) Allows(signal string) bool {
+	if len(t.Signals) == 0 {
+		return true
+	}
+	for _, s := range t.Signals {
+		if s == signal {
+			return true
+		}
+	}
+	return false
+}
+
+// Upstreams are the base URLs of the backends, empty for a disabled backend
+type Upstreams struct {
+	Prometheus string
+	Loki       string
+	// Tempo is the base URL of the OTLP HTTP receiver of Tempo
+	Tempo string
+}
+
+// Gateway is an ingest gateway Deployment
+type Gateway struct {
+	Name      string
+	Namespace string
+	Image     string
+	Replicas  int32
+	Resources corev1.ResourceRequirements
+	Labels    map[string]string
+
+	Mode string
+	// TLSSecretName serves the gateway over TLS if set
+	TLSSecretName string
+	// ClientCASecretName verifies the client certificates in the MTLS mode
+	ClientCASecretName string
+	MaxBodySize        string
+
+	Tenants   []Tenant
+	Upstreams Upstreams
+}
+
+// Name returns the name of the gateway Deployment, Service and Secret of a
+// platform
+func Name(platform string) string {
+	return fmt.Sprintf("ingest-gateway-%s", platform)
+}
+
+// ValidAPIKey returns true if the key can be used as a bearer token
+func ValidAPIKey(key string) bool {
+	return apiKeyPattern.MatchString(key)
+}
+
+// ValidCommonName returns true if the common name can be matched
+func ValidCommonName(name string) bool {
+	return commonNamePattern.MatchString(name)
+}
+
+// CheckAPIKeyHashes returns an error if the same API key authenticates
+// several tenants, which the gateway could not tell apart
+func CheckAPIKeyHashes(tenants []Tenant) error {
+	owners := map[string]string{}
+	for _, t := range tenants {
+		for _, hash := range t.APIKeyHashes {
+			owner, ok := owners[hash]
+			if !ok {
+				owners[hash] = t.Name
+				continue
+			}
+			if owner != t.Name {
+				names := []string{owner, t.Name}
+				sort.Strings(names)
+				return fmt.Errorf("an API key authenticates both tenant %s and tenant %s", names[0], names[1])
+			}
+		}
+	}
+	return nil
+}
+
+// Route forwards the writes of a signal on a path to a backend
+type Route struct {
+	Path     string
+	Signal   string
+	Upstream string
+}
+
+// Routes returns the routes of the enabled backends: Prometheus remote write
+// and OTLP metrics, Loki push and OTLP logs, and OTLP traces
+func Routes(u Upstreams) []Route {
+	var routes []Route
+	if u.Prometheus != "" {
+		routes = append(routes,
+			Route{Path: "/api/v1/write", Signal: SignalMetrics, Upstream: u.Prometheus + "/api/v1/write"},
+			Route{Path: "/v1/metrics", Signal: SignalMetrics, Upstream: u.Prometheus + "/api/v1/otlp/v1/metrics"})
+	}
+	if u.Loki != "" {
+		routes = append(routes,
+			Route{Path: "/loki/api/v1/push", Signal: SignalLogs, Upstream: u.Loki + "/loki/api/v1/push"},
+			Route{Path: "/v1/logs", Signal: SignalLogs, Upstream: u.Loki + "/otlp/v1/logs"})
+	}
+	if u.Tempo != "" {
+		routes = append(routes, Route{Path: "/v1/traces", Signal: SignalTraces, Upstream: u.Tempo + "/v1/traces"})
+	}
+	return routes
+}
+
+// RenderConfig returns the nginx configuration of the gateway. Tenants are
+// matched by the hashes of their API keys, which are lowercase hex, or by
+// the common names of their certificates with case-sensitive regular
+// expressions, as nginx matches plain map strings ignoring the case.
+// A tenant may have the same key several times, for instance in an ApiKey
+// with other signals than its static key. CheckAPIKeyHashes rejects a key of
+// several tenants.
+func RenderConfig(g Gateway) string {
+	var b strings.Builder
+	w := func(format string, args ...interface{}) {
+		fmt.Fprintf(&b, format+"\n", args...)
+	}
+	maxBodySize := g.MaxBodySize
+	if maxBodySize == "" {
+		maxBodySize = DefaultMaxBodySize
+	}
+	tenants := append([]Tenant(nil), g.Tenants...)
+	sort.SliceStable(tenants, func(i, j int) bool { return tenants[i].Name < tenants[j].Name })
+	// owners are the tenants of the valid API key hashes
+	owners := map[string]string{}
+	for _, t := range tenants {
+		for _, hash := range t.APIKeyHashes {
+			if !hashPattern.MatchString(hash) {
+				continue
+			}
+			if _, ok := owners[hash]; !ok {
+				owners[hash] = t.Name
+			}
+		}
+	}
+
+	if g.Mode != ModeMTLS {
+		w("load_module %s;", njsModule)
+	}
+	w("worker_processes auto;")
+	w("pid /tmp/nginx.pid;")
+	w("error_log /dev/stderr warn;")
+	w("")
+	w("events {")
+	w("  worker_connections 4096;")
+	w("}")
+	w("")
+	w("http {")
+	w("  access_log /dev/stdout;")
+	w("  client_body_temp_path /tmp/client_body;")
+	w("  proxy_temp_path /tmp/proxy;")
+	w("  fastcgi_temp_path /tmp/fastcgi;")
+	w("  uwsgi_temp_path /tmp/uwsgi;")
+	w("  scgi_temp_path /tmp/scgi;")
+	w("  client_max_body_size %s;", maxBodySize)
+	w("  server_tokens off;")
+	w("")
+
+	// Authenticate the producer
+	if g.Mode == ModeMTLS {
+		w("  map $ssl_client_s_dn $tenant {")
+		w("    default \"\";")
+		for _, t := range tenants {
+			for _, cn := range t.CommonNames {
+				if ValidCommonName(cn) {
+					w("    \"~(^|,)CN=%s(,|$)\" \"%s\";", regexp.QuoteMeta(cn), t.Name)
+				}
+			}
+		}
+		w("  }")
+	} else {
+		w("  js_path %s;", configPath)
+		w("  js_import %s;", ScriptKey)
+		w("  js_set $api_key_hash apikey.hash;")
+		w("")
+		w("  map $api_key_hash $tenant {")
+		w("    default \"\";")
+		for _, hash := range sortedKeys(owners) {
+			w("    %s \"%s\";", hash, owners[hash])
+		}
+		w("  }")
+	}
+
+	// Authorize the signals of the tenant, or of the key in the APIKey mode
+	for _, signal := range []string{SignalMetrics, SignalLogs, SignalTraces} {
+		w("")
+		if g.Mode == ModeMTLS {
+			w("  map $tenant $allow_%s {", signal)
+			w("    default 0;")
+			for _, t := range tenants {
+				if t.Allows(signal) {
+					w("    \"~^%s$\" 1;", regexp.QuoteMeta(t.Name))
+				}
+			}
+		} else {
+			w("  map $api_key_hash $allow_%s {", signal)
+			w("    default 0;")
+			allowed := map[string]bool{}
+			for _, t := range tenants {
+				for _, hash := range t.APIKeyHashes {
+					if owners[hash] == t.Name && t.Allows(signal) && !allowed[hash] {
+						allowed[hash] = true
+						w("    %s 1;", hash)
+					}
+				}
+			}
+		}
+		w("  }")
+	}
+
+	w("")
+	w("  server {")
+	if g.TLSSecretName != "" {
+		w("    listen %d ssl;", Port)
+		w("    ssl_certificate %s/tls.crt;", tlsPath)
+		w("    ssl_certificate_key %s/tls.key;", tlsPath)
+		w("    ssl_protocols TLSv1.2 TLSv1.3;")
+		if g.Mode == ModeMTLS {
+			w("    ssl_client_certificate %s/ca.crt;", clientCAPath)
+			w("    ssl_verify_client on;")
+		}
+	} else {
+		w("    listen %d;", Port)
+	}
+	for _, route := range Routes(g.Upstreams) {
+		w("")
+		w("    location = %s {", route.Path)
+		w("      if ($tenant = \"\") {")
+		w("        return 401;")
+		w("      }")
+		w("      if ($allow_%s = 0) {", route.Signal)
+		w("        return 403;")
+		w("      }")
+		w("      proxy_set_header Authorization \"\";")
+		w("      proxy_set_header %s $tenant;", TenantHeader)
+		w("      proxy_http_version 1.1;")
+		w("      proxy_pass %s;", route.Upstream)
+		w("    }")
+	}
+	w("")
+	w("    location / {")
+	w("      return 404;")
+	w("    }")
+	w("  }")
+	w("")
+	w("  server {")
+	w("    listen %d;", HealthPort)
+	w("    location = /healthz {")
+	w("      access_log off;")
+	w("      return 200 \"ok\";")
+	w("    }")
+	w("  }")
+	w("}")
+	return b.String()
+}
+
+// sortedKeys returns the keys of the map in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// BuildSecret returns the Secret holding the configuration of the gateway.
+// The configuration only holds the hashes of the API keys, but a weak key
+// could be recovered from its hash, so it is not a ConfigMap.
+func BuildSecret(g Gateway) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      g.Name,
+			Namespace: g.Namespace,
+			Labels:    g.Labels,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			ConfigKey: []byte(RenderConfig(g)),
+			ScriptKey: []byte(script),
+		},
+	}
+}
+
+// BuildDeployment returns the Deployment of the gateway
+func BuildDeployment(g Gateway) *appsv1.Deployment {
+	replicas := g.Replicas
+	image := g.Image
+	if image == "" {
+		image = DefaultImage
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: g.Name},
+			},
+		},
+		{
+			Name:         "tmp",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		},
+	}
+	mounts := []corev1.VolumeMount{
+		{Name: "config", MountPath: configPath, ReadOnly: true},
+		{Name: "tmp", MountPath: "/tmp"},
+	}
+	if g.TLSSecretName != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "tls",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: g.TLSSecretName},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{Name: "tls", MountPath: tlsPath, ReadOnly: true})
+	}
+	if g.Mode == ModeMTLS && g.ClientCASecretName != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "client-ca",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: g.ClientCASecretName},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{Name: "client-ca", MountPath: clientCAPath, ReadOnly: true})
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      g.Name,
+			Namespace: g.Namespace,
+			Labels:    g.Labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: g.Labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: g.Labels},
+				Spec: corev1.PodSpec{
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: &[]bool{true}[0],
+						RunAsUser:    &[]int64{101}[0],
+					},
+					Containers: []corev1.Container{{
+						Name:  "nginx",
+						Image: image,
+						Ports: []corev1.ContainerPort{
+							{Name: "ingest", ContainerPort: Port, Protocol: corev1.ProtocolTCP},
+							{Name: "health", ContainerPort: HealthPort, Protocol: corev1.ProtocolTCP},
+						},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("health")},
+							},
+						},
+						LivenessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("health")},
+							},
+							InitialDelaySeconds: 10,
+						},
+						Resources:    g.Resources,
+						VolumeMounts: mounts,
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: &[]bool{false}[0],
+							ReadOnlyRootFilesystem:   &[]bool{true}[0],
+						},
+					}},
+					Volumes: volumes,
+				},
+			},
+		},
+	}
+}
+
+// BuildService returns the Service of the gateway
+func BuildService(g Gateway) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      g.Name,
+			Namespace: g.Namespace,
+			Labels:    g.Labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: g.Labels,
+			Ports: []corev1.ServicePort{{
+				Name:       "ingest",
+				Port:       Port,
+				TargetPort: intstr.FromString("ingest"),
+				Protocol:   corev1.ProtocolTCP,
+			}},
+		},
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package ingestgateway
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	hashA = strings.Repeat("a", 64)
+	hashB = strings.Repeat("b", 64)
+)
+
+func gateway() Gateway {
+	return Gateway{
+		Name:      Name("prod"),
+		Namespace: "monitoring",
+		Replicas:  2,
+		Labels:    map[string]string{"app.kubernetes.io/name": "ingest-gateway"},
+		Mode:      ModeAPIKey,
+		Tenants: []Tenant{
+			{Name: "team-b", APIKeyHashes: []string{hashB}, Signals: []string{SignalLogs}},
+			{Name: "team-a", APIKeyHashes: []string{hashA, "short"}, CommonNames: []string{"ignored"}},
+		},
+		Upstreams: Upstreams{
+			Prometheus: "http://prometheus-prod.monitoring.svc.cluster.local:9090",
+			Loki:       "http://loki-prod.monitoring.svc.cluster.local:3100",
+		},
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	routes := Routes(Upstreams{Tempo: "http://prod-tempo.monitoring.svc.cluster.local:4318"})
+	assert.Equal(t, []Route{{Path: "/v1/traces", Signal: SignalTraces, Upstream: "http://prod-tempo.monitoring.svc.cluster.local:4318/v1/traces"}}, routes)
+	assert.Len(t, Routes(gateway().Upstreams), 4)
+}
+
+func TestRenderConfigAPIKey(t *testing.T) {
+	config := RenderConfig(gateway())
+
+	assert.True(t, strings.HasPrefix(config, "load_module /usr/lib/nginx/modules/ngx_http_js_module.so;\n"))
+	assert.Contains(t, config, "js_set $api_key_hash apikey.hash;")
+	assert.Contains(t, config, "map $api_key_hash $tenant {")
+	assert.Contains(t, config, hashA+` "team-a";`)
+	assert.Contains(t, config, hashB+` "team-b";`)
+	assert.NotContains(t, config, "short")
+	assert.NotContains(t, config, "ssl_verify_client")
+
+	// team-b only writes logs
+	assert.Contains(t, config, "map $api_key_hash $allow_logs {\n    default 0;\n    "+hashA+" 1;\n    "+hashB+" 1;\n  }")
+	assert.Contains(t, config, "map $api_key_hash $allow_metrics {\n    default 0;\n    "+hashA+" 1;\n  }")
+
+	assert.Contains(t, config, "location = /api/v1/write {")
+	assert.Contains(t, config, "proxy_pass http://loki-prod.monitoring.svc.cluster.local:3100/otlp/v1/logs;")
+	assert.Contains(t, config, "proxy_set_header X-Scope-OrgID $tenant;")
+	assert.NotContains(t, config, "/v1/traces")
+	assert.Contains(t, config, "client_max_body_size 16m;")
+}
+
+func TestCheckAPIKeyHashes(t *testing.T) {
+	g := gateway()
+	require.NoError(t, CheckAPIKeyHashes(g.Tenants))
+
+	// An ApiKey of team-b for traces with the static key of team-b
+	g.Tenants = append(g.Tenants, Tenant{Name: "team-b", APIKeyHashes: []string{hashB}, Signals: []string{SignalTraces}})
+	require.NoError(t, CheckAPIKeyHashes(g.Tenants))
+	config := RenderConfig(g)
+	assert.Equal(t, 1, strings.Count(config, hashB+` "`))
+	assert.Contains(t, config, "map $api_key_hash $allow_traces {\n    default 0;\n    "+hashA+" 1;\n    "+hashB+" 1;\n  }")
+
+	// An ApiKey of team-a with a key of team-b
+	g.Tenants = append(g.Tenants, Tenant{Name: "team-a", APIKeyHashes: []string{hashB}, Signals: []string{SignalTraces}})
+	err := CheckAPIKeyHashes(g.Tenants)
+	require.Error(t, err)
+	assert.Equal(t, "an API key authenticates both tenant team-a and tenant team-b", err.Error())
+}
+
+func TestRenderConfigMTLS(t *testing.T) {
+	g := gateway()
+	g.Mode = ModeMTLS
+	g.TLSSecretName = "gateway-tls"
+	g.ClientCASecretName = "gateway-client-ca"
+	g.Tenants = []Tenant{{Name: "edge", CommonNames: []string{"collector.edge.example.com", "bad\"cn"}}}
+
+	config := RenderConfig(g)
+	assert.Contains(t, config, "map $ssl_client_s_dn $tenant {")
+	assert.Contains(t, config, `"~(^|,)CN=collector\.edge\.example\.com(,|$)" "edge";`)
+	assert.NotContains(t, config, "bad")
+	assert.NotContains(t, config, "js_")
+	assert.Contains(t, config, "listen 8080 ssl;")
+	assert.Contains(t, config, "ssl_client_certificate /etc/ingest-gateway/client-ca/ca.crt;")
+	assert.Contains(t, config, "ssl_verify_client on;")
+}
+
+func TestBuildDeploymentAndService(t *testing.T) {
+	g := gateway()
+	g.Mode = ModeMTLS
+	g.TLSSecretName = "gateway-tls"
+	g.ClientCASecretName = "gateway-client-ca"
+
+	deployment := BuildDeployment(g)
+	assert.Equal(t, "ingest-gateway-prod", deployment.Name)
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+	require.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, DefaultImage, container.Image)
+	assert.Len(t, container.VolumeMounts, 4)
+	assert.Len(t, deployment.Spec.Template.Spec.Volumes, 4)
+	assert.Equal(t, "ingest-gateway-prod", deployment.Spec.Template.Spec.Volumes[0].Secret.SecretName)
+
+	secret := BuildSecret(g)
+	assert.Contains(t, string(secret.Data[ConfigKey]), "ssl_verify_client on;")
+	assert.Contains(t, string(secret.Data[ScriptKey]), "createHash('sha256')")
+
+	service := BuildService(g)
+	assert.Equal(t, deployment.Spec.Template.Labels, service.Spec.Selector)
+	require.Len(t, service.Spec.Ports, 1)
+	assert.Equal(t, Port, service.Spec.Ports[0].Port)
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package jsonnet renders Grafana dashboards defined in Jsonnet, such as
+// Grafonnet dashboards and the dashboards of monitoring mixins, with an
+// embedded evaluator. Sources and libraries are read from memory, so they can
+// come from ConfigMaps or Git without a pre-rendering step.
+package jsonnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	gojsonnet "github.com/google/go-jsonnet"
+)
+
+const (
+	// mixinDashboardsField holds the dashboards of a monitoring mixin
+	mixinDashboardsField = "grafanaDashboards"
+
+	// maxStack bounds the evaluation depth of a source
+	maxStack = 500
+)
+
+// IsSource returns true if a file is a Jsonnet dashboard source. Libraries
+// (*.libsonnet) are only imported.
+func IsSource(name string) bool {
+	return strings.HasSuffix(name, ".jsonnet")
+}
+
+// IsFile returns true if a file may be imported by a Jsonnet source
+func IsFile(name string) bool {
+	return strings.HasSuffix(name, ".jsonnet") || strings.HasSuffix(name, ".libsonnet") || strings.HasSuffix(name, ".json")
+}
+
+// Renderer renders Jsonnet dashboard sources
+type Renderer struct {
+	// Files are the sources and libraries, keyed by slash-separated path
+	Files map[string][]byte
+	// JPath are the directories imports are resolved against after the
+	// directory of the importing file, e.g. vendor for jsonnet-bundler
+	JPath []string
+	// ExtVars are the external variables available through std.extVar
+	ExtVars map[string]string
+}
+
+// Render evaluates a source and returns its dashboards keyed by file name.
+// A source may evaluate to a dashboard, named after the source, to an object
+// of dashboards keyed by file name, or to a mixin, whose (hidden)
+// grafanaDashboards are rendered.
+func (r *Renderer) Render(source string) (map[string][]byte, error) {
+	if _, ok := r.Files[source]; !ok {
+		return nil, fmt.Errorf("%s not found", source)
+	}
+
+	// Mixins hide their dashboards, so they are selected before manifesting
+	quoted, _ := json.Marshal("/" + source)
+	wrapper := fmt.Sprintf(`local source = import %s;
+if std.isObject(source) && std.objectHasAll(source, %q) then source.%s else source`,
+		quoted, mixinDashboardsField, mixinDashboardsField)
+	output, err := r.vm().EvaluateAnonymousSnippet(source, wrapper)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate %s: %w", source, err)
+	}
+
+	var result interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		return nil, fmt.Errorf("%s did not evaluate to JSON: %w", source, err)
+	}
+	object, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s did not evaluate to an object", source)
+	}
+
+	dashboards := object
+	if isDashboard(object) {
+		name := strings.TrimSuffix(path.Base(source), ".jsonnet") + ".json"
+		dashboards = map[string]interface{}{name: object}
+	}
+
+	rendered := make(map[string][]byte, len(dashboards))
+	for name, value := range dashboards {
+		dashboard, ok := value.(map[string]interface{})
+		if !ok || !isDashboard(dashboard) {
+			return nil, fmt.Errorf("%s: %s is not a dashboard", source, name)
+		}
+		data, err := json.Marshal(dashboard)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to encode %s: %w", source, name, err)
+		}
+		if !strings.HasSuffix(name, ".json") {
+			name += ".json"
+		}
+		rendered[name] = data
+	}
+	if len(rendered) == 0 {
+		return nil, fmt.Errorf("%s rendered no dashboards", source)
+	}
+	return rendered, nil
+}
+
+// Field evaluates a source and returns the JSON of one of its fields, which
+// may be hidden, or nil if the source has no such field. Mixins hide their
+// prometheusRules and prometheusAlerts.
+func (r *Renderer) Field(source, field string) ([]byte, error) {
+	if _, ok := r.Files[source]; !ok {
+		return nil, fmt.Errorf("%s not found", source)
+	}
+
+	quoted, _ := json.Marshal("/" + source)
+	wrapper := fmt.Sprintf(`local source = import %s;
+if std.isObject(source) && std.objectHasAll(source, %q) then source[%q] else null`,
+		quoted, field, field)
+	output, err := r.vm().EvaluateAnonymousSnippet(source, wrapper)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate %s: %w", source, err)
+	}
+	if strings.TrimSpace(output) == "null" {
+		return nil, nil
+	}
+	return []byte(output), nil
+}
+
+// RenderAll renders every source in the files below dir and returns the
+// dashboards keyed by the source's directory joined with their file name.
+// Sources in the library path are libraries and are not rendered.
+func (r *Renderer) RenderAll(dir string) (map[string][]byte, error) {
+	dir = strings.Trim(dir, "/")
+	var sources []string
+	for name := range r.Files {
+		if IsSource(name) && (dir == "" || strings.HasPrefix(name, dir+"/")) && !r.inJPath(name) {
+			sources = append(sources, name)
+		}
+	}
+	sort.Strings(sources)
+
+	dashboards := make(map[string][]byte)
+	origins := make(map[string]string)
+	for _, source := range sources {
+		rendered, err := r.Render(source)
+		if err != nil {
+			return nil, err
+		}
+		for name, data := range rendered {
+			key := path.Join(path.Dir(source), name)
+			if other, ok := origins[key]; ok {
+				return nil, fmt.Errorf("%s and %s both render %s", other, source, name)
+			}
+			origins[key] = source
+			dashboards[key] = data
+		}
+	}
+	return dashboards, nil
+}
+
+// inJPath returns true if a file is in the library path
+func (r *Renderer) inJPath(name string) bool {
+	for _, dir := range r.JPath {
+		if dir = strings.Trim(dir, "/"); strings.HasPrefix(name, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// vm returns an evaluator importing from the renderer's files
+func (r *Renderer) vm() *gojsonnet.VM {
+	vm := gojsonnet.MakeVM()
+	vm.MaxStack = maxStack
+	vm.Importer(&importer{files: r.Files, jpath: r.JPath, cache: map[string]gojsonnet.Contents{}})
+	for name, value := range r.ExtVars {
+		vm.ExtVar(name, value)
+	}
+	return vm
+}
+
+// isDashboard returns true if an object looks like a Grafana dashboard
+func isDashboard(object map[string]interface{}) bool {
+	_, panels := object["panels"]
+	_, rows := object["rows"]
+	return panels || rows
+}
+
+// importer resolves imports against the directory of the importing file,
+// then against the library path
+type importer struct {
+	files map[string][]byte
+	jpath []string
+	// cache returns the same contents for a file on every import, as
+	// required by the evaluator
+	cache map[string]gojsonnet.Contents
+}
+
+// Import implements gojsonnet.Importer
+func (i *importer) Import(importedFrom, importedPath string) (gojsonnet.Contents, string, error) {
+	candidates := []string{path.Join(path.Dir(importedFrom), importedPath)}
+	if path.IsAbs(importedPath) {
+		candidates = []string{strings.TrimPrefix(path.Clean(importedPath), "/")}
+	} else {
+		for _, dir := range i.jpath {
+			candidates = append(candidates, path.Join(dir, importedPath))
+		}
+	}
+
+	for _, candidate := range candidates {
+		if contents, ok := i.cache[candidate]; ok {
+			return contents, candidate, nil
+		}
+		if data, ok := i.files[candidate]; ok {
+			contents := gojsonnet.MakeContents(string(data))
+			i.cache[candidate] = contents
+			return contents, candidate, nil
+		}
+	}
+	return gojsonnet.Contents{}, "", fmt.Errorf("couldn't find import %q (tried %s)", importedPath, strings.Join(candidates, ", "))
+}
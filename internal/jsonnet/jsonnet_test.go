@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package jsonnet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderDashboard(t *testing.T) {
+	r := &Renderer{
+		Files: map[string][]byte{
+			"dashboards/api.jsonnet":          []byte(`local lib = import 'lib/panels.libsonnet'; { title: 'API ' + std.extVar('cluster'), panels: [lib.graph('rps')] }`),
+			"dashboards/lib/panels.libsonnet": []byte(`{ graph(title):: { type: 'graph', title: title } }`),
+		},
+		ExtVars: map[string]string{"cluster": "prod"},
+	}
+
+	dashboards, err := r.Render("dashboards/api.jsonnet")
+	require.NoError(t, err)
+	require.Contains(t, dashboards, "api.json")
+
+	var dashboard map[string]interface{}
+	require.NoError(t, json.Unmarshal(dashboards["api.json"], &dashboard))
+	assert.Equal(t, "API prod", dashboard["title"])
+	assert.Len(t, dashboard["panels"], 1)
+}
+
+func TestRenderMixin(t *testing.T) {
+	r := &Renderer{
+		Files: map[string][]byte{
+			"mixin.jsonnet": []byte(`(import 'node-mixin/mixin.libsonnet') + { _config+:: { selector: 'job="node"' } }`),
+			"vendor/node-mixin/mixin.libsonnet": []byte(`{
+				_config:: { selector: '' },
+				grafanaDashboards+:: {
+					'nodes.json': { title: 'Nodes', panels: [{ expr: 'up{%s}' % $._config.selector }] },
+					'disks': { title: 'Disks', rows: [] },
+				},
+				prometheusAlerts:: {},
+			}`),
+		},
+		JPath: []string{"vendor"},
+	}
+
+	dashboards, err := r.Render("mixin.jsonnet")
+	require.NoError(t, err)
+	assert.Len(t, dashboards, 2)
+	assert.Contains(t, string(dashboards["nodes.json"]), `up{job=\"node\"}`)
+	assert.Contains(t, dashboards, "disks.json")
+}
+
+func TestField(t *testing.T) {
+	r := &Renderer{Files: map[string][]byte{
+		"mixin.libsonnet": []byte(`{ prometheusAlerts+:: { groups: [{ name: 'node', rules: [] }] } }`),
+	}}
+
+	alerts, err := r.Field("mixin.libsonnet", "prometheusAlerts")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"groups": [{"name": "node", "rules": []}]}`, string(alerts))
+
+	rules, err := r.Field("mixin.libsonnet", "prometheusRules")
+	require.NoError(t, err)
+	assert.Nil(t, rules)
+}
+
+func TestRenderErrors(t *testing.T) {
+	r := &Renderer{Files: map[string][]byte{
+		"missing.jsonnet": []byte(`import 'missing.libsonnet'`),
+		"array.jsonnet":   []byte(`[]`),
+		"other.jsonnet":   []byte(`{ 'a.json': { title: 'not a dashboard' } }`),
+		"syntax.jsonnet":  []byte(`{`),
+	}}
+
+	_, err := r.Render("missing.jsonnet")
+	assert.ErrorContains(t, err, "couldn't find import")
+	_, err = r.Render("array.jsonnet")
+	assert.ErrorContains(t, err, "did not evaluate to an object")
+	_, err = r.Render("other.jsonnet")
+	assert.ErrorContains(t, err, "a.json is not a dashboard")
+	_, err = r.Render("syntax.jsonnet")
+	assert.ErrorContains(t, err, "failed to evaluate")
+	_, err = r.Render("absent.jsonnet")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestRenderAll(t *testing.T) {
+	r := &Renderer{Files: map[string][]byte{
+		"dashboards/a.jsonnet":      []byte(`{ panels: [] }`),
+		"dashboards/team/b.jsonnet": []byte(`{ 'b1.json': { panels: [] }, 'b2.json': { panels: [] } }`),
+		"other/c.jsonnet":           []byte(`{ panels: [] }`),
+		"vendor/lib/d.jsonnet":      []byte(`{ panels: [] }`),
+	}, JPath: []string{"vendor"}}
+
+	dashboards, err := r.RenderAll("/dashboards/")
+	require.NoError(t, err)
+	assert.Len(t, dashboards, 3)
+	assert.Contains(t, dashboards, "dashboards/a.json")
+	assert.Contains(t, dashboards, "dashboards/team/b1.json")
+
+	dashboards, err = r.RenderAll("")
+	require.NoError(t, err)
+	assert.Len(t, dashboards, 4)
+	assert.NotContains(t, dashboards, "vendor/lib/d.json")
+
+	r.Files["dashboards/a2.jsonnet"] = []byte(`{ 'a.json': { panels: [] } }`)
+	_, err = r.RenderAll("dashboards")
+	assert.ErrorContains(t, err, "both render a.json")
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package labeltransition keeps remote-written series continuous when the
+// external labels of Prometheus change. Remote storage identifies a series by
+// its labels, so a new external label value starts new series and leaves
+// the queries, alerts and recording rules selecting the previous value with a
+// gap. During a transition window every remote write target receives the
+// series twice: with the new external labels, and rewritten back to the
+// previous ones.
+package labeltransition
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RelabelConfig is a write_relabel_configs entry of a remote write
+type RelabelConfig struct {
+	SourceLabels []string
+	Regex        string
+	TargetLabel  string
+	Replacement  string
+}
+
+// Changed returns true if the external labels differ
+func Changed(previous, current map[string]string) bool {
+	if len(previous) != len(current) {
+		return true
+	}
+	for k, v := range previous {
+		if cv, ok := current[k]; !ok || cv != v {
+			return true
+		}
+	}
+	return false
+}
+
+// RestorePrevious returns the write relabel configs rewriting series sent
+// with the current external labels to the previous ones. Prometheus attaches
+// external labels before the write relabeling, so the rewritten series are
+// those written before the change. A label is only rewritten while it holds
+// the current external value: series carrying a label of the same name
+// themselves kept it before the change, and keep it now. A removed label is
+// restored and an added one is dropped, by replacing it with an empty value.
+func RestorePrevious(previous, current map[string]string) []RelabelConfig {
+	names := make(map[string]bool, len(previous)+len(current))
+	for k := range previous {
+		names[k] = true
+	}
+	for k := range current {
+		names[k] = true
+	}
+	keys := make([]string, 0, len(names))
+	for k := range names {
+		if previous[k] != current[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	configs := make([]RelabelConfig, 0, len(keys))
+	for _, k := range keys {
+		configs = append(configs, RelabelConfig{
+			SourceLabels: []string{k},
+			Regex:        regexp.QuoteMeta(current[k]),
+			TargetLabel:  k,
+			Replacement:  strings.ReplaceAll(previous[k], "$", "$$"),
+		})
+	}
+	return configs
+}
+
+// Describe summarizes the changed labels for events and annotations,
+// e.g. "cluster: prod-eu → prod-eu-1, region: (unset) → eu-west-1"
+func Describe(previous, current map[string]string) string {
+	var changes []string
+	for _, config := range RestorePrevious(previous, current) {
+		k := config.TargetLabel
+		changes = append(changes, fmt.Sprintf("%s: %s → %s", k, valueOrUnset(previous, k), valueOrUnset(current, k)))
+	}
+	return strings.Join(changes, ", ")
+}
+
+// valueOrUnset returns the value of a label, or "(unset)"
+func valueOrUnset(labels map[string]string, name string) string {
+	if v, ok := labels[name]; ok {
+		return v
+	}
+	return "(unset)"
+}
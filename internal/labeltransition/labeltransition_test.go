@@ -0,0 +1,42 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package labeltransition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChanged(t *testing.T) {
+	assert.False(t, Changed(nil, map[string]string{}))
+	assert.False(t, Changed(map[string]string{"cluster": "a"}, map[string]string{"cluster": "a"}))
+	assert.True(t, Changed(map[string]string{"cluster": "a"}, map[string]string{"cluster": "b"}))
+	assert.True(t, Changed(map[string]string{"cluster": "a"}, map[string]string{"region": "a"}))
+	assert.True(t, Changed(map[string]string{"cluster": "a"}, map[string]string{"cluster": "a", "region": "eu"}))
+}
+
+func TestRestorePrevious(t *testing.T) {
+	previous := map[string]string{"cluster": "prod-eu", "env": "prod", "team": "core$1"}
+	current := map[string]string{"cluster": "prod-eu-1", "env": "prod", "region": "eu-west-1"}
+
+	assert.Equal(t, []RelabelConfig{
+		{SourceLabels: []string{"cluster"}, Regex: `prod-eu-1`, TargetLabel: "cluster", Replacement: "prod-eu"},
+		{SourceLabels: []string{"region"}, Regex: `eu-west-1`, TargetLabel: "region", Replacement: ""},
+		{SourceLabels: []string{"team"}, Regex: ``, TargetLabel: "team", Replacement: "core$$1"},
+	}, RestorePrevious(previous, current))
+
+	assert.Equal(t, `a\.b`, RestorePrevious(map[string]string{"zone": "x"}, map[string]string{"zone": "a.b"})[0].Regex)
+	assert.Empty(t, RestorePrevious(current, current))
+}
+
+func TestDescribe(t *testing.T) {
+	assert.Equal(t, "cluster: prod-eu → prod-eu-1, region: (unset) → eu-west-1, team: core → (unset)", Describe(
+		map[string]string{"cluster": "prod-eu", "team": "core"},
+		map[string]string{"cluster": "prod-eu-1", "region": "eu-west-1"},
+	))
+}
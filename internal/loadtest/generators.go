@@ -0,0 +1,308 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package loadtest builds the load generators of a PlatformLoadTest and
+// measures how the platform ingested their load. Metrics are exposed by
+// avalanche and scraped by Prometheus, logs are pushed to Loki by
+// loki-canary, which reads them back, and traces are sent to Tempo by
+// telemetrygen. The results are read from the platform's Prometheus.
+package loadtest
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+const (
+	// DefaultAvalancheImage generates the metrics
+	DefaultAvalancheImage = "quay.io/prometheuscommunity/avalanche:v0.4.0"
+	// DefaultCanaryImage generates the logs
+	DefaultCanaryImage = "grafana/loki-canary:2.9.0"
+	// DefaultTelemetrygenImage generates the traces
+	DefaultTelemetrygenImage = "ghcr.io/open-telemetry/opentelemetry-collector-contrib/telemetrygen:v0.96.0"
+
+	// AvalanchePort exposes the generated metrics
+	AvalanchePort int32 = 9001
+	// CanaryPort exposes the metrics of loki-canary
+	CanaryPort int32 = 3500
+
+	// LoadTestLabel names the load test of a generator. Prometheus maps it
+	// to the observability_io_load_test label of the scraped series.
+	LoadTestLabel = "observability.io/load-test"
+
+	// seriesPerMetric is the number of series of each avalanche metric
+	seriesPerMetric = 10
+	// maxSeriesPerReplica bounds the series of an avalanche replica
+	maxSeriesPerReplica = 100000
+	// maxLinesPerReplica bounds the rate of a loki-canary replica
+	maxLinesPerReplica = 500
+	// maxTracesPerReplica bounds the rate of a telemetrygen replica
+	maxTracesPerReplica = 1000
+)
+
+// Targets are the endpoints of the platform the load is sent to
+type Targets struct {
+	// LokiAddress is the host:port of Loki
+	LokiAddress string
+	// TempoAddress is the host:port of Tempo's OTLP gRPC receiver
+	TempoAddress string
+	// TenantID is the tenant logs and traces are written as, if any
+	TenantID string
+}
+
+// MetricsPlan splits the series over avalanche replicas. The generated
+// series are rounded up to whole metrics.
+type MetricsPlan struct {
+	Replicas    int32
+	MetricCount int32
+}
+
+// Series returns the number of series generated
+func (p MetricsPlan) Series() int32 {
+	return p.Replicas * p.MetricCount * seriesPerMetric
+}
+
+// PlanMetrics returns the replicas generating the series
+func PlanMetrics(spec *observabilityv1beta1.LoadTestMetricsSpec) MetricsPlan {
+	replicas := ceilDiv(spec.Series, maxSeriesPerReplica)
+	return MetricsPlan{
+		Replicas:    replicas,
+		MetricCount: ceilDiv(ceilDiv(spec.Series, replicas), seriesPerMetric),
+	}
+}
+
+// LogsPlan splits the lines over loki-canary replicas, each writing a line
+// per interval
+type LogsPlan struct {
+	Replicas int32
+	Interval time.Duration
+}
+
+// PlanLogs returns the replicas writing the lines
+func PlanLogs(spec *observabilityv1beta1.LoadTestLogsSpec) LogsPlan {
+	replicas := ceilDiv(spec.LinesPerSecond, maxLinesPerReplica)
+	return LogsPlan{
+		Replicas: replicas,
+		Interval: time.Duration(replicas) * time.Second / time.Duration(spec.LinesPerSecond),
+	}
+}
+
+// TracesPlan splits the traces over telemetrygen replicas
+type TracesPlan struct {
+	Replicas        int32
+	TracesPerSecond int32
+	ChildSpans      int32
+}
+
+// SpansPerSecond returns the rate of spans sent
+func (p TracesPlan) SpansPerSecond() int32 {
+	return p.Replicas * p.TracesPerSecond * (1 + p.ChildSpans)
+}
+
+// PlanTraces returns the replicas sending the spans. telemetrygen sends
+// whole traces per second, so the rate is rounded to whole traces.
+func PlanTraces(spec *observabilityv1beta1.LoadTestTracesSpec) TracesPlan {
+	traces := int32(math.Round(float64(spec.SpansPerSecond) / float64(1+spec.ChildSpans)))
+	if traces < 1 {
+		traces = 1
+	}
+	replicas := ceilDiv(traces, maxTracesPerReplica)
+	return TracesPlan{
+		Replicas:        replicas,
+		TracesPerSecond: ceilDiv(traces, replicas),
+		ChildSpans:      spec.ChildSpans,
+	}
+}
+
+// GeneratorName returns the name of the Deployment generating a signal
+func GeneratorName(test *observabilityv1beta1.PlatformLoadTest, signal string) string {
+	return fmt.Sprintf("%s-%s", test.Name, signal)
+}
+
+// Labels returns the labels of the generators of a load test
+func Labels(test *observabilityv1beta1.PlatformLoadTest) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/managed-by": "gunj-operator",
+		"observability.io/platform":    test.Spec.Platform,
+		LoadTestLabel:                  test.Name,
+	}
+}
+
+// Generators returns the Deployments generating the load of a test
+func Generators(test *observabilityv1beta1.PlatformLoadTest, targets Targets) []*appsv1.Deployment {
+	var generators []*appsv1.Deployment
+	if spec := test.Spec.Metrics; spec != nil {
+		generators = append(generators, metricsGenerator(test, spec))
+	}
+	if spec := test.Spec.Logs; spec != nil {
+		generators = append(generators, logsGenerator(test, spec, targets))
+	}
+	if spec := test.Spec.Traces; spec != nil {
+		generators = append(generators, tracesGenerator(test, spec, targets))
+	}
+	return generators
+}
+
+// metricsGenerator exposes the series with avalanche, for Prometheus to
+// scrape through its pod annotations
+func metricsGenerator(test *observabilityv1beta1.PlatformLoadTest, spec *observabilityv1beta1.LoadTestMetricsSpec) *appsv1.Deployment {
+	plan := PlanMetrics(spec)
+
+	// Without churn, the series outlive the test
+	churn := int64((test.GetDuration() + time.Hour).Seconds())
+	if spec.ChurnInterval != nil && spec.ChurnInterval.Duration > 0 {
+		churn = int64(math.Max(1, spec.ChurnInterval.Seconds()))
+	}
+
+	container := generatorContainer("avalanche", image(spec.Image, DefaultAvalancheImage), []string{
+		fmt.Sprintf("--metric-count=%d", plan.MetricCount),
+		fmt.Sprintf("--series-count=%d", seriesPerMetric),
+		fmt.Sprintf("--series-interval=%d", churn),
+		fmt.Sprintf("--metric-interval=%d", churn),
+		fmt.Sprintf("--port=%d", AvalanchePort),
+	})
+	container.Ports = []corev1.ContainerPort{{Name: "metrics", ContainerPort: AvalanchePort, Protocol: corev1.ProtocolTCP}}
+	return generator(test, observabilityv1beta1.SignalMetrics, plan.Replicas, container, AvalanchePort)
+}
+
+// logsGenerator pushes the lines to Loki with loki-canary, which tails them
+// back and exposes how many were missing and how late they were
+func logsGenerator(test *observabilityv1beta1.PlatformLoadTest, spec *observabilityv1beta1.LoadTestLogsSpec, targets Targets) *appsv1.Deployment {
+	plan := PlanLogs(spec)
+	size := spec.LineSize
+	if size == 0 {
+		size = observabilityv1beta1.DefaultLoadTestLineSize
+	}
+
+	args := []string{
+		"-addr=" + targets.LokiAddress,
+		"-push=true",
+		"-interval=" + plan.Interval.String(),
+		fmt.Sprintf("-size=%d", size),
+		fmt.Sprintf("-port=%d", CanaryPort),
+		"-labelname=pod",
+		"-labelvalue=$(POD_NAME)",
+		"-streamname=stream",
+		"-streamvalue=stdout",
+	}
+	if targets.TenantID != "" {
+		args = append(args, "-tenant-id="+targets.TenantID)
+	}
+
+	container := generatorContainer("loki-canary", image(spec.Image, DefaultCanaryImage), args)
+	container.Env = []corev1.EnvVar{{
+		Name:      "POD_NAME",
+		ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+	}}
+	container.Ports = []corev1.ContainerPort{{Name: "metrics", ContainerPort: CanaryPort, Protocol: corev1.ProtocolTCP}}
+	return generator(test, observabilityv1beta1.SignalLogs, plan.Replicas, container, CanaryPort)
+}
+
+// tracesGenerator sends the traces to Tempo's OTLP receiver with
+// telemetrygen
+func tracesGenerator(test *observabilityv1beta1.PlatformLoadTest, spec *observabilityv1beta1.LoadTestTracesSpec, targets Targets) *appsv1.Deployment {
+	plan := PlanTraces(spec)
+
+	// telemetrygen stops after its duration; the generator is removed
+	// before, when the results are collected
+	args := []string{
+		"traces",
+		"--otlp-endpoint=" + targets.TempoAddress,
+		"--otlp-insecure",
+		"--workers=1",
+		fmt.Sprintf("--rate=%d", plan.TracesPerSecond),
+		fmt.Sprintf("--child-spans=%d", plan.ChildSpans),
+		"--duration=" + (test.GetDuration() + time.Hour).String(),
+		"--service=" + test.Name,
+	}
+	if targets.TenantID != "" {
+		args = append(args, "--otlp-header=X-Scope-OrgID="+strconv.Quote(targets.TenantID))
+	}
+
+	container := generatorContainer("telemetrygen", image(spec.Image, DefaultTelemetrygenImage), args)
+	return generator(test, observabilityv1beta1.SignalTraces, plan.Replicas, container, 0)
+}
+
+// generatorContainer returns a container of a generator, with modest
+// requests so the generators fit next to the platform
+func generatorContainer(name, image string, args []string) corev1.Container {
+	return corev1.Container{
+		Name:  name,
+		Image: image,
+		Args:  args,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: &[]bool{false}[0],
+			ReadOnlyRootFilesystem:   &[]bool{true}[0],
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		},
+	}
+}
+
+// generator returns the Deployment of a generator. Generators exposing
+// metrics are annotated for Prometheus to scrape them.
+func generator(test *observabilityv1beta1.PlatformLoadTest, signal string, replicas int32, container corev1.Container, metricsPort int32) *appsv1.Deployment {
+	labels := Labels(test)
+	selector := map[string]string{LoadTestLabel: test.Name, "observability.io/signal": signal}
+	podLabels := map[string]string{"observability.io/signal": signal}
+	for k, v := range labels {
+		podLabels[k] = v
+	}
+
+	var annotations map[string]string
+	if metricsPort != 0 {
+		annotations = map[string]string{
+			"prometheus.io/scrape": "true",
+			"prometheus.io/port":   strconv.Itoa(int(metricsPort)),
+			"prometheus.io/path":   "/metrics",
+		}
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GeneratorName(test, signal),
+			Namespace: test.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels, Annotations: annotations},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{container},
+				},
+			},
+		},
+	}
+}
+
+// image returns the image, or the default if empty
+func image(image, defaultImage string) string {
+	if image != "" {
+		return image
+	}
+	return defaultImage
+}
+
+// ceilDiv returns a / b rounded up
+func ceilDiv(a, b int32) int32 {
+	return (a + b - 1) / b
+}
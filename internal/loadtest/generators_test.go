@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package loadtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+func loadTest() *observabilityv1beta1.PlatformLoadTest {
+	return &observabilityv1beta1.PlatformLoadTest{
+		ObjectMeta: metav1.ObjectMeta{Name: "launch", Namespace: "monitoring"},
+		Spec: observabilityv1beta1.PlatformLoadTestSpec{
+			Platform: "production",
+			Duration: &metav1.Duration{Duration: 20 * time.Minute},
+			Metrics:  &observabilityv1beta1.LoadTestMetricsSpec{Series: 250000},
+			Logs:     &observabilityv1beta1.LoadTestLogsSpec{LinesPerSecond: 1200},
+			Traces:   &observabilityv1beta1.LoadTestTracesSpec{SpansPerSecond: 5000, ChildSpans: 4},
+		},
+	}
+}
+
+func TestPlans(t *testing.T) {
+	metrics := PlanMetrics(&observabilityv1beta1.LoadTestMetricsSpec{Series: 250000})
+	assert.Equal(t, MetricsPlan{Replicas: 3, MetricCount: 8334}, metrics)
+	assert.Equal(t, int32(250020), metrics.Series())
+	assert.Equal(t, int32(10), PlanMetrics(&observabilityv1beta1.LoadTestMetricsSpec{Series: 7}).Series())
+
+	logs := PlanLogs(&observabilityv1beta1.LoadTestLogsSpec{LinesPerSecond: 1200})
+	assert.Equal(t, LogsPlan{Replicas: 3, Interval: 2500 * time.Microsecond}, logs)
+	assert.Equal(t, LogsPlan{Replicas: 1, Interval: 100 * time.Millisecond},
+		PlanLogs(&observabilityv1beta1.LoadTestLogsSpec{LinesPerSecond: 10}))
+
+	traces := PlanTraces(&observabilityv1beta1.LoadTestTracesSpec{SpansPerSecond: 5000, ChildSpans: 4})
+	assert.Equal(t, TracesPlan{Replicas: 1, TracesPerSecond: 1000, ChildSpans: 4}, traces)
+	assert.Equal(t, int32(5000), traces.SpansPerSecond())
+	// A trace at least
+	assert.Equal(t, int32(1), PlanTraces(&observabilityv1beta1.LoadTestTracesSpec{SpansPerSecond: 1, ChildSpans: 9}).TracesPerSecond)
+}
+
+func TestGenerators(t *testing.T) {
+	test := loadTest()
+	test.Spec.Logs.Image = "registry.example.com/loki-canary:2.9.0"
+	generators := Generators(test, Targets{
+		LokiAddress:  "loki-production.monitoring.svc.cluster.local:3100",
+		TempoAddress: "production-tempo.monitoring.svc.cluster.local:4317",
+		TenantID:     "team-a",
+	})
+	require.Len(t, generators, 3)
+
+	metrics := generators[0]
+	assert.Equal(t, "launch-metrics", metrics.Name)
+	assert.Equal(t, int32(3), *metrics.Spec.Replicas)
+	assert.Equal(t, "launch", metrics.Labels[LoadTestLabel])
+	assert.Equal(t, "production", metrics.Labels["observability.io/platform"])
+	assert.Equal(t, "true", metrics.Spec.Template.Annotations["prometheus.io/scrape"])
+	assert.Equal(t, "9001", metrics.Spec.Template.Annotations["prometheus.io/port"])
+	container := metrics.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, DefaultAvalancheImage, container.Image)
+	assert.Contains(t, container.Args, "--metric-count=8334")
+	assert.Contains(t, container.Args, "--series-interval=4800", "no churn during the test")
+	assert.Subset(t, metrics.Spec.Template.Labels, metrics.Spec.Selector.MatchLabels)
+
+	logs := generators[1]
+	assert.Equal(t, "launch-logs", logs.Name)
+	container = logs.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "registry.example.com/loki-canary:2.9.0", container.Image)
+	assert.Contains(t, container.Args, "-addr=loki-production.monitoring.svc.cluster.local:3100")
+	assert.Contains(t, container.Args, "-interval=2.5ms")
+	assert.Contains(t, container.Args, "-size=256")
+	assert.Contains(t, container.Args, "-tenant-id=team-a")
+
+	traces := generators[2]
+	assert.Equal(t, "launch-traces", traces.Name)
+	assert.Empty(t, traces.Spec.Template.Annotations)
+	container = traces.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "traces", container.Args[0])
+	assert.Contains(t, container.Args, "--otlp-endpoint=production-tempo.monitoring.svc.cluster.local:4317")
+	assert.Contains(t, container.Args, "--rate=1000")
+	assert.Contains(t, container.Args, `--otlp-header=X-Scope-OrgID="team-a"`)
+
+	churn := loadTest()
+	churn.Spec.Logs, churn.Spec.Traces = nil, nil
+	churn.Spec.Metrics.ChurnInterval = &metav1.Duration{Duration: 5 * time.Minute}
+	generators = Generators(churn, Targets{})
+	require.Len(t, generators, 1)
+	assert.Contains(t, generators[0].Spec.Template.Spec.Containers[0].Args, "--series-interval=300")
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package loadtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// minWindow is the shortest window the results are measured over
+const minWindow = time.Minute
+
+// Querier runs instant queries against a Prometheus
+type Querier struct {
+	HTTP *http.Client
+	// URL is the base URL of the Prometheus
+	URL string
+}
+
+// Query returns the value of a query at a time. ok is false if the query
+// returned no sample.
+func (q *Querier) Query(ctx context.Context, query string, at time.Time) (float64, bool, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("time", strconv.FormatInt(at.Unix(), 10))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, q.URL+"/api/v1/query?"+params.Encode(), nil)
+	if err != nil {
+		return 0, false, err
+	}
+	resp, err := q.HTTP.Do(req)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to query Prometheus: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read Prometheus response: %w", err)
+	}
+	return parseValue(body)
+}
+
+// parseValue parses the value of an instant query's scalar, or of the first
+// sample of its vector
+func parseValue(body []byte) (float64, bool, error) {
+	var response struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Data   struct {
+			ResultType string          `json:"resultType"`
+			Result     json.RawMessage `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return 0, false, fmt.Errorf("failed to decode Prometheus response: %w", err)
+	}
+	if response.Status != "success" {
+		return 0, false, fmt.Errorf("prometheus query failed: %s", response.Error)
+	}
+
+	var sample [2]interface{}
+	switch response.Data.ResultType {
+	case "scalar":
+		if err := json.Unmarshal(response.Data.Result, &sample); err != nil {
+			return 0, false, fmt.Errorf("failed to decode scalar: %w", err)
+		}
+	case "vector":
+		var vector []struct {
+			Value [2]interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(response.Data.Result, &vector); err != nil {
+			return 0, false, fmt.Errorf("failed to decode vector: %w", err)
+		}
+		if len(vector) == 0 {
+			return 0, false, nil
+		}
+		sample = vector[0].Value
+	default:
+		return 0, false, fmt.Errorf("unexpected result type %q", response.Data.ResultType)
+	}
+
+	s, ok := sample[1].(string)
+	if !ok {
+		return 0, false, fmt.Errorf("unexpected sample value %v", sample[1])
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid sample value %q", s)
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false, nil
+	}
+	return value, true, nil
+}
+
+// Window returns the window the results of a test are measured over: its
+// second half, once the generators have started and the backends have
+// warmed up
+func Window(test *observabilityv1beta1.PlatformLoadTest) time.Duration {
+	window := (test.GetDuration() / 2).Truncate(time.Second)
+	if window < minWindow {
+		return minWindow
+	}
+	return window
+}
+
+// Signals measures the ingestion of each generated signal at the end of
+// the test
+func Signals(ctx context.Context, q *Querier, test *observabilityv1beta1.PlatformLoadTest, end time.Time) ([]observabilityv1beta1.LoadTestSignalResult, error) {
+	window := promDuration(Window(test))
+	selector := fmt.Sprintf(`%s=%q`, promLabel(LoadTestLabel), test.Name)
+	threshold := float64(test.GetSuccessThreshold()) / 100
+
+	var results []observabilityv1beta1.LoadTestSignalResult
+	if spec := test.Spec.Metrics; spec != nil {
+		series := PlanMetrics(spec).Series()
+		result := observabilityv1beta1.LoadTestSignalResult{
+			Signal: observabilityv1beta1.SignalMetrics,
+			Target: fmt.Sprintf("%d series", series),
+		}
+
+		// Failed scrapes count as no samples
+		scraped, ok, err := q.Query(ctx, fmt.Sprintf(`avg_over_time(sum(scrape_samples_scraped{%s})[%s:30s])`, selector, window), end)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			setIngested(&result, scraped/float64(series), threshold)
+		}
+		latency, ok, err := q.Query(ctx, fmt.Sprintf(`quantile_over_time(0.99, max(scrape_duration_seconds{%s})[%s:30s])`, selector, window), end)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result.LatencyP99 = formatSeconds(latency)
+		}
+		results = append(results, result)
+	}
+
+	if spec := test.Spec.Logs; spec != nil {
+		result := observabilityv1beta1.LoadTestSignalResult{
+			Signal: observabilityv1beta1.SignalLogs,
+			Target: fmt.Sprintf("%d lines/s", spec.LinesPerSecond),
+		}
+
+		entries, ok, err := q.Query(ctx, fmt.Sprintf(`sum(increase(loki_canary_entries_total{%s}[%s]))`, selector, window), end)
+		if err != nil {
+			return nil, err
+		}
+		if ok && entries > 0 {
+			missing, _, err := q.Query(ctx, fmt.Sprintf(`sum(increase(loki_canary_missing_entries_total{%s}[%s]))`, selector, window), end)
+			if err != nil {
+				return nil, err
+			}
+			setIngested(&result, 1-missing/entries, threshold)
+		}
+		latency, ok, err := q.Query(ctx, fmt.Sprintf(
+			`histogram_quantile(0.99, sum by (le) (increase(loki_canary_response_latency_seconds_bucket{%s}[%s])))`, selector, window), end)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result.LatencyP99 = formatSeconds(latency)
+		}
+		results = append(results, result)
+	}
+
+	if spec := test.Spec.Traces; spec != nil {
+		spans := PlanTraces(spec).SpansPerSecond()
+		result := observabilityv1beta1.LoadTestSignalResult{
+			Signal: observabilityv1beta1.SignalTraces,
+			Target: fmt.Sprintf("%d spans/s", spans),
+		}
+
+		namespace := fmt.Sprintf(`kubernetes_namespace=%q`, test.Namespace)
+		received, ok, err := q.Query(ctx, fmt.Sprintf(`sum(rate(tempo_distributor_spans_received_total{%s}[%s]))`, namespace, window), end)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			setIngested(&result, received/float64(spans), threshold)
+		}
+		latency, ok, err := q.Query(ctx, fmt.Sprintf(
+			`histogram_quantile(0.99, sum by (le) (increase(tempo_request_duration_seconds_bucket{%s,route=~".*TraceService/Export"}[%s])))`, namespace, window), end)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result.LatencyP99 = formatSeconds(latency)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// Headroom measures the peak resource usage of a component's pods over the
+// test, relative to the limits of the pods
+func Headroom(ctx context.Context, q *Querier, test *observabilityv1beta1.PlatformLoadTest, component string, pods []corev1.Pod, end time.Time) (observabilityv1beta1.LoadTestHeadroom, error) {
+	headroom := observabilityv1beta1.LoadTestHeadroom{Component: component}
+	if len(pods) == 0 {
+		return headroom, nil
+	}
+
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, regexp.QuoteMeta(pod.Name))
+	}
+	selector := fmt.Sprintf(`namespace=%q,pod=~%q,container!="",container!="POD"`, test.Namespace, strings.Join(names, "|"))
+	window := promDuration(test.GetDuration())
+
+	cpu, ok, err := q.Query(ctx, fmt.Sprintf(
+		`max(max_over_time(sum by (pod) (rate(container_cpu_usage_seconds_total{%s}[2m]))[%s:30s]))`, selector, window), end)
+	if err != nil {
+		return headroom, err
+	}
+	if ok {
+		headroom.PeakCPU = strconv.FormatFloat(cpu, 'f', 2, 64)
+		if limit := podLimit(&pods[0], corev1.ResourceCPU); limit > 0 {
+			headroom.CPUHeadroom = formatPercent(1 - cpu/limit)
+		}
+	}
+
+	memory, ok, err := q.Query(ctx, fmt.Sprintf(
+		`max(max_over_time(sum by (pod) (container_memory_working_set_bytes{%s})[%s:30s]))`, selector, window), end)
+	if err != nil {
+		return headroom, err
+	}
+	if ok {
+		headroom.PeakMemory = fmt.Sprintf("%.0fMi", memory/(1<<20))
+		if limit := podLimit(&pods[0], corev1.ResourceMemory); limit > 0 {
+			headroom.MemoryHeadroom = formatPercent(1 - memory/limit)
+		}
+	}
+	return headroom, nil
+}
+
+// podLimit returns the sum of the limits of the containers of a pod, 0 if
+// one of them is unlimited
+func podLimit(pod *corev1.Pod, name corev1.ResourceName) float64 {
+	var total float64
+	for _, container := range pod.Spec.Containers {
+		limit, ok := container.Resources.Limits[name]
+		if !ok {
+			return 0
+		}
+		total += limit.AsApproximateFloat64()
+	}
+	return total
+}
+
+// setIngested records the ingested ratio of a signal, capped at 100% as
+// other traffic of the backends can be counted
+func setIngested(result *observabilityv1beta1.LoadTestSignalResult, ratio, threshold float64) {
+	ratio = math.Max(0, math.Min(1, ratio))
+	result.Ingested = formatPercent(ratio)
+	result.Passed = ratio >= threshold
+}
+
+// promLabel returns the Prometheus label a Kubernetes label is mapped to
+func promLabel(label string) string {
+	return regexp.MustCompile(`[^a-zA-Z0-9_]`).ReplaceAllString(label, "_")
+}
+
+// promDuration formats a duration for PromQL
+func promDuration(d time.Duration) string {
+	return fmt.Sprintf("%ds", int64(d.Seconds()))
+}
+
+// formatPercent formats a ratio as a percentage
+func formatPercent(ratio float64) string {
+	return strconv.FormatFloat(ratio*100, 'f', 1, 64) + "%"
+}
+
+// formatSeconds formats seconds as a duration
+func formatSeconds(seconds float64) string {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Millisecond).String()
+}
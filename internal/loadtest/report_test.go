@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// prometheus answers the queries containing a key with its value, and the
+// others with an empty vector
+func prometheus(t *testing.T, values map[string]string) *Querier {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/query", r.URL.Path)
+		query := r.URL.Query().Get("query")
+		for key, value := range values {
+			if strings.Contains(query, key) {
+				fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,%q]}]}}`, value)
+				return
+			}
+		}
+		fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+	}))
+	t.Cleanup(server.Close)
+	return &Querier{HTTP: server.Client(), URL: server.URL}
+}
+
+func TestSignals(t *testing.T) {
+	q := prometheus(t, map[string]string{
+		"scrape_samples_scraped":                 "249000",
+		"scrape_duration_seconds":                "0.35",
+		"loki_canary_entries_total":              "1000",
+		"loki_canary_missing_entries_total":      "2",
+		"loki_canary_response_latency_seconds":   "1.5",
+		"tempo_distributor_spans_received_total": "5100",
+	})
+
+	signals, err := Signals(context.Background(), q, loadTest(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []observabilityv1beta1.LoadTestSignalResult{
+		{Signal: "metrics", Target: "250020 series", Ingested: "99.6%", LatencyP99: "350ms", Passed: true},
+		{Signal: "logs", Target: "1200 lines/s", Ingested: "99.8%", LatencyP99: "1.5s", Passed: true},
+		{Signal: "traces", Target: "5000 spans/s", Ingested: "100.0%", Passed: true},
+	}, signals)
+}
+
+func TestSignalsBelowThreshold(t *testing.T) {
+	q := prometheus(t, map[string]string{
+		"scrape_samples_scraped": "200000",
+	})
+	test := loadTest()
+	test.Spec.SuccessThreshold = 80
+
+	signals, err := Signals(context.Background(), q, test, time.Now())
+	require.NoError(t, err)
+	require.Len(t, signals, 3)
+	assert.Equal(t, "80.0%", signals[0].Ingested)
+	assert.False(t, signals[0].Passed, "80.0% rounds 79.99%")
+	// Unmeasured signals don't pass
+	assert.Empty(t, signals[1].Ingested)
+	assert.False(t, signals[1].Passed)
+}
+
+func TestHeadroom(t *testing.T) {
+	q := prometheus(t, map[string]string{
+		"container_cpu_usage_seconds_total":  "0.5",
+		"container_memory_working_set_bytes": "1610612736",
+	})
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "prometheus-production-0"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name: "prometheus",
+			Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			}},
+		}}},
+	}
+
+	headroom, err := Headroom(context.Background(), q, loadTest(), "prometheus", []corev1.Pod{pod}, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, observabilityv1beta1.LoadTestHeadroom{
+		Component:      "prometheus",
+		PeakCPU:        "0.50",
+		CPUHeadroom:    "75.0%",
+		PeakMemory:     "1536Mi",
+		MemoryHeadroom: "25.0%",
+	}, headroom)
+
+	// Unlimited pods have no headroom
+	pod.Spec.Containers[0].Resources.Limits = nil
+	headroom, err = Headroom(context.Background(), q, loadTest(), "prometheus", []corev1.Pod{pod}, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "0.50", headroom.PeakCPU)
+	assert.Empty(t, headroom.CPUHeadroom)
+}
+
+func TestParseValue(t *testing.T) {
+	value, ok, err := parseValue([]byte(`{"status":"success","data":{"resultType":"scalar","result":[1700000000,"42"]}}`))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 42.0, value)
+
+	_, ok, err = parseValue([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"NaN"]}]}}`))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = parseValue([]byte(`{"status":"error","error":"parse error"}`))
+	assert.Error(t, err)
+}
+
+func TestWindow(t *testing.T) {
+	assert.Equal(t, 10*time.Minute, Window(loadTest()))
+
+	short := loadTest()
+	short.Spec.Duration = &metav1.Duration{Duration: time.Minute}
+	assert.Equal(t, time.Minute, Window(short))
+
+	assert.Equal(t, "observability_io_load_test", promLabel(LoadTestLabel))
+}
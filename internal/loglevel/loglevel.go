@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package loglevel resolves the log level of the components. A component's
+// level overrides the global one, and debug mode raises it to debug for a
+// limited time. Components reading their level from their configuration
+// reload are switched in place; the others read it from a flag or from
+// configuration only loaded at startup, and their pods roll.
+package loglevel
+
+import (
+	"time"
+)
+
+// Levels, in increasing severity
+const (
+	Debug = "debug"
+	Info  = "info"
+	Warn  = "warn"
+	Error = "error"
+
+	// DefaultDebugTTL is how long debug mode lasts if no TTL is set
+	DefaultDebugTTL = time.Hour
+)
+
+// reloadable are the components that apply a new level with a configuration
+// reload. Alloy applies its logging block on /-/reload.
+var reloadable = map[string]bool{
+	"alloy": true,
+}
+
+// Reloadable returns true if a component changes its level without a restart
+func Reloadable(component string) bool {
+	return reloadable[component]
+}
+
+// Level returns the configured level of a component: its own if set, else
+// the global one, else info
+func Level(global, component string) string {
+	if component != "" {
+		return component
+	}
+	if global != "" {
+		return global
+	}
+	return Info
+}
+
+// Window is the time debug mode lasts for a component. An expired window is
+// kept until debug mode is turned off, so it does not reopen.
+type Window struct {
+	Start   time.Time
+	End     time.Time
+	Expired bool
+}
+
+// Active returns true if the window raises the level at now
+func (w *Window) Active(now time.Time) bool {
+	return w != nil && !w.Expired && now.Before(w.End)
+}
+
+// Change is how a debug window changed
+type Change string
+
+const (
+	// Unchanged means the window stays as it was
+	Unchanged Change = ""
+	// Opened means debug mode was turned on
+	Opened Change = "Opened"
+	// Expired means the TTL of debug mode ran out
+	Expired Change = "Expired"
+	// Closed means debug mode was turned off before its TTL ran out
+	Closed Change = "Closed"
+)
+
+// Next returns the debug window of a component after a reconcile at now,
+// given whether debug mode is on and its TTL, and how the window changed.
+// A nil window means debug mode is off.
+func Next(debug bool, ttl time.Duration, current *Window, now time.Time) (*Window, Change) {
+	switch {
+	case !debug && current == nil:
+		return nil, Unchanged
+	case !debug:
+		if current.Expired {
+			return nil, Unchanged
+		}
+		return nil, Closed
+	case current == nil:
+		return &Window{Start: now, End: now.Add(ttl)}, Opened
+	case !current.Expired && !now.Before(current.End):
+		expired := *current
+		expired.Expired = true
+		return &expired, Expired
+	default:
+		return current, Unchanged
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package loglevel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevel(t *testing.T) {
+	assert.Equal(t, Warn, Level(Info, Warn))
+	assert.Equal(t, Error, Level(Error, ""))
+	assert.Equal(t, Info, Level("", ""))
+}
+
+func TestWindowActive(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	window := &Window{Start: now.Add(-time.Minute), End: now.Add(time.Hour)}
+
+	assert.True(t, window.Active(now))
+	assert.False(t, window.Active(now.Add(time.Hour)))
+	assert.False(t, (&Window{End: now.Add(time.Hour), Expired: true}).Active(now))
+	var off *Window
+	assert.False(t, off.Active(now))
+}
+
+func TestNext(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	window, change := Next(false, time.Hour, nil, now)
+	assert.Nil(t, window)
+	assert.Equal(t, Unchanged, change)
+
+	// Turning debug mode on opens a window of the TTL
+	window, change = Next(true, 30*time.Minute, nil, now)
+	assert.Equal(t, Opened, change)
+	assert.Equal(t, &Window{Start: now, End: now.Add(30 * time.Minute)}, window)
+
+	open := window
+	window, change = Next(true, 30*time.Minute, open, now.Add(10*time.Minute))
+	assert.Equal(t, Unchanged, change)
+	assert.Same(t, open, window)
+
+	// The window expires once, and stays expired while debug mode is on
+	window, change = Next(true, 30*time.Minute, open, now.Add(30*time.Minute))
+	assert.Equal(t, Expired, change)
+	assert.True(t, window.Expired)
+	assert.False(t, open.Expired)
+	expired := window
+	window, change = Next(true, 30*time.Minute, expired, now.Add(2*time.Hour))
+	assert.Equal(t, Unchanged, change)
+	assert.Same(t, expired, window)
+
+	// Turning debug mode off clears the window, and on again reopens it
+	window, change = Next(false, 30*time.Minute, expired, now.Add(2*time.Hour))
+	assert.Nil(t, window)
+	assert.Equal(t, Unchanged, change)
+	window, change = Next(false, 30*time.Minute, open, now.Add(10*time.Minute))
+	assert.Nil(t, window)
+	assert.Equal(t, Closed, change)
+}
+
+func TestReloadable(t *testing.T) {
+	assert.True(t, Reloadable("alloy"))
+	assert.False(t, Reloadable("prometheus"))
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package managers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/certmanager"
+)
+
+// ReconcileCertificate creates or updates a cert-manager Certificate owned
+// by the platform. cert-manager writes the certificate to its Secret and
+// renews it before it expires.
+func ReconcileCertificate(ctx context.Context, c client.Client, scheme *runtime.Scheme, platform *observabilityv1beta1.ObservabilityPlatform, cert certmanager.Certificate) error {
+	desired := cert.Object()
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(certmanager.CertificateGVK)
+	obj.SetName(cert.Name)
+	obj.SetNamespace(cert.Namespace)
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, c, obj, func() error {
+		obj.SetLabels(desired.GetLabels())
+		obj.Object["spec"] = desired.Object["spec"]
+		return controllerutil.SetControllerReference(platform, obj, scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to create/update Certificate %s: %w", cert.Name, err)
+	}
+	return nil
+}
+
+// CertificateIssuer converts a cert-manager issuer reference
+func CertificateIssuer(ref *observabilityv1beta1.CertManagerIssuerRef) certmanager.IssuerRef {
+	return certmanager.IssuerRef{Name: ref.Name, Kind: ref.Kind, Group: ref.Group}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package managers
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/configcheck"
+)
+
+// CheckConfig validates the configuration files of a component before they
+// are rolled out. It returns true if the files may be applied: validation is
+// disabled, or the files passed validation. While the validation Job runs it
+// returns false, and the caller keeps the current configuration; the Job's
+// completion triggers the next reconcile. Files failing validation are
+// rejected with an error and the validation output is recorded in status.
+func CheckConfig(ctx context.Context, c client.Client, scheme *runtime.Scheme, platform *observabilityv1beta1.ObservabilityPlatform, component, image string, files map[string]string) (bool, error) {
+	if !platform.IsConfigValidationEnabled() {
+		return true, nil
+	}
+
+	hash := configcheck.Hash(files)
+	previous, ok := platform.Status.ConfigValidation[component]
+	if ok && previous.Hash == hash && previous.State == string(configcheck.StateValid) {
+		return true, nil
+	}
+
+	name := configcheck.Name(component, platform.Name, hash)
+	check := configcheck.Check{
+		Component:      component,
+		Platform:       platform.Name,
+		Namespace:      platform.Namespace,
+		Image:          image,
+		Files:          files,
+		TimeoutSeconds: platform.Spec.ConfigValidation.GetTimeoutSeconds(),
+		Labels: map[string]string{
+			"app.kubernetes.io/name":       component + "-config-check",
+			"app.kubernetes.io/instance":   platform.Name,
+			"app.kubernetes.io/managed-by": "gunj-operator",
+			"observability.io/platform":    platform.Name,
+		},
+	}
+	job := configcheck.BuildJob(check, name)
+	if job == nil {
+		return true, nil
+	}
+
+	existing := &batchv1.Job{}
+	err := c.Get(ctx, client.ObjectKeyFromObject(job), existing)
+	if errors.IsNotFound(err) {
+		configMap := configcheck.BuildConfigMap(check, name)
+		for _, obj := range []client.Object{configMap, job} {
+			if err := controllerutil.SetControllerReference(platform, obj, scheme); err != nil {
+				return false, fmt.Errorf("failed to set owner reference on config check: %w", err)
+			}
+			if err := c.Create(ctx, obj); err != nil && !errors.IsAlreadyExists(err) {
+				return false, fmt.Errorf("failed to create %s config check %s: %w", component, name, err)
+			}
+		}
+		log.FromContext(ctx).Info("Validating configuration before rollout", "component", component, "job", name)
+		setConfigValidationStatus(platform, component, hash, name, configcheck.StatePending, "")
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get config check job %s: %w", name, err)
+	}
+
+	state := configcheck.JobState(existing)
+	switch state {
+	case configcheck.StatePending:
+		setConfigValidationStatus(platform, component, hash, name, state, "")
+		return false, nil
+	case configcheck.StateInvalid:
+		pods := &corev1.PodList{}
+		if err := c.List(ctx, pods, client.InNamespace(platform.Namespace), client.MatchingLabels{"job-name": name}); err != nil {
+			return false, fmt.Errorf("failed to list config check pods: %w", err)
+		}
+		message := configcheck.FailureMessage(existing, pods.Items)
+		setConfigValidationStatus(platform, component, hash, name, state, message)
+		return false, fmt.Errorf("%s configuration failed validation and was not applied: %s", component, message)
+	}
+
+	setConfigValidationStatus(platform, component, hash, name, state, "")
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: platform.Namespace}}
+	if err := c.Delete(ctx, configMap); client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete config check %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// setConfigValidationStatus records the validation state of a component's configuration
+func setConfigValidationStatus(platform *observabilityv1beta1.ObservabilityPlatform, component, hash, job string, state configcheck.State, message string) {
+	if platform.Status.ConfigValidation == nil {
+		platform.Status.ConfigValidation = make(map[string]observabilityv1beta1.ConfigValidationStatus)
+	}
+
+	status := observabilityv1beta1.ConfigValidationStatus{
+		Hash:    hash,
+		State:   string(state),
+		Job:     job,
+		Message: message,
+	}
+	previous, ok := platform.Status.ConfigValidation[component]
+	if ok && previous.Hash == hash && previous.State == string(state) {
+		status.LastTransitionTime = previous.LastTransitionTime
+	} else {
+		now := metav1.Now()
+		status.LastTransitionTime = &now
+	}
+	platform.Status.ConfigValidation[component] = status
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package managers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/envvars"
+)
+
+// ApplyEnv adds the environment variables and sources of a component to the
+// named container. Variables the operator manages are rejected at admission;
+// those still set on the container are skipped and logged.
+func ApplyEnv(podSpec *corev1.PodSpec, platform *observabilityv1beta1.ObservabilityPlatform, component, container string, env []corev1.EnvVar, envFrom []corev1.EnvFromSource) {
+	if len(env) == 0 && len(envFrom) == 0 {
+		return
+	}
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name != container {
+			continue
+		}
+		if skipped := envvars.Apply(&podSpec.Containers[i], env, envFrom); len(skipped) > 0 {
+			log.Log.WithName("envvars").Info("Skipped environment variables managed by the operator",
+				"platform", platform.Name, "namespace", platform.Namespace, "component", component, "names", skipped)
+		}
+	}
+}
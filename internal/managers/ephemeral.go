@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package managers
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/volumes"
+)
+
+// ApplyEphemeralStorage applies the ephemeral storage settings of a component
+// to its pod spec. The container's WAL is moved to a dedicated volume mounted
+// at walPath if configured; components without a WAL pass an empty path.
+func ApplyEphemeralStorage(podSpec *corev1.PodSpec, container, walPath string, spec *observabilityv1beta1.EphemeralStorageSpec) {
+	if spec == nil {
+		return
+	}
+	volumes.Apply(podSpec, container, walPath, EphemeralStorageFor(spec))
+}
+
+// EphemeralStorageFor converts the ephemeral storage settings of a component.
+// Quantities are validated at admission, so invalid values are ignored here.
+func EphemeralStorageFor(spec *observabilityv1beta1.EphemeralStorageSpec) volumes.EphemeralStorage {
+	storage := volumes.EphemeralStorage{
+		Request:           parseQuantity(spec.Request),
+		Limit:             parseQuantity(spec.Limit),
+		EmptyDirSizeLimit: parseQuantity(spec.EmptyDirSizeLimit),
+		EmptyDirMedium:    corev1.StorageMedium(spec.EmptyDirMedium),
+	}
+	if spec.WAL != nil {
+		if size := parseQuantity(spec.WAL.Size); size != nil {
+			storage.WAL = &volumes.WAL{
+				Size:             *size,
+				StorageClassName: spec.WAL.StorageClassName,
+				Medium:           corev1.StorageMedium(spec.WAL.Medium),
+			}
+		}
+	}
+	return storage
+}
+
+// EphemeralStorageResources returns the resources value of a component's
+// container in Helm values with the ephemeral-storage request and limit set
+func EphemeralStorageResources(resources interface{}, spec *observabilityv1beta1.EphemeralStorageSpec) map[string]interface{} {
+	values := map[string]interface{}{}
+	if data, err := json.Marshal(resources); err == nil {
+		_ = json.Unmarshal(data, &values)
+	}
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	set := func(key, quantity string) {
+		if parseQuantity(quantity) == nil {
+			return
+		}
+		list, _ := values[key].(map[string]interface{})
+		if list == nil {
+			list = map[string]interface{}{}
+		}
+		list[string(corev1.ResourceEphemeralStorage)] = quantity
+		values[key] = list
+	}
+	set("requests", spec.Request)
+	set("limits", spec.Limit)
+	return values
+}
+
+// WALVolume returns the dedicated WAL volume of a component and its mount at
+// walPath, for the extra volumes of a chart. ok is false without one.
+func WALVolume(spec *observabilityv1beta1.EphemeralStorageSpec, walPath string) (corev1.Volume, corev1.VolumeMount, bool) {
+	wal := EphemeralStorageFor(spec).WAL
+	if wal == nil {
+		return corev1.Volume{}, corev1.VolumeMount{}, false
+	}
+	return wal.Volume(), corev1.VolumeMount{Name: volumes.WALVolumeName, MountPath: walPath}, true
+}
+
+// parseQuantity parses an optional quantity, returning nil if empty or invalid
+func parseQuantity(value string) *resource.Quantity {
+	if value == "" {
+		return nil
+	}
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return nil
+	}
+	return &q
+}
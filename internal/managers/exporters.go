@@ -0,0 +1,54 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package managers
+
+import (
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/exporters"
+)
+
+// Exporters returns the exporters of a platform's spec.exporters
+func Exporters(platform *observabilityv1beta1.ObservabilityPlatform) []exporters.Exporter {
+	var result []exporters.Exporter
+	for _, spec := range platform.Spec.Exporters {
+		name := spec.GetName()
+		result = append(result, exporters.Exporter{
+			Name:           name,
+			Namespace:      platform.Namespace,
+			Platform:       platform.Name,
+			Type:           spec.Type,
+			Version:        spec.Version,
+			Address:        spec.Address,
+			Username:       spec.Username,
+			PasswordSecret: spec.PasswordSecretRef,
+			Args:           spec.Args,
+			Resources:      spec.Resources,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       spec.Type + "-exporter",
+				"app.kubernetes.io/instance":   platform.Name,
+				"app.kubernetes.io/managed-by": "gunj-operator",
+				"app.kubernetes.io/part-of":    "observability-platform",
+				"app.kubernetes.io/component":  exporters.ComponentLabel,
+				"observability.io/platform":    platform.Name,
+				"observability.io/exporter":    name,
+			},
+		})
+	}
+	return result
+}
+
+// ExporterAlerts returns the exporters of a platform whose default alerts are
+// loaded
+func ExporterAlerts(platform *observabilityv1beta1.ObservabilityPlatform) []exporters.Exporter {
+	var result []exporters.Exporter
+	for i, e := range Exporters(platform) {
+		if platform.Spec.Exporters[i].AlertsEnabled() {
+			result = append(result, e)
+		}
+	}
+	return result
+}
@@ -25,9 +25,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
 
 	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/auditlogs"
+	"github.com/gunjanjp/gunj-operator/internal/eventexporter"
+	"github.com/gunjanjp/gunj-operator/internal/exporters"
+	"github.com/gunjanjp/gunj-operator/internal/fips"
 	"github.com/gunjanjp/gunj-operator/internal/managers"
+	"github.com/gunjanjp/gunj-operator/internal/scheduling"
+	"github.com/gunjanjp/gunj-operator/internal/selfmonitoring"
 )
 
 const (
@@ -106,12 +113,17 @@ func (m *GrafanaManager) ReconcileWithConfig(ctx context.Context, platform *obse
 		return fmt.Errorf("failed to reconcile Service: %w", err)
 	}
 
-	// 6. Create Deployment
+	// 6. Create or remove the image renderer
+	if err := m.reconcileImageRenderer(ctx, platform, grafanaSpec); err != nil {
+		return fmt.Errorf("failed to reconcile image renderer: %w", err)
+	}
+
+	// 7. Create Deployment
 	if err := m.reconcileDeployment(ctx, platform, grafanaSpec); err != nil {
 		return fmt.Errorf("failed to reconcile Deployment: %w", err)
 	}
 
-	// 7. Create Ingress if configured
+	// 8. Create Ingress if configured
 	if grafanaSpec.Ingress != nil && grafanaSpec.Ingress.Enabled {
 		if err := m.reconcileIngress(ctx, platform, grafanaSpec); err != nil {
 			return fmt.Errorf("failed to reconcile Ingress: %w", err)
@@ -141,6 +153,18 @@ func (m *GrafanaManager) Delete(ctx context.Context, platform *observabilityv1be
 				Namespace: platform.Namespace,
 			},
 		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      m.getImageRendererName(platform),
+				Namespace: platform.Namespace,
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      m.getImageRendererName(platform),
+				Namespace: platform.Namespace,
+			},
+		},
 		&corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      m.getServiceName(platform),
@@ -471,6 +495,20 @@ func (m *GrafanaManager) reconcileDashboardConfigMaps(ctx context.Context, platf
 		dashboardsCM.Data = map[string]string{
 			"platform-overview.json": m.generatePlatformOverviewDashboard(),
 		}
+		if components := platform.Spec.Components; components.EventExporterEnabled() && components.EventExporter.DashboardEnabled() {
+			dashboardsCM.Data[eventexporter.DashboardKey] = eventexporter.Dashboard(platform.Name)
+		}
+		if components := platform.Spec.Components; components.AuditLogsEnabled() && components.AuditLogs.DashboardEnabled() {
+			dashboardsCM.Data[auditlogs.DashboardKey] = auditlogs.Dashboard(platform.Name)
+		}
+		for i, exporter := range managers.Exporters(platform) {
+			if platform.Spec.Exporters[i].DashboardEnabled() {
+				dashboardsCM.Data[exporters.DashboardKey(exporter)] = exporters.Dashboard(exporter)
+			}
+		}
+		if platform.Spec.SelfMonitoring.DashboardEnabled() {
+			dashboardsCM.Data[selfmonitoring.DashboardKey] = selfmonitoring.Dashboard()
+		}
 
 		return nil
 	})
@@ -479,6 +517,11 @@ func (m *GrafanaManager) reconcileDashboardConfigMaps(ctx context.Context, platf
 		return fmt.Errorf("failed to create/update default dashboards ConfigMap: %w", err)
 	}
 
+	// Render dashboards defined in Jsonnet
+	if err := m.reconcileJsonnetDashboards(ctx, platform, grafanaSpec); err != nil {
+		return err
+	}
+
 	log.V(1).Info("Dashboard ConfigMaps reconciled")
 	return nil
 }
@@ -589,7 +632,11 @@ func (m *GrafanaManager) reconcileDeployment(ctx context.Context, platform *obse
 		// Build Deployment spec
 		deployment.Spec = m.buildDeploymentSpec(platform, grafanaSpec)
 
-		return nil
+		// Roll configuration changes as requested
+		managers.ApplyDeploymentStrategy(&deployment.Spec, grafanaSpec.UpdateStrategy)
+
+		// Roll the pods on changes of referenced configuration
+		return managers.ApplyConfigChecksum(ctx, m.Client, platform, componentName, grafanaSpec.ReloadStrategy, &deployment.Spec.Template)
 	})
 
 	if err != nil {
@@ -623,6 +670,12 @@ func (m *GrafanaManager) reconcileIngress(ctx context.Context, platform *observa
 		for k, v := range grafanaSpec.Ingress.Annotations {
 			ingress.Annotations[k] = v
 		}
+		// Enforce strict TLS on the ingress controller in FIPS mode
+		if platform.IsFIPSEnabled() {
+			profile := fips.Resolve(platform.Spec.Security.FIPS.MinTLSVersion, platform.Spec.Security.FIPS.CipherSuites)
+			ingress.Annotations["nginx.ingress.kubernetes.io/ssl-protocols"] = profile.NginxProtocols()
+			ingress.Annotations["nginx.ingress.kubernetes.io/ssl-ciphers"] = profile.NginxCiphers()
+		}
 
 		// Set owner reference
 		if err := controllerutil.SetControllerReference(platform, ingress, m.Scheme); err != nil {
@@ -865,6 +918,24 @@ func (m *GrafanaManager) buildDeploymentSpec(platform *observabilityv1beta1.Obse
 		},
 	}
 
+	// Mount the rendered Jsonnet dashboards for their dashboard provider
+	if len(grafanaSpec.JsonnetDashboards) > 0 {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      jsonnetDashboardsVolume,
+			MountPath: jsonnetDashboardsPath,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: jsonnetDashboardsVolume,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: m.getJsonnetDashboardsConfigMapName(platform),
+					},
+				},
+			},
+		})
+	}
+
 	// Add plugins volume if needed
 	if len(grafanaSpec.Plugins) > 0 {
 		volumes = append(volumes, corev1.Volume{
@@ -898,11 +969,32 @@ func (m *GrafanaManager) buildDeploymentSpec(platform *observabilityv1beta1.Obse
 		podSpec.Tolerations = platform.Spec.Global.Tolerations
 	}
 
-	// Add affinity if specified
-	if platform.Spec.Global.Affinity != nil {
-		podSpec.Affinity = platform.Spec.Global.Affinity
+	// Render panels and reports with the image renderer
+	if grafanaSpec.ImageRendererEnabled() {
+		podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, m.imageRendererEnv(platform)...)
 	}
 
+	// Add extra containers and volumes; they precede the priority settings so guaranteed QoS covers them
+	managers.ApplyExtraContainers(&podSpec, platform, "grafana", grafanaSpec.ExtraContainers, grafanaSpec.ExtraVolumes)
+
+	// Add user-defined environment variables to the grafana container
+	managers.ApplyEnv(&podSpec, platform, "grafana", componentName, grafanaSpec.Env, grafanaSpec.EnvFrom)
+
+	// Override the health probes of the grafana container
+	managers.ApplyProbes(&podSpec, componentName, grafanaSpec.Probes)
+
+	// Add affinity and topology spread constraints, component settings override global ones
+	scheduling.Apply(&podSpec, managers.PlacementFor(platform, grafanaSpec.Affinity, grafanaSpec.TopologySpreadConstraints), labels)
+
+	// Add priority class and enforce guaranteed QoS if requested
+	managers.ApplyPriority(&podSpec, platform, "grafana", grafanaSpec.PriorityClassName, grafanaSpec.GuaranteedQoS)
+
+	// Schedule on the dedicated node pool if configured
+	managers.ApplyNodePool(&podSpec, platform)
+
+	// Size ephemeral storage and emptyDir volumes
+	managers.ApplyEphemeralStorage(&podSpec, componentName, "", grafanaSpec.EphemeralStorage)
+
 	return appsv1.DeploymentSpec{
 		Replicas: &replicas,
 		Selector: &metav1.LabelSelector{
@@ -937,13 +1029,20 @@ datasources:`
 	}
 
 	// Add Loki datasource if enabled
+	lokiURL := fmt.Sprintf("http://loki-%s.%s.svc.cluster.local:3100", platform.Name, platform.Namespace)
 	if platform.Spec.Components.Loki != nil && platform.Spec.Components.Loki.Enabled {
-		lokiURL := fmt.Sprintf("http://loki-%s.%s.svc.cluster.local:3100", platform.Name, platform.Namespace)
 		config += fmt.Sprintf(`
   - name: Loki
     type: loki
     access: proxy
     url: %s`, lokiURL)
+		if tenants := managers.QueryTenants(platform); tenants != "" {
+			config += fmt.Sprintf(`
+    jsonData:
+      httpHeaderName1: %s
+    secureJsonData:
+      httpHeaderValue1: %q`, managers.TenantHeader, tenants)
+		}
 	}
 
 	// Add Tempo datasource if enabled
@@ -967,6 +1066,24 @@ datasources:`
         hide: false
       nodeGraph:
         enabled: true`, tempoURL)
+		if tenants := managers.QueryTenants(platform); tenants != "" {
+			config += fmt.Sprintf(`
+      httpHeaderName1: %s
+    secureJsonData:
+      httpHeaderValue1: %q`, managers.TenantHeader, tenants)
+		}
+	}
+
+	// Add the per-team datasources going through the query access control
+	// proxy and the profiling datasource
+	for _, ds := range append(managers.QueryACLDatasources(platform, lokiURL), managers.ProfilingDatasources(platform)...) {
+		entry, err := yaml.Marshal([]interface{}{ds})
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimRight(string(entry), "\n"), "\n") {
+			config += "\n  " + line
+		}
 	}
 
 	// Add custom datasources if provided
@@ -991,7 +1108,7 @@ datasources:`
 
 // generateDashboardProviderConfig generates the dashboard provider configuration
 func (m *GrafanaManager) generateDashboardProviderConfig(platform *observabilityv1beta1.ObservabilityPlatform) string {
-	return `apiVersion: 1
+	config := `apiVersion: 1
 
 providers:
   - name: 'default'
@@ -1003,12 +1120,33 @@ providers:
     allowUiUpdates: false
     options:
       path: /var/lib/grafana/dashboards`
+
+	// Rendered Jsonnet dashboards are provisioned from their own volume
+	if grafanaSpec := platform.Spec.Components.Grafana; grafanaSpec != nil && len(grafanaSpec.JsonnetDashboards) > 0 {
+		config += `
+  - name: 'jsonnet'
+    orgId: 1
+    folder: ''
+    type: file
+    disableDeletion: false
+    updateIntervalSeconds: 10
+    allowUiUpdates: false
+    options:
+      path: ` + jsonnetDashboardsPath
+	}
+	return config
 }
 
 // generateGrafanaConfig generates the grafana.ini configuration
 func (m *GrafanaManager) generateGrafanaConfig(platform *observabilityv1beta1.ObservabilityPlatform, grafanaSpec *observabilityv1beta1.GrafanaSpec) string {
-	config := `[server]
-http_port = 3000
+	server := `[server]
+http_port = 3000`
+	if platform.IsFIPSEnabled() {
+		profile := fips.Resolve(platform.Spec.Security.FIPS.MinTLSVersion, platform.Spec.Security.FIPS.CipherSuites)
+		server += "\nmin_tls_version = " + profile.GrafanaMinVersion()
+	}
+
+	config := server + `
 
 [database]
 type = sqlite3
@@ -1031,7 +1169,7 @@ default_home_dashboard_path = /var/lib/grafana/dashboards/platform-overview.json
 
 [log]
 mode = console
-level = ` + platform.Spec.Global.LogLevel
+level = ` + managers.LogLevel(platform, componentName, grafanaSpec.ComponentLogging)
 
 	// Add SMTP configuration if provided
 	if grafanaSpec.SMTP != nil {
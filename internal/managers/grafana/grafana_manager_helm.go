@@ -14,6 +14,7 @@ import (
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
 
 	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
 	"github.com/gunjanjp/gunj-operator/internal/helm"
@@ -205,6 +206,14 @@ func (m *GrafanaManagerHelm) ConfigureDataSources(ctx context.Context, platform
 	return m.ReconcileWithConfig(ctx, platform, nil)
 }
 
+// chartSelectorLabels returns the labels the chart sets on the Grafana pods
+func chartSelectorLabels(platform *observabilityv1beta1.ObservabilityPlatform) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":     chartName,
+		"app.kubernetes.io/instance": helm.ReleaseName(platform, componentNameHelm),
+	}
+}
+
 // buildHelmValues builds Helm values for Grafana
 func (m *GrafanaManagerHelm) buildHelmValues(
 	platform *observabilityv1beta1.ObservabilityPlatform,
@@ -315,7 +324,7 @@ func (m *GrafanaManagerHelm) buildHelmValues(
 		"name":   fmt.Sprintf("%s-grafana", platform.Name),
 	}
 	
-	// Node selector, tolerations, and affinity from global settings
+	// Node selector and tolerations from global settings
 	if platform.Spec.Global != nil {
 		if len(platform.Spec.Global.NodeSelector) > 0 {
 			values["nodeSelector"] = platform.Spec.Global.NodeSelector
@@ -323,10 +332,95 @@ func (m *GrafanaManagerHelm) buildHelmValues(
 		if len(platform.Spec.Global.Tolerations) > 0 {
 			values["tolerations"] = platform.Spec.Global.Tolerations
 		}
-		if platform.Spec.Global.Affinity != nil {
-			values["affinity"] = platform.Spec.Global.Affinity
+	}
+	
+	// Affinity and topology spread constraints, component settings override global ones
+	placement := managers.PlacementFor(platform, grafanaSpec.Affinity, grafanaSpec.TopologySpreadConstraints)
+	if placement.Affinity != nil {
+		values["affinity"] = placement.Affinity
+	}
+	if constraints := placement.SpreadConstraints(chartSelectorLabels(platform)); len(constraints) > 0 {
+		values["topologySpreadConstraints"] = constraints
+	}
+	
+	// Dedicated node pool, merged with the global node selector and tolerations
+	if nodeSelector, tolerations, ok := managers.NodePoolValues(platform); ok {
+		values["nodeSelector"] = nodeSelector
+		values["tolerations"] = tolerations
+	}
+	
+	// Priority class and guaranteed QoS, component settings override global ones
+	priority := managers.PriorityFor(platform, grafanaSpec.PriorityClassName, grafanaSpec.GuaranteedQoS)
+	if priority.PriorityClassName != "" {
+		values["priorityClassName"] = priority.PriorityClassName
+	}
+	if priority.GuaranteedQoS && grafanaSpec.Resources != nil {
+		values["resources"] = managers.GuaranteedResources(grafanaSpec.Resources)
+	}
+	
+	// Ephemeral storage; the chart has no size limit for its emptyDir volumes
+	if storage := grafanaSpec.EphemeralStorage; storage != nil {
+		values["resources"] = managers.EphemeralStorageResources(values["resources"], storage)
+	}
+	
+	// Extra containers and volumes; the chart renders extraContainers from a YAML string
+	if len(grafanaSpec.ExtraContainers) > 0 {
+		extraContainers, err := yaml.Marshal(grafanaSpec.ExtraContainers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal extra containers: %w", err)
+		}
+		values["extraContainers"] = string(extraContainers)
+	}
+	if len(grafanaSpec.ExtraVolumes) > 0 {
+		values["extraContainerVolumes"] = grafanaSpec.ExtraVolumes
+	}
+	
+	// User-defined environment variables; the chart splits them by source
+	env := map[string]interface{}{}
+	envValueFrom := map[string]interface{}{}
+	for _, e := range grafanaSpec.Env {
+		if e.ValueFrom != nil {
+			envValueFrom[e.Name] = e.ValueFrom
+		} else {
+			env[e.Name] = e.Value
+		}
+	}
+	if len(env) > 0 {
+		values["env"] = env
+	}
+	if len(envValueFrom) > 0 {
+		values["envValueFrom"] = envValueFrom
+	}
+	var envFromSecrets, envFromConfigMaps []interface{}
+	for _, source := range grafanaSpec.EnvFrom {
+		switch {
+		case source.SecretRef != nil:
+			envFromSecrets = append(envFromSecrets, map[string]interface{}{
+				"name":     source.SecretRef.Name,
+				"optional": source.SecretRef.Optional != nil && *source.SecretRef.Optional,
+				"prefix":   source.Prefix,
+			})
+		case source.ConfigMapRef != nil:
+			envFromConfigMaps = append(envFromConfigMaps, map[string]interface{}{
+				"name":     source.ConfigMapRef.Name,
+				"optional": source.ConfigMapRef.Optional != nil && *source.ConfigMapRef.Optional,
+				"prefix":   source.Prefix,
+			})
 		}
 	}
+	if len(envFromSecrets) > 0 {
+		values["envFromSecrets"] = envFromSecrets
+	}
+	if len(envFromConfigMaps) > 0 {
+		values["envFromConfigMaps"] = envFromConfigMaps
+	}
+	
+	// Log level, debug while debug mode is on
+	values["grafana.ini"] = map[string]interface{}{
+		"log": map[string]interface{}{
+			"level": managers.LogLevel(platform, componentNameHelm, grafanaSpec.ComponentLogging),
+		},
+	}
 	
 	// Custom grafana.ini configuration
 	if grafanaSpec.Config != nil {
@@ -364,12 +458,11 @@ func (m *GrafanaManagerHelm) buildDataSources(platform *observabilityv1beta1.Obs
 	}
 	
 	// Add Loki data source if enabled
+	lokiURL := fmt.Sprintf("http://%s-loki.%s.svc.cluster.local:3100",
+		platform.Name,
+		platform.Namespace)
 	if platform.Spec.Components.Loki != nil && platform.Spec.Components.Loki.Enabled {
-		lokiURL := fmt.Sprintf("http://%s-loki.%s.svc.cluster.local:3100",
-			platform.Name,
-			platform.Namespace)
-		
-		datasources = append(datasources, map[string]interface{}{
+		lokiDS := map[string]interface{}{
 			"name":   "Loki",
 			"type":   "loki",
 			"url":    lokiURL,
@@ -377,7 +470,9 @@ func (m *GrafanaManagerHelm) buildDataSources(platform *observabilityv1beta1.Obs
 			"jsonData": map[string]interface{}{
 				"maxLines": 1000,
 			},
-		})
+		}
+		managers.SetTenantHeader(platform, lokiDS)
+		datasources = append(datasources, lokiDS)
 	}
 	
 	// Add Tempo data source if enabled
@@ -424,8 +519,17 @@ func (m *GrafanaManagerHelm) buildDataSources(platform *observabilityv1beta1.Obs
 			}
 		}
 		
+		managers.SetTenantHeader(platform, tempoDS)
 		datasources = append(datasources, tempoDS)
 	}
 	
+	// Add the per-team datasources going through the query access control proxy
+	for _, ds := range managers.QueryACLDatasources(platform, lokiURL) {
+		datasources = append(datasources, ds)
+	}
+	
+	// Add the profiling datasource
+	datasources = append(datasources, managers.ProfilingDatasources(platform)...)
+	
 	return datasources
 }
@@ -0,0 +1,179 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package grafana
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/managers"
+)
+
+const (
+	// imageRendererImage is the grafana-image-renderer image
+	imageRendererImage = "grafana/grafana-image-renderer"
+	// imageRendererPort is the port of the renderer's HTTP API
+	imageRendererPort = 8081
+	// labelImageRenderer is the component label of the renderer
+	labelImageRenderer = "grafana-image-renderer"
+)
+
+// reconcileImageRenderer deploys grafana-image-renderer and its Service, and
+// removes them when the renderer is disabled
+func (m *GrafanaManager) reconcileImageRenderer(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, grafanaSpec *observabilityv1beta1.GrafanaSpec) error {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.getImageRendererName(platform),
+			Namespace: platform.Namespace,
+		},
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.getImageRendererName(platform),
+			Namespace: platform.Namespace,
+		},
+	}
+	if !grafanaSpec.ImageRendererEnabled() {
+		for _, obj := range []client.Object{deployment, service} {
+			if err := m.Client.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete image renderer: %w", err)
+			}
+		}
+		return nil
+	}
+
+	renderer := grafanaSpec.ImageRenderer
+	labels := m.getImageRendererSelectorLabels(platform)
+
+	_, err := controllerutil.CreateOrUpdate(ctx, m.Client, service, func() error {
+		service.Labels = m.getImageRendererLabels(platform)
+		if err := controllerutil.SetControllerReference(platform, service, m.Scheme); err != nil {
+			return err
+		}
+		service.Spec.Type = corev1.ServiceTypeClusterIP
+		service.Spec.Selector = labels
+		service.Spec.Ports = []corev1.ServicePort{
+			{
+				Name:       "http",
+				Port:       imageRendererPort,
+				TargetPort: intstr.FromInt(imageRendererPort),
+				Protocol:   corev1.ProtocolTCP,
+			},
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create/update image renderer Service: %w", err)
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, m.Client, deployment, func() error {
+		deployment.Labels = m.getImageRendererLabels(platform)
+		if err := controllerutil.SetControllerReference(platform, deployment, m.Scheme); err != nil {
+			return err
+		}
+
+		replicas := renderer.GetReplicas()
+		deployment.Spec = appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "image-renderer",
+							Image: fmt.Sprintf("%s:%s", imageRendererImage, renderer.GetVersion()),
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "http",
+									ContainerPort: imageRendererPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							Env: []corev1.EnvVar{
+								{Name: "HTTP_PORT", Value: fmt.Sprintf("%d", imageRendererPort)},
+								// Render one dashboard per browser, so a crashed render
+								// does not take the others down
+								{Name: "RENDERING_MODE", Value: "clustered"},
+								{Name: "RENDERING_CLUSTERING_MODE", Value: "browser"},
+							},
+							Resources: renderer.Resources,
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									HTTPGet: &corev1.HTTPGetAction{
+										Path: "/",
+										Port: intstr.FromInt(imageRendererPort),
+									},
+								},
+								InitialDelaySeconds: 5,
+								PeriodSeconds:       10,
+							},
+							SecurityContext: &corev1.SecurityContext{
+								RunAsNonRoot:             &[]bool{true}[0],
+								RunAsUser:                &[]int64{472}[0],
+								AllowPrivilegeEscalation: &[]bool{false}[0],
+							},
+						},
+					},
+				},
+			},
+		}
+
+		// Schedule on the dedicated node pool if configured
+		managers.ApplyNodePool(&deployment.Spec.Template.Spec, platform)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create/update image renderer Deployment: %w", err)
+	}
+
+	log.FromContext(ctx).V(1).Info("Image renderer reconciled", "name", deployment.Name)
+	return nil
+}
+
+// imageRendererEnv points Grafana at the image renderer, and the renderer
+// back at Grafana for loading the rendered dashboards
+func (m *GrafanaManager) imageRendererEnv(platform *observabilityv1beta1.ObservabilityPlatform) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name:  "GF_RENDERING_SERVER_URL",
+			Value: fmt.Sprintf("http://%s.%s.svc:%d/render", m.getImageRendererName(platform), platform.Namespace, imageRendererPort),
+		},
+		{
+			Name:  "GF_RENDERING_CALLBACK_URL",
+			Value: fmt.Sprintf("http://%s.%s.svc:%d/", m.getServiceName(platform), platform.Namespace, defaultPort),
+		},
+	}
+}
+
+func (m *GrafanaManager) getImageRendererName(platform *observabilityv1beta1.ObservabilityPlatform) string {
+	return fmt.Sprintf("grafana-%s-image-renderer", platform.Name)
+}
+
+func (m *GrafanaManager) getImageRendererLabels(platform *observabilityv1beta1.ObservabilityPlatform) map[string]string {
+	labels := m.getLabels(platform)
+	labels["app.kubernetes.io/name"] = labelImageRenderer
+	labels["app.kubernetes.io/component"] = labelImageRenderer
+	return labels
+}
+
+func (m *GrafanaManager) getImageRendererSelectorLabels(platform *observabilityv1beta1.ObservabilityPlatform) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      labelImageRenderer,
+		"app.kubernetes.io/instance":  platform.Name,
+		"app.kubernetes.io/component": labelImageRenderer,
+	}
+}
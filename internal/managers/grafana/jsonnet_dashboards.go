@@ -0,0 +1,171 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/jsonnet"
+)
+
+const (
+	// jsonnetDashboardsVolume mounts the rendered Jsonnet dashboards
+	jsonnetDashboardsVolume = "jsonnet-dashboards"
+	// jsonnetDashboardsPath is where the rendered Jsonnet dashboards are
+	// provisioned from. It is not below the provisioned dashboards path, as
+	// mount points cannot be created in a ConfigMap volume.
+	jsonnetDashboardsPath = "/var/lib/grafana/jsonnet-dashboards"
+
+	// maxConfigMapSize leaves room for the metadata of a ConfigMap below the
+	// 1MiB object size limit
+	maxConfigMapSize = 1000 * 1024
+)
+
+// reconcileJsonnetDashboards renders the Jsonnet dashboard sources into the
+// ConfigMap provisioned by the jsonnet dashboard provider, and removes it when
+// no sources are configured
+func (m *GrafanaManager) reconcileJsonnetDashboards(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, grafanaSpec *observabilityv1beta1.GrafanaSpec) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.getJsonnetDashboardsConfigMapName(platform),
+			Namespace: platform.Namespace,
+		},
+	}
+	if len(grafanaSpec.JsonnetDashboards) == 0 {
+		if err := m.Delete(ctx, configMap); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete Jsonnet dashboards ConfigMap: %w", err)
+		}
+		return nil
+	}
+
+	dashboards := make(map[string]string)
+	size := 0
+	for _, source := range grafanaSpec.JsonnetDashboards {
+		rendered, err := m.renderJsonnetSource(ctx, platform.Namespace, source)
+		if err != nil {
+			return err
+		}
+		for name, data := range rendered {
+			key := configMapKey(source.ConfigMapRef.Name + "_" + name)
+			if _, ok := dashboards[key]; ok {
+				return fmt.Errorf("rendered Jsonnet dashboards include %s more than once", key)
+			}
+			dashboards[key] = string(data)
+			size += len(data)
+		}
+	}
+	if size > maxConfigMapSize {
+		return fmt.Errorf("rendered Jsonnet dashboards are %d bytes, more than a ConfigMap holds; import them with dashboardsFromGit instead", size)
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, m.Client, configMap, func() error {
+		configMap.Labels = m.getLabels(platform)
+		configMap.Labels["grafana_dashboard"] = "1"
+		if err := controllerutil.SetControllerReference(platform, configMap, m.Scheme); err != nil {
+			return err
+		}
+		configMap.Data = dashboards
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create/update Jsonnet dashboards ConfigMap: %w", err)
+	}
+
+	log.FromContext(ctx).V(1).Info("Jsonnet dashboards rendered", "dashboards", len(dashboards))
+	return nil
+}
+
+// renderJsonnetSource renders the sources of a Jsonnet dashboard ConfigMap
+// with its libraries
+func (m *GrafanaManager) renderJsonnetSource(ctx context.Context, namespace string, source observabilityv1beta1.JsonnetDashboardSource) (map[string][]byte, error) {
+	sources, err := m.readConfigMapFiles(ctx, namespace, source.ConfigMapRef.Name, "")
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		if jsonnet.IsSource(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("ConfigMap %s has no *.jsonnet sources", source.ConfigMapRef.Name)
+	}
+
+	files := sources
+	for _, library := range source.Libraries {
+		libraryFiles, err := m.readConfigMapFiles(ctx, namespace, library.ConfigMapRef.Name, library.Path)
+		if err != nil {
+			return nil, err
+		}
+		for name, data := range libraryFiles {
+			files[name] = data
+		}
+	}
+
+	renderer := &jsonnet.Renderer{Files: files, ExtVars: source.ExtVars}
+	rendered := make(map[string][]byte)
+	for _, name := range names {
+		dashboards, err := renderer.Render(name)
+		if err != nil {
+			return nil, fmt.Errorf("ConfigMap %s: %w", source.ConfigMapRef.Name, err)
+		}
+		for dashboard, data := range dashboards {
+			if _, ok := rendered[dashboard]; ok {
+				return nil, fmt.Errorf("ConfigMap %s: %s is rendered more than once", source.ConfigMapRef.Name, dashboard)
+			}
+			rendered[dashboard] = data
+		}
+	}
+	return rendered, nil
+}
+
+// readConfigMapFiles returns the keys of a ConfigMap as files below dir
+func (m *GrafanaManager) readConfigMapFiles(ctx context.Context, namespace, name, dir string) (map[string][]byte, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := m.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get Jsonnet ConfigMap %s: %w", name, err)
+	}
+
+	dir = strings.Trim(dir, "/")
+	files := make(map[string][]byte, len(configMap.Data)+len(configMap.BinaryData))
+	for key, value := range configMap.Data {
+		files[path.Join(dir, key)] = []byte(value)
+	}
+	for key, value := range configMap.BinaryData {
+		files[path.Join(dir, key)] = value
+	}
+	return files, nil
+}
+
+// configMapKey replaces the characters ConfigMap keys do not allow
+func configMapKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+func (m *GrafanaManager) getJsonnetDashboardsConfigMapName(platform *observabilityv1beta1.ObservabilityPlatform) string {
+	return fmt.Sprintf("grafana-%s-jsonnet-dashboards", platform.Name)
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package managers
+
+import (
+	"time"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/loglevel"
+)
+
+// LogLevel returns the log level a component runs at: debug while its debug
+// mode is active in status, else its own level or the global one
+func LogLevel(platform *observabilityv1beta1.ObservabilityPlatform, component string, logging observabilityv1beta1.ComponentLogging) string {
+	if DebugWindow(platform, component).Active(time.Now()) {
+		return loglevel.Debug
+	}
+	return ConfiguredLogLevel(platform, logging)
+}
+
+// ConfiguredLogLevel returns the log level of a component outside debug
+// mode: its own level, else the global one
+func ConfiguredLogLevel(platform *observabilityv1beta1.ObservabilityPlatform, logging observabilityv1beta1.ComponentLogging) string {
+	global := ""
+	if platform.Spec.Global != nil {
+		global = platform.Spec.Global.LogLevel
+	}
+	return loglevel.Level(global, logging.LogLevel)
+}
+
+// DebugWindow returns the debug window of a component from status, nil when
+// debug mode is off
+func DebugWindow(platform *observabilityv1beta1.ObservabilityPlatform, component string) *loglevel.Window {
+	status, ok := platform.Status.Debug[component]
+	if !ok {
+		return nil
+	}
+	return &loglevel.Window{Start: status.Start.Time, End: status.End.Time, Expired: status.Expired}
+}
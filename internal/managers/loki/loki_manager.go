@@ -24,7 +24,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/fips"
 	"github.com/gunjanjp/gunj-operator/internal/managers"
+	"github.com/gunjanjp/gunj-operator/internal/rollout"
+	"github.com/gunjanjp/gunj-operator/internal/scheduling"
+	"github.com/gunjanjp/gunj-operator/internal/zones"
 )
 
 const (
@@ -34,11 +38,17 @@ const (
 	// Default values
 	defaultHTTPPort       = 3100
 	defaultGRPCPort       = 9095
+	defaultMemberlistPort = 7946
 	defaultDataPath       = "/loki"
 	defaultWALPath        = "/wal"
+	rulesPath             = "/etc/loki-rules"
 	defaultImage          = "grafana/loki"
 	defaultCompactorImage = "grafana/loki"
 	
+	// Ring status page and flush endpoint of the ingesters for staged rollouts
+	ringPath  = "/ring"
+	flushPath = "/ingester/shutdown?flush=true&delete_ring_tokens=false&terminate=false"
+	
 	// Labels
 	labelComponent = "loki"
 	
@@ -193,12 +203,17 @@ func (m *LokiManager) GetStatus(ctx context.Context, platform *observabilityv1be
 		return status, nil
 	}
 	
-	// Check StatefulSet status
+	// Check StatefulSet status, aggregated over the zones when zone-aware
 	sts := &appsv1.StatefulSet{}
-	err := m.Client.Get(ctx, types.NamespacedName{
-		Name:      m.getStatefulSetName(platform),
-		Namespace: platform.Namespace,
-	}, sts)
+	var err error
+	if zoneAwareness := platform.Spec.Components.Loki.ZoneAwareness; zoneAwareness.IsEnabled() {
+		sts, err = managers.GetZonalStatefulSet(ctx, m.Client, platform.Namespace, m.getStatefulSetName(platform), zoneAwareness)
+	} else {
+		err = m.Client.Get(ctx, types.NamespacedName{
+			Name:      m.getStatefulSetName(platform),
+			Namespace: platform.Namespace,
+		}, sts)
+	}
 	
 	if err != nil {
 		status.Phase = "Failed"
@@ -416,6 +431,17 @@ func (m *LokiManager) reconcileS3Secret(ctx context.Context, platform *observabi
 func (m *LokiManager) reconcileConfigMap(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, lokiSpec *observabilityv1beta1.LokiSpec) error {
 	log := log.FromContext(ctx)
 	
+	// Generate loki.yaml
+	data := map[string]string{
+		"loki.yaml": m.generateLokiConfig(platform, lokiSpec),
+	}
+	
+	// Keep the current configuration until the new one passed -verify-config
+	image := fmt.Sprintf("%s:%s", defaultImage, lokiSpec.Version)
+	if ok, err := managers.CheckConfig(ctx, m.Client, m.Scheme, platform, componentName, image, data); err != nil || !ok {
+		return err
+	}
+	
 	configMap := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      m.getConfigMapName(platform),
@@ -432,12 +458,7 @@ func (m *LokiManager) reconcileConfigMap(ctx context.Context, platform *observab
 			return err
 		}
 		
-		// Generate loki.yaml
-		lokiYAML := m.generateLokiConfig(platform, lokiSpec)
-		
-		configMap.Data = map[string]string{
-			"loki.yaml": lokiYAML,
-		}
+		configMap.Data = data
 		
 		return nil
 	})
@@ -536,6 +557,17 @@ func (m *LokiManager) reconcileServices(ctx context.Context, platform *observabi
 			},
 		}
 		
+		if platform.Spec.Components.Loki.ZoneAwareness.IsEnabled() {
+			headlessService.Spec.Ports = append(headlessService.Spec.Ports, corev1.ServicePort{
+				Name:       "memberlist",
+				Port:       defaultMemberlistPort,
+				TargetPort: intstr.FromInt(defaultMemberlistPort),
+				Protocol:   corev1.ProtocolTCP,
+			})
+			// Ring members must find each other before they are ready
+			headlessService.Spec.PublishNotReadyAddresses = true
+		}
+		
 		return nil
 	})
 	
@@ -558,6 +590,21 @@ func (m *LokiManager) reconcileStatefulSet(ctx context.Context, platform *observ
 		},
 	}
 	
+	// Zone-aware ingesters run as one StatefulSet per zone
+	if lokiSpec.ZoneAwareness.IsEnabled() {
+		sts.Labels = m.getLabels(platform)
+		sts.Spec = m.buildStatefulSetSpec(platform, lokiSpec)
+		managers.ApplyStatefulSetUpdateStrategy(&sts.Spec, lokiSpec.UpdateStrategy)
+		if err := managers.ApplyConfigChecksum(ctx, m.Client, platform, componentName, lokiSpec.ReloadStrategy, &sts.Spec.Template); err != nil {
+			return err
+		}
+		if err := managers.ReconcileZonalStatefulSets(ctx, m.Client, m.Scheme, platform, sts, lokiSpec.ZoneAwareness); err != nil {
+			return err
+		}
+		log.V(1).Info("Zonal StatefulSets reconciled", "zones", lokiSpec.ZoneAwareness.Zones)
+		return nil
+	}
+	
 	_, err := controllerutil.CreateOrUpdate(ctx, m.Client, sts, func() error {
 		// Set labels
 		sts.Labels = m.getLabels(platform)
@@ -568,8 +615,23 @@ func (m *LokiManager) reconcileStatefulSet(ctx context.Context, platform *observ
 		}
 		
 		// Build StatefulSet spec
+		previous := sts.DeepCopy()
 		sts.Spec = m.buildStatefulSetSpec(platform, lokiSpec)
 		
+		// Roll configuration changes as requested
+		managers.ApplyStatefulSetUpdateStrategy(&sts.Spec, lokiSpec.UpdateStrategy)
+		
+		// Roll the pods on changes of referenced configuration
+		if err := managers.ApplyConfigChecksum(ctx, m.Client, platform, componentName, lokiSpec.ReloadStrategy, &sts.Spec.Template); err != nil {
+			return err
+		}
+		
+		// Release the ingesters one at a time if staged rollouts are enabled
+		managers.ApplyStagedRollout(previous, sts, lokiSpec.UpdateStrategy, rollout.Config{
+			Port:      defaultHTTPPort,
+			RingPath:  ringPath,
+			FlushPath: flushPath,
+		})
 		return nil
 	})
 	
@@ -577,6 +639,11 @@ func (m *LokiManager) reconcileStatefulSet(ctx context.Context, platform *observ
 		return fmt.Errorf("failed to create/update StatefulSet: %w", err)
 	}
 	
+	// Remove zonal StatefulSets left over from a zone-aware deployment
+	if err := managers.DeleteZonalStatefulSets(ctx, m.Client, sts); err != nil {
+		return err
+	}
+	
 	log.V(1).Info("StatefulSet reconciled", "name", sts.Name)
 	return nil
 }
@@ -709,6 +776,11 @@ func (m *LokiManager) buildStatefulSetSpec(platform *observabilityv1beta1.Observ
 				Name:      "wal",
 				MountPath: defaultWALPath,
 			},
+			{
+				// The rules belong to the tenant the platform writes its logs as
+				Name:      "rules",
+				MountPath: rulesPath + "/" + ruleTenant(platform),
+			},
 		},
 		LivenessProbe: &corev1.Probe{
 			ProbeHandler: corev1.ProbeHandler{
@@ -791,6 +863,17 @@ func (m *LokiManager) buildStatefulSetSpec(platform *observabilityv1beta1.Observ
 				EmptyDir: &corev1.EmptyDirVolumeSource{},
 			},
 		},
+		{
+			Name: "rules",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: RulesConfigMapName(platform.Name),
+					},
+					Optional: &[]bool{true}[0],
+				},
+			},
+		},
 	}
 	
 	// Build volume claim templates
@@ -819,6 +902,17 @@ func (m *LokiManager) buildStatefulSetSpec(platform *observabilityv1beta1.Observ
 		volumeClaimTemplates = append(volumeClaimTemplates, pvc)
 	}
 	
+	// Zone-aware ingesters gossip the ring over memberlist and read their
+	// zone from the environment
+	if lokiSpec.ZoneAwareness.IsEnabled() {
+		container.Args = append(container.Args, "-config.expand-env=true")
+		container.Ports = append(container.Ports, corev1.ContainerPort{
+			Name:          "memberlist",
+			ContainerPort: defaultMemberlistPort,
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
+	
 	// Build pod spec
 	podSpec := corev1.PodSpec{
 		ServiceAccountName: fmt.Sprintf("%s-observability", platform.Name),
@@ -841,10 +935,26 @@ func (m *LokiManager) buildStatefulSetSpec(platform *observabilityv1beta1.Observ
 		podSpec.Tolerations = platform.Spec.Global.Tolerations
 	}
 	
-	// Add affinity if specified
-	if platform.Spec.Global.Affinity != nil {
-		podSpec.Affinity = platform.Spec.Global.Affinity
-	}
+	// Add extra containers and volumes; they precede the priority settings so guaranteed QoS covers them
+	managers.ApplyExtraContainers(&podSpec, platform, "loki", lokiSpec.ExtraContainers, lokiSpec.ExtraVolumes)
+	
+	// Add user-defined environment variables to the loki container
+	managers.ApplyEnv(&podSpec, platform, "loki", componentName, lokiSpec.Env, lokiSpec.EnvFrom)
+	
+	// Override the health probes of the loki container
+	managers.ApplyProbes(&podSpec, componentName, lokiSpec.Probes)
+	
+	// Add affinity and topology spread constraints, component settings override global ones
+	scheduling.Apply(&podSpec, managers.PlacementFor(platform, lokiSpec.Affinity, lokiSpec.TopologySpreadConstraints), labels)
+	
+	// Add priority class and enforce guaranteed QoS if requested
+	managers.ApplyPriority(&podSpec, platform, "loki", lokiSpec.PriorityClassName, lokiSpec.GuaranteedQoS)
+	
+	// Schedule on the dedicated node pool if configured
+	managers.ApplyNodePool(&podSpec, platform)
+	
+	// Size ephemeral storage and emptyDir volumes, and move the WAL to a dedicated volume if configured
+	managers.ApplyEphemeralStorage(&podSpec, componentName, defaultWALPath, lokiSpec.EphemeralStorage)
 	
 	return appsv1.StatefulSetSpec{
 		ServiceName: m.getHeadlessServiceName(platform),
@@ -997,16 +1107,30 @@ func (m *LokiManager) generateLokiConfig(platform *observabilityv1beta1.Observab
 		retention = defaultRetention
 	}
 	
-	config := fmt.Sprintf(`auth_enabled: false
+	// Strict TLS settings for the server block in FIPS mode
+	serverTLS := ""
+	if platform.IsFIPSEnabled() {
+		profile := fips.Resolve(platform.Spec.Security.FIPS.MinTLSVersion, platform.Spec.Security.FIPS.CipherSuites)
+		serverTLS = fmt.Sprintf("\n  tls_min_version: %s\n  tls_cipher_suites: %s", profile.ServerMinVersion(), profile.ServerCipherSuites())
+	}
+
+	// Multi-tenant queries let the main Grafana organization query the logs
+	// of all tenants
+	querier := ""
+	if platform.IsMultiTenant() {
+		querier = "\n\nquerier:\n  multi_tenant_queries_enabled: true"
+	}
+
+	config := fmt.Sprintf(`auth_enabled: %t
 
 server:
   http_listen_port: %d
   grpc_listen_port: %d
-  log_level: %s
+  log_level: %s%s
 
 common:
   path_prefix: %s
-  storage:`, defaultHTTPPort, defaultGRPCPort, platform.Spec.Global.LogLevel, defaultDataPath)
+  storage:`, platform.IsMultiTenant(), defaultHTTPPort, defaultGRPCPort, managers.LogLevel(platform, componentName, lokiSpec.ComponentLogging), serverTLS, defaultDataPath)
 	
 	// Configure storage backend
 	if lokiSpec.S3 != nil && lokiSpec.S3.Enabled {
@@ -1027,12 +1151,37 @@ common:
       rules_directory: /loki/rules`
 	}
 	
-	config += fmt.Sprintf(`
+	// Zone-aware ingesters share the ring over memberlist, one replica per zone
+	commonRing := `
   replication_factor: 1
   ring:
     instance_addr: 127.0.0.1
     kvstore:
-      store: inmemory
+      store: inmemory`
+	lifecyclerRing := `
+    address: 127.0.0.1
+    ring:
+      kvstore:
+        store: inmemory
+      replication_factor: 1`
+	if zoneAwareness := lokiSpec.ZoneAwareness; zoneAwareness.IsEnabled() {
+		commonRing = fmt.Sprintf(`
+  replication_factor: %d
+  ring:
+    instance_availability_zone: ${%s}
+    zone_awareness_enabled: true
+    kvstore:
+      store: memberlist`, zoneAwareness.GetReplicationFactor(), zones.EnvVar)
+		lifecyclerRing = fmt.Sprintf(`
+    availability_zone: ${%s}
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: %d
+      zone_awareness_enabled: true`, zones.EnvVar, zoneAwareness.GetReplicationFactor())
+	}
+	
+	config += commonRing + fmt.Sprintf(`
 
 compactor:
   working_directory: %s/boltdb-shipper-compactor
@@ -1075,12 +1224,7 @@ ingester:
   wal:
     enabled: true
     dir: /wal
-  lifecycler:
-    address: 127.0.0.1
-    ring:
-      kvstore:
-        store: inmemory
-      replication_factor: 1
+  lifecycler:` + lifecyclerRing + `
     final_sleep: 0s
   chunk_idle_period: 1h
   max_chunk_age: 1h
@@ -1114,7 +1258,7 @@ ruler:
   storage:
     type: local
     local:
-      directory: /loki/rules-temp
+      directory: ` + rulesPath + `
   rule_path: /loki/rules-temp
   alertmanager_url: http://alertmanager:9093
   ring:
@@ -1131,11 +1275,29 @@ query_range:
 
 frontend:
   compress_responses: true
-  log_queries_longer_than: 5s`
+  log_queries_longer_than: 5s` + querier
+	
+	if lokiSpec.ZoneAwareness.IsEnabled() {
+		config += fmt.Sprintf(`
+
+memberlist:
+  bind_port: %d
+  join_members:
+    - %s.%s.svc.cluster.local:%d`, defaultMemberlistPort, m.getHeadlessServiceName(platform), platform.Namespace, defaultMemberlistPort)
+	}
 	
 	return config
 }
 
+// ruleTenant returns the tenant the ruler evaluates the rules as. Without
+// multi-tenancy Loki stores all logs as the default tenant.
+func ruleTenant(platform *observabilityv1beta1.ObservabilityPlatform) string {
+	if !platform.IsMultiTenant() {
+		return observabilityv1beta1.DefaultTenantID
+	}
+	return platform.TenantID()
+}
+
 // getStorageType returns the storage type based on configuration
 func (m *LokiManager) getStorageType(lokiSpec *observabilityv1beta1.LokiSpec) string {
 	if lokiSpec.S3 != nil && lokiSpec.S3.Enabled {
@@ -1153,6 +1315,12 @@ func (m *LokiManager) getConfigMapName(platform *observabilityv1beta1.Observabil
 	return fmt.Sprintf("loki-%s-config", platform.Name)
 }
 
+// RulesConfigMapName returns the name of the optional ConfigMap holding the
+// alerting rules Loki's ruler evaluates, one rule file per key
+func RulesConfigMapName(platform string) string {
+	return fmt.Sprintf("loki-%s-rules", platform)
+}
+
 func (m *LokiManager) getServiceName(platform *observabilityv1beta1.ObservabilityPlatform) string {
 	return fmt.Sprintf("loki-%s", platform.Name)
 }
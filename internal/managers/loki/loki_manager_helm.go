@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,6 +31,9 @@ const (
 	
 	// Default values
 	defaultPortHelm = 3100
+	
+	// helmWALPath is the WAL directory below the path prefix of the chart
+	helmWALPath = "/loki/wal"
 )
 
 // LokiManagerHelm manages Loki deployments using Helm
@@ -196,6 +200,15 @@ func (m *LokiManagerHelm) UpdateRetention(ctx context.Context, platform *observa
 	return m.ReconcileWithConfig(ctx, platform, nil)
 }
 
+// chartSelectorLabels returns the labels the chart sets on the single binary pods
+func chartSelectorLabels(platform *observabilityv1beta1.ObservabilityPlatform) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      chartName,
+		"app.kubernetes.io/instance":  helm.ReleaseName(platform, componentNameHelm),
+		"app.kubernetes.io/component": "single-binary",
+	}
+}
+
 // buildHelmValues builds Helm values for Loki
 func (m *LokiManagerHelm) buildHelmValues(
 	platform *observabilityv1beta1.ObservabilityPlatform,
@@ -208,6 +221,12 @@ func (m *LokiManagerHelm) buildHelmValues(
 		return nil, err
 	}
 	
+	// The chart runs the ingesters in a single StatefulSet, which can't be
+	// split into the zonal StatefulSets of the native manager
+	if lokiSpec.ZoneAwareness.IsEnabled() {
+		return nil, fmt.Errorf("zone awareness is not supported when Loki is deployed with Helm")
+	}
+	
 	// Loki deployment mode
 	// Use simple scalable mode for production
 	values["deploymentMode"] = "SingleBinary"
@@ -259,11 +278,11 @@ func (m *LokiManagerHelm) buildHelmValues(
 	
 	// Configure Loki config
 	lokiConfig := map[string]interface{}{
-		"auth_enabled": false,
+		"auth_enabled": platform.IsMultiTenant(),
 		"server": map[string]interface{}{
 			"http_listen_port": 3100,
 			"grpc_listen_port": 9095,
-			"log_level": "info",
+			"log_level": managers.LogLevel(platform, componentNameHelm, lokiSpec.ComponentLogging),
 		},
 		"common": map[string]interface{}{
 			"path_prefix": "/loki",
@@ -308,6 +327,14 @@ func (m *LokiManagerHelm) buildHelmValues(
 		},
 	}
 	
+	// Multi-tenant queries let the main Grafana organization query the logs
+	// of all tenants
+	if platform.IsMultiTenant() {
+		lokiConfig["querier"] = map[string]interface{}{
+			"multi_tenant_queries_enabled": true,
+		}
+	}
+	
 	// Configure retention
 	if lokiSpec.RetentionDays > 0 {
 		lokiConfig["limits_config"] = map[string]interface{}{
@@ -400,7 +427,7 @@ func (m *LokiManagerHelm) buildHelmValues(
 		"enabled": false,
 	}
 	
-	// Node selector, tolerations, and affinity from global settings
+	// Node selector and tolerations from global settings
 	if platform.Spec.Global != nil {
 		globalSettings := map[string]interface{}{}
 		
@@ -410,9 +437,6 @@ func (m *LokiManagerHelm) buildHelmValues(
 		if len(platform.Spec.Global.Tolerations) > 0 {
 			globalSettings["tolerations"] = platform.Spec.Global.Tolerations
 		}
-		if platform.Spec.Global.Affinity != nil {
-			globalSettings["affinity"] = platform.Spec.Global.Affinity
-		}
 		
 		// Apply to single binary
 		if sb, ok := values["singleBinary"].(map[string]interface{}); ok {
@@ -422,6 +446,58 @@ func (m *LokiManagerHelm) buildHelmValues(
 		}
 	}
 	
+	// Affinity and topology spread constraints, component settings override global ones
+	placement := managers.PlacementFor(platform, lokiSpec.Affinity, lokiSpec.TopologySpreadConstraints)
+	if sb, ok := values["singleBinary"].(map[string]interface{}); ok {
+		if placement.Affinity != nil {
+			sb["affinity"] = placement.Affinity
+		}
+		if constraints := placement.SpreadConstraints(chartSelectorLabels(platform)); len(constraints) > 0 {
+			sb["topologySpreadConstraints"] = constraints
+		}
+	}
+	
+	// Dedicated node pool, merged with the global node selector and tolerations
+	if nodeSelector, tolerations, ok := managers.NodePoolValues(platform); ok {
+		if sb, ok := values["singleBinary"].(map[string]interface{}); ok {
+			sb["nodeSelector"] = nodeSelector
+			sb["tolerations"] = tolerations
+		}
+	}
+	
+	// Priority class and guaranteed QoS, component settings override global ones
+	priority := managers.PriorityFor(platform, lokiSpec.PriorityClassName, lokiSpec.GuaranteedQoS)
+	if sb, ok := values["singleBinary"].(map[string]interface{}); ok {
+		if priority.PriorityClassName != "" {
+			sb["priorityClassName"] = priority.PriorityClassName
+		}
+		if priority.GuaranteedQoS && lokiSpec.Resources != nil {
+			sb["resources"] = managers.GuaranteedResources(lokiSpec.Resources)
+		}
+		if len(lokiSpec.ExtraContainers) > 0 {
+			sb["extraContainers"] = lokiSpec.ExtraContainers
+		}
+		if len(lokiSpec.ExtraVolumes) > 0 {
+			sb["extraVolumes"] = lokiSpec.ExtraVolumes
+		}
+		if len(lokiSpec.Env) > 0 {
+			sb["extraEnv"] = lokiSpec.Env
+		}
+		if len(lokiSpec.EnvFrom) > 0 {
+			sb["extraEnvFrom"] = lokiSpec.EnvFrom
+		}
+		
+		// Ephemeral storage and the dedicated WAL volume; the chart has no
+		// size limit for its emptyDir volumes
+		if storage := lokiSpec.EphemeralStorage; storage != nil {
+			sb["resources"] = managers.EphemeralStorageResources(sb["resources"], storage)
+			if volume, mount, ok := managers.WALVolume(storage, helmWALPath); ok {
+				sb["extraVolumes"] = append(append([]corev1.Volume{}, lokiSpec.ExtraVolumes...), volume)
+				sb["extraVolumeMounts"] = []corev1.VolumeMount{mount}
+			}
+		}
+	}
+	
 	// Apply any overrides
 	if overrides != nil {
 		values = m.ValueBuilder.MergeValues(values, overrides)
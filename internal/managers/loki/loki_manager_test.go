@@ -558,6 +558,46 @@ func TestLokiManager_GetServiceURL(t *testing.T) {
 	assert.Equal(t, "http://loki-test-platform.monitoring.svc.cluster.local:3100", url)
 }
 
+func TestLokiManager_generateLokiConfigMultiTenancy(t *testing.T) {
+	manager := &LokiManager{}
+	platform := &observabilityv1beta1.ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-platform", Namespace: "monitoring"},
+	}
+
+	config := manager.generateLokiConfig(platform, &observabilityv1beta1.LokiSpec{})
+	assert.Contains(t, config, "auth_enabled: false\n")
+	assert.NotContains(t, config, "multi_tenant_queries_enabled")
+	assert.Equal(t, observabilityv1beta1.DefaultTenantID, ruleTenant(platform))
+
+	// The tenants of the ingest gateway write to stores of their own
+	platform.Spec.IngestGateway = &observabilityv1beta1.IngestGatewaySpec{
+		Enabled: true,
+		Tenants: []observabilityv1beta1.IngestTenantSpec{{Name: "team-a"}},
+	}
+	config = manager.generateLokiConfig(platform, &observabilityv1beta1.LokiSpec{})
+	assert.Contains(t, config, "auth_enabled: true\n")
+	assert.Contains(t, config, "querier:\n  multi_tenant_queries_enabled: true")
+	assert.Equal(t, observabilityv1beta1.DefaultTenantID, ruleTenant(platform))
+
+	// Teams of query access control query only their tenants
+	platform.Spec.IngestGateway = nil
+	platform.Spec.QueryACL = &observabilityv1beta1.QueryACLSpec{
+		Enabled: true,
+		Teams:   []observabilityv1beta1.QueryACLTeam{{Name: "payments", OrgID: 2, Values: []string{"payments"}}},
+	}
+	config = manager.generateLokiConfig(platform, &observabilityv1beta1.LokiSpec{})
+	assert.Contains(t, config, "auth_enabled: false\n")
+	platform.Spec.QueryACL.Teams[0].Tenants = []string{"payments"}
+	config = manager.generateLokiConfig(platform, &observabilityv1beta1.LokiSpec{})
+	assert.Contains(t, config, "auth_enabled: true\n")
+	assert.Equal(t, []string{observabilityv1beta1.DefaultTenantID, "payments"}, platform.QueryTenantIDs())
+
+	platform.Spec.Global = &observabilityv1beta1.GlobalSettings{
+		Correlation: &observabilityv1beta1.CorrelationSpec{TenantID: "platform-a"},
+	}
+	assert.Equal(t, "platform-a", ruleTenant(platform))
+}
+
 func TestLokiManager_ConfigureStorage(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = observabilityv1beta1.AddToScheme(scheme)
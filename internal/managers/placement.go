@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package managers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/nodepool"
+	"github.com/gunjanjp/gunj-operator/internal/scheduling"
+)
+
+// PlacementFor resolves the pod placement of a component from the platform's
+// global settings and the component's own affinity and topology spread
+// constraints, which take precedence.
+func PlacementFor(platform *observabilityv1beta1.ObservabilityPlatform, affinity *corev1.Affinity, constraints []corev1.TopologySpreadConstraint) scheduling.Placement {
+	var global scheduling.Placement
+	if platform.Spec.Global != nil {
+		global = scheduling.Placement{
+			Affinity:                  platform.Spec.Global.Affinity,
+			TopologySpreadConstraints: platform.Spec.Global.TopologySpreadConstraints,
+		}
+	}
+	return scheduling.Resolve(global, scheduling.Placement{
+		Affinity:                  affinity,
+		TopologySpreadConstraints: constraints,
+	})
+}
+
+// PriorityFor resolves the priority class and QoS enforcement of a component
+// from the platform's global settings and the component's own overrides.
+func PriorityFor(platform *observabilityv1beta1.ObservabilityPlatform, priorityClassName string, guaranteedQoS *bool) scheduling.Priority {
+	var global scheduling.Priority
+	if platform.Spec.Global != nil {
+		global = scheduling.Priority{
+			PriorityClassName: platform.Spec.Global.PriorityClassName,
+			GuaranteedQoS:     platform.Spec.Global.GuaranteedQoS,
+		}
+	}
+	return scheduling.ResolvePriority(global, priorityClassName, guaranteedQoS)
+}
+
+// ApplyPriority sets the resolved priority class of a component on a pod spec
+// and enforces the Guaranteed QoS class if requested. Containers that cannot
+// be made guaranteed are logged, since the pod then stays Burstable.
+func ApplyPriority(podSpec *corev1.PodSpec, platform *observabilityv1beta1.ObservabilityPlatform, component, priorityClassName string, guaranteedQoS *bool) {
+	if incomplete := scheduling.ApplyPriority(podSpec, PriorityFor(platform, priorityClassName, guaranteedQoS)); len(incomplete) > 0 {
+		log.Log.WithName("scheduling").Info("Guaranteed QoS requested but containers have no CPU or memory resources set",
+			"platform", platform.Name, "namespace", platform.Namespace, "component", component, "containers", incomplete)
+	}
+}
+
+// GuaranteedResources returns a copy of the resources with limits equal to
+// requests for CPU and memory, the limit winning when both are set. It is
+// used for Helm values, where the chart renders the pod spec.
+func GuaranteedResources(resources *observabilityv1beta1.ResourceRequirements) *observabilityv1beta1.ResourceRequirements {
+	if resources == nil {
+		return nil
+	}
+
+	pick := func(limit, request string) string {
+		if limit != "" {
+			return limit
+		}
+		return request
+	}
+
+	var requests, limits observabilityv1beta1.ResourceList
+	if resources.Requests != nil {
+		requests = *resources.Requests
+	}
+	if resources.Limits != nil {
+		limits = *resources.Limits
+	}
+	equal := observabilityv1beta1.ResourceList{
+		CPU:    pick(limits.CPU, requests.CPU),
+		Memory: pick(limits.Memory, requests.Memory),
+	}
+	equalLimits := equal
+	return &observabilityv1beta1.ResourceRequirements{Requests: &equal, Limits: &equalLimits}
+}
+
+// NodePoolFor returns the dedicated node pool of the platform, or nil if the
+// components are not scheduled on a dedicated pool.
+func NodePoolFor(platform *observabilityv1beta1.ObservabilityPlatform) *nodepool.Pool {
+	if platform.Spec.Global == nil || !platform.Spec.Global.NodePool.IsEnabled() {
+		return nil
+	}
+	spec := platform.Spec.Global.NodePool
+	return &nodepool.Pool{
+		LabelKey:    spec.GetLabelKey(),
+		Name:        spec.GetName(),
+		TaintEffect: corev1.TaintEffect(spec.GetTaintEffect()),
+	}
+}
+
+// ApplyNodePool adds the node selector and toleration of the platform's
+// dedicated node pool to a pod spec, if configured.
+func ApplyNodePool(podSpec *corev1.PodSpec, platform *observabilityv1beta1.ObservabilityPlatform) {
+	if pool := NodePoolFor(platform); pool != nil {
+		nodepool.Apply(podSpec, *pool)
+	}
+}
+
+// NodePoolValues returns the Helm node selector and tolerations values of
+// the platform's dedicated node pool merged with the global ones. It returns
+// false if no dedicated node pool is configured.
+func NodePoolValues(platform *observabilityv1beta1.ObservabilityPlatform) (map[string]string, []interface{}, bool) {
+	pool := NodePoolFor(platform)
+	if pool == nil {
+		return nil, nil, false
+	}
+
+	nodeSelector := map[string]string{}
+	for k, v := range platform.Spec.Global.NodeSelector {
+		nodeSelector[k] = v
+	}
+	nodeSelector[pool.LabelKey] = pool.Name
+
+	tolerations := make([]interface{}, 0, len(platform.Spec.Global.Tolerations)+1)
+	for _, t := range platform.Spec.Global.Tolerations {
+		tolerations = append(tolerations, t)
+	}
+	tolerations = append(tolerations, pool.Toleration())
+
+	return nodeSelector, tolerations, true
+}
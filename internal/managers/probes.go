@@ -0,0 +1,27 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package managers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/probes"
+)
+
+// ApplyProbes overrides the liveness, readiness and startup probes of the
+// named container with the probe settings of a component
+func ApplyProbes(podSpec *corev1.PodSpec, container string, spec *observabilityv1beta1.ProbesSpec) {
+	if spec == nil {
+		return
+	}
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name == container {
+			probes.Apply(&podSpec.Containers[i], spec)
+		}
+	}
+}
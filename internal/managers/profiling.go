@@ -0,0 +1,24 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package managers
+
+import (
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/profiling"
+)
+
+// ProfilingDatasources returns the Grafana datasource of a platform's
+// profiling backend, if it runs one with the datasource enabled
+func ProfilingDatasources(platform *observabilityv1beta1.ObservabilityPlatform) []map[string]interface{} {
+	components := platform.Spec.Components
+	if !components.ProfilingEnabled() || !components.Profiling.DatasourceEnabled() {
+		return nil
+	}
+	return []map[string]interface{}{
+		profiling.Datasource(platform.Name, platform.Namespace, components.Profiling.GetBackend()),
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/mixins"
+	"github.com/gunjanjp/gunj-operator/internal/runbooks"
+)
+
+// ruleFile is the Prometheus rule file format
+type ruleFile struct {
+	Groups []ruleGroup `yaml:"groups"`
+}
+
+type ruleGroup struct {
+	Name     string `yaml:"name"`
+	Interval string `yaml:"interval,omitempty"`
+	Limit    int32  `yaml:"limit,omitempty"`
+	Rules    []rule `yaml:"rules"`
+}
+
+type rule struct {
+	Alert       string            `yaml:"alert,omitempty"`
+	Record      string            `yaml:"record,omitempty"`
+	Expr        string            `yaml:"expr"`
+	For         string            `yaml:"for,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// generateAlertingRules renders spec.alerting.rules as a Prometheus rule file,
+// adding a runbook_url annotation from the runbook registry to every alert.
+// The groups of spec.alerting.recordingRules follow the alerting rules.
+// It returns an empty string if there are no rules.
+func (m *PrometheusManager) generateAlertingRules(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) (string, error) {
+	if !hasRules(platform) {
+		return "", nil
+	}
+
+	registry, err := m.runbookRegistry(ctx, platform)
+	if err != nil {
+		return "", err
+	}
+
+	var groups []ruleGroup
+	group := ruleGroup{Name: fmt.Sprintf("%s.rules", platform.Name)}
+	for _, r := range platform.Spec.Alerting.Rules {
+		annotations := make(map[string]string, len(r.Annotations)+1)
+		for k, v := range r.Annotations {
+			annotations[k] = v
+		}
+		if registry != nil {
+			if annotations, err = registry.Annotate(annotations, r.RunbookKey, r.Name); err != nil {
+				return "", fmt.Errorf("alerting rule %s: %w", r.Name, err)
+			}
+		}
+
+		group.Rules = append(group.Rules, rule{
+			Alert:       r.Name,
+			Expr:        r.Expression,
+			For:         r.Duration,
+			Labels:      r.Labels,
+			Annotations: annotations,
+		})
+	}
+
+	if len(group.Rules) > 0 {
+		groups = append(groups, group)
+	}
+
+	for _, g := range platform.Spec.Alerting.RecordingRules {
+		recording := ruleGroup{Name: g.Name, Interval: g.Interval, Limit: g.Limit}
+		for _, r := range g.Rules {
+			recording.Rules = append(recording.Rules, rule{
+				Record: r.Record,
+				Expr:   r.Expr,
+				Labels: r.Labels,
+			})
+		}
+		groups = append(groups, recording)
+	}
+
+	data, err := yaml.Marshal(ruleFile{Groups: groups})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal alerting rules: %w", err)
+	}
+	return string(data), nil
+}
+
+// hasRules returns true if the platform defines alerting or recording rules
+func hasRules(platform *observabilityv1beta1.ObservabilityPlatform) bool {
+	alerting := platform.Spec.Alerting
+	return alerting != nil && (len(alerting.Rules) > 0 || len(alerting.RecordingRules) > 0)
+}
+
+// addMixinRules adds the rule files of the platform's mixins to the
+// configuration files. The mixin sync renders them into their own ConfigMap,
+// as rendering fetches the mixins from Git.
+func (m *PrometheusManager) addMixinRules(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, data map[string]string) error {
+	if !platform.Spec.ObservabilityMixins.HasRules() {
+		return nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := m.Client.Get(ctx, types.NamespacedName{Name: mixins.RulesConfigMapName(platform.Name), Namespace: platform.Namespace}, configMap)
+	if errors.IsNotFound(err) {
+		// Not rendered yet
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get mixin rules: %w", err)
+	}
+	for name, rules := range configMap.Data {
+		if strings.HasPrefix(name, mixins.RulesFilePrefix) {
+			data[name] = rules
+		}
+	}
+	return nil
+}
+
+// runbookRegistry builds the runbook registry of the platform, or nil if none is configured
+func (m *PrometheusManager) runbookRegistry(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) (*runbooks.Registry, error) {
+	spec := platform.Spec.Alerting.Runbooks
+	if spec == nil {
+		return nil, nil
+	}
+
+	var configMapData map[string]string
+	if spec.ConfigMapRef != nil {
+		configMap := &corev1.ConfigMap{}
+		key := types.NamespacedName{Name: spec.ConfigMapRef.Name, Namespace: platform.Namespace}
+		if err := m.Client.Get(ctx, key, configMap); err != nil {
+			return nil, fmt.Errorf("failed to get runbook ConfigMap %s: %w", spec.ConfigMapRef.Name, err)
+		}
+		configMapData = configMap.Data
+	}
+
+	return runbooks.NewRegistry(spec.BaseURL, spec.Entries, configMapData, spec.DefaultKey), nil
+}
@@ -9,7 +9,9 @@ package prometheus
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -24,7 +26,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/correlation"
+	"github.com/gunjanjp/gunj-operator/internal/exporters"
+	"github.com/gunjanjp/gunj-operator/internal/fips"
+	"github.com/gunjanjp/gunj-operator/internal/labeltransition"
 	"github.com/gunjanjp/gunj-operator/internal/managers"
+	"github.com/gunjanjp/gunj-operator/internal/mixins"
+	"github.com/gunjanjp/gunj-operator/internal/querylog"
+	"github.com/gunjanjp/gunj-operator/internal/scheduling"
+	"github.com/gunjanjp/gunj-operator/internal/selfmonitoring"
+	"github.com/gunjanjp/gunj-operator/internal/thanos"
 )
 
 const (
@@ -37,6 +48,9 @@ const (
 	defaultDataPath    = "/prometheus"
 	defaultImage       = "prom/prometheus"
 	
+	// alertingRulesFile is the ConfigMap key of the operator-generated alerting rules
+	alertingRulesFile = "alerting-rules.yml"
+	
 	// Labels
 	labelComponent = "prometheus"
 )
@@ -70,7 +84,10 @@ func (m *PrometheusManager) ReconcileWithConfig(ctx context.Context, platform *o
 		return nil
 	}
 	
-	prometheusSpec := platform.Spec.Components.Prometheus
+	prometheusSpec, err := resolveValuesFrom(ctx, m.Client, platform, platform.Spec.Components.Prometheus)
+	if err != nil {
+		return err
+	}
 	log.Info("Reconciling Prometheus", "version", prometheusSpec.Version)
 	
 	// 1. Create ConfigMap
@@ -83,6 +100,11 @@ func (m *PrometheusManager) ReconcileWithConfig(ctx context.Context, platform *o
 		return fmt.Errorf("failed to reconcile Service: %w", err)
 	}
 	
+	// Expose the Store API of the Thanos sidecars
+	if err := m.reconcileThanosSidecarService(ctx, platform, prometheusSpec); err != nil {
+		return err
+	}
+	
 	// 3. Create StatefulSet
 	if err := m.reconcileStatefulSet(ctx, platform, prometheusSpec); err != nil {
 		return fmt.Errorf("failed to reconcile StatefulSet: %w", err)
@@ -129,6 +151,12 @@ func (m *PrometheusManager) Delete(ctx context.Context, platform *observabilityv
 				Namespace: platform.Namespace,
 			},
 		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      thanos.SidecarServiceName(platform.Name),
+				Namespace: platform.Namespace,
+			},
+		},
 		&corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      m.getConfigMapName(platform),
@@ -283,6 +311,48 @@ func (m *PrometheusManager) ScaleReplicas(ctx context.Context, platform *observa
 func (m *PrometheusManager) reconcileConfigMap(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, prometheusSpec *observabilityv1beta1.PrometheusSpec) error {
 	log := log.FromContext(ctx)
 	
+	// Render alerting rules before touching the ConfigMap so an unknown
+	// runbook key fails the reconcile instead of dropping the rules
+	alertingRules, err := m.generateAlertingRules(ctx, platform)
+	if err != nil {
+		return err
+	}
+	
+	// Generate prometheus.yml
+	data := map[string]string{
+		"prometheus.yml": m.generatePrometheusConfig(platform, prometheusSpec),
+	}
+	
+	// Add additional scrape configs if provided
+	if prometheusSpec.AdditionalScrapeConfigs != "" {
+		data["additional-scrape-configs.yml"] = prometheusSpec.AdditionalScrapeConfigs
+	}
+	
+	// Add operator-generated alerting rules
+	if alertingRules != "" {
+		data[alertingRulesFile] = alertingRules
+	}
+	
+	// Add the rule files of the installed mixins
+	if err := m.addMixinRules(ctx, platform, data); err != nil {
+		return err
+	}
+	
+	// Add the default alerts of the platform's exporters
+	exporterRules, err := exporters.Rules(managers.ExporterAlerts(platform))
+	if err != nil {
+		return err
+	}
+	if exporterRules != "" {
+		data[exporters.RulesFile] = exporterRules
+	}
+	
+	// Keep the current configuration until the new one passed promtool
+	image := fmt.Sprintf("%s:%s", defaultImage, prometheusSpec.Version)
+	if ok, err := managers.CheckConfig(ctx, m.Client, m.Scheme, platform, componentName, image, data); err != nil || !ok {
+		return err
+	}
+	
 	configMap := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      m.getConfigMapName(platform),
@@ -290,7 +360,7 @@ func (m *PrometheusManager) reconcileConfigMap(ctx context.Context, platform *ob
 		},
 	}
 	
-	_, err := controllerutil.CreateOrUpdate(ctx, m.Client, configMap, func() error {
+	_, err = controllerutil.CreateOrUpdate(ctx, m.Client, configMap, func() error {
 		// Set labels
 		configMap.Labels = m.getLabels(platform)
 		
@@ -299,17 +369,7 @@ func (m *PrometheusManager) reconcileConfigMap(ctx context.Context, platform *ob
 			return err
 		}
 		
-		// Generate prometheus.yml
-		prometheusYAML := m.generatePrometheusConfig(platform, prometheusSpec)
-		
-		configMap.Data = map[string]string{
-			"prometheus.yml": prometheusYAML,
-		}
-		
-		// Add additional scrape configs if provided
-		if prometheusSpec.AdditionalScrapeConfigs != "" {
-			configMap.Data["additional-scrape-configs.yml"] = prometheusSpec.AdditionalScrapeConfigs
-		}
+		configMap.Data = data
 		
 		return nil
 	})
@@ -390,7 +450,11 @@ func (m *PrometheusManager) reconcileStatefulSet(ctx context.Context, platform *
 		// Build StatefulSet spec
 		sts.Spec = m.buildStatefulSetSpec(platform, prometheusSpec)
 		
-		return nil
+		// Roll configuration changes as requested
+		managers.ApplyStatefulSetUpdateStrategy(&sts.Spec, prometheusSpec.UpdateStrategy)
+		
+		// Roll the pods on changes of referenced configuration
+		return managers.ApplyConfigChecksum(ctx, m.Client, platform, componentName, prometheusSpec.ReloadStrategy, &sts.Spec.Template)
 	})
 	
 	if err != nil {
@@ -479,6 +543,7 @@ func (m *PrometheusManager) buildStatefulSetSpec(platform *observabilityv1beta1.
 			"--web.console.libraries=/usr/share/prometheus/console_libraries",
 			"--web.console.templates=/usr/share/prometheus/consoles",
 			"--web.enable-lifecycle",
+			"--log.level=" + managers.LogLevel(platform, componentName, prometheusSpec.ComponentLogging),
 		},
 		Resources: prometheusSpec.Resources,
 		VolumeMounts: []corev1.VolumeMount{
@@ -533,6 +598,31 @@ func (m *PrometheusManager) buildStatefulSetSpec(platform *observabilityv1beta1.
 		}
 	}
 	
+	// Accept the samples Alloy and the ingest gateway remote write
+	if platform.Spec.Components.AlloyCollectsMetrics() || platform.Spec.IngestGateway.Accepts(observabilityv1beta1.SignalMetrics) {
+		container.Args = append(container.Args, "--web.enable-remote-write-receiver")
+	}
+	// Accept the OTLP metrics forwarded by the ingest gateway
+	if platform.Spec.IngestGateway.Accepts(observabilityv1beta1.SignalMetrics) {
+		container.Args = append(container.Args, "--enable-feature=otlp-write-receiver")
+	}
+	
+	// Protect Prometheus from long and expensive queries
+	if limits := prometheusSpec.QueryLimits; limits != nil {
+		if limits.Timeout != "" {
+			container.Args = append(container.Args, fmt.Sprintf("--query.timeout=%s", limits.Timeout))
+		}
+		if limits.MaxSamples > 0 {
+			container.Args = append(container.Args, fmt.Sprintf("--query.max-samples=%d", limits.MaxSamples))
+		}
+		if limits.MaxConcurrency > 0 {
+			container.Args = append(container.Args, fmt.Sprintf("--query.max-concurrency=%d", limits.MaxConcurrency))
+		}
+		if limits.LookbackDelta != "" {
+			container.Args = append(container.Args, fmt.Sprintf("--query.lookback-delta=%s", limits.LookbackDelta))
+		}
+	}
+	
 	// Build volumes
 	volumes := []corev1.Volume{
 		{
@@ -595,11 +685,32 @@ func (m *PrometheusManager) buildStatefulSetSpec(platform *observabilityv1beta1.
 		podSpec.Tolerations = platform.Spec.Global.Tolerations
 	}
 	
-	// Add affinity if specified
-	if platform.Spec.Global.Affinity != nil {
-		podSpec.Affinity = platform.Spec.Global.Affinity
+	// Upload the blocks to object storage with the Thanos sidecar
+	if prometheusSpec.Thanos.IsEnabled() {
+		addThanosSidecar(&podSpec, prometheusSpec.Thanos)
 	}
 	
+	// Add extra containers and volumes; they precede the priority settings so guaranteed QoS covers them
+	managers.ApplyExtraContainers(&podSpec, platform, "prometheus", prometheusSpec.ExtraContainers, prometheusSpec.ExtraVolumes)
+	
+	// Add user-defined environment variables to the prometheus container
+	managers.ApplyEnv(&podSpec, platform, "prometheus", componentName, prometheusSpec.Env, prometheusSpec.EnvFrom)
+	
+	// Override the health probes of the prometheus container
+	managers.ApplyProbes(&podSpec, componentName, prometheusSpec.Probes)
+	
+	// Add affinity and topology spread constraints, component settings override global ones
+	scheduling.Apply(&podSpec, managers.PlacementFor(platform, prometheusSpec.Affinity, prometheusSpec.TopologySpreadConstraints), labels)
+	
+	// Add priority class and enforce guaranteed QoS if requested
+	managers.ApplyPriority(&podSpec, platform, "prometheus", prometheusSpec.PriorityClassName, prometheusSpec.GuaranteedQoS)
+	
+	// Schedule on the dedicated node pool if configured
+	managers.ApplyNodePool(&podSpec, platform)
+	
+	// Size ephemeral storage and emptyDir volumes, and move the WAL to a dedicated volume if configured
+	managers.ApplyEphemeralStorage(&podSpec, componentName, defaultDataPath+"/wal", prometheusSpec.EphemeralStorage)
+	
 	return appsv1.StatefulSetSpec{
 		ServiceName: m.getServiceName(platform),
 		Replicas:    &replicas,
@@ -622,18 +733,21 @@ func (m *PrometheusManager) generatePrometheusConfig(platform *observabilityv1be
   scrape_interval: 15s
   evaluation_interval: 15s`
 	
-	// Add external labels
-	if len(prometheusSpec.ExternalLabels) > 0 || len(platform.Spec.Global.ExternalLabels) > 0 {
+	// Write the query log to stdout, where the operator reads it for slow-query reports
+	if prometheusSpec.QueryLog.IsEnabled() {
+		config += "\n  query_log_file: " + querylog.LogFile
+	}
+	
+	// Add external labels, with the replica label of the Thanos blocks
+	if labels := thanosExternalLabels(externalLabels(platform, prometheusSpec), prometheusSpec); len(labels) > 0 {
 		config += "\n  external_labels:"
-		
-		// Global external labels first
-		for k, v := range platform.Spec.Global.ExternalLabels {
-			config += fmt.Sprintf("\n    %s: %s", k, v)
+		keys := make([]string, 0, len(labels))
+		for k := range labels {
+			keys = append(keys, k)
 		}
-		
-		// Component-specific external labels (override global)
-		for k, v := range prometheusSpec.ExternalLabels {
-			config += fmt.Sprintf("\n    %s: %s", k, v)
+		sort.Strings(keys)
+		for _, k := range keys {
+			config += fmt.Sprintf("\n    %s: %s", k, labels[k])
 		}
 	}
 	
@@ -647,11 +761,30 @@ alerting:
           # - alertmanager:9093`
 	
 	// Add rule files
-	config += `
+	exporterAlerts := len(managers.ExporterAlerts(platform)) > 0
+	if hasRules(platform) || platform.Spec.ObservabilityMixins.HasRules() || exporterAlerts {
+		config += `
+
+rule_files:`
+		if hasRules(platform) {
+			config += `
+  - "/etc/prometheus/` + alertingRulesFile + `"`
+		}
+		if platform.Spec.ObservabilityMixins.HasRules() {
+			config += `
+  - "/etc/prometheus/` + mixins.RulesFilePrefix + `*.yml"`
+		}
+		if exporterAlerts {
+			config += `
+  - "/etc/prometheus/` + exporters.RulesFile + `"`
+		}
+	} else {
+		config += `
 
 rule_files:
   # - "first_rules.yml"
   # - "second_rules.yml"`
+	}
 	
 	// Add scrape configs
 	config += `
@@ -713,19 +846,26 @@ scrape_configs:
         action: replace
         target_label: kubernetes_pod_name`
 	
+	// Scrape the platform's exporters
+	config += exporters.ScrapeConfigs(managers.Exporters(platform))
+	
+	// Scrape the operator managing the platform
+	if platform.Spec.SelfMonitoring.IsEnabled() {
+		config += selfmonitoring.ScrapeConfig()
+	}
+	
 	// Add remote write configuration if specified
 	if len(prometheusSpec.RemoteWrite) > 0 {
 		config += "\n\nremote_write:"
-		for _, rw := range prometheusSpec.RemoteWrite {
-			config += fmt.Sprintf("\n  - url: %s", rw.URL)
-			if rw.RemoteTimeout != "" {
-				config += fmt.Sprintf("\n    remote_timeout: %s", rw.RemoteTimeout)
-			}
-			if len(rw.Headers) > 0 {
-				config += "\n    headers:"
-				for k, v := range rw.Headers {
-					config += fmt.Sprintf("\n      %s: %s", k, v)
-				}
+		// While external labels transition, every target also receives the
+		// series rewritten to the previous labels
+		restore := previousLabelsRelabeling(platform, prometheusSpec)
+		replica := thanosReplicaRelabeling(prometheusSpec)
+		for i, rw := range prometheusSpec.RemoteWrite {
+			config += remoteWriteConfig(platform, rw, "", replica)
+			if len(restore) > 0 {
+				relabel := append(append([]labeltransition.RelabelConfig{}, replica...), restore...)
+				config += remoteWriteConfig(platform, rw, previousLabelsQueueName(platform, i), relabel)
 			}
 		}
 	}
@@ -733,7 +873,72 @@ scrape_configs:
 	return config
 }
 
+// remoteWriteConfig renders a remote_write entry, named and with write
+// relabel configs if set
+func remoteWriteConfig(platform *observabilityv1beta1.ObservabilityPlatform, rw observabilityv1beta1.RemoteWriteSpec, name string, relabel []labeltransition.RelabelConfig) string {
+	config := fmt.Sprintf("\n  - url: %s", rw.URL)
+	if name != "" {
+		config += fmt.Sprintf("\n    name: %s", name)
+	}
+	if rw.RemoteTimeout != "" {
+		config += fmt.Sprintf("\n    remote_timeout: %s", rw.RemoteTimeout)
+	}
+	if len(rw.Headers) > 0 {
+		config += "\n    headers:"
+		for k, v := range rw.Headers {
+			config += fmt.Sprintf("\n      %s: %s", k, v)
+		}
+	}
+	if len(relabel) > 0 {
+		config += "\n    write_relabel_configs:"
+		for _, r := range relabel {
+			config += fmt.Sprintf("\n      - source_labels: [%s]", strings.Join(r.SourceLabels, ", "))
+			config += fmt.Sprintf("\n        regex: %q", r.Regex)
+			config += fmt.Sprintf("\n        target_label: %s", r.TargetLabel)
+			config += fmt.Sprintf("\n        replacement: %q", r.Replacement)
+		}
+	}
+	// The tls_config of Prometheus clients has no cipher suites: the remote
+	// write target picks the suite, so only the minimum version is enforced.
+	// The admission webhook warns about it.
+	if platform.IsFIPSEnabled() {
+		profile := fips.Resolve(platform.Spec.Security.FIPS.MinTLSVersion, platform.Spec.Security.FIPS.CipherSuites)
+		config += "\n    tls_config:"
+		config += fmt.Sprintf("\n      min_version: %s", profile.PrometheusMinVersion())
+	}
+	return config
+}
+
+// previousLabelsRelabeling returns the write relabel configs restoring the
+// previous external labels while an external label transition is open
+func previousLabelsRelabeling(platform *observabilityv1beta1.ObservabilityPlatform, prometheusSpec *observabilityv1beta1.PrometheusSpec) []labeltransition.RelabelConfig {
+	transition := platform.Status.ExternalLabelTransition
+	if transition == nil || !time.Now().Before(transition.End.Time) {
+		return nil
+	}
+	return labeltransition.RestorePrevious(transition.PreviousLabels, externalLabels(platform, prometheusSpec))
+}
+
+// previousLabelsQueueName names the queue writing the previous external
+// labels to the i-th remote write target, so its remote storage metrics can
+// be told apart
+func previousLabelsQueueName(platform *observabilityv1beta1.ObservabilityPlatform, i int) string {
+	return fmt.Sprintf("%s-previous-labels-%d", platform.Name, i)
+}
+
 // Helper methods for resource naming
+// externalLabels returns the external labels of Prometheus: the global
+// labels, then the correlation labels shared with logs and traces, then the
+// labels of Prometheus. Admission rejects Prometheus labels conflicting with
+// the correlation labels.
+func externalLabels(platform *observabilityv1beta1.ObservabilityPlatform, prometheusSpec *observabilityv1beta1.PrometheusSpec) map[string]string {
+	var global map[string]string
+	if platform.Spec.Global != nil {
+		global = platform.Spec.Global.ExternalLabels
+	}
+	return correlation.Merge(global, platform.CorrelationLabels(), prometheusSpec.ExternalLabels)
+}
+
 func (m *PrometheusManager) getConfigMapName(platform *observabilityv1beta1.ObservabilityPlatform) string {
 	return fmt.Sprintf("prometheus-%s-config", platform.Name)
 }
@@ -9,7 +9,9 @@ package prometheus
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -17,7 +19,10 @@ import (
 
 	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
 	"github.com/gunjanjp/gunj-operator/internal/helm"
+	"github.com/gunjanjp/gunj-operator/internal/labeltransition"
 	"github.com/gunjanjp/gunj-operator/internal/managers"
+	"github.com/gunjanjp/gunj-operator/internal/querylog"
+	"github.com/gunjanjp/gunj-operator/internal/thanos"
 )
 
 const (
@@ -30,6 +35,9 @@ const (
 	
 	// Default values
 	defaultPort = 9090
+	
+	// helmDataPath is where the chart mounts the TSDB
+	helmDataPath = "/data"
 )
 
 // PrometheusManagerHelm manages Prometheus deployments using Helm
@@ -75,7 +83,10 @@ func (m *PrometheusManagerHelm) ReconcileWithConfig(ctx context.Context, platfor
 		return nil
 	}
 	
-	prometheusSpec := platform.Spec.Components.Prometheus
+	prometheusSpec, err := resolveValuesFrom(ctx, m.Client, platform, platform.Spec.Components.Prometheus)
+	if err != nil {
+		return err
+	}
 	logger.Info("Reconciling Prometheus", "version", prometheusSpec.Version)
 	
 	// Ensure repositories are configured
@@ -199,6 +210,15 @@ func (m *PrometheusManagerHelm) ScaleReplicas(ctx context.Context, platform *obs
 	return m.ReconcileWithConfig(ctx, platform, nil)
 }
 
+// serverSelectorLabels returns the labels the chart sets on the server pods
+func serverSelectorLabels(platform *observabilityv1beta1.ObservabilityPlatform) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      chartName,
+		"app.kubernetes.io/instance":  helm.ReleaseName(platform, componentName),
+		"app.kubernetes.io/component": "server",
+	}
+}
+
 // buildHelmValues builds Helm values for Prometheus
 func (m *PrometheusManagerHelm) buildHelmValues(
 	platform *observabilityv1beta1.ObservabilityPlatform,
@@ -232,33 +252,53 @@ func (m *PrometheusManagerHelm) buildHelmValues(
 		"evaluation_interval": "15s",
 	}
 	
-	// External labels
-	externalLabels := make(map[string]interface{})
-	
-	// Add global external labels
-	if platform.Spec.Global != nil {
-		for k, v := range platform.Spec.Global.ExternalLabels {
-			externalLabels[k] = v
+	// External labels: global, then correlation, then Prometheus-specific
+	// and the replica label of the Thanos blocks
+	if labels := thanosExternalLabels(externalLabels(platform, prometheusSpec), prometheusSpec); len(labels) > 0 {
+		external := make(map[string]interface{}, len(labels))
+		for k, v := range labels {
+			external[k] = v
 		}
+		global["external_labels"] = external
 	}
 	
-	// Add Prometheus-specific external labels (override global)
-	if prometheusSpec.ExternalLabels != nil {
-		for k, v := range prometheusSpec.ExternalLabels {
-			externalLabels[k] = v
-		}
+	// Query log for slow-query reports
+	if prometheusSpec.QueryLog.IsEnabled() {
+		global["query_log_file"] = querylog.LogFile
 	}
 	
-	if len(externalLabels) > 0 {
-		global["external_labels"] = externalLabels
+	server["global"] = global
+	
+	// Query limits, passed as extra flags next to the chart's default flag
+	if limits := prometheusSpec.QueryLimits; limits != nil {
+		extraFlags := []interface{}{"web.enable-lifecycle"}
+		if limits.Timeout != "" {
+			extraFlags = append(extraFlags, "query.timeout="+limits.Timeout)
+		}
+		if limits.MaxSamples > 0 {
+			extraFlags = append(extraFlags, fmt.Sprintf("query.max-samples=%d", limits.MaxSamples))
+		}
+		if limits.MaxConcurrency > 0 {
+			extraFlags = append(extraFlags, fmt.Sprintf("query.max-concurrency=%d", limits.MaxConcurrency))
+		}
+		if limits.LookbackDelta != "" {
+			extraFlags = append(extraFlags, "query.lookback-delta="+limits.LookbackDelta)
+		}
+		server["extraFlags"] = extraFlags
 	}
 	
-	server["global"] = global
+	// Log level, debug while debug mode is on
+	server["extraArgs"] = map[string]interface{}{
+		"log.level": managers.LogLevel(platform, componentName, prometheusSpec.ComponentLogging),
+	}
 	
 	// Remote write configuration
 	if len(prometheusSpec.RemoteWrite) > 0 {
 		remoteWrite := make([]interface{}, 0, len(prometheusSpec.RemoteWrite))
-		for _, rw := range prometheusSpec.RemoteWrite {
+		// Dual-write the previous external labels while they transition
+		restore := previousLabelsRelabeling(platform, prometheusSpec)
+		replica := thanosReplicaRelabeling(prometheusSpec)
+		for i, rw := range prometheusSpec.RemoteWrite {
 			rwConfig := map[string]interface{}{
 				"url": rw.URL,
 			}
@@ -268,7 +308,20 @@ func (m *PrometheusManagerHelm) buildHelmValues(
 			if len(rw.Headers) > 0 {
 				rwConfig["headers"] = rw.Headers
 			}
+			if len(replica) > 0 {
+				rwConfig["write_relabel_configs"] = relabelValues(replica)
+			}
 			remoteWrite = append(remoteWrite, rwConfig)
+			
+			if len(restore) > 0 {
+				previous := make(map[string]interface{}, len(rwConfig)+2)
+				for k, v := range rwConfig {
+					previous[k] = v
+				}
+				previous["name"] = previousLabelsQueueName(platform, i)
+				previous["write_relabel_configs"] = relabelValues(append(append([]labeltransition.RelabelConfig{}, replica...), restore...))
+				remoteWrite = append(remoteWrite, previous)
+			}
 		}
 		server["remoteWrite"] = remoteWrite
 	}
@@ -315,7 +368,7 @@ func (m *PrometheusManagerHelm) buildHelmValues(
 		},
 	}
 	
-	// Node selector, tolerations, and affinity from global settings
+	// Node selector and tolerations from global settings
 	if platform.Spec.Global != nil {
 		if len(platform.Spec.Global.NodeSelector) > 0 {
 			server["nodeSelector"] = platform.Spec.Global.NodeSelector
@@ -323,11 +376,70 @@ func (m *PrometheusManagerHelm) buildHelmValues(
 		if len(platform.Spec.Global.Tolerations) > 0 {
 			server["tolerations"] = platform.Spec.Global.Tolerations
 		}
-		if platform.Spec.Global.Affinity != nil {
-			server["affinity"] = platform.Spec.Global.Affinity
+	}
+	
+	// Affinity and topology spread constraints, component settings override global ones
+	placement := managers.PlacementFor(platform, prometheusSpec.Affinity, prometheusSpec.TopologySpreadConstraints)
+	if placement.Affinity != nil {
+		server["affinity"] = placement.Affinity
+	}
+	if constraints := placement.SpreadConstraints(serverSelectorLabels(platform)); len(constraints) > 0 {
+		server["topologySpreadConstraints"] = constraints
+	}
+	
+	// Dedicated node pool, merged with the global node selector and tolerations
+	if nodeSelector, tolerations, ok := managers.NodePoolValues(platform); ok {
+		server["nodeSelector"] = nodeSelector
+		server["tolerations"] = tolerations
+	}
+	
+	// Priority class and guaranteed QoS, component settings override global ones
+	priority := managers.PriorityFor(platform, prometheusSpec.PriorityClassName, prometheusSpec.GuaranteedQoS)
+	if priority.PriorityClassName != "" {
+		server["priorityClassName"] = priority.PriorityClassName
+	}
+	if priority.GuaranteedQoS && prometheusSpec.Resources != nil {
+		server["resources"] = managers.GuaranteedResources(prometheusSpec.Resources)
+	}
+	
+	// Extra containers and volumes; the chart takes sidecars keyed by name
+	if len(prometheusSpec.ExtraContainers) > 0 {
+		sidecars := make(map[string]interface{}, len(prometheusSpec.ExtraContainers))
+		for _, c := range prometheusSpec.ExtraContainers {
+			sidecars[c.Name] = c
+		}
+		server["sidecarContainers"] = sidecars
+	}
+	if len(prometheusSpec.ExtraVolumes) > 0 {
+		server["extraVolumes"] = prometheusSpec.ExtraVolumes
+	}
+	
+	// Ephemeral storage, the size of the chart's emptyDir and the dedicated WAL volume
+	if storage := prometheusSpec.EphemeralStorage; storage != nil {
+		server["resources"] = managers.EphemeralStorageResources(server["resources"], storage)
+		if storage.EmptyDirSizeLimit != "" {
+			server["emptyDir"] = map[string]interface{}{"sizeLimit": storage.EmptyDirSizeLimit}
+		}
+		if volume, mount, ok := managers.WALVolume(storage, helmDataPath+"/wal"); ok {
+			server["extraVolumes"] = append(append([]corev1.Volume{}, prometheusSpec.ExtraVolumes...), volume)
+			server["extraVolumeMounts"] = []corev1.VolumeMount{mount}
 		}
 	}
 	
+	// User-defined environment variables
+	if len(prometheusSpec.Env) > 0 {
+		server["env"] = prometheusSpec.Env
+	}
+	if len(prometheusSpec.EnvFrom) > 0 {
+		server["envFrom"] = prometheusSpec.EnvFrom
+	}
+	
+	// Thanos sidecar uploading the blocks, through the chart's sidecar,
+	// secret mount and gRPC service values
+	if thanosSpec := prometheusSpec.Thanos; thanosSpec.IsEnabled() {
+		addThanosSidecarValues(server, thanosSpec)
+	}
+	
 	// Additional scrape configs
 	if prometheusSpec.AdditionalScrapeConfigs != "" {
 		server["extraScrapeConfigs"] = prometheusSpec.AdditionalScrapeConfigs
@@ -368,3 +480,70 @@ func (m *PrometheusManagerHelm) buildHelmValues(
 	
 	return values, nil
 }
+
+// relabelValues returns write relabel configs as Helm values
+func relabelValues(relabel []labeltransition.RelabelConfig) []interface{} {
+	values := make([]interface{}, 0, len(relabel))
+	for _, r := range relabel {
+		values = append(values, map[string]interface{}{
+			"source_labels": r.SourceLabels,
+			"regex":         r.Regex,
+			"target_label":  r.TargetLabel,
+			"replacement":   r.Replacement,
+		})
+	}
+	return values
+}
+
+// addThanosSidecarValues adds the Thanos sidecar to the chart's server. The
+// chart mounts the TSDB as storage-volume at /data.
+func addThanosSidecarValues(server map[string]interface{}, thanosSpec *observabilityv1beta1.ThanosSpec) {
+	sidecar := thanos.Sidecar{
+		Image:               thanosSpec.Image,
+		Version:             thanosSpec.GetVersion(),
+		ObjectStorageConfig: thanosSpec.ObjectStorageConfig,
+		PrometheusURL:       fmt.Sprintf("http://localhost:%d", defaultPort),
+		DataVolume:          "storage-volume",
+		TSDBPath:            "/data",
+	}
+	if thanosSpec.Sidecar != nil {
+		sidecar.Resources = thanosSpec.Sidecar.Resources
+	}
+	sidecars, _ := server["sidecarContainers"].(map[string]interface{})
+	if sidecars == nil {
+		sidecars = make(map[string]interface{}, 1)
+	}
+	sidecars[thanos.SidecarContainer] = thanos.BuildSidecar(sidecar)
+	server["sidecarContainers"] = sidecars
+	
+	mounts, _ := server["extraSecretMounts"].([]interface{})
+	server["extraSecretMounts"] = append(mounts, map[string]interface{}{
+		"name":       thanos.ObjstoreVolume,
+		"secretName": thanosSpec.ObjectStorageConfig.Name,
+		"mountPath":  "/etc/thanos",
+		"readOnly":   true,
+	})
+	
+	// The chart's flags are set without their dashes
+	flags, _ := server["extraFlags"].([]interface{})
+	if flags == nil {
+		flags = []interface{}{"web.enable-lifecycle"}
+	}
+	for _, arg := range thanos.PrometheusArgs(thanosSpec.Sidecar.GetUploadInterval()) {
+		flags = append(flags, strings.TrimPrefix(arg, "--"))
+	}
+	server["extraFlags"] = flags
+	
+	env, _ := server["env"].([]corev1.EnvVar)
+	server["env"] = append(append([]corev1.EnvVar{}, env...), thanos.PodNameEnv())
+	
+	service, _ := server["service"].(map[string]interface{})
+	if service == nil {
+		service = make(map[string]interface{}, 1)
+	}
+	service["gRPC"] = map[string]interface{}{
+		"enabled":     true,
+		"servicePort": thanos.GRPCPort,
+	}
+	server["service"] = service
+}
@@ -8,7 +8,9 @@ package prometheus
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -528,3 +530,113 @@ func TestPrometheusManager_generatePrometheusConfig(t *testing.T) {
 	assert.Contains(t, config, "remote_timeout: 30s")
 	assert.Contains(t, config, "X-API-Key: secret")
 }
+
+func TestPrometheusManager_generatePrometheusConfigScrapesExporters(t *testing.T) {
+	platform := &observabilityv1beta1.ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-platform",
+			Namespace: "test-namespace",
+		},
+		Spec: observabilityv1beta1.ObservabilityPlatformSpec{
+			Exporters: []observabilityv1beta1.ExporterSpec{
+				{Type: "redis", Address: "redis:6379"},
+				{Type: "postgres", Name: "orders", Address: "orders-db:5432", Alerts: &[]bool{false}[0]},
+			},
+		},
+	}
+
+	manager := &PrometheusManager{}
+	config := manager.generatePrometheusConfig(platform, &observabilityv1beta1.PrometheusSpec{})
+
+	assert.Contains(t, config, "job_name: 'redis-exporter'")
+	assert.Contains(t, config, "job_name: 'orders-exporter'")
+	assert.Contains(t, config, "orders-exporter-test-platform.test-namespace.svc.cluster.local:9187")
+	// Only the redis exporter has alerts
+	assert.Contains(t, config, `- "/etc/prometheus/exporter-rules.yml"`)
+}
+
+func TestPrometheusManager_generatePrometheusConfigScrapesOperator(t *testing.T) {
+	platform := &observabilityv1beta1.ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-platform",
+			Namespace: "test-namespace",
+		},
+	}
+
+	manager := &PrometheusManager{}
+	config := manager.generatePrometheusConfig(platform, &observabilityv1beta1.PrometheusSpec{})
+	assert.NotContains(t, config, "job_name: 'gunj-operator'")
+
+	platform.Spec.SelfMonitoring = &observabilityv1beta1.SelfMonitoringSpec{Enabled: true}
+	config = manager.generatePrometheusConfig(platform, &observabilityv1beta1.PrometheusSpec{})
+	assert.Contains(t, config, "job_name: 'gunj-operator'")
+	assert.Contains(t, config, "regex: gunj-operator;metrics")
+}
+
+func TestPrometheusManager_generatePrometheusConfigDualWritesPreviousLabels(t *testing.T) {
+	platform := &observabilityv1beta1.ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-platform",
+			Namespace: "test-namespace",
+		},
+	}
+	prometheusSpec := &observabilityv1beta1.PrometheusSpec{
+		ExternalLabels: map[string]string{"cluster": "prod-eu-1"},
+		RemoteWrite: []observabilityv1beta1.RemoteWriteSpec{
+			{URL: "https://remote.example.com/write"},
+		},
+	}
+
+	manager := &PrometheusManager{}
+	config := manager.generatePrometheusConfig(platform, prometheusSpec)
+	assert.NotContains(t, config, "write_relabel_configs:")
+
+	platform.Status.ExternalLabelTransition = &observabilityv1beta1.ExternalLabelTransitionStatus{
+		PreviousLabels: map[string]string{"cluster": "prod-eu"},
+		Labels:         map[string]string{"cluster": "prod-eu-1"},
+		Start:          metav1.Now(),
+		End:            metav1.NewTime(time.Now().Add(time.Hour)),
+	}
+	config = manager.generatePrometheusConfig(platform, prometheusSpec)
+	assert.Equal(t, 2, strings.Count(config, "url: https://remote.example.com/write"))
+	assert.Contains(t, config, `
+  - url: https://remote.example.com/write
+    name: test-platform-previous-labels-0
+    write_relabel_configs:
+      - source_labels: [cluster]
+        regex: "prod-eu-1"
+        target_label: cluster
+        replacement: "prod-eu"`)
+
+	// The window is over
+	platform.Status.ExternalLabelTransition.End = metav1.NewTime(time.Now().Add(-time.Minute))
+	config = manager.generatePrometheusConfig(platform, prometheusSpec)
+	assert.Equal(t, 1, strings.Count(config, "url: https://remote.example.com/write"))
+}
+
+func TestPrometheusManager_generatePrometheusConfigThanosReplicaLabel(t *testing.T) {
+	platform := &observabilityv1beta1.ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-platform",
+			Namespace: "test-namespace",
+		},
+	}
+	prometheusSpec := &observabilityv1beta1.PrometheusSpec{
+		RemoteWrite: []observabilityv1beta1.RemoteWriteSpec{
+			{URL: "https://remote.example.com/write"},
+		},
+		Thanos: &observabilityv1beta1.ThanosSpec{Enabled: true},
+	}
+
+	manager := &PrometheusManager{}
+	config := manager.generatePrometheusConfig(platform, prometheusSpec)
+	assert.Contains(t, config, "external_labels:\n    prometheus_replica: ${POD_NAME}")
+	// Remote storage doesn't get a series per replica
+	assert.Contains(t, config, `
+  - url: https://remote.example.com/write
+    write_relabel_configs:
+      - source_labels: [prometheus_replica]
+        regex: ".*"
+        target_label: prometheus_replica
+        replacement: ""`)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package prometheus
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/labeltransition"
+	"github.com/gunjanjp/gunj-operator/internal/thanos"
+)
+
+// addThanosSidecar adds the Thanos sidecar uploading the blocks of the
+// Prometheus container to a pod spec, and the flags Prometheus needs for it
+func addThanosSidecar(podSpec *corev1.PodSpec, thanosSpec *observabilityv1beta1.ThanosSpec) {
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name != componentName {
+			continue
+		}
+		podSpec.Containers[i].Args = append(podSpec.Containers[i].Args, thanos.PrometheusArgs(thanosSpec.Sidecar.GetUploadInterval())...)
+		podSpec.Containers[i].Env = append(podSpec.Containers[i].Env, thanos.PodNameEnv())
+	}
+
+	sidecar := thanos.Sidecar{
+		Image:               thanosSpec.Image,
+		Version:             thanosSpec.GetVersion(),
+		ObjectStorageConfig: thanosSpec.ObjectStorageConfig,
+		PrometheusURL:       fmt.Sprintf("http://localhost:%d", defaultPort),
+		DataVolume:          "data",
+		TSDBPath:            defaultDataPath,
+	}
+	if thanosSpec.Sidecar != nil {
+		sidecar.Resources = thanosSpec.Sidecar.Resources
+	}
+	podSpec.Containers = append(podSpec.Containers, thanos.BuildSidecar(sidecar))
+	podSpec.Volumes = append(podSpec.Volumes, thanos.BuildObjstoreVolume(thanosSpec.ObjectStorageConfig))
+}
+
+// thanosExternalLabels adds the replica label to the external labels when
+// the sidecar uploads blocks, so the blocks of each replica are kept apart
+func thanosExternalLabels(labels map[string]string, prometheusSpec *observabilityv1beta1.PrometheusSpec) map[string]string {
+	if !prometheusSpec.Thanos.IsEnabled() {
+		return labels
+	}
+	withReplica := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		withReplica[k] = v
+	}
+	withReplica[thanos.ReplicaLabel] = thanos.ReplicaLabelValue
+	return withReplica
+}
+
+// thanosReplicaRelabeling drops the replica label from remote-written series:
+// it only tells the uploaded blocks apart, and would split every series in
+// remote storage by pod
+func thanosReplicaRelabeling(prometheusSpec *observabilityv1beta1.PrometheusSpec) []labeltransition.RelabelConfig {
+	if !prometheusSpec.Thanos.IsEnabled() {
+		return nil
+	}
+	return []labeltransition.RelabelConfig{{
+		SourceLabels: []string{thanos.ReplicaLabel},
+		Regex:        ".*",
+		TargetLabel:  thanos.ReplicaLabel,
+		Replacement:  "",
+	}}
+}
+
+// reconcileThanosSidecarService exposes the Store API of the Thanos sidecars,
+// and removes the Service when Thanos is disabled
+func (m *PrometheusManager) reconcileThanosSidecarService(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, prometheusSpec *observabilityv1beta1.PrometheusSpec) error {
+	log := log.FromContext(ctx)
+	name := thanos.SidecarServiceName(platform.Name)
+
+	if !prometheusSpec.Thanos.IsEnabled() {
+		service := &corev1.Service{}
+		if err := m.Client.Get(ctx, client.ObjectKey{Namespace: platform.Namespace, Name: name}, service); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to get Thanos sidecar Service: %w", err)
+		}
+		if err := m.Client.Delete(ctx, service); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete Thanos sidecar Service: %w", err)
+		}
+		return nil
+	}
+
+	desired := thanos.BuildSidecarService(name, platform.Namespace, m.getLabels(platform), m.getSelectorLabels(platform))
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: platform.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, m.Client, service, func() error {
+		service.Labels = desired.Labels
+		// Headless, so queriers reach every replica
+		service.Spec.ClusterIP = desired.Spec.ClusterIP
+		service.Spec.Selector = desired.Spec.Selector
+		service.Spec.Ports = desired.Spec.Ports
+		return controllerutil.SetControllerReference(platform, service, m.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to create/update Thanos sidecar Service: %w", err)
+	}
+
+	log.V(1).Info("Thanos sidecar Service reconciled", "name", name)
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package prometheus
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/correlation"
+	"github.com/gunjanjp/gunj-operator/internal/valuefrom"
+)
+
+// resolveValuesFrom returns a copy of the Prometheus spec with the values
+// read from ConfigMaps merged in, so both managers render the same labels
+// and scrape configurations. The platform's spec is left untouched.
+func resolveValuesFrom(ctx context.Context, c client.Reader, platform *observabilityv1beta1.ObservabilityPlatform, prometheusSpec *observabilityv1beta1.PrometheusSpec) (*observabilityv1beta1.PrometheusSpec, error) {
+	if prometheusSpec.ExternalLabelsFrom == nil && prometheusSpec.AdditionalScrapeConfigsFrom == nil {
+		return prometheusSpec, nil
+	}
+	resolved := *prometheusSpec
+
+	if prometheusSpec.ExternalLabelsFrom != nil {
+		value, err := valuefrom.Resolve(ctx, c, platform.Namespace, prometheusSpec.ExternalLabelsFrom)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read externalLabelsFrom: %w", err)
+		}
+		labels, err := valuefrom.Labels(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid externalLabelsFrom: %w", err)
+		}
+		resolved.ExternalLabels = valuefrom.MergeLabels(labels, prometheusSpec.ExternalLabels)
+		// Labels read from a ConfigMap escape admission, so conflicts with
+		// the correlation labels are caught here
+		if conflicts := correlation.Conflicts(platform.CorrelationLabels(), resolved.ExternalLabels); len(conflicts) > 0 {
+			return nil, fmt.Errorf("externalLabelsFrom conflicts with spec.global.correlation: %w", conflicts[0])
+		}
+	}
+
+	if prometheusSpec.AdditionalScrapeConfigsFrom != nil {
+		value, err := valuefrom.Resolve(ctx, c, platform.Namespace, prometheusSpec.AdditionalScrapeConfigsFrom)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read additionalScrapeConfigsFrom: %w", err)
+		}
+		configs, err := valuefrom.AppendScrapeConfigs(prometheusSpec.AdditionalScrapeConfigs, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid additionalScrapeConfigsFrom: %w", err)
+		}
+		resolved.AdditionalScrapeConfigs = configs
+	}
+
+	return &resolved, nil
+}
+
+// ExternalLabels returns the external labels Prometheus is configured with,
+// including the labels read through externalLabelsFrom
+func ExternalLabels(ctx context.Context, c client.Reader, platform *observabilityv1beta1.ObservabilityPlatform) (map[string]string, error) {
+	resolved, err := resolveValuesFrom(ctx, c, platform, platform.Spec.Components.Prometheus)
+	if err != nil {
+		return nil, err
+	}
+	return externalLabels(platform, resolved), nil
+}
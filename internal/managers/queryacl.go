@@ -0,0 +1,41 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package managers
+
+import (
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/queryacl"
+)
+
+// QueryACLDatasources returns the Grafana datasources of the teams of a
+// platform with query access control enabled: a Prometheus datasource per
+// team organization, and a Loki datasource at lokiURL for the teams with
+// tenants while Loki is enabled
+func QueryACLDatasources(platform *observabilityv1beta1.ObservabilityPlatform, lokiURL string) []map[string]interface{} {
+	acl := platform.Spec.QueryACL
+	if !acl.IsEnabled() || platform.Spec.Components == nil ||
+		platform.Spec.Components.Prometheus == nil || !platform.Spec.Components.Prometheus.Enabled {
+		return nil
+	}
+
+	lokiEnabled := platform.Spec.Components.Loki != nil && platform.Spec.Components.Loki.Enabled
+	proxyURL := queryacl.ProxyURL(platform.Name, platform.Namespace)
+	datasources := make([]map[string]interface{}, 0, len(acl.Teams))
+	for _, t := range acl.Teams {
+		team := queryacl.Team{
+			Name:    t.Name,
+			OrgID:   t.OrgID,
+			Values:  t.Values,
+			Tenants: t.Tenants,
+		}
+		datasources = append(datasources, queryacl.Datasource(team, proxyURL))
+		if lokiEnabled && len(team.Tenants) > 0 {
+			datasources = append(datasources, queryacl.LokiDatasource(team, lokiURL))
+		}
+	}
+	return datasources
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package managers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/reload"
+)
+
+// ApplyConfigChecksum annotates a pod template with the checksum of the
+// ConfigMaps and Secrets it references, so their changes roll the pods. With
+// the Reload strategy the configuration the component reloads in place is
+// left out of the checksum.
+func ApplyConfigChecksum(ctx context.Context, c client.Client, platform *observabilityv1beta1.ObservabilityPlatform, component, strategy string, template *corev1.PodTemplateSpec) error {
+	var exclude []string
+	if reload.StrategyFor(component, strategy) == reload.StrategyReload {
+		exclude = reload.Endpoints[component].Volumes
+	}
+
+	objects, err := FetchReferences(ctx, c, platform.Namespace, reload.References(&template.Spec, exclude))
+	if err != nil {
+		return err
+	}
+
+	if template.Annotations == nil {
+		template.Annotations = make(map[string]string)
+	}
+	template.Annotations[reload.ChecksumAnnotation] = reload.Checksum(objects)
+	return nil
+}
+
+// FetchReferences returns the content of referenced ConfigMaps and Secrets.
+// Missing objects have no content, so their creation changes the checksum.
+func FetchReferences(ctx context.Context, c client.Client, namespace string, refs []reload.Ref) ([]reload.Object, error) {
+	objects := make([]reload.Object, 0, len(refs))
+	for _, ref := range refs {
+		object := reload.Object{Ref: ref}
+		key := client.ObjectKey{Namespace: namespace, Name: ref.Name}
+
+		var err error
+		switch ref.Kind {
+		case "ConfigMap":
+			configMap := &corev1.ConfigMap{}
+			if err = c.Get(ctx, key, configMap); err == nil {
+				object.Data = make(map[string][]byte, len(configMap.Data)+len(configMap.BinaryData))
+				for k, v := range configMap.Data {
+					object.Data[k] = []byte(v)
+				}
+				for k, v := range configMap.BinaryData {
+					object.Data[k] = v
+				}
+			}
+		case "Secret":
+			secret := &corev1.Secret{}
+			if err = c.Get(ctx, key, secret); err == nil {
+				object.Data = secret.Data
+			}
+		}
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get %s %s: %w", ref.Kind, ref.Name, err)
+		}
+		objects = append(objects, object)
+	}
+	return objects, nil
+}
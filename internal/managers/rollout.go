@@ -0,0 +1,35 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package managers
+
+import (
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/rollout"
+)
+
+// ApplyStagedRollout prepares the StatefulSet of a ring-based component for
+// a staged rollout if enabled; the StagedRollouts runnable then releases its
+// pods one at a time. previous is the StatefulSet as stored, nil or without
+// resource version if it does not exist yet. config holds the ring and
+// flush endpoints of the component.
+func ApplyStagedRollout(previous, sts *appsv1.StatefulSet, strategy *observabilityv1beta1.ComponentUpdateStrategy, config rollout.Config) {
+	if !strategy.IsStaged() {
+		delete(sts.Labels, rollout.Label)
+		for _, key := range []string{rollout.ConfigAnnotation, rollout.TemplateHashAnnotation, rollout.StepTimeAnnotation, rollout.FlushingAnnotation} {
+			delete(sts.Annotations, key)
+		}
+		return
+	}
+	// Durations are validated at admission, so invalid values fall back to the defaults
+	config.StabilizationPeriod.Duration, _ = time.ParseDuration(strategy.StagedRollout.StabilizationPeriod)
+	config.FlushTimeout.Duration, _ = time.ParseDuration(strategy.StagedRollout.FlushTimeout)
+	rollout.Prepare(previous, sts, config.WithDefaults())
+}
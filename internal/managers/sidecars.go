@@ -0,0 +1,28 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package managers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/sidecars"
+)
+
+// ApplyExtraContainers adds the extra containers and volumes of a component to
+// its pod spec. Name collisions are rejected at admission; entries that still
+// collide with a managed container or volume are skipped and logged.
+func ApplyExtraContainers(podSpec *corev1.PodSpec, platform *observabilityv1beta1.ObservabilityPlatform, component string, containers []corev1.Container, volumes []corev1.Volume) {
+	if len(containers) == 0 && len(volumes) == 0 {
+		return
+	}
+	if skipped := sidecars.Apply(podSpec, containers, volumes); len(skipped) > 0 {
+		log.Log.WithName("sidecars").Info("Skipped extra containers or volumes colliding with managed ones",
+			"platform", platform.Name, "namespace", platform.Namespace, "component", component, "names", skipped)
+	}
+}
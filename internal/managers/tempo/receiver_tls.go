@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package tempo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/certmanager"
+	"github.com/gunjanjp/gunj-operator/internal/fips"
+	"github.com/gunjanjp/gunj-operator/internal/managers"
+)
+
+const (
+	// Mount paths of the receiver serving certificate and client CA. They
+	// are outside of /etc/tempo, which is the read-only config volume.
+	receiverTLSPath         = "/etc/tempo-tls"
+	receiverTLSClientCAPath = "/etc/tempo-tls-client-ca"
+
+	// receiverTLSReloadInterval is how often the receivers reload the
+	// certificate, which the kubelet refreshes after a renewal
+	receiverTLSReloadInterval = "5m"
+)
+
+// receiverTLSSecretName returns the Secret holding the receiver serving
+// certificate
+func receiverTLSSecretName(platform *observabilityv1beta1.ObservabilityPlatform, receiverTLS *observabilityv1beta1.ReceiverTLSSpec) string {
+	if receiverTLS.IssuerRef == nil && receiverTLS.SecretName != "" {
+		return receiverTLS.SecretName
+	}
+	return receiverCertificateName(platform)
+}
+
+// receiverCertificateName returns the name of the cert-manager Certificate
+// of the receivers
+func receiverCertificateName(platform *observabilityv1beta1.ObservabilityPlatform) string {
+	return fmt.Sprintf("%s-%s-receiver-tls", platform.Name, componentName)
+}
+
+// reconcileReceiverCertificate requests the receiver serving certificate from
+// the configured cert-manager issuer. A Certificate left over from a previous
+// configuration is removed.
+func (m *TempoManager) reconcileReceiverCertificate(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, tempoSpec *observabilityv1beta1.TempoSpec) error {
+	log := log.FromContext(ctx).WithValues("component", componentName)
+
+	receiverTLS := tempoSpec.ReceiverTLS
+	if !receiverTLS.IsEnabled() || receiverTLS.IssuerRef == nil {
+		return m.deleteReceiverCertificate(ctx, platform)
+	}
+
+	service := fmt.Sprintf("%s-%s", platform.Name, componentName)
+	dnsNames := []string{
+		service,
+		fmt.Sprintf("%s.%s", service, platform.Namespace),
+		fmt.Sprintf("%s.%s.svc", service, platform.Namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", service, platform.Namespace),
+	}
+	cert := certmanager.Certificate{
+		Name:        receiverCertificateName(platform),
+		Namespace:   platform.Namespace,
+		Labels:      m.getLabels(platform),
+		SecretName:  receiverTLSSecretName(platform, receiverTLS),
+		DNSNames:    append(dnsNames, receiverTLS.DNSNames...),
+		Issuer:      managers.CertificateIssuer(receiverTLS.IssuerRef),
+		Duration:    receiverTLS.Duration,
+		RenewBefore: receiverTLS.RenewBefore,
+	}
+	if err := managers.ReconcileCertificate(ctx, m.Client, m.Scheme, platform, cert); err != nil {
+		return err
+	}
+
+	log.V(1).Info("Successfully reconciled receiver Certificate", "issuer", receiverTLS.IssuerRef.Name)
+	return nil
+}
+
+// deleteReceiverCertificate removes the receiver Certificate, if any. It is
+// not an error if cert-manager is not installed.
+func (m *TempoManager) deleteReceiverCertificate(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(certmanager.CertificateGVK)
+	obj.SetName(receiverCertificateName(platform))
+	obj.SetNamespace(platform.Namespace)
+	if err := m.Client.Delete(ctx, obj); err != nil && client.IgnoreNotFound(err) != nil && !meta.IsNoMatchError(err) {
+		return fmt.Errorf("failed to delete Certificate %s: %w", obj.GetName(), err)
+	}
+	return nil
+}
+
+// applyReceiverTLS mounts the receiver serving certificate and client CA into
+// the tempo container
+func applyReceiverTLS(podSpec *corev1.PodSpec, platform *observabilityv1beta1.ObservabilityPlatform, receiverTLS *observabilityv1beta1.ReceiverTLSSpec) {
+	if !receiverTLS.IsEnabled() {
+		return
+	}
+
+	volumes := []corev1.Volume{{
+		Name: "receiver-tls",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: receiverTLSSecretName(platform, receiverTLS)},
+		},
+	}}
+	mounts := []corev1.VolumeMount{{Name: "receiver-tls", MountPath: receiverTLSPath, ReadOnly: true}}
+	if receiverTLS.ClientCASecretName != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "receiver-tls-client-ca",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: receiverTLS.ClientCASecretName},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{Name: "receiver-tls-client-ca", MountPath: receiverTLSClientCAPath, ReadOnly: true})
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, volumes...)
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name == componentName {
+			podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, mounts...)
+		}
+	}
+}
+
+// writeReceiverTLS writes the tls block of a receiver endpoint at the given
+// indentation. In FIPS mode the receivers get the TLS constraints of the
+// server block.
+func writeReceiverTLS(sb *strings.Builder, indent string, receiverTLS *observabilityv1beta1.ReceiverTLSSpec, profile *fips.Profile) {
+	if !receiverTLS.IsEnabled() {
+		return
+	}
+	sb.WriteString(indent + "tls:\n")
+	sb.WriteString(fmt.Sprintf("%s  cert_file: %s/tls.crt\n", indent, receiverTLSPath))
+	sb.WriteString(fmt.Sprintf("%s  key_file: %s/tls.key\n", indent, receiverTLSPath))
+	if receiverTLS.ClientCASecretName != "" {
+		sb.WriteString(fmt.Sprintf("%s  client_ca_file: %s/ca.crt\n", indent, receiverTLSClientCAPath))
+	}
+	sb.WriteString(fmt.Sprintf("%s  reload_interval: %s\n", indent, receiverTLSReloadInterval))
+	if profile != nil {
+		sb.WriteString(fmt.Sprintf("%s  min_version: %q\n", indent, profile.ReceiverMinVersion()))
+		sb.WriteString(fmt.Sprintf("%s  cipher_suites:\n", indent))
+		for _, suite := range profile.CipherSuites {
+			sb.WriteString(fmt.Sprintf("%s    - %s\n", indent, suite))
+		}
+	}
+}
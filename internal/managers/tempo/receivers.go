@@ -0,0 +1,270 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package tempo
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// receiver is a trace receiver of Tempo
+type receiver struct {
+	// name of the receiver in the API, also the name of its Service port
+	name string
+	// containerPort is the name of the container port, at most 15 characters
+	containerPort string
+	port          int32
+	protocol      corev1.Protocol
+	// http receivers can be exposed through an Ingress
+	http bool
+}
+
+// receivers are the trace receivers of Tempo, in the order of the ports of
+// the Tempo Service
+var receivers = []receiver{
+	{name: observabilityv1beta1.ReceiverOTLPGRPC, containerPort: "otlp-grpc", port: defaultOTLPGRPCPort, protocol: corev1.ProtocolTCP},
+	{name: observabilityv1beta1.ReceiverOTLPHTTP, containerPort: "otlp-http", port: defaultOTLPHTTPPort, protocol: corev1.ProtocolTCP, http: true},
+	{name: observabilityv1beta1.ReceiverJaegerThriftCompact, containerPort: "jaeger-compact", port: defaultJaegerThriftCompactPort, protocol: corev1.ProtocolUDP},
+	{name: observabilityv1beta1.ReceiverJaegerThriftBinary, containerPort: "jaeger-binary", port: defaultJaegerThriftBinaryPort, protocol: corev1.ProtocolUDP},
+	{name: observabilityv1beta1.ReceiverJaegerThriftHTTP, containerPort: "jaeger-http", port: defaultJaegerThriftHTTPPort, protocol: corev1.ProtocolTCP, http: true},
+	{name: observabilityv1beta1.ReceiverJaegerGRPC, containerPort: "jaeger-grpc", port: defaultJaegerGRPCPort, protocol: corev1.ProtocolTCP},
+	{name: observabilityv1beta1.ReceiverZipkin, containerPort: "zipkin", port: defaultZipkinPort, protocol: corev1.ProtocolTCP, http: true},
+}
+
+// enabledReceivers returns the receivers Tempo listens on
+func enabledReceivers(tempoSpec *observabilityv1beta1.TempoSpec) []receiver {
+	var enabled []receiver
+	for _, r := range receivers {
+		if tempoSpec.Receivers.IsEnabled(r.name) {
+			enabled = append(enabled, r)
+		}
+	}
+	return enabled
+}
+
+// receiverContainerPorts returns the container ports of the enabled receivers
+func receiverContainerPorts(tempoSpec *observabilityv1beta1.TempoSpec) []corev1.ContainerPort {
+	var ports []corev1.ContainerPort
+	for _, r := range enabledReceivers(tempoSpec) {
+		ports = append(ports, corev1.ContainerPort{Name: r.containerPort, ContainerPort: r.port, Protocol: r.protocol})
+	}
+	return ports
+}
+
+// receiverServicePorts returns the Service ports of the enabled receivers
+func receiverServicePorts(tempoSpec *observabilityv1beta1.TempoSpec) []corev1.ServicePort {
+	var ports []corev1.ServicePort
+	for _, r := range enabledReceivers(tempoSpec) {
+		ports = append(ports, r.servicePort())
+	}
+	return ports
+}
+
+func (r receiver) servicePort() corev1.ServicePort {
+	return corev1.ServicePort{
+		Name:       r.name,
+		Port:       r.port,
+		TargetPort: intstr.FromInt(int(r.port)),
+		Protocol:   r.protocol,
+	}
+}
+
+// receiverObjectName returns the name of the dedicated Service and Ingress
+// of a receiver
+func receiverObjectName(platform *observabilityv1beta1.ObservabilityPlatform, r receiver) string {
+	return fmt.Sprintf("%s-%s-%s", platform.Name, componentName, r.name)
+}
+
+// receiverEndpointKey returns the key of a receiver in the platform's
+// status endpoints
+func receiverEndpointKey(r receiver) string {
+	return fmt.Sprintf("%s-%s", componentName, r.name)
+}
+
+// reconcileReceiverExposure creates the dedicated Services and Ingresses of
+// the exposed receivers, removes those of receivers no longer exposed, and
+// publishes the receiver endpoints in the platform status
+func (m *TempoManager) reconcileReceiverExposure(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, tempoSpec *observabilityv1beta1.TempoSpec) error {
+	log := log.FromContext(ctx).WithValues("component", componentName)
+
+	for _, r := range receivers {
+		spec := tempoSpec.Receivers.Get(r.name)
+		enabled := tempoSpec.Receivers.IsEnabled(r.name)
+
+		// In-cluster endpoint through the Tempo Service
+		endpoint := fmt.Sprintf("%s-%s.%s.svc.cluster.local:%d", platform.Name, componentName, platform.Namespace, r.port)
+
+		var svc *corev1.Service
+		if enabled && spec != nil && spec.Service != nil {
+			var err error
+			if svc, err = m.reconcileReceiverService(ctx, platform, r, spec.Service); err != nil {
+				return err
+			}
+			if address := loadBalancerAddress(svc); address != "" {
+				endpoint = fmt.Sprintf("%s:%d", address, r.port)
+			}
+		} else if err := m.deleteReceiverObject(ctx, &corev1.Service{}, platform, r); err != nil {
+			return err
+		}
+
+		if enabled && r.http && spec != nil && spec.Ingress != nil && spec.Ingress.Enabled {
+			if err := m.reconcileReceiverIngress(ctx, platform, r, spec.Ingress); err != nil {
+				return err
+			}
+			scheme := "http"
+			if spec.Ingress.TLS != nil && spec.Ingress.TLS.Enabled {
+				scheme = "https"
+			}
+			endpoint = fmt.Sprintf("%s://%s", scheme, spec.Ingress.Host)
+		} else if err := m.deleteReceiverObject(ctx, &networkingv1.Ingress{}, platform, r); err != nil {
+			return err
+		}
+
+		if enabled {
+			platform.UpdateEndpoint(receiverEndpointKey(r), endpoint)
+		} else {
+			platform.RemoveEndpoint(receiverEndpointKey(r))
+		}
+	}
+
+	log.V(1).Info("Successfully reconciled receiver exposure")
+	return nil
+}
+
+// reconcileReceiverService creates or updates the dedicated Service of a
+// receiver
+func (m *TempoManager) reconcileReceiverService(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, r receiver, spec *observabilityv1beta1.ReceiverServiceSpec) (*corev1.Service, error) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      receiverObjectName(platform, r),
+			Namespace: platform.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, m.Client, svc, func() error {
+		svc.Labels = m.getLabels(platform)
+		svc.Annotations = spec.Annotations
+
+		svcType := spec.Type
+		if svcType == "" {
+			svcType = corev1.ServiceTypeLoadBalancer
+		}
+		svc.Spec.Type = svcType
+		svc.Spec.Selector = m.getLabels(platform)
+		svc.Spec.LoadBalancerSourceRanges = nil
+		if svcType == corev1.ServiceTypeLoadBalancer {
+			svc.Spec.LoadBalancerSourceRanges = spec.LoadBalancerSourceRanges
+		}
+
+		// Keep the node port allocated to the receiver
+		port := r.servicePort()
+		for _, existing := range svc.Spec.Ports {
+			if existing.Name == port.Name && svcType != corev1.ServiceTypeClusterIP {
+				port.NodePort = existing.NodePort
+			}
+		}
+		svc.Spec.Ports = []corev1.ServicePort{port}
+
+		return controllerutil.SetControllerReference(platform, svc, m.Scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create/update Service %s: %w", svc.Name, err)
+	}
+	return svc, nil
+}
+
+// reconcileReceiverIngress creates or updates the Ingress of an HTTP receiver
+func (m *TempoManager) reconcileReceiverIngress(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, r receiver, spec *observabilityv1beta1.IngressSpec) error {
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      receiverObjectName(platform, r),
+			Namespace: platform.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, m.Client, ingress, func() error {
+		ingress.Labels = m.getLabels(platform)
+		ingress.Annotations = spec.Annotations
+
+		pathType := networkingv1.PathTypePrefix
+		ingress.Spec = networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: spec.Host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: &pathType,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: fmt.Sprintf("%s-%s", platform.Name, componentName),
+											Port: networkingv1.ServiceBackendPort{Number: r.port},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		if spec.ClassName != "" {
+			ingress.Spec.IngressClassName = &spec.ClassName
+		}
+		if spec.TLS != nil && spec.TLS.Enabled {
+			ingress.Spec.TLS = []networkingv1.IngressTLS{
+				{
+					Hosts:      []string{spec.Host},
+					SecretName: spec.TLS.SecretName,
+				},
+			}
+		}
+
+		return controllerutil.SetControllerReference(platform, ingress, m.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create/update Ingress %s: %w", ingress.Name, err)
+	}
+	return nil
+}
+
+// deleteReceiverObject removes the dedicated Service or Ingress of a
+// receiver, if any
+func (m *TempoManager) deleteReceiverObject(ctx context.Context, obj client.Object, platform *observabilityv1beta1.ObservabilityPlatform, r receiver) error {
+	obj.SetName(receiverObjectName(platform, r))
+	obj.SetNamespace(platform.Namespace)
+	if err := m.Client.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete %s: %w", obj.GetName(), err)
+	}
+	return nil
+}
+
+// loadBalancerAddress returns the address assigned to a LoadBalancer
+// Service, empty until one is assigned
+func loadBalancerAddress(svc *corev1.Service) string {
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.Hostname != "" {
+			return ingress.Hostname
+		}
+		if ingress.IP != "" {
+			return ingress.IP
+		}
+	}
+	return ""
+}
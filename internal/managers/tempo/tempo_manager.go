@@ -15,6 +15,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -24,7 +25,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/correlation"
+	"github.com/gunjanjp/gunj-operator/internal/fips"
 	"github.com/gunjanjp/gunj-operator/internal/managers"
+	"github.com/gunjanjp/gunj-operator/internal/rollout"
+	"github.com/gunjanjp/gunj-operator/internal/scheduling"
+	"github.com/gunjanjp/gunj-operator/internal/temposync"
+	"github.com/gunjanjp/gunj-operator/internal/zones"
 )
 
 const (
@@ -41,9 +48,14 @@ const (
 	defaultJaegerThriftHTTPPort    = 14268
 	defaultJaegerGRPCPort          = 14250
 	defaultZipkinPort              = 9411
+	defaultMemberlistPort          = 7946
 	defaultDataPath       = "/var/tempo"
 	defaultImage          = "grafana/tempo"
 	
+	// Ring status page and flush endpoint of the ingesters for staged rollouts
+	ringPath  = "/ingester/ring"
+	flushPath = "/shutdown"
+	
 	// Labels
 	labelComponent = "tempo"
 )
@@ -85,17 +97,32 @@ func (m *TempoManager) ReconcileWithConfig(ctx context.Context, platform *observ
 		return fmt.Errorf("failed to reconcile ConfigMap: %w", err)
 	}
 	
+	// Create the runtime overrides file TempoConfigs are applied to
+	if err := m.reconcileOverridesConfigMap(ctx, platform); err != nil {
+		return fmt.Errorf("failed to reconcile overrides ConfigMap: %w", err)
+	}
+	
 	// 2. Create Services
 	if err := m.reconcileServices(ctx, platform); err != nil {
 		return fmt.Errorf("failed to reconcile Services: %w", err)
 	}
 	
-	// 3. Create StatefulSet
+	// 3. Expose the receivers through dedicated Services and Ingresses
+	if err := m.reconcileReceiverExposure(ctx, platform, tempoSpec); err != nil {
+		return fmt.Errorf("failed to reconcile receiver exposure: %w", err)
+	}
+	
+	// 4. Request the receiver serving certificate
+	if err := m.reconcileReceiverCertificate(ctx, platform, tempoSpec); err != nil {
+		return fmt.Errorf("failed to reconcile receiver Certificate: %w", err)
+	}
+	
+	// 5. Create StatefulSet
 	if err := m.reconcileStatefulSet(ctx, platform, tempoSpec); err != nil {
 		return fmt.Errorf("failed to reconcile StatefulSet: %w", err)
 	}
 	
-	// 4. Create PodDisruptionBudget for HA
+	// 6. Create PodDisruptionBudget for HA
 	if tempoSpec.Replicas > 1 {
 		if err := m.reconcilePodDisruptionBudget(ctx, platform, tempoSpec); err != nil {
 			return fmt.Errorf("failed to reconcile PodDisruptionBudget: %w", err)
@@ -163,6 +190,15 @@ func (m *TempoManager) Delete(ctx context.Context, platform *observabilityv1beta
 	if err := m.Delete(ctx, cm); err != nil {
 		log.Error(err, "Failed to delete ConfigMap")
 	}
+	overrides := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      temposync.OverridesConfigMapName(platform.Name),
+			Namespace: platform.Namespace,
+		},
+	}
+	if err := m.Delete(ctx, overrides); err != nil {
+		log.Error(err, "Failed to delete overrides ConfigMap")
+	}
 	
 	log.Info("Successfully deleted Tempo resources")
 	return nil
@@ -183,12 +219,18 @@ func (m *TempoManager) GetStatus(ctx context.Context, platform *observabilityv1b
 		return status, nil
 	}
 	
-	// Check StatefulSet status
+	// Check StatefulSet status, aggregated over the zones when zone-aware
 	sts := &appsv1.StatefulSet{}
-	if err := m.Get(ctx, types.NamespacedName{
-		Name:      fmt.Sprintf("%s-%s", platform.Name, componentName),
-		Namespace: platform.Namespace,
-	}, sts); err != nil {
+	var err error
+	if zoneAwareness := platform.Spec.Components.Tempo.ZoneAwareness; zoneAwareness.IsEnabled() {
+		sts, err = managers.GetZonalStatefulSet(ctx, m.Client, platform.Namespace, fmt.Sprintf("%s-%s", platform.Name, componentName), zoneAwareness)
+	} else {
+		err = m.Get(ctx, types.NamespacedName{
+			Name:      fmt.Sprintf("%s-%s", platform.Name, componentName),
+			Namespace: platform.Namespace,
+		}, sts)
+	}
+	if err != nil {
 		status.Status = observabilityv1beta1.ComponentStatusFailed
 		status.Message = fmt.Sprintf("Failed to get StatefulSet: %v", err)
 		return status, nil
@@ -317,15 +359,22 @@ func (m *TempoManager) UpdateSampling(ctx context.Context, platform *observabili
 func (m *TempoManager) reconcileConfigMap(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform, tempoSpec *observabilityv1beta1.TempoSpec) error {
 	log := log.FromContext(ctx).WithValues("component", componentName)
 	
+	data := map[string]string{
+		"tempo.yaml": m.generateTempoConfig(platform, tempoSpec),
+	}
+	
+	// Keep the current configuration until the new one passed -config.verify
+	if ok, err := managers.CheckConfig(ctx, m.Client, m.Scheme, platform, componentName, m.getImage(tempoSpec), data); err != nil || !ok {
+		return err
+	}
+	
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s-%s-config", platform.Name, componentName),
 			Namespace: platform.Namespace,
 			Labels:    m.getLabels(platform),
 		},
-		Data: map[string]string{
-			"tempo.yaml": m.generateTempoConfig(platform, tempoSpec),
-		},
+		Data: data,
 	}
 	
 	// Set controller reference
@@ -342,6 +391,30 @@ func (m *TempoManager) reconcileConfigMap(ctx context.Context, platform *observa
 	return nil
 }
 
+// reconcileOverridesConfigMap creates the runtime overrides file of Tempo,
+// empty until a TempoConfig is applied to it. The TempoConfig controller
+// owns its content, so an existing ConfigMap is left as is.
+func (m *TempoManager) reconcileOverridesConfigMap(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: platform.Namespace, Name: temposync.OverridesConfigMapName(platform.Name)}
+	if err := m.Get(ctx, key, cm); err == nil || !errors.IsNotFound(err) {
+		return err
+	}
+	
+	cm = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels:    m.getLabels(platform),
+		},
+		Data: map[string]string{temposync.OverridesKey: temposync.EmptyOverrides},
+	}
+	if err := controllerutil.SetControllerReference(platform, cm, m.Scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	return client.IgnoreAlreadyExists(m.Create(ctx, cm))
+}
+
 // reconcileServices creates or updates Tempo services
 func (m *TempoManager) reconcileServices(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error {
 	log := log.FromContext(ctx).WithValues("component", componentName)
@@ -355,7 +428,7 @@ func (m *TempoManager) reconcileServices(ctx context.Context, platform *observab
 		},
 		Spec: corev1.ServiceSpec{
 			Selector: m.getLabels(platform),
-			Ports: []corev1.ServicePort{
+			Ports: append([]corev1.ServicePort{
 				{
 					Name:       "tempo-http",
 					Port:       defaultHTTPPort,
@@ -368,49 +441,7 @@ func (m *TempoManager) reconcileServices(ctx context.Context, platform *observab
 					TargetPort: intstr.FromInt(defaultGRPCPort),
 					Protocol:   corev1.ProtocolTCP,
 				},
-				{
-					Name:       "otlp-grpc",
-					Port:       defaultOTLPGRPCPort,
-					TargetPort: intstr.FromInt(defaultOTLPGRPCPort),
-					Protocol:   corev1.ProtocolTCP,
-				},
-				{
-					Name:       "otlp-http",
-					Port:       defaultOTLPHTTPPort,
-					TargetPort: intstr.FromInt(defaultOTLPHTTPPort),
-					Protocol:   corev1.ProtocolTCP,
-				},
-				{
-					Name:       "jaeger-thrift-compact",
-					Port:       defaultJaegerThriftCompactPort,
-					TargetPort: intstr.FromInt(defaultJaegerThriftCompactPort),
-					Protocol:   corev1.ProtocolUDP,
-				},
-				{
-					Name:       "jaeger-thrift-binary",
-					Port:       defaultJaegerThriftBinaryPort,
-					TargetPort: intstr.FromInt(defaultJaegerThriftBinaryPort),
-					Protocol:   corev1.ProtocolUDP,
-				},
-				{
-					Name:       "jaeger-thrift-http",
-					Port:       defaultJaegerThriftHTTPPort,
-					TargetPort: intstr.FromInt(defaultJaegerThriftHTTPPort),
-					Protocol:   corev1.ProtocolTCP,
-				},
-				{
-					Name:       "jaeger-grpc",
-					Port:       defaultJaegerGRPCPort,
-					TargetPort: intstr.FromInt(defaultJaegerGRPCPort),
-					Protocol:   corev1.ProtocolTCP,
-				},
-				{
-					Name:       "zipkin",
-					Port:       defaultZipkinPort,
-					TargetPort: intstr.FromInt(defaultZipkinPort),
-					Protocol:   corev1.ProtocolTCP,
-				},
-			},
+			}, receiverServicePorts(platform.Spec.Components.Tempo)...),
 			Type: corev1.ServiceTypeClusterIP,
 		},
 	}
@@ -446,6 +477,17 @@ func (m *TempoManager) reconcileServices(ctx context.Context, platform *observab
 		},
 	}
 	
+	if platform.Spec.Components.Tempo.ZoneAwareness.IsEnabled() {
+		headlessSvc.Spec.Ports = append(headlessSvc.Spec.Ports, corev1.ServicePort{
+			Name:       "memberlist",
+			Port:       defaultMemberlistPort,
+			TargetPort: intstr.FromInt(defaultMemberlistPort),
+			Protocol:   corev1.ProtocolTCP,
+		})
+		// Ring members must find each other before they are ready
+		headlessSvc.Spec.PublishNotReadyAddresses = true
+	}
+	
 	// Set controller reference
 	if err := controllerutil.SetControllerReference(platform, headlessSvc, m.Scheme); err != nil {
 		return fmt.Errorf("failed to set controller reference: %w", err)
@@ -474,6 +516,10 @@ func (m *TempoManager) reconcileStatefulSet(ctx context.Context, platform *obser
 			Name:      "storage",
 			MountPath: defaultDataPath,
 		},
+		{
+			Name:      "overrides",
+			MountPath: temposync.OverridesMountPath,
+		},
 	}
 	
 	// Prepare volumes
@@ -488,6 +534,16 @@ func (m *TempoManager) reconcileStatefulSet(ctx context.Context, platform *obser
 				},
 			},
 		},
+		{
+			Name: "overrides",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: temposync.OverridesConfigMapName(platform.Name),
+					},
+				},
+			},
+		},
 	}
 	
 	// Prepare container
@@ -498,17 +554,10 @@ func (m *TempoManager) reconcileStatefulSet(ctx context.Context, platform *obser
 			"-config.file=/etc/tempo/tempo.yaml",
 			"-mem-ballast-size-mbs=1024",
 		},
-		Ports: []corev1.ContainerPort{
+		Ports: append([]corev1.ContainerPort{
 			{Name: "tempo-http", ContainerPort: defaultHTTPPort, Protocol: corev1.ProtocolTCP},
 			{Name: "tempo-grpc", ContainerPort: defaultGRPCPort, Protocol: corev1.ProtocolTCP},
-			{Name: "otlp-grpc", ContainerPort: defaultOTLPGRPCPort, Protocol: corev1.ProtocolTCP},
-			{Name: "otlp-http", ContainerPort: defaultOTLPHTTPPort, Protocol: corev1.ProtocolTCP},
-			{Name: "jaeger-compact", ContainerPort: defaultJaegerThriftCompactPort, Protocol: corev1.ProtocolUDP},
-			{Name: "jaeger-binary", ContainerPort: defaultJaegerThriftBinaryPort, Protocol: corev1.ProtocolUDP},
-			{Name: "jaeger-http", ContainerPort: defaultJaegerThriftHTTPPort, Protocol: corev1.ProtocolTCP},
-			{Name: "jaeger-grpc", ContainerPort: defaultJaegerGRPCPort, Protocol: corev1.ProtocolTCP},
-			{Name: "zipkin", ContainerPort: defaultZipkinPort, Protocol: corev1.ProtocolTCP},
-		},
+		}, receiverContainerPorts(tempoSpec)...),
 		VolumeMounts: volumeMounts,
 		Resources:    tempoSpec.Resources,
 		LivenessProbe: &corev1.Probe{
@@ -537,6 +586,13 @@ func (m *TempoManager) reconcileStatefulSet(ctx context.Context, platform *obser
 		},
 	}
 	
+	// Zone-aware ingesters gossip the ring over memberlist and read their
+	// zone from the environment
+	if tempoSpec.ZoneAwareness.IsEnabled() {
+		container.Args = append(container.Args, "-config.expand-env=true")
+		container.Ports = append(container.Ports, corev1.ContainerPort{Name: "memberlist", ContainerPort: defaultMemberlistPort, Protocol: corev1.ProtocolTCP})
+	}
+	
 	// Create StatefulSet
 	sts := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -589,22 +645,76 @@ func (m *TempoManager) reconcileStatefulSet(ctx context.Context, platform *obser
 					},
 				},
 			},
-			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
-				Type: appsv1.RollingUpdateStatefulSetStrategyType,
-			},
 		},
 	}
 	
+	// Roll configuration changes as requested
+	managers.ApplyStatefulSetUpdateStrategy(&sts.Spec, tempoSpec.UpdateStrategy)
+	
+	// Mount the receiver serving certificate
+	applyReceiverTLS(&sts.Spec.Template.Spec, platform, tempoSpec.ReceiverTLS, profile)
+	
+	// Add extra containers and volumes; they precede the priority settings so guaranteed QoS covers them
+	managers.ApplyExtraContainers(&sts.Spec.Template.Spec, platform, "tempo", tempoSpec.ExtraContainers, tempoSpec.ExtraVolumes)
+	
+	// Add user-defined environment variables to the tempo container
+	managers.ApplyEnv(&sts.Spec.Template.Spec, platform, "tempo", componentName, tempoSpec.Env, tempoSpec.EnvFrom)
+	
+	// Override the health probes of the tempo container
+	managers.ApplyProbes(&sts.Spec.Template.Spec, componentName, tempoSpec.Probes)
+	
+	// Add affinity and topology spread constraints, component settings override global ones
+	scheduling.Apply(&sts.Spec.Template.Spec, managers.PlacementFor(platform, tempoSpec.Affinity, tempoSpec.TopologySpreadConstraints), m.getLabels(platform))
+	
+	// Add priority class and enforce guaranteed QoS if requested
+	managers.ApplyPriority(&sts.Spec.Template.Spec, platform, "tempo", tempoSpec.PriorityClassName, tempoSpec.GuaranteedQoS)
+	
+	// Schedule on the dedicated node pool if configured
+	managers.ApplyNodePool(&sts.Spec.Template.Spec, platform)
+	
+	// Size ephemeral storage and emptyDir volumes, and move the WAL to a dedicated volume if configured
+	managers.ApplyEphemeralStorage(&sts.Spec.Template.Spec, componentName, defaultDataPath+"/wal", tempoSpec.EphemeralStorage)
+	
+	// Roll the pods on changes of referenced configuration
+	if err := managers.ApplyConfigChecksum(ctx, m.Client, platform, componentName, tempoSpec.ReloadStrategy, &sts.Spec.Template); err != nil {
+		return err
+	}
+	
+	// Zone-aware ingesters run as one StatefulSet per zone
+	if tempoSpec.ZoneAwareness.IsEnabled() {
+		if err := managers.ReconcileZonalStatefulSets(ctx, m.Client, m.Scheme, platform, sts, tempoSpec.ZoneAwareness); err != nil {
+			return err
+		}
+		log.V(1).Info("Successfully reconciled zonal StatefulSets", "zones", tempoSpec.ZoneAwareness.Zones)
+		return nil
+	}
+	
 	// Set controller reference
 	if err := controllerutil.SetControllerReference(platform, sts, m.Scheme); err != nil {
 		return fmt.Errorf("failed to set controller reference: %w", err)
 	}
 	
+	// Release the ingesters one at a time if staged rollouts are enabled
+	previous := &appsv1.StatefulSet{}
+	if err := m.Get(ctx, client.ObjectKeyFromObject(sts), previous); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get StatefulSet: %w", err)
+	}
+	managers.ApplyStagedRollout(previous, sts, tempoSpec.UpdateStrategy, rollout.Config{
+		Port:      defaultHTTPPort,
+		RingPath:  ringPath,
+		FlushPath: flushPath,
+	})
+	
 	// Create or update the StatefulSet
 	if err := m.createOrUpdate(ctx, sts); err != nil {
 		return fmt.Errorf("failed to create/update StatefulSet: %w", err)
 	}
 	
+	// Remove zonal StatefulSets left over from a zone-aware deployment
+	if err := managers.DeleteZonalStatefulSets(ctx, m.Client, sts); err != nil {
+		return err
+	}
+	
 	log.V(1).Info("Successfully reconciled StatefulSet")
 	return nil
 }
@@ -650,38 +760,91 @@ func (m *TempoManager) reconcilePodDisruptionBudget(ctx context.Context, platfor
 func (m *TempoManager) generateTempoConfig(platform *observabilityv1beta1.ObservabilityPlatform, tempoSpec *observabilityv1beta1.TempoSpec) string {
 	var sb strings.Builder
 	
+	// Tenants written by the ingest gateway are kept apart
+	if platform.IsMultiTenant() {
+		sb.WriteString("multitenancy_enabled: true\n\n")
+	}
+	
 	// Server configuration
 	sb.WriteString("server:\n")
 	sb.WriteString(fmt.Sprintf("  http_listen_port: %d\n", defaultHTTPPort))
 	sb.WriteString(fmt.Sprintf("  grpc_listen_port: %d\n", defaultGRPCPort))
+	sb.WriteString(fmt.Sprintf("  log_level: %s\n", managers.LogLevel(platform, componentName, tempoSpec.ComponentLogging)))
+	var profile *fips.Profile
+	if platform.IsFIPSEnabled() {
+		profile = fips.Resolve(platform.Spec.Security.FIPS.MinTLSVersion, platform.Spec.Security.FIPS.CipherSuites)
+		sb.WriteString(fmt.Sprintf("  tls_min_version: %s\n", profile.ServerMinVersion()))
+		sb.WriteString(fmt.Sprintf("  tls_cipher_suites: %s\n", profile.ServerCipherSuites()))
+	}
 	sb.WriteString("\n")
 	
 	// Distributor configuration
 	sb.WriteString("distributor:\n")
 	sb.WriteString("  receivers:\n")
-	sb.WriteString("    otlp:\n")
-	sb.WriteString("      protocols:\n")
-	sb.WriteString("        grpc:\n")
-	sb.WriteString(fmt.Sprintf("          endpoint: 0.0.0.0:%d\n", defaultOTLPGRPCPort))
-	sb.WriteString("        http:\n")
-	sb.WriteString(fmt.Sprintf("          endpoint: 0.0.0.0:%d\n", defaultOTLPHTTPPort))
-	sb.WriteString("    jaeger:\n")
-	sb.WriteString("      protocols:\n")
-	sb.WriteString("        thrift_compact:\n")
-	sb.WriteString(fmt.Sprintf("          endpoint: 0.0.0.0:%d\n", defaultJaegerThriftCompactPort))
-	sb.WriteString("        thrift_binary:\n")
-	sb.WriteString(fmt.Sprintf("          endpoint: 0.0.0.0:%d\n", defaultJaegerThriftBinaryPort))
-	sb.WriteString("        thrift_http:\n")
-	sb.WriteString(fmt.Sprintf("          endpoint: 0.0.0.0:%d\n", defaultJaegerThriftHTTPPort))
-	sb.WriteString("        grpc:\n")
-	sb.WriteString(fmt.Sprintf("          endpoint: 0.0.0.0:%d\n", defaultJaegerGRPCPort))
-	sb.WriteString("    zipkin:\n")
-	sb.WriteString(fmt.Sprintf("      endpoint: 0.0.0.0:%d\n", defaultZipkinPort))
+	traceReceivers := tempoSpec.Receivers
+	if traceReceivers.IsEnabled(observabilityv1beta1.ReceiverOTLPGRPC) || traceReceivers.IsEnabled(observabilityv1beta1.ReceiverOTLPHTTP) {
+		sb.WriteString("    otlp:\n")
+		sb.WriteString("      protocols:\n")
+		if traceReceivers.IsEnabled(observabilityv1beta1.ReceiverOTLPGRPC) {
+			sb.WriteString("        grpc:\n")
+			sb.WriteString(fmt.Sprintf("          endpoint: 0.0.0.0:%d\n", defaultOTLPGRPCPort))
+			writeReceiverTLS(&sb, "          ", tempoSpec.ReceiverTLS, profile)
+		}
+		if traceReceivers.IsEnabled(observabilityv1beta1.ReceiverOTLPHTTP) {
+			sb.WriteString("        http:\n")
+			sb.WriteString(fmt.Sprintf("          endpoint: 0.0.0.0:%d\n", defaultOTLPHTTPPort))
+			writeReceiverTLS(&sb, "          ", tempoSpec.ReceiverTLS, profile)
+		}
+	}
+	if traceReceivers.IsEnabled(observabilityv1beta1.ReceiverJaegerThriftCompact) || traceReceivers.IsEnabled(observabilityv1beta1.ReceiverJaegerThriftBinary) ||
+		traceReceivers.IsEnabled(observabilityv1beta1.ReceiverJaegerThriftHTTP) || traceReceivers.IsEnabled(observabilityv1beta1.ReceiverJaegerGRPC) {
+		sb.WriteString("    jaeger:\n")
+		sb.WriteString("      protocols:\n")
+		if traceReceivers.IsEnabled(observabilityv1beta1.ReceiverJaegerThriftCompact) {
+			sb.WriteString("        thrift_compact:\n")
+			sb.WriteString(fmt.Sprintf("          endpoint: 0.0.0.0:%d\n", defaultJaegerThriftCompactPort))
+		}
+		if traceReceivers.IsEnabled(observabilityv1beta1.ReceiverJaegerThriftBinary) {
+			sb.WriteString("        thrift_binary:\n")
+			sb.WriteString(fmt.Sprintf("          endpoint: 0.0.0.0:%d\n", defaultJaegerThriftBinaryPort))
+		}
+		if traceReceivers.IsEnabled(observabilityv1beta1.ReceiverJaegerThriftHTTP) {
+			sb.WriteString("        thrift_http:\n")
+			sb.WriteString(fmt.Sprintf("          endpoint: 0.0.0.0:%d\n", defaultJaegerThriftHTTPPort))
+			writeReceiverTLS(&sb, "          ", tempoSpec.ReceiverTLS, profile)
+		}
+		if traceReceivers.IsEnabled(observabilityv1beta1.ReceiverJaegerGRPC) {
+			sb.WriteString("        grpc:\n")
+			sb.WriteString(fmt.Sprintf("          endpoint: 0.0.0.0:%d\n", defaultJaegerGRPCPort))
+			writeReceiverTLS(&sb, "          ", tempoSpec.ReceiverTLS, profile)
+		}
+	}
+	if traceReceivers.IsEnabled(observabilityv1beta1.ReceiverZipkin) {
+		sb.WriteString("    zipkin:\n")
+		sb.WriteString(fmt.Sprintf("      endpoint: 0.0.0.0:%d\n", defaultZipkinPort))
+		writeReceiverTLS(&sb, "      ", tempoSpec.ReceiverTLS, profile)
+	}
 	sb.WriteString("\n")
 	
 	// Ingester configuration
 	sb.WriteString("ingester:\n")
 	sb.WriteString("  max_block_duration: 5m\n")
+	if zoneAwareness := tempoSpec.ZoneAwareness; zoneAwareness.IsEnabled() {
+		// Zone-aware ingesters share the ring over memberlist, one replica per zone
+		sb.WriteString("  lifecycler:\n")
+		sb.WriteString(fmt.Sprintf("    availability_zone: ${%s}\n", zones.EnvVar))
+		sb.WriteString("    ring:\n")
+		sb.WriteString(fmt.Sprintf("      replication_factor: %d\n", zoneAwareness.GetReplicationFactor()))
+		sb.WriteString("      zone_awareness_enabled: true\n")
+		sb.WriteString("      kvstore:\n")
+		sb.WriteString("        store: memberlist\n")
+		sb.WriteString("\n")
+		
+		sb.WriteString("memberlist:\n")
+		sb.WriteString(fmt.Sprintf("  bind_port: %d\n", defaultMemberlistPort))
+		sb.WriteString("  join_members:\n")
+		sb.WriteString(fmt.Sprintf("    - %s-%s-headless.%s.svc.cluster.local:%d\n", platform.Name, componentName, platform.Namespace, defaultMemberlistPort))
+	}
 	sb.WriteString("\n")
 	
 	// Compactor configuration
@@ -694,7 +857,13 @@ func (m *TempoManager) generateTempoConfig(platform *observabilityv1beta1.Observ
 	sb.WriteString("metrics_generator:\n")
 	sb.WriteString("  registry:\n")
 	sb.WriteString("    external_labels:\n")
-	for k, v := range platform.Spec.Global.ExternalLabels {
+	var globalLabels map[string]string
+	if platform.Spec.Global != nil {
+		globalLabels = platform.Spec.Global.ExternalLabels
+	}
+	// The correlation labels match the generated metrics with the traces
+	// and with the metrics and logs of the same source
+	for k, v := range correlation.Merge(globalLabels, platform.CorrelationLabels()) {
 		sb.WriteString(fmt.Sprintf("      %s: %s\n", k, v))
 	}
 	sb.WriteString("  storage:\n")
@@ -752,6 +921,9 @@ func (m *TempoManager) generateTempoConfig(platform *observabilityv1beta1.Observ
 	sb.WriteString("overrides:\n")
 	sb.WriteString("  max_traces_per_user: 10000\n")
 	sb.WriteString(fmt.Sprintf("  max_search_duration: %s\n", tempoSpec.Retention))
+	// Per-tenant overrides of TempoConfigs, reloaded without a restart
+	sb.WriteString(fmt.Sprintf("  per_tenant_override_config: %s\n", temposync.OverridesPath()))
+	sb.WriteString(fmt.Sprintf("  per_tenant_override_period: %s\n", temposync.ReloadPeriod))
 	
 	return sb.String()
 }
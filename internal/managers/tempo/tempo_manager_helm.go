@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,6 +32,9 @@ const (
 	// Default values
 	defaultHTTPPortHelm = 3200
 	defaultGRPCPortHelm = 9095
+	
+	// helmWALPath is the WAL directory of the traces
+	helmWALPath = "/var/tempo/wal"
 )
 
 // TempoManagerHelm manages Tempo deployments using Helm
@@ -197,6 +201,14 @@ func (m *TempoManagerHelm) UpdateSampling(ctx context.Context, platform *observa
 	return m.ReconcileWithConfig(ctx, platform, nil)
 }
 
+// chartSelectorLabels returns the labels the chart sets on the Tempo pods
+func chartSelectorLabels(platform *observabilityv1beta1.ObservabilityPlatform) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":     chartName,
+		"app.kubernetes.io/instance": helm.ReleaseName(platform, componentNameHelm),
+	}
+}
+
 // buildHelmValues builds Helm values for Tempo
 func (m *TempoManagerHelm) buildHelmValues(
 	platform *observabilityv1beta1.ObservabilityPlatform,
@@ -209,6 +221,12 @@ func (m *TempoManagerHelm) buildHelmValues(
 		return nil, err
 	}
 	
+	// The chart runs the ingesters in a single StatefulSet, which can't be
+	// split into the zonal StatefulSets of the native manager
+	if tempoSpec.ZoneAwareness.IsEnabled() {
+		return nil, fmt.Errorf("zone awareness is not supported when Tempo is deployed with Helm")
+	}
+	
 	// Configure Tempo
 	tempo := map[string]interface{}{
 		"repository": "grafana/tempo",
@@ -232,7 +250,7 @@ func (m *TempoManagerHelm) buildHelmValues(
 				"path": "/var/tempo/traces",
 			},
 			"wal": map[string]interface{}{
-				"path": "/var/tempo/wal",
+				"path": helmWALPath,
 			},
 		},
 	}
@@ -299,7 +317,7 @@ func (m *TempoManagerHelm) buildHelmValues(
 	server := map[string]interface{}{
 		"http_listen_port": defaultHTTPPortHelm,
 		"grpc_listen_port": defaultGRPCPortHelm,
-		"log_level":        "info",
+		"log_level":        managers.LogLevel(platform, componentNameHelm, tempoSpec.ComponentLogging),
 	}
 	
 	// Configure distributor
@@ -326,13 +344,18 @@ func (m *TempoManagerHelm) buildHelmValues(
 		},
 	}
 	
-	// Configure metrics generator
+	// Configure metrics generator. The correlation labels override the
+	// defaults, so the generated metrics match the other signals of the source.
+	generatorLabels := map[string]interface{}{
+		"source": "tempo",
+		"cluster": platform.Name,
+	}
+	for k, v := range platform.CorrelationLabels() {
+		generatorLabels[k] = v
+	}
 	metricsGenerator := map[string]interface{}{
 		"registry": map[string]interface{}{
-			"external_labels": map[string]interface{}{
-				"source": "tempo",
-				"cluster": platform.Name,
-			},
+			"external_labels": generatorLabels,
 		},
 		"storage": map[string]interface{}{
 			"path": "/var/tempo/generator/wal",
@@ -347,14 +370,14 @@ func (m *TempoManagerHelm) buildHelmValues(
 	
 	// Build config
 	config := map[string]interface{}{
-		"auth_enabled":      false,
-		"server":            server,
-		"distributor":       distributor,
-		"ingester":          ingester,
-		"compactor":         compactor,
-		"querier":           querier,
-		"storage":           storage,
-		"metrics_generator": metricsGenerator,
+		"multitenancy_enabled": platform.IsMultiTenant(),
+		"server":               server,
+		"distributor":          distributor,
+		"ingester":             ingester,
+		"compactor":            compactor,
+		"querier":              querier,
+		"storage":              storage,
+		"metrics_generator":    metricsGenerator,
 		"overrides": map[string]interface{}{
 			"max_traces_per_user": 10000,
 			"max_search_duration": "48h",
@@ -405,9 +428,10 @@ func (m *TempoManagerHelm) buildHelmValues(
 		"enabled": false,
 	}
 	
-	// Multi-tenancy (disabled for simplicity)
+	// Multi-tenancy, enabled to keep the tenants of the ingest gateway apart
+	tempo["multitenancyEnabled"] = platform.IsMultiTenant()
 	values["multitenancy"] = map[string]interface{}{
-		"enabled": false,
+		"enabled": platform.IsMultiTenant(),
 	}
 	
 	// Minio (disabled, using local storage or external S3)
@@ -429,7 +453,7 @@ func (m *TempoManagerHelm) buildHelmValues(
 		"fsGroup":      10001,
 	}
 	
-	// Node selector, tolerations, and affinity from global settings
+	// Node selector and tolerations from global settings
 	if platform.Spec.Global != nil {
 		if len(platform.Spec.Global.NodeSelector) > 0 {
 			values["nodeSelector"] = platform.Spec.Global.NodeSelector
@@ -437,8 +461,61 @@ func (m *TempoManagerHelm) buildHelmValues(
 		if len(platform.Spec.Global.Tolerations) > 0 {
 			values["tolerations"] = platform.Spec.Global.Tolerations
 		}
-		if platform.Spec.Global.Affinity != nil {
-			values["affinity"] = platform.Spec.Global.Affinity
+	}
+	
+	// Affinity and topology spread constraints, component settings override global ones
+	placement := managers.PlacementFor(platform, tempoSpec.Affinity, tempoSpec.TopologySpreadConstraints)
+	if placement.Affinity != nil {
+		values["affinity"] = placement.Affinity
+	}
+	if constraints := placement.SpreadConstraints(chartSelectorLabels(platform)); len(constraints) > 0 {
+		values["topologySpreadConstraints"] = constraints
+	}
+	
+	// Dedicated node pool, merged with the global node selector and tolerations
+	if nodeSelector, tolerations, ok := managers.NodePoolValues(platform); ok {
+		values["nodeSelector"] = nodeSelector
+		values["tolerations"] = tolerations
+	}
+	
+	// Priority class and guaranteed QoS, component settings override global ones
+	priority := managers.PriorityFor(platform, tempoSpec.PriorityClassName, tempoSpec.GuaranteedQoS)
+	if priority.PriorityClassName != "" {
+		values["priorityClassName"] = priority.PriorityClassName
+	}
+	if priority.GuaranteedQoS && tempoSpec.Resources != nil {
+		if tempo, ok := values["tempo"].(map[string]interface{}); ok {
+			tempo["resources"] = managers.GuaranteedResources(tempoSpec.Resources)
+		}
+	}
+	
+	// Extra containers and volumes
+	if len(tempoSpec.ExtraContainers) > 0 {
+		values["extraContainers"] = tempoSpec.ExtraContainers
+	}
+	if len(tempoSpec.ExtraVolumes) > 0 {
+		values["extraVolumes"] = tempoSpec.ExtraVolumes
+	}
+	
+	// Ephemeral storage and the dedicated WAL volume; the chart has no size
+	// limit for its emptyDir volumes
+	if storage := tempoSpec.EphemeralStorage; storage != nil {
+		if tempo, ok := values["tempo"].(map[string]interface{}); ok {
+			tempo["resources"] = managers.EphemeralStorageResources(tempo["resources"], storage)
+			if volume, mount, ok := managers.WALVolume(storage, helmWALPath); ok {
+				values["extraVolumes"] = append(append([]corev1.Volume{}, tempoSpec.ExtraVolumes...), volume)
+				tempo["extraVolumeMounts"] = []corev1.VolumeMount{mount}
+			}
+		}
+	}
+	
+	// User-defined environment variables
+	if tempo, ok := values["tempo"].(map[string]interface{}); ok {
+		if len(tempoSpec.Env) > 0 {
+			tempo["extraEnv"] = tempoSpec.Env
+		}
+		if len(tempoSpec.EnvFrom) > 0 {
+			tempo["extraEnvFrom"] = tempoSpec.EnvFrom
 		}
 	}
 	
@@ -9,6 +9,7 @@ package tempo
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -95,6 +96,16 @@ func TestTempoManager_Reconcile(t *testing.T) {
 				assert.Contains(t, cm.Data["tempo.yaml"], "http_listen_port: 3200")
 				assert.Contains(t, cm.Data["tempo.yaml"], "grpc_listen_port: 9095")
 				assert.Contains(t, cm.Data["tempo.yaml"], "max_search_duration: 168h")
+				assert.Contains(t, cm.Data["tempo.yaml"], "per_tenant_override_config: /conf/overrides/overrides.yaml")
+				
+				// Check the overrides file TempoConfigs are applied to
+				overrides := &corev1.ConfigMap{}
+				err = c.Get(context.Background(), types.NamespacedName{
+					Name:      "test-platform-tempo-overrides",
+					Namespace: "test-namespace",
+				}, overrides)
+				assert.NoError(t, err)
+				assert.Equal(t, "overrides: {}\n", overrides.Data["overrides.yaml"])
 				
 				// Check Services
 				svc := &corev1.Service{}
@@ -895,6 +906,27 @@ func TestTempoManager_generateTempoConfig(t *testing.T) {
 				assert.Contains(t, config, "cluster: test")
 				assert.Contains(t, config, "env: dev")
 				assert.Contains(t, config, "max_search_duration: 168h")
+				assert.NotContains(t, config, "multitenancy_enabled")
+			},
+		},
+		{
+			name: "multi-tenancy with the ingest gateway",
+			platform: &observabilityv1beta1.ObservabilityPlatform{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-platform",
+				},
+				Spec: observabilityv1beta1.ObservabilityPlatformSpec{
+					IngestGateway: &observabilityv1beta1.IngestGatewaySpec{
+						Enabled: true,
+						Tenants: []observabilityv1beta1.IngestTenantSpec{{Name: "team-a"}},
+					},
+				},
+			},
+			tempoSpec: &observabilityv1beta1.TempoSpec{
+				Retention: "168h",
+			},
+			configCheck: func(t *testing.T, config string) {
+				assert.True(t, strings.HasPrefix(config, "multitenancy_enabled: true\n"))
 			},
 		},
 		{
@@ -931,6 +963,83 @@ func TestTempoManager_generateTempoConfig(t *testing.T) {
 				assert.Contains(t, config, "max_search_duration: 336h")
 			},
 		},
+		{
+			name: "receiver TLS configuration",
+			platform: &observabilityv1beta1.ObservabilityPlatform{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-platform",
+				},
+			},
+			tempoSpec: &observabilityv1beta1.TempoSpec{
+				Retention: "168h",
+				ReceiverTLS: &observabilityv1beta1.ReceiverTLSSpec{
+					Enabled:            true,
+					IssuerRef:          &observabilityv1beta1.CertManagerIssuerRef{Name: "internal-ca"},
+					ClientCASecretName: "trace-clients-ca",
+				},
+			},
+			configCheck: func(t *testing.T, config string) {
+				// OTLP gRPC and HTTP, Jaeger Thrift HTTP and gRPC, and Zipkin
+				assert.Equal(t, 5, strings.Count(config, "cert_file: /etc/tempo-tls/tls.crt"))
+				assert.Equal(t, 5, strings.Count(config, "key_file: /etc/tempo-tls/tls.key"))
+				assert.Equal(t, 5, strings.Count(config, "client_ca_file: /etc/tempo-tls-client-ca/ca.crt"))
+				assert.Contains(t, config, "      endpoint: 0.0.0.0:9411\n      tls:\n")
+			},
+		},
+		{
+			name: "receiver TLS in FIPS mode",
+			platform: &observabilityv1beta1.ObservabilityPlatform{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-platform",
+				},
+				Spec: observabilityv1beta1.ObservabilityPlatformSpec{
+					Security: &observabilityv1beta1.SecuritySettings{
+						FIPS: &observabilityv1beta1.FIPSSpec{
+							Enabled:       true,
+							MinTLSVersion: "1.2",
+							CipherSuites:  []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+						},
+					},
+				},
+			},
+			tempoSpec: &observabilityv1beta1.TempoSpec{
+				Retention: "168h",
+				ReceiverTLS: &observabilityv1beta1.ReceiverTLSSpec{
+					Enabled:    true,
+					SecretName: "tempo-receiver-tls",
+				},
+			},
+			configCheck: func(t *testing.T, config string) {
+				assert.Contains(t, config, "  tls_min_version: VersionTLS12\n  tls_cipher_suites: TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384\n")
+				assert.Equal(t, 5, strings.Count(config, "min_version: \"1.2\"\n"))
+				assert.Equal(t, 5, strings.Count(config, "cipher_suites:\n"))
+				assert.Contains(t, config, "      endpoint: 0.0.0.0:9411\n      tls:\n")
+				assert.Contains(t, config, "        min_version: \"1.2\"\n        cipher_suites:\n          - TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384\n")
+			},
+		},
+		{
+			name: "disabled receivers",
+			platform: &observabilityv1beta1.ObservabilityPlatform{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-platform",
+				},
+			},
+			tempoSpec: &observabilityv1beta1.TempoSpec{
+				Retention: "168h",
+				Receivers: &observabilityv1beta1.TraceReceiversSpec{
+					OTLPHTTP:            &observabilityv1beta1.TraceReceiverSpec{Enabled: ptrBool(false)},
+					JaegerThriftCompact: &observabilityv1beta1.TraceReceiverSpec{Enabled: ptrBool(false)},
+					JaegerThriftBinary:  &observabilityv1beta1.TraceReceiverSpec{Enabled: ptrBool(false)},
+				},
+			},
+			configCheck: func(t *testing.T, config string) {
+				assert.Contains(t, config, "endpoint: 0.0.0.0:4317")
+				assert.NotContains(t, config, "endpoint: 0.0.0.0:4318")
+				assert.NotContains(t, config, "thrift_compact:")
+				assert.NotContains(t, config, "thrift_binary:")
+				assert.Contains(t, config, "thrift_http:")
+			},
+		},
 	}
 	
 	for _, tt := range tests {
@@ -951,6 +1060,36 @@ func TestTempoManager_generateTempoConfig(t *testing.T) {
 	}
 }
 
+func TestReceiverPorts(t *testing.T) {
+	tempoSpec := &observabilityv1beta1.TempoSpec{}
+	assert.Len(t, receiverContainerPorts(tempoSpec), 7)
+	assert.Len(t, receiverServicePorts(tempoSpec), 7)
+
+	tempoSpec.Receivers = &observabilityv1beta1.TraceReceiversSpec{
+		JaegerGRPC: &observabilityv1beta1.TraceReceiverSpec{Enabled: ptrBool(false)},
+		Zipkin:     &observabilityv1beta1.TraceReceiverSpec{Enabled: ptrBool(true)},
+	}
+	ports := receiverServicePorts(tempoSpec)
+	require.Len(t, ports, 6)
+	for _, port := range ports {
+		assert.NotEqual(t, observabilityv1beta1.ReceiverJaegerGRPC, port.Name)
+	}
+	for _, port := range receiverContainerPorts(tempoSpec) {
+		assert.LessOrEqual(t, len(port.Name), 15)
+	}
+}
+
+func TestLoadBalancerAddress(t *testing.T) {
+	svc := &corev1.Service{}
+	assert.Empty(t, loadBalancerAddress(svc))
+
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "203.0.113.10"}}
+	assert.Equal(t, "203.0.113.10", loadBalancerAddress(svc))
+
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: "lb.example.com", IP: "203.0.113.10"}}
+	assert.Equal(t, "lb.example.com", loadBalancerAddress(svc))
+}
+
 // Helper functions
 func ptrString(s string) *string {
 	return &s
@@ -959,3 +1098,7 @@ func ptrString(s string) *string {
 func ptrInt32(i int32) *int32 {
 	return &i
 }
+
+func ptrBool(b bool) *bool {
+	return &b
+}
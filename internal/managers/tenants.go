@@ -0,0 +1,40 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package managers
+
+import (
+	"strings"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// TenantHeader is the header carrying the tenant to Loki and Tempo
+const TenantHeader = "X-Scope-OrgID"
+
+// QueryTenants returns the X-Scope-OrgID value of the Loki and Tempo
+// datasources of the main Grafana organization: the tenants it queries,
+// separated by "|" for a multi-tenant query. Empty without multi-tenancy.
+func QueryTenants(platform *observabilityv1beta1.ObservabilityPlatform) string {
+	return strings.Join(platform.QueryTenantIDs(), "|")
+}
+
+// SetTenantHeader makes a Loki or Tempo datasource send the tenants of
+// QueryTenants. The value is kept in secureJsonData, which Grafana doesn't
+// return to its users.
+func SetTenantHeader(platform *observabilityv1beta1.ObservabilityPlatform, datasource map[string]interface{}) {
+	tenants := QueryTenants(platform)
+	if tenants == "" {
+		return
+	}
+	jsonData, ok := datasource["jsonData"].(map[string]interface{})
+	if !ok {
+		jsonData = map[string]interface{}{}
+		datasource["jsonData"] = jsonData
+	}
+	jsonData["httpHeaderName1"] = TenantHeader
+	datasource["secureJsonData"] = map[string]interface{}{"httpHeaderValue1": tenants}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package managers
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/updatestrategy"
+)
+
+// ApplyStatefulSetUpdateStrategy sets the update strategy of a component's
+// StatefulSet. Without a strategy the pods are rolled one at a time.
+func ApplyStatefulSetUpdateStrategy(spec *appsv1.StatefulSetSpec, strategy *observabilityv1beta1.ComponentUpdateStrategy) {
+	spec.UpdateStrategy = updatestrategy.ForStatefulSet(UpdateStrategyFor(strategy))
+}
+
+// ApplyDeploymentStrategy sets the update strategy of a component's
+// Deployment. Without a strategy the Deployment defaults apply.
+func ApplyDeploymentStrategy(spec *appsv1.DeploymentSpec, strategy *observabilityv1beta1.ComponentUpdateStrategy) {
+	if strategy == nil {
+		return
+	}
+	spec.Strategy = updatestrategy.ForDeployment(UpdateStrategyFor(strategy))
+}
+
+// UpdateStrategyFor converts the update strategy of a component
+func UpdateStrategyFor(strategy *observabilityv1beta1.ComponentUpdateStrategy) updatestrategy.Strategy {
+	if strategy == nil {
+		return updatestrategy.Strategy{}
+	}
+	return updatestrategy.Strategy{
+		Type:           strategy.Type,
+		MaxUnavailable: strategy.MaxUnavailable,
+		MaxSurge:       strategy.MaxSurge,
+		Partition:      strategy.Partition,
+	}
+}
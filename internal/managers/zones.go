@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package managers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/zones"
+)
+
+// ReconcileZonalStatefulSets creates one StatefulSet per zone from base and
+// removes the unzoned StatefulSet and those of zones no longer configured.
+// It fails without changing anything if a zone has no schedulable nodes.
+func ReconcileZonalStatefulSets(ctx context.Context, c client.Client, scheme *runtime.Scheme, platform *observabilityv1beta1.ObservabilityPlatform, base *appsv1.StatefulSet, spec *observabilityv1beta1.ZoneAwarenessSpec) error {
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+	if missing := zones.Missing(zones.Available(nodes.Items, spec.GetTopologyKey()), spec.Zones); len(missing) > 0 {
+		return fmt.Errorf("no schedulable nodes with label %s in zones %s", spec.GetTopologyKey(), strings.Join(missing, ", "))
+	}
+
+	desired := make(map[string]bool, len(spec.Zones))
+	for _, zone := range spec.Zones {
+		zonal := zones.ForZone(base, spec.GetTopologyKey(), zone, spec.GetReplicasPerZone())
+		desired[zonal.Name] = true
+
+		sts := &appsv1.StatefulSet{ObjectMeta: zonal.ObjectMeta}
+		if _, err := controllerutil.CreateOrUpdate(ctx, c, sts, func() error {
+			sts.Labels = zonal.Labels
+			sts.Spec = zonal.Spec
+			return controllerutil.SetControllerReference(platform, sts, scheme)
+		}); err != nil {
+			return fmt.Errorf("failed to create/update StatefulSet %s: %w", zonal.Name, err)
+		}
+	}
+
+	// The unzoned StatefulSet is replaced by the zonal ones
+	unzoned := &appsv1.StatefulSet{}
+	unzoned.Name, unzoned.Namespace = base.Name, base.Namespace
+	if err := c.Delete(ctx, unzoned); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete StatefulSet %s: %w", base.Name, err)
+	}
+
+	return deleteZonalStatefulSets(ctx, c, base, desired)
+}
+
+// DeleteZonalStatefulSets removes the zonal StatefulSets derived from base,
+// used when zone awareness is turned off
+func DeleteZonalStatefulSets(ctx context.Context, c client.Client, base *appsv1.StatefulSet) error {
+	return deleteZonalStatefulSets(ctx, c, base, nil)
+}
+
+func deleteZonalStatefulSets(ctx context.Context, c client.Client, base *appsv1.StatefulSet, keep map[string]bool) error {
+	if base.Spec.Selector == nil {
+		return nil
+	}
+	zoned, err := labels.NewRequirement(zones.ZoneLabel, selection.Exists, nil)
+	if err != nil {
+		return err
+	}
+	selector := labels.SelectorFromSet(base.Spec.Selector.MatchLabels).Add(*zoned)
+
+	list := &appsv1.StatefulSetList{}
+	if err := c.List(ctx, list, client.InNamespace(base.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("failed to list zonal StatefulSets: %w", err)
+	}
+	for i := range list.Items {
+		sts := &list.Items[i]
+		if keep[sts.Name] || !strings.HasPrefix(sts.Name, base.Name+"-") {
+			continue
+		}
+		if err := c.Delete(ctx, sts); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete StatefulSet %s: %w", sts.Name, err)
+		}
+	}
+	return nil
+}
+
+// GetZonalStatefulSet returns the zonal StatefulSets derived from baseName
+// aggregated into one, so status checks can treat them as a single workload
+func GetZonalStatefulSet(ctx context.Context, c client.Client, namespace, baseName string, spec *observabilityv1beta1.ZoneAwarenessSpec) (*appsv1.StatefulSet, error) {
+	var desired int32
+	aggregated := &appsv1.StatefulSet{}
+	aggregated.Name, aggregated.Namespace = baseName, namespace
+
+	for _, zone := range spec.Zones {
+		sts := &appsv1.StatefulSet{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: zones.Name(baseName, zone)}, sts); err != nil {
+			return nil, err
+		}
+		if sts.Spec.Replicas != nil {
+			desired += *sts.Spec.Replicas
+		}
+		aggregated.Status.Replicas += sts.Status.Replicas
+		aggregated.Status.ReadyReplicas += sts.Status.ReadyReplicas
+	}
+
+	aggregated.Spec.Replicas = &desired
+	return aggregated, nil
+}
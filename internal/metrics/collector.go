@@ -7,6 +7,8 @@ Licensed under the MIT License.
 package metrics
 
 import (
+	"strconv"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
@@ -18,6 +20,17 @@ type Collector struct {
 	reconcileDuration *prometheus.HistogramVec
 	platformsTotal    *prometheus.GaugeVec
 	componentStatus   *prometheus.GaugeVec
+	queryLogQueries   *prometheus.GaugeVec
+	queryLogSlow      *prometheus.GaugeVec
+	queryLogTop       *prometheus.GaugeVec
+	noisyAlerts       *prometheus.GaugeVec
+	noisyAlertFirings *prometheus.GaugeVec
+	cacheObjects      *prometheus.GaugeVec
+	cacheBytes        *prometheus.GaugeVec
+	cacheStripped     *prometheus.CounterVec
+	statusWrites      prometheus.Counter
+	statusUpdates     prometheus.Counter
+	statusSuppressed  prometheus.Counter
 }
 
 // NewCollector creates a new metrics collector
@@ -59,6 +72,80 @@ func NewCollector() *Collector {
 			},
 			[]string{"platform", "namespace", "component"},
 		),
+		queryLogQueries: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "gunj_operator_prometheus_queries",
+				Help: "Number of queries executed by the managed Prometheus in the last query log analysis window",
+			},
+			[]string{"platform", "namespace"},
+		),
+		queryLogSlow: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "gunj_operator_prometheus_slow_queries",
+				Help: "Number of queries above the slow query threshold in the last query log analysis window",
+			},
+			[]string{"platform", "namespace"},
+		),
+		queryLogTop: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "gunj_operator_prometheus_top_query_seconds",
+				Help: "Total execution time of the most expensive queries by rank; the queries are listed in the query report ConfigMap",
+			},
+			[]string{"platform", "namespace", "rank"},
+		),
+		noisyAlerts: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "gunj_operator_noisy_alerts",
+				Help: "Number of alerts of the managed Prometheus reported as noisy in the last analysis",
+			},
+			[]string{"platform", "namespace"},
+		),
+		noisyAlertFirings: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "gunj_operator_noisy_alert_firings",
+				Help: "Number of firings of the noisy alerts within the analysis window; the suggestions are in the noisy alerts ConfigMap",
+			},
+			[]string{"platform", "namespace", "alertname"},
+		),
+		cacheObjects: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "gunj_operator_cache_objects",
+				Help: "Number of objects held by the informer cache by kind",
+			},
+			[]string{"kind"},
+		),
+		cacheBytes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "gunj_operator_cache_bytes",
+				Help: "Estimated size of the objects held by the informer cache by kind, after transforms",
+			},
+			[]string{"kind"},
+		),
+		cacheStripped: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "gunj_operator_cache_stripped_bytes_total",
+				Help: "Bytes of managed fields and annotations stripped from objects before caching them, by kind",
+			},
+			[]string{"kind"},
+		),
+		statusWrites: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "gunj_operator_status_writes_total",
+				Help: "Number of platform status writes to the API server",
+			},
+		),
+		statusUpdates: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "gunj_operator_status_updates_total",
+				Help: "Number of platform status updates requested by the controller, batched into fewer writes",
+			},
+		),
+		statusSuppressed: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "gunj_operator_status_updates_suppressed_total",
+				Help: "Number of platform status updates not written because they did not change the status",
+			},
+		),
 	}
 
 	// Register metrics with the controller-runtime metrics registry
@@ -68,6 +155,17 @@ func NewCollector() *Collector {
 		collector.reconcileDuration,
 		collector.platformsTotal,
 		collector.componentStatus,
+		collector.queryLogQueries,
+		collector.queryLogSlow,
+		collector.queryLogTop,
+		collector.noisyAlerts,
+		collector.noisyAlertFirings,
+		collector.cacheObjects,
+		collector.cacheBytes,
+		collector.cacheStripped,
+		collector.statusWrites,
+		collector.statusUpdates,
+		collector.statusSuppressed,
 	)
 
 	return collector
@@ -106,3 +204,66 @@ func (c *Collector) RecordPlatformCount(counts map[string]int) {
 		c.platformsTotal.WithLabelValues(phase).Set(float64(count))
 	}
 }
+
+// RecordQueryReport records the summary of a Prometheus query log analysis.
+// The top queries are labeled by rank rather than query text to bound the
+// cardinality.
+func (c *Collector) RecordQueryReport(platform, namespace string, total, slow int, topSeconds []float64) {
+	c.queryLogQueries.WithLabelValues(platform, namespace).Set(float64(total))
+	c.queryLogSlow.WithLabelValues(platform, namespace).Set(float64(slow))
+	c.queryLogTop.DeletePartialMatch(prometheus.Labels{"platform": platform, "namespace": namespace})
+	for i, seconds := range topSeconds {
+		c.queryLogTop.WithLabelValues(platform, namespace, strconv.Itoa(i+1)).Set(seconds)
+	}
+}
+
+// DeleteQueryReport removes the query report metrics of a platform
+func (c *Collector) DeleteQueryReport(platform, namespace string) {
+	labels := prometheus.Labels{"platform": platform, "namespace": namespace}
+	c.queryLogQueries.Delete(labels)
+	c.queryLogSlow.Delete(labels)
+	c.queryLogTop.DeletePartialMatch(labels)
+}
+
+// RecordNoisyAlerts records the firings of the noisy alerts of a platform.
+// Only the reported alerts are labeled, which bounds the cardinality.
+func (c *Collector) RecordNoisyAlerts(platform, namespace string, firings map[string]int) {
+	c.noisyAlerts.WithLabelValues(platform, namespace).Set(float64(len(firings)))
+	c.noisyAlertFirings.DeletePartialMatch(prometheus.Labels{"platform": platform, "namespace": namespace})
+	for alert, count := range firings {
+		c.noisyAlertFirings.WithLabelValues(platform, namespace, alert).Set(float64(count))
+	}
+}
+
+// DeleteNoisyAlerts removes the noisy alert metrics of a platform
+func (c *Collector) DeleteNoisyAlerts(platform, namespace string) {
+	labels := prometheus.Labels{"platform": platform, "namespace": namespace}
+	c.noisyAlerts.Delete(labels)
+	c.noisyAlertFirings.DeletePartialMatch(labels)
+}
+
+// RecordCacheSize records the number and estimated size of the cached
+// objects of a kind
+func (c *Collector) RecordCacheSize(kind string, objects, bytes int) {
+	c.cacheObjects.WithLabelValues(kind).Set(float64(objects))
+	c.cacheBytes.WithLabelValues(kind).Set(float64(bytes))
+}
+
+// RecordCacheStripped records the bytes stripped from an object of a kind
+// before caching it
+func (c *Collector) RecordCacheStripped(kind string, bytes int) {
+	c.cacheStripped.WithLabelValues(kind).Add(float64(bytes))
+}
+
+// RecordStatusWrite records a status write batching a number of updates
+func (c *Collector) RecordStatusWrite(updates int) {
+	c.statusWrites.Inc()
+	c.statusUpdates.Add(float64(updates))
+}
+
+// RecordStatusUpdatesSuppressed records a number of updates that did not
+// change the status and were not written
+func (c *Collector) RecordStatusUpdatesSuppressed(updates int) {
+	c.statusUpdates.Add(float64(updates))
+	c.statusSuppressed.Add(float64(updates))
+}
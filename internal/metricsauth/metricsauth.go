@@ -0,0 +1,284 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package metricsauth protects the metrics endpoint of the operator: scrapers
+// authenticate with a client certificate or a ServiceAccount token and are
+// authorized with a SubjectAccessReview on the requested path, the way the
+// Kubernetes components protect their own metrics.
+package metricsauth
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// DefaultCacheTTL is how long the result of a review is reused
+const DefaultCacheTTL = time.Minute
+
+// Options configures how scrapers of the metrics endpoint authenticate
+type Options struct {
+	// ClientCAFile is the PEM bundle verifying client certificates. Client
+	// certificates are not requested if empty.
+	ClientCAFile string
+
+	// TokenReview authenticates bearer tokens with TokenReviews
+	TokenReview bool
+
+	// CacheTTL is how long the result of a review is reused, DefaultCacheTTL
+	// if zero
+	CacheTTL time.Duration
+}
+
+// Enabled reports whether any authentication method is configured
+func (o Options) Enabled() bool {
+	return o.ClientCAFile != "" || o.TokenReview
+}
+
+// TLSOpts returns the TLS options of the metrics server: TLS 1.2 or later
+// and, with a client CA, the verification of the client certificates. The
+// certificate is optional so that token authenticated scrapers are accepted.
+func (o Options) TLSOpts() ([]func(*tls.Config), error) {
+	tlsOpts := []func(*tls.Config){
+		func(c *tls.Config) {
+			c.MinVersion = tls.VersionTLS12
+		},
+	}
+	if o.ClientCAFile == "" {
+		return tlsOpts, nil
+	}
+
+	pem, err := os.ReadFile(o.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in client CA %s", o.ClientCAFile)
+	}
+	return append(tlsOpts, func(c *tls.Config) {
+		c.ClientCAs = pool
+		c.ClientAuth = tls.VerifyClientCertIfGiven
+	}), nil
+}
+
+// FilterProvider returns the metricsserver.FilterProvider authenticating and
+// authorizing the requests to the metrics endpoint
+func (o Options) FilterProvider() func(*rest.Config, *http.Client) (metricsserver.Filter, error) {
+	return func(config *rest.Config, httpClient *http.Client) (metricsserver.Filter, error) {
+		clientset, err := kubernetes.NewForConfigAndClient(config, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the review client: %w", err)
+		}
+		return NewFilter(clientset, o), nil
+	}
+}
+
+// NewFilter returns the filter authenticating and authorizing requests with
+// the reviews of the clientset
+func NewFilter(clientset kubernetes.Interface, o Options) metricsserver.Filter {
+	ttl := o.CacheTTL
+	if ttl == 0 {
+		ttl = DefaultCacheTTL
+	}
+	a := &authorizer{
+		clientset:   clientset,
+		options:     o,
+		tokens:      newCache[*identity](ttl),
+		permissions: newCache[bool](ttl),
+	}
+	return func(log logr.Logger, handler http.Handler) (http.Handler, error) {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, err := a.authenticate(r)
+			if err != nil {
+				log.Error(err, "Failed to authenticate metrics request")
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			if user == nil {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			allowed, err := a.authorize(r, user)
+			if err != nil {
+				log.Error(err, "Failed to authorize metrics request", "user", user.name)
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+			if !allowed {
+				log.V(1).Info("Denied metrics request", "user", user.name, "path", r.URL.Path)
+				http.Error(w, fmt.Sprintf("Forbidden: %s cannot %s %s", user.name, verb(r), r.URL.Path), http.StatusForbidden)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		}), nil
+	}
+}
+
+// identity is an authenticated scraper
+type identity struct {
+	name   string
+	uid    string
+	groups []string
+	extra  map[string]authorizationv1.ExtraValue
+}
+
+type authorizer struct {
+	clientset   kubernetes.Interface
+	options     Options
+	tokens      *cache[*identity]
+	permissions *cache[bool]
+}
+
+// authenticate returns the identity of the verified client certificate or
+// the bearer token of the request, nil if the request has neither
+func (a *authorizer) authenticate(r *http.Request) (*identity, error) {
+	if a.options.ClientCAFile != "" && r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+		cert := r.TLS.VerifiedChains[0][0]
+		return &identity{name: cert.Subject.CommonName, groups: cert.Subject.Organization}, nil
+	}
+	if !a.options.TokenReview {
+		return nil, nil
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return nil, nil
+	}
+	key := hash(token)
+	if user, ok := a.tokens.get(key); ok {
+		return user, nil
+	}
+
+	review, err := a.clientset.AuthenticationV1().TokenReviews().Create(r.Context(), &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("token review failed: %w", err)
+	}
+	var user *identity
+	if review.Status.Authenticated {
+		user = &identity{
+			name:   review.Status.User.Username,
+			uid:    review.Status.User.UID,
+			groups: review.Status.User.Groups,
+			extra:  map[string]authorizationv1.ExtraValue{},
+		}
+		for k, v := range review.Status.User.Extra {
+			user.extra[k] = authorizationv1.ExtraValue(v)
+		}
+	}
+	a.tokens.set(key, user)
+	return user, nil
+}
+
+// authorize checks that the identity may access the path of the request
+func (a *authorizer) authorize(r *http.Request, user *identity) (bool, error) {
+	groups := append([]string(nil), user.groups...)
+	sort.Strings(groups)
+	key := strings.Join([]string{user.name, strings.Join(groups, ","), verb(r), r.URL.Path}, "\x00")
+	if allowed, ok := a.permissions.get(key); ok {
+		return allowed, nil
+	}
+
+	review, err := a.clientset.AuthorizationV1().SubjectAccessReviews().Create(r.Context(), &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.name,
+			UID:    user.uid,
+			Groups: user.groups,
+			Extra:  user.extra,
+			NonResourceAttributes: &authorizationv1.NonResourceAttributes{
+				Path: r.URL.Path,
+				Verb: verb(r),
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("subject access review failed: %w", err)
+	}
+	a.permissions.set(key, review.Status.Allowed)
+	return review.Status.Allowed, nil
+}
+
+// verb returns the RBAC verb of the request method
+func verb(r *http.Request) string {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		return "get"
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut:
+		return "update"
+	default:
+		return strings.ToLower(r.Method)
+	}
+}
+
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// cache keeps review results for a fixed time
+type cache[T any] struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]cacheEntry[T]
+}
+
+type cacheEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+func newCache[T any](ttl time.Duration) *cache[T] {
+	return &cache[T]{ttl: ttl, now: time.Now, entries: map[string]cacheEntry[T]{}}
+}
+
+func (c *cache[T]) get(key string) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || c.now().After(entry.expires) {
+		var zero T
+		return zero, false
+	}
+	return entry.value, true
+}
+
+func (c *cache[T]) set(key string, value T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	// Drop the expired entries so rotated tokens don't accumulate
+	for k, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cacheEntry[T]{value: value, expires: now.Add(c.ttl)}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package metricsauth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newClientset returns a clientset authenticating the token "prometheus"
+// and allowing system:serviceaccount:monitoring:prometheus and the
+// "scrapers" group to get /metrics
+func newClientset(reviews *int) *fake.Clientset {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		*reviews++
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == "prometheus" {
+			review.Status.Authenticated = true
+			review.Status.User = authenticationv1.UserInfo{Username: "system:serviceaccount:monitoring:prometheus"}
+		}
+		return true, review, nil
+	})
+	clientset.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		*reviews++
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attributes := review.Spec.NonResourceAttributes
+		allowedSubject := review.Spec.User == "system:serviceaccount:monitoring:prometheus"
+		for _, group := range review.Spec.Groups {
+			allowedSubject = allowedSubject || group == "scrapers"
+		}
+		review.Status.Allowed = allowedSubject && attributes.Path == "/metrics" && attributes.Verb == "get"
+		return true, review, nil
+	})
+	return clientset
+}
+
+func serve(t *testing.T, options Options, clientset *fake.Clientset, r *http.Request) int {
+	filter := NewFilter(clientset, options)
+	handler, err := filter(logr.Discard(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, r)
+	return recorder.Code
+}
+
+func TestTokenReview(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		want   int
+	}{
+		{name: "allowed", path: "/metrics", token: "prometheus", want: http.StatusOK},
+		{name: "no token", path: "/metrics", want: http.StatusUnauthorized},
+		{name: "invalid token", path: "/metrics", token: "invalid", want: http.StatusUnauthorized},
+		{name: "other path", path: "/debug/pprof", token: "prometheus", want: http.StatusForbidden},
+		{name: "other verb", method: http.MethodPost, path: "/metrics", token: "prometheus", want: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviews := 0
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			r := httptest.NewRequest(method, tt.path, nil)
+			if tt.token != "" {
+				r.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			assert.Equal(t, tt.want, serve(t, Options{TokenReview: true}, newClientset(&reviews), r))
+		})
+	}
+}
+
+func TestReviewsAreCached(t *testing.T) {
+	reviews := 0
+	clientset := newClientset(&reviews)
+	filter := NewFilter(clientset, Options{TokenReview: true})
+	handler, err := filter(logr.Discard(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		r.Header.Set("Authorization", "Bearer prometheus")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, r)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	}
+	assert.Equal(t, 2, reviews)
+}
+
+func TestClientCertificate(t *testing.T) {
+	certificate := func(cn string, groups ...string) *tls.ConnectionState {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn, Organization: groups}}
+		return &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	}
+	tests := []struct {
+		name    string
+		options Options
+		tls     *tls.ConnectionState
+		want    int
+	}{
+		{name: "allowed group", options: Options{ClientCAFile: "ca.crt"}, tls: certificate("vmagent", "scrapers"), want: http.StatusOK},
+		{name: "denied", options: Options{ClientCAFile: "ca.crt"}, tls: certificate("vmagent"), want: http.StatusForbidden},
+		{name: "no certificate", options: Options{ClientCAFile: "ca.crt"}, tls: &tls.ConnectionState{}, want: http.StatusUnauthorized},
+		{name: "certificates disabled", options: Options{TokenReview: true}, tls: certificate("vmagent", "scrapers"), want: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviews := 0
+			r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			r.TLS = tt.tls
+			assert.Equal(t, tt.want, serve(t, tt.options, newClientset(&reviews), r))
+		})
+	}
+}
+
+func TestTLSOpts(t *testing.T) {
+	tlsOpts, err := Options{}.TLSOpts()
+	require.NoError(t, err)
+	config := &tls.Config{}
+	for _, opt := range tlsOpts {
+		opt(config)
+	}
+	assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+	assert.Equal(t, tls.NoClientCert, config.ClientAuth)
+
+	_, err = Options{ClientCAFile: "testdata/missing.crt"}.TLSOpts()
+	assert.ErrorContains(t, err, "failed to read client CA")
+}
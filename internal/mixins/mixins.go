@@ -0,0 +1,251 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package mixins installs community monitoring mixins: Jsonnet bundles of
+// Grafana dashboards, recording rules and alerts. Mixins are rendered from
+// their upstream repositories at the version matching the deployed component,
+// so dashboards and alerts use the metrics that version exposes. Their
+// jsonnet-bundler dependencies are resolved like `jb install` would.
+package mixins
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gunjanjp/gunj-operator/internal/dashboardsync"
+	"github.com/gunjanjp/gunj-operator/internal/jsonnet"
+)
+
+const (
+	// entryFile is the conventional entry point of a mixin
+	entryFile = "mixin.libsonnet"
+	// configuredEntryFile applies the configuration overrides to the mixin
+	configuredEntryFile = "gunj-mixin.jsonnet"
+
+	// RulesFilePrefix starts the file names of mixin rule files, so
+	// Prometheus loads them with a single glob
+	RulesFilePrefix = "mixin-"
+)
+
+// Mixin is a mixin of the catalog
+type Mixin struct {
+	Name string
+	// Repo is the Git repository holding the mixin
+	Repo string
+	// Path is the directory of the mixin in the repository
+	Path string
+	// Component is the component whose version the mixin follows; mixins of
+	// components the operator does not deploy are pinned to Version instead
+	Component string
+	// Version is the ref of pinned mixins
+	Version string
+}
+
+// Catalog holds the curated mixins by name. The node and kubernetes mixins
+// cover the node-exporter and kube-state-metrics deployments of the cluster,
+// which the operator does not manage, so they are pinned.
+var Catalog = map[string]Mixin{
+	"node": {
+		Name:    "node",
+		Repo:    "https://github.com/prometheus/node_exporter.git",
+		Path:    "docs/node-mixin",
+		Version: "v1.7.0",
+	},
+	"kubernetes": {
+		Name:    "kubernetes",
+		Repo:    "https://github.com/kubernetes-monitoring/kubernetes-mixin.git",
+		Version: "release-0.13",
+	},
+	"loki": {
+		Name:      "loki",
+		Repo:      "https://github.com/grafana/loki.git",
+		Path:      "production/loki-mixin",
+		Component: "loki",
+	},
+	"tempo": {
+		Name:      "tempo",
+		Repo:      "https://github.com/grafana/tempo.git",
+		Path:      "operations/tempo-mixin",
+		Component: "tempo",
+	},
+}
+
+// Names returns the sorted names of the catalog's mixins
+func Names() []string {
+	names := make([]string, 0, len(Catalog))
+	for name := range Catalog {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Ref returns the Git ref a mixin is rendered from: the override if set, the
+// release tag of the component version for mixins following a component, or
+// the pinned version
+func Ref(mixin Mixin, componentVersion, override string) string {
+	switch {
+	case override != "":
+		return override
+	case mixin.Component != "" && componentVersion != "":
+		return "v" + strings.TrimPrefix(componentVersion, "v")
+	default:
+		return mixin.Version
+	}
+}
+
+// RulesConfigMapName returns the name of the ConfigMap holding the rule files
+// of a platform's mixins, which Prometheus adds to its configuration
+func RulesConfigMapName(platform string) string {
+	return fmt.Sprintf("prometheus-%s-mixin-rules", platform)
+}
+
+// RulesFile returns the file name of a mixin's rule file
+func RulesFile(name string) string {
+	return RulesFilePrefix + name + ".yml"
+}
+
+// Checksum identifies the settings a mixin is installed with, so changed
+// settings are installed on the next sync
+func Checksum(ref, config string, dashboards, rules bool) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%t\x00%t", ref, config, dashboards, rules)
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// FetchFunc returns the commit a source's ref resolves to and the Jsonnet and
+// JSON files of the repository, like dashboardsync.Fetch
+type FetchFunc func(ctx context.Context, source dashboardsync.Source) (string, map[string][]byte, error)
+
+// Bundle is a rendered mixin
+type Bundle struct {
+	// Revision is the commit the mixin was rendered from
+	Revision string
+	// Dashboards are the mixin's Grafana dashboards
+	Dashboards []dashboardsync.Dashboard
+	// Rules is the Prometheus rule file holding the mixin's recording rules
+	// and alerts, empty if it has none
+	Rules string
+	// RuleGroups is the number of rule groups in Rules
+	RuleGroups int
+}
+
+// Build renders a mixin at ref. config is a Jsonnet object merged into the
+// mixin's _config, where mixins keep their selectors and thresholds.
+func Build(ctx context.Context, fetch FetchFunc, mixin Mixin, ref, config string) (*Bundle, error) {
+	fetcher := &cachingFetcher{fetch: fetch, repos: map[string]fetched{}}
+	revision, repoFiles, err := fetcher.get(ctx, mixin.Repo, ref)
+	if err != nil {
+		return nil, err
+	}
+	files := subtree(repoFiles, mixin.Path)
+	if _, ok := files[entryFile]; !ok {
+		return nil, fmt.Errorf("%s has no %s at %s", mixin.Repo, path.Join(mixin.Path, entryFile), ref)
+	}
+	if err := vendor(ctx, fetcher, files); err != nil {
+		return nil, fmt.Errorf("failed to install the dependencies of the %s mixin: %w", mixin.Name, err)
+	}
+
+	entry := entryFile
+	if strings.TrimSpace(config) != "" {
+		files[configuredEntryFile] = []byte(fmt.Sprintf("(import %q) + { _config+:: %s }", entryFile, config))
+		entry = configuredEntryFile
+	}
+	renderer := &jsonnet.Renderer{Files: files, JPath: []string{vendorDir}}
+
+	bundle := &Bundle{Revision: revision}
+	if bundle.Dashboards, err = dashboards(renderer, entry, mixin.Name); err != nil {
+		return nil, err
+	}
+	if bundle.Rules, bundle.RuleGroups, err = rules(renderer, entry); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// dashboards renders the grafanaDashboards of a mixin. Dashboards are keyed
+// by file name; they are recorded under the mixin's name, so uids derived
+// from file names differ across mixins.
+func dashboards(renderer *jsonnet.Renderer, entry, mixin string) ([]dashboardsync.Dashboard, error) {
+	output, err := renderer.Field(entry, "grafanaDashboards")
+	if err != nil || output == nil {
+		return nil, err
+	}
+	rendered := map[string]json.RawMessage{}
+	if err := json.Unmarshal(output, &rendered); err != nil {
+		return nil, fmt.Errorf("grafanaDashboards of the %s mixin is not an object of dashboards: %w", mixin, err)
+	}
+
+	files := make(map[string][]byte, len(rendered))
+	for name, dashboard := range rendered {
+		if !strings.HasSuffix(name, ".json") {
+			name += ".json"
+		}
+		files[mixin+"/"+name] = dashboard
+	}
+	return dashboardsync.Parse(files)
+}
+
+// ruleGroups is the content of a Prometheus rule file
+type ruleGroups struct {
+	Groups []interface{} `json:"groups" yaml:"groups"`
+}
+
+// rules renders the prometheusRules and prometheusAlerts of a mixin into a
+// single rule file
+func rules(renderer *jsonnet.Renderer, entry string) (string, int, error) {
+	merged := ruleGroups{}
+	for _, field := range []string{"prometheusRules", "prometheusAlerts"} {
+		output, err := renderer.Field(entry, field)
+		if err != nil {
+			return "", 0, err
+		}
+		if output == nil {
+			continue
+		}
+		groups := ruleGroups{}
+		if err := json.Unmarshal(output, &groups); err != nil {
+			return "", 0, fmt.Errorf("%s is not a rule file: %w", field, err)
+		}
+		merged.Groups = append(merged.Groups, groups.Groups...)
+	}
+	if len(merged.Groups) == 0 {
+		return "", 0, nil
+	}
+
+	data, err := yaml.Marshal(merged)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to marshal rules: %w", err)
+	}
+	return string(data), len(merged.Groups), nil
+}
+
+// subtree returns the files below dir, keyed by their path relative to it
+func subtree(files map[string][]byte, dir string) map[string][]byte {
+	dir = strings.Trim(dir, "/")
+	if dir == "" {
+		result := make(map[string][]byte, len(files))
+		for name, data := range files {
+			result[name] = data
+		}
+		return result
+	}
+	result := make(map[string][]byte)
+	for name, data := range files {
+		if rel := strings.TrimPrefix(name, dir+"/"); rel != name {
+			result[rel] = data
+		}
+	}
+	return result
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package mixins
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gunjanjp/gunj-operator/internal/dashboardsync"
+)
+
+// fakeFetch serves repositories keyed by URL@ref
+type fakeFetch map[string]map[string][]byte
+
+func (f fakeFetch) fetch(calls *int) FetchFunc {
+	return func(_ context.Context, source dashboardsync.Source) (string, map[string][]byte, error) {
+		*calls++
+		files, ok := f[source.URL+"@"+source.Ref]
+		if !ok {
+			return "", nil, fmt.Errorf("ref %s not found in %s", source.Ref, source.URL)
+		}
+		return "rev-" + source.Ref, files, nil
+	}
+}
+
+const (
+	mixinRepo = "https://github.com/grafana/loki.git"
+	libsRepo  = "https://github.com/grafana/jsonnet-libs.git"
+)
+
+func testRepos() fakeFetch {
+	return fakeFetch{
+		mixinRepo + "@v2.9.4": {
+			"production/loki-mixin/mixin.libsonnet": []byte(`
+local utils = import 'mixin-utils/utils.libsonnet';
+{
+  _config+:: { selector: 'job="loki"' },
+  grafanaDashboards+:: {
+    'loki-reads.json': { uid: 'loki-reads', title: 'Loki / Reads', panels: [] },
+  },
+  prometheusRules+:: { groups: [{ name: 'loki_rules', rules: [utils.record('job:loki_request:rate5m', $._config.selector)] }] },
+  prometheusAlerts+:: { groups: [{ name: 'loki_alerts', rules: [{ alert: 'LokiRequestErrors', expr: 'up{%s} == 0' % $._config.selector }] }] },
+}`),
+			"production/loki-mixin/jsonnetfile.json": []byte(`{"version": 1, "dependencies": [
+  {"source": {"git": {"remote": "` + libsRepo + `", "subdir": "mixin-utils"}}, "version": "master"}
+]}`),
+			"production/loki-mixin/jsonnetfile.lock.json": []byte(`{"version": 1, "dependencies": [
+  {"source": {"git": {"remote": "` + libsRepo + `", "subdir": "mixin-utils"}}, "version": "abc123"}
+]}`),
+			"pkg/unrelated.json": []byte(`{}`),
+		},
+		libsRepo + "@abc123": {
+			"mixin-utils/utils.libsonnet":       []byte(`{ record(name, expr):: { record: name, expr: expr } }`),
+			"grafana-builder/grafana.libsonnet": []byte(`{}`),
+		},
+	}
+}
+
+func TestRef(t *testing.T) {
+	assert.Equal(t, "v2.9.4", Ref(Catalog["loki"], "2.9.4", ""))
+	assert.Equal(t, "v2.3.1", Ref(Catalog["tempo"], "v2.3.1", ""))
+	assert.Equal(t, "main", Ref(Catalog["loki"], "2.9.4", "main"))
+	assert.Equal(t, "v1.7.0", Ref(Catalog["node"], "", ""))
+	assert.Equal(t, []string{"kubernetes", "loki", "node", "tempo"}, Names())
+}
+
+func TestChecksum(t *testing.T) {
+	checksum := Checksum("v2.9.4", "", true, true)
+	assert.Equal(t, checksum, Checksum("v2.9.4", "", true, true))
+	assert.NotEqual(t, checksum, Checksum("v2.9.5", "", true, true))
+	assert.NotEqual(t, checksum, Checksum("v2.9.4", "{}", true, true))
+	assert.NotEqual(t, checksum, Checksum("v2.9.4", "", true, false))
+}
+
+func TestVendor(t *testing.T) {
+	calls := 0
+	fetcher := &cachingFetcher{fetch: testRepos().fetch(&calls), repos: map[string]fetched{}}
+	_, repoFiles, err := fetcher.get(context.Background(), mixinRepo, "v2.9.4")
+	require.NoError(t, err)
+
+	files := subtree(repoFiles, "production/loki-mixin")
+	require.NoError(t, vendor(context.Background(), fetcher, files))
+
+	// Installed at the locked version, under the remote path and legacy name
+	assert.Contains(t, files, "vendor/github.com/grafana/jsonnet-libs/mixin-utils/utils.libsonnet")
+	assert.Contains(t, files, "vendor/mixin-utils/utils.libsonnet")
+	assert.NotContains(t, files, "vendor/mixin-utils/grafana-builder/grafana.libsonnet")
+	assert.NotContains(t, files, "pkg/unrelated.json")
+	assert.Equal(t, 2, calls)
+
+	// Each repository version is fetched once
+	_, _, err = fetcher.get(context.Background(), libsRepo, "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestRemotePathAndLegacyName(t *testing.T) {
+	dep := dependency{}
+	dep.Source.Git = &struct {
+		Remote string `json:"remote"`
+		Subdir string `json:"subdir"`
+	}{Remote: "git@github.com:grafana/grafonnet-lib.git", Subdir: "grafonnet"}
+	assert.Equal(t, "github.com/grafana/grafonnet-lib/grafonnet", remotePath(dep))
+	assert.Equal(t, "grafonnet", legacyName(dep))
+
+	dep.Source.Git.Remote, dep.Source.Git.Subdir = "https://github.com/jsonnet-libs/docsonnet.git", ""
+	assert.Equal(t, "github.com/jsonnet-libs/docsonnet", remotePath(dep))
+	assert.Equal(t, "docsonnet", legacyName(dep))
+
+	dep.Name = "doc-util"
+	assert.Equal(t, "doc-util", legacyName(dep))
+}
+
+func TestBuild(t *testing.T) {
+	calls := 0
+	bundle, err := Build(context.Background(), testRepos().fetch(&calls), Catalog["loki"], "v2.9.4", `{ selector: 'job="monitoring/loki"' }`)
+	require.NoError(t, err)
+
+	assert.Equal(t, "rev-v2.9.4", bundle.Revision)
+	require.Len(t, bundle.Dashboards, 1)
+	assert.Equal(t, "loki-reads", bundle.Dashboards[0].UID)
+	assert.Equal(t, "loki/loki-reads.json", bundle.Dashboards[0].File)
+
+	assert.Equal(t, 2, bundle.RuleGroups)
+	assert.Contains(t, bundle.Rules, "name: loki_rules")
+	assert.Contains(t, bundle.Rules, "record: job:loki_request:rate5m")
+	assert.Contains(t, bundle.Rules, "alert: LokiRequestErrors")
+	// The configuration overrides reach the rules
+	assert.Contains(t, bundle.Rules, `up{job="monitoring/loki"} == 0`)
+}
+
+func TestBuildErrors(t *testing.T) {
+	calls := 0
+	_, err := Build(context.Background(), testRepos().fetch(&calls), Catalog["loki"], "v9.9.9", "")
+	assert.ErrorContains(t, err, "ref v9.9.9 not found")
+
+	_, err = Build(context.Background(), testRepos().fetch(&calls), Catalog["tempo"], "v2.9.4", "")
+	assert.Error(t, err)
+
+	repos := testRepos()
+	repos[mixinRepo+"@v2.9.4"]["production/loki-mixin/jsonnetfile.json"] = []byte(`{"dependencies": [{"source": {"local": {"directory": "../lib"}}}]}`)
+	_, err = Build(context.Background(), repos.fetch(&calls), Catalog["loki"], "v2.9.4", "")
+	assert.ErrorContains(t, err, "only git dependencies are supported")
+}
+
+func TestRulesFile(t *testing.T) {
+	assert.Equal(t, "mixin-loki.yml", RulesFile("loki"))
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package mixins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/gunjanjp/gunj-operator/internal/dashboardsync"
+)
+
+const (
+	// vendorDir is where jsonnet-bundler installs dependencies
+	vendorDir = "vendor"
+
+	jsonnetfile     = "jsonnetfile.json"
+	jsonnetfileLock = "jsonnetfile.lock.json"
+
+	// maxDependencies bounds the dependencies installed for a mixin
+	maxDependencies = 50
+)
+
+// jsonnetFile is a jsonnetfile.json or jsonnetfile.lock.json
+type jsonnetFile struct {
+	Dependencies []dependency `json:"dependencies"`
+}
+
+// dependency is a jsonnet-bundler dependency
+type dependency struct {
+	Source struct {
+		Git *struct {
+			Remote string `json:"remote"`
+			Subdir string `json:"subdir"`
+		} `json:"git,omitempty"`
+		Local *struct {
+			Directory string `json:"directory"`
+		} `json:"local,omitempty"`
+	} `json:"source"`
+	// Version is a branch, tag or commit; lock files hold commits
+	Version string `json:"version"`
+	// Name overrides the legacy import name
+	Name string `json:"name,omitempty"`
+}
+
+// key identifies a dependency regardless of its version
+func (d dependency) key() string {
+	return d.Source.Git.Remote + "//" + strings.Trim(d.Source.Git.Subdir, "/")
+}
+
+// parseJsonnetFile reads the dependencies of a jsonnetfile, nil if files
+// has no such file
+func parseJsonnetFile(files map[string][]byte, name string) ([]dependency, error) {
+	data, ok := files[name]
+	if !ok {
+		return nil, nil
+	}
+	file := jsonnetFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	for _, dep := range file.Dependencies {
+		if dep.Source.Git == nil {
+			return nil, fmt.Errorf("%s: only git dependencies are supported", name)
+		}
+	}
+	return file.Dependencies, nil
+}
+
+// vendor installs the dependencies of the jsonnetfile.json in files, and
+// theirs, below vendor/ like `jb install`: at their remote path, and at their
+// legacy name for imports predating remote paths. Versions are taken from
+// jsonnetfile.lock.json where it pins them; the first version of a dependency
+// wins. Vendored files already present are kept.
+func vendor(ctx context.Context, fetcher *cachingFetcher, files map[string][]byte) error {
+	pending, err := parseJsonnetFile(files, jsonnetfile)
+	if err != nil {
+		return err
+	}
+	locked, err := parseJsonnetFile(files, jsonnetfileLock)
+	if err != nil {
+		return err
+	}
+	pinned := make(map[string]string, len(locked))
+	for _, dep := range locked {
+		pinned[dep.key()] = dep.Version
+	}
+
+	installed := make(map[string]bool)
+	for len(pending) > 0 {
+		dep := pending[0]
+		pending = pending[1:]
+		if installed[dep.key()] {
+			continue
+		}
+		if len(installed) == maxDependencies {
+			return fmt.Errorf("more than %d dependencies", maxDependencies)
+		}
+		installed[dep.key()] = true
+
+		version := dep.Version
+		if v, ok := pinned[dep.key()]; ok {
+			version = v
+		}
+		if version == "" {
+			version = "master"
+		}
+		_, repoFiles, err := fetcher.get(ctx, dep.Source.Git.Remote, version)
+		if err != nil {
+			return err
+		}
+		depFiles := subtree(repoFiles, dep.Source.Git.Subdir)
+		for _, dir := range []string{remotePath(dep), legacyName(dep)} {
+			for name, data := range depFiles {
+				target := path.Join(vendorDir, dir, name)
+				if _, ok := files[target]; !ok {
+					files[target] = data
+				}
+			}
+		}
+
+		transitive, err := parseJsonnetFile(depFiles, jsonnetfile)
+		if err != nil {
+			return fmt.Errorf("%s: %w", dep.key(), err)
+		}
+		pending = append(pending, transitive...)
+	}
+	return nil
+}
+
+// remotePath returns the vendor directory of a dependency, such as
+// github.com/grafana/jsonnet-libs/mixin-utils
+func remotePath(dep dependency) string {
+	remote := strings.TrimSuffix(dep.Source.Git.Remote, ".git")
+	if u, err := url.Parse(remote); err == nil && u.Host != "" {
+		remote = u.Host + u.Path
+	} else if at := strings.Index(remote, "@"); at >= 0 {
+		// scp-like syntax: git@github.com:org/repo
+		remote = strings.Replace(remote[at+1:], ":", "/", 1)
+	}
+	return path.Join(remote, dep.Source.Git.Subdir)
+}
+
+// legacyName returns the name a dependency was imported by before remote
+// paths: its configured name, or the last element of its subdir or remote
+func legacyName(dep dependency) string {
+	if dep.Name != "" {
+		return dep.Name
+	}
+	if subdir := strings.Trim(dep.Source.Git.Subdir, "/"); subdir != "" {
+		return path.Base(subdir)
+	}
+	return path.Base(strings.TrimSuffix(dep.Source.Git.Remote, ".git"))
+}
+
+// fetched is a fetched repository
+type fetched struct {
+	revision string
+	files    map[string][]byte
+}
+
+// cachingFetcher fetches each repository once per version, as mixins often
+// depend on several directories of one repository
+type cachingFetcher struct {
+	fetch FetchFunc
+	repos map[string]fetched
+}
+
+// get returns the revision and files of a repository at ref
+func (f *cachingFetcher) get(ctx context.Context, repo, ref string) (string, map[string][]byte, error) {
+	key := repo + "@" + ref
+	if cached, ok := f.repos[key]; ok {
+		return cached.revision, cached.files, nil
+	}
+	revision, files, err := f.fetch(ctx, dashboardsync.Source{URL: repo, Ref: ref})
+	if err != nil {
+		return "", nil, err
+	}
+	f.repos[key] = fetched{revision: revision, files: files}
+	return revision, files, nil
+}
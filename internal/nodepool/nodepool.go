@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package nodepool implements the dedicated observability node pool: nodes
+// are labeled and tainted to form the pool, and pod specs are given the
+// matching node selector and toleration.
+package nodepool
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ManagedByAnnotation marks nodes bootstrapped by a platform, so only those
+	// nodes are released when the platform no longer declares them
+	ManagedByAnnotation = "observability.io/node-pool-managed-by"
+	// PoolAnnotation records the pool a node was bootstrapped into, as
+	// <label key>=<name>, so the node is released after the pool is renamed
+	// or removed from the platform
+	PoolAnnotation = "observability.io/node-pool-bootstrapped"
+	// LabeledAnnotation marks nodes whose pool label was added on bootstrap.
+	// Labels set by someone else, e.g. the cloud provider, are kept on release.
+	LabeledAnnotation = "observability.io/node-pool-labeled"
+)
+
+// Pool is a dedicated node pool
+type Pool struct {
+	// LabelKey is the key of the node label and taint
+	LabelKey string
+	// Name is the value of the node label and taint
+	Name string
+	// TaintEffect is the effect of the taint
+	TaintEffect corev1.TaintEffect
+}
+
+// Taint returns the taint keeping other workloads off the pool
+func (p Pool) Taint() corev1.Taint {
+	return corev1.Taint{Key: p.LabelKey, Value: p.Name, Effect: p.TaintEffect}
+}
+
+// Toleration returns the toleration for the pool taint
+func (p Pool) Toleration() corev1.Toleration {
+	return corev1.Toleration{
+		Key:      p.LabelKey,
+		Operator: corev1.TolerationOpEqual,
+		Value:    p.Name,
+		Effect:   p.TaintEffect,
+	}
+}
+
+// Apply adds the pool's node selector and toleration to a pod spec, keeping
+// the selectors and tolerations already set
+func Apply(podSpec *corev1.PodSpec, pool Pool) {
+	nodeSelector := make(map[string]string, len(podSpec.NodeSelector)+1)
+	for k, v := range podSpec.NodeSelector {
+		nodeSelector[k] = v
+	}
+	nodeSelector[pool.LabelKey] = pool.Name
+	podSpec.NodeSelector = nodeSelector
+
+	toleration := pool.Toleration()
+	for _, t := range podSpec.Tolerations {
+		if t.MatchToleration(&toleration) {
+			return
+		}
+	}
+	podSpec.Tolerations = append(podSpec.Tolerations, toleration)
+}
+
+// Bootstrap labels and taints a node to join the pool. It returns false if the
+// node was already part of the pool. A node it changes is marked as managed by
+// owner together with the pool, so the taint and the label it added are
+// removed on release.
+func Bootstrap(node *corev1.Node, pool Pool, owner string) bool {
+	changed := false
+	labeled := false
+
+	if node.Labels[pool.LabelKey] != pool.Name {
+		if node.Labels == nil {
+			node.Labels = map[string]string{}
+		}
+		node.Labels[pool.LabelKey] = pool.Name
+		labeled = true
+		changed = true
+	}
+
+	taint := pool.Taint()
+	found := false
+	for i, t := range node.Spec.Taints {
+		if t.Key != taint.Key {
+			continue
+		}
+		found = true
+		if t.Value != taint.Value || t.Effect != taint.Effect {
+			node.Spec.Taints[i] = taint
+			changed = true
+		}
+	}
+	if !found {
+		node.Spec.Taints = append(node.Spec.Taints, taint)
+		changed = true
+	}
+
+	if changed {
+		if node.Annotations == nil {
+			node.Annotations = map[string]string{}
+		}
+		node.Annotations[ManagedByAnnotation] = owner
+		node.Annotations[PoolAnnotation] = pool.LabelKey + "=" + pool.Name
+		if labeled {
+			node.Annotations[LabeledAnnotation] = "true"
+		}
+	}
+
+	return changed
+}
+
+// RecordedPool returns the pool a node was bootstrapped into
+func RecordedPool(node *corev1.Node) (Pool, bool) {
+	key, name, ok := strings.Cut(node.Annotations[PoolAnnotation], "=")
+	if !ok || key == "" {
+		return Pool{}, false
+	}
+	return Pool{LabelKey: key, Name: name}, true
+}
+
+// Release removes the taint of the pool recorded on a node bootstrapped by
+// owner, and its label if it was added on bootstrap. It returns false if the
+// node is not managed by owner.
+func Release(node *corev1.Node, owner string) bool {
+	if node.Annotations[ManagedByAnnotation] != owner {
+		return false
+	}
+
+	if pool, ok := RecordedPool(node); ok {
+		if node.Annotations[LabeledAnnotation] == "true" {
+			delete(node.Labels, pool.LabelKey)
+		}
+
+		taints := node.Spec.Taints[:0]
+		for _, t := range node.Spec.Taints {
+			if t.Key != pool.LabelKey {
+				taints = append(taints, t)
+			}
+		}
+		node.Spec.Taints = taints
+	}
+
+	delete(node.Annotations, ManagedByAnnotation)
+	delete(node.Annotations, PoolAnnotation)
+	delete(node.Annotations, LabeledAnnotation)
+
+	return true
+}
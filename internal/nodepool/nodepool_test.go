@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package nodepool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var pool = Pool{LabelKey: "observability.io/node-pool", Name: "observability", TaintEffect: corev1.TaintEffectNoSchedule}
+
+func TestApply(t *testing.T) {
+	podSpec := &corev1.PodSpec{
+		NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+		Tolerations:  []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+	}
+
+	Apply(podSpec, pool)
+	Apply(podSpec, pool)
+
+	assert.Equal(t, map[string]string{
+		"kubernetes.io/os":           "linux",
+		"observability.io/node-pool": "observability",
+	}, podSpec.NodeSelector)
+	assert.Len(t, podSpec.Tolerations, 2)
+	assert.Equal(t, pool.Toleration(), podSpec.Tolerations[1])
+}
+
+func TestBootstrapAndRelease(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+		Spec: corev1.NodeSpec{
+			Taints: []corev1.Taint{{Key: "other", Effect: corev1.TaintEffectPreferNoSchedule}},
+		},
+	}
+
+	assert.True(t, Bootstrap(node, pool, "monitoring/production"))
+	assert.False(t, Bootstrap(node, pool, "monitoring/production"))
+	assert.Equal(t, "observability", node.Labels[pool.LabelKey])
+	assert.Equal(t, "monitoring/production", node.Annotations[ManagedByAnnotation])
+	assert.Contains(t, node.Spec.Taints, pool.Taint())
+
+	recorded, ok := RecordedPool(node)
+	assert.True(t, ok)
+	assert.Equal(t, Pool{LabelKey: pool.LabelKey, Name: pool.Name}, recorded)
+
+	// Only the platform that bootstrapped the node releases it
+	assert.False(t, Release(node, "monitoring/staging"))
+	assert.True(t, Release(node, "monitoring/production"))
+	assert.NotContains(t, node.Labels, pool.LabelKey)
+	assert.Empty(t, node.Annotations)
+	assert.Equal(t, []corev1.Taint{{Key: "other", Effect: corev1.TaintEffectPreferNoSchedule}}, node.Spec.Taints)
+
+	// Nodes labeled by someone else keep the label, but not the taint
+	provided := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "node-b",
+		Labels: map[string]string{pool.LabelKey: pool.Name},
+	}}
+	assert.True(t, Bootstrap(provided, pool, "monitoring/production"))
+	assert.Equal(t, "monitoring/production", provided.Annotations[ManagedByAnnotation])
+	assert.True(t, Release(provided, "monitoring/production"))
+	assert.Equal(t, pool.Name, provided.Labels[pool.LabelKey])
+	assert.Empty(t, provided.Spec.Taints)
+
+	// Nodes never bootstrapped are never released
+	assert.False(t, Release(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-c"}}, "monitoring/production"))
+}
+
+func TestReleaseRenamedPool(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}}
+	assert.True(t, Bootstrap(node, pool, "monitoring/production"))
+
+	// The recorded pool is released, whatever the pool is called now
+	assert.True(t, Release(node, "monitoring/production"))
+	assert.NotContains(t, node.Labels, pool.LabelKey)
+	assert.Empty(t, node.Spec.Taints)
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package noisyalerts detects alerts that fire and resolve frequently. It
+// reads the ALERTS series of Prometheus over a window, splits each series
+// into firings, and reports the alerts firing most often together with a
+// suggested adjustment: alerts which mostly resolve on their own within a
+// short time should wait longer before firing, the others should fire on a
+// higher threshold or stop paging.
+package noisyalerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// Query selects the firing alerts
+	Query = `ALERTS{alertstate="firing"}`
+
+	// MinStep is the smallest resolution of the analysis
+	MinStep = time.Minute
+
+	// maxPoints keeps a range query below the 11,000 points Prometheus
+	// returns per series
+	maxPoints = 10000
+)
+
+// Options tune the analysis
+type Options struct {
+	// MinFirings is how many firings make an alert reported
+	MinFirings int
+	// ShortFiring is the duration below which a firing resolved on its own
+	ShortFiring time.Duration
+	// TopN is the number of alerts reported
+	TopN int
+}
+
+// Series is an ALERTS series with the times it was sampled firing
+type Series struct {
+	Labels  map[string]string
+	Samples []time.Time
+}
+
+// Alert summarizes the firings of an alert
+type Alert struct {
+	Name     string `json:"alertname"`
+	Severity string `json:"severity,omitempty"`
+	// Series is the number of label sets the alert fired for
+	Series  int     `json:"series"`
+	Firings int     `json:"firings"`
+	PerDay  float64 `json:"firingsPerDay"`
+	// ShortFirings resolved within the short firing duration
+	ShortFirings         int     `json:"shortFirings"`
+	MedianSeconds        float64 `json:"medianFiringSeconds"`
+	P90Seconds           float64 `json:"p90FiringSeconds"`
+	FiringSeconds        float64 `json:"totalFiringSeconds"`
+	Suggestion           string  `json:"suggestion"`
+	SuggestedForIncrease string  `json:"suggestedForIncrease,omitempty"`
+}
+
+// Report lists the noisiest alerts of a window
+type Report struct {
+	WindowStart time.Time `json:"windowStart"`
+	WindowEnd   time.Time `json:"windowEnd"`
+	Step        string    `json:"step"`
+	// AlertsFiring is the number of alerts which fired in the window
+	AlertsFiring int `json:"alertsFiring"`
+	// Firings is the number of firings of all alerts
+	Firings int     `json:"firings"`
+	Noisy   []Alert `json:"noisyAlerts"`
+}
+
+// Step returns the resolution of a range query over window
+func Step(window time.Duration) time.Duration {
+	step := (window/maxPoints + time.Second - 1).Truncate(time.Second)
+	if step < MinStep {
+		return MinStep
+	}
+	return step
+}
+
+// Fetch reads the firing ALERTS series of the Prometheus at baseURL
+func Fetch(ctx context.Context, httpClient *http.Client, baseURL string, start, end time.Time, step time.Duration) ([]Series, error) {
+	params := url.Values{}
+	params.Set("query", Query)
+	params.Set("start", strconv.FormatInt(start.Unix(), 10))
+	params.Set("end", strconv.FormatInt(end.Unix(), 10))
+	params.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/api/v1/query_range?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Prometheus: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Prometheus response: %w", err)
+	}
+	return Parse(body)
+}
+
+// Parse parses the matrix returned by a range query
+func Parse(body []byte) ([]Series, error) {
+	var response struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Data   struct {
+			ResultType string `json:"resultType"`
+			Result     []struct {
+				Metric map[string]string `json:"metric"`
+				Values [][2]interface{}  `json:"values"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode Prometheus response: %w", err)
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed: %s", response.Error)
+	}
+	if response.Data.ResultType != "matrix" {
+		return nil, fmt.Errorf("unexpected result type %q", response.Data.ResultType)
+	}
+
+	series := make([]Series, 0, len(response.Data.Result))
+	for _, result := range response.Data.Result {
+		s := Series{Labels: result.Metric, Samples: make([]time.Time, 0, len(result.Values))}
+		for _, value := range result.Values {
+			ts, ok := value[0].(float64)
+			if !ok {
+				return nil, fmt.Errorf("invalid sample timestamp %v", value[0])
+			}
+			sec, frac := math.Modf(ts)
+			s.Samples = append(s.Samples, time.Unix(int64(sec), int64(frac*1e9)).UTC())
+		}
+		series = append(series, s)
+	}
+	return series, nil
+}
+
+// Firings splits the samples of a series into firings. A gap longer than two
+// steps ends a firing; a single missed sample does not. Each firing lasts
+// from its first sample to one step after its last.
+func Firings(samples []time.Time, step time.Duration) []time.Duration {
+	var firings []time.Duration
+	if len(samples) == 0 {
+		return firings
+	}
+	sorted := append([]time.Time(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	start := sorted[0]
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Sub(sorted[i-1]) > 2*step {
+			firings = append(firings, sorted[i-1].Sub(start)+step)
+			start = sorted[i]
+		}
+	}
+	return append(firings, sorted[len(sorted)-1].Sub(start)+step)
+}
+
+// Analyze reports the alerts with at least MinFirings firings between start
+// and end, noisiest first
+func Analyze(series []Series, step time.Duration, opts Options, start, end time.Time) *Report {
+	type key struct{ name, severity string }
+	durations := map[key][]time.Duration{}
+	seriesCount := map[key]int{}
+	for _, s := range series {
+		k := key{s.Labels["alertname"], s.Labels["severity"]}
+		durations[k] = append(durations[k], Firings(s.Samples, step)...)
+		seriesCount[k]++
+	}
+
+	report := &Report{
+		WindowStart:  start,
+		WindowEnd:    end,
+		Step:         step.String(),
+		AlertsFiring: len(durations),
+		Noisy:        []Alert{},
+	}
+	days := end.Sub(start).Hours() / 24
+	for k, firings := range durations {
+		report.Firings += len(firings)
+		if len(firings) < opts.MinFirings {
+			continue
+		}
+		sort.Slice(firings, func(i, j int) bool { return firings[i] < firings[j] })
+
+		alert := Alert{
+			Name:          k.name,
+			Severity:      k.severity,
+			Series:        seriesCount[k],
+			Firings:       len(firings),
+			MedianSeconds: percentile(firings, 0.5).Seconds(),
+			P90Seconds:    percentile(firings, 0.9).Seconds(),
+		}
+		if days > 0 {
+			alert.PerDay = math.Round(float64(len(firings))/days*10) / 10
+		}
+		var longestShort time.Duration
+		for _, d := range firings {
+			alert.FiringSeconds += d.Seconds()
+			if d < opts.ShortFiring {
+				alert.ShortFirings++
+				longestShort = d
+			}
+		}
+		suggest(&alert, longestShort)
+		report.Noisy = append(report.Noisy, alert)
+	}
+
+	sort.Slice(report.Noisy, func(i, j int) bool {
+		a, b := report.Noisy[i], report.Noisy[j]
+		if a.Firings != b.Firings {
+			return a.Firings > b.Firings
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Severity < b.Severity
+	})
+	if opts.TopN > 0 && len(report.Noisy) > opts.TopN {
+		report.Noisy = report.Noisy[:opts.TopN]
+	}
+	return report
+}
+
+// suggest sets the adjustment suggested for an alert. An alert mostly
+// resolving on its own should wait until its short firings are over before
+// firing; the longest of them, rounded up to a minute, is added to its for
+// duration.
+func suggest(alert *Alert, longestShort time.Duration) {
+	if alert.ShortFirings*2 >= alert.Firings {
+		increase := (longestShort + time.Minute - 1).Truncate(time.Minute)
+		if increase < time.Minute {
+			increase = time.Minute
+		}
+		alert.SuggestedForIncrease = formatDuration(increase)
+		alert.Suggestion = fmt.Sprintf("%d of %d firings resolved on their own; raise the alert's for duration by %s so only lasting problems fire",
+			alert.ShortFirings, alert.Firings, alert.SuggestedForIncrease)
+		return
+	}
+	alert.Suggestion = fmt.Sprintf("Fires %.1f times a day for a median of %s; raise its threshold, or route it to a non-paging receiver if nobody acts on it",
+		alert.PerDay, formatDuration(time.Duration(alert.MedianSeconds)*time.Second))
+}
+
+// percentile returns the p-th percentile of sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
+
+// formatDuration formats a duration in Prometheus style, e.g. 5m or 1h30m
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	var out string
+	if h := d / time.Hour; h > 0 {
+		out += fmt.Sprintf("%dh", h)
+		d -= h * time.Hour
+	}
+	if m := d / time.Minute; m > 0 {
+		out += fmt.Sprintf("%dm", m)
+		d -= m * time.Minute
+	}
+	if s := d / time.Second; s > 0 || out == "" {
+		out += fmt.Sprintf("%ds", s)
+	}
+	return out
+}
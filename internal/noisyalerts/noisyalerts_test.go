@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package noisyalerts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var t0 = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// samples returns the samples of firings starting at the given minutes and
+// lasting the given minutes, at a one minute step
+func samples(firings ...[2]int) []time.Time {
+	var out []time.Time
+	for _, f := range firings {
+		for m := 0; m < f[1]; m++ {
+			out = append(out, t0.Add(time.Duration(f[0]+m)*time.Minute))
+		}
+	}
+	return out
+}
+
+func TestStep(t *testing.T) {
+	assert.Equal(t, time.Minute, Step(time.Hour))
+	assert.Equal(t, 61*time.Second, Step(7*24*time.Hour))
+	assert.Equal(t, 260*time.Second, Step(30*24*time.Hour))
+}
+
+func TestFirings(t *testing.T) {
+	assert.Empty(t, Firings(nil, time.Minute))
+
+	// A single missed sample does not end a firing
+	s := samples([2]int{0, 3}, [2]int{4, 2}, [2]int{20, 1})
+	assert.Equal(t, []time.Duration{6 * time.Minute, time.Minute}, Firings(s, time.Minute))
+}
+
+func TestAnalyze(t *testing.T) {
+	var flapping [][2]int
+	for i := 0; i < 12; i++ {
+		flapping = append(flapping, [2]int{i * 60, 2})
+	}
+	flapping = append(flapping, [2]int{1000, 30})
+
+	var long [][2]int
+	for i := 0; i < 10; i++ {
+		long = append(long, [2]int{i * 120, 45})
+	}
+
+	series := []Series{
+		{Labels: map[string]string{"alertname": "HighLatency", "severity": "warning", "instance": "a"}, Samples: samples(flapping...)},
+		{Labels: map[string]string{"alertname": "DiskFull", "severity": "critical"}, Samples: samples(long...)},
+		{Labels: map[string]string{"alertname": "Rare"}, Samples: samples([2]int{0, 5})},
+	}
+
+	report := Analyze(series, time.Minute, Options{MinFirings: 10, ShortFiring: 5 * time.Minute, TopN: 10}, t0, t0.Add(48*time.Hour))
+
+	assert.Equal(t, 3, report.AlertsFiring)
+	assert.Equal(t, 24, report.Firings)
+	require.Len(t, report.Noisy, 2)
+
+	flappy := report.Noisy[0]
+	assert.Equal(t, "HighLatency", flappy.Name)
+	assert.Equal(t, "warning", flappy.Severity)
+	assert.Equal(t, 1, flappy.Series)
+	assert.Equal(t, 13, flappy.Firings)
+	assert.Equal(t, 6.5, flappy.PerDay)
+	assert.Equal(t, 12, flappy.ShortFirings)
+	assert.Equal(t, 120.0, flappy.MedianSeconds)
+	assert.Equal(t, "2m", flappy.SuggestedForIncrease)
+	assert.Contains(t, flappy.Suggestion, "12 of 13 firings resolved on their own")
+
+	noisy := report.Noisy[1]
+	assert.Equal(t, "DiskFull", noisy.Name)
+	assert.Equal(t, 0, noisy.ShortFirings)
+	assert.Empty(t, noisy.SuggestedForIncrease)
+	assert.Equal(t, "Fires 5.0 times a day for a median of 45m; raise its threshold, or route it to a non-paging receiver if nobody acts on it", noisy.Suggestion)
+
+	report = Analyze(series, time.Minute, Options{MinFirings: 10, ShortFiring: 5 * time.Minute, TopN: 1}, t0, t0.Add(48*time.Hour))
+	require.Len(t, report.Noisy, 1)
+	assert.Equal(t, "HighLatency", report.Noisy[0].Name)
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/query_range", r.URL.Path)
+		assert.Equal(t, Query, r.URL.Query().Get("query"))
+		assert.Equal(t, "60", r.URL.Query().Get("step"))
+		fmt.Fprint(w, `{"status":"success","data":{"resultType":"matrix","result":[
+			{"metric":{"alertname":"HighLatency","alertstate":"firing"},"values":[[1748736000,"1"],[1748736060.5,"1"]]}
+		]}}`)
+	}))
+	defer server.Close()
+
+	series, err := Fetch(context.Background(), server.Client(), server.URL, t0, t0.Add(time.Hour), time.Minute)
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+	assert.Equal(t, "HighLatency", series[0].Labels["alertname"])
+	assert.Equal(t, []time.Time{t0, t0.Add(60500 * time.Millisecond)}, series[0].Samples)
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse([]byte(`{"status":"error","error":"bad query"}`))
+	assert.EqualError(t, err, "prometheus query failed: bad query")
+
+	_, err = Parse([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+	assert.Error(t, err)
+
+	_, err = Parse([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "0s", formatDuration(0))
+	assert.Equal(t, "45s", formatDuration(45*time.Second))
+	assert.Equal(t, "1h30m", formatDuration(90*time.Minute))
+}
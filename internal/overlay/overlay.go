@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package overlay composes platform specs from a base and overlays. Specs are
+// merged as stored, before they are decoded into typed structs: a field the
+// overlay does not set is inherited, while a typed struct could not tell an
+// unset field from one set to its zero value.
+package overlay
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LocalFields are the spec fields which are never inherited: a base used
+// only as a template is paused without pausing its overlays
+var LocalFields = []string{"paused", "basePlatformRef"}
+
+// MaxDepth bounds the length of a chain of bases
+const MaxDepth = 5
+
+// Merge returns overlay merged onto base. Objects are merged key by key;
+// other values, lists included, are replaced by the overlay's. A null in the
+// overlay removes the base's value, as in a JSON merge patch. Neither
+// argument is modified.
+func Merge(base, overlay map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(overlay))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range overlay {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		baseObject, baseIsObject := merged[key].(map[string]interface{})
+		overlayObject, overlayIsObject := value.(map[string]interface{})
+		if baseIsObject && overlayIsObject {
+			merged[key] = Merge(baseObject, overlayObject)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// Compose merges a chain of specs, the platform's own spec first and its most
+// distant base last, into the effective spec. The local fields are taken from
+// the platform's own spec only.
+func Compose(chain []map[string]interface{}) map[string]interface{} {
+	if len(chain) == 0 {
+		return map[string]interface{}{}
+	}
+	effective := map[string]interface{}{}
+	for i := len(chain) - 1; i > 0; i-- {
+		effective = Merge(effective, withoutLocalFields(chain[i]))
+	}
+	return Merge(withoutLocalFields(effective), chain[0])
+}
+
+// Without returns a copy of spec without the fields at paths, one key per
+// path element. The objects along a path are copied; spec is not modified.
+// Paths that don't exist are ignored.
+func Without(spec map[string]interface{}, paths [][]string) map[string]interface{} {
+	result := spec
+	for _, path := range paths {
+		result = without(result, path)
+	}
+	return result
+}
+
+// without returns a copy of object without the field at path, or object
+// itself if the path doesn't exist
+func without(object map[string]interface{}, path []string) map[string]interface{} {
+	if len(path) == 0 {
+		return object
+	}
+	value, ok := object[path[0]]
+	if !ok {
+		return object
+	}
+	result := make(map[string]interface{}, len(object))
+	for key, v := range object {
+		result[key] = v
+	}
+	if len(path) == 1 {
+		delete(result, path[0])
+		return result
+	}
+	child, ok := value.(map[string]interface{})
+	if !ok {
+		return object
+	}
+	result[path[0]] = without(child, path[1:])
+	return result
+}
+
+// withoutLocalFields returns a copy of spec without the local fields
+func withoutLocalFields(spec map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(spec))
+	for key, value := range spec {
+		result[key] = value
+	}
+	for _, field := range LocalFields {
+		delete(result, field)
+	}
+	return result
+}
+
+// Ref identifies a platform
+type Ref struct {
+	Namespace string
+	Name      string
+}
+
+func (r Ref) String() string {
+	return r.Namespace + "/" + r.Name
+}
+
+// BaseRef reads the basePlatformRef of a spec, defaulting its namespace to
+// the platform's. It returns false if the spec has no base.
+func BaseRef(spec map[string]interface{}, namespace string) (Ref, bool) {
+	ref, ok := spec["basePlatformRef"].(map[string]interface{})
+	if !ok {
+		return Ref{}, false
+	}
+	name, _ := ref["name"].(string)
+	if name == "" {
+		return Ref{}, false
+	}
+	if ns, _ := ref["namespace"].(string); ns != "" {
+		namespace = ns
+	}
+	return Ref{Namespace: namespace, Name: name}, true
+}
+
+// CheckChain returns an error if following a base would create a cycle or
+// exceed MaxDepth. visited holds the platforms of the chain so far.
+func CheckChain(visited []Ref, next Ref) error {
+	for _, ref := range visited {
+		if ref == next {
+			names := make([]string, 0, len(visited)+1)
+			for _, v := range visited {
+				names = append(names, v.String())
+			}
+			return fmt.Errorf("base platforms form a cycle: %s -> %s", strings.Join(names, " -> "), next)
+		}
+	}
+	if len(visited) > MaxDepth {
+		return fmt.Errorf("more than %d base platforms", MaxDepth)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package overlay
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parse(t *testing.T, data string) map[string]interface{} {
+	t.Helper()
+	result := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(data), &result))
+	return result
+}
+
+func TestMerge(t *testing.T) {
+	base := parse(t, `{
+		"components": {"prometheus": {"enabled": true, "version": "v2.48.0", "retention": "30d", "externalLabels": {"org": "acme"}}},
+		"global": {"logLevel": "info"},
+		"nodeSelector": {"pool": "observability"}
+	}`)
+	overlay := parse(t, `{
+		"components": {"prometheus": {"retention": "7d", "externalLabels": {"team": "payments"}}, "loki": {"enabled": true}},
+		"nodeSelector": null
+	}`)
+
+	merged := Merge(base, overlay)
+	assert.Equal(t, parse(t, `{
+		"components": {
+			"prometheus": {"enabled": true, "version": "v2.48.0", "retention": "7d", "externalLabels": {"org": "acme", "team": "payments"}},
+			"loki": {"enabled": true}
+		},
+		"global": {"logLevel": "info"}
+	}`), merged)
+
+	// Inputs are left as they were
+	assert.Equal(t, "30d", base["components"].(map[string]interface{})["prometheus"].(map[string]interface{})["retention"])
+	assert.Contains(t, base, "nodeSelector")
+}
+
+func TestMergeReplacesLists(t *testing.T) {
+	merged := Merge(
+		parse(t, `{"remoteWrite": [{"url": "https://a"}, {"url": "https://b"}]}`),
+		parse(t, `{"remoteWrite": [{"url": "https://c"}]}`),
+	)
+	assert.Equal(t, parse(t, `{"remoteWrite": [{"url": "https://c"}]}`), merged)
+}
+
+func TestCompose(t *testing.T) {
+	golden := parse(t, `{"paused": true, "global": {"logLevel": "info", "externalLabels": {"org": "acme"}}}`)
+	region := parse(t, `{"basePlatformRef": {"name": "golden"}, "global": {"externalLabels": {"region": "eu"}}}`)
+	team := parse(t, `{"basePlatformRef": {"name": "region-eu"}, "global": {"logLevel": "debug"}}`)
+
+	effective := Compose([]map[string]interface{}{team, region, golden})
+	assert.Equal(t, parse(t, `{
+		"basePlatformRef": {"name": "region-eu"},
+		"global": {"logLevel": "debug", "externalLabels": {"org": "acme", "region": "eu"}}
+	}`), effective)
+
+	assert.Equal(t, map[string]interface{}{}, Compose(nil))
+}
+
+func TestWithout(t *testing.T) {
+	spec := parse(t, `{
+		"components": {"prometheus": {"enabled": true, "version": "v2.48.0", "replicas": 1, "externalLabels": {"cluster": "default"}}},
+		"global": {"logLevel": "info"}
+	}`)
+
+	stripped := Without(spec, [][]string{
+		{"components", "prometheus", "version"},
+		{"components", "prometheus", "externalLabels", "cluster"},
+		{"global"},
+		{"components", "loki", "version"},
+	})
+	assert.Equal(t, parse(t, `{
+		"components": {"prometheus": {"enabled": true, "replicas": 1, "externalLabels": {}}}
+	}`), stripped)
+
+	// The input is left as it was
+	assert.Equal(t, "v2.48.0", spec["components"].(map[string]interface{})["prometheus"].(map[string]interface{})["version"])
+	assert.Contains(t, spec, "global")
+}
+
+func TestBaseRef(t *testing.T) {
+	ref, ok := BaseRef(parse(t, `{"basePlatformRef": {"name": "golden"}}`), "team-a")
+	assert.True(t, ok)
+	assert.Equal(t, Ref{Namespace: "team-a", Name: "golden"}, ref)
+
+	ref, ok = BaseRef(parse(t, `{"basePlatformRef": {"name": "golden", "namespace": "platform"}}`), "team-a")
+	assert.True(t, ok)
+	assert.Equal(t, "platform/golden", ref.String())
+
+	_, ok = BaseRef(parse(t, `{"global": {}}`), "team-a")
+	assert.False(t, ok)
+}
+
+func TestCheckChain(t *testing.T) {
+	a, b := Ref{Namespace: "ns", Name: "a"}, Ref{Namespace: "ns", Name: "b"}
+	assert.NoError(t, CheckChain([]Ref{a}, b))
+	assert.EqualError(t, CheckChain([]Ref{a, b}, a), "base platforms form a cycle: ns/a -> ns/b -> ns/a")
+
+	chain := []Ref{a}
+	for i := 0; i < MaxDepth; i++ {
+		chain = append(chain, Ref{Namespace: "ns", Name: string(rune('c' + i))})
+	}
+	assert.Error(t, CheckChain(chain, b))
+}
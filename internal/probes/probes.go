@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package probes applies user overrides of the liveness, readiness and
+// startup probes of managed components, and checks that they stay within
+// sane bounds.
+package probes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+const (
+	// DefaultStartupPeriodSeconds is how often the startup probe runs if not
+	// set
+	DefaultStartupPeriodSeconds = 10
+
+	// DefaultStartupFailureThreshold allows a component 5 minutes to start
+	// with the default period
+	DefaultStartupFailureThreshold = 30
+
+	// MaxStartupBudget bounds how long a startup probe may keep a container
+	// from being probed for liveness
+	MaxStartupBudget = 2 * time.Hour
+)
+
+// Apply overrides the probes of a container. Fields that are not set keep
+// their defaults. A startup probe is added on the readiness endpoint when
+// configured; a container without an HTTP readiness probe gets none.
+func Apply(container *corev1.Container, spec *observabilityv1beta1.ProbesSpec) {
+	if spec == nil {
+		return
+	}
+	if container.LivenessProbe != nil {
+		override(container.LivenessProbe, spec.Liveness)
+	}
+	if container.ReadinessProbe != nil {
+		override(container.ReadinessProbe, spec.Readiness)
+	}
+	if spec.Startup != nil && container.ReadinessProbe != nil && container.ReadinessProbe.HTTPGet != nil {
+		startup := &corev1.Probe{
+			ProbeHandler:     *container.ReadinessProbe.ProbeHandler.DeepCopy(),
+			PeriodSeconds:    DefaultStartupPeriodSeconds,
+			FailureThreshold: DefaultStartupFailureThreshold,
+		}
+		override(startup, spec.Startup)
+		container.StartupProbe = startup
+	}
+}
+
+// override sets the fields of a probe that are set in the spec
+func override(probe *corev1.Probe, spec *observabilityv1beta1.ProbeSpec) {
+	if spec == nil {
+		return
+	}
+	if spec.Path != "" && probe.HTTPGet != nil {
+		probe.HTTPGet.Path = spec.Path
+	}
+	if spec.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *spec.InitialDelaySeconds
+	}
+	if spec.PeriodSeconds != nil {
+		probe.PeriodSeconds = *spec.PeriodSeconds
+	}
+	if spec.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *spec.TimeoutSeconds
+	}
+	if spec.SuccessThreshold != nil {
+		probe.SuccessThreshold = *spec.SuccessThreshold
+	}
+	if spec.FailureThreshold != nil {
+		probe.FailureThreshold = *spec.FailureThreshold
+	}
+}
+
+// Validate checks the probe overrides of a component: paths are absolute,
+// timings and thresholds stay within bounds, a probe times out before the
+// next one runs, liveness and startup probes succeed after one success, and
+// a startup probe gives up within MaxStartupBudget.
+func Validate(spec *observabilityv1beta1.ProbesSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec == nil {
+		return allErrs
+	}
+
+	allErrs = append(allErrs, validateProbe(spec.Liveness, true, fldPath.Child("liveness"))...)
+	allErrs = append(allErrs, validateProbe(spec.Readiness, false, fldPath.Child("readiness"))...)
+	allErrs = append(allErrs, validateProbe(spec.Startup, true, fldPath.Child("startup"))...)
+
+	if spec.Startup != nil {
+		period := int64(value(spec.Startup.PeriodSeconds, DefaultStartupPeriodSeconds))
+		failures := int64(value(spec.Startup.FailureThreshold, DefaultStartupFailureThreshold))
+		if budget := time.Duration(period*failures) * time.Second; budget > MaxStartupBudget {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("startup"), budget.String(),
+				fmt.Sprintf("periodSeconds times failureThreshold must not exceed %s", MaxStartupBudget)))
+		}
+	}
+
+	return allErrs
+}
+
+func validateProbe(spec *observabilityv1beta1.ProbeSpec, singleSuccess bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec == nil {
+		return allErrs
+	}
+
+	if spec.Path != "" && !strings.HasPrefix(spec.Path, "/") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), spec.Path, "must start with /"))
+	}
+	allErrs = append(allErrs, validateRange(spec.InitialDelaySeconds, 0, 3600, fldPath.Child("initialDelaySeconds"))...)
+	allErrs = append(allErrs, validateRange(spec.PeriodSeconds, 1, 300, fldPath.Child("periodSeconds"))...)
+	allErrs = append(allErrs, validateRange(spec.TimeoutSeconds, 1, 300, fldPath.Child("timeoutSeconds"))...)
+	allErrs = append(allErrs, validateRange(spec.SuccessThreshold, 1, 10, fldPath.Child("successThreshold"))...)
+	allErrs = append(allErrs, validateRange(spec.FailureThreshold, 1, 1000, fldPath.Child("failureThreshold"))...)
+
+	if spec.TimeoutSeconds != nil && spec.PeriodSeconds != nil && *spec.TimeoutSeconds > *spec.PeriodSeconds {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeoutSeconds"), *spec.TimeoutSeconds, "must not exceed periodSeconds"))
+	}
+	if singleSuccess && spec.SuccessThreshold != nil && *spec.SuccessThreshold != 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("successThreshold"), *spec.SuccessThreshold, "must be 1 for liveness and startup probes"))
+	}
+
+	return allErrs
+}
+
+func validateRange(v *int32, minimum, maximum int32, fldPath *field.Path) field.ErrorList {
+	if v == nil || (*v >= minimum && *v <= maximum) {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(fldPath, *v, fmt.Sprintf("must be between %d and %d", minimum, maximum))}
+}
+
+func value(v *int32, def int32) int32 {
+	if v == nil {
+		return def
+	}
+	return *v
+}
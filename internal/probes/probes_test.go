@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package probes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+func int32Ptr(v int32) *int32 { return &v }
+
+func httpProbe(path string) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{Path: path, Port: intstr.FromInt(9090)},
+		},
+		InitialDelaySeconds: 30,
+		PeriodSeconds:       10,
+	}
+}
+
+func TestApply(t *testing.T) {
+	container := corev1.Container{
+		LivenessProbe:  httpProbe("/-/healthy"),
+		ReadinessProbe: httpProbe("/-/ready"),
+	}
+
+	Apply(&container, nil)
+	assert.Nil(t, container.StartupProbe)
+
+	Apply(&container, &observabilityv1beta1.ProbesSpec{
+		Liveness:  &observabilityv1beta1.ProbeSpec{FailureThreshold: int32Ptr(6), TimeoutSeconds: int32Ptr(5)},
+		Readiness: &observabilityv1beta1.ProbeSpec{Path: "/ready", InitialDelaySeconds: int32Ptr(0)},
+		Startup:   &observabilityv1beta1.ProbeSpec{FailureThreshold: int32Ptr(180)},
+	})
+
+	assert.Equal(t, "/-/healthy", container.LivenessProbe.HTTPGet.Path)
+	assert.Equal(t, int32(30), container.LivenessProbe.InitialDelaySeconds)
+	assert.Equal(t, int32(6), container.LivenessProbe.FailureThreshold)
+	assert.Equal(t, int32(5), container.LivenessProbe.TimeoutSeconds)
+
+	assert.Equal(t, "/ready", container.ReadinessProbe.HTTPGet.Path)
+	assert.Equal(t, int32(0), container.ReadinessProbe.InitialDelaySeconds)
+
+	require.NotNil(t, container.StartupProbe)
+	assert.Equal(t, "/ready", container.StartupProbe.HTTPGet.Path)
+	assert.Equal(t, int32(DefaultStartupPeriodSeconds), container.StartupProbe.PeriodSeconds)
+	assert.Equal(t, int32(180), container.StartupProbe.FailureThreshold)
+
+	// The startup probe has its own handler
+	container.StartupProbe.HTTPGet.Path = "/other"
+	assert.Equal(t, "/ready", container.ReadinessProbe.HTTPGet.Path)
+}
+
+func TestApplyWithoutReadinessProbe(t *testing.T) {
+	container := corev1.Container{}
+	Apply(&container, &observabilityv1beta1.ProbesSpec{
+		Liveness: &observabilityv1beta1.ProbeSpec{FailureThreshold: int32Ptr(6)},
+		Startup:  &observabilityv1beta1.ProbeSpec{},
+	})
+	assert.Nil(t, container.LivenessProbe)
+	assert.Nil(t, container.StartupProbe)
+}
+
+func TestValidate(t *testing.T) {
+	fldPath := field.NewPath("spec", "components", "prometheus", "probes")
+
+	assert.Empty(t, Validate(nil, fldPath))
+	assert.Empty(t, Validate(&observabilityv1beta1.ProbesSpec{
+		Liveness:  &observabilityv1beta1.ProbeSpec{Path: "/-/healthy", PeriodSeconds: int32Ptr(15), TimeoutSeconds: int32Ptr(10)},
+		Readiness: &observabilityv1beta1.ProbeSpec{SuccessThreshold: int32Ptr(3)},
+		Startup:   &observabilityv1beta1.ProbeSpec{PeriodSeconds: int32Ptr(10), FailureThreshold: int32Ptr(720)},
+	}, fldPath))
+
+	tests := []struct {
+		name  string
+		spec  *observabilityv1beta1.ProbesSpec
+		field string
+	}{
+		{
+			name:  "relative path",
+			spec:  &observabilityv1beta1.ProbesSpec{Readiness: &observabilityv1beta1.ProbeSpec{Path: "ready"}},
+			field: "spec.components.prometheus.probes.readiness.path",
+		},
+		{
+			name:  "period out of range",
+			spec:  &observabilityv1beta1.ProbesSpec{Liveness: &observabilityv1beta1.ProbeSpec{PeriodSeconds: int32Ptr(0)}},
+			field: "spec.components.prometheus.probes.liveness.periodSeconds",
+		},
+		{
+			name:  "initial delay out of range",
+			spec:  &observabilityv1beta1.ProbesSpec{Liveness: &observabilityv1beta1.ProbeSpec{InitialDelaySeconds: int32Ptr(7200)}},
+			field: "spec.components.prometheus.probes.liveness.initialDelaySeconds",
+		},
+		{
+			name:  "timeout exceeds period",
+			spec:  &observabilityv1beta1.ProbesSpec{Readiness: &observabilityv1beta1.ProbeSpec{PeriodSeconds: int32Ptr(5), TimeoutSeconds: int32Ptr(10)}},
+			field: "spec.components.prometheus.probes.readiness.timeoutSeconds",
+		},
+		{
+			name:  "liveness success threshold",
+			spec:  &observabilityv1beta1.ProbesSpec{Liveness: &observabilityv1beta1.ProbeSpec{SuccessThreshold: int32Ptr(2)}},
+			field: "spec.components.prometheus.probes.liveness.successThreshold",
+		},
+		{
+			name:  "startup budget",
+			spec:  &observabilityv1beta1.ProbesSpec{Startup: &observabilityv1beta1.ProbeSpec{PeriodSeconds: int32Ptr(60), FailureThreshold: int32Ptr(1000)}},
+			field: "spec.components.prometheus.probes.startup",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(tt.spec, fldPath)
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.field, errs[0].Field)
+		})
+	}
+}
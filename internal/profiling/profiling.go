@@ -0,0 +1,269 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package profiling builds the continuous profiling backend of a platform,
+// Grafana Pyroscope or Parca, running as a single replica storing profiles on
+// a volume. Pyroscope ingests profiles pushed by Alloy or the Pyroscope SDKs
+// over HTTP; Parca ingests profiles written by the Parca agent over gRPC.
+package profiling
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Pyroscope is Grafana Pyroscope
+	Pyroscope = "pyroscope"
+	// Parca is Parca
+	Parca = "parca"
+
+	// ConfigKey is the key of the configuration in the ConfigMap
+	ConfigKey = "config.yaml"
+
+	configPath = "/etc/profiling"
+	dataPath   = "/data"
+)
+
+// backend describes how a profiling backend is run
+type backend struct {
+	image string
+	port  int32
+	user  int64
+	// datasource is the type of the Grafana datasource plugin
+	datasource string
+	// ready is the readiness endpoint, a TCP check if empty
+	ready string
+}
+
+var backends = map[string]backend{
+	Pyroscope: {image: "grafana/pyroscope", port: 4040, user: 10001, datasource: "grafana-pyroscope-datasource", ready: "/ready"},
+	Parca:     {image: "ghcr.io/parca-dev/parca", port: 7070, user: 65534, datasource: "parca"},
+}
+
+// Server is a profiling backend deployment
+type Server struct {
+	Name      string
+	Namespace string
+	// Backend is Pyroscope or Parca
+	Backend string
+	Version string
+	// Retention is how long profiles are kept, forever if empty; only
+	// Pyroscope enforces it
+	Retention string
+	// Storage is the size of the profiles volume; an emptyDir is used if nil
+	Storage          *resource.Quantity
+	StorageClassName string
+	Resources        corev1.ResourceRequirements
+	Labels           map[string]string
+}
+
+// ServerName returns the name of the profiling StatefulSet and Service of a
+// platform
+func ServerName(platform string) string {
+	return fmt.Sprintf("profiling-%s", platform)
+}
+
+// Port returns the port the backend serves its API and ingestion on
+func Port(backendName string) int32 {
+	return backends[backendName].port
+}
+
+// URL returns the URL of a platform's profiling backend. Alloy's
+// pyroscope.write and the Pyroscope SDKs push to it; the Parca agent writes
+// to it over gRPC.
+func URL(platform, namespace, backendName string) string {
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", ServerName(platform), namespace, Port(backendName))
+}
+
+// Config returns the configuration file of the backend
+func Config(s Server) (string, error) {
+	var config map[string]interface{}
+	switch s.Backend {
+	case Pyroscope:
+		config = map[string]interface{}{
+			"target":      "all",
+			"pyroscopedb": map[string]interface{}{"data_path": dataPath + "/head"},
+			"storage": map[string]interface{}{
+				"backend":    "filesystem",
+				"filesystem": map[string]interface{}{"dir": dataPath + "/blocks"},
+			},
+		}
+		if s.Retention != "" {
+			config["limits"] = map[string]interface{}{"compactor_blocks_retention_period": s.Retention}
+		}
+	case Parca:
+		config = map[string]interface{}{
+			"object_storage": map[string]interface{}{
+				"bucket": map[string]interface{}{
+					"type":   "FILESYSTEM",
+					"config": map[string]interface{}{"directory": dataPath + "/blocks"},
+				},
+			},
+		}
+	default:
+		return "", fmt.Errorf("unsupported profiling backend %q", s.Backend)
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to render %s config: %w", s.Backend, err)
+	}
+	return string(data), nil
+}
+
+// Args returns the arguments of the backend container
+func Args(s Server) []string {
+	if s.Backend == Parca {
+		return []string{
+			fmt.Sprintf("--config-path=%s/%s", configPath, ConfigKey),
+			fmt.Sprintf("--http-address=:%d", Port(Parca)),
+			"--storage-path=" + dataPath + "/local",
+			"--enable-persistence",
+		}
+	}
+	return []string{
+		fmt.Sprintf("-config.file=%s/%s", configPath, ConfigKey),
+		fmt.Sprintf("-server.http-listen-port=%d", Port(Pyroscope)),
+	}
+}
+
+// Datasource returns the Grafana provisioning entry of a platform's profiling
+// datasource
+func Datasource(platform, namespace, backendName string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":     "Profiles",
+		"uid":      "profiling",
+		"type":     backends[backendName].datasource,
+		"url":      URL(platform, namespace, backendName),
+		"access":   "proxy",
+		"editable": false,
+	}
+}
+
+// BuildConfigMap returns the ConfigMap holding the backend configuration
+func BuildConfigMap(s Server) (*corev1.ConfigMap, error) {
+	config, err := Config(s)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.Name,
+			Namespace: s.Namespace,
+			Labels:    s.Labels,
+		},
+		Data: map[string]string{ConfigKey: config},
+	}, nil
+}
+
+// BuildStatefulSet returns the backend StatefulSet. Both backends store
+// their blocks on a local volume, so they run as a single replica.
+func BuildStatefulSet(s Server) *appsv1.StatefulSet {
+	replicas := int32(1)
+	b := backends[s.Backend]
+
+	volumes := []corev1.Volume{{
+		Name: "config",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: s.Name},
+			},
+		},
+	}}
+	var claims []corev1.PersistentVolumeClaim
+	if s.Storage != nil {
+		claim := corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: *s.Storage},
+				},
+			},
+		}
+		if s.StorageClassName != "" {
+			claim.Spec.StorageClassName = &s.StorageClassName
+		}
+		claims = append(claims, claim)
+	} else {
+		volumes = append(volumes, corev1.Volume{
+			Name:         "data",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+	}
+
+	probe := corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString("http")}}
+	if b.ready != "" {
+		probe = corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: b.ready, Port: intstr.FromString("http")}}
+	}
+
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.Name,
+			Namespace: s.Namespace,
+			Labels:    s.Labels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			ServiceName: s.Name,
+			Replicas:    &replicas,
+			Selector:    &metav1.LabelSelector{MatchLabels: s.Labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: s.Labels},
+				Spec: corev1.PodSpec{
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: &[]bool{true}[0],
+						RunAsUser:    &b.user,
+						FSGroup:      &b.user,
+					},
+					Containers: []corev1.Container{{
+						Name:      s.Backend,
+						Image:     fmt.Sprintf("%s:%s", b.image, s.Version),
+						Args:      Args(s),
+						Resources: s.Resources,
+						Ports: []corev1.ContainerPort{{
+							Name:          "http",
+							ContainerPort: b.port,
+							Protocol:      corev1.ProtocolTCP,
+						}},
+						ReadinessProbe: &corev1.Probe{ProbeHandler: probe, PeriodSeconds: 10},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "config", MountPath: configPath},
+							{Name: "data", MountPath: dataPath},
+						},
+					}},
+					Volumes: volumes,
+				},
+			},
+			VolumeClaimTemplates: claims,
+		},
+	}
+}
+
+// BuildService returns the Service serving the backend's API and ingestion
+func BuildService(s Server) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.Name,
+			Namespace: s.Namespace,
+			Labels:    s.Labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: s.Labels,
+			Ports: []corev1.ServicePort{{
+				Name:       "http",
+				Port:       Port(s.Backend),
+				TargetPort: intstr.FromString("http"),
+				Protocol:   corev1.ProtocolTCP,
+			}},
+		},
+	}
+}
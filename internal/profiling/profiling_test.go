@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package profiling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+)
+
+func TestPyroscopeConfigAppliesRetention(t *testing.T) {
+	config, err := Config(Server{Backend: Pyroscope, Retention: "15d"})
+	require.NoError(t, err)
+
+	var parsed map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(config), &parsed))
+	assert.Equal(t, "all", parsed["target"])
+	assert.Equal(t, map[string]interface{}{"compactor_blocks_retention_period": "15d"}, parsed["limits"])
+
+	config, err = Config(Server{Backend: Pyroscope})
+	require.NoError(t, err)
+	assert.NotContains(t, config, "limits")
+}
+
+func TestConfigRejectsUnknownBackend(t *testing.T) {
+	_, err := Config(Server{Backend: "phlare"})
+	assert.Error(t, err)
+}
+
+func TestBuildStatefulSetPerBackend(t *testing.T) {
+	size := resource.MustParse("20Gi")
+	s := Server{
+		Name:      ServerName("prod"),
+		Namespace: "monitoring",
+		Backend:   Parca,
+		Version:   "v0.22.0",
+		Storage:   &size,
+		Labels:    map[string]string{"app.kubernetes.io/name": "profiling"},
+	}
+
+	sts := BuildStatefulSet(s)
+	container := sts.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "ghcr.io/parca-dev/parca:v0.22.0", container.Image)
+	assert.Contains(t, container.Args, "--enable-persistence")
+	assert.Equal(t, int32(7070), container.Ports[0].ContainerPort)
+	assert.NotNil(t, container.ReadinessProbe.TCPSocket)
+	require.Len(t, sts.Spec.VolumeClaimTemplates, 1)
+	assert.Len(t, sts.Spec.Template.Spec.Volumes, 1)
+
+	s.Backend, s.Version, s.Storage = Pyroscope, "1.7.1", nil
+	sts = BuildStatefulSet(s)
+	container = sts.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "grafana/pyroscope:1.7.1", container.Image)
+	assert.Equal(t, "/ready", container.ReadinessProbe.HTTPGet.Path)
+	assert.Empty(t, sts.Spec.VolumeClaimTemplates)
+	assert.Len(t, sts.Spec.Template.Spec.Volumes, 2)
+}
+
+func TestDatasource(t *testing.T) {
+	ds := Datasource("prod", "monitoring", Pyroscope)
+	assert.Equal(t, "grafana-pyroscope-datasource", ds["type"])
+	assert.Equal(t, "http://profiling-prod.monitoring.svc.cluster.local:4040", ds["url"])
+	assert.Equal(t, "parca", Datasource("prod", "monitoring", Parca)["type"])
+}
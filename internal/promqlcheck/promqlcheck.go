@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package promqlcheck validates the PromQL expressions of alerting and
+// recording rules before they reach Prometheus, which would otherwise refuse
+// to load the whole rule file on the next reload.
+package promqlcheck
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// Check parses an expression. The error of an invalid expression tells the
+// position of the error, e.g. "1:14: parse error: unexpected <by>".
+func Check(expr string) error {
+	_, err := parser.ParseExpr(expr)
+	return err
+}
+
+// Result is the validation of an expression
+type Result struct {
+	// Err is the parse error of an invalid expression
+	Err error
+	// Warnings are the selectors which will never match
+	Warnings []string
+}
+
+// Validate parses an expression and checks its label matchers against the
+// external labels of Prometheus. External labels are only attached to series
+// leaving Prometheus, for remote write, federation and alerts, so rules
+// evaluated by Prometheus never see them: a selector requiring one never
+// matches.
+func Validate(expr string, externalLabels map[string]string) Result {
+	parsed, err := parser.ParseExpr(expr)
+	if err != nil {
+		return Result{Err: err}
+	}
+
+	var warnings []string
+	seen := map[string]bool{}
+	parser.Inspect(parsed, func(node parser.Node, _ []parser.Node) error {
+		selector, ok := node.(*parser.VectorSelector)
+		if !ok {
+			return nil
+		}
+		for _, matcher := range selector.LabelMatchers {
+			if _, external := externalLabels[matcher.Name]; !external || matchesAbsent(matcher) {
+				continue
+			}
+			warning := fmt.Sprintf(
+				"selector %s never matches: %s is an external label, which series don't have when rules are evaluated",
+				selector.String(), matcher.Name)
+			if !seen[warning] {
+				seen[warning] = true
+				warnings = append(warnings, warning)
+			}
+		}
+		return nil
+	})
+	sort.Strings(warnings)
+	return Result{Warnings: warnings}
+}
+
+// matchesAbsent reports whether a matcher matches series without its label,
+// like cluster!="other" or cluster=~".*"
+func matchesAbsent(matcher *labels.Matcher) bool {
+	return matcher.Matches("")
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package promqlcheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	assert.NoError(t, Check(`sum by (job) (rate(http_requests_total{code=~"5.."}[5m])) > 0.1`))
+	assert.NoError(t, Check(`up == 0`))
+
+	err := Check(`sum(rate(http_requests_total[5m]) by (job)`)
+	require.Error(t, err)
+	assert.Regexp(t, `^1:\d+: parse error: `, err.Error())
+
+	err = Check("up ==\n  )")
+	require.Error(t, err)
+	assert.Regexp(t, `^2:\d+: parse error: `, err.Error())
+}
+
+func TestValidate(t *testing.T) {
+	external := map[string]string{"cluster": "prod-eu", "environment": "production"}
+
+	result := Validate(`up{job="prometheus"} == 0`, external)
+	assert.NoError(t, result.Err)
+	assert.Empty(t, result.Warnings)
+
+	result = Validate(`sum(rate(errors_total{cluster="prod-eu"}[5m])) / sum(rate(requests_total{cluster="prod-eu"}[5m]))`, external)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, []string{
+		`selector errors_total{cluster="prod-eu"} never matches: cluster is an external label, which series don't have when rules are evaluated`,
+		`selector requests_total{cluster="prod-eu"} never matches: cluster is an external label, which series don't have when rules are evaluated`,
+	}, result.Warnings)
+
+	// Matchers which match series without the label are harmless
+	result = Validate(`up{environment!="staging"} or up{cluster=~".*"}`, external)
+	assert.NoError(t, result.Err)
+	assert.Empty(t, result.Warnings)
+
+	result = Validate(`up{`, external)
+	assert.Error(t, result.Err)
+	assert.Empty(t, result.Warnings)
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package queryacl enforces label-based query access per team. Queries of a
+// team's Grafana org go through prom-label-proxy, which injects a matcher for
+// the team's label values into every PromQL query and rejects queries without
+// them. Logs are restricted by the Loki tenants a team's org queries.
+package queryacl
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// DefaultImage is the prom-label-proxy image
+	DefaultImage = "quay.io/prometheuscommunity/prom-label-proxy:v0.8.1"
+	// DefaultLabel is the label enforced on queries
+	DefaultLabel = "namespace"
+	// Port is the port the proxy listens on
+	Port int32 = 8080
+	// MinOrgID is the lowest Grafana organization a team may use. The main
+	// organization 1 holds the unrestricted datasources.
+	MinOrgID int64 = 2
+	// ValuesHeader is the header carrying the label values to the proxy. It
+	// is in canonical form, as the proxy looks it up without canonicalizing.
+	ValuesHeader = "X-Label-Values"
+	// TenantHeader is the header carrying the tenants to Loki
+	TenantHeader = "X-Scope-OrgID"
+)
+
+// Team is a group of Grafana users restricted to a set of label values
+type Team struct {
+	Name string
+	// OrgID is the Grafana organization of the team
+	OrgID int64
+	// Values are the label values the team may query
+	Values []string
+	// Tenants are the Loki tenants whose logs the team may query
+	Tenants []string
+}
+
+// Proxy is a prom-label-proxy deployment
+type Proxy struct {
+	Name      string
+	Namespace string
+	Image     string
+	// Label is the label enforced on every query
+	Label string
+	// Upstream is the URL of the Prometheus the proxy forwards to
+	Upstream string
+	Replicas int32
+	Labels   map[string]string
+	// Clients are the labels of the pods admitted to the proxy, as it
+	// listens without authentication
+	Clients []map[string]string
+}
+
+// ProxyName returns the name of the proxy Deployment and Service of a platform
+func ProxyName(platform string) string {
+	return fmt.Sprintf("prom-label-proxy-%s", platform)
+}
+
+// ProxyURL returns the in-cluster URL of the proxy of a platform
+func ProxyURL(platform, namespace string) string {
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", ProxyName(platform), namespace, Port)
+}
+
+// DatasourceName returns the name of a team's Prometheus datasource
+func DatasourceName(team Team) string {
+	return fmt.Sprintf("Prometheus (%s)", team.Name)
+}
+
+// LokiDatasourceName returns the name of a team's Loki datasource
+func LokiDatasourceName(team Team) string {
+	return fmt.Sprintf("Loki (%s)", team.Name)
+}
+
+// ValuesRegex returns the regular expression matching exactly the label
+// values of a team, which the proxy enforces. Values are sorted so the
+// rendered datasource is stable.
+func ValuesRegex(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, regexp.QuoteMeta(value))
+	}
+	sort.Strings(quoted)
+	return strings.Join(quoted, "|")
+}
+
+// Datasource returns the Grafana provisioning entry of a team's Prometheus
+// datasource. It lives in the team's org and is not editable, so members can
+// neither remove the label values nor point it at Prometheus directly. The
+// values are sent in a header kept in secureJsonData, which Grafana sets on
+// every proxied request in place of any header of the user. It is the
+// default of the team's org, which must not be the main organization.
+func Datasource(team Team, proxyURL string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":      DatasourceName(team),
+		"type":      "prometheus",
+		"orgId":     team.OrgID,
+		"url":       proxyURL,
+		"access":    "proxy",
+		"isDefault": true,
+		"editable":  false,
+		"jsonData": map[string]interface{}{
+			"timeInterval":    "15s",
+			"httpHeaderName1": ValuesHeader,
+		},
+		"secureJsonData": map[string]interface{}{
+			"httpHeaderValue1": ValuesRegex(team.Values),
+		},
+	}
+}
+
+// LokiDatasource returns the Grafana provisioning entry of a team's Loki
+// datasource, querying the team's tenants. Like the Prometheus datasource,
+// it lives in the team's org, is not editable and keeps the tenants in
+// secureJsonData.
+func LokiDatasource(team Team, lokiURL string) map[string]interface{} {
+	tenants := append([]string(nil), team.Tenants...)
+	sort.Strings(tenants)
+	return map[string]interface{}{
+		"name":     LokiDatasourceName(team),
+		"type":     "loki",
+		"orgId":    team.OrgID,
+		"url":      lokiURL,
+		"access":   "proxy",
+		"editable": false,
+		"jsonData": map[string]interface{}{
+			"maxLines":        1000,
+			"httpHeaderName1": TenantHeader,
+		},
+		"secureJsonData": map[string]interface{}{
+			"httpHeaderValue1": strings.Join(tenants, "|"),
+		},
+	}
+}
+
+// BuildDeployment returns the proxy Deployment. The label values are taken
+// from ValuesHeader as a regular expression, and requests without the header
+// are rejected. The label APIs are filtered too, so teams cannot discover
+// series outside their values.
+func BuildDeployment(p Proxy) *appsv1.Deployment {
+	replicas := p.Replicas
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.Name,
+			Namespace: p.Namespace,
+			Labels:    p.Labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: p.Labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: p.Labels},
+				Spec: corev1.PodSpec{
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: &[]bool{true}[0],
+						RunAsUser:    &[]int64{65534}[0],
+					},
+					Containers: []corev1.Container{{
+						Name:  "prom-label-proxy",
+						Image: p.Image,
+						Args: []string{
+							fmt.Sprintf("--insecure-listen-address=0.0.0.0:%d", Port),
+							"--upstream=" + p.Upstream,
+							"--label=" + p.Label,
+							"--header-name=" + ValuesHeader,
+							"--regex-match",
+							"--enable-label-apis",
+						},
+						Ports: []corev1.ContainerPort{{
+							Name:          "http",
+							ContainerPort: Port,
+							Protocol:      corev1.ProtocolTCP,
+						}},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString("http")},
+							},
+						},
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: &[]bool{false}[0],
+							ReadOnlyRootFilesystem:   &[]bool{true}[0],
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// BuildService returns the proxy Service
+func BuildService(p Proxy) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.Name,
+			Namespace: p.Namespace,
+			Labels:    p.Labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: p.Labels,
+			Ports: []corev1.ServicePort{{
+				Name:       "http",
+				Port:       Port,
+				TargetPort: intstr.FromString("http"),
+				Protocol:   corev1.ProtocolTCP,
+			}},
+		},
+	}
+}
+
+// BuildNetworkPolicy returns the NetworkPolicy admitting only the clients of
+// the proxy, so the label values can't be chosen by querying it directly
+func BuildNetworkPolicy(p Proxy) *networkingv1.NetworkPolicy {
+	port := intstr.FromInt(int(Port))
+	protocol := corev1.ProtocolTCP
+	peers := make([]networkingv1.NetworkPolicyPeer, 0, len(p.Clients))
+	for _, labels := range p.Clients {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{MatchLabels: labels},
+		})
+	}
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.Name,
+			Namespace: p.Namespace,
+			Labels:    p.Labels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: p.Labels},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From:  peers,
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: &protocol, Port: &port}},
+			}},
+		},
+	}
+}
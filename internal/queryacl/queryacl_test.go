@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package queryacl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValuesRegex(t *testing.T) {
+	assert.Equal(t, "payments|shop", ValuesRegex([]string{"shop", "payments"}))
+	assert.Equal(t, `team\.a|team\|b`, ValuesRegex([]string{"team|b", "team.a"}))
+}
+
+func TestDatasource(t *testing.T) {
+	team := Team{Name: "payments", OrgID: 3, Values: []string{"payments-prod", "payments-dev"}}
+
+	ds := Datasource(team, ProxyURL("prod", "monitoring"))
+
+	assert.Equal(t, "Prometheus (payments)", ds["name"])
+	assert.Equal(t, int64(3), ds["orgId"])
+	assert.Equal(t, "http://prom-label-proxy-prod.monitoring.svc.cluster.local:8080", ds["url"])
+	assert.Equal(t, false, ds["editable"])
+	jsonData := ds["jsonData"].(map[string]interface{})
+	assert.Equal(t, ValuesHeader, jsonData["httpHeaderName1"])
+	assert.NotContains(t, jsonData, "customQueryParameters")
+	secureJSONData := ds["secureJsonData"].(map[string]interface{})
+	assert.Equal(t, "payments-dev|payments-prod", secureJSONData["httpHeaderValue1"])
+}
+
+func TestLokiDatasource(t *testing.T) {
+	team := Team{Name: "payments", OrgID: 3, Values: []string{"payments-prod"}, Tenants: []string{"payments", "edge"}}
+
+	ds := LokiDatasource(team, "http://loki-prod.monitoring.svc.cluster.local:3100")
+
+	assert.Equal(t, "Loki (payments)", ds["name"])
+	assert.Equal(t, "loki", ds["type"])
+	assert.Equal(t, int64(3), ds["orgId"])
+	assert.Equal(t, false, ds["editable"])
+	jsonData := ds["jsonData"].(map[string]interface{})
+	assert.Equal(t, "X-Scope-OrgID", jsonData["httpHeaderName1"])
+	secureJSONData := ds["secureJsonData"].(map[string]interface{})
+	assert.Equal(t, "edge|payments", secureJSONData["httpHeaderValue1"])
+}
+
+func TestBuildDeploymentAndService(t *testing.T) {
+	proxy := Proxy{
+		Name:      ProxyName("prod"),
+		Namespace: "monitoring",
+		Image:     DefaultImage,
+		Label:     "team",
+		Upstream:  "http://prometheus-prod.monitoring.svc.cluster.local:9090",
+		Replicas:  2,
+		Labels:    map[string]string{"app.kubernetes.io/name": "prom-label-proxy"},
+	}
+
+	deployment := BuildDeployment(proxy)
+	assert.Equal(t, "prom-label-proxy-prod", deployment.Name)
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+	require.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, DefaultImage, container.Image)
+	assert.Contains(t, container.Args, "--upstream=http://prometheus-prod.monitoring.svc.cluster.local:9090")
+	assert.Contains(t, container.Args, "--label=team")
+	assert.Contains(t, container.Args, "--header-name=X-Label-Values")
+	assert.Contains(t, container.Args, "--regex-match")
+	assert.Contains(t, container.Args, "--enable-label-apis")
+
+	service := BuildService(proxy)
+	assert.Equal(t, deployment.Spec.Template.Labels, service.Spec.Selector)
+	require.Len(t, service.Spec.Ports, 1)
+	assert.Equal(t, Port, service.Spec.Ports[0].Port)
+}
+
+func TestBuildNetworkPolicy(t *testing.T) {
+	proxy := Proxy{
+		Name:      ProxyName("prod"),
+		Namespace: "monitoring",
+		Labels:    map[string]string{"app.kubernetes.io/name": "prom-label-proxy"},
+		Clients: []map[string]string{
+			{"app.kubernetes.io/name": "grafana", "app.kubernetes.io/instance": "prod"},
+		},
+	}
+
+	policy := BuildNetworkPolicy(proxy)
+	assert.Equal(t, proxy.Labels, policy.Spec.PodSelector.MatchLabels)
+	require.Len(t, policy.Spec.Ingress, 1)
+	require.Len(t, policy.Spec.Ingress[0].From, 1)
+	assert.Equal(t, proxy.Clients[0], policy.Spec.Ingress[0].From[0].PodSelector.MatchLabels)
+	require.Len(t, policy.Spec.Ingress[0].Ports, 1)
+	assert.Equal(t, int(Port), policy.Spec.Ingress[0].Ports[0].Port.IntValue())
+}
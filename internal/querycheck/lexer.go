@@ -0,0 +1,318 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package querycheck checks the LogQL and TraceQL queries of specs, such as
+// the Loki rules loaded by the ruler, the queries of dashboards and the
+// default searches of Tempo, so they are rejected at admission instead of
+// failing in the component. Errors tell the position of the error, like those
+// of the PromQL parser: "1:14: parse error: unexpected <by>".
+package querycheck
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind is the kind of a token
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokDuration
+	tokBytes
+	tokOp
+)
+
+// token is a token of a query
+type token struct {
+	kind tokenKind
+	// val is the text of the token, unquoted for strings
+	val string
+	pos int
+}
+
+// String describes a token in errors
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of input"
+	case tokString:
+		return strconv.Quote(t.val)
+	case tokIdent:
+		return "identifier " + strconv.Quote(t.val)
+	default:
+		return strconv.Quote(t.val)
+	}
+}
+
+// ParseError is an error at a position of a query
+type ParseError struct {
+	Query string
+	// Pos is the byte offset of the error
+	Pos int
+	Msg string
+	// Semantic is set for queries with a valid syntax which the backend
+	// refuses, like a comparison of a string and a duration
+	Semantic bool
+}
+
+// Error formats the error with its line and column, both from 1
+func (e *ParseError) Error() string {
+	line, col := 1, 1
+	for i, r := range e.Query {
+		if i >= e.Pos {
+			break
+		}
+		if r == '\n' {
+			line++
+			col = 1
+			continue
+		}
+		col++
+	}
+	kind := "parse error"
+	if e.Semantic {
+		kind = "invalid query"
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", line, col, kind, e.Msg)
+}
+
+// scanner reads the tokens of a query. The languages share strings,
+// numbers, durations and byte sizes; their operators and identifiers differ.
+type scanner struct {
+	input string
+	pos   int
+	// ops are the operators of the language, longest first
+	ops []string
+	// ident reads an identifier at the position, 0 if there is none
+	ident func(s *scanner) int
+}
+
+// errorf returns a parse error at a position
+func (s *scanner) errorf(pos int, format string, args ...interface{}) error {
+	return &ParseError{Query: s.input, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// next reads the next token
+func (s *scanner) next() (token, error) {
+	for s.pos < len(s.input) {
+		r, size := utf8.DecodeRuneInString(s.input[s.pos:])
+		if r == '#' {
+			// Comments run to the end of the line
+			end := strings.IndexByte(s.input[s.pos:], '\n')
+			if end < 0 {
+				s.pos = len(s.input)
+				break
+			}
+			s.pos += end
+			continue
+		}
+		if !unicode.IsSpace(r) {
+			break
+		}
+		s.pos += size
+	}
+	start := s.pos
+	if start >= len(s.input) {
+		return token{kind: tokEOF, pos: start}, nil
+	}
+
+	c := s.input[start]
+	switch {
+	case c == '"' || c == '`':
+		val, err := s.quoted()
+		if err != nil {
+			return token{}, err
+		}
+		return token{kind: tokString, val: val, pos: start}, nil
+	case c >= '0' && c <= '9' || c == '.' && start+1 < len(s.input) && isDigit(s.input[start+1]):
+		return s.number()
+	}
+	if n := s.ident(s); n > 0 {
+		s.pos += n
+		return token{kind: tokIdent, val: s.input[start:s.pos], pos: start}, nil
+	}
+	for _, op := range s.ops {
+		if strings.HasPrefix(s.input[start:], op) {
+			s.pos += len(op)
+			return token{kind: tokOp, val: op, pos: start}, nil
+		}
+	}
+	r, _ := utf8.DecodeRuneInString(s.input[start:])
+	return token{}, s.errorf(start, "unexpected character %q", r)
+}
+
+// invalidf returns a semantic error at a position
+func (s *scanner) invalidf(pos int, format string, args ...interface{}) error {
+	return &ParseError{Query: s.input, Pos: pos, Msg: fmt.Sprintf(format, args...), Semantic: true}
+}
+
+// quoted reads a double-quoted string with escapes, or a raw backquoted one
+func (s *scanner) quoted() (string, error) {
+	start := s.pos
+	quote := s.input[start]
+	i := start + 1
+	for i < len(s.input) {
+		switch s.input[i] {
+		case quote:
+			s.pos = i + 1
+			if quote == '`' {
+				return s.input[start+1 : i], nil
+			}
+			val, err := strconv.Unquote(s.input[start:s.pos])
+			if err != nil {
+				return "", s.errorf(start, "invalid string %s", s.input[start:s.pos])
+			}
+			return val, nil
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case '\n':
+			if quote == '"' {
+				return "", s.errorf(start, "unterminated string")
+			}
+		}
+		i++
+	}
+	return "", s.errorf(start, "unterminated string")
+}
+
+// durationPattern matches durations like 5m, 1h30m or 250ms
+var durationPattern = regexp.MustCompile(`^([0-9]+(ns|us|µs|ms|s|m|h|d|w|y))+$`)
+
+// bytesPattern matches byte sizes like 10KB, 1.5GiB or 512B
+var bytesPattern = regexp.MustCompile(`(?i)^[0-9]+(\.[0-9]+)?([kmgtpe]i?)?b$`)
+
+// number reads a number, a duration or a byte size
+func (s *scanner) number() (token, error) {
+	start := s.pos
+	i := start
+	for i < len(s.input) && (isDigit(s.input[i]) || s.input[i] == '.') {
+		i++
+	}
+	// Exponents, like 1e3
+	if i+1 < len(s.input) && (s.input[i] == 'e' || s.input[i] == 'E') &&
+		(isDigit(s.input[i+1]) || (s.input[i+1] == '-' || s.input[i+1] == '+') && i+2 < len(s.input) && isDigit(s.input[i+2])) {
+		i += 2
+		for i < len(s.input) && isDigit(s.input[i]) {
+			i++
+		}
+	}
+	// Units, like 5m, 1h30m or 10KiB
+	for i < len(s.input) {
+		r, size := utf8.DecodeRuneInString(s.input[i:])
+		if !unicode.IsLetter(r) && !isDigit(s.input[i]) {
+			break
+		}
+		i += size
+	}
+	s.pos = i
+	text := s.input[start:i]
+
+	if _, err := strconv.ParseFloat(text, 64); err == nil {
+		return token{kind: tokNumber, val: text, pos: start}, nil
+	}
+	if durationPattern.MatchString(text) {
+		return token{kind: tokDuration, val: text, pos: start}, nil
+	}
+	if bytesPattern.MatchString(text) {
+		return token{kind: tokBytes, val: text, pos: start}, nil
+	}
+	return token{}, s.errorf(start, "invalid number, duration or byte size %q", text)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isIdentStart reports whether a rune starts an identifier
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+// isIdentRune reports whether a rune continues an identifier
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// parser reads tokens with a lookahead of one
+type parser struct {
+	s    *scanner
+	tok  token
+	prev token
+}
+
+// advance reads the next token
+func (p *parser) advance() error {
+	p.prev = p.tok
+	tok, err := p.s.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+// is reports whether the current token is an operator or keyword
+func (p *parser) is(vals ...string) bool {
+	if p.tok.kind != tokOp && p.tok.kind != tokIdent {
+		return false
+	}
+	for _, val := range vals {
+		if p.tok.val == val {
+			return true
+		}
+	}
+	return false
+}
+
+// accept consumes the current token if it is one of the operators or
+// keywords
+func (p *parser) accept(vals ...string) (bool, error) {
+	if !p.is(vals...) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+// expect consumes an operator or keyword
+func (p *parser) expect(val string) error {
+	if !p.is(val) {
+		return p.unexpected("expected " + strconv.Quote(val))
+	}
+	return p.advance()
+}
+
+// expectKind consumes a token of a kind, returning it
+func (p *parser) expectKind(kind tokenKind, what string) (token, error) {
+	if p.tok.kind != kind {
+		return token{}, p.unexpected("expected " + what)
+	}
+	tok := p.tok
+	return tok, p.advance()
+}
+
+// unexpected returns the error of an unexpected token
+func (p *parser) unexpected(context string) error {
+	return p.s.errorf(p.tok.pos, "unexpected %s, %s", p.tok, context)
+}
+
+// checkRegexp returns the error of an invalid regular expression. Loki and
+// Tempo use the RE2 syntax of Go.
+func (p *parser) checkRegexp(tok token) error {
+	if _, err := regexp.Compile(tok.val); err != nil {
+		return p.s.errorf(tok.pos, "invalid regular expression %q: %v", tok.val, err)
+	}
+	return nil
+}
@@ -0,0 +1,850 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package querycheck
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+// exprKind is the kind of the value of a LogQL expression
+type exprKind int
+
+const (
+	kindLog exprKind = iota
+	kindMetric
+	kindScalar
+)
+
+// logqlOps are the LogQL operators, longest first
+var logqlOps = []string{
+	"--keep-empty", "--strict",
+	"|=", "|~", "|>", "!=", "!~", "!>", "=~", "==", ">=", "<=",
+	"=", ">", "<", "|", "{", "}", "(", ")", "[", "]", ",",
+	"+", "-", "*", "/", "%", "^",
+}
+
+// logqlPrecedence are the binary operators, lowest precedence first
+var logqlPrecedence = [][]string{
+	{"or"},
+	{"and", "unless"},
+	{"==", "!=", ">", ">=", "<", "<="},
+	{"+", "-"},
+	{"*", "/", "%"},
+	{"^"},
+}
+
+// rangeAggregations are the range aggregations, with whether they are
+// allowed with and without unwrap and with a grouping, as Loki validates them
+var rangeAggregations = map[string]struct{ unwrap, plain, grouping bool }{
+	"count_over_time":    {plain: true},
+	"bytes_over_time":    {plain: true},
+	"bytes_rate":         {plain: true},
+	"rate":               {unwrap: true, plain: true, grouping: true},
+	"absent_over_time":   {unwrap: true, plain: true},
+	"rate_counter":       {unwrap: true, grouping: true},
+	"sum_over_time":      {unwrap: true},
+	"avg_over_time":      {unwrap: true, grouping: true},
+	"max_over_time":      {unwrap: true, grouping: true},
+	"min_over_time":      {unwrap: true, grouping: true},
+	"first_over_time":    {unwrap: true, grouping: true},
+	"last_over_time":     {unwrap: true, grouping: true},
+	"stdvar_over_time":   {unwrap: true, grouping: true},
+	"stddev_over_time":   {unwrap: true, grouping: true},
+	"quantile_over_time": {unwrap: true, grouping: true},
+}
+
+// vectorAggregations are the vector aggregations, with whether they take a
+// parameter
+var vectorAggregations = map[string]bool{
+	"sum": false, "avg": false, "min": false, "max": false, "count": false,
+	"stddev": false, "stdvar": false, "sort": false, "sort_desc": false,
+	"topk": true, "bottomk": true,
+}
+
+// unwrapConversions are the conversion functions of unwrap
+var unwrapConversions = map[string]bool{"duration": true, "duration_seconds": true, "bytes": true}
+
+// patternCapture matches the named captures of a pattern parser
+var patternCapture = regexp.MustCompile(`<([A-Za-z_][A-Za-z0-9_]*)>`)
+
+// LogQL checks the syntax of a LogQL query, a log query or a metric query
+func LogQL(query string) error {
+	_, err := parseLogQL(query)
+	return err
+}
+
+// LogQLMetric checks a LogQL query which must be a metric query, like the
+// expressions of the rules of the Loki ruler
+func LogQLMetric(query string) error {
+	kind, err := parseLogQL(query)
+	if err != nil {
+		return err
+	}
+	if kind == kindLog {
+		return fmt.Errorf(`a log query is not allowed, expected a metric query like sum(count_over_time({app="api"} |= "error" [5m]))`)
+	}
+	return nil
+}
+
+// logqlParser is a recursive descent parser of LogQL
+type logqlParser struct {
+	parser
+}
+
+func parseLogQL(query string) (exprKind, error) {
+	p := &logqlParser{parser{s: &scanner{input: query, ops: logqlOps, ident: logqlIdent}}}
+	if err := p.advance(); err != nil {
+		return 0, err
+	}
+	if p.tok.kind == tokEOF {
+		return 0, p.s.errorf(0, "empty query")
+	}
+	kind, err := p.binary(0)
+	if err != nil {
+		return 0, err
+	}
+	if p.tok.kind != tokEOF {
+		return 0, p.unexpected("expected end of query")
+	}
+	return kind, nil
+}
+
+// logqlIdent reads an identifier, like a label or function name
+func logqlIdent(s *scanner) int {
+	n := 0
+	for s.pos+n < len(s.input) {
+		r, size := utf8.DecodeRuneInString(s.input[s.pos+n:])
+		if n == 0 && !isIdentStart(r) || n > 0 && !isIdentRune(r) {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
+// binary parses the binary operations of a precedence level and above
+func (p *logqlParser) binary(level int) (exprKind, error) {
+	if level == len(logqlPrecedence) {
+		return p.unary()
+	}
+	left, err := p.binary(level + 1)
+	if err != nil {
+		return 0, err
+	}
+	for p.is(logqlPrecedence[level]...) {
+		op := p.tok
+		if err := p.advance(); err != nil {
+			return 0, err
+		}
+		if err := p.binaryModifiers(op, level); err != nil {
+			return 0, err
+		}
+		next := level + 1
+		if op.val == "^" {
+			// Exponentiation is right associative
+			next = level
+		}
+		right, err := p.binary(next)
+		if err != nil {
+			return 0, err
+		}
+		if left == kindLog || right == kindLog {
+			return 0, p.s.errorf(op.pos, "operator %q needs metric queries, not log queries", op.val)
+		}
+		if level <= 1 && (left == kindScalar || right == kindScalar) {
+			return 0, p.s.errorf(op.pos, "set operator %q is not allowed with scalars", op.val)
+		}
+		if left == kindScalar && right == kindScalar {
+			continue
+		}
+		left = kindMetric
+	}
+	return left, nil
+}
+
+// binaryModifiers parses the bool, on, ignoring, group_left and group_right
+// modifiers of a binary operator
+func (p *logqlParser) binaryModifiers(op token, level int) error {
+	if p.is("bool") {
+		if level != 2 {
+			return p.s.errorf(p.tok.pos, "bool is only allowed after comparison operators, not %q", op.val)
+		}
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+	matched, err := p.accept("on", "ignoring")
+	if err != nil || !matched {
+		return err
+	}
+	if err := p.labelList(); err != nil {
+		return err
+	}
+	grouped, err := p.accept("group_left", "group_right")
+	if err != nil || !grouped {
+		return err
+	}
+	if p.is("(") {
+		return p.labelList()
+	}
+	return nil
+}
+
+// labelList parses a parenthesized list of label names
+func (p *logqlParser) labelList() error {
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for p.tok.kind == tokIdent {
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if more, err := p.accept(","); err != nil || !more {
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return p.expect(")")
+}
+
+// unary parses a signed expression
+func (p *logqlParser) unary() (exprKind, error) {
+	if p.is("-", "+") {
+		op := p.tok
+		if err := p.advance(); err != nil {
+			return 0, err
+		}
+		kind, err := p.unary()
+		if err != nil {
+			return 0, err
+		}
+		if kind == kindLog {
+			return 0, p.s.errorf(op.pos, "operator %q needs a metric query, not a log query", op.val)
+		}
+		return kind, nil
+	}
+	return p.primary()
+}
+
+// primary parses a number, a parenthesized expression, a log query or a
+// function
+func (p *logqlParser) primary() (exprKind, error) {
+	switch {
+	case p.tok.kind == tokNumber:
+		return kindScalar, p.advance()
+	case p.is("("):
+		if err := p.advance(); err != nil {
+			return 0, err
+		}
+		kind, err := p.binary(0)
+		if err != nil {
+			return 0, err
+		}
+		return kind, p.expect(")")
+	case p.is("{"):
+		if _, err := p.logQuery(false); err != nil {
+			return 0, err
+		}
+		return kindLog, nil
+	case p.tok.kind != tokIdent:
+		return 0, p.unexpected("expected a stream selector, a function or a number")
+	}
+
+	name := p.tok.val
+	if _, ok := rangeAggregations[name]; ok {
+		return kindMetric, p.rangeAggregation()
+	}
+	if _, ok := vectorAggregations[name]; ok {
+		return kindMetric, p.vectorAggregation()
+	}
+	switch name {
+	case "vector":
+		if err := p.advance(); err != nil {
+			return 0, err
+		}
+		if err := p.expect("("); err != nil {
+			return 0, err
+		}
+		if _, err := p.expectKind(tokNumber, "a number"); err != nil {
+			return 0, err
+		}
+		return kindMetric, p.expect(")")
+	case "label_replace":
+		return kindMetric, p.labelReplace()
+	}
+	return 0, p.s.errorf(p.tok.pos, "unknown function %q", name)
+}
+
+// rangeAggregation parses a range aggregation, like
+// rate({app="api"} |= "error" [5m])
+func (p *logqlParser) rangeAggregation() error {
+	fn := p.tok
+	rules := rangeAggregations[fn.val]
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	if fn.val == "quantile_over_time" {
+		if _, err := p.expectKind(tokNumber, "the quantile"); err != nil {
+			return err
+		}
+		if err := p.expect(","); err != nil {
+			return err
+		}
+	}
+	unwrap, err := p.logRange()
+	if err != nil {
+		return err
+	}
+	if err := p.expect(")"); err != nil {
+		return err
+	}
+	switch {
+	case unwrap && !rules.unwrap:
+		return p.s.errorf(fn.pos, "%s is not allowed with unwrap", fn.val)
+	case !unwrap && !rules.plain:
+		return p.s.errorf(fn.pos, "%s needs an unwrapped label, like {app=\"api\"} | unwrap latency [5m]", fn.val)
+	}
+	if p.is("by", "without") {
+		if !rules.grouping {
+			return p.s.errorf(p.tok.pos, "grouping is not allowed for %s", fn.val)
+		}
+		if err := p.advance(); err != nil {
+			return err
+		}
+		return p.labelList()
+	}
+	return nil
+}
+
+// logRange parses a log query with a range, like {app="api"} |= "error" [5m].
+// The range may also follow the selector, or a parenthesized log query.
+// It reports whether the query unwraps a label.
+func (p *logqlParser) logRange() (bool, error) {
+	var unwrap bool
+	var err error
+	switch {
+	case p.is("("):
+		if err := p.advance(); err != nil {
+			return false, err
+		}
+		if unwrap, err = p.logQuery(true); err != nil {
+			return false, err
+		}
+		if err := p.expect(")"); err != nil {
+			return false, err
+		}
+		if err := p.rangeSelector(); err != nil {
+			return false, err
+		}
+	case p.is("{"):
+		if err := p.selector(); err != nil {
+			return false, err
+		}
+		if p.is("[") {
+			if err := p.rangeSelector(); err != nil {
+				return false, err
+			}
+			if unwrap, err = p.pipeline(true); err != nil {
+				return false, err
+			}
+		} else {
+			if unwrap, err = p.pipeline(true); err != nil {
+				return false, err
+			}
+			if err := p.rangeSelector(); err != nil {
+				return false, err
+			}
+		}
+	default:
+		return false, p.unexpected("expected a log query with a range")
+	}
+	if offset, err := p.accept("offset"); err != nil || !offset {
+		return unwrap, err
+	}
+	_, err = p.expectKind(tokDuration, "a duration")
+	return unwrap, err
+}
+
+// rangeSelector parses a range, like [5m]
+func (p *logqlParser) rangeSelector() error {
+	if err := p.expect("["); err != nil {
+		return err
+	}
+	if _, err := p.expectKind(tokDuration, "a duration"); err != nil {
+		return err
+	}
+	return p.expect("]")
+}
+
+// vectorAggregation parses a vector aggregation, like
+// sum by (app) (rate({app="api"}[5m]))
+func (p *logqlParser) vectorAggregation() error {
+	fn := p.tok
+	if err := p.advance(); err != nil {
+		return err
+	}
+	grouped := p.is("by", "without")
+	if grouped {
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if err := p.labelList(); err != nil {
+			return err
+		}
+	}
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	if vectorAggregations[fn.val] {
+		if _, err := p.expectKind(tokNumber, "a number"); err != nil {
+			return err
+		}
+		if err := p.expect(","); err != nil {
+			return err
+		}
+	}
+	arg := p.tok
+	kind, err := p.binary(0)
+	if err != nil {
+		return err
+	}
+	if kind == kindLog {
+		return p.s.errorf(arg.pos, "%s needs a metric query, not a log query; use a range aggregation like count_over_time", fn.val)
+	}
+	if err := p.expect(")"); err != nil {
+		return err
+	}
+	if !grouped && p.is("by", "without") {
+		if err := p.advance(); err != nil {
+			return err
+		}
+		return p.labelList()
+	}
+	return nil
+}
+
+// labelReplace parses label_replace(v, "dst", "replacement", "src", "regex")
+func (p *logqlParser) labelReplace() error {
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	arg := p.tok
+	kind, err := p.binary(0)
+	if err != nil {
+		return err
+	}
+	if kind == kindLog {
+		return p.s.errorf(arg.pos, "label_replace needs a metric query, not a log query")
+	}
+	for i := 0; i < 4; i++ {
+		if err := p.expect(","); err != nil {
+			return err
+		}
+		value, err := p.expectKind(tokString, "a string")
+		if err != nil {
+			return err
+		}
+		if i == 3 {
+			if err := p.checkRegexp(value); err != nil {
+				return err
+			}
+		}
+	}
+	return p.expect(")")
+}
+
+// logQuery parses a stream selector and its pipeline. It reports whether
+// the pipeline unwraps a label, only allowed in range aggregations.
+func (p *logqlParser) logQuery(allowUnwrap bool) (bool, error) {
+	if err := p.selector(); err != nil {
+		return false, err
+	}
+	return p.pipeline(allowUnwrap)
+}
+
+// selector parses a stream selector, like {app="api", env!="dev"}
+func (p *logqlParser) selector() error {
+	open := p.tok
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	selective := false
+	for p.tok.kind == tokIdent {
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if !p.is("=", "!=", "=~", "!~") {
+			return p.unexpected("expected a label matcher operator =, !=, =~ or !~")
+		}
+		op := p.tok.val
+		if err := p.advance(); err != nil {
+			return err
+		}
+		value, err := p.expectKind(tokString, "a string")
+		if err != nil {
+			return err
+		}
+		matchesEmpty := false
+		switch op {
+		case "=":
+			matchesEmpty = value.val == ""
+		case "!=":
+			matchesEmpty = value.val != ""
+		default:
+			if err := p.checkRegexp(value); err != nil {
+				return err
+			}
+			matchesEmpty = regexp.MustCompile("^(?:"+value.val+")$").MatchString("") == (op == "=~")
+		}
+		selective = selective || !matchesEmpty
+		if more, err := p.accept(","); err != nil || !more {
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+	if err := p.expect("}"); err != nil {
+		return err
+	}
+	if !selective {
+		return p.s.errorf(open.pos, `stream selectors need at least one matcher which doesn't match an empty value, like app=~".+" rather than app=~".*"`)
+	}
+	return nil
+}
+
+// pipeline parses the stages of a log query. It reports whether a stage
+// unwraps a label.
+func (p *logqlParser) pipeline(allowUnwrap bool) (bool, error) {
+	unwrap := false
+	for {
+		switch {
+		case p.is("|=", "!=", "|~", "!~", "|>", "!>"):
+			if unwrap {
+				return false, p.s.errorf(p.tok.pos, "only label filters may follow unwrap")
+			}
+			if err := p.lineFilter(); err != nil {
+				return false, err
+			}
+		case p.is("|"):
+			if err := p.advance(); err != nil {
+				return false, err
+			}
+			stage := p.tok
+			if stage.kind == tokIdent && stage.val == "unwrap" {
+				if !allowUnwrap {
+					return false, p.s.errorf(stage.pos, "unwrap is only allowed in range aggregations")
+				}
+				if unwrap {
+					return false, p.s.errorf(stage.pos, "a query unwraps a single label")
+				}
+				unwrap = true
+				if err := p.unwrap(); err != nil {
+					return false, err
+				}
+				continue
+			}
+			parsed, err := p.stage()
+			if err != nil {
+				return false, err
+			}
+			if unwrap && parsed {
+				return false, p.s.errorf(stage.pos, "only label filters may follow unwrap")
+			}
+		default:
+			return unwrap, nil
+		}
+	}
+}
+
+// lineFilter parses a line filter, like |= "error" or "timeout" or !~ "debug|trace"
+func (p *logqlParser) lineFilter() error {
+	op := p.tok.val
+	if err := p.advance(); err != nil {
+		return err
+	}
+	for {
+		if p.is("ip") {
+			if op != "|=" && op != "!=" {
+				return p.s.errorf(p.tok.pos, "ip() is only allowed with |= and !=")
+			}
+			if err := p.ip(); err != nil {
+				return err
+			}
+		} else {
+			value, err := p.expectKind(tokString, "a string")
+			if err != nil {
+				return err
+			}
+			if op == "|~" || op == "!~" {
+				if err := p.checkRegexp(value); err != nil {
+					return err
+				}
+			}
+		}
+		if more, err := p.accept("or"); err != nil || !more {
+			return err
+		}
+	}
+}
+
+// ip parses ip("192.168.0.0/16")
+func (p *logqlParser) ip() error {
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	if _, err := p.expectKind(tokString, "an address, range or CIDR"); err != nil {
+		return err
+	}
+	return p.expect(")")
+}
+
+// stage parses a stage following a pipe, a parser, a formatter or a label
+// filter. It reports whether the stage is a parser or a formatter.
+func (p *logqlParser) stage() (bool, error) {
+	if p.tok.kind != tokIdent {
+		if p.is("(") {
+			return false, p.labelFilter()
+		}
+		return false, p.unexpected("expected a parser, a formatter or a label filter")
+	}
+	stage := p.tok
+	switch stage.val {
+	case "json", "logfmt", "regexp", "pattern", "unpack", "line_format", "label_format",
+		"drop", "keep", "decolorize", "distinct":
+	default:
+		return false, p.labelFilter()
+	}
+	if err := p.advance(); err != nil {
+		return false, err
+	}
+
+	switch stage.val {
+	case "json", "logfmt":
+		for stage.val == "logfmt" && p.is("--strict", "--keep-empty") {
+			if err := p.advance(); err != nil {
+				return false, err
+			}
+		}
+		if p.tok.kind == tokIdent {
+			// Extracted labels, like | json status="response.status"
+			return true, p.extractions(stage.val == "logfmt", false)
+		}
+	case "regexp", "pattern":
+		value, err := p.expectKind(tokString, "a string")
+		if err != nil {
+			return false, err
+		}
+		if stage.val == "regexp" {
+			if err := p.checkRegexp(value); err != nil {
+				return false, err
+			}
+			named := false
+			for _, name := range regexp.MustCompile(value.val).SubexpNames() {
+				named = named || name != ""
+			}
+			if !named {
+				return false, p.s.errorf(value.pos, "regexp %q has no named capture group, like (?P<status>\\d+)", value.val)
+			}
+			return true, nil
+		}
+		named := false
+		for _, match := range patternCapture.FindAllStringSubmatch(value.val, -1) {
+			named = named || match[1] != "_"
+		}
+		if !named {
+			return false, p.s.errorf(value.pos, "pattern %q has no named capture, like <status>", value.val)
+		}
+	case "line_format":
+		if _, err := p.expectKind(tokString, "a template"); err != nil {
+			return false, err
+		}
+	case "label_format":
+		return true, p.extractions(false, true)
+	case "drop", "keep":
+		return true, p.labelMatchers()
+	case "distinct":
+		for {
+			if _, err := p.expectKind(tokIdent, "a label name"); err != nil {
+				return false, err
+			}
+			if more, err := p.accept(","); err != nil || !more {
+				return true, err
+			}
+		}
+	}
+	return true, nil
+}
+
+// extractions parses the labels of the json, logfmt and label_format stages:
+// name="expression" pairs, the expression optional for logfmt, and also a
+// label for label_format
+func (p *logqlParser) extractions(optional, labelValues bool) error {
+	for {
+		if _, err := p.expectKind(tokIdent, "a label name"); err != nil {
+			return err
+		}
+		if !p.is("=") {
+			if !optional {
+				return p.unexpected(`expected "="`)
+			}
+		} else {
+			if err := p.advance(); err != nil {
+				return err
+			}
+			if labelValues && p.tok.kind == tokIdent {
+				if err := p.advance(); err != nil {
+					return err
+				}
+			} else if _, err := p.expectKind(tokString, "a string"); err != nil {
+				return err
+			}
+		}
+		if more, err := p.accept(","); err != nil || !more {
+			return err
+		}
+	}
+}
+
+// labelMatchers parses the labels of the drop and keep stages, names or
+// matchers like level="debug"
+func (p *logqlParser) labelMatchers() error {
+	for {
+		if _, err := p.expectKind(tokIdent, "a label name"); err != nil {
+			return err
+		}
+		if p.is("=", "!=", "=~", "!~") {
+			op := p.tok.val
+			if err := p.advance(); err != nil {
+				return err
+			}
+			value, err := p.expectKind(tokString, "a string")
+			if err != nil {
+				return err
+			}
+			if op == "=~" || op == "!~" {
+				if err := p.checkRegexp(value); err != nil {
+					return err
+				}
+			}
+		}
+		if more, err := p.accept(","); err != nil || !more {
+			return err
+		}
+	}
+}
+
+// unwrap parses | unwrap latency or | unwrap duration(latency)
+func (p *logqlParser) unwrap() error {
+	if err := p.advance(); err != nil {
+		return err
+	}
+	label, err := p.expectKind(tokIdent, "a label name")
+	if err != nil {
+		return err
+	}
+	if !p.is("(") {
+		return nil
+	}
+	if !unwrapConversions[label.val] {
+		return p.s.errorf(label.pos, "unknown unwrap conversion %q, expected duration, duration_seconds or bytes", label.val)
+	}
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if _, err := p.expectKind(tokIdent, "a label name"); err != nil {
+		return err
+	}
+	return p.expect(")")
+}
+
+// labelFilter parses label filters joined with and, or and commas, like
+// status >= 500 and method="POST" or duration > 10s
+func (p *logqlParser) labelFilter() error {
+	for {
+		if err := p.labelFilterAnd(); err != nil {
+			return err
+		}
+		if more, err := p.accept("or"); err != nil || !more {
+			return err
+		}
+	}
+}
+
+// labelFilterAnd parses label filters joined with and, commas or spaces
+func (p *logqlParser) labelFilterAnd() error {
+	for {
+		if err := p.labelFilterPrimary(); err != nil {
+			return err
+		}
+		joined, err := p.accept("and", ",")
+		if err != nil {
+			return err
+		}
+		if !joined && !(p.tok.kind == tokIdent && !p.is("or", "offset")) && !p.is("(") {
+			return nil
+		}
+	}
+}
+
+// labelFilterPrimary parses a label filter, or parenthesized label filters
+func (p *logqlParser) labelFilterPrimary() error {
+	if p.is("(") {
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if err := p.labelFilter(); err != nil {
+			return err
+		}
+		return p.expect(")")
+	}
+	if _, err := p.expectKind(tokIdent, "a label name"); err != nil {
+		return err
+	}
+	if !p.is("=", "!=", "=~", "!~", "==", ">", ">=", "<", "<=") {
+		return p.unexpected("expected a label filter operator")
+	}
+	op := p.tok.val
+	if err := p.advance(); err != nil {
+		return err
+	}
+
+	switch {
+	case op == "=~" || op == "!~":
+		value, err := p.expectKind(tokString, "a regular expression")
+		if err != nil {
+			return err
+		}
+		return p.checkRegexp(value)
+	case p.tok.kind == tokString:
+		if op != "=" && op != "!=" {
+			return p.s.errorf(p.tok.pos, "operator %q needs a number, duration or byte size, not a string", op)
+		}
+		return p.advance()
+	case p.tok.kind == tokNumber || p.tok.kind == tokDuration || p.tok.kind == tokBytes:
+		return p.advance()
+	case p.is("ip"):
+		if op != "=" && op != "!=" {
+			return p.s.errorf(p.tok.pos, "ip() is only allowed with = and !=")
+		}
+		return p.ip()
+	}
+	return p.unexpected("expected a string, number, duration or byte size")
+}
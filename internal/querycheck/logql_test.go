@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package querycheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogQL(t *testing.T) {
+	valid := []string{
+		`{app="api"}`,
+		`{app="api", env!="dev", pod=~"api-.+"} |= "error" != "timeout" |~ "(?i)fail"`,
+		`{app="api"} |= "error" or "fatal"`,
+		`{app="api"} | json | status >= 500 and method="POST" or duration > 10s`,
+		`{app="api"} | json status="response.status", path="request.path" | status="500"`,
+		`{app="api"} | logfmt --strict --keep-empty | level=~"err.*"`,
+		`{app="api"} | regexp "(?P<status>\\d{3})" | pattern "<ip> - <_> <status>"`,
+		`{app="api"} | line_format "{{.status}} {{.path}}" | label_format code=status, svc="{{.app}}"`,
+		`{app="api"} | drop pod, level="debug" | keep app | decolorize`,
+		`{app="api"} | addr = ip("10.0.0.0/8") |= ip("192.168.0.1")`,
+		"{app=`api`} |~ `\\d+ms`",
+		`count_over_time({app="api"} |= "error" [5m])`,
+		`sum by (app) (rate({app="api"} |= "error" [5m])) / sum by (app) (rate({app="api"}[5m])) > 0.05`,
+		`quantile_over_time(0.99, {app="api"} | json | unwrap duration(latency) | __error__="" [5m]) by (path)`,
+		`sum_over_time({app="api"}[1h] | logfmt | unwrap bytes)`,
+		`topk(5, sum without (pod) (bytes_rate({app="api"}[1m] offset 1h)))`,
+		`count_over_time(({app="api"} |= "error")[10m])`,
+		`absent_over_time({app="api"}[5m]) == bool 1`,
+		`sum(rate({app="api"}[5m])) / on (app) group_left sum(rate({app="db"}[5m]))`,
+		`label_replace(rate({app="api"}[5m]), "service", "$1", "app", "(.*)")`,
+		`vector(0) or sum(count_over_time({app="api"}[5m]))`,
+		`-2 * 1e3 ^ 2`,
+	}
+	for _, query := range valid {
+		assert.NoError(t, LogQL(query), query)
+	}
+
+	invalid := map[string]string{
+		``:                                `1:1: parse error: empty query`,
+		`{app="api"`:                      `1:11: parse error: unexpected end of input, expected "}"`,
+		`{app=api}`:                       `1:6: parse error: unexpected identifier "api", expected a string`,
+		`{app=~".*"}`:                     `1:1: parse error: stream selectors need at least one matcher`,
+		`{}`:                              `1:1: parse error: stream selectors need at least one matcher`,
+		`{app="api"} |~ "(unclosed"`:      `1:16: parse error: invalid regular expression`,
+		`{app="api"} | status >= "500"`:   `1:25: parse error: operator ">=" needs a number`,
+		`{app="api"} | regexp "\\d+"`:     `1:22: parse error: regexp "\\d+" has no named capture group`,
+		`{app="api"} | pattern "<_> <_>"`: `1:23: parse error: pattern "<_> <_>" has no named capture`,
+		`{app="api"} | unwrap latency`:    `1:15: parse error: unwrap is only allowed in range aggregations`,
+		`rate({app="api"})`:               `1:17: parse error: unexpected ")", expected "["`,
+		`rate({app="api"}[5])`:            `1:18: parse error: unexpected "5", expected a duration`,
+		`sum_over_time({app="api"}[5m])`:  `1:1: parse error: sum_over_time needs an unwrapped label`,
+		`count_over_time({app="api"} | unwrap bytes [5m])`: `1:1: parse error: count_over_time is not allowed with unwrap`,
+		`count_over_time({app="api"}[5m]) by (pod)`:        `1:34: parse error: grouping is not allowed for count_over_time`,
+		`sum({app="api"})`:                   `1:5: parse error: sum needs a metric query, not a log query`,
+		`{app="api"} / 2`:                    `1:13: parse error: operator "/" needs metric queries`,
+		`sum(rate({app="api"}[5m]) by (app)`: `1:35: parse error: unexpected end of input, expected ")"`,
+		`foo({app="api"})`:                   `1:1: parse error: unknown function "foo"`,
+	}
+	for query, message := range invalid {
+		err := LogQL(query)
+		require.Error(t, err, query)
+		assert.Contains(t, err.Error(), message, query)
+	}
+
+	err := LogQL("rate({app=\"api\"}\n  [5m]) > bool")
+	require.Error(t, err)
+	assert.Equal(t, `2:15: parse error: unexpected end of input, expected a stream selector, a function or a number`, err.Error())
+}
+
+func TestLogQLMetric(t *testing.T) {
+	assert.NoError(t, LogQLMetric(`sum(count_over_time({app="api"} |= "error" [5m])) > 10`))
+
+	err := LogQLMetric(`{app="api"} |= "error"`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected a metric query")
+
+	assert.Error(t, LogQLMetric(`sum(`))
+}
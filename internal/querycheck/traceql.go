@@ -0,0 +1,761 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package querycheck
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// traceqlOps are the TraceQL operators, longest first
+var traceqlOps = []string{
+	"!>>", "!<<", "&>>", "&<<",
+	">>", "<<", "&&", "||", "!=", "!~", "!>", "!<", "=~", ">=", "<=", "&>", "&<", "&~",
+	"=", ">", "<", "~", "!", "{", "}", "(", ")", ",", "|",
+	"+", "-", "*", "/", "%", "^",
+}
+
+// spansetOps are the operators combining spansets, logical and structural
+var spansetOps = []string{
+	"&&", "||", ">>", ">", "<<", "<", "~",
+	"!>>", "!>", "!<<", "!<", "!~", "&>>", "&>", "&<<", "&<", "&~",
+}
+
+// fieldComparisons are the comparison operators of field expressions
+var fieldComparisons = []string{"=", "!=", ">", ">=", "<", "<=", "=~", "!~"}
+
+// fieldPrecedence are the operators of field expressions, lowest precedence
+// first
+var fieldPrecedence = [][]string{
+	{"||"},
+	{"&&"},
+	fieldComparisons,
+	{"+", "-"},
+	{"*", "/", "%"},
+	{"^"},
+}
+
+// staticType is the type of a field expression. The type of attributes is
+// only known once spans are read.
+type staticType int
+
+const (
+	typeAttribute staticType = iota
+	typeString
+	typeNumber
+	typeDuration
+	typeBoolean
+	typeStatus
+	typeKind
+	typeNil
+)
+
+// String names a type in errors
+func (t staticType) String() string {
+	return [...]string{"attribute", "string", "number", "duration", "boolean", "status", "kind", "nil"}[t]
+}
+
+// article names a type with its article
+func (t staticType) article() string {
+	if t == typeAttribute {
+		return "an attribute"
+	}
+	return "a " + t.String()
+}
+
+// numeric reports whether arithmetic is allowed on a type
+func (t staticType) numeric() bool {
+	return t == typeAttribute || t == typeNumber || t == typeDuration
+}
+
+// boolean reports whether a type can be a condition
+func (t staticType) boolean() bool {
+	return t == typeAttribute || t == typeBoolean
+}
+
+// comparable reports whether values of two types can be compared, as Tempo
+// checks them: numbers and durations compare to each other, and attributes
+// and nil to anything
+func (t staticType) comparable(other staticType) bool {
+	switch {
+	case t == other, t == typeAttribute, other == typeAttribute, t == typeNil, other == typeNil:
+		return true
+	}
+	return t.numeric() && other.numeric()
+}
+
+// attributeScopes are the scopes of attributes, like span.http.method
+var attributeScopes = map[string]bool{
+	"span": true, "resource": true, "event": true, "link": true, "instrumentation": true, "parent": true,
+}
+
+// intrinsics are the types of the intrinsics, unscoped or scoped like
+// span:duration
+var intrinsics = map[string]staticType{
+	"name": typeString, "status": typeStatus, "statusMessage": typeString, "duration": typeDuration,
+	"kind": typeKind, "rootName": typeString, "rootServiceName": typeString, "traceDuration": typeDuration,
+	"childCount": typeNumber, "nestedSetLeft": typeNumber, "nestedSetRight": typeNumber,
+	"nestedSetParent": typeNumber, "parent": typeAttribute,
+
+	"span:name": typeString, "span:status": typeStatus, "span:statusMessage": typeString,
+	"span:duration": typeDuration, "span:kind": typeKind, "span:id": typeString,
+	"span:parentID": typeString, "span:childCount": typeNumber,
+	"trace:duration": typeDuration, "trace:rootName": typeString, "trace:rootService": typeString,
+	"trace:id":   typeString,
+	"event:name": typeString, "event:timeSinceStart": typeDuration,
+	"link:spanID": typeString, "link:traceID": typeString,
+	"instrumentation:name": typeString, "instrumentation:version": typeString,
+}
+
+// keywords are the types of the static values of field expressions:
+// booleans, nil, statuses and kinds
+var keywords = map[string]staticType{
+	"true": typeBoolean, "false": typeBoolean, "nil": typeNil,
+	"ok": typeStatus, "error": typeStatus, "unset": typeStatus,
+	"unspecified": typeKind, "internal": typeKind, "server": typeKind, "client": typeKind,
+	"producer": typeKind, "consumer": typeKind,
+}
+
+// scalarAggregates are the aggregates of scalar filters, like count() > 2,
+// with whether they take a field
+var scalarAggregates = map[string]bool{"count": false, "avg": true, "min": true, "max": true, "sum": true}
+
+// metricsFunctions are the metrics functions, with whether they take a
+// field
+var metricsFunctions = map[string]bool{
+	"rate": false, "count_over_time": false,
+	"min_over_time": true, "max_over_time": true, "avg_over_time": true, "sum_over_time": true,
+	"quantile_over_time": true, "histogram_over_time": true, "compare": false,
+}
+
+// TraceQL checks a TraceQL query, like
+// { resource.service.name = "api" && duration > 1s } | count() > 2.
+// Besides the syntax, the types of the intrinsics and values are checked,
+// as Tempo checks them before running a query.
+func TraceQL(query string) error {
+	_, err := parseTraceQL(query)
+	return err
+}
+
+func parseTraceQL(query string) (*traceqlParser, error) {
+	p := &traceqlParser{parser: parser{s: &scanner{input: query, ops: traceqlOps, ident: traceqlIdent}}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokEOF {
+		return nil, p.s.errorf(0, "empty query")
+	}
+	if err := p.pipeline(); err != nil {
+		return nil, err
+	}
+	if p.is("with") {
+		if err := p.hints(); err != nil {
+			return nil, err
+		}
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.unexpected("expected end of query")
+	}
+	return p, nil
+}
+
+// traceqlParser is a recursive descent parser of TraceQL
+type traceqlParser struct {
+	parser
+	// metrics is the first metrics function of the query
+	metrics *token
+}
+
+// traceqlIdent reads an identifier: a keyword, an intrinsic like duration or
+// span:duration, or an attribute like .http.method or resource.service.name.
+// Attribute names run until a space or an operator, as in Tempo, and may be
+// quoted, like span."http method".
+func traceqlIdent(s *scanner) int {
+	rest := s.input[s.pos:]
+	n := 0
+	if !strings.HasPrefix(rest, ".") {
+		for n < len(rest) {
+			r, size := utf8.DecodeRuneInString(rest[n:])
+			if n == 0 && !isIdentStart(r) || n > 0 && !isIdentRune(r) {
+				break
+			}
+			n += size
+		}
+		if n == 0 {
+			return 0
+		}
+		if n < len(rest) && rest[n] == ':' {
+			n++
+			for n < len(rest) {
+				r, size := utf8.DecodeRuneInString(rest[n:])
+				if !isIdentRune(r) {
+					break
+				}
+				n += size
+			}
+			return n
+		}
+		if n == len(rest) || rest[n] != '.' {
+			return n
+		}
+	}
+
+	// The attribute name, after the dot
+	n++
+	if n < len(rest) && rest[n] == '"' {
+		for i := n + 1; i < len(rest); i++ {
+			switch rest[i] {
+			case '\\':
+				i++
+			case '"':
+				return i + 1
+			}
+		}
+		return len(rest)
+	}
+	for n < len(rest) {
+		r, size := utf8.DecodeRuneInString(rest[n:])
+		if unicode.IsSpace(r) || strings.ContainsRune("{}()=~!<>&|^,", r) {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
+// pipeline parses spansets and the stages following them
+func (p *traceqlParser) pipeline() error {
+	if err := p.spansetExpr(); err != nil {
+		return err
+	}
+	metrics := false
+	for p.is("|") {
+		if err := p.advance(); err != nil {
+			return err
+		}
+		stage := p.tok
+		if metrics && !p.is("topk", "bottomk") {
+			return p.s.errorf(stage.pos, "only topk and bottomk may follow a metrics function")
+		}
+		if !metrics && p.is("topk", "bottomk") {
+			return p.s.invalidf(stage.pos, "%s needs a metrics function, like rate(), before it", stage.val)
+		}
+		isMetrics, err := p.stage()
+		if err != nil {
+			return err
+		}
+		if isMetrics && p.metrics == nil {
+			p.metrics = &stage
+		}
+		metrics = metrics || isMetrics
+	}
+	return nil
+}
+
+// spansetExpr parses spansets combined with logical and structural operators
+func (p *traceqlParser) spansetExpr() error {
+	for {
+		if err := p.spansetPrimary(); err != nil {
+			return err
+		}
+		if !p.is(spansetOps...) {
+			return nil
+		}
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+}
+
+// spansetPrimary parses a spanset filter, like { duration > 1s }, or a
+// parenthesized pipeline
+func (p *traceqlParser) spansetPrimary() error {
+	switch {
+	case p.is("("):
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if err := p.pipeline(); err != nil {
+			return err
+		}
+		return p.expect(")")
+	case p.is("{"):
+		return p.spansetFilter()
+	}
+	return p.unexpected(`expected a spanset filter, like { span.http.status_code >= 500 }`)
+}
+
+// spansetFilter parses { field expression }, the expression optional and
+// otherwise a condition
+func (p *traceqlParser) spansetFilter() error {
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	if !p.is("}") {
+		start := p.tok
+		t, err := p.fieldExpr()
+		if err != nil {
+			return err
+		}
+		if !t.boolean() {
+			return p.s.invalidf(start.pos, "spanset filters need a condition, not %s", t.article())
+		}
+	}
+	return p.expect("}")
+}
+
+// stage parses a pipeline stage: a spanset filter, a scalar filter, by(),
+// select(), coalesce(), a metrics function, topk() or bottomk(). It reports
+// whether the stage is a metrics function.
+func (p *traceqlParser) stage() (bool, error) {
+	switch {
+	case p.is("{", "("):
+		return false, p.spansetExpr()
+	case p.tok.kind == tokIdent:
+	default:
+		return false, p.scalarFilter()
+	}
+
+	fn := p.tok
+	if _, ok := metricsFunctions[fn.val]; ok {
+		return true, p.metricsFunction()
+	}
+	switch fn.val {
+	case "by", "select":
+		if err := p.advance(); err != nil {
+			return false, err
+		}
+		return false, p.fieldList()
+	case "coalesce":
+		if err := p.advance(); err != nil {
+			return false, err
+		}
+		if err := p.expect("("); err != nil {
+			return false, err
+		}
+		return false, p.expect(")")
+	case "topk", "bottomk":
+		if err := p.advance(); err != nil {
+			return false, err
+		}
+		if err := p.expect("("); err != nil {
+			return false, err
+		}
+		if err := p.positiveInteger(); err != nil {
+			return false, err
+		}
+		return false, p.expect(")")
+	}
+	return false, p.scalarFilter()
+}
+
+// fieldList parses the parenthesized fields of by() and select(), which
+// must be attributes or intrinsics
+func (p *traceqlParser) fieldList() error {
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for {
+		start := p.tok
+		if _, err := p.fieldExpr(); err != nil {
+			return err
+		}
+		if p.prev.pos != start.pos || start.kind != tokIdent || keywordType(start.val) {
+			return p.s.invalidf(start.pos, "expected an attribute or an intrinsic, like resource.service.name")
+		}
+		if more, err := p.accept(","); err != nil || !more {
+			if err != nil {
+				return err
+			}
+			return p.expect(")")
+		}
+	}
+}
+
+// keywordType reports whether an identifier is a static value, like true or
+// error
+func keywordType(name string) bool {
+	_, ok := keywords[name]
+	return ok
+}
+
+// positiveInteger parses an integer greater than zero
+func (p *traceqlParser) positiveInteger() error {
+	tok, err := p.expectKind(tokNumber, "a number")
+	if err != nil {
+		return err
+	}
+	if n, err := strconv.Atoi(tok.val); err != nil || n <= 0 {
+		return p.s.invalidf(tok.pos, "expected a positive integer, not %s", tok.val)
+	}
+	return nil
+}
+
+// metricsFunction parses a metrics function, like
+// quantile_over_time(duration, .99) by (resource.service.name)
+func (p *traceqlParser) metricsFunction() error {
+	fn := p.tok
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	switch {
+	case fn.val == "compare":
+		if err := p.spansetFilter(); err != nil {
+			return err
+		}
+		if p.is(",") {
+			if err := p.advance(); err != nil {
+				return err
+			}
+			if err := p.positiveInteger(); err != nil {
+				return err
+			}
+		}
+		for p.is(",") {
+			if err := p.advance(); err != nil {
+				return err
+			}
+			if _, err := p.expectKind(tokNumber, "a timestamp"); err != nil {
+				return err
+			}
+		}
+	case metricsFunctions[fn.val]:
+		if err := p.numericArgument(fn); err != nil {
+			return err
+		}
+		quantiles := 0
+		for fn.val == "quantile_over_time" && p.is(",") {
+			if err := p.advance(); err != nil {
+				return err
+			}
+			if err := p.quantile(); err != nil {
+				return err
+			}
+			quantiles++
+		}
+		if fn.val == "quantile_over_time" && quantiles == 0 {
+			return p.s.invalidf(fn.pos, "quantile_over_time needs quantiles, like quantile_over_time(duration, .5, .99)")
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return err
+	}
+	if by, err := p.accept("by"); err != nil || !by {
+		return err
+	}
+	return p.fieldList()
+}
+
+// numericArgument parses the field of an aggregate or a metrics function,
+// which must be numeric
+func (p *traceqlParser) numericArgument(fn token) error {
+	if p.is(")") {
+		return p.s.invalidf(fn.pos, "%s needs a field, like %s(duration)", fn.val, fn.val)
+	}
+	start := p.tok
+	t, err := p.fieldExpr()
+	if err != nil {
+		return err
+	}
+	if !t.numeric() {
+		return p.s.invalidf(start.pos, "%s needs a numeric field, not %s", fn.val, t.article())
+	}
+	return nil
+}
+
+// quantile parses a quantile, between 0 and 1
+func (p *traceqlParser) quantile() error {
+	tok, err := p.expectKind(tokNumber, "a quantile")
+	if err != nil {
+		return err
+	}
+	if q, err := strconv.ParseFloat(tok.val, 64); err != nil || q < 0 || q > 1 {
+		return p.s.invalidf(tok.pos, "quantile %s is not between 0 and 1", tok.val)
+	}
+	return nil
+}
+
+// scalarFilter parses a comparison of scalars, like count() > 2 or
+// avg(duration) > 1s
+func (p *traceqlParser) scalarFilter() error {
+	if err := p.scalarExpr(); err != nil {
+		return err
+	}
+	if !p.is("=", "!=", ">", ">=", "<", "<=") {
+		return p.unexpected("expected a comparison of a scalar filter, like count() > 2")
+	}
+	if err := p.advance(); err != nil {
+		return err
+	}
+	return p.scalarExpr()
+}
+
+// scalarExpr parses aggregates and numbers combined with arithmetic
+func (p *traceqlParser) scalarExpr() error {
+	for {
+		if err := p.scalarPrimary(); err != nil {
+			return err
+		}
+		if !p.is("+", "-", "*", "/", "%", "^") {
+			return nil
+		}
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+}
+
+// scalarPrimary parses an aggregate, a number, a duration or a
+// parenthesized scalar expression
+func (p *traceqlParser) scalarPrimary() error {
+	switch {
+	case p.tok.kind == tokNumber || p.tok.kind == tokDuration:
+		return p.advance()
+	case p.is("-"):
+		if err := p.advance(); err != nil {
+			return err
+		}
+		return p.scalarPrimary()
+	case p.is("("):
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if err := p.scalarExpr(); err != nil {
+			return err
+		}
+		return p.expect(")")
+	case p.tok.kind == tokIdent:
+		takesField, ok := scalarAggregates[p.tok.val]
+		if !ok {
+			break
+		}
+		fn := p.tok
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if err := p.expect("("); err != nil {
+			return err
+		}
+		if !takesField {
+			if !p.is(")") {
+				return p.s.invalidf(p.tok.pos, "%s() takes no field", fn.val)
+			}
+		} else if err := p.numericArgument(fn); err != nil {
+			return err
+		}
+		return p.expect(")")
+	}
+	return p.unexpected("expected an aggregate like count() or avg(duration), or a number")
+}
+
+// fieldExpr parses a field expression, attributes, intrinsics and static
+// values combined with logical, comparison and arithmetic operators, and
+// returns its type
+func (p *traceqlParser) fieldExpr() (staticType, error) {
+	return p.fieldBinary(0)
+}
+
+// fieldBinary parses the binary operations of a precedence level and above
+func (p *traceqlParser) fieldBinary(level int) (staticType, error) {
+	if level == len(fieldPrecedence) {
+		return p.fieldUnary()
+	}
+	left, err := p.fieldBinary(level + 1)
+	if err != nil {
+		return 0, err
+	}
+	for p.is(fieldPrecedence[level]...) {
+		op := p.tok
+		if err := p.advance(); err != nil {
+			return 0, err
+		}
+		if op.val == "=~" || op.val == "!~" {
+			value, err := p.expectKind(tokString, "a regular expression")
+			if err != nil {
+				return 0, err
+			}
+			if err := p.checkRegexp(value); err != nil {
+				return 0, err
+			}
+			if left != typeAttribute && left != typeString {
+				return 0, p.s.invalidf(op.pos, "operator %q needs a string, not %s", op.val, left.article())
+			}
+			left = typeBoolean
+			continue
+		}
+		right, err := p.fieldBinary(level + 1)
+		if err != nil {
+			return 0, err
+		}
+		if left, err = p.binaryType(op, left, right); err != nil {
+			return 0, err
+		}
+	}
+	return left, nil
+}
+
+// binaryType checks the types of the operands of a binary operator and
+// returns the type of the operation
+func (p *traceqlParser) binaryType(op token, left, right staticType) (staticType, error) {
+	switch op.val {
+	case "&&", "||":
+		if !left.boolean() || !right.boolean() {
+			return 0, p.s.invalidf(op.pos, "operator %q needs conditions, not %s and %s", op.val, left.article(), right.article())
+		}
+		return typeBoolean, nil
+	case "=", "!=":
+		if !left.comparable(right) {
+			return 0, p.s.invalidf(op.pos, "cannot compare %s with %s", left.article(), right.article())
+		}
+		return typeBoolean, nil
+	case ">", ">=", "<", "<=":
+		if !left.comparable(right) {
+			return 0, p.s.invalidf(op.pos, "cannot compare %s with %s", left.article(), right.article())
+		}
+		for _, t := range []staticType{left, right} {
+			if t == typeBoolean || t == typeStatus || t == typeKind || t == typeNil {
+				return 0, p.s.invalidf(op.pos, "operator %q is not allowed on %s", op.val, t.article())
+			}
+		}
+		return typeBoolean, nil
+	}
+
+	// Arithmetic
+	if !left.numeric() || !right.numeric() {
+		return 0, p.s.invalidf(op.pos, "operator %q needs numbers, not %s and %s", op.val, left.article(), right.article())
+	}
+	if left == typeDuration || right == typeDuration {
+		return typeDuration, nil
+	}
+	if left == typeNumber && right == typeNumber {
+		return typeNumber, nil
+	}
+	return typeAttribute, nil
+}
+
+// fieldUnary parses a negated field expression
+func (p *traceqlParser) fieldUnary() (staticType, error) {
+	if !p.is("!", "-") {
+		return p.fieldPrimary()
+	}
+	op := p.tok
+	if err := p.advance(); err != nil {
+		return 0, err
+	}
+	t, err := p.fieldUnary()
+	if err != nil {
+		return 0, err
+	}
+	if op.val == "!" && !t.boolean() {
+		return 0, p.s.invalidf(op.pos, "operator \"!\" needs a condition, not %s", t.article())
+	}
+	if op.val == "-" && !t.numeric() {
+		return 0, p.s.invalidf(op.pos, "operator \"-\" needs a number, not %s", t.article())
+	}
+	return t, nil
+}
+
+// fieldPrimary parses an attribute, an intrinsic, a static value or a
+// parenthesized field expression
+func (p *traceqlParser) fieldPrimary() (staticType, error) {
+	switch p.tok.kind {
+	case tokString:
+		return typeString, p.advance()
+	case tokNumber:
+		return typeNumber, p.advance()
+	case tokDuration:
+		return typeDuration, p.advance()
+	case tokIdent:
+		t, err := p.fieldType(p.tok)
+		if err != nil {
+			return 0, err
+		}
+		return t, p.advance()
+	}
+	if p.is("(") {
+		if err := p.advance(); err != nil {
+			return 0, err
+		}
+		t, err := p.fieldExpr()
+		if err != nil {
+			return 0, err
+		}
+		return t, p.expect(")")
+	}
+	return 0, p.unexpected("expected an attribute, an intrinsic or a value")
+}
+
+// fieldType returns the type of an attribute, an intrinsic or a static
+// value, checking the scope of attributes and the names of intrinsics
+func (p *traceqlParser) fieldType(tok token) (staticType, error) {
+	name := tok.val
+	if strings.HasPrefix(name, ".") {
+		if len(name) == 1 {
+			return 0, p.s.errorf(tok.pos, "missing attribute name after %q", name)
+		}
+		return typeAttribute, nil
+	}
+	if scope, _, ok := strings.Cut(name, ":"); ok {
+		t, known := intrinsics[name]
+		switch {
+		case known:
+			return t, nil
+		case scope != "span" && scope != "trace" && scope != "event" && scope != "link" && scope != "instrumentation":
+			return 0, p.s.errorf(tok.pos, "unknown intrinsic scope %q, expected span, trace, event, link or instrumentation", scope)
+		}
+		return 0, p.s.errorf(tok.pos, "unknown intrinsic %q", name)
+	}
+	if scope, attribute, ok := strings.Cut(name, "."); ok {
+		if !attributeScopes[scope] {
+			return 0, p.s.errorf(tok.pos, "unknown attribute scope %q, expected span, resource, event, link or instrumentation, or a leading dot like .%s", scope, name)
+		}
+		if attribute == "" {
+			return 0, p.s.errorf(tok.pos, "missing attribute name after %q", name)
+		}
+		return typeAttribute, nil
+	}
+	if t, ok := intrinsics[name]; ok {
+		return t, nil
+	}
+	if t, ok := keywords[name]; ok {
+		return t, nil
+	}
+	return 0, p.s.errorf(tok.pos, "unknown intrinsic %q, attributes need a scope or a leading dot, like .%s or span.%s", name, name, name)
+}
+
+// hints parses the query hints, like with (most_recent=true)
+func (p *traceqlParser) hints() error {
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for {
+		if _, err := p.expectKind(tokIdent, "a hint name"); err != nil {
+			return err
+		}
+		if err := p.expect("="); err != nil {
+			return err
+		}
+		if _, err := p.fieldPrimary(); err != nil {
+			return err
+		}
+		if more, err := p.accept(","); err != nil || !more {
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return p.expect(")")
+}
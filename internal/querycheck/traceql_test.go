@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package querycheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceQL(t *testing.T) {
+	valid := []string{
+		`{}`,
+		`{ resource.service.name = "api" && duration > 1s }`,
+		`{ .http.status_code >= 500 || status = error } | count() > 2`,
+		`{ span.http.method =~ "GET|POST" && kind = server } | avg(duration) > 200ms`,
+		`{ span:name = "checkout" } >> { span:status = error }`,
+		`({ .db.system = "postgresql" } | count() > 3) && { trace:rootService = "api" }`,
+		`{ span."http method" = "GET" } | select(span.http.url, resource.k8s.pod.name)`,
+		`{ } | by(resource.service.name) | max(duration) > 2 * 1s`,
+		`{ !(.cache.hit = true) && .retries - 1 > 0 }`,
+		`{ status = error } | rate() by (resource.service.name)`,
+		`{ } | quantile_over_time(duration, .5, .99) by (span.http.route) | topk(5)`,
+		`{ } | compare({ status = error }, 10)`,
+		`{ .foo != nil } with (most_recent=true)`,
+	}
+	for _, query := range valid {
+		assert.NoError(t, TraceQL(query), query)
+	}
+
+	invalid := map[string]string{
+		``:                                        `1:1: parse error: empty query`,
+		`{ duration > 1s`:                         `1:16: parse error: unexpected end of input, expected "}"`,
+		`{ service.name = "api" }`:                `1:3: parse error: unknown attribute scope "service"`,
+		`{ http_status = 500 }`:                   `1:3: parse error: unknown intrinsic "http_status"`,
+		`{ foo:name = "x" }`:                      `1:3: parse error: unknown intrinsic scope "foo"`,
+		`{ .path =~ "(unclosed" }`:                `1:12: parse error: invalid regular expression`,
+		`{ .path =~ 5 }`:                          `1:12: parse error: unexpected "5", expected a regular expression`,
+		`{ } | count()`:                           `1:14: parse error: unexpected end of input, expected a comparison of a scalar filter`,
+		`{ } | rate() | count() > 1`:              `1:16: parse error: only topk and bottomk may follow a metrics function`,
+		`duration > 1s`:                           `1:1: parse error: unexpected identifier "duration", expected a spanset filter`,
+		`{ .a = "x" } { .b = "y" }`:               `1:14: parse error: unexpected "{", expected end of query`,
+		`{ .status = "unterminated }`:             `1:13: parse error: unterminated string`,
+		`{ } | quantile_over_time(duration, p99)`: `1:36: parse error: unexpected identifier "p99", expected a quantile`,
+	}
+	for query, message := range invalid {
+		err := TraceQL(query)
+		require.Error(t, err, query)
+		assert.Contains(t, err.Error(), message, query)
+	}
+}
+
+func TestTraceQLTypes(t *testing.T) {
+	valid := []string{
+		`{ duration > 100 && span:duration < 2s }`,
+		`{ status = error && kind != client && name > "a" }`,
+		`{ .http.status_code / 100 = 5 }`,
+		`{ .foo = nil || .bar != nil }`,
+		`{ trace:duration - duration > 1s }`,
+		`{ } | avg(span.db.rows) > 10`,
+		`{ } | histogram_over_time(duration) by (span:kind)`,
+	}
+	for _, query := range valid {
+		assert.NoError(t, TraceQL(query), query)
+	}
+
+	invalid := map[string]string{
+		`{ status = 200 }`:                       `1:10: invalid query: cannot compare a status with a number`,
+		`{ duration = "1s" }`:                    `1:12: invalid query: cannot compare a duration with a string`,
+		`{ status > ok }`:                        `1:10: invalid query: operator ">" is not allowed on a status`,
+		`{ duration =~ "1.*" }`:                  `1:12: invalid query: operator "=~" needs a string, not a duration`,
+		`{ name + 1 > 2 }`:                       `1:8: invalid query: operator "+" needs numbers, not a string and a number`,
+		`{ duration && .ok }`:                    `1:12: invalid query: operator "&&" needs conditions, not a duration and an attribute`,
+		`{ duration }`:                           `1:3: invalid query: spanset filters need a condition, not a duration`,
+		`{ !name }`:                              `1:3: invalid query: operator "!" needs a condition, not a string`,
+		`{ span:foo = 1 }`:                       `1:3: parse error: unknown intrinsic "span:foo"`,
+		`{ } | count(duration) > 1`:              `1:13: invalid query: count() takes no field`,
+		`{ } | avg() > 1s`:                       `1:7: invalid query: avg needs a field, like avg(duration)`,
+		`{ } | sum(name) > 1`:                    `1:11: invalid query: sum needs a numeric field, not a string`,
+		`{ } | quantile_over_time(duration)`:     `1:7: invalid query: quantile_over_time needs quantiles`,
+		`{ } | quantile_over_time(duration, 99)`: `1:36: invalid query: quantile 99 is not between 0 and 1`,
+		`{ } | rate() | topk(0)`:                 `1:21: invalid query: expected a positive integer, not 0`,
+		`{ } | topk(5)`:                          `1:7: invalid query: topk needs a metrics function`,
+		`{ } | rate() by (duration > 1s)`:        `1:18: invalid query: expected an attribute or an intrinsic`,
+		`{ } | select(true)`:                     `1:14: invalid query: expected an attribute or an intrinsic`,
+	}
+	for query, message := range invalid {
+		err := TraceQL(query)
+		require.Error(t, err, query)
+		assert.Contains(t, err.Error(), message, query)
+	}
+}
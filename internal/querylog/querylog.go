@@ -0,0 +1,177 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package querylog parses the Prometheus query log and summarizes slow and
+// expensive queries into a top-N report.
+package querylog
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LogFile is the query log destination. Logging to stdout lets the operator
+// read the query log through the pod logs API without a sidecar.
+const LogFile = "/dev/stdout"
+
+// maxLineSize bounds the size of a single log line; larger lines are skipped
+const maxLineSize = 1024 * 1024
+
+// Entry is a query log entry as written by Prometheus
+type Entry struct {
+	Params    Params    `json:"params"`
+	Stats     Stats     `json:"stats"`
+	Timestamp time.Time `json:"ts"`
+}
+
+// Params are the parameters of a logged query
+type Params struct {
+	Query string  `json:"query"`
+	Start string  `json:"start,omitempty"`
+	End   string  `json:"end,omitempty"`
+	Step  float64 `json:"step,omitempty"`
+}
+
+// Stats are the execution statistics of a logged query
+type Stats struct {
+	Timings Timings `json:"timings"`
+	Samples Samples `json:"samples"`
+}
+
+// Timings are the execution times of a logged query in seconds
+type Timings struct {
+	EvalTotalTime float64 `json:"evalTotalTime"`
+	ExecQueueTime float64 `json:"execQueueTime"`
+	ExecTotalTime float64 `json:"execTotalTime"`
+}
+
+// Samples are the sample counts of a logged query
+type Samples struct {
+	TotalQueryableSamples int64 `json:"totalQueryableSamples"`
+	PeakSamples           int64 `json:"peakSamples"`
+}
+
+// Duration returns the total execution time of the query
+func (e Entry) Duration() time.Duration {
+	return time.Duration(e.Stats.Timings.ExecTotalTime * float64(time.Second))
+}
+
+// Parse reads query log entries from a log stream. Lines that are not query
+// log entries, such as the regular Prometheus log when the query log is
+// written to stdout, are skipped.
+func Parse(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil || entry.Params.Query == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// QueryStats are the aggregated statistics of one query
+type QueryStats struct {
+	Query string `json:"query"`
+	// Count is the number of executions
+	Count int `json:"count"`
+	// SlowCount is the number of executions above the slow query threshold
+	SlowCount int `json:"slowCount"`
+	// TotalSeconds is the summed execution time
+	TotalSeconds float64 `json:"totalSeconds"`
+	// MaxSeconds is the longest execution time
+	MaxSeconds float64 `json:"maxSeconds"`
+	// PeakSamples is the highest number of samples held in memory at once
+	PeakSamples int64 `json:"peakSamples"`
+	// QueryableSamples is the summed number of samples loaded
+	QueryableSamples int64 `json:"queryableSamples"`
+}
+
+// Report summarizes the queries of an analysis window
+type Report struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+	// Since is the start of the analysis window
+	Since time.Time `json:"since"`
+	// SlowQueryThreshold is the execution time above which queries are slow
+	SlowQueryThreshold string `json:"slowQueryThreshold"`
+	// TotalQueries is the number of executions in the window
+	TotalQueries int `json:"totalQueries"`
+	// SlowQueries is the number of executions above the threshold
+	SlowQueries int `json:"slowQueries"`
+	// Top are the most expensive queries by total execution time
+	Top []QueryStats `json:"top"`
+}
+
+// Summarize aggregates entries by query and returns the topN queries by total
+// execution time. Queries that differ only in whitespace are aggregated.
+func Summarize(entries []Entry, threshold time.Duration, topN int, since, now time.Time) Report {
+	report := Report{
+		GeneratedAt:        now,
+		Since:              since,
+		SlowQueryThreshold: threshold.String(),
+	}
+
+	byQuery := map[string]*QueryStats{}
+	for _, entry := range entries {
+		query := normalize(entry.Params.Query)
+		stats, ok := byQuery[query]
+		if !ok {
+			stats = &QueryStats{Query: query}
+			byQuery[query] = stats
+		}
+
+		seconds := entry.Stats.Timings.ExecTotalTime
+		stats.Count++
+		stats.TotalSeconds += seconds
+		if seconds > stats.MaxSeconds {
+			stats.MaxSeconds = seconds
+		}
+		if entry.Stats.Samples.PeakSamples > stats.PeakSamples {
+			stats.PeakSamples = entry.Stats.Samples.PeakSamples
+		}
+		stats.QueryableSamples += entry.Stats.Samples.TotalQueryableSamples
+
+		report.TotalQueries++
+		if entry.Duration() > threshold {
+			stats.SlowCount++
+			report.SlowQueries++
+		}
+	}
+
+	top := make([]QueryStats, 0, len(byQuery))
+	for _, stats := range byQuery {
+		top = append(top, *stats)
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].TotalSeconds != top[j].TotalSeconds {
+			return top[i].TotalSeconds > top[j].TotalSeconds
+		}
+		return top[i].Query < top[j].Query
+	})
+	if topN > 0 && len(top) > topN {
+		top = top[:topN]
+	}
+	report.Top = top
+
+	return report
+}
+
+// normalize collapses whitespace in a query
+func normalize(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package querylog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const logs = `ts=2025-01-10T10:00:00.000Z caller=main.go:1 level=info msg="Server is ready to receive web requests."
+{"httpRequest":{"clientIP":"10.0.0.1","method":"GET","path":"/api/v1/query_range"},"params":{"end":"2025-01-10T10:00:00.000Z","query":"sum(rate(http_requests_total[5m])) by (job)","start":"2025-01-10T09:00:00.000Z","step":15},"stats":{"timings":{"evalTotalTime":2.5,"resultSortTime":0,"queryPreparationTime":0.1,"innerEvalTime":2.3,"execQueueTime":0.01,"execTotalTime":2.6},"samples":{"totalQueryableSamples":120000,"peakSamples":4000}},"ts":"2025-01-10T10:00:01.000Z"}
+{"params":{"query":"up"},"stats":{"timings":{"execTotalTime":0.002},"samples":{"totalQueryableSamples":10,"peakSamples":10}},"ts":"2025-01-10T10:00:02.000Z"}
+{"params":{"query":"sum(rate(http_requests_total[5m]))   by (job)"},"stats":{"timings":{"execTotalTime":0.4},"samples":{"totalQueryableSamples":80000,"peakSamples":6000}},"ts":"2025-01-10T10:00:03.000Z"}
+{"level":"info","msg":"not a query"}
+{broken json
+{"params":{"query":"up"},"stats":{"timings":{"execTotalTime":0.003},"samples":{"totalQueryableSamples":10,"peakSamples":10}},"ts":"2025-01-10T10:00:04.000Z"}
+`
+
+func TestParse(t *testing.T) {
+	entries, err := Parse(strings.NewReader(logs))
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	assert.Equal(t, "sum(rate(http_requests_total[5m])) by (job)", entries[0].Params.Query)
+	assert.Equal(t, 2600*time.Millisecond, entries[0].Duration())
+	assert.Equal(t, int64(4000), entries[0].Stats.Samples.PeakSamples)
+	assert.Equal(t, time.Date(2025, 1, 10, 10, 0, 1, 0, time.UTC), entries[0].Timestamp)
+}
+
+func TestSummarize(t *testing.T) {
+	entries, err := Parse(strings.NewReader(logs))
+	require.NoError(t, err)
+
+	since := time.Date(2025, 1, 10, 9, 45, 0, 0, time.UTC)
+	now := since.Add(15 * time.Minute)
+	report := Summarize(entries, time.Second, 10, since, now)
+
+	assert.Equal(t, 4, report.TotalQueries)
+	assert.Equal(t, 1, report.SlowQueries)
+	assert.Equal(t, "1s", report.SlowQueryThreshold)
+	assert.Equal(t, now, report.GeneratedAt)
+	require.Len(t, report.Top, 2)
+
+	slowest := report.Top[0]
+	assert.Equal(t, "sum(rate(http_requests_total[5m])) by (job)", slowest.Query)
+	assert.Equal(t, 2, slowest.Count)
+	assert.Equal(t, 1, slowest.SlowCount)
+	assert.InDelta(t, 3.0, slowest.TotalSeconds, 1e-9)
+	assert.InDelta(t, 2.6, slowest.MaxSeconds, 1e-9)
+	assert.Equal(t, int64(6000), slowest.PeakSamples)
+	assert.Equal(t, int64(200000), slowest.QueryableSamples)
+
+	assert.Equal(t, "up", report.Top[1].Query)
+	assert.Equal(t, 2, report.Top[1].Count)
+}
+
+func TestSummarizeTopN(t *testing.T) {
+	entries := []Entry{
+		{Params: Params{Query: "a"}, Stats: Stats{Timings: Timings{ExecTotalTime: 1}}},
+		{Params: Params{Query: "b"}, Stats: Stats{Timings: Timings{ExecTotalTime: 3}}},
+		{Params: Params{Query: "c"}, Stats: Stats{Timings: Timings{ExecTotalTime: 2}}},
+	}
+
+	report := Summarize(entries, time.Second, 2, time.Time{}, time.Time{})
+
+	require.Len(t, report.Top, 2)
+	assert.Equal(t, "b", report.Top[0].Query)
+	assert.Equal(t, "c", report.Top[1].Query)
+	assert.Equal(t, 3, report.TotalQueries)
+	assert.Equal(t, 2, report.SlowQueries)
+}
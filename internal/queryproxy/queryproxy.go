@@ -0,0 +1,213 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package queryproxy forwards PromQL, LogQL and TraceQL queries from the
+// operator API to the backend of a platform, so tooling can query platforms
+// without knowing their service DNS names. Queries go to Prometheus, Loki
+// and Tempo by language, with the credentials of the API caller removed and
+// the platform's tenant set in the X-Scope-OrgID header. Prometheus has no
+// tenants, so PromQL queries can be restricted to label values instead.
+package queryproxy
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// Language is a query language
+type Language string
+
+const (
+	PromQL  Language = "promql"
+	LogQL   Language = "logql"
+	TraceQL Language = "traceql"
+)
+
+const (
+	// TenantHeader carries the tenant of a query to Loki and Tempo
+	TenantHeader = "X-Scope-OrgID"
+	// TenantLabel is the namespace label naming the tenant of the platforms
+	// in the namespace
+	TenantLabel = "tenant"
+	// DefaultTenant is the tenant of Loki and Tempo running without
+	// multi-tenancy
+	DefaultTenant = "fake"
+)
+
+// strippedHeaders are the headers of the API caller not forwarded to
+// backends
+var strippedHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", TenantHeader}
+
+// ParseLanguage parses a query language, PromQL by default
+func ParseLanguage(s string) (Language, error) {
+	switch l := Language(strings.ToLower(s)); l {
+	case "":
+		return PromQL, nil
+	case PromQL, LogQL, TraceQL:
+		return l, nil
+	default:
+		return "", fmt.Errorf("unsupported query language %q (supported: promql, logql, traceql)", s)
+	}
+}
+
+// Target is where a query is forwarded
+type Target struct {
+	// URL of the backend API endpoint
+	URL *url.URL
+	// QueryParam is the parameter of the backend holding the query
+	QueryParam string
+	// Query replaces the query of the request if set, e.g. with the label
+	// values of the caller enforced
+	Query string
+}
+
+// TargetFor returns where a query in language goes for platform. Range
+// queries are the ones with a start and an end.
+func TargetFor(platform *observabilityv1beta1.ObservabilityPlatform, language Language, ranged bool) (*Target, error) {
+	components := platform.Spec.Components
+	if components == nil {
+		components = &observabilityv1beta1.Components{}
+	}
+
+	var service, path, param string
+	var port int
+	switch language {
+	case PromQL:
+		if components.Prometheus == nil || !components.Prometheus.Enabled {
+			return nil, fmt.Errorf("prometheus is not enabled for platform %s", platform.Name)
+		}
+		service, port, path, param = "prometheus", 9090, "/api/v1/query", "query"
+		if ranged {
+			path = "/api/v1/query_range"
+		}
+	case LogQL:
+		if components.Loki == nil || !components.Loki.Enabled {
+			return nil, fmt.Errorf("loki is not enabled for platform %s", platform.Name)
+		}
+		service, port, path, param = "loki", 3100, "/loki/api/v1/query", "query"
+		if ranged {
+			path = "/loki/api/v1/query_range"
+		}
+	case TraceQL:
+		if components.Tempo == nil || !components.Tempo.Enabled {
+			return nil, fmt.Errorf("tempo is not enabled for platform %s", platform.Name)
+		}
+		// Tempo searches with TraceQL in the q parameter, over a range if
+		// start and end are given
+		service, port, path, param = "tempo", 3200, "/api/search", "q"
+	default:
+		return nil, fmt.Errorf("unsupported query language %q", language)
+	}
+
+	return &Target{
+		URL: &url.URL{
+			Scheme: "http",
+			Host:   fmt.Sprintf("%s-%s.%s.svc.cluster.local:%d", service, platform.Name, platform.Namespace, port),
+			Path:   path,
+		},
+		QueryParam: param,
+	}, nil
+}
+
+// Ranged returns whether the query parameters ask for a range query
+func Ranged(params url.Values) bool {
+	return params.Get("start") != "" && params.Get("end") != ""
+}
+
+// NewProxy returns a reverse proxy forwarding requests to target with the
+// tenant header set. The query is read from the query parameter of the
+// request, or from its form for POST requests, and passed to the backend in
+// the target's parameter with the other parameters unchanged; the language
+// and namespace parameters of the API route are dropped.
+func NewProxy(target *Target, tenant string) *httputil.ReverseProxy {
+	return &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			params := req.URL.Query()
+			if req.Method == http.MethodPost && req.ParseForm() == nil {
+				for key, values := range req.PostForm {
+					params[key] = values
+				}
+			}
+			query := params.Get("query")
+			if target.Query != "" {
+				query = target.Query
+			}
+			params.Del("query")
+			params.Del("language")
+			params.Del("namespace")
+			params.Set(target.QueryParam, query)
+
+			// Backends are queried with GET, the query is in the URL
+			req.Method = http.MethodGet
+			req.Body = http.NoBody
+			req.ContentLength = 0
+			req.Header.Del("Content-Type")
+			req.Header.Del("Content-Length")
+
+			req.URL = &url.URL{
+				Scheme:   target.URL.Scheme,
+				Host:     target.URL.Host,
+				Path:     target.URL.Path,
+				RawQuery: params.Encode(),
+			}
+			req.Host = target.URL.Host
+			for _, header := range strippedHeaders {
+				req.Header.Del(header)
+			}
+			req.Header.Set(TenantHeader, tenant)
+			// Keep the caller's address out of the backend logs
+			req.Header["X-Forwarded-For"] = nil
+		},
+	}
+}
+
+// EnforceLabel returns a PromQL query with every selector restricted to the
+// values of label. The matcher is added to the matchers of the selectors, so
+// a query can narrow the values but not widen them.
+func EnforceLabel(query, label string, values []string) (string, error) {
+	if len(values) == 0 {
+		return "", fmt.Errorf("no values of label %s to enforce", label)
+	}
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, regexp.QuoteMeta(value))
+	}
+	sort.Strings(quoted)
+	matcher, err := labels.NewMatcher(labels.MatchRegexp, label, strings.Join(quoted, "|"))
+	if err != nil {
+		return "", err
+	}
+
+	expr, err := parser.ParseExpr(query)
+	if err != nil {
+		return "", err
+	}
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		if selector, ok := node.(*parser.VectorSelector); ok {
+			selector.LabelMatchers = append(selector.LabelMatchers, matcher)
+		}
+		return nil
+	})
+	return expr.String(), nil
+}
+
+// Tenant returns the tenant of the platforms in a namespace with labels
+func Tenant(namespaceLabels map[string]string) string {
+	if tenant := namespaceLabels[TenantLabel]; tenant != "" {
+		return tenant
+	}
+	return DefaultTenant
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package queryproxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+func TestParseLanguage(t *testing.T) {
+	l, err := ParseLanguage("")
+	require.NoError(t, err)
+	assert.Equal(t, PromQL, l)
+	l, err = ParseLanguage("LogQL")
+	require.NoError(t, err)
+	assert.Equal(t, LogQL, l)
+	_, err = ParseLanguage("sql")
+	assert.Error(t, err)
+}
+
+func TestTargetFor(t *testing.T) {
+	platform := &observabilityv1beta1.ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "monitoring"},
+		Spec: observabilityv1beta1.ObservabilityPlatformSpec{
+			Components: &observabilityv1beta1.Components{
+				Prometheus: &observabilityv1beta1.PrometheusSpec{Enabled: true},
+				Tempo:      &observabilityv1beta1.TempoSpec{Enabled: true},
+			},
+		},
+	}
+
+	target, err := TargetFor(platform, PromQL, false)
+	require.NoError(t, err)
+	assert.Equal(t, "http://prometheus-prod.monitoring.svc.cluster.local:9090/api/v1/query", target.URL.String())
+	target, err = TargetFor(platform, PromQL, true)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/query_range", target.URL.Path)
+
+	target, err = TargetFor(platform, TraceQL, true)
+	require.NoError(t, err)
+	assert.Equal(t, "http://tempo-prod.monitoring.svc.cluster.local:3200/api/search", target.URL.String())
+	assert.Equal(t, "q", target.QueryParam)
+
+	_, err = TargetFor(platform, LogQL, false)
+	assert.ErrorContains(t, err, "loki is not enabled")
+
+	assert.True(t, Ranged(url.Values{"start": {"1"}, "end": {"2"}}))
+	assert.False(t, Ranged(url.Values{"start": {"1"}}))
+}
+
+func TestProxy(t *testing.T) {
+	var received *http.Request
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		_, _ = w.Write([]byte(`{"status":"success"}`))
+	}))
+	defer backend.Close()
+
+	backendURL, err := url.Parse(backend.URL)
+	require.NoError(t, err)
+	backendURL.Path = "/api/search"
+	proxy := NewProxy(&Target{URL: backendURL, QueryParam: "q"}, "team-a")
+
+	form := url.Values{"query": {`{ span.http.status_code = 500 }`}, "limit": {"20"}}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/platforms/prod/query?namespace=monitoring&language=traceql", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set(TenantHeader, "someone-else")
+	rec := httptest.NewRecorder()
+	proxy.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"success"}`, rec.Body.String())
+	require.NotNil(t, received)
+	assert.Equal(t, http.MethodGet, received.Method)
+	assert.Equal(t, "/api/search", received.URL.Path)
+	assert.Equal(t, `{ span.http.status_code = 500 }`, received.URL.Query().Get("q"))
+	assert.Equal(t, "20", received.URL.Query().Get("limit"))
+	assert.Empty(t, received.URL.Query().Get("language"))
+	assert.Empty(t, received.URL.Query().Get("namespace"))
+	assert.Empty(t, received.Header.Get("Authorization"))
+	assert.Equal(t, "team-a", received.Header.Get(TenantHeader))
+}
+
+func TestProxyEnforcedQuery(t *testing.T) {
+	var received *http.Request
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+	}))
+	defer backend.Close()
+
+	backendURL, err := url.Parse(backend.URL)
+	require.NoError(t, err)
+	backendURL.Path = "/api/v1/query"
+	proxy := NewProxy(&Target{URL: backendURL, QueryParam: "query", Query: `up{namespace=~"shop"}`}, "shop")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/platforms/prod/query?query=up", nil)
+	proxy.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.NotNil(t, received)
+	assert.Equal(t, `up{namespace=~"shop"}`, received.URL.Query().Get("query"))
+}
+
+func TestEnforceLabel(t *testing.T) {
+	query, err := EnforceLabel(`sum(rate(http_requests_total{job="api"}[5m])) / sum(up)`, "namespace", []string{"shop", "payments.prod"})
+	require.NoError(t, err)
+	assert.Equal(t, `sum(rate(http_requests_total{job="api",namespace=~"payments\\.prod|shop"}[5m])) / sum(up{namespace=~"payments\\.prod|shop"})`, query)
+
+	// A query can't widen the values with a matcher of its own
+	query, err = EnforceLabel(`up{namespace=~".+"}`, "namespace", []string{"shop"})
+	require.NoError(t, err)
+	assert.Equal(t, `up{namespace=~".+",namespace=~"shop"}`, query)
+
+	_, err = EnforceLabel(`up{`, "namespace", []string{"shop"})
+	assert.Error(t, err)
+	_, err = EnforceLabel(`up`, "namespace", nil)
+	assert.Error(t, err)
+}
+
+func TestTenant(t *testing.T) {
+	assert.Equal(t, "payments", Tenant(map[string]string{TenantLabel: "payments"}))
+	assert.Equal(t, DefaultTenant, Tenant(nil))
+}
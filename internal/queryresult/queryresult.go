@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package queryresult turns the responses of the Prometheus, Loki and Tempo
+// query APIs into tables for the CLI. Instant vectors list one row per
+// series, range matrices one row per series with its sample count and last
+// value, log streams one row per line and trace searches one row per trace.
+package queryresult
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gunjanjp/gunj-operator/internal/queryproxy"
+)
+
+// Table is a tabular query result
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// response is the envelope of the Prometheus and Loki query APIs
+type response struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+type sample [2]interface{}
+
+type series struct {
+	Metric map[string]string `json:"metric"`
+	Value  sample            `json:"value"`
+	Values []sample          `json:"values"`
+}
+
+type stream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+type search struct {
+	Traces []struct {
+		TraceID           string `json:"traceID"`
+		RootServiceName   string `json:"rootServiceName"`
+		RootTraceName     string `json:"rootTraceName"`
+		StartTimeUnixNano string `json:"startTimeUnixNano"`
+		DurationMs        int64  `json:"durationMs"`
+	} `json:"traces"`
+}
+
+// Parse returns the table of a query response in language
+func Parse(language queryproxy.Language, body []byte) (*Table, error) {
+	if language == queryproxy.TraceQL {
+		return parseSearch(body)
+	}
+
+	var r response
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("invalid query response: %w", err)
+	}
+	if r.Status != "success" {
+		return nil, fmt.Errorf("query failed: %s: %s", r.ErrorType, r.Error)
+	}
+
+	switch r.Data.ResultType {
+	case "vector":
+		var result []series
+		if err := json.Unmarshal(r.Data.Result, &result); err != nil {
+			return nil, fmt.Errorf("invalid vector: %w", err)
+		}
+		table := &Table{Header: []string{"METRIC", "TIMESTAMP", "VALUE"}}
+		for _, s := range result {
+			table.Rows = append(table.Rows, []string{FormatMetric(s.Metric), formatSampleTime(s.Value[0]), fmt.Sprint(s.Value[1])})
+		}
+		return table, nil
+	case "matrix":
+		var result []series
+		if err := json.Unmarshal(r.Data.Result, &result); err != nil {
+			return nil, fmt.Errorf("invalid matrix: %w", err)
+		}
+		table := &Table{Header: []string{"METRIC", "SAMPLES", "LAST TIMESTAMP", "LAST VALUE"}}
+		for _, s := range result {
+			row := []string{FormatMetric(s.Metric), strconv.Itoa(len(s.Values)), "-", "-"}
+			if n := len(s.Values); n > 0 {
+				row[2], row[3] = formatSampleTime(s.Values[n-1][0]), fmt.Sprint(s.Values[n-1][1])
+			}
+			table.Rows = append(table.Rows, row)
+		}
+		return table, nil
+	case "scalar", "string":
+		var value sample
+		if err := json.Unmarshal(r.Data.Result, &value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", r.Data.ResultType, err)
+		}
+		return &Table{Header: []string{"TIMESTAMP", "VALUE"}, Rows: [][]string{{formatSampleTime(value[0]), fmt.Sprint(value[1])}}}, nil
+	case "streams":
+		var result []stream
+		if err := json.Unmarshal(r.Data.Result, &result); err != nil {
+			return nil, fmt.Errorf("invalid streams: %w", err)
+		}
+		return streamsTable(result), nil
+	default:
+		return nil, fmt.Errorf("unsupported result type %q", r.Data.ResultType)
+	}
+}
+
+// streamsTable lists the lines of all streams, newest first
+func streamsTable(streams []stream) *Table {
+	type line struct {
+		ns     int64
+		labels string
+		text   string
+	}
+	var lines []line
+	for _, s := range streams {
+		labels := FormatMetric(s.Stream)
+		for _, v := range s.Values {
+			ns, _ := strconv.ParseInt(v[0], 10, 64)
+			lines = append(lines, line{ns: ns, labels: labels, text: v[1]})
+		}
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].ns > lines[j].ns })
+
+	table := &Table{Header: []string{"TIMESTAMP", "LABELS", "LINE"}}
+	for _, l := range lines {
+		table.Rows = append(table.Rows, []string{formatTime(time.Unix(0, l.ns)), l.labels, l.text})
+	}
+	return table
+}
+
+func parseSearch(body []byte) (*Table, error) {
+	var r search
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("invalid search response: %w", err)
+	}
+	table := &Table{Header: []string{"TRACE ID", "ROOT SERVICE", "ROOT NAME", "START", "DURATION"}}
+	for _, t := range r.Traces {
+		start := "-"
+		if ns, err := strconv.ParseInt(t.StartTimeUnixNano, 10, 64); err == nil {
+			start = formatTime(time.Unix(0, ns))
+		}
+		table.Rows = append(table.Rows, []string{
+			t.TraceID, t.RootServiceName, t.RootTraceName, start,
+			(time.Duration(t.DurationMs) * time.Millisecond).String(),
+		})
+	}
+	return table, nil
+}
+
+// FormatMetric renders labels as name{key="value", ...}, sorted by key
+func FormatMetric(labels map[string]string) string {
+	name := labels["__name__"]
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		if k != "__name__" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", k, labels[k]))
+	}
+	if len(pairs) == 0 && name != "" {
+		return name
+	}
+	return name + "{" + strings.Join(pairs, ", ") + "}"
+}
+
+// formatSampleTime formats the unix seconds of a sample
+func formatSampleTime(v interface{}) string {
+	seconds, ok := v.(float64)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	// Samples have millisecond precision
+	return formatTime(time.UnixMilli(int64(math.Round(seconds * 1000))))
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
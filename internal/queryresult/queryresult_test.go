@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package queryresult
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gunjanjp/gunj-operator/internal/queryproxy"
+)
+
+func TestParseVector(t *testing.T) {
+	body := `{"status":"success","data":{"resultType":"vector","result":[
+		{"metric":{"__name__":"up","job":"prometheus","instance":"localhost:9090"},"value":[1750000000.5,"1"]}
+	]}}`
+	table, err := Parse(queryproxy.PromQL, []byte(body))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"METRIC", "TIMESTAMP", "VALUE"}, table.Header)
+	assert.Equal(t, [][]string{{`up{instance="localhost:9090", job="prometheus"}`, "2025-06-15T15:06:40.5Z", "1"}}, table.Rows)
+}
+
+func TestParseMatrix(t *testing.T) {
+	body := `{"status":"success","data":{"resultType":"matrix","result":[
+		{"metric":{"job":"api"},"values":[[1750000000,"3"],[1750000060,"4"]]},
+		{"metric":{},"values":[]}
+	]}}`
+	table, err := Parse(queryproxy.LogQL, []byte(body))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{`{job="api"}`, "2", "2025-06-15T15:07:40Z", "4"},
+		{"{}", "0", "-", "-"},
+	}, table.Rows)
+}
+
+func TestParseStreams(t *testing.T) {
+	body := `{"status":"success","data":{"resultType":"streams","result":[
+		{"stream":{"app":"api"},"values":[["1750000001000000000","second"]]},
+		{"stream":{"app":"web"},"values":[["1750000002000000000","third"],["1750000000000000000","first"]]}
+	]}}`
+	table, err := Parse(queryproxy.LogQL, []byte(body))
+	require.NoError(t, err)
+	require.Len(t, table.Rows, 3)
+	assert.Equal(t, []string{"2025-06-15T15:06:42Z", `{app="web"}`, "third"}, table.Rows[0])
+	assert.Equal(t, "second", table.Rows[1][2])
+	assert.Equal(t, "first", table.Rows[2][2])
+}
+
+func TestParseSearch(t *testing.T) {
+	body := `{"traces":[{"traceID":"2f3e0cee77ae5dc9c17ade3689eb2e54","rootServiceName":"shop","rootTraceName":"GET /cart","startTimeUnixNano":"1750000000000000000","durationMs":1250}]}`
+	table, err := Parse(queryproxy.TraceQL, []byte(body))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"2f3e0cee77ae5dc9c17ade3689eb2e54", "shop", "GET /cart", "2025-06-15T15:06:40Z", "1.25s"}}, table.Rows)
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse(queryproxy.PromQL, []byte(`{"status":"error","errorType":"bad_data","error":"parse error at char 4"}`))
+	assert.EqualError(t, err, "query failed: bad_data: parse error at char 4")
+
+	_, err = Parse(queryproxy.PromQL, []byte(`not json`))
+	assert.Error(t, err)
+
+	table, err := Parse(queryproxy.PromQL, []byte(`{"status":"success","data":{"resultType":"scalar","result":[1750000000,"42"]}}`))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"2025-06-15T15:06:40Z", "42"}}, table.Rows)
+}
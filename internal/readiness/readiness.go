@@ -0,0 +1,241 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package readiness tracks the readiness of the subsystems of the operator
+// and serves it on the health probe endpoints. /readyz reports the
+// subsystems as JSON when queried with ?verbose, so that a pod stuck at
+// startup shows which subsystem it is waiting for.
+package readiness
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// errPending is the error of a subsystem that has not been marked ready
+var errPending = errors.New("not ready yet")
+
+// Subsystem is the readiness of a subsystem
+type Subsystem struct {
+	Name  string `json:"name"`
+	Ready bool   `json:"ready"`
+	// Message explains why the subsystem is not ready
+	Message string `json:"message,omitempty"`
+	// Since is when the subsystem last changed readiness
+	Since time.Time `json:"since"`
+}
+
+// Report is the readiness of the operator
+type Report struct {
+	Ready      bool        `json:"ready"`
+	Subsystems []Subsystem `json:"subsystems"`
+}
+
+type subsystem struct {
+	// check is nil for subsystems marked ready or not by their owner
+	check healthz.Checker
+	err   error
+	since time.Time
+}
+
+// Tracker tracks the readiness of the subsystems of the operator. The
+// operator is ready once all its subsystems are.
+type Tracker struct {
+	mu         sync.Mutex
+	subsystems map[string]*subsystem
+	now        func() time.Time
+}
+
+// NewTracker returns a Tracker without subsystems
+func NewTracker() *Tracker {
+	return &Tracker{subsystems: map[string]*subsystem{}, now: time.Now}
+}
+
+// Register registers a subsystem that is not ready until it is marked ready
+func (t *Tracker) Register(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.subsystems[name] = &subsystem{err: errPending, since: t.now()}
+}
+
+// AddCheck registers a subsystem whose readiness is checked on each probe
+func (t *Tracker) AddCheck(name string, check healthz.Checker) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.subsystems[name] = &subsystem{check: check, err: errPending, since: t.now()}
+}
+
+// SetReady marks a registered subsystem ready
+func (t *Tracker) SetReady(name string) {
+	t.set(name, nil)
+}
+
+// SetNotReady marks a registered subsystem not ready because of err
+func (t *Tracker) SetNotReady(name string, err error) {
+	t.set(name, err)
+}
+
+func (t *Tracker) set(name string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s, ok := t.subsystems[name]
+	if !ok {
+		s = &subsystem{err: errPending}
+		t.subsystems[name] = s
+	}
+	t.update(s, err)
+}
+
+// update records the result of a subsystem, keeping the time of the last
+// change of readiness
+func (t *Tracker) update(s *subsystem, err error) {
+	if (s.err == nil) != (err == nil) || s.since.IsZero() {
+		s.since = t.now()
+	}
+	s.err = err
+}
+
+// Report checks the subsystems, except the excluded ones
+func (t *Tracker) Report(req *http.Request, exclude ...string) Report {
+	t.mu.Lock()
+	names := make([]string, 0, len(t.subsystems))
+	checks := map[string]healthz.Checker{}
+	for name, s := range t.subsystems {
+		names = append(names, name)
+		if s.check != nil {
+			checks[name] = s.check
+		}
+	}
+	t.mu.Unlock()
+	sort.Strings(names)
+
+	// Run the checks unlocked, they may be slow
+	results := map[string]error{}
+	for name, check := range checks {
+		results[name] = check(req)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	report := Report{Ready: true, Subsystems: []Subsystem{}}
+	for _, name := range names {
+		if contains(exclude, name) {
+			continue
+		}
+		s, ok := t.subsystems[name]
+		if !ok {
+			continue
+		}
+		if err, checked := results[name]; checked {
+			t.update(s, err)
+		}
+		status := Subsystem{Name: name, Ready: s.err == nil, Since: s.since}
+		if s.err != nil {
+			status.Message = s.err.Error()
+			report.Ready = false
+		}
+		report.Subsystems = append(report.Subsystems, status)
+	}
+	return report
+}
+
+// ServeHTTP serves the readiness of the operator. The subsystems are
+// reported as JSON with ?verbose, excluding those of the exclude
+// parameters; /readyz/<subsystem> reports a single subsystem.
+func (t *Tracker) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	exclude := req.URL.Query()["exclude"]
+	report := t.Report(req, exclude...)
+	if name := strings.Trim(req.URL.Path, "/"); name != "" {
+		filtered := Report{Ready: true}
+		for _, s := range report.Subsystems {
+			if s.Name == name {
+				filtered.Ready = s.Ready
+				filtered.Subsystems = []Subsystem{s}
+			}
+		}
+		if filtered.Subsystems == nil {
+			http.Error(w, fmt.Sprintf("subsystem %s not found", name), http.StatusNotFound)
+			return
+		}
+		report = filtered
+	}
+
+	status := http.StatusOK
+	if !report.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	if _, verbose := req.URL.Query()["verbose"]; verbose {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(report)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	if report.Ready {
+		fmt.Fprint(w, "ok")
+		return
+	}
+	for _, s := range report.Subsystems {
+		if !s.Ready {
+			fmt.Fprintf(w, "[-]%s not ready: %s\n", s.Name, s.Message)
+		}
+	}
+	fmt.Fprint(w, "readyz check failed")
+}
+
+// CertificateChecker returns a checker that is ready once the key pair of
+// the files can be loaded and the certificate is valid
+func CertificateChecker(certFile, keyFile string) healthz.Checker {
+	return func(_ *http.Request) error {
+		pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("certificate not loaded: %w", err)
+		}
+		cert, err := x509.ParseCertificate(pair.Certificate[0])
+		if err != nil {
+			return fmt.Errorf("invalid certificate: %w", err)
+		}
+		if now := time.Now(); now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+			return fmt.Errorf("certificate is only valid from %s to %s", cert.NotBefore.Format(time.RFC3339), cert.NotAfter.Format(time.RFC3339))
+		}
+		return nil
+	}
+}
+
+// All returns a checker that is ready once all the checkers are
+func All(checkers ...healthz.Checker) healthz.Checker {
+	return func(req *http.Request) error {
+		for _, check := range checkers {
+			if err := check(req); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package readiness
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func probe(t *testing.T, tracker *Tracker, target string) (*httptest.ResponseRecorder, Report) {
+	handler := http.StripPrefix("/readyz", tracker)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+	report := Report{}
+	if recorder.Header().Get("Content-Type") == "application/json" {
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	}
+	return recorder, report
+}
+
+func TestTracker(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tracker := NewTracker()
+	tracker.now = func() time.Time { return now }
+
+	webhookErr := errors.New("certificate not loaded")
+	tracker.Register("cache-sync")
+	tracker.AddCheck("webhook-cert", func(*http.Request) error { return webhookErr })
+
+	recorder, _ := probe(t, tracker, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "[-]cache-sync not ready: not ready yet")
+	assert.Contains(t, recorder.Body.String(), "[-]webhook-cert not ready: certificate not loaded")
+
+	recorder, report := probe(t, tracker, "/readyz?verbose")
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.False(t, report.Ready)
+	assert.Equal(t, []Subsystem{
+		{Name: "cache-sync", Message: "not ready yet", Since: now},
+		{Name: "webhook-cert", Message: "certificate not loaded", Since: now},
+	}, report.Subsystems)
+
+	now = now.Add(time.Minute)
+	tracker.SetReady("cache-sync")
+	webhookErr = nil
+	recorder, report = probe(t, tracker, "/readyz?verbose")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, report.Ready)
+	assert.Equal(t, []Subsystem{
+		{Name: "cache-sync", Ready: true, Since: now},
+		{Name: "webhook-cert", Ready: true, Since: now},
+	}, report.Subsystems)
+
+	recorder, _ = probe(t, tracker, "/readyz")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "ok", recorder.Body.String())
+
+	// The time of the last change is kept while the readiness does not change
+	now = now.Add(time.Minute)
+	_, report = probe(t, tracker, "/readyz?verbose")
+	assert.Equal(t, now.Add(-time.Minute), report.Subsystems[1].Since)
+}
+
+func TestTrackerSubsystem(t *testing.T) {
+	tracker := NewTracker()
+	tracker.Register("cache-sync")
+	tracker.Register("managers")
+	tracker.SetReady("managers")
+
+	recorder, report := probe(t, tracker, "/readyz/managers?verbose")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	require.Len(t, report.Subsystems, 1)
+	assert.Equal(t, "managers", report.Subsystems[0].Name)
+
+	recorder, _ = probe(t, tracker, "/readyz/cache-sync")
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	recorder, _ = probe(t, tracker, "/readyz?exclude=cache-sync")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder, _ = probe(t, tracker, "/readyz/unknown")
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestCertificateChecker(t *testing.T) {
+	err := CertificateChecker("testdata/missing.crt", "testdata/missing.key")(nil)
+	assert.ErrorContains(t, err, "certificate not loaded")
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package readiness
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// Server serves /healthz and the /readyz of a Tracker. It replaces the
+// health probe server of the manager, which cannot report the subsystems.
+// It is started before the manager so that the probes answer while the
+// caches sync.
+type Server struct {
+	// Addr is the address the server binds to
+	Addr string
+	// Tracker reports the readiness
+	Tracker *Tracker
+	// Liveness are the checks of /healthz
+	Liveness map[string]healthz.Checker
+	Log      logr.Logger
+}
+
+// Start serves the probes until the context is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	liveness := &healthz.Handler{Checks: s.Liveness}
+	mux.Handle("/healthz", http.StripPrefix("/healthz", liveness))
+	mux.Handle("/healthz/", http.StripPrefix("/healthz", liveness))
+	mux.Handle("/readyz", http.StripPrefix("/readyz", s.Tracker))
+	mux.Handle("/readyz/", http.StripPrefix("/readyz", s.Tracker))
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			s.Log.Error(err, "Failed to shut down the health probe server")
+		}
+	}()
+
+	s.Log.Info("Serving health probes", "address", s.Addr)
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package reload applies configuration changes to running components. Pods
+// carry a checksum of the ConfigMaps and Secrets they reference, so a change
+// rolls them; components that can reload their configuration over HTTP are
+// reloaded in place instead, and the configuration they reload is left out of
+// the checksum.
+package reload
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Strategy is how configuration changes are applied to a component
+type Strategy string
+
+const (
+	// StrategyReload calls the component's reload endpoint
+	StrategyReload Strategy = "Reload"
+	// StrategyRestart rolls the component's pods
+	StrategyRestart Strategy = "Restart"
+
+	// ChecksumAnnotation is the pod template annotation holding the checksum
+	// of the referenced ConfigMaps and Secrets
+	ChecksumAnnotation = "observability.io/config-checksum"
+
+	// SettleDelay is how long the kubelet may take to update a mounted
+	// ConfigMap or Secret: its sync period plus the cache TTL, about a minute
+	// by default
+	SettleDelay = 2 * time.Minute
+)
+
+// Endpoint is the HTTP reload endpoint of a component
+type Endpoint struct {
+	Port int32
+	// Paths are POSTed in order to reload
+	Paths []string
+	// BasicAuth is set if the endpoint requires admin credentials
+	BasicAuth bool
+	// Volumes are the volumes whose configuration is reloaded
+	Volumes []string
+}
+
+// Endpoints are the reload endpoints of the components supporting reloads.
+// Prometheus reloads its configuration and rule files; Grafana reloads its
+// provisioned datasources and dashboards, but not grafana.ini; Alloy reloads
+// its whole configuration, the log level included.
+var Endpoints = map[string]Endpoint{
+	"prometheus": {
+		Port:    9090,
+		Paths:   []string{"/-/reload"},
+		Volumes: []string{"config"},
+	},
+	"grafana": {
+		Port: 3000,
+		Paths: []string{
+			"/api/admin/provisioning/datasources/reload",
+			"/api/admin/provisioning/dashboards/reload",
+		},
+		BasicAuth: true,
+		Volumes:   []string{"datasources", "dashboard-provider", "dashboards", "jsonnet-dashboards"},
+	},
+	"alloy": {
+		Port:    12345,
+		Paths:   []string{"/-/reload"},
+		Volumes: []string{"config"},
+	},
+}
+
+// StrategyFor returns the reload strategy of a component, defaulting to
+// Reload for the components supporting it
+func StrategyFor(component, configured string) Strategy {
+	if configured != "" {
+		return Strategy(configured)
+	}
+	if _, ok := Endpoints[component]; ok {
+		return StrategyReload
+	}
+	return StrategyRestart
+}
+
+// Supports returns true if a component can reload its configuration
+func Supports(component string) bool {
+	_, ok := Endpoints[component]
+	return ok
+}
+
+// Ref identifies a ConfigMap or Secret
+type Ref struct {
+	// Kind is ConfigMap or Secret
+	Kind string
+	Name string
+}
+
+// Object is the content of a referenced ConfigMap or Secret; Data is nil if
+// the object does not exist
+type Object struct {
+	Ref
+	Data map[string][]byte
+}
+
+// References returns the ConfigMaps and Secrets referenced by a pod spec
+// through volumes, env and envFrom, skipping the excluded volumes
+func References(podSpec *corev1.PodSpec, exclude []string) []Ref {
+	excluded := make(map[string]bool, len(exclude))
+	for _, volume := range exclude {
+		excluded[volume] = true
+	}
+	return collect(podSpec, func(volume string) bool { return !excluded[volume] }, true)
+}
+
+// VolumeReferences returns the ConfigMaps and Secrets mounted by the named
+// volumes of a pod spec
+func VolumeReferences(podSpec *corev1.PodSpec, volumes []string) []Ref {
+	included := make(map[string]bool, len(volumes))
+	for _, volume := range volumes {
+		included[volume] = true
+	}
+	return collect(podSpec, func(volume string) bool { return included[volume] }, false)
+}
+
+// collect returns the sorted, deduplicated references of the included
+// volumes and, optionally, of env and envFrom
+func collect(podSpec *corev1.PodSpec, include func(volume string) bool, withEnv bool) []Ref {
+	seen := make(map[Ref]bool)
+	add := func(kind, name string) {
+		if name != "" {
+			seen[Ref{Kind: kind, Name: name}] = true
+		}
+	}
+
+	for _, volume := range podSpec.Volumes {
+		if !include(volume.Name) {
+			continue
+		}
+		if volume.ConfigMap != nil {
+			add("ConfigMap", volume.ConfigMap.Name)
+		}
+		if volume.Secret != nil {
+			add("Secret", volume.Secret.SecretName)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					add("ConfigMap", source.ConfigMap.Name)
+				}
+				if source.Secret != nil {
+					add("Secret", source.Secret.Name)
+				}
+			}
+		}
+	}
+
+	if withEnv {
+		containers := append(append([]corev1.Container(nil), podSpec.InitContainers...), podSpec.Containers...)
+		for _, container := range containers {
+			for _, env := range container.Env {
+				if env.ValueFrom == nil {
+					continue
+				}
+				if env.ValueFrom.ConfigMapKeyRef != nil {
+					add("ConfigMap", env.ValueFrom.ConfigMapKeyRef.Name)
+				}
+				if env.ValueFrom.SecretKeyRef != nil {
+					add("Secret", env.ValueFrom.SecretKeyRef.Name)
+				}
+			}
+			for _, envFrom := range container.EnvFrom {
+				if envFrom.ConfigMapRef != nil {
+					add("ConfigMap", envFrom.ConfigMapRef.Name)
+				}
+				if envFrom.SecretRef != nil {
+					add("Secret", envFrom.SecretRef.Name)
+				}
+			}
+		}
+	}
+
+	refs := make([]Ref, 0, len(seen))
+	for ref := range seen {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Kind != refs[j].Kind {
+			return refs[i].Kind < refs[j].Kind
+		}
+		return refs[i].Name < refs[j].Name
+	})
+	return refs
+}
+
+// Checksum returns the checksum of the content of objects
+func Checksum(objects []Object) string {
+	sorted := append([]Object(nil), objects...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Kind != sorted[j].Kind {
+			return sorted[i].Kind < sorted[j].Kind
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	h := sha256.New()
+	for _, obj := range sorted {
+		fmt.Fprintf(h, "%s/%s\x00", obj.Kind, obj.Name)
+		keys := make([]string, 0, len(obj.Data))
+		for key := range obj.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(h, "%s\x00%s\x00", key, obj.Data[key])
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Due reports whether a component must be reloaded, given when the change of
+// its configuration was observed and when it was last reloaded. Reloads are
+// repeated until one happens after the change has settled into the pods.
+func Due(observed, lastReload time.Time) bool {
+	return lastReload.Before(observed.Add(SettleDelay))
+}
+
+// Credentials are the basic auth credentials of a reload endpoint
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Reload calls the reload endpoint of a component at baseURL
+func Reload(ctx context.Context, client *http.Client, baseURL string, endpoint Endpoint, credentials *Credentials) error {
+	for _, path := range endpoint.Paths {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(baseURL, "/")+path, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create reload request: %w", err)
+		}
+		if endpoint.BasicAuth && credentials != nil {
+			req.SetBasicAuth(credentials.Username, credentials.Password)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("reload %s failed: %w", path, err)
+		}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("reload %s failed with status %d: %s", path, resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+	}
+	return nil
+}
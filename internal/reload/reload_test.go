@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package reload
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func podSpec() *corev1.PodSpec {
+	return &corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "prometheus-prod-config"},
+			}}},
+			{Name: "tls", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "prometheus-tls"}}},
+			{Name: "bundle", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{ConfigMap: &corev1.ConfigMapProjection{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "ca-bundle"},
+				}}},
+			}}},
+			{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		},
+		Containers: []corev1.Container{{
+			Name: "prometheus",
+			Env: []corev1.EnvVar{
+				{Name: "PLAIN", Value: "x"},
+				{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "remote-write"}, Key: "token",
+				}}},
+			},
+			EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "ca-bundle"},
+			}}},
+		}},
+	}
+}
+
+func TestReferences(t *testing.T) {
+	assert.Equal(t, []Ref{
+		{Kind: "ConfigMap", Name: "ca-bundle"},
+		{Kind: "ConfigMap", Name: "prometheus-prod-config"},
+		{Kind: "Secret", Name: "prometheus-tls"},
+		{Kind: "Secret", Name: "remote-write"},
+	}, References(podSpec(), nil))
+
+	assert.Equal(t, []Ref{
+		{Kind: "ConfigMap", Name: "ca-bundle"},
+		{Kind: "Secret", Name: "prometheus-tls"},
+		{Kind: "Secret", Name: "remote-write"},
+	}, References(podSpec(), Endpoints["prometheus"].Volumes))
+
+	assert.Equal(t, []Ref{{Kind: "ConfigMap", Name: "prometheus-prod-config"}},
+		VolumeReferences(podSpec(), Endpoints["prometheus"].Volumes))
+}
+
+func TestChecksum(t *testing.T) {
+	objects := []Object{
+		{Ref: Ref{Kind: "ConfigMap", Name: "a"}, Data: map[string][]byte{"x": []byte("1"), "y": []byte("2")}},
+		{Ref: Ref{Kind: "Secret", Name: "b"}},
+	}
+	checksum := Checksum(objects)
+	assert.Len(t, checksum, 16)
+	assert.Equal(t, checksum, Checksum([]Object{objects[1], objects[0]}))
+
+	objects[0].Data["x"] = []byte("3")
+	assert.NotEqual(t, checksum, Checksum(objects))
+}
+
+func TestStrategyFor(t *testing.T) {
+	assert.Equal(t, StrategyReload, StrategyFor("prometheus", ""))
+	assert.Equal(t, StrategyRestart, StrategyFor("prometheus", "Restart"))
+	assert.Equal(t, StrategyRestart, StrategyFor("loki", ""))
+	assert.False(t, Supports("tempo"))
+	assert.Equal(t, StrategyReload, StrategyFor("alloy", ""))
+}
+
+func TestDue(t *testing.T) {
+	observed := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, Due(observed, time.Time{}))
+	assert.True(t, Due(observed, observed.Add(30*time.Second)))
+	assert.False(t, Due(observed, observed.Add(SettleDelay)))
+}
+
+func TestReload(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		paths = append(paths, r.URL.Path)
+	}))
+	defer server.Close()
+
+	endpoint := Endpoints["grafana"]
+	require.NoError(t, Reload(context.Background(), server.Client(), server.URL, endpoint, &Credentials{Username: "admin", Password: "secret"}))
+	assert.Equal(t, endpoint.Paths, paths)
+
+	err := Reload(context.Background(), server.Client(), server.URL, endpoint, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 401")
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package render
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// kustomization is the part of a kustomization.yaml the layout writes
+type kustomization struct {
+	APIVersion string      `json:"apiVersion"`
+	Kind       string      `json:"kind"`
+	Resources  []string    `json:"resources"`
+	Labels     []labelSet  `json:"labels,omitempty"`
+	Replicas   []replicas  `json:"replicas,omitempty"`
+	Images     []imageSpec `json:"images,omitempty"`
+}
+
+// labelSet adds labels to the objects, and to their pod templates
+type labelSet struct {
+	Pairs            map[string]string `json:"pairs"`
+	IncludeSelectors bool              `json:"includeSelectors"`
+	IncludeTemplates bool              `json:"includeTemplates"`
+}
+
+// replicas sets the replicas of a workload
+type replicas struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// imageSpec pins an image
+type imageSpec struct {
+	Name   string `json:"name"`
+	NewTag string `json:"newTag,omitempty"`
+	Digest string `json:"digest,omitempty"`
+}
+
+// Overlay is an example overlay of the base
+type Overlay struct {
+	// Name is the name of the overlay directory, e.g. staging
+	Name string
+	// Replicas, if set, scales every workload to this count
+	Replicas *int64
+	// PinImages lists the images of the workloads with their tags, so they
+	// are promoted by changing the tags of the overlay
+	PinImages bool
+}
+
+// ExampleOverlays are the overlays written by default: staging, scaled down
+// to a replica, and production, with its images pinned
+func ExampleOverlays() []Overlay {
+	one := int64(1)
+	return []Overlay{
+		{Name: "staging", Replicas: &one},
+		{Name: "production", PinImages: true},
+	}
+}
+
+// Layout is a kustomize base and its overlays
+type Layout struct {
+	// Files are the files by path, relative to the layout's directory
+	Files map[string]string
+}
+
+// Paths returns the paths of the files in order
+func (l *Layout) Paths() []string {
+	paths := make([]string, 0, len(l.Files))
+	for p := range l.Files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// file marshals a file
+func (l *Layout) file(p string, content interface{}) error {
+	data, err := yaml.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", p, err)
+	}
+	l.Files[p] = string(data)
+	return nil
+}
+
+// Kustomize lays out objects as a kustomize base, a file per object under
+// base/, and overlays of the base under overlays/. Overlays label the objects
+// with their name as the environment label, leaving the selectors unchanged:
+// those of StatefulSets are immutable.
+func Kustomize(objects []*unstructured.Unstructured, overlays []Overlay) (*Layout, error) {
+	layout := &Layout{Files: map[string]string{}}
+
+	base := kustomization{APIVersion: "kustomize.config.k8s.io/v1beta1", Kind: "Kustomization", Resources: []string{}}
+	for _, obj := range objects {
+		name := fileName(obj)
+		if _, exists := layout.Files[path.Join("base", name)]; exists {
+			name = strings.ToLower(obj.GetNamespace()) + "-" + name
+		}
+		if err := layout.file(path.Join("base", name), obj.Object); err != nil {
+			return nil, err
+		}
+		base.Resources = append(base.Resources, name)
+	}
+	if err := layout.file("base/kustomization.yaml", base); err != nil {
+		return nil, err
+	}
+
+	for _, overlay := range overlays {
+		k := kustomization{
+			APIVersion: base.APIVersion,
+			Kind:       base.Kind,
+			Resources:  []string{"../../base"},
+			Labels: []labelSet{{
+				Pairs:            map[string]string{"environment": overlay.Name},
+				IncludeTemplates: true,
+			}},
+		}
+		for _, obj := range objects {
+			if !isWorkload(obj) {
+				continue
+			}
+			if overlay.Replicas != nil {
+				k.Replicas = append(k.Replicas, replicas{Name: obj.GetName(), Count: *overlay.Replicas})
+			}
+		}
+		if overlay.PinImages {
+			k.Images = images(objects)
+		}
+		if err := layout.file(path.Join("overlays", overlay.Name, "kustomization.yaml"), k); err != nil {
+			return nil, err
+		}
+	}
+	return layout, nil
+}
+
+// fileName returns the name of the file of an object, e.g.
+// statefulset-production-prometheus.yaml
+func fileName(obj *unstructured.Unstructured) string {
+	return strings.ToLower(obj.GetKind()) + "-" + obj.GetName() + ".yaml"
+}
+
+// isWorkload reports whether an object is scaled by its replicas
+func isWorkload(obj *unstructured.Unstructured) bool {
+	switch obj.GetKind() {
+	case "Deployment", "StatefulSet":
+		return true
+	}
+	return false
+}
+
+// images returns the images of the containers of the workloads, pinned to
+// their tag or digest
+func images(objects []*unstructured.Unstructured) []imageSpec {
+	seen := map[string]imageSpec{}
+	for _, obj := range objects {
+		switch obj.GetKind() {
+		case "Deployment", "StatefulSet", "DaemonSet":
+		default:
+			continue
+		}
+		for _, field := range []string{"initContainers", "containers"} {
+			containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", field)
+			for _, container := range containers {
+				c, ok := container.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				ref, _ := c["image"].(string)
+				if image := parseImage(ref); image.Name != "" {
+					seen[image.Name] = image
+				}
+			}
+		}
+	}
+
+	pinned := make([]imageSpec, 0, len(seen))
+	for _, image := range seen {
+		pinned = append(pinned, image)
+	}
+	sort.Slice(pinned, func(i, j int) bool { return pinned[i].Name < pinned[j].Name })
+	return pinned
+}
+
+// parseImage splits an image reference into its name and its tag or digest
+func parseImage(ref string) imageSpec {
+	if name, digest, ok := strings.Cut(ref, "@"); ok {
+		return imageSpec{Name: name, Digest: digest}
+	}
+	// A colon before the last slash separates the port of the registry
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		return imageSpec{Name: ref[:i], NewTag: ref[i+1:]}
+	}
+	return imageSpec{Name: ref, NewTag: "latest"}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func workload(kind, name string, images ...string) *unstructured.Unstructured {
+	containers := make([]interface{}, 0, len(images))
+	for _, image := range images {
+		containers = append(containers, map[string]interface{}{"name": name, "image": image})
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": "monitoring"},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"template": map[string]interface{}{"spec": map[string]interface{}{"containers": containers}},
+		},
+	}}
+}
+
+func service(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "monitoring"},
+	}}
+}
+
+func TestKustomize(t *testing.T) {
+	objects := []*unstructured.Unstructured{
+		service("production-prometheus"),
+		workload("StatefulSet", "production-prometheus", "prom/prometheus:v2.48.0", "registry.local:5000/reloader"),
+		workload("Deployment", "production-grafana", "grafana/grafana@sha256:abc"),
+	}
+
+	layout, err := Kustomize(objects, ExampleOverlays())
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"base/deployment-production-grafana.yaml",
+		"base/kustomization.yaml",
+		"base/service-production-prometheus.yaml",
+		"base/statefulset-production-prometheus.yaml",
+		"overlays/production/kustomization.yaml",
+		"overlays/staging/kustomization.yaml",
+	}, layout.Paths())
+
+	assert.Equal(t, `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+- service-production-prometheus.yaml
+- statefulset-production-prometheus.yaml
+- deployment-production-grafana.yaml
+`, layout.Files["base/kustomization.yaml"])
+
+	assert.Equal(t, `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+labels:
+- includeSelectors: false
+  includeTemplates: true
+  pairs:
+    environment: staging
+replicas:
+- count: 1
+  name: production-prometheus
+- count: 1
+  name: production-grafana
+resources:
+- ../../base
+`, layout.Files["overlays/staging/kustomization.yaml"])
+
+	assert.Equal(t, `apiVersion: kustomize.config.k8s.io/v1beta1
+images:
+- digest: sha256:abc
+  name: grafana/grafana
+- name: prom/prometheus
+  newTag: v2.48.0
+- name: registry.local:5000/reloader
+  newTag: latest
+kind: Kustomization
+labels:
+- includeSelectors: false
+  includeTemplates: true
+  pairs:
+    environment: production
+resources:
+- ../../base
+`, layout.Files["overlays/production/kustomization.yaml"])
+
+	assert.Contains(t, layout.Files["base/statefulset-production-prometheus.yaml"], "image: prom/prometheus:v2.48.0")
+}
+
+func TestKustomizeNameCollision(t *testing.T) {
+	other := service("production-prometheus")
+	other.SetNamespace("Edge")
+
+	layout, err := Kustomize([]*unstructured.Unstructured{service("production-prometheus"), other}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"base/edge-service-production-prometheus.yaml",
+		"base/kustomization.yaml",
+		"base/service-production-prometheus.yaml",
+	}, layout.Paths())
+}
+
+func TestParseImage(t *testing.T) {
+	assert.Equal(t, imageSpec{Name: "prom/prometheus", NewTag: "v2.48.0"}, parseImage("prom/prometheus:v2.48.0"))
+	assert.Equal(t, imageSpec{Name: "localhost:5000/loki", NewTag: "latest"}, parseImage("localhost:5000/loki"))
+	assert.Equal(t, imageSpec{Name: "grafana/grafana", Digest: "sha256:abc"}, parseImage("grafana/grafana@sha256:abc"))
+}
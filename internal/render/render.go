@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package render renders the objects the operator creates for a platform
+// without a cluster, for review workflows and GitOps pipelines that apply
+// pre-rendered manifests. The component managers reconcile the platform
+// against an in-memory client, and the objects they write are recorded.
+package render
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/managers/grafana"
+	"github.com/gunjanjp/gunj-operator/internal/managers/loki"
+	"github.com/gunjanjp/gunj-operator/internal/managers/prometheus"
+	"github.com/gunjanjp/gunj-operator/internal/managers/tempo"
+)
+
+// Result is the rendering of a platform
+type Result struct {
+	// Objects are the rendered objects, ordered by kind and name
+	Objects []*unstructured.Unstructured
+	// Notes list what was left out and what to do about it
+	Notes []string
+}
+
+// reconciler is a component manager
+type reconciler interface {
+	Reconcile(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) error
+}
+
+// Platform renders the objects of a platform. The objects the managers read,
+// such as those of valueFrom references, are given as existing; they are
+// not rendered. Secrets are left out, so no secret material is written to
+// the manifests.
+func Platform(ctx context.Context, scheme *runtime.Scheme, platform *observabilityv1beta1.ObservabilityPlatform, existing ...client.Object) (*Result, error) {
+	objects := append([]client.Object{platform.DeepCopy()}, existing...)
+	rec := &recorder{
+		Client:  fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		scheme:  scheme,
+		objects: map[string]*unstructured.Unstructured{},
+	}
+
+	components := []struct {
+		name    string
+		manager reconciler
+	}{
+		{"prometheus", prometheus.NewPrometheusManager(rec, scheme)},
+		{"grafana", grafana.NewGrafanaManager(rec, scheme)},
+		{"loki", loki.NewLokiManager(rec, scheme)},
+		{"tempo", tempo.NewTempoManager(rec, scheme)},
+	}
+	for _, component := range components {
+		if err := component.manager.Reconcile(ctx, platform.DeepCopy()); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", component.name, err)
+		}
+	}
+
+	result := &Result{}
+	for _, obj := range rec.objects {
+		if obj.GetKind() == "Secret" {
+			result.Notes = append(result.Notes, fmt.Sprintf("Secret %s/%s is left out, create it in the cluster before applying the manifests", obj.GetNamespace(), obj.GetName()))
+			continue
+		}
+		result.Objects = append(result.Objects, obj)
+	}
+	sort.Slice(result.Objects, func(i, j int) bool {
+		a, b := result.Objects[i], result.Objects[j]
+		if a.GetKind() != b.GetKind() {
+			return a.GetKind() < b.GetKind()
+		}
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+	sort.Strings(result.Notes)
+	return result, nil
+}
+
+// recorder is a client recording the objects written through it
+type recorder struct {
+	client.Client
+	scheme  *runtime.Scheme
+	objects map[string]*unstructured.Unstructured
+}
+
+// Create creates an object and records it
+func (r *recorder) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := r.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	return r.record(obj)
+}
+
+// Update updates an object and records it
+func (r *recorder) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := r.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	return r.record(obj)
+}
+
+// Patch patches an object and records it
+func (r *recorder) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := r.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	return r.record(obj)
+}
+
+// record records the latest state of an object
+func (r *recorder) record(obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, r.scheme)
+	if err != nil {
+		return err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj.DeepCopyObject())
+	if err != nil {
+		return fmt.Errorf("failed to convert %s %s: %w", gvk.Kind, obj.GetName(), err)
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+	clean(u)
+	r.objects[gvk.Kind+"/"+obj.GetNamespace()+"/"+obj.GetName()] = u
+	return nil
+}
+
+// clean removes the fields set by the API server and the owner references,
+// which name the UID of a platform of another cluster
+func clean(u *unstructured.Unstructured) {
+	for _, field := range []string{"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "ownerReferences", "selfLink"} {
+		unstructured.RemoveNestedField(u.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(u.Object, "status")
+}
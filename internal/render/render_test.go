@@ -0,0 +1,59 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package render
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	rec := &recorder{
+		Client:  fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+		scheme:  scheme.Scheme,
+		objects: map[string]*unstructured.Unstructured{},
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "production-prometheus-config",
+			Namespace: "monitoring",
+			UID:       types.UID("1234"),
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "observability.io/v1beta1", Kind: "ObservabilityPlatform", Name: "production", UID: "5678"},
+			},
+		},
+		Data: map[string]string{"prometheus.yml": "global: {}"},
+	}
+	require.NoError(t, rec.Create(ctx, cm))
+	cm.Data["prometheus.yml"] = "global:\n  scrape_interval: 30s"
+	require.NoError(t, rec.Update(ctx, cm))
+
+	require.Len(t, rec.objects, 1)
+	recorded := rec.objects["ConfigMap/monitoring/production-prometheus-config"]
+	require.NotNil(t, recorded)
+	assert.Equal(t, "v1", recorded.GetAPIVersion())
+	assert.Equal(t, "ConfigMap", recorded.GetKind())
+	assert.Empty(t, recorded.GetUID())
+	assert.Empty(t, recorded.GetResourceVersion())
+	assert.Empty(t, recorded.GetOwnerReferences())
+	_, hasTimestamp, _ := unstructured.NestedFieldNoCopy(recorded.Object, "metadata", "creationTimestamp")
+	assert.False(t, hasTimestamp)
+
+	data, _, _ := unstructured.NestedStringMap(recorded.Object, "data")
+	assert.Equal(t, "global:\n  scrape_interval: 30s", data["prometheus.yml"])
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ActiveState is the ring state of an instance serving reads and writes
+const ActiveState = "ACTIVE"
+
+// Instance is an instance of a ring as served by the ring status page
+type Instance struct {
+	ID        string `json:"id"`
+	State     string `json:"state"`
+	Address   string `json:"address"`
+	Zone      string `json:"zone"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Heartbeat parses the time of the last heartbeat of the instance
+func (i Instance) Heartbeat() (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05.999999999 -0700 MST"} {
+		if t, err := time.Parse(layout, i.Timestamp); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// ringStatus is the JSON ring status page
+type ringStatus struct {
+	Shards []Instance `json:"shards"`
+}
+
+// ParseRing parses the JSON ring status page
+func ParseRing(body []byte) ([]Instance, error) {
+	status := ringStatus{}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, fmt.Errorf("invalid ring status: %w", err)
+	}
+	return status.Shards, nil
+}
+
+// CheckRing returns why the ring is not healthy, nil if at least expected
+// instances are ACTIVE and no instance is joining, leaving or missing its
+// heartbeats
+func CheckRing(instances []Instance, expected int32, now time.Time, heartbeatTimeout time.Duration) error {
+	var problems []string
+	active := int32(0)
+	for _, instance := range instances {
+		if instance.State != ActiveState {
+			problems = append(problems, fmt.Sprintf("%s is %s", instance.ID, instance.State))
+			continue
+		}
+		if heartbeat, ok := instance.Heartbeat(); ok && now.Sub(heartbeat) > heartbeatTimeout {
+			problems = append(problems, fmt.Sprintf("%s missed its heartbeats", instance.ID))
+			continue
+		}
+		active++
+	}
+	if active < expected {
+		problems = append(problems, fmt.Sprintf("%d/%d instances active", active, expected))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("ring not healthy: %s", strings.Join(problems, ", "))
+}
+
+// Prober reads the ring and flushes ingesters over HTTP
+type Prober struct {
+	HTTP *http.Client
+}
+
+func (p *Prober) client() *http.Client {
+	if p.HTTP == nil {
+		return http.DefaultClient
+	}
+	return p.HTTP
+}
+
+// Ring reads the ring status page at url
+func (p *Prober) Ring(ctx context.Context, url string) ([]Instance, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	body, err := p.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the ring: %w", err)
+	}
+	return ParseRing(body)
+}
+
+// Flush flushes an ingester through its flush endpoint at url, which
+// returns once the flush completed
+func (p *Prober) Flush(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := p.do(req); err != nil {
+		return fmt.Errorf("failed to flush the ingester: %w", err)
+	}
+	return nil
+}
+
+func (p *Prober) do(req *http.Request) ([]byte, error) {
+	resp, err := p.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s %s: %s", req.Method, req.URL.Path, resp.Status)
+	}
+	return body, nil
+}
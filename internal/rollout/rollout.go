@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package rollout implements staged rollouts of the StatefulSets of
+// ring-based components such as the Loki and Tempo ingesters. A change to
+// the pod template holds every pod at its revision with the partition of
+// the rolling update. The pods are then released one at a time, highest
+// ordinal first: once the updated pods are ready and the ring is stable,
+// the next ingester flushes its in-memory data and leaves the ring before
+// it is replaced, so no acknowledged write is lost.
+package rollout
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Label marks the StatefulSets rolled out in stages
+	Label = "observability.io/staged-rollout"
+
+	// ConfigAnnotation holds the Config of a StatefulSet
+	ConfigAnnotation = "observability.io/rollout-config"
+
+	// TemplateHashAnnotation identifies the pod template being rolled out
+	TemplateHashAnnotation = "observability.io/rollout-template-hash"
+
+	// StepTimeAnnotation is when the partition was last lowered
+	StepTimeAnnotation = "observability.io/rollout-step-time"
+
+	// FlushingAnnotation holds the ordinal of the pod whose flush started
+	FlushingAnnotation = "observability.io/rollout-flushing"
+)
+
+// Defaults of the Config
+const (
+	DefaultStabilizationPeriod = time.Minute
+	DefaultFlushTimeout        = 10 * time.Minute
+	DefaultHeartbeatTimeout    = time.Minute
+)
+
+// Config configures the staged rollout of a StatefulSet
+type Config struct {
+	// Port is the HTTP port of the component
+	Port int32 `json:"port"`
+	// RingPath serves the ring status
+	RingPath string `json:"ringPath"`
+	// FlushPath flushes the in-memory data of an ingester and removes it
+	// from the ring; the request returns once the flush completed
+	FlushPath string `json:"flushPath"`
+	// StabilizationPeriod is how long the updated pods must be ready before
+	// the next pod is released
+	StabilizationPeriod metav1.Duration `json:"stabilizationPeriod,omitempty"`
+	// FlushTimeout bounds the flush of an ingester
+	FlushTimeout metav1.Duration `json:"flushTimeout,omitempty"`
+}
+
+// WithDefaults returns the config with the defaults of unset durations
+func (c Config) WithDefaults() Config {
+	if c.StabilizationPeriod.Duration <= 0 {
+		c.StabilizationPeriod.Duration = DefaultStabilizationPeriod
+	}
+	if c.FlushTimeout.Duration <= 0 {
+		c.FlushTimeout.Duration = DefaultFlushTimeout
+	}
+	return c
+}
+
+// ConfigFor reads the config of a StatefulSet prepared by Prepare
+func ConfigFor(sts *appsv1.StatefulSet) (Config, error) {
+	config := Config{}
+	raw, ok := sts.Annotations[ConfigAnnotation]
+	if !ok {
+		return config, fmt.Errorf("StatefulSet %s has no %s annotation", sts.Name, ConfigAnnotation)
+	}
+	if err := json.Unmarshal([]byte(raw), &config); err != nil {
+		return config, fmt.Errorf("invalid %s annotation of StatefulSet %s: %w", ConfigAnnotation, sts.Name, err)
+	}
+	return config.WithDefaults(), nil
+}
+
+// TemplateHash identifies a pod template
+func TemplateHash(template corev1.PodTemplateSpec) string {
+	raw, _ := json.Marshal(template)
+	h := fnv.New64a()
+	_, _ = h.Write(raw)
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// Prepare marks desired for a staged rollout and sets its partition.
+// previous is the StatefulSet as stored, nil if it does not exist yet. A
+// new StatefulSet starts all pods at once; a changed pod template holds
+// every pod at its revision until released by Step; otherwise the partition
+// of the rollout in progress is kept.
+func Prepare(previous, desired *appsv1.StatefulSet, config Config) {
+	raw, _ := json.Marshal(config)
+	hash := TemplateHash(desired.Spec.Template)
+
+	if desired.Labels == nil {
+		desired.Labels = map[string]string{}
+	}
+	desired.Labels[Label] = "true"
+	if desired.Annotations == nil {
+		desired.Annotations = map[string]string{}
+	}
+	desired.Annotations[ConfigAnnotation] = string(raw)
+	desired.Annotations[TemplateHashAnnotation] = hash
+	delete(desired.Annotations, StepTimeAnnotation)
+	delete(desired.Annotations, FlushingAnnotation)
+
+	partition := int32(0)
+	if previous != nil && previous.ResourceVersion != "" {
+		if previous.Annotations[TemplateHashAnnotation] != hash {
+			partition = Replicas(desired)
+		} else {
+			partition = Partition(previous)
+			for _, key := range []string{StepTimeAnnotation, FlushingAnnotation} {
+				if value, ok := previous.Annotations[key]; ok {
+					desired.Annotations[key] = value
+				}
+			}
+		}
+	}
+
+	rollingUpdate := &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition}
+	if desired.Spec.UpdateStrategy.RollingUpdate != nil {
+		rollingUpdate.MaxUnavailable = desired.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable
+	}
+	desired.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+		Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: rollingUpdate,
+	}
+}
+
+// Replicas returns the desired replicas of a StatefulSet
+func Replicas(sts *appsv1.StatefulSet) int32 {
+	if sts.Spec.Replicas == nil {
+		return 1
+	}
+	return *sts.Spec.Replicas
+}
+
+// Partition returns the partition of a StatefulSet
+func Partition(sts *appsv1.StatefulSet) int32 {
+	if sts.Spec.UpdateStrategy.RollingUpdate == nil || sts.Spec.UpdateStrategy.RollingUpdate.Partition == nil {
+		return 0
+	}
+	return *sts.Spec.UpdateStrategy.RollingUpdate.Partition
+}
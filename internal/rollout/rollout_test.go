@@ -0,0 +1,222 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package rollout
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func statefulSet(replicas int32, image string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "monitoring"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "loki", Image: image}}},
+			},
+		},
+	}
+}
+
+func TestPrepare(t *testing.T) {
+	config := Config{Port: 3100, RingPath: "/ring", FlushPath: "/flush"}
+
+	// A new StatefulSet starts all pods
+	created := statefulSet(3, "loki:2.9.0")
+	Prepare(nil, created, config)
+	assert.Equal(t, int32(0), Partition(created))
+	assert.Equal(t, "true", created.Labels[Label])
+	read, err := ConfigFor(created)
+	require.NoError(t, err)
+	assert.Equal(t, "/ring", read.RingPath)
+	assert.Equal(t, DefaultFlushTimeout, read.FlushTimeout.Duration)
+
+	// An unchanged template keeps the partition of the rollout in progress
+	previous := created.DeepCopy()
+	previous.ResourceVersion = "1"
+	partition := int32(1)
+	previous.Spec.UpdateStrategy.RollingUpdate.Partition = &partition
+	previous.Annotations[StepTimeAnnotation] = "2025-01-01T00:00:00Z"
+	unchanged := statefulSet(3, "loki:2.9.0")
+	Prepare(previous, unchanged, config)
+	assert.Equal(t, int32(1), Partition(unchanged))
+	assert.Equal(t, "2025-01-01T00:00:00Z", unchanged.Annotations[StepTimeAnnotation])
+
+	// A changed template holds all pods
+	changed := statefulSet(3, "loki:2.9.1")
+	Prepare(previous, changed, config)
+	assert.Equal(t, int32(3), Partition(changed))
+	assert.NotContains(t, changed.Annotations, StepTimeAnnotation)
+}
+
+func TestStep(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	base := func() State {
+		return State{
+			Replicas:       3,
+			Partition:      2,
+			UpdateRevision: "new",
+			Pods: []Pod{
+				{Ordinal: 0, Revision: "old", Ready: true},
+				{Ordinal: 1, Revision: "old", Ready: true},
+				{Ordinal: 2, Revision: "new", Ready: true},
+			},
+			Flushing:            -1,
+			Now:                 now,
+			StabilizationPeriod: time.Minute,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(s *State)
+		want   Decision
+	}{
+		{
+			name: "flush the next pod",
+			want: Decision{Action: ActionFlush, Ordinal: 1},
+		},
+		{
+			name:   "complete",
+			modify: func(s *State) { s.Partition = 0 },
+			want:   Decision{Action: ActionNone},
+		},
+		{
+			name:   "updated pod not ready",
+			modify: func(s *State) { s.Pods[2].Ready = false },
+			want:   Decision{Action: ActionWait, Reason: "pod 2 is not ready"},
+		},
+		{
+			name:   "updated pod not yet replaced",
+			modify: func(s *State) { s.Pods[2].Revision = "old" },
+			want:   Decision{Action: ActionWait, Reason: "pod 2 is not updated"},
+		},
+		{
+			name:   "stabilizing",
+			modify: func(s *State) { s.StepTime = now.Add(-20 * time.Second) },
+			want:   Decision{Action: ActionWait, Reason: "stabilizing for 40s"},
+		},
+		{
+			name:   "ring not healthy",
+			modify: func(s *State) { s.RingError = errors.New("ring not healthy: loki-2 is JOINING") },
+			want:   Decision{Action: ActionWait, Reason: "ring not healthy: loki-2 is JOINING"},
+		},
+		{
+			name: "resume an interrupted flush",
+			modify: func(s *State) {
+				s.Flushing = 1
+				s.RingError = errors.New("ring not healthy: 2/3 instances active")
+			},
+			want: Decision{Action: ActionFlush, Ordinal: 1},
+		},
+		{
+			name:   "release an updated pod",
+			modify: func(s *State) { s.Pods[1].Revision = "new" },
+			want:   Decision{Action: ActionRelease, Ordinal: 1},
+		},
+		{
+			name:   "all pods held",
+			modify: func(s *State) { s.Partition = 3; s.Pods[2].Revision = "old" },
+			want:   Decision{Action: ActionFlush, Ordinal: 2},
+		},
+		{
+			name:   "generation not observed",
+			modify: func(s *State) { s.Generation = 2; s.ObservedGeneration = 1 },
+			want:   Decision{Action: ActionWait, Reason: "StatefulSet update not observed yet"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := base()
+			if tt.modify != nil {
+				tt.modify(&s)
+			}
+			assert.Equal(t, tt.want, Step(s))
+		})
+	}
+}
+
+func TestStateFor(t *testing.T) {
+	sts := statefulSet(2, "loki:2.9.1")
+	partition := int32(1)
+	sts.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition}
+	sts.Annotations = map[string]string{StepTimeAnnotation: "2025-01-01T00:00:00Z", FlushingAnnotation: "0"}
+	sts.Status.UpdateRevision = "new"
+
+	pod := func(name, revision string, ready corev1.ConditionStatus) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{appsv1.ControllerRevisionHashLabelKey: revision}},
+			Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}},
+		}
+	}
+	s := StateFor(sts, []corev1.Pod{
+		pod("loki-0", "old", corev1.ConditionTrue),
+		pod("loki-1", "new", corev1.ConditionFalse),
+		pod("loki-compactor-0", "new", corev1.ConditionTrue),
+	})
+	assert.Equal(t, int32(1), s.Partition)
+	assert.Equal(t, int32(0), s.Flushing)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), s.StepTime)
+	assert.Equal(t, []Pod{{Ordinal: 0, Revision: "old", Ready: true}, {Ordinal: 1, Revision: "new"}}, s.Pods)
+}
+
+func TestCheckRing(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	body := []byte(`{"shards":[
+		{"id":"loki-0","state":"ACTIVE","timestamp":"2025-01-01T11:59:50Z"},
+		{"id":"loki-1","state":"ACTIVE","timestamp":"2025-01-01T11:59:55Z"},
+		{"id":"loki-2","state":"JOINING","timestamp":"2025-01-01T11:59:58Z"}
+	]}`)
+	instances, err := ParseRing(body)
+	require.NoError(t, err)
+	require.Len(t, instances, 3)
+
+	assert.EqualError(t, CheckRing(instances, 3, now, time.Minute), "ring not healthy: 2/3 instances active, loki-2 is JOINING")
+
+	instances[2].State = ActiveState
+	assert.NoError(t, CheckRing(instances, 3, now, time.Minute))
+	assert.EqualError(t, CheckRing(instances, 3, now.Add(55*time.Second), time.Minute), "ring not healthy: 2/3 instances active, loki-0 missed its heartbeats")
+}
+
+func TestProber(t *testing.T) {
+	flushed := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ring":
+			assert.Equal(t, "application/json", r.Header.Get("Accept"))
+			_, _ = w.Write([]byte(`{"shards":[{"id":"loki-0","state":"ACTIVE"}]}`))
+		case "/flush":
+			assert.Equal(t, http.MethodPost, r.Method)
+			flushed = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	prober := &Prober{}
+	instances, err := prober.Ring(context.Background(), server.URL+"/ring")
+	require.NoError(t, err)
+	assert.Equal(t, []Instance{{ID: "loki-0", State: ActiveState}}, instances)
+
+	require.NoError(t, prober.Flush(context.Background(), server.URL+"/flush"))
+	assert.True(t, flushed)
+
+	assert.Error(t, prober.Flush(context.Background(), server.URL+"/missing"))
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package rollout
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Action is the next step of a staged rollout
+type Action string
+
+const (
+	// ActionNone means the rollout is complete
+	ActionNone Action = "None"
+	// ActionWait means the rollout waits for the updated pods or the ring
+	ActionWait Action = "Wait"
+	// ActionFlush flushes the next pod and then releases it
+	ActionFlush Action = "Flush"
+	// ActionRelease releases the next pod without a flush, as it already
+	// runs the update revision
+	ActionRelease Action = "Release"
+)
+
+// Pod is the rollout state of a pod of the StatefulSet
+type Pod struct {
+	Ordinal  int32
+	Revision string
+	Ready    bool
+}
+
+// State is the observed state of a staged rollout
+type State struct {
+	Replicas  int32
+	Partition int32
+
+	// Generation and ObservedGeneration of the StatefulSet
+	Generation         int64
+	ObservedGeneration int64
+	UpdateRevision     string
+
+	Pods []Pod
+
+	// RingError is why the ring is not healthy, nil if it is
+	RingError error
+
+	// StepTime is when the partition was last lowered
+	StepTime time.Time
+	// Flushing is the ordinal of the pod whose flush started, -1 if none
+	Flushing int32
+
+	Now                 time.Time
+	StabilizationPeriod time.Duration
+}
+
+// Decision is the next step of a staged rollout
+type Decision struct {
+	Action Action
+	// Ordinal of the pod flushed or released; the new partition
+	Ordinal int32
+	// Reason explains a wait
+	Reason string
+}
+
+// Step decides the next step of a staged rollout. The next pod is released
+// once the pods above the partition run the update revision and are ready,
+// the ring is healthy and the last step is older than the stabilization
+// period. A flush started before is completed without waiting for the
+// ring, which the flushed ingester has left.
+func Step(s State) Decision {
+	partition := s.Partition
+	if partition > s.Replicas {
+		partition = s.Replicas
+	}
+	if partition <= 0 {
+		return Decision{Action: ActionNone}
+	}
+	if s.ObservedGeneration < s.Generation {
+		return Decision{Action: ActionWait, Reason: "StatefulSet update not observed yet"}
+	}
+
+	pods := make(map[int32]Pod, len(s.Pods))
+	for _, pod := range s.Pods {
+		pods[pod.Ordinal] = pod
+	}
+	for ordinal := partition; ordinal < s.Replicas; ordinal++ {
+		pod, ok := pods[ordinal]
+		switch {
+		case !ok:
+			return Decision{Action: ActionWait, Reason: fmt.Sprintf("pod %d does not exist", ordinal)}
+		case pod.Revision != s.UpdateRevision:
+			return Decision{Action: ActionWait, Reason: fmt.Sprintf("pod %d is not updated", ordinal)}
+		case !pod.Ready:
+			return Decision{Action: ActionWait, Reason: fmt.Sprintf("pod %d is not ready", ordinal)}
+		}
+	}
+
+	next := partition - 1
+	if s.Flushing == next {
+		return Decision{Action: ActionFlush, Ordinal: next}
+	}
+	if pod, ok := pods[next]; ok && pod.Revision == s.UpdateRevision {
+		return Decision{Action: ActionRelease, Ordinal: next}
+	}
+	if wait := s.StepTime.Add(s.StabilizationPeriod).Sub(s.Now); !s.StepTime.IsZero() && wait > 0 {
+		return Decision{Action: ActionWait, Reason: fmt.Sprintf("stabilizing for %s", wait.Round(time.Second))}
+	}
+	if s.RingError != nil {
+		return Decision{Action: ActionWait, Reason: s.RingError.Error()}
+	}
+	return Decision{Action: ActionFlush, Ordinal: next}
+}
+
+// StateFor returns the rollout state of a StatefulSet and its pods. The
+// ring error and the time are set by the caller.
+func StateFor(sts *appsv1.StatefulSet, pods []corev1.Pod) State {
+	s := State{
+		Replicas:           Replicas(sts),
+		Partition:          Partition(sts),
+		Generation:         sts.Generation,
+		ObservedGeneration: sts.Status.ObservedGeneration,
+		UpdateRevision:     sts.Status.UpdateRevision,
+		Flushing:           -1,
+	}
+	if raw, ok := sts.Annotations[StepTimeAnnotation]; ok {
+		s.StepTime, _ = time.Parse(time.RFC3339, raw)
+	}
+	if raw, ok := sts.Annotations[FlushingAnnotation]; ok {
+		if ordinal, err := strconv.ParseInt(raw, 10, 32); err == nil {
+			s.Flushing = int32(ordinal)
+		}
+	}
+
+	prefix := sts.Name + "-"
+	for i := range pods {
+		pod := &pods[i]
+		if !strings.HasPrefix(pod.Name, prefix) || pod.DeletionTimestamp != nil {
+			continue
+		}
+		ordinal, err := strconv.ParseInt(strings.TrimPrefix(pod.Name, prefix), 10, 32)
+		if err != nil {
+			continue
+		}
+		s.Pods = append(s.Pods, Pod{
+			Ordinal:  int32(ordinal),
+			Revision: pod.Labels[appsv1.ControllerRevisionHashLabelKey],
+			Ready:    podReady(pod),
+		})
+	}
+	return s
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
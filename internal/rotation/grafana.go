@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package rotation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Grafana changes the admin password of a Grafana instance, which keeps its
+// own copy in its database: the password in the environment only seeds a
+// new database.
+type Grafana struct {
+	BaseURL  string
+	Username string
+	HTTP     *http.Client
+}
+
+// NewGrafana returns a client of the Grafana at baseURL for the admin user
+func NewGrafana(baseURL, username string) *Grafana {
+	return &Grafana{
+		BaseURL:  strings.TrimSuffix(baseURL, "/"),
+		Username: username,
+		HTTP:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// ChangePassword changes the admin password from current to next. A
+// rotation interrupted after Grafana accepted next is resumed: if current is
+// rejected but next is accepted, the password is already changed.
+func (g *Grafana) ChangePassword(ctx context.Context, current, next string) error {
+	body := map[string]string{
+		"oldPassword": current,
+		"newPassword": next,
+		"confirmNew":  next,
+	}
+	status, err := g.do(ctx, http.MethodPut, "/api/user/password", current, body)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusUnauthorized {
+		changed, err := g.Authenticates(ctx, next)
+		if err != nil {
+			return err
+		}
+		if changed {
+			return nil
+		}
+		return fmt.Errorf("grafana rejected both the current and the new admin password")
+	}
+	if status >= 300 {
+		return fmt.Errorf("grafana returned status %d changing the admin password", status)
+	}
+	return nil
+}
+
+// Authenticates returns whether Grafana accepts password for the admin user
+func (g *Grafana) Authenticates(ctx context.Context, password string) (bool, error) {
+	status, err := g.do(ctx, http.MethodGet, "/api/user", password, nil)
+	if err != nil {
+		return false, err
+	}
+	return status == http.StatusOK, nil
+}
+
+// do sends a request authenticated with password and returns its status
+func (g *Grafana) do(ctx context.Context, method, path, password string, in interface{}) (int, error) {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, g.BaseURL+path, body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.SetBasicAuth(g.Username, password)
+
+	resp, err := g.HTTP.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("grafana request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1024))
+	return resp.StatusCode, nil
+}
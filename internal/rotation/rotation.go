@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package rotation regenerates the credentials the operator generates for a
+// platform. A rotation is staged before it is applied: the new value is first
+// stored next to the current one in the Secret, then applied to the
+// dependents that keep their own copy, such as Grafana's user database, and
+// only then committed. A rotation interrupted at any step resumes with the
+// staged value, so the Secret and its dependents never disagree for longer
+// than a reconcile.
+package rotation
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	// RequestAnnotation on a platform requests a rotation of all its
+	// credentials whenever its value changes
+	RequestAnnotation = "observability.io/rotate-credentials"
+
+	// RotatedAtAnnotation records the last rotation on a Secret
+	RotatedAtAnnotation = "observability.io/rotated-at"
+
+	// PendingSuffix is appended to a key to stage its next value
+	PendingSuffix = ".pending"
+
+	// DefaultInterval is the interval between two scheduled rotations
+	DefaultInterval = 30 * 24 * time.Hour
+
+	// Length is the length of generated credentials
+	Length = 32
+)
+
+const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// Generate returns a random credential of n alphanumeric characters. The
+// alphabet leaves out symbols so credentials are safe in URLs and
+// configuration files.
+func Generate(n int) (string, error) {
+	b := make([]byte, n)
+	max := big.NewInt(int64(len(alphabet)))
+	for i := range b {
+		j, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate credential: %w", err)
+		}
+		b[i] = alphabet[j.Int64()]
+	}
+	return string(b), nil
+}
+
+// Due returns whether a credential last rotated at last is due at now
+func Due(last time.Time, interval time.Duration, now time.Time) bool {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return !now.Before(last.Add(interval))
+}
+
+// Requested returns the rotation request of a platform with annotations and
+// whether it differs from the last request handled
+func Requested(annotations map[string]string, handled string) (string, bool) {
+	request := annotations[RequestAnnotation]
+	return request, request != "" && request != handled
+}
+
+// Staged returns the staged value of key in data, if any
+func Staged(data map[string][]byte, key string) (string, bool) {
+	value, ok := data[key+PendingSuffix]
+	return string(value), ok
+}
+
+// Stage stores a new value of key in data, unless a rotation interrupted
+// earlier left one, and returns the staged value. It reports whether data
+// changed and must be written before the value is applied.
+func Stage(data map[string][]byte, key string) (value string, changed bool, err error) {
+	if staged, ok := Staged(data, key); ok {
+		return staged, false, nil
+	}
+	value, err = Generate(Length)
+	if err != nil {
+		return "", false, err
+	}
+	data[key+PendingSuffix] = []byte(value)
+	return value, true, nil
+}
+
+// Commit replaces key with its staged value and reports whether there was
+// one
+func Commit(data map[string][]byte, key string) bool {
+	staged, ok := data[key+PendingSuffix]
+	if !ok {
+		return false
+	}
+	data[key] = staged
+	delete(data, key+PendingSuffix)
+	return true
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package rotation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	a, err := Generate(Length)
+	require.NoError(t, err)
+	b, err := Generate(Length)
+	require.NoError(t, err)
+
+	assert.Len(t, a, Length)
+	assert.NotEqual(t, a, b)
+	assert.Regexp(t, "^[a-zA-Z0-9]+$", a)
+}
+
+func TestDue(t *testing.T) {
+	last := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.False(t, Due(last, time.Hour, last.Add(59*time.Minute)))
+	assert.True(t, Due(last, time.Hour, last.Add(time.Hour)))
+	assert.False(t, Due(last, 0, last.Add(29*24*time.Hour)))
+	assert.True(t, Due(last, 0, last.Add(DefaultInterval)))
+}
+
+func TestRequested(t *testing.T) {
+	request, ok := Requested(map[string]string{RequestAnnotation: "2025-06-01"}, "")
+	assert.True(t, ok)
+	assert.Equal(t, "2025-06-01", request)
+
+	_, ok = Requested(map[string]string{RequestAnnotation: "2025-06-01"}, "2025-06-01")
+	assert.False(t, ok)
+
+	_, ok = Requested(nil, "2025-06-01")
+	assert.False(t, ok)
+}
+
+func TestStageAndCommit(t *testing.T) {
+	data := map[string][]byte{"admin-password": []byte("old")}
+
+	value, changed, err := Stage(data, "admin-password")
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, value, string(data["admin-password"+PendingSuffix]))
+
+	// An interrupted rotation resumes with the staged value
+	resumed, changed, err := Stage(data, "admin-password")
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, value, resumed)
+
+	assert.True(t, Commit(data, "admin-password"))
+	assert.Equal(t, map[string][]byte{"admin-password": []byte(value)}, data)
+	assert.False(t, Commit(data, "admin-password"))
+}
+
+// fakeGrafana accepts the admin password it holds
+type fakeGrafana struct {
+	password string
+}
+
+func (f *fakeGrafana) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, password, ok := r.BasicAuth()
+	if !ok || user != "admin" || password != f.password {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/user":
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodPut && r.URL.Path == "/api/user/password":
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["newPassword"] != body["confirmNew"] {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.password = body["newPassword"]
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestGrafanaChangePassword(t *testing.T) {
+	fake := &fakeGrafana{password: "old"}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	grafana := NewGrafana(server.URL+"/", "admin")
+	ctx := context.Background()
+
+	require.NoError(t, grafana.ChangePassword(ctx, "old", "new"))
+	assert.Equal(t, "new", fake.password)
+
+	// Resumed after Grafana accepted the new password
+	require.NoError(t, grafana.ChangePassword(ctx, "old", "new"))
+
+	err := grafana.ChangePassword(ctx, "wrong", "other")
+	assert.Error(t, err)
+	assert.Equal(t, "new", fake.password)
+
+	ok, err := grafana.Authenticates(ctx, "new")
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
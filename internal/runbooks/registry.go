@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package runbooks resolves runbook keys to the runbook_url annotation added
+// to operator-generated alerts.
+package runbooks
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// AnnotationKey is the alert annotation holding the runbook link
+const AnnotationKey = "runbook_url"
+
+// Registry maps runbook keys to URLs
+type Registry struct {
+	baseURL    string
+	entries    map[string]string
+	defaultKey string
+}
+
+// NewRegistry creates a registry from the spec entries and, optionally, the
+// data of a ConfigMap. Spec entries take precedence over ConfigMap entries.
+func NewRegistry(baseURL string, entries, configMapData map[string]string, defaultKey string) *Registry {
+	merged := make(map[string]string, len(entries)+len(configMapData))
+	for k, v := range configMapData {
+		merged[k] = strings.TrimSpace(v)
+	}
+	for k, v := range entries {
+		merged[k] = strings.TrimSpace(v)
+	}
+	return &Registry{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		entries:    merged,
+		defaultKey: defaultKey,
+	}
+}
+
+// Has reports whether the registry contains key
+func (r *Registry) Has(key string) bool {
+	_, ok := r.entries[key]
+	return ok
+}
+
+// Resolve returns the URL of a runbook key
+func (r *Registry) Resolve(key string) (string, bool) {
+	entry, ok := r.entries[key]
+	if !ok {
+		return "", false
+	}
+	if r.baseURL == "" || isAbsolute(entry) {
+		return entry, true
+	}
+	return r.baseURL + "/" + strings.TrimPrefix(entry, "/"), true
+}
+
+// ForAlert returns the runbook URL for an alert. An explicit key must exist;
+// otherwise the alert name is tried, then the default key. An empty result
+// means the alert has no runbook.
+func (r *Registry) ForAlert(key, alertName string) (string, error) {
+	if key != "" {
+		link, ok := r.Resolve(key)
+		if !ok {
+			return "", fmt.Errorf("runbook key %q not found in registry", key)
+		}
+		return link, nil
+	}
+	if link, ok := r.Resolve(alertName); ok {
+		return link, nil
+	}
+	if r.defaultKey != "" {
+		if link, ok := r.Resolve(r.defaultKey); ok {
+			return link, nil
+		}
+	}
+	return "", nil
+}
+
+// Annotate sets the runbook_url annotation unless the alert already has one.
+// The annotations map is returned, allocated if it was nil.
+func (r *Registry) Annotate(annotations map[string]string, key, alertName string) (map[string]string, error) {
+	if annotations[AnnotationKey] != "" {
+		return annotations, nil
+	}
+	link, err := r.ForAlert(key, alertName)
+	if err != nil || link == "" {
+		return annotations, err
+	}
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[AnnotationKey] = link
+	return annotations, nil
+}
+
+// ValidateEntry checks that an entry is an absolute http(s) URL, or a
+// relative path when a base URL is configured
+func ValidateEntry(baseURL, entry string) error {
+	if entry == "" {
+		return fmt.Errorf("runbook entry must not be empty")
+	}
+	if isAbsolute(entry) {
+		u, err := url.Parse(entry)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("invalid runbook URL %q", entry)
+		}
+		return nil
+	}
+	if baseURL == "" {
+		return fmt.Errorf("relative runbook path %q requires a baseURL", entry)
+	}
+	return nil
+}
+
+func isAbsolute(entry string) bool {
+	return strings.HasPrefix(entry, "http://") || strings.HasPrefix(entry, "https://")
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package runbooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryForAlert(t *testing.T) {
+	registry := NewRegistry("https://runbooks.example.com/",
+		map[string]string{
+			"HighErrorRate": "/errors",
+			"default":       "https://wiki.example.com/oncall",
+		},
+		map[string]string{
+			"HighErrorRate": "ignored",
+			"DiskFull":      "storage/disk-full",
+		},
+		"default",
+	)
+
+	tests := []struct {
+		name      string
+		key       string
+		alertName string
+		want      string
+		wantErr   bool
+	}{
+		{name: "explicit key", key: "DiskFull", want: "https://runbooks.example.com/storage/disk-full"},
+		{name: "alert name, spec wins over configmap", alertName: "HighErrorRate", want: "https://runbooks.example.com/errors"},
+		{name: "default key", alertName: "Unknown", want: "https://wiki.example.com/oncall"},
+		{name: "missing explicit key", key: "nope", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := registry.ForAlert(tt.key, tt.alertName)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRegistryAnnotate(t *testing.T) {
+	registry := NewRegistry("", map[string]string{"PodCrashLooping": "https://example.com/crash"}, nil, "")
+
+	annotations, err := registry.Annotate(nil, "", "PodCrashLooping")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/crash", annotations[AnnotationKey])
+
+	existing := map[string]string{AnnotationKey: "https://team.example.com/custom"}
+	annotations, err = registry.Annotate(existing, "", "PodCrashLooping")
+	require.NoError(t, err)
+	assert.Equal(t, "https://team.example.com/custom", annotations[AnnotationKey])
+
+	annotations, err = registry.Annotate(nil, "", "NoRunbook")
+	require.NoError(t, err)
+	assert.Nil(t, annotations)
+}
+
+func TestValidateEntry(t *testing.T) {
+	assert.NoError(t, ValidateEntry("", "https://example.com/a"))
+	assert.NoError(t, ValidateEntry("https://example.com", "a/b"))
+	assert.Error(t, ValidateEntry("", "a/b"))
+	assert.Error(t, ValidateEntry("", "https://"))
+	assert.Error(t, ValidateEntry("", ""))
+}
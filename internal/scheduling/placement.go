@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package scheduling resolves the pod placement of components (affinity and
+// topology spread constraints) from platform-wide and per-component settings.
+package scheduling
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Placement is the pod placement of a component
+type Placement struct {
+	Affinity                  *corev1.Affinity
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint
+}
+
+// Resolve returns the placement of a component. Component settings replace
+// the global ones field by field: a component affinity replaces the global
+// affinity, and component constraints replace all global constraints.
+func Resolve(global, component Placement) Placement {
+	resolved := global
+	if component.Affinity != nil {
+		resolved.Affinity = component.Affinity
+	}
+	if len(component.TopologySpreadConstraints) > 0 {
+		resolved.TopologySpreadConstraints = component.TopologySpreadConstraints
+	}
+	return resolved
+}
+
+// Apply sets the placement on a pod spec. Constraints without a label
+// selector are given one matching selector, so they spread the component's
+// own pods; the placement itself is not modified.
+func Apply(podSpec *corev1.PodSpec, placement Placement, selector map[string]string) {
+	if placement.Affinity != nil {
+		podSpec.Affinity = placement.Affinity.DeepCopy()
+	}
+	if constraints := placement.SpreadConstraints(selector); len(constraints) > 0 {
+		podSpec.TopologySpreadConstraints = constraints
+	}
+}
+
+// SpreadConstraints returns copies of the topology spread constraints, those
+// without a label selector given one matching selector
+func (p Placement) SpreadConstraints(selector map[string]string) []corev1.TopologySpreadConstraint {
+	if len(p.TopologySpreadConstraints) == 0 {
+		return nil
+	}
+	constraints := make([]corev1.TopologySpreadConstraint, 0, len(p.TopologySpreadConstraints))
+	for _, c := range p.TopologySpreadConstraints {
+		constraint := *c.DeepCopy()
+		if constraint.LabelSelector == nil && len(selector) > 0 {
+			constraint.LabelSelector = &metav1.LabelSelector{MatchLabels: copyLabels(selector)}
+		}
+		constraints = append(constraints, constraint)
+	}
+	return constraints
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	out := make(map[string]string, len(labels))
+	for k, v := range labels {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package scheduling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func zoneSpread() corev1.TopologySpreadConstraint {
+	return corev1.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       "topology.kubernetes.io/zone",
+		WhenUnsatisfiable: corev1.DoNotSchedule,
+	}
+}
+
+func TestResolve(t *testing.T) {
+	globalAffinity := &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{}}
+	componentAffinity := &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{}}
+	hostSpread := corev1.TopologySpreadConstraint{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: corev1.ScheduleAnyway}
+
+	global := Placement{Affinity: globalAffinity, TopologySpreadConstraints: []corev1.TopologySpreadConstraint{zoneSpread()}}
+
+	resolved := Resolve(global, Placement{})
+	assert.Equal(t, global, resolved)
+
+	resolved = Resolve(global, Placement{Affinity: componentAffinity})
+	assert.Same(t, componentAffinity, resolved.Affinity)
+	assert.Equal(t, global.TopologySpreadConstraints, resolved.TopologySpreadConstraints)
+
+	resolved = Resolve(global, Placement{TopologySpreadConstraints: []corev1.TopologySpreadConstraint{hostSpread}})
+	assert.Same(t, globalAffinity, resolved.Affinity)
+	assert.Equal(t, []corev1.TopologySpreadConstraint{hostSpread}, resolved.TopologySpreadConstraints)
+}
+
+func TestApply(t *testing.T) {
+	explicit := zoneSpread()
+	explicit.LabelSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "storage"}}
+	placement := Placement{
+		Affinity:                  &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{}},
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{zoneSpread(), explicit},
+	}
+	selector := map[string]string{"app.kubernetes.io/name": "loki"}
+
+	podSpec := &corev1.PodSpec{}
+	Apply(podSpec, placement, selector)
+
+	require.NotNil(t, podSpec.Affinity)
+	assert.NotSame(t, placement.Affinity, podSpec.Affinity)
+	require.Len(t, podSpec.TopologySpreadConstraints, 2)
+	assert.Equal(t, selector, podSpec.TopologySpreadConstraints[0].LabelSelector.MatchLabels)
+	assert.Equal(t, map[string]string{"tier": "storage"}, podSpec.TopologySpreadConstraints[1].LabelSelector.MatchLabels)
+	assert.Nil(t, placement.TopologySpreadConstraints[0].LabelSelector)
+
+	empty := &corev1.PodSpec{}
+	Apply(empty, Placement{}, selector)
+	assert.Nil(t, empty.Affinity)
+	assert.Nil(t, empty.TopologySpreadConstraints)
+}
+
+func TestSpreadConstraints(t *testing.T) {
+	placement := Placement{TopologySpreadConstraints: []corev1.TopologySpreadConstraint{zoneSpread()}}
+	selector := map[string]string{"app.kubernetes.io/instance": "prod-tempo"}
+
+	constraints := placement.SpreadConstraints(selector)
+	require.Len(t, constraints, 1)
+	assert.Equal(t, selector, constraints[0].LabelSelector.MatchLabels)
+	assert.Nil(t, placement.TopologySpreadConstraints[0].LabelSelector)
+
+	assert.Nil(t, Placement{}.SpreadConstraints(selector))
+}
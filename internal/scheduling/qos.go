@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package scheduling
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// guaranteedResources are the resources that must have equal requests and
+// limits for a pod to be in the Guaranteed QoS class
+var guaranteedResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+// Priority is the scheduling priority of a component
+type Priority struct {
+	PriorityClassName string
+	GuaranteedQoS     bool
+}
+
+// ResolvePriority returns the priority of a component. A component priority
+// class replaces the global one, and a component QoS setting, if set,
+// replaces the global one.
+func ResolvePriority(global Priority, priorityClassName string, guaranteedQoS *bool) Priority {
+	resolved := global
+	if priorityClassName != "" {
+		resolved.PriorityClassName = priorityClassName
+	}
+	if guaranteedQoS != nil {
+		resolved.GuaranteedQoS = *guaranteedQoS
+	}
+	return resolved
+}
+
+// ApplyPriority sets the priority class on a pod spec and, if requested,
+// enforces the Guaranteed QoS class. It returns the containers that could not
+// be made guaranteed because neither a request nor a limit is set for CPU or
+// memory.
+func ApplyPriority(podSpec *corev1.PodSpec, priority Priority) []string {
+	if priority.PriorityClassName != "" {
+		podSpec.PriorityClassName = priority.PriorityClassName
+		// The admission controller resolves the value from the class
+		podSpec.Priority = nil
+	}
+	if !priority.GuaranteedQoS {
+		return nil
+	}
+	return EnforceGuaranteed(podSpec)
+}
+
+// EnforceGuaranteed makes the requests and limits of all containers equal
+// for CPU and memory. A limit wins over a request, since lowering a limit
+// could make a running workload get OOM-killed or throttled. It returns the
+// containers missing a value for CPU or memory.
+func EnforceGuaranteed(podSpec *corev1.PodSpec) []string {
+	var incomplete []string
+	for i := range podSpec.InitContainers {
+		if !guarantee(&podSpec.InitContainers[i].Resources) {
+			incomplete = append(incomplete, podSpec.InitContainers[i].Name)
+		}
+	}
+	for i := range podSpec.Containers {
+		if !guarantee(&podSpec.Containers[i].Resources) {
+			incomplete = append(incomplete, podSpec.Containers[i].Name)
+		}
+	}
+	return incomplete
+}
+
+// guarantee equalizes the requests and limits of a container and reports
+// whether every guaranteed resource has a value
+func guarantee(resources *corev1.ResourceRequirements) bool {
+	complete := true
+	for _, name := range guaranteedResources {
+		var value resource.Quantity
+		if limit, ok := resources.Limits[name]; ok && !limit.IsZero() {
+			value = limit
+		} else if request, ok := resources.Requests[name]; ok && !request.IsZero() {
+			value = request
+		} else {
+			complete = false
+			continue
+		}
+
+		if resources.Requests == nil {
+			resources.Requests = corev1.ResourceList{}
+		}
+		if resources.Limits == nil {
+			resources.Limits = corev1.ResourceList{}
+		}
+		resources.Requests[name] = value.DeepCopy()
+		resources.Limits[name] = value.DeepCopy()
+	}
+	return complete
+}
+
+// QOSClass returns the QoS class Kubernetes assigns to a pod spec
+func QOSClass(podSpec *corev1.PodSpec) corev1.PodQOSClass {
+	containers := make([]corev1.Container, 0, len(podSpec.InitContainers)+len(podSpec.Containers))
+	containers = append(containers, podSpec.InitContainers...)
+	containers = append(containers, podSpec.Containers...)
+
+	guaranteed := true
+	burstable := false
+	for _, c := range containers {
+		// A limit without a request defaults the request to the limit, so only
+		// explicit requests can differ from their limits
+		for name, request := range c.Resources.Requests {
+			if !request.IsZero() {
+				burstable = true
+			}
+			// A limit without a request defaults the request to the limit
+			if limit, ok := c.Resources.Limits[name]; !ok || limit.Cmp(request) != 0 {
+				guaranteed = false
+			}
+		}
+		for _, limit := range c.Resources.Limits {
+			if !limit.IsZero() {
+				burstable = true
+			}
+		}
+		for _, name := range guaranteedResources {
+			if limit, ok := c.Resources.Limits[name]; !ok || limit.IsZero() {
+				guaranteed = false
+			}
+		}
+	}
+
+	switch {
+	case !burstable:
+		return corev1.PodQOSBestEffort
+	case guaranteed:
+		return corev1.PodQOSGuaranteed
+	default:
+		return corev1.PodQOSBurstable
+	}
+}
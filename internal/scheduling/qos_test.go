@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package scheduling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestResolvePriority(t *testing.T) {
+	global := Priority{PriorityClassName: "observability-critical", GuaranteedQoS: true}
+
+	assert.Equal(t, global, ResolvePriority(global, "", nil))
+
+	disabled := false
+	resolved := ResolvePriority(global, "observability-high", &disabled)
+	assert.Equal(t, "observability-high", resolved.PriorityClassName)
+	assert.False(t, resolved.GuaranteedQoS)
+}
+
+func TestApplyPriority(t *testing.T) {
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "prometheus",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("500m"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1"),
+					},
+				},
+			},
+			{Name: "config-reloader"},
+		},
+	}
+	assert.Equal(t, corev1.PodQOSBurstable, QOSClass(podSpec))
+
+	incomplete := ApplyPriority(podSpec, Priority{PriorityClassName: "observability-critical", GuaranteedQoS: true})
+	assert.Equal(t, []string{"config-reloader"}, incomplete)
+	assert.Equal(t, "observability-critical", podSpec.PriorityClassName)
+
+	resources := podSpec.Containers[0].Resources
+	assert.True(t, resources.Requests.Cpu().Equal(resource.MustParse("1")))
+	assert.True(t, resources.Limits.Memory().Equal(resource.MustParse("1Gi")))
+
+	podSpec.Containers = podSpec.Containers[:1]
+	assert.Equal(t, corev1.PodQOSGuaranteed, QOSClass(podSpec))
+
+	assert.Equal(t, corev1.PodQOSBestEffort, QOSClass(&corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}))
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package selfmonitoring lets the platforms the operator manages monitor the
+// operator. The platform's Prometheus discovers the operator's metrics
+// Service by its app=gunj-operator label in whichever namespace the operator
+// runs, and Grafana provisions a dashboard of the operator's metrics. Clusters
+// running the Prometheus Operator can get a ServiceMonitor instead.
+package selfmonitoring
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// Job is the Prometheus job of the operator
+	Job = "gunj-operator"
+	// ServiceLabel is the label of the operator's metrics Service
+	ServiceLabel = "app"
+	// ServiceLabelValue is the value of ServiceLabel
+	ServiceLabelValue = "gunj-operator"
+	// PortName is the name of the metrics port of the Service
+	PortName = "metrics"
+	// DashboardKey is the key of the dashboard in the dashboards ConfigMap
+	DashboardKey = "gunj-operator.json"
+)
+
+// ServiceMonitorName returns the name of the ServiceMonitor of a platform
+func ServiceMonitorName(platform string) string {
+	return "gunj-operator-" + platform
+}
+
+// ScrapeConfig returns the scrape_configs entry of the operator, indented to
+// be appended to the scrape_configs of prometheus.yml
+func ScrapeConfig() string {
+	return `
+
+  # Gunj operator self-monitoring
+  - job_name: '` + Job + `'
+    kubernetes_sd_configs:
+      - role: endpoints
+    relabel_configs:
+      - source_labels: [__meta_kubernetes_service_label_` + ServiceLabel + `, __meta_kubernetes_endpoint_port_name]
+        action: keep
+        regex: ` + ServiceLabelValue + `;` + PortName + `
+      - source_labels: [__meta_kubernetes_namespace]
+        target_label: namespace
+      - source_labels: [__meta_kubernetes_pod_name]
+        target_label: pod`
+}
+
+// ServiceMonitor returns the ServiceMonitor selecting the operator's metrics
+// Service in any namespace
+func ServiceMonitor(name, namespace string, labels map[string]string) *unstructured.Unstructured {
+	objLabels := map[string]interface{}{}
+	for k, v := range labels {
+		objLabels[k] = v
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "monitoring.coreos.com/v1",
+		"kind":       "ServiceMonitor",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+			"labels":    objLabels,
+		},
+		"spec": map[string]interface{}{
+			"jobLabel":          ServiceLabel,
+			"namespaceSelector": map[string]interface{}{"any": true},
+			"selector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{ServiceLabel: ServiceLabelValue},
+			},
+			"endpoints": []interface{}{map[string]interface{}{
+				"port":     PortName,
+				"path":     "/metrics",
+				"interval": "30s",
+			}},
+		},
+	}}
+}
+
+// Dashboard returns the Grafana dashboard of the operator's metrics
+func Dashboard() string {
+	datasource := map[string]string{"type": "prometheus", "uid": "${datasource}"}
+	panel := func(id int, title, panelType, expr, legend string, x, y, w, h int) map[string]interface{} {
+		return map[string]interface{}{
+			"id":         id,
+			"title":      title,
+			"type":       panelType,
+			"datasource": datasource,
+			"gridPos":    map[string]int{"x": x, "y": y, "w": w, "h": h},
+			"targets": []interface{}{map[string]interface{}{
+				"refId":        "A",
+				"expr":         expr,
+				"legendFormat": legend,
+				"datasource":   datasource,
+			}},
+		}
+	}
+
+	dashboard := map[string]interface{}{
+		"uid":           "gunj-operator",
+		"title":         "Gunj Operator",
+		"tags":          []string{"gunj-operator", "self-monitoring"},
+		"schemaVersion": 39,
+		"time":          map[string]string{"from": "now-6h", "to": "now"},
+		"templating": map[string]interface{}{"list": []interface{}{
+			map[string]interface{}{"name": "datasource", "type": "datasource", "query": "prometheus"},
+		}},
+		"panels": []interface{}{
+			panel(1, "Operator up", "stat", `sum(up{job="gunj-operator"})`, "", 0, 0, 6, 4),
+			panel(2, "Platforms by phase", "stat", `sum by (phase) (gunj_operator_platforms_total)`, "{{phase}}", 6, 0, 18, 4),
+			panel(3, "Reconciliations/s", "timeseries", `sum by (controller) (rate(gunj_operator_reconcile_total[5m]))`, "{{controller}}", 0, 4, 12, 8),
+			panel(4, "Reconcile errors/s", "timeseries", `sum by (controller) (rate(gunj_operator_reconcile_errors_total[5m]))`, "{{controller}}", 12, 4, 12, 8),
+			panel(5, "Reconcile duration p95", "timeseries", `histogram_quantile(0.95, sum by (le, controller) (rate(gunj_operator_reconcile_duration_seconds_bucket[5m])))`, "{{controller}}", 0, 12, 12, 8),
+			panel(6, "Work queue depth", "timeseries", `sum by (name) (workqueue_depth{job="gunj-operator"})`, "{{name}}", 12, 12, 12, 8),
+			panel(7, "Components not ready", "table", `gunj_operator_component_status == 0`, "", 0, 20, 12, 8),
+			panel(8, "Operator memory", "timeseries", `process_resident_memory_bytes{job="gunj-operator"}`, "{{pod}}", 12, 20, 12, 8),
+		},
+	}
+	data, _ := json.MarshalIndent(dashboard, "", "  ")
+	return string(data)
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package selfmonitoring
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func TestScrapeConfigKeepsOperatorService(t *testing.T) {
+	var parsed []struct {
+		JobName        string `json:"job_name"`
+		RelabelConfigs []struct {
+			SourceLabels []string `json:"source_labels"`
+			Action       string   `json:"action"`
+			Regex        string   `json:"regex"`
+		} `json:"relabel_configs"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(ScrapeConfig()), &parsed))
+	require.Len(t, parsed, 1)
+	assert.Equal(t, "gunj-operator", parsed[0].JobName)
+	assert.Equal(t, "keep", parsed[0].RelabelConfigs[0].Action)
+	assert.Equal(t, "gunj-operator;metrics", parsed[0].RelabelConfigs[0].Regex)
+}
+
+func TestServiceMonitorSelectsAnyNamespace(t *testing.T) {
+	sm := ServiceMonitor(ServiceMonitorName("prod"), "monitoring", map[string]string{"observability.io/platform": "prod"})
+	assert.Equal(t, "gunj-operator-prod", sm.GetName())
+	assert.Equal(t, "ServiceMonitor", sm.GetKind())
+	any, _, _ := unstructured.NestedBool(sm.Object, "spec", "namespaceSelector", "any")
+	assert.True(t, any)
+	selector, _, _ := unstructured.NestedStringMap(sm.Object, "spec", "selector", "matchLabels")
+	assert.Equal(t, map[string]string{"app": "gunj-operator"}, selector)
+}
+
+func TestDashboardIsValidJSON(t *testing.T) {
+	var dashboard map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(Dashboard()), &dashboard))
+	assert.Equal(t, "gunj-operator", dashboard["uid"])
+	assert.Len(t, dashboard["panels"], 8)
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package sidecars injects user-defined extra containers and volumes, such as
+// log shippers, auth proxies or exporters, into the pods of managed
+// components.
+package sidecars
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Reserved are the container names, volume names and ports the operator
+// uses in the pods of a component
+type Reserved struct {
+	Containers []string
+	Volumes    []string
+	Ports      []int32
+}
+
+// ComponentReserved are the reserved names and ports per component. The WAL
+// volume is reserved for the components that support a dedicated WAL, and the
+// Thanos sidecar's names and ports in the Prometheus pods.
+var ComponentReserved = map[string]Reserved{
+	"prometheus": {
+		Containers: []string{"prometheus", "thanos-sidecar"},
+		Volumes:    []string{"config", "data", "wal", "thanos-objstore"},
+		Ports:      []int32{9090, 10901, 10902},
+	},
+	"grafana": {
+		Containers: []string{"grafana", "install-plugins"},
+		Volumes:    []string{"config", "datasources", "dashboard-provider", "dashboards", "jsonnet-dashboards", "data", "plugins"},
+		Ports:      []int32{3000},
+	},
+	"loki": {
+		Containers: []string{"loki", "compactor"},
+		Volumes:    []string{"config", "data", "wal"},
+		Ports:      []int32{3100, 7946, 9095},
+	},
+	"tempo": {
+		Containers: []string{"tempo"},
+		Volumes:    []string{"config", "storage", "wal"},
+		Ports:      []int32{3200, 4317, 4318, 6831, 6832, 7946, 9095, 9411, 14250, 14268},
+	},
+	"opentelemetryCollector": {
+		Containers: []string{"opentelemetry-collector"},
+		Volumes:    []string{"config"},
+	},
+}
+
+// Apply appends copies of the extra containers and volumes to a pod spec.
+// Entries whose name is already used in the pod are skipped, so an extra can
+// never replace a managed container or volume; their names are returned.
+func Apply(podSpec *corev1.PodSpec, containers []corev1.Container, volumes []corev1.Volume) []string {
+	var skipped []string
+
+	used := make(map[string]bool, len(podSpec.InitContainers)+len(podSpec.Containers))
+	for _, c := range podSpec.InitContainers {
+		used[c.Name] = true
+	}
+	for _, c := range podSpec.Containers {
+		used[c.Name] = true
+	}
+	for _, c := range containers {
+		if used[c.Name] {
+			skipped = append(skipped, c.Name)
+			continue
+		}
+		used[c.Name] = true
+		podSpec.Containers = append(podSpec.Containers, *c.DeepCopy())
+	}
+
+	usedVolumes := make(map[string]bool, len(podSpec.Volumes))
+	for _, v := range podSpec.Volumes {
+		usedVolumes[v.Name] = true
+	}
+	for _, v := range volumes {
+		if usedVolumes[v.Name] {
+			skipped = append(skipped, v.Name)
+			continue
+		}
+		usedVolumes[v.Name] = true
+		podSpec.Volumes = append(podSpec.Volumes, *v.DeepCopy())
+	}
+
+	return skipped
+}
+
+// Validate checks the extra containers and volumes of a component: names must
+// be set, unique and not reserved, containers need an image, ports must not
+// clash with each other or the component's ports, and volume mounts must
+// reference a declared or reserved volume.
+func Validate(reserved Reserved, containers []corev1.Container, volumes []corev1.Volume, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	reservedContainers := toSet(reserved.Containers)
+	reservedVolumes := toSet(reserved.Volumes)
+	ports := make(map[int32]string, len(reserved.Ports))
+	for _, port := range reserved.Ports {
+		ports[port] = "the component"
+	}
+
+	knownVolumes := toSet(reserved.Volumes)
+	volumesPath := fldPath.Child("extraVolumes")
+	for i, v := range volumes {
+		namePath := volumesPath.Index(i).Child("name")
+		switch {
+		case v.Name == "":
+			allErrs = append(allErrs, field.Required(namePath, "volume name is required"))
+		case reservedVolumes[v.Name]:
+			allErrs = append(allErrs, field.Invalid(namePath, v.Name, "volume name is reserved by the operator"))
+		case knownVolumes[v.Name]:
+			allErrs = append(allErrs, field.Duplicate(namePath, v.Name))
+		default:
+			knownVolumes[v.Name] = true
+		}
+	}
+
+	seen := map[string]bool{}
+	containersPath := fldPath.Child("extraContainers")
+	for i, c := range containers {
+		containerPath := containersPath.Index(i)
+		namePath := containerPath.Child("name")
+		switch {
+		case c.Name == "":
+			allErrs = append(allErrs, field.Required(namePath, "container name is required"))
+		case reservedContainers[c.Name]:
+			allErrs = append(allErrs, field.Invalid(namePath, c.Name, "container name is reserved by the operator"))
+		case seen[c.Name]:
+			allErrs = append(allErrs, field.Duplicate(namePath, c.Name))
+		default:
+			seen[c.Name] = true
+		}
+
+		if c.Image == "" {
+			allErrs = append(allErrs, field.Required(containerPath.Child("image"), "container image is required"))
+		}
+
+		// Containers of a pod share its network namespace
+		for j, port := range c.Ports {
+			if owner, ok := ports[port.ContainerPort]; ok {
+				allErrs = append(allErrs, field.Invalid(containerPath.Child("ports").Index(j).Child("containerPort"),
+					port.ContainerPort, fmt.Sprintf("port is already used by %s", owner)))
+				continue
+			}
+			ports[port.ContainerPort] = fmt.Sprintf("container %q", c.Name)
+		}
+
+		for j, mount := range c.VolumeMounts {
+			if !knownVolumes[mount.Name] {
+				allErrs = append(allErrs, field.NotFound(containerPath.Child("volumeMounts").Index(j).Child("name"), mount.Name))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// toSet returns the names as a set
+func toSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
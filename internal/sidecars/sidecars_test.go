@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package sidecars
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func emptyDir(name string) corev1.Volume {
+	return corev1.Volume{Name: name, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}
+}
+
+func TestApply(t *testing.T) {
+	podSpec := &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "install-plugins"}},
+		Containers:     []corev1.Container{{Name: "grafana", Image: "grafana/grafana:10.2.0"}},
+		Volumes:        []corev1.Volume{emptyDir("data")},
+	}
+	containers := []corev1.Container{
+		{Name: "oauth2-proxy", Image: "quay.io/oauth2-proxy/oauth2-proxy:v7.5.1"},
+		{Name: "grafana", Image: "evil:latest"},
+		{Name: "install-plugins", Image: "evil:latest"},
+	}
+	volumes := []corev1.Volume{emptyDir("proxy-cache"), emptyDir("data")}
+
+	skipped := Apply(podSpec, containers, volumes)
+
+	assert.Equal(t, []string{"grafana", "install-plugins", "data"}, skipped)
+	require.Len(t, podSpec.Containers, 2)
+	assert.Equal(t, "grafana/grafana:10.2.0", podSpec.Containers[0].Image)
+	assert.Equal(t, "oauth2-proxy", podSpec.Containers[1].Name)
+	require.Len(t, podSpec.Volumes, 2)
+	assert.Equal(t, "proxy-cache", podSpec.Volumes[1].Name)
+
+	// The pod spec holds copies, not the user's objects
+	podSpec.Containers[1].Args = append(podSpec.Containers[1].Args, "--upstream")
+	assert.Empty(t, containers[0].Args)
+}
+
+func TestApplyIsIdempotentPerPodSpec(t *testing.T) {
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "loki"}}}
+	containers := []corev1.Container{{Name: "promtail", Image: "grafana/promtail:2.9.0"}}
+
+	Apply(podSpec, containers, nil)
+	skipped := Apply(podSpec, containers, nil)
+
+	assert.Equal(t, []string{"promtail"}, skipped)
+	assert.Len(t, podSpec.Containers, 2)
+}
+
+func TestValidate(t *testing.T) {
+	fldPath := field.NewPath("spec", "components", "loki")
+
+	tests := []struct {
+		name       string
+		containers []corev1.Container
+		volumes    []corev1.Volume
+		wantFields []string
+	}{
+		{
+			name: "valid",
+			containers: []corev1.Container{{
+				Name:         "log-shipper",
+				Image:        "fluent/fluent-bit:2.2",
+				Ports:        []corev1.ContainerPort{{ContainerPort: 2020}},
+				VolumeMounts: []corev1.VolumeMount{{Name: "buffer"}, {Name: "config"}},
+			}},
+			volumes: []corev1.Volume{emptyDir("buffer")},
+		},
+		{
+			name:       "reserved container name",
+			containers: []corev1.Container{{Name: "loki", Image: "busybox"}},
+			wantFields: []string{"spec.components.loki.extraContainers[0].name"},
+		},
+		{
+			name: "duplicate container name",
+			containers: []corev1.Container{
+				{Name: "proxy", Image: "nginx"},
+				{Name: "proxy", Image: "nginx"},
+			},
+			wantFields: []string{"spec.components.loki.extraContainers[1].name"},
+		},
+		{
+			name:       "missing name and image",
+			containers: []corev1.Container{{}},
+			wantFields: []string{
+				"spec.components.loki.extraContainers[0].name",
+				"spec.components.loki.extraContainers[0].image",
+			},
+		},
+		{
+			name:       "reserved and duplicate volume names",
+			volumes:    []corev1.Volume{emptyDir("wal"), emptyDir("cache"), emptyDir("cache")},
+			wantFields: []string{"spec.components.loki.extraVolumes[0].name", "spec.components.loki.extraVolumes[2].name"},
+		},
+		{
+			name: "port collisions",
+			containers: []corev1.Container{
+				{Name: "exporter", Image: "exporter", Ports: []corev1.ContainerPort{{ContainerPort: 3100}, {ContainerPort: 9100}}},
+				{Name: "proxy", Image: "proxy", Ports: []corev1.ContainerPort{{ContainerPort: 9100}}},
+			},
+			wantFields: []string{
+				"spec.components.loki.extraContainers[0].ports[0].containerPort",
+				"spec.components.loki.extraContainers[1].ports[0].containerPort",
+			},
+		},
+		{
+			name: "unknown volume mount",
+			containers: []corev1.Container{{
+				Name:         "proxy",
+				Image:        "proxy",
+				VolumeMounts: []corev1.VolumeMount{{Name: "missing"}},
+			}},
+			wantFields: []string{"spec.components.loki.extraContainers[0].volumeMounts[0].name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(ComponentReserved["loki"], tt.containers, tt.volumes, fldPath)
+
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package siem
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Exporter buffers records and ships them to a SIEM in batches.
+// It implements manager.Runnable so it can be added to the controller manager.
+type Exporter struct {
+	config  Config
+	sink    Sink
+	log     logr.Logger
+	queue   chan Record
+	dropped atomic.Int64
+	failed  atomic.Int64
+}
+
+// NewExporter creates an exporter for the configured SIEM backend
+func NewExporter(config Config, log logr.Logger) (*Exporter, error) {
+	sink, err := NewSink(config)
+	if err != nil {
+		return nil, err
+	}
+	return NewExporterWithSink(config, sink, log), nil
+}
+
+// NewExporterWithSink creates an exporter that delivers to the given sink
+func NewExporterWithSink(config Config, sink Sink, log logr.Logger) *Exporter {
+	config.setDefaults()
+	return &Exporter{
+		config: config,
+		sink:   sink,
+		log:    log,
+		queue:  make(chan Record, config.QueueSize),
+	}
+}
+
+// Publish queues a record for export. It never blocks; records are dropped
+// when the queue is full so a slow SIEM cannot stall reconciliation.
+func (e *Exporter) Publish(record Record) {
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now()
+	}
+	select {
+	case e.queue <- record:
+	default:
+		e.dropped.Add(1)
+	}
+}
+
+// Dropped returns the number of records dropped because the queue was full
+func (e *Exporter) Dropped() int64 {
+	return e.dropped.Load()
+}
+
+// Failed returns the number of records that could not be delivered after all retries
+func (e *Exporter) Failed() int64 {
+	return e.failed.Load()
+}
+
+// Start runs the batching loop until the context is cancelled, then flushes
+// any buffered records.
+func (e *Exporter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(e.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Record, 0, e.config.BatchSize)
+	flush := func(ctx context.Context) {
+		if len(batch) == 0 {
+			return
+		}
+		e.send(ctx, batch)
+		batch = make([]Record, 0, e.config.BatchSize)
+	}
+
+	for {
+		select {
+		case record := <-e.queue:
+			batch = append(batch, record)
+			if len(batch) >= e.config.BatchSize {
+				flush(ctx)
+			}
+		case <-ticker.C:
+			flush(ctx)
+		case <-ctx.Done():
+			// Drain whatever is already queued with a bounded grace period
+			drainCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+		drain:
+			for {
+				select {
+				case record := <-e.queue:
+					batch = append(batch, record)
+					if len(batch) >= e.config.BatchSize {
+						flush(drainCtx)
+					}
+				default:
+					break drain
+				}
+			}
+			flush(drainCtx)
+			return nil
+		}
+	}
+}
+
+// NeedLeaderElection returns false so every replica exports its own records
+func (e *Exporter) NeedLeaderElection() bool {
+	return false
+}
+
+// send delivers a batch, retrying with exponential backoff
+func (e *Exporter) send(ctx context.Context, batch []Record) {
+	backoff := e.config.RetryBackoff
+	var err error
+
+	for attempt := 0; attempt <= e.config.MaxRetries; attempt++ {
+		if err = e.sink.Send(ctx, batch); err == nil {
+			return
+		}
+		if attempt == e.config.MaxRetries {
+			break
+		}
+
+		e.log.V(1).Info("SIEM export failed, retrying", "attempt", attempt+1, "backoff", backoff, "error", err.Error())
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			e.failed.Add(int64(len(batch)))
+			e.log.Error(ctx.Err(), "SIEM export aborted", "records", len(batch))
+			return
+		}
+		backoff *= 2
+	}
+
+	e.failed.Add(int64(len(batch)))
+	e.log.Error(err, "Failed to export records to SIEM", "records", len(batch), "type", e.config.Type)
+}
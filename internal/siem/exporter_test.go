@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package siem
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSink struct {
+	mu       sync.Mutex
+	failures int
+	batches  [][]Record
+}
+
+func (s *fakeSink) Send(ctx context.Context, records []Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("unavailable")
+	}
+	s.batches = append(s.batches, append([]Record(nil), records...))
+	return nil
+}
+
+func (s *fakeSink) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, b := range s.batches {
+		n += len(b)
+	}
+	return n
+}
+
+func TestEncodeCEF(t *testing.T) {
+	record := Record{
+		Kind:      KindAudit,
+		Timestamp: time.UnixMilli(1700000000000),
+		Action:    "platform.update",
+		Severity:  SeverityMedium,
+		User:      "alice",
+		Namespace: "monitoring",
+		Name:      "prod",
+		Message:   "replicas=3\nok",
+	}
+
+	data, err := Encode(record, FormatCEF)
+	require.NoError(t, err)
+
+	line := string(data)
+	assert.True(t, strings.HasPrefix(line, "CEF:0|Gunj|gunj-operator|v1|audit:platform.update|platform.update|5|"))
+	assert.Contains(t, line, "rt=1700000000000")
+	assert.Contains(t, line, "suser=alice")
+	assert.Contains(t, line, `msg=replicas\=3\nok`)
+
+	_, err = Encode(record, "xml")
+	assert.Error(t, err)
+}
+
+func TestExporterBatchingAndRetry(t *testing.T) {
+	sink := &fakeSink{failures: 1}
+	exporter := NewExporterWithSink(Config{
+		BatchSize:     2,
+		FlushInterval: 50 * time.Millisecond,
+		MaxRetries:    2,
+		RetryBackoff:  time.Millisecond,
+	}, sink, logr.Discard())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = exporter.Start(ctx)
+		close(done)
+	}()
+
+	for i := 0; i < 3; i++ {
+		exporter.Publish(Record{Kind: KindPlatform, Action: "PlatformReady"})
+	}
+
+	assert.Eventually(t, func() bool { return sink.count() == 3 }, 2*time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+
+	assert.Equal(t, int64(0), exporter.Failed())
+	assert.Equal(t, int64(0), exporter.Dropped())
+}
+
+func TestExporterDropsWhenQueueFull(t *testing.T) {
+	exporter := NewExporterWithSink(Config{QueueSize: 1}, &fakeSink{}, logr.Discard())
+
+	exporter.Publish(Record{Action: "a"})
+	exporter.Publish(Record{Action: "b"})
+
+	assert.Equal(t, int64(1), exporter.Dropped())
+}
+
+func TestSplunkSink(t *testing.T) {
+	var auth, path string
+	var lines int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		path = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		lines = strings.Count(string(body), `"sourcetype":"gunj:audit"`)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink, err := NewSink(Config{Type: SinkTypeSplunk, Endpoint: server.URL, Token: "secret"})
+	require.NoError(t, err)
+
+	err = sink.Send(context.Background(), []Record{
+		{Kind: KindAudit, Action: "create"},
+		{Kind: KindAudit, Action: "delete"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Splunk secret", auth)
+	assert.Equal(t, "/services/collector/event", path)
+	assert.Equal(t, 2, lines)
+}
+
+func TestElasticSinkReportsRejectedDocuments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scanner := bufio.NewScanner(r.Body)
+		n := 0
+		for scanner.Scan() {
+			n++
+		}
+		assert.Equal(t, 2, n)
+		_, _ = w.Write([]byte(`{"errors":true}`))
+	}))
+	defer server.Close()
+
+	sink, err := NewSink(Config{Type: SinkTypeElastic, Endpoint: server.URL})
+	require.NoError(t, err)
+
+	err = sink.Send(context.Background(), []Record{{Kind: KindPlatform, Action: "PlatformFailed"}})
+	assert.Error(t, err)
+}
+
+func TestNewSinkValidation(t *testing.T) {
+	_, err := NewSink(Config{Type: SinkTypeSplunk})
+	assert.Error(t, err)
+
+	_, err = NewSink(Config{Type: "kafka", Endpoint: "localhost:9092"})
+	assert.Error(t, err)
+
+	_, err = NewSink(Config{Type: SinkTypeSyslog, Endpoint: "localhost:514", Network: "unix"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package siem
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	cefVendor  = "Gunj"
+	cefProduct = "gunj-operator"
+	cefVersion = "v1"
+)
+
+// Encode encodes a record in the given format
+func Encode(record Record, format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON, "":
+		return json.Marshal(record)
+	case FormatCEF:
+		return []byte(encodeCEF(record)), nil
+	default:
+		return nil, fmt.Errorf("unsupported SIEM format %q", format)
+	}
+}
+
+// encodeCEF renders a record as a CEF:0 line
+func encodeCEF(record Record) string {
+	header := []string{
+		"CEF:0",
+		cefHeaderEscape(cefVendor),
+		cefHeaderEscape(cefProduct),
+		cefHeaderEscape(cefVersion),
+		cefHeaderEscape(string(record.Kind) + ":" + record.Action),
+		cefHeaderEscape(record.Action),
+		fmt.Sprintf("%d", record.Severity),
+	}
+
+	ext := []string{fmt.Sprintf("rt=%d", record.Timestamp.UnixMilli())}
+	add := func(key, value string) {
+		if value != "" {
+			ext = append(ext, key+"="+cefExtensionEscape(value))
+		}
+	}
+	add("suser", record.User)
+	add("src", record.SourceIP)
+	add("cs1Label", "namespace")
+	add("cs1", record.Namespace)
+	add("cs2Label", "name")
+	add("cs2", record.Name)
+	add("request", record.Resource)
+	add("outcome", record.Outcome)
+	add("msg", record.Message)
+
+	keys := make([]string, 0, len(record.Details))
+	for k := range record.Details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		add("gunj"+k, record.Details[k])
+	}
+
+	return strings.Join(header, "|") + "|" + strings.Join(ext, " ")
+}
+
+// cefHeaderEscape escapes pipes and backslashes in CEF header fields
+func cefHeaderEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// cefExtensionEscape escapes backslashes, equals signs and newlines in CEF extension values
+func cefExtensionEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "=", `\=`)
+	s = strings.ReplaceAll(s, "\r", `\r`)
+	return strings.ReplaceAll(s, "\n", `\n`)
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package siem
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// NewSink creates the sink for the configured SIEM backend
+func NewSink(config Config) (Sink, error) {
+	config.setDefaults()
+
+	if config.Endpoint == "" {
+		return nil, fmt.Errorf("SIEM endpoint is required")
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+
+	switch config.Type {
+	case SinkTypeSplunk:
+		return &splunkSink{config: config, client: httpClient}, nil
+	case SinkTypeElastic:
+		return &elasticSink{config: config, client: httpClient}, nil
+	case SinkTypeSyslog:
+		if config.Network != "tcp" && config.Network != "udp" {
+			return nil, fmt.Errorf("unsupported syslog network %q", config.Network)
+		}
+		hostname, _ := os.Hostname()
+		return &syslogSink{config: config, hostname: hostname}, nil
+	default:
+		return nil, fmt.Errorf("unsupported SIEM type %q", config.Type)
+	}
+}
+
+// splunkSink sends records to a Splunk HTTP Event Collector
+type splunkSink struct {
+	config Config
+	client *http.Client
+}
+
+// splunkEvent is the HEC event envelope
+type splunkEvent struct {
+	Time       float64     `json:"time"`
+	Index      string      `json:"index,omitempty"`
+	Source     string      `json:"source"`
+	SourceType string      `json:"sourcetype"`
+	Event      interface{} `json:"event"`
+}
+
+func (s *splunkSink) Send(ctx context.Context, records []Record) error {
+	var body bytes.Buffer
+	for _, record := range records {
+		event, err := s.event(record)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(splunkEvent{
+			Time:       float64(record.Timestamp.UnixNano()) / float64(time.Second),
+			Index:      s.config.Index,
+			Source:     cefProduct,
+			SourceType: "gunj:" + string(record.Kind),
+			Event:      event,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to encode HEC event: %w", err)
+		}
+		body.Write(data)
+	}
+
+	url := strings.TrimSuffix(s.config.Endpoint, "/") + "/services/collector/event"
+	return postBatch(ctx, s.client, url, "Splunk "+s.config.Token, "application/json", &body)
+}
+
+// event returns the HEC event payload in the configured format
+func (s *splunkSink) event(record Record) (interface{}, error) {
+	if s.config.Format == FormatCEF {
+		return encodeCEF(record), nil
+	}
+	return record, nil
+}
+
+// elasticSink sends records to Elasticsearch using the bulk API
+type elasticSink struct {
+	config Config
+	client *http.Client
+}
+
+// elasticBulkResponse is the subset of the bulk API response we inspect
+type elasticBulkResponse struct {
+	Errors bool `json:"errors"`
+}
+
+func (s *elasticSink) Send(ctx context.Context, records []Record) error {
+	var body bytes.Buffer
+	action, _ := json.Marshal(map[string]interface{}{
+		"index": map[string]string{"_index": s.config.Index},
+	})
+
+	for _, record := range records {
+		var doc []byte
+		var err error
+		if s.config.Format == FormatCEF {
+			doc, err = json.Marshal(map[string]string{
+				"@timestamp": record.Timestamp.UTC().Format(time.RFC3339Nano),
+				"message":    encodeCEF(record),
+			})
+		} else {
+			doc, err = json.Marshal(struct {
+				Timestamp string `json:"@timestamp"`
+				Record
+			}{record.Timestamp.UTC().Format(time.RFC3339Nano), record})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to encode bulk document: %w", err)
+		}
+		body.Write(action)
+		body.WriteByte('\n')
+		body.Write(doc)
+		body.WriteByte('\n')
+	}
+
+	auth := ""
+	if s.config.Token != "" {
+		auth = "ApiKey " + s.config.Token
+	}
+	url := strings.TrimSuffix(s.config.Endpoint, "/") + "/_bulk"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send batch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("elasticsearch returned status %d", resp.StatusCode)
+	}
+
+	var result elasticBulkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode bulk response: %w", err)
+	}
+	if result.Errors {
+		return fmt.Errorf("elasticsearch rejected one or more documents")
+	}
+	return nil
+}
+
+// syslogSink sends records as RFC 5424 messages
+type syslogSink struct {
+	config   Config
+	hostname string
+}
+
+// syslogFacilityAudit is the log audit facility (13)
+const syslogFacilityAudit = 13
+
+func (s *syslogSink) Send(ctx context.Context, records []Record) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, s.config.Network, s.config.Endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to connect to syslog endpoint: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetWriteDeadline(deadline)
+	}
+
+	for _, record := range records {
+		payload, err := Encode(record, s.config.Format)
+		if err != nil {
+			return err
+		}
+		msg := fmt.Sprintf("<%d>1 %s %s %s - %s - %s",
+			syslogFacilityAudit*8+syslogSeverity(record.Severity),
+			record.Timestamp.UTC().Format(time.RFC3339Nano),
+			s.hostname,
+			cefProduct,
+			record.Kind,
+			payload,
+		)
+		if s.config.Network == "tcp" {
+			// Octet counting framing (RFC 6587)
+			msg = fmt.Sprintf("%d %s", len(msg), msg)
+		}
+		if _, err := io.WriteString(conn, msg); err != nil {
+			return fmt.Errorf("failed to write syslog message: %w", err)
+		}
+	}
+	return nil
+}
+
+// syslogSeverity maps a record severity onto a syslog severity level
+func syslogSeverity(severity Severity) int {
+	switch {
+	case severity >= SeverityHigh:
+		return 2 // critical
+	case severity >= SeverityMedium:
+		return 4 // warning
+	default:
+		return 6 // informational
+	}
+}
+
+// postBatch posts a request body and treats any non-2xx response as an error
+func postBatch(ctx context.Context, client *http.Client, url, auth, contentType string, body io.Reader) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send batch: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("SIEM endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
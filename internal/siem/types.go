@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package siem exports operator audit records and platform lifecycle events
+// to external security information and event management systems.
+package siem
+
+import (
+	"context"
+	"time"
+)
+
+// Kind identifies the origin of a record
+type Kind string
+
+const (
+	// KindAudit is an audit record of an API or CLI action
+	KindAudit Kind = "audit"
+	// KindPlatform is a platform lifecycle event emitted by the controller
+	KindPlatform Kind = "platform"
+)
+
+// Severity of a record, mapped onto CEF severities 0-10
+type Severity int
+
+const (
+	SeverityLow    Severity = 3
+	SeverityMedium Severity = 5
+	SeverityHigh   Severity = 8
+)
+
+// Format is the wire format used to encode records
+type Format string
+
+const (
+	// FormatJSON encodes each record as a JSON object
+	FormatJSON Format = "json"
+	// FormatCEF encodes each record as an ArcSight Common Event Format line
+	FormatCEF Format = "cef"
+)
+
+// SinkType identifies the SIEM backend
+type SinkType string
+
+const (
+	SinkTypeSplunk  SinkType = "splunk"
+	SinkTypeElastic SinkType = "elastic"
+	SinkTypeSyslog  SinkType = "syslog"
+)
+
+// Record is a single audit record or platform event
+type Record struct {
+	Kind      Kind              `json:"kind"`
+	Timestamp time.Time         `json:"timestamp"`
+	Action    string            `json:"action"`
+	Severity  Severity          `json:"severity"`
+	User      string            `json:"user,omitempty"`
+	SourceIP  string            `json:"sourceIP,omitempty"`
+	Namespace string            `json:"namespace,omitempty"`
+	Name      string            `json:"name,omitempty"`
+	Resource  string            `json:"resource,omitempty"`
+	Outcome   string            `json:"outcome,omitempty"`
+	Message   string            `json:"message,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+}
+
+// Sink delivers a batch of records to a SIEM
+type Sink interface {
+	Send(ctx context.Context, records []Record) error
+}
+
+// Config configures the SIEM exporter
+type Config struct {
+	// Type is the SIEM backend
+	Type SinkType
+	// Endpoint is the HEC/Elasticsearch URL or syslog host:port
+	Endpoint string
+	// Token authenticates against Splunk HEC or Elasticsearch (API key)
+	Token string
+	// Index is the Splunk index or Elasticsearch index name
+	Index string
+	// Network is the syslog transport, "tcp" or "udp"
+	Network string
+	// Format is the record encoding
+	Format Format
+	// BatchSize is the maximum number of records sent in one request
+	BatchSize int
+	// FlushInterval is the maximum time a record is buffered before sending
+	FlushInterval time.Duration
+	// MaxRetries is the number of retries for a failed batch
+	MaxRetries int
+	// RetryBackoff is the initial delay between retries, doubled each attempt
+	RetryBackoff time.Duration
+	// QueueSize is the number of records buffered before new ones are dropped
+	QueueSize int
+}
+
+// setDefaults fills unset configuration values
+func (c *Config) setDefaults() {
+	if c.Format == "" {
+		c.Format = FormatJSON
+	}
+	if c.Network == "" {
+		c.Network = "tcp"
+	}
+	if c.Index == "" {
+		c.Index = "gunj-operator"
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 100
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = 5 * time.Second
+	}
+	if c.MaxRetries < 0 {
+		c.MaxRetries = 0
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = time.Second
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = 10000
+	}
+}
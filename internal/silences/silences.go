@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package silences creates and expires Alertmanager silences through the
+// Alertmanager v2 API, to quiet the alerts of a platform while the operator
+// upgrades one of its components.
+package silences
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// CreatedBy is the author of the operator's silences
+const CreatedBy = "gunj-operator"
+
+// componentPlaceholder is replaced with the upgraded component in matcher values
+const componentPlaceholder = "{{component}}"
+
+// Matcher matches an alert label
+type Matcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+// Silence is an Alertmanager silence
+type Silence struct {
+	ID        string    `json:"id,omitempty"`
+	Matchers  []Matcher `json:"matchers"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	CreatedBy string    `json:"createdBy"`
+	Comment   string    `json:"comment"`
+}
+
+// Upgrade describes a component upgrade
+type Upgrade struct {
+	Platform  string
+	Namespace string
+	Component string
+	From      string
+	To        string
+}
+
+// ForUpgrade returns the silence of a component upgrade. Without matchers the
+// alerts of the platform's namespace are silenced.
+func ForUpgrade(upgrade Upgrade, matchers []observabilityv1beta1.SilenceMatcher, now time.Time, duration time.Duration) Silence {
+	silence := Silence{
+		StartsAt:  now,
+		EndsAt:    now.Add(duration),
+		CreatedBy: CreatedBy,
+		Comment: fmt.Sprintf("Upgrade of %s from %s to %s on platform %s/%s",
+			upgrade.Component, upgrade.From, upgrade.To, upgrade.Namespace, upgrade.Platform),
+	}
+	if len(matchers) == 0 {
+		silence.Matchers = []Matcher{{Name: "namespace", Value: upgrade.Namespace, IsEqual: true}}
+		return silence
+	}
+	for _, m := range matchers {
+		silence.Matchers = append(silence.Matchers, Matcher{
+			Name:    m.Name,
+			Value:   strings.ReplaceAll(m.Value, componentPlaceholder, upgrade.Component),
+			IsRegex: m.Regex,
+			IsEqual: true,
+		})
+	}
+	return silence
+}
+
+// Client is a client of the Alertmanager v2 silence API
+type Client struct {
+	BaseURL string
+	HTTP    *http.Client
+}
+
+// NewClient creates a client of the Alertmanager at baseURL
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		HTTP:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Create creates a silence and returns its ID
+func (c *Client) Create(ctx context.Context, silence Silence) (string, error) {
+	var result struct {
+		SilenceID string `json:"silenceID"`
+	}
+	if _, err := c.do(ctx, http.MethodPost, "/api/v2/silences", silence, &result); err != nil {
+		return "", fmt.Errorf("failed to create silence: %w", err)
+	}
+	return result.SilenceID, nil
+}
+
+// Expire ends a silence. Silences that are gone, e.g. after Alertmanager lost
+// its state, are ignored.
+func (c *Client) Expire(ctx context.Context, id string) error {
+	status, err := c.do(ctx, http.MethodDelete, "/api/v2/silence/"+url.PathEscape(id), nil, nil)
+	if status == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to expire silence %s: %w", id, err)
+	}
+	return nil
+}
+
+// do sends a JSON request and decodes the response into result if non-nil
+func (c *Client) do(ctx context.Context, method, path string, body, result interface{}) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reader)
+	if err != nil {
+		return 0, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("alertmanager request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return resp.StatusCode, fmt.Errorf("alertmanager returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode alertmanager response: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package silences
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+func TestForUpgrade(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	upgrade := Upgrade{Platform: "prod", Namespace: "monitoring", Component: "loki", From: "2.8.0", To: "2.9.0"}
+
+	silence := ForUpgrade(upgrade, nil, now, time.Hour)
+	assert.Equal(t, []Matcher{{Name: "namespace", Value: "monitoring", IsEqual: true}}, silence.Matchers)
+	assert.Equal(t, now, silence.StartsAt)
+	assert.Equal(t, now.Add(time.Hour), silence.EndsAt)
+	assert.Equal(t, CreatedBy, silence.CreatedBy)
+	assert.Equal(t, "Upgrade of loki from 2.8.0 to 2.9.0 on platform monitoring/prod", silence.Comment)
+
+	silence = ForUpgrade(upgrade, []observabilityv1beta1.SilenceMatcher{
+		{Name: "cluster", Value: "eu-1"},
+		{Name: "job", Value: ".*{{component}}.*", Regex: true},
+	}, now, time.Hour)
+	assert.Equal(t, []Matcher{
+		{Name: "cluster", Value: "eu-1", IsEqual: true},
+		{Name: "job", Value: ".*loki.*", IsRegex: true, IsEqual: true},
+	}, silence.Matchers)
+}
+
+func TestClient(t *testing.T) {
+	var created Silence
+	expired := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/silences":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			_, _ = w.Write([]byte(`{"silenceID":"abc"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v2/silence/abc":
+			expired["abc"] = true
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v2/silence/gone":
+			http.Error(w, "silence not found", http.StatusNotFound)
+		default:
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.URL + "/")
+	silence := ForUpgrade(Upgrade{Namespace: "monitoring", Component: "tempo"}, nil, time.Now(), time.Hour)
+
+	id, err := client.Create(ctx, silence)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", id)
+	assert.Equal(t, silence.Matchers, created.Matchers)
+
+	require.NoError(t, client.Expire(ctx, "abc"))
+	assert.True(t, expired["abc"])
+	assert.NoError(t, client.Expire(ctx, "gone"))
+	assert.Error(t, client.Expire(ctx, "other"))
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package sla tracks per-component availability in monthly buckets and
+// produces monthly availability reports.
+package sla
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// MonthLayout is the layout of Month.Month, e.g. "2025-07"
+	MonthLayout = "2006-01"
+
+	// MaxSampleGap is the longest interval between two samples that is still
+	// attributed to the observed state. Longer gaps are treated as unobserved.
+	MaxSampleGap = 15 * time.Minute
+
+	// DefaultRetentionMonths is the number of monthly buckets kept
+	DefaultRetentionMonths = 13
+
+	// MaxWindowsPerMonth caps the downtime windows kept per component and month
+	MaxWindowsPerMonth = 50
+)
+
+// Window is a period during which a component was unavailable.
+// End is zero while the window is still open.
+type Window struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Open reports whether the window has not ended yet
+func (w Window) Open() bool {
+	return w.End.IsZero()
+}
+
+// Duration returns the length of the window, measured up to now if it is still open
+func (w Window) Duration(now time.Time) time.Duration {
+	if w.Open() {
+		return now.Sub(w.Start)
+	}
+	return w.End.Sub(w.Start)
+}
+
+// Component is the availability of one component during one month
+type Component struct {
+	UpSeconds       int64
+	ObservedSeconds int64
+	Windows         []Window
+}
+
+// Month is the availability of all components during one calendar month (UTC)
+type Month struct {
+	Month      string
+	Components map[string]*Component
+}
+
+// History is the availability history of a platform
+type History struct {
+	LastSample time.Time
+	LastStates map[string]bool
+	// Months are kept in ascending order
+	Months []*Month
+}
+
+// Record adds a sample of component states observed at now. Time since the
+// previous sample is attributed to the previously observed states and split
+// at month boundaries. Downtime windows are opened and closed on transitions.
+func (h *History) Record(now time.Time, states map[string]bool, retentionMonths int) {
+	now = now.UTC()
+
+	if !h.LastSample.IsZero() && now.After(h.LastSample) && now.Sub(h.LastSample) <= MaxSampleGap {
+		start := h.LastSample.UTC()
+		for start.Before(now) {
+			monthEnd := time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			end := now
+			if monthEnd.Before(end) {
+				end = monthEnd
+			}
+			seconds := int64(end.Sub(start) / time.Second)
+			month := h.month(start.Format(MonthLayout))
+			for name, up := range h.LastStates {
+				component := month.component(name)
+				component.ObservedSeconds += seconds
+				if up {
+					component.UpSeconds += seconds
+				}
+			}
+			start = end
+		}
+	}
+
+	for name, up := range states {
+		wasUp, known := h.LastStates[name]
+		switch {
+		case !up && (!known || wasUp):
+			h.openWindow(name, now)
+		case up && known && !wasUp:
+			h.closeWindow(name, now)
+		}
+	}
+
+	h.LastSample = now
+	h.LastStates = make(map[string]bool, len(states))
+	for name, up := range states {
+		h.LastStates[name] = up
+	}
+
+	if retentionMonths <= 0 {
+		retentionMonths = DefaultRetentionMonths
+	}
+	h.prune(now, retentionMonths)
+}
+
+// Get returns the month with the given "YYYY-MM" key, or nil
+func (h *History) Get(month string) *Month {
+	for _, m := range h.Months {
+		if m.Month == month {
+			return m
+		}
+	}
+	return nil
+}
+
+// openWindow starts a downtime window in the month of now
+func (h *History) openWindow(name string, now time.Time) {
+	component := h.month(now.Format(MonthLayout)).component(name)
+	if n := len(component.Windows); n > 0 && component.Windows[n-1].Open() {
+		return
+	}
+	if len(component.Windows) >= MaxWindowsPerMonth {
+		component.Windows = component.Windows[1:]
+	}
+	component.Windows = append(component.Windows, Window{Start: now})
+}
+
+// closeWindow ends the most recent open downtime window of a component
+func (h *History) closeWindow(name string, now time.Time) {
+	for i := len(h.Months) - 1; i >= 0; i-- {
+		component, ok := h.Months[i].Components[name]
+		if !ok {
+			continue
+		}
+		if n := len(component.Windows); n > 0 && component.Windows[n-1].Open() {
+			component.Windows[n-1].End = now
+			return
+		}
+	}
+}
+
+// month returns the bucket for key, creating it in order if necessary
+func (h *History) month(key string) *Month {
+	i := sort.Search(len(h.Months), func(i int) bool { return h.Months[i].Month >= key })
+	if i < len(h.Months) && h.Months[i].Month == key {
+		return h.Months[i]
+	}
+
+	m := &Month{Month: key, Components: make(map[string]*Component)}
+	h.Months = append(h.Months, nil)
+	copy(h.Months[i+1:], h.Months[i:])
+	h.Months[i] = m
+	return m
+}
+
+// component returns the availability of a component, creating it if necessary
+func (m *Month) component(name string) *Component {
+	if m.Components == nil {
+		m.Components = make(map[string]*Component)
+	}
+	component, ok := m.Components[name]
+	if !ok {
+		component = &Component{}
+		m.Components[name] = component
+	}
+	return component
+}
+
+// prune drops months outside the retention window
+func (h *History) prune(now time.Time, retentionMonths int) {
+	cutoff := time.Date(now.Year(), now.Month()-time.Month(retentionMonths-1), 1, 0, 0, 0, 0, time.UTC).Format(MonthLayout)
+	i := sort.Search(len(h.Months), func(i int) bool { return h.Months[i].Month >= cutoff })
+	h.Months = h.Months[i:]
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package sla
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ComponentReport is the availability of one component during a month
+type ComponentReport struct {
+	Component string `json:"component"`
+	// Availability is the percentage of observed time the component was available
+	Availability float64 `json:"availability"`
+	// Coverage is the percentage of the month that was observed
+	Coverage float64       `json:"coverage"`
+	Downtime time.Duration `json:"downtime"`
+	Windows  []Window      `json:"windows,omitempty"`
+}
+
+// Report is the availability of a platform during a month
+type Report struct {
+	Platform  string `json:"platform"`
+	Namespace string `json:"namespace"`
+	Month     string `json:"month"`
+	// Availability is the lowest component availability
+	Availability float64           `json:"availability"`
+	Components   []ComponentReport `json:"components"`
+}
+
+// ParseMonth parses a "YYYY-MM" month and returns its UTC bounds
+func ParseMonth(month string) (time.Time, time.Time, error) {
+	start, err := time.Parse(MonthLayout, month)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid month %q: expected YYYY-MM", month)
+	}
+	return start, start.AddDate(0, 1, 0), nil
+}
+
+// BuildReport builds the availability report for a month. Downtime windows
+// are clipped to the month; windows that are still open are measured up to now.
+func BuildReport(platform, namespace string, history *History, month string, now time.Time) (*Report, error) {
+	monthStart, monthEnd, err := ParseMonth(month)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Platform:     platform,
+		Namespace:    namespace,
+		Month:        month,
+		Availability: 100,
+	}
+
+	periodEnd := monthEnd
+	if now.Before(periodEnd) {
+		periodEnd = now
+	}
+	periodSeconds := periodEnd.Sub(monthStart).Seconds()
+
+	bucket := history.Get(month)
+	names := componentNames(history, monthStart, monthEnd)
+	for _, name := range names {
+		componentReport := ComponentReport{Component: name, Availability: 100}
+
+		if bucket != nil {
+			if component, ok := bucket.Components[name]; ok && component.ObservedSeconds > 0 {
+				componentReport.Availability = 100 * float64(component.UpSeconds) / float64(component.ObservedSeconds)
+				if periodSeconds > 0 {
+					componentReport.Coverage = 100 * float64(component.ObservedSeconds) / periodSeconds
+					if componentReport.Coverage > 100 {
+						componentReport.Coverage = 100
+					}
+				}
+			}
+		}
+
+		for _, window := range windowsIn(history, name, monthStart, monthEnd, now) {
+			componentReport.Windows = append(componentReport.Windows, window)
+			componentReport.Downtime += window.Duration(now)
+		}
+
+		if componentReport.Availability < report.Availability {
+			report.Availability = componentReport.Availability
+		}
+		report.Components = append(report.Components, componentReport)
+	}
+
+	return report, nil
+}
+
+// componentNames returns all components with data overlapping the month
+func componentNames(history *History, monthStart, monthEnd time.Time) []string {
+	seen := make(map[string]struct{})
+	for _, m := range history.Months {
+		start, _, err := ParseMonth(m.Month)
+		if err != nil || start.After(monthStart) {
+			continue
+		}
+		for name, component := range m.Components {
+			if start.Equal(monthStart) {
+				seen[name] = struct{}{}
+				continue
+			}
+			// Earlier months only contribute windows still running into this month
+			for _, w := range component.Windows {
+				if w.Open() || w.End.After(monthStart) {
+					seen[name] = struct{}{}
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// windowsIn returns the downtime windows of a component overlapping the month, clipped to it
+func windowsIn(history *History, name string, monthStart, monthEnd, now time.Time) []Window {
+	var windows []Window
+	for _, m := range history.Months {
+		component, ok := m.Components[name]
+		if !ok {
+			continue
+		}
+		for _, w := range component.Windows {
+			end := w.End
+			if w.Open() {
+				end = now
+			}
+			if !w.Start.Before(monthEnd) || !end.After(monthStart) {
+				continue
+			}
+
+			clipped := w
+			if clipped.Start.Before(monthStart) {
+				clipped.Start = monthStart
+			}
+			if !w.Open() && clipped.End.After(monthEnd) {
+				clipped.End = monthEnd
+			}
+			if w.Open() && now.After(monthEnd) {
+				clipped.End = monthEnd
+			}
+			windows = append(windows, clipped)
+		}
+	}
+	return windows
+}
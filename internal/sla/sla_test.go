@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package sla
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// simulate records samples every interval, with the component down in [downFrom, downTo)
+func simulate(h *History, from, to time.Time, interval time.Duration, downFrom, downTo time.Time) {
+	for t := from; !t.After(to); t = t.Add(interval) {
+		up := t.Before(downFrom) || !t.Before(downTo)
+		h.Record(t, map[string]bool{"prometheus": up, "grafana": true}, 0)
+	}
+}
+
+func TestRecordAndReport(t *testing.T) {
+	h := &History{}
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	downFrom := time.Date(2025, 7, 10, 12, 0, 0, 0, time.UTC)
+	downTo := downFrom.Add(time.Hour)
+
+	simulate(h, start, start.Add(24*31*time.Hour), 5*time.Minute, downFrom, downTo)
+
+	report, err := BuildReport("prod", "monitoring", h, "2025-07", start.AddDate(0, 2, 0))
+	require.NoError(t, err)
+	require.Len(t, report.Components, 2)
+
+	grafana := report.Components[0]
+	assert.Equal(t, "grafana", grafana.Component)
+	assert.Equal(t, 100.0, grafana.Availability)
+	assert.Empty(t, grafana.Windows)
+
+	prometheus := report.Components[1]
+	assert.InDelta(t, 100*(1-1.0/(24*31)), prometheus.Availability, 0.001)
+	assert.InDelta(t, 100, prometheus.Coverage, 0.01)
+	require.Len(t, prometheus.Windows, 1)
+	assert.Equal(t, downFrom, prometheus.Windows[0].Start)
+	assert.Equal(t, downTo, prometheus.Windows[0].End)
+	assert.Equal(t, time.Hour, prometheus.Downtime)
+	assert.Equal(t, prometheus.Availability, report.Availability)
+}
+
+func TestWindowSpanningMonths(t *testing.T) {
+	h := &History{}
+	downFrom := time.Date(2025, 7, 31, 23, 0, 0, 0, time.UTC)
+	downTo := time.Date(2025, 8, 1, 1, 0, 0, 0, time.UTC)
+
+	simulate(h, downFrom.Add(-time.Hour), downTo.Add(time.Hour), 5*time.Minute, downFrom, downTo)
+
+	july, err := BuildReport("prod", "monitoring", h, "2025-07", downTo.Add(2*time.Hour))
+	require.NoError(t, err)
+	august, err := BuildReport("prod", "monitoring", h, "2025-08", downTo.Add(2*time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Hour, july.Components[1].Downtime)
+	assert.Equal(t, time.Hour, august.Components[1].Downtime)
+	assert.Equal(t, "2025-08", h.Months[1].Month)
+}
+
+func TestOpenWindowMeasuredToNow(t *testing.T) {
+	h := &History{}
+	start := time.Date(2025, 7, 5, 0, 0, 0, 0, time.UTC)
+	h.Record(start, map[string]bool{"loki": false}, 0)
+
+	report, err := BuildReport("prod", "monitoring", h, "2025-07", start.Add(30*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, report.Components, 1)
+	assert.True(t, report.Components[0].Windows[0].Open())
+	assert.Equal(t, 30*time.Minute, report.Components[0].Downtime)
+}
+
+func TestRetention(t *testing.T) {
+	h := &History{}
+	for m := 0; m < 20; m++ {
+		down := time.Date(2024, time.Month(1+m), 1, 0, 0, 0, 0, time.UTC)
+		h.Record(down, map[string]bool{"tempo": false}, 3)
+		h.Record(down.Add(5*time.Minute), map[string]bool{"tempo": true}, 3)
+	}
+	require.Len(t, h.Months, 3)
+	assert.Equal(t, "2025-06", h.Months[0].Month)
+}
+
+func TestParseMonth(t *testing.T) {
+	start, end, err := ParseMonth("2025-02")
+	require.NoError(t, err)
+	assert.Equal(t, 28*24*time.Hour, end.Sub(start))
+
+	_, _, err = ParseMonth("July")
+	assert.Error(t, err)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package sla
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// FromStatus converts the availability status of a platform into a history
+func FromStatus(status *observabilityv1beta1.AvailabilityStatus) *History {
+	history := &History{}
+	if status == nil {
+		return history
+	}
+
+	if status.LastSampleTime != nil {
+		history.LastSample = status.LastSampleTime.UTC()
+	}
+	if len(status.LastStates) > 0 {
+		history.LastStates = make(map[string]bool, len(status.LastStates))
+		for name, up := range status.LastStates {
+			history.LastStates[name] = up
+		}
+	}
+
+	for _, m := range status.Months {
+		month := &Month{Month: m.Month, Components: make(map[string]*Component, len(m.Components))}
+		for name, c := range m.Components {
+			component := &Component{UpSeconds: c.UpSeconds, ObservedSeconds: c.ObservedSeconds}
+			for _, w := range c.Downtime {
+				window := Window{Start: w.Start.UTC()}
+				if w.End != nil {
+					window.End = w.End.UTC()
+				}
+				component.Windows = append(component.Windows, window)
+			}
+			month.Components[name] = component
+		}
+		history.Months = append(history.Months, month)
+	}
+
+	return history
+}
+
+// ToStatus converts a history into the availability status of a platform
+func ToStatus(history *History) *observabilityv1beta1.AvailabilityStatus {
+	status := &observabilityv1beta1.AvailabilityStatus{}
+
+	if !history.LastSample.IsZero() {
+		t := metav1.NewTime(history.LastSample)
+		status.LastSampleTime = &t
+	}
+	if len(history.LastStates) > 0 {
+		status.LastStates = make(map[string]bool, len(history.LastStates))
+		for name, up := range history.LastStates {
+			status.LastStates[name] = up
+		}
+	}
+
+	for _, m := range history.Months {
+		month := observabilityv1beta1.MonthlyAvailability{
+			Month:      m.Month,
+			Components: make(map[string]observabilityv1beta1.ComponentAvailability, len(m.Components)),
+		}
+		for name, c := range m.Components {
+			component := observabilityv1beta1.ComponentAvailability{
+				UpSeconds:       c.UpSeconds,
+				ObservedSeconds: c.ObservedSeconds,
+			}
+			for _, w := range c.Windows {
+				window := observabilityv1beta1.DowntimeWindow{Start: metav1.NewTime(w.Start)}
+				if !w.Open() {
+					end := metav1.NewTime(w.End)
+					window.End = &end
+				}
+				component.Downtime = append(component.Downtime, window)
+			}
+			month.Components[name] = component
+		}
+		status.Months = append(status.Months, month)
+	}
+
+	return status
+}
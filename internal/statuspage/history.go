@@ -0,0 +1,180 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package statuspage keeps component uptime history and renders the
+// read-only public status page.
+package statuspage
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+const (
+	// dateLayout is the layout of DayRecord.Date
+	dateLayout = "2006-01-02"
+
+	// MaxSampleGap is the longest interval between two samples that is still
+	// attributed to the observed state. Longer gaps (e.g. operator downtime)
+	// are not counted towards either uptime or downtime.
+	MaxSampleGap = 15 * time.Minute
+)
+
+// Availability accumulates observed time for a component
+type Availability struct {
+	// UpSeconds is the time the component was observed healthy
+	UpSeconds int64 `json:"up"`
+	// TotalSeconds is the total observed time
+	TotalSeconds int64 `json:"total"`
+}
+
+// Ratio returns the fraction of observed time the component was healthy,
+// or 1 if nothing has been observed yet.
+func (a Availability) Ratio() float64 {
+	if a.TotalSeconds == 0 {
+		return 1
+	}
+	return float64(a.UpSeconds) / float64(a.TotalSeconds)
+}
+
+// add merges another availability into a
+func (a *Availability) add(other Availability) {
+	a.UpSeconds += other.UpSeconds
+	a.TotalSeconds += other.TotalSeconds
+}
+
+// DayRecord is the availability of all components during one UTC day
+type DayRecord struct {
+	Date       string                  `json:"date"`
+	Components map[string]Availability `json:"components"`
+}
+
+// History is the uptime history of a platform, bucketed by UTC day
+type History struct {
+	// LastSample is the time of the most recent sample
+	LastSample time.Time `json:"lastSample,omitempty"`
+	// LastStates are the component states observed at LastSample
+	LastStates map[string]bool `json:"lastStates,omitempty"`
+	// Days are the daily records in ascending date order
+	Days []DayRecord `json:"days"`
+}
+
+// ParseHistory decodes a history document. An empty document yields an empty history.
+func ParseHistory(data []byte) (*History, error) {
+	history := &History{}
+	if len(data) == 0 {
+		return history, nil
+	}
+	if err := json.Unmarshal(data, history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// Marshal encodes the history document
+func (h *History) Marshal() ([]byte, error) {
+	return json.Marshal(h)
+}
+
+// Record adds a sample of the component states observed at now. The time
+// since the previous sample is attributed to the states observed then, split
+// at UTC day boundaries. Days older than retentionDays are pruned.
+func (h *History) Record(now time.Time, states map[string]bool, retentionDays int) {
+	now = now.UTC()
+
+	if !h.LastSample.IsZero() && now.After(h.LastSample) && now.Sub(h.LastSample) <= MaxSampleGap {
+		start := h.LastSample.UTC()
+		for start.Before(now) {
+			dayEnd := time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, time.UTC)
+			end := now
+			if dayEnd.Before(end) {
+				end = dayEnd
+			}
+			seconds := int64(end.Sub(start) / time.Second)
+			day := h.day(start.Format(dateLayout))
+			for component, up := range h.LastStates {
+				availability := day.Components[component]
+				availability.TotalSeconds += seconds
+				if up {
+					availability.UpSeconds += seconds
+				}
+				day.Components[component] = availability
+			}
+			start = end
+		}
+	}
+
+	h.LastSample = now
+	h.LastStates = make(map[string]bool, len(states))
+	for component, up := range states {
+		h.LastStates[component] = up
+	}
+
+	h.prune(now, retentionDays)
+}
+
+// Availability returns the combined availability of a component for all
+// days in [from, to], inclusive, compared by UTC date.
+func (h *History) Availability(component string, from, to time.Time) Availability {
+	fromDate := from.UTC().Format(dateLayout)
+	toDate := to.UTC().Format(dateLayout)
+
+	var total Availability
+	for _, day := range h.Days {
+		if day.Date < fromDate || day.Date > toDate {
+			continue
+		}
+		total.add(day.Components[component])
+	}
+	return total
+}
+
+// Components returns the names of all components in the history, sorted
+func (h *History) Components() []string {
+	seen := make(map[string]struct{})
+	for _, day := range h.Days {
+		for component := range day.Components {
+			seen[component] = struct{}{}
+		}
+	}
+	for component := range h.LastStates {
+		seen[component] = struct{}{}
+	}
+
+	names := make([]string, 0, len(seen))
+	for component := range seen {
+		names = append(names, component)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// day returns the record for date, creating it in order if necessary
+func (h *History) day(date string) *DayRecord {
+	i := sort.Search(len(h.Days), func(i int) bool { return h.Days[i].Date >= date })
+	if i < len(h.Days) && h.Days[i].Date == date {
+		if h.Days[i].Components == nil {
+			h.Days[i].Components = make(map[string]Availability)
+		}
+		return &h.Days[i]
+	}
+
+	h.Days = append(h.Days, DayRecord{})
+	copy(h.Days[i+1:], h.Days[i:])
+	h.Days[i] = DayRecord{Date: date, Components: make(map[string]Availability)}
+	return &h.Days[i]
+}
+
+// prune drops days that fall outside the retention window
+func (h *History) prune(now time.Time, retentionDays int) {
+	if retentionDays <= 0 {
+		return
+	}
+	cutoff := now.AddDate(0, 0, -(retentionDays - 1)).Format(dateLayout)
+	i := sort.Search(len(h.Days), func(i int) bool { return h.Days[i].Date >= cutoff })
+	h.Days = h.Days[i:]
+}
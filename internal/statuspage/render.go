@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package statuspage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"time"
+)
+
+// Component statuses shown on the page
+const (
+	StatusOperational = "operational"
+	StatusDegraded    = "degraded"
+	StatusUnknown     = "unknown"
+)
+
+// DayView is the uptime of a component on one day
+type DayView struct {
+	Date   string  `json:"date"`
+	Uptime float64 `json:"uptime"`
+	// Observed is false if no samples were recorded that day
+	Observed bool `json:"observed"`
+}
+
+// ComponentView is the public state of a single component
+type ComponentView struct {
+	Name   string    `json:"name"`
+	Status string    `json:"status"`
+	Uptime float64   `json:"uptime"`
+	Days   []DayView `json:"days"`
+}
+
+// Page is the data rendered on the status page. It deliberately contains no
+// cluster internals (messages, versions, endpoints) as it is public.
+type Page struct {
+	Title       string          `json:"title"`
+	Platform    string          `json:"platform"`
+	Status      string          `json:"status"`
+	HistoryDays int             `json:"historyDays"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+	Components  []ComponentView `json:"components"`
+}
+
+// BuildPage builds the page from the history and the current component states
+func BuildPage(title, platform string, history *History, current map[string]bool, historyDays int, now time.Time) *Page {
+	now = now.UTC()
+	from := now.AddDate(0, 0, -(historyDays - 1))
+
+	page := &Page{
+		Title:       title,
+		Platform:    platform,
+		Status:      StatusOperational,
+		HistoryDays: historyDays,
+		UpdatedAt:   now,
+	}
+
+	for _, name := range history.Components() {
+		view := ComponentView{
+			Name:   name,
+			Status: StatusUnknown,
+			Uptime: history.Availability(name, from, now).Ratio(),
+		}
+		if up, ok := current[name]; ok {
+			view.Status = StatusOperational
+			if !up {
+				view.Status = StatusDegraded
+				page.Status = StatusDegraded
+			}
+		}
+
+		for d := 0; d < historyDays; d++ {
+			date := from.AddDate(0, 0, d)
+			availability := history.Availability(name, date, date)
+			view.Days = append(view.Days, DayView{
+				Date:     date.Format(dateLayout),
+				Uptime:   availability.Ratio(),
+				Observed: availability.TotalSeconds > 0,
+			})
+		}
+
+		page.Components = append(page.Components, view)
+	}
+
+	return page
+}
+
+// RenderJSON renders the page as JSON
+func (p *Page) RenderJSON() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// RenderHTML renders the page as a self-contained HTML document
+func (p *Page) RenderHTML() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pageTemplate.Execute(&buf, p); err != nil {
+		return nil, fmt.Errorf("failed to render status page: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+var pageTemplate = template.Must(template.New("status").Funcs(template.FuncMap{
+	"percent": func(v float64) string { return fmt.Sprintf("%.2f%%", v*100) },
+	"barClass": func(d DayView) string {
+		switch {
+		case !d.Observed:
+			return "none"
+		case d.Uptime >= 0.999:
+			return "ok"
+		case d.Uptime >= 0.95:
+			return "warn"
+		default:
+			return "bad"
+		}
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta http-equiv="refresh" content="60">
+<title>{{.Title}}</title>
+<style>
+body{font-family:-apple-system,BlinkMacSystemFont,"Segoe UI",Roboto,sans-serif;max-width:860px;margin:2rem auto;padding:0 1rem;color:#1f2933}
+h1{font-size:1.6rem}
+.banner{padding:1rem;border-radius:6px;color:#fff;font-weight:600}
+.banner.operational{background:#2f9e44}.banner.degraded{background:#e8590c}
+.component{margin:1.5rem 0}
+.component h2{font-size:1.1rem;display:flex;justify-content:space-between}
+.state.operational{color:#2f9e44}.state.degraded{color:#e8590c}.state.unknown{color:#868e96}
+.bars{display:flex;gap:2px}
+.bars span{flex:1;height:28px;border-radius:2px}
+.ok{background:#2f9e44}.warn{background:#f59f00}.bad{background:#e03131}.none{background:#dee2e6}
+footer{color:#868e96;font-size:.85rem;margin-top:2rem}
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="banner {{.Status}}">{{if eq .Status "operational"}}All systems operational{{else}}Some systems are degraded{{end}}</div>
+{{range .Components}}
+<div class="component">
+<h2><span>{{.Name}}</span><span class="state {{.Status}}">{{.Status}}</span></h2>
+<div class="bars">{{range .Days}}<span class="{{barClass .}}" title="{{.Date}}{{if .Observed}}: {{percent .Uptime}}{{end}}"></span>{{end}}</div>
+<small>{{percent .Uptime}} uptime over the last {{$.HistoryDays}} days</small>
+</div>
+{{end}}
+<footer>Last updated {{.UpdatedAt.Format "2006-01-02 15:04 MST"}} &middot; <a href="status.json">JSON</a></footer>
+</body>
+</html>
+`))
@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package statuspage
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryRecord(t *testing.T) {
+	history := &History{}
+	start := time.Date(2025, 3, 1, 23, 55, 0, 0, time.UTC)
+
+	history.Record(start, map[string]bool{"prometheus": true, "grafana": false}, 30)
+	history.Record(start.Add(10*time.Minute), map[string]bool{"prometheus": true, "grafana": true}, 30)
+
+	require.Len(t, history.Days, 2, "interval crossing midnight is split")
+	assert.Equal(t, "2025-03-01", history.Days[0].Date)
+	assert.Equal(t, Availability{UpSeconds: 300, TotalSeconds: 300}, history.Days[0].Components["prometheus"])
+	assert.Equal(t, Availability{UpSeconds: 0, TotalSeconds: 300}, history.Days[1].Components["grafana"])
+
+	// A gap longer than MaxSampleGap is not attributed
+	history.Record(start.Add(2*time.Hour), map[string]bool{"prometheus": false}, 30)
+	assert.Equal(t, int64(300), history.Days[1].Components["prometheus"].TotalSeconds)
+
+	ratio := history.Availability("grafana", start, start.Add(time.Hour)).Ratio()
+	assert.Equal(t, 0.0, ratio)
+}
+
+func TestHistoryPrune(t *testing.T) {
+	history := &History{}
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	for d := 10; d >= 0; d-- {
+		history.day(now.AddDate(0, 0, -d).Format(dateLayout))
+	}
+
+	history.Record(now, map[string]bool{"loki": true}, 7)
+
+	require.Len(t, history.Days, 7)
+	assert.Equal(t, "2025-03-04", history.Days[0].Date)
+}
+
+func TestHistoryRoundTrip(t *testing.T) {
+	history := &History{}
+	now := time.Now()
+	history.Record(now, map[string]bool{"tempo": true}, 30)
+	history.Record(now.Add(time.Minute), map[string]bool{"tempo": true}, 30)
+
+	data, err := history.Marshal()
+	require.NoError(t, err)
+
+	parsed, err := ParseHistory(data)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tempo"}, parsed.Components())
+
+	empty, err := ParseHistory(nil)
+	require.NoError(t, err)
+	assert.Empty(t, empty.Days)
+}
+
+func TestRenderPage(t *testing.T) {
+	history := &History{}
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	history.Record(now.Add(-10*time.Minute), map[string]bool{"prometheus": true, "grafana": true}, 30)
+	history.Record(now, map[string]bool{"prometheus": true, "grafana": false}, 30)
+
+	page := BuildPage("Acme <Status>", "prod", history, map[string]bool{"prometheus": true, "grafana": false}, 7, now)
+	assert.Equal(t, StatusDegraded, page.Status)
+	require.Len(t, page.Components, 2)
+	assert.Len(t, page.Components[0].Days, 7)
+
+	html, err := page.RenderHTML()
+	require.NoError(t, err)
+	assert.Contains(t, string(html), "Acme &lt;Status&gt;")
+	assert.Contains(t, string(html), "Some systems are degraded")
+
+	data, err := page.RenderJSON()
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(data), `"status": "degraded"`))
+}
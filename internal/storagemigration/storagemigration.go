@@ -0,0 +1,249 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package storagemigration moves the data of a component's persistent
+// volumes to another StorageClass. The StatefulSet is scaled down, a Job
+// copies every claim into a new claim in the target class, and the new
+// volumes are then bound under the original claim names, so the recreated
+// StatefulSet finds its data in the new class. The volumes in the previous
+// class are retained as a fallback.
+package storagemigration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+const (
+	// RequestAnnotation on a platform lists the comma-separated components
+	// whose volumes are migrated to the StorageClass of their storage spec
+	RequestAnnotation = "observability.io/migrate-storage"
+
+	// ReclaimPolicyAnnotation records the reclaim policy of a volume while
+	// the migration retains it
+	ReclaimPolicyAnnotation = "observability.io/migration-reclaim-policy"
+
+	// TargetSuffix is appended to a claim's name for the claim its data is
+	// copied into
+	TargetSuffix = "-migration"
+
+	// DefaultImage is the image of the copy Jobs
+	DefaultImage = "busybox:1.36"
+)
+
+// Phases of a migration
+const (
+	PhaseScalingDown = "ScalingDown"
+	PhaseCopying     = "Copying"
+	PhaseSwapping    = "Swapping"
+	PhaseCompleted   = "Completed"
+	PhaseFailed      = "Failed"
+)
+
+// Phases of a volume migration
+const (
+	VolumePending = "Pending"
+	VolumeCopying = "Copying"
+	VolumeCopied  = "Copied"
+	VolumeSwapped = "Swapped"
+	VolumeFailed  = "Failed"
+)
+
+const (
+	backoffLimit            = int32(2)
+	ttlSecondsAfterFinished = int32(24 * 60 * 60)
+	sourcePath              = "/source"
+	targetPath              = "/target"
+)
+
+// Requested returns the components whose migration is requested through the
+// annotations of a platform
+func Requested(annotations map[string]string) []string {
+	var components []string
+	for _, component := range strings.Split(annotations[RequestAnnotation], ",") {
+		if component = strings.TrimSpace(component); component != "" {
+			components = append(components, component)
+		}
+	}
+	return components
+}
+
+// ClaimNames returns the names of the claims a StatefulSet creates from its
+// volume claim templates for its replicas
+func ClaimNames(templates []corev1.PersistentVolumeClaim, statefulSet string, replicas int32) []string {
+	var names []string
+	for _, template := range templates {
+		for i := int32(0); i < replicas; i++ {
+			names = append(names, fmt.Sprintf("%s-%s-%d", template.Name, statefulSet, i))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// StorageClass returns the StorageClass of a claim, or an empty string if it
+// has none
+func StorageClass(pvc *corev1.PersistentVolumeClaim) string {
+	if pvc.Spec.StorageClassName == nil {
+		return ""
+	}
+	return *pvc.Spec.StorageClassName
+}
+
+// TargetClaimName returns the name of the claim the data of a claim is
+// copied into
+func TargetClaimName(claim string) string {
+	return claim + TargetSuffix
+}
+
+// BuildTargetClaim returns the claim in the target StorageClass the data of
+// source is copied into. It requests the capacity of source, so the data fits.
+func BuildTargetClaim(source *corev1.PersistentVolumeClaim, storageClass string, labels map[string]string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TargetClaimName(source.Name),
+			Namespace: source.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      source.Spec.AccessModes,
+			StorageClassName: &storageClass,
+			VolumeMode:       source.Spec.VolumeMode,
+			Resources:        corev1.ResourceRequirements{Requests: capacity(source)},
+		},
+	}
+}
+
+// BuildSwappedClaim returns the claim replacing the migrated claim name,
+// bound to the volume its data was copied into
+func BuildSwappedClaim(name, namespace string, labels map[string]string, pv *corev1.PersistentVolume, storageClass string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      pv.Spec.AccessModes,
+			StorageClassName: &storageClass,
+			VolumeMode:       pv.Spec.VolumeMode,
+			VolumeName:       pv.Name,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: pv.Spec.Capacity[corev1.ResourceStorage]},
+			},
+		},
+	}
+}
+
+// TemplateLabels returns the labels of the volume claim template a
+// StatefulSet created a claim from, so a replaced claim keeps them
+func TemplateLabels(statefulSets []appsv1.StatefulSet, claim string) map[string]string {
+	for _, sts := range statefulSets {
+		for _, template := range sts.Spec.VolumeClaimTemplates {
+			if strings.HasPrefix(claim, fmt.Sprintf("%s-%s-", template.Name, sts.Name)) {
+				return template.Labels
+			}
+		}
+	}
+	return nil
+}
+
+// capacity returns the storage a claim got, or requested if it is not bound
+func capacity(pvc *corev1.PersistentVolumeClaim) corev1.ResourceList {
+	if storage, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+		return corev1.ResourceList{corev1.ResourceStorage: storage}
+	}
+	return corev1.ResourceList{corev1.ResourceStorage: pvc.Spec.Resources.Requests[corev1.ResourceStorage]}
+}
+
+// JobName returns the name of the Job copying a claim
+func JobName(claim string) string {
+	return claim + TargetSuffix
+}
+
+// BuildCopyJob returns the Job copying the data of a claim into its target
+// claim. It runs as root to preserve the owners and permissions of the
+// files, and prints the size of the copied data.
+func BuildCopyJob(claim, namespace, image string, labels map[string]string) *batchv1.Job {
+	limit := backoffLimit
+	ttl := ttlSecondsAfterFinished
+	script := fmt.Sprintf("set -e\ncp -a %s/. %s/\nsync\necho \"copied $(du -sh %s | cut -f1)\"\n", sourcePath, targetPath, targetPath)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      JobName(claim),
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &limit,
+			TTLSecondsAfterFinished: &ttl,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsUser: &[]int64{0}[0],
+					},
+					Containers: []corev1.Container{{
+						Name:    "copy",
+						Image:   image,
+						Command: []string{"/bin/sh", "-c", script},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "source", MountPath: sourcePath, ReadOnly: true},
+							{Name: "target", MountPath: targetPath},
+						},
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: &[]bool{false}[0],
+						},
+					}},
+					Volumes: []corev1.Volume{
+						{
+							Name: "source",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim, ReadOnly: true},
+							},
+						},
+						{
+							Name: "target",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: TargetClaimName(claim)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Progress summarizes the progress of the volume migrations of a phase
+func Progress(phase string, volumes []observabilityv1beta1.VolumeMigrationStatus) string {
+	var copied, swapped int
+	for _, v := range volumes {
+		switch v.Phase {
+		case VolumeCopied:
+			copied++
+		case VolumeSwapped:
+			copied++
+			swapped++
+		}
+	}
+	switch phase {
+	case PhaseSwapping, PhaseCompleted:
+		return fmt.Sprintf("%d/%d volumes swapped", swapped, len(volumes))
+	default:
+		return fmt.Sprintf("%d/%d volumes copied", copied, len(volumes))
+	}
+}
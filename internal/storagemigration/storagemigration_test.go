@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package storagemigration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+func sourceClaim() *corev1.PersistentVolumeClaim {
+	class := "standard"
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "data-prometheus-prod-0",
+			Namespace: "monitoring",
+			Labels:    map[string]string{"app.kubernetes.io/name": "prometheus"},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: &class,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("50Gi")},
+			},
+		},
+	}
+}
+
+func TestRequested(t *testing.T) {
+	assert.Nil(t, Requested(nil))
+	assert.Equal(t, []string{"prometheus", "loki"}, Requested(map[string]string{RequestAnnotation: " prometheus, ,loki"}))
+}
+
+func TestClaimNames(t *testing.T) {
+	templates := []corev1.PersistentVolumeClaim{
+		{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "wal"}},
+	}
+	assert.Equal(t, []string{
+		"data-prometheus-prod-0",
+		"data-prometheus-prod-1",
+		"wal-prometheus-prod-0",
+		"wal-prometheus-prod-1",
+	}, ClaimNames(templates, "prometheus-prod", 2))
+	assert.Empty(t, ClaimNames(templates, "prometheus-prod", 0))
+}
+
+func TestBuildClaims(t *testing.T) {
+	source := sourceClaim()
+	assert.Equal(t, "standard", StorageClass(source))
+	assert.Equal(t, "", StorageClass(&corev1.PersistentVolumeClaim{}))
+
+	target := BuildTargetClaim(source, "fast-ssd", map[string]string{"app.kubernetes.io/component": "storage-migration"})
+	assert.Equal(t, "data-prometheus-prod-0-migration", target.Name)
+	assert.Equal(t, "monitoring", target.Namespace)
+	assert.Equal(t, "fast-ssd", StorageClass(target))
+	assert.Equal(t, resource.MustParse("50Gi"), target.Spec.Resources.Requests[corev1.ResourceStorage])
+
+	// A bound claim may have got more than it requested
+	source.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("64Gi")}
+	target = BuildTargetClaim(source, "fast-ssd", nil)
+	assert.Equal(t, resource.MustParse("64Gi"), target.Spec.Resources.Requests[corev1.ResourceStorage])
+
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-123"},
+		Spec: corev1.PersistentVolumeSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Capacity:    corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("64Gi")},
+		},
+	}
+	swapped := BuildSwappedClaim(source.Name, "monitoring", source.Labels, pv, "fast-ssd")
+	assert.Equal(t, source.Name, swapped.Name)
+	assert.Equal(t, source.Labels, swapped.Labels)
+	assert.Equal(t, "fast-ssd", StorageClass(swapped))
+	assert.Equal(t, "pv-123", swapped.Spec.VolumeName)
+	assert.Equal(t, pv.Spec.AccessModes, swapped.Spec.AccessModes)
+	assert.Equal(t, resource.MustParse("64Gi"), swapped.Spec.Resources.Requests[corev1.ResourceStorage])
+}
+
+func TestTemplateLabels(t *testing.T) {
+	statefulSets := []appsv1.StatefulSet{{
+		ObjectMeta: metav1.ObjectMeta{Name: "loki-prod"},
+		Spec: appsv1.StatefulSetSpec{
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{ObjectMeta: metav1.ObjectMeta{Name: "data", Labels: map[string]string{"volume": "data"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "wal", Labels: map[string]string{"volume": "wal"}}},
+			},
+		},
+	}}
+	assert.Equal(t, map[string]string{"volume": "wal"}, TemplateLabels(statefulSets, "wal-loki-prod-2"))
+	assert.Nil(t, TemplateLabels(statefulSets, "data-tempo-prod-0"))
+}
+
+func TestBuildCopyJob(t *testing.T) {
+	job := BuildCopyJob("data-loki-prod-0", "monitoring", DefaultImage, map[string]string{"a": "b"})
+	assert.Equal(t, "data-loki-prod-0-migration", job.Name)
+	assert.Equal(t, "monitoring", job.Namespace)
+
+	pod := job.Spec.Template.Spec
+	assert.Equal(t, corev1.RestartPolicyNever, pod.RestartPolicy)
+	require.Len(t, pod.Containers, 1)
+	assert.Equal(t, DefaultImage, pod.Containers[0].Image)
+	assert.Contains(t, pod.Containers[0].Command[2], "cp -a /source/. /target/")
+
+	require.Len(t, pod.Volumes, 2)
+	assert.Equal(t, "data-loki-prod-0", pod.Volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.True(t, pod.Volumes[0].PersistentVolumeClaim.ReadOnly)
+	assert.Equal(t, "data-loki-prod-0-migration", pod.Volumes[1].PersistentVolumeClaim.ClaimName)
+}
+
+func TestProgress(t *testing.T) {
+	volumes := []observabilityv1beta1.VolumeMigrationStatus{
+		{Claim: "a", Phase: VolumeSwapped},
+		{Claim: "b", Phase: VolumeCopied},
+		{Claim: "c", Phase: VolumeCopying},
+	}
+	assert.Equal(t, "2/3 volumes copied", Progress(PhaseCopying, volumes))
+	assert.Equal(t, "1/3 volumes swapped", Progress(PhaseSwapping, volumes))
+}
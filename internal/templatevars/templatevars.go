@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package templatevars renders the template variables of platform specs,
+// like {{ .ClusterName }} or {{ .Region }}, with values set once per
+// operator. The same spec can then be applied to every cluster without
+// copy-paste differences in labels, hosts and URLs.
+package templatevars
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Values are the template variables of an operator
+type Values struct {
+	// ClusterName is rendered for {{ .ClusterName }}
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// Region is rendered for {{ .Region }}
+	Region string `json:"region,omitempty"`
+
+	// Environment is rendered for {{ .Environment }}
+	Environment string `json:"environment,omitempty"`
+
+	// Extra are further variables, rendered for {{ .Extra.<name> }}
+	Extra map[string]string `json:"extra,omitempty"`
+}
+
+// Load reads values from YAML
+func Load(data []byte) (Values, error) {
+	values := Values{}
+	if err := yaml.UnmarshalStrict(data, &values); err != nil {
+		return Values{}, fmt.Errorf("invalid template values: %w", err)
+	}
+	return values, nil
+}
+
+// IsTemplate returns true if the string has template actions
+func IsTemplate(s string) bool {
+	return strings.Contains(s, "{{")
+}
+
+// Check returns an error if the string is not a valid template
+func Check(s string) error {
+	_, err := parse(s)
+	return err
+}
+
+// Render returns the string with its template variables rendered. A
+// variable which is not set is an error rather than rendered empty, so a
+// missing value never yields labels or hosts shared by several clusters.
+func (v Values) Render(s string) (string, error) {
+	if !IsTemplate(s) {
+		return s, nil
+	}
+	tmpl, err := parse(s)
+	if err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, v.data()); err != nil {
+		return "", fmt.Errorf("failed to render %q: %w", s, err)
+	}
+	return out.String(), nil
+}
+
+// RenderMap returns a copy of the map with its values rendered
+func (v Values) RenderMap(m map[string]string) (map[string]string, error) {
+	if m == nil {
+		return nil, nil
+	}
+	rendered := make(map[string]string, len(m))
+	for k, s := range m {
+		value, err := v.Render(s)
+		if err != nil {
+			return nil, err
+		}
+		rendered[k] = value
+	}
+	return rendered, nil
+}
+
+// data returns the variables which are set, so unset ones fail to render
+func (v Values) data() map[string]interface{} {
+	data := map[string]interface{}{}
+	if v.ClusterName != "" {
+		data["ClusterName"] = v.ClusterName
+	}
+	if v.Region != "" {
+		data["Region"] = v.Region
+	}
+	if v.Environment != "" {
+		data["Environment"] = v.Environment
+	}
+	if len(v.Extra) > 0 {
+		data["Extra"] = v.Extra
+	}
+	return data
+}
+
+func parse(s string) (*template.Template, error) {
+	tmpl, err := template.New("value").Option("missingkey=error").Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template %q: %w", s, err)
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package templatevars
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	values, err := Load([]byte("clusterName: prod-eu-1\nregion: eu-west-1\nextra:\n  team: sre\n"))
+	require.NoError(t, err)
+	assert.Equal(t, Values{ClusterName: "prod-eu-1", Region: "eu-west-1", Extra: map[string]string{"team": "sre"}}, values)
+
+	_, err = Load([]byte("cluster: prod-eu-1\n"))
+	assert.Error(t, err)
+}
+
+func TestRender(t *testing.T) {
+	values := Values{ClusterName: "prod-eu-1", Region: "eu-west-1", Extra: map[string]string{"domain": "example.com"}}
+
+	rendered, err := values.Render("grafana.{{ .ClusterName }}.{{ .Extra.domain }}")
+	require.NoError(t, err)
+	assert.Equal(t, "grafana.prod-eu-1.example.com", rendered)
+
+	rendered, err = values.Render("https://thanos.{{ .Region }}.example.com/api/v1/receive")
+	require.NoError(t, err)
+	assert.Equal(t, "https://thanos.eu-west-1.example.com/api/v1/receive", rendered)
+
+	rendered, err = values.Render("plain")
+	require.NoError(t, err)
+	assert.Equal(t, "plain", rendered)
+
+	// Unset variables fail instead of rendering empty
+	_, err = values.Render("{{ .Environment }}")
+	assert.ErrorContains(t, err, "Environment")
+	_, err = values.Render("{{ .Extra.team }}")
+	assert.Error(t, err)
+	_, err = Values{}.Render("{{ .Extra.team }}")
+	assert.Error(t, err)
+
+	_, err = values.Render("{{ .ClusterName ")
+	assert.ErrorContains(t, err, "invalid template")
+	assert.Error(t, Check("{{ .ClusterName "))
+	assert.NoError(t, Check("{{ .ClusterName }}"))
+}
+
+func TestRenderMap(t *testing.T) {
+	values := Values{ClusterName: "prod-eu-1", Environment: "production"}
+	labels := map[string]string{"cluster": "{{ .ClusterName }}", "env": "{{ .Environment }}", "team": "sre"}
+
+	rendered, err := values.RenderMap(labels)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"cluster": "prod-eu-1", "env": "production", "team": "sre"}, rendered)
+	assert.Equal(t, "{{ .ClusterName }}", labels["cluster"])
+
+	rendered, err = values.RenderMap(nil)
+	require.NoError(t, err)
+	assert.Nil(t, rendered)
+
+	_, err = Values{}.RenderMap(labels)
+	assert.Error(t, err)
+}
@@ -0,0 +1,388 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package temposync applies TempoConfigs to the Tempo of their target
+// platform and tracks the synchronization in their status. The per-tenant
+// overrides of a TempoConfig are rendered into Tempo's runtime overrides
+// file, which Tempo reloads without a restart.
+//
+// A sync goes Pending, then Applying once the overrides are written, and
+// Applied once Tempo had the reload period to load them. An attempt which
+// fails is Failed and retried with an exponential backoff; configurations
+// which cannot be rendered are not retried until their spec changes.
+//
+// Several TempoConfigs can target a platform. Their overrides are merged by
+// section, the limits of every tenant and those of each tenant, and a section
+// set differently by several of them is taken from the oldest. Every
+// TempoConfig renders the same file, so they never overwrite each other.
+package temposync
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+const (
+	// OverridesKey is the key of the overrides file in its ConfigMap
+	OverridesKey = "overrides.yaml"
+	// OverridesMountPath is where Tempo mounts the overrides ConfigMap
+	OverridesMountPath = "/conf/overrides"
+	// EmptyOverrides is the overrides file of a platform without TempoConfig
+	EmptyOverrides = "overrides: {}\n"
+	// ReloadPeriod is how often Tempo reloads the overrides file
+	ReloadPeriod = 10 * time.Second
+
+	// HashAnnotation records the hash of the overrides written to the ConfigMap
+	HashAnnotation = "observability.io/tempoconfig-hash"
+	// OwnerAnnotation records the TempoConfigs the overrides were rendered
+	// from, separated by commas
+	OwnerAnnotation = "observability.io/tempoconfig"
+
+	// BaseBackoff is the delay before the first retry
+	BaseBackoff = 10 * time.Second
+	// MaxBackoff caps the delay between retries
+	MaxBackoff = 10 * time.Minute
+)
+
+// Reasons of the sync states
+const (
+	ReasonPaused            = "Paused"
+	ReasonTargetNotFound    = observabilityv1beta1.ReasonTargetNotFound
+	ReasonComponentDisabled = observabilityv1beta1.ReasonComponentDisabled
+	ReasonWaitingForTempo   = "WaitingForTempo"
+	ReasonInvalid           = "InvalidConfiguration"
+	ReasonApplyFailed       = "ApplyFailed"
+	ReasonWaitingForReload  = "WaitingForReload"
+	ReasonApplied           = "Applied"
+	ReasonDrifted           = "Drifted"
+)
+
+const (
+	// ConditionConflict is true when other TempoConfigs targeting the same
+	// platform set sections of the overrides differently
+	ConditionConflict = "Conflict"
+	// ReasonOverlappingSections is the reason of a true Conflict condition
+	ReasonOverlappingSections = "OverlappingSections"
+	// ReasonNoConflicts is the reason of a false Conflict condition
+	ReasonNoConflicts = "NoConflicts"
+
+	// DefaultsSection is the section of the limits of every tenant
+	DefaultsSection = "*"
+)
+
+// Sections are the limits of an overrides file by section: DefaultsSection
+// or a tenant
+type Sections map[string]map[string]interface{}
+
+// Source is a TempoConfig contributing to the overrides file of a platform
+type Source struct {
+	Name     string
+	Created  time.Time
+	Sections Sections
+}
+
+// OverridesConfigMapName returns the name of the overrides ConfigMap of a
+// platform's Tempo
+func OverridesConfigMapName(platform string) string {
+	return platform + "-tempo-overrides"
+}
+
+// OverridesPath returns the path of the overrides file in the Tempo pods
+func OverridesPath() string {
+	return OverridesMountPath + "/" + OverridesKey
+}
+
+// RenderOverrides renders the overrides of a TempoConfig into Tempo's
+// runtime overrides file
+func RenderOverrides(spec *observabilityv1beta1.OverridesConfig) (string, error) {
+	sections, err := RenderSections(spec)
+	if err != nil {
+		return "", err
+	}
+	return Render(sections)
+}
+
+// RenderSections renders the overrides of a TempoConfig into sections. The
+// limits of the spec apply to every tenant, through the "*" wildcard, and
+// the tenants' own limits override them.
+func RenderSections(spec *observabilityv1beta1.OverridesConfig) (Sections, error) {
+	overrides := Sections{}
+	if spec == nil {
+		return overrides, nil
+	}
+
+	defaults, err := limits("", observabilityv1beta1.TenantOverride{
+		MaxBytesPerTrace:           spec.MaxBytesPerTrace,
+		MaxTracesPerUser:           spec.MaxTracesPerUser,
+		MaxGlobalTracesPerUser:     spec.MaxGlobalTracesPerUser,
+		MaxBytesPerTagValuesQuery:  spec.MaxBytesPerTagValuesQuery,
+		MaxBlocksPerTagValuesQuery: spec.MaxBlocksPerTagValuesQuery,
+		IngestionRateLimitBytes:    spec.IngestionRateLimitBytes,
+		IngestionBurstSizeBytes:    spec.IngestionBurstSizeBytes,
+		BlockRetention:             spec.BlockRetention,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(defaults) > 0 {
+		overrides[DefaultsSection] = defaults
+	}
+	for tenant, override := range spec.Tenants {
+		if tenant == "" || tenant == DefaultsSection {
+			return nil, fmt.Errorf("invalid tenant name %q", tenant)
+		}
+		tenantLimits, err := limits(tenant, override)
+		if err != nil {
+			return nil, err
+		}
+		overrides[tenant] = tenantLimits
+	}
+	return overrides, nil
+}
+
+// Render renders sections into Tempo's runtime overrides file
+func Render(sections Sections) (string, error) {
+	if len(sections) == 0 {
+		return EmptyOverrides, nil
+	}
+	data, err := yaml.Marshal(map[string]interface{}{"overrides": sections})
+	if err != nil {
+		return "", fmt.Errorf("failed to render overrides: %w", err)
+	}
+	return string(data), nil
+}
+
+// Merge merges the sections of the TempoConfigs targeting a platform. A
+// section set differently by several of them is taken from the oldest, or
+// the first by name, whatever the order of the sources. The conflicts are
+// returned by TempoConfig, for the one applied and for the others.
+func Merge(sources []Source) (Sections, map[string][]observabilityv1beta1.TempoConfigConflict) {
+	ordered := append([]Source(nil), sources...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if !ordered[i].Created.Equal(ordered[j].Created) {
+			return ordered[i].Created.Before(ordered[j].Created)
+		}
+		return ordered[i].Name < ordered[j].Name
+	})
+
+	merged := Sections{}
+	from := map[string]string{}
+	conflicts := map[string][]observabilityv1beta1.TempoConfigConflict{}
+	for _, source := range ordered {
+		for _, section := range sortedSections(source.Sections) {
+			applied, ok := merged[section]
+			if !ok {
+				merged[section] = source.Sections[section]
+				from[section] = source.Name
+				continue
+			}
+			if reflect.DeepEqual(applied, source.Sections[section]) {
+				continue
+			}
+			winner := from[section]
+			conflicts[winner] = append(conflicts[winner], observabilityv1beta1.TempoConfigConflict{
+				Section: section, TempoConfig: source.Name, Applied: true,
+			})
+			conflicts[source.Name] = append(conflicts[source.Name], observabilityv1beta1.TempoConfigConflict{
+				Section: section, TempoConfig: winner, Applied: false,
+			})
+		}
+	}
+	return merged, conflicts
+}
+
+func sortedSections(sections Sections) []string {
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// limits returns the Tempo settings of the limits which are set
+func limits(tenant string, o observabilityv1beta1.TenantOverride) (map[string]interface{}, error) {
+	where := "overrides"
+	if tenant != "" {
+		where = fmt.Sprintf("overrides of tenant %s", tenant)
+	}
+
+	l := map[string]interface{}{}
+	if o.MaxBytesPerTrace != "" {
+		size, err := ParseByteSize(o.MaxBytesPerTrace)
+		if err != nil {
+			return nil, fmt.Errorf("%s: maxBytesPerTrace: %w", where, err)
+		}
+		l["max_bytes_per_trace"] = size
+	}
+	if o.BlockRetention != "" {
+		retention, err := time.ParseDuration(o.BlockRetention)
+		if err != nil || retention < 0 {
+			return nil, fmt.Errorf("%s: blockRetention: invalid duration %q", where, o.BlockRetention)
+		}
+		// Zero keeps the compactor's retention
+		if retention > 0 {
+			l["block_retention"] = o.BlockRetention
+		}
+	}
+	for key, value := range map[string]int64{
+		"max_traces_per_user":             int64(o.MaxTracesPerUser),
+		"max_global_traces_per_user":      int64(o.MaxGlobalTracesPerUser),
+		"max_bytes_per_tag_values_query":  int64(o.MaxBytesPerTagValuesQuery),
+		"max_blocks_per_tag_values_query": int64(o.MaxBlocksPerTagValuesQuery),
+		"ingestion_rate_limit_bytes":      o.IngestionRateLimitBytes,
+		"ingestion_burst_size_bytes":      o.IngestionBurstSizeBytes,
+	} {
+		if value < 0 {
+			return nil, fmt.Errorf("%s: %s must not be negative", where, key)
+		}
+		if value > 0 {
+			l[key] = value
+		}
+	}
+	return l, nil
+}
+
+var byteSizePattern = regexp.MustCompile(`^([0-9]+)\s*([KMGT]i?B|B)?$`)
+
+var byteSizeUnits = map[string]int64{
+	"":    1,
+	"B":   1,
+	"KB":  1000,
+	"MB":  1000 * 1000,
+	"GB":  1000 * 1000 * 1000,
+	"TB":  1000 * 1000 * 1000 * 1000,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+}
+
+// ParseByteSize parses a size like 1MB or 512KiB into bytes
+func ParseByteSize(size string) (int64, error) {
+	match := byteSizePattern.FindStringSubmatch(strings.TrimSpace(size))
+	if match == nil {
+		return 0, fmt.Errorf("invalid size %q", size)
+	}
+	n, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", size, err)
+	}
+	return n * byteSizeUnits[match[2]], nil
+}
+
+// Hash returns the hex SHA-256 of a rendered overrides file
+func Hash(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// Backoff returns the delay before retrying after the given number of
+// failed attempts
+func Backoff(retries int32) time.Duration {
+	delay := BaseBackoff
+	for i := int32(1); i < retries; i++ {
+		delay *= 2
+		if delay >= MaxBackoff {
+			return MaxBackoff
+		}
+	}
+	return delay
+}
+
+// Observe starts tracking the desired configuration. A new hash resets the
+// retries, as the previous errors were about another configuration.
+func Observe(status *observabilityv1beta1.TempoConfigSyncStatus, hash string, now time.Time) {
+	if status.DesiredHash == hash {
+		return
+	}
+	status.DesiredHash = hash
+	status.Retries = 0
+	status.LastError = ""
+	status.NextRetryTime = nil
+	transition(status, observabilityv1beta1.TempoConfigSyncPending, ReasonDrifted, "The configuration changed and is not applied yet", now)
+}
+
+// Pending waits for the platform without counting a failed attempt
+func Pending(status *observabilityv1beta1.TempoConfigSyncStatus, reason, message string, now time.Time) {
+	status.NextRetryTime = nil
+	transition(status, observabilityv1beta1.TempoConfigSyncPending, reason, message, now)
+}
+
+// Fail records a failed attempt and returns when to retry it, zero if the
+// attempt is not retried
+func Fail(status *observabilityv1beta1.TempoConfigSyncStatus, reason string, err error, retry bool, now time.Time) time.Duration {
+	status.LastError = err.Error()
+	transition(status, observabilityv1beta1.TempoConfigSyncFailed, reason, err.Error(), now)
+	if !retry {
+		status.NextRetryTime = nil
+		return 0
+	}
+	status.Retries++
+	delay := Backoff(status.Retries)
+	next := metav1.NewTime(now.Add(delay))
+	status.NextRetryTime = &next
+	return delay
+}
+
+// Applying records that the configuration was written
+func Applying(status *observabilityv1beta1.TempoConfigSyncStatus, now time.Time) {
+	attempt := metav1.NewTime(now)
+	status.LastAttemptTime = &attempt
+	status.NextRetryTime = nil
+	transition(status, observabilityv1beta1.TempoConfigSyncApplying, ReasonWaitingForReload,
+		fmt.Sprintf("Written to the overrides file, Tempo reloads it within %s", ReloadPeriod), now)
+}
+
+// Applied records that Tempo loaded the configuration
+func Applied(status *observabilityv1beta1.TempoConfigSyncStatus, now time.Time) {
+	synced := metav1.NewTime(now)
+	status.LastSyncTime = &synced
+	status.Retries = 0
+	status.LastError = ""
+	status.NextRetryTime = nil
+	transition(status, observabilityv1beta1.TempoConfigSyncApplied, ReasonApplied, "Tempo loaded the configuration", now)
+}
+
+// Reloaded returns true once Tempo had the reload period to load the
+// configuration written by the last attempt
+func Reloaded(status *observabilityv1beta1.TempoConfigSyncStatus, now time.Time) (bool, time.Duration) {
+	if status.LastAttemptTime == nil {
+		return false, ReloadPeriod
+	}
+	wait := status.LastAttemptTime.Add(ReloadPeriod).Sub(now)
+	return wait <= 0, wait
+}
+
+// Phase returns the phase of a TempoConfig in the state
+func Phase(status *observabilityv1beta1.TempoConfigSyncStatus) string {
+	if status.State == observabilityv1beta1.TempoConfigSyncFailed && status.Reason == ReasonInvalid {
+		return "Invalid"
+	}
+	return status.State
+}
+
+func transition(status *observabilityv1beta1.TempoConfigSyncStatus, state, reason, message string, now time.Time) {
+	if status.State != state {
+		t := metav1.NewTime(now)
+		status.LastTransitionTime = &t
+	}
+	status.State = state
+	status.Reason = reason
+	status.Message = message
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package temposync
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+func TestRenderOverrides(t *testing.T) {
+	data, err := RenderOverrides(nil)
+	require.NoError(t, err)
+	assert.Equal(t, EmptyOverrides, data)
+
+	data, err = RenderOverrides(&observabilityv1beta1.OverridesConfig{
+		MaxBytesPerTrace:        "1MB",
+		IngestionRateLimitBytes: 15000,
+		BlockRetention:          "0s",
+		PerTenantOverridePeriod: "10s",
+		Tenants: map[string]observabilityv1beta1.TenantOverride{
+			"team-a": {MaxBytesPerTrace: "5MiB", BlockRetention: "720h"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `overrides:
+  '*':
+    ingestion_rate_limit_bytes: 15000
+    max_bytes_per_trace: 1000000
+  team-a:
+    block_retention: 720h
+    max_bytes_per_trace: 5242880
+`, data)
+
+	_, err = RenderOverrides(&observabilityv1beta1.OverridesConfig{MaxBytesPerTrace: "lots"})
+	assert.ErrorContains(t, err, "maxBytesPerTrace")
+	_, err = RenderOverrides(&observabilityv1beta1.OverridesConfig{
+		Tenants: map[string]observabilityv1beta1.TenantOverride{"team-a": {BlockRetention: "a month"}},
+	})
+	assert.ErrorContains(t, err, "tenant team-a")
+	_, err = RenderOverrides(&observabilityv1beta1.OverridesConfig{
+		Tenants: map[string]observabilityv1beta1.TenantOverride{"*": {}},
+	})
+	assert.Error(t, err)
+}
+
+func TestMerge(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	render := func(spec *observabilityv1beta1.OverridesConfig) Sections {
+		sections, err := RenderSections(spec)
+		require.NoError(t, err)
+		return sections
+	}
+	defaults := &observabilityv1beta1.OverridesConfig{MaxTracesPerUser: 10000}
+	sources := []Source{
+		{Name: "team-b", Created: now, Sections: render(&observabilityv1beta1.OverridesConfig{
+			MaxTracesPerUser: 10000,
+			Tenants: map[string]observabilityv1beta1.TenantOverride{
+				"team-b": {MaxTracesPerUser: 500},
+				"team-c": {MaxTracesPerUser: 100},
+			},
+		})},
+		{Name: "team-a", Created: now, Sections: render(&observabilityv1beta1.OverridesConfig{
+			MaxTracesPerUser: 10000,
+			Tenants: map[string]observabilityv1beta1.TenantOverride{
+				"team-a": {MaxTracesPerUser: 1000},
+				"team-c": {MaxTracesPerUser: 200},
+			},
+		})},
+		{Name: "platform", Created: now.Add(-time.Hour), Sections: render(&observabilityv1beta1.OverridesConfig{MaxTracesPerUser: 5000})},
+		{Name: "copy", Created: now.Add(time.Hour), Sections: render(defaults)},
+	}
+
+	merged, conflicts := Merge(sources)
+	assert.Equal(t, int64(5000), merged[DefaultsSection]["max_traces_per_user"])
+	assert.Equal(t, int64(1000), merged["team-a"]["max_traces_per_user"])
+	assert.Equal(t, int64(500), merged["team-b"]["max_traces_per_user"])
+	// Created at the same time, team-a is first by name
+	assert.Equal(t, int64(200), merged["team-c"]["max_traces_per_user"])
+
+	assert.ElementsMatch(t, []observabilityv1beta1.TempoConfigConflict{
+		{Section: DefaultsSection, TempoConfig: "team-a", Applied: true},
+		{Section: DefaultsSection, TempoConfig: "team-b", Applied: true},
+		{Section: DefaultsSection, TempoConfig: "copy", Applied: true},
+	}, conflicts["platform"])
+	assert.ElementsMatch(t, []observabilityv1beta1.TempoConfigConflict{
+		{Section: DefaultsSection, TempoConfig: "platform", Applied: false},
+		{Section: "team-c", TempoConfig: "team-b", Applied: true},
+	}, conflicts["team-a"])
+	assert.ElementsMatch(t, []observabilityv1beta1.TempoConfigConflict{
+		{Section: DefaultsSection, TempoConfig: "platform", Applied: false},
+		{Section: "team-c", TempoConfig: "team-a", Applied: false},
+	}, conflicts["team-b"])
+
+	// The order of the sources doesn't matter
+	reversed := []Source{sources[3], sources[2], sources[1], sources[0]}
+	again, _ := Merge(reversed)
+	assert.Equal(t, merged, again)
+
+	// Sections set the same way don't conflict
+	_, conflicts = Merge([]Source{
+		{Name: "a", Created: now, Sections: render(defaults)},
+		{Name: "b", Created: now, Sections: render(defaults)},
+	})
+	assert.Empty(t, conflicts)
+
+	data, err := Render(Sections{})
+	require.NoError(t, err)
+	assert.Equal(t, EmptyOverrides, data)
+}
+
+func TestParseByteSize(t *testing.T) {
+	for size, bytes := range map[string]int64{"512": 512, "10B": 10, "1KB": 1000, "1 MiB": 1 << 20, "2GB": 2e9} {
+		parsed, err := ParseByteSize(size)
+		require.NoError(t, err, size)
+		assert.Equal(t, bytes, parsed, size)
+	}
+	_, err := ParseByteSize("1.5MB")
+	assert.Error(t, err)
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, Backoff(1))
+	assert.Equal(t, 20*time.Second, Backoff(2))
+	assert.Equal(t, 80*time.Second, Backoff(4))
+	assert.Equal(t, MaxBackoff, Backoff(10))
+	assert.Equal(t, MaxBackoff, Backoff(1000))
+}
+
+func TestStateMachine(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	status := &observabilityv1beta1.TempoConfigSyncStatus{}
+
+	Observe(status, "h1", now)
+	assert.Equal(t, observabilityv1beta1.TempoConfigSyncPending, status.State)
+	assert.Equal(t, "h1", status.DesiredHash)
+
+	delay := Fail(status, ReasonTargetNotFound, errors.New("platform prod not found"), true, now)
+	assert.Equal(t, 10*time.Second, delay)
+	delay = Fail(status, ReasonTargetNotFound, errors.New("platform prod not found"), true, now.Add(delay))
+	assert.Equal(t, 20*time.Second, delay)
+	assert.Equal(t, observabilityv1beta1.TempoConfigSyncFailed, status.State)
+	assert.Equal(t, int32(2), status.Retries)
+	assert.Equal(t, "platform prod not found", status.LastError)
+	assert.Equal(t, now.Add(30*time.Second), status.NextRetryTime.Time)
+	assert.Equal(t, "Failed", Phase(status))
+
+	// The same configuration keeps its retries
+	Observe(status, "h1", now)
+	assert.Equal(t, int32(2), status.Retries)
+
+	Applying(status, now)
+	assert.Equal(t, observabilityv1beta1.TempoConfigSyncApplying, status.State)
+	assert.Nil(t, status.NextRetryTime)
+	reloaded, wait := Reloaded(status, now.Add(time.Second))
+	assert.False(t, reloaded)
+	assert.Equal(t, ReloadPeriod-time.Second, wait)
+	reloaded, _ = Reloaded(status, now.Add(ReloadPeriod))
+	assert.True(t, reloaded)
+
+	Applied(status, now.Add(ReloadPeriod))
+	assert.Equal(t, observabilityv1beta1.TempoConfigSyncApplied, status.State)
+	assert.Zero(t, status.Retries)
+	assert.Empty(t, status.LastError)
+	assert.Equal(t, now.Add(ReloadPeriod), status.LastTransitionTime.Time)
+
+	// A changed spec starts over
+	Observe(status, "h2", now.Add(time.Minute))
+	assert.Equal(t, observabilityv1beta1.TempoConfigSyncPending, status.State)
+	assert.Equal(t, ReasonDrifted, status.Reason)
+
+	delay = Fail(status, ReasonInvalid, errors.New("invalid size"), false, now)
+	assert.Zero(t, delay)
+	assert.Zero(t, status.Retries)
+	assert.Nil(t, status.NextRetryTime)
+	assert.Equal(t, "Invalid", Phase(status))
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package thanos
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// GRPCPort serves the Store API of the sidecar
+	GRPCPort int32 = 10901
+
+	// SidecarContainer is the name of the sidecar container in the
+	// Prometheus pods
+	SidecarContainer = "thanos-sidecar"
+	// ObjstoreVolume is the volume holding objstore.yml in the Prometheus pods
+	ObjstoreVolume = "thanos-objstore"
+
+	// ReplicaLabel is the external label telling the blocks of Prometheus
+	// replicas apart. Queriers deduplicate the replicas on it.
+	ReplicaLabel = "prometheus_replica"
+	// ReplicaLabelValue expands to the pod name of each replica, with the
+	// expand-external-labels feature of Prometheus and the POD_NAME variable
+	ReplicaLabelValue = "${POD_NAME}"
+
+	// MinUploadInterval is the shortest block duration accepted; shorter
+	// blocks flood the bucket with small blocks for the compactor to merge
+	MinUploadInterval = 5 * time.Minute
+)
+
+// Sidecar is the Thanos sidecar of a Prometheus pod
+type Sidecar struct {
+	// Image is the image repository; the Image constant is used if empty
+	Image   string
+	Version string
+	// ObjectStorageConfig is the Secret key holding objstore.yml
+	ObjectStorageConfig corev1.SecretKeySelector
+	// PrometheusURL is the Prometheus of the pod, for its external labels
+	// and the Store API of the blocks not uploaded yet
+	PrometheusURL string
+	// DataVolume and TSDBPath are the volume and mount path of the
+	// Prometheus TSDB, whose blocks are uploaded
+	DataVolume string
+	TSDBPath   string
+	Resources  *corev1.ResourceRequirements
+}
+
+// ImageRef returns the image reference of a Thanos version
+func ImageRef(repository, version string) string {
+	if repository == "" {
+		repository = Image
+	}
+	return fmt.Sprintf("%s:%s", repository, version)
+}
+
+// SidecarServiceName returns the name of the Service exposing the Store API
+// of the sidecars of a platform
+func SidecarServiceName(platform string) string {
+	return fmt.Sprintf("thanos-sidecar-%s", platform)
+}
+
+// SidecarArgs returns the arguments of the sidecar container
+func SidecarArgs(s Sidecar) []string {
+	return []string{
+		"sidecar",
+		"--log.format=logfmt",
+		fmt.Sprintf("--grpc-address=0.0.0.0:%d", GRPCPort),
+		fmt.Sprintf("--http-address=0.0.0.0:%d", HTTPPort),
+		"--prometheus.url=" + s.PrometheusURL,
+		"--tsdb.path=" + s.TSDBPath,
+		fmt.Sprintf("--objstore.config-file=%s/%s", objstorePath, s.ObjectStorageConfig.Key),
+	}
+}
+
+// BuildSidecar returns the sidecar container. It mounts the TSDB of
+// Prometheus and the objstore.yml volume returned by BuildObjstoreVolume.
+func BuildSidecar(s Sidecar) corev1.Container {
+	container := corev1.Container{
+		Name:  SidecarContainer,
+		Image: ImageRef(s.Image, s.Version),
+		Args:  SidecarArgs(s),
+		Ports: []corev1.ContainerPort{
+			{Name: "grpc", ContainerPort: GRPCPort, Protocol: corev1.ProtocolTCP},
+			{Name: "thanos-http", ContainerPort: HTTPPort, Protocol: corev1.ProtocolTCP},
+		},
+		LivenessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "/-/healthy", Port: intstr.FromString("thanos-http")},
+			},
+		},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "/-/ready", Port: intstr.FromString("thanos-http")},
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: s.DataVolume, MountPath: s.TSDBPath},
+			{Name: ObjstoreVolume, MountPath: objstorePath, ReadOnly: true},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: &[]bool{false}[0],
+			ReadOnlyRootFilesystem:   &[]bool{true}[0],
+		},
+	}
+	if s.Resources != nil {
+		container.Resources = *s.Resources
+	}
+	return container
+}
+
+// BuildObjstoreVolume returns the volume holding objstore.yml
+func BuildObjstoreVolume(config corev1.SecretKeySelector) corev1.Volume {
+	return corev1.Volume{
+		Name: ObjstoreVolume,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: config.Name,
+				Items:      []corev1.KeyToPath{{Key: config.Key, Path: config.Key}},
+			},
+		},
+	}
+}
+
+// PrometheusArgs returns the Prometheus flags the sidecar needs: blocks are
+// cut every upload interval and not compacted locally, and the replica label
+// is expanded to the pod name
+func PrometheusArgs(uploadInterval string) []string {
+	return []string{
+		"--storage.tsdb.min-block-duration=" + uploadInterval,
+		"--storage.tsdb.max-block-duration=" + uploadInterval,
+		"--enable-feature=expand-external-labels",
+	}
+}
+
+// PodNameEnv is the variable the replica label is expanded from
+func PodNameEnv() corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: "POD_NAME",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+		},
+	}
+}
+
+// BuildSidecarService returns the headless Service exposing the Store API of
+// every sidecar, so queriers can discover the replicas with a DNS SRV lookup
+// of _grpc._tcp.<service>
+func BuildSidecarService(name, namespace string, labels, selector map[string]string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Selector:  selector,
+			Ports: []corev1.ServicePort{
+				{Name: "grpc", Port: GRPCPort, TargetPort: intstr.FromString("grpc"), Protocol: corev1.ProtocolTCP},
+				{Name: "http", Port: HTTPPort, TargetPort: intstr.FromString("thanos-http"), Protocol: corev1.ProtocolTCP},
+			},
+		},
+	}
+}
+
+// ValidateUploadInterval checks the upload interval is a duration of at
+// least MinUploadInterval
+func ValidateUploadInterval(value string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	d, err := model.ParseDuration(value)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, value, err.Error()))
+	}
+	if time.Duration(d) < MinUploadInterval {
+		allErrs = append(allErrs, field.Invalid(fldPath, value,
+			fmt.Sprintf("must be at least %s", model.Duration(MinUploadInterval))))
+	}
+	return allErrs
+}
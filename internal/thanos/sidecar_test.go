@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package thanos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestBuildSidecar(t *testing.T) {
+	s := Sidecar{
+		Image:               "registry.example.com/thanos",
+		Version:             "v0.34.1",
+		ObjectStorageConfig: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "thanos-objstore"}, Key: "objstore.yml"},
+		PrometheusURL:       "http://localhost:9090",
+		DataVolume:          "data",
+		TSDBPath:            "/prometheus",
+	}
+
+	container := BuildSidecar(s)
+	assert.Equal(t, SidecarContainer, container.Name)
+	assert.Equal(t, "registry.example.com/thanos:v0.34.1", container.Image)
+	assert.Contains(t, container.Args, "--tsdb.path=/prometheus")
+	assert.Contains(t, container.Args, "--prometheus.url=http://localhost:9090")
+	assert.Contains(t, container.Args, "--objstore.config-file=/etc/thanos/objstore.yml")
+	assert.Contains(t, container.Args, "--grpc-address=0.0.0.0:10901")
+	require.Len(t, container.VolumeMounts, 2)
+	assert.Equal(t, corev1.VolumeMount{Name: "data", MountPath: "/prometheus"}, container.VolumeMounts[0])
+	assert.Equal(t, ObjstoreVolume, container.VolumeMounts[1].Name)
+	assert.Empty(t, container.Resources.Limits)
+
+	volume := BuildObjstoreVolume(s.ObjectStorageConfig)
+	assert.Equal(t, ObjstoreVolume, volume.Name)
+	assert.Equal(t, "thanos-objstore", volume.Secret.SecretName)
+
+	s.Image = ""
+	assert.Equal(t, "quay.io/thanos/thanos:v0.34.1", BuildSidecar(s).Image)
+}
+
+func TestPrometheusArgs(t *testing.T) {
+	assert.Equal(t, []string{
+		"--storage.tsdb.min-block-duration=2h",
+		"--storage.tsdb.max-block-duration=2h",
+		"--enable-feature=expand-external-labels",
+	}, PrometheusArgs("2h"))
+}
+
+func TestBuildSidecarService(t *testing.T) {
+	selector := map[string]string{"app.kubernetes.io/name": "prometheus"}
+	svc := BuildSidecarService(SidecarServiceName("prod"), "monitoring", nil, selector)
+	assert.Equal(t, "thanos-sidecar-prod", svc.Name)
+	assert.Equal(t, corev1.ClusterIPNone, svc.Spec.ClusterIP)
+	assert.Equal(t, selector, svc.Spec.Selector)
+	require.Len(t, svc.Spec.Ports, 2)
+	assert.Equal(t, "grpc", svc.Spec.Ports[0].Name)
+	assert.Equal(t, GRPCPort, svc.Spec.Ports[0].Port)
+}
+
+func TestValidateUploadInterval(t *testing.T) {
+	fldPath := field.NewPath("uploadInterval")
+	assert.Empty(t, ValidateUploadInterval("2h", fldPath))
+	assert.Empty(t, ValidateUploadInterval("30m", fldPath))
+	assert.Len(t, ValidateUploadInterval("1m", fldPath), 1)
+	assert.Len(t, ValidateUploadInterval("two hours", fldPath), 1)
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package thanos builds the Thanos components of a platform. The sidecar in
+// the Prometheus pods uploads the blocks to object storage and serves them to
+// queriers. The compactor compacts the blocks in object storage, downsamples
+// them to 5m and 1h resolution and deletes them once the retention of their
+// resolution expires.
+package thanos
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/common/model"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// Image is the Thanos image repository
+	Image = "quay.io/thanos/thanos"
+	// HTTPPort serves the metrics of the sidecar, and the compactor's metrics
+	// and bucket UI
+	HTTPPort int32 = 10902
+
+	// MinRawRetention is the youngest raw blocks are downsampled to 5m
+	// resolution; Thanos only downsamples blocks spanning 40h
+	MinRawRetention = 40 * time.Hour
+	// MinFiveMinutesRetention is the youngest 5m blocks are downsampled to 1h
+	// resolution; Thanos only downsamples blocks spanning 10d
+	MinFiveMinutesRetention = 10 * 24 * time.Hour
+
+	dataPath     = "/var/thanos/compact"
+	objstorePath = "/etc/thanos"
+)
+
+// Retention is the retention of each block resolution, in the duration
+// format of Prometheus (e.g. "30d"). A retention of 0 keeps blocks forever.
+type Retention struct {
+	Raw         string
+	FiveMinutes string
+	OneHour     string
+}
+
+// ValidateRetention checks the retention of each resolution is a duration
+// and, with downsampling, that blocks live long enough to be downsampled and
+// that coarser resolutions are not deleted before finer ones
+func ValidateRetention(r Retention, downsampling bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	parse := func(name, value string) (time.Duration, bool) {
+		d, err := model.ParseDuration(value)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(name), value, err.Error()))
+			return 0, false
+		}
+		return time.Duration(d), true
+	}
+	raw, rawOK := parse("raw", r.Raw)
+	fiveMinutes, fiveMinutesOK := parse("fiveMinutes", r.FiveMinutes)
+	oneHour, oneHourOK := parse("oneHour", r.OneHour)
+	if !downsampling {
+		return allErrs
+	}
+
+	if rawOK && raw != 0 && raw < MinRawRetention {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("raw"), r.Raw,
+			fmt.Sprintf("must be at least %s for raw blocks to be downsampled before they are deleted", model.Duration(MinRawRetention))))
+	}
+	if fiveMinutesOK && fiveMinutes != 0 && fiveMinutes < MinFiveMinutesRetention {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("fiveMinutes"), r.FiveMinutes,
+			fmt.Sprintf("must be at least %s for 5m blocks to be downsampled before they are deleted", model.Duration(MinFiveMinutesRetention))))
+	}
+	if rawOK && fiveMinutesOK && shorter(fiveMinutes, raw) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("fiveMinutes"), r.FiveMinutes,
+			"must not be shorter than the raw retention"))
+	}
+	if fiveMinutesOK && oneHourOK && shorter(oneHour, fiveMinutes) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("oneHour"), r.OneHour,
+			"must not be shorter than the 5m retention"))
+	}
+
+	return allErrs
+}
+
+// shorter reports whether retention a expires before b, where 0 is forever
+func shorter(a, b time.Duration) bool {
+	if a == 0 {
+		return false
+	}
+	return b == 0 || a < b
+}
+
+// Compactor is a Thanos compactor deployment
+type Compactor struct {
+	Name      string
+	Namespace string
+	// Image is the image repository; the Image constant is used if empty
+	Image   string
+	Version string
+	// ObjectStorageConfig is the Secret key holding objstore.yml
+	ObjectStorageConfig corev1.SecretKeySelector
+	Downsampling        bool
+	Retention           Retention
+	// Storage is the size of the working volume; an emptyDir is used if nil
+	Storage          *resource.Quantity
+	StorageClassName string
+	Labels           map[string]string
+}
+
+// CompactorName returns the name of the compactor StatefulSet of a platform
+func CompactorName(platform string) string {
+	return fmt.Sprintf("thanos-compactor-%s", platform)
+}
+
+// CompactorArgs returns the arguments of the compactor container
+func CompactorArgs(c Compactor) []string {
+	args := []string{
+		"compact",
+		"--wait",
+		"--log.format=logfmt",
+		fmt.Sprintf("--http-address=0.0.0.0:%d", HTTPPort),
+		"--data-dir=" + dataPath,
+		fmt.Sprintf("--objstore.config-file=%s/%s", objstorePath, c.ObjectStorageConfig.Key),
+		"--retention.resolution-raw=" + c.Retention.Raw,
+	}
+	if !c.Downsampling {
+		return append(args, "--downsampling.disable")
+	}
+	return append(args,
+		"--retention.resolution-5m="+c.Retention.FiveMinutes,
+		"--retention.resolution-1h="+c.Retention.OneHour,
+	)
+}
+
+// BuildCompactor returns the compactor StatefulSet. The compactor must run as
+// a singleton, as concurrent compactors corrupt the bucket.
+func BuildCompactor(c Compactor) *appsv1.StatefulSet {
+	replicas := int32(1)
+
+	volumes := []corev1.Volume{{
+		Name: "objstore",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: c.ObjectStorageConfig.Name,
+				Items:      []corev1.KeyToPath{{Key: c.ObjectStorageConfig.Key, Path: c.ObjectStorageConfig.Key}},
+			},
+		},
+	}}
+	var claims []corev1.PersistentVolumeClaim
+	if c.Storage != nil {
+		claim := corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: *c.Storage},
+				},
+			},
+		}
+		if c.StorageClassName != "" {
+			claim.Spec.StorageClassName = &c.StorageClassName
+		}
+		claims = append(claims, claim)
+	} else {
+		volumes = append(volumes, corev1.Volume{
+			Name:         "data",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+	}
+
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.Name,
+			Namespace: c.Namespace,
+			Labels:    c.Labels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			ServiceName: c.Name,
+			Replicas:    &replicas,
+			Selector:    &metav1.LabelSelector{MatchLabels: c.Labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: c.Labels},
+				Spec: corev1.PodSpec{
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: &[]bool{true}[0],
+						RunAsUser:    &[]int64{65534}[0],
+						FSGroup:      &[]int64{65534}[0],
+					},
+					Containers: []corev1.Container{{
+						Name:  "thanos-compactor",
+						Image: ImageRef(c.Image, c.Version),
+						Args:  CompactorArgs(c),
+						Ports: []corev1.ContainerPort{{
+							Name:          "http",
+							ContainerPort: HTTPPort,
+							Protocol:      corev1.ProtocolTCP,
+						}},
+						LivenessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{Path: "/-/healthy", Port: intstr.FromString("http")},
+							},
+						},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{Path: "/-/ready", Port: intstr.FromString("http")},
+							},
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "objstore", MountPath: objstorePath, ReadOnly: true},
+							{Name: "data", MountPath: dataPath},
+						},
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: &[]bool{false}[0],
+							ReadOnlyRootFilesystem:   &[]bool{true}[0],
+						},
+					}},
+					Volumes: volumes,
+				},
+			},
+			VolumeClaimTemplates: claims,
+		},
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package thanos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateRetention(t *testing.T) {
+	fldPath := field.NewPath("retention")
+
+	tests := []struct {
+		name         string
+		retention    Retention
+		downsampling bool
+		invalid      []string
+	}{
+		{
+			name:         "defaults",
+			retention:    Retention{Raw: "30d", FiveMinutes: "90d", OneHour: "1y"},
+			downsampling: true,
+		},
+		{
+			name:         "forever",
+			retention:    Retention{Raw: "30d", FiveMinutes: "0d", OneHour: "0d"},
+			downsampling: true,
+		},
+		{
+			name:         "unparsable",
+			retention:    Retention{Raw: "30 days", FiveMinutes: "90d", OneHour: "1y"},
+			downsampling: true,
+			invalid:      []string{"retention.raw"},
+		},
+		{
+			name:         "raw too short to downsample",
+			retention:    Retention{Raw: "1d", FiveMinutes: "90d", OneHour: "1y"},
+			downsampling: true,
+			invalid:      []string{"retention.raw"},
+		},
+		{
+			name:         "5m too short to downsample",
+			retention:    Retention{Raw: "2d", FiveMinutes: "7d", OneHour: "1y"},
+			downsampling: true,
+			invalid:      []string{"retention.fiveMinutes"},
+		},
+		{
+			name:         "coarser resolutions expire first",
+			retention:    Retention{Raw: "0d", FiveMinutes: "90d", OneHour: "30d"},
+			downsampling: true,
+			invalid:      []string{"retention.fiveMinutes", "retention.oneHour"},
+		},
+		{
+			name:      "short raw retention without downsampling",
+			retention: Retention{Raw: "1d", FiveMinutes: "90d", OneHour: "1y"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateRetention(tt.retention, tt.downsampling, fldPath)
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			assert.Equal(t, tt.invalid, fields)
+		})
+	}
+}
+
+func TestCompactorArgs(t *testing.T) {
+	c := Compactor{
+		ObjectStorageConfig: corev1.SecretKeySelector{Key: "objstore.yml"},
+		Downsampling:        true,
+		Retention:           Retention{Raw: "30d", FiveMinutes: "90d", OneHour: "1y"},
+	}
+
+	args := CompactorArgs(c)
+	assert.Contains(t, args, "--objstore.config-file=/etc/thanos/objstore.yml")
+	assert.Contains(t, args, "--retention.resolution-raw=30d")
+	assert.Contains(t, args, "--retention.resolution-5m=90d")
+	assert.Contains(t, args, "--retention.resolution-1h=1y")
+	assert.NotContains(t, args, "--downsampling.disable")
+
+	c.Downsampling = false
+	args = CompactorArgs(c)
+	assert.Contains(t, args, "--downsampling.disable")
+	assert.NotContains(t, args, "--retention.resolution-5m=90d")
+}
+
+func TestBuildCompactor(t *testing.T) {
+	c := Compactor{
+		Name:                CompactorName("prod"),
+		Namespace:           "monitoring",
+		Version:             "v0.34.1",
+		ObjectStorageConfig: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "thanos-objstore"}, Key: "objstore.yml"},
+		Downsampling:        true,
+		Retention:           Retention{Raw: "30d", FiveMinutes: "90d", OneHour: "1y"},
+		Labels:              map[string]string{"app.kubernetes.io/name": "thanos-compactor"},
+	}
+
+	sts := BuildCompactor(c)
+	assert.Equal(t, "thanos-compactor-prod", sts.Name)
+	assert.Equal(t, int32(1), *sts.Spec.Replicas)
+	require.Len(t, sts.Spec.Template.Spec.Containers, 1)
+	assert.Equal(t, "quay.io/thanos/thanos:v0.34.1", sts.Spec.Template.Spec.Containers[0].Image)
+	assert.Empty(t, sts.Spec.VolumeClaimTemplates)
+	require.Len(t, sts.Spec.Template.Spec.Volumes, 2)
+	assert.Equal(t, "thanos-objstore", sts.Spec.Template.Spec.Volumes[0].Secret.SecretName)
+	assert.NotNil(t, sts.Spec.Template.Spec.Volumes[1].EmptyDir)
+
+	size := resource.MustParse("50Gi")
+	c.Storage = &size
+	sts = BuildCompactor(c)
+	require.Len(t, sts.Spec.VolumeClaimTemplates, 1)
+	assert.Len(t, sts.Spec.Template.Spec.Volumes, 1)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package timeline
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/graph"
+)
+
+// Collect builds the timeline of a platform from the events reported on it
+// and on the objects managed for it, and from its status
+func Collect(ctx context.Context, c client.Reader, platform *observabilityv1beta1.ObservabilityPlatform, opts Options) (*Timeline, error) {
+	g, err := graph.Collect(ctx, c, platform)
+	if err != nil {
+		return nil, err
+	}
+	managed := make(map[string]bool, len(g.Nodes))
+	for _, node := range g.Nodes {
+		if !node.External {
+			managed[node.Kind+"/"+node.Name] = true
+		}
+	}
+
+	list := &corev1.EventList{}
+	if err := c.List(ctx, list, client.InNamespace(platform.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	var events []corev1.Event
+	for _, event := range list.Items {
+		involved := event.InvolvedObject
+		if involved.UID == platform.UID || managed[involved.Kind+"/"+involved.Name] {
+			events = append(events, event)
+		}
+	}
+
+	return &Timeline{
+		Platform:  platform.Name,
+		Namespace: platform.Namespace,
+		Entries:   Build(opts, FromEvents(events), FromStatus(platform)),
+	}, nil
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package timeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// components are the platform components conditions and events can refer to
+var components = []string{"prometheus", "grafana", "loki", "tempo"}
+
+// FromEvents converts Kubernetes events into entries. The operator prefixes
+// the message of component events with "[component] ", which is moved to
+// the entry's component.
+func FromEvents(events []corev1.Event) []Entry {
+	entries := make([]Entry, 0, len(events))
+	for _, event := range events {
+		entry := Entry{
+			Time:    eventTime(event),
+			Kind:    kindOf(event.Reason),
+			Object:  event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+			Reason:  event.Reason,
+			Message: event.Message,
+			Warning: event.Type == corev1.EventTypeWarning,
+		}
+		if strings.HasPrefix(entry.Message, "[") {
+			if end := strings.Index(entry.Message, "] "); end > 0 {
+				entry.Component = entry.Message[1:end]
+				entry.Message = entry.Message[end+2:]
+			}
+		}
+		if event.Count > 1 {
+			entry.Message = fmt.Sprintf("%s (x%d)", entry.Message, event.Count)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// eventTime returns when an event last occurred
+func eventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// FromStatus derives entries from the platform status: the last transition
+// of each condition, and the steps of the upgrades, storage migrations and
+// snapshots it records. Unlike events, these survive the event TTL.
+func FromStatus(platform *observabilityv1beta1.ObservabilityPlatform) []Entry {
+	status := &platform.Status
+	var entries []Entry
+
+	for _, c := range status.Conditions {
+		entries = append(entries, Entry{
+			Time:      c.LastTransitionTime.Time,
+			Kind:      KindCondition,
+			Component: conditionComponent(c.Type),
+			Reason:    c.Reason,
+			Message:   strings.TrimSuffix(fmt.Sprintf("%s is %s: %s", c.Type, c.Status, c.Message), ": "),
+			Warning:   conditionWarning(c),
+		})
+	}
+
+	for _, component := range sortedKeys(status.UpgradeHooks) {
+		hook := status.UpgradeHooks[component]
+		if hook.LastTransitionTime == nil {
+			continue
+		}
+		reason := "UpgradeHookStarted"
+		switch hook.State {
+		case "Succeeded":
+			reason = "UpgradeHookSucceeded"
+		case "Failed":
+			reason = "UpgradeHookFailed"
+		}
+		entries = append(entries, Entry{
+			Time:      hook.LastTransitionTime.Time,
+			Kind:      KindUpgrade,
+			Component: component,
+			Reason:    reason,
+			Message:   fmt.Sprintf("%s hook %s of upgrade %s -> %s", hook.Phase, hook.Hook, hook.From, hook.To),
+			Warning:   hook.State == "Failed",
+		})
+	}
+
+	for _, component := range sortedKeys(status.StorageMigrations) {
+		migration := status.StorageMigrations[component]
+		message := fmt.Sprintf("Migrating volumes from StorageClass %s to %s", migration.From, migration.To)
+		if migration.StartTime != nil {
+			entries = append(entries, Entry{
+				Time:      migration.StartTime.Time,
+				Kind:      KindMigration,
+				Component: component,
+				Reason:    "StorageMigrationStarted",
+				Message:   message,
+			})
+		}
+		if migration.CompletionTime != nil {
+			failed := migration.Phase == "Failed"
+			entry := Entry{
+				Time:      migration.CompletionTime.Time,
+				Kind:      KindMigration,
+				Component: component,
+				Reason:    "StorageMigrationCompleted",
+				Message:   message,
+				Warning:   failed,
+			}
+			if failed {
+				entry.Reason = "StorageMigrationFailed"
+				entry.Message = migration.Message
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	for _, component := range sortedKeys(status.UpgradeSnapshots) {
+		for _, snapshots := range status.UpgradeSnapshots[component] {
+			entries = append(entries, Entry{
+				Time:      snapshots.CreationTime.Time,
+				Kind:      KindBackup,
+				Component: component,
+				Reason:    "UpgradeSnapshotsRequested",
+				Message: fmt.Sprintf("Snapshotting %d volumes before upgrade %s -> %s (%s)",
+					len(snapshots.Snapshots), snapshots.From, snapshots.To, snapshots.Phase),
+				Warning: snapshots.Phase == "Failed",
+			})
+		}
+	}
+
+	if dp := status.DataProtection; dp != nil {
+		if dp.StartTime != nil {
+			entries = append(entries, Entry{
+				Time:    dp.StartTime.Time,
+				Kind:    KindBackup,
+				Reason:  "DataProtectionStarted",
+				Message: fmt.Sprintf("Applying the %s deletion policy", dp.Policy),
+			})
+		}
+		if dp.CompletionTime != nil {
+			entries = append(entries, Entry{
+				Time:    dp.CompletionTime.Time,
+				Kind:    KindBackup,
+				Reason:  "DataProtection" + dp.Phase,
+				Message: dp.Message,
+				Warning: dp.Phase == "Failed",
+			})
+		}
+	}
+
+	return entries
+}
+
+// conditionComponent returns the component of a condition such as
+// PrometheusReady
+func conditionComponent(conditionType string) string {
+	for _, component := range components {
+		if strings.HasPrefix(strings.ToLower(conditionType), component) {
+			return component
+		}
+	}
+	return ""
+}
+
+// conditionWarning reports whether a condition is in a bad state
+func conditionWarning(c metav1.Condition) bool {
+	switch c.Type {
+	case "Degraded", "Error":
+		return c.Status == metav1.ConditionTrue
+	}
+	return strings.HasSuffix(c.Type, "Ready") && c.Status == metav1.ConditionFalse
+}
+
+// sortedKeys returns the keys of a map in order, for a stable feed
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package timeline merges the Kubernetes events, condition transitions,
+// upgrades, storage migrations and backups of a platform into a single
+// chronological feed.
+package timeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kind is the category of a timeline entry
+type Kind string
+
+const (
+	// KindEvent is a Kubernetes event not covered by another kind
+	KindEvent Kind = "Event"
+	// KindCondition is a transition of a platform condition
+	KindCondition Kind = "Condition"
+	// KindUpgrade is a step of a component upgrade
+	KindUpgrade Kind = "Upgrade"
+	// KindMigration is a step of a storage migration
+	KindMigration Kind = "Migration"
+	// KindBackup is a backup, restore or volume snapshot
+	KindBackup Kind = "Backup"
+)
+
+// Kinds lists the entry kinds
+var Kinds = []Kind{KindEvent, KindCondition, KindUpgrade, KindMigration, KindBackup}
+
+// DedupWindow is how close in time an event and a status entry with the
+// same component and reason must be to be reported once
+const DedupWindow = time.Minute
+
+// ParseKinds parses a comma-separated list of kinds, case-insensitively
+func ParseKinds(names string) ([]Kind, error) {
+	var kinds []Kind
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, k := range Kinds {
+			if strings.EqualFold(string(k), name) {
+				kinds = append(kinds, k)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unsupported timeline kind %q (supported: event, condition, upgrade, migration, backup)", name)
+		}
+	}
+	return kinds, nil
+}
+
+// Entry is something that happened to a platform
+type Entry struct {
+	Time time.Time `json:"time"`
+	Kind Kind      `json:"kind"`
+	// Component is the component concerned, if any
+	Component string `json:"component,omitempty"`
+	// Object is the object an event was reported on, as Kind/Name
+	Object  string `json:"object,omitempty"`
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+	// Warning is set for failures and degradations
+	Warning bool `json:"warning,omitempty"`
+}
+
+// Timeline is the chronological feed of a platform, oldest entry first
+type Timeline struct {
+	Platform  string  `json:"platform"`
+	Namespace string  `json:"namespace"`
+	Entries   []Entry `json:"entries"`
+}
+
+// Options filter the entries of a timeline
+type Options struct {
+	// Since drops the entries before it
+	Since time.Time
+	// Kinds keeps only these kinds; all if empty
+	Kinds []Kind
+	// Component keeps only the entries of a component
+	Component string
+	// Limit keeps only the newest entries; all if 0
+	Limit int
+}
+
+// Build merges entries into a chronological feed. Sources are given in order
+// of preference: an entry with the same component and reason as one of an
+// earlier source within DedupWindow is dropped, so a step reported both as
+// an event and in the status is listed once.
+func Build(opts Options, sources ...[]Entry) []Entry {
+	entries := []Entry{}
+	for _, source := range sources {
+		kept := len(entries)
+		for _, entry := range source {
+			if !opts.matches(entry) || duplicate(entries[:kept], entry) {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+	if opts.Limit > 0 && len(entries) > opts.Limit {
+		entries = entries[len(entries)-opts.Limit:]
+	}
+	return entries
+}
+
+// matches reports whether an entry passes the filters
+func (o Options) matches(entry Entry) bool {
+	if !o.Since.IsZero() && entry.Time.Before(o.Since) {
+		return false
+	}
+	if o.Component != "" && entry.Component != o.Component {
+		return false
+	}
+	if len(o.Kinds) == 0 {
+		return true
+	}
+	for _, k := range o.Kinds {
+		if entry.Kind == k {
+			return true
+		}
+	}
+	return false
+}
+
+// duplicate reports whether entries already contain the same step
+func duplicate(entries []Entry, entry Entry) bool {
+	for _, e := range entries {
+		if e.Component != entry.Component || e.Reason != entry.Reason {
+			continue
+		}
+		if d := e.Time.Sub(entry.Time); d <= DedupWindow && d >= -DedupWindow {
+			return true
+		}
+	}
+	return false
+}
+
+// kindOf categorizes an event by its reason
+func kindOf(reason string) Kind {
+	switch {
+	case strings.Contains(reason, "Migration"):
+		return KindMigration
+	case strings.Contains(reason, "Snapshot"), strings.Contains(reason, "Backup"),
+		strings.HasPrefix(reason, "Restore"), strings.HasPrefix(reason, "DataProtection"):
+		return KindBackup
+	case strings.Contains(reason, "Upgrad"), reason == "PlatformRolledBack":
+		return KindUpgrade
+	}
+	return KindEvent
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package timeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+var t0 = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func at(minutes int) time.Time {
+	return t0.Add(time.Duration(minutes) * time.Minute)
+}
+
+func TestParseKinds(t *testing.T) {
+	kinds, err := ParseKinds("upgrade, Migration,")
+	require.NoError(t, err)
+	assert.Equal(t, []Kind{KindUpgrade, KindMigration}, kinds)
+
+	kinds, err = ParseKinds("")
+	require.NoError(t, err)
+	assert.Empty(t, kinds)
+
+	_, err = ParseKinds("upgrade,deploy")
+	assert.Error(t, err)
+}
+
+func TestBuild(t *testing.T) {
+	events := []Entry{
+		{Time: at(10), Kind: KindMigration, Component: "loki", Reason: "StorageMigrationStarted", Message: "from event"},
+		{Time: at(2), Kind: KindEvent, Reason: "PlatformReady"},
+	}
+	status := []Entry{
+		{Time: at(10).Add(30 * time.Second), Kind: KindMigration, Component: "loki", Reason: "StorageMigrationStarted", Message: "from status"},
+		{Time: at(5), Kind: KindCondition, Component: "prometheus", Reason: "ComponentReady"},
+		{Time: at(20), Kind: KindMigration, Component: "loki", Reason: "StorageMigrationStarted", Message: "second migration"},
+	}
+
+	entries := Build(Options{}, events, status)
+	require.Len(t, entries, 4)
+	assert.Equal(t, "PlatformReady", entries[0].Reason)
+	assert.Equal(t, "ComponentReady", entries[1].Reason)
+	assert.Equal(t, "from event", entries[2].Message)
+	assert.Equal(t, "second migration", entries[3].Message)
+
+	entries = Build(Options{Kinds: []Kind{KindMigration}, Since: at(15)}, events, status)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "second migration", entries[0].Message)
+
+	entries = Build(Options{Component: "loki"}, events, status)
+	assert.Len(t, entries, 2)
+
+	entries = Build(Options{Limit: 2}, events, status)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "from event", entries[0].Message)
+
+	assert.NotNil(t, Build(Options{}))
+}
+
+func TestFromEvents(t *testing.T) {
+	entries := FromEvents([]corev1.Event{
+		{
+			InvolvedObject: corev1.ObjectReference{Kind: "ObservabilityPlatform", Name: "prod"},
+			Reason:         "UpgradeHookFailed",
+			Message:        "[loki] pre-upgrade hook failed",
+			Type:           corev1.EventTypeWarning,
+			LastTimestamp:  metav1.NewTime(at(3)),
+		},
+		{
+			InvolvedObject: corev1.ObjectReference{Kind: "StatefulSet", Name: "loki-prod"},
+			Reason:         "SuccessfulCreate",
+			Message:        "create Pod loki-prod-0",
+			Type:           corev1.EventTypeNormal,
+			Count:          3,
+			EventTime:      metav1.NewMicroTime(at(4)),
+		},
+		{
+			Reason:  "UpgradeSnapshotsReady",
+			Message: "[prometheus] Snapshotted 2 volumes",
+		},
+	})
+
+	require.Len(t, entries, 3)
+	assert.Equal(t, Entry{
+		Time:      at(3),
+		Kind:      KindUpgrade,
+		Component: "loki",
+		Object:    "ObservabilityPlatform/prod",
+		Reason:    "UpgradeHookFailed",
+		Message:   "pre-upgrade hook failed",
+		Warning:   true,
+	}, entries[0])
+	assert.Equal(t, at(4), entries[1].Time)
+	assert.Equal(t, KindEvent, entries[1].Kind)
+	assert.Equal(t, "create Pod loki-prod-0 (x3)", entries[1].Message)
+	assert.Equal(t, KindBackup, entries[2].Kind)
+	assert.Equal(t, "prometheus", entries[2].Component)
+}
+
+func TestFromStatus(t *testing.T) {
+	platform := &observabilityv1beta1.ObservabilityPlatform{}
+	platform.Status = observabilityv1beta1.ObservabilityPlatformStatus{
+		Conditions: []metav1.Condition{
+			{Type: "LokiReady", Status: metav1.ConditionFalse, Reason: "ComponentFailed", Message: "0/1 replicas", LastTransitionTime: metav1.NewTime(at(1))},
+			{Type: "Progressing", Status: metav1.ConditionFalse, Reason: "Ready", LastTransitionTime: metav1.NewTime(at(2))},
+		},
+		UpgradeHooks: map[string]observabilityv1beta1.UpgradeHookStatus{
+			"loki": {From: "2.8.0", To: "2.9.0", Phase: "pre-upgrade", Hook: "compact", State: "Succeeded", LastTransitionTime: &metav1.Time{Time: at(3)}},
+		},
+		StorageMigrations: map[string]observabilityv1beta1.StorageMigrationStatus{
+			"prometheus": {From: "standard", To: "fast", Phase: "Failed", Message: "copy failed", StartTime: &metav1.Time{Time: at(4)}, CompletionTime: &metav1.Time{Time: at(5)}},
+		},
+		UpgradeSnapshots: map[string][]observabilityv1beta1.UpgradeSnapshotStatus{
+			"loki": {{From: "2.8.0", To: "2.9.0", Phase: "Ready", CreationTime: metav1.NewTime(at(2)),
+				Snapshots: []observabilityv1beta1.UpgradeVolumeSnapshot{{Claim: "data-loki-0", Snapshot: "s"}}}},
+		},
+	}
+
+	entries := Build(Options{}, FromStatus(platform))
+	require.Len(t, entries, 6)
+
+	assert.Equal(t, "loki", entries[0].Component)
+	assert.Equal(t, "LokiReady is False: 0/1 replicas", entries[0].Message)
+	assert.True(t, entries[0].Warning)
+
+	assert.Equal(t, "Progressing is False", entries[1].Message)
+	assert.False(t, entries[1].Warning)
+
+	assert.Equal(t, KindBackup, entries[2].Kind)
+	assert.Equal(t, "Snapshotting 1 volumes before upgrade 2.8.0 -> 2.9.0 (Ready)", entries[2].Message)
+
+	assert.Equal(t, "UpgradeHookSucceeded", entries[3].Reason)
+	assert.Equal(t, "pre-upgrade hook compact of upgrade 2.8.0 -> 2.9.0", entries[3].Message)
+
+	assert.Equal(t, "StorageMigrationStarted", entries[4].Reason)
+	assert.Equal(t, "StorageMigrationFailed", entries[5].Reason)
+	assert.Equal(t, "copy failed", entries[5].Message)
+	assert.True(t, entries[5].Warning)
+}
+
+func TestCollect(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	platform := &observabilityv1beta1.ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "monitoring", UID: "p"},
+	}
+	owner := []metav1.OwnerReference{{Kind: "ObservabilityPlatform", Name: "prod", UID: "p"}}
+	event := func(name string, involved corev1.ObjectReference, minute int) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "monitoring"},
+			InvolvedObject: involved,
+			Reason:         name,
+			LastTimestamp:  metav1.NewTime(at(minute)),
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "loki-prod", Namespace: "monitoring", UID: "s", OwnerReferences: owner}},
+		event("PlatformReady", corev1.ObjectReference{Kind: "ObservabilityPlatform", Name: "prod", UID: "p"}, 2),
+		event("SuccessfulCreate", corev1.ObjectReference{Kind: "StatefulSet", Name: "loki-prod", UID: "s"}, 1),
+		event("Unrelated", corev1.ObjectReference{Kind: "StatefulSet", Name: "other"}, 3),
+	).Build()
+
+	timeline, err := Collect(context.Background(), c, platform, Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "prod", timeline.Platform)
+	require.Len(t, timeline.Entries, 2)
+	assert.Equal(t, "SuccessfulCreate", timeline.Entries[0].Reason)
+	assert.Equal(t, "StatefulSet/loki-prod", timeline.Entries[0].Object)
+	assert.Equal(t, "PlatformReady", timeline.Entries[1].Reason)
+}
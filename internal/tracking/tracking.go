@@ -0,0 +1,315 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package tracking links a platform to the children ownerReferences cannot
+// express: cluster scoped objects and objects in other namespaces than the
+// platform. Such children carry tracking labels naming their owner, and an
+// Index kept up to date from watch events maps each platform to its tracked
+// children, so they trigger reconciles of their owner, are counted in its
+// status and are pruned when the platform no longer wants them.
+package tracking
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// OwnerNameLabel is the name of the platform owning a tracked child
+	OwnerNameLabel = "observability.io/owner-name"
+	// OwnerNamespaceLabel is the namespace of the platform owning a tracked
+	// child
+	OwnerNamespaceLabel = "observability.io/owner-namespace"
+	// OwnerUIDLabel is the UID of the platform owning a tracked child, so the
+	// children of a deleted platform are not adopted by a new one with the
+	// same name
+	OwnerUIDLabel = "observability.io/owner-uid"
+)
+
+// NeedsTracking returns whether child cannot be owned by owner through an
+// ownerReference, which requires both in the same namespace
+func NeedsTracking(owner, child metav1.Object) bool {
+	return child.GetNamespace() != owner.GetNamespace()
+}
+
+// SetOwner sets the tracking labels of owner on child
+func SetOwner(owner, child metav1.Object) {
+	labels := child.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[OwnerNameLabel] = owner.GetName()
+	labels[OwnerNamespaceLabel] = owner.GetNamespace()
+	labels[OwnerUIDLabel] = string(owner.GetUID())
+	child.SetLabels(labels)
+}
+
+// OwnerOf returns the owner named by the tracking labels of child
+func OwnerOf(child metav1.Object) (types.NamespacedName, bool) {
+	labels := child.GetLabels()
+	name, namespace := labels[OwnerNameLabel], labels[OwnerNamespaceLabel]
+	if name == "" || namespace == "" {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, true
+}
+
+// OwnedBy returns whether child is tracked as a child of owner
+func OwnedBy(owner, child metav1.Object) bool {
+	key, ok := OwnerOf(child)
+	return ok && key.Name == owner.GetName() && key.Namespace == owner.GetNamespace() &&
+		child.GetLabels()[OwnerUIDLabel] == string(owner.GetUID())
+}
+
+// MatchingOwner selects the tracked children of owner
+func MatchingOwner(owner metav1.Object) client.MatchingLabels {
+	return client.MatchingLabels{
+		OwnerNameLabel:      owner.GetName(),
+		OwnerNamespaceLabel: owner.GetNamespace(),
+		OwnerUIDLabel:       string(owner.GetUID()),
+	}
+}
+
+// Ref identifies a tracked child
+type Ref struct {
+	Kind      schema.GroupVersionKind
+	Namespace string
+	Name      string
+}
+
+// Less orders refs by kind, namespace and name
+func (r Ref) Less(o Ref) bool {
+	if r.Kind.String() != o.Kind.String() {
+		return r.Kind.String() < o.Kind.String()
+	}
+	if r.Namespace != o.Namespace {
+		return r.Namespace < o.Namespace
+	}
+	return r.Name < o.Name
+}
+
+// Index maps platforms to their tracked children. It is fed by the event
+// handlers of the tracked kinds and records the children each reconcile
+// applies, so the ones no longer applied can be pruned. A nil index tracks
+// nothing.
+type Index struct {
+	mu         sync.Mutex
+	children   map[types.NamespacedName]map[Ref]bool
+	owners     map[Ref]types.NamespacedName
+	applied    map[types.NamespacedName]map[Ref]bool
+	incomplete map[types.NamespacedName]bool
+}
+
+// NewIndex returns an empty index
+func NewIndex() *Index {
+	return &Index{
+		children:   make(map[types.NamespacedName]map[Ref]bool),
+		owners:     make(map[Ref]types.NamespacedName),
+		applied:    make(map[types.NamespacedName]map[Ref]bool),
+		incomplete: make(map[types.NamespacedName]bool),
+	}
+}
+
+// Set records child as tracked by owner, replacing its previous owner
+func (i *Index) Set(ref Ref, owner types.NamespacedName) {
+	if i == nil {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.remove(ref)
+	if i.children[owner] == nil {
+		i.children[owner] = make(map[Ref]bool)
+	}
+	i.children[owner][ref] = true
+	i.owners[ref] = owner
+}
+
+// Remove forgets a child
+func (i *Index) Remove(ref Ref) {
+	if i == nil {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.remove(ref)
+}
+
+func (i *Index) remove(ref Ref) {
+	owner, ok := i.owners[ref]
+	if !ok {
+		return
+	}
+	delete(i.owners, ref)
+	delete(i.children[owner], ref)
+	if len(i.children[owner]) == 0 {
+		delete(i.children, owner)
+	}
+}
+
+// Children returns the tracked children of owner, sorted
+func (i *Index) Children(owner types.NamespacedName) []Ref {
+	if i == nil {
+		return nil
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	refs := make([]Ref, 0, len(i.children[owner]))
+	for ref := range i.children[owner] {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(a, b int) bool { return refs[a].Less(refs[b]) })
+	return refs
+}
+
+// Count returns the number of tracked children of owner
+func (i *Index) Count(owner types.NamespacedName) int {
+	if i == nil {
+		return 0
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return len(i.children[owner])
+}
+
+// BeginReconcile starts recording the children applied for owner. The
+// reconciles of a platform never run concurrently.
+func (i *Index) BeginReconcile(owner types.NamespacedName) {
+	if i == nil {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.applied[owner] = make(map[Ref]bool)
+	delete(i.incomplete, owner)
+}
+
+// Incomplete marks the current reconcile of owner as having failed to apply
+// some of its children, which must then not be pruned
+func (i *Index) Incomplete(owner types.NamespacedName) {
+	if i == nil {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.incomplete[owner] = true
+}
+
+// Applied records a child as wanted by the current reconcile of owner
+func (i *Index) Applied(owner types.NamespacedName, ref Ref) {
+	if i == nil {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.applied[owner] == nil {
+		i.applied[owner] = make(map[Ref]bool)
+	}
+	i.applied[owner][ref] = true
+}
+
+// Stale returns the tracked children of owner not applied by its current
+// reconcile, none if no reconcile began or it was incomplete, and ends the
+// reconcile
+func (i *Index) Stale(owner types.NamespacedName) []Ref {
+	if i == nil {
+		return nil
+	}
+	i.mu.Lock()
+	applied, begun := i.applied[owner]
+	incomplete := i.incomplete[owner]
+	delete(i.applied, owner)
+	delete(i.incomplete, owner)
+	i.mu.Unlock()
+	if !begun || incomplete {
+		return nil
+	}
+
+	var stale []Ref
+	for _, ref := range i.Children(owner) {
+		if !applied[ref] {
+			stale = append(stale, ref)
+		}
+	}
+	return stale
+}
+
+// Forget drops owner from the index once it is deleted
+func (i *Index) Forget(owner types.NamespacedName) {
+	if i == nil {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for ref := range i.children[owner] {
+		delete(i.owners, ref)
+	}
+	delete(i.children, owner)
+	delete(i.applied, owner)
+	delete(i.incomplete, owner)
+}
+
+// EventHandler returns the handler of the watch of a tracked kind. It keeps
+// the index up to date and enqueues the owner of the changed child.
+func (i *Index) EventHandler(kind schema.GroupVersionKind) handler.EventHandler {
+	return &eventHandler{index: i, kind: kind}
+}
+
+type eventHandler struct {
+	index *Index
+	kind  schema.GroupVersionKind
+}
+
+func (h *eventHandler) ref(obj client.Object) Ref {
+	return Ref{Kind: h.kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+}
+
+// observe updates the index from obj and enqueues its owner
+func (h *eventHandler) observe(obj client.Object, q workqueue.RateLimitingInterface) {
+	ref := h.ref(obj)
+	owner, ok := OwnerOf(obj)
+	if !ok {
+		h.index.Remove(ref)
+		return
+	}
+	h.index.Set(ref, owner)
+	q.Add(reconcile.Request{NamespacedName: owner})
+}
+
+func (h *eventHandler) Create(_ context.Context, e event.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.observe(e.Object, q)
+}
+
+func (h *eventHandler) Update(_ context.Context, e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	// A child whose tracking labels changed also notifies its previous owner
+	if owner, ok := OwnerOf(e.ObjectOld); ok {
+		if current, _ := OwnerOf(e.ObjectNew); current != owner {
+			q.Add(reconcile.Request{NamespacedName: owner})
+		}
+	}
+	h.observe(e.ObjectNew, q)
+}
+
+func (h *eventHandler) Delete(_ context.Context, e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	h.index.Remove(h.ref(e.Object))
+	if owner, ok := OwnerOf(e.Object); ok {
+		q.Add(reconcile.Request{NamespacedName: owner})
+	}
+}
+
+func (h *eventHandler) Generic(_ context.Context, e event.GenericEvent, q workqueue.RateLimitingInterface) {
+	h.observe(e.Object, q)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package tracking
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var (
+	clusterRoleKind = rbacv1.SchemeGroupVersion.WithKind("ClusterRole")
+	owner           = &metav1.ObjectMeta{Name: "prod", Namespace: "monitoring", UID: "uid-1"}
+	ownerKey        = types.NamespacedName{Namespace: "monitoring", Name: "prod"}
+)
+
+func TestSetOwner(t *testing.T) {
+	role := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "prod-alloy", Labels: map[string]string{"app": "alloy"}}}
+	assert.True(t, NeedsTracking(owner, role))
+	assert.False(t, NeedsTracking(owner, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring"}}))
+
+	SetOwner(owner, role)
+	assert.Equal(t, "alloy", role.Labels["app"])
+	key, ok := OwnerOf(role)
+	require.True(t, ok)
+	assert.Equal(t, ownerKey, key)
+	assert.True(t, OwnedBy(owner, role))
+
+	recreated := &metav1.ObjectMeta{Name: "prod", Namespace: "monitoring", UID: "uid-2"}
+	assert.False(t, OwnedBy(recreated, role))
+	assert.Equal(t, "uid-2", MatchingOwner(recreated)[OwnerUIDLabel])
+
+	_, ok = OwnerOf(&rbacv1.ClusterRole{})
+	assert.False(t, ok)
+}
+
+func TestIndexStale(t *testing.T) {
+	index := NewIndex()
+	alloy := Ref{Kind: clusterRoleKind, Name: "prod-alloy"}
+	beyla := Ref{Kind: clusterRoleKind, Name: "prod-beyla"}
+	index.Set(alloy, ownerKey)
+	index.Set(beyla, ownerKey)
+	assert.Equal(t, 2, index.Count(ownerKey))
+	assert.Equal(t, []Ref{alloy, beyla}, index.Children(ownerKey))
+
+	index.BeginReconcile(ownerKey)
+	index.Applied(ownerKey, alloy)
+	assert.Equal(t, []Ref{beyla}, index.Stale(ownerKey))
+
+	index.BeginReconcile(ownerKey)
+	index.Incomplete(ownerKey)
+	assert.Empty(t, index.Stale(ownerKey))
+
+	// Moving a child to another owner removes it from the previous one
+	other := types.NamespacedName{Namespace: "monitoring", Name: "staging"}
+	index.Set(beyla, other)
+	assert.Equal(t, []Ref{alloy}, index.Children(ownerKey))
+	assert.Equal(t, 1, index.Count(other))
+
+	index.Forget(ownerKey)
+	assert.Zero(t, index.Count(ownerKey))
+	index.Remove(beyla)
+	assert.Zero(t, index.Count(other))
+
+	var none *Index
+	none.Applied(ownerKey, alloy)
+	assert.Empty(t, none.Stale(ownerKey))
+}
+
+func TestEventHandler(t *testing.T) {
+	index := NewIndex()
+	h := index.EventHandler(clusterRoleKind)
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+	ctx := context.Background()
+
+	role := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "prod-alloy"}}
+	SetOwner(owner, role)
+	h.Create(ctx, event.CreateEvent{Object: role}, q)
+	assert.Equal(t, 1, index.Count(ownerKey))
+	require.Equal(t, 1, q.Len())
+	item, _ := q.Get()
+	assert.Equal(t, reconcile.Request{NamespacedName: ownerKey}, item)
+	q.Done(item)
+
+	// Removing the tracking labels notifies the previous owner
+	untracked := role.DeepCopy()
+	untracked.Labels = nil
+	h.Update(ctx, event.UpdateEvent{ObjectOld: role, ObjectNew: untracked}, q)
+	assert.Zero(t, index.Count(ownerKey))
+	assert.Equal(t, 1, q.Len())
+
+	h.Create(ctx, event.CreateEvent{Object: role}, q)
+	h.Delete(ctx, event.DeleteEvent{Object: role}, q)
+	assert.Zero(t, index.Count(ownerKey))
+}
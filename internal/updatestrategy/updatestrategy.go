@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package updatestrategy converts the update strategy of a component into
+// the update strategy of its StatefulSet or Deployment. Combinations a
+// workload kind does not support are rejected at admission, so the
+// conversion ignores the settings that do not apply.
+package updatestrategy
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Strategy types
+const (
+	RollingUpdate = "RollingUpdate"
+	Recreate      = "Recreate"
+	OnDelete      = "OnDelete"
+)
+
+// Strategy is the update strategy of a component
+type Strategy struct {
+	// Type is RollingUpdate, Recreate or OnDelete, RollingUpdate if empty
+	Type string
+	// MaxUnavailable pods during a rolling update
+	MaxUnavailable *intstr.IntOrString
+	// MaxSurge pods during a rolling update of a Deployment
+	MaxSurge *intstr.IntOrString
+	// Partition is the lowest ordinal of the StatefulSet pods updated
+	Partition *int32
+}
+
+// ForStatefulSet returns the StatefulSet update strategy. Recreate is not
+// supported by StatefulSets and falls back to a rolling update.
+func ForStatefulSet(s Strategy) appsv1.StatefulSetUpdateStrategy {
+	if s.Type == OnDelete {
+		return appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType}
+	}
+	strategy := appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType}
+	if s.Partition != nil || s.MaxUnavailable != nil {
+		strategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition:      copyInt32(s.Partition),
+			MaxUnavailable: copyIntOrString(s.MaxUnavailable),
+		}
+	}
+	return strategy
+}
+
+// ForDeployment returns the Deployment strategy. OnDelete and the partition
+// are not supported by Deployments; OnDelete falls back to a rolling update.
+func ForDeployment(s Strategy) appsv1.DeploymentStrategy {
+	if s.Type == Recreate {
+		return appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+	}
+	strategy := appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType}
+	if s.MaxUnavailable != nil || s.MaxSurge != nil {
+		strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{
+			MaxUnavailable: copyIntOrString(s.MaxUnavailable),
+			MaxSurge:       copyIntOrString(s.MaxSurge),
+		}
+	}
+	return strategy
+}
+
+func copyInt32(v *int32) *int32 {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
+
+func copyIntOrString(v *intstr.IntOrString) *intstr.IntOrString {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package updatestrategy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func int32Ptr(v int32) *int32 { return &v }
+
+func TestForStatefulSet(t *testing.T) {
+	one := intstr.FromInt(1)
+	tests := []struct {
+		name     string
+		strategy Strategy
+		want     appsv1.StatefulSetUpdateStrategy
+	}{
+		{
+			name: "default",
+			want: appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType},
+		},
+		{
+			name:     "partition",
+			strategy: Strategy{Type: RollingUpdate, Partition: int32Ptr(2), MaxUnavailable: &one},
+			want: appsv1.StatefulSetUpdateStrategy{
+				Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: int32Ptr(2), MaxUnavailable: &one},
+			},
+		},
+		{
+			name:     "on delete ignores partition",
+			strategy: Strategy{Type: OnDelete, Partition: int32Ptr(2)},
+			want:     appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType},
+		},
+		{
+			name:     "recreate falls back to rolling update",
+			strategy: Strategy{Type: Recreate},
+			want:     appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ForStatefulSet(tt.strategy))
+		})
+	}
+}
+
+func TestForDeployment(t *testing.T) {
+	surge := intstr.FromString("25%")
+	tests := []struct {
+		name     string
+		strategy Strategy
+		want     appsv1.DeploymentStrategy
+	}{
+		{
+			name: "default",
+			want: appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType},
+		},
+		{
+			name:     "surge",
+			strategy: Strategy{MaxSurge: &surge, Partition: int32Ptr(1)},
+			want: appsv1.DeploymentStrategy{
+				Type:          appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: &surge},
+			},
+		},
+		{
+			name:     "recreate",
+			strategy: Strategy{Type: Recreate, MaxSurge: &surge},
+			want:     appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+		},
+		{
+			name:     "on delete falls back to rolling update",
+			strategy: Strategy{Type: OnDelete},
+			want:     appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ForDeployment(tt.strategy))
+		})
+	}
+}
+
+func TestForStatefulSetCopies(t *testing.T) {
+	partition := int32Ptr(3)
+	strategy := ForStatefulSet(Strategy{Partition: partition})
+	*partition = 0
+	assert.Equal(t, int32(3), *strategy.RollingUpdate.Partition)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package upgradehooks runs data migration Jobs around component upgrades.
+// Hooks are defined per version transition in a catalog; pre-upgrade hooks
+// must succeed before the new version is rolled out and post-upgrade hooks
+// before the upgrade is considered complete.
+package upgradehooks
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"sigs.k8s.io/yaml"
+)
+
+// Phase is the point of an upgrade a hook runs at
+type Phase string
+
+const (
+	// PreUpgrade hooks run before the new version is rolled out
+	PreUpgrade Phase = "pre-upgrade"
+	// PostUpgrade hooks run after the new version is rolled out
+	PostUpgrade Phase = "post-upgrade"
+)
+
+//go:embed catalog.yaml
+var defaultCatalog []byte
+
+// Hook is a data migration Job run for a version transition of a component
+type Hook struct {
+	// Name identifies the hook within its component
+	Name string `json:"name"`
+	// Component the hook belongs to
+	Component string `json:"component"`
+	// Phase the hook runs at
+	Phase Phase `json:"phase"`
+	// From is a semver constraint on the deployed version
+	From string `json:"from"`
+	// To is a semver constraint on the target version
+	To string `json:"to"`
+	// Image of the Job; defaults to the component image at the target version.
+	// "{{from}}" and "{{to}}" are replaced by the versions.
+	Image string `json:"image,omitempty"`
+	// Command and Args of the Job container
+	Command []string `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+	// MountConfig mounts the component's configuration at ConfigPath
+	MountConfig bool   `json:"mountConfig,omitempty"`
+	ConfigPath  string `json:"configPath,omitempty"`
+	// BackoffLimit is the number of retries before the hook fails
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// ActiveDeadlineSeconds bounds the runtime of the hook
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+// Catalog is a set of upgrade hooks
+type Catalog struct {
+	Hooks []Hook `json:"hooks"`
+}
+
+// Default returns the built-in hook catalog
+func Default() (*Catalog, error) {
+	return Parse(defaultCatalog)
+}
+
+// Parse parses and validates a hook catalog
+func Parse(data []byte) (*Catalog, error) {
+	catalog := &Catalog{}
+	if err := yaml.Unmarshal(data, catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse upgrade hook catalog: %w", err)
+	}
+	if err := catalog.Validate(); err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
+
+// Validate checks that every hook is complete and its constraints parse
+func (c *Catalog) Validate() error {
+	seen := map[string]bool{}
+	for i, hook := range c.Hooks {
+		if hook.Name == "" || hook.Component == "" {
+			return fmt.Errorf("hook %d: name and component are required", i)
+		}
+		key := hook.Component + "/" + hook.Name
+		if seen[key] {
+			return fmt.Errorf("hook %s: defined more than once", key)
+		}
+		seen[key] = true
+
+		if hook.Phase != PreUpgrade && hook.Phase != PostUpgrade {
+			return fmt.Errorf("hook %s: phase must be %s or %s", key, PreUpgrade, PostUpgrade)
+		}
+		if _, err := semver.NewConstraint(hook.From); err != nil {
+			return fmt.Errorf("hook %s: invalid from constraint: %w", key, err)
+		}
+		if _, err := semver.NewConstraint(hook.To); err != nil {
+			return fmt.Errorf("hook %s: invalid to constraint: %w", key, err)
+		}
+		if hook.MountConfig && hook.ConfigPath == "" {
+			return fmt.Errorf("hook %s: configPath is required to mount the config", key)
+		}
+	}
+	return nil
+}
+
+// Merge returns a catalog with the hooks of both catalogs; hooks of other
+// replace hooks of the same component and name
+func (c *Catalog) Merge(other *Catalog) *Catalog {
+	merged := &Catalog{}
+	if other == nil {
+		merged.Hooks = append(merged.Hooks, c.Hooks...)
+		return merged
+	}
+
+	overridden := map[string]bool{}
+	for _, hook := range other.Hooks {
+		overridden[hook.Component+"/"+hook.Name] = true
+	}
+	for _, hook := range c.Hooks {
+		if !overridden[hook.Component+"/"+hook.Name] {
+			merged.Hooks = append(merged.Hooks, hook)
+		}
+	}
+	merged.Hooks = append(merged.Hooks, other.Hooks...)
+	return merged
+}
+
+// Match returns the hooks of a component to run at phase when upgrading
+// from one version to another, in catalog order. Nothing runs for fresh
+// installs, unchanged versions or versions that are not semver.
+func (c *Catalog) Match(component string, phase Phase, from, to string) []Hook {
+	if from == "" || normalize(from) == normalize(to) {
+		return nil
+	}
+	fromVersion, err := semver.NewVersion(normalize(from))
+	if err != nil {
+		return nil
+	}
+	toVersion, err := semver.NewVersion(normalize(to))
+	if err != nil {
+		return nil
+	}
+
+	var hooks []Hook
+	for _, hook := range c.Hooks {
+		if hook.Component != component || hook.Phase != phase {
+			continue
+		}
+		// Constraints were validated when the catalog was parsed
+		fromConstraint, _ := semver.NewConstraint(hook.From)
+		toConstraint, _ := semver.NewConstraint(hook.To)
+		if fromConstraint.Check(fromVersion) && toConstraint.Check(toVersion) {
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks
+}
+
+// normalize strips the "v" prefix used by some image tags
+func normalize(version string) string {
+	return strings.TrimPrefix(version, "v")
+}
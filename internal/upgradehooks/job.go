@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package upgradehooks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// HookLabel is the label holding the hook name on hook Jobs
+	HookLabel = "observability.io/upgrade-hook"
+	// PhaseLabel is the label holding the hook phase on hook Jobs
+	PhaseLabel = "observability.io/upgrade-hook-phase"
+
+	defaultBackoffLimit          int32 = 2
+	defaultActiveDeadlineSeconds int64 = 1800
+	defaultTTLSecondsAfterFinish int32 = 7 * 24 * 3600
+)
+
+// State is the state of a hook Job
+type State string
+
+const (
+	// StateRunning means the Job has not finished yet
+	StateRunning State = "Running"
+	// StateSucceeded means the Job completed
+	StateSucceeded State = "Succeeded"
+	// StateFailed means the Job exhausted its retries or deadline
+	StateFailed State = "Failed"
+)
+
+// Target is the upgrade a hook Job runs for
+type Target struct {
+	Platform  string
+	Namespace string
+	Component string
+	From      string
+	To        string
+	// Image is the component image at the target version
+	Image string
+	// ConfigMap holds the component's configuration
+	ConfigMap string
+	// ServiceAccountName runs the Job
+	ServiceAccountName string
+	// Labels are added to the Job and its pod
+	Labels map[string]string
+}
+
+// JobName returns the name of the Job running a hook for an upgrade. The
+// versions are hashed into the name, so each transition gets its own Job.
+func JobName(platform, component, hook, from, to string) string {
+	sum := sha256.Sum256([]byte(from + "->" + to))
+	suffix := hex.EncodeToString(sum[:])[:8]
+	name := fmt.Sprintf("%s-%s-%s", platform, component, hook)
+	// Job names become pod labels, which are limited to 63 characters
+	if max := 63 - len(suffix) - 1; len(name) > max {
+		name = strings.TrimRight(name[:max], "-.")
+	}
+	return name + "-" + suffix
+}
+
+// BuildJob returns the Job running a hook for an upgrade
+func BuildJob(hook Hook, target Target) *batchv1.Job {
+	labels := make(map[string]string, len(target.Labels)+2)
+	for k, v := range target.Labels {
+		labels[k] = v
+	}
+	labels[HookLabel] = hook.Name
+	labels[PhaseLabel] = string(hook.Phase)
+
+	image := target.Image
+	if hook.Image != "" {
+		image = strings.NewReplacer("{{from}}", target.From, "{{to}}", target.To).Replace(hook.Image)
+	}
+
+	container := corev1.Container{
+		Name:    "hook",
+		Image:   image,
+		Command: hook.Command,
+		Args:    hook.Args,
+		Env: []corev1.EnvVar{
+			{Name: "UPGRADE_FROM_VERSION", Value: target.From},
+			{Name: "UPGRADE_TO_VERSION", Value: target.To},
+		},
+	}
+	var volumes []corev1.Volume
+	if hook.MountConfig && target.ConfigMap != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: target.ConfigMap},
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "config",
+			MountPath: hook.ConfigPath,
+			ReadOnly:  true,
+		})
+	}
+
+	backoffLimit := defaultBackoffLimit
+	if hook.BackoffLimit != nil {
+		backoffLimit = *hook.BackoffLimit
+	}
+	activeDeadlineSeconds := defaultActiveDeadlineSeconds
+	if hook.ActiveDeadlineSeconds != nil {
+		activeDeadlineSeconds = *hook.ActiveDeadlineSeconds
+	}
+	ttl := defaultTTLSecondsAfterFinish
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      JobName(target.Platform, target.Component, hook.Name, target.From, target.To),
+			Namespace: target.Namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				"observability.io/upgrade-from": target.From,
+				"observability.io/upgrade-to":   target.To,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			ActiveDeadlineSeconds:   &activeDeadlineSeconds,
+			TTLSecondsAfterFinished: &ttl,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: target.ServiceAccountName,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					Containers:         []corev1.Container{container},
+					Volumes:            volumes,
+				},
+			},
+		},
+	}
+}
+
+// JobState returns the state of a hook Job from its conditions
+func JobState(job *batchv1.Job) State {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return StateSucceeded
+		case batchv1.JobFailed:
+			return StateFailed
+		}
+	}
+	return StateRunning
+}
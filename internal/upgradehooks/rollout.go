@@ -0,0 +1,44 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package upgradehooks
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// StatefulSetRolledOut reports whether all replicas of a StatefulSet run its
+// latest revision and are ready. Ready replicas of the previous revision, as
+// during a rollout that hasn't started or a rollback, don't count.
+func StatefulSetRolledOut(sts *appsv1.StatefulSet) bool {
+	desired := replicas(sts.Spec.Replicas)
+	status := sts.Status
+	return status.ObservedGeneration >= sts.Generation &&
+		status.Replicas == desired &&
+		status.UpdatedReplicas == desired &&
+		status.ReadyReplicas == desired &&
+		status.CurrentRevision == status.UpdateRevision
+}
+
+// DeploymentRolledOut reports whether all replicas of a Deployment run its
+// latest revision and are available, with no replica of an older revision
+// left
+func DeploymentRolledOut(deployment *appsv1.Deployment) bool {
+	desired := replicas(deployment.Spec.Replicas)
+	status := deployment.Status
+	return status.ObservedGeneration >= deployment.Generation &&
+		status.Replicas == desired &&
+		status.UpdatedReplicas == desired &&
+		status.AvailableReplicas == desired
+}
+
+// replicas returns the desired replicas, which default to 1
+func replicas(desired *int32) int32 {
+	if desired == nil {
+		return 1
+	}
+	return *desired
+}
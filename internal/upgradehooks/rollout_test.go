@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package upgradehooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStatefulSetRolledOut(t *testing.T) {
+	three := int32(3)
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Spec:       appsv1.StatefulSetSpec{Replicas: &three},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 2,
+			Replicas:           3,
+			UpdatedReplicas:    3,
+			ReadyReplicas:      3,
+			CurrentRevision:    "prometheus-b",
+			UpdateRevision:     "prometheus-b",
+		},
+	}
+	assert.True(t, StatefulSetRolledOut(sts))
+
+	// The controller hasn't seen the new spec yet; the ready replicas run
+	// the previous revision
+	stale := sts.DeepCopy()
+	stale.Generation = 3
+	assert.False(t, StatefulSetRolledOut(stale))
+
+	rolling := sts.DeepCopy()
+	rolling.Status.UpdatedReplicas = 1
+	rolling.Status.CurrentRevision = "prometheus-a"
+	assert.False(t, StatefulSetRolledOut(rolling))
+
+	unready := sts.DeepCopy()
+	unready.Status.ReadyReplicas = 2
+	assert.False(t, StatefulSetRolledOut(unready))
+}
+
+func TestDeploymentRolledOut(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Generation: 4},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 4,
+			Replicas:           1,
+			UpdatedReplicas:    1,
+			AvailableReplicas:  1,
+		},
+	}
+	assert.True(t, DeploymentRolledOut(deployment))
+
+	// A surge replica of the old revision is still running
+	surging := deployment.DeepCopy()
+	surging.Status.Replicas = 2
+	surging.Status.AvailableReplicas = 2
+	assert.False(t, DeploymentRolledOut(surging))
+
+	stale := deployment.DeepCopy()
+	stale.Status.ObservedGeneration = 3
+	assert.False(t, DeploymentRolledOut(stale))
+}
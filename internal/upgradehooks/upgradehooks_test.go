@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package upgradehooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDefaultCatalog(t *testing.T) {
+	catalog, err := Default()
+	require.NoError(t, err)
+
+	hooks := catalog.Match("loki", PreUpgrade, "2.9.4", "v3.0.0")
+	require.Len(t, hooks, 1)
+	assert.Equal(t, "verify-config", hooks[0].Name)
+
+	assert.Empty(t, catalog.Match("loki", PostUpgrade, "2.9.4", "3.0.0"))
+	assert.Empty(t, catalog.Match("loki", PreUpgrade, "3.0.0", "3.1.0"))
+	assert.Empty(t, catalog.Match("loki", PreUpgrade, "", "3.0.0"), "fresh installs run no hooks")
+	assert.Empty(t, catalog.Match("loki", PreUpgrade, "latest", "3.0.0"))
+}
+
+func TestParseAndMerge(t *testing.T) {
+	_, err := Parse([]byte(`hooks: [{name: x, component: grafana, phase: during, from: "*", to: "*"}]`))
+	assert.Error(t, err)
+
+	_, err = Parse([]byte(`hooks: [{name: x, component: grafana, phase: pre-upgrade, from: "not a constraint", to: "*"}]`))
+	assert.Error(t, err)
+
+	custom, err := Parse([]byte(`
+hooks:
+  - name: migrate-db
+    component: grafana
+    phase: post-upgrade
+    from: "< 11.0.0"
+    to: ">= 11.0.0"
+    image: example.com/grafana-migrate:{{to}}
+  - name: verify-config
+    component: loki
+    phase: pre-upgrade
+    from: "< 3.0.0-0"
+    to: ">= 3.0.0-0"
+    args: [-verify-config]
+`))
+	require.NoError(t, err)
+
+	defaults, err := Default()
+	require.NoError(t, err)
+	merged := defaults.Merge(custom)
+
+	hooks := merged.Match("loki", PreUpgrade, "2.9.0", "3.0.0")
+	require.Len(t, hooks, 1)
+	assert.Equal(t, []string{"-verify-config"}, hooks[0].Args)
+	assert.Len(t, merged.Match("grafana", PostUpgrade, "10.4.0", "11.0.0"), 1)
+}
+
+func TestBuildJob(t *testing.T) {
+	hook := Hook{
+		Name:        "migrate-db",
+		Component:   "grafana",
+		Phase:       PostUpgrade,
+		Image:       "example.com/grafana-migrate:{{to}}",
+		MountConfig: true,
+		ConfigPath:  "/etc/grafana",
+	}
+	job := BuildJob(hook, Target{
+		Platform:  "production",
+		Namespace: "monitoring",
+		Component: "grafana",
+		From:      "10.4.0",
+		To:        "11.0.0",
+		Image:     "grafana/grafana:11.0.0",
+		ConfigMap: "grafana-production-config",
+		Labels:    map[string]string{"app.kubernetes.io/instance": "production"},
+	})
+
+	assert.Equal(t, JobName("production", "grafana", "migrate-db", "10.4.0", "11.0.0"), job.Name)
+	assert.NotEqual(t, job.Name, JobName("production", "grafana", "migrate-db", "11.0.0", "11.1.0"))
+	assert.Equal(t, "migrate-db", job.Labels[HookLabel])
+	assert.Equal(t, "post-upgrade", job.Spec.Template.Labels[PhaseLabel])
+
+	container := job.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "example.com/grafana-migrate:11.0.0", container.Image)
+	assert.Equal(t, "/etc/grafana", container.VolumeMounts[0].MountPath)
+	assert.Equal(t, corev1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+
+	assert.LessOrEqual(t, len(JobName("a-very-long-platform-name-for-testing", "prometheus", "check-config-with-new-promtool", "2.53.0", "3.0.0")), 63)
+}
+
+func TestJobState(t *testing.T) {
+	job := &batchv1.Job{}
+	assert.Equal(t, StateRunning, JobState(job))
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+	assert.Equal(t, StateFailed, JobState(job))
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	assert.Equal(t, StateSucceeded, JobState(job))
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package valuefrom resolves the configuration values a platform reads from
+// ConfigMaps at render time, so settings shared by many platforms are
+// maintained in one place.
+package valuefrom
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// SharedLabel marks the ConfigMaps platforms of other namespaces may read
+const SharedLabel = "observability.io/shared"
+
+// Resolve returns the value selected by the source for a platform in the
+// namespace. An optional value which does not exist resolves to empty.
+// A ConfigMap of another namespace must be labeled shared, so a platform
+// cannot read the configuration of any namespace.
+func Resolve(ctx context.Context, c client.Reader, namespace string, src *observabilityv1beta1.ValueFromSource) (string, error) {
+	if src == nil || src.ConfigMapKeyRef == nil {
+		return "", nil
+	}
+	ref := src.ConfigMapKeyRef
+	key := client.ObjectKey{Namespace: namespace, Name: ref.Name}
+	if ref.Namespace != "" {
+		key.Namespace = ref.Namespace
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, key, cm); err != nil {
+		if errors.IsNotFound(err) && ref.Optional {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get ConfigMap %s: %w", key, err)
+	}
+	if key.Namespace != namespace && cm.Labels[SharedLabel] != "true" {
+		return "", fmt.Errorf("ConfigMap %s is in another namespace and not labeled %s=true", key, SharedLabel)
+	}
+	value, ok := cm.Data[ref.Key]
+	if !ok {
+		if ref.Optional {
+			return "", nil
+		}
+		return "", fmt.Errorf("ConfigMap %s has no key %s", key, ref.Key)
+	}
+	return value, nil
+}
+
+// Labels parses a YAML map of labels
+func Labels(value string) (map[string]string, error) {
+	labels := map[string]string{}
+	if strings.TrimSpace(value) == "" {
+		return labels, nil
+	}
+	if err := yaml.UnmarshalStrict([]byte(value), &labels); err != nil {
+		return nil, fmt.Errorf("labels must be a map of strings: %w", err)
+	}
+	return labels, nil
+}
+
+// MergeLabels returns the labels read from a ConfigMap overridden by the
+// inline labels
+func MergeLabels(resolved, inline map[string]string) map[string]string {
+	merged := make(map[string]string, len(resolved)+len(inline))
+	for k, v := range resolved {
+		merged[k] = v
+	}
+	for k, v := range inline {
+		merged[k] = v
+	}
+	return merged
+}
+
+// AppendScrapeConfigs returns the inline scrape configurations followed by
+// the ones read from a ConfigMap. Both are YAML lists of scrape configs.
+func AppendScrapeConfigs(inline, resolved string) (string, error) {
+	if strings.TrimSpace(resolved) == "" {
+		return inline, nil
+	}
+	var configs []interface{}
+	if err := yaml.Unmarshal([]byte(resolved), &configs); err != nil {
+		return "", fmt.Errorf("scrape configs must be a YAML list: %w", err)
+	}
+	if strings.TrimSpace(inline) == "" {
+		return resolved, nil
+	}
+	return strings.TrimRight(inline, "\n") + "\n" + resolved, nil
+}
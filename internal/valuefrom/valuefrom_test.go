@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package valuefrom
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+func ref(name, namespace, key string, optional bool) *observabilityv1beta1.ValueFromSource {
+	return &observabilityv1beta1.ValueFromSource{
+		ConfigMapKeyRef: &observabilityv1beta1.ConfigMapKeyReference{Name: name, Namespace: namespace, Key: key, Optional: optional},
+	}
+}
+
+func TestResolve(t *testing.T) {
+	c := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "monitoring"},
+			Data:       map[string]string{"labels.yaml": "cluster: prod-eu\n"},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "observability", Labels: map[string]string{SharedLabel: "true"}},
+			Data:       map[string]string{"labels.yaml": "region: eu-west-1\n"},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "private", Namespace: "observability"},
+			Data:       map[string]string{"labels.yaml": "secret: value\n"},
+		},
+	).Build()
+	ctx := context.Background()
+
+	value, err := Resolve(ctx, c, "monitoring", nil)
+	require.NoError(t, err)
+	assert.Empty(t, value)
+
+	value, err = Resolve(ctx, c, "monitoring", ref("labels", "", "labels.yaml", false))
+	require.NoError(t, err)
+	assert.Equal(t, "cluster: prod-eu\n", value)
+
+	value, err = Resolve(ctx, c, "monitoring", ref("shared", "observability", "labels.yaml", false))
+	require.NoError(t, err)
+	assert.Equal(t, "region: eu-west-1\n", value)
+
+	_, err = Resolve(ctx, c, "monitoring", ref("private", "observability", "labels.yaml", false))
+	assert.ErrorContains(t, err, "not labeled observability.io/shared=true")
+
+	_, err = Resolve(ctx, c, "monitoring", ref("labels", "", "missing", false))
+	assert.ErrorContains(t, err, "has no key missing")
+	value, err = Resolve(ctx, c, "monitoring", ref("labels", "", "missing", true))
+	require.NoError(t, err)
+	assert.Empty(t, value)
+
+	_, err = Resolve(ctx, c, "monitoring", ref("absent", "", "labels.yaml", false))
+	assert.Error(t, err)
+	value, err = Resolve(ctx, c, "monitoring", ref("absent", "", "labels.yaml", true))
+	require.NoError(t, err)
+	assert.Empty(t, value)
+}
+
+func TestLabels(t *testing.T) {
+	labels, err := Labels("cluster: prod-eu\nregion: eu-west-1\n")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"cluster": "prod-eu", "region": "eu-west-1"}, labels)
+
+	_, err = Labels("- cluster\n")
+	assert.Error(t, err)
+
+	merged := MergeLabels(labels, map[string]string{"cluster": "prod-eu-1", "team": "sre"})
+	assert.Equal(t, map[string]string{"cluster": "prod-eu-1", "region": "eu-west-1", "team": "sre"}, merged)
+}
+
+func TestAppendScrapeConfigs(t *testing.T) {
+	shared := "- job_name: shared\n  static_configs:\n    - targets: ['exporter:9100']\n"
+
+	configs, err := AppendScrapeConfigs("- job_name: local\n", shared)
+	require.NoError(t, err)
+	assert.Equal(t, "- job_name: local\n"+shared, configs)
+
+	configs, err = AppendScrapeConfigs("", shared)
+	require.NoError(t, err)
+	assert.Equal(t, shared, configs)
+
+	configs, err = AppendScrapeConfigs("- job_name: local\n", "")
+	require.NoError(t, err)
+	assert.Equal(t, "- job_name: local\n", configs)
+
+	_, err = AppendScrapeConfigs("", "job_name: not-a-list\n")
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package vendorexport
+
+import "fmt"
+
+// renderDatadog renders the configuration of the Datadog Agent: datadog.yaml
+// and an OpenMetrics check for static targets
+func renderDatadog(intents *Intents, export *Export) error {
+	agent := map[string]interface{}{
+		"api_key":      "${DD_API_KEY}",
+		"site":         "${DD_SITE}",
+		"cluster_name": intents.Platform,
+	}
+	if tags := sortedLabels(intents.Labels); len(tags) > 0 {
+		agent["tags"] = tags
+	}
+
+	var instances []interface{}
+	for _, job := range intents.Scrape {
+		switch {
+		case len(job.Targets) > 0:
+			for _, target := range job.Targets {
+				instance := map[string]interface{}{
+					"openmetrics_endpoint": job.url(target),
+					"namespace":            job.Name,
+					"metrics":              []string{".*"},
+					"tags":                 []string{"job:" + job.Name},
+				}
+				if interval := seconds(job.Interval); interval > 0 {
+					instance["min_collection_interval"] = interval
+				}
+				instances = append(instances, instance)
+			}
+		case job.Annotated:
+			// The agent discovers pods by the same annotation
+			agent["prometheus_scrape"] = map[string]interface{}{"enabled": true}
+			if len(job.Namespaces) > 0 {
+				export.note("job %s: pods are discovered in all namespaces, not only %v", job.Name, job.Namespaces)
+			}
+		case job.Role == "node":
+			export.note("job %s: node metrics are collected by the kubelet check", job.Name)
+		case job.Name == "kubernetes-apiservers":
+			export.note("job %s: API server metrics are collected by the kube_apiserver_metrics check of the Cluster Agent", job.Name)
+		default:
+			export.note("job %s: discovery of %s targets is not exported; annotate the pods prometheus.io/scrape", job.Name, job.Role)
+		}
+	}
+	if len(instances) > 0 {
+		checks := map[string]interface{}{"init_config": map[string]interface{}{}, "instances": instances}
+		if err := export.file("conf.d/openmetrics.d/conf.yaml", checks); err != nil {
+			return err
+		}
+	}
+
+	if intents.Logs != nil {
+		agent["logs_enabled"] = true
+		agent["logs_config"] = map[string]interface{}{"container_collect_all": true}
+		if len(intents.Logs.Namespaces) > 0 {
+			include := make([]string, 0, len(intents.Logs.Namespaces))
+			for _, namespace := range intents.Logs.Namespaces {
+				include = append(include, fmt.Sprintf("kube_namespace:^%s$", namespace))
+			}
+			agent["container_include_logs"] = include
+			agent["container_exclude_logs"] = []string{"kube_namespace:.*"}
+		}
+	}
+
+	if intents.Traces != nil {
+		agent["apm_config"] = map[string]interface{}{"enabled": true}
+		protocols := map[string]interface{}{}
+		if intents.Traces.has("otlp-grpc") {
+			protocols["grpc"] = map[string]interface{}{"endpoint": "0.0.0.0:4317"}
+		}
+		if intents.Traces.has("otlp-http") {
+			protocols["http"] = map[string]interface{}{"endpoint": "0.0.0.0:4318"}
+		}
+		if len(protocols) > 0 {
+			agent["otlp_config"] = map[string]interface{}{"receiver": map[string]interface{}{"protocols": protocols}}
+		}
+		for _, protocol := range []string{"jaeger", "zipkin"} {
+			if intents.Traces.has(protocol) {
+				export.note("traces: %s is not accepted by the agent; send OTLP instead", protocol)
+			}
+		}
+	}
+
+	return export.file("datadog.yaml", agent)
+}
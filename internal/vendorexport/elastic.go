@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package vendorexport
+
+// renderElastic renders the standalone configuration of the Elastic Agent
+func renderElastic(intents *Intents, export *Export) error {
+	var processors []interface{}
+	if len(intents.Labels) > 0 {
+		processors = append(processors, map[string]interface{}{
+			"add_fields": map[string]interface{}{"target": "labels", "fields": intents.Labels},
+		})
+	}
+	input := func(id, inputType string, settings map[string]interface{}) map[string]interface{} {
+		result := map[string]interface{}{
+			"id":          id,
+			"type":        inputType,
+			"use_output":  "default",
+			"data_stream": map[string]interface{}{"namespace": "default"},
+		}
+		if len(processors) > 0 {
+			result["processors"] = processors
+		}
+		for key, value := range settings {
+			result[key] = value
+		}
+		return result
+	}
+
+	var inputs []interface{}
+	hints := false
+	for _, job := range intents.Scrape {
+		switch {
+		case len(job.Targets) > 0:
+			hosts := make([]string, 0, len(job.Targets))
+			for _, target := range job.Targets {
+				if job.Scheme == "https" {
+					target = "https://" + target
+				}
+				hosts = append(hosts, target)
+			}
+			stream := map[string]interface{}{
+				"id":           "prometheus-" + job.Name,
+				"data_stream":  map[string]interface{}{"dataset": "prometheus.collector"},
+				"metricsets":   []string{"collector"},
+				"hosts":        hosts,
+				"metrics_path": job.Path,
+				"period":       job.Interval,
+			}
+			if job.Path == "" {
+				stream["metrics_path"] = "/metrics"
+			}
+			if job.Interval == "" {
+				stream["period"] = "10s"
+			}
+			inputs = append(inputs, input("prometheus-"+job.Name, "prometheus/metrics", map[string]interface{}{
+				"streams": []interface{}{stream},
+			}))
+		case job.Annotated:
+			hints = true
+			export.note("job %s: annotate the pods co.elastic.hints/package: prometheus to have them discovered", job.Name)
+		case job.Role == "node" || job.Name == "kubernetes-apiservers":
+			export.note("job %s: cluster metrics are collected by the Kubernetes integration", job.Name)
+		default:
+			export.note("job %s: discovery of %s targets is not exported; annotate the pods co.elastic.hints/package: prometheus", job.Name, job.Role)
+		}
+	}
+
+	if intents.Logs != nil {
+		inputs = append(inputs, input("container-logs", "filestream", map[string]interface{}{
+			"streams": []interface{}{map[string]interface{}{
+				"id":                          "container-logs",
+				"data_stream":                 map[string]interface{}{"dataset": "kubernetes.container_logs"},
+				"paths":                       containerLogPaths(intents.Logs.Namespaces),
+				"prospector.scanner.symlinks": true,
+				"parsers": []interface{}{map[string]interface{}{
+					"container": map[string]interface{}{"stream": "all", "format": "auto"},
+				}},
+			}},
+		}))
+	}
+
+	if intents.Traces != nil {
+		// APM Server accepts OTLP on its own port
+		inputs = append(inputs, input("apm", "apm", map[string]interface{}{
+			"apm-server": map[string]interface{}{"host": "0.0.0.0:8200"},
+		}))
+		if intents.Traces.has("otlp-grpc") || intents.Traces.has("otlp-http") {
+			export.note("traces: point OTLP exporters at the APM Server on port 8200")
+		}
+		for _, protocol := range []string{"jaeger", "zipkin"} {
+			if intents.Traces.has(protocol) {
+				export.note("traces: %s is not accepted by the APM Server; send OTLP instead", protocol)
+			}
+		}
+	}
+
+	config := map[string]interface{}{
+		"outputs": map[string]interface{}{
+			"default": map[string]interface{}{
+				"type":    "elasticsearch",
+				"hosts":   []string{"${ES_HOST}"},
+				"api_key": "${ES_API_KEY}",
+			},
+		},
+		"inputs": inputs,
+	}
+	if hints {
+		config["providers"] = map[string]interface{}{
+			"kubernetes": map[string]interface{}{"hints": map[string]interface{}{"enabled": true}},
+		}
+	}
+	return export.file("elastic-agent.yml", config)
+}
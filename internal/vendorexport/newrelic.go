@@ -0,0 +1,134 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package vendorexport
+
+// renderNewRelic renders the configuration of the New Relic infrastructure
+// agent, nri-prometheus for metrics and an OpenTelemetry Collector forwarding
+// traces to the New Relic OTLP endpoint
+func renderNewRelic(intents *Intents, export *Export) error {
+	infra := map[string]interface{}{
+		"license_key":  "${NRIA_LICENSE_KEY}",
+		"display_name": intents.Platform,
+	}
+	if len(intents.Labels) > 0 {
+		infra["custom_attributes"] = intents.Labels
+	}
+	if err := export.file("newrelic-infra.yml", infra); err != nil {
+		return err
+	}
+
+	if len(intents.Scrape) > 0 {
+		prometheus := map[string]interface{}{
+			"cluster_name":          intents.Platform,
+			"disable_autodiscovery": true,
+		}
+		var targets []interface{}
+		interval := ""
+		for _, job := range intents.Scrape {
+			if job.Interval != "" {
+				if interval != "" && interval != job.Interval {
+					export.note("job %s: nri-prometheus scrapes all targets every %s", job.Name, interval)
+				} else {
+					interval = job.Interval
+				}
+			}
+			switch {
+			case len(job.Targets) > 0:
+				urls := make([]string, 0, len(job.Targets))
+				for _, target := range job.Targets {
+					urls = append(urls, job.url(target))
+				}
+				targets = append(targets, map[string]interface{}{"description": job.Name, "urls": urls})
+			case job.Annotated:
+				// nri-prometheus discovers pods by the same annotation
+				prometheus["disable_autodiscovery"] = false
+				prometheus["scrape_enabled_label"] = "prometheus.io/scrape"
+				prometheus["require_scrape_enabled_label_for_nodes"] = true
+			case job.Role == "node" || job.Name == "kubernetes-apiservers":
+				export.note("job %s: cluster metrics are collected by the New Relic Kubernetes integration", job.Name)
+			default:
+				export.note("job %s: discovery of %s targets is not exported; annotate the pods prometheus.io/scrape", job.Name, job.Role)
+			}
+		}
+		if len(targets) > 0 {
+			prometheus["targets"] = targets
+		}
+		if interval != "" {
+			prometheus["scrape_duration"] = interval
+		}
+		if err := export.file("nri-prometheus-config.yml", prometheus); err != nil {
+			return err
+		}
+	}
+
+	if intents.Logs != nil {
+		var logs []interface{}
+		for _, path := range containerLogPaths(intents.Logs.Namespaces) {
+			logs = append(logs, map[string]interface{}{"name": "containers", "file": path})
+		}
+		if err := export.file("logging.d/containers.yml", map[string]interface{}{"logs": logs}); err != nil {
+			return err
+		}
+	}
+
+	if intents.Traces != nil {
+		if err := export.file("otel-collector.yaml", otlpForwarder(intents.Traces, "otlphttp", map[string]interface{}{
+			"endpoint": "https://otlp.nr-data.net",
+			"headers":  map[string]interface{}{"api-key": "${NEW_RELIC_LICENSE_KEY}"},
+		})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// otlpForwarder returns an OpenTelemetry Collector configuration receiving
+// traces with the intended protocols and forwarding them with an exporter
+func otlpForwarder(traces *TracesIntent, exporter string, exporterConfig map[string]interface{}) map[string]interface{} {
+	receivers := map[string]interface{}{}
+	otlp := map[string]interface{}{}
+	if traces.has("otlp-grpc") {
+		otlp["grpc"] = map[string]interface{}{"endpoint": "0.0.0.0:4317"}
+	}
+	if traces.has("otlp-http") {
+		otlp["http"] = map[string]interface{}{"endpoint": "0.0.0.0:4318"}
+	}
+	if len(otlp) > 0 {
+		receivers["otlp"] = map[string]interface{}{"protocols": otlp}
+	}
+	if traces.has("jaeger") {
+		receivers["jaeger"] = map[string]interface{}{"protocols": map[string]interface{}{
+			"grpc":        map[string]interface{}{},
+			"thrift_http": map[string]interface{}{},
+		}}
+	}
+	if traces.has("zipkin") {
+		receivers["zipkin"] = map[string]interface{}{}
+	}
+
+	names := make([]string, 0, len(receivers))
+	for _, name := range []string{"otlp", "jaeger", "zipkin"} {
+		if _, ok := receivers[name]; ok {
+			names = append(names, name)
+		}
+	}
+
+	return map[string]interface{}{
+		"receivers":  receivers,
+		"processors": map[string]interface{}{"batch": map[string]interface{}{}},
+		"exporters":  map[string]interface{}{exporter: exporterConfig},
+		"service": map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"traces": map[string]interface{}{
+					"receivers":  names,
+					"processors": []string{"batch"},
+					"exporters":  []string{exporter},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package vendorexport
+
+import (
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// FromPlatform returns the telemetry a platform collects. Scrape configs
+// which cannot be exported are returned as notes.
+func FromPlatform(platform *observabilityv1beta1.ObservabilityPlatform) (*Intents, []string, error) {
+	intents := &Intents{Platform: platform.Name, Labels: map[string]string{}}
+	if platform.Spec.Global != nil {
+		for key, value := range platform.Spec.Global.ExternalLabels {
+			intents.Labels[key] = value
+		}
+	}
+
+	components := platform.Spec.Components
+	if components == nil {
+		return intents, nil, nil
+	}
+
+	var notes []string
+	if prometheus := components.Prometheus; prometheus != nil && prometheus.Enabled {
+		for key, value := range prometheus.ExternalLabels {
+			intents.Labels[key] = value
+		}
+		intents.Scrape = append(intents.Scrape, BuiltinJobs...)
+		if prometheus.AdditionalScrapeConfigs != "" {
+			jobs, jobNotes, err := ParseScrapeConfigs(prometheus.AdditionalScrapeConfigs)
+			if err != nil {
+				return nil, nil, err
+			}
+			intents.Scrape = append(intents.Scrape, jobs...)
+			notes = append(notes, jobNotes...)
+		}
+	}
+	if loki := components.Loki; loki != nil && loki.Enabled {
+		intents.Logs = &LogsIntent{}
+	}
+	if tempo := components.Tempo; tempo != nil && tempo.Enabled {
+		intents.Traces = &TracesIntent{Protocols: []string{"otlp-grpc", "otlp-http"}}
+	}
+	return intents, notes, nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package vendorexport
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+)
+
+// BuiltinJobs are the scrape jobs of the platform's Prometheus configuration
+var BuiltinJobs = []ScrapeJob{
+	{Name: "kubernetes-apiservers", Role: "endpoints", Scheme: "https"},
+	{Name: "kubernetes-nodes", Role: "node", Scheme: "https"},
+	{Name: "kubernetes-pods", Role: "pod", Annotated: true},
+}
+
+// scrapeConfig is the part of a Prometheus scrape config which is exported
+type scrapeConfig struct {
+	JobName        string `json:"job_name"`
+	MetricsPath    string `json:"metrics_path"`
+	Scheme         string `json:"scheme"`
+	ScrapeInterval string `json:"scrape_interval"`
+	StaticConfigs  []struct {
+		Targets []string `json:"targets"`
+	} `json:"static_configs"`
+	KubernetesSDConfigs []struct {
+		Role       string `json:"role"`
+		Namespaces struct {
+			Names []string `json:"names"`
+		} `json:"namespaces"`
+	} `json:"kubernetes_sd_configs"`
+	RelabelConfigs []struct {
+		SourceLabels []string `json:"source_labels"`
+		Action       string   `json:"action"`
+	} `json:"relabel_configs"`
+	FileSDConfigs   []interface{} `json:"file_sd_configs"`
+	ConsulSDConfigs []interface{} `json:"consul_sd_configs"`
+	DNSSDConfigs    []interface{} `json:"dns_sd_configs"`
+}
+
+// annotationLabel is the discovery label of the prometheus.io/scrape annotation
+const annotationLabel = "__meta_kubernetes_pod_annotation_prometheus_io_scrape"
+
+// ParseScrapeConfigs parses Prometheus scrape configs, as in the platform's
+// additionalScrapeConfigs. Settings of a job which cannot be exported are
+// returned as notes.
+func ParseScrapeConfigs(data string) ([]ScrapeJob, []string, error) {
+	var configs []scrapeConfig
+	if err := yaml.Unmarshal([]byte(data), &configs); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse scrape configs: %w", err)
+	}
+
+	var jobs []ScrapeJob
+	var notes []string
+	for _, config := range configs {
+		job := ScrapeJob{
+			Name:     config.JobName,
+			Path:     config.MetricsPath,
+			Scheme:   config.Scheme,
+			Interval: config.ScrapeInterval,
+		}
+		for _, static := range config.StaticConfigs {
+			job.Targets = append(job.Targets, static.Targets...)
+		}
+		if len(config.KubernetesSDConfigs) > 0 {
+			sd := config.KubernetesSDConfigs[0]
+			job.Role, job.Namespaces = sd.Role, sd.Namespaces.Names
+			if len(config.KubernetesSDConfigs) > 1 {
+				notes = append(notes, fmt.Sprintf("job %s: only the first kubernetes_sd_config is exported", job.Name))
+			}
+		}
+		for _, relabel := range config.RelabelConfigs {
+			if relabel.Action == "keep" && len(relabel.SourceLabels) == 1 && relabel.SourceLabels[0] == annotationLabel {
+				job.Annotated = true
+				continue
+			}
+			notes = append(notes, fmt.Sprintf("job %s: relabelling is not exported", job.Name))
+			break
+		}
+		if len(config.FileSDConfigs)+len(config.ConsulSDConfigs)+len(config.DNSSDConfigs) > 0 {
+			notes = append(notes, fmt.Sprintf("job %s: only static and Kubernetes discovery is exported", job.Name))
+		}
+		if job.Role == "" && len(job.Targets) == 0 {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, notes, nil
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package vendorexport renders the telemetry a platform collects, its scrape
+// jobs, log collection and trace ingestion, as configurations of vendor
+// agents. Teams running a hybrid setup during a vendor migration collect the
+// same telemetry into both. Credentials are left as environment variable
+// references.
+package vendorexport
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Intents is the telemetry a platform collects
+type Intents struct {
+	// Platform names the platform, used as the cluster name of the agents
+	Platform string
+	// Labels are attached to all telemetry
+	Labels map[string]string
+	// Scrape are the metrics scrape jobs
+	Scrape []ScrapeJob
+	// Logs is set if container logs are collected
+	Logs *LogsIntent
+	// Traces is set if traces are ingested
+	Traces *TracesIntent
+}
+
+// ScrapeJob is a metrics scrape job
+type ScrapeJob struct {
+	Name string
+	// Role is the Kubernetes discovery role (pod, node, endpoints or
+	// service), empty for static targets
+	Role string
+	// Annotated restricts discovery to pods annotated prometheus.io/scrape
+	Annotated bool
+	// Namespaces restricts discovery, all namespaces if empty
+	Namespaces []string
+	// Targets are the static targets, as host:port
+	Targets []string
+	// Path is the metrics path, /metrics if empty
+	Path string
+	// Scheme is http or https, http if empty
+	Scheme string
+	// Interval is the scrape interval, the agent's default if empty
+	Interval string
+}
+
+// url returns the URL of a static target
+func (j ScrapeJob) url(target string) string {
+	scheme, path := j.Scheme, j.Path
+	if scheme == "" {
+		scheme = "http"
+	}
+	if path == "" {
+		path = "/metrics"
+	}
+	return fmt.Sprintf("%s://%s%s", scheme, target, path)
+}
+
+// LogsIntent is the collection of container logs
+type LogsIntent struct {
+	// Namespaces restricts collection, all namespaces if empty
+	Namespaces []string
+}
+
+// TracesIntent is the ingestion of traces
+type TracesIntent struct {
+	// Protocols are the accepted protocols: otlp-grpc, otlp-http, jaeger
+	// and zipkin
+	Protocols []string
+}
+
+// has returns true if a protocol is accepted
+func (t *TracesIntent) has(protocol string) bool {
+	for _, p := range t.Protocols {
+		if p == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// Export is a rendered agent configuration
+type Export struct {
+	// Vendor is the vendor the configuration is for
+	Vendor string `json:"vendor"`
+	// Files are the configuration files by path
+	Files map[string]string `json:"files"`
+	// Notes list what could not be rendered and what to do about it
+	Notes []string `json:"notes,omitempty"`
+}
+
+// note records a note
+func (e *Export) note(format string, args ...interface{}) {
+	e.Notes = append(e.Notes, fmt.Sprintf(format, args...))
+}
+
+// file marshals a configuration file
+func (e *Export) file(path string, content interface{}) error {
+	data, err := yaml.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", path, err)
+	}
+	e.Files[path] = string(data)
+	return nil
+}
+
+// Paths returns the paths of the files in order
+func (e *Export) Paths() []string {
+	paths := make([]string, 0, len(e.Files))
+	for path := range e.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// renderers render the intents for each vendor
+var renderers = map[string]func(*Intents, *Export) error{
+	"datadog":  renderDatadog,
+	"newrelic": renderNewRelic,
+	"elastic":  renderElastic,
+}
+
+// Vendors returns the supported vendors
+func Vendors() []string {
+	vendors := make([]string, 0, len(renderers))
+	for vendor := range renderers {
+		vendors = append(vendors, vendor)
+	}
+	sort.Strings(vendors)
+	return vendors
+}
+
+// Render renders the intents as the agent configuration of a vendor
+func Render(vendor string, intents *Intents) (*Export, error) {
+	render, ok := renderers[vendor]
+	if !ok {
+		return nil, fmt.Errorf("unsupported vendor %q: must be one of %s", vendor, strings.Join(Vendors(), ", "))
+	}
+	export := &Export{Vendor: vendor, Files: map[string]string{}}
+	if err := render(intents, export); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// sortedLabels returns labels as sorted key:value pairs
+func sortedLabels(labels map[string]string) []string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+":"+value)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+// seconds converts a Prometheus duration to seconds, 0 if it is not set or
+// cannot be parsed
+func seconds(duration string) int {
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return 0
+	}
+	return int(d.Seconds())
+}
+
+// containerLogPaths returns the paths of the container log files of the
+// namespaces, all containers if none are given
+func containerLogPaths(namespaces []string) []string {
+	if len(namespaces) == 0 {
+		return []string{"/var/log/containers/*.log"}
+	}
+	paths := make([]string, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		paths = append(paths, fmt.Sprintf("/var/log/containers/*_%s_*.log", namespace))
+	}
+	return paths
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package vendorexport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func intents(t *testing.T) *Intents {
+	t.Helper()
+	jobs, notes, err := ParseScrapeConfigs(`
+- job_name: payments
+  scrape_interval: 30s
+  metrics_path: /stats
+  static_configs:
+  - targets: ["payments:8080", "payments-canary:8080"]
+- job_name: team-pods
+  kubernetes_sd_configs:
+  - role: pod
+    namespaces:
+      names: [team-a]
+  relabel_configs:
+  - source_labels: [__meta_kubernetes_pod_annotation_prometheus_io_scrape]
+    action: keep
+    regex: true
+- job_name: consul
+  consul_sd_configs:
+  - server: consul:8500
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"job consul: only static and Kubernetes discovery is exported"}, notes)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, ScrapeJob{Name: "team-pods", Role: "pod", Annotated: true, Namespaces: []string{"team-a"}}, jobs[1])
+
+	return &Intents{
+		Platform: "production",
+		Labels:   map[string]string{"cluster": "prod-eu"},
+		Scrape:   append(append([]ScrapeJob{}, BuiltinJobs...), jobs...),
+		Logs:     &LogsIntent{Namespaces: []string{"payments"}},
+		Traces:   &TracesIntent{Protocols: []string{"otlp-grpc", "otlp-http", "zipkin"}},
+	}
+}
+
+func parse(t *testing.T, export *Export, path string) map[string]interface{} {
+	t.Helper()
+	require.Contains(t, export.Files, path)
+	result := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(export.Files[path]), &result))
+	return result
+}
+
+func TestRenderDatadog(t *testing.T) {
+	export, err := Render("datadog", intents(t))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"conf.d/openmetrics.d/conf.yaml", "datadog.yaml"}, export.Paths())
+
+	agent := parse(t, export, "datadog.yaml")
+	assert.Equal(t, "${DD_API_KEY}", agent["api_key"])
+	assert.Equal(t, []interface{}{"cluster:prod-eu"}, agent["tags"])
+	assert.Equal(t, map[string]interface{}{"enabled": true}, agent["prometheus_scrape"])
+	assert.Equal(t, true, agent["logs_enabled"])
+	assert.Equal(t, []interface{}{"kube_namespace:^payments$"}, agent["container_include_logs"])
+	assert.Contains(t, agent, "otlp_config")
+
+	checks := parse(t, export, "conf.d/openmetrics.d/conf.yaml")
+	instances := checks["instances"].([]interface{})
+	require.Len(t, instances, 2)
+	assert.Equal(t, "http://payments:8080/stats", instances[0].(map[string]interface{})["openmetrics_endpoint"])
+	assert.Equal(t, float64(30), instances[0].(map[string]interface{})["min_collection_interval"])
+
+	assert.Contains(t, export.Notes, "traces: zipkin is not accepted by the agent; send OTLP instead")
+	assert.Contains(t, export.Notes, "job team-pods: pods are discovered in all namespaces, not only [team-a]")
+}
+
+func TestRenderNewRelic(t *testing.T) {
+	export, err := Render("newrelic", intents(t))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"logging.d/containers.yml", "newrelic-infra.yml", "nri-prometheus-config.yml", "otel-collector.yaml"}, export.Paths())
+
+	prometheus := parse(t, export, "nri-prometheus-config.yml")
+	assert.Equal(t, false, prometheus["disable_autodiscovery"])
+	assert.Equal(t, "30s", prometheus["scrape_duration"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"description": "payments",
+		"urls":        []interface{}{"http://payments:8080/stats", "http://payments-canary:8080/stats"},
+	}}, prometheus["targets"])
+
+	logs := parse(t, export, "logging.d/containers.yml")
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "containers", "file": "/var/log/containers/*_payments_*.log"}}, logs["logs"])
+
+	collector := parse(t, export, "otel-collector.yaml")
+	pipeline := collector["service"].(map[string]interface{})["pipelines"].(map[string]interface{})["traces"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"otlp", "zipkin"}, pipeline["receivers"])
+	assert.Equal(t, []interface{}{"otlphttp"}, pipeline["exporters"])
+}
+
+func TestRenderElastic(t *testing.T) {
+	export, err := Render("elastic", intents(t))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"elastic-agent.yml"}, export.Paths())
+
+	config := parse(t, export, "elastic-agent.yml")
+	inputs := config["inputs"].([]interface{})
+	require.Len(t, inputs, 3)
+	prometheus := inputs[0].(map[string]interface{})
+	assert.Equal(t, "prometheus/metrics", prometheus["type"])
+	stream := prometheus["streams"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{"payments:8080", "payments-canary:8080"}, stream["hosts"])
+	assert.Equal(t, "/stats", stream["metrics_path"])
+	assert.Equal(t, "filestream", inputs[1].(map[string]interface{})["type"])
+	assert.Equal(t, "apm", inputs[2].(map[string]interface{})["type"])
+	assert.Contains(t, config, "providers")
+}
+
+func TestRenderUnsupportedVendor(t *testing.T) {
+	_, err := Render("splunk", &Intents{})
+	assert.EqualError(t, err, `unsupported vendor "splunk": must be one of datadog, elastic, newrelic`)
+}
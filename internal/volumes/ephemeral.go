@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package volumes sizes the node-local storage of component pods: emptyDir
+// size limits, ephemeral-storage requests and limits, and dedicated WAL
+// volumes.
+package volumes
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// WALVolumeName is the name of the dedicated WAL volume
+const WALVolumeName = "wal"
+
+// EphemeralStorage is the node-local storage configuration of a component
+type EphemeralStorage struct {
+	// Request and Limit are the ephemeral-storage request and limit of the
+	// component's container; nil leaves them unset
+	Request *resource.Quantity
+	Limit   *resource.Quantity
+	// EmptyDirSizeLimit caps the emptyDir volumes; nil leaves them unbounded
+	EmptyDirSizeLimit *resource.Quantity
+	// EmptyDirMedium is the medium of the emptyDir volumes
+	EmptyDirMedium corev1.StorageMedium
+	// WAL is the dedicated WAL volume; nil keeps the WAL on the data volume
+	WAL *WAL
+}
+
+// WAL is a dedicated write-ahead log volume
+type WAL struct {
+	Size             resource.Quantity
+	StorageClassName string
+	Medium           corev1.StorageMedium
+}
+
+// Volume returns the WAL volume: a generic ephemeral volume if a storage class
+// is set, an emptyDir volume limited to the WAL size otherwise
+func (w WAL) Volume() corev1.Volume {
+	if w.StorageClassName == "" {
+		size := w.Size.DeepCopy()
+		return corev1.Volume{
+			Name: WALVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: w.Medium, SizeLimit: &size},
+			},
+		}
+	}
+
+	storageClassName := w.StorageClassName
+	return corev1.Volume{
+		Name: WALVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Ephemeral: &corev1.EphemeralVolumeSource{
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						StorageClassName: &storageClassName,
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: w.Size.DeepCopy()},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Apply sets the ephemeral storage configuration on a pod spec. The
+// ephemeral-storage request and limit are set on the named container, and the
+// WAL volume, if any, is mounted into it at walPath, replacing a volume of the
+// same name. The emptyDir limits are applied to all other emptyDir volumes
+// that have no size limit yet.
+func Apply(podSpec *corev1.PodSpec, container, walPath string, storage EphemeralStorage) {
+	for i := range podSpec.Volumes {
+		emptyDir := podSpec.Volumes[i].EmptyDir
+		if emptyDir == nil {
+			continue
+		}
+		if emptyDir.SizeLimit == nil && storage.EmptyDirSizeLimit != nil {
+			limit := storage.EmptyDirSizeLimit.DeepCopy()
+			emptyDir.SizeLimit = &limit
+		}
+		if emptyDir.Medium == corev1.StorageMediumDefault {
+			emptyDir.Medium = storage.EmptyDirMedium
+		}
+	}
+
+	if storage.WAL != nil {
+		setVolume(podSpec, storage.WAL.Volume())
+	}
+
+	for i := range podSpec.Containers {
+		c := &podSpec.Containers[i]
+		if c.Name != container {
+			continue
+		}
+		setEphemeralStorage(&c.Resources, storage.Request, storage.Limit)
+		if storage.WAL != nil && walPath != "" {
+			setVolumeMount(c, corev1.VolumeMount{Name: WALVolumeName, MountPath: walPath})
+		}
+	}
+}
+
+// setEphemeralStorage sets the ephemeral-storage request and limit
+func setEphemeralStorage(resources *corev1.ResourceRequirements, request, limit *resource.Quantity) {
+	if request != nil {
+		if resources.Requests == nil {
+			resources.Requests = corev1.ResourceList{}
+		}
+		resources.Requests[corev1.ResourceEphemeralStorage] = request.DeepCopy()
+	}
+	if limit != nil {
+		if resources.Limits == nil {
+			resources.Limits = corev1.ResourceList{}
+		}
+		resources.Limits[corev1.ResourceEphemeralStorage] = limit.DeepCopy()
+	}
+}
+
+// setVolume adds a volume to a pod spec or replaces the volume of the same name
+func setVolume(podSpec *corev1.PodSpec, volume corev1.Volume) {
+	for i := range podSpec.Volumes {
+		if podSpec.Volumes[i].Name == volume.Name {
+			podSpec.Volumes[i] = volume
+			return
+		}
+	}
+	podSpec.Volumes = append(podSpec.Volumes, volume)
+}
+
+// setVolumeMount adds a volume mount to a container or replaces the mount of
+// the same volume
+func setVolumeMount(container *corev1.Container, mount corev1.VolumeMount) {
+	for i := range container.VolumeMounts {
+		if container.VolumeMounts[i].Name == mount.Name {
+			container.VolumeMounts[i] = mount
+			return
+		}
+	}
+	container.VolumeMounts = append(container.VolumeMounts, mount)
+}
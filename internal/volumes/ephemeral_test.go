@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package volumes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func quantity(s string) *resource.Quantity {
+	q := resource.MustParse(s)
+	return &q
+}
+
+func podSpec() *corev1.PodSpec {
+	return &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name:         "loki",
+				VolumeMounts: []corev1.VolumeMount{{Name: "wal", MountPath: "/wal"}},
+			},
+			{Name: "sidecar"},
+		},
+		Volumes: []corev1.Volume{
+			{Name: "wal", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			{Name: "tmp", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+		},
+	}
+}
+
+func TestApply(t *testing.T) {
+	spec := podSpec()
+	Apply(spec, "loki", "/wal", EphemeralStorage{
+		Request:           quantity("1Gi"),
+		Limit:             quantity("4Gi"),
+		EmptyDirSizeLimit: quantity("512Mi"),
+		WAL:               &WAL{Size: resource.MustParse("10Gi"), StorageClassName: "local-ssd"},
+	})
+
+	loki := spec.Containers[0]
+	assert.True(t, loki.Resources.Requests.StorageEphemeral().Equal(resource.MustParse("1Gi")))
+	assert.True(t, loki.Resources.Limits.StorageEphemeral().Equal(resource.MustParse("4Gi")))
+	assert.Empty(t, spec.Containers[1].Resources.Limits)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "wal", MountPath: "/wal"}}, loki.VolumeMounts)
+
+	require.Len(t, spec.Volumes, 3)
+	wal := spec.Volumes[0]
+	require.NotNil(t, wal.Ephemeral)
+	assert.Equal(t, "local-ssd", *wal.Ephemeral.VolumeClaimTemplate.Spec.StorageClassName)
+	assert.True(t, spec.Volumes[1].EmptyDir.SizeLimit.Equal(resource.MustParse("512Mi")))
+}
+
+func TestApplyEmptyDirWAL(t *testing.T) {
+	spec := podSpec()
+	spec.Containers[0].VolumeMounts = nil
+	Apply(spec, "loki", "/var/loki/wal", EphemeralStorage{
+		EmptyDirMedium: corev1.StorageMediumMemory,
+		WAL:            &WAL{Size: resource.MustParse("2Gi")},
+	})
+
+	wal := spec.Volumes[0].EmptyDir
+	require.NotNil(t, wal)
+	assert.True(t, wal.SizeLimit.Equal(resource.MustParse("2Gi")))
+	assert.Equal(t, corev1.StorageMediumDefault, wal.Medium)
+	assert.Nil(t, spec.Volumes[1].EmptyDir.SizeLimit)
+	assert.Equal(t, corev1.StorageMediumMemory, spec.Volumes[1].EmptyDir.Medium)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "wal", MountPath: "/var/loki/wal"}}, spec.Containers[0].VolumeMounts)
+}
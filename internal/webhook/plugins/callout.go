@@ -0,0 +1,305 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion/redaction"
+)
+
+var log = logf.Log.WithName("admission-plugins")
+
+const (
+	// CalloutAPIVersion is the API version of the reviews sent to callouts
+	CalloutAPIVersion = "admission.observability.io/v1"
+	// CalloutKind is the kind of the reviews sent to callouts
+	CalloutKind = "PlatformReview"
+
+	// DefaultCalloutTimeout is the timeout of callouts without one
+	DefaultCalloutTimeout = 5 * time.Second
+	// MaxCalloutTimeout bounds the timeout of callouts, which must answer
+	// well within the 10s the API server waits for the operator's webhook
+	MaxCalloutTimeout = 8 * time.Second
+
+	// maxResponseSize bounds the responses read from callouts
+	maxResponseSize = 1 << 20
+)
+
+// FailurePolicy decides what happens to a request a callout could not review
+type FailurePolicy string
+
+const (
+	// FailurePolicyFail rejects the request
+	FailurePolicyFail FailurePolicy = "Fail"
+	// FailurePolicyIgnore admits the request with a warning
+	FailurePolicyIgnore FailurePolicy = "Ignore"
+)
+
+// CalloutConfig configures a validator calling an HTTP endpoint of the
+// platform team, to add admission logic without forking the operator
+type CalloutConfig struct {
+	// Name of the plugin
+	Name string `json:"name"`
+	// URL the reviews are POSTed to, which must be https
+	URL string `json:"url"`
+	// Order of the callout in the chain, after the built-in validators if 0
+	Order int `json:"order,omitempty"`
+	// FeatureGate turns the callout off when set to false, always on if empty
+	FeatureGate string `json:"featureGate,omitempty"`
+	// Operations the callout reviews, CREATE and UPDATE if empty
+	Operations []Operation `json:"operations,omitempty"`
+	// Timeout of a review, 5s if unset
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// FailurePolicy of the requests the callout could not review, Fail if
+	// empty
+	FailurePolicy FailurePolicy `json:"failurePolicy,omitempty"`
+	// CAFile is the PEM bundle verifying the endpoint's certificate, the
+	// system roots if empty
+	CAFile string `json:"caFile,omitempty"`
+	// BearerTokenFile holds the token sent in the Authorization header. It
+	// is read on every review, so it can be rotated in place.
+	BearerTokenFile string `json:"bearerTokenFile,omitempty"`
+}
+
+// CalloutsConfig is the file of callouts passed to the operator
+type CalloutsConfig struct {
+	Callouts []CalloutConfig `json:"callouts"`
+}
+
+// CalloutReview is the body exchanged with callouts: the request is sent,
+// and the response is read back
+type CalloutReview struct {
+	APIVersion string           `json:"apiVersion"`
+	Kind       string           `json:"kind"`
+	Request    *CalloutRequest  `json:"request,omitempty"`
+	Response   *CalloutResponse `json:"response,omitempty"`
+}
+
+// CalloutRequest is the object under review. Secrets of the objects, such as
+// passwords and tokens, are masked by the redaction policy of the operator.
+type CalloutRequest struct {
+	Operation Operation       `json:"operation"`
+	Object    json.RawMessage `json:"object"`
+	OldObject json.RawMessage `json:"oldObject,omitempty"`
+}
+
+// CalloutResponse is the verdict of a callout. The request is rejected if it
+// is not allowed or has errors.
+type CalloutResponse struct {
+	Allowed  bool           `json:"allowed"`
+	Warnings []string       `json:"warnings,omitempty"`
+	Errors   []CalloutError `json:"errors,omitempty"`
+}
+
+// CalloutError is a reason to reject a request
+type CalloutError struct {
+	// Field is the path of the offending field, e.g. spec.components.grafana
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// LoadCallouts parses and checks a file of callouts
+func LoadCallouts(data []byte) ([]CalloutConfig, error) {
+	var config CalloutsConfig
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid admission callouts: %w", err)
+	}
+
+	names := map[string]bool{}
+	for i := range config.Callouts {
+		callout := &config.Callouts[i]
+		if err := callout.validate(); err != nil {
+			return nil, err
+		}
+		if names[callout.Name] {
+			return nil, fmt.Errorf("admission callout %s is defined twice", callout.Name)
+		}
+		names[callout.Name] = true
+	}
+	return config.Callouts, nil
+}
+
+// validate checks the configuration of the callout
+func (c *CalloutConfig) validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("admission callout has no name")
+	}
+	// The reviews carry the platform, so they are only sent over TLS
+	u, err := url.Parse(c.URL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("admission callout %s: url must be an https URL", c.Name)
+	}
+	for _, o := range c.Operations {
+		if o != Create && o != Update {
+			return fmt.Errorf("admission callout %s: unknown operation %q", c.Name, o)
+		}
+	}
+	if c.Timeout.Duration < 0 || c.Timeout.Duration > MaxCalloutTimeout {
+		return fmt.Errorf("admission callout %s: timeout must be at most %s", c.Name, MaxCalloutTimeout)
+	}
+	switch c.FailurePolicy {
+	case "", FailurePolicyFail, FailurePolicyIgnore:
+	default:
+		return fmt.Errorf("admission callout %s: failurePolicy must be Fail or Ignore", c.Name)
+	}
+	return nil
+}
+
+// NewCallout returns a validator plugin POSTing reviews to the callout's URL
+func NewCallout[T any](config CalloutConfig) (Plugin[T], error) {
+	if err := config.validate(); err != nil {
+		return Plugin[T]{}, err
+	}
+
+	timeout := config.Timeout.Duration
+	if timeout == 0 {
+		timeout = DefaultCalloutTimeout
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.CAFile != "" {
+		pem, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return Plugin[T]{}, fmt.Errorf("admission callout %s: %w", config.Name, err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return Plugin[T]{}, fmt.Errorf("admission callout %s: no certificate in %s", config.Name, config.CAFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+	}
+
+	c := &callout{
+		config: config,
+		client: &http.Client{Transport: transport, Timeout: timeout},
+	}
+	plugin := Plugin[T]{
+		Name:       config.Name,
+		Order:      config.Order,
+		Operations: config.Operations,
+		Validate: func(ctx context.Context, req Request[T]) (admission.Warnings, field.ErrorList) {
+			return c.validate(ctx, req.Operation, req.Object, req.OldObject)
+		},
+	}
+	if config.FeatureGate != "" {
+		plugin.Gate = &Gate{Name: config.FeatureGate, Default: true}
+	}
+	return plugin, nil
+}
+
+// callout reviews requests with an HTTP endpoint
+type callout struct {
+	config CalloutConfig
+	client *http.Client
+}
+
+// validate reviews a request, applying the failure policy if the endpoint
+// gives no verdict
+func (c *callout) validate(ctx context.Context, op Operation, obj, old interface{}) (admission.Warnings, field.ErrorList) {
+	response, err := c.review(ctx, op, obj, old)
+	if err != nil {
+		log.Error(err, "Admission callout failed", "callout", c.config.Name)
+		if c.config.FailurePolicy == FailurePolicyIgnore {
+			return admission.Warnings{fmt.Sprintf("admission plugin %s was skipped: %v", c.config.Name, err)}, nil
+		}
+		return nil, field.ErrorList{field.InternalError(field.NewPath("spec"),
+			fmt.Errorf("admission plugin %s: %w", c.config.Name, err))}
+	}
+
+	var allErrs field.ErrorList
+	for _, e := range response.Errors {
+		path := field.NewPath("spec")
+		if e.Field != "" {
+			path = field.NewPath(e.Field)
+		}
+		allErrs = append(allErrs, field.Forbidden(path, fmt.Sprintf("%s: %s", c.config.Name, e.Message)))
+	}
+	if !response.Allowed && len(allErrs) == 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"),
+			fmt.Sprintf("rejected by admission plugin %s", c.config.Name)))
+	}
+	return response.Warnings, allErrs
+}
+
+// review POSTs the request to the endpoint and reads back its verdict
+func (c *callout) review(ctx context.Context, op Operation, obj, old interface{}) (*CalloutResponse, error) {
+	request := &CalloutRequest{Operation: op}
+	var err error
+	if request.Object, err = redactedJSON(obj); err != nil {
+		return nil, err
+	}
+	if op == Update {
+		if request.OldObject, err = redactedJSON(old); err != nil {
+			return nil, err
+		}
+	}
+	body, err := json.Marshal(&CalloutReview{APIVersion: CalloutAPIVersion, Kind: CalloutKind, Request: request})
+	if err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	if c.config.BearerTokenFile != "" {
+		token, err := os.ReadFile(c.config.BearerTokenFile)
+		if err != nil {
+			return nil, err
+		}
+		httpRequest.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	httpResponse, err := c.client.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(httpResponse.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", httpResponse.StatusCode)
+	}
+	var review CalloutReview
+	if err := json.Unmarshal(data, &review); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	if review.Response == nil {
+		return nil, fmt.Errorf("invalid response: no verdict")
+	}
+	return review.Response, nil
+}
+
+// redactedJSON marshals an object with its secrets masked
+func redactedJSON(obj interface{}) (json.RawMessage, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	return redaction.Default().JSON(data)
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/conversion/redaction"
+)
+
+func calloutServer(t *testing.T, handle func(review *CalloutReview, r *http.Request) *CalloutResponse) *httptest.Server {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var review CalloutReview
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&review))
+		assert.Equal(t, CalloutAPIVersion, review.APIVersion)
+		assert.Equal(t, CalloutKind, review.Kind)
+
+		response := handle(&review, r)
+		if response == nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&CalloutReview{APIVersion: CalloutAPIVersion, Kind: CalloutKind, Response: response})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// caFile writes the certificate of a test server to a PEM file
+func caFile(t *testing.T, server *httptest.Server) string {
+	path := filepath.Join(t.TempDir(), "ca.crt")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestCalloutVerdicts(t *testing.T) {
+	server := calloutServer(t, func(review *CalloutReview, _ *http.Request) *CalloutResponse {
+		var obj object
+		require.NoError(t, json.Unmarshal(review.Request.Object, &obj))
+		switch obj.Name {
+		case "allowed":
+			return &CalloutResponse{Allowed: true, Warnings: []string{"cost center label missing"}}
+		case "denied":
+			return &CalloutResponse{Allowed: false}
+		default:
+			return &CalloutResponse{Allowed: false, Errors: []CalloutError{
+				{Field: "spec.components.grafana", Message: "grafana is provided centrally"},
+			}}
+		}
+	})
+
+	plugin, err := NewCallout[*object](CalloutConfig{Name: "org-policy", URL: server.URL, CAFile: caFile(t, server)})
+	require.NoError(t, err)
+
+	warnings, errs := plugin.Validate(context.Background(), Request[*object]{Operation: Create, Object: &object{Name: "allowed"}})
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"cost center label missing"}, []string(warnings))
+
+	_, errs = plugin.Validate(context.Background(), Request[*object]{Operation: Create, Object: &object{Name: "denied"}})
+	require.Len(t, errs, 1)
+	assert.Equal(t, field.ErrorTypeForbidden, errs[0].Type)
+	assert.Equal(t, "spec", errs[0].Field)
+	assert.Contains(t, errs[0].Detail, "org-policy")
+
+	_, errs = plugin.Validate(context.Background(), Request[*object]{Operation: Create, Object: &object{Name: "grafana"}})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.components.grafana", errs[0].Field)
+	assert.Equal(t, "org-policy: grafana is provided centrally", errs[0].Detail)
+}
+
+func TestCalloutSendsOldObjectAndToken(t *testing.T) {
+	token := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(token, []byte("secret\n"), 0o600))
+
+	server := calloutServer(t, func(review *CalloutReview, r *http.Request) *CalloutResponse {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, Update, review.Request.Operation)
+		assert.JSONEq(t, `{"name":"old"}`, string(review.Request.OldObject))
+		return &CalloutResponse{Allowed: true}
+	})
+
+	plugin, err := NewCallout[*object](CalloutConfig{Name: "org-policy", URL: server.URL, CAFile: caFile(t, server), BearerTokenFile: token})
+	require.NoError(t, err)
+	_, errs := plugin.Validate(context.Background(), Request[*object]{
+		Operation: Update, Object: &object{Name: "new"}, OldObject: &object{Name: "old"},
+	})
+	assert.Empty(t, errs)
+}
+
+func TestCalloutRedactsSecrets(t *testing.T) {
+	server := calloutServer(t, func(review *CalloutReview, _ *http.Request) *CalloutResponse {
+		var obj object
+		require.NoError(t, json.Unmarshal(review.Request.Object, &obj))
+		assert.Equal(t, "production", obj.Name)
+		assert.Equal(t, redaction.Default().Mask(), obj.Password)
+		return &CalloutResponse{Allowed: true}
+	})
+
+	plugin, err := NewCallout[*object](CalloutConfig{Name: "org-policy", URL: server.URL, CAFile: caFile(t, server)})
+	require.NoError(t, err)
+	_, errs := plugin.Validate(context.Background(), Request[*object]{
+		Operation: Create, Object: &object{Name: "production", Password: "hunter2"},
+	})
+	assert.Empty(t, errs)
+}
+
+func TestCalloutFailurePolicy(t *testing.T) {
+	server := calloutServer(t, func(*CalloutReview, *http.Request) *CalloutResponse {
+		return nil
+	})
+
+	failing, err := NewCallout[*object](CalloutConfig{Name: "strict", URL: server.URL, CAFile: caFile(t, server)})
+	require.NoError(t, err)
+	_, errs := failing.Validate(context.Background(), Request[*object]{Operation: Create, Object: &object{}})
+	require.Len(t, errs, 1)
+	assert.Equal(t, field.ErrorTypeInternal, errs[0].Type)
+
+	ignoring, err := NewCallout[*object](CalloutConfig{
+		Name: "lenient", URL: server.URL, CAFile: caFile(t, server), FailurePolicy: FailurePolicyIgnore,
+		Timeout: metav1.Duration{Duration: time.Second},
+	})
+	require.NoError(t, err)
+	warnings, errs := ignoring.Validate(context.Background(), Request[*object]{Operation: Create, Object: &object{}})
+	assert.Empty(t, errs)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "admission plugin lenient was skipped")
+}
+
+func TestLoadCallouts(t *testing.T) {
+	callouts, err := LoadCallouts([]byte(`
+callouts:
+  - name: org-policy
+    url: https://policy.platform.svc/review
+    order: 50
+    featureGate: OrgPolicy
+    operations: [CREATE]
+    timeout: 2s
+    failurePolicy: Ignore
+`))
+	require.NoError(t, err)
+	require.Len(t, callouts, 1)
+	assert.Equal(t, 2*time.Second, callouts[0].Timeout.Duration)
+	assert.Equal(t, []Operation{Create}, callouts[0].Operations)
+
+	plugin, err := NewCallout[*object](callouts[0])
+	require.NoError(t, err)
+	assert.Equal(t, &Gate{Name: "OrgPolicy", Default: true}, plugin.Gate)
+	assert.Equal(t, 50, plugin.Order)
+
+	for name, invalid := range map[string]string{
+		"unknown field":  "callouts: [{name: a, url: 'https://a', retries: 3}]",
+		"no name":        "callouts: [{url: 'https://a'}]",
+		"bad url":        "callouts: [{name: a, url: 'ftp://a'}]",
+		"plain http":     "callouts: [{name: a, url: 'http://a'}]",
+		"duplicate":      "callouts: [{name: a, url: 'https://a'}, {name: a, url: 'https://b'}]",
+		"operation":      "callouts: [{name: a, url: 'https://a', operations: [DELETE]}]",
+		"timeout":        "callouts: [{name: a, url: 'https://a', timeout: 1m}]",
+		"failure policy": "callouts: [{name: a, url: 'https://a', failurePolicy: Retry}]",
+	} {
+		_, err := LoadCallouts([]byte(invalid))
+		assert.Error(t, err, name)
+	}
+}
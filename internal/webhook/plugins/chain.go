@@ -0,0 +1,218 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Operation is an admission operation validators run for
+type Operation string
+
+const (
+	// Create is the admission of a new object
+	Create Operation = "CREATE"
+	// Update is the admission of a changed object
+	Update Operation = "UPDATE"
+)
+
+// Request is an object under admission
+type Request[T any] struct {
+	Operation Operation
+	Object    T
+	// OldObject is the object replaced by an update, the zero value on creates
+	OldObject T
+}
+
+// DefaultFunc sets the defaults of an object
+type DefaultFunc[T any] func(ctx context.Context, obj T) error
+
+// ValidateFunc validates an admission request, returning warnings for the
+// user and the errors rejecting the request
+type ValidateFunc[T any] func(ctx context.Context, req Request[T]) (admission.Warnings, field.ErrorList)
+
+// Plugin is a step of an admission chain: a defaulter, a validator or both
+type Plugin[T any] struct {
+	// Name identifies the plugin, unique in its chain
+	Name string
+	// Order sorts the plugins of the chain, lowest first. Plugins of the
+	// same order run in the order they were registered.
+	Order int
+	// Gate turns the plugin on or off, always on if nil
+	Gate *Gate
+	// Operations the validator runs for, all if empty
+	Operations []Operation
+	// SkipOnErrors skips the validator once an earlier one has rejected the
+	// request, for costly checks whose outcome is moot then
+	SkipOnErrors bool
+
+	Default  DefaultFunc[T]
+	Validate ValidateFunc[T]
+}
+
+// runsFor returns true if the validator runs for the operation
+func (p *Plugin[T]) runsFor(op Operation) bool {
+	if len(p.Operations) == 0 {
+		return true
+	}
+	for _, o := range p.Operations {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// Info describes a registered plugin
+type Info struct {
+	Name      string
+	Order     int
+	Gate      string
+	Enabled   bool
+	Defaulter bool
+	Validator bool
+}
+
+// Chain runs the admission plugins registered for a type in order
+type Chain[T any] struct {
+	mu      sync.RWMutex
+	plugins []Plugin[T]
+	gates   Gates
+}
+
+// NewChain returns a chain without plugins
+func NewChain[T any]() *Chain[T] {
+	return &Chain[T]{}
+}
+
+// Register adds plugins to the chain
+func (c *Chain[T]) Register(plugins ...Plugin[T]) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, p := range plugins {
+		if p.Name == "" {
+			return fmt.Errorf("admission plugin has no name")
+		}
+		if p.Default == nil && p.Validate == nil {
+			return fmt.Errorf("admission plugin %s neither defaults nor validates", p.Name)
+		}
+		for _, o := range p.Operations {
+			if o != Create && o != Update {
+				return fmt.Errorf("admission plugin %s: unknown operation %q", p.Name, o)
+			}
+		}
+		for _, registered := range c.plugins {
+			if registered.Name == p.Name {
+				return fmt.Errorf("admission plugin %s is already registered", p.Name)
+			}
+			if p.Gate != nil && registered.Gate != nil && p.Gate.Name == registered.Gate.Name &&
+				p.Gate.Default != registered.Gate.Default {
+				return fmt.Errorf("admission plugin %s: feature gate %s has another default in plugin %s",
+					p.Name, p.Gate.Name, registered.Name)
+			}
+		}
+		c.plugins = append(c.plugins, p)
+	}
+
+	sort.SliceStable(c.plugins, func(i, j int) bool {
+		return c.plugins[i].Order < c.plugins[j].Order
+	})
+	return nil
+}
+
+// SetGates sets the feature gates of the chain's plugins, rejecting gates no
+// registered plugin is guarded by
+func (c *Chain[T]) SetGates(gates Gates) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	known := map[string]bool{}
+	for _, p := range c.plugins {
+		if p.Gate != nil {
+			known[p.Gate.Name] = true
+		}
+	}
+	for name := range gates {
+		if !known[name] {
+			return fmt.Errorf("unknown admission feature gate %s", name)
+		}
+	}
+	c.gates = gates
+	return nil
+}
+
+// Default runs the enabled defaulters in order. A failing defaulter does not
+// stop the ones after it; the errors of all are returned.
+func (c *Chain[T]) Default(ctx context.Context, obj T) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var errs []error
+	for i := range c.plugins {
+		p := &c.plugins[i]
+		if p.Default == nil || !c.gates.Enabled(p.Gate) {
+			continue
+		}
+		if err := p.Default(ctx, obj); err != nil {
+			errs = append(errs, fmt.Errorf("admission plugin %s: %w", p.Name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// Validate runs the enabled validators of the request's operation in order,
+// and collects their warnings and errors
+func (c *Chain[T]) Validate(ctx context.Context, req Request[T]) (admission.Warnings, field.ErrorList) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	for i := range c.plugins {
+		p := &c.plugins[i]
+		if p.Validate == nil || !c.gates.Enabled(p.Gate) || !p.runsFor(req.Operation) {
+			continue
+		}
+		if p.SkipOnErrors && len(allErrs) > 0 {
+			continue
+		}
+		w, errs := p.Validate(ctx, req)
+		warnings = append(warnings, w...)
+		allErrs = append(allErrs, errs...)
+	}
+	return warnings, allErrs
+}
+
+// Plugins describes the registered plugins in order
+func (c *Chain[T]) Plugins() []Info {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	infos := make([]Info, 0, len(c.plugins))
+	for _, p := range c.plugins {
+		info := Info{
+			Name:      p.Name,
+			Order:     p.Order,
+			Enabled:   c.gates.Enabled(p.Gate),
+			Defaulter: p.Default != nil,
+			Validator: p.Validate != nil,
+		}
+		if p.Gate != nil {
+			info.Gate = p.Gate.Name
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+type object struct {
+	Name     string   `json:"name"`
+	Steps    []string `json:"steps,omitempty"`
+	Password string   `json:"password,omitempty"`
+}
+
+func step(name string, order int) Plugin[*object] {
+	return Plugin[*object]{
+		Name:  name,
+		Order: order,
+		Default: func(_ context.Context, obj *object) error {
+			obj.Steps = append(obj.Steps, name)
+			return nil
+		},
+	}
+}
+
+func rejecting(name string, order int) Plugin[*object] {
+	return Plugin[*object]{
+		Name:  name,
+		Order: order,
+		Validate: func(_ context.Context, req Request[*object]) (admission.Warnings, field.ErrorList) {
+			return admission.Warnings{name}, field.ErrorList{field.Invalid(field.NewPath("name"), req.Object.Name, name)}
+		},
+	}
+}
+
+func TestChainRunsPluginsInOrder(t *testing.T) {
+	chain := NewChain[*object]()
+	require.NoError(t, chain.Register(step("c", 30), step("a", 10)))
+	require.NoError(t, chain.Register(step("b", 20), step("a2", 10)))
+
+	obj := &object{}
+	require.NoError(t, chain.Default(context.Background(), obj))
+	assert.Equal(t, []string{"a", "a2", "b", "c"}, obj.Steps)
+}
+
+func TestChainRejectsInvalidPlugins(t *testing.T) {
+	chain := NewChain[*object]()
+	require.NoError(t, chain.Register(step("a", 0)))
+
+	assert.Error(t, chain.Register(step("a", 1)), "duplicate name")
+	assert.Error(t, chain.Register(step("", 1)), "no name")
+	assert.Error(t, chain.Register(Plugin[*object]{Name: "noop"}), "no function")
+
+	invalidOperation := rejecting("op", 0)
+	invalidOperation.Operations = []Operation{"DELETE"}
+	assert.Error(t, chain.Register(invalidOperation))
+
+	on, off := step("on", 0), step("off", 0)
+	on.Gate = &Gate{Name: "Extra", Default: true}
+	off.Gate = &Gate{Name: "Extra", Default: false}
+	require.NoError(t, chain.Register(on))
+	assert.Error(t, chain.Register(off), "conflicting gate defaults")
+}
+
+func TestChainGates(t *testing.T) {
+	chain := NewChain[*object]()
+	on, off := step("on", 1), step("off", 2)
+	on.Gate = &Gate{Name: "On", Default: true}
+	off.Gate = &Gate{Name: "Off"}
+	require.NoError(t, chain.Register(step("always", 0), on, off))
+
+	obj := &object{}
+	require.NoError(t, chain.Default(context.Background(), obj))
+	assert.Equal(t, []string{"always", "on"}, obj.Steps)
+
+	require.NoError(t, chain.SetGates(Gates{"On": false, "Off": true}))
+	obj = &object{}
+	require.NoError(t, chain.Default(context.Background(), obj))
+	assert.Equal(t, []string{"always", "off"}, obj.Steps)
+
+	assert.Error(t, chain.SetGates(Gates{"Typo": true}))
+
+	infos := chain.Plugins()
+	require.Len(t, infos, 3)
+	assert.Equal(t, Info{Name: "on", Order: 1, Gate: "On", Enabled: false, Defaulter: true}, infos[1])
+}
+
+func TestChainDefaultCollectsErrors(t *testing.T) {
+	chain := NewChain[*object]()
+	failing := Plugin[*object]{
+		Name: "failing",
+		Default: func(context.Context, *object) error {
+			return fmt.Errorf("boom")
+		},
+	}
+	require.NoError(t, chain.Register(failing, step("after", 1)))
+
+	obj := &object{}
+	err := chain.Default(context.Background(), obj)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "admission plugin failing: boom")
+	assert.Equal(t, []string{"after"}, obj.Steps)
+}
+
+func TestChainValidate(t *testing.T) {
+	chain := NewChain[*object]()
+	updateOnly := rejecting("update-only", 1)
+	updateOnly.Operations = []Operation{Update}
+	costly := rejecting("costly", 2)
+	costly.SkipOnErrors = true
+	require.NoError(t, chain.Register(rejecting("first", 0), updateOnly, costly))
+
+	warnings, errs := chain.Validate(context.Background(), Request[*object]{Operation: Create, Object: &object{Name: "x"}})
+	assert.Equal(t, admission.Warnings{"first"}, warnings)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "first", errs[0].Detail)
+
+	warnings, errs = chain.Validate(context.Background(), Request[*object]{Operation: Update, Object: &object{}, OldObject: &object{}})
+	assert.Equal(t, admission.Warnings{"first", "update-only"}, warnings)
+	assert.Len(t, errs, 2)
+
+	chain = NewChain[*object]()
+	require.NoError(t, chain.Register(costly))
+	_, errs = chain.Validate(context.Background(), Request[*object]{Operation: Create, Object: &object{}})
+	assert.Len(t, errs, 1, "runs without earlier errors")
+}
+
+func TestParseGates(t *testing.T) {
+	gates, err := ParseGates(" A=true, B=false ,")
+	require.NoError(t, err)
+	assert.Equal(t, Gates{"A": true, "B": false}, gates)
+
+	gates, err = ParseGates("")
+	require.NoError(t, err)
+	assert.Empty(t, gates)
+
+	for _, invalid := range []string{"A", "=true", "A=maybe", "A=true,A=false"} {
+		_, err := ParseGates(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package plugins
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Gate is a feature gate turning admission plugins on or off
+type Gate struct {
+	Name string
+	// Default is the state of the gate when the operator's flags don't set it
+	Default bool
+}
+
+// Gates are the states of the feature gates set by the operator's flags
+type Gates map[string]bool
+
+// Enabled returns true if the gate is on, or is nil
+func (g Gates) Enabled(gate *Gate) bool {
+	if gate == nil {
+		return true
+	}
+	if enabled, ok := g[gate.Name]; ok {
+		return enabled
+	}
+	return gate.Default
+}
+
+// ParseGates parses feature gates of the form "Name=true,Other=false"
+func ParseGates(s string) (Gates, error) {
+	gates := Gates{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("feature gate %q must be name=true or name=false", pair)
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("feature gate %s: invalid value %q", name, value)
+		}
+		if _, dup := gates[name]; dup {
+			return nil, fmt.Errorf("feature gate %s is set twice", name)
+		}
+		gates[name] = enabled
+	}
+	return gates, nil
+}
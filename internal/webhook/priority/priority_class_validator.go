@@ -0,0 +1,100 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package priority
+
+import (
+	"context"
+	"fmt"
+
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+var log = logf.Log.WithName("priority-class-validator")
+
+// PriorityClassValidator checks that the priority classes referenced by a
+// platform exist in the cluster, since pods referencing a missing class are
+// rejected at creation time rather than when the platform is applied
+type PriorityClassValidator struct {
+	Client client.Client
+}
+
+// ValidatePriorityClasses validates the global and per-component priority
+// class names of a platform
+func (v *PriorityClassValidator) ValidatePriorityClasses(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) field.ErrorList {
+	var allErrs field.ErrorList
+
+	type reference struct {
+		path *field.Path
+		name string
+	}
+	var refs []reference
+	if platform.Spec.Global != nil {
+		refs = append(refs, reference{field.NewPath("spec", "global", "priorityClassName"), platform.Spec.Global.PriorityClassName})
+	}
+	if components := platform.Spec.Components; components != nil {
+		componentsPath := field.NewPath("spec", "components")
+		if components.Prometheus != nil && components.Prometheus.Enabled {
+			refs = append(refs, reference{componentsPath.Child("prometheus", "priorityClassName"), components.Prometheus.PriorityClassName})
+		}
+		if components.Grafana != nil && components.Grafana.Enabled {
+			refs = append(refs, reference{componentsPath.Child("grafana", "priorityClassName"), components.Grafana.PriorityClassName})
+		}
+		if components.Loki != nil && components.Loki.Enabled {
+			refs = append(refs, reference{componentsPath.Child("loki", "priorityClassName"), components.Loki.PriorityClassName})
+		}
+		if components.Tempo != nil && components.Tempo.Enabled {
+			refs = append(refs, reference{componentsPath.Child("tempo", "priorityClassName"), components.Tempo.PriorityClassName})
+		}
+		if components.OpenTelemetryCollector != nil && components.OpenTelemetryCollector.Enabled {
+			refs = append(refs, reference{componentsPath.Child("opentelemetryCollector", "priorityClassName"), components.OpenTelemetryCollector.PriorityClassName})
+		}
+	}
+
+	// Look up every class once, several components usually share one
+	exists := map[string]bool{}
+	for _, ref := range refs {
+		fldPath, name := ref.path, ref.name
+		if name == "" {
+			continue
+		}
+		found, checked := exists[name]
+		if !checked {
+			var err error
+			found, err = v.priorityClassExists(ctx, name)
+			if err != nil {
+				log.Error(err, "Failed to get PriorityClass", "name", name)
+				allErrs = append(allErrs, field.InternalError(fldPath, fmt.Errorf("failed to get PriorityClass %s: %w", name, err)))
+				continue
+			}
+			exists[name] = found
+		}
+		if !found {
+			allErrs = append(allErrs, field.NotFound(fldPath, name))
+		}
+	}
+
+	return allErrs
+}
+
+// priorityClassExists reports whether the named PriorityClass exists
+func (v *PriorityClassValidator) priorityClassExists(ctx context.Context, name string) (bool, error) {
+	pc := &schedulingv1.PriorityClass{}
+	if err := v.Client.Get(ctx, types.NamespacedName{Name: name}, pc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package priority
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+func TestPriorityClassValidator_ValidatePriorityClasses(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = schedulingv1.AddToScheme(scheme)
+	_ = observabilityv1beta1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "observability-critical"}, Value: 1000000},
+	).Build()
+	validator := &PriorityClassValidator{Client: c}
+
+	platform := &observabilityv1beta1.ObservabilityPlatform{
+		Spec: observabilityv1beta1.ObservabilityPlatformSpec{
+			Global: &observabilityv1beta1.GlobalSettings{PriorityClassName: "observability-critical"},
+			Components: &observabilityv1beta1.Components{
+				Prometheus: &observabilityv1beta1.PrometheusSpec{Enabled: true},
+				Loki:       &observabilityv1beta1.LokiSpec{Enabled: true, PriorityClassName: "missing"},
+				// Disabled components are not validated
+				Tempo: &observabilityv1beta1.TempoSpec{Enabled: false, PriorityClassName: "missing"},
+			},
+		},
+	}
+
+	errs := validator.ValidatePriorityClasses(context.Background(), platform)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "spec.components.loki.priorityClassName", errs[0].Field)
+		assert.Equal(t, "missing", errs[0].BadValue)
+	}
+
+	platform.Spec.Components.Loki.PriorityClassName = ""
+	assert.Empty(t, validator.ValidatePriorityClasses(context.Background(), platform))
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package scheduling
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/feasibility"
+)
+
+var log = logf.Log.WithName("feasibility-validator")
+
+// FeasibilityValidator warns about the replicas of a platform that would fit
+// on no node of the cluster. It never rejects a platform: capacity may be
+// added by an autoscaler, and the check is an approximation of the scheduler.
+type FeasibilityValidator struct {
+	// Reader lists nodes and pods. It should not be the manager's cached
+	// client, which would start an informer for every pod of the cluster.
+	Reader client.Reader
+}
+
+// Warnings returns one warning per component with unschedulable replicas,
+// if the platform asks for the scheduling check
+func (v *FeasibilityValidator) Warnings(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) admission.Warnings {
+	if !feasibility.Enabled(platform) {
+		return nil
+	}
+
+	results, err := feasibility.Check(ctx, v.Reader, platform)
+	if err != nil {
+		// The check is advisory; invalid quantities are reported by the
+		// resource validation
+		log.Error(err, "Failed to simulate scheduling", "platform", platform.Name, "namespace", platform.Namespace)
+		return nil
+	}
+
+	var warnings admission.Warnings
+	for _, result := range results {
+		warnings = append(warnings, "spec.components: "+result.String())
+	}
+	return warnings
+}
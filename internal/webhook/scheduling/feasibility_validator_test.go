@@ -0,0 +1,57 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package scheduling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+func TestFeasibilityValidator_Warnings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+		Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2"),
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+			corev1.ResourcePods:   resource.MustParse("110"),
+		}},
+	}).Build()
+	validator := &FeasibilityValidator{Reader: c}
+
+	platform := &observabilityv1beta1.ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "monitoring"},
+		Spec: observabilityv1beta1.ObservabilityPlatformSpec{
+			Components: &observabilityv1beta1.Components{
+				Prometheus: &observabilityv1beta1.PrometheusSpec{
+					Enabled:   true,
+					Replicas:  2,
+					Resources: &observabilityv1beta1.ResourceRequirements{Requests: &observabilityv1beta1.ResourceList{Memory: "3Gi"}},
+				},
+			},
+		},
+	}
+
+	// The check is opt-in
+	assert.Empty(t, validator.Warnings(context.Background(), platform))
+
+	platform.Spec.Global = &observabilityv1beta1.GlobalSettings{SchedulingCheck: true}
+	assert.Equal(t, []string{
+		"spec.components: prometheus: 1 of 2 replicas cannot be scheduled: insufficient memory on the 1 matching nodes",
+	}, []string(validator.Warnings(context.Background(), platform)))
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package topology
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/zones"
+)
+
+var log = logf.Log.WithName("zone-validator")
+
+// ZoneValidator checks that the zones claimed by zone-aware components exist
+// in the cluster, i.e. have schedulable nodes carrying the topology key
+type ZoneValidator struct {
+	Client client.Client
+}
+
+// ValidateZones validates the zone-awareness settings of the Loki and Tempo
+// ingesters against the cluster's nodes
+func (v *ZoneValidator) ValidateZones(ctx context.Context, platform *observabilityv1beta1.ObservabilityPlatform) field.ErrorList {
+	var allErrs field.ErrorList
+
+	components := platform.Spec.Components
+	if components == nil {
+		return allErrs
+	}
+
+	checks := map[string]*observabilityv1beta1.ZoneAwarenessSpec{}
+	if components.Loki != nil && components.Loki.Enabled {
+		checks["loki"] = components.Loki.ZoneAwareness
+	}
+	if components.Tempo != nil && components.Tempo.Enabled {
+		checks["tempo"] = components.Tempo.ZoneAwareness
+	}
+
+	var nodes *corev1.NodeList
+	for _, component := range []string{"loki", "tempo"} {
+		spec := checks[component]
+		if !spec.IsEnabled() {
+			continue
+		}
+
+		if nodes == nil {
+			nodes = &corev1.NodeList{}
+			if err := v.Client.List(ctx, nodes); err != nil {
+				log.Error(err, "Failed to list nodes")
+				return append(allErrs, field.InternalError(field.NewPath("spec", "components"), fmt.Errorf("failed to list nodes: %w", err)))
+			}
+		}
+
+		fldPath := field.NewPath("spec", "components", component, "zoneAwareness", "zones")
+		available := zones.Available(nodes.Items, spec.GetTopologyKey())
+		if missing := zones.Missing(available, spec.Zones); len(missing) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath, spec.Zones,
+				fmt.Sprintf("no schedulable nodes with label %s in zones %s (available: %s)",
+					spec.GetTopologyKey(), strings.Join(missing, ", "), joinZones(available))))
+		}
+	}
+
+	return allErrs
+}
+
+// joinZones lists the available zones for error messages
+func joinZones(available map[string]int) string {
+	if len(available) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(available))
+	for zone := range available {
+		names = append(names, zone)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package topology
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+func zoneNode(name, zone string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   name,
+		Labels: map[string]string{"topology.kubernetes.io/zone": zone},
+	}}
+}
+
+func TestZoneValidator_ValidateZones(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = observabilityv1beta1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		zoneNode("node-a", "zone-a"),
+		zoneNode("node-b", "zone-b"),
+	).Build()
+	validator := &ZoneValidator{Client: c}
+
+	platform := func(lokiZones, tempoZones []string) *observabilityv1beta1.ObservabilityPlatform {
+		return &observabilityv1beta1.ObservabilityPlatform{
+			Spec: observabilityv1beta1.ObservabilityPlatformSpec{
+				Components: &observabilityv1beta1.Components{
+					Loki: &observabilityv1beta1.LokiSpec{
+						Enabled:       true,
+						ZoneAwareness: &observabilityv1beta1.ZoneAwarenessSpec{Enabled: true, Zones: lokiZones},
+					},
+					Tempo: &observabilityv1beta1.TempoSpec{
+						Enabled:       true,
+						ZoneAwareness: &observabilityv1beta1.ZoneAwarenessSpec{Enabled: len(tempoZones) > 0, Zones: tempoZones},
+					},
+				},
+			},
+		}
+	}
+
+	errs := validator.ValidateZones(context.Background(), platform([]string{"zone-a", "zone-b"}, nil))
+	assert.Empty(t, errs)
+
+	errs = validator.ValidateZones(context.Background(), platform([]string{"zone-a", "zone-b"}, []string{"zone-a", "zone-c"}))
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "spec.components.tempo.zoneAwareness.zones", errs[0].Field)
+		assert.Contains(t, errs[0].Detail, "zone-c")
+		assert.Contains(t, errs[0].Detail, "available: zone-a, zone-b")
+	}
+}
@@ -9,17 +9,45 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/alertmanager"
+	"github.com/gunjanjp/gunj-operator/internal/capacity"
+	"github.com/gunjanjp/gunj-operator/internal/correlation"
+	"github.com/gunjanjp/gunj-operator/internal/envvars"
+	"github.com/gunjanjp/gunj-operator/internal/fips"
+	"github.com/gunjanjp/gunj-operator/internal/grafanareports"
+	"github.com/gunjanjp/gunj-operator/internal/mixins"
+	"github.com/gunjanjp/gunj-operator/internal/probes"
+	"github.com/gunjanjp/gunj-operator/internal/queryacl"
+	"github.com/gunjanjp/gunj-operator/internal/reload"
+	"github.com/gunjanjp/gunj-operator/internal/runbooks"
+	"github.com/gunjanjp/gunj-operator/internal/sidecars"
+	"github.com/gunjanjp/gunj-operator/internal/storagemigration"
+	"github.com/gunjanjp/gunj-operator/internal/thanos"
 )
 
+// prometheusLabelNameRegex matches valid Prometheus label names
+var prometheusLabelNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// prometheusMetricNameRegex matches valid Prometheus metric names
+var prometheusMetricNameRegex = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// minDashboardPollInterval bounds how often dashboard repositories are polled
+const minDashboardPollInterval = 30 * time.Second
+
 // ConfigurationValidator validates ObservabilityPlatform configurations
 type ConfigurationValidator struct {
 	Log                logr.Logger
@@ -93,6 +121,126 @@ func (v *ConfigurationValidator) ValidateConfiguration(ctx context.Context, plat
 	// Validate configuration dependencies
 	allErrs = append(allErrs, v.validateConfigurationDependencies(platform, field.NewPath("spec"))...)
 
+	// Validate FIPS/strict-TLS constraints
+	allErrs = append(allErrs, v.validateFIPSSettings(platform, field.NewPath("spec", "security", "fips"))...)
+
+	// Validate affinity and topology spread constraints
+	allErrs = append(allErrs, v.validatePlacement(platform, field.NewPath("spec"))...)
+
+	// Validate ephemeral storage and WAL volumes
+	allErrs = append(allErrs, v.validateEphemeralStorage(platform, field.NewPath("spec", "components"))...)
+
+	// Validate extra containers and volumes
+	allErrs = append(allErrs, v.validateExtraContainers(platform, field.NewPath("spec", "components"))...)
+
+	// Validate environment variables
+	allErrs = append(allErrs, v.validateEnv(platform, field.NewPath("spec", "components"))...)
+
+	// Validate health probe overrides
+	allErrs = append(allErrs, v.validateProbes(platform, field.NewPath("spec", "components"))...)
+
+	// Validate log levels and debug modes
+	allErrs = append(allErrs, v.validateLogging(platform, field.NewPath("spec", "components"))...)
+
+	// Validate dedicated node pool
+	if platform.Spec.Global != nil && platform.Spec.Global.NodePool.IsEnabled() {
+		allErrs = append(allErrs, v.validateNodePool(platform.Spec.Global.NodePool, field.NewPath("spec", "global", "nodePool"))...)
+	}
+
+	// Validate query access control
+	if platform.Spec.QueryACL.IsEnabled() {
+		allErrs = append(allErrs, v.validateQueryACL(platform, field.NewPath("spec", "queryACL"))...)
+	}
+
+	// Validate Thanos downsampling and retention policies
+	if platform.Spec.Components != nil && platform.Spec.Components.Prometheus != nil &&
+		platform.Spec.Components.Prometheus.Thanos.IsEnabled() {
+		allErrs = append(allErrs, v.validateThanos(platform.Spec.Components.Prometheus.Thanos,
+			field.NewPath("spec", "components", "prometheus", "thanos"))...)
+	}
+
+	// Validate runbook registry and references
+	allErrs = append(allErrs, v.validateRunbooks(platform, field.NewPath("spec", "alerting"))...)
+
+	// Validate recording rules and their backfill
+	allErrs = append(allErrs, v.validateRecordingRules(platform, field.NewPath("spec", "alerting"))...)
+
+	// Validate inhibit rules and mute time intervals
+	allErrs = append(allErrs, v.validateAlertmanagerRouting(platform, field.NewPath("spec", "alerting"))...)
+
+	// Validate that the correlation labels are the same for all signals
+	if platform.Spec.Global != nil && platform.Spec.Global.Correlation != nil {
+		allErrs = append(allErrs, v.validateCorrelation(platform, field.NewPath("spec", "global", "correlation"))...)
+	}
+
+	// Validate capacity planning settings
+	if planning := platform.CapacityPlanning(); planning != nil {
+		planningPath := field.NewPath("spec", "global", "capacityPlanning")
+		if planning.History != "" {
+			if d, err := time.ParseDuration(planning.History); err != nil || d < capacity.MinPoints*capacity.Step {
+				allErrs = append(allErrs, field.Invalid(planningPath.Child("history"), planning.History,
+					fmt.Sprintf("must be a duration of at least %dh, to fit a trend on %d days", capacity.MinPoints*24, capacity.MinPoints)))
+			}
+		}
+		if planning.AnalysisInterval != "" {
+			if d, err := time.ParseDuration(planning.AnalysisInterval); err != nil || d < time.Minute {
+				allErrs = append(allErrs, field.Invalid(planningPath.Child("analysisInterval"), planning.AnalysisInterval, "must be a duration of at least 1m"))
+			}
+		}
+		components := platform.Spec.Components
+		if planning.Enabled && (components == nil || components.Prometheus == nil || !components.Prometheus.Enabled) {
+			allErrs = append(allErrs, field.Invalid(planningPath.Child("enabled"), planning.Enabled, "capacity planning reads the usage history from Prometheus, which must be enabled"))
+		}
+	}
+
+	// Validate configuration reload strategies
+	allErrs = append(allErrs, v.validateReloadStrategies(platform, field.NewPath("spec", "components"))...)
+
+	// Validate the Git source of Grafana dashboards
+	if platform.Spec.Components != nil && platform.Spec.Components.Grafana != nil &&
+		platform.Spec.Components.Grafana.DashboardsFromGit != nil {
+		allErrs = append(allErrs, v.validateDashboardsFromGit(platform.Spec.Components.Grafana.DashboardsFromGit,
+			field.NewPath("spec", "components", "grafana", "dashboardsFromGit"))...)
+	}
+
+	// Validate the Jsonnet dashboard sources
+	if platform.Spec.Components != nil && platform.Spec.Components.Grafana != nil {
+		allErrs = append(allErrs, v.validateJsonnetDashboards(platform.Spec.Components.Grafana.JsonnetDashboards,
+			field.NewPath("spec", "components", "grafana", "jsonnetDashboards"))...)
+	}
+
+	// Validate the scheduled Grafana reports
+	if platform.Spec.Components != nil && platform.Spec.Components.Grafana != nil {
+		allErrs = append(allErrs, v.validateGrafanaReports(platform.Spec.Components.Grafana,
+			field.NewPath("spec", "components", "grafana"))...)
+	}
+
+	// Validate the silences of component upgrades
+	if platform.Spec.UpgradeSilences != nil {
+		allErrs = append(allErrs, v.validateUpgradeSilences(platform.Spec.UpgradeSilences, field.NewPath("spec", "upgradeSilences"))...)
+	}
+
+	// Validate the volume snapshots of component upgrades
+	if platform.Spec.UpgradeSnapshots != nil {
+		allErrs = append(allErrs, v.validateUpgradeSnapshots(platform.Spec.UpgradeSnapshots, field.NewPath("spec", "upgradeSnapshots"))...)
+	}
+
+	// Validate the requested storage migrations
+	if _, ok := platform.Annotations[storagemigration.RequestAnnotation]; ok {
+		allErrs = append(allErrs, v.validateStorageMigration(platform,
+			field.NewPath("metadata", "annotations").Key(storagemigration.RequestAnnotation))...)
+	}
+
+	// Validate the monitoring mixins
+	if platform.Spec.ObservabilityMixins != nil {
+		allErrs = append(allErrs, v.validateObservabilityMixins(platform, field.NewPath("spec", "observabilityMixins"))...)
+	}
+
+	// Validate the base platform reference
+	if platform.Spec.BasePlatformRef != nil {
+		allErrs = append(allErrs, v.validateBasePlatformRef(platform, field.NewPath("spec", "basePlatformRef"))...)
+	}
+
 	return allErrs
 }
 
@@ -172,6 +320,50 @@ func (v *ConfigurationValidator) validatePrometheusConfig(prometheus *observabil
 		}
 	}
 
+	// Validate query log settings
+	if prometheus.QueryLog != nil {
+		queryLogPath := fldPath.Child("queryLog")
+		if prometheus.QueryLog.SlowQueryThreshold != "" {
+			if d, err := time.ParseDuration(prometheus.QueryLog.SlowQueryThreshold); err != nil || d <= 0 {
+				allErrs = append(allErrs, field.Invalid(queryLogPath.Child("slowQueryThreshold"), prometheus.QueryLog.SlowQueryThreshold, "must be a positive duration"))
+			}
+		}
+		if prometheus.QueryLog.AnalysisInterval != "" {
+			if d, err := time.ParseDuration(prometheus.QueryLog.AnalysisInterval); err != nil || d < time.Minute {
+				allErrs = append(allErrs, field.Invalid(queryLogPath.Child("analysisInterval"), prometheus.QueryLog.AnalysisInterval, "must be a duration of at least 1m"))
+			}
+		}
+	}
+
+	// Validate the dual-write window of external label changes
+	if transition := prometheus.ExternalLabelTransition; transition != nil && transition.Window != "" {
+		if d, err := time.ParseDuration(transition.Window); err != nil || d <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("externalLabelTransition", "window"), transition.Window, "must be a positive duration"))
+		}
+	}
+
+	// Validate noisy alert settings
+	if noisy := prometheus.NoisyAlerts; noisy != nil {
+		noisyPath := fldPath.Child("noisyAlerts")
+		if noisy.Window != "" {
+			if d, err := time.ParseDuration(noisy.Window); err != nil || d < time.Hour {
+				allErrs = append(allErrs, field.Invalid(noisyPath.Child("window"), noisy.Window, "must be a duration of at least 1h"))
+			}
+		}
+		if noisy.AnalysisInterval != "" {
+			if d, err := time.ParseDuration(noisy.AnalysisInterval); err != nil || d < time.Minute {
+				allErrs = append(allErrs, field.Invalid(noisyPath.Child("analysisInterval"), noisy.AnalysisInterval, "must be a duration of at least 1m"))
+			}
+		}
+		if noisy.ShortFiring != "" {
+			if d, err := time.ParseDuration(noisy.ShortFiring); err != nil || d <= 0 {
+				allErrs = append(allErrs, field.Invalid(noisyPath.Child("shortFiring"), noisy.ShortFiring, "must be a positive duration"))
+			} else if d >= noisy.GetWindow() {
+				allErrs = append(allErrs, field.Invalid(noisyPath.Child("shortFiring"), noisy.ShortFiring, "must be shorter than the window"))
+			}
+		}
+	}
+
 	return allErrs
 }
 
@@ -236,6 +428,11 @@ func (v *ConfigurationValidator) validateLokiConfig(loki *observabilityv1beta1.L
 		allErrs = append(allErrs, v.validateRetentionSpec(loki.Retention, fldPath.Child("retention"))...)
 	}
 
+	// Validate zone awareness
+	if loki.ZoneAwareness.IsEnabled() {
+		allErrs = append(allErrs, v.validateZoneAwareness(loki.ZoneAwareness, fldPath.Child("zoneAwareness"))...)
+	}
+
 	return allErrs
 }
 
@@ -258,6 +455,11 @@ func (v *ConfigurationValidator) validateTempoConfig(tempo *observabilityv1beta1
 		allErrs = append(allErrs, v.validateStorageSpec(tempo.Storage, fldPath.Child("storage"))...)
 	}
 
+	// Validate zone awareness
+	if tempo.ZoneAwareness.IsEnabled() {
+		allErrs = append(allErrs, v.validateZoneAwareness(tempo.ZoneAwareness, fldPath.Child("zoneAwareness"))...)
+	}
+
 	return allErrs
 }
 
@@ -537,199 +739,957 @@ func (v *ConfigurationValidator) validateConfigurationDependencies(platform *obs
 	return allErrs
 }
 
-// Helper functions
+// fipsVersionCheck pairs a component with the version requested in the spec
+type fipsVersionCheck struct {
+	component string
+	child     string
+	version   string
+}
 
-// validateResourceRequirements validates resource requests and limits
-func (v *ConfigurationValidator) validateResourceRequirements(resources *observabilityv1beta1.ResourceRequirements, fldPath *field.Path) field.ErrorList {
+// validateFIPSSettings validates the strict-TLS profile and that every enabled
+// component runs a version able to honour it
+func (v *ConfigurationValidator) validateFIPSSettings(platform *observabilityv1beta1.ObservabilityPlatform, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	// Validate requests
-	if resources.Requests != nil {
-		allErrs = append(allErrs, v.validateResourceList(resources.Requests, fldPath.Child("requests"))...)
+	if !platform.IsFIPSEnabled() {
+		return allErrs
 	}
+	spec := platform.Spec.Security.FIPS
 
-	// Validate limits
-	if resources.Limits != nil {
-		allErrs = append(allErrs, v.validateResourceList(resources.Limits, fldPath.Child("limits"))...)
+	if spec.MinTLSVersion != "" && spec.MinTLSVersion != observabilityv1beta1.TLSVersion12 && spec.MinTLSVersion != observabilityv1beta1.TLSVersion13 {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("minTLSVersion"), spec.MinTLSVersion,
+			[]string{observabilityv1beta1.TLSVersion12, observabilityv1beta1.TLSVersion13}))
 	}
 
-	// Ensure limits >= requests
-	if resources.Requests != nil && resources.Limits != nil {
-		if resources.Requests.CPU != "" && resources.Limits.CPU != "" {
-			reqCPU, err1 := resource.ParseQuantity(resources.Requests.CPU)
-			limCPU, err2 := resource.ParseQuantity(resources.Limits.CPU)
-			if err1 == nil && err2 == nil && reqCPU.Cmp(limCPU) > 0 {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("requests", "cpu"), 
-					resources.Requests.CPU, "CPU request cannot exceed limit"))
-			}
+	for i, suite := range spec.CipherSuites {
+		if len(fips.UnapprovedCipherSuites([]string{suite})) > 0 {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("cipherSuites").Index(i), suite, fips.ApprovedCipherSuites))
 		}
+	}
 
-		if resources.Requests.Memory != "" && resources.Limits.Memory != "" {
-			reqMem, err1 := resource.ParseQuantity(resources.Requests.Memory)
-			limMem, err2 := resource.ParseQuantity(resources.Limits.Memory)
-			if err1 == nil && err2 == nil && reqMem.Cmp(limMem) > 0 {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("requests", "memory"), 
-					resources.Requests.Memory, "Memory request cannot exceed limit"))
-			}
+	if platform.Spec.Components == nil {
+		return allErrs
+	}
+
+	componentsPath := field.NewPath("spec", "components")
+	var checks []fipsVersionCheck
+	if c := platform.Spec.Components.Prometheus; c != nil && c.Enabled {
+		checks = append(checks, fipsVersionCheck{"prometheus", "prometheus", c.Version})
+	}
+	if c := platform.Spec.Components.Grafana; c != nil && c.Enabled {
+		checks = append(checks, fipsVersionCheck{"grafana", "grafana", c.Version})
+	}
+	if c := platform.Spec.Components.Loki; c != nil && c.Enabled {
+		checks = append(checks, fipsVersionCheck{"loki", "loki", c.Version})
+	}
+	if c := platform.Spec.Components.Tempo; c != nil && c.Enabled {
+		checks = append(checks, fipsVersionCheck{"tempo", "tempo", c.Version})
+	}
+	if c := platform.Spec.Components.OpenTelemetryCollector; c != nil && c.Enabled {
+		checks = append(checks, fipsVersionCheck{"opentelemetry-collector", "opentelemetryCollector", c.Version})
+	}
+
+	for _, check := range checks {
+		if check.version == "" || fips.SupportsComponentVersion(check.component, check.version) {
+			continue
 		}
+		allErrs = append(allErrs, field.Invalid(componentsPath.Child(check.child, "version"), check.version,
+			fmt.Sprintf("FIPS mode requires %s %s or newer", check.component, fips.MinimumComponentVersions[check.component])))
 	}
 
 	return allErrs
 }
 
-// validateResourceList validates CPU and memory resource specifications
-func (v *ConfigurationValidator) validateResourceList(resources *observabilityv1beta1.ResourceList, fldPath *field.Path) field.ErrorList {
+// validatePlacement validates the global and per-component topology spread constraints
+func (v *ConfigurationValidator) validatePlacement(platform *observabilityv1beta1.ObservabilityPlatform, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if resources.CPU != "" {
-		if _, err := resource.ParseQuantity(resources.CPU); err != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("cpu"), resources.CPU, 
-				fmt.Sprintf("invalid CPU quantity: %v", err)))
-		}
+	if platform.Spec.Global != nil {
+		allErrs = append(allErrs, v.validateTopologySpreadConstraints(platform.Spec.Global.TopologySpreadConstraints,
+			fldPath.Child("global", "topologySpreadConstraints"))...)
 	}
 
-	if resources.Memory != "" {
-		if _, err := resource.ParseQuantity(resources.Memory); err != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("memory"), resources.Memory, 
-				fmt.Sprintf("invalid memory quantity: %v", err)))
-		}
+	components := platform.Spec.Components
+	if components == nil {
+		return allErrs
+	}
+	componentsPath := fldPath.Child("components")
+	if components.Prometheus != nil {
+		allErrs = append(allErrs, v.validateTopologySpreadConstraints(components.Prometheus.TopologySpreadConstraints,
+			componentsPath.Child("prometheus", "topologySpreadConstraints"))...)
+	}
+	if components.Grafana != nil {
+		allErrs = append(allErrs, v.validateTopologySpreadConstraints(components.Grafana.TopologySpreadConstraints,
+			componentsPath.Child("grafana", "topologySpreadConstraints"))...)
+	}
+	if components.Loki != nil {
+		allErrs = append(allErrs, v.validateTopologySpreadConstraints(components.Loki.TopologySpreadConstraints,
+			componentsPath.Child("loki", "topologySpreadConstraints"))...)
+	}
+	if components.Tempo != nil {
+		allErrs = append(allErrs, v.validateTopologySpreadConstraints(components.Tempo.TopologySpreadConstraints,
+			componentsPath.Child("tempo", "topologySpreadConstraints"))...)
+	}
+	if components.OpenTelemetryCollector != nil {
+		allErrs = append(allErrs, v.validateTopologySpreadConstraints(components.OpenTelemetryCollector.TopologySpreadConstraints,
+			componentsPath.Child("opentelemetryCollector", "topologySpreadConstraints"))...)
 	}
 
 	return allErrs
 }
 
-// validateStorageSpec validates storage configuration
-func (v *ConfigurationValidator) validateStorageSpec(storage *observabilityv1beta1.StorageSpec, fldPath *field.Path) field.ErrorList {
+// validateTopologySpreadConstraints applies the Kubernetes pod validation rules
+// for topology spread constraints so errors surface at admission rather than
+// when the operator creates the workloads
+func (v *ConfigurationValidator) validateTopologySpreadConstraints(constraints []corev1.TopologySpreadConstraint, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
+	seen := make(map[string]bool, len(constraints))
 
-	// Validate storage size
-	if _, err := resource.ParseQuantity(storage.Size); err != nil {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), storage.Size, 
-			fmt.Sprintf("invalid storage size: %v", err)))
-	}
+	for i, c := range constraints {
+		idxPath := fldPath.Index(i)
 
-	// Validate retention format
-	if storage.Retention != "" {
-		if !isValidRetention(storage.Retention) {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("retention"), storage.Retention, 
-				"invalid retention format, must match pattern: ^\\d+[hdwmy]$"))
+		if c.MaxSkew < 1 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("maxSkew"), c.MaxSkew, "must be greater than zero"))
 		}
+		if c.TopologyKey == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("topologyKey"), "topologyKey is required"))
+		}
+		switch c.WhenUnsatisfiable {
+		case corev1.DoNotSchedule, corev1.ScheduleAnyway:
+		default:
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("whenUnsatisfiable"), c.WhenUnsatisfiable,
+				[]string{string(corev1.DoNotSchedule), string(corev1.ScheduleAnyway)}))
+		}
+		if c.MinDomains != nil {
+			if *c.MinDomains < 1 {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("minDomains"), *c.MinDomains, "must be greater than zero"))
+			}
+			if c.WhenUnsatisfiable != corev1.DoNotSchedule {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("minDomains"), *c.MinDomains,
+					"can only be used with whenUnsatisfiable DoNotSchedule"))
+			}
+		}
+
+		key := c.TopologyKey + "/" + string(c.WhenUnsatisfiable)
+		if seen[key] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, key))
+		}
+		seen[key] = true
 	}
 
 	return allErrs
 }
 
-// validateLokiStorageSpec validates Loki-specific storage configuration
-func (v *ConfigurationValidator) validateLokiStorageSpec(storage *observabilityv1beta1.LokiStorageSpec, fldPath *field.Path) field.ErrorList {
+// validateEphemeralStorage validates the ephemeral storage settings of all components
+func (v *ConfigurationValidator) validateEphemeralStorage(platform *observabilityv1beta1.ObservabilityPlatform, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	// Validate base storage spec
-	allErrs = append(allErrs, v.validateStorageSpec(&storage.StorageSpec, fldPath)...)
+	components := platform.Spec.Components
+	if components == nil {
+		return allErrs
+	}
 
-	// Validate S3 configuration if enabled
-	if storage.S3 != nil && storage.S3.Enabled {
-		if storage.S3.BucketName == "" {
-			allErrs = append(allErrs, field.Required(fldPath.Child("s3", "bucketName"), 
-				"bucket name is required when S3 is enabled"))
+	type ephemeralStorageCheck struct {
+		child   string
+		spec    *observabilityv1beta1.EphemeralStorageSpec
+		withWAL bool
+	}
+	var checks []ephemeralStorageCheck
+	if components.Prometheus != nil {
+		checks = append(checks, ephemeralStorageCheck{"prometheus", components.Prometheus.EphemeralStorage, true})
+	}
+	if components.Grafana != nil {
+		checks = append(checks, ephemeralStorageCheck{"grafana", components.Grafana.EphemeralStorage, false})
+	}
+	if components.Loki != nil {
+		checks = append(checks, ephemeralStorageCheck{"loki", components.Loki.EphemeralStorage, true})
+	}
+	if components.Tempo != nil {
+		checks = append(checks, ephemeralStorageCheck{"tempo", components.Tempo.EphemeralStorage, true})
+	}
+	if components.OpenTelemetryCollector != nil {
+		checks = append(checks, ephemeralStorageCheck{"opentelemetryCollector", components.OpenTelemetryCollector.EphemeralStorage, false})
+	}
+
+	for _, check := range checks {
+		if check.spec == nil {
+			continue
 		}
-		if storage.S3.Region == "" && storage.S3.Endpoint == "" {
-			allErrs = append(allErrs, field.Required(fldPath.Child("s3", "region"), 
-				"region is required when S3 is enabled (unless custom endpoint is specified)"))
+		specPath := fldPath.Child(check.child, "ephemeralStorage")
+
+		parsed := map[string]resource.Quantity{}
+		for name, value := range map[string]string{
+			"request":           check.spec.Request,
+			"limit":             check.spec.Limit,
+			"emptyDirSizeLimit": check.spec.EmptyDirSizeLimit,
+		} {
+			if value == "" {
+				continue
+			}
+			q, err := resource.ParseQuantity(value)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(specPath.Child(name), value, "invalid quantity"))
+				continue
+			}
+			parsed[name] = q
+		}
+		request, hasRequest := parsed["request"]
+		limit, hasLimit := parsed["limit"]
+		if hasRequest && hasLimit && request.Cmp(limit) > 0 {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("request"), check.spec.Request, "must not exceed the limit"))
+		}
+
+		if check.spec.WAL == nil {
+			continue
+		}
+		walPath := specPath.Child("wal")
+		if !check.withWAL {
+			allErrs = append(allErrs, field.Forbidden(walPath, fmt.Sprintf("%s has no write-ahead log", check.child)))
+			continue
+		}
+		if check.spec.WAL.Size == "" {
+			allErrs = append(allErrs, field.Required(walPath.Child("size"), "WAL volume size is required"))
+		} else if _, err := resource.ParseQuantity(check.spec.WAL.Size); err != nil {
+			allErrs = append(allErrs, field.Invalid(walPath.Child("size"), check.spec.WAL.Size, "invalid quantity"))
+		}
+		if check.spec.WAL.StorageClassName != "" && check.spec.WAL.Medium != "" {
+			allErrs = append(allErrs, field.Forbidden(walPath.Child("medium"), "medium only applies to emptyDir WAL volumes without a storage class"))
 		}
 	}
 
 	return allErrs
 }
 
-// validateIngressSpec validates ingress configuration
-func (v *ConfigurationValidator) validateIngressSpec(ingress *observabilityv1beta1.IngressSpec, fldPath *field.Path) field.ErrorList {
+// validateExtraContainers validates the extra containers and volumes of all
+// components against each other and the containers, volumes and ports the
+// operator manages
+func (v *ConfigurationValidator) validateExtraContainers(platform *observabilityv1beta1.ObservabilityPlatform, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if ingress.Host == "" {
-		allErrs = append(allErrs, field.Required(fldPath.Child("host"), 
-			"host is required when ingress is enabled"))
+	components := platform.Spec.Components
+	if components == nil {
+		return allErrs
 	}
 
-	// Validate TLS configuration
-	if ingress.TLS != nil && ingress.TLS.Enabled {
-		if ingress.TLS.SecretName == "" {
-			v.Log.Info("TLS enabled without secret name, expecting cert-manager or similar to provide certificate")
-		}
+	type extraContainersCheck struct {
+		child      string
+		containers []corev1.Container
+		volumes    []corev1.Volume
+	}
+	var checks []extraContainersCheck
+	if components.Prometheus != nil {
+		checks = append(checks, extraContainersCheck{"prometheus", components.Prometheus.ExtraContainers, components.Prometheus.ExtraVolumes})
+	}
+	if components.Grafana != nil {
+		checks = append(checks, extraContainersCheck{"grafana", components.Grafana.ExtraContainers, components.Grafana.ExtraVolumes})
+	}
+	if components.Loki != nil {
+		checks = append(checks, extraContainersCheck{"loki", components.Loki.ExtraContainers, components.Loki.ExtraVolumes})
+	}
+	if components.Tempo != nil {
+		checks = append(checks, extraContainersCheck{"tempo", components.Tempo.ExtraContainers, components.Tempo.ExtraVolumes})
+	}
+	if components.OpenTelemetryCollector != nil {
+		checks = append(checks, extraContainersCheck{"opentelemetryCollector", components.OpenTelemetryCollector.ExtraContainers, components.OpenTelemetryCollector.ExtraVolumes})
 	}
 
-	// Validate annotations
-	for k, v := range ingress.Annotations {
-		if strings.TrimSpace(k) == "" {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("annotations"), k, 
-				"annotation key cannot be empty"))
-		}
-		if strings.TrimSpace(v) == "" {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("annotations").Key(k), v, 
-				"annotation value cannot be empty"))
-		}
+	for _, check := range checks {
+		allErrs = append(allErrs, sidecars.Validate(sidecars.ComponentReserved[check.child], check.containers, check.volumes, fldPath.Child(check.child))...)
 	}
 
 	return allErrs
 }
 
-// validatePersistenceSpec validates persistence configuration
-func (v *ConfigurationValidator) validatePersistenceSpec(persistence *observabilityv1beta1.PersistenceSpec, fldPath *field.Path) field.ErrorList {
+// validateEnv validates the environment variables and sources of all components
+func (v *ConfigurationValidator) validateEnv(platform *observabilityv1beta1.ObservabilityPlatform, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if _, err := resource.ParseQuantity(persistence.Size); err != nil {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), persistence.Size, 
-			fmt.Sprintf("invalid storage size: %v", err)))
+	components := platform.Spec.Components
+	if components == nil {
+		return allErrs
+	}
+
+	type envCheck struct {
+		child   string
+		env     []corev1.EnvVar
+		envFrom []corev1.EnvFromSource
+	}
+	var checks []envCheck
+	if components.Prometheus != nil {
+		checks = append(checks, envCheck{"prometheus", components.Prometheus.Env, components.Prometheus.EnvFrom})
+	}
+	if components.Grafana != nil {
+		checks = append(checks, envCheck{"grafana", components.Grafana.Env, components.Grafana.EnvFrom})
+	}
+	if components.Loki != nil {
+		checks = append(checks, envCheck{"loki", components.Loki.Env, components.Loki.EnvFrom})
+	}
+	if components.Tempo != nil {
+		checks = append(checks, envCheck{"tempo", components.Tempo.Env, components.Tempo.EnvFrom})
+	}
+	if components.OpenTelemetryCollector != nil {
+		checks = append(checks, envCheck{"opentelemetryCollector", components.OpenTelemetryCollector.Env, components.OpenTelemetryCollector.EnvFrom})
+	}
+
+	for _, check := range checks {
+		allErrs = append(allErrs, envvars.Validate(envvars.ComponentReserved[check.child], check.env, check.envFrom, fldPath.Child(check.child))...)
 	}
 
 	return allErrs
 }
 
-// validateDataSourceSpec validates Grafana data source configuration
-func (v *ConfigurationValidator) validateDataSourceSpec(ds observabilityv1beta1.DataSourceSpec, fldPath *field.Path) field.ErrorList {
+// validateProbes validates the health probe overrides of all components
+func (v *ConfigurationValidator) validateProbes(platform *observabilityv1beta1.ObservabilityPlatform, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if ds.Name == "" {
-		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "data source name is required"))
+	components := platform.Spec.Components
+	if components == nil {
+		return allErrs
 	}
 
-	if ds.URL == "" {
-		allErrs = append(allErrs, field.Required(fldPath.Child("url"), "data source URL is required"))
+	type probesCheck struct {
+		child  string
+		probes *observabilityv1beta1.ProbesSpec
 	}
-
-	validTypes := map[string]bool{
-		"prometheus":    true,
-		"loki":          true,
-		"tempo":         true,
-		"elasticsearch": true,
+	var checks []probesCheck
+	if components.Prometheus != nil {
+		checks = append(checks, probesCheck{"prometheus", components.Prometheus.Probes})
 	}
-	if !validTypes[ds.Type] {
-		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), ds.Type, 
-			[]string{"prometheus", "loki", "tempo", "elasticsearch"}))
+	if components.Grafana != nil {
+		checks = append(checks, probesCheck{"grafana", components.Grafana.Probes})
+	}
+	if components.Loki != nil {
+		checks = append(checks, probesCheck{"loki", components.Loki.Probes})
+	}
+	if components.Tempo != nil {
+		checks = append(checks, probesCheck{"tempo", components.Tempo.Probes})
 	}
 
-	validAccessModes := map[string]bool{"proxy": true, "direct": true}
-	if !validAccessModes[ds.Access] {
-		allErrs = append(allErrs, field.NotSupported(fldPath.Child("access"), ds.Access, 
-			[]string{"proxy", "direct"}))
+	for _, check := range checks {
+		allErrs = append(allErrs, probes.Validate(check.probes, fldPath.Child(check.child, "probes"))...)
 	}
 
 	return allErrs
 }
 
-// validateRemoteWriteSpec validates Prometheus remote write configuration
-func (v *ConfigurationValidator) validateRemoteWriteSpec(rw observabilityv1beta1.RemoteWriteSpec, fldPath *field.Path) field.ErrorList {
+// validateLogging validates the debug mode TTL of all components
+func (v *ConfigurationValidator) validateLogging(platform *observabilityv1beta1.ObservabilityPlatform, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if rw.URL == "" {
-		allErrs = append(allErrs, field.Required(fldPath.Child("url"), "remote write URL is required"))
+	components := platform.Spec.Components
+	if components == nil {
+		return allErrs
 	}
 
-	// Validate basic auth if provided
-	if rw.BasicAuth != nil {
-		if rw.BasicAuth.Username == "" {
-			allErrs = append(allErrs, field.Required(fldPath.Child("basicAuth", "username"), 
-				"username is required for basic auth"))
-		}
+	type loggingCheck struct {
+		child   string
+		logging observabilityv1beta1.ComponentLogging
+	}
+	var checks []loggingCheck
+	if components.Prometheus != nil {
+		checks = append(checks, loggingCheck{"prometheus", components.Prometheus.ComponentLogging})
+	}
+	if components.Grafana != nil {
+		checks = append(checks, loggingCheck{"grafana", components.Grafana.ComponentLogging})
+	}
+	if components.Loki != nil {
+		checks = append(checks, loggingCheck{"loki", components.Loki.ComponentLogging})
+	}
+	if components.Tempo != nil {
+		checks = append(checks, loggingCheck{"tempo", components.Tempo.ComponentLogging})
+	}
+	if components.GrafanaAlloy != nil {
+		checks = append(checks, loggingCheck{"grafanaAlloy", components.GrafanaAlloy.ComponentLogging})
+	}
+
+	for _, check := range checks {
+		if ttl := check.logging.DebugTTL; ttl != "" {
+			if d, err := time.ParseDuration(ttl); err != nil || d <= 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child(check.child, "debugTTL"), ttl, "must be a positive duration"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateQueryACL validates the enforced label and the teams of query access control
+func (v *ConfigurationValidator) validateQueryACL(platform *observabilityv1beta1.ObservabilityPlatform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	acl := platform.Spec.QueryACL
+
+	components := platform.Spec.Components
+	if components == nil || components.Prometheus == nil || !components.Prometheus.Enabled {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("enabled"), "query access control requires Prometheus"))
+	}
+	if components == nil || components.Grafana == nil || !components.Grafana.Enabled {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("enabled"), "query access control requires Grafana for the team datasources"))
+	}
+
+	if !prometheusLabelNameRegex.MatchString(acl.GetLabel()) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("label"), acl.Label, "must be a valid Prometheus label name"))
+	}
+
+	names := map[string]bool{}
+	orgs := map[int64]bool{}
+	for i, team := range acl.Teams {
+		teamPath := fldPath.Child("teams").Index(i)
+		if team.Name == "" {
+			allErrs = append(allErrs, field.Required(teamPath.Child("name"), "team name is required"))
+		} else if names[team.Name] {
+			allErrs = append(allErrs, field.Duplicate(teamPath.Child("name"), team.Name))
+		}
+		names[team.Name] = true
+
+		// A Grafana organization holds a single team datasource, and the
+		// main organization holds the unrestricted ones
+		if team.OrgID < queryacl.MinOrgID {
+			allErrs = append(allErrs, field.Invalid(teamPath.Child("orgId"), team.OrgID, "must be a Grafana organization ID other than the main organization 1"))
+		} else if orgs[team.OrgID] {
+			allErrs = append(allErrs, field.Duplicate(teamPath.Child("orgId"), team.OrgID))
+		}
+		orgs[team.OrgID] = true
+
+		if len(team.Values) == 0 {
+			allErrs = append(allErrs, field.Required(teamPath.Child("values"), "at least one label value is required"))
+		}
+		for j, value := range team.Values {
+			if value == "" {
+				allErrs = append(allErrs, field.Invalid(teamPath.Child("values").Index(j), value, "label value must not be empty"))
+			}
+		}
+		for j, tenant := range team.Tenants {
+			if tenant == "" {
+				allErrs = append(allErrs, field.Invalid(teamPath.Child("tenants").Index(j), tenant, "tenant must not be empty"))
+			} else if err := correlation.ValidateTenantID(tenant); err != nil {
+				allErrs = append(allErrs, field.Invalid(teamPath.Child("tenants").Index(j), tenant, err.Error()))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateNodePool validates the label, taint and declared nodes of a dedicated node pool
+func (v *ConfigurationValidator) validateNodePool(pool *observabilityv1beta1.NodePoolSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, msg := range validation.IsQualifiedName(pool.GetLabelKey()) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("labelKey"), pool.LabelKey, msg))
+	}
+	for _, msg := range validation.IsValidLabelValue(pool.GetName()) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), pool.Name, msg))
+	}
+
+	seen := make(map[string]bool, len(pool.Nodes))
+	for i, node := range pool.Nodes {
+		switch {
+		case node == "":
+			allErrs = append(allErrs, field.Required(fldPath.Child("nodes").Index(i), "node name must not be empty"))
+		case seen[node]:
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("nodes").Index(i), node))
+		}
+		seen[node] = true
+	}
+
+	return allErrs
+}
+
+// validateRunbooks validates the runbook registry entries and that the runbook
+// keys referenced by alerting rules exist. Keys can only be checked when the
+// registry is defined inline; ConfigMap entries are resolved at reconcile time.
+func (v *ConfigurationValidator) validateRunbooks(platform *observabilityv1beta1.ObservabilityPlatform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	alerting := platform.Spec.Alerting
+	if alerting == nil {
+		return allErrs
+	}
+	registry := alerting.Runbooks
+
+	if registry == nil {
+		for i, rule := range alerting.Rules {
+			if rule.RunbookKey != "" {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("rules").Index(i).Child("runbookKey"), rule.RunbookKey,
+					"runbookKey requires spec.alerting.runbooks to be configured"))
+			}
+		}
+		return allErrs
+	}
+
+	registryPath := fldPath.Child("runbooks")
+	if registry.ConfigMapRef != nil && registry.ConfigMapRef.Name == "" {
+		allErrs = append(allErrs, field.Required(registryPath.Child("configMapRef", "name"), "ConfigMap name is required"))
+	}
+	for key, entry := range registry.Entries {
+		if err := runbooks.ValidateEntry(registry.BaseURL, entry); err != nil {
+			allErrs = append(allErrs, field.Invalid(registryPath.Child("entries").Key(key), entry, err.Error()))
+		}
+	}
+
+	if registry.ConfigMapRef != nil {
+		return allErrs
+	}
+
+	if registry.DefaultKey != "" {
+		if _, ok := registry.Entries[registry.DefaultKey]; !ok {
+			allErrs = append(allErrs, field.NotFound(registryPath.Child("defaultKey"), registry.DefaultKey))
+		}
+	}
+	for i, rule := range alerting.Rules {
+		if rule.RunbookKey == "" {
+			continue
+		}
+		if _, ok := registry.Entries[rule.RunbookKey]; !ok {
+			allErrs = append(allErrs, field.NotFound(fldPath.Child("rules").Index(i).Child("runbookKey"), rule.RunbookKey))
+		}
+	}
+
+	return allErrs
+}
+
+// validateAlertmanagerRouting validates the inhibit rules and mute time
+// intervals, and that the time intervals referenced by the Alertmanager
+// routes are defined
+func (v *ConfigurationValidator) validateAlertmanagerRouting(platform *observabilityv1beta1.ObservabilityPlatform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	alerting := platform.Spec.Alerting
+	if alerting == nil {
+		return allErrs
+	}
+
+	for i, rule := range alerting.InhibitRules {
+		allErrs = append(allErrs, alertmanager.ValidateInhibitRule(rule, fldPath.Child("inhibitRules").Index(i))...)
+	}
+
+	intervals := make(map[string]bool)
+	for i, mute := range alerting.MuteTimeIntervals {
+		mutePath := fldPath.Child("muteTimeIntervals").Index(i)
+		if mute.Name == "" {
+			allErrs = append(allErrs, field.Required(mutePath.Child("name"), "time interval name is required"))
+		} else if intervals[mute.Name] {
+			allErrs = append(allErrs, field.Duplicate(mutePath.Child("name"), mute.Name))
+		}
+		intervals[mute.Name] = true
+
+		if len(mute.TimeIntervals) == 0 {
+			allErrs = append(allErrs, field.Required(mutePath.Child("timeIntervals"), "at least one time interval is required"))
+		}
+		for j, ti := range mute.TimeIntervals {
+			allErrs = append(allErrs, alertmanager.ValidateTimeInterval(ti, mutePath.Child("timeIntervals").Index(j))...)
+		}
+	}
+
+	if alerting.Alertmanager == nil || alerting.Alertmanager.Config == nil {
+		return allErrs
+	}
+	config := alerting.Alertmanager.Config
+	configPath := fldPath.Child("alertmanager", "config")
+	for i, rule := range config.InhibitRules {
+		allErrs = append(allErrs, alertmanager.ValidateInhibitRule(rule, configPath.Child("inhibitRules").Index(i))...)
+	}
+	if config.Route == nil {
+		return allErrs
+	}
+
+	// Alertmanager does not allow time intervals on the root route, which
+	// receives every alert
+	routePath := configPath.Child("route")
+	if len(config.Route.MuteTimeIntervals) > 0 {
+		allErrs = append(allErrs, field.Forbidden(routePath.Child("muteTimeIntervals"), "the root route cannot be muted; set it on a child route"))
+	}
+	if len(config.Route.ActiveTimeIntervals) > 0 {
+		allErrs = append(allErrs, field.Forbidden(routePath.Child("activeTimeIntervals"), "the root route cannot be muted; set it on a child route"))
+	}
+
+	var walk func(route observabilityv1beta1.Route, path *field.Path)
+	walk = func(route observabilityv1beta1.Route, path *field.Path) {
+		for i, m := range route.Matchers {
+			allErrs = append(allErrs, alertmanager.ValidateMatcher(m, path.Child("matchers").Index(i))...)
+		}
+		for i, name := range route.MuteTimeIntervals {
+			if !intervals[name] {
+				allErrs = append(allErrs, field.NotFound(path.Child("muteTimeIntervals").Index(i), name))
+			}
+		}
+		for i, name := range route.ActiveTimeIntervals {
+			if !intervals[name] {
+				allErrs = append(allErrs, field.NotFound(path.Child("activeTimeIntervals").Index(i), name))
+			}
+		}
+		for i, child := range route.Routes {
+			walk(child, path.Child("routes").Index(i))
+		}
+	}
+	walk(*config.Route, routePath)
+
+	return allErrs
+}
+
+// validateCorrelation validates the correlation labels and tenant, and
+// rejects external labels setting a correlation label to another value,
+// which would leave the metrics of Prometheus unmatched with their logs and
+// traces in Grafana
+func (v *ConfigurationValidator) validateCorrelation(platform *observabilityv1beta1.ObservabilityPlatform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	spec := platform.Spec.Global.Correlation
+	for k, val := range spec.Labels {
+		labelPath := fldPath.Child("labels").Key(k)
+		switch {
+		case !prometheusLabelNameRegex.MatchString(k) || strings.HasPrefix(k, "__"):
+			allErrs = append(allErrs, field.Invalid(labelPath, k, "must be a valid label name"))
+		case k == correlation.PlatformLabel:
+			allErrs = append(allErrs, field.Forbidden(labelPath, "the platform label is set by the operator"))
+		case val == "":
+			allErrs = append(allErrs, field.Required(labelPath, "correlation labels need a value"))
+		}
+	}
+	if spec.TenantID != "" {
+		if err := correlation.ValidateTenantID(spec.TenantID); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("tenantId"), spec.TenantID, err.Error()))
+		}
+	}
+
+	for _, conflict := range correlation.Conflicts(spec.Labels, platform.Spec.Global.ExternalLabels) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "global", "externalLabels").Key(conflict.Label), conflict.Value,
+			fmt.Sprintf("conflicts with the correlation label %q; remove it or set the same value", conflict.Want)))
+	}
+	if components := platform.Spec.Components; components != nil && components.Prometheus != nil {
+		for _, conflict := range correlation.Conflicts(spec.Labels, components.Prometheus.ExternalLabels) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "components", "prometheus", "externalLabels").Key(conflict.Label), conflict.Value,
+				fmt.Sprintf("conflicts with the correlation label %q; Grafana could not match the metrics with their logs and traces", conflict.Want)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateThanos validates the object storage reference, the sidecar's upload
+// interval and the consistency of the compactor's downsampling and retention
+// policies
+func (v *ConfigurationValidator) validateThanos(spec *observabilityv1beta1.ThanosSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.ObjectStorageConfig.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("objectStorageConfig", "name"), "object storage Secret is required"))
+	}
+	if spec.ObjectStorageConfig.Key == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("objectStorageConfig", "key"), "object storage Secret key is required"))
+	}
+
+	if spec.Sidecar != nil && spec.Sidecar.UploadInterval != "" {
+		allErrs = append(allErrs, thanos.ValidateUploadInterval(spec.Sidecar.UploadInterval, fldPath.Child("sidecar", "uploadInterval"))...)
+	}
+
+	compactorPath := fldPath.Child("compactor")
+	downsampling := spec.Compactor.IsDownsamplingEnabled()
+	if !downsampling && spec.Compactor.Retention != nil {
+		retentionPath := compactorPath.Child("retention")
+		if spec.Compactor.Retention.FiveMinutes != "" {
+			allErrs = append(allErrs, field.Invalid(retentionPath.Child("fiveMinutes"), spec.Compactor.Retention.FiveMinutes,
+				"has no effect when downsampling is disabled"))
+		}
+		if spec.Compactor.Retention.OneHour != "" {
+			allErrs = append(allErrs, field.Invalid(retentionPath.Child("oneHour"), spec.Compactor.Retention.OneHour,
+				"has no effect when downsampling is disabled"))
+		}
+	}
+
+	retention := spec.Compactor.GetRetention()
+	allErrs = append(allErrs, thanos.ValidateRetention(thanos.Retention{
+		Raw:         retention.Raw,
+		FiveMinutes: retention.FiveMinutes,
+		OneHour:     retention.OneHour,
+	}, downsampling, compactorPath.Child("retention"))...)
+
+	return allErrs
+}
+
+// validateRecordingRules validates the recording rule groups and the backfill settings
+func (v *ConfigurationValidator) validateRecordingRules(platform *observabilityv1beta1.ObservabilityPlatform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	alerting := platform.Spec.Alerting
+	if alerting == nil {
+		return allErrs
+	}
+
+	groupNames := make(map[string]bool)
+	for i, group := range alerting.RecordingRules {
+		groupPath := fldPath.Child("recordingRules").Index(i)
+		if group.Name == "" {
+			allErrs = append(allErrs, field.Required(groupPath.Child("name"), "rule group name is required"))
+		} else if groupNames[group.Name] {
+			allErrs = append(allErrs, field.Duplicate(groupPath.Child("name"), group.Name))
+		}
+		groupNames[group.Name] = true
+
+		if group.Interval != "" {
+			if d, err := time.ParseDuration(group.Interval); err != nil || d <= 0 {
+				allErrs = append(allErrs, field.Invalid(groupPath.Child("interval"), group.Interval, "must be a positive duration"))
+			}
+		}
+		for j, rule := range group.Rules {
+			rulePath := groupPath.Child("rules").Index(j)
+			if !prometheusMetricNameRegex.MatchString(rule.Record) {
+				allErrs = append(allErrs, field.Invalid(rulePath.Child("record"), rule.Record, "must be a valid metric name"))
+			}
+			if rule.Expr == "" {
+				allErrs = append(allErrs, field.Required(rulePath.Child("expr"), "expression is required"))
+			}
+		}
+	}
+
+	if backfill := alerting.Backfill; backfill.IsEnabled() {
+		backfillPath := fldPath.Child("backfill")
+		durations := []struct{ name, value string }{
+			{"lookback", backfill.Lookback},
+			{"evalInterval", backfill.EvalInterval},
+		}
+		for _, duration := range durations {
+			if duration.value == "" {
+				continue
+			}
+			if d, err := time.ParseDuration(duration.value); err != nil || d <= 0 {
+				allErrs = append(allErrs, field.Invalid(backfillPath.Child(duration.name), duration.value, "must be a positive duration"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// Helper functions
+
+// validateZoneAwareness validates the zone-aware replication settings of an ingester ring.
+// Whether the zones exist in the cluster is checked against the nodes at admission time.
+func (v *ConfigurationValidator) validateZoneAwareness(zones *observabilityv1beta1.ZoneAwarenessSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if zones.TopologyKey != "" {
+		for _, msg := range validation.IsQualifiedName(zones.TopologyKey) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("topologyKey"), zones.TopologyKey, msg))
+		}
+	}
+
+	if len(zones.Zones) < 2 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("zones"), zones.Zones, "at least two zones are required for zone-aware replication"))
+	}
+	seen := make(map[string]bool, len(zones.Zones))
+	for i, zone := range zones.Zones {
+		switch {
+		case zone == "":
+			allErrs = append(allErrs, field.Required(fldPath.Child("zones").Index(i), "zone name must not be empty"))
+		case seen[zone]:
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("zones").Index(i), zone))
+		}
+		seen[zone] = true
+	}
+
+	if zones.ReplicationFactor < 0 || (zones.ReplicationFactor > 0 && len(zones.Zones) > 0 && int(zones.ReplicationFactor) > len(zones.Zones)) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("replicationFactor"), zones.ReplicationFactor,
+			fmt.Sprintf("must be between 1 and the number of zones (%d)", len(zones.Zones))))
+	}
+	if zones.ReplicasPerZone < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("replicasPerZone"), zones.ReplicasPerZone, "must not be negative"))
+	}
+
+	return allErrs
+}
+
+// validateResourceRequirements validates resource requests and limits
+func (v *ConfigurationValidator) validateResourceRequirements(resources *observabilityv1beta1.ResourceRequirements, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// Validate requests
+	if resources.Requests != nil {
+		allErrs = append(allErrs, v.validateResourceList(resources.Requests, fldPath.Child("requests"))...)
+	}
+
+	// Validate limits
+	if resources.Limits != nil {
+		allErrs = append(allErrs, v.validateResourceList(resources.Limits, fldPath.Child("limits"))...)
+	}
+
+	// Ensure limits >= requests
+	if resources.Requests != nil && resources.Limits != nil {
+		if resources.Requests.CPU != "" && resources.Limits.CPU != "" {
+			reqCPU, err1 := resource.ParseQuantity(resources.Requests.CPU)
+			limCPU, err2 := resource.ParseQuantity(resources.Limits.CPU)
+			if err1 == nil && err2 == nil && reqCPU.Cmp(limCPU) > 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("requests", "cpu"), 
+					resources.Requests.CPU, "CPU request cannot exceed limit"))
+			}
+		}
+
+		if resources.Requests.Memory != "" && resources.Limits.Memory != "" {
+			reqMem, err1 := resource.ParseQuantity(resources.Requests.Memory)
+			limMem, err2 := resource.ParseQuantity(resources.Limits.Memory)
+			if err1 == nil && err2 == nil && reqMem.Cmp(limMem) > 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("requests", "memory"), 
+					resources.Requests.Memory, "Memory request cannot exceed limit"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateResourceList validates CPU and memory resource specifications
+func (v *ConfigurationValidator) validateResourceList(resources *observabilityv1beta1.ResourceList, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if resources.CPU != "" {
+		if _, err := resource.ParseQuantity(resources.CPU); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("cpu"), resources.CPU, 
+				fmt.Sprintf("invalid CPU quantity: %v", err)))
+		}
+	}
+
+	if resources.Memory != "" {
+		if _, err := resource.ParseQuantity(resources.Memory); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("memory"), resources.Memory, 
+				fmt.Sprintf("invalid memory quantity: %v", err)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateStorageSpec validates storage configuration
+func (v *ConfigurationValidator) validateStorageSpec(storage *observabilityv1beta1.StorageSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// Validate storage size
+	if _, err := resource.ParseQuantity(storage.Size); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), storage.Size, 
+			fmt.Sprintf("invalid storage size: %v", err)))
+	}
+
+	// Validate retention format
+	if storage.Retention != "" {
+		if !isValidRetention(storage.Retention) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("retention"), storage.Retention, 
+				"invalid retention format, must match pattern: ^\\d+[hdwmy]$"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateLokiStorageSpec validates Loki-specific storage configuration
+func (v *ConfigurationValidator) validateLokiStorageSpec(storage *observabilityv1beta1.LokiStorageSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// Validate base storage spec
+	allErrs = append(allErrs, v.validateStorageSpec(&storage.StorageSpec, fldPath)...)
+
+	// Validate S3 configuration if enabled
+	if storage.S3 != nil && storage.S3.Enabled {
+		if storage.S3.BucketName == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("s3", "bucketName"), 
+				"bucket name is required when S3 is enabled"))
+		}
+		if storage.S3.Region == "" && storage.S3.Endpoint == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("s3", "region"), 
+				"region is required when S3 is enabled (unless custom endpoint is specified)"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateIngressSpec validates ingress configuration
+func (v *ConfigurationValidator) validateIngressSpec(ingress *observabilityv1beta1.IngressSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ingress.Host == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("host"), 
+			"host is required when ingress is enabled"))
+	}
+
+	// Validate TLS configuration
+	if ingress.TLS != nil && ingress.TLS.Enabled {
+		if ingress.TLS.SecretName == "" {
+			v.Log.Info("TLS enabled without secret name, expecting cert-manager or similar to provide certificate")
+		}
+	}
+
+	// Validate annotations
+	for k, v := range ingress.Annotations {
+		if strings.TrimSpace(k) == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("annotations"), k, 
+				"annotation key cannot be empty"))
+		}
+		if strings.TrimSpace(v) == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("annotations").Key(k), v, 
+				"annotation value cannot be empty"))
+		}
+	}
+
+	return allErrs
+}
+
+// validatePersistenceSpec validates persistence configuration
+func (v *ConfigurationValidator) validatePersistenceSpec(persistence *observabilityv1beta1.PersistenceSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if _, err := resource.ParseQuantity(persistence.Size); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), persistence.Size, 
+			fmt.Sprintf("invalid storage size: %v", err)))
+	}
+
+	return allErrs
+}
+
+// validateDataSourceSpec validates Grafana data source configuration
+func (v *ConfigurationValidator) validateDataSourceSpec(ds observabilityv1beta1.DataSourceSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ds.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "data source name is required"))
+	}
+
+	if ds.URL == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("url"), "data source URL is required"))
+	}
+
+	validTypes := map[string]bool{
+		"prometheus":    true,
+		"loki":          true,
+		"tempo":         true,
+		"elasticsearch": true,
+	}
+	if !validTypes[ds.Type] {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), ds.Type, 
+			[]string{"prometheus", "loki", "tempo", "elasticsearch"}))
+	}
+
+	validAccessModes := map[string]bool{"proxy": true, "direct": true}
+	if !validAccessModes[ds.Access] {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("access"), ds.Access, 
+			[]string{"proxy", "direct"}))
+	}
+
+	return allErrs
+}
+
+// validateRemoteWriteSpec validates Prometheus remote write configuration
+func (v *ConfigurationValidator) validateRemoteWriteSpec(rw observabilityv1beta1.RemoteWriteSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if rw.URL == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("url"), "remote write URL is required"))
+	}
+
+	// Validate basic auth if provided
+	if rw.BasicAuth != nil {
+		if rw.BasicAuth.Username == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("basicAuth", "username"), 
+				"username is required for basic auth"))
+		}
 		if rw.BasicAuth.Password == "" {
 			allErrs = append(allErrs, field.Required(fldPath.Child("basicAuth", "password"), 
 				"password is required for basic auth"))
@@ -959,3 +1919,317 @@ func getDeprecatedConfigurations() []DeprecatedConfig {
 		// Add more deprecated configurations as needed
 	}
 }
+
+// validateReloadStrategies rejects the Reload strategy for components without a reload endpoint
+func (v *ConfigurationValidator) validateReloadStrategies(platform *observabilityv1beta1.ObservabilityPlatform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	components := platform.Spec.Components
+	if components == nil {
+		return allErrs
+	}
+
+	strategies := make(map[string]string)
+	if components.Prometheus != nil {
+		strategies["prometheus"] = components.Prometheus.ReloadStrategy
+	}
+	if components.Grafana != nil {
+		strategies["grafana"] = components.Grafana.ReloadStrategy
+	}
+	if components.Loki != nil {
+		strategies["loki"] = components.Loki.ReloadStrategy
+	}
+	if components.Tempo != nil {
+		strategies["tempo"] = components.Tempo.ReloadStrategy
+	}
+
+	for _, component := range []string{"prometheus", "grafana", "loki", "tempo"} {
+		strategy, ok := strategies[component]
+		if ok && reload.Strategy(strategy) == reload.StrategyReload && !reload.Supports(component) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child(component, "reloadStrategy"),
+				strategy, []string{string(reload.StrategyRestart)}))
+		}
+	}
+
+	return allErrs
+}
+
+// validateDashboardsFromGit validates the Git source of Grafana dashboards
+func (v *ConfigurationValidator) validateDashboardsFromGit(spec *observabilityv1beta1.DashboardsFromGitSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	ssh := strings.HasPrefix(spec.Repo, "ssh://")
+	if !ssh && !strings.HasPrefix(spec.Repo, "https://") && !strings.HasPrefix(spec.Repo, "http://") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("repo"), spec.Repo, "must be an https:// or ssh:// URL"))
+	}
+	if ssh && spec.AuthSecretRef == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("authSecretRef"),
+			"SSH repositories require a Secret with ssh-privatekey and known_hosts"))
+	}
+	if spec.AuthSecretRef != nil && spec.AuthSecretRef.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("authSecretRef", "name"), "auth Secret name is required"))
+	}
+
+	if spec.PollInterval != "" {
+		interval, err := time.ParseDuration(spec.PollInterval)
+		switch {
+		case err != nil:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("pollInterval"), spec.PollInterval, err.Error()))
+		case interval < minDashboardPollInterval:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("pollInterval"), spec.PollInterval,
+				fmt.Sprintf("must be at least %s", minDashboardPollInterval)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateJsonnetDashboards validates the ConfigMaps of Jsonnet dashboard sources and libraries
+func (v *ConfigurationValidator) validateJsonnetDashboards(sources []observabilityv1beta1.JsonnetDashboardSource, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := make(map[string]bool, len(sources))
+	for i, source := range sources {
+		sourcePath := fldPath.Index(i)
+		name := source.ConfigMapRef.Name
+		switch {
+		case name == "":
+			allErrs = append(allErrs, field.Required(sourcePath.Child("configMapRef", "name"), "source ConfigMap name is required"))
+		case seen[name]:
+			allErrs = append(allErrs, field.Duplicate(sourcePath.Child("configMapRef", "name"), name))
+		}
+		seen[name] = true
+
+		for j, library := range source.Libraries {
+			libraryPath := sourcePath.Child("libraries").Index(j)
+			if library.ConfigMapRef.Name == "" {
+				allErrs = append(allErrs, field.Required(libraryPath.Child("configMapRef", "name"), "library ConfigMap name is required"))
+			}
+			for _, segment := range strings.Split(library.Path, "/") {
+				if segment == ".." {
+					allErrs = append(allErrs, field.Invalid(libraryPath.Child("path"), library.Path, "must not contain '..'"))
+					break
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateGrafanaReports validates the scheduled reports of Grafana and their
+// recipients. Reports are rendered by the image renderer.
+func (v *ConfigurationValidator) validateGrafanaReports(grafana *observabilityv1beta1.GrafanaSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(grafana.Reports) == 0 {
+		return allErrs
+	}
+
+	reportsPath := fldPath.Child("reports")
+	if !grafana.ImageRendererEnabled() {
+		allErrs = append(allErrs, field.Required(fldPath.Child("imageRenderer", "enabled"),
+			"reports are rendered by the image renderer, which must be enabled"))
+	}
+
+	seen := make(map[string]bool, len(grafana.Reports))
+	for i, report := range grafana.Reports {
+		reportPath := reportsPath.Index(i)
+		if seen[report.Name] {
+			allErrs = append(allErrs, field.Duplicate(reportPath.Child("name"), report.Name))
+		}
+		seen[report.Name] = true
+
+		if report.DashboardUID == "" {
+			allErrs = append(allErrs, field.Required(reportPath.Child("dashboardUID"), "dashboard UID is required"))
+		}
+		if _, err := grafanareports.ParseSchedule(report.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(reportPath.Child("schedule"), report.Schedule, err.Error()))
+		}
+
+		recipients := report.Recipients
+		recipientsPath := reportPath.Child("recipients")
+		if len(recipients.Emails) == 0 && len(recipients.Webhooks) == 0 {
+			allErrs = append(allErrs, field.Required(recipientsPath, "at least one email address or webhook is required"))
+		}
+		for j, email := range recipients.Emails {
+			if _, err := mail.ParseAddress(email); err != nil {
+				allErrs = append(allErrs, field.Invalid(recipientsPath.Child("emails").Index(j), email, "must be an email address"))
+			}
+		}
+		if len(recipients.Emails) > 0 {
+			switch {
+			case recipients.SMTP == nil:
+				allErrs = append(allErrs, field.Required(recipientsPath.Child("smtp"), "an SMTP server is required to mail reports"))
+			case recipients.SMTP.Host == "":
+				allErrs = append(allErrs, field.Required(recipientsPath.Child("smtp", "host"), "SMTP host is required"))
+			}
+			if recipients.SMTP != nil {
+				if _, err := mail.ParseAddress(recipients.SMTP.From); err != nil {
+					allErrs = append(allErrs, field.Invalid(recipientsPath.Child("smtp", "from"), recipients.SMTP.From, "must be an email address"))
+				}
+			}
+		}
+		for j, webhook := range recipients.Webhooks {
+			webhookPath := recipientsPath.Child("webhooks").Index(j)
+			if u, err := url.Parse(webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				allErrs = append(allErrs, field.Invalid(webhookPath.Child("url"), webhook.URL, "must be an http:// or https:// URL"))
+			}
+			if ref := webhook.BearerTokenSecretRef; ref != nil && (ref.Name == "" || ref.Key == "") {
+				allErrs = append(allErrs, field.Required(webhookPath.Child("bearerTokenSecretRef"), "token Secret name and key are required"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateUpgradeSilences validates the Alertmanager silences of component upgrades
+func (v *ConfigurationValidator) validateUpgradeSilences(spec *observabilityv1beta1.UpgradeSilencesSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.Duration != "" {
+		if duration, err := time.ParseDuration(spec.Duration); err != nil || duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("duration"), spec.Duration, "must be a positive duration"))
+		}
+	}
+	for i, matcher := range spec.Matchers {
+		matcherPath := fldPath.Child("matchers").Index(i)
+		if !prometheusLabelNameRegex.MatchString(matcher.Name) {
+			allErrs = append(allErrs, field.Invalid(matcherPath.Child("name"), matcher.Name, "must be a valid label name"))
+		}
+		if matcher.Regex {
+			if _, err := regexp.Compile(strings.ReplaceAll(matcher.Value, "{{component}}", "component")); err != nil {
+				allErrs = append(allErrs, field.Invalid(matcherPath.Child("value"), matcher.Value, err.Error()))
+			}
+		}
+	}
+	for i, component := range spec.Components {
+		switch component {
+		case "prometheus", "grafana", "loki", "tempo":
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("components").Index(i), component,
+				[]string{"prometheus", "grafana", "loki", "tempo"}))
+		}
+	}
+
+	return allErrs
+}
+
+// validateStorageMigration validates the components whose volumes are
+// migrated: they must be enabled and set the StorageClass to migrate to
+func (v *ConfigurationValidator) validateStorageMigration(platform *observabilityv1beta1.ObservabilityPlatform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	components := platform.Spec.Components
+	for _, component := range storagemigration.Requested(platform.Annotations) {
+		var storage *observabilityv1beta1.StorageSpec
+		enabled := false
+		switch component {
+		case "prometheus":
+			if components != nil && components.Prometheus != nil {
+				enabled, storage = components.Prometheus.Enabled, components.Prometheus.Storage
+			}
+		case "loki":
+			if components != nil && components.Loki != nil {
+				enabled = components.Loki.Enabled
+				if components.Loki.Storage != nil {
+					storage = &components.Loki.Storage.StorageSpec
+				}
+			}
+		case "tempo":
+			if components != nil && components.Tempo != nil {
+				enabled, storage = components.Tempo.Enabled, components.Tempo.Storage
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath, component, []string{"prometheus", "loki", "tempo"}))
+			continue
+		}
+
+		switch {
+		case !enabled:
+			allErrs = append(allErrs, field.Invalid(fldPath, component, "component is not enabled"))
+		case storage == nil || storage.StorageClassName == "":
+			allErrs = append(allErrs, field.Required(field.NewPath("spec", "components", component, "storage", "storageClassName"),
+				"the StorageClass to migrate to is required"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateUpgradeSnapshots validates the snapshots taken before upgrades:
+// only the components with persistent storage can be snapshotted
+func (v *ConfigurationValidator) validateUpgradeSnapshots(spec *observabilityv1beta1.UpgradeSnapshotsSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.Keep != nil && *spec.Keep < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("keep"), *spec.Keep, "must be at least 1"))
+	}
+	for i, component := range spec.Components {
+		switch component {
+		case "prometheus", "loki", "tempo":
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("components").Index(i), component,
+				[]string{"prometheus", "loki", "tempo"}))
+		}
+	}
+
+	return allErrs
+}
+
+// validateObservabilityMixins validates the mixin settings. Mixins following
+// a component require it, as they are versioned with it.
+func (v *ConfigurationValidator) validateObservabilityMixins(platform *observabilityv1beta1.ObservabilityPlatform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	components := platform.Spec.Components
+	enabled := map[string]bool{
+		"loki":  components != nil && components.Loki != nil && components.Loki.Enabled,
+		"tempo": components != nil && components.Tempo != nil && components.Tempo.Enabled,
+	}
+	for _, name := range mixins.Names() {
+		spec := platform.Spec.ObservabilityMixins.Get(name)
+		if !spec.IsEnabled() {
+			continue
+		}
+		mixinPath := fldPath.Child(name)
+
+		if component := mixins.Catalog[name].Component; component != "" && !enabled[component] {
+			allErrs = append(allErrs, field.Invalid(mixinPath.Child("enabled"), true,
+				fmt.Sprintf("the %s mixin requires %s to be enabled", name, component)))
+		}
+		if strings.ContainsAny(spec.Version, " \t\n") {
+			allErrs = append(allErrs, field.Invalid(mixinPath.Child("version"), spec.Version, "must be a Git branch, tag or commit"))
+		}
+		if config := strings.TrimSpace(spec.Config); config != "" &&
+			(!strings.HasPrefix(config, "{") || !strings.HasSuffix(config, "}")) {
+			allErrs = append(allErrs, field.Invalid(mixinPath.Child("config"), spec.Config, "must be a Jsonnet object"))
+		}
+		if !spec.InstallsDashboards() && !spec.InstallsRules() {
+			allErrs = append(allErrs, field.Invalid(mixinPath, name, "installs neither dashboards nor rules; disable the mixin instead"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateBasePlatformRef validates the reference to a base platform. Cycles
+// through other bases and cross-namespace sharing are checked on reconcile,
+// as the bases may not exist yet.
+func (v *ConfigurationValidator) validateBasePlatformRef(platform *observabilityv1beta1.ObservabilityPlatform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	ref := platform.Spec.BasePlatformRef
+	if ref.Name == "" {
+		return append(allErrs, field.Required(fldPath.Child("name"), "base platform name is required"))
+	}
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = platform.Namespace
+	}
+	if ref.Name == platform.Name && namespace == platform.Namespace {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), ref.Name, "a platform cannot be its own base"))
+	}
+
+	return allErrs
+}
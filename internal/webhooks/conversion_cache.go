@@ -0,0 +1,177 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+const (
+	// defaultCacheEntries bounds the number of cached conversions
+	defaultCacheEntries = 2048
+	// defaultWarmInterval is how often the platforms are converted again, so
+	// a replica's cache follows updates missed between two warmings
+	defaultWarmInterval = 2 * time.Minute
+)
+
+// conversionCache keeps the converted objects keyed by the UID and
+// resourceVersion of the source object and the target version. An object
+// with a given resourceVersion never changes, so entries never go stale; they
+// are only evicted, oldest first, when the cache is full.
+type conversionCache struct {
+	mu         sync.Mutex
+	entries    map[string][]byte
+	order      []string
+	maxEntries int
+}
+
+// newConversionCache returns an empty cache holding up to maxEntries objects
+func newConversionCache(maxEntries int) *conversionCache {
+	return &conversionCache{entries: make(map[string][]byte), maxEntries: maxEntries}
+}
+
+// conversionCacheKey returns the cache key of a conversion, false if the
+// object has no UID or resourceVersion yet
+func conversionCacheKey(raw []byte, target schema.GroupVersionKind) (string, bool) {
+	var obj struct {
+		APIVersion string `json:"apiVersion"`
+		Metadata   struct {
+			UID             string `json:"uid"`
+			ResourceVersion string `json:"resourceVersion"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(raw, &obj); err != nil || obj.Metadata.UID == "" || obj.Metadata.ResourceVersion == "" {
+		return "", false
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", obj.Metadata.UID, obj.Metadata.ResourceVersion, obj.APIVersion, target.GroupVersion()), true
+}
+
+// get returns the converted object of a key
+func (c *conversionCache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	converted, ok := c.entries[key]
+	return converted, ok
+}
+
+// put stores a converted object
+func (c *conversionCache) put(key string, converted []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; ok {
+		return
+	}
+	for len(c.order) >= c.maxEntries {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.entries[key] = converted
+	c.order = append(c.order, key)
+}
+
+// len returns the number of cached conversions
+func (c *conversionCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// CacheWarmer converts every platform ahead of the API server, on every
+// replica. The webhook server serves conversions on all replicas but only
+// the leader reconciles, so without warming the caches of the other replicas
+// stay cold and a failover, or the Service routing a request to another
+// replica, pays for the full conversion of every object listed. The API
+// server only keeps the labels and annotations of converted objects'
+// metadata, so converting the objects of the informer cache gives the
+// responses the API server's requests would get.
+type CacheWarmer struct {
+	Client  client.Client
+	Webhook *ConversionWebhook
+	// Targets are the versions objects are converted to; the storage
+	// version's objects are converted to v1alpha1 if empty
+	Targets []schema.GroupVersionKind
+	// Interval between two warmings, 2 minutes if zero
+	Interval time.Duration
+	Log      logr.Logger
+}
+
+// NeedLeaderElection returns false so every replica warms its own cache
+func (w *CacheWarmer) NeedLeaderElection() bool {
+	return false
+}
+
+// Start warms the cache until the context is cancelled
+func (w *CacheWarmer) Start(ctx context.Context) error {
+	interval := w.Interval
+	if interval == 0 {
+		interval = defaultWarmInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Warm(ctx); err != nil {
+			w.Log.Error(err, "Failed to warm the conversion cache")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Warm converts the platforms which are not cached yet
+func (w *CacheWarmer) Warm(ctx context.Context) error {
+	targets := w.Targets
+	if len(targets) == 0 {
+		targets = []schema.GroupVersionKind{{Group: "observability.io", Version: "v1alpha1", Kind: "ObservabilityPlatform"}}
+	}
+
+	platforms := &v1beta1.ObservabilityPlatformList{}
+	if err := w.Client.List(ctx, platforms); err != nil {
+		return fmt.Errorf("listing platforms: %w", err)
+	}
+
+	warmed := 0
+	for i := range platforms.Items {
+		platform := &platforms.Items[i]
+		platform.APIVersion = v1beta1.GroupVersion.String()
+		platform.Kind = "ObservabilityPlatform"
+		raw, err := json.Marshal(platform)
+		if err != nil {
+			return fmt.Errorf("encoding platform %s/%s: %w", platform.Namespace, platform.Name, err)
+		}
+		for _, target := range targets {
+			key, ok := conversionCacheKey(raw, target)
+			if !ok {
+				continue
+			}
+			if _, cached := w.Webhook.cache.get(key); cached {
+				continue
+			}
+			if _, err := w.Webhook.convertObject(ctx, raw, target); err != nil {
+				w.Log.Error(err, "Failed to convert platform", "platform", client.ObjectKeyFromObject(platform), "target", target.Version)
+				continue
+			}
+			warmed++
+		}
+	}
+
+	w.Log.V(1).Info("Conversion cache warmed", "platforms", len(platforms.Items), "converted", warmed, "cached", w.Webhook.cache.len())
+	return nil
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2025 Gunjan Jain.
+
+Licensed under the MIT License.
+*/
+
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestConversionCacheKey(t *testing.T) {
+	target := schema.GroupVersionKind{Group: "observability.io", Version: "v1alpha1", Kind: "ObservabilityPlatform"}
+
+	key, ok := conversionCacheKey([]byte(`{"apiVersion":"observability.io/v1beta1","metadata":{"uid":"abc","resourceVersion":"42"}}`), target)
+	assert.True(t, ok)
+	assert.Equal(t, "abc/42/observability.io/v1beta1/observability.io/v1alpha1", key)
+
+	// Objects which were not persisted yet are not cached
+	_, ok = conversionCacheKey([]byte(`{"apiVersion":"observability.io/v1beta1","metadata":{"name":"new"}}`), target)
+	assert.False(t, ok)
+	_, ok = conversionCacheKey([]byte(`not json`), target)
+	assert.False(t, ok)
+}
+
+func TestConversionCacheEvictsOldest(t *testing.T) {
+	cache := newConversionCache(2)
+	cache.put("a", []byte("1"))
+	cache.put("b", []byte("2"))
+	cache.put("a", []byte("ignored"))
+	cache.put("c", []byte("3"))
+
+	_, ok := cache.get("a")
+	assert.False(t, ok)
+	converted, ok := cache.get("b")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("2"), converted)
+	assert.Equal(t, 2, cache.len())
+}
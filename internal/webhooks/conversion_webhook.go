@@ -32,6 +32,8 @@ type ConversionWebhook struct {
 	scheme  *runtime.Scheme
 	decoder *conversion.Decoder
 	log     logr.Logger
+	// cache holds the converted objects, warmed by the CacheWarmer
+	cache *conversionCache
 }
 
 // NewConversionWebhook creates a new conversion webhook
@@ -55,6 +57,7 @@ func NewConversionWebhook(mgr manager.Manager, log logr.Logger) (*ConversionWebh
 		scheme:  scheme,
 		decoder: decoder,
 		log:     log,
+		cache:   newConversionCache(defaultCacheEntries),
 	}, nil
 }
 
@@ -137,8 +140,24 @@ func (w *ConversionWebhook) handleConversionReview(ctx context.Context, review *
 	return review
 }
 
-// convertObject converts a single object to the target version
+// convertObject converts a single object to the target version, from the
+// cache if it was converted before
 func (w *ConversionWebhook) convertObject(ctx context.Context, raw []byte, targetGVK schema.GroupVersionKind) ([]byte, error) {
+	key, cacheable := conversionCacheKey(raw, targetGVK)
+	if cacheable {
+		if converted, ok := w.cache.get(key); ok {
+			return converted, nil
+		}
+	}
+	converted, err := w.convert(ctx, raw, targetGVK)
+	if err == nil && cacheable {
+		w.cache.put(key, converted)
+	}
+	return converted, err
+}
+
+// convert converts a single object to the target version
+func (w *ConversionWebhook) convert(ctx context.Context, raw []byte, targetGVK schema.GroupVersionKind) ([]byte, error) {
 	// Decode the source object
 	srcObj, srcGVK, err := w.decoder.Decode(raw)
 	if err != nil {
@@ -285,6 +304,15 @@ func SetupWebhookWithManager(mgr manager.Manager) error {
 	// Register the webhook handler
 	mgr.GetWebhookServer().Register("/convert", webhook)
 	
+	// Warm the conversion cache on every replica, not only the leader
+	if err := mgr.Add(&CacheWarmer{
+		Client:  mgr.GetClient(),
+		Webhook: webhook,
+		Log:     log.WithName("cache-warmer"),
+	}); err != nil {
+		return fmt.Errorf("adding conversion cache warmer: %w", err)
+	}
+	
 	log.Info("conversion webhook registered")
 	return nil
 }
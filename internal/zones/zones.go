@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package zones implements zone-aware replication for ring-based components
+// such as the Loki and Tempo ingesters: one StatefulSet per zone, pinned to
+// the zone's nodes and told its availability zone through the environment.
+package zones
+
+import (
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultTopologyKey is the well-known node label holding the zone
+	DefaultTopologyKey = "topology.kubernetes.io/zone"
+
+	// ZoneLabel is set on zonal StatefulSets and their pods
+	ZoneLabel = "observability.io/zone"
+
+	// EnvVar holds the availability zone of an instance; configurations
+	// reference it as ${AVAILABILITY_ZONE} with environment expansion enabled
+	EnvVar = "AVAILABILITY_ZONE"
+)
+
+// Name returns the name of the StatefulSet of a zone
+func Name(base, zone string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(zone) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+	return base + "-" + strings.Trim(b.String(), "-")
+}
+
+// ForZone derives the StatefulSet of a zone from the base StatefulSet. The
+// zone label is added to the selector and pod labels, pods are pinned to the
+// zone with a node selector and every container gets the zone in EnvVar.
+// The base StatefulSet is not modified.
+func ForZone(base *appsv1.StatefulSet, topologyKey, zone string, replicas int32) *appsv1.StatefulSet {
+	sts := base.DeepCopy()
+	sts.Name = Name(base.Name, zone)
+	sts.ResourceVersion = ""
+	sts.UID = ""
+
+	sts.Labels = withLabel(sts.Labels, ZoneLabel, zone)
+	sts.Spec.Replicas = &replicas
+	if sts.Spec.Selector != nil {
+		sts.Spec.Selector.MatchLabels = withLabel(sts.Spec.Selector.MatchLabels, ZoneLabel, zone)
+	}
+	sts.Spec.Template.Labels = withLabel(sts.Spec.Template.Labels, ZoneLabel, zone)
+
+	podSpec := &sts.Spec.Template.Spec
+	podSpec.NodeSelector = withLabel(podSpec.NodeSelector, topologyKey, zone)
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Env = append(podSpec.Containers[i].Env, corev1.EnvVar{Name: EnvVar, Value: zone})
+	}
+
+	return sts
+}
+
+// Available returns the zones of the nodes that can run pods, with the
+// number of such nodes per zone
+func Available(nodes []corev1.Node, topologyKey string) map[string]int {
+	available := make(map[string]int)
+	for _, node := range nodes {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		if zone, ok := node.Labels[topologyKey]; ok && zone != "" {
+			available[zone]++
+		}
+	}
+	return available
+}
+
+// Missing returns the requested zones without schedulable nodes, sorted
+func Missing(available map[string]int, zones []string) []string {
+	var missing []string
+	for _, zone := range zones {
+		if available[zone] == 0 {
+			missing = append(missing, zone)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+func withLabel(labels map[string]string, key, value string) map[string]string {
+	out := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		out[k] = v
+	}
+	out[key] = value
+	return out
+}
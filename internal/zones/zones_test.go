@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package zones
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestName(t *testing.T) {
+	assert.Equal(t, "loki-prod-us-east-1a", Name("loki-prod", "us-east-1a"))
+	assert.Equal(t, "tempo-prod-europe-west1-b", Name("tempo-prod", "Europe_West1.b"))
+}
+
+func TestForZone(t *testing.T) {
+	selector := map[string]string{"app.kubernetes.io/name": "loki"}
+	base := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "loki-prod", Namespace: "monitoring", Labels: selector, ResourceVersion: "7"},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: selector},
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"pool": "observability"},
+					Containers:   []corev1.Container{{Name: "loki"}},
+				},
+			},
+		},
+	}
+
+	sts := ForZone(base, DefaultTopologyKey, "us-east-1a", 2)
+
+	assert.Equal(t, "loki-prod-us-east-1a", sts.Name)
+	assert.Empty(t, sts.ResourceVersion)
+	require.NotNil(t, sts.Spec.Replicas)
+	assert.Equal(t, int32(2), *sts.Spec.Replicas)
+	assert.Equal(t, "us-east-1a", sts.Spec.Selector.MatchLabels[ZoneLabel])
+	assert.Equal(t, "us-east-1a", sts.Spec.Template.Labels[ZoneLabel])
+	assert.Equal(t, map[string]string{"pool": "observability", DefaultTopologyKey: "us-east-1a"}, sts.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, []corev1.EnvVar{{Name: EnvVar, Value: "us-east-1a"}}, sts.Spec.Template.Spec.Containers[0].Env)
+
+	// The base is left untouched
+	assert.NotContains(t, base.Spec.Selector.MatchLabels, ZoneLabel)
+	assert.Len(t, base.Spec.Template.Spec.NodeSelector, 1)
+	assert.Empty(t, base.Spec.Template.Spec.Containers[0].Env)
+}
+
+func TestAvailableAndMissing(t *testing.T) {
+	node := func(zone string, unschedulable bool) corev1.Node {
+		n := corev1.Node{Spec: corev1.NodeSpec{Unschedulable: unschedulable}}
+		if zone != "" {
+			n.Labels = map[string]string{DefaultTopologyKey: zone}
+		}
+		return n
+	}
+
+	available := Available([]corev1.Node{
+		node("a", false), node("a", false), node("b", false), node("c", true), node("", false),
+	}, DefaultTopologyKey)
+
+	assert.Equal(t, map[string]int{"a": 2, "b": 1}, available)
+	assert.Equal(t, []string{"c", "d"}, Missing(available, []string{"d", "a", "b", "c"}))
+	assert.Empty(t, Missing(available, []string{"a", "b"}))
+}
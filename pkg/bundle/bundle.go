@@ -0,0 +1,333 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+// Package bundle moves ObservabilityPlatforms between clusters. A bundle
+// holds the platforms without their status and cluster-specific metadata,
+// keeping the data preserved by conversions in their annotations, and lists
+// the Secrets and ConfigMaps they reference. Referenced objects are never
+// copied: their contents stay in the source cluster, and they are created in
+// the target cluster by whatever manages them there. Importing a bundle can
+// move the platforms, and the namespaces they refer to, to other namespaces.
+package bundle
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// APIVersion and Kind identify a bundle file
+	APIVersion = "migrate.observability.io/v1"
+	Kind       = "PlatformBundle"
+
+	// Kinds of the referenced objects
+	SecretKind    = "Secret"
+	ConfigMapKind = "ConfigMap"
+
+	lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// Bundle is a set of platforms exported from a cluster
+type Bundle struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// ExportedAt is when the platforms were exported
+	ExportedAt metav1.Time `json:"exportedAt"`
+	// Source names the cluster the platforms were exported from
+	Source    string                      `json:"source,omitempty"`
+	Platforms []unstructured.Unstructured `json:"platforms"`
+	// References are the Secrets and ConfigMaps the platforms need in the
+	// target cluster
+	References []Reference `json:"references,omitempty"`
+}
+
+// Reference is a Secret or ConfigMap referenced by platforms
+type Reference struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Keys are the keys read from it, empty when the whole object is used
+	Keys []string `json:"keys,omitempty"`
+	// Optional is set when every platform referencing it can do without it
+	Optional bool `json:"optional,omitempty"`
+	// UsedBy are the platforms referencing it, as namespace/name
+	UsedBy []string `json:"usedBy"`
+}
+
+func (r Reference) String() string {
+	return fmt.Sprintf("%s %s/%s", r.Kind, r.Namespace, r.Name)
+}
+
+// New returns a bundle of platforms, stripped of their status and
+// cluster-specific metadata, with the references they hold
+func New(source string, platforms []unstructured.Unstructured, now time.Time) *Bundle {
+	b := &Bundle{
+		APIVersion: APIVersion,
+		Kind:       Kind,
+		ExportedAt: metav1.NewTime(now),
+		Source:     source,
+		Platforms:  make([]unstructured.Unstructured, 0, len(platforms)),
+	}
+	for i := range platforms {
+		b.Platforms = append(b.Platforms, *Strip(&platforms[i]))
+	}
+	sort.Slice(b.Platforms, func(i, j int) bool {
+		if b.Platforms[i].GetNamespace() != b.Platforms[j].GetNamespace() {
+			return b.Platforms[i].GetNamespace() < b.Platforms[j].GetNamespace()
+		}
+		return b.Platforms[i].GetName() < b.Platforms[j].GetName()
+	})
+	b.References = References(b.Platforms)
+	return b
+}
+
+// Strip returns a copy of a platform without its status and the metadata
+// the source cluster assigned. Labels and annotations are kept, the
+// preserved conversion data included.
+func Strip(platform *unstructured.Unstructured) *unstructured.Unstructured {
+	stripped := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": platform.GetAPIVersion(),
+		"kind":       platform.GetKind(),
+	}}
+	stripped.SetName(platform.GetName())
+	stripped.SetNamespace(platform.GetNamespace())
+	stripped.SetLabels(platform.GetLabels())
+
+	annotations := platform.GetAnnotations()
+	delete(annotations, lastAppliedAnnotation)
+	if len(annotations) > 0 {
+		stripped.SetAnnotations(annotations)
+	}
+
+	if spec, ok := platform.Object["spec"]; ok {
+		stripped.Object["spec"] = runtime.DeepCopyJSONValue(spec)
+	}
+	return stripped
+}
+
+// References returns the Secrets and ConfigMaps referenced by the specs of
+// platforms. References are recognized by their shape: a name and key, with
+// an optional namespace, are a key of a Secret unless the field names a
+// ConfigMap, and a name alone is an object if the field names a Secret or a
+// ConfigMap. A secretName field names a Secret.
+func References(platforms []unstructured.Unstructured) []Reference {
+	found := map[string]*Reference{}
+	optional := map[string]bool{}
+	for i := range platforms {
+		platform := &platforms[i]
+		user := platform.GetNamespace() + "/" + platform.GetName()
+		collect(platform.Object["spec"], "", func(ref Reference, isOptional bool) {
+			if ref.Namespace == "" {
+				ref.Namespace = platform.GetNamespace()
+			}
+			id := ref.String()
+			existing, ok := found[id]
+			if !ok {
+				existing = &Reference{Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name}
+				found[id] = existing
+				optional[id] = true
+			}
+			existing.Keys = appendUnique(existing.Keys, ref.Keys...)
+			existing.UsedBy = appendUnique(existing.UsedBy, user)
+			optional[id] = optional[id] && isOptional
+		})
+	}
+
+	refs := make([]Reference, 0, len(found))
+	for id, ref := range found {
+		ref.Optional = optional[id]
+		sort.Strings(ref.Keys)
+		sort.Strings(ref.UsedBy)
+		refs = append(refs, *ref)
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].String() < refs[j].String() })
+	return refs
+}
+
+// referenceFields are the fields of a reference
+var referenceFields = map[string]bool{"name": true, "key": true, "namespace": true, "optional": true}
+
+// collect calls found for every reference of a spec value held by field
+func collect(value interface{}, field string, found func(Reference, bool)) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, isOptional, ok := reference(field, v); ok {
+			found(ref, isOptional)
+			return
+		}
+		for k, child := range v {
+			collect(child, k, found)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collect(child, field, found)
+		}
+	case string:
+		if field == "secretName" && v != "" {
+			found(Reference{Kind: SecretKind, Name: v}, false)
+		}
+	}
+}
+
+// reference returns the reference a map held by field is, if it is one
+func reference(field string, m map[string]interface{}) (Reference, bool, bool) {
+	name, _ := m["name"].(string)
+	if name == "" {
+		return Reference{}, false, false
+	}
+	for k := range m {
+		if !referenceFields[k] {
+			return Reference{}, false, false
+		}
+	}
+
+	lower := strings.ToLower(field)
+	key, hasKey := m["key"].(string)
+	var kind string
+	switch {
+	case strings.Contains(lower, "configmap") || field == "catalogRef":
+		kind = ConfigMapKind
+	case strings.Contains(lower, "secret") || hasKey:
+		kind = SecretKind
+	default:
+		return Reference{}, false, false
+	}
+
+	ref := Reference{Kind: kind, Name: name}
+	ref.Namespace, _ = m["namespace"].(string)
+	if hasKey && key != "" {
+		ref.Keys = []string{key}
+	}
+	isOptional, _ := m["optional"].(bool)
+	return ref, isOptional, true
+}
+
+func appendUnique(values []string, add ...string) []string {
+	for _, a := range add {
+		found := false
+		for _, v := range values {
+			if v == a {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, a)
+		}
+	}
+	return values
+}
+
+// NamespaceMap maps the namespaces of the source cluster to those of the
+// target cluster. Unmapped namespaces are kept.
+type NamespaceMap map[string]string
+
+// ParseNamespaceMap parses source=target mappings
+func ParseNamespaceMap(values []string) (NamespaceMap, error) {
+	m := NamespaceMap{}
+	for _, value := range values {
+		parts := strings.Split(value, "=")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("%q is not source=target", value)
+		}
+		if target, ok := m[parts[0]]; ok && target != parts[1] {
+			return nil, fmt.Errorf("namespace %s is mapped to both %s and %s", parts[0], target, parts[1])
+		}
+		m[parts[0]] = parts[1]
+	}
+	return m, nil
+}
+
+// Namespace returns the target namespace of a source namespace
+func (m NamespaceMap) Namespace(namespace string) string {
+	if target, ok := m[namespace]; ok {
+		return target
+	}
+	return namespace
+}
+
+// Remap moves the platforms and references of the bundle to the target
+// namespaces. Every namespace and namespaces field of the specs is mapped
+// too: references to ConfigMaps and base platforms, and the namespaces
+// targets are discovered in.
+func (b *Bundle) Remap(m NamespaceMap) {
+	if len(m) == 0 {
+		return
+	}
+	for i := range b.Platforms {
+		platform := &b.Platforms[i]
+		platform.SetNamespace(m.Namespace(platform.GetNamespace()))
+		if spec, ok := platform.Object["spec"]; ok {
+			platform.Object["spec"] = remap(spec, "", m)
+		}
+	}
+	for i := range b.References {
+		ref := &b.References[i]
+		ref.Namespace = m.Namespace(ref.Namespace)
+		for j, user := range ref.UsedBy {
+			if parts := strings.SplitN(user, "/", 2); len(parts) == 2 {
+				ref.UsedBy[j] = m.Namespace(parts[0]) + "/" + parts[1]
+			}
+		}
+	}
+}
+
+// remap maps the namespaces of a spec value held by field
+func remap(value interface{}, field string, m NamespaceMap) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			v[k] = remap(child, k, m)
+		}
+	case []interface{}:
+		for i, child := range v {
+			if s, ok := child.(string); ok && field == "namespaces" {
+				v[i] = m.Namespace(s)
+				continue
+			}
+			v[i] = remap(child, field, m)
+		}
+	case string:
+		if field == "namespace" && v != "" {
+			return m.Namespace(v)
+		}
+	}
+	return value
+}
+
+// Write writes the bundle as YAML
+func (b *Bundle) Write(w io.Writer) error {
+	data, err := yaml.Marshal(b)
+	if err != nil {
+		return fmt.Errorf("failed to marshal bundle: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Read reads a bundle written by Write
+func Read(r io.Reader) (*Bundle, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	b := &Bundle{}
+	if err := yaml.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("failed to read bundle: %w", err)
+	}
+	if b.APIVersion != APIVersion || b.Kind != Kind {
+		return nil, fmt.Errorf("not a platform bundle: apiVersion %q, kind %q", b.APIVersion, b.Kind)
+	}
+	return b, nil
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package bundle
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func platform(namespace, name string, spec map[string]interface{}) unstructured.Unstructured {
+	u := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "observability.io/v1beta1",
+		"kind":       "ObservabilityPlatform",
+		"spec":       spec,
+		"status":     map[string]interface{}{"phase": "Ready"},
+	}}
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
+
+func monitoringPlatform() unstructured.Unstructured {
+	p := platform("monitoring", "prod", map[string]interface{}{
+		"base": map[string]interface{}{"name": "defaults", "namespace": "shared"},
+		"components": map[string]interface{}{
+			"prometheus": map[string]interface{}{
+				"enabled": true,
+				"externalLabelsFrom": map[string]interface{}{
+					"configMapKeyRef": map[string]interface{}{"namespace": "shared", "name": "cluster-settings", "key": "labels.yaml"},
+				},
+				"thanos": map[string]interface{}{
+					"objectStorageConfig": map[string]interface{}{"name": "thanos-objstore", "key": "objstore.yml"},
+				},
+			},
+			"grafana": map[string]interface{}{
+				"adminPasswordSecret": map[string]interface{}{"name": "grafana-admin", "key": "password"},
+				"ingress":             map[string]interface{}{"tls": map[string]interface{}{"secretName": "grafana-tls"}},
+				"datasources": []interface{}{
+					map[string]interface{}{"secureJsonDataSecret": map[string]interface{}{"name": "datasource-keys"}},
+				},
+			},
+			"alloy": map[string]interface{}{"namespaces": []interface{}{"monitoring", "apps"}},
+		},
+	})
+	p.SetUID("3f1c")
+	p.SetResourceVersion("4711")
+	p.SetGeneration(3)
+	p.SetFinalizers([]string{"observabilityplatform.observability.io/finalizer"})
+	p.SetLabels(map[string]string{"team": "sre"})
+	p.SetAnnotations(map[string]string{
+		"observability.io/conversion-data":                 `{"unknownFields":{}}`,
+		"kubectl.kubernetes.io/last-applied-configuration": "{}",
+	})
+	return p
+}
+
+func TestStrip(t *testing.T) {
+	p := monitoringPlatform()
+	stripped := Strip(&p)
+
+	assert.Equal(t, "prod", stripped.GetName())
+	assert.Equal(t, "monitoring", stripped.GetNamespace())
+	assert.Empty(t, stripped.GetUID())
+	assert.Empty(t, stripped.GetResourceVersion())
+	assert.Zero(t, stripped.GetGeneration())
+	assert.Empty(t, stripped.GetFinalizers())
+	assert.NotContains(t, stripped.Object, "status")
+	assert.Equal(t, map[string]string{"team": "sre"}, stripped.GetLabels())
+	assert.Equal(t, map[string]string{"observability.io/conversion-data": `{"unknownFields":{}}`}, stripped.GetAnnotations())
+
+	// The spec is copied
+	require.NoError(t, unstructured.SetNestedField(stripped.Object, false, "spec", "components", "prometheus", "enabled"))
+	enabled, _, _ := unstructured.NestedBool(p.Object, "spec", "components", "prometheus", "enabled")
+	assert.True(t, enabled)
+}
+
+func TestReferences(t *testing.T) {
+	other := platform("monitoring", "staging", map[string]interface{}{
+		"components": map[string]interface{}{
+			"grafana": map[string]interface{}{
+				"adminPasswordSecret": map[string]interface{}{"name": "grafana-admin", "key": "user"},
+			},
+			"prometheus": map[string]interface{}{
+				"additionalScrapeConfigsFrom": map[string]interface{}{
+					"configMapKeyRef": map[string]interface{}{"name": "scrape-configs", "key": "jobs.yaml", "optional": true},
+				},
+			},
+		},
+	})
+
+	refs := References([]unstructured.Unstructured{monitoringPlatform(), other})
+
+	assert.Equal(t, []Reference{
+		{Kind: ConfigMapKind, Namespace: "monitoring", Name: "scrape-configs", Keys: []string{"jobs.yaml"}, Optional: true, UsedBy: []string{"monitoring/staging"}},
+		{Kind: ConfigMapKind, Namespace: "shared", Name: "cluster-settings", Keys: []string{"labels.yaml"}, UsedBy: []string{"monitoring/prod"}},
+		{Kind: SecretKind, Namespace: "monitoring", Name: "datasource-keys", UsedBy: []string{"monitoring/prod"}},
+		{Kind: SecretKind, Namespace: "monitoring", Name: "grafana-admin", Keys: []string{"password", "user"}, UsedBy: []string{"monitoring/prod", "monitoring/staging"}},
+		{Kind: SecretKind, Namespace: "monitoring", Name: "grafana-tls", UsedBy: []string{"monitoring/prod"}},
+		{Kind: SecretKind, Namespace: "monitoring", Name: "thanos-objstore", Keys: []string{"objstore.yml"}, UsedBy: []string{"monitoring/prod"}},
+	}, refs)
+}
+
+func TestReferencesIgnoresOtherObjects(t *testing.T) {
+	p := platform("monitoring", "prod", map[string]interface{}{
+		"base":           map[string]interface{}{"name": "defaults"},
+		"targetPlatform": map[string]interface{}{"name": "prod"},
+		"secretRef":      map[string]interface{}{"name": "creds", "url": "https://git.example.com"},
+	})
+
+	assert.Empty(t, References([]unstructured.Unstructured{p}))
+}
+
+func TestParseNamespaceMap(t *testing.T) {
+	m, err := ParseNamespaceMap([]string{"monitoring=observability", "shared=platform-shared"})
+	require.NoError(t, err)
+	assert.Equal(t, "observability", m.Namespace("monitoring"))
+	assert.Equal(t, "apps", m.Namespace("apps"))
+
+	for _, invalid := range [][]string{{"monitoring"}, {"=observability"}, {"monitoring="}, {"a=b=c"}, {"a=b", "a=c"}} {
+		_, err := ParseNamespaceMap(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestRemap(t *testing.T) {
+	b := New("prod-eu", []unstructured.Unstructured{monitoringPlatform()}, time.Now())
+	b.Remap(NamespaceMap{"monitoring": "observability", "shared": "platform-shared"})
+
+	p := b.Platforms[0]
+	assert.Equal(t, "observability", p.GetNamespace())
+
+	baseNamespace, _, _ := unstructured.NestedString(p.Object, "spec", "base", "namespace")
+	assert.Equal(t, "platform-shared", baseNamespace)
+	refNamespace, _, _ := unstructured.NestedString(p.Object, "spec", "components", "prometheus", "externalLabelsFrom", "configMapKeyRef", "namespace")
+	assert.Equal(t, "platform-shared", refNamespace)
+	namespaces, _, _ := unstructured.NestedStringSlice(p.Object, "spec", "components", "alloy", "namespaces")
+	assert.Equal(t, []string{"observability", "apps"}, namespaces)
+
+	for _, ref := range b.References {
+		assert.Contains(t, []string{"observability", "platform-shared"}, ref.Namespace, ref.String())
+		assert.Equal(t, []string{"observability/prod"}, ref.UsedBy, ref.String())
+	}
+	// References are found at the same place after the move
+	assert.Equal(t, b.References, References(b.Platforms))
+}
+
+func TestWriteRead(t *testing.T) {
+	exportedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	b := New("prod-eu", []unstructured.Unstructured{
+		platform("monitoring", "staging", map[string]interface{}{"paused": true}),
+		monitoringPlatform(),
+	}, exportedAt)
+
+	var buf bytes.Buffer
+	require.NoError(t, b.Write(&buf))
+	assert.Contains(t, buf.String(), "kind: PlatformBundle")
+	assert.NotContains(t, buf.String(), "last-applied-configuration")
+
+	read, err := Read(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, "prod-eu", read.Source)
+	assert.True(t, read.ExportedAt.Equal(&b.ExportedAt))
+	require.Len(t, read.Platforms, 2)
+	assert.Equal(t, "prod", read.Platforms[0].GetName())
+	assert.Equal(t, "staging", read.Platforms[1].GetName())
+	assert.Equal(t, "ObservabilityPlatform", read.Platforms[0].GetKind())
+	assert.Equal(t, b.References, read.References)
+}
+
+func TestReadRejectsOtherFiles(t *testing.T) {
+	_, err := Read(strings.NewReader("apiVersion: observability.io/v1beta1\nkind: ObservabilityPlatform\n"))
+	assert.ErrorContains(t, err, "not a platform bundle")
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+// Package catalog lists and instantiates the example platforms of the
+// PlatformCatalogs of a cluster. The operator ships a builtin catalog of
+// curated examples, which is also used when the cluster has none.
+package catalog
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// BuiltinCatalog is the name of the catalog of the examples shipped with
+// the operator
+const BuiltinCatalog = "builtin"
+
+// EntryLabel is set on instantiated platforms to <catalog>.<entry>
+const EntryLabel = "observability.io/catalog-entry"
+
+// GVK is the kind of the catalogs
+var GVK = schema.GroupVersionKind{Group: "observability.io", Version: "v1beta1", Kind: "PlatformCatalog"}
+
+//go:embed examples/*.yaml
+var examples embed.FS
+
+// Entry is an example platform of a catalog
+type Entry struct {
+	// Catalog is the name of the PlatformCatalog of the entry
+	Catalog     string   `json:"catalog"`
+	Name        string   `json:"name"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	// Spec is the ObservabilityPlatform spec of the example
+	Spec map[string]interface{} `json:"spec"`
+}
+
+// Ref returns the reference of the entry, <catalog>/<entry>
+func (e *Entry) Ref() string {
+	return e.Catalog + "/" + e.Name
+}
+
+// Builtin returns the examples shipped with the operator
+func Builtin() ([]Entry, error) {
+	files, err := fs.Glob(examples, "examples/*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(files))
+	for _, file := range files {
+		data, err := examples.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		entry := Entry{}
+		if err := yaml.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		entry.Catalog = BuiltinCatalog
+		entries = append(entries, entry)
+	}
+	sortEntries(entries)
+	return entries, nil
+}
+
+// BuiltinObject returns the builtin PlatformCatalog
+func BuiltinObject() (*unstructured.Unstructured, error) {
+	entries, err := Builtin()
+	if err != nil {
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(GVK)
+	obj.SetName(BuiltinCatalog)
+	obj.SetLabels(map[string]string{"app.kubernetes.io/managed-by": "gunj-operator"})
+	if err := setEntries(obj, entries); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// EnsureBuiltin creates the builtin PlatformCatalog, or overwrites its
+// entries with the shipped ones
+func EnsureBuiltin(ctx context.Context, c client.Client) error {
+	desired, err := BuiltinObject()
+	if err != nil {
+		return err
+	}
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(GVK)
+	if err := c.Get(ctx, client.ObjectKey{Name: BuiltinCatalog}, current); err != nil {
+		if apierrors.IsNotFound(err) {
+			return c.Create(ctx, desired)
+		}
+		return err
+	}
+	current.Object["spec"] = desired.Object["spec"]
+	current.SetLabels(desired.GetLabels())
+	return c.Update(ctx, current)
+}
+
+// List returns the entries of the PlatformCatalogs of the cluster, sorted by
+// catalog and name. The builtin examples are returned when the cluster has
+// no catalogs, e.g. before the operator installed its own or without the
+// PlatformCatalog CRD.
+func List(ctx context.Context, c client.Reader) ([]Entry, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(GVK.GroupVersion().WithKind(GVK.Kind + "List"))
+	if err := c.List(ctx, list); err != nil {
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+			return Builtin()
+		}
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return Builtin()
+	}
+
+	var entries []Entry
+	for i := range list.Items {
+		catalogEntries, err := Entries(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, catalogEntries...)
+	}
+	sortEntries(entries)
+	return entries, nil
+}
+
+// Entries returns the entries of a PlatformCatalog
+func Entries(obj *unstructured.Unstructured) ([]Entry, error) {
+	raw, _, err := unstructured.NestedSlice(obj.Object, "spec", "entries")
+	if err != nil {
+		return nil, fmt.Errorf("catalog %s: %w", obj.GetName(), err)
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("catalog %s: %w", obj.GetName(), err)
+	}
+	for i := range entries {
+		entries[i].Catalog = obj.GetName()
+	}
+	return entries, nil
+}
+
+// Find returns the entry of a reference, <catalog>/<entry> or an entry name
+// which is unique across the catalogs
+func Find(entries []Entry, ref string) (*Entry, error) {
+	catalog, name, qualified := strings.Cut(ref, "/")
+	if !qualified {
+		catalog, name = "", ref
+	}
+
+	var found []*Entry
+	for i := range entries {
+		if entries[i].Name == name && (catalog == "" || entries[i].Catalog == catalog) {
+			found = append(found, &entries[i])
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("catalog entry %q not found", ref)
+	case 1:
+		return found[0], nil
+	}
+	refs := make([]string, 0, len(found))
+	for _, entry := range found {
+		refs = append(refs, entry.Ref())
+	}
+	return nil, fmt.Errorf("catalog entry %q is ambiguous, use one of %s", ref, strings.Join(refs, ", "))
+}
+
+// Instantiate returns a platform with the spec of the entry
+func Instantiate(entry *Entry, name, namespace string) (*unstructured.Unstructured, error) {
+	spec, err := deepCopyJSON(entry.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("catalog entry %s: %w", entry.Ref(), err)
+	}
+	platform := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	platform.SetAPIVersion("observability.io/v1beta1")
+	platform.SetKind("ObservabilityPlatform")
+	platform.SetName(name)
+	platform.SetNamespace(namespace)
+	platform.SetLabels(map[string]string{EntryLabel: entry.Catalog + "." + entry.Name})
+	return platform, nil
+}
+
+// setEntries sets the entries of a PlatformCatalog
+func setEntries(obj *unstructured.Unstructured, entries []Entry) error {
+	items := make([]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		item := map[string]interface{}{
+			"name": entry.Name,
+			"spec": entry.Spec,
+		}
+		if entry.Title != "" {
+			item["title"] = entry.Title
+		}
+		if entry.Description != "" {
+			item["description"] = entry.Description
+		}
+		if len(entry.Tags) > 0 {
+			tags := make([]interface{}, 0, len(entry.Tags))
+			for _, tag := range entry.Tags {
+				tags = append(tags, tag)
+			}
+			item["tags"] = tags
+		}
+		items = append(items, item)
+	}
+	spec, err := deepCopyJSON(map[string]interface{}{"entries": items})
+	if err != nil {
+		return err
+	}
+	obj.Object["spec"] = spec
+	return nil
+}
+
+// deepCopyJSON copies a JSON object, with its integers as int64 as
+// unstructured objects expect
+func deepCopyJSON(value map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	copied := map[string]interface{}{}
+	if err := utiljson.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
+
+func sortEntries(entries []Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Catalog != entries[j].Catalog {
+			return entries[i].Catalog < entries[j].Catalog
+		}
+		return entries[i].Name < entries[j].Name
+	})
+}
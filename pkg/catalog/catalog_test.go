@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(GVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(GVK.GroupVersion().WithKind(GVK.Kind+"List"), &unstructured.UnstructuredList{})
+	return scheme
+}
+
+func TestBuiltin(t *testing.T) {
+	entries, err := Builtin()
+	require.NoError(t, err)
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+		assert.Equal(t, BuiltinCatalog, entry.Catalog)
+		assert.NotEmpty(t, entry.Title, entry.Name)
+		assert.NotEmpty(t, entry.Description, entry.Name)
+		assert.Contains(t, entry.Spec, "components", entry.Name)
+	}
+	assert.Equal(t, []string{"ha-production", "logging-heavy", "minimal", "tracing-heavy"}, names)
+}
+
+func TestInstantiate(t *testing.T) {
+	entries, err := Builtin()
+	require.NoError(t, err)
+	entry, err := Find(entries, "builtin/minimal")
+	require.NoError(t, err)
+
+	platform, err := Instantiate(entry, "dev", "monitoring")
+	require.NoError(t, err)
+	assert.Equal(t, "ObservabilityPlatform", platform.GetKind())
+	assert.Equal(t, "monitoring", platform.GetNamespace())
+	assert.Equal(t, "builtin.minimal", platform.GetLabels()[EntryLabel])
+	replicas, found, err := unstructured.NestedInt64(platform.Object, "spec", "components", "prometheus", "replicas")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, int64(1), replicas)
+
+	// The entry is not shared with the platform
+	require.NoError(t, unstructured.SetNestedField(platform.Object, int64(5), "spec", "components", "prometheus", "replicas"))
+	assert.EqualValues(t, 1, entry.Spec["components"].(map[string]interface{})["prometheus"].(map[string]interface{})["replicas"])
+}
+
+func TestFind(t *testing.T) {
+	entries := []Entry{
+		{Catalog: "builtin", Name: "minimal"},
+		{Catalog: "builtin", Name: "ha-production"},
+		{Catalog: "team-a", Name: "minimal"},
+	}
+
+	entry, err := Find(entries, "ha-production")
+	require.NoError(t, err)
+	assert.Equal(t, "builtin/ha-production", entry.Ref())
+
+	entry, err = Find(entries, "team-a/minimal")
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", entry.Catalog)
+
+	_, err = Find(entries, "minimal")
+	assert.ErrorContains(t, err, "ambiguous, use one of builtin/minimal, team-a/minimal")
+
+	_, err = Find(entries, "tracing-heavy")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestEnsureBuiltinAndList(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+
+	require.NoError(t, EnsureBuiltin(ctx, c))
+
+	// Edits to the builtin catalog are overwritten
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(GVK)
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: BuiltinCatalog}, current))
+	require.NoError(t, unstructured.SetNestedSlice(current.Object, []interface{}{}, "spec", "entries"))
+	require.NoError(t, c.Update(ctx, current))
+	require.NoError(t, EnsureBuiltin(ctx, c))
+
+	custom := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"entries": []interface{}{
+				map[string]interface{}{
+					"name":  "team-default",
+					"title": "Team default",
+					"spec": map[string]interface{}{
+						"components": map[string]interface{}{"prometheus": map[string]interface{}{"enabled": true}},
+					},
+				},
+			},
+		},
+	}}
+	custom.SetGroupVersionKind(GVK)
+	custom.SetName("team-a")
+	require.NoError(t, c.Create(ctx, custom))
+
+	entries, err := List(ctx, c)
+	require.NoError(t, err)
+	var refs []string
+	for _, entry := range entries {
+		refs = append(refs, entry.Ref())
+	}
+	assert.Equal(t, []string{
+		"builtin/ha-production", "builtin/logging-heavy", "builtin/minimal", "builtin/tracing-heavy",
+		"team-a/team-default",
+	}, refs)
+}
+
+func TestListWithoutCRD(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	entries, err := List(context.Background(), c)
+	require.NoError(t, err)
+	assert.Len(t, entries, 4)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package cliout
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/migration"
+)
+
+// Checkpointer runs migrations which are checkpointed when the tool is
+// interrupted, and resumed by its next run. It is implemented by
+// migration.MigrationManager.
+type Checkpointer interface {
+	Drain(ctx context.Context) error
+	Checkpoint(ctx context.Context) error
+	Resume(ctx context.Context) ([]*migration.MigrationTask, error)
+	GetMigrationStatus(taskID string) (*migration.MigrationTask, error)
+}
+
+// DrainOnSignal stops the migrations when the tool is interrupted: the
+// running migrations get drainTimeout to finish, and the unfinished ones are
+// checkpointed for the next run to resume. The returned function stops
+// listening for the signals.
+func DrainOnSignal(manager Checkpointer, drainTimeout time.Duration) func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-signals:
+		case <-done:
+			return
+		}
+		fmt.Fprintf(os.Stderr, "\nInterrupted, waiting up to %s for the running migrations\n", drainTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+		err := manager.Drain(ctx)
+		cancel()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		} else {
+			fmt.Fprintln(os.Stderr, "Unfinished migrations are resumed by the next gunj-migrate migrate")
+		}
+		os.Exit(ExitFailure)
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// ResumeCheckpointed resumes the migrations checkpointed by an interrupted
+// run and waits for them, checking their status every interval. Migrations
+// still running when ctx is done are checkpointed again.
+func ResumeCheckpointed(ctx context.Context, out io.Writer, manager Checkpointer, interval time.Duration) error {
+	tasks, err := manager.Resume(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		fmt.Fprintf(out, "Resuming interrupted migration of %d resources as %s...\n", len(task.Resources), task.ID)
+		finished, err := waitForMigration(ctx, manager, task.ID, interval)
+		if err != nil {
+			return err
+		}
+		if finished == nil {
+			if err := manager.Checkpoint(context.Background()); err != nil {
+				return fmt.Errorf("resumed migration %s didn't finish: %w", task.ID, err)
+			}
+			return fmt.Errorf("resumed migration %s didn't finish and was checkpointed again: %w", task.ID, ctx.Err())
+		}
+		if err := ResultError(finished.ID, finished.Status, finished.Progress.TotalResources,
+			finished.Progress.MigratedResources, finished.Progress.FailedResources); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Resumed migration %s %s\n", task.ID, finished.Status)
+	}
+	return nil
+}
+
+// waitForMigration returns a migration once it finished, nil if ctx is done
+// first
+func waitForMigration(ctx context.Context, manager Checkpointer, taskID string, interval time.Duration) (*migration.MigrationTask, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		task, err := manager.GetMigrationStatus(taskID)
+		if err != nil {
+			return nil, err
+		}
+		if task.Status != migration.MigrationStatusPending && task.Status != migration.MigrationStatusInProgress {
+			return task, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package cliout
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/migration"
+)
+
+// fakeCheckpointer resumes tasks whose status is read from statuses
+type fakeCheckpointer struct {
+	tasks        []*migration.MigrationTask
+	statuses     map[string]*migration.MigrationTask
+	checkpointed int
+}
+
+func (f *fakeCheckpointer) Drain(context.Context) error { return nil }
+
+func (f *fakeCheckpointer) Checkpoint(context.Context) error {
+	f.checkpointed++
+	return nil
+}
+
+func (f *fakeCheckpointer) Resume(context.Context) ([]*migration.MigrationTask, error) {
+	return f.tasks, nil
+}
+
+func (f *fakeCheckpointer) GetMigrationStatus(taskID string) (*migration.MigrationTask, error) {
+	task, ok := f.statuses[taskID]
+	if !ok {
+		return nil, errors.New("migration task not found: " + taskID)
+	}
+	return task, nil
+}
+
+func TestResumeCheckpointed(t *testing.T) {
+	task := func(id string, status migration.MigrationStatus, migrated, failed int) *migration.MigrationTask {
+		m := &migration.MigrationTask{ID: id, Status: status}
+		m.Progress.TotalResources = migrated + failed
+		m.Progress.MigratedResources = migrated
+		m.Progress.FailedResources = failed
+		return m
+	}
+
+	// Nothing was checkpointed
+	var out bytes.Buffer
+	require.NoError(t, ResumeCheckpointed(context.Background(), &out, &fakeCheckpointer{}, time.Millisecond))
+	assert.Empty(t, out.String())
+
+	// Resumed migrations are waited for, and their result is the error
+	manager := &fakeCheckpointer{
+		tasks: []*migration.MigrationTask{{ID: "m1"}, {ID: "m2"}},
+		statuses: map[string]*migration.MigrationTask{
+			"m1": task("m1", migration.MigrationStatusCompleted, 2, 0),
+			"m2": task("m2", migration.MigrationStatusFailed, 1, 1),
+		},
+	}
+	err := ResumeCheckpointed(context.Background(), &out, manager, time.Millisecond)
+	require.Error(t, err)
+	assert.Equal(t, ExitPartial, ExitCode(err))
+	assert.Contains(t, out.String(), "Resumed migration m1 Completed")
+
+	// A migration still running when the context is done is checkpointed
+	// again
+	manager = &fakeCheckpointer{
+		tasks:    []*migration.MigrationTask{{ID: "m3"}},
+		statuses: map[string]*migration.MigrationTask{"m3": task("m3", migration.MigrationStatusInProgress, 0, 0)},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = ResumeCheckpointed(ctx, &out, manager, time.Millisecond)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, manager.checkpointed)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+// Package cliout writes the results of the command-line tools as tables for
+// people, or as JSON or YAML for scripts. The JSON and YAML are encoded from
+// the same values, so they have the same fields.
+package cliout
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+// Output formats
+const (
+	Table = "table"
+	JSON  = "json"
+	YAML  = "yaml"
+)
+
+// Formats are the supported output formats
+var Formats = []string{Table, JSON, YAML}
+
+// Validate returns an error for an unsupported output format
+func Validate(format string) error {
+	for _, f := range Formats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported output format %q, use one of %s", format, strings.Join(Formats, ", "))
+}
+
+// Write writes a result in a format. Tables are written by table, JSON and
+// YAML are encoded from v.
+func Write(w io.Writer, format string, v interface{}, table func(io.Writer) error) error {
+	switch format {
+	case Table:
+		return table(w)
+	case JSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	case YAML:
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	default:
+		return Validate(format)
+	}
+}
+
+// AddFlag adds the persistent --output flag of the subcommands of a command,
+// completed with the formats. Subcommands defining an --output flag of their
+// own, for a file or other formats, keep it.
+func AddFlag(cmd *cobra.Command, format *string) {
+	cmd.PersistentFlags().StringVarP(format, "output", "o", Table, "Output format ("+strings.Join(Formats, ", ")+")")
+	_ = cmd.RegisterFlagCompletionFunc("output", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return Formats, cobra.ShellCompDirectiveNoFileComp
+	})
+}
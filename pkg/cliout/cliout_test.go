@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package cliout
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type result struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+func TestWrite(t *testing.T) {
+	v := []result{{Name: "production", Version: "v1beta1"}, {Name: "staging"}}
+	table := func(w io.Writer) error {
+		_, err := fmt.Fprintln(w, "NAME  VERSION")
+		return err
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, Write(&out, Table, v, table))
+	assert.Equal(t, "NAME  VERSION\n", out.String())
+
+	out.Reset()
+	require.NoError(t, Write(&out, JSON, v, table))
+	assert.JSONEq(t, `[{"name":"production","version":"v1beta1"},{"name":"staging"}]`, out.String())
+
+	out.Reset()
+	require.NoError(t, Write(&out, YAML, v, table))
+	assert.Equal(t, "- name: production\n  version: v1beta1\n- name: staging\n", out.String())
+
+	err := Write(&out, "xml", v, table)
+	assert.EqualError(t, err, `unsupported output format "xml", use one of table, json, yaml`)
+}
+
+func TestAddFlag(t *testing.T) {
+	var format string
+	root := &cobra.Command{Use: "tool"}
+	AddFlag(root, &format)
+	root.AddCommand(&cobra.Command{Use: "status", RunE: func(*cobra.Command, []string) error { return nil }})
+
+	root.SetArgs([]string{"status", "-o", "yaml"})
+	require.NoError(t, root.Execute())
+	assert.Equal(t, YAML, format)
+
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetArgs([]string{cobra.ShellCompRequestCmd, "status", "--output", ""})
+	require.NoError(t, root.Execute())
+	// The formats, then the directive not to complete file names
+	assert.Contains(t, out.String(), "table\njson\nyaml\n:4\n")
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package cliout
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/migration"
+)
+
+// Exit codes of the migration tools, for pipelines
+const (
+	// ExitSuccess is returned when every resource was migrated or skipped
+	ExitSuccess = 0
+
+	// ExitFailure is returned for errors and migrations that failed entirely
+	ExitFailure = 1
+
+	// ExitPartial is returned for migrations that failed for some of the
+	// resources only
+	ExitPartial = 2
+
+	// ExitValidationFailed is returned when resources failed the migration
+	// checks, or aren't ready to be migrated
+	ExitValidationFailed = 3
+
+	// ExitRolledBack is returned for migrations whose changes were rolled
+	// back
+	ExitRolledBack = 4
+)
+
+// exitError is an error with the exit code it ends the tool with
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// WithExitCode returns an error ending the tool with an exit code
+func WithExitCode(code int, err error) error {
+	return &exitError{code: code, err: err}
+}
+
+// ExitCode returns the exit code of the error of a command
+func ExitCode(err error) int {
+	var exitErr *exitError
+	switch {
+	case err == nil:
+		return ExitSuccess
+	case errors.As(err, &exitErr):
+		return exitErr.code
+	case errors.Is(err, migration.ErrRolledBack):
+		return ExitRolledBack
+	case errors.Is(err, migration.ErrValidationFailed):
+		return ExitValidationFailed
+	default:
+		return ExitFailure
+	}
+}
+
+// ResultError returns the error of a finished migration, nil if all of its
+// resources were migrated or skipped
+func ResultError(taskID string, status migration.MigrationStatus, total, migrated, failed int) error {
+	switch {
+	case status == migration.MigrationStatusRolledBack:
+		return WithExitCode(ExitRolledBack, fmt.Errorf("migration %s was rolled back", taskID))
+	case failed > 0 && migrated > 0:
+		return WithExitCode(ExitPartial, fmt.Errorf("migration %s failed for %d of %d resources", taskID, failed, total))
+	case status == migration.MigrationStatusFailed || failed > 0:
+		return WithExitCode(ExitFailure, fmt.Errorf("migration %s failed", taskID))
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,32 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package cliout
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gunjanjp/gunj-operator/api/v1beta1/migration"
+)
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, ExitSuccess, ExitCode(nil))
+	assert.Equal(t, ExitFailure, ExitCode(errors.New("boom")))
+	assert.Equal(t, ExitPartial, ExitCode(fmt.Errorf("wrapped: %w", WithExitCode(ExitPartial, errors.New("boom")))))
+	assert.Equal(t, ExitRolledBack, ExitCode(fmt.Errorf("timed out: %w", migration.ErrRolledBack)))
+	assert.Equal(t, ExitValidationFailed, ExitCode(fmt.Errorf("not ready: %w", migration.ErrValidationFailed)))
+}
+
+func TestResultError(t *testing.T) {
+	assert.NoError(t, ResultError("m1", migration.MigrationStatusCompleted, 3, 3, 0))
+	assert.Equal(t, ExitPartial, ExitCode(ResultError("m1", migration.MigrationStatusFailed, 3, 2, 1)))
+	assert.Equal(t, ExitFailure, ExitCode(ResultError("m1", migration.MigrationStatusFailed, 3, 0, 3)))
+	assert.Equal(t, ExitRolledBack, ExitCode(ResultError("m1", migration.MigrationStatusRolledBack, 3, 3, 0)))
+}
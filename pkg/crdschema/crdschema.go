@@ -0,0 +1,227 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+// Package crdschema compares the CRD schemas of operator releases. The CRDs
+// of every release are embedded, so the fields, defaults and validations a
+// release adds, removes or changes can be listed without access to the
+// release's sources.
+package crdschema
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/yaml"
+)
+
+// releases holds the CRDs of each release, in releases/<release>/
+//
+//go:embed releases
+var releases embed.FS
+
+// Releases returns the releases with embedded CRDs, oldest first
+func Releases() []string {
+	entries, err := releases.ReadDir("releases")
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		vi, erri := version.ParseSemantic(names[i])
+		vj, errj := version.ParseSemantic(names[j])
+		if erri != nil || errj != nil {
+			return names[i] < names[j]
+		}
+		return vi.LessThan(vj)
+	})
+	return names
+}
+
+// Load returns the CRDs of a release, or of a CRD file or directory of CRD
+// files, by name
+func Load(source string) (map[string]*apiextensionsv1.CustomResourceDefinition, error) {
+	if sub, err := fs.Sub(releases, "releases/"+source); err == nil {
+		if _, err := fs.Stat(sub, "."); err == nil {
+			return loadFS(sub)
+		}
+	}
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("%s is neither a release (%s) nor a CRD file or directory", source, strings.Join(Releases(), ", "))
+	}
+	if info.IsDir() {
+		return loadFS(os.DirFS(source))
+	}
+	return loadFS(os.DirFS(filepath.Dir(source)), filepath.Base(source))
+}
+
+// loadFS reads the CRDs of the YAML files of fsys, or of the given files
+func loadFS(fsys fs.FS, files ...string) (map[string]*apiextensionsv1.CustomResourceDefinition, error) {
+	if len(files) == 0 {
+		matches, err := fs.Glob(fsys, "*.yaml")
+		if err != nil {
+			return nil, err
+		}
+		files = matches
+	}
+	crds := map[string]*apiextensionsv1.CustomResourceDefinition{}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range bytes.Split(data, []byte("\n---")) {
+			if len(bytes.TrimSpace(doc)) == 0 {
+				continue
+			}
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			if err := yaml.Unmarshal(doc, crd); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+			}
+			if crd.Kind != "CustomResourceDefinition" {
+				continue
+			}
+			crds[crd.Name] = crd
+		}
+	}
+	if len(crds) == 0 {
+		return nil, fmt.Errorf("no CRDs found")
+	}
+	return crds, nil
+}
+
+// Field is a field of a schema
+type Field struct {
+	Type     string
+	Required bool
+	// Default is the JSON of the default value
+	Default string
+	// Validations are the validations of the field by keyword, e.g.
+	// pattern, enum or x-kubernetes-validations
+	Validations map[string]string
+}
+
+// Flatten returns the fields of a schema by path. Array items are addressed
+// by "[]" and additional properties by "*".
+func Flatten(schema *apiextensionsv1.JSONSchemaProps) map[string]Field {
+	fields := map[string]Field{}
+	flatten(schema, "", false, fields)
+	return fields
+}
+
+func flatten(schema *apiextensionsv1.JSONSchemaProps, path string, required bool, fields map[string]Field) {
+	if schema == nil {
+		return
+	}
+	if path != "" {
+		fields[path] = Field{Type: schemaType(schema), Required: required, Default: rawJSON(schema.Default), Validations: validations(schema)}
+	}
+	requiredFields := map[string]bool{}
+	for _, name := range schema.Required {
+		requiredFields[name] = true
+	}
+	for name, property := range schema.Properties {
+		property := property
+		flatten(&property, path+"."+name, requiredFields[name], fields)
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		flatten(schema.Items.Schema, path+"[]", false, fields)
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		flatten(schema.AdditionalProperties.Schema, path+".*", false, fields)
+	}
+}
+
+func schemaType(schema *apiextensionsv1.JSONSchemaProps) string {
+	switch {
+	case schema.XIntOrString:
+		return "int-or-string"
+	case schema.XPreserveUnknownFields != nil && *schema.XPreserveUnknownFields && len(schema.Properties) == 0:
+		return schema.Type + " (any)"
+	}
+	return schema.Type
+}
+
+func validations(schema *apiextensionsv1.JSONSchemaProps) map[string]string {
+	v := map[string]string{}
+	set := func(keyword string, value interface{}) {
+		data, err := json.Marshal(value)
+		if err == nil {
+			v[keyword] = string(data)
+		}
+	}
+	if schema.Pattern != "" {
+		v["pattern"] = schema.Pattern
+	}
+	if schema.Format != "" {
+		v["format"] = schema.Format
+	}
+	if len(schema.Enum) > 0 {
+		values := make([]string, 0, len(schema.Enum))
+		for i := range schema.Enum {
+			values = append(values, rawJSON(&schema.Enum[i]))
+		}
+		v["enum"] = strings.Join(values, ", ")
+	}
+	if schema.Minimum != nil {
+		set("minimum", *schema.Minimum)
+	}
+	if schema.Maximum != nil {
+		set("maximum", *schema.Maximum)
+	}
+	if schema.ExclusiveMinimum {
+		v["exclusiveMinimum"] = "true"
+	}
+	if schema.ExclusiveMaximum {
+		v["exclusiveMaximum"] = "true"
+	}
+	if schema.MinLength != nil {
+		set("minLength", *schema.MinLength)
+	}
+	if schema.MaxLength != nil {
+		set("maxLength", *schema.MaxLength)
+	}
+	if schema.MinItems != nil {
+		set("minItems", *schema.MinItems)
+	}
+	if schema.MaxItems != nil {
+		set("maxItems", *schema.MaxItems)
+	}
+	if schema.MinProperties != nil {
+		set("minProperties", *schema.MinProperties)
+	}
+	if schema.MaxProperties != nil {
+		set("maxProperties", *schema.MaxProperties)
+	}
+	if schema.Nullable {
+		v["nullable"] = "true"
+	}
+	for _, rule := range schema.XValidations {
+		v["x-kubernetes-validations: "+rule.Rule] = rule.Message
+	}
+	return v
+}
+
+func rawJSON(value *apiextensionsv1.JSON) string {
+	if value == nil {
+		return ""
+	}
+	return string(value.Raw)
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package crdschema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const oldCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.io
+spec:
+  group: example.io
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            properties:
+              size:
+                type: string
+              replicas:
+                type: integer
+                default: 1
+                minimum: 1
+              legacy:
+                type: string
+              tags:
+                type: array
+                items:
+                  type: string
+`
+
+const newCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.io
+spec:
+  group: example.io
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: false
+    deprecated: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required:
+            - size
+            properties:
+              size:
+                type: integer
+              replicas:
+                type: integer
+                default: 3
+                minimum: 1
+                maximum: 10
+              tags:
+                type: array
+                items:
+                  type: string
+                  pattern: ^[a-z]+$
+              color:
+                type: string
+                enum: [red, blue]
+  - name: v2
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+`
+
+func writeCRD(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "widgets.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func findChange(changes []Change, version, path, aspect string) *Change {
+	for i := range changes {
+		if changes[i].Version == version && changes[i].Path == path && changes[i].Aspect == aspect {
+			return &changes[i]
+		}
+	}
+	return nil
+}
+
+func TestDiff(t *testing.T) {
+	from, err := Load(writeCRD(t, oldCRD))
+	require.NoError(t, err)
+	to, err := Load(writeCRD(t, newCRD))
+	require.NoError(t, err)
+	require.Len(t, to, 1)
+
+	changes := Diff(from, to)
+	assert.True(t, HasBreaking(changes))
+
+	tests := []struct {
+		version, path, aspect string
+		kind                  ChangeKind
+		from, to              string
+		breaking              bool
+	}{
+		{"v2", "", "version", Added, "", "", false},
+		{"v1", "", "storage", Changed, "true", "false", false},
+		{"v1", "", "deprecated", Changed, "false", "true", false},
+		{"v1", ".spec.size", "type", Changed, "string", "integer", true},
+		{"v1", ".spec.size", "required", Changed, "false", "true", true},
+		{"v1", ".spec.replicas", "default", Changed, "1", "3", false},
+		{"v1", ".spec.replicas", "maximum", Added, "", "10", true},
+		{"v1", ".spec.legacy", "field", Removed, "string", "", true},
+		{"v1", ".spec.color", "field", Added, "", "string", false},
+		{"v1", ".spec.tags[]", "pattern", Added, "", "^[a-z]+$", true},
+	}
+	for _, tt := range tests {
+		change := findChange(changes, tt.version, tt.path, tt.aspect)
+		require.NotNil(t, change, "%s %s %s", tt.version, tt.path, tt.aspect)
+		assert.Equal(t, tt.kind, change.Kind, change.String())
+		assert.Equal(t, tt.from, change.From, change.String())
+		assert.Equal(t, tt.to, change.To, change.String())
+		assert.Equal(t, tt.breaking, change.Breaking, change.String())
+	}
+
+	// Unchanged fields are not reported
+	assert.Nil(t, findChange(changes, "v1", ".spec.replicas", "minimum"))
+	assert.Empty(t, Diff(from, from))
+}
+
+func TestDiffCRDs(t *testing.T) {
+	from, err := Load(writeCRD(t, oldCRD))
+	require.NoError(t, err)
+
+	changes := Diff(from, nil)
+	require.Len(t, changes, 1)
+	assert.Equal(t, Removed, changes[0].Kind)
+	assert.True(t, changes[0].Breaking)
+	assert.Equal(t, "widgets.example.io: crd removed [breaking]", changes[0].String())
+}
+
+func TestReleases(t *testing.T) {
+	releases := Releases()
+	require.NotEmpty(t, releases)
+
+	crds, err := Load(releases[len(releases)-1])
+	require.NoError(t, err)
+	assert.Contains(t, crds, "observabilityplatforms.observability.io")
+
+	_, err = Load("v0.0.0-missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package crdschema
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// ChangeKind is the kind of a schema change
+type ChangeKind string
+
+const (
+	Added   ChangeKind = "Added"
+	Removed ChangeKind = "Removed"
+	Changed ChangeKind = "Changed"
+)
+
+// Change is a difference between two releases' CRDs
+type Change struct {
+	CRD string `json:"crd"`
+	// Version is the API version of the change, empty for a whole CRD
+	Version string `json:"version,omitempty"`
+	// Path is the field of the change, empty for a whole version
+	Path string     `json:"path,omitempty"`
+	Kind ChangeKind `json:"kind"`
+	// Aspect is what changed: crd, version, served, storage, deprecated,
+	// field, type, required, default, or a validation keyword
+	Aspect string `json:"aspect"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+	// Breaking is set when objects or clients valid with the previous
+	// release may be rejected by the new one
+	Breaking bool `json:"breaking"`
+}
+
+// Diff returns the changes between the CRDs of two releases, sorted by CRD,
+// version and path
+func Diff(from, to map[string]*apiextensionsv1.CustomResourceDefinition) []Change {
+	var changes []Change
+	for _, name := range unionKeys(from, to) {
+		oldCRD, newCRD := from[name], to[name]
+		switch {
+		case newCRD == nil:
+			changes = append(changes, Change{CRD: name, Kind: Removed, Aspect: "crd", Breaking: true})
+		case oldCRD == nil:
+			changes = append(changes, Change{CRD: name, Kind: Added, Aspect: "crd"})
+		default:
+			changes = append(changes, diffCRD(name, oldCRD, newCRD)...)
+		}
+	}
+	return changes
+}
+
+// HasBreaking reports whether any of the changes is breaking
+func HasBreaking(changes []Change) bool {
+	for _, change := range changes {
+		if change.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+func diffCRD(name string, from, to *apiextensionsv1.CustomResourceDefinition) []Change {
+	oldVersions := versionsByName(from)
+	newVersions := versionsByName(to)
+
+	var changes []Change
+	for _, v := range unionKeys(oldVersions, newVersions) {
+		oldVersion, newVersion := oldVersions[v], newVersions[v]
+		switch {
+		case newVersion == nil:
+			changes = append(changes, Change{CRD: name, Version: v, Kind: Removed, Aspect: "version", Breaking: oldVersion.Served})
+			continue
+		case oldVersion == nil:
+			changes = append(changes, Change{CRD: name, Version: v, Kind: Added, Aspect: "version"})
+			continue
+		}
+
+		if oldVersion.Served != newVersion.Served {
+			changes = append(changes, Change{CRD: name, Version: v, Kind: Changed, Aspect: "served",
+				From: strconv.FormatBool(oldVersion.Served), To: strconv.FormatBool(newVersion.Served), Breaking: !newVersion.Served})
+		}
+		if oldVersion.Storage != newVersion.Storage {
+			changes = append(changes, Change{CRD: name, Version: v, Kind: Changed, Aspect: "storage",
+				From: strconv.FormatBool(oldVersion.Storage), To: strconv.FormatBool(newVersion.Storage)})
+		}
+		if oldVersion.Deprecated != newVersion.Deprecated {
+			changes = append(changes, Change{CRD: name, Version: v, Kind: Changed, Aspect: "deprecated",
+				From: strconv.FormatBool(oldVersion.Deprecated), To: strconv.FormatBool(newVersion.Deprecated)})
+		}
+
+		changes = append(changes, diffFields(name, v, Flatten(openAPISchema(oldVersion)), Flatten(openAPISchema(newVersion)))...)
+	}
+	return changes
+}
+
+func diffFields(crd, v string, from, to map[string]Field) []Change {
+	var changes []Change
+	for _, path := range unionKeys(from, to) {
+		oldField, inOld := from[path]
+		newField, inNew := to[path]
+		change := Change{CRD: crd, Version: v, Path: path}
+		switch {
+		case !inNew:
+			change.Kind, change.Aspect, change.From, change.Breaking = Removed, "field", oldField.Type, true
+			changes = append(changes, change)
+			continue
+		case !inOld:
+			change.Kind, change.Aspect, change.To, change.Breaking = Added, "field", newField.Type, newField.Required
+			changes = append(changes, change)
+			continue
+		}
+
+		if oldField.Type != newField.Type {
+			c := change
+			c.Kind, c.Aspect, c.From, c.To, c.Breaking = Changed, "type", oldField.Type, newField.Type, true
+			changes = append(changes, c)
+		}
+		if oldField.Required != newField.Required {
+			c := change
+			c.Kind, c.Aspect, c.From, c.To, c.Breaking = Changed, "required",
+				strconv.FormatBool(oldField.Required), strconv.FormatBool(newField.Required), newField.Required
+			changes = append(changes, c)
+		}
+		if oldField.Default != newField.Default {
+			c := change
+			c.Kind, c.Aspect, c.From, c.To = kindOf(oldField.Default, newField.Default), "default", oldField.Default, newField.Default
+			changes = append(changes, c)
+		}
+		for _, keyword := range unionKeys(oldField.Validations, newField.Validations) {
+			oldValue, inOld := oldField.Validations[keyword]
+			newValue, inNew := newField.Validations[keyword]
+			if inOld && inNew && oldValue == newValue {
+				continue
+			}
+			c := change
+			c.Aspect, c.From, c.To = keyword, oldValue, newValue
+			switch {
+			case !inNew:
+				c.Kind = Removed
+			case !inOld:
+				// A new validation may reject objects that were valid
+				c.Kind, c.Breaking = Added, true
+			default:
+				c.Kind, c.Breaking = Changed, true
+			}
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// String describes the change on a line
+func (c Change) String() string {
+	subject := c.CRD
+	if c.Version != "" {
+		subject += " " + c.Version
+	}
+	if c.Path != "" {
+		subject += " " + c.Path
+	}
+
+	var detail string
+	switch c.Kind {
+	case Added:
+		detail = fmt.Sprintf("%s added", c.Aspect)
+		if c.To != "" {
+			detail += ": " + c.To
+		}
+	case Removed:
+		detail = fmt.Sprintf("%s removed", c.Aspect)
+		if c.From != "" {
+			detail += " (was " + c.From + ")"
+		}
+	default:
+		detail = fmt.Sprintf("%s changed: %s -> %s", c.Aspect, c.From, c.To)
+	}
+	if c.Breaking {
+		detail += " [breaking]"
+	}
+	return subject + ": " + detail
+}
+
+func kindOf(from, to string) ChangeKind {
+	switch {
+	case from == "":
+		return Added
+	case to == "":
+		return Removed
+	}
+	return Changed
+}
+
+func versionsByName(crd *apiextensionsv1.CustomResourceDefinition) map[string]*apiextensionsv1.CustomResourceDefinitionVersion {
+	versions := map[string]*apiextensionsv1.CustomResourceDefinitionVersion{}
+	for i := range crd.Spec.Versions {
+		versions[crd.Spec.Versions[i].Name] = &crd.Spec.Versions[i]
+	}
+	return versions
+}
+
+func openAPISchema(v *apiextensionsv1.CustomResourceDefinitionVersion) *apiextensionsv1.JSONSchemaProps {
+	if v.Schema == nil {
+		return nil
+	}
+	return v.Schema.OpenAPIV3Schema
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
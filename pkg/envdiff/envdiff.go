@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+// Package envdiff compares two live ObservabilityPlatforms, usually the same
+// platform in two clusters or environments, and reports their drift: the
+// settings of their specs and the configuration the operator rendered for
+// them into ConfigMaps. Platforms named differently are compared with their
+// names replaced in ConfigMap names and rendered files.
+package envdiff
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gunjanjp/gunj-operator/internal/changesummary"
+)
+
+const (
+	// PlatformLabel is set on the children of a platform
+	PlatformLabel = "observability.io/platform"
+
+	// ManagedByLabel and ManagedBy select the ConfigMaps rendered by the
+	// operator among the children of a platform
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	ManagedBy      = "gunj-operator"
+
+	// platformPlaceholder replaces the platform name when platforms named
+	// differently are compared
+	platformPlaceholder = "<platform>"
+)
+
+// PlatformGVK is the kind of the platforms
+var PlatformGVK = schema.GroupVersionKind{Group: "observability.io", Version: "v1beta1", Kind: "ObservabilityPlatform"}
+
+// Snapshot is the state of a platform compared
+type Snapshot struct {
+	Namespace string
+	Name      string
+	// Spec is the flattened spec of the platform
+	Spec changesummary.Snapshot
+	// Rendered holds the rendered configuration by "configmap/key"
+	Rendered map[string]string
+}
+
+// Capture reads the snapshot of a platform and its rendered ConfigMaps
+func Capture(ctx context.Context, c client.Client, namespace, name string) (*Snapshot, error) {
+	platform := &unstructured.Unstructured{}
+	platform.SetGroupVersionKind(PlatformGVK)
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, platform); err != nil {
+		return nil, fmt.Errorf("failed to get platform %s/%s: %w", namespace, name, err)
+	}
+	spec, err := changesummary.Flatten(platform.Object["spec"])
+	if err != nil {
+		return nil, err
+	}
+
+	configMaps := &corev1.ConfigMapList{}
+	if err := c.List(ctx, configMaps, client.InNamespace(namespace),
+		client.MatchingLabels{PlatformLabel: name, ManagedByLabel: ManagedBy}); err != nil {
+		return nil, fmt.Errorf("failed to list ConfigMaps of platform %s/%s: %w", namespace, name, err)
+	}
+	rendered := make(map[string]string)
+	for _, cm := range configMaps.Items {
+		for key, value := range cm.Data {
+			rendered[cm.Name+"/"+key] = value
+		}
+		for key := range cm.BinaryData {
+			rendered[cm.Name+"/"+key] = fmt.Sprintf("(%d bytes of binary data)", len(cm.BinaryData[key]))
+		}
+	}
+
+	return &Snapshot{Namespace: namespace, Name: name, Spec: spec, Rendered: rendered}, nil
+}
+
+// ConfigDrift is a rendered configuration file that differs
+type ConfigDrift struct {
+	// Path is "configmap/key"
+	Path string
+	// OnlyIn is "source" or "target" for files of only one platform
+	OnlyIn string
+	// Removed and Added are the lines of the source missing from the target
+	// and the lines of the target missing from the source, in order
+	Removed []string
+	Added   []string
+}
+
+// Report is the drift between two platforms
+type Report struct {
+	Source, Target string
+	Spec           []changesummary.Change
+	Config         []ConfigDrift
+}
+
+// InSync returns whether the platforms have no drift
+func (r *Report) InSync() bool {
+	return len(r.Spec) == 0 && len(r.Config) == 0
+}
+
+// Differences returns the number of differing settings and files
+func (r *Report) Differences() int {
+	return len(r.Spec) + len(r.Config)
+}
+
+// Compare returns the drift of target from source. Settings under the
+// ignored paths, e.g. "global.externalLabels", are expected to differ and
+// left out.
+func Compare(source, target *Snapshot, ignore []string) *Report {
+	report := &Report{
+		Source: source.Namespace + "/" + source.Name,
+		Target: target.Namespace + "/" + target.Name,
+	}
+	for _, change := range changesummary.Diff(source.Spec, target.Spec) {
+		if !ignored(change.Path, ignore) {
+			report.Spec = append(report.Spec, change)
+		}
+	}
+
+	sourceRendered, targetRendered := source.Rendered, target.Rendered
+	if source.Name != target.Name {
+		// Rendered files and their ConfigMaps mention the platform by name
+		sourceRendered = withoutName(sourceRendered, source.Name)
+		targetRendered = withoutName(targetRendered, target.Name)
+	}
+
+	paths := make(map[string]bool)
+	for path := range sourceRendered {
+		paths[path] = true
+	}
+	for path := range targetRendered {
+		paths[path] = true
+	}
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+
+	for _, path := range sorted {
+		from, inSource := sourceRendered[path]
+		to, inTarget := targetRendered[path]
+		switch {
+		case !inTarget:
+			report.Config = append(report.Config, ConfigDrift{Path: path, OnlyIn: "source"})
+		case !inSource:
+			report.Config = append(report.Config, ConfigDrift{Path: path, OnlyIn: "target"})
+		case from == to:
+		default:
+			removed, added := LineDiff(from, to)
+			report.Config = append(report.Config, ConfigDrift{Path: path, Removed: removed, Added: added})
+		}
+	}
+	return report
+}
+
+// withoutName replaces the platform name in the paths and files of rendered
+func withoutName(rendered map[string]string, name string) map[string]string {
+	replaced := make(map[string]string, len(rendered))
+	for path, value := range rendered {
+		replaced[strings.ReplaceAll(path, name, platformPlaceholder)] = strings.ReplaceAll(value, name, platformPlaceholder)
+	}
+	return replaced
+}
+
+// ignored returns whether path is under one of the ignored paths
+func ignored(path string, ignore []string) bool {
+	for _, prefix := range ignore {
+		if path == prefix || strings.HasPrefix(path, prefix+".") || strings.HasPrefix(path, prefix+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// LineDiff returns the lines of a missing from b and the lines of b missing
+// from a, counting repeated lines. Moved lines are not reported, so
+// reordered but equivalent files show no lines; the files still differ.
+func LineDiff(a, b string) (removed, added []string) {
+	aLines, bLines := strings.Split(a, "\n"), strings.Split(b, "\n")
+	return missing(aLines, bLines), missing(bLines, aLines)
+}
+
+// missing returns the lines of a not in b, in order
+func missing(a, b []string) []string {
+	counts := make(map[string]int, len(b))
+	for _, line := range b {
+		counts[line]++
+	}
+	var lines []string
+	for _, line := range a {
+		if counts[line] > 0 {
+			counts[line]--
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
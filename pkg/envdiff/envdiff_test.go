@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package envdiff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gunjanjp/gunj-operator/internal/changesummary"
+)
+
+func platform(name, version string) *unstructured.Unstructured {
+	p := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"components": map[string]interface{}{
+				"prometheus": map[string]interface{}{"enabled": true, "version": version},
+			},
+		},
+	}}
+	p.SetGroupVersionKind(PlatformGVK)
+	p.SetName(name)
+	p.SetNamespace("monitoring")
+	return p
+}
+
+func renderedConfigMap(name, platform, config string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "monitoring",
+			Labels:    map[string]string{PlatformLabel: platform, ManagedByLabel: ManagedBy},
+		},
+		Data: map[string]string{"prometheus.yml": config},
+	}
+}
+
+func TestCapture(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+		platform("prod", "v2.48.0"),
+		renderedConfigMap("prometheus-prod-config", "prod", "global:\n  scrape_interval: 30s"),
+		// Not rendered by the operator
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name: "notes", Namespace: "monitoring", Labels: map[string]string{PlatformLabel: "prod"},
+		}, Data: map[string]string{"a": "b"}},
+	).Build()
+
+	snapshot, err := Capture(context.Background(), c, "monitoring", "prod")
+	require.NoError(t, err)
+	assert.Equal(t, "v2.48.0", snapshot.Spec["components.prometheus.version"])
+	assert.Equal(t, map[string]string{
+		"prometheus-prod-config/prometheus.yml": "global:\n  scrape_interval: 30s",
+	}, snapshot.Rendered)
+
+	_, err = Capture(context.Background(), c, "monitoring", "missing")
+	assert.Error(t, err)
+}
+
+func TestCompare(t *testing.T) {
+	source := &Snapshot{
+		Namespace: "monitoring", Name: "prod",
+		Spec: changesummary.Snapshot{
+			"components.prometheus.version":   "v2.48.0",
+			"global.externalLabels.env":       "prod",
+			"components.prometheus.retention": "30d",
+		},
+		Rendered: map[string]string{
+			"prometheus-prod-config/prometheus.yml": "global:\n  external_labels:\n    platform: prod\n  scrape_interval: 30s",
+			"prometheus-prod-rules/alerts.yml":      "groups: []",
+		},
+	}
+	target := &Snapshot{
+		Namespace: "monitoring", Name: "staging",
+		Spec: changesummary.Snapshot{
+			"components.prometheus.version": "v2.51.0",
+			"global.externalLabels.env":     "staging",
+		},
+		Rendered: map[string]string{
+			"prometheus-staging-config/prometheus.yml": "global:\n  external_labels:\n    platform: staging\n  scrape_interval: 15s",
+		},
+	}
+
+	report := Compare(source, target, []string{"global.externalLabels"})
+	assert.False(t, report.InSync())
+	assert.Equal(t, 4, report.Differences())
+	assert.Equal(t, []changesummary.Change{
+		{Path: "components.prometheus.retention", Old: "30d", New: "(unset)"},
+		{Path: "components.prometheus.version", Old: "v2.48.0", New: "v2.51.0"},
+	}, report.Spec)
+	assert.Equal(t, []ConfigDrift{
+		{
+			Path:    "prometheus-<platform>-config/prometheus.yml",
+			Removed: []string{"  scrape_interval: 30s"},
+			Added:   []string{"  scrape_interval: 15s"},
+		},
+		{Path: "prometheus-<platform>-rules/alerts.yml", OnlyIn: "source"},
+	}, report.Config)
+
+	assert.True(t, Compare(source, source, nil).InSync())
+}
+
+func TestLineDiff(t *testing.T) {
+	removed, added := LineDiff("a\nb\nb\nc", "b\nc\nd\na")
+	assert.Equal(t, []string{"b"}, removed)
+	assert.Equal(t, []string{"d"}, added)
+
+	removed, added = LineDiff("a\nb", "b\na")
+	assert.Empty(t, removed)
+	assert.Empty(t, added)
+}
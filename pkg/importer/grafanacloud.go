@@ -0,0 +1,399 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package importer
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// GrafanaCloudSources are the configurations of a Grafana Cloud or a
+// self-managed Mimir and Loki setup to import. All are optional.
+type GrafanaCloudSources struct {
+	// Agent is a Grafana Agent configuration in static mode, or a Prometheus
+	// configuration
+	Agent map[string]interface{}
+	// Mimir is a Mimir configuration
+	Mimir map[string]interface{}
+	// MimirOverrides is a Mimir runtime configuration with per-tenant limits
+	MimirOverrides map[string]interface{}
+	// Loki is a Loki configuration
+	Loki map[string]interface{}
+	// LokiOverrides is a Loki runtime configuration with per-tenant limits
+	LokiOverrides map[string]interface{}
+	// Rules are rule files by name, in the format of Prometheus or of
+	// mimirtool rules print
+	Rules map[string]map[string]interface{}
+}
+
+// Reasons for the Grafana Cloud settings which are not imported
+var (
+	remoteWriteReasons = map[string]string{
+		"password_file":         "credentials from files are not imported; set basicAuth",
+		"bearer_token_file":     "credentials from files are not imported; set bearerToken",
+		"headers":               "remote write headers are not supported",
+		"write_relabel_configs": "relabelling of remote writes is not supported",
+		"queue_config":          "the remote write queue is tuned by the operator",
+	}
+	agentReasons = map[string]string{
+		"integrations": "exporters are not deployed by the platform",
+		"rule_files":   "rule files are not read; pass them to the import as rules",
+		"alerting":     "Prometheus sends alerts to the platform's Alertmanager",
+	}
+	mimirReasons = map[string]string{
+		"*": "Mimir deployment settings have no equivalent in Prometheus",
+		"ruler_storage": "rules are not read from the ruler storage; export them with mimirtool rules print " +
+			"and pass them to the import as rules",
+		"alertmanager_storage": "Alertmanager configurations are not imported; set spec.alerting.alertmanager.config",
+	}
+	lokiReasons = map[string]string{
+		"*": "Loki deployment settings are managed by the operator",
+	}
+	// lokiLimits maps Loki limits to the fields of the LokiConfig limits
+	lokiLimits = map[string]string{
+		"ingestion_rate_mb":           "ingestionRateMB",
+		"ingestion_burst_size_mb":     "ingestionBurstSizeMB",
+		"max_label_name_length":       "maxLabelNameLength",
+		"max_label_value_length":      "maxLabelValueLength",
+		"max_label_names_per_series":  "maxLabelNamesPerSeries",
+		"reject_old_samples":          "rejectOldSamples",
+		"reject_old_samples_max_age":  "rejectOldSamplesMaxAge",
+		"creation_grace_period":       "creationGracePeriod",
+		"max_streams_per_user":        "maxStreamsPerUser",
+		"max_global_streams_per_user": "maxGlobalStreamsPerUser",
+		"max_chunks_per_query":        "maxChunksPerQuery",
+		"max_query_series":            "maxQuerySeries",
+		"max_query_lookback":          "maxQueryLookback",
+		"max_query_length":            "maxQueryLength",
+		"max_query_parallelism":       "maxQueryParallelism",
+		"max_entries_limit_per_query": "maxEntriesLimitPerQuery",
+	}
+)
+
+// ImportGrafanaCloud generates platform fragments from the configurations of
+// Grafana Agent, Mimir and Loki: remote writes and scrape configs of the
+// agent, retention, Loki tenancy and limits, and alerting and recording
+// rules. The components receiving the data are enabled. Loki tenancy is
+// returned as a related LokiConfig fragment. Prometheus is single-tenant, so
+// Mimir tenants are reported as unsupported.
+func ImportGrafanaCloud(name, namespace string, sources GrafanaCloudSources) *Result {
+	result := newResult(name, namespace)
+
+	if sources.Agent != nil {
+		importAgent(result, newSection(result, "agent", "", sources.Agent))
+	}
+	if sources.Mimir != nil {
+		importMimir(result, newSection(result, "mimir", "", sources.Mimir))
+	}
+	if sources.MimirOverrides != nil {
+		importMimirOverrides(result, newSection(result, "mimir overrides", "", sources.MimirOverrides))
+	}
+
+	loki := map[string]interface{}{}
+	if sources.Loki != nil {
+		importLoki(result, newSection(result, "loki", "", sources.Loki), loki)
+	}
+	if sources.LokiOverrides != nil {
+		importLokiOverrides(result, newSection(result, "loki overrides", "", sources.LokiOverrides), loki)
+	}
+	if len(loki) > 0 {
+		lokiConfig := &unstructured.Unstructured{Object: map[string]interface{}{"spec": loki}}
+		lokiConfig.SetAPIVersion("observability.io/v1beta1")
+		lokiConfig.SetKind("LokiConfig")
+		lokiConfig.SetName(name)
+		lokiConfig.SetNamespace(namespace)
+		result.Related = append(result.Related, lokiConfig)
+	}
+
+	files := make([]string, 0, len(sources.Rules))
+	for file := range sources.Rules {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		importRules(result, newSection(result, file, "", sources.Rules[file]))
+	}
+	return result
+}
+
+// importAgent maps the metrics, logs and traces pipelines of a Grafana Agent
+// configuration. A Prometheus configuration is read like the agent's metrics.
+func importAgent(result *Result, agent *section) {
+	metrics := agent
+	if agent.has("metrics") {
+		metrics = agent.section("metrics")
+	}
+
+	global := metrics.section("global")
+	remoteWrites := global.list("remote_write")
+	if labels := global.stringMap("external_labels"); labels != nil {
+		result.set(labels, "spec", "global", "externalLabels")
+	}
+	global.used["scrape_interval"], global.used["scrape_timeout"] = true, true
+	global.finish(nil)
+
+	remoteWrites = append(remoteWrites, metrics.list("remote_write")...)
+	scrapeConfigs := metrics.values["scrape_configs"]
+	metrics.used["scrape_configs"] = true
+	for _, config := range metrics.list("configs") {
+		remoteWrites = append(remoteWrites, config.list("remote_write")...)
+		if configs, ok := config.values["scrape_configs"].([]interface{}); ok {
+			existing, _ := scrapeConfigs.([]interface{})
+			scrapeConfigs = append(existing, configs...)
+		}
+		config.used["name"], config.used["scrape_configs"] = true, true
+		config.finish(nil)
+	}
+
+	if metrics.has("configs") || len(remoteWrites) > 0 || scrapeConfigs != nil {
+		prometheus := result.object("spec", "components", "prometheus")
+		prometheus["enabled"] = true
+		seen := map[string]bool{}
+		var endpoints []interface{}
+		for _, remoteWrite := range remoteWrites {
+			endpoint := importAgentRemoteWrite(remoteWrite)
+			url := fmt.Sprint(endpoint["url"])
+			if seen[url] {
+				continue
+			}
+			seen[url] = true
+			endpoints = append(endpoints, endpoint)
+		}
+		if len(endpoints) > 0 {
+			prometheus["remoteWrite"] = endpoints
+		}
+		if configs, ok := scrapeConfigs.([]interface{}); ok && len(configs) > 0 {
+			if data, err := yaml.Marshal(configs); err == nil {
+				prometheus["additionalScrapeConfigs"] = string(data)
+			}
+		}
+	}
+	if metrics != agent {
+		metrics.used["wal_directory"] = true
+		metrics.finish(nil)
+	}
+
+	if logs := agent.section("logs"); len(logs.values) > 0 {
+		result.object("spec", "components", "loki")["enabled"] = true
+		for _, config := range logs.list("configs") {
+			for _, client := range config.list("clients") {
+				client.used["tenant_id"], client.used["basic_auth"] = true, true
+				client.unsupported("url", "logs are shipped by the collectors; point them at the platform's Loki")
+				client.finish(nil)
+			}
+			config.used["name"], config.used["positions"] = true, true
+			config.finish(map[string]string{
+				"scrape_configs": "log collection is not imported; collect logs with components.opentelemetryCollector",
+			})
+		}
+		logs.used["positions_directory"] = true
+		logs.finish(nil)
+	}
+
+	if traces := agent.section("traces"); len(traces.values) > 0 {
+		result.object("spec", "components", "tempo")["enabled"] = true
+		for _, config := range traces.list("configs") {
+			for _, remoteWrite := range config.list("remote_write") {
+				remoteWrite.used["basic_auth"], remoteWrite.used["insecure"] = true, true
+				remoteWrite.unsupported("endpoint", "traces are shipped by the collectors; point them at the platform's Tempo")
+				remoteWrite.finish(nil)
+			}
+			config.used["name"] = true
+			config.finish(map[string]string{
+				"receivers": "the platform's Tempo receives traces directly",
+			})
+		}
+		traces.finish(nil)
+	}
+
+	agent.used["server"] = true
+	agent.finish(agentReasons)
+}
+
+// importAgentRemoteWrite maps a remote write of a Grafana Agent or Prometheus
+// configuration
+func importAgentRemoteWrite(spec *section) map[string]interface{} {
+	remoteWrite := map[string]interface{}{}
+	spec.copy("url", remoteWrite, "url")
+	spec.copy("name", remoteWrite, "name")
+	spec.copy("bearer_token", remoteWrite, "bearerToken")
+
+	authValues := spec.section("basic_auth")
+	auth := map[string]interface{}{}
+	authValues.copy("username", auth, "username")
+	authValues.copy("password", auth, "password")
+	authValues.finish(remoteWriteReasons)
+	if len(auth) > 0 {
+		remoteWrite["basicAuth"] = auth
+	}
+
+	tlsValues := spec.section("tls_config")
+	tls := map[string]interface{}{}
+	tlsValues.copy("insecure_skip_verify", tls, "insecureSkipVerify")
+	tlsValues.copy("ca_file", tls, "caFile")
+	tlsValues.copy("cert_file", tls, "certFile")
+	tlsValues.copy("key_file", tls, "keyFile")
+	tlsValues.finish(nil)
+	if len(tls) > 0 {
+		remoteWrite["tlsConfig"] = tls
+	}
+
+	if tenant, ok := spec.section("headers").values["X-Scope-OrgID"]; ok {
+		spec.result.unsupported(spec.source, spec.field("headers"),
+			fmt.Sprintf("remote write headers are not supported; tenant %v is not sent", tenant))
+	} else {
+		spec.used["headers"] = false
+	}
+	spec.finish(remoteWriteReasons)
+	return remoteWrite
+}
+
+// importMimir maps the retention of a Mimir configuration
+func importMimir(result *Result, mimir *section) {
+	result.object("spec", "components", "prometheus")["enabled"] = true
+
+	limits := mimir.section("limits")
+	if retention := limits.string("compactor_blocks_retention_period"); retention != "" && retention != "0" && retention != "0s" {
+		result.set(retention, "spec", "global", "retentionPolicies", "metrics")
+	}
+	limits.finish(map[string]string{"*": "Prometheus has no tenant limits"})
+
+	mimir.used["multitenancy_enabled"], mimir.used["target"] = true, true
+	mimir.finish(mimirReasons)
+}
+
+// importMimirOverrides reports the tenants of a Mimir runtime configuration
+func importMimirOverrides(result *Result, overrides *section) {
+	tenants := overrides.section("overrides")
+	for _, tenant := range sortedKeys(tenants.values) {
+		tenants.unsupported(tenant, "Prometheus is single-tenant; create a platform per tenant, with the tenant as an external label")
+	}
+	overrides.finish(nil)
+}
+
+// importLoki maps the tenancy, limits and retention of a Loki configuration
+// into the spec of a LokiConfig
+func importLoki(result *Result, loki *section, lokiConfig map[string]interface{}) {
+	result.object("spec", "components", "loki")["enabled"] = true
+
+	if loki.bool("auth_enabled", true) {
+		lokiConfig["multiTenancy"] = map[string]interface{}{
+			"enabled":        true,
+			"authEnabled":    true,
+			"tenantIdHeader": "X-Scope-OrgID",
+		}
+	}
+
+	limitsValues := loki.section("limits_config")
+	if retention := limitsValues.string("retention_period"); retention != "" && retention != "0s" {
+		result.set(retention, "spec", "global", "retentionPolicies", "logs")
+	}
+	if limits := importLokiLimits(limitsValues); len(limits) > 0 {
+		lokiConfig["limits"] = limits
+	}
+
+	loki.finish(lokiReasons)
+}
+
+// importLokiOverrides maps the tenants of a Loki runtime configuration into
+// the spec of a LokiConfig
+func importLokiOverrides(result *Result, overrides *section, lokiConfig map[string]interface{}) {
+	tenantValues := overrides.section("overrides")
+	var tenants []interface{}
+	for _, id := range sortedKeys(tenantValues.values) {
+		tenant := map[string]interface{}{"id": id}
+		if limits := importLokiLimits(tenantValues.section(id)); len(limits) > 0 {
+			tenant["limits"] = limits
+		}
+		tenants = append(tenants, tenant)
+	}
+	overrides.finish(nil)
+
+	if len(tenants) > 0 {
+		multiTenancy, _ := lokiConfig["multiTenancy"].(map[string]interface{})
+		if multiTenancy == nil {
+			multiTenancy = map[string]interface{}{"enabled": true, "authEnabled": true, "tenantIdHeader": "X-Scope-OrgID"}
+			lokiConfig["multiTenancy"] = multiTenancy
+		}
+		multiTenancy["tenants"] = tenants
+	}
+}
+
+// importLokiLimits maps Loki limits to the LokiConfig limits
+func importLokiLimits(limits *section) map[string]interface{} {
+	result := map[string]interface{}{}
+	for key, field := range lokiLimits {
+		limits.copy(key, result, field)
+	}
+	limits.finish(map[string]string{
+		"retention_period": "per-tenant retention is not supported; set spec.global.retentionPolicies.logs",
+	})
+	return result
+}
+
+// importRules maps the alerting and recording rules of a rule file
+func importRules(result *Result, file *section) {
+	file.used["namespace"] = true
+	for _, group := range file.list("groups") {
+		name := group.string("name")
+		recording := map[string]interface{}{"name": name}
+		group.copy("interval", recording, "interval")
+		group.copy("limit", recording, "limit")
+
+		var recordingRules []interface{}
+		for _, rule := range group.list("rules") {
+			if alert := rule.string("alert"); alert != "" {
+				alerting := map[string]interface{}{
+					"name":       alert,
+					"expression": rule.string("expr"),
+					"duration":   "0s",
+				}
+				rule.copy("for", alerting, "duration")
+				if labels := rule.stringMap("labels"); labels != nil {
+					alerting["labels"] = labels
+				}
+				if annotations := rule.stringMap("annotations"); annotations != nil {
+					alerting["annotations"] = annotations
+				}
+				rule.finish(nil)
+				alerts, _ := result.object("spec", "alerting")["rules"].([]interface{})
+				result.set(append(alerts, alerting), "spec", "alerting", "rules")
+				continue
+			}
+
+			record := map[string]interface{}{"record": rule.string("record"), "expr": rule.string("expr")}
+			if labels := rule.stringMap("labels"); labels != nil {
+				record["labels"] = labels
+			}
+			rule.finish(nil)
+			recordingRules = append(recordingRules, record)
+		}
+
+		if len(recordingRules) > 0 {
+			recording["rules"] = recordingRules
+			groups, _ := result.object("spec", "alerting")["recordingRules"].([]interface{})
+			result.set(append(groups, recording), "spec", "alerting", "recordingRules")
+		}
+		group.finish(map[string]string{
+			"source_tenants": "Prometheus is single-tenant; federated rule groups are not supported",
+		})
+	}
+	file.finish(nil)
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func values(t *testing.T, data string) map[string]interface{} {
+	t.Helper()
+	result, err := ReadValues(strings.NewReader(data))
+	require.NoError(t, err)
+	return result
+}
+
+func TestImportGrafanaAgent(t *testing.T) {
+	result := ImportGrafanaCloud("production", "monitoring", GrafanaCloudSources{Agent: values(t, `
+server:
+  log_level: info
+metrics:
+  wal_directory: /tmp/wal
+  global:
+    scrape_interval: 60s
+    external_labels:
+      cluster: prod-eu
+    remote_write:
+    - url: https://prometheus-prod-01.grafana.net/api/prom/push
+      basic_auth:
+        username: "123456"
+        password_file: /var/run/secrets/grafana-cloud
+  configs:
+  - name: default
+    scrape_configs:
+    - job_name: node
+      static_configs:
+      - targets: ["localhost:9100"]
+    remote_write:
+    - url: https://prometheus-prod-01.grafana.net/api/prom/push
+    - url: https://mimir.example.com/api/v1/push
+      headers:
+        X-Scope-OrgID: team-a
+logs:
+  configs:
+  - name: default
+    clients:
+    - url: https://logs-prod-eu-west-0.grafana.net/loki/api/v1/push
+      tenant_id: team-a
+traces:
+  configs:
+  - name: default
+    remote_write:
+    - endpoint: tempo-eu-west-0.grafana.net:443
+integrations:
+  node_exporter:
+    enabled: true
+`)})
+
+	assert.Equal(t, map[string]interface{}{"cluster": "prod-eu"}, spec(t, result, "global.externalLabels"))
+	assert.Equal(t, true, spec(t, result, "components.prometheus.enabled"))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"url":       "https://prometheus-prod-01.grafana.net/api/prom/push",
+			"basicAuth": map[string]interface{}{"username": "123456"},
+		},
+		map[string]interface{}{"url": "https://mimir.example.com/api/v1/push"},
+	}, spec(t, result, "components.prometheus.remoteWrite"))
+	assert.Contains(t, spec(t, result, "components.prometheus.additionalScrapeConfigs"), "job_name: node")
+	assert.Equal(t, true, spec(t, result, "components.loki.enabled"))
+	assert.Equal(t, true, spec(t, result, "components.tempo.enabled"))
+
+	assert.ElementsMatch(t, []string{
+		"agent metrics.global.remote_write[0].basic_auth.password_file",
+		"agent metrics.configs[0].remote_write[1].headers",
+		"agent logs.configs[0].clients[0].url",
+		"agent traces.configs[0].remote_write[0].endpoint",
+		"agent integrations",
+	}, unsupportedFields(result))
+	assert.Empty(t, result.Related)
+}
+
+func TestImportPrometheusConfig(t *testing.T) {
+	result := ImportGrafanaCloud("production", "monitoring", GrafanaCloudSources{Agent: values(t, `
+global:
+  evaluation_interval: 30s
+  external_labels:
+    region: eu
+remote_write:
+- url: https://mimir.example.com/api/v1/push
+  tls_config:
+    ca_file: /etc/ssl/ca.pem
+rule_files:
+- /etc/prometheus/rules/*.yml
+`)})
+
+	assert.Equal(t, map[string]interface{}{"region": "eu"}, spec(t, result, "global.externalLabels"))
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"url":       "https://mimir.example.com/api/v1/push",
+		"tlsConfig": map[string]interface{}{"caFile": "/etc/ssl/ca.pem"},
+	}}, spec(t, result, "components.prometheus.remoteWrite"))
+	assert.Equal(t, []string{"agent global.evaluation_interval", "agent rule_files"}, unsupportedFields(result))
+}
+
+func TestImportMimirAndLoki(t *testing.T) {
+	result := ImportGrafanaCloud("production", "monitoring", GrafanaCloudSources{
+		Mimir: values(t, `
+multitenancy_enabled: true
+limits:
+  compactor_blocks_retention_period: 1y
+  ingestion_rate: 100000
+blocks_storage:
+  backend: s3
+`),
+		MimirOverrides: values(t, `
+overrides:
+  team-a:
+    ingestion_rate: 200000
+`),
+		Loki: values(t, `
+auth_enabled: true
+limits_config:
+  retention_period: 744h
+  ingestion_rate_mb: 8
+  max_query_length: 721h
+schema_config:
+  configs: [{from: "2024-01-01", store: tsdb}]
+`),
+		LokiOverrides: values(t, `
+overrides:
+  team-b:
+    ingestion_rate_mb: 16
+  team-a:
+    max_global_streams_per_user: 10000
+    retention_period: 24h
+`),
+	})
+
+	assert.Equal(t, "1y", spec(t, result, "global.retentionPolicies.metrics"))
+	assert.Equal(t, "744h", spec(t, result, "global.retentionPolicies.logs"))
+	assert.Equal(t, true, spec(t, result, "components.prometheus.enabled"))
+	assert.Equal(t, true, spec(t, result, "components.loki.enabled"))
+
+	require.Len(t, result.Related, 1)
+	lokiConfig := result.Related[0]
+	assert.Equal(t, "LokiConfig", lokiConfig.GetKind())
+	assert.Equal(t, map[string]interface{}{
+		"limits": map[string]interface{}{"ingestionRateMB": float64(8), "maxQueryLength": "721h"},
+		"multiTenancy": map[string]interface{}{
+			"enabled":        true,
+			"authEnabled":    true,
+			"tenantIdHeader": "X-Scope-OrgID",
+			"tenants": []interface{}{
+				map[string]interface{}{"id": "team-a", "limits": map[string]interface{}{"maxGlobalStreamsPerUser": float64(10000)}},
+				map[string]interface{}{"id": "team-b", "limits": map[string]interface{}{"ingestionRateMB": float64(16)}},
+			},
+		},
+	}, lokiConfig.Object["spec"])
+
+	assert.ElementsMatch(t, []string{
+		"mimir limits.ingestion_rate",
+		"mimir blocks_storage",
+		"mimir overrides overrides.team-a",
+		"loki schema_config",
+		"loki overrides overrides.team-a.retention_period",
+	}, unsupportedFields(result))
+
+	data, err := result.YAML()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "---\napiVersion: observability.io/v1beta1\nkind: LokiConfig\n")
+}
+
+func TestImportRules(t *testing.T) {
+	result := ImportGrafanaCloud("production", "monitoring", GrafanaCloudSources{Rules: map[string]map[string]interface{}{
+		"node.yaml": values(t, `
+namespace: node
+groups:
+- name: node.rules
+  interval: 1m
+  rules:
+  - record: instance:node_cpu:rate5m
+    expr: sum by (instance) (rate(node_cpu_seconds_total{mode!="idle"}[5m]))
+  - alert: NodeDown
+    expr: up{job="node"} == 0
+    for: 5m
+    labels:
+      severity: critical
+    annotations:
+      summary: Node {{ $labels.instance }} is down
+  - alert: Watchdog
+    expr: vector(1)
+- name: federated
+  source_tenants: [team-a, team-b]
+  rules:
+  - alert: TenantsDown
+    expr: absent(up)
+`),
+	}})
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name":        "NodeDown",
+			"expression":  `up{job="node"} == 0`,
+			"duration":    "5m",
+			"labels":      map[string]interface{}{"severity": "critical"},
+			"annotations": map[string]interface{}{"summary": "Node {{ $labels.instance }} is down"},
+		},
+		map[string]interface{}{"name": "Watchdog", "expression": "vector(1)", "duration": "0s"},
+		map[string]interface{}{"name": "TenantsDown", "expression": "absent(up)", "duration": "0s"},
+	}, spec(t, result, "alerting.rules"))
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"name":     "node.rules",
+		"interval": "1m",
+		"rules": []interface{}{map[string]interface{}{
+			"record": "instance:node_cpu:rate5m",
+			"expr":   `sum by (instance) (rate(node_cpu_seconds_total{mode!="idle"}[5m]))`,
+		}},
+	}}, spec(t, result, "alerting.recordingRules"))
+	assert.Equal(t, []string{"node.yaml groups[1].source_tenants"}, unsupportedFields(result))
+}
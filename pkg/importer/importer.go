@@ -0,0 +1,340 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+// Package importer generates ObservabilityPlatform specs from the
+// configuration of other monitoring stacks, so existing installations can be
+// migrated to the operator. Settings without an equivalent are reported
+// rather than dropped silently.
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// Unsupported is an imported setting which has no equivalent in the
+// generated spec
+type Unsupported struct {
+	// Source is the resource or file the setting was read from
+	Source string `json:"source"`
+	// Field is the path of the setting in its source
+	Field string `json:"field,omitempty"`
+	// Reason explains what to do about the setting
+	Reason string `json:"reason"`
+}
+
+func (u Unsupported) String() string {
+	if u.Field == "" {
+		return fmt.Sprintf("%s: %s", u.Source, u.Reason)
+	}
+	return fmt.Sprintf("%s: %s: %s", u.Source, u.Field, u.Reason)
+}
+
+// Result is an imported platform
+type Result struct {
+	// Platform is the generated ObservabilityPlatform
+	Platform *unstructured.Unstructured
+	// Related are fragments of further resources of the platform, such as
+	// the tenancy of a LokiConfig, to merge into the existing resources
+	Related []*unstructured.Unstructured
+	// Unsupported lists the settings which were not imported
+	Unsupported []Unsupported
+}
+
+// newResult returns a result with an empty platform
+func newResult(name, namespace string) *Result {
+	platform := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"components": map[string]interface{}{}},
+	}}
+	platform.SetAPIVersion("observability.io/v1beta1")
+	platform.SetKind("ObservabilityPlatform")
+	platform.SetName(name)
+	platform.SetNamespace(namespace)
+	return &Result{Platform: platform}
+}
+
+// YAML returns the platform and the related fragments as YAML documents
+func (r *Result) YAML() ([]byte, error) {
+	var buf bytes.Buffer
+	for i, obj := range append([]*unstructured.Unstructured{r.Platform}, r.Related...) {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// set sets a field of the platform, creating the objects on its path
+func (r *Result) set(value interface{}, path ...string) {
+	r.object(path[:len(path)-1]...)[path[len(path)-1]] = value
+}
+
+// object returns the object at a path of the platform, creating the objects
+// on the path
+func (r *Result) object(path ...string) map[string]interface{} {
+	object := r.Platform.Object
+	for _, key := range path {
+		next, ok := object[key].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			object[key] = next
+		}
+		object = next
+	}
+	return object
+}
+
+// unsupported records a setting which was not imported
+func (r *Result) unsupported(source, field, reason string) {
+	r.Unsupported = append(r.Unsupported, Unsupported{Source: source, Field: field, Reason: reason})
+}
+
+// ReadObjects reads the Kubernetes objects of a YAML stream, which may hold
+// several documents and lists
+func ReadObjects(r io.Reader) ([]*unstructured.Unstructured, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	var objects []*unstructured.Unstructured
+	for {
+		data, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(data)) == 0 {
+			continue
+		}
+		jsonData, err := yaml.YAMLToJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		if string(jsonData) == "null" {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(jsonData); err != nil {
+			return nil, err
+		}
+		if !obj.IsList() {
+			objects = append(objects, obj)
+			continue
+		}
+		list, err := obj.ToList()
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	}
+}
+
+// ReadValues reads a YAML configuration file, such as Helm values
+func ReadValues(r io.Reader) (map[string]interface{}, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// section is a part of an imported configuration being mapped. The keys a
+// mapping reads are marked used; finish reports the others as unsupported.
+type section struct {
+	result *Result
+	source string
+	path   string
+	values map[string]interface{}
+	used   map[string]bool
+}
+
+// newSection returns a section of values read from source
+func newSection(result *Result, source, path string, values map[string]interface{}) *section {
+	return &section{result: result, source: source, path: path, values: values, used: map[string]bool{}}
+}
+
+// field returns the path of a key of the section
+func (s *section) field(key string) string {
+	if s.path == "" {
+		return key
+	}
+	return s.path + "." + key
+}
+
+// get returns the value of a key and marks it used. Zero values are
+// reported as unset.
+func (s *section) get(key string) (interface{}, bool) {
+	s.used[key] = true
+	value, ok := s.values[key]
+	return value, ok && !isZero(value)
+}
+
+// has returns true if a key is set, without marking it used
+func (s *section) has(key string) bool {
+	value, ok := s.values[key]
+	return ok && !isZero(value)
+}
+
+// string returns the value of a string key
+func (s *section) string(key string) string {
+	value, _ := s.get(key)
+	str, _ := value.(string)
+	return str
+}
+
+// bool returns the value of a boolean key, or def if it is not set
+func (s *section) bool(key string, def bool) bool {
+	s.used[key] = true
+	if value, ok := s.values[key].(bool); ok {
+		return value
+	}
+	return def
+}
+
+// int returns the value of an integer key
+func (s *section) int(key string) (int64, bool) {
+	value, ok := s.get(key)
+	if !ok {
+		return 0, false
+	}
+	switch n := value.(type) {
+	case int64:
+		return n, true
+	case float64:
+		return int64(n), true
+	}
+	return 0, false
+}
+
+// stringMap returns the value of a key holding a map of strings
+func (s *section) stringMap(key string) map[string]interface{} {
+	value, _ := s.get(key)
+	values, _ := value.(map[string]interface{})
+	result := map[string]interface{}{}
+	for k, v := range values {
+		result[k] = fmt.Sprint(v)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// copy copies a key to an object unchanged
+func (s *section) copy(key string, to map[string]interface{}, as string) {
+	if value, ok := s.get(key); ok {
+		to[as] = value
+	}
+}
+
+// section returns the nested section of a key and marks the key used
+func (s *section) section(key string) *section {
+	value, _ := s.get(key)
+	values, _ := value.(map[string]interface{})
+	return newSection(s.result, s.source, s.field(key), values)
+}
+
+// list returns the nested sections of a key holding a list of objects
+func (s *section) list(key string) []*section {
+	value, _ := s.get(key)
+	items, _ := value.([]interface{})
+	sections := make([]*section, 0, len(items))
+	for i, item := range items {
+		values, _ := item.(map[string]interface{})
+		sections = append(sections, newSection(s.result, s.source, fmt.Sprintf("%s[%d]", s.field(key), i), values))
+	}
+	return sections
+}
+
+// unsupported reports a key as unsupported and marks it used
+func (s *section) unsupported(key, reason string) {
+	s.used[key] = true
+	s.result.unsupported(s.source, s.field(key), reason)
+}
+
+// finish reports the keys which are set but were not used, with the reason
+// given for the key, the reason given for "*" or a generic one
+func (s *section) finish(reasons map[string]string) {
+	keys := make([]string, 0, len(s.values))
+	for key := range s.values {
+		if !s.used[key] && s.has(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		reason, ok := reasons[key]
+		if !ok {
+			reason, ok = reasons["*"]
+		}
+		if !ok {
+			reason = "no equivalent setting"
+		}
+		s.result.unsupported(s.source, s.field(key), reason)
+	}
+}
+
+// isZero returns true for values which leave a setting at its default
+func isZero(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// imageTag returns the tag of an image, given as a reference or as Helm
+// image values, and reports a repository other than upstream as unsupported
+func imageTag(s *section, key, upstream string) string {
+	value, ok := s.get(key)
+	if !ok {
+		return ""
+	}
+
+	var repository, tag string
+	switch image := value.(type) {
+	case string:
+		repository = image
+		if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+			repository, tag = image[:i], image[i+1:]
+		}
+	case map[string]interface{}:
+		repository, _ = image["repository"].(string)
+		tag, _ = image["tag"].(string)
+	}
+
+	if repository != "" && !strings.HasSuffix(repository, upstream) {
+		s.result.unsupported(s.source, s.field(key), fmt.Sprintf("only the upstream %s image is deployed", upstream))
+	}
+	return tag
+}
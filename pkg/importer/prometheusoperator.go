@@ -0,0 +1,407 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// prometheusOperatorGroup is the API group of the prometheus-operator resources
+const prometheusOperatorGroup = "monitoring.coreos.com"
+
+// PrometheusOperatorSources are the resources of a prometheus-operator or
+// kube-prometheus-stack installation to import
+type PrometheusOperatorSources struct {
+	// Objects are Prometheus and Alertmanager resources. Objects of other
+	// kinds are ignored.
+	Objects []*unstructured.Unstructured
+	// Values are the Helm values of a kube-prometheus-stack release. The
+	// Prometheus and Alertmanager resources take precedence over the values
+	// they were rendered from.
+	Values map[string]interface{}
+}
+
+// Reasons for the prometheus-operator settings which are not imported
+var (
+	prometheusReasons = map[string]string{
+		"serviceMonitorSelector":                  "ServiceMonitor resources are not discovered; add their targets to additionalScrapeConfigs",
+		"serviceMonitorNamespaceSelector":         "ServiceMonitor resources are not discovered; add their targets to additionalScrapeConfigs",
+		"serviceMonitorSelectorNilUsesHelmValues": "ServiceMonitor resources are not discovered; add their targets to additionalScrapeConfigs",
+		"podMonitorSelector":                      "PodMonitor resources are not discovered; add their targets to additionalScrapeConfigs",
+		"podMonitorNamespaceSelector":             "PodMonitor resources are not discovered; add their targets to additionalScrapeConfigs",
+		"podMonitorSelectorNilUsesHelmValues":     "PodMonitor resources are not discovered; add their targets to additionalScrapeConfigs",
+		"probeSelector":                           "Probe resources are not discovered; add their targets to additionalScrapeConfigs",
+		"probeNamespaceSelector":                  "Probe resources are not discovered; add their targets to additionalScrapeConfigs",
+		"probeSelectorNilUsesHelmValues":          "Probe resources are not discovered; add their targets to additionalScrapeConfigs",
+		"ruleSelector":                            "PrometheusRule resources are not discovered; convert them to AlertingRule resources",
+		"ruleNamespaceSelector":                   "PrometheusRule resources are not discovered; convert them to AlertingRule resources",
+		"ruleSelectorNilUsesHelmValues":           "PrometheusRule resources are not discovered; convert them to AlertingRule resources",
+		"nodeSelector":                            "node selection applies to all components; set spec.global.nodeSelector",
+		"tolerations":                             "tolerations apply to all components; set spec.global.tolerations",
+		"alerting":                                "Prometheus sends alerts to the platform's Alertmanager",
+		"retentionSize":                           "size-based retention is not supported; set storage.retention",
+		"thanos":                                  "the Thanos sidecar settings differ; configure components.prometheus.thanos",
+	}
+	alertmanagerReasons = map[string]string{
+		"configSecret":                        "the configuration Secret is not imported; set spec.alerting.alertmanager.config",
+		"alertmanagerConfigSelector":          "AlertmanagerConfig resources are not discovered; set spec.alerting.alertmanager.config",
+		"alertmanagerConfigNamespaceSelector": "AlertmanagerConfig resources are not discovered; set spec.alerting.alertmanager.config",
+		"nodeSelector":                        "node selection applies to all components; set spec.global.nodeSelector",
+		"tolerations":                         "tolerations apply to all components; set spec.global.tolerations",
+	}
+	grafanaReasons = map[string]string{
+		"sidecar":                  "dashboards and datasources in labelled ConfigMaps are not discovered; use components.grafana.dashboardsFromGit and dataSources",
+		"dashboards":               "dashboards are not imported; use components.grafana.dashboardsFromGit or jsonnetDashboards",
+		"dashboardProviders":       "dashboards are not imported; use components.grafana.dashboardsFromGit or jsonnetDashboards",
+		"defaultDashboardsEnabled": "install the kubernetes mixin with spec.observabilityMixins.kubernetes",
+		"grafana.ini":              "the server configuration is managed by the operator",
+		"admin":                    "the admin credentials Secret is not imported; set adminUser and adminPassword",
+		"nodeSelector":             "node selection applies to all components; set spec.global.nodeSelector",
+		"tolerations":              "tolerations apply to all components; set spec.global.tolerations",
+	}
+	stackReasons = map[string]string{
+		"defaultRules":                 "the chart's rules are not imported; install the kubernetes mixin with spec.observabilityMixins.kubernetes",
+		"additionalPrometheusRulesMap": "convert the rules to AlertingRule resources",
+		"prometheusOperator":           "the operator manages the components directly",
+		"kube-state-metrics":           "exporters are not deployed by the platform",
+		"kubeStateMetrics":             "exporters are not deployed by the platform",
+		"prometheus-node-exporter":     "exporters are not deployed by the platform",
+		"nodeExporter":                 "exporters are not deployed by the platform",
+	}
+	// stackIgnored are chart values which have no bearing on the platform
+	stackIgnored = []string{"nameOverride", "fullnameOverride", "namespaceOverride", "commonLabels", "crds", "global", "cleanPrometheusOperatorObjectNames"}
+)
+
+// ImportPrometheusOperator generates a platform from the Prometheus and
+// Alertmanager resources and the Helm values of a prometheus-operator
+// installation. A platform runs a single Prometheus and Alertmanager; further
+// resources are reported as unsupported. Grafana is imported from the values
+// only, as it is not managed by prometheus-operator.
+func ImportPrometheusOperator(name, namespace string, sources PrometheusOperatorSources) *Result {
+	result := newResult(name, namespace)
+
+	var prometheuses, alertmanagers []*unstructured.Unstructured
+	for _, obj := range sources.Objects {
+		if obj.GroupVersionKind().Group != prometheusOperatorGroup {
+			continue
+		}
+		switch obj.GetKind() {
+		case "Prometheus":
+			prometheuses = append(prometheuses, obj)
+		case "Alertmanager":
+			alertmanagers = append(alertmanagers, obj)
+		}
+	}
+
+	var values *section
+	if sources.Values != nil {
+		values = newSection(result, "values", "", sources.Values)
+	}
+
+	switch {
+	case len(prometheuses) > 0:
+		result.set(importPrometheus(objectSpec(result, prometheuses[0])), "spec", "components", "prometheus")
+		for _, extra := range prometheuses[1:] {
+			result.unsupported(objectSource(extra), "", "a platform runs a single Prometheus; import it into another platform")
+		}
+	case values != nil:
+		prometheus := values.section("prometheus")
+		if prometheus.bool("enabled", true) {
+			result.set(importPrometheus(prometheus.section("prometheusSpec")), "spec", "components", "prometheus")
+		}
+		prometheus.finish(nil)
+	}
+
+	switch {
+	case len(alertmanagers) > 0:
+		result.set(importAlertmanager(objectSpec(result, alertmanagers[0])), "spec", "alerting", "alertmanager")
+		for _, extra := range alertmanagers[1:] {
+			result.unsupported(objectSource(extra), "", "a platform runs a single Alertmanager; import it into another platform")
+		}
+	case values != nil:
+		alertmanager := values.section("alertmanager")
+		if alertmanager.bool("enabled", true) {
+			result.set(importAlertmanager(alertmanager.section("alertmanagerSpec")), "spec", "alerting", "alertmanager")
+		}
+		alertmanager.finish(map[string]string{
+			"config": "the routes and receivers are not imported; set spec.alerting.alertmanager.config",
+		})
+	}
+
+	if values != nil {
+		grafana := values.section("grafana")
+		if grafana.bool("enabled", true) {
+			result.set(importGrafana(grafana), "spec", "components", "grafana")
+		} else {
+			grafana.used = allUsed(grafana.values)
+		}
+		grafana.finish(grafanaReasons)
+
+		// The values of rendered resources are superseded by the resources
+		values.used["prometheus"], values.used["alertmanager"] = true, true
+		for _, key := range stackIgnored {
+			values.used[key] = true
+		}
+		values.finish(stackReasons)
+	}
+	return result
+}
+
+// objectSource names an object in reports
+func objectSource(obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+}
+
+// objectSpec returns the spec section of an object
+func objectSpec(result *Result, obj *unstructured.Unstructured) *section {
+	spec, _, _ := unstructured.NestedMap(obj.Object, "spec")
+	return newSection(result, objectSource(obj), "spec", spec)
+}
+
+// importPrometheus maps a Prometheus spec, as in the resource or in the
+// prometheusSpec of the chart's values
+func importPrometheus(spec *section) map[string]interface{} {
+	prometheus := map[string]interface{}{"enabled": true}
+	setVersion(prometheus, spec, "prometheus/prometheus", true)
+	if replicas, ok := spec.int("replicas"); ok {
+		prometheus["replicas"] = replicas
+	}
+	if resources := importResources(spec.section("resources")); resources != nil {
+		prometheus["resources"] = resources
+	}
+	storage := importStorage(spec.section("storage"))
+	if retention := spec.string("retention"); retention != "" {
+		storage["retention"] = retention
+	}
+	if len(storage) > 0 {
+		prometheus["storage"] = storage
+	}
+	if labels := spec.stringMap("externalLabels"); labels != nil {
+		prometheus["externalLabels"] = labels
+	}
+
+	var remoteWrites []interface{}
+	for _, remoteWrite := range spec.list("remoteWrite") {
+		remoteWrites = append(remoteWrites, importRemoteWrite(remoteWrite))
+	}
+	if len(remoteWrites) > 0 {
+		prometheus["remoteWrite"] = remoteWrites
+	}
+
+	if value, ok := spec.values["additionalScrapeConfigs"]; ok && !isZero(value) {
+		// The chart takes the scrape configs inline, the resource from a Secret
+		if configs, isList := value.([]interface{}); isList {
+			if data, err := yaml.Marshal(configs); err == nil {
+				spec.used["additionalScrapeConfigs"] = true
+				prometheus["additionalScrapeConfigs"] = string(data)
+			}
+		} else {
+			spec.unsupported("additionalScrapeConfigs", "the scrape configs Secret is not imported; copy its contents to components.prometheus.additionalScrapeConfigs")
+		}
+	}
+
+	importScheduling(spec, prometheus)
+	spec.finish(prometheusReasons)
+	return prometheus
+}
+
+// importAlertmanager maps an Alertmanager spec, as in the resource or in the
+// alertmanagerSpec of the chart's values
+func importAlertmanager(spec *section) map[string]interface{} {
+	alertmanager := map[string]interface{}{"enabled": true}
+	setVersion(alertmanager, spec, "prometheus/alertmanager", true)
+	if replicas, ok := spec.int("replicas"); ok {
+		alertmanager["replicas"] = replicas
+	}
+	if resources := importResources(spec.section("resources")); resources != nil {
+		alertmanager["resources"] = resources
+	}
+	storage := importStorage(spec.section("storage"))
+	if retention := spec.string("retention"); retention != "" {
+		storage["retention"] = retention
+	}
+	if len(storage) > 0 {
+		alertmanager["storage"] = storage
+	}
+	spec.finish(alertmanagerReasons)
+	return alertmanager
+}
+
+// importGrafana maps the grafana values of the chart
+func importGrafana(values *section) map[string]interface{} {
+	grafana := map[string]interface{}{"enabled": true}
+	setVersion(grafana, values, "grafana/grafana", false)
+	if replicas, ok := values.int("replicas"); ok {
+		grafana["replicas"] = replicas
+	}
+	if resources := importResources(values.section("resources")); resources != nil {
+		grafana["resources"] = resources
+	}
+	values.copy("adminUser", grafana, "adminUser")
+	values.copy("adminPassword", grafana, "adminPassword")
+
+	if ingressValues := values.section("ingress"); ingressValues.bool("enabled", false) {
+		ingress := map[string]interface{}{"enabled": true}
+		ingressValues.copy("ingressClassName", ingress, "className")
+		if annotations := ingressValues.stringMap("annotations"); annotations != nil {
+			ingress["annotations"] = annotations
+		}
+		if value, ok := ingressValues.get("hosts"); ok {
+			hosts, _ := value.([]interface{})
+			if len(hosts) > 0 {
+				ingress["host"] = fmt.Sprint(hosts[0])
+			}
+			if len(hosts) > 1 {
+				values.result.unsupported(values.source, ingressValues.field("hosts"), "a single host is supported; the first is imported")
+			}
+		}
+		if tls := ingressValues.list("tls"); len(tls) > 0 {
+			ingress["tls"] = map[string]interface{}{"enabled": true, "secretName": tls[0].string("secretName")}
+			tls[0].used["hosts"] = true
+			tls[0].finish(nil)
+		}
+		ingressValues.finish(nil)
+		grafana["ingress"] = ingress
+	} else {
+		ingressValues.used = allUsed(ingressValues.values)
+	}
+
+	if persistenceValues := values.section("persistence"); persistenceValues.bool("enabled", false) {
+		persistence := map[string]interface{}{"enabled": true}
+		persistenceValues.copy("size", persistence, "size")
+		persistenceValues.copy("storageClassName", persistence, "storageClassName")
+		persistenceValues.used["type"] = true
+		persistenceValues.finish(nil)
+		grafana["persistence"] = persistence
+	} else {
+		persistenceValues.used = allUsed(persistenceValues.values)
+	}
+
+	var dataSources []interface{}
+	for _, dataSourceValues := range values.list("additionalDataSources") {
+		dataSource := map[string]interface{}{}
+		for _, key := range []string{"name", "type", "url", "access", "isDefault"} {
+			dataSourceValues.copy(key, dataSource, key)
+		}
+		dataSourceValues.finish(nil)
+		dataSources = append(dataSources, dataSource)
+	}
+	if len(dataSources) > 0 {
+		grafana["dataSources"] = dataSources
+	}
+
+	values.used["enabled"] = true
+	return grafana
+}
+
+// setVersion sets the version of a component from the version or the image
+// tag of its spec. Prometheus and Alertmanager versions start with a v,
+// Grafana's do not.
+func setVersion(component map[string]interface{}, spec *section, upstream string, prefixed bool) {
+	version := spec.string("version")
+	if tag := imageTag(spec, "image", upstream); version == "" {
+		version = tag
+	}
+	if version == "" {
+		return
+	}
+	version = strings.TrimPrefix(version, "v")
+	if prefixed {
+		version = "v" + version
+	}
+	component["version"] = version
+}
+
+// importResources maps resource requirements
+func importResources(resources *section) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, key := range []string{"requests", "limits"} {
+		list := resources.section(key)
+		values := map[string]interface{}{}
+		for _, name := range []string{"cpu", "memory"} {
+			if value, ok := list.get(name); ok {
+				values[name] = fmt.Sprint(value)
+			}
+		}
+		list.finish(nil)
+		if len(values) > 0 {
+			result[key] = values
+		}
+	}
+	resources.finish(nil)
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// importStorage maps the volume claim template of a storage spec
+func importStorage(storage *section) map[string]interface{} {
+	result := map[string]interface{}{}
+	claimSpec := storage.section("volumeClaimTemplate").section("spec")
+	claimSpec.copy("storageClassName", result, "storageClassName")
+	if size := claimSpec.section("resources").section("requests").string("storage"); size != "" {
+		result["size"] = size
+	}
+	claimSpec.used["accessModes"] = true
+	claimSpec.finish(nil)
+	storage.finish(map[string]string{
+		"emptyDir":  "components always store their data on persistent volumes",
+		"ephemeral": "components always store their data on persistent volumes",
+	})
+	return result
+}
+
+// importRemoteWrite maps a remote write endpoint
+func importRemoteWrite(spec *section) map[string]interface{} {
+	remoteWrite := map[string]interface{}{}
+	spec.copy("url", remoteWrite, "url")
+	spec.copy("name", remoteWrite, "name")
+	spec.copy("bearerToken", remoteWrite, "bearerToken")
+
+	tlsValues := spec.section("tlsConfig")
+	tls := map[string]interface{}{}
+	for _, key := range []string{"insecureSkipVerify", "caFile", "certFile", "keyFile"} {
+		tlsValues.copy(key, tls, key)
+	}
+	tlsValues.finish(map[string]string{
+		"ca":        "certificates from Secrets are not imported; mount them and set caFile",
+		"cert":      "certificates from Secrets are not imported; mount them and set certFile",
+		"keySecret": "keys from Secrets are not imported; mount them and set keyFile",
+	})
+	if len(tls) > 0 {
+		remoteWrite["tlsConfig"] = tls
+	}
+
+	spec.finish(map[string]string{
+		"basicAuth":           "credentials from Secrets are not imported; set basicAuth",
+		"writeRelabelConfigs": "relabelling of remote writes is not supported",
+		"queueConfig":         "the remote write queue is tuned by the operator",
+	})
+	return remoteWrite
+}
+
+// importScheduling copies the scheduling settings a component supports
+func importScheduling(spec *section, component map[string]interface{}) {
+	spec.copy("affinity", component, "affinity")
+	spec.copy("priorityClassName", component, "priorityClassName")
+	spec.copy("topologySpreadConstraints", component, "topologySpreadConstraints")
+}
+
+// allUsed marks all keys of a section's values used, for sections which are
+// disabled as a whole
+func allUsed(values map[string]interface{}) map[string]bool {
+	used := make(map[string]bool, len(values))
+	for key := range values {
+		used[key] = true
+	}
+	return used
+}
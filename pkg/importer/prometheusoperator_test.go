@@ -0,0 +1,230 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+const prometheusOperatorResources = `
+apiVersion: monitoring.coreos.com/v1
+kind: Prometheus
+metadata:
+  name: k8s
+  namespace: monitoring
+spec:
+  version: 2.47.0
+  replicas: 2
+  retention: 15d
+  retentionSize: 50GB
+  externalLabels:
+    cluster: prod-eu
+  resources:
+    requests:
+      cpu: 500m
+      memory: 2Gi
+  storage:
+    volumeClaimTemplate:
+      spec:
+        storageClassName: fast-ssd
+        resources:
+          requests:
+            storage: 100Gi
+  remoteWrite:
+  - url: https://mimir.example.com/api/v1/push
+    name: mimir
+    basicAuth:
+      username:
+        name: mimir-credentials
+        key: username
+  serviceMonitorSelector:
+    matchLabels:
+      release: kube-prometheus-stack
+  priorityClassName: monitoring
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: monitoring.coreos.com/v1
+  kind: Alertmanager
+  metadata:
+    name: main
+    namespace: monitoring
+  spec:
+    image: quay.io/prometheus/alertmanager:v0.26.0
+    replicas: 3
+    configSecret: alertmanager-main
+- apiVersion: monitoring.coreos.com/v1
+  kind: Prometheus
+  metadata:
+    name: user-workload
+    namespace: monitoring
+  spec: {}
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: unrelated
+`
+
+const kubePrometheusStackValues = `
+nameOverride: kps
+defaultRules:
+  create: true
+prometheus:
+  enabled: true
+  prometheusSpec:
+    image:
+      registry: quay.io
+      repository: prometheus/prometheus
+      tag: v2.48.0
+    retention: 10d
+    ruleSelectorNilUsesHelmValues: false
+    additionalScrapeConfigs:
+    - job_name: external
+      static_configs:
+      - targets: ["10.0.0.1:9100"]
+alertmanager:
+  enabled: false
+grafana:
+  image:
+    repository: grafana/grafana
+    tag: 10.2.2
+  adminPassword: secret
+  ingress:
+    enabled: true
+    ingressClassName: nginx
+    hosts: [grafana.example.com, grafana.internal]
+    tls:
+    - secretName: grafana-tls
+      hosts: [grafana.example.com]
+  persistence:
+    enabled: true
+    size: 10Gi
+  sidecar:
+    dashboards:
+      enabled: true
+  additionalDataSources:
+  - name: Loki
+    type: loki
+    url: http://loki:3100
+    jsonData:
+      maxLines: 1000
+`
+
+func spec(t *testing.T, result *Result, path string) interface{} {
+	t.Helper()
+	var value interface{} = result.Platform.Object["spec"]
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		require.True(t, ok, "no object at %s", key)
+		value = object[key]
+	}
+	return value
+}
+
+func unsupportedFields(result *Result) []string {
+	var fields []string
+	for _, u := range result.Unsupported {
+		fields = append(fields, u.Source+" "+u.Field)
+	}
+	return fields
+}
+
+func TestImportPrometheusOperatorResources(t *testing.T) {
+	objects, err := ReadObjects(strings.NewReader(prometheusOperatorResources))
+	require.NoError(t, err)
+	require.Len(t, objects, 4)
+
+	result := ImportPrometheusOperator("production", "monitoring", PrometheusOperatorSources{Objects: objects})
+	assert.Equal(t, "ObservabilityPlatform", result.Platform.GetKind())
+	assert.Equal(t, "production", result.Platform.GetName())
+
+	assert.Equal(t, "v2.47.0", spec(t, result, "components.prometheus.version"))
+	assert.Equal(t, int64(2), spec(t, result, "components.prometheus.replicas"))
+	assert.Equal(t, map[string]interface{}{"size": "100Gi", "storageClassName": "fast-ssd", "retention": "15d"},
+		spec(t, result, "components.prometheus.storage"))
+	assert.Equal(t, map[string]interface{}{"requests": map[string]interface{}{"cpu": "500m", "memory": "2Gi"}},
+		spec(t, result, "components.prometheus.resources"))
+	assert.Equal(t, map[string]interface{}{"cluster": "prod-eu"}, spec(t, result, "components.prometheus.externalLabels"))
+	assert.Equal(t, []interface{}{map[string]interface{}{"url": "https://mimir.example.com/api/v1/push", "name": "mimir"}},
+		spec(t, result, "components.prometheus.remoteWrite"))
+	assert.Equal(t, "monitoring", spec(t, result, "components.prometheus.priorityClassName"))
+
+	assert.Equal(t, "v0.26.0", spec(t, result, "alerting.alertmanager.version"))
+	assert.Equal(t, int64(3), spec(t, result, "alerting.alertmanager.replicas"))
+	assert.Nil(t, spec(t, result, "components.grafana"))
+
+	assert.ElementsMatch(t, []string{
+		"Prometheus monitoring/k8s spec.retentionSize",
+		"Prometheus monitoring/k8s spec.remoteWrite[0].basicAuth",
+		"Prometheus monitoring/k8s spec.serviceMonitorSelector",
+		"Alertmanager monitoring/main spec.configSecret",
+		"Prometheus monitoring/user-workload ",
+	}, unsupportedFields(result))
+}
+
+func TestImportKubePrometheusStackValues(t *testing.T) {
+	values, err := ReadValues(strings.NewReader(kubePrometheusStackValues))
+	require.NoError(t, err)
+
+	result := ImportPrometheusOperator("production", "monitoring", PrometheusOperatorSources{Values: values})
+
+	assert.Equal(t, "v2.48.0", spec(t, result, "components.prometheus.version"))
+	assert.Equal(t, map[string]interface{}{"retention": "10d"}, spec(t, result, "components.prometheus.storage"))
+	scrapeConfigs := []interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(spec(t, result, "components.prometheus.additionalScrapeConfigs").(string)), &scrapeConfigs))
+	assert.Len(t, scrapeConfigs, 1)
+	assert.Nil(t, spec(t, result, "alerting"))
+
+	assert.Equal(t, "10.2.2", spec(t, result, "components.grafana.version"))
+	assert.Equal(t, "secret", spec(t, result, "components.grafana.adminPassword"))
+	assert.Equal(t, map[string]interface{}{
+		"enabled":   true,
+		"className": "nginx",
+		"host":      "grafana.example.com",
+		"tls":       map[string]interface{}{"enabled": true, "secretName": "grafana-tls"},
+	}, spec(t, result, "components.grafana.ingress"))
+	assert.Equal(t, map[string]interface{}{"enabled": true, "size": "10Gi"}, spec(t, result, "components.grafana.persistence"))
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "Loki", "type": "loki", "url": "http://loki:3100"}},
+		spec(t, result, "components.grafana.dataSources"))
+
+	assert.ElementsMatch(t, []string{
+		"values grafana.ingress.hosts",
+		"values grafana.sidecar",
+		"values grafana.additionalDataSources[0].jsonData",
+		"values defaultRules",
+	}, unsupportedFields(result))
+}
+
+func TestImportPrometheusOperatorCustomImage(t *testing.T) {
+	values, err := ReadValues(strings.NewReader(`
+prometheus:
+  prometheusSpec:
+    image:
+      repository: registry.example.com/mirror/prom
+      tag: v2.48.0
+alertmanager:
+  enabled: false
+grafana:
+  enabled: false
+  adminPassword: ignored
+`))
+	require.NoError(t, err)
+
+	result := ImportPrometheusOperator("production", "monitoring", PrometheusOperatorSources{Values: values})
+	assert.Equal(t, "v2.48.0", spec(t, result, "components.prometheus.version"))
+	assert.Equal(t, []string{"values prometheus.prometheusSpec.image"}, unsupportedFields(result))
+
+	platform, err := result.YAML()
+	require.NoError(t, err)
+	assert.Contains(t, string(platform), "kind: ObservabilityPlatform")
+}
@@ -0,0 +1,362 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+// Package lint checks ObservabilityPlatform manifests offline with the
+// validation the admission webhook applies, so invalid specs are caught
+// before they reach a cluster, for example in pre-commit hooks and CI.
+// Findings carry a severity and, where one can be derived, a suggested fix.
+package lint
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/deprecation"
+	"github.com/gunjanjp/gunj-operator/internal/webhooks"
+)
+
+// Severity is how serious a finding is
+type Severity string
+
+const (
+	// SeverityError findings are rejected by the admission webhook
+	SeverityError Severity = "error"
+	// SeverityWarning findings are accepted but should be addressed
+	SeverityWarning Severity = "warning"
+	// SeverityInfo findings are informational
+	SeverityInfo Severity = "info"
+)
+
+// rank orders severities from least to most serious
+var rank = map[Severity]int{SeverityInfo: 0, SeverityWarning: 1, SeverityError: 2}
+
+// AtLeast returns true if s is as serious as min or more
+func (s Severity) AtLeast(min Severity) bool {
+	return rank[s] >= rank[min]
+}
+
+// ParseSeverity parses a severity name
+func ParseSeverity(name string) (Severity, error) {
+	severity := Severity(strings.ToLower(name))
+	if _, ok := rank[severity]; !ok {
+		return "", fmt.Errorf("unknown severity %q: must be error, warning or info", name)
+	}
+	return severity, nil
+}
+
+// Finding is a problem found in a manifest
+type Finding struct {
+	// File is the linted file
+	File string `json:"file"`
+	// Document is the 1-based index of the YAML document in the file
+	Document int `json:"document"`
+	// Name is the name of the platform
+	Name string `json:"name,omitempty"`
+	// Rule identifies the check which produced the finding
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	// Field is the path of the offending field
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+	// Fix is a suggested fix, empty if none can be derived
+	Fix string `json:"fix,omitempty"`
+}
+
+func (f Finding) String() string {
+	location := fmt.Sprintf("%s:%d", f.File, f.Document)
+	if f.Name != "" {
+		location += " (" + f.Name + ")"
+	}
+	text := fmt.Sprintf("%s: %s [%s]", location, f.Severity, f.Rule)
+	if f.Field != "" {
+		text += " " + f.Field + ":"
+	}
+	text += " " + f.Message
+	if f.Fix != "" {
+		text += "\n    fix: " + f.Fix
+	}
+	return text
+}
+
+// Rules producing findings
+const (
+	RuleParse        = "parse"
+	RuleAPIVersion   = "api-version"
+	RuleUnknownField = "unknown-field"
+	RuleValidation   = "validation"
+	RuleDeprecation  = "deprecation"
+)
+
+// Linter lints ObservabilityPlatform manifests
+type Linter struct {
+	validator    *webhooks.ConfigurationValidator
+	deprecations *deprecation.Checker
+}
+
+// New returns a linter applying the admission webhook's validation
+func New() *Linter {
+	return &Linter{
+		validator:    webhooks.NewConfigurationValidator(logr.Discard()),
+		deprecations: deprecation.NewChecker(),
+	}
+}
+
+// Lint lints the YAML documents of a file. Documents of other kinds are
+// skipped. Findings are sorted by document, severity and field.
+func (l *Linter) Lint(file string, r io.Reader) ([]Finding, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	var findings []Finding
+	for document := 1; ; document++ {
+		data, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		if len(bytes.TrimSpace(data)) == 0 {
+			document--
+			continue
+		}
+		for _, finding := range l.lintDocument(data) {
+			finding.File, finding.Document = file, document
+			findings = append(findings, finding)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Document != b.Document {
+			return a.Document < b.Document
+		}
+		if rank[a.Severity] != rank[b.Severity] {
+			return rank[a.Severity] > rank[b.Severity]
+		}
+		return a.Field < b.Field
+	})
+	return findings, nil
+}
+
+// lintDocument lints a single YAML document
+func (l *Linter) lintDocument(data []byte) []Finding {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return []Finding{{Rule: RuleParse, Severity: SeverityError, Message: err.Error()}}
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(jsonData); err != nil {
+		return []Finding{{Rule: RuleParse, Severity: SeverityError, Message: err.Error()}}
+	}
+	if obj.GetKind() != "ObservabilityPlatform" {
+		return nil
+	}
+
+	name := obj.GetName()
+	var findings []Finding
+	add := func(finding Finding) {
+		finding.Name = name
+		findings = append(findings, finding)
+	}
+
+	if obj.GetAPIVersion() != observabilityv1beta1.GroupVersion.String() {
+		add(Finding{
+			Rule:     RuleAPIVersion,
+			Severity: SeverityError,
+			Field:    "apiVersion",
+			Message:  fmt.Sprintf("only %s manifests are linted", observabilityv1beta1.GroupVersion),
+			Fix:      fmt.Sprintf("convert the manifest with `gunj-migrate migrate --to %s`", observabilityv1beta1.GroupVersion.Version),
+		})
+		return findings
+	}
+
+	platform := &observabilityv1beta1.ObservabilityPlatform{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(platform); err != nil {
+		// The API server drops unknown fields, so a typo silently loses a setting
+		if unknown := unknownField(err); unknown != "" {
+			finding := Finding{
+				Rule:     RuleUnknownField,
+				Severity: SeverityError,
+				Field:    unknown,
+				Message:  "unknown field, which the API server drops",
+			}
+			if suggestion := closestField(unknown); suggestion != "" {
+				finding.Fix = fmt.Sprintf("did you mean %q?", suggestion)
+			}
+			add(finding)
+		}
+		// Lint the known fields regardless
+		platform = &observabilityv1beta1.ObservabilityPlatform{}
+		if err := json.Unmarshal(jsonData, platform); err != nil {
+			add(Finding{Rule: RuleParse, Severity: SeverityError, Message: err.Error()})
+			return findings
+		}
+	}
+
+	// Validate the platform as admitted, with the mutating webhook's defaults
+	platform.Default()
+	for _, err := range platform.ValidateOffline(l.validator) {
+		add(Finding{
+			Rule:     RuleValidation,
+			Severity: SeverityError,
+			Field:    err.Field,
+			Message:  validationMessage(err),
+			Fix:      fixFor(err),
+		})
+	}
+
+	if result, err := l.deprecations.Check(obj, obj.GroupVersionKind()); err == nil {
+		for _, warning := range result.Warnings {
+			severity := SeverityWarning
+			if warning.Severity == deprecation.SeverityInfo {
+				severity = SeverityInfo
+			}
+			add(Finding{
+				Rule:     RuleDeprecation,
+				Severity: severity,
+				Field:    warning.Field,
+				Message:  warning.Message,
+				Fix:      warning.MigrationGuide,
+			})
+		}
+	}
+	return findings
+}
+
+// validationMessage returns the message of a validation error without its field
+func validationMessage(err *field.Error) string {
+	message := err.ErrorBody()
+	if err.Type == field.ErrorTypeRequired || err.Type == field.ErrorTypeForbidden {
+		if err.Detail != "" {
+			return err.Detail
+		}
+	}
+	return message
+}
+
+// fixFor derives a suggested fix from a validation error
+func fixFor(err *field.Error) string {
+	switch err.Type {
+	case field.ErrorTypeRequired:
+		return fmt.Sprintf("set %s", err.Field)
+	case field.ErrorTypeNotSupported:
+		if supported := strings.TrimPrefix(err.Detail, "supported values: "); supported != err.Detail {
+			return fmt.Sprintf("set %s to one of %s", err.Field, supported)
+		}
+	case field.ErrorTypeDuplicate:
+		return fmt.Sprintf("remove the duplicate %s", err.Field)
+	case field.ErrorTypeForbidden:
+		return fmt.Sprintf("remove %s", err.Field)
+	case field.ErrorTypeTooLong, field.ErrorTypeTooMany:
+		return fmt.Sprintf("shorten %s", err.Field)
+	}
+	return ""
+}
+
+// unknownFieldRegex matches the unknown field errors of encoding/json
+var unknownFieldRegex = regexp.MustCompile(`^json: unknown field "(.+)"$`)
+
+// unknownField returns the name of the unknown field of a decoding error
+func unknownField(err error) string {
+	if match := unknownFieldRegex.FindStringSubmatch(err.Error()); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// knownFields are the JSON field names of the ObservabilityPlatform types
+var knownFields = jsonFields(reflect.TypeOf(observabilityv1beta1.ObservabilityPlatform{}))
+
+// jsonFields collects the JSON field names of a type and the types it nests
+func jsonFields(t reflect.Type) []string {
+	seen := map[reflect.Type]bool{}
+	names := map[string]bool{}
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct || seen[t] {
+			return
+		}
+		seen[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := strings.Split(f.Tag.Get("json"), ",")[0]
+			if tag != "" && tag != "-" {
+				names[tag] = true
+			}
+			walk(f.Type)
+		}
+	}
+	walk(t)
+
+	fields := make([]string, 0, len(names))
+	for name := range names {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// closestField returns the known field name closest to an unknown one, or
+// the empty string if none is close enough to be a typo
+func closestField(unknown string) string {
+	best, bestDistance := "", 3
+	for _, name := range knownFields {
+		if d := distance(strings.ToLower(unknown), strings.ToLower(name)); d < bestDistance {
+			best, bestDistance = name, d
+		}
+	}
+	return best
+}
+
+// distance returns the Levenshtein distance of two strings
+func distance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minOf(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func minOf(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
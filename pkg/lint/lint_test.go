@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func lint(t *testing.T, manifest string) []Finding {
+	t.Helper()
+	findings, err := New().Lint("platform.yaml", strings.NewReader(manifest))
+	require.NoError(t, err)
+	return findings
+}
+
+func findingsOf(findings []Finding, rule string) []Finding {
+	var result []Finding
+	for _, finding := range findings {
+		if finding.Rule == rule {
+			result = append(result, finding)
+		}
+	}
+	return result
+}
+
+func TestLintUnknownField(t *testing.T) {
+	findings := lint(t, `
+apiVersion: observability.io/v1beta1
+kind: ObservabilityPlatform
+metadata:
+  name: production
+spec:
+  components:
+    prometheus:
+      enabled: true
+      retension: 30d
+`)
+
+	unknown := findingsOf(findings, RuleUnknownField)
+	require.Len(t, unknown, 1)
+	assert.Equal(t, "production", unknown[0].Name)
+	assert.Equal(t, SeverityError, unknown[0].Severity)
+	assert.Equal(t, `did you mean "retention"?`, unknown[0].Fix)
+}
+
+func TestLintAPIVersion(t *testing.T) {
+	findings := lint(t, `
+apiVersion: observability.io/v1alpha1
+kind: ObservabilityPlatform
+metadata:
+  name: legacy
+spec: {}
+`)
+
+	require.Len(t, findings, 1)
+	assert.Equal(t, RuleAPIVersion, findings[0].Rule)
+	assert.Contains(t, findings[0].Fix, "gunj-migrate migrate --to v1beta1")
+}
+
+func TestLintMultipleDocuments(t *testing.T) {
+	findings := lint(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+apiVersion: observability.io/v1beta1
+kind: ObservabilityPlatform
+metadata:
+  name: first
+spec:
+  components:
+    prometheus:
+      enabled: true
+---
+apiVersion: observability.io/v1beta1
+kind: ObservabilityPlatform
+metadata:
+  name: second
+spec:
+  global:
+    logLevl: debug
+`)
+
+	for _, finding := range findings {
+		assert.Equal(t, "platform.yaml", finding.File)
+		assert.NotEqual(t, 1, finding.Document, "documents of other kinds are skipped")
+	}
+	unknown := findingsOf(findings, RuleUnknownField)
+	require.Len(t, unknown, 1)
+	assert.Equal(t, 3, unknown[0].Document)
+	assert.Equal(t, "second", unknown[0].Name)
+}
+
+func TestLintParseError(t *testing.T) {
+	findings := lint(t, "kind: [ObservabilityPlatform\n")
+	require.Len(t, findings, 1)
+	assert.Equal(t, RuleParse, findings[0].Rule)
+}
+
+func TestFixFor(t *testing.T) {
+	path := field.NewPath("spec", "global", "logLevel")
+	assert.Equal(t, `set spec.global.logLevel to one of "debug", "info"`,
+		fixFor(field.NotSupported(path, "verbose", []string{"debug", "info"})))
+	assert.Equal(t, "set spec.global.logLevel", fixFor(field.Required(path, "")))
+	assert.Equal(t, "remove spec.global.logLevel", fixFor(field.Forbidden(path, "not allowed")))
+	assert.Empty(t, fixFor(field.Invalid(path, "x", "invalid")))
+}
+
+func TestClosestField(t *testing.T) {
+	assert.Equal(t, "retention", closestField("retension"))
+	assert.Equal(t, "enabled", closestField("enabeld"))
+	assert.Empty(t, closestField("somethingUnrelated"))
+
+	assert.Equal(t, 0, distance("loki", "loki"))
+	assert.Equal(t, 3, distance("kitten", "sitting"))
+}
+
+func TestSeverity(t *testing.T) {
+	severity, err := ParseSeverity("Warning")
+	require.NoError(t, err)
+	assert.Equal(t, SeverityWarning, severity)
+
+	_, err = ParseSeverity("fatal")
+	assert.Error(t, err)
+
+	assert.True(t, SeverityError.AtLeast(SeverityWarning))
+	assert.True(t, SeverityWarning.AtLeast(SeverityWarning))
+	assert.False(t, SeverityInfo.AtLeast(SeverityWarning))
+}
+
+func TestFindingString(t *testing.T) {
+	finding := Finding{
+		File:     "platform.yaml",
+		Document: 2,
+		Name:     "production",
+		Rule:     RuleValidation,
+		Severity: SeverityError,
+		Field:    "spec.global.logLevel",
+		Message:  "Unsupported value",
+		Fix:      "set spec.global.logLevel to one of \"info\"",
+	}
+	assert.Equal(t, "platform.yaml:2 (production): error [validation] spec.global.logLevel: Unsupported value\n    fix: set spec.global.logLevel to one of \"info\"", finding.String())
+}
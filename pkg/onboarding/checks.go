@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package onboarding
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Severity is the severity of a pre-check finding
+type Severity string
+
+const (
+	// Error findings prevent the platform from being deployed as generated
+	Error Severity = "error"
+	// Warning findings need attention but do not prevent the deployment
+	Warning Severity = "warning"
+)
+
+// Finding is the result of a failed pre-check
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Check    string   `json:"check"`
+	Message  string   `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Severity, f.Check, f.Message)
+}
+
+// HasErrors reports whether any of the findings is an error
+func HasErrors(findings []Finding) bool {
+	for _, finding := range findings {
+		if finding.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+// storageClassDefaultAnnotation marks the default StorageClass of a cluster
+const storageClassDefaultAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+// Check checks that the cluster can run the platform of the answers: the
+// storage and ingress classes and the SSO Secret exist, the platform does not
+// and the nodes can fit the profile's requests. Checks whose resources cannot
+// be read are reported as warnings.
+func Check(ctx context.Context, c client.Reader, a Answers) []Finding {
+	var findings []Finding
+	add := func(severity Severity, check, format string, args ...interface{}) {
+		findings = append(findings, Finding{Severity: severity, Check: check, Message: fmt.Sprintf(format, args...)})
+	}
+	profile, _ := ProfileFor(a.Size)
+	findings = append(findings, CheckTarget(ctx, c, a.Name, a.Namespace)...)
+
+	if hasStorage(profile, a.Components) {
+		findings = append(findings, checkStorageClass(ctx, c, a.StorageClass)...)
+	}
+
+	if a.IngressHost != "" && a.IngressClass != "" {
+		switch err := c.Get(ctx, client.ObjectKey{Name: a.IngressClass}, &networkingv1.IngressClass{}); {
+		case apierrors.IsNotFound(err):
+			add(Error, "ingress", "IngressClass %s does not exist", a.IngressClass)
+		case err != nil:
+			add(Warning, "ingress", "cannot read IngressClass %s: %v", a.IngressClass, err)
+		}
+	}
+	if a.IngressTLSSecret != "" {
+		switch err := c.Get(ctx, client.ObjectKey{Namespace: a.Namespace, Name: a.IngressTLSSecret}, &corev1.Secret{}); {
+		case apierrors.IsNotFound(err):
+			add(Warning, "ingress", "TLS Secret %s/%s does not exist yet", a.Namespace, a.IngressTLSSecret)
+		case err != nil:
+			add(Warning, "ingress", "cannot read TLS Secret %s/%s: %v", a.Namespace, a.IngressTLSSecret, err)
+		}
+	}
+
+	if a.SSOProvider != "" {
+		secret := &corev1.Secret{}
+		switch err := c.Get(ctx, client.ObjectKey{Namespace: a.Namespace, Name: a.SSOClientSecret}, secret); {
+		case apierrors.IsNotFound(err):
+			add(Warning, "sso", "Secret %s/%s of the client secret does not exist yet", a.Namespace, a.SSOClientSecret)
+		case err != nil:
+			add(Warning, "sso", "cannot read Secret %s/%s: %v", a.Namespace, a.SSOClientSecret, err)
+		case len(secret.Data[SSOClientSecretKey]) == 0:
+			add(Error, "sso", "Secret %s/%s has no %s key", a.Namespace, a.SSOClientSecret, SSOClientSecretKey)
+		}
+	}
+
+	findings = append(findings, checkCapacity(ctx, c, profile, a.Components)...)
+	return findings
+}
+
+// CheckTarget checks that a platform can be created: it does not exist yet
+// and its namespace does
+func CheckTarget(ctx context.Context, c client.Reader, name, namespace string) []Finding {
+	var findings []Finding
+	add := func(severity Severity, check, format string, args ...interface{}) {
+		findings = append(findings, Finding{Severity: severity, Check: check, Message: fmt.Sprintf(format, args...)})
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetAPIVersion("observability.io/v1beta1")
+	existing.SetKind("ObservabilityPlatform")
+	switch err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, existing); {
+	case err == nil:
+		add(Error, "platform", "platform %s/%s already exists", namespace, name)
+	case !apierrors.IsNotFound(err):
+		add(Warning, "platform", "cannot check for an existing platform, is the operator installed? %v", err)
+	}
+
+	switch err := c.Get(ctx, client.ObjectKey{Name: namespace}, &corev1.Namespace{}); {
+	case apierrors.IsNotFound(err):
+		add(Warning, "namespace", "namespace %s does not exist and must be created first", namespace)
+	case err != nil:
+		add(Warning, "namespace", "cannot read namespace %s: %v", namespace, err)
+	}
+	return findings
+}
+
+// checkStorageClass checks that the storage class exists, or that the
+// cluster has a default class when none is given
+func checkStorageClass(ctx context.Context, c client.Reader, name string) []Finding {
+	if name != "" {
+		switch err := c.Get(ctx, client.ObjectKey{Name: name}, &storagev1.StorageClass{}); {
+		case apierrors.IsNotFound(err):
+			return []Finding{{Severity: Error, Check: "storage", Message: fmt.Sprintf("StorageClass %s does not exist", name)}}
+		case err != nil:
+			return []Finding{{Severity: Warning, Check: "storage", Message: fmt.Sprintf("cannot read StorageClass %s: %v", name, err)}}
+		}
+		return nil
+	}
+
+	classes := &storagev1.StorageClassList{}
+	if err := c.List(ctx, classes); err != nil {
+		return []Finding{{Severity: Warning, Check: "storage", Message: fmt.Sprintf("cannot list StorageClasses: %v", err)}}
+	}
+	for _, class := range classes.Items {
+		if class.Annotations[storageClassDefaultAnnotation] == "true" {
+			return nil
+		}
+	}
+	return []Finding{{Severity: Error, Check: "storage", Message: "the cluster has no default StorageClass; choose a storage class"}}
+}
+
+// checkCapacity checks that the allocatable resources of the schedulable
+// nodes can fit the requests of the profile
+func checkCapacity(ctx context.Context, c client.Reader, profile Profile, components []string) []Finding {
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		return []Finding{{Severity: Warning, Check: "capacity", Message: fmt.Sprintf("cannot list nodes: %v", err)}}
+	}
+
+	var allocatableCPU, allocatableMemory resource.Quantity
+	schedulable := 0
+	for _, node := range nodes.Items {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		schedulable++
+		allocatableCPU.Add(node.Status.Allocatable[corev1.ResourceCPU])
+		allocatableMemory.Add(node.Status.Allocatable[corev1.ResourceMemory])
+	}
+
+	var requestedCPU, requestedMemory resource.Quantity
+	var maxReplicas int64
+	for _, name := range components {
+		sizing, ok := profile.Components[name]
+		if !ok {
+			continue
+		}
+		for i := int64(0); i < sizing.Replicas; i++ {
+			requestedCPU.Add(resource.MustParse(sizing.CPURequest))
+			requestedMemory.Add(resource.MustParse(sizing.MemoryRequest))
+		}
+		if sizing.Replicas > maxReplicas {
+			maxReplicas = sizing.Replicas
+		}
+	}
+
+	var findings []Finding
+	if requestedCPU.Cmp(allocatableCPU) > 0 || requestedMemory.Cmp(allocatableMemory) > 0 {
+		findings = append(findings, Finding{Severity: Error, Check: "capacity", Message: fmt.Sprintf(
+			"the %s profile requests %s CPU and %s memory, the nodes can allocate %s CPU and %s memory",
+			profile.Name, requestedCPU.String(), requestedMemory.String(), allocatableCPU.String(), allocatableMemory.String())})
+	}
+	if profile.HighAvailability && int64(schedulable) < maxReplicas {
+		findings = append(findings, Finding{Severity: Warning, Check: "capacity", Message: fmt.Sprintf(
+			"the %s profile spreads %d replicas over nodes, the cluster has %d schedulable nodes", profile.Name, maxReplicas, schedulable)})
+	}
+	if recommended := ProfileForNodes(schedulable); recommended.Name != profile.Name {
+		findings = append(findings, Finding{Severity: Warning, Check: "capacity", Message: fmt.Sprintf(
+			"the cluster has %d schedulable nodes, which matches the %s profile", schedulable, recommended.Name)})
+	}
+	return findings
+}
+
+func hasStorage(profile Profile, components []string) bool {
+	for _, name := range components {
+		if profile.Components[name].StorageSize != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+// Package onboarding generates a ready-to-apply ObservabilityPlatform from a
+// few answers about the cluster: its size, storage, ingress and single
+// sign-on. Each cluster size maps to a profile of replicas, resources,
+// volume sizes and retention.
+package onboarding
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// Components which can be deployed, in the order they are asked for
+var Components = []string{"prometheus", "grafana", "loki", "tempo"}
+
+// SSOProviders are the single sign-on providers of Grafana
+var SSOProviders = []string{"github", "gitlab", "google", "azuread", "okta", "generic_oauth"}
+
+// Sizing is the sizing of a component in a profile
+type Sizing struct {
+	Replicas      int64
+	CPURequest    string
+	MemoryRequest string
+	CPULimit      string
+	MemoryLimit   string
+	// StorageSize is the size of the component's volume, empty for
+	// components without storage
+	StorageSize string
+	Retention   string
+}
+
+// Profile is the sizing of a platform for a cluster size
+type Profile struct {
+	Name        string
+	Description string
+	// HighAvailability spreads the replicas of the components over nodes
+	HighAvailability bool
+	Components       map[string]Sizing
+}
+
+// profiles are the size profiles, smallest first
+var profiles = []Profile{
+	{
+		Name:        "small",
+		Description: "Development or small clusters, up to 20 nodes",
+		Components: map[string]Sizing{
+			"prometheus": {Replicas: 1, CPURequest: "250m", MemoryRequest: "1Gi", CPULimit: "1", MemoryLimit: "2Gi", StorageSize: "20Gi", Retention: "15d"},
+			"grafana":    {Replicas: 1, CPURequest: "100m", MemoryRequest: "256Mi", CPULimit: "500m", MemoryLimit: "512Mi"},
+			"loki":       {Replicas: 1, CPURequest: "250m", MemoryRequest: "512Mi", CPULimit: "1", MemoryLimit: "1Gi", StorageSize: "20Gi", Retention: "7d"},
+			"tempo":      {Replicas: 1, CPURequest: "250m", MemoryRequest: "512Mi", CPULimit: "1", MemoryLimit: "1Gi", StorageSize: "10Gi", Retention: "3d"},
+		},
+	},
+	{
+		Name:             "medium",
+		Description:      "Production clusters of 20 to 100 nodes",
+		HighAvailability: true,
+		Components: map[string]Sizing{
+			"prometheus": {Replicas: 2, CPURequest: "1", MemoryRequest: "4Gi", CPULimit: "2", MemoryLimit: "8Gi", StorageSize: "100Gi", Retention: "30d"},
+			"grafana":    {Replicas: 2, CPURequest: "250m", MemoryRequest: "512Mi", CPULimit: "1", MemoryLimit: "1Gi"},
+			"loki":       {Replicas: 2, CPURequest: "500m", MemoryRequest: "2Gi", CPULimit: "2", MemoryLimit: "4Gi", StorageSize: "100Gi", Retention: "14d"},
+			"tempo":      {Replicas: 2, CPURequest: "500m", MemoryRequest: "2Gi", CPULimit: "2", MemoryLimit: "4Gi", StorageSize: "50Gi", Retention: "7d"},
+		},
+	},
+	{
+		Name:             "large",
+		Description:      "Production clusters of more than 100 nodes",
+		HighAvailability: true,
+		Components: map[string]Sizing{
+			"prometheus": {Replicas: 3, CPURequest: "4", MemoryRequest: "16Gi", CPULimit: "8", MemoryLimit: "32Gi", StorageSize: "500Gi", Retention: "30d"},
+			"grafana":    {Replicas: 3, CPURequest: "500m", MemoryRequest: "1Gi", CPULimit: "2", MemoryLimit: "2Gi"},
+			"loki":       {Replicas: 3, CPURequest: "2", MemoryRequest: "8Gi", CPULimit: "4", MemoryLimit: "16Gi", StorageSize: "500Gi", Retention: "30d"},
+			"tempo":      {Replicas: 3, CPURequest: "1", MemoryRequest: "4Gi", CPULimit: "4", MemoryLimit: "8Gi", StorageSize: "200Gi", Retention: "14d"},
+		},
+	},
+}
+
+// Profiles returns the size profiles, smallest first
+func Profiles() []Profile {
+	return profiles
+}
+
+// ProfileFor returns the profile of a cluster size
+func ProfileFor(size string) (Profile, bool) {
+	for _, profile := range profiles {
+		if profile.Name == size {
+			return profile, true
+		}
+	}
+	return Profile{}, false
+}
+
+// ProfileForNodes returns the profile matching a cluster's node count
+func ProfileForNodes(nodes int) Profile {
+	switch {
+	case nodes <= 20:
+		return profiles[0]
+	case nodes <= 100:
+		return profiles[1]
+	}
+	return profiles[2]
+}
+
+// Answers are the answers of the onboarding questions
+type Answers struct {
+	Name      string
+	Namespace string
+	// Size is the name of the size profile
+	Size string
+	// Components are the components to deploy
+	Components []string
+	// StorageClass of the volumes, empty for the cluster's default class
+	StorageClass string
+
+	// IngressHost exposes Grafana on this host when set
+	IngressHost  string
+	IngressClass string
+	// IngressTLSSecret is the Secret of the host's certificate, empty to
+	// serve plain HTTP
+	IngressTLSSecret string
+
+	// SSOProvider is the Grafana OAuth provider, empty to keep the login form
+	SSOProvider string
+	SSOClientID string
+	// SSOClientSecret is the Secret holding the OAuth client secret under
+	// the key client-secret
+	SSOClientSecret string
+}
+
+// SSOClientSecretKey is the key of the OAuth client secret in its Secret
+const SSOClientSecretKey = "client-secret"
+
+// Validate checks the answers without a cluster
+func (a *Answers) Validate() error {
+	var errs []string
+	for _, msg := range validation.IsDNS1123Subdomain(a.Name) {
+		errs = append(errs, "name: "+msg)
+	}
+	for _, msg := range validation.IsDNS1123Label(a.Namespace) {
+		errs = append(errs, "namespace: "+msg)
+	}
+	if _, ok := ProfileFor(a.Size); !ok {
+		errs = append(errs, fmt.Sprintf("size: must be one of %s", strings.Join(profileNames(), ", ")))
+	}
+	if len(a.Components) == 0 {
+		errs = append(errs, "components: at least one component is required")
+	}
+	for _, component := range a.Components {
+		if !contains(Components, component) {
+			errs = append(errs, fmt.Sprintf("components: unknown component %q, must be one of %s", component, strings.Join(Components, ", ")))
+		}
+	}
+	if a.IngressHost != "" {
+		if !contains(a.Components, "grafana") {
+			errs = append(errs, "ingress: Grafana must be deployed to be exposed")
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(a.IngressHost) {
+			errs = append(errs, "ingress host: "+msg)
+		}
+	}
+	if a.SSOProvider != "" {
+		switch {
+		case !contains(SSOProviders, a.SSOProvider):
+			errs = append(errs, fmt.Sprintf("sso: unknown provider %q, must be one of %s", a.SSOProvider, strings.Join(SSOProviders, ", ")))
+		case !contains(a.Components, "grafana"):
+			errs = append(errs, "sso: Grafana must be deployed to sign in with SSO")
+		case a.SSOClientID == "" || a.SSOClientSecret == "":
+			errs = append(errs, "sso: the client ID and the client secret's Secret are required")
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid answers: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Manifest is a generated platform with the resources it needs
+type Manifest struct {
+	Platform *unstructured.Unstructured
+	// Related are further resources of the platform, such as the
+	// GrafanaConfig of its single sign-on
+	Related []*unstructured.Unstructured
+	// Profile is the size profile the platform was generated with
+	Profile Profile
+}
+
+// Generate generates the platform of the answers
+func Generate(a Answers) (*Manifest, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	profile, _ := ProfileFor(a.Size)
+
+	platform := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	platform.SetAPIVersion("observability.io/v1beta1")
+	platform.SetKind("ObservabilityPlatform")
+	platform.SetName(a.Name)
+	platform.SetNamespace(a.Namespace)
+	platform.SetLabels(map[string]string{"observability.io/size-profile": profile.Name})
+
+	components := map[string]interface{}{}
+	for _, name := range Components {
+		if !contains(a.Components, name) {
+			continue
+		}
+		sizing := profile.Components[name]
+		component := map[string]interface{}{
+			"enabled":  true,
+			"replicas": sizing.Replicas,
+			"resources": map[string]interface{}{
+				"requests": map[string]interface{}{"cpu": sizing.CPURequest, "memory": sizing.MemoryRequest},
+				"limits":   map[string]interface{}{"cpu": sizing.CPULimit, "memory": sizing.MemoryLimit},
+			},
+		}
+		if sizing.StorageSize != "" {
+			storage := map[string]interface{}{"size": sizing.StorageSize, "retention": sizing.Retention}
+			if a.StorageClass != "" {
+				storage["storageClassName"] = a.StorageClass
+			}
+			component["storage"] = storage
+		}
+		if name == "grafana" && a.IngressHost != "" {
+			ingress := map[string]interface{}{"enabled": true, "host": a.IngressHost}
+			if a.IngressClass != "" {
+				ingress["className"] = a.IngressClass
+			}
+			if a.IngressTLSSecret != "" {
+				ingress["tls"] = map[string]interface{}{"enabled": true, "secretName": a.IngressTLSSecret}
+			}
+			component["ingress"] = ingress
+		}
+		components[name] = component
+	}
+
+	spec := map[string]interface{}{"components": components}
+	if profile.HighAvailability {
+		spec["highAvailability"] = map[string]interface{}{"enabled": true}
+	}
+	platform.Object["spec"] = spec
+
+	manifest := &Manifest{Platform: platform, Profile: profile}
+	if a.SSOProvider != "" {
+		manifest.Related = append(manifest.Related, grafanaSSO(a))
+	}
+	return manifest, nil
+}
+
+// grafanaSSO returns the GrafanaConfig signing in to the platform's Grafana
+// with the OAuth provider of the answers
+func grafanaSSO(a Answers) *unstructured.Unstructured {
+	config := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"targetRef": map[string]interface{}{"name": a.Name},
+			"auth": map[string]interface{}{
+				"oauthAutoLogin": true,
+				"oauth": map[string]interface{}{
+					"providers": []interface{}{
+						map[string]interface{}{
+							"name":     a.SSOProvider,
+							"enabled":  true,
+							"clientId": a.SSOClientID,
+							"clientSecretRef": map[string]interface{}{
+								"name": a.SSOClientSecret,
+								"key":  SSOClientSecretKey,
+							},
+							"allowSignUp": true,
+						},
+					},
+				},
+			},
+		},
+	}}
+	config.SetAPIVersion("observability.io/v1beta1")
+	config.SetKind("GrafanaConfig")
+	config.SetName(a.Name + "-sso")
+	config.SetNamespace(a.Namespace)
+	return config
+}
+
+// YAML returns the platform and the related resources as YAML documents
+func (m *Manifest) YAML() ([]byte, error) {
+	var buf bytes.Buffer
+	for i, obj := range append([]*unstructured.Unstructured{m.Platform}, m.Related...) {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+func profileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		names = append(names, profile.Name)
+	}
+	return names
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
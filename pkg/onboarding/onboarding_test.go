@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package onboarding
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func answers() Answers {
+	return Answers{
+		Name:         "production",
+		Namespace:    "monitoring",
+		Size:         "medium",
+		Components:   []string{"prometheus", "grafana", "loki"},
+		StorageClass: "fast",
+		IngressHost:  "grafana.example.com",
+		IngressClass: "nginx",
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	a := answers()
+	a.IngressTLSSecret = "grafana-tls"
+	a.SSOProvider, a.SSOClientID, a.SSOClientSecret = "okta", "grafana", "grafana-oauth"
+
+	manifest, err := Generate(a)
+	require.NoError(t, err)
+	assert.Equal(t, "medium", manifest.Profile.Name)
+
+	platform := manifest.Platform.Object
+	replicas, _, _ := unstructured.NestedInt64(platform, "spec", "components", "prometheus", "replicas")
+	assert.Equal(t, int64(2), replicas)
+	class, _, _ := unstructured.NestedString(platform, "spec", "components", "loki", "storage", "storageClassName")
+	assert.Equal(t, "fast", class)
+	retention, _, _ := unstructured.NestedString(platform, "spec", "components", "prometheus", "storage", "retention")
+	assert.Equal(t, "30d", retention)
+	host, _, _ := unstructured.NestedString(platform, "spec", "components", "grafana", "ingress", "host")
+	assert.Equal(t, "grafana.example.com", host)
+	secret, _, _ := unstructured.NestedString(platform, "spec", "components", "grafana", "ingress", "tls", "secretName")
+	assert.Equal(t, "grafana-tls", secret)
+	_, hasStorage, _ := unstructured.NestedMap(platform, "spec", "components", "grafana", "storage")
+	assert.False(t, hasStorage)
+	_, hasTempo, _ := unstructured.NestedMap(platform, "spec", "components", "tempo")
+	assert.False(t, hasTempo)
+	ha, _, _ := unstructured.NestedBool(platform, "spec", "highAvailability", "enabled")
+	assert.True(t, ha)
+	assert.Equal(t, "medium", manifest.Platform.GetLabels()["observability.io/size-profile"])
+
+	require.Len(t, manifest.Related, 1)
+	sso := manifest.Related[0]
+	assert.Equal(t, "GrafanaConfig", sso.GetKind())
+	providers, _, _ := unstructured.NestedSlice(sso.Object, "spec", "auth", "oauth", "providers")
+	require.Len(t, providers, 1)
+	assert.Equal(t, "okta", providers[0].(map[string]interface{})["name"])
+
+	data, err := manifest.YAML()
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(data), "\n---\n"))
+	assert.Contains(t, string(data), "kind: ObservabilityPlatform")
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		modify func(*Answers)
+		errMsg string
+	}{
+		"valid":              {modify: func(a *Answers) {}},
+		"invalid name":       {modify: func(a *Answers) { a.Name = "Prod_1" }, errMsg: "name:"},
+		"unknown size":       {modify: func(a *Answers) { a.Size = "huge" }, errMsg: "size: must be one of small, medium, large"},
+		"no components":      {modify: func(a *Answers) { a.Components = nil }, errMsg: "at least one component"},
+		"unknown component":  {modify: func(a *Answers) { a.Components = append(a.Components, "jaeger") }, errMsg: `unknown component "jaeger"`},
+		"ingress no grafana": {modify: func(a *Answers) { a.Components = []string{"prometheus"} }, errMsg: "Grafana must be deployed"},
+		"sso without client": {modify: func(a *Answers) { a.SSOProvider = "github" }, errMsg: "client ID"},
+		"unknown provider":   {modify: func(a *Answers) { a.SSOProvider = "ldap" }, errMsg: `unknown provider "ldap"`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := answers()
+			tt.modify(&a)
+			err := a.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func node(name, cpu, memory string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		}},
+	}
+}
+
+func findings(t *testing.T, a Answers, objects ...client.Object) map[string][]Finding {
+	t.Helper()
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build()
+	byCheck := map[string][]Finding{}
+	for _, finding := range Check(context.Background(), c, a) {
+		if finding.Check == "platform" {
+			// The fake client does not serve ObservabilityPlatforms
+			continue
+		}
+		byCheck[finding.Check] = append(byCheck[finding.Check], finding)
+	}
+	return byCheck
+}
+
+func TestCheck(t *testing.T) {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "monitoring"}}
+	fast := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "fast"}}
+	nginx := &networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{Name: "nginx"}}
+	var nodes []client.Object
+	for _, name := range []string{"a", "b", "c"} {
+		nodes = append(nodes, node(name, "8", "32Gi"))
+	}
+
+	t.Run("ready cluster", func(t *testing.T) {
+		result := findings(t, answers(), append(nodes, namespace, fast, nginx)...)
+		// Three nodes match the small profile
+		require.Len(t, result["capacity"], 1)
+		assert.Contains(t, result["capacity"][0].Message, "matches the small profile")
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("missing classes", func(t *testing.T) {
+		a := answers()
+		a.Size = "small"
+		result := findings(t, a, nodes...)
+		require.Len(t, result["storage"], 1)
+		assert.Equal(t, Error, result["storage"][0].Severity)
+		require.Len(t, result["ingress"], 1)
+		assert.Equal(t, Error, result["ingress"][0].Severity)
+		require.Len(t, result["namespace"], 1)
+		assert.Equal(t, Warning, result["namespace"][0].Severity)
+	})
+
+	t.Run("no default storage class", func(t *testing.T) {
+		a := answers()
+		a.Size, a.StorageClass = "small", ""
+		result := findings(t, a, append(nodes, namespace, fast, nginx)...)
+		require.Len(t, result["storage"], 1)
+		assert.Contains(t, result["storage"][0].Message, "no default StorageClass")
+
+		fast.Annotations = map[string]string{storageClassDefaultAnnotation: "true"}
+		defer func() { fast.Annotations = nil }()
+		assert.Empty(t, findings(t, a, append(nodes, namespace, fast, nginx)...))
+	})
+
+	t.Run("insufficient capacity", func(t *testing.T) {
+		a := answers()
+		a.Size = "large"
+		result := findings(t, a, node("small", "4", "16Gi"), namespace, fast, nginx)
+		assert.True(t, HasErrors(result["capacity"]))
+	})
+
+	t.Run("sso secret", func(t *testing.T) {
+		a := answers()
+		a.Size = "small"
+		a.SSOProvider, a.SSOClientID, a.SSOClientSecret = "github", "grafana", "grafana-oauth"
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "grafana-oauth", Namespace: "monitoring"}}
+		result := findings(t, a, append(nodes, namespace, fast, nginx, secret)...)
+		require.Len(t, result["sso"], 1)
+		assert.Equal(t, Error, result["sso"][0].Severity)
+
+		secret.Data = map[string][]byte{SSOClientSecretKey: []byte("s3cr3t")}
+		assert.Empty(t, findings(t, a, append(nodes, namespace, fast, nginx, secret)...)["sso"])
+	})
+}
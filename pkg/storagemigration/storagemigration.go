@@ -0,0 +1,349 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+// Package storagemigration rewrites the stored objects of a custom resource
+// to a new version without a conversion webhook. Without one the API server
+// only relabels objects read in another version than they were stored in, so
+// a single version can be served safely once every object is stored in it.
+// The migrator reads each object in its stored version, converts it with the
+// converters of the API types, makes the new version the storage version and
+// writes the object back, then records the new version as the only stored
+// version of the CRD.
+package storagemigration
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// StoredVersionAnnotation marks the objects rewritten in a version, so an
+// interrupted migration resumes without converting them twice
+const StoredVersionAnnotation = "observability.io/stored-version"
+
+// Converter converts an object to another version of its kind
+type Converter interface {
+	Convert(obj *unstructured.Unstructured, version string) (*unstructured.Unstructured, error)
+}
+
+// SchemeConverter converts objects with the conversion functions of the
+// API types of a scheme: the Convertible versions of a kind convert to and
+// from its Hub version
+type SchemeConverter struct {
+	Scheme *runtime.Scheme
+}
+
+// Convert converts obj to version
+func (c *SchemeConverter) Convert(obj *unstructured.Unstructured, version string) (*unstructured.Unstructured, error) {
+	gvk := obj.GroupVersionKind()
+	target := gvk.GroupVersion().WithKind(gvk.Kind)
+	target.Version = version
+
+	src, err := c.Scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, src); err != nil {
+		return nil, fmt.Errorf("failed to decode %s %s: %w", gvk, obj.GetName(), err)
+	}
+	dst, err := c.Scheme.New(target)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case gvk.Version == version:
+		dst = src
+	case isConvertible(src) && isHub(dst):
+		err = src.(conversion.Convertible).ConvertTo(dst.(conversion.Hub))
+	case isHub(src) && isConvertible(dst):
+		err = dst.(conversion.Convertible).ConvertFrom(src.(conversion.Hub))
+	case isConvertible(src) && isConvertible(dst):
+		// Convert through the hub
+		var hub runtime.Object
+		if hub, err = c.hub(gvk); err == nil {
+			if err = src.(conversion.Convertible).ConvertTo(hub.(conversion.Hub)); err == nil {
+				err = dst.(conversion.Convertible).ConvertFrom(hub.(conversion.Hub))
+			}
+		}
+	default:
+		return nil, fmt.Errorf("no conversion from %s to %s", gvk, target)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s to %s: %w", obj.GetName(), version, err)
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(dst)
+	if err != nil {
+		return nil, err
+	}
+	converted := &unstructured.Unstructured{Object: content}
+	converted.SetGroupVersionKind(target)
+	return converted, nil
+}
+
+// hub returns a new object of the hub version of a kind
+func (c *SchemeConverter) hub(gvk schema.GroupVersionKind) (runtime.Object, error) {
+	for candidate := range c.Scheme.AllKnownTypes() {
+		if candidate.Group != gvk.Group || candidate.Kind != gvk.Kind {
+			continue
+		}
+		obj, err := c.Scheme.New(candidate)
+		if err == nil && isHub(obj) {
+			return obj, nil
+		}
+	}
+	return nil, fmt.Errorf("no hub version of %s", gvk.GroupKind())
+}
+
+func isHub(obj runtime.Object) bool {
+	_, ok := obj.(conversion.Hub)
+	return ok
+}
+
+func isConvertible(obj runtime.Object) bool {
+	_, ok := obj.(conversion.Convertible)
+	return ok
+}
+
+// Result reports a migration
+type Result struct {
+	// Source is the version the objects were read in
+	Source string
+	// Target is the new storage version
+	Target string
+	// Migrated are the objects rewritten, Skipped those already stored in
+	// the target version
+	Migrated []types.NamespacedName
+	Skipped  []types.NamespacedName
+	// Failed are the objects which could not be converted or written
+	Failed map[types.NamespacedName]error
+	// StoredVersions are the stored versions of the CRD after the migration
+	StoredVersions []string
+}
+
+// Complete returns whether every object is stored in the target version
+func (r *Result) Complete() bool {
+	return len(r.Failed) == 0
+}
+
+// Migrator migrates the stored objects of a CRD
+type Migrator struct {
+	Client    client.Client
+	Converter Converter
+	// Out receives the progress, discarded if nil
+	Out io.Writer
+	// DryRun converts the objects without writing anything
+	DryRun bool
+	// KeepServed keeps serving the previous versions after the migration
+	KeepServed bool
+}
+
+// Run migrates the objects of the CRD crdName to the version target
+func (m *Migrator) Run(ctx context.Context, crdName, target string) (*Result, error) {
+	out := m.Out
+	if out == nil {
+		out = io.Discard
+	}
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := m.Client.Get(ctx, types.NamespacedName{Name: crdName}, crd); err != nil {
+		return nil, fmt.Errorf("failed to get CRD %s: %w", crdName, err)
+	}
+	if version(crd, target) == nil {
+		return nil, fmt.Errorf("CRD %s has no version %s", crdName, target)
+	}
+
+	result := &Result{Target: target, Failed: map[types.NamespacedName]error{}, StoredVersions: crd.Status.StoredVersions}
+	storage := storageVersion(crd)
+	if storage == target && len(crd.Status.StoredVersions) == 1 && crd.Status.StoredVersions[0] == target {
+		fmt.Fprintf(out, "All %s are stored in %s\n", crd.Spec.Names.Plural, target)
+		return result, nil
+	}
+
+	// Read the objects in the version they are stored in: the current
+	// storage version, or a previous one if the storage version was already
+	// switched by an interrupted migration
+	result.Source = storage
+	if storage == target {
+		result.Source = ""
+		for _, stored := range crd.Status.StoredVersions {
+			if v := version(crd, stored); stored != target && v != nil && v.Served {
+				result.Source = stored
+				break
+			}
+		}
+		if result.Source == "" {
+			return nil, fmt.Errorf("no served version of CRD %s to read the objects stored in %v in", crdName, crd.Status.StoredVersions)
+		}
+	}
+	if v := version(crd, result.Source); v == nil || !v.Served {
+		return nil, fmt.Errorf("version %s of CRD %s is not served", result.Source, crdName)
+	}
+	source := schema.GroupVersionKind{Group: crd.Spec.Group, Version: result.Source, Kind: crd.Spec.Names.Kind}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(source.GroupVersion().WithKind(source.Kind + "List"))
+	if err := m.Client.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", crd.Spec.Names.Plural, err)
+	}
+	fmt.Fprintf(out, "Migrating %d %s from %s to %s\n", len(list.Items), crd.Spec.Names.Plural, result.Source, target)
+
+	// Convert every object before changing anything, so a conversion
+	// failure leaves the cluster untouched
+	for i := range list.Items {
+		obj := &list.Items[i]
+		if obj.GetAnnotations()[StoredVersionAnnotation] == target {
+			continue
+		}
+		if _, err := m.Converter.Convert(obj, target); err != nil {
+			result.Failed[client.ObjectKeyFromObject(obj)] = err
+		}
+	}
+	if len(result.Failed) > 0 {
+		return result, fmt.Errorf("%d of %d %s cannot be converted to %s, nothing was changed", len(result.Failed), len(list.Items), crd.Spec.Names.Plural, target)
+	}
+	if m.DryRun {
+		fmt.Fprintf(out, "All %s convert to %s (dry run)\n", crd.Spec.Names.Plural, target)
+		return result, nil
+	}
+
+	if storage != target {
+		for i := range crd.Spec.Versions {
+			v := &crd.Spec.Versions[i]
+			v.Storage = v.Name == target
+			if v.Name == target {
+				v.Served = true
+			}
+		}
+		if err := m.Client.Update(ctx, crd); err != nil {
+			return nil, fmt.Errorf("failed to make %s the storage version: %w", target, err)
+		}
+		fmt.Fprintf(out, "Storage version of %s is %s\n", crdName, target)
+	}
+
+	for i := range list.Items {
+		key := client.ObjectKeyFromObject(&list.Items[i])
+		migrated, err := m.rewrite(ctx, source, key, target, hasStatus(crd, target))
+		switch {
+		case err != nil:
+			result.Failed[key] = err
+			fmt.Fprintf(out, "  %s: %v\n", key, err)
+		case migrated:
+			result.Migrated = append(result.Migrated, key)
+			fmt.Fprintf(out, "  %s: migrated\n", key)
+		default:
+			result.Skipped = append(result.Skipped, key)
+		}
+	}
+	if len(result.Failed) > 0 {
+		return result, fmt.Errorf("%d %s could not be migrated, rerun the migration to resume", len(result.Failed), crd.Spec.Names.Plural)
+	}
+
+	// Every object is stored in the target version
+	if err := m.Client.Get(ctx, types.NamespacedName{Name: crdName}, crd); err != nil {
+		return result, fmt.Errorf("failed to get CRD %s: %w", crdName, err)
+	}
+	crd.Status.StoredVersions = []string{target}
+	if err := m.Client.Status().Update(ctx, crd); err != nil {
+		return result, fmt.Errorf("failed to update the stored versions of CRD %s: %w", crdName, err)
+	}
+	result.StoredVersions = crd.Status.StoredVersions
+	fmt.Fprintf(out, "Stored versions of %s: %v\n", crdName, crd.Status.StoredVersions)
+
+	if !m.KeepServed {
+		var unserved []string
+		for i := range crd.Spec.Versions {
+			if v := &crd.Spec.Versions[i]; v.Name != target && v.Served {
+				v.Served = false
+				unserved = append(unserved, v.Name)
+			}
+		}
+		if len(unserved) > 0 {
+			if err := m.Client.Update(ctx, crd); err != nil {
+				return result, fmt.Errorf("failed to stop serving %v: %w", unserved, err)
+			}
+			sort.Strings(unserved)
+			fmt.Fprintf(out, "Stopped serving %v\n", unserved)
+		}
+	}
+	return result, nil
+}
+
+// rewrite reads an object in the source version and writes it back
+// converted to the target version, the new storage version. It returns
+// false if the object is already stored in the target version.
+func (m *Migrator) rewrite(ctx context.Context, source schema.GroupVersionKind, key types.NamespacedName, target string, status bool) (bool, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(source)
+	if err := m.Client.Get(ctx, key, obj); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if obj.GetAnnotations()[StoredVersionAnnotation] == target {
+		return false, nil
+	}
+
+	converted, err := m.Converter.Convert(obj, target)
+	if err != nil {
+		return false, err
+	}
+	annotations := converted.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[StoredVersionAnnotation] = target
+	converted.SetAnnotations(annotations)
+	converted.SetResourceVersion(obj.GetResourceVersion())
+
+	convertedStatus, hasConvertedStatus := converted.Object["status"]
+	if err := m.Client.Update(ctx, converted); err != nil {
+		return false, fmt.Errorf("failed to write: %w", err)
+	}
+	if status && hasConvertedStatus {
+		// The status subresource is written separately
+		converted.Object["status"] = convertedStatus
+		if err := m.Client.Status().Update(ctx, converted); err != nil {
+			return false, fmt.Errorf("failed to write status: %w", err)
+		}
+	}
+	return true, nil
+}
+
+func version(crd *apiextensionsv1.CustomResourceDefinition, name string) *apiextensionsv1.CustomResourceDefinitionVersion {
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Name == name {
+			return &crd.Spec.Versions[i]
+		}
+	}
+	return nil
+}
+
+func storageVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			return v.Name
+		}
+	}
+	return ""
+}
+
+func hasStatus(crd *apiextensionsv1.CustomResourceDefinition, name string) bool {
+	v := version(crd, name)
+	return v != nil && v.Subresources != nil && v.Subresources.Status != nil
+}
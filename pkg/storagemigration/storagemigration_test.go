@@ -0,0 +1,298 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package storagemigration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+const group = "example.io"
+
+// widgetV1 is the old version of a test kind: its size is a string
+type widgetV1 struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		Size string `json:"size,omitempty"`
+	} `json:"spec,omitempty"`
+}
+
+func (w *widgetV1) DeepCopyObject() runtime.Object {
+	c := *w
+	w.ObjectMeta.DeepCopyInto(&c.ObjectMeta)
+	return &c
+}
+
+func (w *widgetV1) ConvertTo(dst conversion.Hub) error {
+	hub := dst.(*widgetV2)
+	hub.ObjectMeta = w.ObjectMeta
+	_, err := fmt.Sscanf(w.Spec.Size, "%d", &hub.Spec.Replicas)
+	return err
+}
+
+func (w *widgetV1) ConvertFrom(src conversion.Hub) error {
+	hub := src.(*widgetV2)
+	w.ObjectMeta = hub.ObjectMeta
+	w.Spec.Size = fmt.Sprint(hub.Spec.Replicas)
+	return nil
+}
+
+// widgetV2 is the hub version of the test kind
+type widgetV2 struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		Replicas int64 `json:"replicas,omitempty"`
+	} `json:"spec,omitempty"`
+}
+
+func (w *widgetV2) DeepCopyObject() runtime.Object {
+	c := *w
+	w.ObjectMeta.DeepCopyInto(&c.ObjectMeta)
+	return &c
+}
+
+func (*widgetV2) Hub() {}
+
+func widgetScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	s.AddKnownTypeWithName(schema.GroupVersionKind{Group: group, Version: "v1", Kind: "Widget"}, &widgetV1{})
+	s.AddKnownTypeWithName(schema.GroupVersionKind{Group: group, Version: "v2", Kind: "Widget"}, &widgetV2{})
+	return s
+}
+
+func widget(version, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: group, Version: version, Kind: "Widget"})
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	return obj
+}
+
+func TestSchemeConverter(t *testing.T) {
+	converter := &SchemeConverter{Scheme: widgetScheme()}
+
+	converted, err := converter.Convert(widget("v1", "a", map[string]interface{}{"size": "3"}), "v2")
+	require.NoError(t, err)
+	assert.Equal(t, group+"/v2", converted.GetAPIVersion())
+	assert.Equal(t, "a", converted.GetName())
+	replicas, _, _ := unstructured.NestedInt64(converted.Object, "spec", "replicas")
+	assert.Equal(t, int64(3), replicas)
+
+	converted, err = converter.Convert(widget("v2", "a", map[string]interface{}{"replicas": int64(2)}), "v1")
+	require.NoError(t, err)
+	size, _, _ := unstructured.NestedString(converted.Object, "spec", "size")
+	assert.Equal(t, "2", size)
+
+	_, err = converter.Convert(widget("v1", "a", map[string]interface{}{"size": "large"}), "v2")
+	assert.Error(t, err)
+	_, err = converter.Convert(widget("v1", "a", nil), "v3")
+	assert.Error(t, err)
+}
+
+// storageClient stores every object of the test kind in the storage version
+// of its CRD and relabels it on reads, like the API server without a
+// conversion webhook
+type storageClient struct {
+	client.Client
+	crd string
+}
+
+func (c *storageClient) storage(ctx context.Context) string {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := c.Client.Get(ctx, types.NamespacedName{Name: c.crd}, crd); err != nil {
+		panic(err)
+	}
+	return storageVersion(crd)
+}
+
+func (c *storageClient) relabel(obj runtime.Object, version string) {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	gvk.Version = version
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+}
+
+func (c *storageClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return c.Client.Get(ctx, key, obj, opts...)
+	}
+	requested := u.GroupVersionKind().Version
+	c.relabel(u, "stored")
+	err := c.Client.Get(ctx, key, u, opts...)
+	c.relabel(u, requested)
+	return err
+}
+
+func (c *storageClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	u := list.(*unstructured.UnstructuredList)
+	requested := u.GroupVersionKind().Version
+	c.relabel(u, "stored")
+	err := c.Client.List(ctx, u, opts...)
+	c.relabel(u, requested)
+	for i := range u.Items {
+		c.relabel(&u.Items[i], requested)
+	}
+	return err
+}
+
+func (c *storageClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return c.Client.Update(ctx, obj, opts...)
+	}
+	version := u.GroupVersionKind().Version
+	if version != c.storage(ctx) {
+		return fmt.Errorf("test client only writes in the storage version")
+	}
+	c.relabel(u, "stored")
+	err := c.Client.Update(ctx, u, opts...)
+	c.relabel(u, version)
+	return err
+}
+
+type converterFunc func(obj *unstructured.Unstructured, version string) (*unstructured.Unstructured, error)
+
+func (f converterFunc) Convert(obj *unstructured.Unstructured, version string) (*unstructured.Unstructured, error) {
+	return f(obj, version)
+}
+
+func widgetCRD() *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets." + group},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "Widget", Plural: "widgets"},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true, Storage: true},
+				{Name: "v2", Served: true},
+			},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: []string{"v1"}},
+	}
+}
+
+func newMigrator(t *testing.T, objs ...client.Object) (*Migrator, *storageClient) {
+	s := runtime.NewScheme()
+	require.NoError(t, apiextensionsv1.AddToScheme(s))
+	for _, obj := range objs {
+		if u, ok := obj.(*unstructured.Unstructured); ok {
+			u.SetAPIVersion(group + "/stored")
+		}
+	}
+	inner := fake.NewClientBuilder().WithScheme(s).
+		WithObjects(objs...).
+		WithStatusSubresource(&apiextensionsv1.CustomResourceDefinition{}).
+		Build()
+	c := &storageClient{Client: inner, crd: "widgets." + group}
+	return &Migrator{Client: c, Converter: &SchemeConverter{Scheme: widgetScheme()}, Out: &bytes.Buffer{}}, c
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	m, c := newMigrator(t, widgetCRD(),
+		widget("v1", "a", map[string]interface{}{"size": "3"}),
+		widget("v1", "b", map[string]interface{}{"size": "1"}))
+
+	result, err := m.Run(ctx, "widgets."+group, "v2")
+	require.NoError(t, err)
+	assert.True(t, result.Complete())
+	assert.Equal(t, "v1", result.Source)
+	assert.Len(t, result.Migrated, 2)
+	assert.Equal(t, []string{"v2"}, result.StoredVersions)
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, c.Client.Get(ctx, types.NamespacedName{Name: "widgets." + group}, crd))
+	assert.Equal(t, "v2", storageVersion(crd))
+	assert.Equal(t, []string{"v2"}, crd.Status.StoredVersions)
+	assert.False(t, version(crd, "v1").Served)
+
+	stored := widget("v2", "a", nil)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(stored), stored))
+	replicas, _, _ := unstructured.NestedInt64(stored.Object, "spec", "replicas")
+	assert.Equal(t, int64(3), replicas)
+	assert.Equal(t, "v2", stored.GetAnnotations()[StoredVersionAnnotation])
+
+	// A second run has nothing to do
+	result, err = m.Run(ctx, "widgets."+group, "v2")
+	require.NoError(t, err)
+	assert.Empty(t, result.Migrated)
+}
+
+func TestRunLeavesTheClusterUntouchedWhenAConversionFails(t *testing.T) {
+	ctx := context.Background()
+	m, c := newMigrator(t, widgetCRD(),
+		widget("v1", "a", map[string]interface{}{"size": "3"}),
+		widget("v1", "b", map[string]interface{}{"size": "large"}))
+
+	result, err := m.Run(ctx, "widgets."+group, "v2")
+	assert.Error(t, err)
+	assert.Contains(t, result.Failed, types.NamespacedName{Namespace: "default", Name: "b"})
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, c.Client.Get(ctx, types.NamespacedName{Name: "widgets." + group}, crd))
+	assert.Equal(t, "v1", storageVersion(crd))
+	assert.Equal(t, []string{"v1"}, crd.Status.StoredVersions)
+}
+
+func TestRunResumes(t *testing.T) {
+	ctx := context.Background()
+	crd := widgetCRD()
+	// Interrupted after the storage version was switched and a was rewritten
+	crd.Spec.Versions[0].Storage, crd.Spec.Versions[1].Storage = false, true
+	crd.Status.StoredVersions = []string{"v1", "v2"}
+	migrated := widget("v2", "a", map[string]interface{}{"replicas": int64(3)})
+	migrated.SetAnnotations(map[string]string{StoredVersionAnnotation: "v2"})
+	m, _ := newMigrator(t, crd, migrated, widget("v1", "b", map[string]interface{}{"size": "1"}))
+
+	var converted []string
+	schemeConverter := m.Converter
+	m.Converter = converterFunc(func(obj *unstructured.Unstructured, version string) (*unstructured.Unstructured, error) {
+		converted = append(converted, obj.GetName())
+		return schemeConverter.Convert(obj, version)
+	})
+	m.KeepServed = true
+
+	result, err := m.Run(ctx, "widgets."+group, "v2")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", result.Source)
+	assert.Equal(t, []types.NamespacedName{{Namespace: "default", Name: "b"}}, result.Migrated)
+	assert.Equal(t, []types.NamespacedName{{Namespace: "default", Name: "a"}}, result.Skipped)
+	assert.NotContains(t, converted, "a")
+	assert.Equal(t, []string{"v2"}, result.StoredVersions)
+}
+
+func TestRunDryRun(t *testing.T) {
+	ctx := context.Background()
+	m, c := newMigrator(t, widgetCRD(), widget("v1", "a", map[string]interface{}{"size": "3"}))
+	m.DryRun = true
+
+	_, err := m.Run(ctx, "widgets."+group, "v2")
+	require.NoError(t, err)
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, c.Client.Get(ctx, types.NamespacedName{Name: "widgets." + group}, crd))
+	assert.Equal(t, "v1", storageVersion(crd))
+
+	_, err = m.Run(ctx, "widgets."+group, "v3")
+	assert.Error(t, err)
+}
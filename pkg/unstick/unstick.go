@@ -0,0 +1,479 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+// Package unstick diagnoses ObservabilityPlatforms stuck terminating and
+// releases them. A platform is stuck when its finalizers are not removed:
+// the operator is down or failing, a webhook the API server calls on every
+// update has no ready endpoints, or a finalizer belongs to another
+// controller. The operator's finalizers are only removed once the children
+// of the platform are gone, and every removal leaves an audit record.
+package unstick
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// OperatorFinalizerPrefix prefixes the finalizers of the operator
+	OperatorFinalizerPrefix = "observabilityplatform.observability.io/"
+
+	// PlatformLabel is set on the children of a platform
+	PlatformLabel = "observability.io/platform"
+
+	// AuditLabel is set on the audit records, with the name of the platform
+	AuditLabel = "observability.io/unstuck-platform"
+
+	// AuditRecordKey is the ConfigMap key holding the audit record
+	AuditRecordKey = "record.json"
+
+	// DefaultThreshold is how long a platform terminates before it is
+	// considered stuck
+	DefaultThreshold = 15 * time.Minute
+
+	retainedAnnotation = "observability.io/retained"
+	backupTypeLabel    = "observability.io/backup-type"
+	platformResource   = "observabilityplatforms"
+	platformGroup      = "observability.io"
+	platformCRD        = platformResource + "." + platformGroup
+)
+
+// PlatformGVK is the kind of the platforms
+var PlatformGVK = schema.GroupVersionKind{Group: platformGroup, Version: "v1beta1", Kind: "ObservabilityPlatform"}
+
+// Child is a remaining child of a platform
+type Child struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// WebhookProblem is a webhook called on platform updates that cannot be
+// reached, so the API server rejects the removal of the finalizers
+type WebhookProblem struct {
+	// Configuration is the webhook configuration or the CRD conversion
+	Configuration string `json:"configuration"`
+	Service       string `json:"service"`
+	Reason        string `json:"reason"`
+}
+
+func (w WebhookProblem) String() string {
+	return fmt.Sprintf("%s: service %s %s", w.Configuration, w.Service, w.Reason)
+}
+
+// Diagnosis describes why a platform is still terminating
+type Diagnosis struct {
+	Platform  string `json:"platform"`
+	Namespace string `json:"namespace"`
+	UID       string `json:"uid"`
+	// Terminating is how long the platform has been terminating, zero if it
+	// is not being deleted
+	Terminating        time.Duration    `json:"terminating"`
+	OperatorFinalizers []string         `json:"operatorFinalizers,omitempty"`
+	ForeignFinalizers  []string         `json:"foreignFinalizers,omitempty"`
+	Children           []Child          `json:"children,omitempty"`
+	Webhooks           []WebhookProblem `json:"webhooks,omitempty"`
+}
+
+// Stuck returns whether the platform has been terminating longer than
+// threshold
+func (d *Diagnosis) Stuck(threshold time.Duration) bool {
+	return d.Terminating > 0 && d.Terminating >= threshold
+}
+
+// Reasons explains what keeps the platform terminating
+func (d *Diagnosis) Reasons() []string {
+	var reasons []string
+	for _, w := range d.Webhooks {
+		reasons = append(reasons, "unreachable webhook "+w.String())
+	}
+	if len(d.OperatorFinalizers) > 0 {
+		reasons = append(reasons, fmt.Sprintf("operator finalizers not removed: %s", strings.Join(d.OperatorFinalizers, ", ")))
+	}
+	if len(d.Children) > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d children not deleted", len(d.Children)))
+	}
+	if len(d.ForeignFinalizers) > 0 {
+		reasons = append(reasons, fmt.Sprintf("finalizers of other controllers: %s", strings.Join(d.ForeignFinalizers, ", ")))
+	}
+	return reasons
+}
+
+// SplitFinalizers separates the finalizers of the operator from the others
+func SplitFinalizers(finalizers []string) (operator, foreign []string) {
+	for _, f := range finalizers {
+		if strings.HasPrefix(f, OperatorFinalizerPrefix) {
+			operator = append(operator, f)
+		} else {
+			foreign = append(foreign, f)
+		}
+	}
+	return operator, foreign
+}
+
+// Terminating returns how long an object deleted at deletionTimestamp has
+// been terminating at now
+func Terminating(deletionTimestamp *metav1.Time, now time.Time) time.Duration {
+	if deletionTimestamp == nil {
+		return 0
+	}
+	return now.Sub(deletionTimestamp.Time)
+}
+
+// Inspector diagnoses and releases platforms
+type Inspector struct {
+	Client client.Client
+	// Now returns the current time, time.Now if nil
+	Now func() time.Time
+}
+
+func (i *Inspector) now() time.Time {
+	if i.Now != nil {
+		return i.Now()
+	}
+	return time.Now()
+}
+
+// Diagnose inspects a platform, its children and the webhooks the API server
+// calls when the platform is updated
+func (i *Inspector) Diagnose(ctx context.Context, namespace, name string) (*Diagnosis, error) {
+	platform := &unstructured.Unstructured{}
+	platform.SetGroupVersionKind(PlatformGVK)
+	if err := i.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, platform); err != nil {
+		return nil, fmt.Errorf("failed to get platform %s/%s: %w", namespace, name, err)
+	}
+
+	d := &Diagnosis{
+		Platform:    name,
+		Namespace:   namespace,
+		UID:         string(platform.GetUID()),
+		Terminating: Terminating(platform.GetDeletionTimestamp(), i.now()),
+	}
+	d.OperatorFinalizers, d.ForeignFinalizers = SplitFinalizers(platform.GetFinalizers())
+
+	children, err := i.children(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	d.Children = children
+
+	webhooks, err := i.webhookProblems(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d.Webhooks = webhooks
+	return d, nil
+}
+
+// children lists the remaining children of a platform. Claims kept by the
+// Retain deletion policy and pre-deletion backups outlive the platform and
+// are not counted.
+func (i *Inspector) children(ctx context.Context, namespace, name string) ([]Child, error) {
+	lists := []struct {
+		kind string
+		list client.ObjectList
+	}{
+		{"Deployment", &appsv1.DeploymentList{}},
+		{"StatefulSet", &appsv1.StatefulSetList{}},
+		{"DaemonSet", &appsv1.DaemonSetList{}},
+		{"Service", &corev1.ServiceList{}},
+		{"ConfigMap", &corev1.ConfigMapList{}},
+		{"Secret", &corev1.SecretList{}},
+		{"PersistentVolumeClaim", &corev1.PersistentVolumeClaimList{}},
+	}
+
+	var children []Child
+	for _, l := range lists {
+		if err := i.Client.List(ctx, l.list, client.InNamespace(namespace), client.MatchingLabels{PlatformLabel: name}); err != nil {
+			return nil, fmt.Errorf("failed to list %s children: %w", l.kind, err)
+		}
+		items, err := metaItems(l.list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if item.GetAnnotations()[retainedAnnotation] == "true" || item.GetLabels()[backupTypeLabel] == "pre-deletion" {
+				continue
+			}
+			children = append(children, Child{Kind: l.kind, Name: item.GetName()})
+		}
+	}
+	return children, nil
+}
+
+// webhookProblems returns the webhooks called on platform updates whose
+// service has no ready endpoints
+func (i *Inspector) webhookProblems(ctx context.Context) ([]WebhookProblem, error) {
+	var problems []WebhookProblem
+	check := func(configuration string, service *admissionregistrationv1.ServiceReference) error {
+		if service == nil {
+			return nil
+		}
+		reason, err := i.serviceProblem(ctx, service.Namespace, service.Name)
+		if err != nil || reason == "" {
+			return err
+		}
+		problems = append(problems, WebhookProblem{
+			Configuration: configuration,
+			Service:       service.Namespace + "/" + service.Name,
+			Reason:        reason,
+		})
+		return nil
+	}
+
+	validating := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	if err := i.Client.List(ctx, validating); err != nil {
+		return nil, fmt.Errorf("failed to list validating webhooks: %w", err)
+	}
+	for _, c := range validating.Items {
+		for _, w := range c.Webhooks {
+			if updatesPlatforms(w.Rules, w.FailurePolicy) {
+				if err := check("ValidatingWebhookConfiguration "+c.Name, w.ClientConfig.Service); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	mutating := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	if err := i.Client.List(ctx, mutating); err != nil {
+		return nil, fmt.Errorf("failed to list mutating webhooks: %w", err)
+	}
+	for _, c := range mutating.Items {
+		for _, w := range c.Webhooks {
+			if updatesPlatforms(w.Rules, w.FailurePolicy) {
+				if err := check("MutatingWebhookConfiguration "+c.Name, w.ClientConfig.Service); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	// The conversion webhook is called when the stored version differs from
+	// the requested one
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"})
+	if err := i.Client.Get(ctx, types.NamespacedName{Name: platformCRD}, crd); err != nil {
+		if errors.IsNotFound(err) {
+			return problems, nil
+		}
+		return nil, fmt.Errorf("failed to get CRD %s: %w", platformCRD, err)
+	}
+	service, found, _ := unstructured.NestedStringMap(crd.Object, "spec", "conversion", "webhook", "clientConfig", "service")
+	if found {
+		ref := &admissionregistrationv1.ServiceReference{Namespace: service["namespace"], Name: service["name"]}
+		if err := check("CustomResourceDefinition "+platformCRD+" conversion", ref); err != nil {
+			return nil, err
+		}
+	}
+	return problems, nil
+}
+
+// updatesPlatforms returns whether a webhook rejects platform updates when it
+// cannot be reached
+func updatesPlatforms(rules []admissionregistrationv1.RuleWithOperations, failurePolicy *admissionregistrationv1.FailurePolicyType) bool {
+	if failurePolicy != nil && *failurePolicy == admissionregistrationv1.Ignore {
+		return false
+	}
+	for _, rule := range rules {
+		if matches(rule.APIGroups, platformGroup) && matches(rule.Resources, platformResource) &&
+			matchesOperation(rule.Operations, admissionregistrationv1.Update) {
+			return true
+		}
+	}
+	return false
+}
+
+func matches(values []string, value string) bool {
+	for _, v := range values {
+		if v == "*" || v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesOperation(operations []admissionregistrationv1.OperationType, operation admissionregistrationv1.OperationType) bool {
+	for _, o := range operations {
+		if o == admissionregistrationv1.OperationAll || o == operation {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceProblem returns why a service cannot serve a webhook, empty if it
+// has ready endpoints
+func (i *Inspector) serviceProblem(ctx context.Context, namespace, name string) (string, error) {
+	key := types.NamespacedName{Namespace: namespace, Name: name}
+	if err := i.Client.Get(ctx, key, &corev1.Service{}); err != nil {
+		if errors.IsNotFound(err) {
+			return "does not exist", nil
+		}
+		return "", fmt.Errorf("failed to get service %s: %w", key, err)
+	}
+
+	endpoints := &corev1.Endpoints{}
+	if err := i.Client.Get(ctx, key, endpoints); err != nil {
+		if errors.IsNotFound(err) {
+			return "has no endpoints", nil
+		}
+		return "", fmt.Errorf("failed to get endpoints %s: %w", key, err)
+	}
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return "", nil
+		}
+	}
+	return "has no ready endpoints", nil
+}
+
+// Options controls Unstick
+type Options struct {
+	// Force removes the finalizers even if children remain
+	Force bool
+	// Actor and Reason are recorded in the audit record
+	Actor  string
+	Reason string
+	// DryRun reports what would be removed without changing anything
+	DryRun bool
+}
+
+// AuditRecord records the removal of the finalizers of a platform
+type AuditRecord struct {
+	Platform          string    `json:"platform"`
+	Namespace         string    `json:"namespace"`
+	UID               string    `json:"uid"`
+	Time              time.Time `json:"time"`
+	Actor             string    `json:"actor,omitempty"`
+	Reason            string    `json:"reason,omitempty"`
+	Forced            bool      `json:"forced"`
+	RemovedFinalizers []string  `json:"removedFinalizers"`
+	KeptFinalizers    []string  `json:"keptFinalizers,omitempty"`
+	// RemainingChildren are the children left behind by a forced removal
+	RemainingChildren []Child `json:"remainingChildren,omitempty"`
+	Terminating       string  `json:"terminating"`
+}
+
+// Unstick removes the operator's finalizers from a terminating platform,
+// keeping the finalizers of other controllers, and stores an audit record in
+// a ConfigMap of the platform's namespace. It refuses to remove them while
+// children remain, unless forced, and while a webhook rejects the update.
+func (i *Inspector) Unstick(ctx context.Context, d *Diagnosis, opts Options) (*AuditRecord, error) {
+	if d.Terminating == 0 {
+		return nil, fmt.Errorf("platform %s/%s is not being deleted", d.Namespace, d.Platform)
+	}
+	if len(d.OperatorFinalizers) == 0 {
+		return nil, fmt.Errorf("platform %s/%s has no operator finalizers", d.Namespace, d.Platform)
+	}
+	if len(d.Webhooks) > 0 {
+		return nil, fmt.Errorf("the API server would reject the update: unreachable webhook %s; restore the operator or remove the webhook first", d.Webhooks[0])
+	}
+	if len(d.Children) > 0 && !opts.Force {
+		return nil, fmt.Errorf("%d children of the platform remain (first %s %s); wait for the operator to delete them or use --force", len(d.Children), d.Children[0].Kind, d.Children[0].Name)
+	}
+
+	record := &AuditRecord{
+		Platform:          d.Platform,
+		Namespace:         d.Namespace,
+		UID:               d.UID,
+		Time:              i.now().UTC(),
+		Actor:             opts.Actor,
+		Reason:            opts.Reason,
+		Forced:            len(d.Children) > 0,
+		RemovedFinalizers: d.OperatorFinalizers,
+		KeptFinalizers:    d.ForeignFinalizers,
+		RemainingChildren: d.Children,
+		Terminating:       d.Terminating.Round(time.Second).String(),
+	}
+	if opts.DryRun {
+		return record, nil
+	}
+
+	// Store the record first, so a removal is never unaudited
+	if err := i.Client.Create(ctx, AuditConfigMap(record)); err != nil {
+		return nil, fmt.Errorf("failed to store audit record: %w", err)
+	}
+
+	platform := &unstructured.Unstructured{}
+	platform.SetGroupVersionKind(PlatformGVK)
+	if err := i.Client.Get(ctx, types.NamespacedName{Namespace: d.Namespace, Name: d.Platform}, platform); err != nil {
+		if errors.IsNotFound(err) {
+			return record, nil
+		}
+		return nil, fmt.Errorf("failed to get platform: %w", err)
+	}
+	if string(platform.GetUID()) != d.UID {
+		return nil, fmt.Errorf("platform %s/%s was recreated since it was diagnosed", d.Namespace, d.Platform)
+	}
+
+	patch := client.MergeFromWithOptions(platform.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	_, foreign := SplitFinalizers(platform.GetFinalizers())
+	if foreign == nil {
+		foreign = []string{}
+	}
+	platform.SetFinalizers(foreign)
+	if err := i.Client.Patch(ctx, platform, patch); err != nil {
+		return nil, fmt.Errorf("failed to remove finalizers: %w", err)
+	}
+	return record, nil
+}
+
+// AuditConfigMap returns the ConfigMap storing an audit record. It is not
+// labeled as a child of the platform, so it outlives it.
+func AuditConfigMap(record *AuditRecord) *corev1.ConfigMap {
+	data, _ := json.MarshalIndent(record, "", "  ")
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-unstick-%d", truncate(record.Platform, 253-30), record.Time.Unix()),
+			Namespace: record.Namespace,
+			Labels: map[string]string{
+				AuditLabel:                     truncate(record.Platform, 63),
+				"app.kubernetes.io/managed-by": "gunj-operator",
+			},
+		},
+		Data: map[string]string{AuditRecordKey: string(data)},
+	}
+}
+
+func truncate(s string, max int) string {
+	if len(s) > max {
+		return strings.TrimRight(s[:max], "-.")
+	}
+	return s
+}
+
+// metaItems returns the items of a list, sorted by name
+func metaItems(list client.ObjectList) ([]metav1.Object, error) {
+	objects, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]metav1.Object, 0, len(objects))
+	for _, obj := range objects {
+		item, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(a, b int) bool { return items[a].GetName() < items[b].GetName() })
+	return items, nil
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The Gunj Operator Authors.
+
+Licensed under the MIT License.
+*/
+
+package unstick
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var deletedAt = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func terminatingPlatform(finalizers ...string) *unstructured.Unstructured {
+	platform := &unstructured.Unstructured{}
+	platform.SetGroupVersionKind(PlatformGVK)
+	platform.SetName("prod")
+	platform.SetNamespace("monitoring")
+	platform.SetUID("uid-1")
+	platform.SetFinalizers(finalizers)
+	platform.SetDeletionTimestamp(&metav1.Time{Time: deletedAt})
+	return platform
+}
+
+func newInspector(objects ...client.Object) *Inspector {
+	return &Inspector{
+		Client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(objects...).Build(),
+		Now:    func() time.Time { return deletedAt.Add(time.Hour) },
+	}
+}
+
+func TestSplitFinalizers(t *testing.T) {
+	operator, foreign := SplitFinalizers([]string{
+		"observabilityplatform.observability.io/finalizer",
+		"example.com/protect",
+		"observabilityplatform.observability.io/data-protection",
+	})
+	assert.Equal(t, []string{
+		"observabilityplatform.observability.io/finalizer",
+		"observabilityplatform.observability.io/data-protection",
+	}, operator)
+	assert.Equal(t, []string{"example.com/protect"}, foreign)
+}
+
+func TestDiagnose(t *testing.T) {
+	fail := admissionregistrationv1.Fail
+	webhooks := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "gunj-validating"},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+			Name:          "vobservabilityplatform.kb.io",
+			FailurePolicy: &fail,
+			ClientConfig: admissionregistrationv1.WebhookClientConfig{
+				Service: &admissionregistrationv1.ServiceReference{Namespace: "gunj-system", Name: "webhook-service"},
+			},
+			Rules: []admissionregistrationv1.RuleWithOperations{{
+				Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+				Rule: admissionregistrationv1.Rule{
+					APIGroups: []string{"observability.io"},
+					Resources: []string{"observabilityplatforms"},
+				},
+			}},
+		}},
+	}
+	labels := map[string]string{PlatformLabel: "prod"}
+	inspector := newInspector(
+		terminatingPlatform("observabilityplatform.observability.io/finalizer", "example.com/protect"),
+		webhooks,
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "webhook-service", Namespace: "gunj-system"}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "prod-loki", Namespace: "monitoring", Labels: labels}},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+			Name: "data-prometheus-0", Namespace: "monitoring", Labels: labels,
+			Annotations: map[string]string{"observability.io/retained": "true"},
+		}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name: "prod-final-backup", Namespace: "monitoring",
+			Labels: map[string]string{PlatformLabel: "prod", "observability.io/backup-type": "pre-deletion"},
+		}},
+	)
+
+	d, err := inspector.Diagnose(context.Background(), "monitoring", "prod")
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Hour, d.Terminating)
+	assert.True(t, d.Stuck(DefaultThreshold))
+	assert.Equal(t, []string{"observabilityplatform.observability.io/finalizer"}, d.OperatorFinalizers)
+	assert.Equal(t, []string{"example.com/protect"}, d.ForeignFinalizers)
+	assert.Equal(t, []Child{{Kind: "StatefulSet", Name: "prod-loki"}}, d.Children)
+	require.Len(t, d.Webhooks, 1)
+	assert.Equal(t, "gunj-system/webhook-service", d.Webhooks[0].Service)
+	assert.Equal(t, "has no endpoints", d.Webhooks[0].Reason)
+	assert.Len(t, d.Reasons(), 4)
+}
+
+func TestUnstickRefusesWhileChildrenRemain(t *testing.T) {
+	inspector := newInspector(
+		terminatingPlatform("observabilityplatform.observability.io/finalizer"),
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "prod-grafana", Namespace: "monitoring", Labels: map[string]string{PlatformLabel: "prod"}}},
+	)
+	ctx := context.Background()
+	d, err := inspector.Diagnose(ctx, "monitoring", "prod")
+	require.NoError(t, err)
+
+	_, err = inspector.Unstick(ctx, d, Options{})
+	assert.ErrorContains(t, err, "children of the platform remain")
+
+	record, err := inspector.Unstick(ctx, d, Options{Force: true, Actor: "alice"})
+	require.NoError(t, err)
+	assert.True(t, record.Forced)
+	assert.Equal(t, []Child{{Kind: "Service", Name: "prod-grafana"}}, record.RemainingChildren)
+}
+
+func TestUnstickRemovesOperatorFinalizers(t *testing.T) {
+	inspector := newInspector(terminatingPlatform(
+		"observabilityplatform.observability.io/finalizer",
+		"observabilityplatform.observability.io/component-cleanup",
+		"example.com/protect",
+	))
+	ctx := context.Background()
+	d, err := inspector.Diagnose(ctx, "monitoring", "prod")
+	require.NoError(t, err)
+
+	record, err := inspector.Unstick(ctx, d, Options{Actor: "alice", Reason: "operator uninstalled"})
+	require.NoError(t, err)
+	assert.False(t, record.Forced)
+	assert.Equal(t, "1h0m0s", record.Terminating)
+
+	platform := &unstructured.Unstructured{}
+	platform.SetGroupVersionKind(PlatformGVK)
+	require.NoError(t, inspector.Client.Get(ctx, types.NamespacedName{Namespace: "monitoring", Name: "prod"}, platform))
+	assert.Equal(t, []string{"example.com/protect"}, platform.GetFinalizers())
+
+	audits := &corev1.ConfigMapList{}
+	require.NoError(t, inspector.Client.List(ctx, audits, client.MatchingLabels{AuditLabel: "prod"}))
+	require.Len(t, audits.Items, 1)
+	var stored AuditRecord
+	require.NoError(t, json.Unmarshal([]byte(audits.Items[0].Data[AuditRecordKey]), &stored))
+	assert.Equal(t, "alice", stored.Actor)
+	assert.Equal(t, "operator uninstalled", stored.Reason)
+	assert.Equal(t, []string{
+		"observabilityplatform.observability.io/finalizer",
+		"observabilityplatform.observability.io/component-cleanup",
+	}, stored.RemovedFinalizers)
+}
+
+func TestUnstickDryRun(t *testing.T) {
+	inspector := newInspector(terminatingPlatform("observabilityplatform.observability.io/finalizer"))
+	ctx := context.Background()
+	d, err := inspector.Diagnose(ctx, "monitoring", "prod")
+	require.NoError(t, err)
+
+	_, err = inspector.Unstick(ctx, d, Options{DryRun: true})
+	require.NoError(t, err)
+
+	audits := &corev1.ConfigMapList{}
+	require.NoError(t, inspector.Client.List(ctx, audits))
+	assert.Empty(t, audits.Items)
+}
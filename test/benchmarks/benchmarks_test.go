@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+// Package benchmarks holds the performance regression suite of the operator:
+// reconcile throughput, conversion throughput and admission webhook latency.
+// Run it with `make bench`, which writes the results in the Go benchmark
+// format read by benchstat.
+package benchmarks
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	observabilityv1alpha1 "github.com/gunjanjp/gunj-operator/api/v1alpha1"
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/internal/metrics"
+)
+
+var (
+	collectorOnce sync.Once
+	collector     *metrics.Collector
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	stopWebhookEnv()
+	os.Exit(code)
+}
+
+// newScheme returns a scheme with the core and operator types
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = observabilityv1alpha1.AddToScheme(scheme)
+	_ = observabilityv1beta1.AddToScheme(scheme)
+	return scheme
+}
+
+// metricsCollector returns the collector shared by the benchmarks, as its
+// metrics can only be registered once
+func metricsCollector() *metrics.Collector {
+	collectorOnce.Do(func() {
+		collector = metrics.NewCollector()
+	})
+	return collector
+}
+
+// newPlatform returns a typical v1beta1 platform
+func newPlatform(namespace string, i int) *observabilityv1beta1.ObservabilityPlatform {
+	return &observabilityv1beta1.ObservabilityPlatform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("platform-%d", i),
+			Namespace: namespace,
+			Labels:    map[string]string{"environment": "benchmark"},
+		},
+		Spec: observabilityv1beta1.ObservabilityPlatformSpec{
+			Components: observabilityv1beta1.Components{
+				Prometheus: &observabilityv1beta1.PrometheusSpec{
+					Enabled: true,
+					Version: "v2.48.0",
+					Resources: observabilityv1beta1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    "100m",
+							corev1.ResourceMemory: "256Mi",
+						},
+					},
+				},
+				Grafana: &observabilityv1beta1.GrafanaSpec{
+					Enabled: true,
+					Version: "10.2.0",
+				},
+				Loki: &observabilityv1beta1.LokiSpec{
+					Enabled: true,
+					Version: "2.9.0",
+				},
+			},
+			Global: observabilityv1beta1.GlobalConfig{
+				ExternalLabels: map[string]string{"cluster": "benchmark"},
+				LogLevel:       observabilityv1beta1.LogLevelInfo,
+			},
+		},
+	}
+}
+
+// reportLatencies reports the median and 99th percentile of the latencies in
+// milliseconds
+func reportLatencies(b *testing.B, latencies []time.Duration) {
+	if len(latencies) == 0 {
+		return
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	percentile := func(p float64) float64 {
+		index := int(float64(len(latencies)-1) * p)
+		return float64(latencies[index]) / float64(time.Millisecond)
+	}
+	b.ReportMetric(percentile(0.50), "p50-ms")
+	b.ReportMetric(percentile(0.99), "p99-ms")
+}
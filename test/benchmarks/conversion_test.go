@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package benchmarks
+
+import (
+	"testing"
+
+	observabilityv1alpha1 "github.com/gunjanjp/gunj-operator/api/v1alpha1"
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// BenchmarkConversion measures the conversions served by the conversion
+// webhook, in conversions per second
+func BenchmarkConversion(b *testing.B) {
+	hub := newPlatform("benchmark", 0)
+	spoke := &observabilityv1alpha1.ObservabilityPlatform{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		b.Fatalf("convert to v1alpha1: %v", err)
+	}
+
+	b.Run("v1alpha1-to-v1beta1", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			dst := &observabilityv1beta1.ObservabilityPlatform{}
+			if err := spoke.DeepCopy().ConvertTo(dst); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "conversions/s")
+	})
+
+	b.Run("v1beta1-to-v1alpha1", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			dst := &observabilityv1alpha1.ObservabilityPlatform{}
+			if err := dst.ConvertFrom(hub.DeepCopy()); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "conversions/s")
+	})
+
+	b.Run("round-trip", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			alpha := &observabilityv1alpha1.ObservabilityPlatform{}
+			if err := alpha.ConvertFrom(hub.DeepCopy()); err != nil {
+				b.Fatal(err)
+			}
+			if err := alpha.ConvertTo(&observabilityv1beta1.ObservabilityPlatform{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "conversions/s")
+	})
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+	"github.com/gunjanjp/gunj-operator/controllers"
+	"github.com/gunjanjp/gunj-operator/internal/managers"
+)
+
+// BenchmarkReconcile measures the throughput of the platform controller
+// against a fake API server holding N platforms. The component managers are
+// mocked, so the benchmark covers the controller's own work: reading the
+// platform, the shared reconcilers and the status updates.
+func BenchmarkReconcile(b *testing.B) {
+	for _, n := range []int{10, 100, 500} {
+		b.Run(fmt.Sprintf("platforms=%d", n), func(b *testing.B) {
+			scheme := newScheme()
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "benchmark"}}
+			objects := []client.Object{namespace}
+			requests := make([]reconcile.Request, 0, n)
+			for i := 0; i < n; i++ {
+				platform := newPlatform(namespace.Name, i)
+				objects = append(objects, platform)
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: platform.Name, Namespace: platform.Namespace},
+				})
+			}
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objects...).
+				WithStatusSubresource(&observabilityv1beta1.ObservabilityPlatform{}).
+				Build()
+
+			reconciler := &controllers.ObservabilityPlatformReconciler{
+				Client:            c,
+				Scheme:            scheme,
+				Log:               log.Log.WithName("benchmark"),
+				PrometheusManager: &managers.MockPrometheusManager{},
+				GrafanaManager:    &managers.MockGrafanaManager{},
+				LokiManager:       &managers.MockLokiManager{},
+				TempoManager:      &managers.MockTempoManager{},
+				Metrics:           metricsCollector(),
+			}
+
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, req := range requests {
+					if _, err := reconciler.Reconcile(ctx, req); err != nil {
+						b.Fatalf("reconcile %s: %v", req.NamespacedName, err)
+					}
+				}
+			}
+			b.ReportMetric(float64(b.N*n)/b.Elapsed().Seconds(), "reconciles/s")
+		})
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2025.
+
+Licensed under the MIT License.
+*/
+
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	observabilityv1beta1 "github.com/gunjanjp/gunj-operator/api/v1beta1"
+)
+
+// platformWebhooks returns the admission webhooks of the platform, as
+// generated from the kubebuilder markers
+func platformWebhooks() ([]*admissionregistrationv1.MutatingWebhookConfiguration, []*admissionregistrationv1.ValidatingWebhookConfiguration) {
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	failurePolicy := admissionregistrationv1.Fail
+	rules := []admissionregistrationv1.RuleWithOperations{{
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{observabilityv1beta1.GroupVersion.Group},
+			APIVersions: []string{observabilityv1beta1.GroupVersion.Version},
+			Resources:   []string{"observabilityplatforms"},
+		},
+	}}
+	clientConfig := func(path string) admissionregistrationv1.WebhookClientConfig {
+		return admissionregistrationv1.WebhookClientConfig{
+			Service: &admissionregistrationv1.ServiceReference{Name: "webhook-service", Namespace: "system", Path: &path},
+		}
+	}
+
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "mutating-webhook-configuration"},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{{
+			Name:                    "mobservabilityplatform.kb.io",
+			ClientConfig:            clientConfig("/mutate-observability-io-v1beta1-observabilityplatform"),
+			Rules:                   rules,
+			SideEffects:             &sideEffects,
+			FailurePolicy:           &failurePolicy,
+			AdmissionReviewVersions: []string{"v1"},
+		}},
+	}
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "validating-webhook-configuration"},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+			Name:                    "vobservabilityplatform.kb.io",
+			ClientConfig:            clientConfig("/validate-observability-io-v1beta1-observabilityplatform"),
+			Rules:                   rules,
+			SideEffects:             &sideEffects,
+			FailurePolicy:           &failurePolicy,
+			AdmissionReviewVersions: []string{"v1"},
+		}},
+	}
+	return []*admissionregistrationv1.MutatingWebhookConfiguration{mutating},
+		[]*admissionregistrationv1.ValidatingWebhookConfiguration{validating}
+}
+
+// webhookEnv is an API server from envtest calling the webhooks of a
+// manager, shared by the runs of the webhook benchmarks
+type webhookEnv struct {
+	testEnv   *envtest.Environment
+	cancel    context.CancelFunc
+	client    client.Client
+	namespace string
+}
+
+var (
+	webhookEnvOnce sync.Once
+	sharedEnv      *webhookEnv
+	sharedEnvErr   error
+)
+
+// startWebhookEnv starts the shared environment on first use
+func startWebhookEnv() (*webhookEnv, error) {
+	webhookEnvOnce.Do(func() {
+		sharedEnv, sharedEnvErr = newWebhookEnv()
+	})
+	return sharedEnv, sharedEnvErr
+}
+
+// stopWebhookEnv stops the shared environment if it was started
+func stopWebhookEnv() {
+	if sharedEnv != nil {
+		sharedEnv.stop()
+	}
+}
+
+// stop stops the manager and the API server
+func (e *webhookEnv) stop() {
+	e.cancel()
+	_ = e.testEnv.Stop()
+}
+
+func newWebhookEnv() (*webhookEnv, error) {
+	mutating, validating := platformWebhooks()
+	testEnv := &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "config", "crd", "bases")},
+		ErrorIfCRDPathMissing: true,
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			MutatingWebhooks:   mutating,
+			ValidatingWebhooks: validating,
+		},
+	}
+	cfg, err := testEnv.Start()
+	if err != nil {
+		return nil, fmt.Errorf("start envtest: %w", err)
+	}
+	env := &webhookEnv{testEnv: testEnv, cancel: func() {}, namespace: "benchmark"}
+
+	scheme := newScheme()
+	webhookOptions := testEnv.WebhookInstallOptions
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Host:    webhookOptions.LocalServingHost,
+			Port:    webhookOptions.LocalServingPort,
+			CertDir: webhookOptions.LocalServingCertDir,
+		}),
+	})
+	if err != nil {
+		_ = testEnv.Stop()
+		return nil, fmt.Errorf("create manager: %w", err)
+	}
+	if err := (&observabilityv1beta1.ObservabilityPlatform{}).SetupWebhookWithManager(mgr); err != nil {
+		_ = testEnv.Stop()
+		return nil, fmt.Errorf("set up webhook: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	env.cancel = cancel
+	go func() { _ = mgr.Start(ctx) }()
+	if !mgr.GetCache().WaitForCacheSync(ctx) {
+		env.stop()
+		return nil, fmt.Errorf("cache did not sync")
+	}
+
+	if env.client, err = client.New(cfg, client.Options{Scheme: scheme}); err != nil {
+		env.stop()
+		return nil, fmt.Errorf("create client: %w", err)
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: env.namespace}}
+	if err := env.client.Create(ctx, namespace); err != nil {
+		env.stop()
+		return nil, fmt.Errorf("create namespace: %w", err)
+	}
+
+	// Wait for the webhook server to serve
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		err := env.client.Create(ctx, newPlatform(env.namespace, 0), client.DryRunAll)
+		if err == nil {
+			return env, nil
+		}
+		if time.Now().After(deadline) {
+			env.stop()
+			return nil, fmt.Errorf("webhook not ready: %w", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// BenchmarkWebhookLatency measures the latency of API requests going through
+// the platform admission webhooks, with a real API server from envtest. Each
+// iteration is a dry-run create, which runs the mutating and validating
+// webhooks without storing the platform. It is skipped when the envtest
+// binaries are not installed, see `make bench`.
+func BenchmarkWebhookLatency(b *testing.B) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		b.Skip("KUBEBUILDER_ASSETS is not set, run `make bench`")
+	}
+	env, err := startWebhookEnv()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	ctx := context.Background()
+	latencies := make([]time.Duration, 0, b.N)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		platform := newPlatform(env.namespace, i)
+		start := time.Now()
+		if err := env.client.Create(ctx, platform, client.DryRunAll); err != nil {
+			b.Fatalf("dry-run create %s: %v", platform.Name, err)
+		}
+		latencies = append(latencies, time.Since(start))
+	}
+	b.StopTimer()
+	reportLatencies(b, latencies)
+}